@@ -0,0 +1,52 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/yourusername/cron-observer/backend/internal/integrity"
+)
+
+func runCheckIntegrity(ctx context.Context, args []string) error {
+	fs := newFlagSet("check-integrity", "[--policy=flag|reassign|clean] [--yes]")
+	policyName := fs.String("policy", string(integrity.PolicyFlag), "flag only lists dangling references; reassign detaches tasks of deleted task groups; clean also deletes tasks of deleted projects, executions of deleted tasks and stats of deleted projects")
+	yes := fs.Bool("yes", false, "Repair without asking for confirmation")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	policy, err := integrity.ParsePolicy(*policyName)
+	if err != nil {
+		return err
+	}
+
+	repo, closeDB, err := openRepository()
+	if err != nil {
+		return err
+	}
+	defer closeDB()
+
+	orphans, err := integrity.Find(ctx, repo)
+	if err != nil {
+		return err
+	}
+	orphans.WriteSummary(os.Stdout)
+	if orphans.Empty() || policy == integrity.PolicyFlag {
+		return nil
+	}
+
+	if !*yes && !confirm(fmt.Sprintf("Type 'delete' to apply the %s policy to the above: ", policy)) {
+		return errors.New("aborted, nothing was changed")
+	}
+
+	// The server's scheduler picks up detached tasks on its next restart or task update
+	result, err := integrity.Repair(ctx, repo, nil, orphans, policy)
+	summary := fmt.Sprintf("detached %d tasks, deleted %d tasks, %d executions and the stats of %d projects",
+		result.DetachedTasks, result.DeletedTasks, result.DeletedExecutions, result.DeletedStats)
+	if err != nil {
+		return fmt.Errorf("%w (%s before failing)", err, summary)
+	}
+	fmt.Printf("✅ Repaired: %s\n", summary)
+	return nil
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/yourusername/cron-observer/backend/internal/cleanup"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func runCleanup(ctx context.Context, args []string) error {
+	fs := newFlagSet("cleanup", "[--keep-project=ID,...] [--delete-executions-before=DATE] [--dry-run] [--yes]")
+	keepProjects := fs.String("keep-project", "", "Comma-separated IDs of the projects to keep; every other project is deleted")
+	before := fs.String("delete-executions-before", "", "Delete executions of the kept projects started before this date (2006-01-02 or RFC 3339)")
+	dryRun := fs.Bool("dry-run", false, "Only print what would be deleted")
+	yes := fs.Bool("yes", false, "Delete without asking for confirmation")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	var opts cleanup.Options
+	if *keepProjects != "" {
+		for _, id := range strings.Split(*keepProjects, ",") {
+			projectID, err := primitive.ObjectIDFromHex(strings.TrimSpace(id))
+			if err != nil {
+				return fmt.Errorf("invalid project ID %q", id)
+			}
+			opts.KeepProjects = append(opts.KeepProjects, projectID)
+		}
+	}
+	if *before != "" {
+		cutoff, err := parseDate(*before)
+		if err != nil {
+			return err
+		}
+		opts.DeleteExecutionsBefore = cutoff
+	}
+	if len(opts.KeepProjects) == 0 && opts.DeleteExecutionsBefore.IsZero() {
+		fs.Usage()
+		return errors.New("set --keep-project, --delete-executions-before or both")
+	}
+
+	repo, closeDB, err := openRepository()
+	if err != nil {
+		return err
+	}
+	defer closeDB()
+
+	plan, err := cleanup.NewPlan(ctx, repo, opts)
+	if err != nil {
+		return err
+	}
+	plan.WriteSummary(os.Stdout)
+	if plan.Empty() || *dryRun {
+		return nil
+	}
+
+	if !*yes && !confirm("Type 'delete' to delete the above: ") {
+		return errors.New("aborted, nothing was deleted")
+	}
+
+	result, err := cleanup.Apply(ctx, repo, plan)
+	if err != nil {
+		return fmt.Errorf("%w (deleted %d projects and %d executions before failing)", err, result.Projects, result.Executions)
+	}
+	fmt.Printf("✅ Deleted %d projects and %d executions\n", result.Projects, result.Executions)
+	return nil
+}
+
+// parseDate accepts a date (midnight UTC) or an RFC 3339 timestamp
+func parseDate(value string) (time.Time, error) {
+	if t, err := time.Parse("2006-01-02", value); err == nil {
+		return t, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid date %q: use 2006-01-02 or RFC 3339", value)
+	}
+	return t, nil
+}
+
+// confirm asks on stdin and reports whether the answer was "delete"
+func confirm(prompt string) bool {
+	fmt.Print(prompt)
+	answer, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+	return strings.TrimSpace(answer) == "delete"
+}
@@ -0,0 +1,129 @@
+// Command admin runs operational tasks against the database configured through the usual environment
+// variables or .env file (see docs/CONFIG_MANAGEMENT.md).
+//
+// Usage:
+//
+//	go run cmd/admin/main.go <command> [flags]
+//	go run cmd/admin/main.go <command> --help
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"sort"
+	"sync"
+	"syscall"
+
+	"github.com/yourusername/cron-observer/backend/internal/config"
+	"github.com/yourusername/cron-observer/backend/internal/database"
+	"github.com/yourusername/cron-observer/backend/internal/repositories"
+)
+
+// command is an admin subcommand. run receives the arguments after the command name.
+type command struct {
+	summary string
+	run     func(ctx context.Context, args []string) error
+}
+
+var commands = map[string]command{
+	"backup":          {"Dump projects, task groups, tasks and optionally executions to an archive", runBackup},
+	"check-integrity": {"List tasks, executions and stats pointing at deleted groups, projects or tasks, and repair them", runCheckIntegrity},
+	"cleanup":         {"Delete all projects except the kept ones and old executions, after a summary", runCleanup},
+	"requeue-deletes": {"List tasks stuck in PENDING_DELETE or DELETE_FAILED and re-publish their delete jobs", runRequeueDeletes},
+	"restore":         {"Load a backup archive into the database", runRestore},
+	"run-task":        {"Create an execution of a task and send it to its endpoint, bypassing the API", runRunTask},
+	"seed":            {"Create a demo project with task groups, tasks and synthetic execution history", runSeed},
+}
+
+func main() {
+	if len(os.Args) < 2 || os.Args[1] == "help" || os.Args[1] == "--help" || os.Args[1] == "-h" {
+		usage()
+		os.Exit(2)
+	}
+
+	cmd, ok := commands[os.Args[1]]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "Unknown command %q\n\n", os.Args[1])
+		usage()
+		os.Exit(2)
+	}
+
+	// Interrupting stops the command between documents instead of killing it mid-write
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if err := cmd.run(ctx, os.Args[2:]); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			os.Exit(2)
+		}
+		fmt.Fprintf(os.Stderr, "❌ %s failed: %v\n", os.Args[1], err)
+		os.Exit(1)
+	}
+}
+
+func usage() {
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	fmt.Fprintln(os.Stderr, "Usage: admin <command> [flags]")
+	fmt.Fprintln(os.Stderr)
+	fmt.Fprintln(os.Stderr, "Commands:")
+	for _, name := range names {
+		fmt.Fprintf(os.Stderr, "  %-16s %s\n", name, commands[name].summary)
+	}
+	fmt.Fprintln(os.Stderr)
+	fmt.Fprintln(os.Stderr, "Run admin <command> --help for the flags of a command.")
+}
+
+// newFlagSet returns a flag set for a command whose errors are returned rather than exiting
+func newFlagSet(name, usageLine string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: admin %s %s\n\nFlags:\n", name, usageLine)
+		fs.PrintDefaults()
+	}
+	return fs
+}
+
+var (
+	loadedConfig    *config.Config
+	loadedConfigErr error
+	loadConfigOnce  sync.Once
+)
+
+// loadConfig loads the configuration on first use and returns the same result afterwards
+func loadConfig() (*config.Config, error) {
+	loadConfigOnce.Do(func() {
+		loadedConfig, loadedConfigErr = config.Load()
+	})
+	return loadedConfig, loadedConfigErr
+}
+
+// openRepository connects to the configured MongoDB database. The returned function closes the connection.
+func openRepository() (repositories.Repository, func(), error) {
+	cfg, err := loadConfig()
+	if err != nil {
+		return nil, nil, err
+	}
+	if cfg.Database.Driver == config.DatabaseDriverMemory {
+		return nil, nil, errors.New("admin commands need a MongoDB database, but DATABASE_DRIVER is memory")
+	}
+
+	db, err := database.NewConnection(cfg.Database)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var repo repositories.Repository = repositories.NewMongoRepository(db.DB)
+	if cfg.Database.PartitionExecutions {
+		repo = repositories.NewPartitionedRepository(db.DB)
+	}
+	return repo, func() { db.Close() }, nil
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/yourusername/cron-observer/backend/internal/deletequeue"
+	"github.com/yourusername/cron-observer/backend/internal/jobqueue"
+	"github.com/yourusername/cron-observer/backend/internal/reconciler"
+)
+
+func runRequeueDeletes(ctx context.Context, args []string) error {
+	fs := newFlagSet("requeue-deletes", "[--list] [--task=UUID,...] [--older-than=DURATION]")
+	list := fs.Bool("list", false, "Only list tasks in PENDING_DELETE or DELETE_FAILED")
+	taskUUIDs := fs.String("task", "", "Comma-separated UUIDs of the tasks to requeue (default: every stuck task)")
+	olderThan := fs.Duration("older-than", 0, "Skip tasks whose delete was requested or last failed more recently, e.g. 10m")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	repo, closeDB, err := openRepository()
+	if err != nil {
+		return err
+	}
+	defer closeDB()
+
+	tasks, err := reconciler.FindStuckDeletes(ctx, repo, *olderThan)
+	if err != nil {
+		return err
+	}
+	if *taskUUIDs != "" {
+		selected := make(map[string]bool)
+		for _, taskUUID := range strings.Split(*taskUUIDs, ",") {
+			selected[strings.TrimSpace(taskUUID)] = true
+		}
+		filtered := tasks[:0]
+		for _, task := range tasks {
+			if selected[task.UUID] {
+				filtered = append(filtered, task)
+			}
+		}
+		tasks = filtered
+	}
+
+	if len(tasks) == 0 {
+		fmt.Println("No stuck delete jobs.")
+		return nil
+	}
+
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "TASK UUID\tPROJECT ID\tSTATUS\tWAITING\tNAME")
+	for _, task := range tasks {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", task.UUID, task.ProjectID.Hex(), task.Status, time.Since(task.UpdatedAt).Round(time.Second), task.Name)
+	}
+	tw.Flush()
+	if *list {
+		return nil
+	}
+
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+	jobs, err := jobqueue.NewPublisher(cfg.Broker, repo)
+	if err != nil {
+		return fmt.Errorf("connect to the job queue: %w", err)
+	}
+	defer jobs.Close()
+	publisher := deletequeue.NewPublisher(jobs)
+
+	failed := 0
+	for _, task := range tasks {
+		if err := reconciler.RequeueDelete(ctx, publisher, task); err != nil {
+			fmt.Fprintf(os.Stderr, "⚠️  Failed to requeue %s: %v\n", task.UUID, err)
+			failed++
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d delete jobs could not be requeued", failed, len(tasks))
+	}
+	fmt.Printf("✅ Requeued %d delete jobs\n", len(tasks))
+	return nil
+}
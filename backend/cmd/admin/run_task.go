@@ -0,0 +1,105 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/yourusername/cron-observer/backend/internal/models"
+	"github.com/yourusername/cron-observer/backend/internal/repositories"
+	"github.com/yourusername/cron-observer/backend/internal/scheduler"
+	"github.com/yourusername/cron-observer/backend/internal/secrets"
+)
+
+func runRunTask(ctx context.Context, args []string) error {
+	fs := newFlagSet("run-task", "--uuid=TASK_UUID [--follow=DURATION]")
+	taskUUID := fs.String("uuid", "", "UUID of the task to run (required)")
+	follow := fs.Duration("follow", 0, "After dispatching, wait up to this long for the execution to finish and print its logs, e.g. 2m")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *taskUUID == "" {
+		fs.Usage()
+		return errors.New("--uuid is required")
+	}
+
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+	repo, closeDB, err := openRepository()
+	if err != nil {
+		return err
+	}
+	defer closeDB()
+
+	// Resolve {{secret:NAME}} references exactly as the server does
+	if cfg.Secrets.MasterKey != "" {
+		cipher, err := secrets.NewCipher(cfg.Secrets.MasterKey)
+		if err != nil {
+			return err
+		}
+		scheduler.SetSecretResolver(secrets.NewResolver(repo, cipher))
+	}
+
+	task, err := repo.GetTaskByUUID(ctx, *taskUUID)
+	if err != nil {
+		return fmt.Errorf("task %s: %w", *taskUUID, err)
+	}
+	fmt.Printf("Running task %q (%s, status %s)\n", task.Name, task.UUID, task.Status)
+
+	// Without an event bus a timeout still fails the execution, but sends no alert
+	executionUUID, result, err := scheduler.ExecuteTaskAndWait(ctx, task, repo, nil, "RUN-TASK")
+	if executionUUID != "" {
+		fmt.Printf("Execution: %s\n", executionUUID)
+	}
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("POST %s -> %d in %s\n", result.Endpoint, result.StatusCode, result.Duration.Round(time.Millisecond))
+	if body := strings.TrimSpace(result.Body); body != "" {
+		fmt.Printf("Response: %s\n", body)
+	}
+	if result.StatusCode < 200 || result.StatusCode >= 300 {
+		return fmt.Errorf("execution endpoint returned %d", result.StatusCode)
+	}
+
+	if *follow > 0 {
+		return followExecution(ctx, repo, executionUUID, *follow)
+	}
+	return nil
+}
+
+// followExecution polls the execution until it finishes or the wait is over, then prints its status and logs
+func followExecution(ctx context.Context, repo repositories.Repository, executionUUID string, wait time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, wait)
+	defer cancel()
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+
+	for {
+		execution, err := repo.GetExecutionByUUID(ctx, executionUUID)
+		if err != nil && ctx.Err() == nil {
+			return err
+		}
+		if execution != nil && (execution.Status == models.ExecutionStatusSuccess || execution.Status == models.ExecutionStatusFailed) {
+			for _, entry := range execution.Logs {
+				fmt.Printf("  %s [%s] %s\n", entry.Timestamp.Format(time.RFC3339), entry.Level, entry.Message)
+			}
+			fmt.Printf("Finished with status %s\n", execution.Status)
+			if execution.Status == models.ExecutionStatusFailed {
+				return fmt.Errorf("execution failed: %s", execution.Error)
+			}
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("execution has not finished after %s", wait)
+		case <-ticker.C:
+		}
+	}
+}
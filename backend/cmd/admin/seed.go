@@ -0,0 +1,42 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/yourusername/cron-observer/backend/internal/seed"
+)
+
+func runSeed(ctx context.Context, args []string) error {
+	fs := newFlagSet("seed", "[--name=NAME] [--owner=EMAIL] [--endpoint=URL] [--days=N] [--random-seed=N]")
+	name := fs.String("name", seed.DefaultProjectName, "Name of the demo project; seeding fails if it already exists")
+	owner := fs.String("owner", "", "Email added as project admin, so the project shows up when signed in as that user")
+	endpoint := fs.String("endpoint", "", "Execution endpoint of the demo project (default: none, the tasks are not dispatched)")
+	days := fs.Int("days", 14, "Days of synthetic execution history")
+	randomSeed := fs.Int64("random-seed", 1, "Seed of the synthetic history; the same seed gives the same runs and failures")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	repo, closeDB, err := openRepository()
+	if err != nil {
+		return err
+	}
+	defer closeDB()
+
+	result, err := seed.Demo(ctx, repo, seed.Options{
+		ProjectName:       *name,
+		OwnerEmail:        *owner,
+		ExecutionEndpoint: *endpoint,
+		Days:              *days,
+		RandomSeed:        *randomSeed,
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("✅ Created project %q (ID %s) with %d task groups, %d tasks and %d executions (%d failed)\n",
+		result.Project.Name, result.Project.ID.Hex(), result.TaskGroups, result.Tasks, result.Executions, result.Failures)
+	fmt.Printf("   API key: %s\n", result.Project.APIKey)
+	return nil
+}
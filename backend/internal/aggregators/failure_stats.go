@@ -22,7 +22,7 @@ func NewFailureStatsAggregator(repo repositories.Repository, eventBus *events.Ev
 }
 
 func (a *FailureStatsAggregator) Start(ctx context.Context) {
-	executionFailedCh := a.eventBus.Subscribe(events.ExecutionFailed)
+	executionFailedCh := events.Subscribe(a.eventBus, events.ExecutionFailedTopic)
 
 	go func() {
 		for {
@@ -30,24 +30,18 @@ func (a *FailureStatsAggregator) Start(ctx context.Context) {
 			case <-ctx.Done():
 				log.Println("FailureStatsAggregator context cancelled, stopping")
 				return
-			case event, ok := <-executionFailedCh:
+			case payload, ok := <-executionFailedCh:
 				if !ok {
 					log.Println("ExecutionFailed channel closed")
 					return
 				}
-				a.handleExecutionFailed(event)
+				a.handleExecutionFailed(payload)
 			}
 		}
 	}()
 }
 
-func (a *FailureStatsAggregator) handleExecutionFailed(event events.Event) {
-	payload, ok := event.Payload.(events.ExecutionFailedPayload)
-	if !ok {
-		log.Printf("Invalid payload for ExecutionFailed event")
-		return
-	}
-
+func (a *FailureStatsAggregator) handleExecutionFailed(payload events.ExecutionFailedPayload) {
 	// Extract date from execution (use ended_at if available, else started_at)
 	var date time.Time
 	if payload.Execution.EndedAt != nil {
@@ -64,5 +58,10 @@ func (a *FailureStatsAggregator) handleExecutionFailed(event events.Event) {
 	if err := a.repo.IncrementFailureStat(ctx, payload.Task.ProjectID, dateStr); err != nil {
 		log.Printf("Failed to increment failure stat: %v", err)
 	}
+
+	// Record the failure on the task so task lists can be sorted by last failure
+	if err := a.repo.UpdateTaskLastFailureAt(ctx, payload.Task.UUID, date); err != nil {
+		log.Printf("Failed to update last failure of task %s: %v", payload.Task.UUID, err)
+	}
 }
 
@@ -0,0 +1,69 @@
+package alert
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/yourusername/cron-observer/backend/internal/events"
+	"github.com/yourusername/cron-observer/backend/internal/models"
+)
+
+// autoPause pauses the task once its incident counts auto_pause_after_failures consecutive failures, so a broken
+// job stops calling downstream systems until someone resumes it. A resumed task that fails again before its
+// incident resolves is paused at once; resolving the incident by hand gives it the full number of attempts.
+func (s *Service) autoPause(ctx context.Context, task *models.Task, incident *models.Incident, execution *models.Execution) {
+	if task.AutoPauseAfter == 0 || incident.FailureCount < task.AutoPauseAfter {
+		return
+	}
+
+	// The task in the payload may be stale, e.g. when executions of a task paused a moment ago finish
+	current, err := s.repo.GetTaskByUUID(ctx, task.UUID)
+	if err != nil {
+		log.Printf("[AlertService] Failed to get task %s to pause it: %v", task.UUID, err)
+		return
+	}
+	if current.Status != models.TaskStatusActive || current.AutoPauseAfter == 0 || incident.FailureCount < current.AutoPauseAfter {
+		return
+	}
+
+	now := time.Now()
+	paused := *current
+	paused.Status = models.TaskStatusPaused
+	paused.State = models.TaskStateNotRunning
+	paused.AutoPausedAt = &now
+	paused.UpdatedAt = now
+	// The scheduler unregisters the task on TaskUpdated
+	err = events.PublishAtomic(ctx, s.eventBus, events.TaskUpdatedTopic, func(ctx context.Context) (events.TaskPayload, error) {
+		return events.TaskPayload{Task: &paused}, s.repo.UpdateTask(ctx, paused.UUID, &paused)
+	})
+	if err != nil {
+		log.Printf("[AlertService] Failed to pause task %s after %d consecutive failures: %v", task.UUID, incident.FailureCount, err)
+		return
+	}
+	log.Printf("[AlertService] Paused task %s after %d consecutive failures", task.UUID, incident.FailureCount)
+
+	if paused.IsMuted(now) {
+		log.Printf("[AlertService] Task %s is muted until %s, skipping %s notification", task.UUID, paused.MutedUntil.Format(time.RFC3339), eventTaskAutoPaused)
+		return
+	}
+	project, err := s.repo.GetProjectByID(ctx, paused.ProjectID)
+	if err != nil {
+		log.Printf("[AlertService] Failed to get project %s: %v", paused.ProjectID.Hex(), err)
+		return
+	}
+
+	subject := fmt.Sprintf("Task Auto-Paused: %s", paused.Name)
+	summary := fmt.Sprintf("was paused after %d consecutive failures and will not run until it is resumed by setting its status to ACTIVE.", incident.FailureCount)
+	s.send(ctx, project, &paused, notification{
+		event:     eventTaskAutoPaused,
+		subject:   withSeverity(paused.EffectiveSeverity(), subject),
+		text:      fmt.Sprintf("Task %s (%s) in project %s %s\nLast error: %s\nIncident: %s", paused.Name, paused.UUID, project.Name, summary, execution.Error, incident.UUID),
+		htmlBody:  buildTaskEmailBody(project, &paused, execution, subject, summary),
+		project:   project,
+		task:      &paused,
+		execution: execution,
+		incident:  incident,
+	})
+}
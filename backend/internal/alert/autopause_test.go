@@ -0,0 +1,70 @@
+package alert
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/yourusername/cron-observer/backend/internal/events"
+	"github.com/yourusername/cron-observer/backend/internal/models"
+	"github.com/yourusername/cron-observer/backend/internal/repositories"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestService_AutoPause_PausesTaskAfterConsecutiveFailures(t *testing.T) {
+	var sent []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload webhookPayload
+		json.NewDecoder(r.Body).Decode(&payload)
+		sent = append(sent, payload.Event)
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	repo := repositories.NewMemoryRepository()
+	project := &models.Project{
+		ID:                   primitive.NewObjectID(),
+		Name:                 "billing",
+		NotificationChannels: []models.NotificationChannel{{Name: "ops", Type: models.NotificationChannelWebhook, URL: server.URL}},
+	}
+	if err := repo.CreateProject(ctx, project); err != nil {
+		t.Fatalf("CreateProject: %v", err)
+	}
+	task := &models.Task{
+		ID: primitive.NewObjectID(), UUID: "task-1", Name: "sync", ProjectID: project.ID,
+		Status: models.TaskStatusActive, AutoPauseAfter: 3,
+	}
+	if err := repo.CreateTask(ctx, project.ID.Hex(), task); err != nil {
+		t.Fatalf("CreateTask: %v", err)
+	}
+
+	bus := events.NewEventBus(1)
+	updated := events.Subscribe(bus, events.TaskUpdatedTopic)
+	service := NewService(repo, bus, nil)
+	start := time.Date(2025, 1, 15, 10, 0, 0, 0, time.UTC)
+	for minute := 0; minute < 3; minute++ {
+		endedAt := start.Add(time.Duration(minute) * time.Minute)
+		execution := &models.Execution{UUID: "execution-" + endedAt.Format("1504"), Status: models.ExecutionStatusFailed, StartedAt: endedAt, EndedAt: &endedAt}
+		service.handleExecutionFailed(events.ExecutionFailedPayload{Task: task, Execution: execution})
+	}
+
+	if got, want := strings.Join(sent, ","), "incident.opened,task.auto_paused"; got != want {
+		t.Fatalf("notifications = %s, want %s", got, want)
+	}
+	paused, err := repo.GetTaskByUUID(ctx, task.UUID)
+	if err != nil || paused.Status != models.TaskStatusPaused || paused.AutoPausedAt == nil {
+		t.Fatalf("task after three failures = %+v, %v", paused, err)
+	}
+	select {
+	case payload := <-updated:
+		if payload.Task.Status != models.TaskStatusPaused {
+			t.Errorf("published task status = %s, want PAUSED", payload.Task.Status)
+		}
+	case <-time.After(time.Second):
+		t.Error("expected a TaskUpdated event for the paused task")
+	}
+}
@@ -0,0 +1,327 @@
+package alert
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/yourusername/cron-observer/backend/internal/gmail"
+	"github.com/yourusername/cron-observer/backend/internal/models"
+)
+
+// channelTimeout bounds a single Slack, webhook or PagerDuty delivery
+const channelTimeout = 10 * time.Second
+
+// pagerDutyEventsURL is the PagerDuty Events API v2 endpoint
+const pagerDutyEventsURL = "https://events.pagerduty.com/v2/enqueue"
+
+const (
+	eventExecutionFailed      = "execution.failed" // a failure that could not be recorded on an incident
+	eventIncidentOpened       = "incident.opened"
+	eventIncidentAcknowledged = "incident.acknowledged"
+	eventIncidentResolved     = "incident.resolved"
+	eventTaskFlapping         = "task.flapping"
+	eventTaskFlappingStopped  = "task.flapping_stopped"
+	eventTaskAutoPaused       = "task.auto_paused"
+	eventCircuitOpened        = "endpoint.circuit_opened"
+	eventTest                 = "test"
+)
+
+var (
+	// ErrEmailNotConfigured is returned for email channels when no Gmail sender is configured
+	ErrEmailNotConfigured = errors.New("email alerts are not configured")
+	// ErrNoRecipients is returned for email channels without addresses in projects without users
+	ErrNoRecipients = errors.New("no email recipients")
+)
+
+// defaultChannel emails the project users; it is used by projects without notification channels
+var defaultChannel = models.NotificationChannel{Type: models.NotificationChannelEmail}
+
+// notification is an alert rendered for every channel type
+type notification struct {
+	event     string
+	subject   string
+	text      string // Slack message and webhook summary
+	htmlBody  string // email body
+	project   *models.Project
+	task      *models.Task
+	execution *models.Execution
+	incident  *models.Incident
+	onCall    *models.OnCallParticipant // The project's on-call participant when the alert was sent
+}
+
+// webhookPayload is the JSON body POSTed to webhook channels
+type webhookPayload struct {
+	Event     string            `json:"event"`
+	Subject   string            `json:"subject"`
+	Text      string            `json:"text"`
+	Project   webhookProject    `json:"project"`
+	Task      *webhookTask      `json:"task,omitempty"`
+	Execution *webhookExecution `json:"execution,omitempty"`
+	Incident  *webhookIncident  `json:"incident,omitempty"`
+	OnCall    *webhookOnCall    `json:"on_call,omitempty"`
+	SentAt    time.Time         `json:"sent_at"`
+}
+
+type webhookProject struct {
+	ID   string `json:"id"`
+	UUID string `json:"uuid"`
+	Name string `json:"name"`
+}
+
+type webhookTask struct {
+	UUID     string `json:"uuid"`
+	Name     string `json:"name"`
+	Severity string `json:"severity"`
+}
+
+type webhookExecution struct {
+	UUID      string     `json:"uuid"`
+	Status    string     `json:"status"`
+	Error     string     `json:"error,omitempty"`
+	StartedAt time.Time  `json:"started_at"`
+	EndedAt   *time.Time `json:"ended_at,omitempty"`
+}
+
+type webhookIncident struct {
+	UUID           string     `json:"uuid"`
+	Status         string     `json:"status"`
+	FailureCount   int        `json:"failure_count"`
+	OpenedAt       time.Time  `json:"opened_at"`
+	AcknowledgedBy string     `json:"acknowledged_by,omitempty"`
+	ResolvedBy     string     `json:"resolved_by,omitempty"`
+	ResolvedAt     *time.Time `json:"resolved_at,omitempty"`
+}
+
+type webhookOnCall struct {
+	Name  string `json:"name"`
+	Email string `json:"email"`
+	Phone string `json:"phone,omitempty"`
+}
+
+// pagerDutyEvent is the Events API v2 body of PagerDuty channels
+type pagerDutyEvent struct {
+	RoutingKey  string            `json:"routing_key"`
+	EventAction string            `json:"event_action"`
+	DedupKey    string            `json:"dedup_key"`
+	Payload     *pagerDutyPayload `json:"payload,omitempty"` // only sent with trigger events
+}
+
+type pagerDutyPayload struct {
+	Summary       string         `json:"summary"`
+	Source        string         `json:"source"`
+	Severity      string         `json:"severity"`
+	CustomDetails webhookPayload `json:"custom_details"`
+}
+
+// notificationChannels returns the channels alerts of the task are sent to: those of the first of the project's
+// routes matching the task, every channel when no route matches, and the project users when there are no channels
+func notificationChannels(project *models.Project, task *models.Task) []models.NotificationChannel {
+	if len(project.NotificationChannels) == 0 {
+		return []models.NotificationChannel{defaultChannel}
+	}
+	if task == nil {
+		return project.NotificationChannels
+	}
+
+	for _, route := range project.AlertRoutes {
+		if !route.Matches(task) {
+			continue
+		}
+		channels := make([]models.NotificationChannel, 0, len(route.Channels))
+		for _, name := range route.Channels {
+			if channel, ok := project.FindNotificationChannel(name); ok {
+				channels = append(channels, *channel)
+			}
+		}
+		return channels
+	}
+	return project.NotificationChannels
+}
+
+// deliver sends the notification through one channel
+func (s *Service) deliver(ctx context.Context, channel models.NotificationChannel, n notification) error {
+	switch channel.Type {
+	case models.NotificationChannelEmail:
+		return s.deliverEmail(channel, n)
+	case models.NotificationChannelSlack:
+		return s.postJSON(ctx, channel.URL, map[string]string{"text": n.text})
+	case models.NotificationChannelWebhook:
+		return s.postJSON(ctx, channel.URL, buildWebhookPayload(n))
+	case models.NotificationChannelPagerDuty:
+		return s.postJSON(ctx, s.pagerDutyURL, buildPagerDutyEvent(channel.RoutingKey, n))
+	default:
+		return fmt.Errorf("unsupported notification channel type %q", channel.Type)
+	}
+}
+
+// deliverEmail emails the channel's addresses. Without addresses it emails the person on call in projects with an
+// on-call schedule and the project users otherwise.
+func (s *Service) deliverEmail(channel models.NotificationChannel, n notification) error {
+	sender := s.sender()
+	if sender == nil {
+		return ErrEmailNotConfigured
+	}
+
+	recipients := channel.Emails
+	if len(recipients) == 0 {
+		if onCall := n.project.CurrentOnCall(time.Now()); onCall != nil {
+			recipients = []string{onCall.Email}
+		}
+	}
+	if len(recipients) == 0 {
+		for _, projectUser := range n.project.ProjectUsers {
+			if projectUser.Email != "" {
+				recipients = append(recipients, projectUser.Email)
+			}
+		}
+	}
+	if len(recipients) == 0 {
+		return ErrNoRecipients
+	}
+
+	return sender.Send(gmail.EmailMessage{
+		To:      recipients,
+		Subject: n.subject,
+		Body:    n.htmlBody,
+	})
+}
+
+// postJSON POSTs body to target and fails unless the response is 2xx. Errors do not include the URL, which often
+// embeds a secret token.
+func (s *Service) postJSON(ctx context.Context, target string, body interface{}) error {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("failed to encode notification: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, channelTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, target, bytes.NewReader(data))
+	if err != nil {
+		return errors.New("invalid channel url")
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "cron-observer")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			err = urlErr.Err
+		}
+		return fmt.Errorf("failed to reach channel: %w", err)
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 4096))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("channel responded with status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// pagerDutySeverities maps task severities to the severities of PagerDuty events
+var pagerDutySeverities = map[models.TaskSeverity]string{
+	models.TaskSeverityCritical: "critical",
+	models.TaskSeverityHigh:     "error",
+	models.TaskSeverityNormal:   "warning",
+	models.TaskSeverityLow:      "info",
+}
+
+// pagerDutyActions maps incident states to the PagerDuty event that moves the PagerDuty incident to the same state
+var pagerDutyActions = map[models.IncidentStatus]string{
+	models.IncidentStatusOpen:         "trigger",
+	models.IncidentStatusAcknowledged: "acknowledge",
+	models.IncidentStatusResolved:     "resolve",
+}
+
+// buildPagerDutyEvent mirrors the notification's incident in PagerDuty: each incident has its own PagerDuty
+// incident, which is acknowledged and resolved together with it. Failures without an incident trigger one per task,
+// and flapping tasks have one that resolves when they stop flapping.
+func buildPagerDutyEvent(routingKey string, n notification) pagerDutyEvent {
+	dedupKey := "cron-observer-test-" + n.project.UUID
+	action := "trigger"
+	severity := "info"
+	if n.task != nil {
+		dedupKey = "cron-observer-task-" + n.task.UUID
+		severity = pagerDutySeverities[n.task.EffectiveSeverity()]
+	}
+	if n.incident != nil {
+		dedupKey = "cron-observer-incident-" + n.incident.UUID
+		action = pagerDutyActions[n.incident.Status]
+	}
+	switch n.event {
+	case eventTaskFlapping:
+		dedupKey = "cron-observer-flapping-" + n.task.UUID
+	case eventTaskFlappingStopped:
+		dedupKey = "cron-observer-flapping-" + n.task.UUID
+		action = "resolve"
+	case eventCircuitOpened:
+		dedupKey = "cron-observer-circuit-" + n.project.UUID
+		severity = "error"
+	}
+
+	event := pagerDutyEvent{
+		RoutingKey:  routingKey,
+		EventAction: action,
+		DedupKey:    dedupKey,
+	}
+	if action == "trigger" {
+		event.Payload = &pagerDutyPayload{
+			Summary:       n.subject,
+			Source:        "cron-observer/" + n.project.Name,
+			Severity:      severity,
+			CustomDetails: buildWebhookPayload(n),
+		}
+	}
+	return event
+}
+
+func buildWebhookPayload(n notification) webhookPayload {
+	payload := webhookPayload{
+		Event:   n.event,
+		Subject: n.subject,
+		Text:    n.text,
+		Project: webhookProject{
+			ID:   n.project.ID.Hex(),
+			UUID: n.project.UUID,
+			Name: n.project.Name,
+		},
+		SentAt: time.Now().UTC(),
+	}
+	if n.task != nil {
+		payload.Task = &webhookTask{UUID: n.task.UUID, Name: n.task.Name, Severity: string(n.task.EffectiveSeverity())}
+	}
+	if n.execution != nil {
+		payload.Execution = &webhookExecution{
+			UUID:      n.execution.UUID,
+			Status:    string(n.execution.Status),
+			Error:     n.execution.Error,
+			StartedAt: n.execution.StartedAt,
+			EndedAt:   n.execution.EndedAt,
+		}
+	}
+	if n.incident != nil {
+		payload.Incident = &webhookIncident{
+			UUID:           n.incident.UUID,
+			Status:         string(n.incident.Status),
+			FailureCount:   n.incident.FailureCount,
+			OpenedAt:       n.incident.OpenedAt,
+			AcknowledgedBy: n.incident.AcknowledgedBy,
+			ResolvedBy:     n.incident.ResolvedBy,
+			ResolvedAt:     n.incident.ResolvedAt,
+		}
+	}
+	if n.onCall != nil {
+		payload.OnCall = &webhookOnCall{Name: n.onCall.Name, Email: n.onCall.Email, Phone: n.onCall.Phone}
+	}
+	return payload
+}
@@ -0,0 +1,361 @@
+package alert
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/yourusername/cron-observer/backend/internal/events"
+	"github.com/yourusername/cron-observer/backend/internal/gmail"
+	"github.com/yourusername/cron-observer/backend/internal/models"
+	"github.com/yourusername/cron-observer/backend/internal/repositories"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// recordingSender records the emails it is asked to send
+type recordingSender struct {
+	sent []gmail.EmailMessage
+}
+
+func (s *recordingSender) Send(msg gmail.EmailMessage) error {
+	s.sent = append(s.sent, msg)
+	return nil
+}
+
+func TestService_SendTestAlert_PostsWebhookPayload(t *testing.T) {
+	var payload webhookPayload
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Content-Type") != "application/json" {
+			t.Errorf("Content-Type = %q", r.Header.Get("Content-Type"))
+		}
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Errorf("decode payload: %v", err)
+		}
+	}))
+	defer server.Close()
+
+	service := NewService(repositories.NewMemoryRepository(), events.NewEventBus(1), nil)
+	project := &models.Project{ID: primitive.NewObjectID(), UUID: "project-1", Name: "billing"}
+	channel := &models.NotificationChannel{Name: "ops", Type: models.NotificationChannelWebhook, URL: server.URL}
+
+	if err := service.SendTestAlert(context.Background(), project, channel, "dev@example.com"); err != nil {
+		t.Fatalf("SendTestAlert: %v", err)
+	}
+	if payload.Event != eventTest || payload.Project.UUID != "project-1" || payload.Task != nil {
+		t.Errorf("unexpected payload: %+v", payload)
+	}
+	if !strings.Contains(payload.Text, "dev@example.com") {
+		t.Errorf("text %q does not name the requester", payload.Text)
+	}
+}
+
+func TestService_SendTestAlert_ReportsRejectedDelivery(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "no_service", http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	service := NewService(repositories.NewMemoryRepository(), events.NewEventBus(1), nil)
+	project := &models.Project{ID: primitive.NewObjectID(), Name: "billing"}
+	channel := &models.NotificationChannel{Name: "ops-slack", Type: models.NotificationChannelSlack, URL: server.URL + "/services/secret"}
+
+	err := service.SendTestAlert(context.Background(), project, channel, "dev@example.com")
+	if err == nil || !strings.Contains(err.Error(), "404") {
+		t.Fatalf("err = %v, want the status", err)
+	}
+	if strings.Contains(err.Error(), "secret") {
+		t.Errorf("error %q leaks the channel URL", err)
+	}
+}
+
+func TestService_SendTestAlert_DefaultsToProjectUsers(t *testing.T) {
+	project := &models.Project{
+		ID:           primitive.NewObjectID(),
+		Name:         "billing",
+		ProjectUsers: []models.ProjectUser{{Email: "dev@example.com"}},
+	}
+
+	service := NewService(repositories.NewMemoryRepository(), events.NewEventBus(1), nil)
+	if err := service.SendTestAlert(context.Background(), project, nil, "dev@example.com"); err != ErrEmailNotConfigured {
+		t.Fatalf("err = %v, want ErrEmailNotConfigured", err)
+	}
+
+	sender := &recordingSender{}
+	service.SetSender(sender)
+	if err := service.SendTestAlert(context.Background(), project, nil, "dev@example.com"); err != nil {
+		t.Fatalf("SendTestAlert: %v", err)
+	}
+	if len(sender.sent) != 1 || sender.sent[0].To[0] != "dev@example.com" {
+		t.Errorf("sent %+v", sender.sent)
+	}
+}
+
+func TestService_SendTestAlert_EmailsPersonOnCall(t *testing.T) {
+	now := time.Now()
+	project := &models.Project{
+		ID:           primitive.NewObjectID(),
+		Name:         "billing",
+		ProjectUsers: []models.ProjectUser{{Email: "dev@example.com"}, {Email: "ops@example.com"}},
+		OnCall: &models.OnCallSchedule{
+			Participants: []models.OnCallParticipant{
+				{Name: "Alice", Email: "alice@example.com"},
+				{Name: "Bob", Email: "bob@example.com", Phone: "+4915112345678"},
+			},
+			Timezone:    "UTC",
+			HandoffTime: "09:00",
+			ShiftDays:   1,
+			StartDate:   "2025-01-06",
+			Overrides:   []models.OnCallOverride{{Email: "bob@example.com", Start: now.Add(-time.Hour), End: now.Add(time.Hour)}},
+		},
+	}
+
+	var payload webhookPayload
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&payload)
+	}))
+	defer server.Close()
+
+	service := NewService(repositories.NewMemoryRepository(), events.NewEventBus(1), nil)
+	sender := &recordingSender{}
+	service.SetSender(sender)
+	if err := service.SendTestAlert(context.Background(), project, nil, "dev@example.com"); err != nil {
+		t.Fatalf("SendTestAlert: %v", err)
+	}
+	if len(sender.sent) != 1 || len(sender.sent[0].To) != 1 || sender.sent[0].To[0] != "bob@example.com" {
+		t.Errorf("sent %+v, want only the person on call", sender.sent)
+	}
+
+	channel := &models.NotificationChannel{Name: "ops", Type: models.NotificationChannelWebhook, URL: server.URL}
+	if err := service.SendTestAlert(context.Background(), project, channel, "dev@example.com"); err != nil {
+		t.Fatalf("SendTestAlert: %v", err)
+	}
+	if payload.OnCall == nil || payload.OnCall.Phone != "+4915112345678" || !strings.Contains(payload.Text, "On call: Bob") {
+		t.Errorf("payload does not name the person on call: %+v", payload)
+	}
+}
+
+func TestService_HandleExecutionFailed_SendsToEveryChannel(t *testing.T) {
+	var slackText string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]string
+		json.NewDecoder(r.Body).Decode(&body)
+		slackText = body["text"]
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	repo := repositories.NewMemoryRepository()
+	project := &models.Project{
+		ID:   primitive.NewObjectID(),
+		Name: "billing",
+		NotificationChannels: []models.NotificationChannel{
+			{Name: "broken", Type: models.NotificationChannelWebhook, URL: "http://127.0.0.1:1/unreachable"},
+			{Name: "ops-email", Type: models.NotificationChannelEmail, Emails: []string{"ops@example.com"}},
+			{Name: "ops-slack", Type: models.NotificationChannelSlack, URL: server.URL},
+		},
+	}
+	if err := repo.CreateProject(ctx, project); err != nil {
+		t.Fatalf("CreateProject: %v", err)
+	}
+
+	sender := &recordingSender{}
+	service := NewService(repo, events.NewEventBus(1), sender)
+	service.handleExecutionFailed(events.ExecutionFailedPayload{
+		Task:      &models.Task{UUID: "task-1", Name: "nightly-invoices", ProjectID: project.ID},
+		Execution: &models.Execution{UUID: "execution-1", Status: models.ExecutionStatusFailed, Error: "exit status 1", StartedAt: time.Now()},
+	})
+
+	if len(sender.sent) != 1 || sender.sent[0].To[0] != "ops@example.com" {
+		t.Errorf("emails sent: %+v", sender.sent)
+	}
+	if !strings.Contains(slackText, "nightly-invoices") || !strings.Contains(slackText, "exit status 1") {
+		t.Errorf("slack text = %q", slackText)
+	}
+}
+
+func TestNotificationChannels_FirstMatchingRouteDecides(t *testing.T) {
+	project := &models.Project{
+		NotificationChannels: []models.NotificationChannel{
+			{Name: "pagerduty", Type: models.NotificationChannelPagerDuty, RoutingKey: "key"},
+			{Name: "digest", Type: models.NotificationChannelEmail, DigestMinutes: 60},
+			{Name: "ops-slack", Type: models.NotificationChannelSlack, URL: "https://hooks.slack.com/services/T/B/X"},
+		},
+		AlertRoutes: []models.AlertRoute{
+			{Tags: []string{"critical", "team:payments"}, Channels: []string{"pagerduty", "ops-slack"}},
+			{Tags: []string{"critical"}, Channels: []string{"pagerduty"}},
+			{Channels: []string{"digest"}},
+		},
+	}
+
+	tests := []struct {
+		tags []string
+		want []string
+	}{
+		{[]string{"team:payments", "critical"}, []string{"pagerduty", "ops-slack"}},
+		{[]string{"critical"}, []string{"pagerduty"}},
+		{nil, []string{"digest"}},
+	}
+	for _, tt := range tests {
+		var got []string
+		for _, channel := range notificationChannels(project, &models.Task{Tags: tt.tags}) {
+			got = append(got, channel.Name)
+		}
+		if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+			t.Errorf("tags %v routed to %v, want %v", tt.tags, got, tt.want)
+		}
+	}
+
+	// Severities narrow a route like tags do; tasks without a severity are NORMAL
+	project.AlertRoutes[1].Severities = []models.TaskSeverity{models.TaskSeverityCritical, models.TaskSeverityHigh}
+	for severity, want := range map[models.TaskSeverity]string{models.TaskSeverityHigh: "pagerduty", "": "digest"} {
+		got := notificationChannels(project, &models.Task{Tags: []string{"critical"}, Severity: severity})
+		if len(got) != 1 || got[0].Name != want {
+			t.Errorf("severity %q routed to %v, want %s", severity, got, want)
+		}
+	}
+
+	// Without a catch-all route, unmatched alerts go to every channel
+	project.AlertRoutes = project.AlertRoutes[:2]
+	if got := notificationChannels(project, &models.Task{}); len(got) != 3 {
+		t.Errorf("unmatched alert routed to %d channels, want 3", len(got))
+	}
+}
+
+func TestService_HandleExecutionFailed_TriggersPagerDutyAndQueuesDigest(t *testing.T) {
+	var event pagerDutyEvent
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&event)
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	repo := repositories.NewMemoryRepository()
+	project := &models.Project{
+		ID:   primitive.NewObjectID(),
+		Name: "billing",
+		NotificationChannels: []models.NotificationChannel{
+			{Name: "pagerduty", Type: models.NotificationChannelPagerDuty, RoutingKey: "routing-key"},
+			{Name: "digest", Type: models.NotificationChannelEmail, Emails: []string{"ops@example.com"}, DigestMinutes: 60},
+		},
+		AlertRoutes: []models.AlertRoute{
+			{Tags: []string{"critical"}, Channels: []string{"pagerduty"}},
+			{Channels: []string{"digest"}},
+		},
+	}
+	if err := repo.CreateProject(ctx, project); err != nil {
+		t.Fatalf("CreateProject: %v", err)
+	}
+
+	sender := &recordingSender{}
+	service := NewService(repo, events.NewEventBus(1), sender)
+	service.pagerDutyURL = server.URL
+	fail := func(task *models.Task) {
+		task.ProjectID = project.ID
+		service.handleExecutionFailed(events.ExecutionFailedPayload{
+			Task:      task,
+			Execution: &models.Execution{UUID: "execution-" + task.UUID, Status: models.ExecutionStatusFailed, Error: "exit status 1", StartedAt: time.Now()},
+		})
+	}
+	fail(&models.Task{UUID: "task-1", Name: "charge-cards", Tags: []string{"critical"}})
+	fail(&models.Task{UUID: "task-2", Name: "cleanup"})
+	fail(&models.Task{UUID: "task-3", Name: "reports"})
+
+	if event.RoutingKey != "routing-key" || !strings.HasPrefix(event.DedupKey, "cron-observer-incident-") {
+		t.Errorf("unexpected PagerDuty event: %+v", event)
+	}
+	if len(sender.sent) != 0 {
+		t.Fatalf("digest alerts were emailed at once: %+v", sender.sent)
+	}
+
+	service.flushDigests(ctx, time.Now(), false)
+	if len(sender.sent) != 0 {
+		t.Fatal("digest sent before it was due")
+	}
+	service.flushDigests(ctx, time.Now().Add(time.Hour), false)
+	if len(sender.sent) != 1 {
+		t.Fatalf("sent %d digests, want 1", len(sender.sent))
+	}
+	digest := sender.sent[0]
+	if !strings.HasPrefix(digest.Subject, "2 failed") || !strings.Contains(digest.Body, "cleanup") || !strings.Contains(digest.Body, "reports") {
+		t.Errorf("unexpected digest %q: %s", digest.Subject, digest.Body)
+	}
+}
+
+func TestService_Incidents_AlertOnStateChangesOnly(t *testing.T) {
+	var pagerDuty []pagerDutyEvent
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var event pagerDutyEvent
+		json.NewDecoder(r.Body).Decode(&event)
+		pagerDuty = append(pagerDuty, event)
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	repo := repositories.NewMemoryRepository()
+	project := &models.Project{
+		ID:   primitive.NewObjectID(),
+		Name: "billing",
+		NotificationChannels: []models.NotificationChannel{
+			{Name: "pagerduty", Type: models.NotificationChannelPagerDuty, RoutingKey: "routing-key"},
+			{Name: "ops-email", Type: models.NotificationChannelEmail, Emails: []string{"ops@example.com"}},
+		},
+	}
+	if err := repo.CreateProject(ctx, project); err != nil {
+		t.Fatalf("CreateProject: %v", err)
+	}
+
+	sender := &recordingSender{}
+	service := NewService(repo, events.NewEventBus(1), sender)
+	service.pagerDutyURL = server.URL
+	task := &models.Task{UUID: "task-1", Name: "nightly-invoices", ProjectID: project.ID}
+	for _, executionUUID := range []string{"execution-1", "execution-2", "execution-3"} {
+		service.handleExecutionFailed(events.ExecutionFailedPayload{
+			Task:      task,
+			Execution: &models.Execution{UUID: executionUUID, Status: models.ExecutionStatusFailed, Error: "exit status 1", StartedAt: time.Now()},
+		})
+	}
+
+	if len(sender.sent) != 1 || len(pagerDuty) != 1 {
+		t.Fatalf("sent %d emails and %d PagerDuty events for one incident, want 1 each", len(sender.sent), len(pagerDuty))
+	}
+	incidents, _, err := repo.ListIncidents(ctx, project.ID, models.IncidentListFilter{}, 1, 10)
+	if err != nil || len(incidents) != 1 {
+		t.Fatalf("ListIncidents = %v, %v", incidents, err)
+	}
+	incident := incidents[0]
+	if incident.FailureCount != 3 || incident.FirstExecutionUUID != "execution-1" || incident.LastExecutionUUID != "execution-3" {
+		t.Errorf("unexpected incident: %+v", incident)
+	}
+
+	service.handleExecutionSucceeded(events.ExecutionSucceededPayload{
+		Task:      task,
+		Execution: &models.Execution{UUID: "execution-4", Status: models.ExecutionStatusSuccess, StartedAt: time.Now()},
+	})
+
+	resolved, err := repo.GetIncidentByUUID(ctx, project.ID, incident.UUID)
+	if err != nil || resolved.Status != models.IncidentStatusResolved || resolved.ResolvedExecutionUUID != "execution-4" {
+		t.Fatalf("incident after success = %+v, %v", resolved, err)
+	}
+	if len(sender.sent) != 2 || !strings.HasPrefix(sender.sent[1].Subject, "Incident Resolved") {
+		t.Errorf("emails sent: %+v", sender.sent)
+	}
+	last := pagerDuty[len(pagerDuty)-1]
+	if last.EventAction != "resolve" || last.DedupKey != "cron-observer-incident-"+incident.UUID || last.Payload != nil {
+		t.Errorf("unexpected PagerDuty event: %+v", last)
+	}
+
+	// Successes without an active incident send nothing
+	service.handleExecutionSucceeded(events.ExecutionSucceededPayload{
+		Task:      task,
+		Execution: &models.Execution{UUID: "execution-5", Status: models.ExecutionStatusSuccess, StartedAt: time.Now()},
+	})
+	if len(sender.sent) != 2 {
+		t.Errorf("sent %d emails, want 2", len(sender.sent))
+	}
+}
@@ -0,0 +1,76 @@
+package alert
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"log"
+	"time"
+
+	"github.com/yourusername/cron-observer/backend/internal/events"
+)
+
+// handleCircuitOpened tells the project's channels that the scheduler stopped dispatching to one of its execution
+// endpoints. The executions failed meanwhile raise no alerts of their own, so this is the only notification until
+// the endpoint recovers. It goes to every channel of the project, digest channels included.
+func (s *Service) handleCircuitOpened(payload events.CircuitOpenedPayload) {
+	ctx := context.Background()
+	project := payload.Project
+
+	subject := fmt.Sprintf("Execution Endpoint Failing: %s", project.Name)
+	summary := fmt.Sprintf("The last %d executions sent to %s failed (%s). Executions are failed with %q instead of being sent until %s, then one execution tests whether the endpoint recovered.",
+		payload.Failures, payload.Endpoint, payload.LastError, "circuit_open", payload.OpenUntil.UTC().Format(time.RFC3339))
+	n := notification{
+		event:    eventCircuitOpened,
+		subject:  subject,
+		text:     fmt.Sprintf("Project %s: %s", project.Name, summary),
+		htmlBody: buildCircuitEmailBody(subject, project.Name, summary),
+		project:  project,
+		onCall:   project.CurrentOnCall(time.Now()),
+	}
+	n.text += onCallLine(n.onCall)
+
+	for _, channel := range notificationChannels(project, nil) {
+		if err := s.deliver(ctx, channel, n); err != nil {
+			log.Printf("[AlertService] Failed to send %s alert for project %s via %s channel %q: %v", n.event, project.Name, channel.Type, channel.Name, err)
+			continue
+		}
+		s.meter.RecordAlert(project.ID)
+		log.Printf("[AlertService] Sent %s alert for project %s via %s channel %q", n.event, project.Name, channel.Type, channel.Name)
+	}
+}
+
+// buildCircuitEmailBody creates the HTML email body for an execution endpoint whose circuit opened
+func buildCircuitEmailBody(headline, projectName, summary string) string {
+	return fmt.Sprintf(`
+<!DOCTYPE html>
+<html>
+<head>
+	<style>
+		body { font-family: Arial, sans-serif; line-height: 1.6; color: #333; }
+		.container { max-width: 600px; margin: 0 auto; padding: 20px; }
+		.header { background-color: #dc3545; color: white; padding: 20px; border-radius: 5px 5px 0 0; }
+		.content { background-color: #f8f9fa; padding: 20px; border: 1px solid #dee2e6; border-top: none; }
+		.footer { margin-top: 20px; padding-top: 20px; border-top: 1px solid #dee2e6; font-size: 12px; color: #6c757d; }
+	</style>
+</head>
+<body>
+	<div class="container">
+		<div class="header">
+			<h2 style="margin: 0;">%s</h2>
+		</div>
+		<div class="content">
+			<p>Project <strong>%s</strong>: %s</p>
+		</div>
+		<div class="footer">
+			<p>This is an automated alert from Cron Observer.</p>
+		</div>
+	</div>
+</body>
+</html>
+`,
+		html.EscapeString(headline),
+		html.EscapeString(projectName),
+		html.EscapeString(summary),
+	)
+}
@@ -0,0 +1,51 @@
+package alert
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/yourusername/cron-observer/backend/internal/events"
+	"github.com/yourusername/cron-observer/backend/internal/models"
+	"github.com/yourusername/cron-observer/backend/internal/repositories"
+)
+
+func TestService_CircuitOpened_NotifiesEveryProjectChannel(t *testing.T) {
+	var sent []webhookPayload
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload webhookPayload
+		json.NewDecoder(r.Body).Decode(&payload)
+		sent = append(sent, payload)
+	}))
+	defer server.Close()
+
+	project := &models.Project{
+		UUID: "project-1",
+		Name: "billing",
+		NotificationChannels: []models.NotificationChannel{
+			{Name: "ops", Type: models.NotificationChannelWebhook, URL: server.URL},
+			{Name: "db", Type: models.NotificationChannelWebhook, URL: server.URL},
+		},
+		// Routes apply to task alerts only
+		AlertRoutes: []models.AlertRoute{{Tags: []string{"db"}, Channels: []string{"db"}}},
+	}
+
+	service := NewService(repositories.NewMemoryRepository(), events.NewEventBus(1), nil)
+	service.handleCircuitOpened(events.CircuitOpenedPayload{
+		Project:   project,
+		Endpoint:  "https://api.example.com/execute",
+		Failures:  5,
+		LastError: "status 503",
+		OpenUntil: time.Date(2025, 1, 15, 10, 1, 0, 0, time.UTC),
+	})
+
+	if len(sent) != 2 {
+		t.Fatalf("Expected one notification per channel, got %d", len(sent))
+	}
+	if sent[0].Event != eventCircuitOpened || !strings.Contains(sent[0].Text, "https://api.example.com/execute") || sent[0].Task != nil {
+		t.Errorf("Unexpected notification %+v", sent[0])
+	}
+}
@@ -0,0 +1,158 @@
+package alert
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/yourusername/cron-observer/backend/internal/models"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// digestCheckInterval is how often queued digests are checked for being due
+const digestCheckInterval = 30 * time.Second
+
+// maxDigestAlerts bounds the incidents listed in one digest email; the rest are only counted
+const maxDigestAlerts = 100
+
+type digestKey struct {
+	projectID primitive.ObjectID
+	channel   string
+}
+
+// digest collects the opened incidents of an email channel with digest_minutes until it is due
+type digest struct {
+	project *models.Project
+	channel models.NotificationChannel
+	alerts  []notification
+	dropped int
+	due     time.Time
+}
+
+// queueDigest adds an alert to the channel's pending digest, which is sent digest_minutes after its first alert.
+// Pending digests are kept in memory and lost if the process dies without shutting down.
+func (s *Service) queueDigest(project *models.Project, channel models.NotificationChannel, n notification, now time.Time) {
+	s.digestMu.Lock()
+	defer s.digestMu.Unlock()
+
+	key := digestKey{projectID: project.ID, channel: channel.Name}
+	pending, ok := s.digests[key]
+	if !ok {
+		pending = &digest{due: now.Add(time.Duration(channel.DigestMinutes) * time.Minute)}
+		s.digests[key] = pending
+	}
+	// The latest project and channel are used, in case the recipients changed
+	pending.project = project
+	pending.channel = channel
+	if len(pending.alerts) < maxDigestAlerts {
+		pending.alerts = append(pending.alerts, n)
+	} else {
+		pending.dropped++
+	}
+}
+
+// flushDigests sends the digests due at now, or all pending digests when all is set
+func (s *Service) flushDigests(ctx context.Context, now time.Time, all bool) {
+	s.digestMu.Lock()
+	var due []*digest
+	for key, pending := range s.digests {
+		if all || !now.Before(pending.due) {
+			due = append(due, pending)
+			delete(s.digests, key)
+		}
+	}
+	s.digestMu.Unlock()
+
+	for _, pending := range due {
+		count := len(pending.alerts) + pending.dropped
+		n := notification{
+			event:    eventIncidentOpened,
+			subject:  fmt.Sprintf("%d failed task executions in %s", count, pending.project.Name),
+			htmlBody: buildDigestEmailBody(pending),
+			project:  pending.project,
+		}
+		if err := s.deliverEmail(pending.channel, n); err != nil {
+			log.Printf("[AlertService] Failed to send digest of %d alerts for project %s via channel %q: %v", count, pending.project.Name, pending.channel.Name, err)
+			continue
+		}
+		s.meter.RecordAlert(pending.project.ID)
+		log.Printf("[AlertService] Sent digest of %d alerts for project %s via channel %q", count, pending.project.Name, pending.channel.Name)
+	}
+}
+
+// buildDigestEmailBody creates the HTML email body listing the failures of a digest
+func buildDigestEmailBody(pending *digest) string {
+	// Failures are grouped by severity, the most urgent first
+	bySeverity := make(map[models.TaskSeverity][]notification)
+	for _, n := range pending.alerts {
+		severity := n.task.EffectiveSeverity()
+		bySeverity[severity] = append(bySeverity[severity], n)
+	}
+
+	var rows strings.Builder
+	for _, severity := range models.TaskSeverities {
+		alerts := bySeverity[severity]
+		if len(alerts) == 0 {
+			continue
+		}
+		fmt.Fprintf(&rows, "\t\t\t\t<tr><th colspan=\"4\" class=\"severity\">%s (%d)</th></tr>\n", severity, len(alerts))
+		for _, n := range alerts {
+			failedAt := n.execution.StartedAt
+			if n.execution.EndedAt != nil {
+				failedAt = *n.execution.EndedAt
+			}
+			fmt.Fprintf(&rows, "\t\t\t\t<tr><td>%s</td><td>%s</td><td>%s</td><td>%s</td></tr>\n",
+				failedAt.UTC().Format(time.RFC3339),
+				html.EscapeString(n.task.Name),
+				html.EscapeString(n.execution.UUID),
+				html.EscapeString(n.execution.Error),
+			)
+		}
+	}
+	more := ""
+	if pending.dropped > 0 {
+		more = fmt.Sprintf("<p>%d more failures are not listed.</p>", pending.dropped)
+	}
+
+	return fmt.Sprintf(`
+<!DOCTYPE html>
+<html>
+<head>
+	<style>
+		body { font-family: Arial, sans-serif; line-height: 1.6; color: #333; }
+		.container { max-width: 800px; margin: 0 auto; padding: 20px; }
+		.header { background-color: #dc3545; color: white; padding: 20px; border-radius: 5px 5px 0 0; }
+		.content { background-color: #f8f9fa; padding: 20px; border: 1px solid #dee2e6; border-top: none; }
+		table { width: 100%%; border-collapse: collapse; }
+		th, td { text-align: left; padding: 6px; border-bottom: 1px solid #dee2e6; font-size: 14px; }
+		.severity { background-color: #e9ecef; padding-top: 12px; }
+		.footer { margin-top: 20px; padding-top: 20px; border-top: 1px solid #dee2e6; font-size: 12px; color: #6c757d; }
+	</style>
+</head>
+<body>
+	<div class="container">
+		<div class="header">
+			<h2 style="margin: 0;">Failed Task Executions: %s</h2>
+		</div>
+		<div class="content">
+			<table>
+				<tr><th>Time</th><th>Task</th><th>Execution UUID</th><th>Error</th></tr>
+%s			</table>
+			%s
+		</div>
+		<div class="footer">
+			<p>This is an automated alert digest from Cron Observer, sent every %d minutes while tasks fail.</p>
+		</div>
+	</div>
+</body>
+</html>
+`,
+		html.EscapeString(pending.project.Name),
+		rows.String(),
+		more,
+		pending.channel.DigestMinutes,
+	)
+}
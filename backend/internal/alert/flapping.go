@@ -0,0 +1,187 @@
+package alert
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"log"
+	"time"
+
+	"github.com/yourusername/cron-observer/backend/internal/models"
+)
+
+// flapWindow is the period flips between success and failure are counted over
+const flapWindow = time.Hour
+
+// flapHistory is what the service remembers about the recent outcomes of a task. Histories are kept in memory, so
+// after a restart a task needs a full flapWindow of executions before it is considered settled again.
+type flapHistory struct {
+	failed        bool        // Outcome of the latest execution
+	observedSince time.Time   // When the first execution of the history finished
+	flips         []time.Time // When the outcome changed, within flapWindow; oldest first
+	flappingSince *time.Time
+}
+
+type flapChange int
+
+const (
+	flapUnchanged flapChange = iota
+	flapStarted
+	flapStopped
+)
+
+// recordOutcome adds a finished execution of the task to its history. A task starts flapping once its outcome
+// flipped more than threshold times within flapWindow, and stops once at most half as many flips fall into a full
+// window, so tasks near the threshold do not toggle the mark with every execution.
+func (s *Service) recordOutcome(task *models.Task, failed bool, at time.Time, threshold int) (flapChange, *flapHistory) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	history, ok := s.flaps[task.UUID]
+	if !ok {
+		history = &flapHistory{failed: failed, observedSince: at, flappingSince: task.FlappingSince}
+		s.flaps[task.UUID] = history
+	} else if history.failed != failed {
+		history.failed = failed
+		history.flips = append(history.flips, at)
+	}
+
+	// Only whether there are more than threshold flips matters, so older ones are dropped as well
+	cutoff := at.Add(-flapWindow)
+	drop := 0
+	for drop < len(history.flips) && (!history.flips[drop].After(cutoff) || len(history.flips)-drop > threshold+1) {
+		drop++
+	}
+	history.flips = history.flips[drop:]
+
+	switch {
+	case history.flappingSince == nil && len(history.flips) > threshold:
+		history.flappingSince = &at
+		return flapStarted, history
+	case history.flappingSince != nil && len(history.flips) <= threshold/2 && !at.Before(history.observedSince.Add(flapWindow)):
+		history.flappingSince = nil
+		return flapStopped, history
+	}
+	return flapUnchanged, history
+}
+
+// handleFlapping feeds a finished execution to flap detection, marks or unmarks the task and sends one notification
+// when it starts and one when it stops flapping. Reports whether the task is flapping, in which case the alerts of
+// the execution are held back.
+func (s *Service) handleFlapping(ctx context.Context, task *models.Task, execution *models.Execution, finishedAt time.Time) bool {
+	settings, err := s.repo.GetProjectSettings(ctx, task.ProjectID)
+	if err != nil {
+		log.Printf("[AlertService] Failed to get settings for project %s, using the default flap threshold: %v", task.ProjectID.Hex(), err)
+	}
+	failed := execution.Status == models.ExecutionStatusFailed
+	change, history := s.recordOutcome(task, failed, finishedAt, settings.EffectiveFlapThreshold())
+
+	switch change {
+	case flapStarted:
+		if err := s.repo.SetTaskFlapping(ctx, task.UUID, history.flappingSince); err != nil {
+			log.Printf("[AlertService] Failed to mark task %s as flapping: %v", task.UUID, err)
+		}
+		log.Printf("[AlertService] Task %s is flapping", task.UUID)
+		s.notifyFlapping(ctx, task, execution, eventTaskFlapping)
+		return true
+	case flapStopped:
+		if err := s.repo.SetTaskFlapping(ctx, task.UUID, nil); err != nil {
+			log.Printf("[AlertService] Failed to clear flapping mark of task %s: %v", task.UUID, err)
+		}
+		log.Printf("[AlertService] Task %s stopped flapping", task.UUID)
+		s.notifyFlapping(ctx, task, execution, eventTaskFlappingStopped)
+		return false
+	}
+	if history.flappingSince != nil {
+		log.Printf("[AlertService] Task %s is flapping, holding back alerts of execution %s", task.UUID, execution.UUID)
+		return true
+	}
+	return false
+}
+
+// notifyFlapping tells the task's channels that it started or stopped flapping
+func (s *Service) notifyFlapping(ctx context.Context, task *models.Task, execution *models.Execution, event string) {
+	if task.IsMuted(time.Now()) {
+		log.Printf("[AlertService] Task %s is muted until %s, skipping %s notification", task.UUID, task.MutedUntil.Format(time.RFC3339), event)
+		return
+	}
+	project, err := s.repo.GetProjectByID(ctx, task.ProjectID)
+	if err != nil {
+		log.Printf("[AlertService] Failed to get project %s: %v", task.ProjectID.Hex(), err)
+		return
+	}
+
+	outcome := "succeeded"
+	if execution.Status == models.ExecutionStatusFailed {
+		outcome = "failed"
+	}
+	subject := fmt.Sprintf("Task Flapping: %s", task.Name)
+	summary := fmt.Sprintf("keeps flipping between success and failure. Its failure alerts are held back until it settles; the latest execution %s.", outcome)
+	if event == eventTaskFlappingStopped {
+		subject = fmt.Sprintf("Task Stopped Flapping: %s", task.Name)
+		summary = fmt.Sprintf("has settled and is no longer flapping. Failure alerts resume; the latest execution %s.", outcome)
+	}
+
+	s.send(ctx, project, task, notification{
+		event:     event,
+		subject:   withSeverity(task.EffectiveSeverity(), subject),
+		text:      fmt.Sprintf("Task %s (%s) in project %s %s", task.Name, task.UUID, project.Name, summary),
+		htmlBody:  buildTaskEmailBody(project, task, execution, subject, summary),
+		project:   project,
+		task:      task,
+		execution: execution,
+	})
+}
+
+// buildTaskEmailBody creates the HTML email body for a task that started or stopped flapping or was paused
+func buildTaskEmailBody(project *models.Project, task *models.Task, execution *models.Execution, headline, summary string) string {
+	return fmt.Sprintf(`
+<!DOCTYPE html>
+<html>
+<head>
+	<style>
+		body { font-family: Arial, sans-serif; line-height: 1.6; color: #333; }
+		.container { max-width: 600px; margin: 0 auto; padding: 20px; }
+		.header { background-color: #fd7e14; color: white; padding: 20px; border-radius: 5px 5px 0 0; }
+		.content { background-color: #f8f9fa; padding: 20px; border: 1px solid #dee2e6; border-top: none; }
+		.detail-row { margin: 10px 0; }
+		.label { font-weight: bold; color: #495057; }
+		.value { color: #212529; }
+		.footer { margin-top: 20px; padding-top: 20px; border-top: 1px solid #dee2e6; font-size: 12px; color: #6c757d; }
+	</style>
+</head>
+<body>
+	<div class="container">
+		<div class="header">
+			<h2 style="margin: 0;">%s</h2>
+		</div>
+		<div class="content">
+			<p>The task %s</p>
+			<div class="detail-row">
+				<span class="label">Project:</span>
+				<span class="value">%s</span>
+			</div>
+			<div class="detail-row">
+				<span class="label">Task UUID:</span>
+				<span class="value">%s</span>
+			</div>
+			<div class="detail-row">
+				<span class="label">Latest Execution:</span>
+				<span class="value">%s (%s)</span>
+			</div>
+		</div>
+		<div class="footer">
+			<p>This is an automated alert from Cron Observer.</p>
+		</div>
+	</div>
+</body>
+</html>
+`,
+		html.EscapeString(headline),
+		html.EscapeString(summary),
+		html.EscapeString(project.Name),
+		html.EscapeString(task.UUID),
+		html.EscapeString(execution.UUID),
+		html.EscapeString(string(execution.Status)),
+	)
+}
@@ -0,0 +1,90 @@
+package alert
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/yourusername/cron-observer/backend/internal/events"
+	"github.com/yourusername/cron-observer/backend/internal/models"
+	"github.com/yourusername/cron-observer/backend/internal/repositories"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestService_Flapping_SendsOneNotificationUntilTaskSettles(t *testing.T) {
+	var sent []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload webhookPayload
+		json.NewDecoder(r.Body).Decode(&payload)
+		sent = append(sent, payload.Event)
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	repo := repositories.NewMemoryRepository()
+	project := &models.Project{
+		ID:                   primitive.NewObjectID(),
+		Name:                 "billing",
+		NotificationChannels: []models.NotificationChannel{{Name: "ops", Type: models.NotificationChannelWebhook, URL: server.URL}},
+	}
+	if err := repo.CreateProject(ctx, project); err != nil {
+		t.Fatalf("CreateProject: %v", err)
+	}
+	task := &models.Task{ID: primitive.NewObjectID(), UUID: "task-1", Name: "sync", ProjectID: project.ID}
+	if err := repo.CreateTask(ctx, project.ID.Hex(), task); err != nil {
+		t.Fatalf("CreateTask: %v", err)
+	}
+
+	service := NewService(repo, events.NewEventBus(1), nil)
+	start := time.Date(2025, 1, 15, 10, 0, 0, 0, time.UTC)
+	finish := func(minute int, failed bool) {
+		endedAt := start.Add(time.Duration(minute) * time.Minute)
+		execution := &models.Execution{UUID: "execution-" + endedAt.Format("1504"), StartedAt: endedAt, EndedAt: &endedAt}
+		if failed {
+			execution.Status = models.ExecutionStatusFailed
+			service.handleExecutionFailed(events.ExecutionFailedPayload{Task: task, Execution: execution})
+			return
+		}
+		execution.Status = models.ExecutionStatusSuccess
+		service.handleExecutionSucceeded(events.ExecutionSucceededPayload{Task: task, Execution: execution})
+	}
+
+	// Six flips within the hour exceed the default threshold of 5; the flips after it are held back
+	for minute := 0; minute < 10; minute++ {
+		finish(minute, minute%2 == 0)
+	}
+	want := "incident.opened,incident.resolved,incident.opened,incident.resolved,incident.opened,incident.resolved,task.flapping"
+	if got := strings.Join(sent, ","); got != want {
+		t.Fatalf("notifications = %s, want %s", got, want)
+	}
+	flapping, err := repo.GetTaskByUUID(ctx, task.UUID)
+	if err != nil || flapping.FlappingSince == nil || !flapping.FlappingSince.Equal(start.Add(6*time.Minute)) {
+		t.Fatalf("task after flapping = %+v, %v", flapping, err)
+	}
+
+	// Successes alone do not flip; once the flips are an hour old the task has settled
+	finish(30, false)
+	finish(70, false)
+	if got := sent[len(sent)-1]; len(sent) != 8 || got != eventTaskFlappingStopped {
+		t.Fatalf("notifications after settling = %v", sent)
+	}
+	settled, err := repo.GetTaskByUUID(ctx, task.UUID)
+	if err != nil || settled.FlappingSince != nil {
+		t.Fatalf("task after settling = %+v, %v", settled, err)
+	}
+}
+
+func TestBuildPagerDutyEvent_FlappingResolvesWhenStopped(t *testing.T) {
+	project := &models.Project{UUID: "project-1", Name: "billing"}
+	task := &models.Task{UUID: "task-1", Name: "sync"}
+
+	started := buildPagerDutyEvent("key", notification{event: eventTaskFlapping, project: project, task: task})
+	stopped := buildPagerDutyEvent("key", notification{event: eventTaskFlappingStopped, project: project, task: task})
+	if started.EventAction != "trigger" || stopped.EventAction != "resolve" || started.DedupKey != stopped.DedupKey {
+		t.Errorf("flapping events %+v and %+v do not share one PagerDuty incident", started, stopped)
+	}
+}
@@ -2,122 +2,427 @@ package alert
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"html"
 	"log"
+	"net/http"
+	"sync"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/yourusername/cron-observer/backend/internal/events"
 	"github.com/yourusername/cron-observer/backend/internal/gmail"
+	"github.com/yourusername/cron-observer/backend/internal/metering"
 	"github.com/yourusername/cron-observer/backend/internal/models"
 	"github.com/yourusername/cron-observer/backend/internal/repositories"
+	"go.mongodb.org/mongo-driver/mongo"
 )
 
-// Service handles alert notifications for execution failures
+// Service groups failed executions into incidents and sends alerts when incidents open, are acknowledged and resolve
 type Service struct {
-	repo        repositories.Repository
-	eventBus    *events.EventBus
-	gmailSender gmail.Sender
+	repo     repositories.Repository
+	eventBus *events.EventBus
+
+	senderMu    sync.RWMutex
+	gmailSender gmail.Sender // replaced by SetSender when the configuration is reloaded
+
+	mu         sync.Mutex
+	lastAlerts map[string]time.Time    // taskUUID -> time the last alert was sent, for per-project throttling
+	flaps      map[string]*flapHistory // taskUUID -> recent outcomes, for flap detection
+
+	meter *metering.Meter // counts sent alerts; nil records nothing
+
+	client       *http.Client // delivers Slack, webhook and PagerDuty notifications
+	pagerDutyURL string
+
+	digestMu sync.Mutex
+	digests  map[digestKey]*digest // pending alerts of email channels with digest_minutes
 }
 
 // NewService creates a new alert service
 func NewService(repo repositories.Repository, eventBus *events.EventBus, gmailSender gmail.Sender) *Service {
 	return &Service{
-		repo:        repo,
-		eventBus:    eventBus,
-		gmailSender: gmailSender,
+		repo:         repo,
+		eventBus:     eventBus,
+		gmailSender:  gmailSender,
+		lastAlerts:   make(map[string]time.Time),
+		flaps:        make(map[string]*flapHistory),
+		client:       &http.Client{Timeout: channelTimeout},
+		pagerDutyURL: pagerDutyEventsURL,
+		digests:      make(map[digestKey]*digest),
 	}
 }
 
-// Start starts the alert service and begins listening for execution failed events
+// SetSender replaces the sender of alert emails, e.g. after the Gmail credentials were reloaded. nil disables
+// alert emails. Alerts being sent finish with the previous sender.
+func (s *Service) SetSender(sender gmail.Sender) {
+	s.senderMu.Lock()
+	defer s.senderMu.Unlock()
+	s.gmailSender = sender
+}
+
+// SetMeter counts the alerts sent for usage metering
+func (s *Service) SetMeter(meter *metering.Meter) {
+	s.meter = meter
+}
+
+func (s *Service) sender() gmail.Sender {
+	s.senderMu.RLock()
+	defer s.senderMu.RUnlock()
+	return s.gmailSender
+}
+
+// Start starts the alert service and begins listening for execution and incident events
 func (s *Service) Start(ctx context.Context) {
-	executionFailedCh := s.eventBus.Subscribe(events.ExecutionFailed)
+	executionFailedCh := events.Subscribe(s.eventBus, events.ExecutionFailedTopic)
+	executionSucceededCh := events.Subscribe(s.eventBus, events.ExecutionSucceededTopic)
+	incidentUpdatedCh := events.Subscribe(s.eventBus, events.IncidentUpdatedTopic)
+	circuitOpenedCh := events.Subscribe(s.eventBus, events.CircuitOpenedTopic)
 
 	go func() {
+		digestTicker := time.NewTicker(digestCheckInterval)
+		defer digestTicker.Stop()
+
 		for {
 			select {
 			case <-ctx.Done():
 				log.Println("[AlertService] Context cancelled, stopping")
+				// Pending digests are sent early rather than lost
+				s.flushDigests(context.Background(), time.Now(), true)
 				return
-			case event, ok := <-executionFailedCh:
+			case now := <-digestTicker.C:
+				s.flushDigests(ctx, now, false)
+			case payload, ok := <-executionFailedCh:
 				if !ok {
 					log.Println("[AlertService] ExecutionFailed channel closed")
 					return
 				}
-				s.handleExecutionFailed(event)
+				s.handleExecutionFailed(payload)
+			case payload, ok := <-executionSucceededCh:
+				if !ok {
+					log.Println("[AlertService] ExecutionSucceeded channel closed")
+					return
+				}
+				s.handleExecutionSucceeded(payload)
+			case payload, ok := <-incidentUpdatedCh:
+				if !ok {
+					log.Println("[AlertService] IncidentUpdated channel closed")
+					return
+				}
+				s.handleIncidentUpdated(payload)
+			case payload, ok := <-circuitOpenedCh:
+				if !ok {
+					log.Println("[AlertService] CircuitOpened channel closed")
+					return
+				}
+				s.handleCircuitOpened(payload)
 			}
 		}
 	}()
 
-	log.Println("[AlertService] Started and listening for execution failed events")
+	log.Println("[AlertService] Started and listening for execution and incident events")
 }
 
-// handleExecutionFailed processes an execution failed event and sends alerts
-func (s *Service) handleExecutionFailed(event events.Event) {
-	payload, ok := event.Payload.(events.ExecutionFailedPayload)
-	if !ok {
-		log.Printf("[AlertService] Invalid payload for ExecutionFailed event")
+// handleExecutionFailed records a failed execution on its task's incident and alerts when the failure opened the
+// incident. Later failures only update the incident, and flapping tasks are not alerted for.
+func (s *Service) handleExecutionFailed(payload events.ExecutionFailedPayload) {
+	ctx := context.Background()
+	failedAt := payload.Execution.StartedAt
+	if payload.Execution.EndedAt != nil {
+		failedAt = *payload.Execution.EndedAt
+	}
+
+	// Incidents are tracked for muted tasks too, so unmuting does not lose track of an ongoing failure
+	incident, err := s.repo.RecordIncidentFailure(ctx, models.IncidentFailure{
+		IncidentUUID:  uuid.New().String(),
+		ProjectID:     payload.Task.ProjectID,
+		TaskUUID:      payload.Task.UUID,
+		TaskName:      payload.Task.Name,
+		Severity:      payload.Task.EffectiveSeverity(),
+		ExecutionUUID: payload.Execution.UUID,
+		Error:         payload.Execution.Error,
+		FailedAt:      failedAt,
+	})
+	if err == nil {
+		s.autoPause(ctx, payload.Task, incident, payload.Execution)
+	}
+	flapping := s.handleFlapping(ctx, payload.Task, payload.Execution, failedAt)
+	if err != nil {
+		// An alert per failure is better than none
+		log.Printf("[AlertService] Failed to record failure of task %s on its incident, alerting for the failure: %v", payload.Task.UUID, err)
+		incident = nil
+	} else if incident.FailureCount > 1 {
+		log.Printf("[AlertService] Failure %d of task %s added to incident %s", incident.FailureCount, payload.Task.UUID, incident.UUID)
+		return
+	}
+	if flapping {
+		return
+	}
+
+	// Muted tasks keep running and recording executions, only the notification is skipped
+	if payload.Task.IsMuted(time.Now()) {
+		log.Printf("[AlertService] Task %s is muted until %s, skipping alert", payload.Task.UUID, payload.Task.MutedUntil.Format(time.RFC3339))
 		return
 	}
 
 	// Get project from task's ProjectID
-	ctx := context.Background()
 	project, err := s.repo.GetProjectByID(ctx, payload.Task.ProjectID)
 	if err != nil {
 		log.Printf("[AlertService] Failed to get project %s: %v", payload.Task.ProjectID.Hex(), err)
 		return
 	}
 
-	// Check if Gmail sender is available
-	if s.gmailSender == nil {
-		log.Printf("[AlertService] Gmail sender not configured, skipping alert for task %s", payload.Task.UUID)
+	// Projects can throttle repeated alerts for the same task, e.g. of incidents that open and resolve repeatedly
+	settings, err := s.repo.GetProjectSettings(ctx, project.ID)
+	if err != nil {
+		log.Printf("[AlertService] Failed to get settings for project %s, sending without throttling: %v", project.Name, err)
+	}
+	if !s.allowAlert(payload.Task.UUID, settings.AlertThrottle(), time.Now()) {
+		log.Printf("[AlertService] Alert for task %s throttled by project %s settings", payload.Task.UUID, project.Name)
 		return
 	}
 
-	// Collect email addresses from project_users
-	var recipients []string
-	for _, projectUser := range project.ProjectUsers {
-		if projectUser.Email != "" {
-			recipients = append(recipients, projectUser.Email)
-		}
+	// Format execution time
+	executionTime := failedAt.Format(time.RFC3339)
+
+	errorMsg := payload.Execution.Error
+	if errorMsg == "" {
+		errorMsg = "No error message available"
 	}
 
-	// If no project users, skip sending alert
-	if len(recipients) == 0 {
-		log.Printf("[AlertService] No project users found for project %s, skipping alert", project.Name)
-		return
+	severity := payload.Task.EffectiveSeverity()
+	event := eventExecutionFailed
+	text := fmt.Sprintf("Task execution failed in project %s\nTask: %s (%s)\nSeverity: %s\nExecution: %s at %s\nError: %s", project.Name, payload.Task.Name, payload.Task.UUID, severity, payload.Execution.UUID, executionTime, errorMsg)
+	if incident != nil {
+		event = eventIncidentOpened
+		text += fmt.Sprintf("\nIncident: %s (further failures are added to it until the task succeeds)", incident.UUID)
 	}
 
-	// Format execution time
-	executionTime := payload.Execution.StartedAt.Format(time.RFC3339)
+	s.send(ctx, project, payload.Task, notification{
+		event:     event,
+		subject:   withSeverity(severity, fmt.Sprintf("Task Execution Failed: %s", payload.Task.Name)),
+		text:      text,
+		htmlBody:  s.buildEmailBody(payload, project, incident, executionTime),
+		project:   project,
+		task:      payload.Task,
+		execution: payload.Execution,
+		incident:  incident,
+	})
+}
+
+// handleExecutionSucceeded resolves the task's active incident, if any, and notifies its channels unless the task
+// is flapping
+func (s *Service) handleExecutionSucceeded(payload events.ExecutionSucceededPayload) {
+	ctx := context.Background()
+	resolvedAt := time.Now()
 	if payload.Execution.EndedAt != nil {
-		executionTime = payload.Execution.EndedAt.Format(time.RFC3339)
+		resolvedAt = *payload.Execution.EndedAt
 	}
 
-	// Build email subject and body
-	subject := fmt.Sprintf("Task Execution Failed: %s", payload.Task.Name)
-	body := s.buildEmailBody(payload, project, executionTime)
+	incident, err := s.repo.ResolveActiveIncident(ctx, payload.Task.UUID, payload.Execution.UUID, resolvedAt)
+	flapping := s.handleFlapping(ctx, payload.Task, payload.Execution, resolvedAt)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return
+	}
+	if err != nil {
+		log.Printf("[AlertService] Failed to resolve incident of task %s after execution %s succeeded: %v", payload.Task.UUID, payload.Execution.UUID, err)
+		return
+	}
 
-	// Send email to all project users
-	msg := gmail.EmailMessage{
-		To:      recipients,
-		Subject: subject,
-		Body:    body,
+	log.Printf("[AlertService] Incident %s of task %s resolved by execution %s", incident.UUID, payload.Task.UUID, payload.Execution.UUID)
+	if flapping {
+		return
+	}
+	s.notifyIncident(ctx, incident, payload.Task)
+}
+
+// handleIncidentUpdated notifies the channels of an incident that was acknowledged or resolved by hand
+func (s *Service) handleIncidentUpdated(payload events.IncidentPayload) {
+	ctx := context.Background()
+	incident := payload.Incident
+
+	// The task decides where alerts are routed; incidents of deleted tasks are routed by what the incident recorded
+	task, err := s.repo.GetTaskByUUID(ctx, incident.TaskUUID)
+	if err != nil {
+		task = &models.Task{UUID: incident.TaskUUID, Name: incident.TaskName, ProjectID: incident.ProjectID, Severity: incident.Severity}
+	}
+	s.notifyIncident(ctx, incident, task)
+}
+
+// notifyIncident tells the task's channels that its incident was acknowledged or resolved. These notifications are
+// not throttled, since they follow the alert of the opened incident.
+func (s *Service) notifyIncident(ctx context.Context, incident *models.Incident, task *models.Task) {
+	if task.IsMuted(time.Now()) {
+		log.Printf("[AlertService] Task %s is muted until %s, skipping incident %s notification", task.UUID, task.MutedUntil.Format(time.RFC3339), incident.UUID)
+		return
+	}
+
+	project, err := s.repo.GetProjectByID(ctx, incident.ProjectID)
+	if err != nil {
+		log.Printf("[AlertService] Failed to get project %s: %v", incident.ProjectID.Hex(), err)
+		return
 	}
 
-	if err := s.gmailSender.Send(msg); err != nil {
-		log.Printf("[AlertService] Failed to send alert email for task %s: %v", payload.Task.UUID, err)
+	var event, subject, summary string
+	switch incident.Status {
+	case models.IncidentStatusAcknowledged:
+		event = eventIncidentAcknowledged
+		subject = fmt.Sprintf("Incident Acknowledged: %s", incident.TaskName)
+		summary = fmt.Sprintf("acknowledged by %s", incident.AcknowledgedBy)
+	case models.IncidentStatusResolved:
+		event = eventIncidentResolved
+		subject = fmt.Sprintf("Incident Resolved: %s", incident.TaskName)
+		summary = fmt.Sprintf("resolved by %s", incident.ResolvedBy)
+		if incident.ResolvedBy == "" {
+			summary = fmt.Sprintf("resolved by successful execution %s", incident.ResolvedExecutionUUID)
+		}
+	default:
 		return
 	}
 
-	log.Printf("[AlertService] Successfully sent alert email to %d recipients for failed task %s", len(recipients), payload.Task.UUID)
+	s.send(ctx, project, task, notification{
+		event:    event,
+		subject:  withSeverity(task.EffectiveSeverity(), subject),
+		text:     fmt.Sprintf("Incident %s of task %s in project %s was %s after %d failed executions", incident.UUID, incident.TaskName, project.Name, summary, incident.FailureCount),
+		htmlBody: buildIncidentEmailBody(project, incident, subject, summary),
+		project:  project,
+		task:     task,
+		incident: incident,
+	})
+}
+
+// send delivers the notification through every channel the task's alerts are routed to. Email channels with
+// digest_minutes collect opened incidents and failures into their digest and skip the other notifications.
+func (s *Service) send(ctx context.Context, project *models.Project, task *models.Task, n notification) {
+	n.onCall = project.CurrentOnCall(time.Now())
+	n.text += onCallLine(n.onCall)
+
+	// Every channel is tried; one failing does not keep the alert from the others
+	channels := notificationChannels(project, task)
+	if len(channels) == 0 {
+		log.Printf("[AlertService] Alert route of task %s has no channels, skipping alert", task.UUID)
+	}
+	for _, channel := range channels {
+		if channel.Type == models.NotificationChannelEmail && channel.DigestMinutes > 0 {
+			if n.event == eventIncidentOpened || n.event == eventExecutionFailed {
+				s.queueDigest(project, channel, n, time.Now())
+			}
+			continue
+		}
+		if err := s.deliver(ctx, channel, n); err != nil {
+			log.Printf("[AlertService] Failed to send %s alert for task %s via %s channel %q: %v", n.event, task.UUID, channel.Type, channel.Name, err)
+			continue
+		}
+		s.meter.RecordAlert(project.ID)
+		log.Printf("[AlertService] Sent %s alert for task %s via %s channel %q", n.event, task.UUID, channel.Type, channel.Name)
+	}
+}
+
+// onCallLine names the person on call in Slack and webhook texts, so responders know who to page
+func onCallLine(onCall *models.OnCallParticipant) string {
+	if onCall == nil {
+		return ""
+	}
+	if onCall.Phone != "" {
+		return fmt.Sprintf("\nOn call: %s <%s>, %s", onCall.Name, onCall.Email, onCall.Phone)
+	}
+	return fmt.Sprintf("\nOn call: %s <%s>", onCall.Name, onCall.Email)
+}
+
+// withSeverity prefixes the subject of alerts of urgent tasks with their severity, so they stand out in inboxes
+func withSeverity(severity models.TaskSeverity, subject string) string {
+	if severity.Rank() > models.TaskSeverityNormal.Rank() {
+		return fmt.Sprintf("[%s] %s", severity, subject)
+	}
+	return subject
+}
+
+// SendTestAlert sends a sample alert through a channel so its configuration can be verified without waiting for a
+// failure. A nil channel emails the project users, like projects without channels. Test alerts are sent at once,
+// also through digest channels, and are not metered.
+func (s *Service) SendTestAlert(ctx context.Context, project *models.Project, channel *models.NotificationChannel, requestedBy string) error {
+	target := defaultChannel
+	if channel != nil {
+		target = *channel
+	}
+
+	sentAt := time.Now().UTC().Format(time.RFC3339)
+	n := notification{
+		event:    eventTest,
+		subject:  fmt.Sprintf("Test alert: %s", project.Name),
+		text:     fmt.Sprintf("This is a test alert for project %s, sent by %s at %s. Failure alerts will be delivered here.", project.Name, requestedBy, sentAt),
+		htmlBody: s.buildTestEmailBody(project, requestedBy, sentAt),
+		project:  project,
+		onCall:   project.CurrentOnCall(time.Now()),
+	}
+	n.text += onCallLine(n.onCall)
+	if err := s.deliver(ctx, target, n); err != nil {
+		return err
+	}
+
+	log.Printf("[AlertService] Sent test alert for project %s via %s channel %q, requested by %s", project.Name, target.Type, target.Name, requestedBy)
+	return nil
+}
+
+// allowAlert reports whether an alert for the task may be sent now and records it if so
+func (s *Service) allowAlert(taskUUID string, throttle time.Duration, now time.Time) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if last, ok := s.lastAlerts[taskUUID]; ok && throttle > 0 && now.Sub(last) < throttle {
+		return false
+	}
+	s.lastAlerts[taskUUID] = now
+	return true
+}
+
+// buildTestEmailBody creates the HTML email body for a test alert
+func (s *Service) buildTestEmailBody(project *models.Project, requestedBy, sentAt string) string {
+	return fmt.Sprintf(`
+<!DOCTYPE html>
+<html>
+<head>
+	<style>
+		body { font-family: Arial, sans-serif; line-height: 1.6; color: #333; }
+		.container { max-width: 600px; margin: 0 auto; padding: 20px; }
+		.header { background-color: #0d6efd; color: white; padding: 20px; border-radius: 5px 5px 0 0; }
+		.content { background-color: #f8f9fa; padding: 20px; border: 1px solid #dee2e6; border-top: none; }
+		.footer { margin-top: 20px; padding-top: 20px; border-top: 1px solid #dee2e6; font-size: 12px; color: #6c757d; }
+	</style>
+</head>
+<body>
+	<div class="container">
+		<div class="header">
+			<h2 style="margin: 0;">Test Alert</h2>
+		</div>
+		<div class="content">
+			<p>This is a test alert for project <strong>%s</strong>, sent by %s at %s.</p>
+			<p>Failed task executions of this project will be reported to this address.</p>
+		</div>
+		<div class="footer">
+			<p>This is a test alert from Cron Observer. No action is required.</p>
+		</div>
+	</div>
+</body>
+</html>
+`,
+		html.EscapeString(project.Name),
+		html.EscapeString(requestedBy),
+		sentAt,
+	)
 }
 
 // buildEmailBody creates the HTML email body for the alert
-func (s *Service) buildEmailBody(payload events.ExecutionFailedPayload, project *models.Project, executionTime string) string {
+func (s *Service) buildEmailBody(payload events.ExecutionFailedPayload, project *models.Project, incident *models.Incident, executionTime string) string {
 	errorMsg := "No error message available"
 	if payload.Execution.Error != "" {
 		errorMsg = payload.Execution.Error
 	}
+	incidentUUID := "Not recorded"
+	if incident != nil {
+		incidentUUID = incident.UUID
+	}
 
 	html := fmt.Sprintf(`
 <!DOCTYPE html>
@@ -153,6 +458,10 @@ func (s *Service) buildEmailBody(payload events.ExecutionFailedPayload, project
 				<span class="label">Task UUID:</span>
 				<span class="value">%s</span>
 			</div>
+			<div class="detail-row">
+				<span class="label">Severity:</span>
+				<span class="value">%s</span>
+			</div>
 			<div class="detail-row">
 				<span class="label">Execution UUID:</span>
 				<span class="value">%s</span>
@@ -161,13 +470,17 @@ func (s *Service) buildEmailBody(payload events.ExecutionFailedPayload, project
 				<span class="label">Execution Time:</span>
 				<span class="value">%s</span>
 			</div>
+			<div class="detail-row">
+				<span class="label">Incident UUID:</span>
+				<span class="value">%s</span>
+			</div>
 			<div class="error-box">
 				<strong>Error Message:</strong><br>
 				%s
 			</div>
 		</div>
 		<div class="footer">
-			<p>This is an automated alert from Cron Observer. Please check the task execution logs for more details.</p>
+			<p>This is an automated alert from Cron Observer. Further failures of the task are added to the incident without another alert until it succeeds. Please check the task execution logs for more details.</p>
 		</div>
 	</div>
 </body>
@@ -176,10 +489,80 @@ func (s *Service) buildEmailBody(payload events.ExecutionFailedPayload, project
 		project.Name,
 		payload.Task.Name,
 		payload.Task.UUID,
+		payload.Task.EffectiveSeverity(),
 		payload.Execution.UUID,
 		executionTime,
+		incidentUUID,
 		errorMsg,
 	)
 
 	return html
 }
+
+// buildIncidentEmailBody creates the HTML email body for an incident that was acknowledged or resolved
+func buildIncidentEmailBody(project *models.Project, incident *models.Incident, headline, summary string) string {
+	color := "#0d6efd"
+	if incident.Status == models.IncidentStatusResolved {
+		color = "#198754"
+	}
+
+	return fmt.Sprintf(`
+<!DOCTYPE html>
+<html>
+<head>
+	<style>
+		body { font-family: Arial, sans-serif; line-height: 1.6; color: #333; }
+		.container { max-width: 600px; margin: 0 auto; padding: 20px; }
+		.header { background-color: %s; color: white; padding: 20px; border-radius: 5px 5px 0 0; }
+		.content { background-color: #f8f9fa; padding: 20px; border: 1px solid #dee2e6; border-top: none; }
+		.detail-row { margin: 10px 0; }
+		.label { font-weight: bold; color: #495057; }
+		.value { color: #212529; }
+		.footer { margin-top: 20px; padding-top: 20px; border-top: 1px solid #dee2e6; font-size: 12px; color: #6c757d; }
+	</style>
+</head>
+<body>
+	<div class="container">
+		<div class="header">
+			<h2 style="margin: 0;">%s</h2>
+		</div>
+		<div class="content">
+			<p>The incident was %s.</p>
+			<div class="detail-row">
+				<span class="label">Project:</span>
+				<span class="value">%s</span>
+			</div>
+			<div class="detail-row">
+				<span class="label">Task UUID:</span>
+				<span class="value">%s</span>
+			</div>
+			<div class="detail-row">
+				<span class="label">Incident UUID:</span>
+				<span class="value">%s</span>
+			</div>
+			<div class="detail-row">
+				<span class="label">Opened At:</span>
+				<span class="value">%s</span>
+			</div>
+			<div class="detail-row">
+				<span class="label">Failed Executions:</span>
+				<span class="value">%d</span>
+			</div>
+		</div>
+		<div class="footer">
+			<p>This is an automated alert from Cron Observer.</p>
+		</div>
+	</div>
+</body>
+</html>
+`,
+		color,
+		html.EscapeString(headline),
+		html.EscapeString(summary),
+		html.EscapeString(project.Name),
+		html.EscapeString(incident.TaskUUID),
+		html.EscapeString(incident.UUID),
+		incident.OpenedAt.UTC().Format(time.RFC3339),
+		incident.FailureCount,
+	)
+}
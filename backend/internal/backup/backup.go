@@ -0,0 +1,471 @@
+// Package backup dumps projects, task groups, tasks and optionally executions to a portable archive and loads
+// them back, for disaster recovery and cloning environments.
+//
+// An archive is a gzip-compressed stream of JSON lines. The first line is a Header; every following line is a
+// record holding one document in canonical MongoDB Extended JSON, so IDs and timestamps survive the round trip.
+// Records are ordered so that every document follows the documents it references: projects, task groups, tasks,
+// then executions.
+package backup
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/yourusername/cron-observer/backend/internal/models"
+	"github.com/yourusername/cron-observer/backend/internal/repositories"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// Format identifies backup archives in their header
+const Format = "cron-observer-backup"
+
+// FormatVersion is the archive version written by Backup. Restore rejects newer versions.
+const FormatVersion = 1
+
+// Record kinds
+const (
+	KindProject   = "project"
+	KindTaskGroup = "task_group"
+	KindTask      = "task"
+	KindExecution = "execution"
+)
+
+// maxProblems bounds how many integrity problems are collected before verification stops
+const maxProblems = 50
+
+// Header is the first line of an archive
+type Header struct {
+	Format            string    `json:"format"`
+	Version           int       `json:"version"`
+	CreatedAt         time.Time `json:"created_at"`
+	IncludeExecutions bool      `json:"include_executions"`
+}
+
+// record is one line of an archive after the header
+type record struct {
+	Kind     string      `bson:"kind"`
+	Document interface{} `bson:"document"`
+}
+
+// rawRecord is a record whose document is decoded once its kind is known
+type rawRecord struct {
+	Kind     string   `bson:"kind"`
+	Document bson.Raw `bson:"document"`
+}
+
+// Counts is how many documents of each kind an archive holds
+type Counts struct {
+	Projects   int `json:"projects"`
+	TaskGroups int `json:"task_groups"`
+	Tasks      int `json:"tasks"`
+	Executions int `json:"executions"`
+}
+
+func (c Counts) String() string {
+	return fmt.Sprintf("%d projects, %d task groups, %d tasks, %d executions", c.Projects, c.TaskGroups, c.Tasks, c.Executions)
+}
+
+// Options selects what Backup writes
+type Options struct {
+	ProjectIDs        []primitive.ObjectID // Projects to back up; all projects when empty
+	IncludeExecutions bool                 // Executions with their logs can make up most of an archive
+}
+
+// IntegrityError lists the documents of an archive that reference documents missing from it
+type IntegrityError struct {
+	Problems []string
+}
+
+func (e *IntegrityError) Error() string {
+	return fmt.Sprintf("archive failed integrity checks:\n  %s", strings.Join(e.Problems, "\n  "))
+}
+
+// Backup writes the selected projects with their task groups, tasks and optionally executions to w. Projects and
+// tasks being deleted are skipped.
+func Backup(ctx context.Context, repo repositories.Repository, w io.Writer, opts Options) (Counts, error) {
+	var counts Counts
+
+	projects, err := selectProjects(ctx, repo, opts.ProjectIDs)
+	if err != nil {
+		return counts, err
+	}
+
+	gz := gzip.NewWriter(w)
+	enc := &encoder{w: bufio.NewWriter(gz)}
+
+	if err := enc.header(Header{Format: Format, Version: FormatVersion, CreatedAt: time.Now().UTC(), IncludeExecutions: opts.IncludeExecutions}); err != nil {
+		return counts, err
+	}
+
+	// Collect every project's groups and tasks first, so the archive lists all groups before any task
+	var taskGroups []*models.TaskGroup
+	var tasks []*models.Task
+	for _, project := range projects {
+		if err := enc.record(KindProject, project); err != nil {
+			return counts, err
+		}
+		counts.Projects++
+
+		groups, err := repo.GetTaskGroupsByProjectID(ctx, project.ID)
+		if err != nil {
+			return counts, fmt.Errorf("failed to get task groups of project %s: %w", project.ID.Hex(), err)
+		}
+		taskGroups = append(taskGroups, groups...)
+
+		projectTasks, err := repo.GetTasksByProjectID(ctx, project.ID)
+		if err != nil {
+			return counts, fmt.Errorf("failed to get tasks of project %s: %w", project.ID.Hex(), err)
+		}
+		tasks = append(tasks, projectTasks...)
+	}
+
+	for _, group := range taskGroups {
+		if err := enc.record(KindTaskGroup, group); err != nil {
+			return counts, err
+		}
+		counts.TaskGroups++
+	}
+	for _, task := range tasks {
+		if err := enc.record(KindTask, task); err != nil {
+			return counts, err
+		}
+		counts.Tasks++
+	}
+
+	if opts.IncludeExecutions {
+		for _, task := range tasks {
+			executions, err := repo.GetExecutionsByTaskUUID(ctx, task.UUID, nil, nil)
+			if err != nil {
+				return counts, fmt.Errorf("failed to get executions of task %s: %w", task.UUID, err)
+			}
+			for _, execution := range executions {
+				if err := enc.record(KindExecution, execution); err != nil {
+					return counts, err
+				}
+				counts.Executions++
+			}
+		}
+	}
+
+	if err := enc.w.Flush(); err != nil {
+		return counts, err
+	}
+	return counts, gz.Close()
+}
+
+func selectProjects(ctx context.Context, repo repositories.Repository, projectIDs []primitive.ObjectID) ([]*models.Project, error) {
+	if len(projectIDs) == 0 {
+		projects, err := repo.GetAllProjects(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get projects: %w", err)
+		}
+		return projects, nil
+	}
+
+	projects := make([]*models.Project, 0, len(projectIDs))
+	for _, projectID := range projectIDs {
+		project, err := repo.GetProjectByID(ctx, projectID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get project %s: %w", projectID.Hex(), err)
+		}
+		projects = append(projects, project)
+	}
+	return projects, nil
+}
+
+type encoder struct {
+	w *bufio.Writer
+}
+
+func (e *encoder) header(header Header) error {
+	line, err := json.Marshal(header)
+	if err != nil {
+		return err
+	}
+	return e.line(line)
+}
+
+func (e *encoder) record(kind string, document interface{}) error {
+	line, err := bson.MarshalExtJSON(record{Kind: kind, Document: document}, true, false)
+	if err != nil {
+		return fmt.Errorf("failed to encode %s: %w", kind, err)
+	}
+	return e.line(line)
+}
+
+func (e *encoder) line(line []byte) error {
+	if _, err := e.w.Write(line); err != nil {
+		return err
+	}
+	return e.w.WriteByte('\n')
+}
+
+// decoder reads an archive record by record
+type decoder struct {
+	r      *bufio.Reader
+	header Header
+	line   int
+}
+
+func newDecoder(r io.Reader) (*decoder, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("not a backup archive: %w", err)
+	}
+	d := &decoder{r: bufio.NewReader(gz)}
+
+	line, err := d.next()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read archive header: %w", err)
+	}
+	if err := json.Unmarshal(line, &d.header); err != nil || d.header.Format != Format {
+		return nil, errors.New("not a backup archive: missing header")
+	}
+	if d.header.Version > FormatVersion {
+		return nil, fmt.Errorf("archive version %d is newer than the supported version %d", d.header.Version, FormatVersion)
+	}
+	return d, nil
+}
+
+func (d *decoder) next() ([]byte, error) {
+	line, err := d.r.ReadBytes('\n')
+	if err == io.EOF && len(line) > 0 {
+		err = nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	d.line++
+	return line, nil
+}
+
+// decoded is one document of an archive; exactly one field is set
+type decoded struct {
+	project   *models.Project
+	taskGroup *models.TaskGroup
+	task      *models.Task
+	execution *models.Execution
+}
+
+// decode returns the next document, or io.EOF at the end of the archive
+func (d *decoder) decode() (decoded, error) {
+	line, err := d.next()
+	if err != nil {
+		return decoded{}, err
+	}
+
+	var raw rawRecord
+	if err := bson.UnmarshalExtJSON(line, true, &raw); err != nil {
+		return decoded{}, fmt.Errorf("line %d: %w", d.line, err)
+	}
+
+	var doc decoded
+	switch raw.Kind {
+	case KindProject:
+		doc.project = &models.Project{}
+		err = bson.Unmarshal(raw.Document, doc.project)
+	case KindTaskGroup:
+		doc.taskGroup = &models.TaskGroup{}
+		err = bson.Unmarshal(raw.Document, doc.taskGroup)
+	case KindTask:
+		doc.task = &models.Task{}
+		err = bson.Unmarshal(raw.Document, doc.task)
+	case KindExecution:
+		doc.execution = &models.Execution{}
+		err = bson.Unmarshal(raw.Document, doc.execution)
+	default:
+		err = fmt.Errorf("unknown record kind %q", raw.Kind)
+	}
+	if err != nil {
+		return decoded{}, fmt.Errorf("line %d: %w", d.line, err)
+	}
+	return doc, nil
+}
+
+// references tracks the documents seen so far, to check that later documents only reference earlier ones
+type references struct {
+	projects   map[primitive.ObjectID]bool
+	taskGroups map[primitive.ObjectID]*models.TaskGroup
+	tasks      map[string]*models.Task
+	executions map[string]bool
+	problems   []string
+}
+
+func newReferences() *references {
+	return &references{
+		projects:   make(map[primitive.ObjectID]bool),
+		taskGroups: make(map[primitive.ObjectID]*models.TaskGroup),
+		tasks:      make(map[string]*models.Task),
+		executions: make(map[string]bool),
+	}
+}
+
+func (r *references) problem(format string, args ...interface{}) {
+	if len(r.problems) < maxProblems {
+		r.problems = append(r.problems, fmt.Sprintf(format, args...))
+	}
+}
+
+// check records doc and any references it makes to documents missing from the archive
+func (r *references) check(doc decoded) {
+	switch {
+	case doc.project != nil:
+		if r.projects[doc.project.ID] {
+			r.problem("project %s appears twice", doc.project.ID.Hex())
+		}
+		r.projects[doc.project.ID] = true
+
+	case doc.taskGroup != nil:
+		group := doc.taskGroup
+		if !r.projects[group.ProjectID] {
+			r.problem("task group %s references missing project %s", group.UUID, group.ProjectID.Hex())
+		}
+		if r.taskGroups[group.ID] != nil {
+			r.problem("task group %s appears twice", group.UUID)
+		}
+		r.taskGroups[group.ID] = group
+
+	case doc.task != nil:
+		task := doc.task
+		if !r.projects[task.ProjectID] {
+			r.problem("task %s references missing project %s", task.UUID, task.ProjectID.Hex())
+		}
+		if task.TaskGroupID != nil {
+			group := r.taskGroups[*task.TaskGroupID]
+			if group == nil {
+				r.problem("task %s references missing task group %s", task.UUID, task.TaskGroupID.Hex())
+			} else if group.ProjectID != task.ProjectID {
+				r.problem("task %s belongs to a different project than its task group %s", task.UUID, group.UUID)
+			}
+		}
+		if r.tasks[task.UUID] != nil {
+			r.problem("task %s appears twice", task.UUID)
+		}
+		r.tasks[task.UUID] = task
+
+	case doc.execution != nil:
+		execution := doc.execution
+		task := r.tasks[execution.TaskUUID]
+		if task == nil {
+			r.problem("execution %s references missing task %s", execution.UUID, execution.TaskUUID)
+		} else if execution.TaskID != task.ID {
+			r.problem("execution %s has a task_id that does not match task %s", execution.UUID, task.UUID)
+		}
+		if r.executions[execution.UUID] {
+			r.problem("execution %s appears twice", execution.UUID)
+		}
+		r.executions[execution.UUID] = true
+	}
+}
+
+func (r *references) err() error {
+	if len(r.problems) == 0 {
+		return nil
+	}
+	return &IntegrityError{Problems: r.problems}
+}
+
+// Verify reads a whole archive and checks that every document only references documents stored before it.
+// It returns the archive's header and counts; integrity problems are reported as an *IntegrityError.
+func Verify(r io.Reader) (Header, Counts, error) {
+	var counts Counts
+	d, err := newDecoder(r)
+	if err != nil {
+		return Header{}, counts, err
+	}
+
+	refs := newReferences()
+	for {
+		doc, err := d.decode()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return d.header, counts, err
+		}
+		refs.check(doc)
+		counts.add(doc)
+	}
+	return d.header, counts, refs.err()
+}
+
+func (c *Counts) add(doc decoded) {
+	switch {
+	case doc.project != nil:
+		c.Projects++
+	case doc.taskGroup != nil:
+		c.TaskGroups++
+	case doc.task != nil:
+		c.Tasks++
+	case doc.execution != nil:
+		c.Executions++
+	}
+}
+
+// Restore loads an archive into repo, keeping the IDs it holds. Archives should be checked with Verify first:
+// Restore stops at the first integrity problem, leaving what was restored before it. A project that already
+// exists in repo is never overwritten; Restore fails before writing anything of it.
+func Restore(ctx context.Context, repo repositories.Repository, r io.Reader) (Counts, error) {
+	var counts Counts
+	d, err := newDecoder(r)
+	if err != nil {
+		return counts, err
+	}
+
+	refs := newReferences()
+	for {
+		doc, err := d.decode()
+		if err == io.EOF {
+			return counts, nil
+		}
+		if err != nil {
+			return counts, err
+		}
+		if refs.check(doc); refs.err() != nil {
+			return counts, refs.err()
+		}
+
+		if err := restoreDocument(ctx, repo, doc); err != nil {
+			return counts, err
+		}
+		counts.add(doc)
+	}
+}
+
+func restoreDocument(ctx context.Context, repo repositories.Repository, doc decoded) error {
+	switch {
+	case doc.project != nil:
+		_, err := repo.GetProjectByID(ctx, doc.project.ID)
+		if err == nil {
+			return fmt.Errorf("project %s (%s) already exists", doc.project.ID.Hex(), doc.project.Name)
+		}
+		if err != mongo.ErrNoDocuments {
+			return fmt.Errorf("failed to check project %s: %w", doc.project.ID.Hex(), err)
+		}
+		if err := repo.CreateProject(ctx, doc.project); err != nil {
+			return fmt.Errorf("failed to restore project %s: %w", doc.project.ID.Hex(), err)
+		}
+	case doc.taskGroup != nil:
+		if err := repo.CreateTaskGroup(ctx, doc.taskGroup.ProjectID.Hex(), doc.taskGroup); err != nil {
+			return fmt.Errorf("failed to restore task group %s: %w", doc.taskGroup.UUID, err)
+		}
+	case doc.task != nil:
+		if err := repo.CreateTask(ctx, doc.task.ProjectID.Hex(), doc.task); err != nil {
+			return fmt.Errorf("failed to restore task %s: %w", doc.task.UUID, err)
+		}
+	case doc.execution != nil:
+		if err := repo.CreateExecution(ctx, doc.execution); err != nil {
+			return fmt.Errorf("failed to restore execution %s: %w", doc.execution.UUID, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,137 @@
+package backup
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/yourusername/cron-observer/backend/internal/models"
+	"github.com/yourusername/cron-observer/backend/internal/repositories"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// seed stores a project with a group, a grouped task and two executions
+func seed(t *testing.T, repo repositories.Repository) (*models.Project, *models.Task) {
+	t.Helper()
+	ctx := context.Background()
+	now := time.Now().UTC().Truncate(time.Millisecond)
+
+	project := &models.Project{ID: primitive.NewObjectID(), UUID: "project-1", Name: "Billing", APIKey: "key-1", CreatedAt: now}
+	group := &models.TaskGroup{ID: primitive.NewObjectID(), UUID: "group-1", ProjectID: project.ID, Name: "nightly"}
+	task := &models.Task{ID: primitive.NewObjectID(), UUID: "task-1", ProjectID: project.ID, TaskGroupID: &group.ID, Name: "Invoice run", CreatedAt: now}
+	if err := repo.CreateProject(ctx, project); err != nil {
+		t.Fatalf("CreateProject: %v", err)
+	}
+	if err := repo.CreateTaskGroup(ctx, project.ID.Hex(), group); err != nil {
+		t.Fatalf("CreateTaskGroup: %v", err)
+	}
+	if err := repo.CreateTask(ctx, project.ID.Hex(), task); err != nil {
+		t.Fatalf("CreateTask: %v", err)
+	}
+	for i, uuid := range []string{"execution-1", "execution-2"} {
+		execution := &models.Execution{UUID: uuid, TaskID: task.ID, TaskUUID: task.UUID, Status: models.ExecutionStatusSuccess,
+			StartedAt: now.Add(time.Duration(i) * time.Minute), Logs: []models.LogEntry{{Message: "done", Level: "info", Timestamp: now}}}
+		if err := repo.CreateExecution(ctx, execution); err != nil {
+			t.Fatalf("CreateExecution: %v", err)
+		}
+	}
+	return project, task
+}
+
+func TestBackupAndRestore_RoundTrip(t *testing.T) {
+	ctx := context.Background()
+	source := repositories.NewMemoryRepository()
+	project, task := seed(t, source)
+
+	var archive bytes.Buffer
+	counts, err := Backup(ctx, source, &archive, Options{IncludeExecutions: true})
+	if err != nil {
+		t.Fatalf("Backup: %v", err)
+	}
+	want := Counts{Projects: 1, TaskGroups: 1, Tasks: 1, Executions: 2}
+	if counts != want {
+		t.Fatalf("backed up %v, want %v", counts, want)
+	}
+
+	header, verified, err := Verify(bytes.NewReader(archive.Bytes()))
+	if err != nil || verified != want || !header.IncludeExecutions {
+		t.Fatalf("Verify = %+v, %v, %v", header, verified, err)
+	}
+
+	target := repositories.NewMemoryRepository()
+	restored, err := Restore(ctx, target, bytes.NewReader(archive.Bytes()))
+	if err != nil || restored != want {
+		t.Fatalf("Restore = %v, %v", restored, err)
+	}
+
+	restoredProject, err := target.GetProjectByID(ctx, project.ID)
+	if err != nil || restoredProject.APIKey != "key-1" || !restoredProject.CreatedAt.Equal(project.CreatedAt) {
+		t.Fatalf("restored project = %+v, %v", restoredProject, err)
+	}
+	restoredTask, err := target.GetTaskByUUID(ctx, task.UUID)
+	if err != nil || restoredTask.TaskGroupID == nil || *restoredTask.TaskGroupID != *task.TaskGroupID {
+		t.Fatalf("restored task = %+v, %v", restoredTask, err)
+	}
+	executions, _ := target.GetExecutionsByTaskUUID(ctx, task.UUID, nil, nil)
+	if len(executions) != 2 || len(executions[0].Logs) != 1 {
+		t.Fatalf("restored executions = %+v", executions)
+	}
+}
+
+func TestBackup_WithoutExecutions(t *testing.T) {
+	source := repositories.NewMemoryRepository()
+	seed(t, source)
+
+	var archive bytes.Buffer
+	counts, err := Backup(context.Background(), source, &archive, Options{})
+	if err != nil || counts.Executions != 0 || counts.Tasks != 1 {
+		t.Fatalf("Backup = %v, %v", counts, err)
+	}
+}
+
+func TestRestore_RefusesToOverwriteAProject(t *testing.T) {
+	ctx := context.Background()
+	source := repositories.NewMemoryRepository()
+	seed(t, source)
+
+	var archive bytes.Buffer
+	if _, err := Backup(ctx, source, &archive, Options{}); err != nil {
+		t.Fatalf("Backup: %v", err)
+	}
+
+	restored, err := Restore(ctx, source, &archive)
+	if err == nil || !strings.Contains(err.Error(), "already exists") || restored.Projects != 0 {
+		t.Fatalf("Restore into the source = %v, %v, want an already exists error", restored, err)
+	}
+}
+
+func TestVerify_ReportsMissingReferences(t *testing.T) {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	enc := &encoder{w: bufio.NewWriter(gz)}
+	projectID := primitive.NewObjectID()
+	missingGroup := primitive.NewObjectID()
+	enc.header(Header{Format: Format, Version: FormatVersion})
+	enc.record(KindProject, &models.Project{ID: projectID, Name: "Billing"})
+	enc.record(KindTask, &models.Task{ID: primitive.NewObjectID(), UUID: "task-1", ProjectID: projectID, TaskGroupID: &missingGroup})
+	enc.record(KindExecution, &models.Execution{UUID: "execution-1", TaskUUID: "task-2"})
+	enc.w.Flush()
+	gz.Close()
+
+	_, _, err := Verify(&buf)
+	var integrityErr *IntegrityError
+	if !errors.As(err, &integrityErr) || len(integrityErr.Problems) != 2 {
+		t.Fatalf("Verify error = %v, want two integrity problems", err)
+	}
+}
+
+func TestVerify_RejectsOtherFiles(t *testing.T) {
+	if _, _, err := Verify(strings.NewReader("not gzip")); err == nil {
+		t.Fatal("expected an error for a file that is not an archive")
+	}
+}
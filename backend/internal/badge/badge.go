@@ -0,0 +1,60 @@
+// Package badge renders shields.io-style flat SVG status badges
+package badge
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"unicode/utf8"
+)
+
+// Badge colors, matching the shields.io palette
+const (
+	ColorGreen = "#4c1"
+	ColorRed   = "#e05d44"
+	ColorAmber = "#dfb317"
+	ColorBlue  = "#007ec6"
+	ColorGrey  = "#9f9f9f"
+)
+
+const (
+	charWidth   = 7  // Approximate width of a Verdana 11px character
+	sidePadding = 10 // Horizontal padding on each side of a text section
+	maxLabelLen = 40
+)
+
+// Render returns an SVG badge showing label on a grey section and message on a section of the given color
+func Render(label, message, color string) []byte {
+	if utf8.RuneCountInString(label) > maxLabelLen {
+		label = string([]rune(label)[:maxLabelLen-1]) + "…"
+	}
+
+	labelWidth := textWidth(label)
+	messageWidth := textWidth(message)
+	width := labelWidth + messageWidth
+	label = html.EscapeString(label)
+	message = html.EscapeString(message)
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="20" role="img" aria-label="%s: %s">`, width, label, message)
+	fmt.Fprintf(&buf, `<title>%s: %s</title>`, label, message)
+	buf.WriteString(`<linearGradient id="s" x2="0" y2="100%"><stop offset="0" stop-color="#bbb" stop-opacity=".1"/><stop offset="1" stop-opacity=".1"/></linearGradient>`)
+	fmt.Fprintf(&buf, `<clipPath id="r"><rect width="%d" height="20" rx="3" fill="#fff"/></clipPath>`, width)
+	fmt.Fprintf(&buf, `<g clip-path="url(#r)"><rect width="%d" height="20" fill="#555"/><rect x="%d" width="%d" height="20" fill="%s"/><rect width="%d" height="20" fill="url(#s)"/></g>`,
+		labelWidth, labelWidth, messageWidth, color, width)
+	buf.WriteString(`<g fill="#fff" text-anchor="middle" font-family="Verdana,Geneva,DejaVu Sans,sans-serif" font-size="11">`)
+	writeText(&buf, labelWidth/2, label)
+	writeText(&buf, labelWidth+messageWidth/2, message)
+	buf.WriteString(`</g></svg>`)
+	return buf.Bytes()
+}
+
+// textWidth estimates the rendered width of a section holding text
+func textWidth(text string) int {
+	return utf8.RuneCountInString(text)*charWidth + 2*sidePadding
+}
+
+// writeText writes escaped text centered at x, with a drop shadow
+func writeText(buf *bytes.Buffer, x int, text string) {
+	fmt.Fprintf(buf, `<text x="%d" y="15" fill="#010101" fill-opacity=".3">%s</text><text x="%d" y="14">%s</text>`, x, text, x, text)
+}
@@ -0,0 +1,32 @@
+package badge
+
+import (
+	"encoding/xml"
+	"strings"
+	"testing"
+)
+
+func TestRender_ProducesValidSVG(t *testing.T) {
+	svg := string(Render(`Backup <prod> & "more"`, "passing", ColorGreen))
+
+	if err := xml.Unmarshal([]byte(svg), new(struct{})); err != nil {
+		t.Fatalf("Expected well-formed XML, got %v: %s", err, svg)
+	}
+	if !strings.Contains(svg, "Backup &lt;prod&gt; &amp; &#34;more&#34;") {
+		t.Errorf("Expected the label to be escaped, got %s", svg)
+	}
+	if !strings.Contains(svg, `fill="#4c1"`) || !strings.Contains(svg, ">passing</text>") {
+		t.Errorf("Expected a green passing section, got %s", svg)
+	}
+}
+
+func TestRender_TruncatesLongLabels(t *testing.T) {
+	svg := string(Render(strings.Repeat("x", 100), "failing", ColorRed))
+
+	if strings.Contains(svg, strings.Repeat("x", maxLabelLen)) {
+		t.Errorf("Expected the label to be truncated to %d characters", maxLabelLen)
+	}
+	if !strings.Contains(svg, strings.Repeat("x", maxLabelLen-1)+"…") {
+		t.Errorf("Expected an ellipsis after the truncated label, got %s", svg)
+	}
+}
@@ -0,0 +1,425 @@
+package cache
+
+import (
+	"context"
+	"time"
+
+	"github.com/yourusername/cron-observer/backend/internal/models"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Methods that neither read nor change a cached document go straight to the wrapped repository
+
+// Projects
+
+func (r *Repository) GetAllProjects(ctx context.Context) ([]*models.Project, error) {
+	return r.next.GetAllProjects(ctx)
+}
+
+func (r *Repository) GetProjectByName(ctx context.Context, name string) (*models.Project, error) {
+	return r.next.GetProjectByName(ctx, name)
+}
+
+func (r *Repository) GetUserProjects(ctx context.Context, email string) ([]*models.Project, error) {
+	return r.next.GetUserProjects(ctx, email)
+}
+
+func (r *Repository) CreateProject(ctx context.Context, project *models.Project) error {
+	return r.next.CreateProject(ctx, project)
+}
+
+// Organizations
+
+func (r *Repository) CreateOrganization(ctx context.Context, organization *models.Organization) error {
+	return r.next.CreateOrganization(ctx, organization)
+}
+
+func (r *Repository) GetOrganizationByID(ctx context.Context, organizationID primitive.ObjectID) (*models.Organization, error) {
+	return r.next.GetOrganizationByID(ctx, organizationID)
+}
+
+func (r *Repository) GetAllOrganizations(ctx context.Context) ([]*models.Organization, error) {
+	return r.next.GetAllOrganizations(ctx)
+}
+
+func (r *Repository) GetUserOrganizations(ctx context.Context, email string) ([]*models.Organization, error) {
+	return r.next.GetUserOrganizations(ctx, email)
+}
+
+func (r *Repository) UpdateOrganization(ctx context.Context, organizationID primitive.ObjectID, organization *models.Organization) error {
+	return r.next.UpdateOrganization(ctx, organizationID, organization)
+}
+
+func (r *Repository) DeleteOrganization(ctx context.Context, organizationID primitive.ObjectID) error {
+	return r.next.DeleteOrganization(ctx, organizationID)
+}
+
+func (r *Repository) GetProjectsByOrganizationID(ctx context.Context, organizationID primitive.ObjectID) ([]*models.Project, error) {
+	return r.next.GetProjectsByOrganizationID(ctx, organizationID)
+}
+
+func (r *Repository) SetOrganizationQuotas(ctx context.Context, organizationID primitive.ObjectID, quotas *models.ProjectQuotas) error {
+	return r.next.SetOrganizationQuotas(ctx, organizationID, quotas)
+}
+
+// Invitations
+
+func (r *Repository) CreateInvitation(ctx context.Context, invitation *models.Invitation) error {
+	return r.next.CreateInvitation(ctx, invitation)
+}
+
+func (r *Repository) GetInvitationByUUID(ctx context.Context, invitationUUID string) (*models.Invitation, error) {
+	return r.next.GetInvitationByUUID(ctx, invitationUUID)
+}
+
+func (r *Repository) GetInvitationsByProjectID(ctx context.Context, projectID primitive.ObjectID, status models.InvitationStatus) ([]*models.Invitation, error) {
+	return r.next.GetInvitationsByProjectID(ctx, projectID, status)
+}
+
+func (r *Repository) UpdateInvitationStatus(ctx context.Context, invitationUUID string, status models.InvitationStatus) error {
+	return r.next.UpdateInvitationStatus(ctx, invitationUUID, status)
+}
+
+func (r *Repository) AcceptInvitation(ctx context.Context, invitationUUID, email string, at time.Time) (bool, error) {
+	return r.next.AcceptInvitation(ctx, invitationUUID, email, at)
+}
+
+// Secrets
+
+func (r *Repository) UpsertSecret(ctx context.Context, secret *models.Secret) error {
+	return r.next.UpsertSecret(ctx, secret)
+}
+
+func (r *Repository) GetSecretByName(ctx context.Context, projectID primitive.ObjectID, name string) (*models.Secret, error) {
+	return r.next.GetSecretByName(ctx, projectID, name)
+}
+
+func (r *Repository) GetSecretsByProjectID(ctx context.Context, projectID primitive.ObjectID) ([]*models.Secret, error) {
+	return r.next.GetSecretsByProjectID(ctx, projectID)
+}
+
+func (r *Repository) DeleteSecret(ctx context.Context, projectID primitive.ObjectID, name string) error {
+	return r.next.DeleteSecret(ctx, projectID, name)
+}
+
+// Project settings
+
+func (r *Repository) GetProjectSettingsWithRetention(ctx context.Context) ([]*models.ProjectSettings, error) {
+	return r.next.GetProjectSettingsWithRetention(ctx)
+}
+
+// Task templates
+
+func (r *Repository) CreateTaskTemplate(ctx context.Context, template *models.TaskTemplate) error {
+	return r.next.CreateTaskTemplate(ctx, template)
+}
+
+func (r *Repository) GetTaskTemplatesByProjectID(ctx context.Context, projectID primitive.ObjectID) ([]*models.TaskTemplate, error) {
+	return r.next.GetTaskTemplatesByProjectID(ctx, projectID)
+}
+
+func (r *Repository) GetTaskTemplateByUUID(ctx context.Context, projectID primitive.ObjectID, templateUUID string) (*models.TaskTemplate, error) {
+	return r.next.GetTaskTemplateByUUID(ctx, projectID, templateUUID)
+}
+
+func (r *Repository) DeleteTaskTemplate(ctx context.Context, projectID primitive.ObjectID, templateUUID string) error {
+	return r.next.DeleteTaskTemplate(ctx, projectID, templateUUID)
+}
+
+func (r *Repository) DeleteTaskTemplatesByProjectID(ctx context.Context, projectID primitive.ObjectID) error {
+	return r.next.DeleteTaskTemplatesByProjectID(ctx, projectID)
+}
+
+// Token revocations
+
+func (r *Repository) CreateTokenRevocation(ctx context.Context, revocation *models.TokenRevocation) error {
+	return r.next.CreateTokenRevocation(ctx, revocation)
+}
+
+func (r *Repository) IsTokenRevoked(ctx context.Context, jti string, email string, issuedAt time.Time) (bool, error) {
+	return r.next.IsTokenRevoked(ctx, jti, email, issuedAt)
+}
+
+// Tasks
+
+func (r *Repository) CreateTask(ctx context.Context, projectID string, task *models.Task) error {
+	return r.next.CreateTask(ctx, projectID, task)
+}
+
+func (r *Repository) GetAllActiveTasks(ctx context.Context) ([]*models.Task, error) {
+	return r.next.GetAllActiveTasks(ctx)
+}
+
+func (r *Repository) GetTasksByStatus(ctx context.Context, statuses []models.TaskStatus) ([]*models.Task, error) {
+	return r.next.GetTasksByStatus(ctx, statuses)
+}
+
+func (r *Repository) GetTasksByProjectID(ctx context.Context, projectID primitive.ObjectID) ([]*models.Task, error) {
+	return r.next.GetTasksByProjectID(ctx, projectID)
+}
+
+func (r *Repository) GetTaskBatchByProjectID(ctx context.Context, projectID primitive.ObjectID, limit int) ([]*models.Task, error) {
+	return r.next.GetTaskBatchByProjectID(ctx, projectID, limit)
+}
+
+func (r *Repository) ListTasksByProjectID(ctx context.Context, projectID primitive.ObjectID, filter models.TaskListFilter, page, pageSize int) ([]*models.Task, int64, error) {
+	return r.next.ListTasksByProjectID(ctx, projectID, filter, page, pageSize)
+}
+
+// Task groups
+
+func (r *Repository) CreateTaskGroup(ctx context.Context, projectID string, taskGroup *models.TaskGroup) error {
+	return r.next.CreateTaskGroup(ctx, projectID, taskGroup)
+}
+
+func (r *Repository) GetTaskGroupsByProjectID(ctx context.Context, projectID primitive.ObjectID) ([]*models.TaskGroup, error) {
+	return r.next.GetTaskGroupsByProjectID(ctx, projectID)
+}
+
+func (r *Repository) GetTaskGroupByUUID(ctx context.Context, taskGroupUUID string) (*models.TaskGroup, error) {
+	return r.next.GetTaskGroupByUUID(ctx, taskGroupUUID)
+}
+
+func (r *Repository) GetTaskGroupByID(ctx context.Context, taskGroupID primitive.ObjectID) (*models.TaskGroup, error) {
+	return r.next.GetTaskGroupByID(ctx, taskGroupID)
+}
+
+func (r *Repository) UpdateTaskGroup(ctx context.Context, taskGroupUUID string, taskGroup *models.TaskGroup) error {
+	return r.next.UpdateTaskGroup(ctx, taskGroupUUID, taskGroup)
+}
+
+func (r *Repository) UpdateTaskGroupStatus(ctx context.Context, taskGroupUUID string, status models.TaskGroupStatus) error {
+	return r.next.UpdateTaskGroupStatus(ctx, taskGroupUUID, status)
+}
+
+func (r *Repository) UpdateTaskGroupState(ctx context.Context, taskGroupUUID string, state models.TaskGroupState) error {
+	return r.next.UpdateTaskGroupState(ctx, taskGroupUUID, state)
+}
+
+func (r *Repository) DeleteTaskGroup(ctx context.Context, taskGroupUUID string) error {
+	return r.next.DeleteTaskGroup(ctx, taskGroupUUID)
+}
+
+func (r *Repository) GetTasksByGroupID(ctx context.Context, taskGroupID primitive.ObjectID) ([]*models.Task, error) {
+	return r.next.GetTasksByGroupID(ctx, taskGroupID)
+}
+
+func (r *Repository) GetActiveTaskGroupsWithWindows(ctx context.Context) ([]*models.TaskGroup, error) {
+	return r.next.GetActiveTaskGroupsWithWindows(ctx)
+}
+
+// Executions
+
+func (r *Repository) CreateExecution(ctx context.Context, execution *models.Execution) error {
+	return r.next.CreateExecution(ctx, execution)
+}
+
+func (r *Repository) GetExecutionsByTaskUUID(ctx context.Context, taskUUID string, startDate, endDate *time.Time) ([]*models.Execution, error) {
+	return r.next.GetExecutionsByTaskUUID(ctx, taskUUID, startDate, endDate)
+}
+
+func (r *Repository) GetExecutionsByTaskUUIDPaginated(ctx context.Context, taskUUID string, startDate, endDate *time.Time, page, pageSize int) ([]*models.Execution, int64, error) {
+	return r.next.GetExecutionsByTaskUUIDPaginated(ctx, taskUUID, startDate, endDate, page, pageSize)
+}
+
+func (r *Repository) AppendLogToExecution(ctx context.Context, executionUUID string, logEntry models.LogEntry) error {
+	return r.next.AppendLogToExecution(ctx, executionUUID, logEntry)
+}
+
+func (r *Repository) UpdateExecutionStatus(ctx context.Context, executionUUID string, status models.ExecutionStatus, errorMessage *string) error {
+	return r.next.UpdateExecutionStatus(ctx, executionUUID, status, errorMessage)
+}
+
+func (r *Repository) RecordExecutionEndpoint(ctx context.Context, executionUUID string, endpoint string) error {
+	return r.next.RecordExecutionEndpoint(ctx, executionUUID, endpoint)
+}
+
+func (r *Repository) FailExecutionIfUnfinished(ctx context.Context, executionUUID string, errorMessage string) (bool, error) {
+	return r.next.FailExecutionIfUnfinished(ctx, executionUUID, errorMessage)
+}
+
+func (r *Repository) StartQueuedExecution(ctx context.Context, executionUUID string, startedAt time.Time) (bool, error) {
+	return r.next.StartQueuedExecution(ctx, executionUUID, startedAt)
+}
+
+func (r *Repository) CountActiveExecutions(ctx context.Context, taskUUIDs []string, since time.Time) (int64, error) {
+	return r.next.CountActiveExecutions(ctx, taskUUIDs, since)
+}
+
+func (r *Repository) RecordExecutionHeartbeat(ctx context.Context, executionUUID string, at time.Time) (bool, error) {
+	return r.next.RecordExecutionHeartbeat(ctx, executionUUID, at)
+}
+
+func (r *Repository) GetExecutionByUUID(ctx context.Context, executionUUID string) (*models.Execution, error) {
+	return r.next.GetExecutionByUUID(ctx, executionUUID)
+}
+
+func (r *Repository) GetLatestExecutionByTaskUUID(ctx context.Context, taskUUID string) (*models.Execution, error) {
+	return r.next.GetLatestExecutionByTaskUUID(ctx, taskUUID)
+}
+
+func (r *Repository) GetLatestExecutionsByTaskUUIDs(ctx context.Context, taskUUIDs []string) (map[string]*models.Execution, error) {
+	return r.next.GetLatestExecutionsByTaskUUIDs(ctx, taskUUIDs)
+}
+
+func (r *Repository) GetLastSuccessByTaskUUIDs(ctx context.Context, taskUUIDs []string) (map[string]time.Time, error) {
+	return r.next.GetLastSuccessByTaskUUIDs(ctx, taskUUIDs)
+}
+
+func (r *Repository) DeleteExecutionsByTaskUUIDs(ctx context.Context, taskUUIDs []string) (int64, error) {
+	return r.next.DeleteExecutionsByTaskUUIDs(ctx, taskUUIDs)
+}
+
+func (r *Repository) DeleteExecutionsByTaskUUIDsBefore(ctx context.Context, taskUUIDs []string, before time.Time) (int64, error) {
+	return r.next.DeleteExecutionsByTaskUUIDsBefore(ctx, taskUUIDs, before)
+}
+
+func (r *Repository) GetExecutionBatchByTaskUUID(ctx context.Context, taskUUID string, limit int) ([]*models.Execution, error) {
+	return r.next.GetExecutionBatchByTaskUUID(ctx, taskUUID, limit)
+}
+
+func (r *Repository) DeleteExecutionsByIDs(ctx context.Context, executionIDs []primitive.ObjectID) (int64, error) {
+	return r.next.DeleteExecutionsByIDs(ctx, executionIDs)
+}
+
+func (r *Repository) TrimExecutionLogsBefore(ctx context.Context, taskUUIDs []string, statuses []models.ExecutionStatus, before time.Time) (int64, error) {
+	return r.next.TrimExecutionLogsBefore(ctx, taskUUIDs, statuses, before)
+}
+
+func (r *Repository) GetExecutionsByTaskUUIDsBetween(ctx context.Context, taskUUIDs []string, from, to time.Time, limit int) ([]*models.Execution, error) {
+	return r.next.GetExecutionsByTaskUUIDsBetween(ctx, taskUUIDs, from, to, limit)
+}
+
+// Failure statistics
+
+func (r *Repository) IncrementFailureStat(ctx context.Context, projectID primitive.ObjectID, date string) error {
+	return r.next.IncrementFailureStat(ctx, projectID, date)
+}
+
+func (r *Repository) GetFailureStatsByProject(ctx context.Context, projectID primitive.ObjectID, days int) ([]*models.FailedExecutionStats, int, error) {
+	return r.next.GetFailureStatsByProject(ctx, projectID, days)
+}
+
+func (r *Repository) DeleteStatsByProjectID(ctx context.Context, projectID primitive.ObjectID) error {
+	return r.next.DeleteStatsByProjectID(ctx, projectID)
+}
+
+func (r *Repository) DecrementFailureStats(ctx context.Context, projectID primitive.ObjectID, countsByDate map[string]int) error {
+	return r.next.DecrementFailureStats(ctx, projectID, countsByDate)
+}
+
+// Execution statistics
+
+func (r *Repository) GetExecutionStatsByProject(ctx context.Context, projectID primitive.ObjectID, days int) ([]*models.ExecutionStats, error) {
+	return r.next.GetExecutionStatsByProject(ctx, projectID, days)
+}
+
+// Task failures by date
+
+func (r *Repository) GetTaskFailuresByDate(ctx context.Context, projectID primitive.ObjectID, date string) ([]*models.TaskFailureStats, int, error) {
+	return r.next.GetTaskFailuresByDate(ctx, projectID, date)
+}
+
+// Stored task failure stats (pre-calculated)
+
+func (r *Repository) StoreTaskFailureStats(ctx context.Context, stats *models.StoredTaskFailureStats) error {
+	return r.next.StoreTaskFailureStats(ctx, stats)
+}
+
+func (r *Repository) GetStoredTaskFailureStats(ctx context.Context, projectID primitive.ObjectID, date string) (*models.StoredTaskFailureStats, error) {
+	return r.next.GetStoredTaskFailureStats(ctx, projectID, date)
+}
+
+func (r *Repository) CalculateTaskFailureStats(ctx context.Context, projectID primitive.ObjectID, date string) (*models.StoredTaskFailureStats, error) {
+	return r.next.CalculateTaskFailureStats(ctx, projectID, date)
+}
+
+func (r *Repository) RemoveTaskFromStoredFailureStats(ctx context.Context, projectID primitive.ObjectID, taskUUID string) error {
+	return r.next.RemoveTaskFromStoredFailureStats(ctx, projectID, taskUUID)
+}
+
+// Usage metering
+
+func (r *Repository) IncrementUsage(ctx context.Context, projectID primitive.ObjectID, date string, counters models.UsageCounters) error {
+	return r.next.IncrementUsage(ctx, projectID, date, counters)
+}
+
+func (r *Repository) GetUsage(ctx context.Context, filter models.UsageFilter) ([]*models.UsageRecord, error) {
+	return r.next.GetUsage(ctx, filter)
+}
+
+// Event outbox
+
+func (r *Repository) CreateOutboxEvent(ctx context.Context, event *models.OutboxEvent) error {
+	return r.next.CreateOutboxEvent(ctx, event)
+}
+
+func (r *Repository) ClaimOutboxEvents(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*models.OutboxEvent, error) {
+	return r.next.ClaimOutboxEvents(ctx, now, lease, limit)
+}
+
+func (r *Repository) DeleteOutboxEvent(ctx context.Context, id primitive.ObjectID) error {
+	return r.next.DeleteOutboxEvent(ctx, id)
+}
+
+// In-process job queue
+
+func (r *Repository) CreateQueuedJob(ctx context.Context, job *models.QueuedJob) error {
+	return r.next.CreateQueuedJob(ctx, job)
+}
+
+func (r *Repository) ClaimQueuedJobs(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*models.QueuedJob, error) {
+	return r.next.ClaimQueuedJobs(ctx, now, lease, limit)
+}
+
+func (r *Repository) DeleteQueuedJob(ctx context.Context, id primitive.ObjectID) error {
+	return r.next.DeleteQueuedJob(ctx, id)
+}
+
+// Incidents
+
+func (r *Repository) RecordIncidentFailure(ctx context.Context, failure models.IncidentFailure) (*models.Incident, error) {
+	return r.next.RecordIncidentFailure(ctx, failure)
+}
+
+func (r *Repository) ResolveActiveIncident(ctx context.Context, taskUUID string, executionUUID string, resolvedAt time.Time) (*models.Incident, error) {
+	return r.next.ResolveActiveIncident(ctx, taskUUID, executionUUID, resolvedAt)
+}
+
+func (r *Repository) GetIncidentByUUID(ctx context.Context, projectID primitive.ObjectID, incidentUUID string) (*models.Incident, error) {
+	return r.next.GetIncidentByUUID(ctx, projectID, incidentUUID)
+}
+
+func (r *Repository) ListIncidents(ctx context.Context, projectID primitive.ObjectID, filter models.IncidentListFilter, page, pageSize int) ([]*models.Incident, int64, error) {
+	return r.next.ListIncidents(ctx, projectID, filter, page, pageSize)
+}
+
+func (r *Repository) AcknowledgeIncident(ctx context.Context, projectID primitive.ObjectID, incidentUUID string, acknowledgedBy string, acknowledgedAt time.Time) (*models.Incident, error) {
+	return r.next.AcknowledgeIncident(ctx, projectID, incidentUUID, acknowledgedBy, acknowledgedAt)
+}
+
+func (r *Repository) ResolveIncident(ctx context.Context, projectID primitive.ObjectID, incidentUUID string, resolvedBy string, resolvedAt time.Time) (*models.Incident, error) {
+	return r.next.ResolveIncident(ctx, projectID, incidentUUID, resolvedBy, resolvedAt)
+}
+
+func (r *Repository) AddIncidentComment(ctx context.Context, projectID primitive.ObjectID, incidentUUID string, comment models.IncidentComment) error {
+	return r.next.AddIncidentComment(ctx, projectID, incidentUUID, comment)
+}
+
+func (r *Repository) DeleteIncidentsByProjectID(ctx context.Context, projectID primitive.ObjectID) error {
+	return r.next.DeleteIncidentsByProjectID(ctx, projectID)
+}
+
+// Referential integrity
+
+func (r *Repository) GetTaskReferences(ctx context.Context) ([]*models.TaskReference, error) {
+	return r.next.GetTaskReferences(ctx)
+}
+
+func (r *Repository) GetExecutionTaskUUIDs(ctx context.Context) ([]string, error) {
+	return r.next.GetExecutionTaskUUIDs(ctx)
+}
+
+func (r *Repository) GetStatsProjectIDs(ctx context.Context) ([]primitive.ObjectID, error) {
+	return r.next.GetStatsProjectIDs(ctx)
+}
@@ -0,0 +1,336 @@
+package cache
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/yourusername/cron-observer/backend/internal/events"
+	"github.com/yourusername/cron-observer/backend/internal/models"
+	"github.com/yourusername/cron-observer/backend/internal/repositories"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Repository caches the lookups made on every SDK request (projects by ID, tasks by UUID, project settings) in
+// front of another repository. Writes made through it invalidate the affected entries; writes that bypass it
+// reach the cache through events (see Run) or expire with the TTL. Cache failures are logged and fall back to
+// the wrapped repository, so the cache never fails a request.
+//
+// The wrapped repository is not embedded, so every method of repositories.Repository is written out: the ones
+// that can change a cached document invalidate it, the rest are in passthrough.go. A method added to the
+// interface does not compile until it is placed in one of the two.
+type Repository struct {
+	next  repositories.Repository
+	store Store
+	ttl   time.Duration
+}
+
+var _ repositories.Repository = (*Repository)(nil)
+
+func NewRepository(repo repositories.Repository, store Store, ttl time.Duration) *Repository {
+	return &Repository{next: repo, store: store, ttl: ttl}
+}
+
+func projectKey(projectID primitive.ObjectID) string  { return "project:" + projectID.Hex() }
+func taskKey(taskUUID string) string                  { return "task:" + taskUUID }
+func settingsKey(projectID primitive.ObjectID) string { return "project_settings:" + projectID.Hex() }
+
+// Run invalidates cached entries on task and project events until ctx is cancelled. Events derived from change
+// streams or received from other replicas cover writes this replica did not make.
+func (r *Repository) Run(ctx context.Context, bus *events.EventBus) {
+	taskUpdated := events.Subscribe(bus, events.TaskUpdatedTopic)
+	taskDeleted := events.Subscribe(bus, events.TaskDeletedTopic)
+	projectArchived := events.Subscribe(bus, events.ProjectArchivedTopic)
+	projectRestored := events.Subscribe(bus, events.ProjectRestoredTopic)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case payload, ok := <-taskUpdated:
+			if !ok {
+				return
+			}
+			if payload.Task != nil {
+				r.invalidate(ctx, taskKey(payload.Task.UUID))
+			}
+		case payload, ok := <-taskDeleted:
+			if !ok {
+				return
+			}
+			r.invalidate(ctx, taskKey(payload.TaskUUID))
+		case payload, ok := <-projectArchived:
+			if !ok {
+				return
+			}
+			if payload.Project != nil {
+				r.invalidate(ctx, projectKey(payload.Project.ID))
+			}
+		case payload, ok := <-projectRestored:
+			if !ok {
+				return
+			}
+			if payload.Project != nil {
+				r.invalidate(ctx, projectKey(payload.Project.ID))
+			}
+		}
+	}
+}
+
+// cached returns the document stored under key, or loads and stores it. A nil document is cached as well, so
+// lookups that legitimately find nothing (project settings) are not repeated. Errors are never cached.
+func cached[T any](ctx context.Context, r *Repository, key string, load func() (*T, error)) (*T, error) {
+	value, ok, err := r.store.Get(ctx, key)
+	if err != nil {
+		log.Printf("[Cache] Failed to read %s: %v", key, err)
+	} else if ok {
+		if len(value) == 0 {
+			return nil, nil
+		}
+		var doc T
+		if err := bson.Unmarshal(value, &doc); err == nil {
+			return &doc, nil
+		}
+		log.Printf("[Cache] Failed to decode %s: %v", key, err)
+	}
+
+	doc, err := load()
+	if err != nil {
+		return nil, err
+	}
+
+	var encoded []byte
+	if doc != nil {
+		if encoded, err = bson.Marshal(doc); err != nil {
+			log.Printf("[Cache] Failed to encode %s: %v", key, err)
+			return doc, nil
+		}
+	}
+	if err := r.store.Set(ctx, key, encoded, r.ttl); err != nil {
+		log.Printf("[Cache] Failed to write %s: %v", key, err)
+	}
+	return doc, nil
+}
+
+// invalidate removes entries after a write. It runs even when the request was cancelled, since the write
+// may have been applied.
+func (r *Repository) invalidate(ctx context.Context, keys ...string) {
+	if tx, ok := ctx.Value(transactionKey{}).(*transaction); ok {
+		tx.invalidated = append(tx.invalidated, keys...)
+	}
+	if err := r.store.Delete(context.WithoutCancel(ctx), keys...); err != nil {
+		log.Printf("[Cache] Failed to invalidate %v: %v", keys, err)
+	}
+}
+
+// transactionKey carries the transaction a write is part of, see RunInTransaction
+type transactionKey struct{}
+
+// transaction collects the keys invalidated by the writes of a transaction
+type transaction struct {
+	invalidated []string
+}
+
+// RunInTransaction invalidates the entries written in the transaction again once it ended: until the commit,
+// a read could cache the document as it was before the transaction.
+func (r *Repository) RunInTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := ctx.Value(transactionKey{}).(*transaction); ok {
+		return r.next.RunInTransaction(ctx, fn)
+	}
+
+	tx := &transaction{}
+	err := r.next.RunInTransaction(context.WithValue(ctx, transactionKey{}, tx), fn)
+	if len(tx.invalidated) > 0 {
+		r.invalidate(ctx, tx.invalidated...)
+	}
+	return err
+}
+
+// Cached reads
+
+func (r *Repository) GetProjectByID(ctx context.Context, projectID primitive.ObjectID) (*models.Project, error) {
+	return cached(ctx, r, projectKey(projectID), func() (*models.Project, error) {
+		return r.next.GetProjectByID(ctx, projectID)
+	})
+}
+
+func (r *Repository) GetTaskByUUID(ctx context.Context, taskUUID string) (*models.Task, error) {
+	return cached(ctx, r, taskKey(taskUUID), func() (*models.Task, error) {
+		return r.next.GetTaskByUUID(ctx, taskUUID)
+	})
+}
+
+func (r *Repository) GetProjectSettings(ctx context.Context, projectID primitive.ObjectID) (*models.ProjectSettings, error) {
+	return cached(ctx, r, settingsKey(projectID), func() (*models.ProjectSettings, error) {
+		return r.next.GetProjectSettings(ctx, projectID)
+	})
+}
+
+// Project writes
+
+func (r *Repository) UpdateProject(ctx context.Context, projectID primitive.ObjectID, project *models.Project) error {
+	defer r.invalidate(ctx, projectKey(projectID))
+	return r.next.UpdateProject(ctx, projectID, project)
+}
+
+func (r *Repository) UpdateProjectStatus(ctx context.Context, projectID primitive.ObjectID, status models.ProjectStatus) error {
+	defer r.invalidate(ctx, projectKey(projectID))
+	return r.next.UpdateProjectStatus(ctx, projectID, status)
+}
+
+func (r *Repository) UpdateProjectDeletionProgress(ctx context.Context, projectID primitive.ObjectID, progress *models.ProjectDeletionProgress) error {
+	defer r.invalidate(ctx, projectKey(projectID))
+	return r.next.UpdateProjectDeletionProgress(ctx, projectID, progress)
+}
+
+func (r *Repository) DeleteProject(ctx context.Context, projectID primitive.ObjectID) error {
+	defer r.invalidate(ctx, projectKey(projectID))
+	return r.next.DeleteProject(ctx, projectID)
+}
+
+func (r *Repository) AddScopedAPIKey(ctx context.Context, projectID primitive.ObjectID, apiKey models.ScopedAPIKey) error {
+	defer r.invalidate(ctx, projectKey(projectID))
+	return r.next.AddScopedAPIKey(ctx, projectID, apiKey)
+}
+
+func (r *Repository) RemoveScopedAPIKey(ctx context.Context, projectID primitive.ObjectID, keyID string) error {
+	defer r.invalidate(ctx, projectKey(projectID))
+	return r.next.RemoveScopedAPIKey(ctx, projectID, keyID)
+}
+
+func (r *Repository) UpdateScopedAPIKeyAllowedCIDRs(ctx context.Context, projectID primitive.ObjectID, keyID string, allowedCIDRs []string) error {
+	defer r.invalidate(ctx, projectKey(projectID))
+	return r.next.UpdateScopedAPIKeyAllowedCIDRs(ctx, projectID, keyID, allowedCIDRs)
+}
+
+func (r *Repository) AddProjectEnvironment(ctx context.Context, projectID primitive.ObjectID, environment models.ProjectEnvironment) error {
+	defer r.invalidate(ctx, projectKey(projectID))
+	return r.next.AddProjectEnvironment(ctx, projectID, environment)
+}
+
+func (r *Repository) UpdateProjectEnvironmentEndpoint(ctx context.Context, projectID primitive.ObjectID, name, executionEndpoint string) error {
+	defer r.invalidate(ctx, projectKey(projectID))
+	return r.next.UpdateProjectEnvironmentEndpoint(ctx, projectID, name, executionEndpoint)
+}
+
+func (r *Repository) RemoveProjectEnvironment(ctx context.Context, projectID primitive.ObjectID, name string) error {
+	defer r.invalidate(ctx, projectKey(projectID))
+	return r.next.RemoveProjectEnvironment(ctx, projectID, name)
+}
+
+func (r *Repository) AddProjectUser(ctx context.Context, projectID primitive.ObjectID, user models.ProjectUser) error {
+	defer r.invalidate(ctx, projectKey(projectID))
+	return r.next.AddProjectUser(ctx, projectID, user)
+}
+
+func (r *Repository) SetProjectStatusPageToken(ctx context.Context, projectID primitive.ObjectID, token string) error {
+	defer r.invalidate(ctx, projectKey(projectID))
+	return r.next.SetProjectStatusPageToken(ctx, projectID, token)
+}
+
+func (r *Repository) AddNotificationChannel(ctx context.Context, projectID primitive.ObjectID, channel models.NotificationChannel) error {
+	defer r.invalidate(ctx, projectKey(projectID))
+	return r.next.AddNotificationChannel(ctx, projectID, channel)
+}
+
+func (r *Repository) UpdateNotificationChannel(ctx context.Context, projectID primitive.ObjectID, channel models.NotificationChannel) error {
+	defer r.invalidate(ctx, projectKey(projectID))
+	return r.next.UpdateNotificationChannel(ctx, projectID, channel)
+}
+
+func (r *Repository) RemoveNotificationChannel(ctx context.Context, projectID primitive.ObjectID, name string) error {
+	defer r.invalidate(ctx, projectKey(projectID))
+	return r.next.RemoveNotificationChannel(ctx, projectID, name)
+}
+
+func (r *Repository) SetAlertRoutes(ctx context.Context, projectID primitive.ObjectID, routes []models.AlertRoute) error {
+	defer r.invalidate(ctx, projectKey(projectID))
+	return r.next.SetAlertRoutes(ctx, projectID, routes)
+}
+
+func (r *Repository) SetOnCallSchedule(ctx context.Context, projectID primitive.ObjectID, schedule *models.OnCallSchedule) error {
+	defer r.invalidate(ctx, projectKey(projectID))
+	return r.next.SetOnCallSchedule(ctx, projectID, schedule)
+}
+
+func (r *Repository) SetProjectQuotas(ctx context.Context, projectID primitive.ObjectID, quotas *models.ProjectQuotas) error {
+	defer r.invalidate(ctx, projectKey(projectID))
+	return r.next.SetProjectQuotas(ctx, projectID, quotas)
+}
+
+func (r *Repository) SetProjectOrganization(ctx context.Context, projectID primitive.ObjectID, organizationID *primitive.ObjectID) error {
+	defer r.invalidate(ctx, projectKey(projectID))
+	return r.next.SetProjectOrganization(ctx, projectID, organizationID)
+}
+
+// Project settings writes
+
+func (r *Repository) UpsertProjectSettings(ctx context.Context, settings *models.ProjectSettings) error {
+	defer r.invalidate(ctx, settingsKey(settings.ProjectID))
+	return r.next.UpsertProjectSettings(ctx, settings)
+}
+
+func (r *Repository) DeleteProjectSettings(ctx context.Context, projectID primitive.ObjectID) error {
+	defer r.invalidate(ctx, settingsKey(projectID))
+	return r.next.DeleteProjectSettings(ctx, projectID)
+}
+
+// Task writes
+
+func (r *Repository) UpdateTaskLastFailureAt(ctx context.Context, taskUUID string, failedAt time.Time) error {
+	defer r.invalidate(ctx, taskKey(taskUUID))
+	return r.next.UpdateTaskLastFailureAt(ctx, taskUUID, failedAt)
+}
+
+func (r *Repository) SetTaskMutedUntil(ctx context.Context, taskUUID string, mutedUntil *time.Time) error {
+	defer r.invalidate(ctx, taskKey(taskUUID))
+	return r.next.SetTaskMutedUntil(ctx, taskUUID, mutedUntil)
+}
+
+func (r *Repository) SetTaskNextRunAt(ctx context.Context, taskUUID string, nextRunAt *time.Time) error {
+	defer r.invalidate(ctx, taskKey(taskUUID))
+	return r.next.SetTaskNextRunAt(ctx, taskUUID, nextRunAt)
+}
+
+func (r *Repository) SetTaskFlapping(ctx context.Context, taskUUID string, since *time.Time) error {
+	defer r.invalidate(ctx, taskKey(taskUUID))
+	return r.next.SetTaskFlapping(ctx, taskUUID, since)
+}
+
+func (r *Repository) UpdateTask(ctx context.Context, taskUUID string, task *models.Task) error {
+	defer r.invalidate(ctx, taskKey(taskUUID))
+	return r.next.UpdateTask(ctx, taskUUID, task)
+}
+
+func (r *Repository) UpdateTaskStatus(ctx context.Context, taskUUID string, status models.TaskStatus) error {
+	defer r.invalidate(ctx, taskKey(taskUUID))
+	return r.next.UpdateTaskStatus(ctx, taskUUID, status)
+}
+
+func (r *Repository) UpdateTaskState(ctx context.Context, taskUUID string, state models.TaskState) error {
+	defer r.invalidate(ctx, taskKey(taskUUID))
+	return r.next.UpdateTaskState(ctx, taskUUID, state)
+}
+
+func (r *Repository) DeleteTask(ctx context.Context, taskUUID string) error {
+	defer r.invalidate(ctx, taskKey(taskUUID))
+	return r.next.DeleteTask(ctx, taskUUID)
+}
+
+// UpdateTaskGroupWithTasks invalidates every task of the group, since the cascade may have changed any of them
+func (r *Repository) UpdateTaskGroupWithTasks(ctx context.Context, taskGroup *models.TaskGroup, cascade models.TaskGroupCascade) (*models.TaskGroupCascadeResult, error) {
+	result, err := r.next.UpdateTaskGroupWithTasks(ctx, taskGroup, cascade)
+
+	tasks, listErr := r.next.GetTasksByGroupID(context.WithoutCancel(ctx), taskGroup.ID)
+	if listErr != nil {
+		log.Printf("[Cache] Failed to list tasks of group %s to invalidate: %v", taskGroup.UUID, listErr)
+		return result, err
+	}
+	keys := make([]string, 0, len(tasks))
+	for _, task := range tasks {
+		keys = append(keys, taskKey(task.UUID))
+	}
+	r.invalidate(ctx, keys...)
+	return result, err
+}
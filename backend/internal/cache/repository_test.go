@@ -0,0 +1,210 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/yourusername/cron-observer/backend/internal/models"
+	"github.com/yourusername/cron-observer/backend/mocks"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.uber.org/mock/gomock"
+)
+
+// mapStore is an in-memory Store; TTLs are ignored
+type mapStore struct {
+	mu     sync.Mutex
+	values map[string][]byte
+	err    error
+}
+
+func newMapStore() *mapStore {
+	return &mapStore{values: make(map[string][]byte)}
+}
+
+func (s *mapStore) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.err != nil {
+		return nil, false, s.err
+	}
+	value, ok := s.values[key]
+	return value, ok, nil
+}
+
+func (s *mapStore) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.err != nil {
+		return s.err
+	}
+	s.values[key] = value
+	return nil
+}
+
+func (s *mapStore) Delete(ctx context.Context, keys ...string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, key := range keys {
+		delete(s.values, key)
+	}
+	return s.err
+}
+
+func TestRepository_GetProjectByIDIsServedFromCache(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	project := &models.Project{ID: primitive.NewObjectID(), UUID: "project-1", Name: "Billing", APIKey: "key-1"}
+	repo := mocks.NewMockRepository(ctrl)
+	repo.EXPECT().GetProjectByID(gomock.Any(), project.ID).Return(project, nil).Times(1)
+
+	cached := NewRepository(repo, newMapStore(), time.Minute)
+	for i := 0; i < 2; i++ {
+		found, err := cached.GetProjectByID(ctx, project.ID)
+		if err != nil {
+			t.Fatalf("GetProjectByID: %v", err)
+		}
+		if found.UUID != "project-1" || found.APIKey != "key-1" {
+			t.Fatalf("unexpected project %+v", found)
+		}
+	}
+}
+
+func TestRepository_UpdateProjectInvalidates(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	project := &models.Project{ID: primitive.NewObjectID(), Name: "Billing"}
+	renamed := &models.Project{ID: project.ID, Name: "Payments"}
+	repo := mocks.NewMockRepository(ctrl)
+	gomock.InOrder(
+		repo.EXPECT().GetProjectByID(gomock.Any(), project.ID).Return(project, nil),
+		repo.EXPECT().UpdateProject(gomock.Any(), project.ID, renamed).Return(nil),
+		repo.EXPECT().GetProjectByID(gomock.Any(), project.ID).Return(renamed, nil),
+	)
+
+	cached := NewRepository(repo, newMapStore(), time.Minute)
+	cached.GetProjectByID(ctx, project.ID)
+	if err := cached.UpdateProject(ctx, project.ID, renamed); err != nil {
+		t.Fatalf("UpdateProject: %v", err)
+	}
+	found, _ := cached.GetProjectByID(ctx, project.ID)
+	if found.Name != "Payments" {
+		t.Fatalf("name = %q after update, want Payments", found.Name)
+	}
+}
+
+func TestRepository_ProjectFieldWritesInvalidate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	projectID := primitive.NewObjectID()
+	organizationID := primitive.NewObjectID()
+	writes := map[string]func(r *Repository) error{
+		"SetProjectOrganization": func(r *Repository) error { return r.SetProjectOrganization(ctx, projectID, &organizationID) },
+		"SetProjectQuotas":       func(r *Repository) error { return r.SetProjectQuotas(ctx, projectID, &models.ProjectQuotas{}) },
+		"AddNotificationChannel": func(r *Repository) error {
+			return r.AddNotificationChannel(ctx, projectID, models.NotificationChannel{Name: "ops"})
+		},
+		"UpdateNotificationChannel": func(r *Repository) error {
+			return r.UpdateNotificationChannel(ctx, projectID, models.NotificationChannel{Name: "ops"})
+		},
+		"RemoveNotificationChannel": func(r *Repository) error { return r.RemoveNotificationChannel(ctx, projectID, "ops") },
+		"SetAlertRoutes":            func(r *Repository) error { return r.SetAlertRoutes(ctx, projectID, nil) },
+		"SetOnCallSchedule":         func(r *Repository) error { return r.SetOnCallSchedule(ctx, projectID, nil) },
+	}
+
+	repo := mocks.NewMockRepository(ctrl)
+	repo.EXPECT().SetProjectOrganization(gomock.Any(), projectID, gomock.Any()).Return(nil)
+	repo.EXPECT().SetProjectQuotas(gomock.Any(), projectID, gomock.Any()).Return(nil)
+	repo.EXPECT().AddNotificationChannel(gomock.Any(), projectID, gomock.Any()).Return(nil)
+	repo.EXPECT().UpdateNotificationChannel(gomock.Any(), projectID, gomock.Any()).Return(nil)
+	repo.EXPECT().RemoveNotificationChannel(gomock.Any(), projectID, "ops").Return(nil)
+	repo.EXPECT().SetAlertRoutes(gomock.Any(), projectID, gomock.Any()).Return(nil)
+	repo.EXPECT().SetOnCallSchedule(gomock.Any(), projectID, gomock.Any()).Return(nil)
+	repo.EXPECT().GetProjectByID(gomock.Any(), projectID).Return(&models.Project{ID: projectID}, nil).Times(2 * len(writes))
+
+	cached := NewRepository(repo, newMapStore(), time.Minute)
+	for name, write := range writes {
+		cached.GetProjectByID(ctx, projectID)
+		if err := write(cached); err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		// The write must have removed the cached project, so this reads through again
+		cached.GetProjectByID(ctx, projectID)
+		cached.invalidate(ctx, projectKey(projectID))
+	}
+}
+
+func TestRepository_RunInTransactionInvalidatesAgainAfterCommit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	projectID := primitive.NewObjectID()
+	repo := mocks.NewMockRepository(ctrl)
+	repo.EXPECT().RunInTransaction(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+		return fn(ctx)
+	})
+	repo.EXPECT().UpdateProjectStatus(gomock.Any(), projectID, models.ProjectStatusArchived).Return(nil)
+	repo.EXPECT().GetProjectByID(gomock.Any(), projectID).Return(&models.Project{ID: projectID}, nil).Times(2)
+
+	cached := NewRepository(repo, newMapStore(), time.Minute)
+	err := cached.RunInTransaction(ctx, func(ctx context.Context) error {
+		if err := cached.UpdateProjectStatus(ctx, projectID, models.ProjectStatusArchived); err != nil {
+			return err
+		}
+		// Before the commit, this read caches the project as other readers see it
+		_, err := cached.GetProjectByID(ctx, projectID)
+		return err
+	})
+	if err != nil {
+		t.Fatalf("RunInTransaction failed: %v", err)
+	}
+
+	// The entry cached during the transaction must be gone, so this reads through again
+	cached.GetProjectByID(ctx, projectID)
+}
+
+func TestRepository_MissingProjectSettingsAreCached(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	projectID := primitive.NewObjectID()
+	repo := mocks.NewMockRepository(ctrl)
+	repo.EXPECT().GetProjectSettings(gomock.Any(), projectID).Return(nil, nil).Times(1)
+
+	cached := NewRepository(repo, newMapStore(), time.Minute)
+	for i := 0; i < 2; i++ {
+		if settings, err := cached.GetProjectSettings(ctx, projectID); settings != nil || err != nil {
+			t.Fatalf("GetProjectSettings: got %v, %v, want nil, nil", settings, err)
+		}
+	}
+}
+
+func TestRepository_StoreErrorsFallBackToRepository(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	task := &models.Task{ID: primitive.NewObjectID(), UUID: "task-1", Name: "Backup"}
+	repo := mocks.NewMockRepository(ctrl)
+	repo.EXPECT().GetTaskByUUID(gomock.Any(), "task-1").Return(task, nil).Times(2)
+
+	store := newMapStore()
+	store.err = errors.New("connection refused")
+	cached := NewRepository(repo, store, time.Minute)
+	for i := 0; i < 2; i++ {
+		found, err := cached.GetTaskByUUID(ctx, "task-1")
+		if err != nil || found.Name != "Backup" {
+			t.Fatalf("GetTaskByUUID: got %v, %v", found, err)
+		}
+	}
+}
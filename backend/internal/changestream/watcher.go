@@ -0,0 +1,272 @@
+package changestream
+
+import (
+	"context"
+	"errors"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/yourusername/cron-observer/backend/internal/database"
+	"github.com/yourusername/cron-observer/backend/internal/events"
+	"github.com/yourusername/cron-observer/backend/internal/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// retryInterval is how long the watcher waits before reopening a failed change stream
+const retryInterval = 5 * time.Second
+
+// systemFields are written by the scheduler and aggregators while running tasks. Updates touching only
+// these fields are not task changes, and re-registering on them would feed back into the scheduler.
+var systemFields = map[string]bool{
+	"state":           true,
+	"next_run_at":     true,
+	"last_failure_at": true,
+	"muted_until":     true,
+	"updated_at":      true,
+}
+
+// Watcher turns MongoDB change stream events of the tasks and task_groups collections into task and task
+// group events, so the scheduler converges on writes that bypass the API (migrations, manual fixes, other
+// services). Writes made through the API produce the same event twice; the scheduler's handlers are
+// idempotent. Change streams require a replica set or sharded cluster.
+type Watcher struct {
+	tasks      *mongo.Collection
+	taskGroups *mongo.Collection
+	eventBus   *events.EventBus
+
+	// Delete events only carry the document _id, so UUIDs are remembered for them
+	mu             sync.Mutex
+	taskUUIDs      map[primitive.ObjectID]string
+	taskGroupUUIDs map[primitive.ObjectID]string
+}
+
+// changeEvent is the part of a change stream event the watcher uses
+type changeEvent struct {
+	OperationType string `bson:"operationType"`
+	DocumentKey   struct {
+		ID primitive.ObjectID `bson:"_id"`
+	} `bson:"documentKey"`
+	FullDocument      bson.Raw `bson:"fullDocument"`
+	UpdateDescription struct {
+		UpdatedFields bson.Raw `bson:"updatedFields"`
+		RemovedFields []string `bson:"removedFields"`
+	} `bson:"updateDescription"`
+}
+
+func NewWatcher(db *database.Database, eventBus *events.EventBus) *Watcher {
+	return &Watcher{
+		tasks:          db.GetTasksCollection(),
+		taskGroups:     db.GetTaskGroupsCollection(),
+		eventBus:       eventBus,
+		taskUUIDs:      make(map[primitive.ObjectID]string),
+		taskGroupUUIDs: make(map[primitive.ObjectID]string),
+	}
+}
+
+// Start watches both collections until ctx is cancelled. Failed streams are reopened where they left off.
+func (w *Watcher) Start(ctx context.Context) {
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		w.watch(ctx, w.tasks, w.taskUUIDs, w.handleTaskChange)
+	}()
+	go func() {
+		defer wg.Done()
+		w.watch(ctx, w.taskGroups, w.taskGroupUUIDs, w.handleTaskGroupChange)
+	}()
+
+	log.Println("[ChangeStream] Watching tasks and task groups")
+	wg.Wait()
+	log.Println("[ChangeStream] Stopped")
+}
+
+// watch consumes the collection's change stream, reopening it after errors until ctx is cancelled
+func (w *Watcher) watch(ctx context.Context, collection *mongo.Collection, uuids map[primitive.ObjectID]string, handle func(changeEvent)) {
+	var resumeToken bson.Raw
+	for ctx.Err() == nil {
+		if resumeToken == nil {
+			// Without a resume token deletes of documents seen before the stream opened need their UUIDs
+			if err := w.loadUUIDs(ctx, collection, uuids); err != nil {
+				log.Printf("[ChangeStream] Failed to load %s UUIDs: %v", collection.Name(), err)
+			}
+		}
+
+		token, err := w.consume(ctx, collection, resumeToken, handle)
+		resumeToken = token
+		if ctx.Err() != nil {
+			return
+		}
+		var cmdErr mongo.CommandError
+		if errors.As(err, &cmdErr) && cmdErr.HasErrorLabel("NonResumableChangeStreamError") {
+			// The oplog no longer holds the resume point; changes in the gap are lost
+			log.Printf("[ChangeStream] Cannot resume %s stream, restarting from now: %v", collection.Name(), err)
+			resumeToken = nil
+		} else {
+			log.Printf("[ChangeStream] %s stream failed, reopening in %s: %v", collection.Name(), retryInterval, err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(retryInterval):
+		}
+	}
+}
+
+// consume opens a change stream and passes its events to handle. Returns the last resume token and
+// the error that ended the stream.
+func (w *Watcher) consume(ctx context.Context, collection *mongo.Collection, resumeToken bson.Raw, handle func(changeEvent)) (bson.Raw, error) {
+	opts := options.ChangeStream().SetFullDocument(options.UpdateLookup)
+	if resumeToken != nil {
+		opts.SetResumeAfter(resumeToken)
+	}
+
+	stream, err := collection.Watch(ctx, mongo.Pipeline{}, opts)
+	if err != nil {
+		return resumeToken, err
+	}
+	defer stream.Close(context.Background())
+
+	for stream.Next(ctx) {
+		var change changeEvent
+		if err := stream.Decode(&change); err != nil {
+			log.Printf("[ChangeStream] Failed to decode %s change: %v", collection.Name(), err)
+		} else if change.OperationType == "invalidate" {
+			// The collection was dropped or renamed; a new stream starts from scratch
+			return nil, errors.New("change stream invalidated")
+		} else {
+			handle(change)
+		}
+		resumeToken = stream.ResumeToken()
+	}
+	return resumeToken, stream.Err()
+}
+
+// loadUUIDs remembers the UUID of every document of the collection
+func (w *Watcher) loadUUIDs(ctx context.Context, collection *mongo.Collection, uuids map[primitive.ObjectID]string) error {
+	cursor, err := collection.Find(ctx, bson.M{}, options.Find().SetProjection(bson.M{"uuid": 1}))
+	if err != nil {
+		return err
+	}
+	defer cursor.Close(ctx)
+
+	var docs []struct {
+		ID   primitive.ObjectID `bson:"_id"`
+		UUID string             `bson:"uuid"`
+	}
+	if err := cursor.All(ctx, &docs); err != nil {
+		return err
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for _, doc := range docs {
+		uuids[doc.ID] = doc.UUID
+	}
+	return nil
+}
+
+// handleTaskChange publishes the task event matching a change of the tasks collection
+func (w *Watcher) handleTaskChange(change changeEvent) {
+	switch change.OperationType {
+	case "insert", "update", "replace":
+		if change.OperationType == "update" && onlySystemFields(change) {
+			return
+		}
+		// Missing when the task was deleted before the lookup; the delete event follows
+		if change.FullDocument == nil {
+			return
+		}
+		var task models.Task
+		if err := bson.Unmarshal(change.FullDocument, &task); err != nil {
+			log.Printf("[ChangeStream] Failed to decode task %s: %v", change.DocumentKey.ID.Hex(), err)
+			return
+		}
+		w.remember(w.taskUUIDs, task.ID, task.UUID)
+
+		topic := events.TaskUpdatedTopic
+		if change.OperationType == "insert" {
+			topic = events.TaskCreatedTopic
+		}
+		w.eventBus.PublishLocal(topic.Event(events.TaskPayload{Task: &task}))
+	case "delete":
+		uuid, ok := w.forget(w.taskUUIDs, change.DocumentKey.ID)
+		if !ok {
+			log.Printf("[ChangeStream] Task %s deleted, but its UUID is unknown", change.DocumentKey.ID.Hex())
+			return
+		}
+		w.eventBus.PublishLocal(events.TaskDeletedTopic.Event(events.TaskDeletedPayload{TaskUUID: uuid}))
+	}
+}
+
+// handleTaskGroupChange publishes the task group event matching a change of the task_groups collection
+func (w *Watcher) handleTaskGroupChange(change changeEvent) {
+	switch change.OperationType {
+	case "insert", "update", "replace":
+		if change.OperationType == "update" && onlySystemFields(change) {
+			return
+		}
+		if change.FullDocument == nil {
+			return
+		}
+		var taskGroup models.TaskGroup
+		if err := bson.Unmarshal(change.FullDocument, &taskGroup); err != nil {
+			log.Printf("[ChangeStream] Failed to decode task group %s: %v", change.DocumentKey.ID.Hex(), err)
+			return
+		}
+		w.remember(w.taskGroupUUIDs, taskGroup.ID, taskGroup.UUID)
+
+		topic := events.TaskGroupUpdatedTopic
+		if change.OperationType == "insert" {
+			topic = events.TaskGroupCreatedTopic
+		}
+		w.eventBus.PublishLocal(topic.Event(events.TaskGroupPayload{TaskGroup: &taskGroup}))
+	case "delete":
+		uuid, ok := w.forget(w.taskGroupUUIDs, change.DocumentKey.ID)
+		if !ok {
+			log.Printf("[ChangeStream] Task group %s deleted, but its UUID is unknown", change.DocumentKey.ID.Hex())
+			return
+		}
+		w.eventBus.PublishLocal(events.TaskGroupDeletedTopic.Event(events.TaskGroupDeletedPayload{TaskGroupUUID: uuid}))
+	}
+}
+
+// onlySystemFields reports whether an update changed nothing but system-controlled fields
+func onlySystemFields(change changeEvent) bool {
+	fields := change.UpdateDescription.RemovedFields
+	elements, err := change.UpdateDescription.UpdatedFields.Elements()
+	if err != nil {
+		return false
+	}
+	for _, element := range elements {
+		fields = append(fields, element.Key())
+	}
+
+	for _, field := range fields {
+		topLevel, _, _ := strings.Cut(field, ".")
+		if !systemFields[topLevel] {
+			return false
+		}
+	}
+	return true
+}
+
+func (w *Watcher) remember(uuids map[primitive.ObjectID]string, id primitive.ObjectID, uuid string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	uuids[id] = uuid
+}
+
+func (w *Watcher) forget(uuids map[primitive.ObjectID]string, id primitive.ObjectID) (string, bool) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	uuid, ok := uuids[id]
+	delete(uuids, id)
+	return uuid, ok
+}
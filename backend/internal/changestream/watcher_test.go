@@ -0,0 +1,144 @@
+package changestream
+
+import (
+	"testing"
+
+	"github.com/yourusername/cron-observer/backend/internal/events"
+	"github.com/yourusername/cron-observer/backend/internal/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func newTestWatcher() *Watcher {
+	return &Watcher{
+		eventBus:       events.NewEventBus(10),
+		taskUUIDs:      make(map[primitive.ObjectID]string),
+		taskGroupUUIDs: make(map[primitive.ObjectID]string),
+	}
+}
+
+func taskChange(t *testing.T, operation string, task *models.Task, updatedFields bson.M) changeEvent {
+	t.Helper()
+	change := changeEvent{OperationType: operation}
+	change.DocumentKey.ID = task.ID
+	if operation != "delete" {
+		doc, err := bson.Marshal(task)
+		if err != nil {
+			t.Fatalf("marshal task: %v", err)
+		}
+		change.FullDocument = doc
+	}
+	if updatedFields != nil {
+		fields, err := bson.Marshal(updatedFields)
+		if err != nil {
+			t.Fatalf("marshal updated fields: %v", err)
+		}
+		change.UpdateDescription.UpdatedFields = fields
+	}
+	return change
+}
+
+func TestHandleTaskChange_InsertPublishesTaskCreated(t *testing.T) {
+	w := newTestWatcher()
+	created := events.Subscribe(w.eventBus, events.TaskCreatedTopic)
+	task := &models.Task{ID: primitive.NewObjectID(), UUID: "task-1", Name: "Backup"}
+
+	w.handleTaskChange(taskChange(t, "insert", task, nil))
+
+	select {
+	case payload := <-created:
+		if payload.Task.UUID != "task-1" || payload.Task.Name != "Backup" {
+			t.Fatalf("unexpected payload %+v", payload.Task)
+		}
+	default:
+		t.Fatal("TaskCreated not published")
+	}
+}
+
+func TestHandleTaskChange_UpdatePublishesTaskUpdated(t *testing.T) {
+	w := newTestWatcher()
+	updated := events.Subscribe(w.eventBus, events.TaskUpdatedTopic)
+	task := &models.Task{ID: primitive.NewObjectID(), UUID: "task-1"}
+
+	w.handleTaskChange(taskChange(t, "update", task, bson.M{"schedule_config.cron_expression": "*/5 * * * *", "updated_at": "now"}))
+
+	if len(updated) != 1 {
+		t.Fatalf("published %d TaskUpdated events, want 1", len(updated))
+	}
+}
+
+func TestHandleTaskChange_IgnoresSystemFieldUpdates(t *testing.T) {
+	w := newTestWatcher()
+	updated := events.Subscribe(w.eventBus, events.TaskUpdatedTopic)
+	task := &models.Task{ID: primitive.NewObjectID(), UUID: "task-1"}
+
+	change := taskChange(t, "update", task, bson.M{"state": "RUNNING", "updated_at": "now"})
+	change.UpdateDescription.RemovedFields = []string{"next_run_at"}
+	w.handleTaskChange(change)
+
+	if len(updated) != 0 {
+		t.Fatalf("published %d TaskUpdated events for a scheduler write, want 0", len(updated))
+	}
+}
+
+func TestHandleTaskChange_DeletePublishesTaskDeleted(t *testing.T) {
+	w := newTestWatcher()
+	deleted := events.Subscribe(w.eventBus, events.TaskDeletedTopic)
+	task := &models.Task{ID: primitive.NewObjectID(), UUID: "task-1"}
+	w.taskUUIDs[task.ID] = task.UUID
+
+	w.handleTaskChange(taskChange(t, "delete", task, nil))
+
+	select {
+	case payload := <-deleted:
+		if payload.TaskUUID != "task-1" {
+			t.Fatalf("deleted %s, want task-1", payload.TaskUUID)
+		}
+	default:
+		t.Fatal("TaskDeleted not published")
+	}
+	if _, ok := w.taskUUIDs[task.ID]; ok {
+		t.Fatal("UUID of deleted task still remembered")
+	}
+}
+
+func TestHandleTaskChange_DeleteOfUnknownTaskIsSkipped(t *testing.T) {
+	w := newTestWatcher()
+	deleted := events.Subscribe(w.eventBus, events.TaskDeletedTopic)
+
+	w.handleTaskChange(taskChange(t, "delete", &models.Task{ID: primitive.NewObjectID()}, nil))
+
+	if len(deleted) != 0 {
+		t.Fatalf("published %d TaskDeleted events, want 0", len(deleted))
+	}
+}
+
+func TestHandleTaskGroupChange_InsertThenDelete(t *testing.T) {
+	w := newTestWatcher()
+	created := events.Subscribe(w.eventBus, events.TaskGroupCreatedTopic)
+	deleted := events.Subscribe(w.eventBus, events.TaskGroupDeletedTopic)
+	taskGroup := &models.TaskGroup{ID: primitive.NewObjectID(), UUID: "group-1"}
+	doc, err := bson.Marshal(taskGroup)
+	if err != nil {
+		t.Fatalf("marshal task group: %v", err)
+	}
+
+	insert := changeEvent{OperationType: "insert", FullDocument: doc}
+	insert.DocumentKey.ID = taskGroup.ID
+	w.handleTaskGroupChange(insert)
+	remove := changeEvent{OperationType: "delete"}
+	remove.DocumentKey.ID = taskGroup.ID
+	w.handleTaskGroupChange(remove)
+
+	if len(created) != 1 {
+		t.Fatalf("published %d TaskGroupCreated events, want 1", len(created))
+	}
+	select {
+	case payload := <-deleted:
+		if payload.TaskGroupUUID != "group-1" {
+			t.Fatalf("deleted %s, want group-1", payload.TaskGroupUUID)
+		}
+	default:
+		t.Fatal("TaskGroupDeleted not published")
+	}
+}
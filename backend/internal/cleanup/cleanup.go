@@ -0,0 +1,200 @@
+// Package cleanup plans and applies bulk deletions for the admin cleanup command. A Plan lists everything that
+// would be deleted, so it can be reviewed before Apply touches the database.
+package cleanup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/yourusername/cron-observer/backend/internal/deletequeue"
+	"github.com/yourusername/cron-observer/backend/internal/deleteworker"
+	"github.com/yourusername/cron-observer/backend/internal/models"
+	"github.com/yourusername/cron-observer/backend/internal/repositories"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// ErrNothingToDo is returned by NewPlan when the options select no deletion at all
+var ErrNothingToDo = errors.New("nothing to clean up: set kept projects or an execution cutoff")
+
+// Options selects what a cleanup deletes
+type Options struct {
+	// KeepProjects are the projects that survive; every other project is deleted with its task groups, tasks,
+	// executions, stats, settings and templates. Empty deletes no project.
+	KeepProjects []primitive.ObjectID
+
+	// DeleteExecutionsBefore deletes executions of the remaining projects that started before it. Zero keeps them.
+	DeleteExecutionsBefore time.Time
+}
+
+// ProjectDeletion is a project the plan deletes entirely
+type ProjectDeletion struct {
+	Project    *models.Project
+	TaskGroups int
+	Tasks      int
+	Executions int64
+}
+
+// ExecutionDeletion is the old executions the plan deletes from a project that is kept
+type ExecutionDeletion struct {
+	Project    *models.Project
+	Executions int64
+	taskUUIDs  []string
+}
+
+// Plan is what a cleanup will delete
+type Plan struct {
+	Projects               []ProjectDeletion
+	Executions             []ExecutionDeletion
+	DeleteExecutionsBefore time.Time
+}
+
+// Result counts what Apply deleted
+type Result struct {
+	Projects   int
+	Executions int64
+}
+
+// NewPlan works out what the options delete. Kept projects must exist, so a mistyped ID cannot turn into
+// deleting the project it was meant to keep.
+func NewPlan(ctx context.Context, repo repositories.Repository, opts Options) (*Plan, error) {
+	if len(opts.KeepProjects) == 0 && opts.DeleteExecutionsBefore.IsZero() {
+		return nil, ErrNothingToDo
+	}
+
+	projects, err := repo.GetAllProjects(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list projects: %w", err)
+	}
+	keep := make(map[primitive.ObjectID]bool, len(opts.KeepProjects))
+	for _, id := range opts.KeepProjects {
+		keep[id] = true
+	}
+	for _, project := range projects {
+		delete(keep, project.ID)
+	}
+	for id := range keep {
+		return nil, fmt.Errorf("project %s to keep does not exist", id.Hex())
+	}
+	for _, id := range opts.KeepProjects {
+		keep[id] = true
+	}
+
+	plan := &Plan{DeleteExecutionsBefore: opts.DeleteExecutionsBefore}
+	for _, project := range projects {
+		tasks, err := repo.GetTasksByProjectID(ctx, project.ID)
+		if err != nil {
+			return nil, fmt.Errorf("list tasks of project %s: %w", project.ID.Hex(), err)
+		}
+
+		if len(opts.KeepProjects) > 0 && !keep[project.ID] {
+			groups, err := repo.GetTaskGroupsByProjectID(ctx, project.ID)
+			if err != nil {
+				return nil, fmt.Errorf("list task groups of project %s: %w", project.ID.Hex(), err)
+			}
+			executions, err := countExecutions(ctx, repo, tasks, nil)
+			if err != nil {
+				return nil, err
+			}
+			plan.Projects = append(plan.Projects, ProjectDeletion{Project: project, TaskGroups: len(groups), Tasks: len(tasks), Executions: executions})
+			continue
+		}
+
+		if opts.DeleteExecutionsBefore.IsZero() {
+			continue
+		}
+		executions, err := countExecutions(ctx, repo, tasks, &opts.DeleteExecutionsBefore)
+		if err != nil {
+			return nil, err
+		}
+		if executions == 0 {
+			continue
+		}
+		taskUUIDs := make([]string, 0, len(tasks))
+		for _, task := range tasks {
+			taskUUIDs = append(taskUUIDs, task.UUID)
+		}
+		plan.Executions = append(plan.Executions, ExecutionDeletion{Project: project, Executions: executions, taskUUIDs: taskUUIDs})
+	}
+	return plan, nil
+}
+
+// countExecutions counts the executions of the tasks, only those started before the cutoff when one is given
+func countExecutions(ctx context.Context, repo repositories.Repository, tasks []*models.Task, before *time.Time) (int64, error) {
+	var endDate *time.Time
+	if before != nil {
+		// The end date is inclusive and executions are stored with millisecond precision
+		lastIncluded := before.Add(-time.Millisecond)
+		endDate = &lastIncluded
+	}
+
+	var total int64
+	for _, task := range tasks {
+		_, count, err := repo.GetExecutionsByTaskUUIDPaginated(ctx, task.UUID, nil, endDate, 1, 1)
+		if err != nil {
+			return 0, fmt.Errorf("count executions of task %s: %w", task.UUID, err)
+		}
+		total += count
+	}
+	return total, nil
+}
+
+// Empty reports whether the plan deletes nothing
+func (p *Plan) Empty() bool {
+	return len(p.Projects) == 0 && len(p.Executions) == 0
+}
+
+// WriteSummary writes a human-readable list of everything the plan deletes
+func (p *Plan) WriteSummary(w io.Writer) {
+	if p.Empty() {
+		fmt.Fprintln(w, "Nothing to delete.")
+		return
+	}
+
+	if len(p.Projects) > 0 {
+		fmt.Fprintf(w, "Projects to delete (%d):\n", len(p.Projects))
+		for _, d := range p.Projects {
+			fmt.Fprintf(w, "  - %s %q: %d task groups, %d tasks, %d executions\n",
+				d.Project.ID.Hex(), d.Project.Name, d.TaskGroups, d.Tasks, d.Executions)
+		}
+	}
+	if len(p.Executions) > 0 {
+		fmt.Fprintf(w, "Executions started before %s to delete:\n", p.DeleteExecutionsBefore.UTC().Format(time.RFC3339))
+		for _, d := range p.Executions {
+			fmt.Fprintf(w, "  - %s %q: %d executions\n", d.Project.ID.Hex(), d.Project.Name, d.Executions)
+		}
+	}
+}
+
+// Apply carries out the plan. Projects are removed the way the delete worker removes them; a failure stops the
+// cleanup and leaves the failed project PENDING_DELETE, so the delete reconciler or a rerun finishes it.
+func Apply(ctx context.Context, repo repositories.Repository, plan *Plan) (Result, error) {
+	var result Result
+	worker := deleteworker.NewWorker(repo, nil, nil)
+
+	for _, d := range plan.Projects {
+		if err := repo.UpdateProjectStatus(ctx, d.Project.ID, models.ProjectStatusPendingDelete); err != nil {
+			return result, fmt.Errorf("mark project %s for deletion: %w", d.Project.ID.Hex(), err)
+		}
+		msg := deletequeue.DeleteProjectMessage{
+			Kind:        deletequeue.DeleteJobKindProject,
+			ProjectID:   d.Project.ID.Hex(),
+			RequestedAt: time.Now(),
+		}
+		if err := worker.ProcessDeleteProject(ctx, msg); err != nil {
+			return result, fmt.Errorf("delete project %s: %w", d.Project.ID.Hex(), err)
+		}
+		result.Projects++
+	}
+
+	for _, d := range plan.Executions {
+		deleted, err := repo.DeleteExecutionsByTaskUUIDsBefore(ctx, d.taskUUIDs, plan.DeleteExecutionsBefore)
+		result.Executions += deleted
+		if err != nil {
+			return result, fmt.Errorf("delete executions of project %s: %w", d.Project.ID.Hex(), err)
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,114 @@
+package cleanup
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/yourusername/cron-observer/backend/internal/models"
+	"github.com/yourusername/cron-observer/backend/internal/repositories"
+	"github.com/yourusername/cron-observer/backend/internal/seed"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var now = time.Now().UTC().Truncate(time.Hour)
+
+// seedProjects creates two demo projects with a week of history each
+func seedProjects(t *testing.T, repo repositories.Repository) (kept, other *models.Project) {
+	t.Helper()
+	var projects []*models.Project
+	for _, name := range []string{"Kept", "Other"} {
+		result, err := seed.Demo(context.Background(), repo, seed.Options{ProjectName: name, Days: 7, Now: now})
+		if err != nil {
+			t.Fatalf("seed %s: %v", name, err)
+		}
+		projects = append(projects, result.Project)
+	}
+	return projects[0], projects[1]
+}
+
+func countExecutionsOf(t *testing.T, repo repositories.Repository, projectID primitive.ObjectID) int {
+	t.Helper()
+	tasks, _ := repo.GetTasksByProjectID(context.Background(), projectID)
+	total := 0
+	for _, task := range tasks {
+		executions, _ := repo.GetExecutionsByTaskUUID(context.Background(), task.UUID, nil, nil)
+		total += len(executions)
+	}
+	return total
+}
+
+func TestCleanup_DeletesAllButKeptProjects(t *testing.T) {
+	ctx := context.Background()
+	repo := repositories.NewMemoryRepository()
+	kept, other := seedProjects(t, repo)
+	keptExecutions := countExecutionsOf(t, repo, kept.ID)
+
+	plan, err := NewPlan(ctx, repo, Options{KeepProjects: []primitive.ObjectID{kept.ID}})
+	if err != nil {
+		t.Fatalf("NewPlan: %v", err)
+	}
+	if len(plan.Projects) != 1 || plan.Projects[0].Project.ID != other.ID || plan.Projects[0].Tasks != 8 || len(plan.Executions) != 0 {
+		t.Fatalf("unexpected plan %+v", plan)
+	}
+	var summary bytes.Buffer
+	plan.WriteSummary(&summary)
+	if !strings.Contains(summary.String(), `"Other": 3 task groups, 8 tasks`) {
+		t.Fatalf("summary does not list the project:\n%s", summary.String())
+	}
+
+	result, err := Apply(ctx, repo, plan)
+	if err != nil || result.Projects != 1 {
+		t.Fatalf("Apply = %+v, %v", result, err)
+	}
+	if _, err := repo.GetProjectByID(ctx, other.ID); err == nil {
+		t.Fatal("other project still exists")
+	}
+	if tasks, _ := repo.GetTasksByProjectID(ctx, other.ID); len(tasks) != 0 {
+		t.Fatalf("%d tasks of the deleted project remain", len(tasks))
+	}
+	if got := countExecutionsOf(t, repo, kept.ID); got != keptExecutions {
+		t.Fatalf("kept project has %d executions, want %d", got, keptExecutions)
+	}
+}
+
+func TestCleanup_DeletesOldExecutions(t *testing.T) {
+	ctx := context.Background()
+	repo := repositories.NewMemoryRepository()
+	kept, other := seedProjects(t, repo)
+	cutoff := now.AddDate(0, 0, -3)
+
+	plan, err := NewPlan(ctx, repo, Options{DeleteExecutionsBefore: cutoff})
+	if err != nil {
+		t.Fatalf("NewPlan: %v", err)
+	}
+	if len(plan.Projects) != 0 || len(plan.Executions) != 2 {
+		t.Fatalf("unexpected plan %+v", plan)
+	}
+	planned := plan.Executions[0].Executions + plan.Executions[1].Executions
+	before := countExecutionsOf(t, repo, kept.ID) + countExecutionsOf(t, repo, other.ID)
+
+	result, err := Apply(ctx, repo, plan)
+	if err != nil || result.Executions != planned {
+		t.Fatalf("Apply = %+v, %v, want %d executions deleted", result, err, planned)
+	}
+	after := countExecutionsOf(t, repo, kept.ID) + countExecutionsOf(t, repo, other.ID)
+	if int64(before-after) != planned {
+		t.Fatalf("%d executions deleted, planned %d", before-after, planned)
+	}
+}
+
+func TestNewPlan_RejectsUnknownKeptProject(t *testing.T) {
+	repo := repositories.NewMemoryRepository()
+	seedProjects(t, repo)
+
+	if _, err := NewPlan(context.Background(), repo, Options{KeepProjects: []primitive.ObjectID{primitive.NewObjectID()}}); err == nil {
+		t.Fatal("expected an error for a kept project that does not exist")
+	}
+	if _, err := NewPlan(context.Background(), repo, Options{}); !errors.Is(err, ErrNothingToDo) {
+		t.Fatalf("NewPlan without options = %v, want ErrNothingToDo", err)
+	}
+}
@@ -4,11 +4,22 @@ import "time"
 
 // Config holds all configuration for the application
 type Config struct {
-	Server   ServerConfig
-	Database DatabaseConfig
-	Auth     AuthConfig
-	Gmail    GmailConfig
-	Broker   BrokerConfig
+	Server    ServerConfig
+	Database  DatabaseConfig
+	Auth      AuthConfig
+	Gmail     GmailConfig
+	Broker    BrokerConfig
+	Invite    InviteConfig
+	RateLimit RateLimitConfig
+	Quota     QuotaConfig
+	Metering  MeteringConfig
+	Retention RetentionConfig
+	Secrets   SecretsConfig
+	Scheduler SchedulerConfig
+	Events    EventsConfig
+	Cache     CacheConfig
+	Integrity IntegrityConfig
+	Log       LogConfig
 }
 
 // ServerConfig holds HTTP server configuration
@@ -16,6 +27,21 @@ type ServerConfig struct {
 	Port         string        `mapstructure:"port"`
 	ReadTimeout  time.Duration `mapstructure:"read_timeout"`
 	WriteTimeout time.Duration `mapstructure:"write_timeout"`
+
+	// ShutdownTimeout bounds how long shutdown waits for requests, dispatches and queue jobs in progress
+	ShutdownTimeout time.Duration `mapstructure:"shutdown_timeout"`
+
+	// GRPCPort is the port of the gRPC SDK API; empty disables it
+	GRPCPort string `mapstructure:"grpc_port"`
+
+	// TLS: HTTPS with the certificate in TLSCertFile/TLSKeyFile, or with certificates obtained from Let's Encrypt for
+	// TLSAutocertDomains. Plain HTTP when neither is set, e.g. behind a load balancer that terminates TLS.
+	TLSCertFile         string `mapstructure:"tls_cert_file"`
+	TLSKeyFile          string `mapstructure:"tls_key_file"`
+	TLSAutocertDomains  string `mapstructure:"tls_autocert_domains"`   // Comma-separated host names the certificates are requested for
+	TLSAutocertEmail    string `mapstructure:"tls_autocert_email"`     // Contact address for the ACME account; optional
+	TLSAutocertCacheDir string `mapstructure:"tls_autocert_cache_dir"` // Where obtained certificates are kept across restarts
+	TLSHTTPPort         string `mapstructure:"tls_http_port"`          // Plain HTTP port redirecting to HTTPS and answering ACME challenges; empty disables it
 }
 
 // DatabaseConfig holds database connection configuration
@@ -24,12 +50,42 @@ type DatabaseConfig struct {
 	Name     string        `mapstructure:"name"`
 	Timeout  time.Duration `mapstructure:"timeout"`
 	MaxConns int           `mapstructure:"max_conns"`
+
+	Driver string `mapstructure:"driver"` // "mongodb", or "memory" to keep all data in process memory (local demos; lost on restart)
+
+	PartitionExecutions      bool `mapstructure:"partition_executions"`       // Store executions in monthly collections (executions_2025_01, ...)
+	PartitionRetentionMonths int  `mapstructure:"partition_retention_months"` // Full months of partitions kept before the current one; 0 keeps all
+
+	MigrateOnStartup bool `mapstructure:"migrate_on_startup"` // Apply pending data migrations before serving; otherwise run `migrate up`
+
+	RetryWrites bool `mapstructure:"retry_writes"` // Retry reads and writes once after transient network errors and failovers
+
+	RetryMaxAttempts int           `mapstructure:"retry_max_attempts"` // Attempts per repository operation on transient errors; 1 disables retries
+	RetryBaseDelay   time.Duration `mapstructure:"retry_base_delay"`   // Delay before the first retry; doubled for each further retry
+	RetryMaxDelay    time.Duration `mapstructure:"retry_max_delay"`    // Upper bound on the delay between retries
+
+	AnalyticsReadPreference string `mapstructure:"analytics_read_preference"` // Read preference of statistics, failure stats and export queries, e.g. secondaryPreferred
 }
 
+// DatabaseDriverMemory selects repositories.MemoryRepository instead of MongoDB
+const DatabaseDriverMemory = "memory"
+
 // AuthConfig holds authentication configuration
 type AuthConfig struct {
-	JWTSecret   string   `mapstructure:"jwt_secret"`
-	SuperAdmins []string `mapstructure:"super_admins"` // Comma-separated list of super admin emails
+	JWTSecret   string            `mapstructure:"jwt_secret"`
+	JWTSecrets  map[string]string `mapstructure:"-"`            // Additional secrets by kid, from JWT_SECRETS=kid1=secret1,kid2=secret2
+	SuperAdmins []string          `mapstructure:"super_admins"` // Comma-separated list of super admin emails
+
+	// TokenMaxLifetime bounds how long an issued token can stay valid; revocation entries are kept this long
+	TokenMaxLifetime time.Duration `mapstructure:"token_max_lifetime"`
+	// RevocationCacheTTL is how long revocation checks are cached; other servers accept a revoked token this long
+	RevocationCacheTTL time.Duration `mapstructure:"revocation_cache_ttl"`
+
+	// OIDC provider (Auth0, Keycloak, Azure AD, ...). RS256 tokens are accepted only when OIDCIssuerURL is set.
+	OIDCIssuerURL       string        `mapstructure:"oidc_issuer_url"`
+	OIDCAudience        string        `mapstructure:"oidc_audience"`
+	OIDCJWKSURL         string        `mapstructure:"oidc_jwks_url"` // Optional; discovered from the issuer when empty
+	OIDCRefreshInterval time.Duration `mapstructure:"oidc_refresh_interval"`
 }
 
 // GmailConfig holds Gmail SMTP configuration
@@ -44,4 +100,118 @@ type BrokerConfig struct {
 	DeleteQueueName   string        `mapstructure:"delete_queue_name"`
 	ReconcilerInterval time.Duration `mapstructure:"reconciler_interval"`
 	ReconcilerThreshold time.Duration `mapstructure:"reconciler_threshold"`
+
+	// Failed jobs are retried after RetryDelay and parked in the dead-letter queue after MaxAttempts (RabbitMQ)
+	MaxAttempts         int           `mapstructure:"max_attempts"`
+	RetryDelay          time.Duration `mapstructure:"retry_delay"`
+	DeadLetterQueueName string        `mapstructure:"dead_letter_queue_name"` // Defaults to "<DeleteQueueName>.dead"
+
+	// Jobs processed in parallel by each backend replica, and jobs RabbitMQ delivers ahead (at least the workers)
+	ConsumerWorkers  int `mapstructure:"consumer_workers"`
+	ConsumerPrefetch int `mapstructure:"consumer_prefetch"`
+
+	Driver string `mapstructure:"driver"` // Job queue broker: "rabbitmq", "sqs" or "redis" for deployments that can't run RabbitMQ, "inprocess" for a single node
+
+	// AWS SQS; credentials come from the AWS SDK's default chain
+	SQSQueueURL           string        `mapstructure:"sqs_queue_url"`
+	SQSRegion             string        `mapstructure:"sqs_region"`
+	SQSDeadLetterQueueURL string        `mapstructure:"sqs_dead_letter_queue_url"` // Receives messages that can never be processed; retries exhausted by the queue's redrive policy land there too
+	SQSVisibilityTimeout  time.Duration `mapstructure:"sqs_visibility_timeout"`    // How long a received job stays hidden from other consumers; extended while the job runs
+	SQSRetryDelay         time.Duration `mapstructure:"sqs_retry_delay"`           // How long a failed job stays hidden before it is received again
+
+	// Redis Streams; the stream is named after DeleteQueueName
+	RedisURL           string        `mapstructure:"redis_url"`            // Defaults to the cache's Redis URL
+	RedisConsumerGroup string        `mapstructure:"redis_consumer_group"` // Consumer group shared by all backend replicas
+	RedisClaimMinIdle  time.Duration `mapstructure:"redis_claim_min_idle"` // How long a job stays unacknowledged before it is retried
+	RedisMaxDeliveries int64         `mapstructure:"redis_max_deliveries"` // Deliveries before a job is moved to the "<stream>:dead" stream
+}
+
+// Job queue brokers selectable with BrokerConfig.Driver
+const (
+	BrokerDriverRabbitMQ = "rabbitmq"
+	BrokerDriverSQS      = "sqs"
+	BrokerDriverRedis    = "redis"
+
+	// BrokerDriverInProcess runs jobs inside the backend, persisted in the database; also used when AMQPURL is empty
+	BrokerDriverInProcess = "inprocess"
+)
+
+// InviteConfig holds project invitation configuration
+type InviteConfig struct {
+	SigningSecret string        `mapstructure:"signing_secret"` // HMAC key for invite tokens; falls back to JWT_SECRET when empty
+	AcceptURL     string        `mapstructure:"accept_url"`     // UI page that accepts invites; the token is appended as ?token=
+	TTL           time.Duration `mapstructure:"ttl"`
+}
+
+// RateLimitConfig holds default per-project quotas for SDK endpoints
+type RateLimitConfig struct {
+	LogAppendsPerMinute    int `mapstructure:"log_appends_per_minute"`
+	StatusUpdatesPerMinute int `mapstructure:"status_updates_per_minute"`
+}
+
+// QuotaConfig holds default per-project quotas; organizations and projects can override them. 0 means unlimited.
+type QuotaConfig struct {
+	MaxTasksPerProject      int `mapstructure:"max_tasks_per_project"`
+	MaxExecutionsPerDay     int `mapstructure:"max_executions_per_day"`      // Per UTC day, counting scheduled, triggered and reported executions
+	MaxLogBytesPerExecution int `mapstructure:"max_log_bytes_per_execution"` // Total size of the log messages of one execution
+}
+
+// MeteringConfig holds usage metering configuration
+type MeteringConfig struct {
+	FlushInterval time.Duration `mapstructure:"flush_interval"` // How often metered usage is written to the usage collection
+}
+
+// RetentionConfig holds the default retention of execution logs; projects can override it. 0 keeps logs forever.
+type RetentionConfig struct {
+	LogSuccessDays int `mapstructure:"log_success_days"` // Logs of successful executions older than this are removed; the executions are kept
+	LogFailureDays int `mapstructure:"log_failure_days"` // Logs of failed executions older than this are removed; usually longer, for postmortems
+}
+
+// LogConfig holds logging configuration
+type LogConfig struct {
+	Level string `mapstructure:"level"` // info, warn or error; lines below it are dropped (see the logging package)
+}
+
+// SecretsConfig holds configuration for encrypted project secrets
+type SecretsConfig struct {
+	MasterKey string `mapstructure:"master_key"` // Base64-encoded 32-byte key; secrets are disabled when empty
+}
+
+// SchedulerConfig holds cron scheduler configuration
+type SchedulerConfig struct {
+	DispatchWorkers int `mapstructure:"dispatch_workers"` // Firings dispatched concurrently; queued firings wait in task priority order
+
+	// IANA timezone of cron expressions without a timezone of their own; task group windows are converted to it
+	Timezone string `mapstructure:"timezone"`
+
+	// How often task group window jobs are compared with the database and repaired; 0 disables it
+	GroupWindowReconcileInterval time.Duration `mapstructure:"group_window_reconcile_interval"`
+}
+
+// IntegrityConfig holds configuration of the referential-integrity reconciler
+type IntegrityConfig struct {
+	Interval time.Duration `mapstructure:"interval"` // How often dangling references are looked for; 0 disables it
+	Policy   string        `mapstructure:"policy"`   // flag (only log them), reassign (detach tasks of deleted groups) or clean (also delete orphaned tasks, executions and stats)
+}
+
+// EventsConfig holds internal event bus configuration
+type EventsConfig struct {
+	OutboxEnabled      bool          `mapstructure:"outbox_enabled"`       // Persist events in MongoDB so they survive full subscriber channels and restarts
+	OutboxPollInterval time.Duration `mapstructure:"outbox_poll_interval"` // How often undelivered events are retried
+
+	OverflowPolicy       string        `mapstructure:"overflow_policy"`        // log, block or spill: what happens to events for a subscriber whose channel is full
+	OverflowBlockTimeout time.Duration `mapstructure:"overflow_block_timeout"` // How long publishers wait for a full channel under the block policy
+	OverflowSpillDir     string        `mapstructure:"overflow_spill_dir"`     // Where the spill policy queues events; defaults to the system temp directory
+
+	BrokerEnabled  bool   `mapstructure:"broker_enabled"`  // Send task, task group and project events through RabbitMQ (Broker.AMQPURL) so every replica's scheduler sees them
+	BrokerExchange string `mapstructure:"broker_exchange"` // Topic exchange the events are published to
+
+	ChangeStreamEnabled bool `mapstructure:"change_stream_enabled"` // Derive task and task group events from MongoDB change streams; requires a replica set
+}
+
+// CacheConfig holds configuration of the Redis cache for hot repository reads
+type CacheConfig struct {
+	RedisURL  string        `mapstructure:"redis_url"`  // redis://host:port/db; caching is disabled when empty
+	TTL       time.Duration `mapstructure:"ttl"`        // Upper bound on how stale a cached project, task or settings document can be
+	KeyPrefix string        `mapstructure:"key_prefix"` // Namespaces cache keys within the Redis database
 }
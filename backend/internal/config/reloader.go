@@ -0,0 +1,160 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"reflect"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"syscall"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/yourusername/cron-observer/backend/internal/logging"
+)
+
+// ReloadFunc applies reloaded configuration to a component. It must not modify cfg.
+type ReloadFunc func(cfg *Config)
+
+// reloadableSetting is a part of the configuration that can change while the server runs
+type reloadableSetting struct {
+	key  string
+	get  func(cfg *Config) interface{}
+	copy func(dst, src *Config)
+}
+
+// reloadableSettings are the settings Reload applies; the others keep their startup value until a restart
+var reloadableSettings = []reloadableSetting{
+	{
+		key:  "auth.super_admins",
+		get:  func(cfg *Config) interface{} { return cfg.Auth.SuperAdmins },
+		copy: func(dst, src *Config) { dst.Auth.SuperAdmins = src.Auth.SuperAdmins },
+	},
+	{
+		key:  "gmail",
+		get:  func(cfg *Config) interface{} { return cfg.Gmail },
+		copy: func(dst, src *Config) { dst.Gmail = src.Gmail },
+	},
+	{
+		key:  "rate_limit",
+		get:  func(cfg *Config) interface{} { return cfg.RateLimit },
+		copy: func(dst, src *Config) { dst.RateLimit = src.RateLimit },
+	},
+	{
+		key:  "log.level",
+		get:  func(cfg *Config) interface{} { return cfg.Log.Level },
+		copy: func(dst, src *Config) { dst.Log.Level = src.Log.Level },
+	},
+	{
+		key:  "quota",
+		get:  func(cfg *Config) interface{} { return cfg.Quota },
+		copy: func(dst, src *Config) { dst.Quota = src.Quota },
+	},
+}
+
+// Reloader re-reads the configuration while the server runs, on SIGHUP, when the .env file changes or on demand,
+// and hands the reloadable settings (super admins, Gmail credentials of alert emails, default rate limits, the log
+// level and the default quotas) to the components registered with OnReload. The scheduler and the connections are not restarted.
+type Reloader struct {
+	load    func() (*Config, error)
+	current atomic.Pointer[Config]
+
+	mu    sync.Mutex // serializes reloads
+	hooks []ReloadFunc
+}
+
+// NewReloader creates a reloader starting from the configuration loaded at startup
+func NewReloader(cfg *Config) *Reloader {
+	r := &Reloader{load: Load}
+	r.current.Store(cfg)
+	return r
+}
+
+// Current returns the configuration in effect. Safe to call from any goroutine; the result must not be modified.
+func (r *Reloader) Current() *Config {
+	return r.current.Load()
+}
+
+// OnReload registers fn to be called with the new configuration after each reload that changed a setting
+func (r *Reloader) OnReload(fn ReloadFunc) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.hooks = append(r.hooks, fn)
+}
+
+// Reload reads the configuration again and applies the reloadable settings that changed, returning their keys.
+// A configuration that fails to load or validate is rejected and the current one stays in effect.
+func (r *Reloader) Reload() ([]string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	loaded, err := r.load()
+	if err != nil {
+		return nil, fmt.Errorf("load configuration: %w", err)
+	}
+	if _, err := logging.ParseLevel(loaded.Log.Level); err != nil {
+		return nil, err
+	}
+
+	current := r.current.Load()
+	next := *current
+	var changed []string
+	for _, setting := range reloadableSettings {
+		if !reflect.DeepEqual(setting.get(current), setting.get(loaded)) {
+			setting.copy(&next, loaded)
+			changed = append(changed, setting.key)
+		}
+	}
+	if len(changed) == 0 {
+		log.Printf("[config] Configuration reloaded, nothing changed")
+		return nil, nil
+	}
+
+	r.current.Store(&next)
+	for _, hook := range r.hooks {
+		hook(&next)
+	}
+	log.Printf("[config] Configuration reloaded, applied %s", strings.Join(changed, ", "))
+	return changed, nil
+}
+
+// WatchSignals reloads the configuration on every SIGHUP until ctx is done
+func (r *Reloader) WatchSignals(ctx context.Context) {
+	hangups := make(chan os.Signal, 1)
+	signal.Notify(hangups, syscall.SIGHUP)
+	defer signal.Stop(hangups)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-hangups:
+			log.Printf("[config] SIGHUP received, reloading configuration")
+			if _, err := r.Reload(); err != nil {
+				log.Printf("[config] Failed to reload configuration: %v", err)
+			}
+		}
+	}
+}
+
+// WatchFile reloads the configuration whenever the .env file changes. Returns false when there is no .env file
+// to watch; environment variables can only be changed by a restart.
+func (r *Reloader) WatchFile() bool {
+	v := newViper()
+	if v.ConfigFileUsed() == "" {
+		return false
+	}
+
+	v.OnConfigChange(func(event fsnotify.Event) {
+		log.Printf("[config] %s changed, reloading configuration", event.Name)
+		if _, err := r.Reload(); err != nil {
+			log.Printf("[config] Failed to reload configuration: %v", err)
+		}
+	})
+	v.WatchConfig()
+	log.Printf("[config] Watching %s for changes", v.ConfigFileUsed())
+	return true
+}
@@ -0,0 +1,80 @@
+package config
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestReloader_AppliesOnlyReloadableSettings(t *testing.T) {
+	startup := &Config{
+		Server:    ServerConfig{Port: "8080"},
+		Auth:      AuthConfig{SuperAdmins: []string{"root@example.com"}},
+		RateLimit: RateLimitConfig{LogAppendsPerMinute: 600, StatusUpdatesPerMinute: 120},
+		Log:       LogConfig{Level: "info"},
+	}
+	reloaded := &Config{
+		Server:    ServerConfig{Port: "9090"},
+		Auth:      AuthConfig{SuperAdmins: []string{"root@example.com", "ops@example.com"}},
+		RateLimit: RateLimitConfig{LogAppendsPerMinute: 600, StatusUpdatesPerMinute: 60},
+		Log:       LogConfig{Level: "info"},
+	}
+
+	r := NewReloader(startup)
+	r.load = func() (*Config, error) { return reloaded, nil }
+
+	var applied *Config
+	r.OnReload(func(cfg *Config) { applied = cfg })
+
+	changed, err := r.Reload()
+	if err != nil {
+		t.Fatalf("Reload() error = %v", err)
+	}
+	if want := []string{"auth.super_admins", "rate_limit"}; !reflect.DeepEqual(changed, want) {
+		t.Errorf("Reload() changed = %v, want %v", changed, want)
+	}
+
+	current := r.Current()
+	if applied != current {
+		t.Error("hook was not called with the current configuration")
+	}
+	if len(current.Auth.SuperAdmins) != 2 || current.RateLimit.StatusUpdatesPerMinute != 60 {
+		t.Errorf("reloadable settings not applied: %+v %+v", current.Auth, current.RateLimit)
+	}
+	if current.Server.Port != "8080" {
+		t.Errorf("Server.Port = %s, want the startup value 8080", current.Server.Port)
+	}
+	if startup.RateLimit.StatusUpdatesPerMinute != 120 {
+		t.Error("Reload() modified the startup configuration")
+	}
+}
+
+func TestReloader_NothingChanged(t *testing.T) {
+	cfg := &Config{Log: LogConfig{Level: "info"}}
+	r := NewReloader(cfg)
+	r.load = func() (*Config, error) { return &Config{Log: LogConfig{Level: "info"}}, nil }
+
+	called := false
+	r.OnReload(func(*Config) { called = true })
+
+	changed, err := r.Reload()
+	if err != nil || len(changed) != 0 {
+		t.Errorf("Reload() = %v, %v, want nothing changed", changed, err)
+	}
+	if called || r.Current() != cfg {
+		t.Error("configuration replaced although nothing changed")
+	}
+}
+
+func TestReloader_KeepsCurrentOnError(t *testing.T) {
+	cfg := &Config{Log: LogConfig{Level: "info"}}
+	r := NewReloader(cfg)
+	r.load = func() (*Config, error) { return nil, errors.New("missing DATABASE_URI") }
+
+	if _, err := r.Reload(); err == nil {
+		t.Fatal("expected an error")
+	}
+	if r.Current() != cfg {
+		t.Error("configuration replaced after a failed reload")
+	}
+}
@@ -9,12 +9,35 @@ import (
 func (c *Config) Validate() error {
 	var missing []string
 
-	// Check required database fields
-	if c.Database.URI == "" {
-		missing = append(missing, "DATABASE_URI")
+	// Check required database fields; the in-memory store needs no connection
+	if c.Database.Driver != DatabaseDriverMemory {
+		if c.Database.URI == "" {
+			missing = append(missing, "DATABASE_URI")
+		}
+		if c.Database.Name == "" {
+			missing = append(missing, "DATABASE_NAME")
+		}
+	}
+
+	// SQS and Redis have no default address, unlike RabbitMQ
+	if c.Broker.Driver == BrokerDriverSQS {
+		if c.Broker.SQSQueueURL == "" {
+			missing = append(missing, "SQS_QUEUE_URL")
+		}
+		if c.Broker.SQSRegion == "" {
+			missing = append(missing, "SQS_REGION")
+		}
+	}
+	if c.Broker.Driver == BrokerDriverRedis && c.Broker.RedisURL == "" {
+		missing = append(missing, "JOB_QUEUE_REDIS_URL")
+	}
+
+	// A certificate is useless without its key and the other way round
+	if c.Server.TLSCertFile != "" && c.Server.TLSKeyFile == "" {
+		missing = append(missing, "SERVER_TLS_KEY_FILE")
 	}
-	if c.Database.Name == "" {
-		missing = append(missing, "DATABASE_NAME")
+	if c.Server.TLSKeyFile != "" && c.Server.TLSCertFile == "" {
+		missing = append(missing, "SERVER_TLS_CERT_FILE")
 	}
 
 	if len(missing) > 0 {
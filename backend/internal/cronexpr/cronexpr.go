@@ -0,0 +1,95 @@
+// Package cronexpr parses and describes task cron expressions exactly as the scheduler evaluates them.
+package cronexpr
+
+import (
+	"strings"
+	"time"
+
+	"github.com/robfig/cron/v3"
+)
+
+// Parser is the parser the scheduler's cron engine uses: standard five-field expressions, six fields with
+// a leading seconds field, and descriptors such as @daily
+var Parser = cron.NewParser(cron.SecondOptional | cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow | cron.Descriptor)
+
+// Parse parses an expression, including an optional CRON_TZ= or TZ= prefix
+func Parse(expression string) (cron.Schedule, error) {
+	return Parser.Parse(expression)
+}
+
+// WithTimezone prefixes a cron expression with CRON_TZ so it is evaluated in the given timezone.
+// Expressions that already carry a timezone prefix, or an empty timezone, are returned unchanged.
+func WithTimezone(expression, timezone string) string {
+	if timezone == "" || hasTimezonePrefix(expression) {
+		return expression
+	}
+	return "CRON_TZ=" + timezone + " " + expression
+}
+
+// NextRuns returns up to n fire times of the schedule after from. Schedules that never fire return fewer.
+func NextRuns(schedule cron.Schedule, from time.Time, n int) []time.Time {
+	runs := make([]time.Time, 0, n)
+	next := from
+	for len(runs) < n {
+		next = schedule.Next(next)
+		if next.IsZero() {
+			break
+		}
+		runs = append(runs, next)
+	}
+	return runs
+}
+
+// RunsBetween returns the fire times of the schedule from from through to, both inclusive, up to limit of them.
+// The second result reports whether fire times were left out because of the limit.
+func RunsBetween(schedule cron.Schedule, from, to time.Time, limit int) ([]time.Time, bool) {
+	var runs []time.Time
+	// Next returns times strictly after its argument, so start just before from to include it
+	for next := schedule.Next(from.Add(-time.Nanosecond)); !next.IsZero() && !next.After(to); next = schedule.Next(next) {
+		if len(runs) == limit {
+			return runs, true
+		}
+		runs = append(runs, next)
+	}
+	return runs, false
+}
+
+// prevRunMaxLookback bounds how far back PrevRun searches; enough for yearly schedules
+const prevRunMaxLookback = 2 * 366 * 24 * time.Hour
+
+// PrevRun returns the latest fire time of the schedule at or before t.
+// Returns false if the schedule did not fire within roughly the previous year.
+func PrevRun(schedule cron.Schedule, t time.Time) (time.Time, bool) {
+	// Widen the window until it contains a fire time, then walk forward to the last one before t
+	for lookback := time.Minute; lookback <= prevRunMaxLookback; lookback *= 2 {
+		prev := schedule.Next(t.Add(-lookback))
+		if prev.IsZero() || prev.After(t) {
+			continue
+		}
+		for {
+			next := schedule.Next(prev)
+			if next.IsZero() || next.After(t) {
+				return prev, true
+			}
+			prev = next
+		}
+	}
+	return time.Time{}, false
+}
+
+// hasTimezonePrefix reports whether the expression starts with CRON_TZ= or TZ=
+func hasTimezonePrefix(expression string) bool {
+	return strings.HasPrefix(expression, "CRON_TZ=") || strings.HasPrefix(expression, "TZ=")
+}
+
+// stripTimezone removes a leading CRON_TZ= or TZ= prefix
+func stripTimezone(expression string) string {
+	expression = strings.TrimSpace(expression)
+	if !hasTimezonePrefix(expression) {
+		return expression
+	}
+	if i := strings.IndexByte(expression, ' '); i >= 0 {
+		return strings.TrimSpace(expression[i+1:])
+	}
+	return ""
+}
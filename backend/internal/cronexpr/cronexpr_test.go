@@ -0,0 +1,179 @@
+package cronexpr
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestDescribe(t *testing.T) {
+	cases := []struct {
+		expression string
+		want       string
+	}{
+		{"0 */15 9-16 * * 1-5", "every 15 minutes between 9am and 5pm on weekdays"},
+		{"*/15 9-16 * * 1-5", "every 15 minutes between 9am and 5pm on weekdays"},
+		{"0 2 * * *", "every day at 2:00am"},
+		{"0 30 9 * * *", "every day at 9:30am"},
+		{"0 0 9,17 * * *", "every day at 9:00am and 5:00pm"},
+		{"0 30 6 * * MON", "at 6:30am on Mondays"},
+		{"0 0 0 1 * *", "at 12:00am on day 1 of the month"},
+		{"0 15 * * * *", "at minute 15 of every hour"},
+		{"0 0 */2 * * *", "at minute 0 of every 2 hours"},
+		{"* * * * * *", "every second"},
+		{"0 * * * * *", "every minute"},
+		{"*/10 * * * * *", "every 10 seconds"},
+		{"0 0 8 * 1,7 *", "at 8:00am in January and July"},
+		{"CRON_TZ=Europe/Berlin 0 0 12 * * 0,6", "at 12:00pm on weekends"},
+		{"@hourly", "every hour"},
+		{"@every 5m", "every 5 minutes"},
+		{"@daily", "every day at 12:00am"},
+	}
+
+	for _, tc := range cases {
+		got, err := Describe(tc.expression)
+		if err != nil {
+			t.Errorf("Describe(%q) returned error: %v", tc.expression, err)
+			continue
+		}
+		if got != tc.want {
+			t.Errorf("Describe(%q) = %q, want %q", tc.expression, got, tc.want)
+		}
+	}
+}
+
+func TestDescribe_RejectsInvalidExpressions(t *testing.T) {
+	for _, expression := range []string{"", "* * * *", "0 61 * * * *", "0 0 0 * * * *", "@fortnightly", "not a cron"} {
+		if _, err := Describe(expression); err == nil {
+			t.Errorf("Describe(%q) expected an error", expression)
+		}
+	}
+}
+
+func TestNextRuns_UsesTimezonePrefix(t *testing.T) {
+	schedule, err := Parse(WithTimezone("0 0 9 * * *", "America/New_York"))
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	from := time.Date(2025, 1, 15, 12, 0, 0, 0, time.UTC) // 7am in New York
+	runs := NextRuns(schedule, from, 3)
+	if len(runs) != 3 {
+		t.Fatalf("Expected 3 runs, got %d", len(runs))
+	}
+
+	want := time.Date(2025, 1, 15, 14, 0, 0, 0, time.UTC)
+	for i, run := range runs {
+		if !run.Equal(want.AddDate(0, 0, i)) {
+			t.Errorf("Run %d = %v, want %v", i, run.UTC(), want.AddDate(0, 0, i))
+		}
+	}
+}
+
+func TestRunsBetween_IncludesBoundsAndStopsAtLimit(t *testing.T) {
+	schedule, err := Parse("0 * * * *") // hourly
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	from := time.Date(2025, 1, 15, 9, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 1, 15, 12, 0, 0, 0, time.UTC)
+	runs, truncated := RunsBetween(schedule, from, to, 10)
+	if truncated || len(runs) != 4 || !runs[0].Equal(from) || !runs[3].Equal(to) {
+		t.Errorf("RunsBetween = %v, %v; want 09:00 through 12:00", runs, truncated)
+	}
+
+	runs, truncated = RunsBetween(schedule, from, to, 2)
+	if !truncated || len(runs) != 2 {
+		t.Errorf("RunsBetween with limit 2 = %v, %v; want 2 runs, truncated", runs, truncated)
+	}
+}
+
+func TestPrevRun(t *testing.T) {
+	at := time.Date(2025, 1, 15, 12, 0, 0, 0, time.UTC) // Wednesday
+
+	cases := []struct {
+		expression string
+		want       time.Time
+	}{
+		{"*/5 * * * *", time.Date(2025, 1, 15, 12, 0, 0, 0, time.UTC)},
+		{"0 2 * * *", time.Date(2025, 1, 15, 2, 0, 0, 0, time.UTC)},
+		{"0 0 9 * * *", time.Date(2025, 1, 15, 9, 0, 0, 0, time.UTC)},
+		{"30 6 * * MON", time.Date(2025, 1, 13, 6, 30, 0, 0, time.UTC)},
+		{"0 0 1 3 *", time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tc := range cases {
+		schedule, err := Parse(tc.expression)
+		if err != nil {
+			t.Fatalf("Parse(%q) failed: %v", tc.expression, err)
+		}
+		got, ok := PrevRun(schedule, at)
+		if !ok || !got.Equal(tc.want) {
+			t.Errorf("PrevRun(%q) = %v, %v; want %v", tc.expression, got, ok, tc.want)
+		}
+	}
+}
+
+func TestPrevRun_NeverFired(t *testing.T) {
+	schedule, err := Parse("0 0 30 2 *") // February 30th
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if got, ok := PrevRun(schedule, time.Now()); ok {
+		t.Errorf("Expected no previous run, got %v", got)
+	}
+}
+
+func TestWithTimezone_KeepsExistingPrefix(t *testing.T) {
+	if got := WithTimezone("TZ=UTC 0 0 * * * *", "Asia/Dhaka"); got != "TZ=UTC 0 0 * * * *" {
+		t.Errorf("Expected existing prefix to be kept, got %q", got)
+	}
+	if got := WithTimezone("0 0 * * * *", ""); got != "0 0 * * * *" {
+		t.Errorf("Expected expression unchanged without timezone, got %q", got)
+	}
+}
+
+func TestFromPhrase(t *testing.T) {
+	cases := []struct {
+		phrase string
+		want   string
+	}{
+		{"every 15 minutes", "0 */15 * * * *"},
+		{"Every 30 seconds", "*/30 * * * * *"},
+		{"every 2 hours", "0 0 */2 * * *"},
+		{"every 1 minute", "0 * * * * *"},
+		{"hourly", "0 0 * * * *"},
+		{"daily", "0 0 0 * * *"},
+		{"weekdays at 9am", "0 0 9 * * 1-5"},
+		{"every weekend at noon", "0 0 12 * * 0,6"},
+		{"every monday at 06:30", "0 30 6 * * 1"},
+		{"Mondays and Fridays at 5:30 PM", "0 30 17 * * 1,5"},
+		{"every day at 12am", "0 0 0 * * *"},
+		{"at 18:00", "0 0 18 * * *"},
+		{"at 9am on weekdays", "0 0 9 * * 1-5"},
+		{"every month on the 1st at 9am", "0 0 9 1 * *"},
+	}
+
+	for _, tc := range cases {
+		got, err := FromPhrase(tc.phrase)
+		if err != nil {
+			t.Errorf("FromPhrase(%q) returned error: %v", tc.phrase, err)
+			continue
+		}
+		if got != tc.want {
+			t.Errorf("FromPhrase(%q) = %q, want %q", tc.phrase, got, tc.want)
+		}
+		if _, err := Parse(got); err != nil {
+			t.Errorf("FromPhrase(%q) produced %q which the scheduler rejects: %v", tc.phrase, got, err)
+		}
+	}
+}
+
+func TestFromPhrase_RejectsUnknownPhrases(t *testing.T) {
+	for _, phrase := range []string{"", "sometimes", "every 90 minutes", "weekdays at 25:00", "every funday at 9am", "every month on the 32nd"} {
+		if _, err := FromPhrase(phrase); !errors.Is(err, ErrUnrecognizedSchedule) {
+			t.Errorf("FromPhrase(%q) expected ErrUnrecognizedSchedule, got %v", phrase, err)
+		}
+	}
+}
@@ -0,0 +1,421 @@
+package cronexpr
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var weekdayNames = []string{"Sunday", "Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday"}
+
+var monthNames = []string{"", "January", "February", "March", "April", "May", "June", "July", "August", "September", "October", "November", "December"}
+
+// Describe returns an English description of a cron expression, such as
+// "every 15 minutes between 9am and 5pm on weekdays". A timezone prefix is ignored.
+func Describe(expression string) (string, error) {
+	if _, err := Parse(expression); err != nil {
+		return "", err
+	}
+
+	expression = stripTimezone(expression)
+	if strings.HasPrefix(expression, "@") {
+		return describeDescriptor(expression), nil
+	}
+
+	// Five-field expressions fire at second 0
+	fields := strings.Fields(expression)
+	if len(fields) == 5 {
+		fields = append([]string{"0"}, fields...)
+	}
+	second, minute, hour := fields[0], fields[1], fields[2]
+	dom, month, dow := fields[3], fields[4], fields[5]
+
+	timePart := describeTime(second, minute, hour)
+	dayPart := describeDays(dom, dow)
+	monthPart := describeMonths(month)
+
+	if dayPart == "" && monthPart == "" && isTimeOfDay(second, minute, hour) {
+		return "every day " + timePart, nil
+	}
+
+	parts := []string{timePart}
+	if dayPart != "" {
+		parts = append(parts, dayPart)
+	}
+	if monthPart != "" {
+		parts = append(parts, monthPart)
+	}
+	return strings.Join(parts, " "), nil
+}
+
+// isTimeOfDay reports whether the fields select fixed times of day rather than a frequency
+func isTimeOfDay(second, minute, hour string) bool {
+	_, secondFixed := number(second)
+	_, minuteFixed := number(minute)
+	_, hoursFixed := numbers(hour)
+	return secondFixed && minuteFixed && hoursFixed
+}
+
+// describeDescriptor describes the predefined schedules (@daily, @every 5m, ...)
+func describeDescriptor(descriptor string) string {
+	switch descriptor {
+	case "@yearly", "@annually":
+		return "every year on January 1 at 12:00am"
+	case "@monthly":
+		return "on day 1 of every month at 12:00am"
+	case "@weekly":
+		return "every Sunday at 12:00am"
+	case "@daily", "@midnight":
+		return "every day at 12:00am"
+	case "@hourly":
+		return "every hour"
+	}
+
+	if interval, ok := strings.CutPrefix(descriptor, "@every "); ok {
+		if d, err := time.ParseDuration(interval); err == nil {
+			return "every " + describeDuration(d)
+		}
+	}
+	return descriptor
+}
+
+// describeDuration writes a whole number of hours, minutes or seconds in words, falling back to Go's notation
+func describeDuration(d time.Duration) string {
+	switch {
+	case d >= time.Hour && d%time.Hour == 0:
+		return plural(int(d/time.Hour), "hour")
+	case d >= time.Minute && d%time.Minute == 0:
+		return plural(int(d/time.Minute), "minute")
+	case d >= time.Second && d%time.Second == 0:
+		return plural(int(d/time.Second), "second")
+	}
+	return d.String()
+}
+
+// describeTime describes the second, minute and hour fields
+func describeTime(second, minute, hour string) string {
+	s, secondFixed := number(second)
+	m, minuteFixed := number(minute)
+
+	// Fixed times of day: "at 9:30am" or "at 9:00am and 5:00pm"
+	if secondFixed && minuteFixed {
+		if hours, ok := numbers(hour); ok {
+			clocks := make([]string, len(hours))
+			for i, h := range hours {
+				clocks[i] = clock(h, m, s)
+			}
+			return "at " + joinAnd(clocks)
+		}
+
+		phrase := fmt.Sprintf("at minute %d", m)
+		if s != 0 {
+			phrase = fmt.Sprintf("at %d:%02d past", m, s)
+		}
+		return phrase + " of " + describeHours(hour)
+	}
+
+	var phrase string
+	if secondFixed {
+		phrase = describeField(minute, "minute", strconv.Itoa)
+		if s != 0 {
+			phrase += fmt.Sprintf(" at second %d", s)
+		}
+	} else {
+		phrase = describeField(second, "second", strconv.Itoa)
+		if minute != "*" {
+			phrase += " during " + strings.TrimPrefix(describeField(minute, "minute", strconv.Itoa), "at ")
+		}
+	}
+
+	if hour != "*" {
+		phrase += " " + hourWindow(hour)
+	}
+	return phrase
+}
+
+// describeHours describes an hour field as the hours something happens in ("every hour", "every 2 hours", ...)
+func describeHours(hour string) string {
+	if hour == "*" {
+		return "every hour"
+	}
+	if step, ok := strings.CutPrefix(hour, "*/"); ok {
+		if n, err := strconv.Atoi(step); err == nil {
+			return "every " + plural(n, "hour")
+		}
+	}
+	return "every hour " + hourWindow(hour)
+}
+
+// hourWindow restricts a phrase to an hour field: "between 9am and 5pm", "of every 2nd hour", ...
+func hourWindow(hour string) string {
+	if h, ok := number(hour); ok {
+		return fmt.Sprintf("between %s and %s", hourLabel(h), hourLabel(h+1))
+	}
+	if from, to, ok := hourRange(hour); ok {
+		return fmt.Sprintf("between %s and %s", hourLabel(from), hourLabel(to+1))
+	}
+	if step, ok := strings.CutPrefix(hour, "*/"); ok {
+		if n, err := strconv.Atoi(step); err == nil {
+			return "of every " + ordinal(n) + " hour"
+		}
+	}
+	return "during " + strings.TrimPrefix(describeField(hour, "hour", hourLabel), "at ")
+}
+
+// hourRange parses a plain "a-b" hour range
+func hourRange(hour string) (int, int, bool) {
+	from, to, ok := strings.Cut(hour, "-")
+	if !ok {
+		return 0, 0, false
+	}
+	f, okFrom := number(from)
+	t, okTo := number(to)
+	return f, t, okFrom && okTo
+}
+
+// describeDays describes the day-of-month and day-of-week fields. When both are restricted a day matching either runs.
+func describeDays(dom, dow string) string {
+	domPhrase := ""
+	if dom != "*" && dom != "?" {
+		domPhrase = describeDaysOfMonth(dom)
+	}
+	dowPhrase := ""
+	if dow != "*" && dow != "?" {
+		dowPhrase = describeDaysOfWeek(dow)
+	}
+
+	switch {
+	case domPhrase != "" && dowPhrase != "":
+		return domPhrase + " or " + dowPhrase
+	case domPhrase != "":
+		return domPhrase
+	default:
+		return dowPhrase
+	}
+}
+
+// describeDaysOfMonth describes a restricted day-of-month field
+func describeDaysOfMonth(dom string) string {
+	if step, ok := strings.CutPrefix(dom, "*/"); ok {
+		if n, err := strconv.Atoi(step); err == nil {
+			return "every " + plural(n, "day")
+		}
+	}
+	if days, ok := numbers(dom); ok {
+		labels := make([]string, len(days))
+		for i, d := range days {
+			labels[i] = strconv.Itoa(d)
+		}
+		if len(days) == 1 {
+			return "on day " + labels[0] + " of the month"
+		}
+		return "on days " + joinAnd(labels) + " of the month"
+	}
+	if from, to, ok := strings.Cut(dom, "-"); ok && !strings.Contains(to, "/") {
+		return "on days " + from + " through " + to + " of the month"
+	}
+	return "on days " + dom + " of the month"
+}
+
+// describeDaysOfWeek describes a restricted day-of-week field
+func describeDaysOfWeek(dow string) string {
+	switch strings.ToUpper(dow) {
+	case "1-5", "MON-FRI":
+		return "on weekdays"
+	case "0,6", "6,0", "SAT,SUN", "SUN,SAT":
+		return "on weekends"
+	}
+
+	if from, to, ok := strings.Cut(dow, "-"); ok && !strings.ContainsAny(dow, ",/") {
+		f, okFrom := weekday(from)
+		t, okTo := weekday(to)
+		if okFrom && okTo {
+			return "on " + weekdayNames[f] + " through " + weekdayNames[t]
+		}
+	}
+
+	var names []string
+	for _, part := range strings.Split(dow, ",") {
+		d, ok := weekday(part)
+		if !ok {
+			return "on days of the week " + dow
+		}
+		names = append(names, weekdayNames[d])
+	}
+	if len(names) == 1 {
+		return "on " + names[0] + "s"
+	}
+	return "on " + joinAnd(names)
+}
+
+// describeMonths describes a restricted month field
+func describeMonths(month string) string {
+	if month == "*" || month == "?" {
+		return ""
+	}
+	if step, ok := strings.CutPrefix(month, "*/"); ok {
+		if n, err := strconv.Atoi(step); err == nil {
+			return "every " + plural(n, "month")
+		}
+	}
+	if from, to, ok := strings.Cut(month, "-"); ok && !strings.ContainsAny(month, ",/") {
+		f, okFrom := monthNumber(from)
+		t, okTo := monthNumber(to)
+		if okFrom && okTo {
+			return "from " + monthNames[f] + " through " + monthNames[t]
+		}
+	}
+
+	var names []string
+	for _, part := range strings.Split(month, ",") {
+		m, ok := monthNumber(part)
+		if !ok {
+			return "in months " + month
+		}
+		names = append(names, monthNames[m])
+	}
+	return "in " + joinAnd(names)
+}
+
+// describeField describes a non-fixed second, minute or hour field: "every minute", "every 15 minutes", "at minutes 0 and 30", ...
+func describeField(field, unit string, label func(int) string) string {
+	if field == "*" {
+		return "every " + unit
+	}
+	if step, ok := strings.CutPrefix(field, "*/"); ok {
+		if n, err := strconv.Atoi(step); err == nil {
+			return "every " + plural(n, unit)
+		}
+	}
+	if rng, step, ok := strings.Cut(field, "/"); ok {
+		if n, err := strconv.Atoi(step); err == nil {
+			if from, to, ok := strings.Cut(rng, "-"); ok {
+				return fmt.Sprintf("every %s from %s %s to %s", plural(n, unit), unit, from, to)
+			}
+			return fmt.Sprintf("every %s starting at %s %s", plural(n, unit), unit, rng)
+		}
+	}
+	if from, to, ok := strings.Cut(field, "-"); ok && !strings.Contains(field, ",") {
+		return fmt.Sprintf("every %s from %s %s to %s", unit, unit, from, to)
+	}
+	if values, ok := numbers(field); ok {
+		labels := make([]string, len(values))
+		for i, v := range values {
+			labels[i] = label(v)
+		}
+		return "at " + unit + "s " + joinAnd(labels)
+	}
+	return "at " + unit + "s " + field
+}
+
+// clock formats a time of day as "9:30am", adding seconds when they are not zero
+func clock(hour, minute, second int) string {
+	suffix := "am"
+	if hour >= 12 {
+		suffix = "pm"
+	}
+	h := hour % 12
+	if h == 0 {
+		h = 12
+	}
+	if second != 0 {
+		return fmt.Sprintf("%d:%02d:%02d%s", h, minute, second, suffix)
+	}
+	return fmt.Sprintf("%d:%02d%s", h, minute, suffix)
+}
+
+// hourLabel formats the start of an hour as "9am", "12pm" or "12am" (also for 24)
+func hourLabel(hour int) string {
+	hour %= 24
+	switch {
+	case hour == 0:
+		return "12am"
+	case hour < 12:
+		return fmt.Sprintf("%dam", hour)
+	case hour == 12:
+		return "12pm"
+	default:
+		return fmt.Sprintf("%dpm", hour-12)
+	}
+}
+
+// number parses a field holding a single integer
+func number(field string) (int, bool) {
+	n, err := strconv.Atoi(field)
+	return n, err == nil
+}
+
+// numbers parses a field holding a comma-separated list of integers
+func numbers(field string) ([]int, bool) {
+	parts := strings.Split(field, ",")
+	values := make([]int, len(parts))
+	for i, part := range parts {
+		n, ok := number(part)
+		if !ok {
+			return nil, false
+		}
+		values[i] = n
+	}
+	return values, true
+}
+
+// weekday parses a day of week given as 0-6 or a three-letter name
+func weekday(value string) (int, bool) {
+	if n, ok := number(value); ok && n >= 0 && n <= 6 {
+		return n, true
+	}
+	for i, name := range weekdayNames {
+		if strings.EqualFold(value, name[:3]) {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+// monthNumber parses a month given as 1-12 or a three-letter name
+func monthNumber(value string) (int, bool) {
+	if n, ok := number(value); ok && n >= 1 && n <= 12 {
+		return n, true
+	}
+	for i := 1; i < len(monthNames); i++ {
+		if strings.EqualFold(value, monthNames[i][:3]) {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+// plural formats a count with its unit: "1 minute", "15 minutes"
+func plural(n int, unit string) string {
+	if n == 1 {
+		return "1 " + unit
+	}
+	return fmt.Sprintf("%d %ss", n, unit)
+}
+
+// ordinal formats 2 as "2nd", 3 as "3rd", ...
+func ordinal(n int) string {
+	suffix := "th"
+	switch {
+	case n%100 >= 11 && n%100 <= 13:
+	case n%10 == 1:
+		suffix = "st"
+	case n%10 == 2:
+		suffix = "nd"
+	case n%10 == 3:
+		suffix = "rd"
+	}
+	return strconv.Itoa(n) + suffix
+}
+
+// joinAnd joins items as "a", "a and b" or "a, b and c"
+func joinAnd(items []string) string {
+	switch len(items) {
+	case 0:
+		return ""
+	case 1:
+		return items[0]
+	}
+	return strings.Join(items[:len(items)-1], ", ") + " and " + items[len(items)-1]
+}
@@ -0,0 +1,199 @@
+package cronexpr
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// ErrUnrecognizedSchedule is returned by FromPhrase when a phrase does not match any supported form
+var ErrUnrecognizedSchedule = errors.New("unrecognized schedule")
+
+var (
+	intervalPattern  = regexp.MustCompile(`^every (\d+) (second|minute|hour)s?$`)
+	atTimePattern    = regexp.MustCompile(`^(?:(?:every|on) )?(.+?) at (.+)$`)
+	timeFirstPattern = regexp.MustCompile(`^at (.+?)(?: (?:every|on) (.+))?$`)
+	monthDayPattern  = regexp.MustCompile(`^(?:every month|monthly) on (?:the )?(\d{1,2})(?:st|nd|rd|th)?(?: at (.+))?$`)
+	clockPattern     = regexp.MustCompile(`^(\d{1,2})(?::(\d{2}))? ?(am|pm)?$`)
+	listSeparator    = regexp.MustCompile(`\s*(?:,|\band\b)\s*`)
+)
+
+// FromPhrase translates a natural-language schedule into a six-field cron expression. Supported forms:
+//
+//	every 15 minutes, every 30 seconds, every 2 hours, every minute, hourly
+//	daily, weekly, monthly, every day at 9am, at 18:00
+//	weekdays at 9am, every weekend at noon, every monday at 06:30, mondays and fridays at 5:30pm
+//	every month on the 1st at 9am
+func FromPhrase(phrase string) (string, error) {
+	normalized := strings.Join(strings.Fields(strings.ToLower(phrase)), " ")
+	normalized = strings.TrimRight(normalized, ".")
+
+	switch normalized {
+	case "every second":
+		return "* * * * * *", nil
+	case "every minute":
+		return "0 * * * * *", nil
+	case "every hour", "hourly":
+		return "0 0 * * * *", nil
+	case "every day", "daily":
+		return "0 0 0 * * *", nil
+	case "every week", "weekly":
+		return "0 0 0 * * 0", nil
+	case "every month", "monthly":
+		return "0 0 0 1 * *", nil
+	}
+
+	if m := intervalPattern.FindStringSubmatch(normalized); m != nil {
+		return fromInterval(m[1], m[2], phrase)
+	}
+
+	if m := monthDayPattern.FindStringSubmatch(normalized); m != nil {
+		day, _ := strconv.Atoi(m[1])
+		if day < 1 || day > 31 {
+			return "", fmt.Errorf("%w: day of month must be between 1 and 31 in %q", ErrUnrecognizedSchedule, phrase)
+		}
+		hour, minute := 0, 0
+		if m[2] != "" {
+			var err error
+			if hour, minute, err = parseClock(m[2]); err != nil {
+				return "", fmt.Errorf("%w: %v in %q", ErrUnrecognizedSchedule, err, phrase)
+			}
+		}
+		return fmt.Sprintf("0 %d %d %d * *", minute, hour, day), nil
+	}
+
+	if m := timeFirstPattern.FindStringSubmatch(normalized); m != nil {
+		return fromDaysAndTime(m[2], m[1], phrase)
+	}
+	if m := atTimePattern.FindStringSubmatch(normalized); m != nil {
+		return fromDaysAndTime(m[1], m[2], phrase)
+	}
+
+	return "", fmt.Errorf("%w: %q", ErrUnrecognizedSchedule, phrase)
+}
+
+// fromInterval translates "every N seconds|minutes|hours"
+func fromInterval(count, unit, phrase string) (string, error) {
+	n, err := strconv.Atoi(count)
+	if err != nil || n < 1 {
+		return "", fmt.Errorf("%w: interval must be a positive number in %q", ErrUnrecognizedSchedule, phrase)
+	}
+
+	limit := 60
+	if unit == "hour" {
+		limit = 24
+	}
+	if n >= limit {
+		return "", fmt.Errorf("%w: every %d %ss does not fit a cron field, use a larger unit in %q", ErrUnrecognizedSchedule, n, unit, phrase)
+	}
+
+	step := "*"
+	if n > 1 {
+		step = "*/" + strconv.Itoa(n)
+	}
+	switch unit {
+	case "second":
+		return step + " * * * * *", nil
+	case "minute":
+		return "0 " + step + " * * * *", nil
+	default:
+		return "0 0 " + step + " * * *", nil
+	}
+}
+
+// fromDaysAndTime translates a day specification ("weekdays", "monday and friday", "day", ...) and a time of day
+func fromDaysAndTime(days, clock, phrase string) (string, error) {
+	hour, minute, err := parseClock(clock)
+	if err != nil {
+		return "", fmt.Errorf("%w: %v in %q", ErrUnrecognizedSchedule, err, phrase)
+	}
+
+	dow, err := parseDays(days)
+	if err != nil {
+		return "", fmt.Errorf("%w: %v in %q", ErrUnrecognizedSchedule, err, phrase)
+	}
+	return fmt.Sprintf("0 %d %d * * %s", minute, hour, dow), nil
+}
+
+// parseDays translates a day specification into a day-of-week field
+func parseDays(days string) (string, error) {
+	switch days {
+	case "", "day", "days", "daily":
+		return "*", nil
+	case "weekday", "weekdays":
+		return "1-5", nil
+	case "weekend", "weekends":
+		return "0,6", nil
+	}
+
+	var numbers []string
+	for _, name := range listSeparator.Split(days, -1) {
+		if name == "" {
+			continue
+		}
+		day, ok := weekday(strings.TrimSuffix(name, "s"))
+		if !ok {
+			day, ok = weekdayByName(strings.TrimSuffix(name, "s"))
+		}
+		if !ok {
+			return "", fmt.Errorf("unknown day %q", name)
+		}
+		numbers = append(numbers, strconv.Itoa(day))
+	}
+	if len(numbers) == 0 {
+		return "", fmt.Errorf("no days given")
+	}
+	return strings.Join(numbers, ","), nil
+}
+
+// weekdayByName parses a full weekday name such as "monday"
+func weekdayByName(name string) (int, bool) {
+	for i, weekdayName := range weekdayNames {
+		if strings.EqualFold(name, weekdayName) {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+// parseClock parses "9am", "9:30 pm", "06:30", "18:00", "noon" or "midnight" into an hour and minute
+func parseClock(value string) (int, int, error) {
+	switch value {
+	case "noon":
+		return 12, 0, nil
+	case "midnight":
+		return 0, 0, nil
+	}
+
+	m := clockPattern.FindStringSubmatch(value)
+	if m == nil {
+		return 0, 0, fmt.Errorf("unknown time %q", value)
+	}
+
+	hour, _ := strconv.Atoi(m[1])
+	minute := 0
+	if m[2] != "" {
+		minute, _ = strconv.Atoi(m[2])
+	}
+	if minute > 59 {
+		return 0, 0, fmt.Errorf("invalid minute in %q", value)
+	}
+
+	switch m[3] {
+	case "":
+		if hour > 23 {
+			return 0, 0, fmt.Errorf("invalid hour in %q", value)
+		}
+	default:
+		if hour < 1 || hour > 12 {
+			return 0, 0, fmt.Errorf("invalid hour in %q", value)
+		}
+		hour %= 12
+		if m[3] == "pm" {
+			hour += 12
+		}
+	}
+	return hour, minute, nil
+}
@@ -0,0 +1,63 @@
+package crons
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/robfig/cron/v3"
+	"github.com/yourusername/cron-observer/backend/internal/repositories"
+)
+
+// ExecutionPartitionPruningCron drops monthly execution partitions older than the retention period once a day.
+// Dropping a whole collection is far cheaper than deleting its executions one by one.
+type ExecutionPartitionPruningCron struct {
+	repo            *repositories.PartitionedRepository
+	retentionMonths int
+	cron            *cron.Cron
+}
+
+// NewExecutionPartitionPruningCron creates a cron that keeps the current month and retentionMonths full months before it
+func NewExecutionPartitionPruningCron(repo *repositories.PartitionedRepository, retentionMonths int) *ExecutionPartitionPruningCron {
+	c := cron.New(cron.WithSeconds())
+	return &ExecutionPartitionPruningCron{
+		repo:            repo,
+		retentionMonths: retentionMonths,
+		cron:            c,
+	}
+}
+
+// Start starts the cron and schedules the job
+func (c *ExecutionPartitionPruningCron) Start(ctx context.Context) {
+	// Schedule job to run daily at 03:30, after the per-project execution retention cleanup
+	_, err := c.cron.AddFunc("0 30 3 * * *", func() {
+		log.Println("[ExecutionPartitionPruningCron] Starting scheduled pruning...")
+		c.prune(context.Background(), time.Now())
+	})
+	if err != nil {
+		log.Printf("[ExecutionPartitionPruningCron] Failed to schedule cron job: %v", err)
+		return
+	}
+
+	// Start the cron engine
+	c.cron.Start()
+	log.Printf("[ExecutionPartitionPruningCron] Started (runs daily at 03:30, keeps %d months)", c.retentionMonths)
+
+	// Wait for context cancellation
+	<-ctx.Done()
+	log.Println("[ExecutionPartitionPruningCron] Context cancelled, stopping...")
+	c.cron.Stop()
+	log.Println("[ExecutionPartitionPruningCron] Stopped")
+}
+
+// prune drops the partitions of months that ended before the retention cutoff
+func (c *ExecutionPartitionPruningCron) prune(ctx context.Context, now time.Time) {
+	now = now.UTC()
+	cutoff := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC).AddDate(0, -c.retentionMonths, 0)
+
+	dropped, err := c.repo.DropExecutionPartitionsBefore(ctx, cutoff)
+	if err != nil {
+		log.Printf("[ExecutionPartitionPruningCron] Failed to drop partitions before %s: %v", cutoff.Format("2006-01"), err)
+	}
+	log.Printf("[ExecutionPartitionPruningCron] Dropped %d partitions before %s: %v", len(dropped), cutoff.Format("2006-01"), dropped)
+}
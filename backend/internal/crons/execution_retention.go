@@ -0,0 +1,89 @@
+package crons
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/robfig/cron/v3"
+	"github.com/yourusername/cron-observer/backend/internal/models"
+	"github.com/yourusername/cron-observer/backend/internal/repositories"
+)
+
+// ExecutionRetentionCron deletes executions older than each project's execution_retention_days setting once a day
+type ExecutionRetentionCron struct {
+	repo repositories.Repository
+	cron *cron.Cron
+}
+
+// NewExecutionRetentionCron creates a new ExecutionRetentionCron
+func NewExecutionRetentionCron(repo repositories.Repository) *ExecutionRetentionCron {
+	c := cron.New(cron.WithSeconds())
+	return &ExecutionRetentionCron{
+		repo: repo,
+		cron: c,
+	}
+}
+
+// Start starts the cron and schedules the job
+func (c *ExecutionRetentionCron) Start(ctx context.Context) {
+	// Schedule job to run daily at 03:00
+	_, err := c.cron.AddFunc("0 0 3 * * *", func() {
+		log.Println("[ExecutionRetentionCron] Starting scheduled cleanup...")
+		c.cleanupAllProjects(context.Background(), time.Now())
+	})
+	if err != nil {
+		log.Printf("[ExecutionRetentionCron] Failed to schedule cron job: %v", err)
+		return
+	}
+
+	// Start the cron engine
+	c.cron.Start()
+	log.Println("[ExecutionRetentionCron] Started (runs daily at 03:00)")
+
+	// Wait for context cancellation
+	<-ctx.Done()
+	log.Println("[ExecutionRetentionCron] Context cancelled, stopping...")
+	c.cron.Stop()
+	log.Println("[ExecutionRetentionCron] Stopped")
+}
+
+// cleanupAllProjects applies the retention period of every project that has one
+func (c *ExecutionRetentionCron) cleanupAllProjects(ctx context.Context, now time.Time) {
+	settingsList, err := c.repo.GetProjectSettingsWithRetention(ctx)
+	if err != nil {
+		log.Printf("[ExecutionRetentionCron] Failed to get project settings: %v", err)
+		return
+	}
+
+	for _, settings := range settingsList {
+		if err := c.cleanupProject(ctx, settings, now); err != nil {
+			log.Printf("[ExecutionRetentionCron] Failed to clean up executions for project %s: %v", settings.ProjectID.Hex(), err)
+			// Continue with other projects
+		}
+	}
+
+	log.Println("[ExecutionRetentionCron] Completed scheduled cleanup")
+}
+
+// cleanupProject deletes the project's executions that started before its retention cutoff
+func (c *ExecutionRetentionCron) cleanupProject(ctx context.Context, settings *models.ProjectSettings, now time.Time) error {
+	tasks, err := c.repo.GetTasksByProjectID(ctx, settings.ProjectID)
+	if err != nil {
+		return err
+	}
+
+	taskUUIDs := make([]string, 0, len(tasks))
+	for _, task := range tasks {
+		taskUUIDs = append(taskUUIDs, task.UUID)
+	}
+
+	cutoff := now.AddDate(0, 0, -settings.ExecutionRetentionDays)
+	deleted, err := c.repo.DeleteExecutionsByTaskUUIDsBefore(ctx, taskUUIDs, cutoff)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[ExecutionRetentionCron] Deleted %d executions older than %d days for project %s", deleted, settings.ExecutionRetentionDays, settings.ProjectID.Hex())
+	return nil
+}
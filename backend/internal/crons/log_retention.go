@@ -0,0 +1,115 @@
+package crons
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/robfig/cron/v3"
+	"github.com/yourusername/cron-observer/backend/internal/models"
+	"github.com/yourusername/cron-observer/backend/internal/repositories"
+)
+
+// LogRetentionCron removes the logs of old finished executions once a day, keeping the executions. Successful and
+// failed executions have separate retention periods, taken from each project's log_retention setting or the server
+// defaults.
+type LogRetentionCron struct {
+	repo     repositories.Repository
+	defaults models.LogRetentionPolicy
+	cron     *cron.Cron
+}
+
+// NewLogRetentionCron creates a new LogRetentionCron
+func NewLogRetentionCron(repo repositories.Repository, defaults models.LogRetentionPolicy) *LogRetentionCron {
+	c := cron.New(cron.WithSeconds())
+	return &LogRetentionCron{
+		repo:     repo,
+		defaults: defaults,
+		cron:     c,
+	}
+}
+
+// Start starts the cron and schedules the job
+func (c *LogRetentionCron) Start(ctx context.Context) {
+	// Schedule job to run daily at 03:30, after the execution retention cleanup
+	_, err := c.cron.AddFunc("0 30 3 * * *", func() {
+		log.Println("[LogRetentionCron] Starting scheduled log trimming...")
+		c.trimAllProjects(context.Background(), time.Now())
+	})
+	if err != nil {
+		log.Printf("[LogRetentionCron] Failed to schedule cron job: %v", err)
+		return
+	}
+
+	// Start the cron engine
+	c.cron.Start()
+	log.Println("[LogRetentionCron] Started (runs daily at 03:30)")
+
+	// Wait for context cancellation
+	<-ctx.Done()
+	log.Println("[LogRetentionCron] Context cancelled, stopping...")
+	c.cron.Stop()
+	log.Println("[LogRetentionCron] Stopped")
+}
+
+// trimAllProjects applies the log retention of every project
+func (c *LogRetentionCron) trimAllProjects(ctx context.Context, now time.Time) {
+	projects, err := c.repo.GetAllProjects(ctx)
+	if err != nil {
+		log.Printf("[LogRetentionCron] Failed to get projects: %v", err)
+		return
+	}
+
+	for _, project := range projects {
+		if err := c.trimProject(ctx, project, now); err != nil {
+			log.Printf("[LogRetentionCron] Failed to trim logs for project %s: %v", project.ID.Hex(), err)
+			// Continue with other projects
+		}
+	}
+
+	log.Println("[LogRetentionCron] Completed scheduled log trimming")
+}
+
+// trimProject removes the logs of the project's successful and failed executions that started before their
+// retention cutoffs
+func (c *LogRetentionCron) trimProject(ctx context.Context, project *models.Project, now time.Time) error {
+	settings, err := c.repo.GetProjectSettings(ctx, project.ID)
+	if err != nil {
+		return err
+	}
+	policy := settings.EffectiveLogRetention(c.defaults)
+	if policy.SuccessDays == 0 && policy.FailureDays == 0 {
+		return nil
+	}
+
+	tasks, err := c.repo.GetTasksByProjectID(ctx, project.ID)
+	if err != nil {
+		return err
+	}
+	taskUUIDs := make([]string, 0, len(tasks))
+	for _, task := range tasks {
+		taskUUIDs = append(taskUUIDs, task.UUID)
+	}
+
+	periods := []struct {
+		status models.ExecutionStatus
+		days   int
+	}{
+		{models.ExecutionStatusSuccess, policy.SuccessDays},
+		{models.ExecutionStatusFailed, policy.FailureDays},
+	}
+	for _, period := range periods {
+		if period.days == 0 {
+			continue
+		}
+		cutoff := now.AddDate(0, 0, -period.days)
+		trimmed, err := c.repo.TrimExecutionLogsBefore(ctx, taskUUIDs, []models.ExecutionStatus{period.status}, cutoff)
+		if err != nil {
+			return err
+		}
+		if trimmed > 0 {
+			log.Printf("[LogRetentionCron] Removed logs of %d %s executions older than %d days for project %s", trimmed, period.status, period.days, project.ID.Hex())
+		}
+	}
+	return nil
+}
@@ -0,0 +1,81 @@
+package crons
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/yourusername/cron-observer/backend/internal/models"
+	"github.com/yourusername/cron-observer/backend/internal/repositories"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestLogRetentionCron_TrimsByStatusWithProjectOverrides(t *testing.T) {
+	ctx := context.Background()
+	repo := repositories.NewMemoryRepository()
+	now := time.Now()
+
+	// The first project uses the defaults, the second keeps successful logs for 30 days
+	var projects []*models.Project
+	for _, name := range []string{"billing", "reports"} {
+		project := &models.Project{ID: primitive.NewObjectID(), UUID: name, Name: name, APIKey: name + "-key"}
+		if err := repo.CreateProject(ctx, project); err != nil {
+			t.Fatalf("CreateProject: %v", err)
+		}
+		task := &models.Task{ID: primitive.NewObjectID(), UUID: name + "-task", ProjectID: project.ID, Name: name}
+		if err := repo.CreateTask(ctx, project.ID.Hex(), task); err != nil {
+			t.Fatalf("CreateTask: %v", err)
+		}
+		projects = append(projects, project)
+	}
+	if err := repo.UpsertProjectSettings(ctx, &models.ProjectSettings{
+		ProjectID:    projects[1].ID,
+		LogRetention: &models.LogRetentionPolicy{SuccessDays: 30},
+	}); err != nil {
+		t.Fatalf("UpsertProjectSettings: %v", err)
+	}
+
+	executions := []struct {
+		uuid     string
+		task     string
+		status   models.ExecutionStatus
+		age      int // days
+		wantLogs bool
+	}{
+		{"old-success", "billing-task", models.ExecutionStatusSuccess, 10, false},
+		{"new-success", "billing-task", models.ExecutionStatusSuccess, 3, true},
+		{"old-failure", "billing-task", models.ExecutionStatusFailed, 10, true},
+		{"ancient-failure", "billing-task", models.ExecutionStatusFailed, 100, false},
+		{"old-running", "billing-task", models.ExecutionStatusRunning, 100, true},
+		{"overridden-success", "reports-task", models.ExecutionStatusSuccess, 10, true},
+		{"overridden-failure", "reports-task", models.ExecutionStatusFailed, 100, true},
+	}
+	for _, e := range executions {
+		execution := &models.Execution{
+			UUID:      e.uuid,
+			TaskUUID:  e.task,
+			Status:    e.status,
+			StartedAt: now.AddDate(0, 0, -e.age),
+			Logs:      []models.LogEntry{{Message: "hello", Level: "info", Timestamp: now}},
+		}
+		if err := repo.CreateExecution(ctx, execution); err != nil {
+			t.Fatalf("CreateExecution: %v", err)
+		}
+	}
+
+	c := NewLogRetentionCron(repo, models.LogRetentionPolicy{SuccessDays: 7, FailureDays: 90})
+	c.trimAllProjects(ctx, now)
+
+	for _, e := range executions {
+		execution, err := repo.GetExecutionByUUID(ctx, e.uuid)
+		if err != nil {
+			t.Fatalf("GetExecutionByUUID(%s): %v", e.uuid, err)
+		}
+		if hasLogs := len(execution.Logs) > 0; hasLogs != e.wantLogs {
+			t.Errorf("%s: has logs = %v, want %v", e.uuid, hasLogs, e.wantLogs)
+		}
+		if trimmed := execution.LogsTrimmedAt != nil; trimmed == e.wantLogs {
+			t.Errorf("%s: logs_trimmed_at = %v", e.uuid, execution.LogsTrimmedAt)
+		}
+	}
+}
@@ -14,6 +14,8 @@ import (
 type TaskFailureStatsCron struct {
 	repo repositories.Repository
 	cron *cron.Cron
+
+	analyticsRepo repositories.Repository // Runs the failure aggregation; may read from secondaries
 }
 
 // NewTaskFailureStatsCron creates a new TaskFailureStatsCron
@@ -25,6 +27,12 @@ func NewTaskFailureStatsCron(repo repositories.Repository) *TaskFailureStatsCron
 	}
 }
 
+// SetAnalyticsRepository runs the failure aggregation on a repository that may read from secondaries. Results
+// are still stored through the primary repository. Must be called before Start.
+func (c *TaskFailureStatsCron) SetAnalyticsRepository(repo repositories.Repository) {
+	c.analyticsRepo = repo
+}
+
 // Start starts the cron and schedules the job
 func (c *TaskFailureStatsCron) Start(ctx context.Context) {
 	// Schedule job to run every 6 hours: "0 0 0,6,12,18 * * *" (at 00:00, 06:00, 12:00, 18:00)
@@ -84,7 +92,11 @@ func (c *TaskFailureStatsCron) calculateAllStats(ctx context.Context) {
 // calculateStatsForProjectAndDate calculates and stores stats for a specific project and date
 func (c *TaskFailureStatsCron) calculateStatsForProjectAndDate(ctx context.Context, projectID primitive.ObjectID, date string) error {
 	// Calculate stats
-	stats, err := c.repo.CalculateTaskFailureStats(ctx, projectID, date)
+	analytics := c.repo
+	if c.analyticsRepo != nil {
+		analytics = c.analyticsRepo
+	}
+	stats, err := analytics.CalculateTaskFailureStats(ctx, projectID, date)
 	if err != nil {
 		return err
 	}
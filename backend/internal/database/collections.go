@@ -13,11 +13,25 @@ import (
 const (
 	// Collection names
 	CollectionProjects              = "projects"
+	CollectionOrganizations         = "organizations"
 	CollectionTasks                 = "tasks"
 	CollectionTaskGroups            = "task_groups"
 	CollectionExecutions            = "executions"
 	CollectionExecutionFailureStats = "execution_failure_stats"
 	CollectionTaskFailureStats      = "task_failure_stats"
+	CollectionUsage                 = "usage"
+	CollectionInvitations           = "invitations"
+	CollectionSecrets               = "secrets"
+	CollectionTokenRevocations      = "token_revocations"
+	CollectionProjectSettings       = "project_settings"
+	CollectionTaskTemplates         = "task_templates"
+	CollectionEventOutbox           = "event_outbox"
+	CollectionJobQueue              = "job_queue"
+	CollectionIncidents             = "incidents"
+	CollectionMigrations            = "migrations"
+
+	// CollectionExecutionPartitionPrefix starts the names of monthly execution partitions (executions_2025_01, ...)
+	CollectionExecutionPartitionPrefix = CollectionExecutions + "_"
 )
 
 // GetProjectsCollection returns the projects collection
@@ -25,6 +39,11 @@ func (d *Database) GetProjectsCollection() *mongo.Collection {
 	return d.DB.Collection(CollectionProjects)
 }
 
+// GetOrganizationsCollection returns the organizations collection
+func (d *Database) GetOrganizationsCollection() *mongo.Collection {
+	return d.DB.Collection(CollectionOrganizations)
+}
+
 // GetTasksCollection returns the tasks collection
 func (d *Database) GetTasksCollection() *mongo.Collection {
 	return d.DB.Collection(CollectionTasks)
@@ -35,6 +54,46 @@ func (d *Database) GetTaskGroupsCollection() *mongo.Collection {
 	return d.DB.Collection(CollectionTaskGroups)
 }
 
+// GetInvitationsCollection returns the invitations collection
+func (d *Database) GetInvitationsCollection() *mongo.Collection {
+	return d.DB.Collection(CollectionInvitations)
+}
+
+// GetSecretsCollection returns the secrets collection
+func (d *Database) GetSecretsCollection() *mongo.Collection {
+	return d.DB.Collection(CollectionSecrets)
+}
+
+// GetTokenRevocationsCollection returns the token_revocations collection
+func (d *Database) GetTokenRevocationsCollection() *mongo.Collection {
+	return d.DB.Collection(CollectionTokenRevocations)
+}
+
+// GetProjectSettingsCollection returns the project_settings collection
+func (d *Database) GetProjectSettingsCollection() *mongo.Collection {
+	return d.DB.Collection(CollectionProjectSettings)
+}
+
+// GetEventOutboxCollection returns the event_outbox collection
+func (d *Database) GetEventOutboxCollection() *mongo.Collection {
+	return d.DB.Collection(CollectionEventOutbox)
+}
+
+// GetJobQueueCollection returns the job_queue collection
+func (d *Database) GetJobQueueCollection() *mongo.Collection {
+	return d.DB.Collection(CollectionJobQueue)
+}
+
+// GetIncidentsCollection returns the incidents collection
+func (d *Database) GetIncidentsCollection() *mongo.Collection {
+	return d.DB.Collection(CollectionIncidents)
+}
+
+// GetTaskTemplatesCollection returns the task_templates collection
+func (d *Database) GetTaskTemplatesCollection() *mongo.Collection {
+	return d.DB.Collection(CollectionTaskTemplates)
+}
+
 // CreateIndexes creates all necessary indexes for collections
 func (d *Database) CreateIndexes(ctx context.Context) error {
 	// Create indexes for projects collection
@@ -42,6 +101,11 @@ func (d *Database) CreateIndexes(ctx context.Context) error {
 		return fmt.Errorf("failed to create project indexes: %w", err)
 	}
 
+	// Create indexes for organizations collection
+	if err := d.createOrganizationIndexes(ctx); err != nil {
+		return fmt.Errorf("failed to create organization indexes: %w", err)
+	}
+
 	// Create indexes for tasks collection
 	if err := d.createTaskIndexes(ctx); err != nil {
 		return fmt.Errorf("failed to create task indexes: %w", err)
@@ -62,6 +126,51 @@ func (d *Database) CreateIndexes(ctx context.Context) error {
 		return fmt.Errorf("failed to create task failure stats indexes: %w", err)
 	}
 
+	// Create indexes for usage collection
+	if err := d.createUsageIndexes(ctx); err != nil {
+		return fmt.Errorf("failed to create usage indexes: %w", err)
+	}
+
+	// Create indexes for invitations collection
+	if err := d.createInvitationIndexes(ctx); err != nil {
+		return fmt.Errorf("failed to create invitation indexes: %w", err)
+	}
+
+	// Create indexes for secrets collection
+	if err := d.createSecretIndexes(ctx); err != nil {
+		return fmt.Errorf("failed to create secret indexes: %w", err)
+	}
+
+	// Create indexes for token_revocations collection
+	if err := d.createTokenRevocationIndexes(ctx); err != nil {
+		return fmt.Errorf("failed to create token revocation indexes: %w", err)
+	}
+
+	// Create indexes for project_settings collection
+	if err := d.createProjectSettingsIndexes(ctx); err != nil {
+		return fmt.Errorf("failed to create project settings indexes: %w", err)
+	}
+
+	// Create indexes for task_templates collection
+	if err := d.createTaskTemplateIndexes(ctx); err != nil {
+		return fmt.Errorf("failed to create task template indexes: %w", err)
+	}
+
+	// Create indexes for event_outbox collection
+	if err := d.createEventOutboxIndexes(ctx); err != nil {
+		return fmt.Errorf("failed to create event outbox indexes: %w", err)
+	}
+
+	// Create indexes for job_queue collection
+	if err := d.createJobQueueIndexes(ctx); err != nil {
+		return fmt.Errorf("failed to create job queue indexes: %w", err)
+	}
+
+	// Create indexes for incidents collection
+	if err := d.createIncidentIndexes(ctx); err != nil {
+		return fmt.Errorf("failed to create incident indexes: %w", err)
+	}
+
 	return nil
 }
 
@@ -77,6 +186,14 @@ func (d *Database) createProjectIndexes(ctx context.Context) error {
 			Keys:    bson.D{{Key: "api_key", Value: 1}},
 			Options: options.Index().SetUnique(true).SetName("idx_api_key"),
 		},
+		{
+			Keys:    bson.D{{Key: "scoped_api_keys.key", Value: 1}},
+			Options: options.Index().SetName("idx_scoped_api_keys_key"),
+		},
+		{
+			Keys:    bson.D{{Key: "environments.api_key", Value: 1}},
+			Options: options.Index().SetName("idx_environments_api_key"),
+		},
 		{
 			Keys: bson.D{{Key: "name", Value: 1}},
 			Options: options.Index().
@@ -88,6 +205,42 @@ func (d *Database) createProjectIndexes(ctx context.Context) error {
 			Keys:    bson.D{{Key: "created_at", Value: -1}},
 			Options: options.Index().SetName("idx_created_at"),
 		},
+		{
+			Keys:    bson.D{{Key: "organization_id", Value: 1}},
+			Options: options.Index().SetName("idx_organization_id").SetSparse(true),
+		},
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	_, err := collection.Indexes().CreateMany(ctx, indexes)
+	if err != nil {
+		return fmt.Errorf("failed to create indexes: %w", err)
+	}
+
+	return nil
+}
+
+// createOrganizationIndexes creates indexes for the organizations collection
+func (d *Database) createOrganizationIndexes(ctx context.Context) error {
+	collection := d.GetOrganizationsCollection()
+	indexes := []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "uuid", Value: 1}},
+			Options: options.Index().SetUnique(true).SetName("idx_uuid"),
+		},
+		{
+			Keys: bson.D{{Key: "name", Value: 1}},
+			Options: options.Index().
+				SetUnique(true).
+				SetName("idx_name_unique").
+				SetCollation(&options.Collation{Locale: "en", Strength: 2}), // case-insensitive
+		},
+		{
+			Keys:    bson.D{{Key: "members.email", Value: 1}},
+			Options: options.Index().SetName("idx_members_email"),
+		},
 	}
 
 	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
@@ -139,6 +292,42 @@ func (d *Database) createTaskIndexes(ctx context.Context) error {
 			},
 			Options: options.Index().SetName("idx_project_created"),
 		},
+		{
+			Keys: bson.D{
+				{Key: "project_id", Value: 1},
+				{Key: "name", Value: 1},
+			},
+			Options: options.Index().SetName("idx_project_name"),
+		},
+		{
+			Keys: bson.D{
+				{Key: "project_id", Value: 1},
+				{Key: "last_failure_at", Value: -1},
+			},
+			Options: options.Index().SetName("idx_project_last_failure"),
+		},
+		{
+			Keys: bson.D{
+				{Key: "project_id", Value: 1},
+				{Key: "tags", Value: 1},
+			},
+			Options: options.Index().SetName("idx_project_tags"),
+		},
+		{
+			// Task search matches metadata keys through this index instead of expanding every task's metadata
+			Keys: bson.D{
+				{Key: "project_id", Value: 1},
+				{Key: "metadata_keys", Value: 1},
+			},
+			Options: options.Index().SetName("idx_project_metadata_keys"),
+		},
+		{
+			Keys: bson.D{
+				{Key: "project_id", Value: 1},
+				{Key: "severity", Value: 1},
+			},
+			Options: options.Index().SetName("idx_project_severity"),
+		},
 		{
 			Keys:    bson.D{{Key: "task_group_id", Value: 1}},
 			Options: options.Index().SetName("idx_task_group_id"),
@@ -221,6 +410,35 @@ func (d *Database) createExecutionFailureStatsIndexes(ctx context.Context) error
 	return nil
 }
 
+// createUsageIndexes creates indexes for the usage collection
+func (d *Database) createUsageIndexes(ctx context.Context) error {
+	collection := d.DB.Collection(CollectionUsage)
+	indexes := []mongo.IndexModel{
+		{
+			Keys: bson.D{
+				{Key: "project_id", Value: 1},
+				{Key: "date", Value: 1},
+			},
+			Options: options.Index().SetUnique(true).SetName("idx_project_date"),
+		},
+		{
+			// Exports of all projects select a date range
+			Keys:    bson.D{{Key: "date", Value: 1}},
+			Options: options.Index().SetName("idx_date"),
+		},
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	_, err := collection.Indexes().CreateMany(ctx, indexes)
+	if err != nil {
+		return fmt.Errorf("failed to create indexes: %w", err)
+	}
+
+	return nil
+}
+
 // createTaskFailureStatsIndexes creates indexes for the task_failure_stats collection
 func (d *Database) createTaskFailureStatsIndexes(ctx context.Context) error {
 	collection := d.DB.Collection(CollectionTaskFailureStats)
@@ -256,3 +474,261 @@ func (d *Database) createTaskFailureStatsIndexes(ctx context.Context) error {
 
 	return nil
 }
+
+// createInvitationIndexes creates indexes for the invitations collection
+func (d *Database) createInvitationIndexes(ctx context.Context) error {
+	collection := d.GetInvitationsCollection()
+	indexes := []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "uuid", Value: 1}},
+			Options: options.Index().SetUnique(true).SetName("idx_uuid"),
+		},
+		{
+			Keys: bson.D{
+				{Key: "project_id", Value: 1},
+				{Key: "status", Value: 1},
+			},
+			Options: options.Index().SetName("idx_project_status"),
+		},
+		{
+			Keys: bson.D{
+				{Key: "project_id", Value: 1},
+				{Key: "email", Value: 1},
+			},
+			Options: options.Index().SetName("idx_project_email"),
+		},
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	_, err := collection.Indexes().CreateMany(ctx, indexes)
+	if err != nil {
+		return fmt.Errorf("failed to create indexes: %w", err)
+	}
+
+	return nil
+}
+
+// createSecretIndexes creates indexes for the secrets collection
+func (d *Database) createSecretIndexes(ctx context.Context) error {
+	collection := d.GetSecretsCollection()
+	indexes := []mongo.IndexModel{
+		{
+			Keys: bson.D{
+				{Key: "project_id", Value: 1},
+				{Key: "name", Value: 1},
+			},
+			Options: options.Index().SetUnique(true).SetName("idx_project_name_unique"),
+		},
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	_, err := collection.Indexes().CreateMany(ctx, indexes)
+	if err != nil {
+		return fmt.Errorf("failed to create indexes: %w", err)
+	}
+
+	return nil
+}
+
+// createTokenRevocationIndexes creates indexes for the token_revocations collection
+func (d *Database) createTokenRevocationIndexes(ctx context.Context) error {
+	collection := d.GetTokenRevocationsCollection()
+	indexes := []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "jti", Value: 1}},
+			Options: options.Index().SetName("idx_jti").SetSparse(true),
+		},
+		{
+			Keys: bson.D{
+				{Key: "email", Value: 1},
+				{Key: "revoked_before", Value: -1},
+			},
+			Options: options.Index().SetName("idx_email_revoked_before").SetSparse(true),
+		},
+		{
+			// Revocations are only needed until the tokens they cover would have expired anyway
+			Keys:    bson.D{{Key: "expires_at", Value: 1}},
+			Options: options.Index().SetName("idx_expires_at_ttl").SetExpireAfterSeconds(0),
+		},
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	_, err := collection.Indexes().CreateMany(ctx, indexes)
+	if err != nil {
+		return fmt.Errorf("failed to create indexes: %w", err)
+	}
+
+	return nil
+}
+
+// createProjectSettingsIndexes creates indexes for the project_settings collection
+func (d *Database) createProjectSettingsIndexes(ctx context.Context) error {
+	collection := d.GetProjectSettingsCollection()
+	indexes := []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "project_id", Value: 1}},
+			Options: options.Index().SetName("idx_project_id_unique").SetUnique(true),
+		},
+		{
+			Keys:    bson.D{{Key: "execution_retention_days", Value: 1}},
+			Options: options.Index().SetName("idx_execution_retention_days"),
+		},
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	_, err := collection.Indexes().CreateMany(ctx, indexes)
+	if err != nil {
+		return fmt.Errorf("failed to create indexes: %w", err)
+	}
+
+	return nil
+}
+
+// createTaskTemplateIndexes creates indexes for the task_templates collection
+func (d *Database) createTaskTemplateIndexes(ctx context.Context) error {
+	collection := d.GetTaskTemplatesCollection()
+	indexes := []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "uuid", Value: 1}},
+			Options: options.Index().SetUnique(true).SetName("idx_uuid"),
+		},
+		{
+			Keys: bson.D{
+				{Key: "project_id", Value: 1},
+				{Key: "name", Value: 1},
+			},
+			Options: options.Index().SetName("idx_project_name"),
+		},
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	_, err := collection.Indexes().CreateMany(ctx, indexes)
+	if err != nil {
+		return fmt.Errorf("failed to create indexes: %w", err)
+	}
+
+	return nil
+}
+
+// createEventOutboxIndexes creates indexes for the event_outbox collection
+func (d *Database) createEventOutboxIndexes(ctx context.Context) error {
+	collection := d.GetEventOutboxCollection()
+	indexes := []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "locked_until", Value: 1}, {Key: "created_at", Value: 1}},
+			Options: options.Index().SetName("idx_locked_until_created_at"),
+		},
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	_, err := collection.Indexes().CreateMany(ctx, indexes)
+	if err != nil {
+		return fmt.Errorf("failed to create indexes: %w", err)
+	}
+
+	return nil
+}
+
+// createJobQueueIndexes creates indexes for the job_queue collection
+func (d *Database) createJobQueueIndexes(ctx context.Context) error {
+	collection := d.GetJobQueueCollection()
+	indexes := []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "locked_until", Value: 1}, {Key: "created_at", Value: 1}},
+			Options: options.Index().SetName("idx_locked_until_created_at"),
+		},
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	_, err := collection.Indexes().CreateMany(ctx, indexes)
+	if err != nil {
+		return fmt.Errorf("failed to create indexes: %w", err)
+	}
+
+	return nil
+}
+
+// createIncidentIndexes creates indexes for the incidents collection
+func (d *Database) createIncidentIndexes(ctx context.Context) error {
+	collection := d.GetIncidentsCollection()
+	indexes := []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "uuid", Value: 1}},
+			Options: options.Index().SetUnique(true).SetName("idx_uuid"),
+		},
+		{
+			// Finds the active incident of a task on every failure and success
+			Keys:    bson.D{{Key: "task_uuid", Value: 1}, {Key: "status", Value: 1}},
+			Options: options.Index().SetName("idx_task_uuid_status"),
+		},
+		{
+			// A task has at most one OPEN or ACKNOWLEDGED incident, so concurrent failures cannot open two
+			Keys: bson.D{{Key: "task_uuid", Value: 1}},
+			Options: options.Index().SetUnique(true).SetName("idx_task_uuid_active").
+				SetPartialFilterExpression(bson.M{"status": bson.M{"$in": bson.A{"OPEN", "ACKNOWLEDGED"}}}),
+		},
+		{
+			Keys:    bson.D{{Key: "project_id", Value: 1}, {Key: "opened_at", Value: -1}},
+			Options: options.Index().SetName("idx_project_opened_at"),
+		},
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	_, err := collection.Indexes().CreateMany(ctx, indexes)
+	if err != nil {
+		return fmt.Errorf("failed to create indexes: %w", err)
+	}
+
+	return nil
+}
+
+// CreateExecutionPartitionIndexes creates the indexes of a monthly execution partition. Partitions are created on
+// demand, so this is called by the partitioned repository rather than by CreateIndexes.
+func CreateExecutionPartitionIndexes(ctx context.Context, collection *mongo.Collection) error {
+	indexes := []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "uuid", Value: 1}},
+			Options: options.Index().SetName("idx_uuid"),
+		},
+		{
+			Keys: bson.D{
+				{Key: "task_uuid", Value: 1},
+				{Key: "started_at", Value: -1},
+			},
+			Options: options.Index().SetName("idx_task_uuid_started_at"),
+		},
+		{
+			Keys: bson.D{
+				{Key: "task_id", Value: 1},
+				{Key: "started_at", Value: -1},
+			},
+			Options: options.Index().SetName("idx_task_id_started_at"),
+		},
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	_, err := collection.Indexes().CreateMany(ctx, indexes)
+	if err != nil {
+		return fmt.Errorf("failed to create indexes: %w", err)
+	}
+
+	return nil
+}
@@ -4,12 +4,13 @@ import (
 	"context"
 	"fmt"
 	"log"
-	"os"
 	"time"
 
+	"github.com/yourusername/cron-observer/backend/internal/config"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/mongo/readpref"
 )
 
 // Database holds the MongoDB client and database instance
@@ -40,51 +41,63 @@ func (d *Database) FindAll(ctx context.Context, collection string, filter interf
 	return cursor.All(ctx, results)
 }
 
-// NewConnection creates a new MongoDB connection
-func NewConnection() (*Database, error) {
-	// Get connection string from environment or use default
-	// Check DATABASE_URI first (used by config system), then MONGODB_URI for backward compatibility
-	uri := os.Getenv("DATABASE_URI")
-	if uri == "" {
-		uri = os.Getenv("MONGODB_URI")
+// NewConnection creates a new MongoDB connection with the configured pool size and timeouts
+func NewConnection(cfg config.DatabaseConfig) (*Database, error) {
+	opts := options.Client().
+		ApplyURI(cfg.URI).
+		SetRetryWrites(cfg.RetryWrites).
+		SetRetryReads(cfg.RetryWrites)
+	if cfg.MaxConns > 0 {
+		opts.SetMaxPoolSize(uint64(cfg.MaxConns))
 	}
-	if uri == "" {
-		uri = "mongodb://localhost:27017"
-	}
-
-	// Get database name from environment or use default
-	// Check DATABASE_NAME first (used by config system), then DB_NAME for backward compatibility
-	dbName := os.Getenv("DATABASE_NAME")
-	if dbName == "" {
-		dbName = os.Getenv("DB_NAME")
-	}
-	if dbName == "" {
-		dbName = "cronobserver"
+	if cfg.Timeout > 0 {
+		// Bounds dialing and waiting for a usable server; operations are bounded by their request contexts
+		opts.SetConnectTimeout(cfg.Timeout).SetServerSelectionTimeout(cfg.Timeout)
 	}
 
 	// Create context with timeout for connection
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	timeout := cfg.Timeout
+	if timeout <= 0 {
+		timeout = 10 * time.Second
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
 	defer cancel()
 
 	// Connect to MongoDB
-	client, err := mongo.Connect(ctx, options.Client().ApplyURI(uri))
+	client, err := mongo.Connect(ctx, opts)
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to MongoDB: %w", err)
 	}
 
 	// Ping to verify connection
 	if err = client.Ping(ctx, nil); err != nil {
+		client.Disconnect(context.Background())
 		return nil, fmt.Errorf("failed to ping MongoDB: %w", err)
 	}
 
-	log.Printf("Connected to MongoDB at %s, database: %s", uri, dbName)
+	log.Printf("Connected to MongoDB, database: %s (max pool size %d)", cfg.Name, cfg.MaxConns)
 
 	return &Database{
 		Client: client,
-		DB:     client.Database(dbName),
+		DB:     client.Database(cfg.Name),
 	}, nil
 }
 
+// AnalyticsDB returns a handle on the same database that reads with the given read preference mode (primary,
+// primaryPreferred, secondary, secondaryPreferred or nearest). Repositories built on it keep heavy reporting
+// queries off the primary; their results may lag behind the latest writes.
+func (d *Database) AnalyticsDB(readPreference string) (*mongo.Database, error) {
+	mode, err := readpref.ModeFromString(readPreference)
+	if err != nil {
+		return nil, fmt.Errorf("invalid analytics read preference %q: %w", readPreference, err)
+	}
+	pref, err := readpref.New(mode)
+	if err != nil {
+		return nil, err
+	}
+	return d.Client.Database(d.DB.Name(), options.Database().SetReadPreference(pref)), nil
+}
+
 // Close gracefully closes the MongoDB connection
 func (d *Database) Close() error {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
@@ -0,0 +1,53 @@
+package deletequeue
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/yourusername/cron-observer/backend/internal/jobqueue"
+)
+
+// RegisterHandlers routes the delete job types to the handler. Delete messages published before the
+// job queue existed carry no envelope; they are dispatched by their kind.
+func RegisterHandlers(router *jobqueue.Router, handler DeleteJobHandler) {
+	jobqueue.Register(router, handler.ProcessDeleteTask)
+	jobqueue.Register(router, handler.ProcessDeleteTaskGroup)
+	jobqueue.Register(router, handler.ProcessDeleteProject)
+	router.HandleUnenveloped(func(ctx context.Context, body json.RawMessage) error {
+		return dispatchUnenveloped(ctx, handler, body)
+	})
+}
+
+// dispatchUnenveloped decodes a delete job without an envelope by its kind and passes it to the handler
+func dispatchUnenveloped(ctx context.Context, handler DeleteJobHandler, body []byte) error {
+	var envelope struct {
+		Kind DeleteJobKind `json:"kind"`
+	}
+	if err := json.Unmarshal(body, &envelope); err != nil {
+		return fmt.Errorf("%w: %v", jobqueue.ErrMalformedJob, err)
+	}
+
+	switch envelope.Kind {
+	case DeleteJobKindTask, "":
+		var deleteMsg DeleteTaskMessage
+		if err := json.Unmarshal(body, &deleteMsg); err != nil {
+			return fmt.Errorf("%w: %v", jobqueue.ErrMalformedJob, err)
+		}
+		return handler.ProcessDeleteTask(ctx, deleteMsg)
+	case DeleteJobKindTaskGroup:
+		var deleteMsg DeleteTaskGroupMessage
+		if err := json.Unmarshal(body, &deleteMsg); err != nil {
+			return fmt.Errorf("%w: %v", jobqueue.ErrMalformedJob, err)
+		}
+		return handler.ProcessDeleteTaskGroup(ctx, deleteMsg)
+	case DeleteJobKindProject:
+		var deleteMsg DeleteProjectMessage
+		if err := json.Unmarshal(body, &deleteMsg); err != nil {
+			return fmt.Errorf("%w: %v", jobqueue.ErrMalformedJob, err)
+		}
+		return handler.ProcessDeleteProject(ctx, deleteMsg)
+	default:
+		return fmt.Errorf("%w: unknown delete job kind %q", jobqueue.ErrMalformedJob, envelope.Kind)
+	}
+}
@@ -0,0 +1,86 @@
+package deletequeue
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/yourusername/cron-observer/backend/internal/jobqueue"
+)
+
+// recordingHandler records the delete jobs it receives
+type recordingHandler struct {
+	tasks, taskGroups, projects []string
+}
+
+func (h *recordingHandler) ProcessDeleteTask(ctx context.Context, msg DeleteTaskMessage) error {
+	h.tasks = append(h.tasks, msg.TaskUUID)
+	return nil
+}
+
+func (h *recordingHandler) ProcessDeleteTaskGroup(ctx context.Context, msg DeleteTaskGroupMessage) error {
+	h.taskGroups = append(h.taskGroups, msg.TaskGroupUUID)
+	return nil
+}
+
+func (h *recordingHandler) ProcessDeleteProject(ctx context.Context, msg DeleteProjectMessage) error {
+	h.projects = append(h.projects, msg.ProjectID)
+	return nil
+}
+
+// capturePublisher keeps published jobs in their wire format
+type capturePublisher struct {
+	bodies [][]byte
+}
+
+func (p *capturePublisher) Publish(ctx context.Context, job jobqueue.Job) error {
+	envelope, err := jobqueue.NewEnvelope(job)
+	if err != nil {
+		return err
+	}
+	body, err := json.Marshal(envelope)
+	p.bodies = append(p.bodies, body)
+	return err
+}
+
+func TestPublishedDeleteJobsReachTheirHandlers(t *testing.T) {
+	ctx := context.Background()
+	jobs := &capturePublisher{}
+	publisher := NewPublisher(jobs)
+	publisher.PublishDeleteTask(ctx, DeleteTaskMessage{TaskUUID: "task-1"})
+	publisher.PublishDeleteTaskGroup(ctx, DeleteTaskGroupMessage{TaskGroupUUID: "group-1"})
+	publisher.PublishDeleteProject(ctx, DeleteProjectMessage{ProjectID: "project-1"})
+
+	handler := &recordingHandler{}
+	router := jobqueue.NewRouter()
+	RegisterHandlers(router, handler)
+	for _, body := range jobs.bodies {
+		if _, err := router.Dispatch(ctx, body); err != nil {
+			t.Fatalf("Dispatch(%s): %v", body, err)
+		}
+	}
+
+	if len(handler.tasks) != 1 || len(handler.taskGroups) != 1 || len(handler.projects) != 1 || handler.projects[0] != "project-1" {
+		t.Fatalf("handled %+v", handler)
+	}
+}
+
+func TestUnenvelopedDeleteJobsAreDispatchedByKind(t *testing.T) {
+	handler := &recordingHandler{}
+	router := jobqueue.NewRouter()
+	RegisterHandlers(router, handler)
+
+	for _, body := range []string{
+		`{"task_uuid":"task-1","project_id":"p1"}`,
+		`{"kind":"task_group","task_group_uuid":"group-1"}`,
+		`{"kind":"project","project_id":"project-1"}`,
+	} {
+		if _, err := router.Dispatch(context.Background(), []byte(body)); err != nil {
+			t.Fatalf("Dispatch(%s): %v", body, err)
+		}
+	}
+
+	if len(handler.tasks) != 1 || handler.tasks[0] != "task-1" || len(handler.taskGroups) != 1 || len(handler.projects) != 1 {
+		t.Fatalf("handled %+v", handler)
+	}
+}
@@ -1,12 +1,82 @@
+// Package deletequeue defines the delete jobs of tasks, task groups and projects, the first job types of the
+// job queue (see package jobqueue).
 package deletequeue
 
-import "time"
+import (
+	"time"
+
+	"github.com/yourusername/cron-observer/backend/internal/jobqueue"
+)
+
+// Job types of the delete jobs
+const (
+	JobTypeDeleteTask      jobqueue.JobType = "delete_task"
+	JobTypeDeleteTaskGroup jobqueue.JobType = "delete_task_group"
+	JobTypeDeleteProject   jobqueue.JobType = "delete_project"
+)
+
+// DeleteJobKind identifies what a delete job removes.
+// Messages published before kinds existed carry no kind and are task deletes.
+type DeleteJobKind string
+
+const (
+	DeleteJobKindTask      DeleteJobKind = "task"
+	DeleteJobKindTaskGroup DeleteJobKind = "task_group"
+	DeleteJobKindProject   DeleteJobKind = "project"
+)
 
 // DeleteTaskMessage is the message contract for enqueueing a task deletion job.
 // It is serialized to JSON when publishing to the message broker.
 type DeleteTaskMessage struct {
-	TaskUUID    string    `json:"task_uuid"`
-	ProjectID   string    `json:"project_id"`
-	RequestedAt time.Time `json:"requested_at"`
-	RequestID   string    `json:"request_id,omitempty"`
+	Kind        DeleteJobKind `json:"kind,omitempty"`
+	TaskUUID    string        `json:"task_uuid"`
+	ProjectID   string        `json:"project_id"`
+	RequestedAt time.Time     `json:"requested_at"`
+	RequestID   string        `json:"request_id,omitempty"`
+}
+
+// TaskGroupTaskPolicy decides what happens to a task group's tasks when the group is deleted.
+type TaskGroupTaskPolicy string
+
+const (
+	// TaskGroupTaskPolicyDetach keeps the tasks and removes their group reference (default).
+	TaskGroupTaskPolicyDetach TaskGroupTaskPolicy = "DETACH"
+	// TaskGroupTaskPolicyDelete deletes the tasks together with their executions.
+	TaskGroupTaskPolicyDelete TaskGroupTaskPolicy = "DELETE"
+)
+
+// DeleteTaskGroupMessage is the message contract for enqueueing a task group deletion job.
+type DeleteTaskGroupMessage struct {
+	Kind          DeleteJobKind       `json:"kind"`
+	TaskGroupUUID string              `json:"task_group_uuid"`
+	ProjectID     string              `json:"project_id"`
+	TaskPolicy    TaskGroupTaskPolicy `json:"task_policy"`
+	RequestedAt   time.Time           `json:"requested_at"`
+	RequestID     string              `json:"request_id,omitempty"`
 }
+
+// DeleteProjectMessage is the message contract for enqueueing a project deletion job.
+type DeleteProjectMessage struct {
+	Kind        DeleteJobKind `json:"kind"`
+	ProjectID   string        `json:"project_id"`
+	RequestedAt time.Time     `json:"requested_at"`
+	RequestID   string        `json:"request_id,omitempty"`
+}
+
+// JobType implements jobqueue.Job
+func (DeleteTaskMessage) JobType() jobqueue.JobType { return JobTypeDeleteTask }
+
+// JobType implements jobqueue.Job
+func (DeleteTaskGroupMessage) JobType() jobqueue.JobType { return JobTypeDeleteTaskGroup }
+
+// JobType implements jobqueue.Job
+func (DeleteProjectMessage) JobType() jobqueue.JobType { return JobTypeDeleteProject }
+
+// OrderingKey implements jobqueue.OrderedJob: deletes of the same task run one at a time
+func (m DeleteTaskMessage) OrderingKey() string { return m.TaskUUID }
+
+// OrderingKey implements jobqueue.OrderedJob
+func (m DeleteTaskGroupMessage) OrderingKey() string { return m.TaskGroupUUID }
+
+// OrderingKey implements jobqueue.OrderedJob
+func (m DeleteProjectMessage) OrderingKey() string { return m.ProjectID }
@@ -7,11 +7,14 @@ import "context"
 // the rest of the code stays independent of the specific broker.
 type DeleteJobPublisher interface {
 	PublishDeleteTask(ctx context.Context, msg DeleteTaskMessage) error
+	PublishDeleteTaskGroup(ctx context.Context, msg DeleteTaskGroupMessage) error
+	PublishDeleteProject(ctx context.Context, msg DeleteProjectMessage) error
 }
 
-// DeleteJobConsumer is a broker-agnostic interface for consuming delete job messages.
-// Start subscribes to the delete queue and invokes the handler for each message.
-// The handler should return nil to acknowledge the message, or an error to trigger retry/DLQ per broker policy.
-type DeleteJobConsumer interface {
-	Start(ctx context.Context, handler func(context.Context, DeleteTaskMessage) error) error
+// DeleteJobHandler processes delete jobs taken off the queue.
+// Returning nil acks the message; a non-nil error triggers broker retry/DLQ.
+type DeleteJobHandler interface {
+	ProcessDeleteTask(ctx context.Context, msg DeleteTaskMessage) error
+	ProcessDeleteTaskGroup(ctx context.Context, msg DeleteTaskGroupMessage) error
+	ProcessDeleteProject(ctx context.Context, msg DeleteProjectMessage) error
 }
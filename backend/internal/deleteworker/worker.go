@@ -4,14 +4,23 @@ import (
 	"context"
 	"errors"
 	"log"
+	"time"
 
 	"github.com/yourusername/cron-observer/backend/internal/deletequeue"
 	"github.com/yourusername/cron-observer/backend/internal/events"
 	"github.com/yourusername/cron-observer/backend/internal/models"
 	"github.com/yourusername/cron-observer/backend/internal/repositories"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
 )
 
+// executionDeleteBatchSize bounds how many executions are removed per delete operation
+// so large histories do not turn into one long-running delete.
+const executionDeleteBatchSize = 500
+
+// taskDeleteBatchSize bounds how many tasks are loaded at once while deleting a project
+const taskDeleteBatchSize = 100
+
 // TaskUnregisterer is the minimal scheduler interface needed for the delete worker.
 type TaskUnregisterer interface {
 	UnregisterTask(taskUUID string)
@@ -19,10 +28,10 @@ type TaskUnregisterer interface {
 
 // EventPublisher is the minimal event bus interface needed for the delete worker.
 type EventPublisher interface {
-	Publish(event events.Event)
+	Publish(event events.Event) error
 }
 
-// Worker processes delete job messages: stops cron, hard-deletes the task, publishes TaskDeleted.
+// Worker processes delete job messages: stops cron, hard-deletes the task or task group, publishes the deleted events.
 type Worker struct {
 	repo         repositories.Repository
 	scheduler    TaskUnregisterer // optional; nil-safe
@@ -66,7 +75,18 @@ func (w *Worker) ProcessDeleteTask(ctx context.Context, msg deletequeue.DeleteTa
 		log.Printf("[Worker] WARNING: Scheduler is nil, skipping UnregisterTask: TaskUUID=%s", task.UUID)
 	}
 
-	// Step 3: Hard delete from MongoDB
+	// Step 3: Delete the task's executions and take them out of the failure stats.
+	// Runs before the task document is removed so a failed attempt is retried with the task still present.
+	deletedExecutions, err := w.deleteTaskExecutions(ctx, task, true)
+	if err != nil {
+		log.Printf("[Worker] ERROR: Failed to delete executions: TaskUUID=%s, TaskName=%s, error=%v",
+			task.UUID, task.Name, err)
+		w.markDeleteFailed(ctx, task)
+		return err
+	}
+	log.Printf("[Worker] Task executions deleted: TaskUUID=%s, Executions=%d", task.UUID, deletedExecutions)
+
+	// Step 4: Hard delete from MongoDB
 	log.Printf("[Worker] Deleting task from database: TaskUUID=%s, TaskName=%s", 
 		task.UUID, task.Name)
 	if err := w.repo.DeleteTask(ctx, task.UUID); err != nil {
@@ -74,34 +94,313 @@ func (w *Worker) ProcessDeleteTask(ctx context.Context, msg deletequeue.DeleteTa
 			task.UUID, task.Name, err)
 		
 		// Mark as DELETE_FAILED for observability
-		if updateErr := w.repo.UpdateTaskStatus(ctx, task.UUID, models.TaskStatusDeleteFailed); updateErr != nil {
-			log.Printf("[Worker] WARNING: Failed to update status to DELETE_FAILED: TaskUUID=%s, error=%v", 
-				task.UUID, updateErr)
-		} else {
-			log.Printf("[Worker] Task marked as DELETE_FAILED: TaskUUID=%s, TaskName=%s", 
-				task.UUID, task.Name)
-		}
-		
+		w.markDeleteFailed(ctx, task)
 		return err
 	}
 
 	log.Printf("[Worker] Task successfully deleted from database: TaskUUID=%s, TaskName=%s", 
 		task.UUID, task.Name)
 
-	// Step 4: Publish TaskDeleted event
+	// Step 5: Publish TaskDeleted event
 	if w.eventPublisher != nil {
-		event := events.Event{
-			Type: events.TaskDeleted,
-			Payload: events.TaskDeletedPayload{
-				TaskUUID: task.UUID,
-			},
-		}
-		w.eventPublisher.Publish(event)
-		log.Printf("[Worker] TaskDeleted event published: TaskUUID=%s, TaskName=%s", 
-			task.UUID, task.Name)
+		if err := w.eventPublisher.Publish(events.TaskDeletedTopic.Event(events.TaskDeletedPayload{TaskUUID: task.UUID})); err != nil {
+			log.Printf("[Worker] ERROR: Failed to publish TaskDeleted event: TaskUUID=%s, error=%v", task.UUID, err)
+		} else {
+			log.Printf("[Worker] TaskDeleted event published: TaskUUID=%s, TaskName=%s", 
+				task.UUID, task.Name)
+		}
 	}
 
 	log.Printf("[Worker] Task delete process completed successfully: TaskUUID=%s, TaskName=%s", 
 		task.UUID, task.Name)
 	return nil
 }
+
+// deleteTaskExecutions removes a task's executions in batches. With adjustStats it also subtracts the task's failures
+// from the project's stats; each batch is deleted before its failures are subtracted, so an interrupted run can leave
+// counters high but never too low. Project deletion skips the adjustment because the project's stats are dropped anyway.
+func (w *Worker) deleteTaskExecutions(ctx context.Context, task *models.Task, adjustStats bool) (int64, error) {
+	var deleted int64
+	for {
+		executions, err := w.repo.GetExecutionBatchByTaskUUID(ctx, task.UUID, executionDeleteBatchSize)
+		if err != nil {
+			return deleted, err
+		}
+		if len(executions) == 0 {
+			break
+		}
+
+		ids := make([]primitive.ObjectID, 0, len(executions))
+		failuresByDate := make(map[string]int)
+		for _, execution := range executions {
+			ids = append(ids, execution.ID)
+			if adjustStats && execution.Status == models.ExecutionStatusFailed {
+				failuresByDate[execution.FailureStatDate()]++
+			}
+		}
+
+		count, err := w.repo.DeleteExecutionsByIDs(ctx, ids)
+		if err != nil {
+			return deleted, err
+		}
+		deleted += count
+
+		if len(failuresByDate) > 0 {
+			if err := w.repo.DecrementFailureStats(ctx, task.ProjectID, failuresByDate); err != nil {
+				return deleted, err
+			}
+		}
+
+		if len(executions) < executionDeleteBatchSize {
+			break
+		}
+	}
+
+	if !adjustStats {
+		return deleted, nil
+	}
+	if err := w.repo.RemoveTaskFromStoredFailureStats(ctx, task.ProjectID, task.UUID); err != nil {
+		return deleted, err
+	}
+	return deleted, nil
+}
+
+// markDeleteFailed sets the task status to DELETE_FAILED so the failure is visible to clients
+func (w *Worker) markDeleteFailed(ctx context.Context, task *models.Task) {
+	if updateErr := w.repo.UpdateTaskStatus(ctx, task.UUID, models.TaskStatusDeleteFailed); updateErr != nil {
+		log.Printf("[Worker] WARNING: Failed to update status to DELETE_FAILED: TaskUUID=%s, error=%v",
+			task.UUID, updateErr)
+	} else {
+		log.Printf("[Worker] Task marked as DELETE_FAILED: TaskUUID=%s, TaskName=%s",
+			task.UUID, task.Name)
+	}
+}
+
+// ProcessDeleteTaskGroup deletes a task group after deleting or detaching its tasks according to the message's policy.
+// Idempotent and retryable: tasks already handled are no longer members of the group on a retry.
+func (w *Worker) ProcessDeleteTaskGroup(ctx context.Context, msg deletequeue.DeleteTaskGroupMessage) error {
+	// Step 1: Fetch task group from repository
+	taskGroup, err := w.repo.GetTaskGroupByUUID(ctx, msg.TaskGroupUUID)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			log.Printf("[Worker] Task group already deleted (idempotent success): TaskGroupUUID=%s", msg.TaskGroupUUID)
+			return nil
+		}
+		log.Printf("[Worker] ERROR: Failed to fetch task group: TaskGroupUUID=%s, error=%v", msg.TaskGroupUUID, err)
+		return err
+	}
+
+	log.Printf("[Worker] Starting task group delete process: TaskGroupUUID=%s, Name=%s, TaskPolicy=%s",
+		taskGroup.UUID, taskGroup.Name, msg.TaskPolicy)
+
+	// Step 2: Delete or detach member tasks
+	tasks, err := w.repo.GetTasksByGroupID(ctx, taskGroup.ID)
+	if err != nil {
+		log.Printf("[Worker] ERROR: Failed to fetch tasks of task group: TaskGroupUUID=%s, error=%v", taskGroup.UUID, err)
+		return err
+	}
+
+	for _, task := range tasks {
+		if msg.TaskPolicy == deletequeue.TaskGroupTaskPolicyDelete {
+			taskMsg := deletequeue.DeleteTaskMessage{
+				Kind:        deletequeue.DeleteJobKindTask,
+				TaskUUID:    task.UUID,
+				ProjectID:   msg.ProjectID,
+				RequestedAt: msg.RequestedAt,
+				RequestID:   msg.RequestID,
+			}
+			if err := w.ProcessDeleteTask(ctx, taskMsg); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if err := w.detachTask(ctx, task); err != nil {
+			log.Printf("[Worker] ERROR: Failed to detach task from task group: TaskUUID=%s, TaskGroupUUID=%s, error=%v",
+				task.UUID, taskGroup.UUID, err)
+			return err
+		}
+	}
+
+	// Step 3: Hard delete the task group
+	if err := w.repo.DeleteTaskGroup(ctx, taskGroup.UUID); err != nil {
+		log.Printf("[Worker] ERROR: Failed to delete task group from database: TaskGroupUUID=%s, error=%v", taskGroup.UUID, err)
+		return err
+	}
+
+	// Step 4: Publish TaskGroupDeleted so the scheduler drops the group's window jobs
+	if w.eventPublisher != nil {
+		w.publish(events.TaskGroupDeletedTopic.Event(events.TaskGroupDeletedPayload{TaskGroupUUID: taskGroup.UUID}))
+	}
+
+	log.Printf("[Worker] Task group delete process completed successfully: TaskGroupUUID=%s, Tasks=%d, TaskPolicy=%s",
+		taskGroup.UUID, len(tasks), msg.TaskPolicy)
+	return nil
+}
+
+// detachTask removes a task's group reference and publishes TaskUpdated so the scheduler
+// re-registers it as a standalone task
+func (w *Worker) detachTask(ctx context.Context, task *models.Task) error {
+	task.TaskGroupID = nil
+	task.State = models.TaskStateNotRunning
+	task.UpdatedAt = time.Now()
+
+	if err := w.repo.UpdateTask(ctx, task.UUID, task); err != nil {
+		return err
+	}
+
+	if w.eventPublisher != nil {
+		w.publish(events.TaskUpdatedTopic.Event(events.TaskPayload{Task: task}))
+	}
+	return nil
+}
+
+// publish publishes an event about a deletion already applied; failures are logged since the deletion stands
+func (w *Worker) publish(event events.Event) {
+	if err := w.eventPublisher.Publish(event); err != nil {
+		log.Printf("[Worker] ERROR: Failed to publish %s event: %v", event.Type, err)
+	}
+}
+
+// ProcessDeleteProject removes a PENDING_DELETE project with its tasks, executions, task groups, stats, settings and
+// templates. Tasks are removed in batches and progress is stored on the project after each batch. Idempotent and
+// retryable: a retry picks up whatever is left.
+func (w *Worker) ProcessDeleteProject(ctx context.Context, msg deletequeue.DeleteProjectMessage) error {
+	projectID, err := primitive.ObjectIDFromHex(msg.ProjectID)
+	if err != nil {
+		log.Printf("[Worker] ERROR: Invalid project ID in delete job, dropping message: ProjectID=%s", msg.ProjectID)
+		return nil
+	}
+
+	// Step 1: Fetch project from repository
+	project, err := w.repo.GetProjectByID(ctx, projectID)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			log.Printf("[Worker] Project already deleted (idempotent success): ProjectID=%s", msg.ProjectID)
+			return nil
+		}
+		log.Printf("[Worker] ERROR: Failed to fetch project: ProjectID=%s, error=%v", msg.ProjectID, err)
+		return err
+	}
+	if project.Status != models.ProjectStatusPendingDelete {
+		log.Printf("[Worker] Project is not pending deletion, skipping: ProjectID=%s, Status=%s", msg.ProjectID, project.Status)
+		return nil
+	}
+
+	log.Printf("[Worker] Starting project delete process: ProjectID=%s, Name=%s", msg.ProjectID, project.Name)
+
+	progress := project.DeletionProgress
+	if progress == nil {
+		progress = &models.ProjectDeletionProgress{}
+	}
+
+	// Step 2: Delete tasks and their executions in batches
+	progress.Phase = models.ProjectDeletionPhaseTasks
+	for {
+		tasks, err := w.repo.GetTaskBatchByProjectID(ctx, projectID, taskDeleteBatchSize)
+		if err != nil {
+			log.Printf("[Worker] ERROR: Failed to fetch tasks of project: ProjectID=%s, error=%v", msg.ProjectID, err)
+			return err
+		}
+		if len(tasks) == 0 {
+			break
+		}
+
+		for _, task := range tasks {
+			deletedExecutions, err := w.deleteProjectTask(ctx, task)
+			progress.ExecutionsDeleted += deletedExecutions
+			if err != nil {
+				log.Printf("[Worker] ERROR: Failed to delete task of project: ProjectID=%s, TaskUUID=%s, error=%v",
+					msg.ProjectID, task.UUID, err)
+				w.saveDeletionProgress(ctx, projectID, progress)
+				return err
+			}
+			progress.TasksDeleted++
+		}
+		w.saveDeletionProgress(ctx, projectID, progress)
+
+		if len(tasks) < taskDeleteBatchSize {
+			break
+		}
+	}
+
+	// Step 3: Delete task groups
+	progress.Phase = models.ProjectDeletionPhaseTaskGroups
+	w.saveDeletionProgress(ctx, projectID, progress)
+	taskGroups, err := w.repo.GetTaskGroupsByProjectID(ctx, projectID)
+	if err != nil {
+		log.Printf("[Worker] ERROR: Failed to fetch task groups of project: ProjectID=%s, error=%v", msg.ProjectID, err)
+		return err
+	}
+	for _, taskGroup := range taskGroups {
+		if err := w.repo.DeleteTaskGroup(ctx, taskGroup.UUID); err != nil {
+			log.Printf("[Worker] ERROR: Failed to delete task group: ProjectID=%s, TaskGroupUUID=%s, error=%v",
+				msg.ProjectID, taskGroup.UUID, err)
+			w.saveDeletionProgress(ctx, projectID, progress)
+			return err
+		}
+		progress.TaskGroupsDeleted++
+
+		// Publish TaskGroupDeleted so the scheduler drops the group's window jobs
+		if w.eventPublisher != nil {
+			w.publish(events.TaskGroupDeletedTopic.Event(events.TaskGroupDeletedPayload{TaskGroupUUID: taskGroup.UUID}))
+		}
+	}
+
+	// Step 4: Delete stats, settings, templates and incidents, then the project itself
+	progress.Phase = models.ProjectDeletionPhaseCleanup
+	w.saveDeletionProgress(ctx, projectID, progress)
+	if err := w.repo.DeleteStatsByProjectID(ctx, projectID); err != nil {
+		log.Printf("[Worker] ERROR: Failed to delete stats of project: ProjectID=%s, error=%v", msg.ProjectID, err)
+		return err
+	}
+	if err := w.repo.DeleteProjectSettings(ctx, projectID); err != nil {
+		log.Printf("[Worker] ERROR: Failed to delete settings of project: ProjectID=%s, error=%v", msg.ProjectID, err)
+		return err
+	}
+	if err := w.repo.DeleteTaskTemplatesByProjectID(ctx, projectID); err != nil {
+		log.Printf("[Worker] ERROR: Failed to delete task templates of project: ProjectID=%s, error=%v", msg.ProjectID, err)
+		return err
+	}
+	if err := w.repo.DeleteIncidentsByProjectID(ctx, projectID); err != nil {
+		log.Printf("[Worker] ERROR: Failed to delete incidents of project: ProjectID=%s, error=%v", msg.ProjectID, err)
+		return err
+	}
+	if err := w.repo.DeleteProject(ctx, projectID); err != nil && !errors.Is(err, mongo.ErrNoDocuments) {
+		log.Printf("[Worker] ERROR: Failed to delete project from database: ProjectID=%s, error=%v", msg.ProjectID, err)
+		return err
+	}
+
+	log.Printf("[Worker] Project delete process completed successfully: ProjectID=%s, Tasks=%d, TaskGroups=%d, Executions=%d",
+		msg.ProjectID, progress.TasksDeleted, progress.TaskGroupsDeleted, progress.ExecutionsDeleted)
+	return nil
+}
+
+// deleteProjectTask unregisters and hard-deletes one task of a project being deleted together with its executions
+func (w *Worker) deleteProjectTask(ctx context.Context, task *models.Task) (int64, error) {
+	if w.scheduler != nil {
+		w.scheduler.UnregisterTask(task.UUID)
+	}
+
+	deletedExecutions, err := w.deleteTaskExecutions(ctx, task, false)
+	if err != nil {
+		return deletedExecutions, err
+	}
+
+	if err := w.repo.DeleteTask(ctx, task.UUID); err != nil && !errors.Is(err, mongo.ErrNoDocuments) {
+		return deletedExecutions, err
+	}
+
+	if w.eventPublisher != nil {
+		w.publish(events.TaskDeletedTopic.Event(events.TaskDeletedPayload{TaskUUID: task.UUID}))
+	}
+	return deletedExecutions, nil
+}
+
+// saveDeletionProgress stores the project's deletion progress. Failures are only logged: progress is informational
+// and the next batch or retry writes it again.
+func (w *Worker) saveDeletionProgress(ctx context.Context, projectID primitive.ObjectID, progress *models.ProjectDeletionProgress) {
+	if err := w.repo.UpdateProjectDeletionProgress(ctx, projectID, progress); err != nil {
+		log.Printf("[Worker] WARNING: Failed to update project deletion progress: ProjectID=%s, error=%v", projectID.Hex(), err)
+	}
+}
@@ -82,6 +82,14 @@ func TestWorker_ProcessDeleteTask_SuccessfulDelete(t *testing.T) {
 		UnregisterTask(taskUUID).
 		Times(1)
 
+	repo.EXPECT().
+		GetExecutionBatchByTaskUUID(gomock.Any(), taskUUID, executionDeleteBatchSize).
+		Return(nil, nil)
+
+	repo.EXPECT().
+		RemoveTaskFromStoredFailureStats(gomock.Any(), task.ProjectID, taskUUID).
+		Return(nil)
+
 	repo.EXPECT().
 		DeleteTask(gomock.Any(), taskUUID).
 		Return(nil).
@@ -150,6 +158,14 @@ func TestWorker_ProcessDeleteTask_DeleteFailure(t *testing.T) {
 		UnregisterTask(taskUUID).
 		Times(1)
 
+	repo.EXPECT().
+		GetExecutionBatchByTaskUUID(gomock.Any(), taskUUID, executionDeleteBatchSize).
+		Return(nil, nil)
+
+	repo.EXPECT().
+		RemoveTaskFromStoredFailureStats(gomock.Any(), task.ProjectID, taskUUID).
+		Return(nil)
+
 	repo.EXPECT().
 		DeleteTask(gomock.Any(), taskUUID).
 		Return(deleteErr).
@@ -216,6 +232,14 @@ func TestWorker_ProcessDeleteTask_DeleteFailure_UpdateStatusFails(t *testing.T)
 		UnregisterTask(taskUUID).
 		Times(1)
 
+	repo.EXPECT().
+		GetExecutionBatchByTaskUUID(gomock.Any(), taskUUID, executionDeleteBatchSize).
+		Return(nil, nil)
+
+	repo.EXPECT().
+		RemoveTaskFromStoredFailureStats(gomock.Any(), task.ProjectID, taskUUID).
+		Return(nil)
+
 	repo.EXPECT().
 		DeleteTask(gomock.Any(), taskUUID).
 		Return(deleteErr).
@@ -314,6 +338,14 @@ func TestWorker_ProcessDeleteTask_NilScheduler(t *testing.T) {
 		Return(task, nil).
 		Times(1)
 
+	repo.EXPECT().
+		GetExecutionBatchByTaskUUID(gomock.Any(), taskUUID, executionDeleteBatchSize).
+		Return(nil, nil)
+
+	repo.EXPECT().
+		RemoveTaskFromStoredFailureStats(gomock.Any(), task.ProjectID, taskUUID).
+		Return(nil)
+
 	repo.EXPECT().
 		DeleteTask(gomock.Any(), taskUUID).
 		Return(nil).
@@ -385,6 +417,14 @@ func TestWorker_ProcessDeleteTask_NilEventPublisher(t *testing.T) {
 	scheduler.EXPECT().
 		UnregisterTask(taskUUID)
 
+	repo.EXPECT().
+		GetExecutionBatchByTaskUUID(gomock.Any(), taskUUID, executionDeleteBatchSize).
+		Return(nil, nil)
+
+	repo.EXPECT().
+		RemoveTaskFromStoredFailureStats(gomock.Any(), task.ProjectID, taskUUID).
+		Return(nil)
+
 	repo.EXPECT().
 		DeleteTask(gomock.Any(), taskUUID).
 		Return(nil)
@@ -428,6 +468,14 @@ func TestWorker_ProcessDeleteTask_Idempotency(t *testing.T) {
 		UnregisterTask(taskUUID).
 		Times(1)
 
+	repo.EXPECT().
+		GetExecutionBatchByTaskUUID(gomock.Any(), taskUUID, executionDeleteBatchSize).
+		Return(nil, nil)
+
+	repo.EXPECT().
+		RemoveTaskFromStoredFailureStats(gomock.Any(), task.ProjectID, taskUUID).
+		Return(nil)
+
 	repo.EXPECT().
 		DeleteTask(gomock.Any(), taskUUID).
 		Return(nil).
@@ -538,6 +586,14 @@ func TestWorker_ProcessDeleteTask_EventPayloadValidation(t *testing.T) {
 		UnregisterTask(taskUUID).
 		Times(1)
 
+	repo.EXPECT().
+		GetExecutionBatchByTaskUUID(gomock.Any(), taskUUID, executionDeleteBatchSize).
+		Return(nil, nil)
+
+	repo.EXPECT().
+		RemoveTaskFromStoredFailureStats(gomock.Any(), task.ProjectID, taskUUID).
+		Return(nil)
+
 	repo.EXPECT().
 		DeleteTask(gomock.Any(), taskUUID).
 		Return(nil).
@@ -574,6 +630,282 @@ func TestWorker_ProcessDeleteTask_EventPayloadValidation(t *testing.T) {
 	}
 }
 
+func TestWorker_ProcessDeleteTask_DeletesExecutionsInBatchesAndDecrementsStats(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	taskUUID := "test-uuid"
+	task := &models.Task{
+		ID:        primitive.NewObjectID(),
+		UUID:      taskUUID,
+		ProjectID: primitive.NewObjectID(),
+		Status:    models.TaskStatusPendingDelete,
+	}
+
+	repo := mocks.NewMockRepository(ctrl)
+	eventPublisher := mocks.NewMockEventPublisher(ctrl)
+	worker := NewWorker(repo, nil, eventPublisher)
+
+	// A full first batch forces a second lookup; the short second batch ends the loop
+	day := time.Date(2025, 1, 15, 23, 30, 0, 0, time.UTC)
+	firstBatch := make([]*models.Execution, executionDeleteBatchSize)
+	for i := range firstBatch {
+		firstBatch[i] = &models.Execution{ID: primitive.NewObjectID(), Status: models.ExecutionStatusSuccess, StartedAt: day}
+	}
+	endedNextDay := day.Add(time.Hour)
+	firstBatch[0].Status = models.ExecutionStatusFailed
+	firstBatch[1].Status = models.ExecutionStatusFailed
+	firstBatch[1].EndedAt = &endedNextDay
+	secondBatch := []*models.Execution{
+		{ID: primitive.NewObjectID(), Status: models.ExecutionStatusFailed, StartedAt: day},
+	}
+
+	gomock.InOrder(
+		repo.EXPECT().GetTaskByUUID(gomock.Any(), taskUUID).Return(task, nil),
+		repo.EXPECT().GetExecutionBatchByTaskUUID(gomock.Any(), taskUUID, executionDeleteBatchSize).Return(firstBatch, nil),
+		repo.EXPECT().DeleteExecutionsByIDs(gomock.Any(), gomock.Len(executionDeleteBatchSize)).Return(int64(executionDeleteBatchSize), nil),
+		repo.EXPECT().
+			DecrementFailureStats(gomock.Any(), task.ProjectID, map[string]int{"2025-01-15": 1, "2025-01-16": 1}).
+			Return(nil),
+		repo.EXPECT().GetExecutionBatchByTaskUUID(gomock.Any(), taskUUID, executionDeleteBatchSize).Return(secondBatch, nil),
+		repo.EXPECT().DeleteExecutionsByIDs(gomock.Any(), []primitive.ObjectID{secondBatch[0].ID}).Return(int64(1), nil),
+		repo.EXPECT().DecrementFailureStats(gomock.Any(), task.ProjectID, map[string]int{"2025-01-15": 1}).Return(nil),
+		repo.EXPECT().RemoveTaskFromStoredFailureStats(gomock.Any(), task.ProjectID, taskUUID).Return(nil),
+		repo.EXPECT().DeleteTask(gomock.Any(), taskUUID).Return(nil),
+		eventPublisher.EXPECT().Publish(gomock.Any()),
+	)
+
+	err := worker.ProcessDeleteTask(context.Background(), deletequeue.DeleteTaskMessage{TaskUUID: taskUUID, RequestedAt: time.Now()})
+	if err != nil {
+		t.Fatalf("Expected nil error, got: %v", err)
+	}
+}
+
+func TestWorker_ProcessDeleteTask_ExecutionDeleteFailureKeepsTask(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	taskUUID := "test-uuid"
+	task := &models.Task{
+		ID:        primitive.NewObjectID(),
+		UUID:      taskUUID,
+		ProjectID: primitive.NewObjectID(),
+		Status:    models.TaskStatusPendingDelete,
+	}
+
+	repo := mocks.NewMockRepository(ctrl)
+	worker := NewWorker(repo, nil, mocks.NewMockEventPublisher(ctrl))
+
+	repo.EXPECT().GetTaskByUUID(gomock.Any(), taskUUID).Return(task, nil)
+	repo.EXPECT().
+		GetExecutionBatchByTaskUUID(gomock.Any(), taskUUID, executionDeleteBatchSize).
+		Return(nil, errors.New("connection reset"))
+	repo.EXPECT().UpdateTaskStatus(gomock.Any(), taskUUID, models.TaskStatusDeleteFailed).Return(nil)
+
+	err := worker.ProcessDeleteTask(context.Background(), deletequeue.DeleteTaskMessage{TaskUUID: taskUUID, RequestedAt: time.Now()})
+	if err == nil {
+		t.Fatal("Expected error so the delete job is retried")
+	}
+}
+
+func TestWorker_ProcessDeleteTaskGroup_DetachesTasks(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	groupID := primitive.NewObjectID()
+	taskGroup := &models.TaskGroup{ID: groupID, UUID: "group-uuid", ProjectID: primitive.NewObjectID()}
+	task := &models.Task{UUID: "task-uuid", TaskGroupID: &groupID, State: models.TaskStateRunning}
+
+	repo := mocks.NewMockRepository(ctrl)
+	eventPublisher := mocks.NewMockEventPublisher(ctrl)
+	worker := NewWorker(repo, nil, eventPublisher)
+
+	var published []events.Event
+	gomock.InOrder(
+		repo.EXPECT().GetTaskGroupByUUID(gomock.Any(), "group-uuid").Return(taskGroup, nil),
+		repo.EXPECT().GetTasksByGroupID(gomock.Any(), groupID).Return([]*models.Task{task}, nil),
+		repo.EXPECT().
+			UpdateTask(gomock.Any(), "task-uuid", gomock.Any()).
+			Do(func(ctx context.Context, taskUUID string, updated *models.Task) {
+				if updated.TaskGroupID != nil {
+					t.Errorf("Expected task to be detached from its group, got %v", updated.TaskGroupID)
+				}
+			}).
+			Return(nil),
+		repo.EXPECT().DeleteTaskGroup(gomock.Any(), "group-uuid").Return(nil),
+	)
+	eventPublisher.EXPECT().
+		Publish(gomock.Any()).
+		Do(func(event events.Event) { published = append(published, event) }).
+		Times(2)
+
+	msg := deletequeue.DeleteTaskGroupMessage{
+		TaskGroupUUID: "group-uuid",
+		TaskPolicy:    deletequeue.TaskGroupTaskPolicyDetach,
+		RequestedAt:   time.Now(),
+	}
+	if err := worker.ProcessDeleteTaskGroup(context.Background(), msg); err != nil {
+		t.Fatalf("Expected nil error, got: %v", err)
+	}
+
+	if published[0].Type != events.TaskUpdated || published[1].Type != events.TaskGroupDeleted {
+		t.Errorf("Expected TaskUpdated then TaskGroupDeleted, got %v and %v", published[0].Type, published[1].Type)
+	}
+}
+
+func TestWorker_ProcessDeleteTaskGroup_DeletesTasks(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	groupID := primitive.NewObjectID()
+	projectID := primitive.NewObjectID()
+	taskGroup := &models.TaskGroup{ID: groupID, UUID: "group-uuid", ProjectID: projectID}
+	task := &models.Task{UUID: "task-uuid", ProjectID: projectID, TaskGroupID: &groupID}
+
+	repo := mocks.NewMockRepository(ctrl)
+	scheduler := mocks.NewMockTaskUnregisterer(ctrl)
+	eventPublisher := mocks.NewMockEventPublisher(ctrl)
+	worker := NewWorker(repo, scheduler, eventPublisher)
+
+	gomock.InOrder(
+		repo.EXPECT().GetTaskGroupByUUID(gomock.Any(), "group-uuid").Return(taskGroup, nil),
+		repo.EXPECT().GetTasksByGroupID(gomock.Any(), groupID).Return([]*models.Task{task}, nil),
+		repo.EXPECT().GetTaskByUUID(gomock.Any(), "task-uuid").Return(task, nil),
+		repo.EXPECT().GetExecutionBatchByTaskUUID(gomock.Any(), "task-uuid", executionDeleteBatchSize).Return(nil, nil),
+		repo.EXPECT().RemoveTaskFromStoredFailureStats(gomock.Any(), projectID, "task-uuid").Return(nil),
+		repo.EXPECT().DeleteTask(gomock.Any(), "task-uuid").Return(nil),
+		repo.EXPECT().DeleteTaskGroup(gomock.Any(), "group-uuid").Return(nil),
+	)
+	scheduler.EXPECT().UnregisterTask("task-uuid")
+	eventPublisher.EXPECT().Publish(gomock.Any()).Times(2) // TaskDeleted, TaskGroupDeleted
+
+	msg := deletequeue.DeleteTaskGroupMessage{
+		TaskGroupUUID: "group-uuid",
+		ProjectID:     projectID.Hex(),
+		TaskPolicy:    deletequeue.TaskGroupTaskPolicyDelete,
+		RequestedAt:   time.Now(),
+	}
+	if err := worker.ProcessDeleteTaskGroup(context.Background(), msg); err != nil {
+		t.Fatalf("Expected nil error, got: %v", err)
+	}
+}
+
+func TestWorker_ProcessDeleteTaskGroup_AlreadyDeleted(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockRepository(ctrl)
+	worker := NewWorker(repo, nil, nil)
+
+	repo.EXPECT().GetTaskGroupByUUID(gomock.Any(), "group-uuid").Return(nil, mongo.ErrNoDocuments)
+
+	msg := deletequeue.DeleteTaskGroupMessage{TaskGroupUUID: "group-uuid", RequestedAt: time.Now()}
+	if err := worker.ProcessDeleteTaskGroup(context.Background(), msg); err != nil {
+		t.Errorf("Expected nil error (idempotent success), got: %v", err)
+	}
+}
+
+func TestWorker_ProcessDeleteProject_DeletesEverythingAndTracksProgress(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	projectID := primitive.NewObjectID()
+	project := &models.Project{ID: projectID, Name: "doomed", Status: models.ProjectStatusPendingDelete}
+	task := &models.Task{ID: primitive.NewObjectID(), UUID: "task-uuid", ProjectID: projectID}
+	failed := &models.Execution{ID: primitive.NewObjectID(), TaskUUID: "task-uuid", Status: models.ExecutionStatusFailed}
+
+	repo := mocks.NewMockRepository(ctrl)
+	scheduler := mocks.NewMockTaskUnregisterer(ctrl)
+	eventPublisher := mocks.NewMockEventPublisher(ctrl)
+	worker := NewWorker(repo, scheduler, eventPublisher)
+
+	var phases []models.ProjectDeletionPhase
+	var lastProgress models.ProjectDeletionProgress
+	repo.EXPECT().
+		UpdateProjectDeletionProgress(gomock.Any(), projectID, gomock.Any()).
+		Do(func(ctx context.Context, id primitive.ObjectID, progress *models.ProjectDeletionProgress) {
+			phases = append(phases, progress.Phase)
+			lastProgress = *progress
+		}).
+		Return(nil).
+		AnyTimes()
+
+	gomock.InOrder(
+		repo.EXPECT().GetProjectByID(gomock.Any(), projectID).Return(project, nil),
+		repo.EXPECT().GetTaskBatchByProjectID(gomock.Any(), projectID, taskDeleteBatchSize).Return([]*models.Task{task}, nil),
+		repo.EXPECT().GetExecutionBatchByTaskUUID(gomock.Any(), "task-uuid", executionDeleteBatchSize).Return([]*models.Execution{failed}, nil),
+		repo.EXPECT().DeleteExecutionsByIDs(gomock.Any(), []primitive.ObjectID{failed.ID}).Return(int64(1), nil),
+		repo.EXPECT().DeleteTask(gomock.Any(), "task-uuid").Return(nil),
+		repo.EXPECT().GetTaskGroupsByProjectID(gomock.Any(), projectID).Return([]*models.TaskGroup{{UUID: "group-uuid", ProjectID: projectID}}, nil),
+		repo.EXPECT().DeleteTaskGroup(gomock.Any(), "group-uuid").Return(nil),
+		repo.EXPECT().DeleteStatsByProjectID(gomock.Any(), projectID).Return(nil),
+		repo.EXPECT().DeleteProjectSettings(gomock.Any(), projectID).Return(nil),
+		repo.EXPECT().DeleteTaskTemplatesByProjectID(gomock.Any(), projectID).Return(nil),
+		repo.EXPECT().DeleteIncidentsByProjectID(gomock.Any(), projectID).Return(nil),
+		repo.EXPECT().DeleteProject(gomock.Any(), projectID).Return(nil),
+	)
+	// Stats are dropped with the project, so no per-task adjustment
+	repo.EXPECT().DecrementFailureStats(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+	repo.EXPECT().RemoveTaskFromStoredFailureStats(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+	scheduler.EXPECT().UnregisterTask("task-uuid")
+	eventPublisher.EXPECT().Publish(gomock.Any()).Times(2) // TaskDeleted, TaskGroupDeleted
+
+	msg := deletequeue.DeleteProjectMessage{ProjectID: projectID.Hex(), RequestedAt: time.Now()}
+	if err := worker.ProcessDeleteProject(context.Background(), msg); err != nil {
+		t.Fatalf("Expected nil error, got: %v", err)
+	}
+
+	if len(phases) == 0 || phases[0] != models.ProjectDeletionPhaseTasks || phases[len(phases)-1] != models.ProjectDeletionPhaseCleanup {
+		t.Errorf("Expected progress from TASKS to CLEANUP, got %v", phases)
+	}
+	if lastProgress.TasksDeleted != 1 || lastProgress.ExecutionsDeleted != 1 {
+		t.Errorf("Expected 1 task and 1 execution deleted, got %+v", lastProgress)
+	}
+}
+
+func TestWorker_ProcessDeleteProject_TaskFailureKeepsProject(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	projectID := primitive.NewObjectID()
+	project := &models.Project{ID: projectID, Status: models.ProjectStatusPendingDelete}
+	task := &models.Task{UUID: "task-uuid", ProjectID: projectID}
+
+	repo := mocks.NewMockRepository(ctrl)
+	worker := NewWorker(repo, nil, nil)
+
+	repo.EXPECT().GetProjectByID(gomock.Any(), projectID).Return(project, nil)
+	repo.EXPECT().GetTaskBatchByProjectID(gomock.Any(), projectID, taskDeleteBatchSize).Return([]*models.Task{task}, nil)
+	repo.EXPECT().GetExecutionBatchByTaskUUID(gomock.Any(), "task-uuid", executionDeleteBatchSize).Return(nil, nil)
+	repo.EXPECT().DeleteTask(gomock.Any(), "task-uuid").Return(errors.New("connection reset"))
+	repo.EXPECT().UpdateProjectDeletionProgress(gomock.Any(), projectID, gomock.Any()).Return(nil)
+	repo.EXPECT().DeleteProject(gomock.Any(), gomock.Any()).Times(0)
+
+	msg := deletequeue.DeleteProjectMessage{ProjectID: projectID.Hex(), RequestedAt: time.Now()}
+	if err := worker.ProcessDeleteProject(context.Background(), msg); err == nil {
+		t.Fatal("Expected error so the delete job is retried")
+	}
+}
+
+func TestWorker_ProcessDeleteProject_SkipsProjectNotPendingDelete(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	projectID := primitive.NewObjectID()
+	project := &models.Project{ID: projectID, Status: models.ProjectStatusActive}
+
+	repo := mocks.NewMockRepository(ctrl)
+	worker := NewWorker(repo, nil, nil)
+
+	repo.EXPECT().GetProjectByID(gomock.Any(), projectID).Return(project, nil)
+	repo.EXPECT().GetTaskBatchByProjectID(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+	msg := deletequeue.DeleteProjectMessage{ProjectID: projectID.Hex(), RequestedAt: time.Now()}
+	if err := worker.ProcessDeleteProject(context.Background(), msg); err != nil {
+		t.Errorf("Expected nil error, got: %v", err)
+	}
+}
+
 func TestNewWorker(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
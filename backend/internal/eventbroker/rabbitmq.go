@@ -0,0 +1,206 @@
+package eventbroker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+	"github.com/yourusername/cron-observer/backend/internal/events"
+	"github.com/yourusername/cron-observer/backend/internal/jobqueue"
+)
+
+const (
+	// defaultQueueExpiry is how long the queue of a replica outlives its last connection
+	defaultQueueExpiry = time.Hour
+	// consumerPrefetch bounds the unacknowledged events RabbitMQ delivers ahead
+	consumerPrefetch = 50
+)
+
+// RabbitMQOptions tunes a RabbitMQ broker. Zero values use the defaults.
+type RabbitMQOptions struct {
+	// Replica names this replica's queue. Defaults to the hostname, which must then be stable across restarts
+	// (e.g. a StatefulSet pod name) for events published while the replica is down to reach it.
+	Replica string
+	// QueueExpiry is how long the queue of a replica is kept once nothing consumes it, so the queues of replicas
+	// that are gone for good are removed (default one hour)
+	QueueExpiry time.Duration
+}
+
+// RabbitMQBroker implements events.Broker with a RabbitMQ topic exchange. Events are published with their type
+// as routing key, as persistent messages confirmed by the broker. Every replica consumes them from its own
+// durable queue bound to the exchange and acknowledges each event once it has been handed to local
+// subscribers, so events survive a RabbitMQ restart and reconnects of the replica. The connection is
+// re-established when RabbitMQ closes it.
+type RabbitMQBroker struct {
+	session  *jobqueue.RabbitMQSession
+	exchange string
+	queue    string
+}
+
+// NewRabbitMQBroker connects to RabbitMQ at the given URL, declares the exchange and binds the queue of
+// this replica to it.
+func NewRabbitMQBroker(amqpURL, exchange string, opts RabbitMQOptions) (*RabbitMQBroker, error) {
+	if opts.Replica == "" {
+		hostname, err := os.Hostname()
+		if err != nil {
+			return nil, fmt.Errorf("name the replica of the event broker: %w", err)
+		}
+		opts.Replica = hostname
+	}
+	if opts.QueueExpiry <= 0 {
+		opts.QueueExpiry = defaultQueueExpiry
+	}
+	queue := exchange + "." + opts.Replica
+
+	session, err := jobqueue.NewRabbitMQSession(amqpURL, func(ch *amqp.Channel) error {
+		// Declare exchange (idempotent: creates if not exists, same on every replica)
+		err := ch.ExchangeDeclare(
+			exchange, // name
+			"topic",  // kind
+			true,     // durable
+			false,    // auto-deleted
+			false,    // internal
+			false,    // no-wait
+			nil,      // arguments
+		)
+		if err != nil {
+			return err
+		}
+
+		// Durable and not exclusive, so the queue and its events outlive the connection; x-expires removes
+		// it once the replica has not come back for a while
+		_, err = ch.QueueDeclare(
+			queue, // name
+			true,  // durable
+			false, // delete when unused
+			false, // exclusive
+			false, // no-wait
+			amqp.Table{"x-expires": opts.QueueExpiry.Milliseconds()},
+		)
+		if err != nil {
+			return err
+		}
+		if err := ch.QueueBind(queue, "#", exchange, false, nil); err != nil {
+			return err
+		}
+
+		if err := ch.Qos(consumerPrefetch, 0, false); err != nil {
+			return err
+		}
+
+		// Publisher confirms: the broker acks each event once it is stored in the bound queues
+		return ch.Confirm(false)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &RabbitMQBroker{
+		session:  session,
+		exchange: exchange,
+		queue:    queue,
+	}, nil
+}
+
+// PublishEvent publishes the encoded event to the exchange, routed by its type, and waits for RabbitMQ to
+// confirm it. Fails with jobqueue.ErrNotConnected while reconnecting.
+func (b *RabbitMQBroker) PublishEvent(ctx context.Context, eventType events.EventType, body []byte) error {
+	ch, err := b.session.Channel()
+	if err != nil {
+		return err
+	}
+
+	confirmation, err := ch.PublishWithDeferredConfirmWithContext(
+		ctx,
+		b.exchange,        // exchange
+		string(eventType), // routing key
+		false,             // mandatory
+		false,             // immediate
+		amqp.Publishing{
+			ContentType:  "application/bson",
+			Type:         string(eventType),
+			Body:         body,
+			DeliveryMode: amqp.Persistent, // survives a RabbitMQ restart, like the durable queues
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	acked, err := confirmation.WaitContext(ctx)
+	if err != nil {
+		return fmt.Errorf("waiting for RabbitMQ to confirm %s event: %w", eventType, err)
+	}
+	if !acked {
+		return fmt.Errorf("%s event not confirmed by RabbitMQ", eventType)
+	}
+	return nil
+}
+
+// ConsumeEvents consumes this replica's queue and passes each event to handle, acknowledging it once handle
+// returned. When the connection is lost, unacknowledged events are redelivered and the consumer subscribes
+// again once reconnected. Runs until ctx is cancelled or the broker is closed.
+func (b *RabbitMQBroker) ConsumeEvents(ctx context.Context, handle func(eventType events.EventType, body []byte)) error {
+	for {
+		ch, err := b.session.WaitChannel(ctx)
+		if errors.Is(err, jobqueue.ErrSessionClosed) {
+			return nil
+		}
+		if err != nil {
+			log.Printf("[eventbroker] Consumer context cancelled, stopping")
+			return err
+		}
+
+		msgs, err := ch.Consume(
+			b.queue, // queue
+			"",      // consumer tag (empty = auto-generated)
+			false,   // auto-ack (false = manual ack)
+			true,    // exclusive
+			false,   // no-local
+			false,   // no-wait
+			nil,     // args
+		)
+		if err != nil {
+			if errors.Is(err, amqp.ErrClosed) {
+				// The channel closed between WaitChannel and Consume; wait for the reconnection
+				continue
+			}
+			return err
+		}
+
+		log.Printf("[eventbroker] RabbitMQ consumer started for exchange: %s (queue %s)", b.exchange, b.queue)
+		if err := b.consume(ctx, msgs, handle); err != nil {
+			return err
+		}
+		log.Printf("[eventbroker] Message channel closed, re-subscribing after reconnect")
+	}
+}
+
+// consume handles deliveries until the channel closes (nil) or ctx is cancelled
+func (b *RabbitMQBroker) consume(ctx context.Context, msgs <-chan amqp.Delivery, handle func(eventType events.EventType, body []byte)) error {
+	for {
+		select {
+		case <-ctx.Done():
+			log.Printf("[eventbroker] Consumer context cancelled, stopping")
+			return ctx.Err()
+		case msg, ok := <-msgs:
+			if !ok {
+				return nil
+			}
+			handle(events.EventType(msg.RoutingKey), msg.Body)
+			if err := msg.Ack(false); err != nil {
+				// Redelivered after the reconnection; subscribers tolerate duplicates
+				log.Printf("[eventbroker] Failed to acknowledge %s event: %v", msg.RoutingKey, err)
+			}
+		}
+	}
+}
+
+// Close closes the RabbitMQ connection and stops reconnecting.
+func (b *RabbitMQBroker) Close() error {
+	return b.session.Close()
+}
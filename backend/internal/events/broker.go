@@ -0,0 +1,92 @@
+package events
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+)
+
+// Broker carries events between the backend replicas of a deployment. Every replica receives every
+// event published by any replica, including its own. Implemented by the eventbroker package.
+type Broker interface {
+	PublishEvent(ctx context.Context, eventType EventType, body []byte) error
+	// ConsumeEvents passes received events to handle until ctx is cancelled or the broker connection fails
+	ConsumeEvents(ctx context.Context, handle func(eventType EventType, body []byte)) error
+}
+
+// replicatedEvents are the events every replica must see: each replica's scheduler keeps its own cron
+// entries. Other events, such as ExecutionFailed, are handled once by the replica that published them.
+var replicatedEvents = map[EventType]bool{
+	TaskCreated:      true,
+	TaskUpdated:      true,
+	TaskDeleted:      true,
+	TaskGroupCreated: true,
+	TaskGroupUpdated: true,
+	TaskGroupDeleted: true,
+	ProjectArchived:  true,
+	ProjectRestored:  true,
+}
+
+const brokerPublishTimeout = 5 * time.Second
+
+type broker struct {
+	Broker
+}
+
+// EnableBroker makes Publish send replicated events (task, task group and project changes) through the
+// broker instead of straight to local subscribers, so the schedulers of all replicas see them. With the
+// outbox enabled as well, replicated events are persisted first and RunOutbox forwards them to the broker,
+// retrying until it accepts them. Start RunBroker, which delivers the events received from the broker.
+// Call before publishing.
+func (b *EventBus) EnableBroker(br Broker) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.broker = &broker{Broker: br}
+}
+
+// RunBroker delivers events received from the broker to local subscribers until ctx is cancelled or
+// the broker stops consuming. Returns nil immediately if no broker is enabled.
+func (b *EventBus) RunBroker(ctx context.Context) error {
+	b.mu.RLock()
+	br := b.broker
+	b.mu.RUnlock()
+	if br == nil {
+		return nil
+	}
+
+	return br.ConsumeEvents(ctx, func(eventType EventType, body []byte) {
+		event, err := decodeEventRecord(body)
+		if err != nil {
+			log.Printf("[EventBus] Dropping undecodable %s event from broker: %v", eventType, err)
+			b.metrics.dropped(eventType)
+			return
+		}
+		b.publishLocal(event)
+	})
+}
+
+// publish sends a replicated event straight to the broker, on buses without an outbox. Returns false if the
+// event is not replicated or the broker is unavailable, in which case only local subscribers can receive it.
+func (br *broker) publish(event Event) bool {
+	if !replicatedEvents[event.Type] {
+		return false
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), brokerPublishTimeout)
+	defer cancel()
+	if err := br.send(ctx, event); err != nil {
+		log.Printf("[EventBus] Failed to publish %s event to broker, other replicas will miss it: %v", event.Type, err)
+		return false
+	}
+	return true
+}
+
+// send encodes the event and publishes it to the broker
+func (br *broker) send(ctx context.Context, event Event) error {
+	body, err := encodeEventRecord(event)
+	if err != nil {
+		return fmt.Errorf("encode %s event: %w", event.Type, err)
+	}
+	return br.PublishEvent(ctx, event.Type, body)
+}
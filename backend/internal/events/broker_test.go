@@ -0,0 +1,180 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/yourusername/cron-observer/backend/internal/models"
+)
+
+type brokerMessage struct {
+	eventType EventType
+	body      []byte
+}
+
+// memoryBroker fans every published event out to all connected replicas
+type memoryBroker struct {
+	mu       sync.Mutex
+	replicas []chan brokerMessage
+	failing  bool
+}
+
+// connect returns the Broker of one replica
+func (m *memoryBroker) connect() Broker {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	ch := make(chan brokerMessage, 10)
+	m.replicas = append(m.replicas, ch)
+	return &memoryBrokerReplica{broker: m, messages: ch}
+}
+
+type memoryBrokerReplica struct {
+	broker   *memoryBroker
+	messages chan brokerMessage
+}
+
+func (r *memoryBrokerReplica) PublishEvent(_ context.Context, eventType EventType, body []byte) error {
+	r.broker.mu.Lock()
+	defer r.broker.mu.Unlock()
+	if r.broker.failing {
+		return errors.New("broker unavailable")
+	}
+	for _, ch := range r.broker.replicas {
+		ch <- brokerMessage{eventType: eventType, body: body}
+	}
+	return nil
+}
+
+func (r *memoryBrokerReplica) ConsumeEvents(ctx context.Context, handle func(eventType EventType, body []byte)) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case msg := <-r.messages:
+			handle(msg.eventType, msg.body)
+		}
+	}
+}
+
+func TestBroker_DeliversReplicatedEventsToEveryReplica(t *testing.T) {
+	broker := &memoryBroker{}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var received []<-chan TaskPayload
+	var buses []*EventBus
+	for i := 0; i < 2; i++ {
+		bus := NewEventBus(10)
+		bus.EnableBroker(broker.connect())
+		received = append(received, Subscribe(bus, TaskUpdatedTopic))
+		buses = append(buses, bus)
+		go bus.RunBroker(ctx)
+	}
+
+	Publish(buses[0], TaskUpdatedTopic, TaskPayload{Task: &models.Task{UUID: "task-1"}})
+
+	for i, ch := range received {
+		select {
+		case payload := <-ch:
+			if payload.Task == nil || payload.Task.UUID != "task-1" {
+				t.Fatalf("replica %d received unexpected payload %+v", i, payload)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("replica %d did not receive the event", i)
+		}
+	}
+}
+
+func TestBroker_KeepsOtherEventsLocal(t *testing.T) {
+	broker := &memoryBroker{}
+	local, remote := NewEventBus(10), NewEventBus(10)
+	local.EnableBroker(broker.connect())
+	remote.EnableBroker(broker.connect())
+	localFailed := Subscribe(local, ExecutionFailedTopic)
+	remoteFailed := Subscribe(remote, ExecutionFailedTopic)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go local.RunBroker(ctx)
+	go remote.RunBroker(ctx)
+
+	Publish(local, ExecutionFailedTopic, ExecutionFailedPayload{Task: &models.Task{UUID: "task-1"}})
+
+	if len(localFailed) != 1 {
+		t.Fatalf("local subscriber received %d events, want 1", len(localFailed))
+	}
+	select {
+	case <-remoteFailed:
+		t.Fatal("ExecutionFailed reached another replica")
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestBroker_FallsBackToLocalDeliveryWhenUnavailable(t *testing.T) {
+	broker := &memoryBroker{failing: true}
+	bus := NewEventBus(10)
+	bus.EnableBroker(broker.connect())
+	created := Subscribe(bus, TaskCreatedTopic)
+
+	Publish(bus, TaskCreatedTopic, TaskPayload{Task: &models.Task{UUID: "task-1"}})
+
+	if len(created) != 1 {
+		t.Fatalf("subscriber received %d events, want 1", len(created))
+	}
+}
+
+func TestBroker_OutboxKeepsReplicatedEventsUntilBrokerAcceptsThem(t *testing.T) {
+	broker := &memoryBroker{failing: true}
+	store := &memoryOutbox{}
+	local, remote := NewEventBus(10), NewEventBus(10)
+	local.EnableOutbox(store, OutboxOptions{PollInterval: 10 * time.Millisecond, Lease: 10 * time.Millisecond})
+	local.EnableBroker(broker.connect())
+	remote.EnableBroker(broker.connect())
+	localUpdated := Subscribe(local, TaskUpdatedTopic)
+	remoteUpdated := Subscribe(remote, TaskUpdatedTopic)
+
+	if err := Publish(local, TaskUpdatedTopic, TaskPayload{Task: &models.Task{UUID: "task-1"}}); err != nil {
+		t.Fatalf("Publish failed: %v", err)
+	}
+	if store.pending() != 1 {
+		t.Fatalf("Expected the event to be persisted before reaching the broker, %d pending", store.pending())
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go local.RunOutbox(ctx)
+	go local.RunBroker(ctx)
+	go remote.RunBroker(ctx)
+
+	// While the broker is down the event stays in the outbox instead of reaching this replica only
+	time.Sleep(50 * time.Millisecond)
+	if store.pending() != 1 || len(localUpdated) != 0 {
+		t.Fatalf("Expected the event to wait for the broker, %d pending, %d delivered", store.pending(), len(localUpdated))
+	}
+
+	broker.mu.Lock()
+	broker.failing = false
+	broker.mu.Unlock()
+
+	for name, ch := range map[string]<-chan TaskPayload{"local": localUpdated, "remote": remoteUpdated} {
+		select {
+		case payload := <-ch:
+			if payload.Task == nil || payload.Task.UUID != "task-1" {
+				t.Fatalf("%s replica received unexpected payload %+v", name, payload)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("%s replica did not receive the event", name)
+		}
+	}
+
+	deadline := time.Now().Add(time.Second)
+	for store.pending() > 0 && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	if store.pending() != 0 {
+		t.Errorf("Expected the forwarded event to be acknowledged, %d pending", store.pending())
+	}
+}
@@ -1,60 +1,227 @@
 package events
 
 import (
+	"context"
+	"errors"
+	"log"
 	"sync"
+	"time"
 )
 
 // EventBus manages event subscriptions and publishing
 type EventBus struct {
-	subscribers map[EventType][]chan Event
+	subscribers map[EventType][]*subscription
 	mu          sync.RWMutex
 	bufferSize  int
+	outbox      *outbox // Optional durable delivery, see EnableOutbox
+	broker      *broker // Optional delivery to every replica, see EnableBroker
+	closed      bool
+
+	overflow OverflowOptions // What Publish does when a subscriber channel is full, see SetOverflow
+	metrics  metrics
+}
+
+// subscriber is a subscription channel, either of raw events or of typed payloads (see Subscribe)
+type subscriber interface {
+	// trySend delivers the event without blocking; errChannelFull when the channel is full
+	trySend(event Event) error
+	// send delivers the event, waiting for room in the channel until timeout fires or ctx is done
+	send(ctx context.Context, event Event, timeout <-chan time.Time) error
+	close()
 }
 
+// subscription is a subscriber together with the events spilled for it under OverflowSpill
+type subscription struct {
+	eventType EventType
+	sub       subscriber
+	spill     *spillQueue
+}
+
+var (
+	// errChannelFull is returned by trySend when the subscriber channel is full
+	errChannelFull = errors.New("subscriber channel full")
+	// errDeliveryTimeout is returned by send when the subscriber did not accept the event in time
+	errDeliveryTimeout = errors.New("timed out waiting for subscriber channel")
+	// errPayloadMismatch is returned for events a typed subscriber cannot receive; retrying never helps
+	errPayloadMismatch = errors.New("unexpected payload type")
+)
+
 // NewEventBus creates a new EventBus with the specified buffer size for channels
 func NewEventBus(bufferSize int) *EventBus {
 	return &EventBus{
-		subscribers: make(map[EventType][]chan Event),
+		subscribers: make(map[EventType][]*subscription),
 		bufferSize:  bufferSize,
+		overflow:    OverflowOptions{Policy: OverflowLog},
 	}
 }
 
-// Subscribe creates a subscription channel for a specific event type
+// Subscribe creates a subscription channel for a specific event type. Prefer the typed
+// Subscribe function, which delivers payloads already asserted to the topic's payload type.
 func (b *EventBus) Subscribe(eventType EventType) <-chan Event {
+	ch := make(eventSubscriber, b.bufferSize)
+	b.addSubscriber(eventType, ch)
+	return ch
+}
+
+func (b *EventBus) addSubscriber(eventType EventType, sub subscriber) {
 	b.mu.Lock()
 	defer b.mu.Unlock()
 
-	ch := make(chan Event, b.bufferSize)
-	b.subscribers[eventType] = append(b.subscribers[eventType], ch)
+	b.subscribers[eventType] = append(b.subscribers[eventType], &subscription{
+		eventType: eventType,
+		sub:       sub,
+		spill:     &spillQueue{},
+	})
+}
 
-	return ch
+// Publish sends an event to all subscribers of that event type. With the outbox enabled events are persisted and
+// delivered by RunOutbox; an event the outbox fails to store is not delivered at all and the error is returned,
+// since delivering it from memory would lose it again on a crash. With a broker enabled, replicated events are
+// sent through the broker, by RunOutbox when the outbox is enabled, and delivered by RunBroker on every replica.
+// Otherwise full subscriber channels are handled by the overflow policy. Prefer the typed Publish function, which
+// checks the payload type at compile time, and PublishAtomic for events describing a state change.
+func (b *EventBus) Publish(event Event) error {
+	b.metrics.published(event.Type)
+
+	b.mu.RLock()
+	br, o := b.broker, b.outbox
+	b.mu.RUnlock()
+	if o != nil && o.stores(event.Type) {
+		if err := o.persist(event); err != nil {
+			b.metrics.dropped(event.Type)
+			log.Printf("[Outbox] DROPPED %s event: %v", event.Type, err)
+			return err
+		}
+		return nil
+	}
+	if br != nil && br.publish(event) {
+		return nil
+	}
+
+	b.publishLocal(event)
+	return nil
+}
+
+// PublishLocal sends an event to the subscribers of this process only, bypassing the broker and the outbox.
+// For events every replica derives by itself, such as those read from MongoDB change streams.
+func (b *EventBus) PublishLocal(event Event) {
+	b.metrics.published(event.Type)
+	b.publishLocal(event)
 }
 
-// Publish sends an event to all subscribers of that event type
-func (b *EventBus) Publish(event Event) {
+// publishLocal sends an event to the subscribers of this process
+func (b *EventBus) publishLocal(event Event) {
 	b.mu.RLock()
 	defer b.mu.RUnlock()
 
-	subscribers := b.subscribers[event.Type]
-	for _, ch := range subscribers {
-		select {
-		case ch <- event:
-		default:
-			// Channel is full, skip to avoid blocking
-			// In production, you might want to log this
+	for _, s := range b.subscribers[event.Type] {
+		b.offer(s, event)
+	}
+}
+
+// offer sends the event to one subscriber, applying the overflow policy when its channel is full.
+// Must be called with b.mu held for reading.
+func (b *EventBus) offer(s *subscription, event Event) {
+	// Events spilled earlier go first, so a subscriber sees events in publish order
+	if b.overflow.Policy == OverflowSpill && s.spill.pending() {
+		b.spillEvent(s, event)
+		return
+	}
+
+	err := s.sub.trySend(event)
+	if errors.Is(err, errChannelFull) {
+		switch b.overflow.Policy {
+		case OverflowBlock:
+			timer := time.NewTimer(b.overflow.BlockTimeout)
+			err = s.sub.send(context.Background(), event, timer.C)
+			timer.Stop()
+		case OverflowSpill:
+			b.spillEvent(s, event)
+			return
 		}
 	}
+	b.recordDelivery(event.Type, err)
 }
 
-// Close closes all subscriber channels
+// recordDelivery counts the outcome of sending an event to one subscriber
+func (b *EventBus) recordDelivery(eventType EventType, err error) {
+	if err == nil {
+		b.metrics.delivered(eventType)
+		return
+	}
+	b.metrics.dropped(eventType)
+	log.Printf("[EventBus] DROPPED %s event for a subscriber (policy %s, buffer %d): %v", eventType, b.overflow.Policy, b.bufferSize, err)
+}
+
+// deliver sends an event to all subscribers of that event type, waiting up to timeout for full channels
+func (b *EventBus) deliver(ctx context.Context, event Event, timeout time.Duration) error {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	// Keep events for the next process rather than acknowledging them with no one listening
+	if b.closed {
+		return errors.New("event bus closed")
+	}
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	for _, s := range b.subscribers[event.Type] {
+		err := s.sub.send(ctx, event, timer.C)
+		if errors.Is(err, errPayloadMismatch) {
+			b.recordDelivery(event.Type, err)
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		b.metrics.delivered(event.Type)
+	}
+	return nil
+}
+
+// Close closes all subscriber channels. Spilled events that were not replayed yet are dropped.
 func (b *EventBus) Close() {
 	b.mu.Lock()
 	defer b.mu.Unlock()
 
-	for _, channels := range b.subscribers {
-		for _, ch := range channels {
-			close(ch)
+	b.closed = true
+
+	for eventType, subscriptions := range b.subscribers {
+		for _, s := range subscriptions {
+			if discarded := s.spill.discard(); discarded > 0 {
+				b.metrics.droppedN(eventType, uint64(discarded))
+				log.Printf("[EventBus] DROPPED %d spilled %s events on close", discarded, eventType)
+			}
+			s.sub.close()
 		}
 	}
-	b.subscribers = make(map[EventType][]chan Event)
+	b.subscribers = make(map[EventType][]*subscription)
+}
+
+// eventSubscriber receives events as published
+type eventSubscriber chan Event
+
+func (ch eventSubscriber) trySend(event Event) error {
+	select {
+	case ch <- event:
+		return nil
+	default:
+		return errChannelFull
+	}
+}
+
+func (ch eventSubscriber) send(ctx context.Context, event Event, timeout <-chan time.Time) error {
+	select {
+	case ch <- event:
+		return nil
+	case <-timeout:
+		return errDeliveryTimeout
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (ch eventSubscriber) close() {
+	close(ch)
 }
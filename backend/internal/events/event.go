@@ -1,19 +1,28 @@
 package events
 
-import "github.com/yourusername/cron-observer/backend/internal/models"
+import (
+	"time"
+
+	"github.com/yourusername/cron-observer/backend/internal/models"
+)
 
 // EventType defines the type of event
 type EventType string
 
 const (
-	TaskCreated       EventType = "task.created"
-	TaskUpdated       EventType = "task.updated"
-	TaskDeleted       EventType = "task.deleted" // Published after a task is hard-deleted (e.g. by delete worker); scheduler unregisters it.
-	TaskGroupCreated  EventType = "taskgroup.created"
-	TaskGroupUpdated  EventType = "taskgroup.updated"
-	TaskGroupDeleted  EventType = "taskgroup.deleted"
-	ExecutionFailed   EventType = "execution.failed"
-	ExecutionTimedOut EventType = "execution.timed_out"
+	TaskCreated        EventType = "task.created"
+	TaskUpdated        EventType = "task.updated"
+	TaskDeleted        EventType = "task.deleted" // Published after a task is hard-deleted (e.g. by delete worker); scheduler unregisters it.
+	TaskGroupCreated   EventType = "taskgroup.created"
+	TaskGroupUpdated   EventType = "taskgroup.updated"
+	TaskGroupDeleted   EventType = "taskgroup.deleted"
+	ProjectArchived    EventType = "project.archived" // Scheduler unregisters every task of the project
+	ProjectRestored    EventType = "project.restored" // Scheduler re-registers the project's active tasks
+	ExecutionFailed    EventType = "execution.failed"
+	ExecutionSucceeded EventType = "execution.succeeded" // Resolves the task's active incident
+	ExecutionTimedOut  EventType = "execution.timed_out"
+	IncidentUpdated    EventType = "incident.updated" // Published when an incident is acknowledged or resolved by hand; the alert service notifies its channels
+	CircuitOpened      EventType = "circuit.opened"   // Published when the scheduler stops dispatching to a failing execution endpoint; the alert service notifies its channels
 )
 
 // Event represents an event in the system
@@ -42,12 +51,37 @@ type TaskGroupDeletedPayload struct {
 	TaskGroupUUID string
 }
 
+// ProjectPayload contains the project data for archived/restored events
+type ProjectPayload struct {
+	Project *models.Project
+}
+
 // ExecutionFailedPayload contains execution and task data for failed execution events
 type ExecutionFailedPayload struct {
 	Execution *models.Execution
 	Task      *models.Task
 }
 
+// ExecutionSucceededPayload contains execution and task data for successful execution events
+type ExecutionSucceededPayload struct {
+	Execution *models.Execution
+	Task      *models.Task
+}
+
+// IncidentPayload contains the incident data for incident events
+type IncidentPayload struct {
+	Incident *models.Incident
+}
+
+// CircuitOpenedPayload contains the project whose execution endpoint kept failing
+type CircuitOpenedPayload struct {
+	Project   *models.Project
+	Endpoint  string
+	Failures  int       // Consecutive failed dispatches that opened the circuit
+	LastError string    // Why the last dispatch failed
+	OpenUntil time.Time // When a dispatch is let through again to test the endpoint
+}
+
 // ExecutionTimedOutPayload contains execution UUID and timeout information
 type ExecutionTimedOutPayload struct {
 	ExecutionUUID  string
@@ -0,0 +1,79 @@
+package events
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// Metrics counts the events of one type handled by the bus since it was created
+type Metrics struct {
+	Published uint64 // Events published
+	Delivered uint64 // Events accepted by a subscriber channel; an event with two subscribers counts twice
+	Dropped   uint64 // Events a subscriber will never receive
+}
+
+// metrics holds the per event type counters of a bus
+type metrics struct {
+	mu       sync.RWMutex
+	counters map[EventType]*counters
+}
+
+type counters struct {
+	published atomic.Uint64
+	delivered atomic.Uint64
+	dropped   atomic.Uint64
+}
+
+// Metrics returns a snapshot of the counters of every event type published or delivered so far
+func (b *EventBus) Metrics() map[EventType]Metrics {
+	b.metrics.mu.RLock()
+	defer b.metrics.mu.RUnlock()
+
+	snapshot := make(map[EventType]Metrics, len(b.metrics.counters))
+	for eventType, c := range b.metrics.counters {
+		snapshot[eventType] = Metrics{
+			Published: c.published.Load(),
+			Delivered: c.delivered.Load(),
+			Dropped:   c.dropped.Load(),
+		}
+	}
+	return snapshot
+}
+
+func (m *metrics) published(eventType EventType) {
+	m.get(eventType).published.Add(1)
+}
+
+func (m *metrics) delivered(eventType EventType) {
+	m.get(eventType).delivered.Add(1)
+}
+
+func (m *metrics) dropped(eventType EventType) {
+	m.droppedN(eventType, 1)
+}
+
+func (m *metrics) droppedN(eventType EventType, n uint64) {
+	m.get(eventType).dropped.Add(n)
+}
+
+// get returns the counters of the event type, creating them on first use
+func (m *metrics) get(eventType EventType) *counters {
+	m.mu.RLock()
+	c, ok := m.counters[eventType]
+	m.mu.RUnlock()
+	if ok {
+		return c
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if c, ok := m.counters[eventType]; ok {
+		return c
+	}
+	if m.counters == nil {
+		m.counters = make(map[EventType]*counters)
+	}
+	c = &counters{}
+	m.counters[eventType] = c
+	return c
+}
@@ -0,0 +1,251 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"reflect"
+	"time"
+
+	"github.com/yourusername/cron-observer/backend/internal/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// OutboxStore persists events until they are delivered. Implemented by the repository.
+type OutboxStore interface {
+	CreateOutboxEvent(ctx context.Context, event *models.OutboxEvent) error
+	ClaimOutboxEvents(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*models.OutboxEvent, error)
+	DeleteOutboxEvent(ctx context.Context, id primitive.ObjectID) error
+	// RunInTransaction runs fn in a transaction; calls made with the ctx passed to fn join it
+	RunInTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+// OutboxOptions tunes the outbox dispatcher. Zero values use the defaults.
+type OutboxOptions struct {
+	PollInterval    time.Duration // How often undelivered events are retried; new events are dispatched immediately
+	Lease           time.Duration // How long a claimed event is owned by the dispatcher before it is redelivered
+	DeliveryTimeout time.Duration // How long to wait for a full subscriber channel before retrying the event later
+	BatchSize       int
+}
+
+const (
+	defaultOutboxPollInterval    = 5 * time.Second
+	defaultOutboxLease           = 30 * time.Second
+	defaultOutboxDeliveryTimeout = 5 * time.Second
+	defaultOutboxBatchSize       = 100
+	outboxWriteTimeout           = 5 * time.Second
+)
+
+// outboxPayloads creates an empty payload of the type published with each event type, for decoding stored events
+var outboxPayloads = map[EventType]func() interface{}{
+	TaskCreated:        TaskCreatedTopic.newPayload,
+	TaskUpdated:        TaskUpdatedTopic.newPayload,
+	TaskDeleted:        TaskDeletedTopic.newPayload,
+	TaskGroupCreated:   TaskGroupCreatedTopic.newPayload,
+	TaskGroupUpdated:   TaskGroupUpdatedTopic.newPayload,
+	TaskGroupDeleted:   TaskGroupDeletedTopic.newPayload,
+	ProjectArchived:    ProjectArchivedTopic.newPayload,
+	ProjectRestored:    ProjectRestoredTopic.newPayload,
+	ExecutionFailed:    ExecutionFailedTopic.newPayload,
+	ExecutionSucceeded: ExecutionSucceededTopic.newPayload,
+	ExecutionTimedOut:  ExecutionTimedOutTopic.newPayload,
+	IncidentUpdated:    IncidentUpdatedTopic.newPayload,
+	CircuitOpened:      CircuitOpenedTopic.newPayload,
+}
+
+type outbox struct {
+	store   OutboxStore
+	options OutboxOptions
+	notify  chan struct{}
+}
+
+// EnableOutbox makes Publish persist events in store and deliver them from there with RunOutbox, so an event
+// reaches every subscriber at least once even if a subscriber is slow or the process restarts. Subscribers
+// must tolerate duplicates. Call before publishing and start RunOutbox, which does the delivery.
+func (b *EventBus) EnableOutbox(store OutboxStore, options OutboxOptions) {
+	if options.PollInterval <= 0 {
+		options.PollInterval = defaultOutboxPollInterval
+	}
+	if options.Lease <= 0 {
+		options.Lease = defaultOutboxLease
+	}
+	if options.DeliveryTimeout <= 0 {
+		options.DeliveryTimeout = defaultOutboxDeliveryTimeout
+	}
+	if options.BatchSize <= 0 {
+		options.BatchSize = defaultOutboxBatchSize
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.outbox = &outbox{
+		store:   store,
+		options: options,
+		notify:  make(chan struct{}, 1),
+	}
+}
+
+// RunOutbox delivers persisted events to subscribers until ctx is cancelled, forwarding replicated events to the
+// broker if one is enabled. Events are acknowledged (deleted) only once every subscriber, or the broker, has
+// accepted them; otherwise they are redelivered after the lease expires.
+// Returns immediately if the outbox is not enabled.
+func (b *EventBus) RunOutbox(ctx context.Context) {
+	b.mu.RLock()
+	o := b.outbox
+	b.mu.RUnlock()
+	if o == nil {
+		return
+	}
+
+	ticker := time.NewTicker(o.options.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		b.dispatchOutbox(ctx, o)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-o.notify:
+		}
+	}
+}
+
+// dispatchOutbox delivers claimed events in batches until none are left
+func (b *EventBus) dispatchOutbox(ctx context.Context, o *outbox) {
+	for ctx.Err() == nil {
+		claimed, err := o.store.ClaimOutboxEvents(ctx, time.Now(), o.options.Lease, o.options.BatchSize)
+		if err != nil {
+			log.Printf("[Outbox] Failed to claim events: %v", err)
+		}
+
+		for _, stored := range claimed {
+			event, err := decodeOutboxEvent(stored)
+			if err != nil {
+				// Undecodable events can never be delivered, so drop them instead of retrying forever
+				log.Printf("[Outbox] Dropping undecodable event %s (%s): %v", stored.ID.Hex(), stored.Type, err)
+			} else if err := b.dispatch(ctx, o, event); err != nil {
+				log.Printf("[Outbox] Event %s (%s) not delivered, retrying after %s: %v", stored.ID.Hex(), stored.Type, o.options.Lease, err)
+				continue
+			}
+
+			if err := o.store.DeleteOutboxEvent(ctx, stored.ID); err != nil {
+				log.Printf("[Outbox] Failed to acknowledge event %s: %v", stored.ID.Hex(), err)
+			}
+		}
+
+		if len(claimed) < o.options.BatchSize {
+			return
+		}
+	}
+}
+
+// stores reports whether events of the type can go through the outbox
+func (o *outbox) stores(eventType EventType) bool {
+	_, ok := outboxPayloads[eventType]
+	return ok
+}
+
+// dispatch forwards a stored event to the broker when it is replicated, which delivers it to every replica
+// including this one, and delivers it to local subscribers otherwise
+func (b *EventBus) dispatch(ctx context.Context, o *outbox, event Event) error {
+	b.mu.RLock()
+	br := b.broker
+	b.mu.RUnlock()
+	if br != nil && replicatedEvents[event.Type] {
+		ctx, cancel := context.WithTimeout(ctx, brokerPublishTimeout)
+		defer cancel()
+		return br.send(ctx, event)
+	}
+	return b.deliver(ctx, event, o.options.DeliveryTimeout)
+}
+
+// persist stores the event in the outbox and wakes the dispatcher. The caller checks stores first.
+func (o *outbox) persist(event Event) error {
+	ctx, cancel := context.WithTimeout(context.Background(), outboxWriteTimeout)
+	defer cancel()
+	if err := o.write(ctx, event); err != nil {
+		return err
+	}
+	o.wake()
+	return nil
+}
+
+// write stores the event in the outbox, within the transaction of ctx if there is one
+func (o *outbox) write(ctx context.Context, event Event) error {
+	stored, err := encodeEvent(event)
+	if err != nil {
+		return fmt.Errorf("encode %s event: %w", event.Type, err)
+	}
+	if err := o.store.CreateOutboxEvent(ctx, stored); err != nil {
+		return fmt.Errorf("persist %s event: %w", event.Type, err)
+	}
+	return nil
+}
+
+// wake starts a dispatch of the events stored since the last one
+func (o *outbox) wake() {
+	select {
+	case o.notify <- struct{}{}:
+	default:
+		// A dispatch is already pending
+	}
+}
+
+// encodeEvent converts the event for storage outside the process
+func encodeEvent(event Event) (*models.OutboxEvent, error) {
+	stored := &models.OutboxEvent{
+		Type:      string(event.Type),
+		CreatedAt: time.Now(),
+	}
+	if event.Payload != nil {
+		payload, err := bson.Marshal(event.Payload)
+		if err != nil {
+			return nil, err
+		}
+		stored.Payload = payload
+	}
+	return stored, nil
+}
+
+// decodeOutboxEvent restores the event, with the payload type its subscribers expect
+func decodeOutboxEvent(stored *models.OutboxEvent) (Event, error) {
+	eventType := EventType(stored.Type)
+	newPayload, ok := outboxPayloads[eventType]
+	if !ok {
+		return Event{}, errors.New("unknown event type")
+	}
+
+	event := Event{Type: eventType}
+	if len(stored.Payload) > 0 {
+		payload := newPayload()
+		if err := bson.Unmarshal(stored.Payload, payload); err != nil {
+			return Event{}, err
+		}
+		event.Payload = reflect.ValueOf(payload).Elem().Interface()
+	}
+	return event, nil
+}
+
+// encodeEventRecord encodes the event as a self-contained BSON document, which starts with its own length
+func encodeEventRecord(event Event) ([]byte, error) {
+	if _, ok := outboxPayloads[event.Type]; !ok {
+		return nil, errors.New("no payload type registered for event type")
+	}
+	stored, err := encodeEvent(event)
+	if err != nil {
+		return nil, err
+	}
+	return bson.Marshal(stored)
+}
+
+func decodeEventRecord(record []byte) (Event, error) {
+	var stored models.OutboxEvent
+	if err := bson.Unmarshal(record, &stored); err != nil {
+		return Event{}, err
+	}
+	return decodeOutboxEvent(&stored)
+}
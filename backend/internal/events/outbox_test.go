@@ -0,0 +1,239 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/yourusername/cron-observer/backend/internal/models"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// memoryOutbox is an in-memory OutboxStore. Its transactions restore the stored events and tasks when they fail.
+type memoryOutbox struct {
+	mu         sync.Mutex
+	events     []*models.OutboxEvent
+	tasks      []string // stands in for the state changes made together with publishing
+	failWrites error    // returned by CreateOutboxEvent when set
+}
+
+func (m *memoryOutbox) CreateOutboxEvent(_ context.Context, event *models.OutboxEvent) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.failWrites != nil {
+		return m.failWrites
+	}
+	event.ID = primitive.NewObjectID()
+	m.events = append(m.events, event)
+	return nil
+}
+
+func (m *memoryOutbox) ClaimOutboxEvents(_ context.Context, now time.Time, lease time.Duration, limit int) ([]*models.OutboxEvent, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var claimed []*models.OutboxEvent
+	for _, event := range m.events {
+		if len(claimed) == limit {
+			break
+		}
+		if !event.LockedUntil.After(now) {
+			event.LockedUntil = now.Add(lease)
+			event.Attempts++
+			claimed = append(claimed, event)
+		}
+	}
+	return claimed, nil
+}
+
+func (m *memoryOutbox) DeleteOutboxEvent(_ context.Context, id primitive.ObjectID) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for i, event := range m.events {
+		if event.ID == id {
+			m.events = append(m.events[:i], m.events[i+1:]...)
+			break
+		}
+	}
+	return nil
+}
+
+func (m *memoryOutbox) RunInTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	m.mu.Lock()
+	events, tasks := append([]*models.OutboxEvent(nil), m.events...), append([]string(nil), m.tasks...)
+	m.mu.Unlock()
+
+	err := fn(ctx)
+	if err != nil {
+		m.mu.Lock()
+		m.events, m.tasks = events, tasks
+		m.mu.Unlock()
+	}
+	return err
+}
+
+func (m *memoryOutbox) createTask(uuid string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.tasks = append(m.tasks, uuid)
+}
+
+func (m *memoryOutbox) pending() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return len(m.events)
+}
+
+func TestOutbox_DeliversEventsBlockedByFullChannel(t *testing.T) {
+	store := &memoryOutbox{}
+	bus := NewEventBus(1)
+	bus.EnableOutbox(store, OutboxOptions{PollInterval: 10 * time.Millisecond, Lease: 10 * time.Millisecond, DeliveryTimeout: 10 * time.Millisecond})
+	updated := bus.Subscribe(TaskUpdated)
+
+	// Without the outbox the second and third events would be dropped by the one-slot channel
+	for _, uuid := range []string{"task-1", "task-2", "task-3"} {
+		bus.Publish(Event{Type: TaskUpdated, Payload: TaskPayload{Task: &models.Task{UUID: uuid}}})
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go bus.RunOutbox(ctx)
+
+	for _, want := range []string{"task-1", "task-2", "task-3"} {
+		select {
+		case event := <-updated:
+			payload, ok := event.Payload.(TaskPayload)
+			if !ok || payload.Task.UUID != want {
+				t.Fatalf("Expected TaskPayload for %s, got %#v", want, event.Payload)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("Timed out waiting for %s", want)
+		}
+	}
+
+	deadline := time.Now().Add(time.Second)
+	for store.pending() > 0 && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	if store.pending() != 0 {
+		t.Errorf("Expected delivered events to be acknowledged, %d pending", store.pending())
+	}
+}
+
+func TestOutbox_KeepsEventsAfterClose(t *testing.T) {
+	store := &memoryOutbox{}
+	bus := NewEventBus(1)
+	bus.EnableOutbox(store, OutboxOptions{})
+	bus.Subscribe(ExecutionFailed)
+	bus.Close()
+
+	bus.Publish(Event{Type: ExecutionFailed, Payload: ExecutionFailedPayload{Execution: &models.Execution{UUID: "exec-1"}}})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	bus.RunOutbox(ctx)
+
+	if store.pending() != 1 {
+		t.Errorf("Expected the event to stay in the outbox for the next process, %d pending", store.pending())
+	}
+}
+
+func TestOutbox_PublishFailsWhenEventCannotBeStored(t *testing.T) {
+	store := &memoryOutbox{failWrites: errors.New("write failed")}
+	bus := NewEventBus(1)
+	bus.EnableOutbox(store, OutboxOptions{})
+	updated := bus.Subscribe(TaskUpdated)
+
+	err := bus.Publish(Event{Type: TaskUpdated, Payload: TaskPayload{Task: &models.Task{UUID: "task-1"}}})
+	if err == nil {
+		t.Fatal("Expected Publish to fail")
+	}
+
+	// Delivering from memory would lose the event again on a crash, so it is not delivered at all
+	select {
+	case event := <-updated:
+		t.Errorf("Expected no delivery, got %#v", event)
+	default:
+	}
+	if dropped := bus.Metrics()[TaskUpdated].Dropped; dropped != 1 {
+		t.Errorf("Expected the event to be counted as dropped, got %d", dropped)
+	}
+}
+
+func TestPublishAtomic_StoresEventWithChange(t *testing.T) {
+	store := &memoryOutbox{}
+	bus := NewEventBus(1)
+	bus.EnableOutbox(store, OutboxOptions{})
+
+	err := PublishAtomic(context.Background(), bus, TaskCreatedTopic, func(ctx context.Context) (TaskPayload, error) {
+		store.createTask("task-1")
+		return TaskPayload{Task: &models.Task{UUID: "task-1"}}, nil
+	})
+	if err != nil {
+		t.Fatalf("PublishAtomic failed: %v", err)
+	}
+
+	if len(store.tasks) != 1 || store.pending() != 1 {
+		t.Fatalf("Expected the task and its event to be stored, got %d tasks and %d events", len(store.tasks), store.pending())
+	}
+	event, err := decodeOutboxEvent(store.events[0])
+	if err != nil {
+		t.Fatalf("decodeOutboxEvent failed: %v", err)
+	}
+	if payload, ok := event.Payload.(TaskPayload); !ok || payload.Task.UUID != "task-1" {
+		t.Errorf("Unexpected payload %#v", event.Payload)
+	}
+}
+
+func TestPublishAtomic_RollsBackChangeWhenEventCannotBeStored(t *testing.T) {
+	store := &memoryOutbox{failWrites: errors.New("write failed")}
+	bus := NewEventBus(1)
+	bus.EnableOutbox(store, OutboxOptions{})
+
+	err := PublishAtomic(context.Background(), bus, TaskCreatedTopic, func(ctx context.Context) (TaskPayload, error) {
+		store.createTask("task-1")
+		return TaskPayload{Task: &models.Task{UUID: "task-1"}}, nil
+	})
+	if err == nil {
+		t.Fatal("Expected PublishAtomic to fail")
+	}
+	if len(store.tasks) != 0 {
+		t.Errorf("Expected the change to be rolled back, got tasks %v", store.tasks)
+	}
+}
+
+func TestPublishAtomic_StoresNoEventWhenChangeFails(t *testing.T) {
+	store := &memoryOutbox{}
+	bus := NewEventBus(1)
+	bus.EnableOutbox(store, OutboxOptions{})
+	changeErr := errors.New("update failed")
+
+	err := PublishAtomic(context.Background(), bus, TaskUpdatedTopic, func(ctx context.Context) (TaskPayload, error) {
+		return TaskPayload{}, changeErr
+	})
+	if !errors.Is(err, changeErr) {
+		t.Fatalf("Expected the change's error, got %v", err)
+	}
+	if store.pending() != 0 {
+		t.Errorf("Expected no event to be stored, got %d", store.pending())
+	}
+}
+
+func TestDecodeOutboxEvent_RestoresPayloadType(t *testing.T) {
+	store := &memoryOutbox{}
+	o := &outbox{store: store, notify: make(chan struct{}, 1)}
+
+	if err := o.persist(Event{Type: ExecutionTimedOut, Payload: ExecutionTimedOutPayload{ExecutionUUID: "exec-1", TimeoutSeconds: 30}}); err != nil {
+		t.Fatalf("persist failed: %v", err)
+	}
+
+	event, err := decodeOutboxEvent(store.events[0])
+	if err != nil {
+		t.Fatalf("decodeOutboxEvent failed: %v", err)
+	}
+	payload, ok := event.Payload.(ExecutionTimedOutPayload)
+	if !ok || payload.ExecutionUUID != "exec-1" || payload.TimeoutSeconds != 30 {
+		t.Errorf("Unexpected payload %#v", event.Payload)
+	}
+}
@@ -0,0 +1,219 @@
+package events
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"sync"
+	"time"
+)
+
+// OverflowPolicy decides what Publish does when a subscriber channel is full
+type OverflowPolicy string
+
+const (
+	OverflowLog   OverflowPolicy = "log"   // Drop the event for that subscriber and log it (default)
+	OverflowBlock OverflowPolicy = "block" // Wait up to BlockTimeout for room in the channel, then drop and log
+	OverflowSpill OverflowPolicy = "spill" // Write the event to a file in SpillDir and replay it once the subscriber catches up
+)
+
+// OverflowOptions configures the overflow policy. Zero values use the defaults.
+type OverflowOptions struct {
+	Policy       OverflowPolicy
+	BlockTimeout time.Duration // Per subscriber; Publish callers wait this long for each full channel
+	SpillDir     string        // Defaults to the system temp directory
+}
+
+const (
+	defaultOverflowBlockTimeout = time.Second
+	// spillReplayTimeout is how long a replay waits for room before checking whether the bus was closed
+	spillReplayTimeout = 100 * time.Millisecond
+)
+
+// SetOverflow sets how Publish handles full subscriber channels. Spilled events live only as long as the
+// process; enable the outbox for delivery across restarts. Call before subscribing and publishing.
+func (b *EventBus) SetOverflow(options OverflowOptions) error {
+	switch options.Policy {
+	case "":
+		options.Policy = OverflowLog
+	case OverflowLog, OverflowBlock:
+	case OverflowSpill:
+		if options.SpillDir == "" {
+			options.SpillDir = os.TempDir()
+		}
+		if err := os.MkdirAll(options.SpillDir, 0o700); err != nil {
+			return fmt.Errorf("failed to create spill directory: %w", err)
+		}
+	default:
+		return fmt.Errorf("unknown overflow policy %q", options.Policy)
+	}
+	if options.BlockTimeout <= 0 {
+		options.BlockTimeout = defaultOverflowBlockTimeout
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.overflow = options
+	return nil
+}
+
+// spillEvent queues the event on disk for the subscriber and starts replaying if needed.
+// Must be called with b.mu held for reading.
+func (b *EventBus) spillEvent(s *subscription, event Event) {
+	record, err := encodeEventRecord(event)
+	var startReplay bool
+	if err == nil {
+		startReplay, err = s.spill.push(b.overflow.SpillDir, event.Type, record)
+	}
+	if err != nil {
+		b.recordDelivery(event.Type, fmt.Errorf("failed to spill event: %w", err))
+		return
+	}
+	if startReplay {
+		go b.replaySpill(s)
+	}
+}
+
+// replaySpill sends spilled events to the subscriber in order until none are left or the bus is closed
+func (b *EventBus) replaySpill(s *subscription) {
+	for b.replayNextSpilled(s) {
+	}
+}
+
+// replayNextSpilled sends the oldest spilled event to the subscriber, leaving it queued while the channel
+// stays full. Returns false once the spill is empty or the bus is closed.
+func (b *EventBus) replayNextSpilled(s *subscription) bool {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	// Close discards the spill
+	if b.closed {
+		return false
+	}
+
+	record, err := s.spill.peek()
+	if err != nil {
+		discarded := s.spill.discard()
+		b.metrics.droppedN(s.eventType, uint64(discarded))
+		log.Printf("[EventBus] DROPPED %d spilled %s events, spill file unreadable: %v", discarded, s.eventType, err)
+		return false
+	}
+
+	event, err := decodeEventRecord(record)
+	if err == nil {
+		timer := time.NewTimer(spillReplayTimeout)
+		err = s.sub.send(context.Background(), event, timer.C)
+		timer.Stop()
+		if errors.Is(err, errDeliveryTimeout) {
+			return true
+		}
+	}
+	b.recordDelivery(s.eventType, err)
+	return s.spill.pop(len(record))
+}
+
+// spillQueue is a file-backed FIFO of spill records for one subscriber. The file is created on the first
+// push and truncated whenever the queue drains.
+type spillQueue struct {
+	mu        sync.Mutex
+	file      *os.File
+	readOff   int64
+	writeOff  int64
+	count     int
+	replaying bool
+}
+
+// pending reports whether spilled events are waiting to be replayed
+func (q *spillQueue) pending() bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.count > 0
+}
+
+// push appends a record. Returns true if no replay is running and the caller must start one.
+func (q *spillQueue) push(dir string, eventType EventType, record []byte) (bool, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.file == nil {
+		file, err := os.CreateTemp(dir, "events-"+string(eventType)+"-*.spill")
+		if err != nil {
+			return false, err
+		}
+		q.file = file
+	}
+	if _, err := q.file.WriteAt(record, q.writeOff); err != nil {
+		return false, err
+	}
+	q.writeOff += int64(len(record))
+	q.count++
+
+	startReplay := !q.replaying
+	q.replaying = true
+	return startReplay, nil
+}
+
+// peek reads the oldest record without removing it
+func (q *spillQueue) peek() ([]byte, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.count == 0 {
+		return nil, errors.New("spill queue empty")
+	}
+
+	var size [4]byte
+	if _, err := q.file.ReadAt(size[:], q.readOff); err != nil {
+		return nil, err
+	}
+	length := int64(binary.LittleEndian.Uint32(size[:]))
+	if length < int64(len(size)) || q.readOff+length > q.writeOff {
+		return nil, io.ErrUnexpectedEOF
+	}
+
+	record := make([]byte, length)
+	if _, err := q.file.ReadAt(record, q.readOff); err != nil {
+		return nil, err
+	}
+	return record, nil
+}
+
+// pop removes the oldest record of the given size. Returns false once the queue is empty,
+// which also ends the replay.
+func (q *spillQueue) pop(size int) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.readOff += int64(size)
+	q.count--
+	if q.count > 0 {
+		return true
+	}
+
+	if err := q.file.Truncate(0); err != nil {
+		log.Printf("[EventBus] Failed to truncate spill file %s: %v", q.file.Name(), err)
+	}
+	q.readOff, q.writeOff = 0, 0
+	q.replaying = false
+	return false
+}
+
+// discard removes the spill file and returns the number of records it held
+func (q *spillQueue) discard() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	discarded := q.count
+	if q.file != nil {
+		q.file.Close()
+		os.Remove(q.file.Name())
+		q.file = nil
+	}
+	q.readOff, q.writeOff, q.count = 0, 0, 0
+	q.replaying = false
+	return discarded
+}
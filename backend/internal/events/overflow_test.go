@@ -0,0 +1,134 @@
+package events
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/yourusername/cron-observer/backend/internal/models"
+)
+
+func publishTasks(bus *EventBus, uuids ...string) {
+	for _, uuid := range uuids {
+		Publish(bus, TaskUpdatedTopic, TaskPayload{Task: &models.Task{UUID: uuid}})
+	}
+}
+
+func TestMetrics_CountsPublishedDeliveredAndDropped(t *testing.T) {
+	bus := NewEventBus(1)
+	updated := bus.Subscribe(TaskUpdated)
+
+	publishTasks(bus, "task-1", "task-2")
+	<-updated
+
+	got := bus.Metrics()[TaskUpdated]
+	want := Metrics{Published: 2, Delivered: 1, Dropped: 1}
+	if got != want {
+		t.Fatalf("metrics = %+v, want %+v", got, want)
+	}
+}
+
+func TestMetrics_CountsMismatchedPayloadAsDropped(t *testing.T) {
+	bus := NewEventBus(1)
+	Subscribe(bus, TaskCreatedTopic)
+
+	bus.Publish(Event{Type: TaskCreated, Payload: "not a task"})
+
+	if got := bus.Metrics()[TaskCreated]; got.Dropped != 1 || got.Delivered != 0 {
+		t.Fatalf("metrics = %+v, want one dropped event", got)
+	}
+}
+
+func TestSetOverflow_RejectsUnknownPolicy(t *testing.T) {
+	bus := NewEventBus(1)
+	if err := bus.SetOverflow(OverflowOptions{Policy: "discard"}); err == nil {
+		t.Fatal("expected an error for an unknown policy")
+	}
+}
+
+func TestOverflowBlock_WaitsForRoom(t *testing.T) {
+	bus := NewEventBus(1)
+	if err := bus.SetOverflow(OverflowOptions{Policy: OverflowBlock, BlockTimeout: time.Second}); err != nil {
+		t.Fatalf("SetOverflow: %v", err)
+	}
+	updated := Subscribe(bus, TaskUpdatedTopic)
+
+	publishTasks(bus, "task-1")
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		<-updated
+	}()
+	publishTasks(bus, "task-2")
+
+	if got := bus.Metrics()[TaskUpdated]; got.Delivered != 2 || got.Dropped != 0 {
+		t.Fatalf("metrics = %+v, want both events delivered", got)
+	}
+}
+
+func TestOverflowBlock_DropsAfterTimeout(t *testing.T) {
+	bus := NewEventBus(1)
+	if err := bus.SetOverflow(OverflowOptions{Policy: OverflowBlock, BlockTimeout: 10 * time.Millisecond}); err != nil {
+		t.Fatalf("SetOverflow: %v", err)
+	}
+	Subscribe(bus, TaskUpdatedTopic)
+
+	publishTasks(bus, "task-1", "task-2")
+
+	if got := bus.Metrics()[TaskUpdated]; got.Delivered != 1 || got.Dropped != 1 {
+		t.Fatalf("metrics = %+v, want one delivered and one dropped", got)
+	}
+}
+
+func TestOverflowSpill_ReplaysInOrder(t *testing.T) {
+	bus := NewEventBus(1)
+	if err := bus.SetOverflow(OverflowOptions{Policy: OverflowSpill, SpillDir: t.TempDir()}); err != nil {
+		t.Fatalf("SetOverflow: %v", err)
+	}
+	updated := Subscribe(bus, TaskUpdatedTopic)
+
+	uuids := []string{"task-1", "task-2", "task-3", "task-4"}
+	publishTasks(bus, uuids...)
+
+	for _, want := range uuids {
+		select {
+		case payload := <-updated:
+			if payload.Task.UUID != want {
+				t.Fatalf("received %s, want %s", payload.Task.UUID, want)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("%s not replayed", want)
+		}
+	}
+
+	// The replay counts an event just after handing it over
+	deadline := time.Now().Add(time.Second)
+	for bus.Metrics()[TaskUpdated].Delivered != 4 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	if got := bus.Metrics()[TaskUpdated]; got.Delivered != 4 || got.Dropped != 0 {
+		t.Fatalf("metrics = %+v, want all events delivered", got)
+	}
+}
+
+func TestOverflowSpill_CloseRemovesSpillFile(t *testing.T) {
+	dir := t.TempDir()
+	bus := NewEventBus(1)
+	if err := bus.SetOverflow(OverflowOptions{Policy: OverflowSpill, SpillDir: dir}); err != nil {
+		t.Fatalf("SetOverflow: %v", err)
+	}
+	Subscribe(bus, TaskUpdatedTopic)
+
+	publishTasks(bus, "task-1", "task-2", "task-3")
+	bus.Close()
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("ReadDir: %v", err)
+	}
+	if len(entries) != 0 {
+		t.Fatalf("spill files left after Close: %d", len(entries))
+	}
+	if got := bus.Metrics()[TaskUpdated]; got.Delivered+got.Dropped != 3 {
+		t.Fatalf("metrics = %+v, want every event delivered or dropped", got)
+	}
+}
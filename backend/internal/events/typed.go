@@ -0,0 +1,134 @@
+package events
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Topic pairs an event type with the payload type published with it, so that publishers
+// and subscribers of the topic agree on the payload at compile time
+type Topic[P any] struct {
+	Type EventType
+}
+
+var (
+	TaskCreatedTopic        = Topic[TaskPayload]{Type: TaskCreated}
+	TaskUpdatedTopic        = Topic[TaskPayload]{Type: TaskUpdated}
+	TaskDeletedTopic        = Topic[TaskDeletedPayload]{Type: TaskDeleted}
+	TaskGroupCreatedTopic   = Topic[TaskGroupPayload]{Type: TaskGroupCreated}
+	TaskGroupUpdatedTopic   = Topic[TaskGroupPayload]{Type: TaskGroupUpdated}
+	TaskGroupDeletedTopic   = Topic[TaskGroupDeletedPayload]{Type: TaskGroupDeleted}
+	ProjectArchivedTopic    = Topic[ProjectPayload]{Type: ProjectArchived}
+	ProjectRestoredTopic    = Topic[ProjectPayload]{Type: ProjectRestored}
+	ExecutionFailedTopic    = Topic[ExecutionFailedPayload]{Type: ExecutionFailed}
+	ExecutionSucceededTopic = Topic[ExecutionSucceededPayload]{Type: ExecutionSucceeded}
+	ExecutionTimedOutTopic  = Topic[ExecutionTimedOutPayload]{Type: ExecutionTimedOut}
+	IncidentUpdatedTopic    = Topic[IncidentPayload]{Type: IncidentUpdated}
+	CircuitOpenedTopic      = Topic[CircuitOpenedPayload]{Type: CircuitOpened}
+)
+
+// Event wraps the payload in an event of the topic, for publishers that take untyped events
+func (t Topic[P]) Event(payload P) Event {
+	return Event{Type: t.Type, Payload: payload}
+}
+
+// newPayload returns a pointer to an empty payload of the topic, for decoding stored events
+func (t Topic[P]) newPayload() interface{} {
+	return new(P)
+}
+
+// Publish publishes the payload on the topic
+func Publish[P any](bus *EventBus, topic Topic[P], payload P) error {
+	return bus.Publish(topic.Event(payload))
+}
+
+// PublishAtomic makes a state change and publishes the event describing it, which change returns. With the outbox
+// enabled the change and the write of the event run in one transaction of the outbox store, so the event is stored
+// if and only if the change is; change must make its repository calls with the ctx it is given. Otherwise the
+// payload is published once change succeeded. A nil bus only runs change.
+func PublishAtomic[P any](ctx context.Context, bus *EventBus, topic Topic[P], change func(ctx context.Context) (P, error)) error {
+	if bus == nil {
+		_, err := change(ctx)
+		return err
+	}
+
+	bus.mu.RLock()
+	o := bus.outbox
+	bus.mu.RUnlock()
+	if o == nil || !o.stores(topic.Type) {
+		payload, err := change(ctx)
+		if err != nil {
+			return err
+		}
+		return bus.Publish(topic.Event(payload))
+	}
+
+	err := o.store.RunInTransaction(ctx, func(ctx context.Context) error {
+		payload, err := change(ctx)
+		if err != nil {
+			return err
+		}
+		return o.write(ctx, topic.Event(payload))
+	})
+	if err != nil {
+		return err
+	}
+	bus.metrics.published(topic.Type)
+	o.wake()
+	return nil
+}
+
+// Subscribe creates a subscription channel receiving the payloads published on the topic.
+// Events published on the topic's type with a different payload type are logged and dropped.
+func Subscribe[P any](bus *EventBus, topic Topic[P]) <-chan P {
+	ch := &payloadSubscriber[P]{ch: make(chan P, bus.bufferSize)}
+	bus.addSubscriber(topic.Type, ch)
+	return ch.ch
+}
+
+// payloadSubscriber receives the payloads of events of one topic
+type payloadSubscriber[P any] struct {
+	ch chan P
+}
+
+// payload asserts the event payload to the topic's payload type
+func (s *payloadSubscriber[P]) payload(event Event) (P, error) {
+	payload, ok := event.Payload.(P)
+	if !ok {
+		return payload, fmt.Errorf("%w %T, expected %T", errPayloadMismatch, event.Payload, payload)
+	}
+	return payload, nil
+}
+
+func (s *payloadSubscriber[P]) trySend(event Event) error {
+	payload, err := s.payload(event)
+	if err != nil {
+		return err
+	}
+	select {
+	case s.ch <- payload:
+		return nil
+	default:
+		return errChannelFull
+	}
+}
+
+func (s *payloadSubscriber[P]) send(ctx context.Context, event Event, timeout <-chan time.Time) error {
+	payload, err := s.payload(event)
+	if err != nil {
+		return err
+	}
+	select {
+	case s.ch <- payload:
+		return nil
+	case <-timeout:
+		return errDeliveryTimeout
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (s *payloadSubscriber[P]) close() {
+	close(s.ch)
+}
@@ -0,0 +1,87 @@
+package events
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/yourusername/cron-observer/backend/internal/models"
+)
+
+func TestSubscribe_ReceivesTypedPayload(t *testing.T) {
+	bus := NewEventBus(1)
+	created := Subscribe(bus, TaskCreatedTopic)
+
+	Publish(bus, TaskCreatedTopic, TaskPayload{Task: &models.Task{UUID: "task-1"}})
+
+	select {
+	case payload := <-created:
+		if payload.Task == nil || payload.Task.UUID != "task-1" {
+			t.Fatalf("unexpected payload %+v", payload)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("payload not delivered")
+	}
+}
+
+func TestSubscribe_DropsMismatchedPayload(t *testing.T) {
+	bus := NewEventBus(1)
+	created := Subscribe(bus, TaskCreatedTopic)
+	untyped := bus.Subscribe(TaskCreated)
+
+	// An untyped publisher sending the wrong payload must not reach typed subscribers
+	bus.Publish(Event{Type: TaskCreated, Payload: &TaskPayload{}})
+
+	select {
+	case payload := <-created:
+		t.Fatalf("mismatched payload delivered: %+v", payload)
+	default:
+	}
+	if len(untyped) != 1 {
+		t.Fatalf("untyped subscriber received %d events, want 1", len(untyped))
+	}
+}
+
+func TestSubscribe_OnlyReceivesItsTopic(t *testing.T) {
+	bus := NewEventBus(1)
+	created := Subscribe(bus, TaskCreatedTopic)
+
+	Publish(bus, TaskUpdatedTopic, TaskPayload{Task: &models.Task{UUID: "task-1"}})
+
+	if len(created) != 0 {
+		t.Fatalf("TaskCreated subscriber received %d payloads, want 0", len(created))
+	}
+}
+
+func TestSubscribe_ClosedWithBus(t *testing.T) {
+	bus := NewEventBus(1)
+	failed := Subscribe(bus, ExecutionFailedTopic)
+
+	bus.Close()
+
+	if _, ok := <-failed; ok {
+		t.Fatal("channel still open after Close")
+	}
+}
+
+func TestSubscribe_ReceivesOutboxEvents(t *testing.T) {
+	store := &memoryOutbox{}
+	bus := NewEventBus(1)
+	bus.EnableOutbox(store, OutboxOptions{PollInterval: 10 * time.Millisecond})
+	timedOut := Subscribe(bus, ExecutionTimedOutTopic)
+
+	Publish(bus, ExecutionTimedOutTopic, ExecutionTimedOutPayload{ExecutionUUID: "exec-1", TaskUUID: "task-1", TimeoutSeconds: 30})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go bus.RunOutbox(ctx)
+
+	select {
+	case payload := <-timedOut:
+		if payload.ExecutionUUID != "exec-1" || payload.TimeoutSeconds != 30 {
+			t.Fatalf("unexpected payload %+v", payload)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("payload not delivered from the outbox")
+	}
+}
@@ -0,0 +1,67 @@
+package graphapi
+
+import (
+	"context"
+	"sync"
+
+	"github.com/yourusername/cron-observer/backend/internal/models"
+)
+
+// maxExecutionPageSize matches the limit of the REST executions endpoint
+const maxExecutionPageSize = 100
+
+// Task is a task in a query result. Tasks listed together share a batch, so their last executions are loaded
+// with one query.
+type Task struct {
+	*models.Task
+	batch *taskBatch
+}
+
+// taskBatch loads the latest execution of every task in a list with one query, the first time any task in the
+// list asks for it, instead of one query per task
+type taskBatch struct {
+	r     *Resolver
+	uuids []string
+
+	once   sync.Once
+	latest map[string]*models.Execution
+	err    error
+}
+
+func (b *taskBatch) latestExecution(ctx context.Context, taskUUID string) (*models.Execution, error) {
+	b.once.Do(func() {
+		b.latest, b.err = b.r.repo.GetLatestExecutionsByTaskUUIDs(ctx, b.uuids)
+	})
+	if b.err != nil {
+		return nil, b.err
+	}
+	return b.latest[taskUUID], nil
+}
+
+// newTasks wraps a list of tasks so they share one latest-execution lookup
+func newTasks(r *Resolver, tasks []*models.Task) []*Task {
+	batch := &taskBatch{r: r, uuids: make([]string, len(tasks))}
+	wrapped := make([]*Task, len(tasks))
+	for i, task := range tasks {
+		batch.uuids[i] = task.UUID
+		wrapped[i] = &Task{Task: task, batch: batch}
+	}
+	return wrapped
+}
+
+// ExecutionPage is one page of a task's executions, newest first
+type ExecutionPage struct {
+	Nodes      []*models.Execution
+	Page       int
+	PageSize   int
+	TotalCount int64
+}
+
+// TotalPages is the number of pages of PageSize executions, at least 1
+func (p *ExecutionPage) TotalPages() int {
+	totalPages := (p.TotalCount + int64(p.PageSize) - 1) / int64(p.PageSize)
+	if totalPages == 0 {
+		totalPages = 1
+	}
+	return int(totalPages)
+}
@@ -0,0 +1,180 @@
+package graphapi
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/yourusername/cron-observer/backend/internal/models"
+	"github.com/yourusername/cron-observer/backend/mocks"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.uber.org/mock/gomock"
+)
+
+// fakeViewer may view the listed projects only
+type fakeViewer struct {
+	projects []*models.Project
+}
+
+func (v *fakeViewer) CanViewProject(ctx context.Context, projectID primitive.ObjectID) bool {
+	for _, project := range v.projects {
+		if project.ID == projectID {
+			return true
+		}
+	}
+	return false
+}
+
+func (v *fakeViewer) Projects(ctx context.Context) ([]*models.Project, error) {
+	return v.projects, nil
+}
+
+func TestServer_RejectsQueriesNestedTooDeeply(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// No repository call is expected: the query is rejected before any resolver runs
+	server := NewServer(mocks.NewMockRepository(ctrl))
+
+	query := `{ projects { taskGroups { tasks { taskGroup { tasks { taskGroup { tasks { taskGroup { name } } } } } } } } }`
+	ctx := WithViewer(context.Background(), &fakeViewer{})
+	response := server.Exec(ctx, query, "", nil)
+
+	if len(response.Errors) != 1 || !strings.Contains(response.Errors[0].Message, "exceeds the limit of 8") {
+		t.Fatalf("Expected a depth limit error, got %v", response.Errors)
+	}
+	if response.Data != nil {
+		t.Errorf("Expected no data, got %s", response.Data)
+	}
+}
+
+func TestQuery_ProjectPageInOneRequest(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockRepository(ctrl)
+	server := NewServer(repo)
+
+	project := &models.Project{ID: primitive.NewObjectID(), Name: "Billing"}
+	groupID := primitive.NewObjectID()
+	startedAt := time.Date(2025, 1, 15, 10, 0, 0, 0, time.UTC)
+	endedAt := startedAt.Add(1500 * time.Millisecond)
+	tasks := []*models.Task{
+		{UUID: "task-1", ProjectID: project.ID, Name: "Invoices", TaskGroupID: &groupID, Tags: []string{"team:billing"}},
+		{UUID: "task-2", ProjectID: project.ID, Name: "Reminders"},
+	}
+
+	repo.EXPECT().GetProjectByID(gomock.Any(), project.ID).Return(project, nil)
+	repo.EXPECT().GetTaskGroupsByProjectID(gomock.Any(), project.ID).Return([]*models.TaskGroup{
+		{ID: groupID, Name: "Nightly"},
+	}, nil)
+	repo.EXPECT().ListTasksByProjectID(gomock.Any(), project.ID, models.TaskListFilter{Tags: []string{"team:billing"}}, 1, 0).Return(tasks, int64(2), nil)
+	// The last execution of every listed task comes from a single lookup
+	repo.EXPECT().GetLatestExecutionsByTaskUUIDs(gomock.Any(), []string{"task-1", "task-2"}).Return(map[string]*models.Execution{
+		"task-1": {UUID: "exec-1", Status: models.ExecutionStatusSuccess, StartedAt: startedAt, EndedAt: &endedAt},
+	}, nil).Times(1)
+
+	query := `query ProjectPage($id: ID!) {
+		project(id: $id) {
+			name
+			taskGroups { name }
+			tasks(tags: ["team:billing"]) {
+				name
+				tags
+				lastExecution { uuid status durationMs }
+			}
+		}
+	}`
+	ctx := WithViewer(context.Background(), &fakeViewer{projects: []*models.Project{project}})
+	response := server.Exec(ctx, query, "ProjectPage", map[string]interface{}{"id": project.ID.Hex()})
+	if len(response.Errors) > 0 {
+		t.Fatalf("Query returned errors: %v", response.Errors)
+	}
+
+	var data struct {
+		Project struct {
+			Name       string
+			TaskGroups []struct{ Name string }
+			Tasks      []struct {
+				Name          string
+				Tags          []string
+				LastExecution *struct {
+					UUID       string
+					Status     string
+					DurationMs float64
+				}
+			}
+		}
+	}
+	if err := json.Unmarshal(response.Data, &data); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+
+	if data.Project.Name != "Billing" || len(data.Project.TaskGroups) != 1 || len(data.Project.Tasks) != 2 {
+		t.Fatalf("Unexpected project data: %s", response.Data)
+	}
+	if last := data.Project.Tasks[0].LastExecution; last == nil || last.UUID != "exec-1" || last.DurationMs != 1500 {
+		t.Errorf("Unexpected last execution for task-1: %s", response.Data)
+	}
+	if data.Project.Tasks[1].LastExecution != nil {
+		t.Errorf("Expected no last execution for task-2, got %+v", data.Project.Tasks[1].LastExecution)
+	}
+}
+
+func TestQuery_HidesProjectsOutsideViewer(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockRepository(ctrl)
+	server := NewServer(repo)
+
+	otherProjectID := primitive.NewObjectID()
+	repo.EXPECT().GetTaskByUUID(gomock.Any(), "foreign-task").Return(&models.Task{UUID: "foreign-task", ProjectID: otherProjectID}, nil)
+
+	ctx := WithViewer(context.Background(), &fakeViewer{})
+	response := server.Exec(ctx, `{ project(id: "`+otherProjectID.Hex()+`") { name } task(uuid: "foreign-task") { name } }`, "", nil)
+
+	if len(response.Errors) != 2 {
+		t.Fatalf("Expected 2 errors, got %v", response.Errors)
+	}
+	if string(response.Data) != `{"project":null,"task":null}` {
+		t.Errorf("Expected null results, got %s", response.Data)
+	}
+}
+
+func TestQuery_TaskExecutionsPage(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockRepository(ctrl)
+	server := NewServer(repo)
+
+	project := &models.Project{ID: primitive.NewObjectID()}
+	from := time.Date(2025, 1, 8, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC)
+
+	repo.EXPECT().GetTaskByUUID(gomock.Any(), "task-1").Return(&models.Task{UUID: "task-1", ProjectID: project.ID}, nil)
+	repo.EXPECT().GetExecutionsByTaskUUIDPaginated(gomock.Any(), "task-1", gomock.Any(), gomock.Any(), 2, 100).
+		DoAndReturn(func(_ context.Context, _ string, start, end *time.Time, _, _ int) ([]*models.Execution, int64, error) {
+			if start == nil || !start.Equal(from) || end == nil || !end.Equal(to) {
+				t.Errorf("Unexpected range %v - %v", start, end)
+			}
+			return []*models.Execution{{UUID: "exec-101", Status: models.ExecutionStatusFailed, Error: "boom"}}, int64(101), nil
+		})
+
+	query := `{ task(uuid: "task-1") { executions(from: "2025-01-08T00:00:00Z", to: "2025-01-15T00:00:00Z", page: 2, pageSize: 500) {
+		totalCount totalPages pageSize nodes { uuid error endedAt }
+	} } }`
+	ctx := WithViewer(context.Background(), &fakeViewer{projects: []*models.Project{project}})
+	response := server.Exec(ctx, query, "", nil)
+	if len(response.Errors) > 0 {
+		t.Fatalf("Query returned errors: %v", response.Errors)
+	}
+
+	want := `{"task":{"executions":{"totalCount":101,"totalPages":2,"pageSize":100,"nodes":[{"uuid":"exec-101","error":"boom","endedAt":null}]}}}`
+	if string(response.Data) != want {
+		t.Errorf("Unexpected response:\n got %s\nwant %s", response.Data, want)
+	}
+}
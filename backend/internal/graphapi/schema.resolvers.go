@@ -0,0 +1,340 @@
+package graphapi
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.78
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/yourusername/cron-observer/backend/internal/models"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Status is the resolver for the status field.
+func (r *executionResolver) Status(ctx context.Context, obj *models.Execution) (string, error) {
+	return string(obj.Status), nil
+}
+
+// DurationMs is the resolver for the durationMs field.
+func (r *executionResolver) DurationMs(ctx context.Context, obj *models.Execution) (*float64, error) {
+	if obj.EndedAt == nil {
+		return nil, nil
+	}
+	durationMs := float64(obj.EndedAt.Sub(obj.StartedAt).Milliseconds())
+	return &durationMs, nil
+}
+
+// Error is the resolver for the error field.
+func (r *executionResolver) Error(ctx context.Context, obj *models.Execution) (*string, error) {
+	return optionalString(obj.Error), nil
+}
+
+// ID is the resolver for the id field.
+func (r *projectResolver) ID(ctx context.Context, obj *models.Project) (string, error) {
+	return obj.ID.Hex(), nil
+}
+
+// Description is the resolver for the description field.
+func (r *projectResolver) Description(ctx context.Context, obj *models.Project) (*string, error) {
+	return optionalString(obj.Description), nil
+}
+
+// Status is the resolver for the status field.
+func (r *projectResolver) Status(ctx context.Context, obj *models.Project) (string, error) {
+	if obj.Status == "" {
+		return string(models.ProjectStatusActive), nil
+	}
+	return string(obj.Status), nil
+}
+
+// TaskGroups is the resolver for the taskGroups field.
+func (r *projectResolver) TaskGroups(ctx context.Context, obj *models.Project) ([]*models.TaskGroup, error) {
+	taskGroups, err := r.repo.GetTaskGroupsByProjectID(ctx, obj.ID)
+	if err != nil {
+		log.Printf("[GraphQL] Failed to get task groups for project %s: %v", obj.ID.Hex(), err)
+		return nil, errors.New("failed to fetch task groups")
+	}
+	return taskGroups, nil
+}
+
+// Tasks is the resolver for the tasks field.
+func (r *projectResolver) Tasks(ctx context.Context, obj *models.Project, status *string, taskGroupID *string, tags []string, severity *string, search *string) ([]*Task, error) {
+	var filter models.TaskListFilter
+	if status != nil {
+		filter.Status = models.TaskStatus(*status)
+	}
+	if taskGroupID != nil {
+		groupID, err := primitive.ObjectIDFromHex(*taskGroupID)
+		if err != nil {
+			return nil, errors.New("invalid task group id")
+		}
+		filter.TaskGroupID = &groupID
+	}
+	filter.Tags = tags
+	if severity != nil {
+		filter.Severity = models.TaskSeverity(*severity)
+	}
+	if search != nil {
+		filter.Search = *search
+	}
+
+	tasks, _, err := r.repo.ListTasksByProjectID(ctx, obj.ID, filter, 1, 0)
+	if err != nil {
+		log.Printf("[GraphQL] Failed to get tasks for project %s: %v", obj.ID.Hex(), err)
+		return nil, errors.New("failed to fetch tasks")
+	}
+	return newTasks(r.Resolver, tasks), nil
+}
+
+// Projects is the resolver for the projects field.
+func (r *queryResolver) Projects(ctx context.Context, includeArchived *bool) ([]*models.Project, error) {
+	viewer, ok := viewerFrom(ctx)
+	if !ok {
+		return []*models.Project{}, nil
+	}
+
+	projects, err := viewer.Projects(ctx)
+	if err != nil {
+		log.Printf("[GraphQL] Failed to list projects: %v", err)
+		return nil, errors.New("failed to fetch projects")
+	}
+
+	visible := make([]*models.Project, 0, len(projects))
+	for _, project := range projects {
+		if project.Status == models.ProjectStatusArchived && (includeArchived == nil || !*includeArchived) {
+			continue
+		}
+		visible = append(visible, project)
+	}
+	return visible, nil
+}
+
+// Project is the resolver for the project field.
+func (r *queryResolver) Project(ctx context.Context, id string) (*models.Project, error) {
+	projectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, errors.New("invalid project id")
+	}
+	if !canView(ctx, projectID) {
+		return nil, errNotFound
+	}
+
+	project, err := r.repo.GetProjectByID(ctx, projectID)
+	if err != nil {
+		return nil, lookupError("project", projectID.Hex(), err)
+	}
+	return project, nil
+}
+
+// Task is the resolver for the task field.
+func (r *queryResolver) Task(ctx context.Context, uuid string) (*Task, error) {
+	task, err := r.repo.GetTaskByUUID(ctx, uuid)
+	if err != nil {
+		return nil, lookupError("task", uuid, err)
+	}
+	if !canView(ctx, task.ProjectID) {
+		return nil, errNotFound
+	}
+	return newTasks(r.Resolver, []*models.Task{task})[0], nil
+}
+
+// Execution is the resolver for the execution field.
+func (r *queryResolver) Execution(ctx context.Context, uuid string) (*models.Execution, error) {
+	execution, err := r.repo.GetExecutionByUUID(ctx, uuid)
+	if err != nil {
+		return nil, lookupError("execution", uuid, err)
+	}
+	task, err := r.repo.GetTaskByUUID(ctx, execution.TaskUUID)
+	if err != nil {
+		return nil, lookupError("task", execution.TaskUUID, err)
+	}
+	if !canView(ctx, task.ProjectID) {
+		return nil, errNotFound
+	}
+	return execution, nil
+}
+
+// ID is the resolver for the id field.
+func (r *taskResolver) ID(ctx context.Context, obj *Task) (string, error) {
+	return obj.Task.ID.Hex(), nil
+}
+
+// Description is the resolver for the description field.
+func (r *taskResolver) Description(ctx context.Context, obj *Task) (*string, error) {
+	return optionalString(obj.Task.Description), nil
+}
+
+// ScheduleType is the resolver for the scheduleType field.
+func (r *taskResolver) ScheduleType(ctx context.Context, obj *Task) (string, error) {
+	return string(obj.Task.ScheduleType), nil
+}
+
+// Status is the resolver for the status field.
+func (r *taskResolver) Status(ctx context.Context, obj *Task) (string, error) {
+	return string(obj.Task.Status), nil
+}
+
+// State is the resolver for the state field.
+func (r *taskResolver) State(ctx context.Context, obj *Task) (string, error) {
+	return string(obj.Task.State), nil
+}
+
+// CronExpression is the resolver for the cronExpression field.
+func (r *taskResolver) CronExpression(ctx context.Context, obj *Task) (*string, error) {
+	return optionalString(obj.ScheduleConfig.CronExpression), nil
+}
+
+// Timezone is the resolver for the timezone field.
+func (r *taskResolver) Timezone(ctx context.Context, obj *Task) (*string, error) {
+	return optionalString(obj.ScheduleConfig.Timezone), nil
+}
+
+// Schedule is the resolver for the schedule field.
+func (r *taskResolver) Schedule(ctx context.Context, obj *Task) (*string, error) {
+	return optionalString(obj.ScheduleConfig.Schedule), nil
+}
+
+// Environment is the resolver for the environment field.
+func (r *taskResolver) Environment(ctx context.Context, obj *Task) (*string, error) {
+	return optionalString(obj.Task.Environment), nil
+}
+
+// Severity is the resolver for the severity field.
+func (r *taskResolver) Severity(ctx context.Context, obj *Task) (string, error) {
+	return string(obj.EffectiveSeverity()), nil
+}
+
+// TimeoutSeconds is the resolver for the timeoutSeconds field.
+func (r *taskResolver) TimeoutSeconds(ctx context.Context, obj *Task) (*int, error) {
+	return obj.Task.TimeoutSeconds, nil
+}
+
+// Tags is the resolver for the tags field.
+func (r *taskResolver) Tags(ctx context.Context, obj *Task) ([]string, error) {
+	if obj.Task.Tags == nil {
+		return []string{}, nil
+	}
+	return obj.Task.Tags, nil
+}
+
+// Muted is the resolver for the muted field.
+func (r *taskResolver) Muted(ctx context.Context, obj *Task) (bool, error) {
+	return obj.IsMuted(time.Now()), nil
+}
+
+// TaskGroup is the resolver for the taskGroup field.
+func (r *taskResolver) TaskGroup(ctx context.Context, obj *Task) (*models.TaskGroup, error) {
+	if obj.TaskGroupID == nil {
+		return nil, nil
+	}
+	taskGroup, err := r.repo.GetTaskGroupByID(ctx, *obj.TaskGroupID)
+	if err != nil {
+		return nil, lookupError("task group", obj.TaskGroupID.Hex(), err)
+	}
+	return taskGroup, nil
+}
+
+// LastExecution is the resolver for the lastExecution field.
+func (r *taskResolver) LastExecution(ctx context.Context, obj *Task) (*models.Execution, error) {
+	execution, err := obj.batch.latestExecution(ctx, obj.UUID)
+	if err != nil {
+		log.Printf("[GraphQL] Failed to get last execution for task %s: %v", obj.UUID, err)
+		return nil, errors.New("failed to fetch last execution")
+	}
+	return execution, nil
+}
+
+// Executions is the resolver for the executions field.
+func (r *taskResolver) Executions(ctx context.Context, obj *Task, from *time.Time, to *time.Time, page *int, pageSize *int) (*ExecutionPage, error) {
+	pageNumber := 1
+	if page != nil && *page > 0 {
+		pageNumber = *page
+	}
+	size := 20
+	if pageSize != nil && *pageSize > 0 {
+		size = *pageSize
+	}
+	if size > maxExecutionPageSize {
+		size = maxExecutionPageSize
+	}
+	if from != nil && to != nil && to.Before(*from) {
+		return nil, errors.New("from must not be after to")
+	}
+
+	executions, totalCount, err := r.repo.GetExecutionsByTaskUUIDPaginated(ctx, obj.UUID, from, to, pageNumber, size)
+	if err != nil {
+		log.Printf("[GraphQL] Failed to get executions for task %s: %v", obj.UUID, err)
+		return nil, errors.New("failed to fetch executions")
+	}
+	return &ExecutionPage{Nodes: executions, Page: pageNumber, PageSize: size, TotalCount: totalCount}, nil
+}
+
+// ID is the resolver for the id field.
+func (r *taskGroupResolver) ID(ctx context.Context, obj *models.TaskGroup) (string, error) {
+	return obj.ID.Hex(), nil
+}
+
+// Description is the resolver for the description field.
+func (r *taskGroupResolver) Description(ctx context.Context, obj *models.TaskGroup) (*string, error) {
+	return optionalString(obj.Description), nil
+}
+
+// Status is the resolver for the status field.
+func (r *taskGroupResolver) Status(ctx context.Context, obj *models.TaskGroup) (string, error) {
+	return string(obj.Status), nil
+}
+
+// State is the resolver for the state field.
+func (r *taskGroupResolver) State(ctx context.Context, obj *models.TaskGroup) (string, error) {
+	return string(obj.State), nil
+}
+
+// StartTime is the resolver for the startTime field.
+func (r *taskGroupResolver) StartTime(ctx context.Context, obj *models.TaskGroup) (*string, error) {
+	return optionalString(obj.StartTime), nil
+}
+
+// EndTime is the resolver for the endTime field.
+func (r *taskGroupResolver) EndTime(ctx context.Context, obj *models.TaskGroup) (*string, error) {
+	return optionalString(obj.EndTime), nil
+}
+
+// Timezone is the resolver for the timezone field.
+func (r *taskGroupResolver) Timezone(ctx context.Context, obj *models.TaskGroup) (*string, error) {
+	return optionalString(obj.Timezone), nil
+}
+
+// Tasks is the resolver for the tasks field.
+func (r *taskGroupResolver) Tasks(ctx context.Context, obj *models.TaskGroup) ([]*Task, error) {
+	tasks, err := r.repo.GetTasksByGroupID(ctx, obj.ID)
+	if err != nil {
+		log.Printf("[GraphQL] Failed to get tasks for task group %s: %v", obj.UUID, err)
+		return nil, errors.New("failed to fetch tasks")
+	}
+	return newTasks(r.Resolver, tasks), nil
+}
+
+// Execution returns ExecutionResolver implementation.
+func (r *Resolver) Execution() ExecutionResolver { return &executionResolver{r} }
+
+// Project returns ProjectResolver implementation.
+func (r *Resolver) Project() ProjectResolver { return &projectResolver{r} }
+
+// Query returns QueryResolver implementation.
+func (r *Resolver) Query() QueryResolver { return &queryResolver{r} }
+
+// Task returns TaskResolver implementation.
+func (r *Resolver) Task() TaskResolver { return &taskResolver{r} }
+
+// TaskGroup returns TaskGroupResolver implementation.
+func (r *Resolver) TaskGroup() TaskGroupResolver { return &taskGroupResolver{r} }
+
+type executionResolver struct{ *Resolver }
+type projectResolver struct{ *Resolver }
+type queryResolver struct{ *Resolver }
+type taskResolver struct{ *Resolver }
+type taskGroupResolver struct{ *Resolver }
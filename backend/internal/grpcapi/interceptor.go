@@ -0,0 +1,88 @@
+package grpcapi
+
+import (
+	"context"
+	"net"
+	"net/http"
+
+	"github.com/yourusername/cron-observer/backend/internal/middleware"
+	"github.com/yourusername/cron-observer/backend/internal/repositories"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// executionRequest is implemented by every SDK request message
+type executionRequest interface {
+	GetExecutionUuid() string
+}
+
+type accessKey struct{}
+
+// accessFrom returns the execution access stored by APIKeyInterceptor
+func accessFrom(ctx context.Context) (*middleware.ExecutionAccess, bool) {
+	access, ok := ctx.Value(accessKey{}).(*middleware.ExecutionAccess)
+	return access, ok
+}
+
+// APIKeyInterceptor authenticates SDK calls with the project API key sent in the "authorization" metadata,
+// using the same checks as the REST APIKeyMiddleware. Optional "x-task-uuid" metadata enables lenient check-ins.
+func APIKeyInterceptor(repo repositories.Repository) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		apiKey, checkInTaskUUID := "", ""
+		if md, ok := metadata.FromIncomingContext(ctx); ok {
+			if values := md.Get("authorization"); len(values) > 0 {
+				apiKey = values[0]
+			}
+			if values := md.Get(middleware.CheckInTaskHeader); len(values) > 0 {
+				checkInTaskUUID = values[0]
+			}
+		}
+		if apiKey == "" {
+			return nil, status.Error(codes.Unauthenticated, "authorization metadata required")
+		}
+
+		execReq, ok := req.(executionRequest)
+		if !ok || execReq.GetExecutionUuid() == "" {
+			return nil, status.Error(codes.InvalidArgument, "execution_uuid is required")
+		}
+
+		access, err := middleware.AuthorizeExecutionAPIKey(ctx, repo, execReq.GetExecutionUuid(), checkInTaskUUID, apiKey, clientIP(ctx))
+		if err != nil {
+			return nil, status.Error(grpcCode(err.Status), err.Message)
+		}
+
+		return handler(context.WithValue(ctx, accessKey{}, access), req)
+	}
+}
+
+// clientIP returns the caller's IP address, or an empty string when it is unknown
+func clientIP(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		return p.Addr.String()
+	}
+	return host
+}
+
+// grpcCode maps the HTTP status of a rejected API key check to a gRPC status code
+func grpcCode(httpStatus int) codes.Code {
+	switch httpStatus {
+	case http.StatusBadRequest:
+		return codes.InvalidArgument
+	case http.StatusUnauthorized:
+		return codes.Unauthenticated
+	case http.StatusForbidden:
+		return codes.PermissionDenied
+	case http.StatusNotFound:
+		return codes.NotFound
+	default:
+		return codes.Internal
+	}
+}
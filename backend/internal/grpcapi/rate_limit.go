@@ -0,0 +1,78 @@
+package grpcapi
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/yourusername/cron-observer/backend/internal/grpcapi/sdkv1"
+	"github.com/yourusername/cron-observer/backend/internal/middleware"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// rateLimitKinds maps SDK methods to the per-project quota their calls count against, as their REST counterparts do.
+// Methods without a quota, such as Heartbeat, are not limited.
+var rateLimitKinds = map[string]middleware.RateLimitKind{
+	sdkv1.ExecutionService_AppendLog_FullMethodName:       middleware.RateLimitLogAppends,
+	sdkv1.ExecutionService_StartExecution_FullMethodName:  middleware.RateLimitStatusUpdates,
+	sdkv1.ExecutionService_FinishExecution_FullMethodName: middleware.RateLimitStatusUpdates,
+}
+
+// RateLimitInterceptor enforces the per-project quotas of the REST ProjectRateLimitMiddleware on unary SDK calls,
+// drawing on the same limiter. It must run after APIKeyInterceptor, which stores the project in the context.
+func RateLimitInterceptor(limiter *middleware.RateLimiter) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if err := checkRateLimit(ctx, limiter, info.FullMethod); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// RateLimitStreamInterceptor enforces the same quotas on streaming SDK calls, counting every message received from
+// the client. It must run after the interceptor that authenticates the stream.
+func RateLimitStreamInterceptor(limiter *middleware.RateLimiter) grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if _, ok := rateLimitKinds[info.FullMethod]; !ok {
+			return handler(srv, stream)
+		}
+		return handler(srv, &rateLimitedStream{ServerStream: stream, limiter: limiter, method: info.FullMethod})
+	}
+}
+
+// rateLimitedStream counts every received message against the caller's quota
+type rateLimitedStream struct {
+	grpc.ServerStream
+	limiter *middleware.RateLimiter
+	method  string
+}
+
+func (s *rateLimitedStream) RecvMsg(m interface{}) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	return checkRateLimit(s.Context(), s.limiter, s.method)
+}
+
+// checkRateLimit counts a call of method against the quota of the project in ctx. Calls without an authenticated
+// project, and methods without a quota, are not counted.
+func checkRateLimit(ctx context.Context, limiter *middleware.RateLimiter, method string) error {
+	kind, ok := rateLimitKinds[method]
+	if !ok {
+		return nil
+	}
+	access, ok := accessFrom(ctx)
+	if !ok {
+		return nil
+	}
+
+	decision := limiter.Allow(access.Project, kind)
+	if decision.Allowed {
+		return nil
+	}
+	retryAfter := strconv.Itoa(decision.RetryAfter)
+	_ = grpc.SetTrailer(ctx, metadata.Pairs("retry-after", retryAfter))
+	return status.Error(codes.ResourceExhausted, "rate limit exceeded for this project, retry after "+retryAfter+" seconds")
+}
@@ -0,0 +1,546 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: sdkv1/executions.proto
+
+package sdkv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type ExecutionStatus int32
+
+const (
+	ExecutionStatus_EXECUTION_STATUS_UNSPECIFIED ExecutionStatus = 0
+	ExecutionStatus_EXECUTION_STATUS_PENDING     ExecutionStatus = 1
+	ExecutionStatus_EXECUTION_STATUS_RUNNING     ExecutionStatus = 2
+	ExecutionStatus_EXECUTION_STATUS_SUCCESS     ExecutionStatus = 3
+	ExecutionStatus_EXECUTION_STATUS_FAILED      ExecutionStatus = 4
+)
+
+// Enum value maps for ExecutionStatus.
+var (
+	ExecutionStatus_name = map[int32]string{
+		0: "EXECUTION_STATUS_UNSPECIFIED",
+		1: "EXECUTION_STATUS_PENDING",
+		2: "EXECUTION_STATUS_RUNNING",
+		3: "EXECUTION_STATUS_SUCCESS",
+		4: "EXECUTION_STATUS_FAILED",
+	}
+	ExecutionStatus_value = map[string]int32{
+		"EXECUTION_STATUS_UNSPECIFIED": 0,
+		"EXECUTION_STATUS_PENDING":     1,
+		"EXECUTION_STATUS_RUNNING":     2,
+		"EXECUTION_STATUS_SUCCESS":     3,
+		"EXECUTION_STATUS_FAILED":      4,
+	}
+)
+
+func (x ExecutionStatus) Enum() *ExecutionStatus {
+	p := new(ExecutionStatus)
+	*p = x
+	return p
+}
+
+func (x ExecutionStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ExecutionStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_sdkv1_executions_proto_enumTypes[0].Descriptor()
+}
+
+func (ExecutionStatus) Type() protoreflect.EnumType {
+	return &file_sdkv1_executions_proto_enumTypes[0]
+}
+
+func (x ExecutionStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ExecutionStatus.Descriptor instead.
+func (ExecutionStatus) EnumDescriptor() ([]byte, []int) {
+	return file_sdkv1_executions_proto_rawDescGZIP(), []int{0}
+}
+
+type LogLevel int32
+
+const (
+	LogLevel_LOG_LEVEL_UNSPECIFIED LogLevel = 0
+	LogLevel_LOG_LEVEL_INFO        LogLevel = 1
+	LogLevel_LOG_LEVEL_WARN        LogLevel = 2
+	LogLevel_LOG_LEVEL_ERROR       LogLevel = 3
+)
+
+// Enum value maps for LogLevel.
+var (
+	LogLevel_name = map[int32]string{
+		0: "LOG_LEVEL_UNSPECIFIED",
+		1: "LOG_LEVEL_INFO",
+		2: "LOG_LEVEL_WARN",
+		3: "LOG_LEVEL_ERROR",
+	}
+	LogLevel_value = map[string]int32{
+		"LOG_LEVEL_UNSPECIFIED": 0,
+		"LOG_LEVEL_INFO":        1,
+		"LOG_LEVEL_WARN":        2,
+		"LOG_LEVEL_ERROR":       3,
+	}
+)
+
+func (x LogLevel) Enum() *LogLevel {
+	p := new(LogLevel)
+	*p = x
+	return p
+}
+
+func (x LogLevel) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (LogLevel) Descriptor() protoreflect.EnumDescriptor {
+	return file_sdkv1_executions_proto_enumTypes[1].Descriptor()
+}
+
+func (LogLevel) Type() protoreflect.EnumType {
+	return &file_sdkv1_executions_proto_enumTypes[1]
+}
+
+func (x LogLevel) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use LogLevel.Descriptor instead.
+func (LogLevel) EnumDescriptor() ([]byte, []int) {
+	return file_sdkv1_executions_proto_rawDescGZIP(), []int{1}
+}
+
+type StartExecutionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ExecutionUuid string                 `protobuf:"bytes,1,opt,name=execution_uuid,json=executionUuid,proto3" json:"execution_uuid,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StartExecutionRequest) Reset() {
+	*x = StartExecutionRequest{}
+	mi := &file_sdkv1_executions_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StartExecutionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StartExecutionRequest) ProtoMessage() {}
+
+func (x *StartExecutionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_sdkv1_executions_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StartExecutionRequest.ProtoReflect.Descriptor instead.
+func (*StartExecutionRequest) Descriptor() ([]byte, []int) {
+	return file_sdkv1_executions_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *StartExecutionRequest) GetExecutionUuid() string {
+	if x != nil {
+		return x.ExecutionUuid
+	}
+	return ""
+}
+
+type HeartbeatRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ExecutionUuid string                 `protobuf:"bytes,1,opt,name=execution_uuid,json=executionUuid,proto3" json:"execution_uuid,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HeartbeatRequest) Reset() {
+	*x = HeartbeatRequest{}
+	mi := &file_sdkv1_executions_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HeartbeatRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HeartbeatRequest) ProtoMessage() {}
+
+func (x *HeartbeatRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_sdkv1_executions_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HeartbeatRequest.ProtoReflect.Descriptor instead.
+func (*HeartbeatRequest) Descriptor() ([]byte, []int) {
+	return file_sdkv1_executions_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *HeartbeatRequest) GetExecutionUuid() string {
+	if x != nil {
+		return x.ExecutionUuid
+	}
+	return ""
+}
+
+type AppendLogRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ExecutionUuid string                 `protobuf:"bytes,1,opt,name=execution_uuid,json=executionUuid,proto3" json:"execution_uuid,omitempty"`
+	Level         LogLevel               `protobuf:"varint,2,opt,name=level,proto3,enum=cronobserver.sdk.v1.LogLevel" json:"level,omitempty"`
+	Message       string                 `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	// Time the entry was written; defaults to the time the server receives it
+	Timestamp     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AppendLogRequest) Reset() {
+	*x = AppendLogRequest{}
+	mi := &file_sdkv1_executions_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AppendLogRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AppendLogRequest) ProtoMessage() {}
+
+func (x *AppendLogRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_sdkv1_executions_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AppendLogRequest.ProtoReflect.Descriptor instead.
+func (*AppendLogRequest) Descriptor() ([]byte, []int) {
+	return file_sdkv1_executions_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *AppendLogRequest) GetExecutionUuid() string {
+	if x != nil {
+		return x.ExecutionUuid
+	}
+	return ""
+}
+
+func (x *AppendLogRequest) GetLevel() LogLevel {
+	if x != nil {
+		return x.Level
+	}
+	return LogLevel_LOG_LEVEL_UNSPECIFIED
+}
+
+func (x *AppendLogRequest) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *AppendLogRequest) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+type AppendLogResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AppendLogResponse) Reset() {
+	*x = AppendLogResponse{}
+	mi := &file_sdkv1_executions_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AppendLogResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AppendLogResponse) ProtoMessage() {}
+
+func (x *AppendLogResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_sdkv1_executions_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AppendLogResponse.ProtoReflect.Descriptor instead.
+func (*AppendLogResponse) Descriptor() ([]byte, []int) {
+	return file_sdkv1_executions_proto_rawDescGZIP(), []int{3}
+}
+
+type FinishExecutionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ExecutionUuid string                 `protobuf:"bytes,1,opt,name=execution_uuid,json=executionUuid,proto3" json:"execution_uuid,omitempty"`
+	// EXECUTION_STATUS_SUCCESS or EXECUTION_STATUS_FAILED
+	Status ExecutionStatus `protobuf:"varint,2,opt,name=status,proto3,enum=cronobserver.sdk.v1.ExecutionStatus" json:"status,omitempty"`
+	// Failure reason; only used when status is EXECUTION_STATUS_FAILED
+	Error         string `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FinishExecutionRequest) Reset() {
+	*x = FinishExecutionRequest{}
+	mi := &file_sdkv1_executions_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FinishExecutionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FinishExecutionRequest) ProtoMessage() {}
+
+func (x *FinishExecutionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_sdkv1_executions_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FinishExecutionRequest.ProtoReflect.Descriptor instead.
+func (*FinishExecutionRequest) Descriptor() ([]byte, []int) {
+	return file_sdkv1_executions_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *FinishExecutionRequest) GetExecutionUuid() string {
+	if x != nil {
+		return x.ExecutionUuid
+	}
+	return ""
+}
+
+func (x *FinishExecutionRequest) GetStatus() ExecutionStatus {
+	if x != nil {
+		return x.Status
+	}
+	return ExecutionStatus_EXECUTION_STATUS_UNSPECIFIED
+}
+
+func (x *FinishExecutionRequest) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type ExecutionStatusResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ExecutionUuid string                 `protobuf:"bytes,1,opt,name=execution_uuid,json=executionUuid,proto3" json:"execution_uuid,omitempty"`
+	Status        ExecutionStatus        `protobuf:"varint,2,opt,name=status,proto3,enum=cronobserver.sdk.v1.ExecutionStatus" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExecutionStatusResponse) Reset() {
+	*x = ExecutionStatusResponse{}
+	mi := &file_sdkv1_executions_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExecutionStatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExecutionStatusResponse) ProtoMessage() {}
+
+func (x *ExecutionStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_sdkv1_executions_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExecutionStatusResponse.ProtoReflect.Descriptor instead.
+func (*ExecutionStatusResponse) Descriptor() ([]byte, []int) {
+	return file_sdkv1_executions_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ExecutionStatusResponse) GetExecutionUuid() string {
+	if x != nil {
+		return x.ExecutionUuid
+	}
+	return ""
+}
+
+func (x *ExecutionStatusResponse) GetStatus() ExecutionStatus {
+	if x != nil {
+		return x.Status
+	}
+	return ExecutionStatus_EXECUTION_STATUS_UNSPECIFIED
+}
+
+var File_sdkv1_executions_proto protoreflect.FileDescriptor
+
+const file_sdkv1_executions_proto_rawDesc = "" +
+	"\n" +
+	"\x16sdkv1/executions.proto\x12\x13cronobserver.sdk.v1\x1a\x1fgoogle/protobuf/timestamp.proto\">\n" +
+	"\x15StartExecutionRequest\x12%\n" +
+	"\x0eexecution_uuid\x18\x01 \x01(\tR\rexecutionUuid\"9\n" +
+	"\x10HeartbeatRequest\x12%\n" +
+	"\x0eexecution_uuid\x18\x01 \x01(\tR\rexecutionUuid\"\xc2\x01\n" +
+	"\x10AppendLogRequest\x12%\n" +
+	"\x0eexecution_uuid\x18\x01 \x01(\tR\rexecutionUuid\x123\n" +
+	"\x05level\x18\x02 \x01(\x0e2\x1d.cronobserver.sdk.v1.LogLevelR\x05level\x12\x18\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage\x128\n" +
+	"\ttimestamp\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\"\x13\n" +
+	"\x11AppendLogResponse\"\x93\x01\n" +
+	"\x16FinishExecutionRequest\x12%\n" +
+	"\x0eexecution_uuid\x18\x01 \x01(\tR\rexecutionUuid\x12<\n" +
+	"\x06status\x18\x02 \x01(\x0e2$.cronobserver.sdk.v1.ExecutionStatusR\x06status\x12\x14\n" +
+	"\x05error\x18\x03 \x01(\tR\x05error\"~\n" +
+	"\x17ExecutionStatusResponse\x12%\n" +
+	"\x0eexecution_uuid\x18\x01 \x01(\tR\rexecutionUuid\x12<\n" +
+	"\x06status\x18\x02 \x01(\x0e2$.cronobserver.sdk.v1.ExecutionStatusR\x06status*\xaa\x01\n" +
+	"\x0fExecutionStatus\x12 \n" +
+	"\x1cEXECUTION_STATUS_UNSPECIFIED\x10\x00\x12\x1c\n" +
+	"\x18EXECUTION_STATUS_PENDING\x10\x01\x12\x1c\n" +
+	"\x18EXECUTION_STATUS_RUNNING\x10\x02\x12\x1c\n" +
+	"\x18EXECUTION_STATUS_SUCCESS\x10\x03\x12\x1b\n" +
+	"\x17EXECUTION_STATUS_FAILED\x10\x04*b\n" +
+	"\bLogLevel\x12\x19\n" +
+	"\x15LOG_LEVEL_UNSPECIFIED\x10\x00\x12\x12\n" +
+	"\x0eLOG_LEVEL_INFO\x10\x01\x12\x12\n" +
+	"\x0eLOG_LEVEL_WARN\x10\x02\x12\x13\n" +
+	"\x0fLOG_LEVEL_ERROR\x10\x032\xaa\x03\n" +
+	"\x10ExecutionService\x12j\n" +
+	"\x0eStartExecution\x12*.cronobserver.sdk.v1.StartExecutionRequest\x1a,.cronobserver.sdk.v1.ExecutionStatusResponse\x12`\n" +
+	"\tHeartbeat\x12%.cronobserver.sdk.v1.HeartbeatRequest\x1a,.cronobserver.sdk.v1.ExecutionStatusResponse\x12Z\n" +
+	"\tAppendLog\x12%.cronobserver.sdk.v1.AppendLogRequest\x1a&.cronobserver.sdk.v1.AppendLogResponse\x12l\n" +
+	"\x0fFinishExecution\x12+.cronobserver.sdk.v1.FinishExecutionRequest\x1a,.cronobserver.sdk.v1.ExecutionStatusResponseBLZJgithub.com/yourusername/cron-observer/backend/internal/grpcapi/sdkv1;sdkv1b\x06proto3"
+
+var (
+	file_sdkv1_executions_proto_rawDescOnce sync.Once
+	file_sdkv1_executions_proto_rawDescData []byte
+)
+
+func file_sdkv1_executions_proto_rawDescGZIP() []byte {
+	file_sdkv1_executions_proto_rawDescOnce.Do(func() {
+		file_sdkv1_executions_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_sdkv1_executions_proto_rawDesc), len(file_sdkv1_executions_proto_rawDesc)))
+	})
+	return file_sdkv1_executions_proto_rawDescData
+}
+
+var file_sdkv1_executions_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_sdkv1_executions_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_sdkv1_executions_proto_goTypes = []any{
+	(ExecutionStatus)(0),            // 0: cronobserver.sdk.v1.ExecutionStatus
+	(LogLevel)(0),                   // 1: cronobserver.sdk.v1.LogLevel
+	(*StartExecutionRequest)(nil),   // 2: cronobserver.sdk.v1.StartExecutionRequest
+	(*HeartbeatRequest)(nil),        // 3: cronobserver.sdk.v1.HeartbeatRequest
+	(*AppendLogRequest)(nil),        // 4: cronobserver.sdk.v1.AppendLogRequest
+	(*AppendLogResponse)(nil),       // 5: cronobserver.sdk.v1.AppendLogResponse
+	(*FinishExecutionRequest)(nil),  // 6: cronobserver.sdk.v1.FinishExecutionRequest
+	(*ExecutionStatusResponse)(nil), // 7: cronobserver.sdk.v1.ExecutionStatusResponse
+	(*timestamppb.Timestamp)(nil),   // 8: google.protobuf.Timestamp
+}
+var file_sdkv1_executions_proto_depIdxs = []int32{
+	1, // 0: cronobserver.sdk.v1.AppendLogRequest.level:type_name -> cronobserver.sdk.v1.LogLevel
+	8, // 1: cronobserver.sdk.v1.AppendLogRequest.timestamp:type_name -> google.protobuf.Timestamp
+	0, // 2: cronobserver.sdk.v1.FinishExecutionRequest.status:type_name -> cronobserver.sdk.v1.ExecutionStatus
+	0, // 3: cronobserver.sdk.v1.ExecutionStatusResponse.status:type_name -> cronobserver.sdk.v1.ExecutionStatus
+	2, // 4: cronobserver.sdk.v1.ExecutionService.StartExecution:input_type -> cronobserver.sdk.v1.StartExecutionRequest
+	3, // 5: cronobserver.sdk.v1.ExecutionService.Heartbeat:input_type -> cronobserver.sdk.v1.HeartbeatRequest
+	4, // 6: cronobserver.sdk.v1.ExecutionService.AppendLog:input_type -> cronobserver.sdk.v1.AppendLogRequest
+	6, // 7: cronobserver.sdk.v1.ExecutionService.FinishExecution:input_type -> cronobserver.sdk.v1.FinishExecutionRequest
+	7, // 8: cronobserver.sdk.v1.ExecutionService.StartExecution:output_type -> cronobserver.sdk.v1.ExecutionStatusResponse
+	7, // 9: cronobserver.sdk.v1.ExecutionService.Heartbeat:output_type -> cronobserver.sdk.v1.ExecutionStatusResponse
+	5, // 10: cronobserver.sdk.v1.ExecutionService.AppendLog:output_type -> cronobserver.sdk.v1.AppendLogResponse
+	7, // 11: cronobserver.sdk.v1.ExecutionService.FinishExecution:output_type -> cronobserver.sdk.v1.ExecutionStatusResponse
+	8, // [8:12] is the sub-list for method output_type
+	4, // [4:8] is the sub-list for method input_type
+	4, // [4:4] is the sub-list for extension type_name
+	4, // [4:4] is the sub-list for extension extendee
+	0, // [0:4] is the sub-list for field type_name
+}
+
+func init() { file_sdkv1_executions_proto_init() }
+func file_sdkv1_executions_proto_init() {
+	if File_sdkv1_executions_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_sdkv1_executions_proto_rawDesc), len(file_sdkv1_executions_proto_rawDesc)),
+			NumEnums:      2,
+			NumMessages:   6,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_sdkv1_executions_proto_goTypes,
+		DependencyIndexes: file_sdkv1_executions_proto_depIdxs,
+		EnumInfos:         file_sdkv1_executions_proto_enumTypes,
+		MessageInfos:      file_sdkv1_executions_proto_msgTypes,
+	}.Build()
+	File_sdkv1_executions_proto = out.File
+	file_sdkv1_executions_proto_goTypes = nil
+	file_sdkv1_executions_proto_depIdxs = nil
+}
@@ -0,0 +1,214 @@
+// Package grpcapi serves the SDK execution reporting API over gRPC, alongside the REST SDK endpoints,
+// for high-throughput workers.
+package grpcapi
+
+import (
+	"context"
+	"errors"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/yourusername/cron-observer/backend/internal/events"
+	"github.com/yourusername/cron-observer/backend/internal/grpcapi/sdkv1"
+	"github.com/yourusername/cron-observer/backend/internal/metering"
+	"github.com/yourusername/cron-observer/backend/internal/middleware"
+	"github.com/yourusername/cron-observer/backend/internal/models"
+	"github.com/yourusername/cron-observer/backend/internal/quota"
+	"github.com/yourusername/cron-observer/backend/internal/repositories"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// NewServer creates a gRPC server exposing the SDK ExecutionService, authenticated by project API keys.
+// quotas may be nil to disable quota checks, meter nil to record no usage, and limiter nil to apply no per-project
+// rate limits; pass the limiter of the REST SDK endpoints so both APIs share the same quotas.
+func NewServer(repo repositories.Repository, eventBus *events.EventBus, quotas *quota.Service, meter *metering.Meter, limiter *middleware.RateLimiter, opts ...grpc.ServerOption) *grpc.Server {
+	unary := []grpc.UnaryServerInterceptor{APIKeyInterceptor(repo)}
+	var stream []grpc.StreamServerInterceptor
+	if limiter != nil {
+		unary = append(unary, RateLimitInterceptor(limiter))
+		stream = append(stream, RateLimitStreamInterceptor(limiter))
+	}
+	opts = append([]grpc.ServerOption{grpc.ChainUnaryInterceptor(unary...), grpc.ChainStreamInterceptor(stream...)}, opts...)
+	server := grpc.NewServer(opts...)
+	service := NewExecutionService(repo, eventBus)
+	service.SetQuotaService(quotas)
+	service.SetMeter(meter)
+	sdkv1.RegisterExecutionServiceServer(server, service)
+	return server
+}
+
+// ExecutionService implements sdkv1.ExecutionServiceServer on top of the repository
+type ExecutionService struct {
+	sdkv1.UnimplementedExecutionServiceServer
+
+	repo     repositories.Repository
+	eventBus *events.EventBus
+	quotas   *quota.Service  // nil disables quota checks
+	meter    *metering.Meter // nil records no usage
+}
+
+// NewExecutionService creates a new ExecutionService. Calls must pass through APIKeyInterceptor.
+func NewExecutionService(repo repositories.Repository, eventBus *events.EventBus) *ExecutionService {
+	return &ExecutionService{
+		repo:     repo,
+		eventBus: eventBus,
+	}
+}
+
+// SetQuotaService enables the log quota of AppendLog
+func (s *ExecutionService) SetQuotaService(quotas *quota.Service) {
+	s.quotas = quotas
+}
+
+// SetMeter counts the log bytes of AppendLog for usage metering
+func (s *ExecutionService) SetMeter(meter *metering.Meter) {
+	s.meter = meter
+}
+
+// StartExecution marks an execution as RUNNING
+func (s *ExecutionService) StartExecution(ctx context.Context, req *sdkv1.StartExecutionRequest) (*sdkv1.ExecutionStatusResponse, error) {
+	if err := s.repo.UpdateExecutionStatus(ctx, req.GetExecutionUuid(), models.ExecutionStatusRunning, nil); err != nil {
+		log.Printf("[gRPC] Failed to start execution %s: %v", req.GetExecutionUuid(), err)
+		return nil, status.Error(codes.Internal, "failed to update execution status")
+	}
+	return statusResponse(req.GetExecutionUuid(), models.ExecutionStatusRunning), nil
+}
+
+// Heartbeat records that the worker is still processing the execution and returns its current status
+func (s *ExecutionService) Heartbeat(ctx context.Context, req *sdkv1.HeartbeatRequest) (*sdkv1.ExecutionStatusResponse, error) {
+	access, ok := accessFrom(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "authorization metadata required")
+	}
+
+	recorded, err := s.repo.RecordExecutionHeartbeat(ctx, req.GetExecutionUuid(), time.Now())
+	if err != nil {
+		log.Printf("[gRPC] Failed to record heartbeat for execution %s: %v", req.GetExecutionUuid(), err)
+		return nil, status.Error(codes.Internal, "failed to record heartbeat")
+	}
+	if recorded {
+		return statusResponse(req.GetExecutionUuid(), access.Execution.Status), nil
+	}
+
+	// The execution finished since it was loaded, e.g. by its timeout; report the final status
+	execution, err := s.repo.GetExecutionByUUID(ctx, req.GetExecutionUuid())
+	if err != nil {
+		log.Printf("[gRPC] Failed to get execution %s: %v", req.GetExecutionUuid(), err)
+		return nil, status.Error(codes.Internal, "failed to get execution")
+	}
+	return statusResponse(execution.UUID, execution.Status), nil
+}
+
+// AppendLog appends a log entry to the execution
+func (s *ExecutionService) AppendLog(ctx context.Context, req *sdkv1.AppendLogRequest) (*sdkv1.AppendLogResponse, error) {
+	if req.GetMessage() == "" {
+		return nil, status.Error(codes.InvalidArgument, "message is required")
+	}
+
+	level, ok := logLevels[req.GetLevel()]
+	if !ok {
+		return nil, status.Error(codes.InvalidArgument, "invalid log level, must be one of: LOG_LEVEL_INFO, LOG_LEVEL_WARN, LOG_LEVEL_ERROR")
+	}
+
+	access, ok := accessFrom(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "authorization metadata required")
+	}
+	if err := s.quotas.CheckLog(ctx, access.Project, access.Execution, req.GetMessage()); err != nil {
+		var exceeded *quota.ExceededError
+		if errors.As(err, &exceeded) {
+			return nil, status.Error(codes.ResourceExhausted, exceeded.Error())
+		}
+		log.Printf("[gRPC] Failed to check the log quota of execution %s: %v", req.GetExecutionUuid(), err)
+		return nil, status.Error(codes.Internal, "failed to check quota")
+	}
+
+	timestamp := time.Now()
+	if req.GetTimestamp() != nil {
+		timestamp = req.GetTimestamp().AsTime()
+	}
+
+	logEntry := models.LogEntry{
+		Message:   req.GetMessage(),
+		Level:     level,
+		Timestamp: timestamp,
+	}
+	if err := s.repo.AppendLogToExecution(ctx, req.GetExecutionUuid(), logEntry); err != nil {
+		log.Printf("[gRPC] Failed to append log to execution %s: %v", req.GetExecutionUuid(), err)
+		return nil, status.Error(codes.Internal, "failed to append log")
+	}
+	s.meter.RecordLogBytes(access.Project.ID, len(logEntry.Message))
+	return &sdkv1.AppendLogResponse{}, nil
+}
+
+// FinishExecution records the final status of the execution and reports failures to alerting
+func (s *ExecutionService) FinishExecution(ctx context.Context, req *sdkv1.FinishExecutionRequest) (*sdkv1.ExecutionStatusResponse, error) {
+	var executionStatus models.ExecutionStatus
+	switch req.GetStatus() {
+	case sdkv1.ExecutionStatus_EXECUTION_STATUS_SUCCESS:
+		executionStatus = models.ExecutionStatusSuccess
+	case sdkv1.ExecutionStatus_EXECUTION_STATUS_FAILED:
+		executionStatus = models.ExecutionStatusFailed
+	default:
+		return nil, status.Error(codes.InvalidArgument, "status must be EXECUTION_STATUS_SUCCESS or EXECUTION_STATUS_FAILED")
+	}
+
+	var errorMsg *string
+	if executionStatus == models.ExecutionStatusFailed && strings.TrimSpace(req.GetError()) != "" {
+		errorMessage := req.GetError()
+		errorMsg = &errorMessage
+	}
+
+	if err := s.repo.UpdateExecutionStatus(ctx, req.GetExecutionUuid(), executionStatus, errorMsg); err != nil {
+		log.Printf("[gRPC] Failed to finish execution %s: %v", req.GetExecutionUuid(), err)
+		return nil, status.Error(codes.Internal, "failed to update execution status")
+	}
+
+	// Emit ExecutionFailed or ExecutionSucceeded, as the REST status endpoint does
+	if executionStatus == models.ExecutionStatusFailed || executionStatus == models.ExecutionStatusSuccess {
+		if access, ok := accessFrom(ctx); ok {
+			execution, err := s.repo.GetExecutionByUUID(ctx, req.GetExecutionUuid())
+			if err == nil && execution != nil {
+				if executionStatus == models.ExecutionStatusFailed {
+					events.Publish(s.eventBus, events.ExecutionFailedTopic, events.ExecutionFailedPayload{
+						Execution: execution,
+						Task:      access.Task,
+					})
+				} else {
+					events.Publish(s.eventBus, events.ExecutionSucceededTopic, events.ExecutionSucceededPayload{
+						Execution: execution,
+						Task:      access.Task,
+					})
+				}
+			}
+		}
+	}
+
+	return statusResponse(req.GetExecutionUuid(), executionStatus), nil
+}
+
+// logLevels maps protobuf log levels to the levels stored on log entries
+var logLevels = map[sdkv1.LogLevel]string{
+	sdkv1.LogLevel_LOG_LEVEL_INFO:  "info",
+	sdkv1.LogLevel_LOG_LEVEL_WARN:  "warn",
+	sdkv1.LogLevel_LOG_LEVEL_ERROR: "error",
+}
+
+// executionStatuses maps stored execution statuses to their protobuf values
+var executionStatuses = map[models.ExecutionStatus]sdkv1.ExecutionStatus{
+	models.ExecutionStatusQueued:  sdkv1.ExecutionStatus_EXECUTION_STATUS_PENDING, // Not dispatched yet
+	models.ExecutionStatusPending: sdkv1.ExecutionStatus_EXECUTION_STATUS_PENDING,
+	models.ExecutionStatusRunning: sdkv1.ExecutionStatus_EXECUTION_STATUS_RUNNING,
+	models.ExecutionStatusSuccess: sdkv1.ExecutionStatus_EXECUTION_STATUS_SUCCESS,
+	models.ExecutionStatusFailed:  sdkv1.ExecutionStatus_EXECUTION_STATUS_FAILED,
+}
+
+func statusResponse(executionUUID string, executionStatus models.ExecutionStatus) *sdkv1.ExecutionStatusResponse {
+	return &sdkv1.ExecutionStatusResponse{
+		ExecutionUuid: executionUUID,
+		Status:        executionStatuses[executionStatus],
+	}
+}
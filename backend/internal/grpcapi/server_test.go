@@ -0,0 +1,249 @@
+package grpcapi
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/yourusername/cron-observer/backend/internal/events"
+	"github.com/yourusername/cron-observer/backend/internal/grpcapi/sdkv1"
+	"github.com/yourusername/cron-observer/backend/internal/middleware"
+	"github.com/yourusername/cron-observer/backend/internal/models"
+	"github.com/yourusername/cron-observer/backend/mocks"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+const testAPIKey = "sk_test_key"
+
+// startTestServer serves the SDK API over an in-memory listener and returns a connected client
+func startTestServer(t *testing.T, repo *mocks.MockRepository, eventBus *events.EventBus) sdkv1.ExecutionServiceClient {
+	return startRateLimitedTestServer(t, repo, eventBus, nil)
+}
+
+// startRateLimitedTestServer is startTestServer with the per-project rate limits of limiter
+func startRateLimitedTestServer(t *testing.T, repo *mocks.MockRepository, eventBus *events.EventBus, limiter *middleware.RateLimiter) sdkv1.ExecutionServiceClient {
+	listener := bufconn.Listen(1024 * 1024)
+	server := NewServer(repo, eventBus, nil, nil, limiter)
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("Failed to dial test server: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return sdkv1.NewExecutionServiceClient(conn)
+}
+
+// expectExecutionLookup sets up the repository calls made by the API key interceptor
+func expectExecutionLookup(repo *mocks.MockRepository, execution *models.Execution) *models.Task {
+	project := &models.Project{ID: primitive.NewObjectID(), APIKey: testAPIKey}
+	task := &models.Task{UUID: execution.TaskUUID, ProjectID: project.ID}
+	repo.EXPECT().GetExecutionByUUID(gomock.Any(), execution.UUID).Return(execution, nil)
+	repo.EXPECT().GetTaskByUUID(gomock.Any(), execution.TaskUUID).Return(task, nil)
+	repo.EXPECT().GetProjectByID(gomock.Any(), project.ID).Return(project, nil)
+	return task
+}
+
+func withAPIKey(apiKey string) context.Context {
+	return metadata.AppendToOutgoingContext(context.Background(), "authorization", apiKey)
+}
+
+func TestExecutionService_ReportsExecutionLifecycle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockRepository(ctrl)
+	eventBus := events.NewEventBus(10)
+	defer eventBus.Close()
+	client := startTestServer(t, repo, eventBus)
+
+	execution := &models.Execution{UUID: "exec-1", TaskUUID: "task-1", Status: models.ExecutionStatusPending}
+	ctx := withAPIKey(testAPIKey)
+
+	expectExecutionLookup(repo, execution)
+	repo.EXPECT().UpdateExecutionStatus(gomock.Any(), "exec-1", models.ExecutionStatusRunning, nil).Return(nil)
+	resp, err := client.StartExecution(ctx, &sdkv1.StartExecutionRequest{ExecutionUuid: "exec-1"})
+	if err != nil {
+		t.Fatalf("StartExecution failed: %v", err)
+	}
+	if resp.GetStatus() != sdkv1.ExecutionStatus_EXECUTION_STATUS_RUNNING {
+		t.Errorf("Expected RUNNING, got %v", resp.GetStatus())
+	}
+
+	execution.Status = models.ExecutionStatusRunning
+	expectExecutionLookup(repo, execution)
+	repo.EXPECT().AppendLogToExecution(gomock.Any(), "exec-1", gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ string, entry models.LogEntry) error {
+			if entry.Level != "warn" || entry.Message != "retrying" {
+				t.Errorf("Unexpected log entry %+v", entry)
+			}
+			return nil
+		})
+	if _, err := client.AppendLog(ctx, &sdkv1.AppendLogRequest{ExecutionUuid: "exec-1", Level: sdkv1.LogLevel_LOG_LEVEL_WARN, Message: "retrying"}); err != nil {
+		t.Fatalf("AppendLog failed: %v", err)
+	}
+
+	expectExecutionLookup(repo, execution)
+	repo.EXPECT().RecordExecutionHeartbeat(gomock.Any(), "exec-1", gomock.Any()).Return(true, nil)
+	resp, err = client.Heartbeat(ctx, &sdkv1.HeartbeatRequest{ExecutionUuid: "exec-1"})
+	if err != nil {
+		t.Fatalf("Heartbeat failed: %v", err)
+	}
+	if resp.GetStatus() != sdkv1.ExecutionStatus_EXECUTION_STATUS_RUNNING {
+		t.Errorf("Expected RUNNING, got %v", resp.GetStatus())
+	}
+
+	expectExecutionLookup(repo, execution)
+	repo.EXPECT().UpdateExecutionStatus(gomock.Any(), "exec-1", models.ExecutionStatusSuccess, nil).Return(nil)
+	repo.EXPECT().GetExecutionByUUID(gomock.Any(), "exec-1").Return(&models.Execution{UUID: "exec-1", Status: models.ExecutionStatusSuccess}, nil)
+	resp, err = client.FinishExecution(ctx, &sdkv1.FinishExecutionRequest{ExecutionUuid: "exec-1", Status: sdkv1.ExecutionStatus_EXECUTION_STATUS_SUCCESS})
+	if err != nil {
+		t.Fatalf("FinishExecution failed: %v", err)
+	}
+	if resp.GetStatus() != sdkv1.ExecutionStatus_EXECUTION_STATUS_SUCCESS {
+		t.Errorf("Expected SUCCESS, got %v", resp.GetStatus())
+	}
+}
+
+func TestExecutionService_HeartbeatReportsTimedOutExecution(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockRepository(ctrl)
+	client := startTestServer(t, repo, events.NewEventBus(1))
+
+	execution := &models.Execution{UUID: "exec-1", TaskUUID: "task-1", Status: models.ExecutionStatusRunning}
+	expectExecutionLookup(repo, execution)
+	repo.EXPECT().RecordExecutionHeartbeat(gomock.Any(), "exec-1", gomock.Any()).Return(false, nil)
+	repo.EXPECT().GetExecutionByUUID(gomock.Any(), "exec-1").Return(&models.Execution{UUID: "exec-1", Status: models.ExecutionStatusFailed}, nil)
+
+	resp, err := client.Heartbeat(withAPIKey(testAPIKey), &sdkv1.HeartbeatRequest{ExecutionUuid: "exec-1"})
+	if err != nil {
+		t.Fatalf("Heartbeat failed: %v", err)
+	}
+	if resp.GetStatus() != sdkv1.ExecutionStatus_EXECUTION_STATUS_FAILED {
+		t.Errorf("Expected FAILED, got %v", resp.GetStatus())
+	}
+}
+
+func TestExecutionService_FinishFailedPublishesEvent(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockRepository(ctrl)
+	eventBus := events.NewEventBus(10)
+	defer eventBus.Close()
+	failed := eventBus.Subscribe(events.ExecutionFailed)
+	client := startTestServer(t, repo, eventBus)
+
+	execution := &models.Execution{UUID: "exec-1", TaskUUID: "task-1", Status: models.ExecutionStatusRunning}
+	task := expectExecutionLookup(repo, execution)
+	errorMessage := "upstream returned 502"
+	repo.EXPECT().UpdateExecutionStatus(gomock.Any(), "exec-1", models.ExecutionStatusFailed, &errorMessage).Return(nil)
+	repo.EXPECT().GetExecutionByUUID(gomock.Any(), "exec-1").Return(&models.Execution{UUID: "exec-1", Status: models.ExecutionStatusFailed, Error: errorMessage}, nil)
+
+	_, err := client.FinishExecution(withAPIKey(testAPIKey), &sdkv1.FinishExecutionRequest{
+		ExecutionUuid: "exec-1",
+		Status:        sdkv1.ExecutionStatus_EXECUTION_STATUS_FAILED,
+		Error:         errorMessage,
+	})
+	if err != nil {
+		t.Fatalf("FinishExecution failed: %v", err)
+	}
+
+	select {
+	case event := <-failed:
+		payload := event.Payload.(events.ExecutionFailedPayload)
+		if payload.Task.UUID != task.UUID || payload.Execution.Error != errorMessage {
+			t.Errorf("Unexpected event payload %+v", payload)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Expected an ExecutionFailed event")
+	}
+}
+
+func TestAPIKeyInterceptor_RejectsInvalidCredentials(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockRepository(ctrl)
+	client := startTestServer(t, repo, events.NewEventBus(1))
+
+	// Missing key
+	_, err := client.StartExecution(context.Background(), &sdkv1.StartExecutionRequest{ExecutionUuid: "exec-1"})
+	if status.Code(err) != codes.Unauthenticated {
+		t.Errorf("Expected Unauthenticated without a key, got %v", err)
+	}
+
+	// Missing execution UUID
+	_, err = client.StartExecution(withAPIKey(testAPIKey), &sdkv1.StartExecutionRequest{})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument without execution_uuid, got %v", err)
+	}
+
+	// Key of another project
+	expectExecutionLookup(repo, &models.Execution{UUID: "exec-1", TaskUUID: "task-1"})
+	_, err = client.StartExecution(withAPIKey("sk_other_project"), &sdkv1.StartExecutionRequest{ExecutionUuid: "exec-1"})
+	if status.Code(err) != codes.Unauthenticated {
+		t.Errorf("Expected Unauthenticated for a foreign key, got %v", err)
+	}
+}
+
+func TestRateLimitInterceptor_SharesProjectQuotasWithREST(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockRepository(ctrl)
+	limiter := middleware.NewRateLimiter(middleware.RateLimitDefaults{LogAppendsPerMinute: 100, StatusUpdatesPerMinute: 100})
+	client := startRateLimitedTestServer(t, repo, events.NewEventBus(1), limiter)
+
+	project := &models.Project{
+		ID:         primitive.NewObjectID(),
+		APIKey:     testAPIKey,
+		RateLimits: &models.ProjectRateLimits{LogAppendsPerMinute: 2},
+	}
+	execution := &models.Execution{UUID: "exec-1", TaskUUID: "task-1", Status: models.ExecutionStatusRunning}
+	repo.EXPECT().GetExecutionByUUID(gomock.Any(), execution.UUID).Return(execution, nil).AnyTimes()
+	repo.EXPECT().GetTaskByUUID(gomock.Any(), execution.TaskUUID).Return(&models.Task{UUID: execution.TaskUUID, ProjectID: project.ID}, nil).AnyTimes()
+	repo.EXPECT().GetProjectByID(gomock.Any(), project.ID).Return(project, nil).AnyTimes()
+	repo.EXPECT().AppendLogToExecution(gomock.Any(), "exec-1", gomock.Any()).Return(nil)
+	repo.EXPECT().UpdateExecutionStatus(gomock.Any(), "exec-1", models.ExecutionStatusRunning, nil).Return(nil)
+
+	// A log append sent over REST counts against the same quota
+	if decision := limiter.Allow(project, middleware.RateLimitLogAppends); !decision.Allowed {
+		t.Fatalf("Expected the REST request to be allowed, got %+v", decision)
+	}
+
+	request := &sdkv1.AppendLogRequest{ExecutionUuid: "exec-1", Message: "working", Level: sdkv1.LogLevel_LOG_LEVEL_INFO}
+	if _, err := client.AppendLog(withAPIKey(testAPIKey), request); err != nil {
+		t.Fatalf("Expected the second log append to be allowed, got %v", err)
+	}
+	var trailer metadata.MD
+	_, err := client.AppendLog(withAPIKey(testAPIKey), request, grpc.Trailer(&trailer))
+	if status.Code(err) != codes.ResourceExhausted {
+		t.Fatalf("Expected ResourceExhausted over the quota, got %v", err)
+	}
+	if len(trailer.Get("retry-after")) == 0 {
+		t.Errorf("Expected a retry-after trailer, got %v", trailer)
+	}
+
+	// Quotas are counted per kind: status updates are still allowed
+	if _, err := client.StartExecution(withAPIKey(testAPIKey), &sdkv1.StartExecutionRequest{ExecutionUuid: "exec-1"}); err != nil {
+		t.Errorf("Expected StartExecution to be allowed, got %v", err)
+	}
+	if decision := limiter.Allow(project, middleware.RateLimitLogAppends); decision.Allowed {
+		t.Error("Expected REST log appends to be over the quota after the gRPC calls")
+	}
+}
@@ -12,13 +12,50 @@ import (
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
-// ProjectAuthGuard checks if the current user has admin access to a project
+// ProjectPermission represents an action a project user may perform
+type ProjectPermission string
+
+const (
+	// PermissionViewProject allows reading the project, its tasks, task groups and executions
+	PermissionViewProject ProjectPermission = "project:view"
+	// PermissionManageTasks allows creating, updating, triggering and deleting tasks and task groups
+	PermissionManageTasks ProjectPermission = "tasks:manage"
+	// PermissionManageProject allows changing project settings, members and API keys
+	PermissionManageProject ProjectPermission = "project:manage"
+)
+
+// rolePermissions maps each project role to the permissions it grants
+var rolePermissions = map[models.ProjectUserRole]map[ProjectPermission]bool{
+	models.ProjectUserRoleAdmin: {
+		PermissionViewProject:   true,
+		PermissionManageTasks:   true,
+		PermissionManageProject: true,
+	},
+	models.ProjectUserRoleEditor: {
+		PermissionViewProject: true,
+		PermissionManageTasks: true,
+	},
+	models.ProjectUserRoleViewer: {
+		PermissionViewProject: true,
+	},
+	models.ProjectUserRoleReadonly: {
+		PermissionViewProject: true,
+	},
+}
+
+// RoleHasPermission reports whether the given project role grants the permission
+func RoleHasPermission(role models.ProjectUserRole, permission ProjectPermission) bool {
+	return rolePermissions[role][permission]
+}
+
+// ProjectAuthGuard checks if the current user has the given permission on a project
 // Returns true if:
 //   - User is a super admin, OR
-//   - User is in project's project_users with role 'admin'
+//   - User is in project's project_users with a role that grants the permission, OR
+//   - User is an admin of the project's organization, which grants every permission
 //
 // Returns false otherwise
-func ProjectAuthGuard(c *gin.Context, repo repositories.Repository, projectID primitive.ObjectID, superAdminMap map[string]bool) bool {
+func ProjectAuthGuard(c *gin.Context, repo repositories.Repository, projectID primitive.ObjectID, superAdmins *middleware.SuperAdmins, permission ProjectPermission) bool {
 	// Get authenticated user from context
 	user, exists := middleware.GetUserFromContext(c)
 	if !exists {
@@ -32,8 +69,8 @@ func ProjectAuthGuard(c *gin.Context, repo repositories.Repository, projectID pr
 		return false
 	}
 
-	// Check if user is a super admin
-	if superAdminMap[userEmail] {
+	// Check if user is a super admin (role assigned by AuthMiddleware after token verification)
+	if user.IsSuperAdmin() || superAdmins.Contains(userEmail) {
 		log.Printf("[AUTH GUARD] User %s is a super admin, access granted", userEmail)
 		return true
 	}
@@ -45,27 +82,89 @@ func ProjectAuthGuard(c *gin.Context, repo repositories.Repository, projectID pr
 		return false
 	}
 
-	// Check if user is in project_users with role 'admin'
+	// Check if user is in project_users with a role granting the permission
 	for _, projectUser := range project.ProjectUsers {
 		projectUserEmail := strings.ToLower(strings.TrimSpace(projectUser.Email))
-		if projectUserEmail == userEmail && projectUser.Role == models.ProjectUserRoleAdmin {
-			log.Printf("[AUTH GUARD] User %s is admin in project %s, access granted", userEmail, projectID.Hex())
+		if projectUserEmail == userEmail && RoleHasPermission(projectUser.Role, permission) {
+			log.Printf("[AUTH GUARD] User %s has role %s in project %s, %s granted", userEmail, projectUser.Role, projectID.Hex(), permission)
 			return true
 		}
 	}
 
-	log.Printf("[AUTH GUARD] User %s does not have admin access to project %s", userEmail, projectID.Hex())
+	// Organization admins have admin rights on every project of the organization
+	if project.OrganizationID != nil {
+		organization, err := repo.GetOrganizationByID(c.Request.Context(), *project.OrganizationID)
+		if err != nil {
+			log.Printf("[AUTH GUARD] Failed to get organization %s of project %s: %v", project.OrganizationID.Hex(), projectID.Hex(), err)
+		} else if organization.IsAdmin(userEmail) {
+			log.Printf("[AUTH GUARD] User %s is an admin of organization %s, %s granted on project %s", userEmail, organization.Name, permission, projectID.Hex())
+			return true
+		}
+	}
+
+	log.Printf("[AUTH GUARD] User %s does not have %s access to project %s", userEmail, permission, projectID.Hex())
 	return false
 }
 
-// RequireProjectAdmin is a middleware-like function that checks authorization and returns error if not authorized
-func RequireProjectAdmin(c *gin.Context, repo repositories.Repository, projectID primitive.ObjectID, superAdminMap map[string]bool) bool {
-	if !ProjectAuthGuard(c, repo, projectID, superAdminMap) {
+// RequireProjectPermission checks authorization and writes a 403 response if the user lacks the permission
+func RequireProjectPermission(c *gin.Context, repo repositories.Repository, projectID primitive.ObjectID, superAdmins *middleware.SuperAdmins, permission ProjectPermission) bool {
+	if !ProjectAuthGuard(c, repo, projectID, superAdmins, permission) {
 		c.JSON(http.StatusForbidden, gin.H{
-			"error": "You do not have permission to perform this action. Admin role or super admin access required.",
+			"error": "You do not have permission to perform this action. " + permissionRequirement(permission),
 		})
 		c.Abort()
 		return false
 	}
 	return true
 }
+
+// requireSuperAdmin writes a 401 or 403 response unless the user is a super admin
+func requireSuperAdmin(c *gin.Context, superAdmins *middleware.SuperAdmins) bool {
+	user, exists := middleware.GetUserFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "User not authenticated",
+		})
+		return false
+	}
+
+	if !user.IsSuperAdmin() && !superAdmins.Contains(user.Email) {
+		c.JSON(http.StatusForbidden, gin.H{
+			"error": "You do not have permission to perform this action. Super admin access required.",
+		})
+		return false
+	}
+	return true
+}
+
+// ProjectPermissionMiddleware enforces a project permission at the route level for routes with a :project_id parameter
+func ProjectPermissionMiddleware(repo repositories.Repository, superAdmins *middleware.SuperAdmins, permission ProjectPermission) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		projectID, err := primitive.ObjectIDFromHex(c.Param("project_id"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "Invalid project_id format in path",
+			})
+			c.Abort()
+			return
+		}
+
+		if !RequireProjectPermission(c, repo, projectID, superAdmins, permission) {
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// permissionRequirement describes which roles grant a permission, for error messages
+func permissionRequirement(permission ProjectPermission) string {
+	switch permission {
+	case PermissionViewProject:
+		return "Project membership or super admin access required."
+	case PermissionManageTasks:
+		return "Editor or admin role or super admin access required."
+	default:
+		return "Admin role, organization admin role or super admin access required."
+	}
+}
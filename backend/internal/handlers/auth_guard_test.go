@@ -0,0 +1,126 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yourusername/cron-observer/backend/internal/middleware"
+	"github.com/yourusername/cron-observer/backend/internal/models"
+	"github.com/yourusername/cron-observer/backend/mocks"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.uber.org/mock/gomock"
+)
+
+func TestProjectEndpoints_EnforceRolePermissions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	projectID := primitive.NewObjectID()
+	project := &models.Project{
+		ID: projectID,
+		ProjectUsers: []models.ProjectUser{
+			{Email: "admin@example.com", Role: models.ProjectUserRoleAdmin},
+			{Email: "editor@example.com", Role: models.ProjectUserRoleEditor},
+			{Email: "viewer@example.com", Role: models.ProjectUserRoleViewer},
+		},
+	}
+	task := &models.Task{UUID: "task-uuid", ProjectID: projectID, Name: "nightly"}
+	group := &models.TaskGroup{ID: primitive.NewObjectID(), UUID: "group-uuid", ProjectID: projectID}
+
+	repo := mocks.NewMockRepository(ctrl)
+	repo.EXPECT().GetProjectByID(gomock.Any(), projectID).Return(project, nil).AnyTimes()
+	repo.EXPECT().GetTaskByUUID(gomock.Any(), task.UUID).Return(task, nil).AnyTimes()
+	repo.EXPECT().ListTasksByProjectID(gomock.Any(), projectID, gomock.Any(), gomock.Any(), gomock.Any()).Return([]*models.Task{task}, int64(1), nil).AnyTimes()
+	repo.EXPECT().GetLatestExecutionsByTaskUUIDs(gomock.Any(), gomock.Any()).Return(map[string]*models.Execution{}, nil).AnyTimes()
+	repo.EXPECT().GetLatestExecutionByTaskUUID(gomock.Any(), task.UUID).Return(nil, nil).AnyTimes()
+	repo.EXPECT().GetTaskGroupsByProjectID(gomock.Any(), projectID).Return([]*models.TaskGroup{group}, nil).AnyTimes()
+	repo.EXPECT().GetTaskGroupByUUID(gomock.Any(), group.UUID).Return(group, nil).AnyTimes()
+	repo.EXPECT().GetTasksByGroupID(gomock.Any(), group.ID).Return([]*models.Task{task}, nil).AnyTimes()
+	repo.EXPECT().GetExecutionsByTaskUUIDPaginated(gomock.Any(), task.UUID, gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, int64(0), nil).AnyTimes()
+	repo.EXPECT().GetExecutionStatsByProject(gomock.Any(), projectID, gomock.Any()).Return(nil, nil).AnyTimes()
+	deletePublisher := mocks.NewMockDeleteJobPublisher(ctrl)
+	deletePublisher.EXPECT().PublishDeleteTask(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+	superAdmins := middleware.NewSuperAdmins([]string{"root@example.com"})
+	taskHandler := NewTaskHandler(repo, nil, &mockScheduler{}, superAdmins, deletePublisher)
+	taskGroupHandler := NewTaskGroupHandler(repo, nil, nil, superAdmins, nil)
+	executionHandler := NewExecutionHandler(repo, nil, superAdmins)
+
+	base := "/projects/" + projectID.Hex()
+	endpoints := []struct {
+		name       string
+		method     string
+		route      string
+		path       string
+		handler    gin.HandlerFunc
+		permission ProjectPermission
+	}{
+		{"list tasks", http.MethodGet, "/projects/:project_id/tasks", base + "/tasks", taskHandler.GetTasksByProject, PermissionViewProject},
+		{"get task", http.MethodGet, "/projects/:project_id/tasks/:task_uuid", base + "/tasks/task-uuid", taskHandler.GetTask, PermissionViewProject},
+		{"delete task", http.MethodDelete, "/projects/:project_id/tasks/:task_uuid", base + "/tasks/task-uuid", taskHandler.DeleteTask, PermissionManageTasks},
+		{"list task groups", http.MethodGet, "/projects/:project_id/task-groups", base + "/task-groups", taskGroupHandler.GetTaskGroupsByProject, PermissionViewProject},
+		{"get task group", http.MethodGet, "/projects/:project_id/task-groups/:group_uuid", base + "/task-groups/group-uuid", taskGroupHandler.GetTaskGroup, PermissionViewProject},
+		{"list group tasks", http.MethodGet, "/projects/:project_id/task-groups/:group_uuid/tasks", base + "/task-groups/group-uuid/tasks", taskGroupHandler.GetTasksByGroup, PermissionViewProject},
+		{"list executions", http.MethodGet, "/projects/:project_id/tasks/:task_uuid/executions", base + "/tasks/task-uuid/executions?date=2025-03-09&tz=UTC", executionHandler.GetExecutionsByTaskUUID, PermissionViewProject},
+		{"execution stats", http.MethodGet, "/projects/:project_id/executions/stats", base + "/executions/stats", executionHandler.GetExecutionStats, PermissionViewProject},
+	}
+	users := []struct {
+		email string
+		role  models.ProjectUserRole // empty for users outside the project
+	}{
+		{"root@example.com", models.ProjectUserRoleAdmin},
+		{"admin@example.com", models.ProjectUserRoleAdmin},
+		{"editor@example.com", models.ProjectUserRoleEditor},
+		{"viewer@example.com", models.ProjectUserRoleViewer},
+		{"stranger@example.com", ""},
+	}
+
+	for _, user := range users {
+		router := setupProjectRouter(user.email)
+		for _, endpoint := range endpoints {
+			router.Handle(endpoint.method, endpoint.route, endpoint.handler)
+		}
+
+		for _, endpoint := range endpoints {
+			req := httptest.NewRequest(endpoint.method, endpoint.path, nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			allowed := RoleHasPermission(user.role, endpoint.permission)
+			if allowed && (w.Code < 200 || w.Code >= 300) {
+				t.Errorf("%s %s: expected success, got %d: %s", user.email, endpoint.name, w.Code, w.Body.String())
+			}
+			if !allowed && w.Code != http.StatusForbidden {
+				t.Errorf("%s %s: expected status %d, got %d: %s", user.email, endpoint.name, http.StatusForbidden, w.Code, w.Body.String())
+			}
+		}
+	}
+}
+
+func TestTaskGroupHandler_RequireManageTasks_AbortsOnInvalidProjectID(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	handler := NewTaskGroupHandler(mocks.NewMockRepository(ctrl), nil, nil, middleware.NewSuperAdmins(nil), nil)
+
+	router := setupProjectRouter("root@example.com")
+	reached := false
+	router.DELETE("/projects/:project_id/task-groups/:group_uuid", func(c *gin.Context) {
+		handler.requireManageTasks(c)
+	}, func(c *gin.Context) {
+		reached = true
+	})
+
+	req := httptest.NewRequest(http.MethodDelete, "/projects/not-an-id/task-groups/group-uuid", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status %d, got %d", http.StatusBadRequest, w.Code)
+	}
+	if reached {
+		t.Error("Expected the request to be aborted")
+	}
+}
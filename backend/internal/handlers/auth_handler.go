@@ -0,0 +1,160 @@
+package handlers
+
+import (
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yourusername/cron-observer/backend/internal/middleware"
+	"github.com/yourusername/cron-observer/backend/internal/models"
+	"github.com/yourusername/cron-observer/backend/internal/repositories"
+	"github.com/yourusername/cron-observer/backend/internal/utils"
+)
+
+type AuthHandler struct {
+	repo             repositories.Repository
+	superAdmins      *middleware.SuperAdmins
+	tokenMaxLifetime time.Duration
+	revocations      *middleware.RevocationCache // nil when revocation checks are not cached
+}
+
+func NewAuthHandler(repo repositories.Repository, superAdmins *middleware.SuperAdmins, tokenMaxLifetime time.Duration) *AuthHandler {
+	if tokenMaxLifetime <= 0 {
+		tokenMaxLifetime = 30 * 24 * time.Hour
+	}
+
+	return &AuthHandler{
+		repo:             repo,
+		superAdmins:      superAdmins,
+		tokenMaxLifetime: tokenMaxLifetime,
+	}
+}
+
+// SetRevocationCache sets the cache of TokenRevocationMiddleware, so revocations apply on this server right away
+func (h *AuthHandler) SetRevocationCache(cache *middleware.RevocationCache) {
+	h.revocations = cache
+}
+
+// Logout revokes the token used for this request
+// @Summary      Revoke the current token
+// @Description  Revoke the bearer token used for this request by its jti claim
+// @Tags         auth
+// @Produce      json
+// @Success      204  "No Content"
+// @Failure      400  {object}  models.ErrorResponse
+// @Failure      401  {object}  models.ErrorResponse
+// @Failure      500  {object}  models.ErrorResponse
+// @Router       /auth/logout [post]
+func (h *AuthHandler) Logout(c *gin.Context) {
+	user, exists := middleware.GetUserFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "User not authenticated",
+		})
+		return
+	}
+
+	if user.TokenID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Token has no jti claim. Use /auth/revoke-all to revoke all of your tokens",
+		})
+		return
+	}
+
+	h.createRevocation(c, &models.TokenRevocation{JTI: user.TokenID}, user.Email)
+}
+
+// RevokeAllMyTokens revokes every token the current user obtained until now
+// @Summary      Revoke all of my tokens
+// @Description  Invalidate every token issued to the current user before now, e.g. after a dashboard token leaked
+// @Tags         auth
+// @Produce      json
+// @Success      204  "No Content"
+// @Failure      401  {object}  models.ErrorResponse
+// @Failure      500  {object}  models.ErrorResponse
+// @Router       /auth/revoke-all [post]
+func (h *AuthHandler) RevokeAllMyTokens(c *gin.Context) {
+	user, exists := middleware.GetUserFromContext(c)
+	if !exists || user.Email == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "User not authenticated",
+		})
+		return
+	}
+
+	now := time.Now()
+	h.createRevocation(c, &models.TokenRevocation{Email: user.Email, RevokedBefore: &now}, user.Email)
+}
+
+// RevokeTokens revokes a token by jti or all tokens of a user (super admins only)
+// @Summary      Revoke tokens
+// @Description  Revoke a single token by jti, or every token issued to an email address before now. Super admin access required.
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        revocation body models.RevokeTokensRequest true "Revocation request"
+// @Success      204  "No Content"
+// @Failure      400  {object}  models.ErrorResponse
+// @Failure      401  {object}  models.ErrorResponse
+// @Failure      403  {object}  models.ErrorResponse
+// @Failure      500  {object}  models.ErrorResponse
+// @Router       /auth/revocations [post]
+func (h *AuthHandler) RevokeTokens(c *gin.Context) {
+	user, exists := middleware.GetUserFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "User not authenticated",
+		})
+		return
+	}
+
+	if !user.IsSuperAdmin() && !h.superAdmins.Contains(user.Email) {
+		c.JSON(http.StatusForbidden, gin.H{
+			"error": "You do not have permission to perform this action. Super admin access required.",
+		})
+		return
+	}
+
+	var req models.RevokeTokensRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.HandleValidationError(c, err)
+		return
+	}
+
+	revocation := &models.TokenRevocation{JTI: req.JTI}
+	if req.Email != "" {
+		now := time.Now()
+		revocation = &models.TokenRevocation{Email: req.Email, RevokedBefore: &now}
+	}
+
+	h.createRevocation(c, revocation, user.Email)
+}
+
+// createRevocation fills in bookkeeping fields, stores the revocation and writes the response
+func (h *AuthHandler) createRevocation(c *gin.Context, revocation *models.TokenRevocation, revokedBy string) {
+	now := time.Now()
+	revocation.Email = strings.ToLower(strings.TrimSpace(revocation.Email))
+	revocation.RevokedBy = revokedBy
+	revocation.CreatedAt = now
+	revocation.ExpiresAt = now.Add(h.tokenMaxLifetime)
+
+	if err := h.repo.CreateTokenRevocation(c.Request.Context(), revocation); err != nil {
+		log.Printf("[AUTH] Failed to store token revocation: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to revoke token",
+		})
+		return
+	}
+	if h.revocations != nil {
+		h.revocations.Clear()
+	}
+
+	if revocation.JTI != "" {
+		log.Printf("[AUTH] Token %s revoked by %s", revocation.JTI, revokedBy)
+	} else {
+		log.Printf("[AUTH] All tokens of %s issued before %s revoked by %s", revocation.Email, revocation.RevokedBefore.Format(time.RFC3339), revokedBy)
+	}
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,92 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yourusername/cron-observer/backend/internal/middleware"
+	"github.com/yourusername/cron-observer/backend/internal/repositories"
+)
+
+// setupRevocationRouter serves the auth endpoints and a protected endpoint behind TokenRevocationMiddleware. The
+// token of each request is described by the X-Test-Jti and X-Test-Iat headers.
+func setupRevocationRouter(repo repositories.Repository) *gin.Engine {
+	superAdmins := middleware.NewSuperAdmins(nil)
+	cache := middleware.NewRevocationCache(time.Minute)
+	handler := NewAuthHandler(repo, superAdmins, time.Hour)
+	handler.SetRevocationCache(cache)
+
+	router := setupRouter()
+	router.Use(func(c *gin.Context) {
+		issuedAt, _ := time.Parse(time.RFC3339, c.GetHeader("X-Test-Iat"))
+		c.Set(middleware.UserContextKey, middleware.UserInfo{
+			Email:    "user@example.com",
+			TokenID:  c.GetHeader("X-Test-Jti"),
+			IssuedAt: issuedAt,
+		})
+		c.Next()
+	}, middleware.TokenRevocationMiddleware(repo, cache))
+	router.POST("/auth/logout", handler.Logout)
+	router.POST("/auth/revoke-all", handler.RevokeAllMyTokens)
+	router.GET("/me", func(c *gin.Context) { c.Status(http.StatusOK) })
+	return router
+}
+
+func requestWithToken(router *gin.Engine, method, path, jti string, issuedAt time.Time) int {
+	req := httptest.NewRequest(method, path, nil)
+	req.Header.Set("X-Test-Jti", jti)
+	req.Header.Set("X-Test-Iat", issuedAt.Format(time.RFC3339))
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w.Code
+}
+
+func TestAuthHandler_Logout_RejectsRevokedJTI(t *testing.T) {
+	router := setupRevocationRouter(repositories.NewMemoryRepository())
+	issuedAt := time.Now().Add(-time.Minute)
+
+	// The check is cached before the logout; the logout must still apply right away
+	if code := requestWithToken(router, http.MethodGet, "/me", "token-1", issuedAt); code != http.StatusOK {
+		t.Fatalf("Expected the token to be accepted before logout, got %d", code)
+	}
+	if code := requestWithToken(router, http.MethodPost, "/auth/logout", "token-1", issuedAt); code != http.StatusNoContent {
+		t.Fatalf("Expected logout to succeed, got %d", code)
+	}
+
+	if code := requestWithToken(router, http.MethodGet, "/me", "token-1", issuedAt); code != http.StatusUnauthorized {
+		t.Errorf("Expected the revoked token to be rejected, got %d", code)
+	}
+	if code := requestWithToken(router, http.MethodGet, "/me", "token-2", issuedAt); code != http.StatusOK {
+		t.Errorf("Expected the user's other tokens to stay valid, got %d", code)
+	}
+}
+
+func TestAuthHandler_Logout_RequiresJTI(t *testing.T) {
+	router := setupRevocationRouter(repositories.NewMemoryRepository())
+
+	if code := requestWithToken(router, http.MethodPost, "/auth/logout", "", time.Now()); code != http.StatusBadRequest {
+		t.Errorf("Expected status %d for a token without jti, got %d", http.StatusBadRequest, code)
+	}
+}
+
+func TestAuthHandler_RevokeAllMyTokens_RejectsEarlierTokens(t *testing.T) {
+	router := setupRevocationRouter(repositories.NewMemoryRepository())
+	earlier := time.Now().Add(-time.Hour)
+
+	if code := requestWithToken(router, http.MethodPost, "/auth/revoke-all", "token-1", earlier); code != http.StatusNoContent {
+		t.Fatalf("Expected revoke-all to succeed, got %d", code)
+	}
+
+	for _, jti := range []string{"token-1", "token-2", ""} {
+		if code := requestWithToken(router, http.MethodGet, "/me", jti, earlier); code != http.StatusUnauthorized {
+			t.Errorf("Expected token %q issued before the cutoff to be rejected, got %d", jti, code)
+		}
+	}
+	// A token obtained after logging out everywhere is accepted
+	if code := requestWithToken(router, http.MethodGet, "/me", "token-3", time.Now().Add(time.Minute)); code != http.StatusOK {
+		t.Errorf("Expected a token issued after the cutoff to be accepted, got %d", code)
+	}
+}
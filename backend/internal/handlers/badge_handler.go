@@ -0,0 +1,158 @@
+package handlers
+
+import (
+	"crypto/subtle"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yourusername/cron-observer/backend/internal/badge"
+	"github.com/yourusername/cron-observer/backend/internal/models"
+	"github.com/yourusername/cron-observer/backend/internal/repositories"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+const (
+	// badgeCacheTTL bounds how stale a badge can be; README hosts such as GitHub re-fetch badges often
+	badgeCacheTTL = time.Minute
+	// badgeLateAfter is how long past its next run time a task may go without firing before it is late
+	badgeLateAfter = 5 * time.Minute
+)
+
+// BadgeHandler serves embeddable SVG health badges for tasks of projects with an enabled status page
+type BadgeHandler struct {
+	repo repositories.Repository
+	now  func() time.Time
+
+	mu    sync.Mutex
+	cache map[string]cachedBadge // keyed by task UUID
+}
+
+type cachedBadge struct {
+	svg       []byte
+	token     string // Status page token at render time
+	expiresAt time.Time
+}
+
+func NewBadgeHandler(repo repositories.Repository) *BadgeHandler {
+	return &BadgeHandler{
+		repo:  repo,
+		now:   time.Now,
+		cache: make(map[string]cachedBadge),
+	}
+}
+
+// GetTaskBadge renders the health badge of a task
+// @Summary      Get a task health badge
+// @Description  Public endpoint returning a shields.io-style SVG badge (passing, failing, late, running, disabled or unknown) for embedding in READMEs and wikis. Authenticated by the project's status page token; returns 404 when the status page is disabled or the token does not match. Badges are cached for one minute.
+// @Tags         status
+// @Produce      image/svg+xml
+// @Param        task_uuid path string true "Task UUID followed by .svg"
+// @Param        token query string true "Status page token of the task's project"
+// @Success      200  {file}    file
+// @Failure      404  {object}  models.ErrorResponse
+// @Failure      500  {object}  models.ErrorResponse
+// @Router       /badges/tasks/{task_uuid}.svg [get]
+func (h *BadgeHandler) GetTaskBadge(c *gin.Context) {
+	notFound := func() {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Badge not found",
+		})
+	}
+
+	taskUUID, ok := strings.CutSuffix(c.Param("task_uuid"), ".svg")
+	token := c.Query("token")
+	if !ok || taskUUID == "" || token == "" {
+		notFound()
+		return
+	}
+
+	now := h.now()
+	h.mu.Lock()
+	cached, hit := h.cache[taskUUID]
+	h.mu.Unlock()
+	if hit && now.Before(cached.expiresAt) {
+		if subtle.ConstantTimeCompare([]byte(token), []byte(cached.token)) != 1 {
+			notFound()
+			return
+		}
+		writeBadge(c, cached.svg)
+		return
+	}
+
+	task, err := h.repo.GetTaskByUUID(c.Request.Context(), taskUUID)
+	if err != nil {
+		if err != mongo.ErrNoDocuments {
+			log.Printf("Failed to get task %s for badge: %v", taskUUID, err)
+		}
+		notFound()
+		return
+	}
+
+	project, err := h.repo.GetProjectByID(c.Request.Context(), task.ProjectID)
+	if err != nil {
+		if err != mongo.ErrNoDocuments {
+			log.Printf("Failed to get project %s for badge: %v", task.ProjectID.Hex(), err)
+		}
+		notFound()
+		return
+	}
+	if project.StatusPageToken == "" || project.Status == models.ProjectStatusPendingDelete ||
+		subtle.ConstantTimeCompare([]byte(token), []byte(project.StatusPageToken)) != 1 {
+		notFound()
+		return
+	}
+
+	latest, err := h.repo.GetLatestExecutionByTaskUUID(c.Request.Context(), taskUUID)
+	if err != nil {
+		log.Printf("Failed to get latest execution of task %s for badge: %v", taskUUID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to render badge",
+		})
+		return
+	}
+
+	message, color := badgeStatus(task, latest, now)
+	svg := badge.Render(task.Name, message, color)
+
+	h.mu.Lock()
+	h.cache[taskUUID] = cachedBadge{svg: svg, token: project.StatusPageToken, expiresAt: now.Add(badgeCacheTTL)}
+	h.mu.Unlock()
+
+	writeBadge(c, svg)
+}
+
+// badgeStatus derives the badge message and color from the task and its latest execution
+func badgeStatus(task *models.Task, latest *models.Execution, now time.Time) (string, string) {
+	if task.Status == models.TaskStatusDisabled {
+		return "disabled", badge.ColorGrey
+	}
+	if task.Status == models.TaskStatusPaused {
+		return "paused", badge.ColorRed
+	}
+	// The scheduler refreshes next_run_at on every fire, so a time well in the past means a missed run
+	if task.NextRunAt != nil && now.Sub(*task.NextRunAt) > badgeLateAfter {
+		return "late", badge.ColorAmber
+	}
+	if latest == nil {
+		return "unknown", badge.ColorGrey
+	}
+
+	switch latest.Status {
+	case models.ExecutionStatusSuccess:
+		return "passing", badge.ColorGreen
+	case models.ExecutionStatusFailed:
+		return "failing", badge.ColorRed
+	default:
+		return "running", badge.ColorBlue
+	}
+}
+
+func writeBadge(c *gin.Context, svg []byte) {
+	c.Header("Cache-Control", "public, max-age="+strconv.Itoa(int(badgeCacheTTL.Seconds())))
+	c.Data(http.StatusOK, "image/svg+xml; charset=utf-8", svg)
+}
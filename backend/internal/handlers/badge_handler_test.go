@@ -0,0 +1,112 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/yourusername/cron-observer/backend/internal/models"
+	"github.com/yourusername/cron-observer/backend/mocks"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.uber.org/mock/gomock"
+)
+
+func TestBadgeHandler_GetTaskBadge_CachesRenderedBadge(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockRepository(ctrl)
+	handler := NewBadgeHandler(repo)
+	now := time.Date(2025, 1, 15, 10, 0, 0, 0, time.UTC)
+	handler.now = func() time.Time { return now }
+
+	project := &models.Project{ID: primitive.NewObjectID(), StatusPageToken: "page-token"}
+	task := &models.Task{UUID: "task-1", Name: "Nightly backup", ProjectID: project.ID, Status: models.TaskStatusActive}
+
+	// Only the first request reaches the repository
+	repo.EXPECT().GetTaskByUUID(gomock.Any(), "task-1").Return(task, nil)
+	repo.EXPECT().GetProjectByID(gomock.Any(), project.ID).Return(project, nil)
+	repo.EXPECT().GetLatestExecutionByTaskUUID(gomock.Any(), "task-1").Return(&models.Execution{Status: models.ExecutionStatusSuccess}, nil)
+
+	router := setupRouter()
+	router.GET("/badges/tasks/:task_uuid", handler.GetTaskBadge)
+
+	for i := 0; i < 2; i++ {
+		req := httptest.NewRequest(http.MethodGet, "/badges/tasks/task-1.svg?token=page-token", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		if w.Code != http.StatusOK {
+			t.Fatalf("Expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+		}
+		if !strings.HasPrefix(w.Header().Get("Content-Type"), "image/svg+xml") {
+			t.Errorf("Expected an SVG content type, got %s", w.Header().Get("Content-Type"))
+		}
+		if !strings.Contains(w.Body.String(), ">passing</text>") {
+			t.Errorf("Expected a passing badge, got %s", w.Body.String())
+		}
+	}
+
+	// The cached badge still checks the token
+	req := httptest.NewRequest(http.MethodGet, "/badges/tasks/task-1.svg?token=guess", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusNotFound {
+		t.Errorf("Expected status %d for a wrong token, got %d", http.StatusNotFound, w.Code)
+	}
+}
+
+func TestBadgeHandler_GetTaskBadge_RequiresEnabledStatusPage(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockRepository(ctrl)
+	handler := NewBadgeHandler(repo)
+
+	project := &models.Project{ID: primitive.NewObjectID()}
+	repo.EXPECT().GetTaskByUUID(gomock.Any(), "task-1").Return(&models.Task{UUID: "task-1", ProjectID: project.ID}, nil)
+	repo.EXPECT().GetProjectByID(gomock.Any(), project.ID).Return(project, nil)
+
+	router := setupRouter()
+	router.GET("/badges/tasks/:task_uuid", handler.GetTaskBadge)
+
+	for _, path := range []string{"/badges/tasks/task-1.svg?token=page-token", "/badges/tasks/task-1?token=page-token"} {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		if w.Code != http.StatusNotFound {
+			t.Errorf("%s: expected status %d, got %d", path, http.StatusNotFound, w.Code)
+		}
+	}
+}
+
+func TestBadgeStatus(t *testing.T) {
+	now := time.Date(2025, 1, 15, 10, 0, 0, 0, time.UTC)
+	missed := now.Add(-time.Hour)
+	upcoming := now.Add(time.Hour)
+
+	tests := []struct {
+		name    string
+		task    *models.Task
+		latest  *models.Execution
+		message string
+	}{
+		{"passing", &models.Task{NextRunAt: &upcoming}, &models.Execution{Status: models.ExecutionStatusSuccess}, "passing"},
+		{"failing", &models.Task{NextRunAt: &upcoming}, &models.Execution{Status: models.ExecutionStatusFailed}, "failing"},
+		{"late", &models.Task{NextRunAt: &missed}, &models.Execution{Status: models.ExecutionStatusSuccess}, "late"},
+		{"running", &models.Task{}, &models.Execution{Status: models.ExecutionStatusRunning}, "running"},
+		{"never ran", &models.Task{}, nil, "unknown"},
+		{"disabled", &models.Task{Status: models.TaskStatusDisabled, NextRunAt: &missed}, nil, "disabled"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if message, _ := badgeStatus(tt.task, tt.latest, now); message != tt.message {
+				t.Errorf("Expected %s, got %s", tt.message, message)
+			}
+		})
+	}
+}
@@ -0,0 +1,368 @@
+package handlers
+
+import (
+	"log"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yourusername/cron-observer/backend/internal/cronexpr"
+	"github.com/yourusername/cron-observer/backend/internal/middleware"
+	"github.com/yourusername/cron-observer/backend/internal/models"
+	"github.com/yourusername/cron-observer/backend/internal/repositories"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+const (
+	// calendarMaxDays bounds the range of a run calendar
+	calendarMaxDays = 31
+	// calendarMaxRunsPerTask bounds the planned runs listed per task, e.g. of tasks firing every second
+	calendarMaxRunsPerTask = 2000
+	// calendarMaxExecutions bounds the executions read for a run calendar
+	calendarMaxExecutions = 20000
+	// calendarMatchWindow is how long after its planned time an execution still counts as that run; planned runs
+	// without an execution are MISSED once it has passed
+	calendarMatchWindow = 10 * time.Minute
+)
+
+// CalendarHandler serves the planned-vs-actual run calendar of a project
+type CalendarHandler struct {
+	repo              repositories.Repository
+	superAdmins       *middleware.SuperAdmins
+	schedulerLocation *time.Location // Timezone of tasks without one, like the scheduler's
+}
+
+func NewCalendarHandler(repo repositories.Repository, superAdmins *middleware.SuperAdmins) *CalendarHandler {
+	return &CalendarHandler{
+		repo:              repo,
+		superAdmins:       superAdmins,
+		schedulerLocation: time.UTC,
+	}
+}
+
+// SetSchedulerLocation sets the timezone tasks without their own or a project default are expanded in; it must
+// match the scheduler's (SCHEDULER_TIMEZONE)
+func (h *CalendarHandler) SetSchedulerLocation(loc *time.Location) {
+	h.schedulerLocation = loc
+}
+
+// GetRunCalendar overlays the planned runs of a project's tasks with their executions
+// @Summary      Get the run calendar
+// @Description  For each task, list the fire times its schedule planned between from and to together with the executions that started for them, so missed runs show explicitly as MISSED. Executions no planned run accounts for, e.g. manual triggers, are listed without scheduled_for. Planned runs follow the current schedule, status and task group window of each task, and start no earlier than the task was created. The range is at most 31 days.
+// @Tags         executions
+// @Accept       json
+// @Produce      json
+// @Param        project_id path string true "Project ID"
+// @Param        from query string true "Start of the range (YYYY-MM-DD or RFC3339), inclusive"
+// @Param        to query string true "End of the range (YYYY-MM-DD or RFC3339), inclusive"
+// @Param        tz query string false "IANA timezone dates are interpreted in (default: the project's default_timezone, then UTC)"
+// @Param        task_uuid query string false "Only this task"
+// @Success      200  {object}  models.RunCalendarResponse
+// @Failure      400  {object}  models.ErrorResponse
+// @Failure      403  {object}  models.ErrorResponse
+// @Failure      404  {object}  models.ErrorResponse
+// @Failure      500  {object}  models.ErrorResponse
+// @Router       /projects/{project_id}/calendar [get]
+func (h *CalendarHandler) GetRunCalendar(c *gin.Context) {
+	projectID, err := primitive.ObjectIDFromHex(c.Param("project_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid project_id format in path",
+		})
+		return
+	}
+	if c.Query("from") == "" || c.Query("to") == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "from and to parameters are required (YYYY-MM-DD or RFC3339)",
+		})
+		return
+	}
+
+	if !RequireProjectPermission(c, h.repo, projectID, h.superAdmins, PermissionViewProject) {
+		return
+	}
+
+	ctx := c.Request.Context()
+	project, err := h.repo.GetProjectByID(ctx, projectID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Project not found",
+		})
+		return
+	}
+	settings, err := h.repo.GetProjectSettings(ctx, projectID)
+	if err != nil {
+		log.Printf("Failed to get settings for project %s, using task timezones only: %v", projectID.Hex(), err)
+	}
+
+	timezone := c.Query("tz")
+	if timezone == "" {
+		timezone = settings.EffectiveTimezone("")
+	}
+	loc := time.UTC
+	if timezone != "" {
+		if loc, err = time.LoadLocation(timezone); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "Invalid tz. Use an IANA timezone such as America/New_York",
+			})
+			return
+		}
+	}
+
+	from, err := rangeBound(c.Query("from"), loc, false)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid from format. Use YYYY-MM-DD or RFC3339",
+		})
+		return
+	}
+	to, err := rangeBound(c.Query("to"), loc, true)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid to format. Use YYYY-MM-DD or RFC3339",
+		})
+		return
+	}
+	if to.Before(*from) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "from must not be after to",
+		})
+		return
+	}
+	if to.Sub(*from) > calendarMaxDays*24*time.Hour {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "The range must not exceed 31 days",
+		})
+		return
+	}
+
+	tasks, ok := h.calendarTasks(c, projectID)
+	if !ok {
+		return
+	}
+	groups, err := h.repo.GetTaskGroupsByProjectID(ctx, projectID)
+	if err != nil {
+		log.Printf("Failed to get task groups of project %s: %v", projectID.Hex(), err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to get run calendar",
+		})
+		return
+	}
+	groupsByID := make(map[primitive.ObjectID]*models.TaskGroup, len(groups))
+	for _, group := range groups {
+		groupsByID[group.ID] = group
+	}
+
+	// Executions of runs planned shortly before to may start after it
+	taskUUIDs := make([]string, 0, len(tasks))
+	for _, task := range tasks {
+		taskUUIDs = append(taskUUIDs, task.UUID)
+	}
+	executions, err := h.repo.GetExecutionsByTaskUUIDsBetween(ctx, taskUUIDs, *from, to.Add(calendarMatchWindow), calendarMaxExecutions)
+	if err != nil {
+		log.Printf("Failed to get executions of project %s: %v", projectID.Hex(), err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to get run calendar",
+		})
+		return
+	}
+	executionsByTask := make(map[string][]*models.Execution, len(tasks))
+	for _, execution := range executions {
+		executionsByTask[execution.TaskUUID] = append(executionsByTask[execution.TaskUUID], execution)
+	}
+
+	response := models.RunCalendarResponse{
+		From:      *from,
+		To:        *to,
+		Tasks:     make([]models.TaskRunCalendar, 0, len(tasks)),
+		Truncated: len(executions) == calendarMaxExecutions,
+	}
+	now := time.Now()
+	for _, task := range tasks {
+		calendar := models.TaskRunCalendar{TaskUUID: task.UUID, TaskName: task.Name}
+		var planned []time.Time
+		if task.ScheduleConfig.CronExpression != "" {
+			calendar.CronExpression = task.ScheduleConfig.CronExpression
+			calendar.Timezone = settings.EffectiveTimezone(task.ScheduleConfig.Timezone)
+			if calendar.Timezone == "" {
+				calendar.Timezone = h.schedulerLocation.String()
+			}
+			if isScheduled(project, task, groupsByID) {
+				planned, calendar.Truncated = plannedRuns(task, calendar.Timezone, groupsByID, *from, *to)
+			}
+		}
+		response.Tasks = append(response.Tasks, buildTaskRunCalendar(calendar, planned, executionsByTask[task.UUID], *to, now))
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// calendarTasks returns the task named by the task_uuid parameter, or all tasks of the project. Writes the error
+// response and returns false on failure.
+func (h *CalendarHandler) calendarTasks(c *gin.Context, projectID primitive.ObjectID) ([]*models.Task, bool) {
+	ctx := c.Request.Context()
+	if taskUUID := c.Query("task_uuid"); taskUUID != "" {
+		task, err := h.repo.GetTaskByUUID(ctx, taskUUID)
+		if err != nil && err != mongo.ErrNoDocuments {
+			log.Printf("Failed to get task %s: %v", taskUUID, err)
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "Failed to get run calendar",
+			})
+			return nil, false
+		}
+		if err == mongo.ErrNoDocuments || task.ProjectID != projectID {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": "Task not found",
+			})
+			return nil, false
+		}
+		return []*models.Task{task}, true
+	}
+
+	tasks, err := h.repo.GetTasksByProjectID(ctx, projectID)
+	if err != nil {
+		log.Printf("Failed to get tasks of project %s: %v", projectID.Hex(), err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to get run calendar",
+		})
+		return nil, false
+	}
+	return tasks, true
+}
+
+// isScheduled reports whether the scheduler fires the task, by the same rules it registers tasks with
+func isScheduled(project *models.Project, task *models.Task, groups map[primitive.ObjectID]*models.TaskGroup) bool {
+	if project.Status == models.ProjectStatusArchived || project.Status == models.ProjectStatusPendingDelete {
+		return false
+	}
+	if task.TaskGroupID != nil {
+		group, ok := groups[*task.TaskGroupID]
+		return ok && group.Status == models.TaskGroupStatusActive
+	}
+	return task.Status == models.TaskStatusActive
+}
+
+// plannedRuns expands the task's schedule between from and to, leaving out fire times before the task was created
+// and outside the window of its task group. The second result reports whether runs were left out because of
+// calendarMaxRunsPerTask.
+func plannedRuns(task *models.Task, timezone string, groups map[primitive.ObjectID]*models.TaskGroup, from, to time.Time) ([]time.Time, bool) {
+	schedule, err := cronexpr.Parse(cronexpr.WithTimezone(task.ScheduleConfig.CronExpression, timezone))
+	if err != nil {
+		log.Printf("Failed to parse schedule of task %s: %v", task.UUID, err)
+		return nil, false
+	}
+	if task.CreatedAt.After(from) {
+		from = task.CreatedAt
+	}
+
+	runs, truncated := cronexpr.RunsBetween(schedule, from, to, calendarMaxRunsPerTask)
+	if task.TaskGroupID == nil {
+		return runs, truncated
+	}
+	group := groups[*task.TaskGroupID]
+	inWindow := runs[:0]
+	for _, run := range runs {
+		if withinGroupWindow(group, run) {
+			inWindow = append(inWindow, run)
+		}
+	}
+	return inWindow, truncated
+}
+
+// withinGroupWindow reports whether t falls into the group's daily start_time to end_time window, like the
+// scheduler's check. Groups without a window always run.
+func withinGroupWindow(group *models.TaskGroup, t time.Time) bool {
+	if group.StartTime == "" || group.EndTime == "" {
+		return true
+	}
+	loc, err := time.LoadLocation(group.Timezone)
+	if err != nil {
+		return false
+	}
+	start, errStart := time.Parse("15:04", group.StartTime)
+	end, errEnd := time.Parse("15:04", group.EndTime)
+	if errStart != nil || errEnd != nil {
+		return false
+	}
+
+	local := t.In(loc)
+	minute := local.Hour()*60 + local.Minute()
+	return minute >= start.Hour()*60+start.Minute() && minute < end.Hour()*60+end.Minute()
+}
+
+// buildTaskRunCalendar matches the executions of a task, oldest first, to its planned runs and lists both in
+// chronological order
+func buildTaskRunCalendar(calendar models.TaskRunCalendar, planned []time.Time, executions []*models.Execution, to, now time.Time) models.TaskRunCalendar {
+	matched := make([]*models.Execution, len(planned))
+	var unplanned []*models.Execution
+	for _, execution := range executions {
+		slot := plannedSlot(planned, execution)
+		if slot < 0 || matched[slot] != nil {
+			// Executions after to were only read for runs planned before it
+			if !execution.StartedAt.After(to) {
+				unplanned = append(unplanned, execution)
+			}
+			continue
+		}
+		matched[slot] = execution
+	}
+
+	runs := make([]models.CalendarRun, 0, len(planned)+len(unplanned))
+	for i := range planned {
+		run := models.CalendarRun{ScheduledFor: &planned[i]}
+		if execution := matched[i]; execution != nil {
+			delayMs := execution.StartedAt.Sub(planned[i]).Milliseconds()
+			run.Status = models.CalendarRunStatus(execution.Status)
+			run.Execution = execution.Summary()
+			run.DelayMs = &delayMs
+		} else if now.Sub(planned[i]) > calendarMatchWindow {
+			run.Status = models.CalendarRunMissed
+			calendar.Missed++
+		} else {
+			run.Status = models.CalendarRunUpcoming
+		}
+		runs = append(runs, run)
+	}
+	for _, execution := range unplanned {
+		runs = append(runs, models.CalendarRun{
+			Status:    models.CalendarRunStatus(execution.Status),
+			Execution: execution.Summary(),
+		})
+	}
+	sort.SliceStable(runs, func(a, b int) bool {
+		return calendarRunTime(runs[a]).Before(calendarRunTime(runs[b]))
+	})
+
+	calendar.Planned = len(planned)
+	calendar.Unplanned = len(unplanned)
+	calendar.Runs = runs
+	return calendar
+}
+
+// plannedSlot returns the index of the planned run the execution started for, or -1 if none: the run a recovered
+// check-in names, otherwise the latest run at or before the execution started, within calendarMatchWindow
+func plannedSlot(planned []time.Time, execution *models.Execution) int {
+	if execution.ScheduledFor != nil {
+		i := sort.Search(len(planned), func(i int) bool { return !planned[i].Before(*execution.ScheduledFor) })
+		if i < len(planned) && planned[i].Equal(*execution.ScheduledFor) {
+			return i
+		}
+		return -1
+	}
+
+	i := sort.Search(len(planned), func(i int) bool { return planned[i].After(execution.StartedAt) }) - 1
+	if i < 0 || execution.StartedAt.Sub(planned[i]) > calendarMatchWindow {
+		return -1
+	}
+	return i
+}
+
+// calendarRunTime is when a run is placed on the calendar: its planned time, or when its execution started
+func calendarRunTime(run models.CalendarRun) time.Time {
+	if run.ScheduledFor != nil {
+		return *run.ScheduledFor
+	}
+	return run.Execution.StartedAt
+}
@@ -0,0 +1,146 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/yourusername/cron-observer/backend/internal/middleware"
+	"github.com/yourusername/cron-observer/backend/internal/models"
+	"github.com/yourusername/cron-observer/backend/mocks"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCalendarHandler_GetRunCalendar_OverlaysExecutionsOnPlannedRuns(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	projectID := primitive.NewObjectID()
+	created := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	daily := &models.Task{
+		UUID: "daily", ProjectID: projectID, Name: "daily", Status: models.TaskStatusActive, CreatedAt: created,
+		ScheduleConfig: models.ScheduleConfig{CronExpression: "0 9 * * *"},
+	}
+	disabled := &models.Task{
+		UUID: "disabled", ProjectID: projectID, Name: "disabled", Status: models.TaskStatusDisabled, CreatedAt: created,
+		ScheduleConfig: models.ScheduleConfig{CronExpression: "0 * * * *"},
+	}
+	at := func(day, hour, minute, second int) time.Time {
+		return time.Date(2025, 1, day, hour, minute, second, 0, time.UTC)
+	}
+	executions := []*models.Execution{
+		{UUID: "on-time", TaskUUID: "daily", Status: models.ExecutionStatusSuccess, StartedAt: at(10, 9, 0, 2)},
+		{UUID: "manual", TaskUUID: "daily", Status: models.ExecutionStatusSuccess, StartedAt: at(11, 15, 0, 0)},
+		{UUID: "late", TaskUUID: "daily", Status: models.ExecutionStatusFailed, StartedAt: at(12, 9, 5, 0)},
+		{UUID: "manual-disabled", TaskUUID: "disabled", Status: models.ExecutionStatusSuccess, StartedAt: at(11, 12, 0, 0)},
+	}
+
+	repo := mocks.NewMockRepository(ctrl)
+	handler := NewCalendarHandler(repo, middleware.NewSuperAdmins([]string{"root@example.com"}))
+	repo.EXPECT().GetProjectByID(gomock.Any(), projectID).Return(&models.Project{ID: projectID}, nil)
+	repo.EXPECT().GetProjectSettings(gomock.Any(), projectID).Return(&models.ProjectSettings{ProjectID: projectID}, nil)
+	repo.EXPECT().GetTasksByProjectID(gomock.Any(), projectID).Return([]*models.Task{daily, disabled}, nil)
+	repo.EXPECT().GetTaskGroupsByProjectID(gomock.Any(), projectID).Return(nil, nil)
+	repo.EXPECT().GetExecutionsByTaskUUIDsBetween(gomock.Any(), []string{"daily", "disabled"}, at(10, 0, 0, 0), gomock.Any(), calendarMaxExecutions).
+		Return(executions, nil)
+
+	router := setupProjectRouter("root@example.com")
+	router.GET("/api/v1/projects/:project_id/calendar", handler.GetRunCalendar)
+
+	req, _ := http.NewRequest(http.MethodGet, "/api/v1/projects/"+projectID.Hex()+"/calendar?from=2025-01-10&to=2025-01-12", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var body models.RunCalendarResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil || len(body.Tasks) != 2 {
+		t.Fatalf("Unexpected response: %s", w.Body.String())
+	}
+
+	calendar := body.Tasks[0]
+	if calendar.Planned != 3 || calendar.Missed != 1 || calendar.Unplanned != 1 || calendar.Timezone != "UTC" {
+		t.Errorf("Expected 3 planned, 1 missed and 1 unplanned run in UTC, got %+v", calendar)
+	}
+	want := []struct {
+		scheduledFor *time.Time
+		status       models.CalendarRunStatus
+		execution    string
+	}{
+		{timePtr(at(10, 9, 0, 0)), models.CalendarRunStatus(models.ExecutionStatusSuccess), "on-time"},
+		{timePtr(at(11, 9, 0, 0)), models.CalendarRunMissed, ""},
+		{nil, models.CalendarRunStatus(models.ExecutionStatusSuccess), "manual"},
+		{timePtr(at(12, 9, 0, 0)), models.CalendarRunStatus(models.ExecutionStatusFailed), "late"},
+	}
+	if len(calendar.Runs) != len(want) {
+		t.Fatalf("Expected %d runs, got %+v", len(want), calendar.Runs)
+	}
+	for i, run := range calendar.Runs {
+		if (run.ScheduledFor == nil) != (want[i].scheduledFor == nil) ||
+			run.ScheduledFor != nil && !run.ScheduledFor.Equal(*want[i].scheduledFor) {
+			t.Errorf("run %d: scheduled for %v, want %v", i, run.ScheduledFor, want[i].scheduledFor)
+		}
+		uuid := ""
+		if run.Execution != nil {
+			uuid = run.Execution.UUID
+		}
+		if run.Status != want[i].status || uuid != want[i].execution {
+			t.Errorf("run %d: %s with execution %q, want %s with %q", i, run.Status, uuid, want[i].status, want[i].execution)
+		}
+	}
+	if delay := calendar.Runs[3].DelayMs; delay == nil || *delay != (5*time.Minute).Milliseconds() {
+		t.Errorf("Expected a delay of 5 minutes for the late run, got %v", delay)
+	}
+
+	// Disabled tasks have no planned runs, only their executions
+	if disabled := body.Tasks[1]; disabled.Planned != 0 || disabled.Unplanned != 1 || len(disabled.Runs) != 1 {
+		t.Errorf("Expected only the manual run of the disabled task, got %+v", disabled)
+	}
+}
+
+func TestCalendarHandler_GetRunCalendar_RejectsLongRanges(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	projectID := primitive.NewObjectID()
+	repo := mocks.NewMockRepository(ctrl)
+	handler := NewCalendarHandler(repo, middleware.NewSuperAdmins([]string{"root@example.com"}))
+	repo.EXPECT().GetProjectByID(gomock.Any(), projectID).Return(&models.Project{ID: projectID}, nil)
+	repo.EXPECT().GetProjectSettings(gomock.Any(), projectID).Return(nil, nil)
+
+	router := setupProjectRouter("root@example.com")
+	router.GET("/api/v1/projects/:project_id/calendar", handler.GetRunCalendar)
+
+	req, _ := http.NewRequest(http.MethodGet, "/api/v1/projects/"+projectID.Hex()+"/calendar?from=2025-01-01&to=2025-03-01", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status 400, got %d: %s", w.Code, w.Body.String())
+	}
+}
+
+func TestWithinGroupWindow(t *testing.T) {
+	group := &models.TaskGroup{StartTime: "09:00", EndTime: "17:00", Timezone: "Europe/Berlin"}
+	tests := []struct {
+		at   time.Time
+		want bool
+	}{
+		{time.Date(2025, 1, 10, 7, 59, 0, 0, time.UTC), false},
+		{time.Date(2025, 1, 10, 8, 0, 0, 0, time.UTC), true},
+		{time.Date(2025, 1, 10, 15, 59, 0, 0, time.UTC), true},
+		{time.Date(2025, 1, 10, 16, 0, 0, 0, time.UTC), false},
+	}
+	for _, tt := range tests {
+		if got := withinGroupWindow(group, tt.at); got != tt.want {
+			t.Errorf("withinGroupWindow(%s) = %v, want %v", tt.at, got, tt.want)
+		}
+	}
+	if !withinGroupWindow(&models.TaskGroup{}, time.Now()) {
+		t.Error("Expected groups without a window to always run")
+	}
+}
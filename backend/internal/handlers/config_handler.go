@@ -0,0 +1,55 @@
+package handlers
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yourusername/cron-observer/backend/internal/config"
+	"github.com/yourusername/cron-observer/backend/internal/middleware"
+	"github.com/yourusername/cron-observer/backend/internal/models"
+)
+
+// ConfigHandler lets super admins reload the configuration without restarting the server
+type ConfigHandler struct {
+	reloader    *config.Reloader
+	superAdmins *middleware.SuperAdmins
+}
+
+func NewConfigHandler(reloader *config.Reloader, superAdmins *middleware.SuperAdmins) *ConfigHandler {
+	return &ConfigHandler{
+		reloader:    reloader,
+		superAdmins: superAdmins,
+	}
+}
+
+// ReloadConfig reloads the configuration, as SIGHUP does
+// @Summary      Reload the configuration
+// @Description  Read the environment and the .env file again and apply the settings that can change without a restart: super admins, Gmail credentials of alert emails, default rate limits, default quotas and the log level. Other settings need a restart. Super admin access required.
+// @Tags         admin
+// @Produce      json
+// @Success      200  {object}  models.ConfigReloadResponse
+// @Failure      401  {object}  models.ErrorResponse
+// @Failure      403  {object}  models.ErrorResponse
+// @Failure      500  {object}  models.ErrorResponse
+// @Router       /admin/config/reload [post]
+func (h *ConfigHandler) ReloadConfig(c *gin.Context) {
+	if !requireSuperAdmin(c, h.superAdmins) {
+		return
+	}
+
+	changed, err := h.reloader.Reload()
+	if err != nil {
+		log.Printf("[Handler] Configuration reload failed: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to reload configuration",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	if changed == nil {
+		changed = []string{}
+	}
+	c.JSON(http.StatusOK, models.ConfigReloadResponse{Changed: changed})
+}
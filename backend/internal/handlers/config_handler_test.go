@@ -0,0 +1,81 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/yourusername/cron-observer/backend/internal/config"
+	"github.com/yourusername/cron-observer/backend/internal/middleware"
+	"github.com/yourusername/cron-observer/backend/internal/models"
+)
+
+func TestConfigHandler_ReloadConfig_AppliesSuperAdmins(t *testing.T) {
+	t.Setenv("DATABASE_DRIVER", "memory")
+	t.Setenv("SUPER_ADMINS", "root@example.com")
+	startup, err := config.Load()
+	if err != nil {
+		t.Fatalf("config.Load() error = %v", err)
+	}
+
+	superAdmins := middleware.NewSuperAdmins(startup.Auth.SuperAdmins)
+	reloader := config.NewReloader(startup)
+	reloader.OnReload(func(cfg *config.Config) { superAdmins.Set(cfg.Auth.SuperAdmins) })
+	handler := NewConfigHandler(reloader, superAdmins)
+
+	reload := func(email string) *httptest.ResponseRecorder {
+		router := setupProjectRouter(email)
+		router.POST("/api/v1/admin/config/reload", handler.ReloadConfig)
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPost, "/api/v1/admin/config/reload", nil)
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	if w := reload("ops@example.com"); w.Code != http.StatusForbidden {
+		t.Fatalf("Expected status 403 before the reload, got %d: %s", w.Code, w.Body.String())
+	}
+
+	t.Setenv("SUPER_ADMINS", "root@example.com, ops@example.com")
+	w := reload("root@example.com")
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var response models.ConfigReloadResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if want := []string{"auth.super_admins"}; !reflect.DeepEqual(response.Changed, want) {
+		t.Errorf("changed = %v, want %v", response.Changed, want)
+	}
+
+	if w := reload("ops@example.com"); w.Code != http.StatusOK {
+		t.Errorf("Expected status 200 for the reloaded super admin, got %d: %s", w.Code, w.Body.String())
+	}
+}
+
+func TestConfigHandler_ReloadConfig_RejectsInvalidConfig(t *testing.T) {
+	t.Setenv("DATABASE_DRIVER", "memory")
+	startup, err := config.Load()
+	if err != nil {
+		t.Fatalf("config.Load() error = %v", err)
+	}
+	reloader := config.NewReloader(startup)
+	handler := NewConfigHandler(reloader, middleware.NewSuperAdmins([]string{"root@example.com"}))
+
+	t.Setenv("LOG_LEVEL", "verbose")
+	router := setupProjectRouter("root@example.com")
+	router.POST("/api/v1/admin/config/reload", handler.ReloadConfig)
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPost, "/api/v1/admin/config/reload", nil)
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusInternalServerError {
+		t.Fatalf("Expected status 500, got %d: %s", w.Code, w.Body.String())
+	}
+	if reloader.Current() != startup {
+		t.Error("configuration replaced after a failed reload")
+	}
+}
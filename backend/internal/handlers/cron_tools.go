@@ -0,0 +1,74 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yourusername/cron-observer/backend/internal/cronexpr"
+	"github.com/yourusername/cron-observer/backend/internal/models"
+	"github.com/yourusername/cron-observer/backend/internal/utils"
+)
+
+// cronPreviewRuns is how many upcoming fire times the validate endpoint returns
+const cronPreviewRuns = 5
+
+// CronToolsHandler serves helpers for writing cron expressions
+type CronToolsHandler struct{}
+
+// NewCronToolsHandler creates a new CronToolsHandler
+func NewCronToolsHandler() *CronToolsHandler {
+	return &CronToolsHandler{}
+}
+
+// ValidateCron parses a cron expression exactly as the scheduler would
+// @Summary      Validate a cron expression
+// @Description  Parse the expression with the scheduler's parser (five fields, six fields with leading seconds, or a descriptor such as @hourly). Returns the parser error for invalid expressions, otherwise an English description and the next 5 fire times in the given timezone.
+// @Tags         tools
+// @Accept       json
+// @Produce      json
+// @Param        request body models.ValidateCronRequest true "Expression and timezone"
+// @Success      200  {object}  models.ValidateCronResponse
+// @Failure      400  {object}  models.ErrorResponse
+// @Router       /tools/cron/validate [post]
+func (h *CronToolsHandler) ValidateCron(c *gin.Context) {
+	var req models.ValidateCronRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.HandleValidationError(c, err)
+		return
+	}
+
+	timezone := req.Timezone
+	if timezone == "" {
+		timezone = "UTC"
+	}
+	loc, err := time.LoadLocation(timezone)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid timezone",
+		})
+		return
+	}
+
+	response := models.ValidateCronResponse{Timezone: timezone}
+
+	schedule, err := cronexpr.Parse(cronexpr.WithTimezone(req.Expression, timezone))
+	if err != nil {
+		response.Error = err.Error()
+		c.JSON(http.StatusOK, response)
+		return
+	}
+	description, err := cronexpr.Describe(req.Expression)
+	if err != nil {
+		response.Error = err.Error()
+		c.JSON(http.StatusOK, response)
+		return
+	}
+
+	response.Valid = true
+	response.Description = description
+	for _, run := range cronexpr.NextRuns(schedule, time.Now(), cronPreviewRuns) {
+		response.NextRuns = append(response.NextRuns, run.In(loc))
+	}
+	c.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,67 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/yourusername/cron-observer/backend/internal/models"
+)
+
+func TestCronToolsHandler_ValidateCron_DescribesAndPreviewsRuns(t *testing.T) {
+	router := setupValidatedRouter(t, "")
+	router.POST("/api/v1/tools/cron/validate", NewCronToolsHandler().ValidateCron)
+
+	body := `{"expression":"0 */15 9-16 * * 1-5","timezone":"Europe/Berlin"}`
+	req, _ := http.NewRequest("POST", "/api/v1/tools/cron/validate", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status code %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+
+	var response models.ValidateCronResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if !response.Valid || response.Description != "every 15 minutes between 9am and 5pm on weekdays" {
+		t.Errorf("Unexpected result: valid=%v description=%q error=%q", response.Valid, response.Description, response.Error)
+	}
+	if len(response.NextRuns) != cronPreviewRuns {
+		t.Fatalf("Expected %d next runs, got %d", cronPreviewRuns, len(response.NextRuns))
+	}
+	for _, run := range response.NextRuns {
+		if _, offset := run.Zone(); offset != 3600 && offset != 7200 {
+			t.Errorf("Expected run in Europe/Berlin offset, got %v", run)
+		}
+		if run.Minute()%15 != 0 || run.Hour() < 9 || run.Hour() > 16 {
+			t.Errorf("Run %v is outside the schedule", run)
+		}
+	}
+}
+
+func TestCronToolsHandler_ValidateCron_ReportsParserError(t *testing.T) {
+	router := setupValidatedRouter(t, "")
+	router.POST("/api/v1/tools/cron/validate", NewCronToolsHandler().ValidateCron)
+
+	req, _ := http.NewRequest("POST", "/api/v1/tools/cron/validate", strings.NewReader(`{"expression":"0 61 * * * *"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status code %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+
+	var response models.ValidateCronResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if response.Valid || response.Error == "" || len(response.NextRuns) != 0 {
+		t.Errorf("Expected invalid expression with parser error, got %+v", response)
+	}
+}
@@ -0,0 +1,218 @@
+package handlers
+
+import (
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yourusername/cron-observer/backend/internal/deletequeue"
+	"github.com/yourusername/cron-observer/backend/internal/jobqueue"
+	"github.com/yourusername/cron-observer/backend/internal/middleware"
+	"github.com/yourusername/cron-observer/backend/internal/models"
+	"github.com/yourusername/cron-observer/backend/internal/reconciler"
+	"github.com/yourusername/cron-observer/backend/internal/repositories"
+	"github.com/yourusername/cron-observer/backend/internal/utils"
+)
+
+// DeleteJobsHandler lets super admins inspect and re-publish delete jobs that have not completed,
+// instead of waiting for the delete reconciler, and replay jobs parked in the dead-letter queue
+type DeleteJobsHandler struct {
+	repo            repositories.Repository
+	deletePublisher deletequeue.DeleteJobPublisher
+	parkedJobs      jobqueue.ParkedJobs // nil when the job queue driver has no dead-letter queue of its own
+	superAdmins     *middleware.SuperAdmins
+}
+
+func NewDeleteJobsHandler(repo repositories.Repository, deletePublisher deletequeue.DeleteJobPublisher, parkedJobs jobqueue.ParkedJobs, superAdmins *middleware.SuperAdmins) *DeleteJobsHandler {
+	return &DeleteJobsHandler{
+		repo:            repo,
+		deletePublisher: deletePublisher,
+		parkedJobs:      parkedJobs,
+		superAdmins:     superAdmins,
+	}
+}
+
+// ListStuckDeletes lists tasks whose delete has not completed
+// @Summary      List stuck delete jobs
+// @Description  List tasks in PENDING_DELETE or DELETE_FAILED, regardless of how recently the delete was requested. Super admin access required.
+// @Tags         admin
+// @Produce      json
+// @Success      200  {object}  models.ListStuckDeletesResponse
+// @Failure      401  {object}  models.ErrorResponse
+// @Failure      403  {object}  models.ErrorResponse
+// @Failure      500  {object}  models.ErrorResponse
+// @Router       /admin/delete-jobs [get]
+func (h *DeleteJobsHandler) ListStuckDeletes(c *gin.Context) {
+	if !requireSuperAdmin(c, h.superAdmins) {
+		return
+	}
+
+	tasks, err := reconciler.FindStuckDeletes(c.Request.Context(), h.repo, 0)
+	if err != nil {
+		log.Printf("[Handler] Failed to list stuck delete tasks: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to list stuck delete jobs",
+		})
+		return
+	}
+
+	response := models.ListStuckDeletesResponse{Tasks: make([]models.StuckDeleteTask, 0, len(tasks))}
+	for _, task := range tasks {
+		response.Tasks = append(response.Tasks, models.NewStuckDeleteTask(task))
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+// RequeueStuckDeletes re-publishes the delete jobs of stuck tasks
+// @Summary      Requeue stuck delete jobs
+// @Description  Re-publish the delete job of every task in PENDING_DELETE or DELETE_FAILED, or only of the given tasks. Super admin access required.
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        request body models.RequeueDeletesRequest false "Tasks to requeue"
+// @Success      200  {object}  models.RequeueDeletesResponse
+// @Failure      400  {object}  models.ErrorResponse
+// @Failure      401  {object}  models.ErrorResponse
+// @Failure      403  {object}  models.ErrorResponse
+// @Failure      500  {object}  models.ErrorResponse
+// @Router       /admin/delete-jobs/requeue [post]
+func (h *DeleteJobsHandler) RequeueStuckDeletes(c *gin.Context) {
+	if !requireSuperAdmin(c, h.superAdmins) {
+		return
+	}
+
+	var req models.RequeueDeletesRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			utils.HandleValidationError(c, err)
+			return
+		}
+	}
+
+	if h.deletePublisher == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Delete queue not available",
+		})
+		return
+	}
+
+	ctx := c.Request.Context()
+	tasks, err := reconciler.FindStuckDeletes(ctx, h.repo, 0)
+	if err != nil {
+		log.Printf("[Handler] Failed to list stuck delete tasks: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to list stuck delete jobs",
+		})
+		return
+	}
+
+	selected := make(map[string]bool, len(req.TaskUUIDs))
+	for _, taskUUID := range req.TaskUUIDs {
+		selected[taskUUID] = true
+	}
+
+	response := models.RequeueDeletesResponse{Requeued: []string{}}
+	for _, task := range tasks {
+		if len(selected) > 0 && !selected[task.UUID] {
+			continue
+		}
+		if err := reconciler.RequeueDelete(ctx, h.deletePublisher, task); err != nil {
+			log.Printf("[Handler] Failed to requeue delete job: TaskUUID=%s, error=%v", task.UUID, err)
+			if response.Failed == nil {
+				response.Failed = make(map[string]string)
+			}
+			response.Failed[task.UUID] = err.Error()
+			continue
+		}
+		response.Requeued = append(response.Requeued, task.UUID)
+	}
+
+	log.Printf("[Handler] Requeued %d stuck delete job(s), %d failed", len(response.Requeued), len(response.Failed))
+	c.JSON(http.StatusOK, response)
+}
+
+// ListParkedJobs lists jobs parked in the dead-letter queue
+// @Summary      List parked jobs
+// @Description  List jobs moved to the dead-letter queue after exhausting their retries or failing to decode, oldest first. Super admin access required.
+// @Tags         admin
+// @Produce      json
+// @Param        limit query int false "Maximum number of jobs (default 50, max 500)"
+// @Success      200  {object}  models.ListParkedJobsResponse
+// @Failure      401  {object}  models.ErrorResponse
+// @Failure      403  {object}  models.ErrorResponse
+// @Failure      500  {object}  models.ErrorResponse
+// @Router       /admin/delete-jobs/parked [get]
+func (h *DeleteJobsHandler) ListParkedJobs(c *gin.Context) {
+	if !requireSuperAdmin(c, h.superAdmins) || !h.requireParkedJobs(c) {
+		return
+	}
+
+	limit := 50
+	if limitParam := c.Query("limit"); limitParam != "" {
+		if parsedLimit, err := strconv.Atoi(limitParam); err == nil && parsedLimit > 0 {
+			limit = min(parsedLimit, 500)
+		}
+	}
+
+	jobs, err := h.parkedJobs.ListParked(c.Request.Context(), limit)
+	if err != nil {
+		log.Printf("[Handler] Failed to list parked jobs: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to list parked jobs",
+		})
+		return
+	}
+	c.JSON(http.StatusOK, models.ListParkedJobsResponse{Jobs: jobs})
+}
+
+// ReplayParkedJobs moves parked jobs back to the job queue
+// @Summary      Replay parked jobs
+// @Description  Move every parked job, or only the given ones, back to the job queue with their attempts reset. Super admin access required.
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        request body models.ReplayParkedJobsRequest false "Jobs to replay"
+// @Success      200  {object}  models.ReplayParkedJobsResponse
+// @Failure      400  {object}  models.ErrorResponse
+// @Failure      401  {object}  models.ErrorResponse
+// @Failure      403  {object}  models.ErrorResponse
+// @Failure      500  {object}  models.ErrorResponse
+// @Router       /admin/delete-jobs/parked/replay [post]
+func (h *DeleteJobsHandler) ReplayParkedJobs(c *gin.Context) {
+	if !requireSuperAdmin(c, h.superAdmins) || !h.requireParkedJobs(c) {
+		return
+	}
+
+	var req models.ReplayParkedJobsRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			utils.HandleValidationError(c, err)
+			return
+		}
+	}
+
+	replayed, err := h.parkedJobs.ReplayParked(c.Request.Context(), req.IDs)
+	if err != nil {
+		log.Printf("[Handler] Failed to replay parked jobs after %d replayed: %v", len(replayed), err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":    "Failed to replay parked jobs",
+			"replayed": replayed,
+		})
+		return
+	}
+
+	log.Printf("[Handler] Replayed %d parked job(s)", len(replayed))
+	c.JSON(http.StatusOK, models.ReplayParkedJobsResponse{Replayed: replayed})
+}
+
+// requireParkedJobs writes a 500 response unless a dead-letter queue is available
+func (h *DeleteJobsHandler) requireParkedJobs(c *gin.Context) bool {
+	if h.parkedJobs == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Dead-letter queue not available",
+		})
+		return false
+	}
+	return true
+}
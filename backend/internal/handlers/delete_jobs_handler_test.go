@@ -0,0 +1,181 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/yourusername/cron-observer/backend/internal/deletequeue"
+	"github.com/yourusername/cron-observer/backend/internal/middleware"
+	"github.com/yourusername/cron-observer/backend/internal/models"
+	"github.com/yourusername/cron-observer/backend/mocks"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.uber.org/mock/gomock"
+)
+
+func stuckTasks() []*models.Task {
+	projectID := primitive.NewObjectID()
+	return []*models.Task{
+		{UUID: "task-pending", ProjectID: projectID, Name: "Backup", Status: models.TaskStatusPendingDelete, UpdatedAt: time.Now()},
+		{UUID: "task-failed", ProjectID: projectID, Name: "Report", Status: models.TaskStatusDeleteFailed, UpdatedAt: time.Now().Add(-time.Hour)},
+	}
+}
+
+func TestDeleteJobsHandler_RequeueStuckDeletes(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockRepository(ctrl)
+	mockPublisher := mocks.NewMockDeleteJobPublisher(ctrl)
+	mockRepo.EXPECT().GetTasksByStatus(gomock.Any(), gomock.Any()).Return(stuckTasks(), nil)
+	mockPublisher.EXPECT().PublishDeleteTask(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ interface{}, msg deletequeue.DeleteTaskMessage) error {
+			if msg.TaskUUID == "task-failed" {
+				return errors.New("channel closed")
+			}
+			return nil
+		}).Times(2)
+
+	handler := NewDeleteJobsHandler(mockRepo, mockPublisher, nil, middleware.NewSuperAdmins([]string{"admin@example.com"}))
+	router := setupProjectRouter("admin@example.com")
+	router.POST("/api/v1/admin/delete-jobs/requeue", handler.RequeueStuckDeletes)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPost, "/api/v1/admin/delete-jobs/requeue", nil)
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var response models.RequeueDeletesResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if len(response.Requeued) != 1 || response.Requeued[0] != "task-pending" || response.Failed["task-failed"] != "channel closed" {
+		t.Fatalf("unexpected response %+v", response)
+	}
+}
+
+func TestDeleteJobsHandler_RequeueSelectedTasks(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockRepository(ctrl)
+	mockPublisher := mocks.NewMockDeleteJobPublisher(ctrl)
+	mockRepo.EXPECT().GetTasksByStatus(gomock.Any(), gomock.Any()).Return(stuckTasks(), nil)
+	mockPublisher.EXPECT().PublishDeleteTask(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ interface{}, msg deletequeue.DeleteTaskMessage) error {
+			if msg.TaskUUID != "task-failed" || msg.Kind != deletequeue.DeleteJobKindTask {
+				t.Errorf("unexpected delete message %+v", msg)
+			}
+			return nil
+		})
+
+	handler := NewDeleteJobsHandler(mockRepo, mockPublisher, nil, middleware.NewSuperAdmins([]string{"admin@example.com"}))
+	router := setupProjectRouter("admin@example.com")
+	router.POST("/api/v1/admin/delete-jobs/requeue", handler.RequeueStuckDeletes)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPost, "/api/v1/admin/delete-jobs/requeue", strings.NewReader(`{"task_uuids":["task-failed"]}`))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+}
+
+func TestDeleteJobsHandler_ListStuckDeletes_RequiresSuperAdmin(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	handler := NewDeleteJobsHandler(mocks.NewMockRepository(ctrl), mocks.NewMockDeleteJobPublisher(ctrl), nil, middleware.NewSuperAdmins([]string{"admin@example.com"}))
+	router := setupProjectRouter("user@example.com")
+	router.GET("/api/v1/admin/delete-jobs", handler.ListStuckDeletes)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/api/v1/admin/delete-jobs", nil)
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusForbidden {
+		t.Fatalf("Expected status 403, got %d: %s", w.Code, w.Body.String())
+	}
+}
+
+func TestDeleteJobsHandler_ListParkedJobs(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockParked := mocks.NewMockParkedJobs(ctrl)
+	mockParked.EXPECT().ListParked(gomock.Any(), 500).Return([]models.ParkedJob{
+		{ID: "job-1", Type: "delete_task", Attempts: 5, Error: "connection reset"},
+	}, nil)
+
+	handler := NewDeleteJobsHandler(mocks.NewMockRepository(ctrl), mocks.NewMockDeleteJobPublisher(ctrl), mockParked, middleware.NewSuperAdmins([]string{"admin@example.com"}))
+	router := setupProjectRouter("admin@example.com")
+	router.GET("/api/v1/admin/delete-jobs/parked", handler.ListParkedJobs)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/api/v1/admin/delete-jobs/parked?limit=10000", nil)
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var response models.ListParkedJobsResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if len(response.Jobs) != 1 || response.Jobs[0].ID != "job-1" || response.Jobs[0].Attempts != 5 {
+		t.Fatalf("unexpected response %+v", response)
+	}
+}
+
+func TestDeleteJobsHandler_ReplayParkedJobs(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockParked := mocks.NewMockParkedJobs(ctrl)
+	mockParked.EXPECT().ReplayParked(gomock.Any(), []string{"job-1", "job-2"}).Return([]string{"job-1"}, nil)
+
+	handler := NewDeleteJobsHandler(mocks.NewMockRepository(ctrl), mocks.NewMockDeleteJobPublisher(ctrl), mockParked, middleware.NewSuperAdmins([]string{"admin@example.com"}))
+	router := setupProjectRouter("admin@example.com")
+	router.POST("/api/v1/admin/delete-jobs/parked/replay", handler.ReplayParkedJobs)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPost, "/api/v1/admin/delete-jobs/parked/replay", strings.NewReader(`{"ids":["job-1","job-2"]}`))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var response models.ReplayParkedJobsResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if len(response.Replayed) != 1 || response.Replayed[0] != "job-1" {
+		t.Fatalf("unexpected response %+v", response)
+	}
+}
+
+func TestDeleteJobsHandler_ParkedJobsUnavailable(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	handler := NewDeleteJobsHandler(mocks.NewMockRepository(ctrl), mocks.NewMockDeleteJobPublisher(ctrl), nil, middleware.NewSuperAdmins([]string{"admin@example.com"}))
+	router := setupProjectRouter("admin@example.com")
+	router.GET("/api/v1/admin/delete-jobs/parked", handler.ListParkedJobs)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/api/v1/admin/delete-jobs/parked", nil)
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusInternalServerError {
+		t.Fatalf("Expected status 500, got %d: %s", w.Code, w.Body.String())
+	}
+}
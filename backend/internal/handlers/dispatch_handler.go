@@ -0,0 +1,76 @@
+package handlers
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yourusername/cron-observer/backend/internal/middleware"
+	"github.com/yourusername/cron-observer/backend/internal/models"
+	"github.com/yourusername/cron-observer/backend/internal/repositories"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// DispatchBacklogReporter reports the firings waiting in the scheduler's dispatch queue; implemented by
+// scheduler.Scheduler
+type DispatchBacklogReporter interface {
+	DispatchBacklog(projectID primitive.ObjectID) models.DispatchBacklog
+}
+
+// DispatchHandler shows how the scheduler dispatches a project's firings
+type DispatchHandler struct {
+	repo        repositories.Repository
+	scheduler   DispatchBacklogReporter
+	superAdmins *middleware.SuperAdmins
+}
+
+func NewDispatchHandler(repo repositories.Repository, scheduler DispatchBacklogReporter, superAdmins *middleware.SuperAdmins) *DispatchHandler {
+	return &DispatchHandler{
+		repo:        repo,
+		scheduler:   scheduler,
+		superAdmins: superAdmins,
+	}
+}
+
+// GetDispatchBacklog returns the project's firings waiting in the dispatch queue
+// @Summary      Get the dispatch backlog
+// @Description  Show how many scheduled firings of the project wait in this server's dispatch queue and when the oldest was queued. Firings queue up when the project's dispatch_rate_limit is reached or all dispatch workers are busy; throttled counts the firings the rate limit delayed since the scheduler started.
+// @Tags         projects
+// @Accept       json
+// @Produce      json
+// @Param        project_id path string true "Project ID"
+// @Success      200  {object}  models.DispatchBacklog
+// @Failure      400  {object}  models.ErrorResponse
+// @Failure      403  {object}  models.ErrorResponse
+// @Failure      404  {object}  models.ErrorResponse
+// @Router       /projects/{project_id}/dispatch-queue [get]
+func (h *DispatchHandler) GetDispatchBacklog(c *gin.Context) {
+	projectID, err := primitive.ObjectIDFromHex(c.Param("project_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid project_id format in path",
+		})
+		return
+	}
+
+	if !RequireProjectPermission(c, h.repo, projectID, h.superAdmins, PermissionViewProject) {
+		return
+	}
+
+	ctx := c.Request.Context()
+	if _, err := h.repo.GetProjectByID(ctx, projectID); err != nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Project not found",
+		})
+		return
+	}
+
+	backlog := h.scheduler.DispatchBacklog(projectID)
+	settings, err := h.repo.GetProjectSettings(ctx, projectID)
+	if err != nil {
+		log.Printf("Failed to get settings for project %s: %v", projectID.Hex(), err)
+	} else if settings != nil {
+		backlog.RateLimit = settings.DispatchRateLimit
+	}
+	c.JSON(http.StatusOK, backlog)
+}
@@ -1,56 +1,96 @@
 package handlers
 
 import (
-	"context"
-	"fmt"
 	"log"
 	"net/http"
 	"strconv"
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 	"github.com/yourusername/cron-observer/backend/internal/events"
+	"github.com/yourusername/cron-observer/backend/internal/metering"
+	"github.com/yourusername/cron-observer/backend/internal/middleware"
 	"github.com/yourusername/cron-observer/backend/internal/models"
+	"github.com/yourusername/cron-observer/backend/internal/quota"
 	"github.com/yourusername/cron-observer/backend/internal/repositories"
+	"github.com/yourusername/cron-observer/backend/internal/utils"
 	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
 )
 
 type ExecutionHandler struct {
-	repo     repositories.Repository
-	eventBus *events.EventBus
+	repo        repositories.Repository
+	eventBus    *events.EventBus
+	superAdmins *middleware.SuperAdmins
+
+	analyticsRepo repositories.Repository // Serves statistics; may read from secondaries
+	quotas        *quota.Service          // optional; nil enforces no quotas
+	meter         *metering.Meter         // optional; nil records no usage
 }
 
-func NewExecutionHandler(repo repositories.Repository, eventBus *events.EventBus) *ExecutionHandler {
+func NewExecutionHandler(repo repositories.Repository, eventBus *events.EventBus, superAdmins *middleware.SuperAdmins) *ExecutionHandler {
 	return &ExecutionHandler{
-		repo:     repo,
-		eventBus: eventBus,
+		repo:        repo,
+		eventBus:    eventBus,
+		superAdmins: superAdmins,
+	}
+}
+
+// SetAnalyticsRepository routes statistics queries to a repository that may read from secondaries
+func (h *ExecutionHandler) SetAnalyticsRepository(repo repositories.Repository) {
+	h.analyticsRepo = repo
+}
+
+// SetQuotaService enforces the project execution and log quotas on SDK reports
+func (h *ExecutionHandler) SetQuotaService(quotas *quota.Service) {
+	h.quotas = quotas
+}
+
+// SetMeter counts the log bytes appended through the SDK for usage metering
+func (h *ExecutionHandler) SetMeter(meter *metering.Meter) {
+	h.meter = meter
+}
+
+// analytics returns the repository for statistics queries
+func (h *ExecutionHandler) analytics() repositories.Repository {
+	if h.analyticsRepo != nil {
+		return h.analyticsRepo
 	}
+	return h.repo
 }
 
 // GetExecutionsByTaskUUID retrieves executions for a specific task
 // @Summary      Get executions for a task
-// @Description  Retrieve paginated executions for a specific task filtered by date
+// @Description  Retrieve paginated executions for a specific task, filtered by a single date or a from/to range.
+// @Description  Dates are calendar days in tz, or in the task's timezone (falling back to the project default, then UTC)
+// @Description  when tz is omitted. from and to also accept RFC3339 timestamps; a to date includes that whole day.
 // @Tags         executions
 // @Accept       json
 // @Produce      json
 // @Param        project_id path string true "Project ID"
 // @Param        task_uuid path string true "Task UUID"
-// @Param        date query string true "Filter by date (YYYY-MM-DD format). Returns executions for that date only"
+// @Param        date query string false "Filter by date (YYYY-MM-DD format). Returns executions for that date only. Required unless from or to is given"
+// @Param        from query string false "Start of the range (YYYY-MM-DD or RFC3339), inclusive"
+// @Param        to query string false "End of the range (YYYY-MM-DD or RFC3339), inclusive"
+// @Param        tz query string false "IANA timezone dates are interpreted in, e.g. America/New_York"
 // @Param        page query int false "Page number (default: 1)"
 // @Param        page_size query int false "Page size (default: 100)"
 // @Success      200  {object}  models.PaginatedExecutionsResponse
 // @Failure      400  {object}  models.ErrorResponse
+// @Failure      403  {object}  models.ErrorResponse
+// @Failure      404  {object}  models.ErrorResponse
 // @Failure      500  {object}  models.ErrorResponse
 // @Router       /projects/{project_id}/tasks/{task_uuid}/executions [get]
 func (h *ExecutionHandler) GetExecutionsByTaskUUID(c *gin.Context) {
-	projectID := c.Param("project_id")
-	taskUUID := c.Param("task_uuid")
-	if projectID == "" {
+	projectID, err := primitive.ObjectIDFromHex(c.Param("project_id"))
+	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{
-			"error": "project_id is required in path",
+			"error": "Invalid project_id format in path",
 		})
 		return
 	}
+	taskUUID := c.Param("task_uuid")
 	if taskUUID == "" {
 		c.JSON(http.StatusBadRequest, gin.H{
 			"error": "task_uuid is required in path",
@@ -58,24 +98,31 @@ func (h *ExecutionHandler) GetExecutionsByTaskUUID(c *gin.Context) {
 		return
 	}
 
-	// Date parameter is required
-	dateParam := c.Query("date")
-	if dateParam == "" {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": "date parameter is required (YYYY-MM-DD format)",
-		})
+	// Check authorization: user must be a member of the project, or super admin
+	if !RequireProjectPermission(c, h.repo, projectID, h.superAdmins, PermissionViewProject) {
 		return
 	}
 
-	// Parse date in YYYY-MM-DD format (will be parsed as UTC)
-	parsedDate, err := time.Parse("2006-01-02", dateParam)
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": "Invalid date format. Use YYYY-MM-DD",
+	task, err := h.repo.GetTaskByUUID(c.Request.Context(), taskUUID)
+	if err != nil && err != mongo.ErrNoDocuments {
+		log.Printf("Failed to get task %s: %v", taskUUID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to get executions",
+		})
+		return
+	}
+	if err != nil || task.ProjectID != projectID {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Task not found",
 		})
 		return
 	}
 
+	startDate, endDate, ok := h.executionsRange(c, task)
+	if !ok {
+		return
+	}
+
 	// Parse pagination parameters with defaults
 	page := 1
 	if pageParam := c.Query("page"); pageParam != "" {
@@ -96,14 +143,6 @@ func (h *ExecutionHandler) GetExecutionsByTaskUUID(c *gin.Context) {
 		}
 	}
 
-	// MongoDB stores times in UTC, so we need to create date range in UTC
-	// Set startDate to beginning of day in UTC
-	startOfDay := time.Date(parsedDate.Year(), parsedDate.Month(), parsedDate.Day(), 0, 0, 0, 0, time.UTC)
-	startDate := &startOfDay
-	// Set endDate to end of day in UTC
-	endOfDay := time.Date(parsedDate.Year(), parsedDate.Month(), parsedDate.Day(), 23, 59, 59, 999999999, time.UTC)
-	endDate := &endOfDay
-
 	executions, totalCount, err := h.repo.GetExecutionsByTaskUUIDPaginated(c.Request.Context(), taskUUID, startDate, endDate, page, pageSize)
 	if err != nil {
 		log.Printf("Failed to get executions for task %s: %v", taskUUID, err)
@@ -135,6 +174,134 @@ func (h *ExecutionHandler) GetExecutionsByTaskUUID(c *gin.Context) {
 	c.JSON(http.StatusOK, response)
 }
 
+// executionsRange builds the started_at range from either the date parameter or the from/to parameters.
+// Writes the error response and returns false on failure.
+func (h *ExecutionHandler) executionsRange(c *gin.Context, task *models.Task) (*time.Time, *time.Time, bool) {
+	dateParam := c.Query("date")
+	fromParam := c.Query("from")
+	toParam := c.Query("to")
+
+	if dateParam != "" && (fromParam != "" || toParam != "") {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Use either date or from/to, not both",
+		})
+		return nil, nil, false
+	}
+	if dateParam == "" && fromParam == "" && toParam == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "date (YYYY-MM-DD) or from/to parameters are required",
+		})
+		return nil, nil, false
+	}
+
+	// Timestamps carry their own offset, so the timezone is only looked up for calendar dates
+	loc := time.UTC
+	if dateParam != "" || !isTimestamp(fromParam) || !isTimestamp(toParam) {
+		var ok bool
+		if loc, ok = h.executionsLocation(c, task); !ok {
+			return nil, nil, false
+		}
+	}
+
+	if dateParam != "" {
+		startOfDay, endOfDay, err := dayRange(dateParam, loc)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "Invalid date format. Use YYYY-MM-DD",
+			})
+			return nil, nil, false
+		}
+		return &startOfDay, &endOfDay, true
+	}
+
+	from, err := rangeBound(fromParam, loc, false)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid from format. Use YYYY-MM-DD or RFC3339",
+		})
+		return nil, nil, false
+	}
+	to, err := rangeBound(toParam, loc, true)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid to format. Use YYYY-MM-DD or RFC3339",
+		})
+		return nil, nil, false
+	}
+	if from != nil && to != nil && to.Before(*from) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "from must not be after to",
+		})
+		return nil, nil, false
+	}
+	return from, to, true
+}
+
+// rangeBound parses a from/to value given as an RFC3339 timestamp or a YYYY-MM-DD date in loc.
+// A date resolves to the start of that day, or to its end when endOfDay is set. Empty values return nil.
+func rangeBound(value string, loc *time.Location, endOfDay bool) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return &t, nil
+	}
+	start, end, err := dayRange(value, loc)
+	if err != nil {
+		return nil, err
+	}
+	if endOfDay {
+		return &end, nil
+	}
+	return &start, nil
+}
+
+// isTimestamp reports whether a from/to value is empty or an RFC3339 timestamp rather than a calendar date
+func isTimestamp(value string) bool {
+	if value == "" {
+		return true
+	}
+	_, err := time.Parse(time.RFC3339, value)
+	return err == nil
+}
+
+// executionsLocation resolves the timezone a date filter is interpreted in: the tz query parameter if given,
+// otherwise the task's effective timezone, otherwise UTC. Writes the error response and returns false on failure.
+func (h *ExecutionHandler) executionsLocation(c *gin.Context, task *models.Task) (*time.Location, bool) {
+	timezone := c.Query("tz")
+	if timezone == "" {
+		settings, err := h.repo.GetProjectSettings(c.Request.Context(), task.ProjectID)
+		if err != nil {
+			log.Printf("Failed to get project settings for task %s, using task timezone only: %v", task.UUID, err)
+		}
+		timezone = settings.EffectiveTimezone(task.ScheduleConfig.Timezone)
+	}
+	if timezone == "" {
+		return time.UTC, true
+	}
+
+	loc, err := time.LoadLocation(timezone)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid tz. Use an IANA timezone such as America/New_York",
+		})
+		return nil, false
+	}
+	return loc, true
+}
+
+// dayRange returns the first and last instant of a YYYY-MM-DD calendar day in loc. The range follows local
+// midnights, so days with a DST transition are 23 or 25 hours long.
+func dayRange(date string, loc *time.Location) (time.Time, time.Time, error) {
+	day, err := time.ParseInLocation("2006-01-02", date, loc)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	start := time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, loc)
+	end := start.AddDate(0, 0, 1).Add(-time.Nanosecond)
+	return start, end, nil
+}
+
 // AppendLogToExecution appends a log entry to an execution
 // @Summary      Append log to execution
 // @Description  Append a log entry to an execution by execution UUID
@@ -146,7 +313,9 @@ func (h *ExecutionHandler) GetExecutionsByTaskUUID(c *gin.Context) {
 // @Success      200  {object}  map[string]string
 // @Failure      400  {object}  models.ErrorResponse
 // @Failure      404  {object}  models.ErrorResponse
+// @Failure      413  {object}  models.ErrorResponse
 // @Failure      500  {object}  models.ErrorResponse
+// @Failure      429  {object}  models.ErrorResponse
 // @Router       /executions/{execution_uuid}/logs [post]
 func (h *ExecutionHandler) AppendLogToExecution(c *gin.Context) {
 	executionUUID := c.Param("execution_uuid")
@@ -179,6 +348,21 @@ func (h *ExecutionHandler) AppendLogToExecution(c *gin.Context) {
 		return
 	}
 
+	// The API key middleware stores the project and execution; without them there is nothing to measure against
+	project, hasProject := middleware.GetProjectFromContext(c)
+	execution, hasExecution := middleware.GetExecutionFromContext(c)
+	if hasProject && hasExecution {
+		if err := h.quotas.CheckLog(c.Request.Context(), project, execution, logRequest.Message); err != nil {
+			if !respondQuotaExceeded(c, err) {
+				log.Printf("Failed to check log quota of execution %s: %v", executionUUID, err)
+				c.JSON(http.StatusInternalServerError, gin.H{
+					"error": "Failed to check project quotas",
+				})
+			}
+			return
+		}
+	}
+
 	logEntry := models.LogEntry{
 		Message:   logRequest.Message,
 		Level:     logRequest.Level,
@@ -192,6 +376,9 @@ func (h *ExecutionHandler) AppendLogToExecution(c *gin.Context) {
 		})
 		return
 	}
+	if hasProject {
+		h.meter.RecordLogBytes(project.ID, len(logEntry.Message))
+	}
 
 	c.JSON(http.StatusOK, gin.H{
 		"message": "Log appended successfully",
@@ -210,6 +397,7 @@ func (h *ExecutionHandler) AppendLogToExecution(c *gin.Context) {
 // @Failure      400  {object}  models.ErrorResponse
 // @Failure      404  {object}  models.ErrorResponse
 // @Failure      500  {object}  models.ErrorResponse
+// @Failure      429  {object}  models.ErrorResponse
 // @Router       /executions/{execution_uuid}/status [patch]
 func (h *ExecutionHandler) UpdateExecutionStatus(c *gin.Context) {
 	executionUUID := c.Param("execution_uuid")
@@ -265,20 +453,14 @@ func (h *ExecutionHandler) UpdateExecutionStatus(c *gin.Context) {
 		return
 	}
 
-	// Emit ExecutionFailed event if status is FAILED
-	if models.ExecutionStatus(statusRequest.Status) == models.ExecutionStatusFailed {
+	// Emit ExecutionFailed or ExecutionSucceeded, which open and resolve the task's incidents
+	if status := models.ExecutionStatus(statusRequest.Status); status == models.ExecutionStatusFailed || status == models.ExecutionStatusSuccess {
 		// Fetch execution and task for event payload
 		execution, err := h.repo.GetExecutionByUUID(c.Request.Context(), executionUUID)
 		if err == nil && execution != nil {
 			task, err := h.repo.GetTaskByUUID(c.Request.Context(), execution.TaskUUID)
 			if err == nil && task != nil {
-				h.eventBus.Publish(events.Event{
-					Type: events.ExecutionFailed,
-					Payload: events.ExecutionFailedPayload{
-						Execution: execution,
-						Task:      task,
-					},
-				})
+				publishExecutionFinished(h.eventBus, execution, task)
 			}
 		}
 	}
@@ -289,6 +471,137 @@ func (h *ExecutionHandler) UpdateExecutionStatus(c *gin.Context) {
 	})
 }
 
+// CreateClientExecution opens an execution for a run the scheduler did not trigger
+// @Summary      Report an externally scheduled run
+// @Description  Open an execution for a run scheduled outside the tool (e.g. system cron or Kubernetes CronJob), so the tool can monitor it. Report logs and status on the returned execution as usual, or send SUCCESS or FAILED to record a finished run in one call. Requires a non read-only API key of the task's project. Disabled tasks accept reports, so a task can be monitored without being triggered.
+// @Tags         executions
+// @Accept       json
+// @Produce      json
+// @Param        task_uuid path string true "Task UUID"
+// @Param        execution body models.CreateClientExecutionRequest false "Initial execution state"
+// @Success      201  {object}  models.Execution
+// @Failure      400  {object}  models.ErrorResponse
+// @Failure      401  {object}  models.ErrorResponse
+// @Failure      403  {object}  models.ErrorResponse
+// @Failure      404  {object}  models.ErrorResponse
+// @Failure      409  {object}  models.ErrorResponse
+// @Failure      500  {object}  models.ErrorResponse
+// @Failure      429  {object}  models.ErrorResponse
+// @Router       /tasks/{task_uuid}/executions [post]
+func (h *ExecutionHandler) CreateClientExecution(c *gin.Context) {
+	task, ok := middleware.GetTaskFromContext(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "Authorization header required",
+		})
+		return
+	}
+	project, ok := middleware.GetProjectFromContext(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "Authorization header required",
+		})
+		return
+	}
+
+	// The body is optional; an empty body opens a RUNNING execution starting now
+	var req models.CreateClientExecutionRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			utils.HandleValidationError(c, err)
+			return
+		}
+	}
+
+	if task.Status == models.TaskStatusPendingDelete || task.Status == models.TaskStatusDeleteFailed {
+		c.JSON(http.StatusConflict, gin.H{
+			"error": "Task is being deleted",
+		})
+		return
+	}
+	if project.Status == models.ProjectStatusArchived || project.Status == models.ProjectStatusPendingDelete {
+		c.JSON(http.StatusConflict, gin.H{
+			"error": "Executions cannot be reported for an archived or deleted project",
+		})
+		return
+	}
+
+	if err := h.quotas.CheckExecution(c.Request.Context(), project); err != nil {
+		if !respondQuotaExceeded(c, err) {
+			log.Printf("Failed to check execution quota of project %s: %v", project.UUID, err)
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "Failed to check project quotas",
+			})
+		}
+		return
+	}
+
+	now := time.Now()
+	startedAt := now
+	if req.StartedAt != nil {
+		if req.StartedAt.After(now) {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "started_at cannot be in the future",
+			})
+			return
+		}
+		startedAt = *req.StartedAt
+	}
+
+	status := req.Status
+	if status == "" {
+		status = models.ExecutionStatusRunning
+	}
+
+	execution := &models.Execution{
+		ID:        primitive.NewObjectID(),
+		UUID:      uuid.New().String(),
+		TaskID:    task.ID,
+		TaskUUID:  task.UUID,
+		Status:    status,
+		StartedAt: startedAt,
+		CreatedAt: now,
+		UpdatedAt: now,
+		Source:    models.ExecutionSourceClient,
+	}
+	if status != models.ExecutionStatusRunning {
+		execution.EndedAt = &now
+	}
+	if status == models.ExecutionStatusFailed {
+		execution.Error = req.Error
+	}
+
+	if err := h.repo.CreateExecution(c.Request.Context(), execution); err != nil {
+		log.Printf("Failed to create client execution for task %s: %v", task.UUID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to create execution",
+		})
+		return
+	}
+
+	publishExecutionFinished(h.eventBus, execution, task)
+
+	log.Printf("Client execution opened: task=%s, execution=%s, status=%s", task.UUID, execution.UUID, status)
+	c.JSON(http.StatusCreated, execution)
+}
+
+// publishExecutionFinished publishes ExecutionFailed or ExecutionSucceeded for an execution that finished with
+// that status; other statuses publish nothing
+func publishExecutionFinished(eventBus *events.EventBus, execution *models.Execution, task *models.Task) {
+	switch execution.Status {
+	case models.ExecutionStatusFailed:
+		events.Publish(eventBus, events.ExecutionFailedTopic, events.ExecutionFailedPayload{
+			Execution: execution,
+			Task:      task,
+		})
+	case models.ExecutionStatusSuccess:
+		events.Publish(eventBus, events.ExecutionSucceededTopic, events.ExecutionSucceededPayload{
+			Execution: execution,
+			Task:      task,
+		})
+	}
+}
+
 // GetFailedExecutionsStats retrieves failure statistics for a project
 // @Summary      Get failure statistics for a project
 // @Description  Retrieve failed executions grouped by date for the last N days
@@ -299,6 +612,7 @@ func (h *ExecutionHandler) UpdateExecutionStatus(c *gin.Context) {
 // @Param        days query int false "Number of days to look back (default: 7)"
 // @Success      200  {object}  models.FailedExecutionsStatsResponse
 // @Failure      400  {object}  models.ErrorResponse
+// @Failure      403  {object}  models.ErrorResponse
 // @Failure      500  {object}  models.ErrorResponse
 // @Router       /projects/{project_id}/executions/failed-stats [get]
 func (h *ExecutionHandler) GetFailedExecutionsStats(c *gin.Context) {
@@ -319,6 +633,11 @@ func (h *ExecutionHandler) GetFailedExecutionsStats(c *gin.Context) {
 		return
 	}
 
+	// Check authorization: user must be a member of the project, or super admin
+	if !RequireProjectPermission(c, h.repo, projectID, h.superAdmins, PermissionViewProject) {
+		return
+	}
+
 	// Parse optional days parameter (default: 7, max: 30)
 	days := 7
 	if daysParam := c.Query("days"); daysParam != "" {
@@ -332,7 +651,7 @@ func (h *ExecutionHandler) GetFailedExecutionsStats(c *gin.Context) {
 	}
 
 	// Get failure stats
-	stats, total, err := h.repo.GetFailureStatsByProject(c.Request.Context(), projectID, days)
+	stats, total, err := h.analytics().GetFailureStatsByProject(c.Request.Context(), projectID, days)
 	if err != nil {
 		log.Printf("Failed to get failure stats for project %s: %v", projectIDParam, err)
 		c.JSON(http.StatusInternalServerError, gin.H{
@@ -365,6 +684,7 @@ func (h *ExecutionHandler) GetFailedExecutionsStats(c *gin.Context) {
 // @Param        days query int false "Number of days to look back (default: 7)"
 // @Success      200  {object}  models.ExecutionStatsResponse
 // @Failure      400  {object}  models.ErrorResponse
+// @Failure      403  {object}  models.ErrorResponse
 // @Failure      500  {object}  models.ErrorResponse
 // @Router       /projects/{project_id}/executions/stats [get]
 func (h *ExecutionHandler) GetExecutionStats(c *gin.Context) {
@@ -385,6 +705,11 @@ func (h *ExecutionHandler) GetExecutionStats(c *gin.Context) {
 		return
 	}
 
+	// Check authorization: user must be a member of the project, or super admin
+	if !RequireProjectPermission(c, h.repo, projectID, h.superAdmins, PermissionViewProject) {
+		return
+	}
+
 	// Parse optional days parameter (default: 7, max: 30)
 	days := 7
 	if daysParam := c.Query("days"); daysParam != "" {
@@ -398,7 +723,7 @@ func (h *ExecutionHandler) GetExecutionStats(c *gin.Context) {
 	}
 
 	// Get execution stats
-	stats, err := h.repo.GetExecutionStatsByProject(c.Request.Context(), projectID, days)
+	stats, err := h.analytics().GetExecutionStatsByProject(c.Request.Context(), projectID, days)
 	if err != nil {
 		log.Printf("Failed to get execution stats for project %s: %v", projectIDParam, err)
 		c.JSON(http.StatusInternalServerError, gin.H{
@@ -430,6 +755,7 @@ func (h *ExecutionHandler) GetExecutionStats(c *gin.Context) {
 // @Param        date query string true "Date in YYYY-MM-DD format"
 // @Success      200  {array}  models.TaskFailureStats
 // @Failure      400  {object}  models.ErrorResponse
+// @Failure      403  {object}  models.ErrorResponse
 // @Failure      500  {object}  models.ErrorResponse
 // @Router       /projects/{project_id}/failures [get]
 func (h *ExecutionHandler) GetTaskFailuresByDate(c *gin.Context) {
@@ -450,6 +776,11 @@ func (h *ExecutionHandler) GetTaskFailuresByDate(c *gin.Context) {
 		return
 	}
 
+	// Check authorization: user must be a member of the project, or super admin
+	if !RequireProjectPermission(c, h.repo, projectID, h.superAdmins, PermissionViewProject) {
+		return
+	}
+
 	// Get date parameter
 	dateParam := c.Query("date")
 	if dateParam == "" {
@@ -469,7 +800,7 @@ func (h *ExecutionHandler) GetTaskFailuresByDate(c *gin.Context) {
 	}
 
 	// Get stored task failure stats (includes calculated_at timestamp)
-	storedStats, err := h.repo.GetStoredTaskFailureStats(c.Request.Context(), projectID, dateParam)
+	storedStats, err := h.analytics().GetStoredTaskFailureStats(c.Request.Context(), projectID, dateParam)
 	if err != nil {
 		log.Printf("Failed to get task failures for project %s on date %s: %v", projectIDParam, dateParam, err)
 		c.JSON(http.StatusInternalServerError, gin.H{
@@ -499,85 +830,3 @@ func (h *ExecutionHandler) GetTaskFailuresByDate(c *gin.Context) {
 	}
 	c.JSON(http.StatusOK, response)
 }
-
-// HandleExecutionTimedOut handles ExecutionTimedOut events
-func (h *ExecutionHandler) HandleExecutionTimedOut(event events.Event) {
-	payload, ok := event.Payload.(events.ExecutionTimedOutPayload)
-	if !ok {
-		log.Printf("Invalid payload for ExecutionTimedOut event")
-		return
-	}
-
-	// Get current execution status to avoid race conditions
-	execution, err := h.repo.GetExecutionByUUID(context.Background(), payload.ExecutionUUID)
-	if err != nil {
-		log.Printf("Failed to get execution for timeout handling: %v", err)
-		return
-	}
-
-	// Only mark as failed if still pending or running
-	if execution.Status != models.ExecutionStatusPending &&
-		execution.Status != models.ExecutionStatusRunning {
-		// Execution already completed, ignore timeout
-		return
-	}
-
-	// Add timeout log entry first
-	logEntry := models.LogEntry{
-		Message:   "Task timed out",
-		Level:     "error",
-		Timestamp: time.Now(),
-	}
-	if err := h.repo.AppendLogToExecution(context.Background(), payload.ExecutionUUID, logEntry); err != nil {
-		log.Printf("Failed to add timeout log: %v", err)
-	}
-
-	// Mark execution as failed
-	timeoutError := fmt.Sprintf("Execution timed out after %d seconds", payload.TimeoutSeconds)
-	err = h.repo.UpdateExecutionStatus(
-		context.Background(),
-		payload.ExecutionUUID,
-		models.ExecutionStatusFailed,
-		&timeoutError,
-	)
-	if err != nil {
-		log.Printf("Failed to mark execution as failed on timeout: %v", err)
-		return
-	}
-
-	// Emit ExecutionFailed event (for failure stats aggregator)
-	task, err := h.repo.GetTaskByUUID(context.Background(), payload.TaskUUID)
-	if err == nil && task != nil {
-		failedExecution, err := h.repo.GetExecutionByUUID(context.Background(), payload.ExecutionUUID)
-		if err == nil && failedExecution != nil {
-			h.eventBus.Publish(events.Event{
-				Type: events.ExecutionFailed,
-				Payload: events.ExecutionFailedPayload{
-					Execution: failedExecution,
-					Task:      task,
-				},
-			})
-		}
-	}
-}
-
-// Start starts the execution handler event listener
-func (h *ExecutionHandler) Start(ctx context.Context) {
-	executionTimedOutCh := h.eventBus.Subscribe(events.ExecutionTimedOut)
-
-	go func() {
-		for {
-			select {
-			case <-ctx.Done():
-				log.Println("ExecutionHandler context cancelled, stopping event listener")
-				return
-			case event, ok := <-executionTimedOutCh:
-				if !ok {
-					log.Println("ExecutionTimedOut channel closed")
-					return
-				}
-				h.HandleExecutionTimedOut(event)
-			}
-		}
-	}()
-}
@@ -0,0 +1,422 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yourusername/cron-observer/backend/internal/events"
+	"github.com/yourusername/cron-observer/backend/internal/middleware"
+	"github.com/yourusername/cron-observer/backend/internal/models"
+	"github.com/yourusername/cron-observer/backend/mocks"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.uber.org/mock/gomock"
+)
+
+func TestDayRange_FollowsLocalMidnightsAcrossDST(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatalf("Failed to load timezone: %v", err)
+	}
+	london, err := time.LoadLocation("Europe/London")
+	if err != nil {
+		t.Fatalf("Failed to load timezone: %v", err)
+	}
+
+	cases := []struct {
+		name      string
+		date      string
+		loc       *time.Location
+		wantStart time.Time
+		wantHours float64
+	}{
+		{"utc", "2025-03-09", time.UTC, time.Date(2025, 3, 9, 0, 0, 0, 0, time.UTC), 24},
+		{"new york standard time", "2025-01-15", newYork, time.Date(2025, 1, 15, 5, 0, 0, 0, time.UTC), 24},
+		{"new york spring forward", "2025-03-09", newYork, time.Date(2025, 3, 9, 5, 0, 0, 0, time.UTC), 23},
+		{"new york fall back", "2025-11-02", newYork, time.Date(2025, 11, 2, 4, 0, 0, 0, time.UTC), 25},
+		{"london spring forward", "2025-03-30", london, time.Date(2025, 3, 30, 0, 0, 0, 0, time.UTC), 23},
+		{"london fall back", "2025-10-26", london, time.Date(2025, 10, 25, 23, 0, 0, 0, time.UTC), 25},
+	}
+
+	for _, tc := range cases {
+		start, end, err := dayRange(tc.date, tc.loc)
+		if err != nil {
+			t.Errorf("%s: dayRange returned error: %v", tc.name, err)
+			continue
+		}
+		if !start.Equal(tc.wantStart) {
+			t.Errorf("%s: start = %v, want %v", tc.name, start.UTC(), tc.wantStart)
+		}
+		if hours := end.Add(time.Nanosecond).Sub(start).Hours(); hours != tc.wantHours {
+			t.Errorf("%s: day is %v hours long, want %v", tc.name, hours, tc.wantHours)
+		}
+	}
+}
+
+func TestDayRange_RejectsInvalidDate(t *testing.T) {
+	for _, date := range []string{"", "2025-13-01", "09/03/2025", "2025-02-30"} {
+		if _, _, err := dayRange(date, time.UTC); err == nil {
+			t.Errorf("dayRange(%q) expected an error", date)
+		}
+	}
+}
+
+func TestExecutionHandler_GetExecutionsByTaskUUID_UsesTimezone(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	projectID := primitive.NewObjectID()
+	taskUUID := "test-task-uuid"
+
+	cases := []struct {
+		name      string
+		query     string
+		task      *models.Task
+		expect    func(repo *mocks.MockRepository)
+		wantStart time.Time
+		wantEnd   time.Time
+	}{
+		{
+			name:      "tz parameter",
+			query:     "date=2025-03-09&tz=America/New_York",
+			expect:    func(repo *mocks.MockRepository) {},
+			wantStart: time.Date(2025, 3, 9, 5, 0, 0, 0, time.UTC),
+			wantEnd:   time.Date(2025, 3, 10, 4, 0, 0, 0, time.UTC).Add(-time.Nanosecond),
+		},
+		{
+			name:  "task timezone",
+			query: "date=2025-11-02",
+			task: &models.Task{
+				UUID:           taskUUID,
+				ProjectID:      projectID,
+				ScheduleConfig: models.ScheduleConfig{Timezone: "America/New_York"},
+			},
+			expect: func(repo *mocks.MockRepository) {
+				repo.EXPECT().GetProjectSettings(gomock.Any(), projectID).Return(nil, nil)
+			},
+			wantStart: time.Date(2025, 11, 2, 4, 0, 0, 0, time.UTC),
+			wantEnd:   time.Date(2025, 11, 3, 5, 0, 0, 0, time.UTC).Add(-time.Nanosecond),
+		},
+		{
+			name:  "project default timezone",
+			query: "date=2025-03-30",
+			expect: func(repo *mocks.MockRepository) {
+				repo.EXPECT().GetProjectSettings(gomock.Any(), projectID).Return(&models.ProjectSettings{DefaultTimezone: "Europe/London"}, nil)
+			},
+			wantStart: time.Date(2025, 3, 30, 0, 0, 0, 0, time.UTC),
+			wantEnd:   time.Date(2025, 3, 30, 23, 0, 0, 0, time.UTC).Add(-time.Nanosecond),
+		},
+		{
+			name:  "utc fallback",
+			query: "date=2025-03-09",
+			expect: func(repo *mocks.MockRepository) {
+				repo.EXPECT().GetProjectSettings(gomock.Any(), projectID).Return(nil, nil)
+			},
+			wantStart: time.Date(2025, 3, 9, 0, 0, 0, 0, time.UTC),
+			wantEnd:   time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC).Add(-time.Nanosecond),
+		},
+	}
+
+	for _, tc := range cases {
+		repo := mocks.NewMockRepository(ctrl)
+		handler := NewExecutionHandler(repo, events.NewEventBus(1), middleware.NewSuperAdmins([]string{"root@example.com"}))
+		if tc.task == nil {
+			tc.task = &models.Task{UUID: taskUUID, ProjectID: projectID}
+		}
+		repo.EXPECT().GetTaskByUUID(gomock.Any(), taskUUID).Return(tc.task, nil)
+		tc.expect(repo)
+
+		var gotStart, gotEnd *time.Time
+		repo.EXPECT().GetExecutionsByTaskUUIDPaginated(gomock.Any(), taskUUID, gomock.Any(), gomock.Any(), 1, 100).
+			DoAndReturn(func(_ interface{}, _ string, start, end *time.Time, _, _ int) ([]*models.Execution, int64, error) {
+				gotStart, gotEnd = start, end
+				return nil, 0, nil
+			})
+
+		router := setupProjectRouter("root@example.com")
+		router.GET("/projects/:project_id/tasks/:task_uuid/executions", handler.GetExecutionsByTaskUUID)
+
+		req := httptest.NewRequest(http.MethodGet, "/projects/"+projectID.Hex()+"/tasks/"+taskUUID+"/executions?"+tc.query, nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		if w.Code != http.StatusOK {
+			t.Errorf("%s: expected status %d, got %d: %s", tc.name, http.StatusOK, w.Code, w.Body.String())
+			continue
+		}
+		if gotStart == nil || !gotStart.Equal(tc.wantStart) {
+			t.Errorf("%s: start = %v, want %v", tc.name, gotStart, tc.wantStart)
+		}
+		if gotEnd == nil || !gotEnd.Equal(tc.wantEnd) {
+			t.Errorf("%s: end = %v, want %v", tc.name, gotEnd, tc.wantEnd)
+		}
+	}
+}
+
+func TestExecutionHandler_GetExecutionsByTaskUUID_RejectsInvalidTimezone(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	projectID := primitive.NewObjectID()
+	repo := mocks.NewMockRepository(ctrl)
+	repo.EXPECT().GetTaskByUUID(gomock.Any(), "t").Return(&models.Task{UUID: "t", ProjectID: projectID}, nil)
+	handler := NewExecutionHandler(repo, events.NewEventBus(1), middleware.NewSuperAdmins([]string{"root@example.com"}))
+
+	router := setupProjectRouter("root@example.com")
+	router.GET("/projects/:project_id/tasks/:task_uuid/executions", handler.GetExecutionsByTaskUUID)
+
+	req := httptest.NewRequest(http.MethodGet, "/projects/"+projectID.Hex()+"/tasks/t/executions?date=2025-03-09&tz=Mars/Olympus", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status %d, got %d", http.StatusBadRequest, w.Code)
+	}
+}
+
+func TestExecutionHandler_GetExecutionsByTaskUUID_DateRange(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	projectID := primitive.NewObjectID()
+	taskUUID := "test-task-uuid"
+
+	cases := []struct {
+		name      string
+		query     string
+		wantStart *time.Time
+		wantEnd   *time.Time
+	}{
+		{
+			name:      "week of dates in tz",
+			query:     "from=2025-03-03&to=2025-03-09&tz=America/New_York",
+			wantStart: timePtr(time.Date(2025, 3, 3, 5, 0, 0, 0, time.UTC)),
+			wantEnd:   timePtr(time.Date(2025, 3, 10, 4, 0, 0, 0, time.UTC).Add(-time.Nanosecond)),
+		},
+		{
+			name:      "timestamps",
+			query:     "from=2025-03-03T10:00:00Z&to=2025-03-04T02:00:00%2B02:00",
+			wantStart: timePtr(time.Date(2025, 3, 3, 10, 0, 0, 0, time.UTC)),
+			wantEnd:   timePtr(time.Date(2025, 3, 4, 0, 0, 0, 0, time.UTC)),
+		},
+		{
+			name:      "open-ended",
+			query:     "from=2025-03-03T10:00:00Z",
+			wantStart: timePtr(time.Date(2025, 3, 3, 10, 0, 0, 0, time.UTC)),
+		},
+	}
+
+	for _, tc := range cases {
+		repo := mocks.NewMockRepository(ctrl)
+		handler := NewExecutionHandler(repo, events.NewEventBus(1), middleware.NewSuperAdmins([]string{"root@example.com"}))
+		repo.EXPECT().GetTaskByUUID(gomock.Any(), taskUUID).Return(&models.Task{UUID: taskUUID, ProjectID: projectID}, nil)
+
+		var gotStart, gotEnd *time.Time
+		repo.EXPECT().GetExecutionsByTaskUUIDPaginated(gomock.Any(), taskUUID, gomock.Any(), gomock.Any(), 1, 100).
+			DoAndReturn(func(_ interface{}, _ string, start, end *time.Time, _, _ int) ([]*models.Execution, int64, error) {
+				gotStart, gotEnd = start, end
+				return nil, 0, nil
+			})
+
+		router := setupProjectRouter("root@example.com")
+		router.GET("/projects/:project_id/tasks/:task_uuid/executions", handler.GetExecutionsByTaskUUID)
+
+		req := httptest.NewRequest(http.MethodGet, "/projects/"+projectID.Hex()+"/tasks/"+taskUUID+"/executions?"+tc.query, nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		if w.Code != http.StatusOK {
+			t.Errorf("%s: expected status %d, got %d: %s", tc.name, http.StatusOK, w.Code, w.Body.String())
+			continue
+		}
+		if !sameTime(gotStart, tc.wantStart) {
+			t.Errorf("%s: start = %v, want %v", tc.name, gotStart, tc.wantStart)
+		}
+		if !sameTime(gotEnd, tc.wantEnd) {
+			t.Errorf("%s: end = %v, want %v", tc.name, gotEnd, tc.wantEnd)
+		}
+	}
+}
+
+func TestExecutionHandler_GetExecutionsByTaskUUID_RejectsInvalidRange(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	projectID := primitive.NewObjectID()
+	repo := mocks.NewMockRepository(ctrl)
+	repo.EXPECT().GetTaskByUUID(gomock.Any(), "t").Return(&models.Task{UUID: "t", ProjectID: projectID}, nil).AnyTimes()
+	handler := NewExecutionHandler(repo, events.NewEventBus(1), middleware.NewSuperAdmins([]string{"root@example.com"}))
+
+	router := setupProjectRouter("root@example.com")
+	router.GET("/projects/:project_id/tasks/:task_uuid/executions", handler.GetExecutionsByTaskUUID)
+
+	for _, query := range []string{
+		"",
+		"date=2025-03-09&from=2025-03-01T00:00:00Z",
+		"from=2025-03-09T00:00:00Z&to=2025-03-01T00:00:00Z",
+		"from=last-week&tz=UTC",
+		"to=2025-03-32&tz=UTC",
+	} {
+		req := httptest.NewRequest(http.MethodGet, "/projects/"+projectID.Hex()+"/tasks/t/executions?"+query, nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("%q: expected status %d, got %d", query, http.StatusBadRequest, w.Code)
+		}
+	}
+}
+
+func timePtr(t time.Time) *time.Time {
+	return &t
+}
+
+func sameTime(got, want *time.Time) bool {
+	if got == nil || want == nil {
+		return got == want
+	}
+	return got.Equal(*want)
+}
+
+// setupClientExecutionRouter routes POST /tasks/:task_uuid/executions as if TaskAPIKeyMiddleware authorized the task
+func setupClientExecutionRouter(handler *ExecutionHandler, project *models.Project, task *models.Task) *gin.Engine {
+	router := setupRouter()
+	router.POST("/tasks/:task_uuid/executions", func(c *gin.Context) {
+		c.Set(middleware.ProjectContextKey, project)
+		c.Set(middleware.TaskContextKey, task)
+		c.Next()
+	}, handler.CreateClientExecution)
+	return router
+}
+
+func TestExecutionHandler_CreateClientExecution_OpensRunningExecution(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockRepository(ctrl)
+	handler := NewExecutionHandler(repo, events.NewEventBus(1), middleware.NewSuperAdmins([]string{"root@example.com"}))
+
+	project := &models.Project{ID: primitive.NewObjectID()}
+	task := &models.Task{ID: primitive.NewObjectID(), UUID: "task-1", ProjectID: project.ID, Status: models.TaskStatusDisabled}
+
+	repo.EXPECT().CreateExecution(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, execution *models.Execution) error {
+		if execution.TaskUUID != "task-1" || execution.Status != models.ExecutionStatusRunning || execution.Source != models.ExecutionSourceClient {
+			t.Errorf("Unexpected execution %+v", execution)
+		}
+		if execution.UUID == "" || execution.EndedAt != nil {
+			t.Errorf("Expected an open execution with a UUID, got %+v", execution)
+		}
+		return nil
+	})
+
+	req := httptest.NewRequest(http.MethodPost, "/tasks/task-1/executions", nil)
+	w := httptest.NewRecorder()
+	setupClientExecutionRouter(handler, project, task).ServeHTTP(w, req)
+
+	if w.Code != http.StatusCreated {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusCreated, w.Code, w.Body.String())
+	}
+}
+
+func TestExecutionHandler_CreateClientExecution_RecordsFinishedFailure(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockRepository(ctrl)
+	eventBus := events.NewEventBus(10)
+	defer eventBus.Close()
+	failed := eventBus.Subscribe(events.ExecutionFailed)
+	handler := NewExecutionHandler(repo, eventBus, middleware.NewSuperAdmins([]string{"root@example.com"}))
+
+	project := &models.Project{ID: primitive.NewObjectID()}
+	task := &models.Task{ID: primitive.NewObjectID(), UUID: "task-1", ProjectID: project.ID, Status: models.TaskStatusActive}
+	startedAt := time.Date(2025, 1, 15, 2, 0, 0, 0, time.UTC)
+
+	repo.EXPECT().CreateExecution(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, execution *models.Execution) error {
+		if !execution.StartedAt.Equal(startedAt) || execution.EndedAt == nil || execution.Error != "exit status 1" {
+			t.Errorf("Unexpected execution %+v", execution)
+		}
+		return nil
+	})
+
+	body := `{"status": "FAILED", "started_at": "2025-01-15T02:00:00Z", "error": "exit status 1"}`
+	req := httptest.NewRequest(http.MethodPost, "/tasks/task-1/executions", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	setupClientExecutionRouter(handler, project, task).ServeHTTP(w, req)
+
+	if w.Code != http.StatusCreated {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusCreated, w.Code, w.Body.String())
+	}
+
+	select {
+	case event := <-failed:
+		if payload := event.Payload.(events.ExecutionFailedPayload); payload.Task.UUID != "task-1" {
+			t.Errorf("Unexpected event payload %+v", payload)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Expected an ExecutionFailed event")
+	}
+}
+
+func TestExecutionHandler_CreateClientExecution_RejectsInvalidRequests(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	handler := NewExecutionHandler(mocks.NewMockRepository(ctrl), events.NewEventBus(1), middleware.NewSuperAdmins([]string{"root@example.com"}))
+	task := &models.Task{UUID: "task-1", Status: models.TaskStatusActive}
+
+	cases := []struct {
+		name     string
+		project  *models.Project
+		body     string
+		expected int
+	}{
+		{"pending status", &models.Project{}, `{"status": "PENDING"}`, http.StatusBadRequest},
+		{"future start", &models.Project{}, `{"started_at": "` + time.Now().Add(time.Hour).Format(time.RFC3339) + `"}`, http.StatusBadRequest},
+		{"archived project", &models.Project{Status: models.ProjectStatusArchived}, `{}`, http.StatusConflict},
+	}
+
+	for _, tc := range cases {
+		req := httptest.NewRequest(http.MethodPost, "/tasks/task-1/executions", strings.NewReader(tc.body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		setupClientExecutionRouter(handler, tc.project, task).ServeHTTP(w, req)
+
+		if w.Code != tc.expected {
+			t.Errorf("%s: expected status %d, got %d: %s", tc.name, tc.expected, w.Code, w.Body.String())
+		}
+	}
+}
+
+func TestExecutionHandler_GetExecutionStats_UsesAnalyticsRepository(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	projectID := primitive.NewObjectID()
+	repo := mocks.NewMockRepository(ctrl)
+	analyticsRepo := mocks.NewMockRepository(ctrl)
+	analyticsRepo.EXPECT().GetExecutionStatsByProject(gomock.Any(), projectID, 7).
+		Return([]*models.ExecutionStats{{Date: "2025-03-09", Success: 3, Total: 3}}, nil)
+
+	handler := NewExecutionHandler(repo, events.NewEventBus(1), middleware.NewSuperAdmins([]string{"root@example.com"}))
+	handler.SetAnalyticsRepository(analyticsRepo)
+
+	router := setupProjectRouter("root@example.com")
+	router.GET("/projects/:project_id/executions/stats", handler.GetExecutionStats)
+
+	req := httptest.NewRequest(http.MethodGet, "/projects/"+projectID.Hex()+"/executions/stats", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, body = %s", w.Code, w.Body.String())
+	}
+	if !strings.Contains(w.Body.String(), `"2025-03-09"`) {
+		t.Fatalf("stats from the analytics repository missing from %s", w.Body.String())
+	}
+}
@@ -0,0 +1,86 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yourusername/cron-observer/backend/internal/graphapi"
+	"github.com/yourusername/cron-observer/backend/internal/middleware"
+	"github.com/yourusername/cron-observer/backend/internal/models"
+	"github.com/yourusername/cron-observer/backend/internal/repositories"
+	"github.com/yourusername/cron-observer/backend/internal/utils"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// GraphQLHandler serves the read-only GraphQL API
+type GraphQLHandler struct {
+	repo        repositories.Repository
+	superAdmins *middleware.SuperAdmins
+	server      *graphapi.Server
+}
+
+// NewGraphQLHandler creates a new GraphQLHandler
+func NewGraphQLHandler(repo repositories.Repository, superAdmins *middleware.SuperAdmins) *GraphQLHandler {
+	return &GraphQLHandler{
+		repo:        repo,
+		superAdmins: superAdmins,
+		server:      graphapi.NewServer(repo),
+	}
+}
+
+// Query executes a GraphQL query
+// @Summary      Execute a GraphQL query
+// @Description  Query projects, task groups, tasks and executions with field selection and nesting in one request. Results are limited to projects the user can view; projects outside that set resolve to null with a "not found" error.
+// @Tags         graphql
+// @Accept       json
+// @Produce      json
+// @Param        request body models.GraphQLRequest true "GraphQL query and variables"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      400  {object}  models.ErrorResponse
+// @Failure      401  {object}  models.ErrorResponse
+// @Router       /graphql [post]
+func (h *GraphQLHandler) Query(c *gin.Context) {
+	user, exists := middleware.GetUserFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "User not authenticated",
+		})
+		return
+	}
+
+	var req models.GraphQLRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.HandleValidationError(c, err)
+		return
+	}
+
+	viewer := &graphQLViewer{c: c, repo: h.repo, superAdmins: h.superAdmins, user: user}
+	ctx := graphapi.WithViewer(c.Request.Context(), viewer)
+
+	// Errors inside the query are reported in the response body, as GraphQL clients expect
+	response := h.server.Exec(ctx, req.Query, req.OperationName, req.Variables)
+	c.JSON(http.StatusOK, response)
+}
+
+// graphQLViewer authorizes GraphQL queries as the authenticated user, with the same rules as the REST endpoints
+type graphQLViewer struct {
+	c           *gin.Context
+	repo        repositories.Repository
+	superAdmins *middleware.SuperAdmins
+	user        *middleware.UserInfo
+}
+
+// CanViewProject reports whether the user may view the project
+func (v *graphQLViewer) CanViewProject(ctx context.Context, projectID primitive.ObjectID) bool {
+	return ProjectAuthGuard(v.c, v.repo, projectID, v.superAdmins, PermissionViewProject)
+}
+
+// Projects returns all projects for super admins, and otherwise the user's projects and those of the organizations
+// they administer
+func (v *graphQLViewer) Projects(ctx context.Context) ([]*models.Project, error) {
+	if v.user.IsSuperAdmin() || v.superAdmins.Contains(v.user.Email) {
+		return v.repo.GetAllProjects(ctx)
+	}
+	return v.repo.GetUserProjects(ctx, v.user.Email)
+}
@@ -0,0 +1,70 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/yourusername/cron-observer/backend/internal/middleware"
+	"github.com/yourusername/cron-observer/backend/internal/models"
+	"github.com/yourusername/cron-observer/backend/mocks"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.uber.org/mock/gomock"
+)
+
+func TestGraphQLHandler_Query_UsesProjectMembership(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockRepository(ctrl)
+	handler := NewGraphQLHandler(repo, middleware.NewSuperAdmins([]string{}))
+
+	member := &models.Project{
+		ID:           primitive.NewObjectID(),
+		Name:         "Member project",
+		ProjectUsers: []models.ProjectUser{{Email: "viewer@example.com", Role: models.ProjectUserRoleViewer}},
+	}
+	other := &models.Project{ID: primitive.NewObjectID(), Name: "Other project"}
+
+	repo.EXPECT().GetUserProjects(gomock.Any(), "viewer@example.com").Return([]*models.Project{member}, nil)
+	repo.EXPECT().GetProjectByID(gomock.Any(), other.ID).Return(other, nil)
+
+	router := setupProjectRouter("viewer@example.com")
+	router.POST("/graphql", handler.Query)
+
+	body := `{"query": "{ projects { name } project(id: \"` + other.ID.Hex() + `\") { name } }"}`
+	req := httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	if !strings.Contains(w.Body.String(), `"data":{"projects":[{"name":"Member project"}],"project":null}`) {
+		t.Errorf("Expected only the member project, got %s", w.Body.String())
+	}
+	if !strings.Contains(w.Body.String(), `"not found"`) {
+		t.Errorf("Expected a not found error for the other project, got %s", w.Body.String())
+	}
+}
+
+func TestGraphQLHandler_Query_RequiresAuthenticatedUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	handler := NewGraphQLHandler(mocks.NewMockRepository(ctrl), middleware.NewSuperAdmins([]string{}))
+
+	router := setupRouter()
+	router.POST("/graphql", handler.Query)
+
+	req := httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(`{"query": "{ projects { name } }"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("Expected status %d, got %d", http.StatusUnauthorized, w.Code)
+	}
+}
@@ -0,0 +1,302 @@
+package handlers
+
+import (
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yourusername/cron-observer/backend/internal/events"
+	"github.com/yourusername/cron-observer/backend/internal/middleware"
+	"github.com/yourusername/cron-observer/backend/internal/models"
+	"github.com/yourusername/cron-observer/backend/internal/repositories"
+	"github.com/yourusername/cron-observer/backend/internal/utils"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// IncidentHandler lists a project's incidents and lets its members acknowledge, resolve and comment on them
+type IncidentHandler struct {
+	repo        repositories.Repository
+	eventBus    *events.EventBus
+	superAdmins *middleware.SuperAdmins
+}
+
+func NewIncidentHandler(repo repositories.Repository, eventBus *events.EventBus, superAdmins *middleware.SuperAdmins) *IncidentHandler {
+	return &IncidentHandler{
+		repo:        repo,
+		eventBus:    eventBus,
+		superAdmins: superAdmins,
+	}
+}
+
+// ListIncidents retrieves the incidents of a project
+// @Summary      List incidents
+// @Description  Retrieve a project's incidents, most recently opened first. An incident groups the consecutive failures of a task: it is opened by the first failure and resolved by the next successful execution.
+// @Tags         incidents
+// @Accept       json
+// @Produce      json
+// @Param        project_id path string true "Project ID"
+// @Param        status query string false "Filter by status" Enums(OPEN, ACKNOWLEDGED, RESOLVED)
+// @Param        task_uuid query string false "Filter by task UUID"
+// @Param        page query int false "Page number (default: 1)"
+// @Param        page_size query int false "Page size (default: 100, max: 100)"
+// @Success      200  {object}  models.PaginatedIncidentsResponse
+// @Failure      400  {object}  models.ErrorResponse
+// @Failure      403  {object}  models.ErrorResponse
+// @Failure      500  {object}  models.ErrorResponse
+// @Router       /projects/{project_id}/incidents [get]
+func (h *IncidentHandler) ListIncidents(c *gin.Context) {
+	projectID, ok := h.authorize(c, PermissionViewProject)
+	if !ok {
+		return
+	}
+
+	filter := models.IncidentListFilter{TaskUUID: c.Query("task_uuid")}
+	switch status := models.IncidentStatus(c.Query("status")); status {
+	case "", models.IncidentStatusOpen, models.IncidentStatusAcknowledged, models.IncidentStatusResolved:
+		filter.Status = status
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid status. Use OPEN, ACKNOWLEDGED or RESOLVED",
+		})
+		return
+	}
+
+	page, pageSize := parsePagination(c)
+	incidents, totalCount, err := h.repo.ListIncidents(c.Request.Context(), projectID, filter, page, pageSize)
+	if err != nil {
+		log.Printf("Failed to list incidents of project %s: %v", projectID.Hex(), err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to get incidents",
+		})
+		return
+	}
+	if incidents == nil {
+		incidents = []*models.Incident{}
+	}
+
+	totalPages := int((totalCount + int64(pageSize) - 1) / int64(pageSize))
+	if totalPages == 0 {
+		totalPages = 1
+	}
+
+	c.JSON(http.StatusOK, models.PaginatedIncidentsResponse{
+		Data:       incidents,
+		Page:       page,
+		PageSize:   pageSize,
+		TotalCount: totalCount,
+		TotalPages: totalPages,
+	})
+}
+
+// GetIncident retrieves an incident
+// @Summary      Get an incident
+// @Description  Retrieve an incident with its comments
+// @Tags         incidents
+// @Accept       json
+// @Produce      json
+// @Param        project_id path string true "Project ID"
+// @Param        incident_uuid path string true "Incident UUID"
+// @Success      200  {object}  models.Incident
+// @Failure      400  {object}  models.ErrorResponse
+// @Failure      403  {object}  models.ErrorResponse
+// @Failure      404  {object}  models.ErrorResponse
+// @Failure      500  {object}  models.ErrorResponse
+// @Router       /projects/{project_id}/incidents/{incident_uuid} [get]
+func (h *IncidentHandler) GetIncident(c *gin.Context) {
+	projectID, ok := h.authorize(c, PermissionViewProject)
+	if !ok {
+		return
+	}
+
+	incident, err := h.repo.GetIncidentByUUID(c.Request.Context(), projectID, c.Param("incident_uuid"))
+	if err != nil {
+		h.respondIncidentError(c, err, "Failed to get incident")
+		return
+	}
+	c.JSON(http.StatusOK, incident)
+}
+
+// AcknowledgeIncident marks an open incident as being looked into
+// @Summary      Acknowledge an incident
+// @Description  Mark an open incident as being looked into. The project's notification channels are told who acknowledged it, and PagerDuty incidents are acknowledged too.
+// @Tags         incidents
+// @Accept       json
+// @Produce      json
+// @Param        project_id path string true "Project ID"
+// @Param        incident_uuid path string true "Incident UUID"
+// @Success      200  {object}  models.Incident
+// @Failure      400  {object}  models.ErrorResponse
+// @Failure      403  {object}  models.ErrorResponse
+// @Failure      404  {object}  models.ErrorResponse
+// @Failure      409  {object}  models.ErrorResponse "The incident is not open"
+// @Failure      500  {object}  models.ErrorResponse
+// @Router       /projects/{project_id}/incidents/{incident_uuid}/acknowledge [post]
+func (h *IncidentHandler) AcknowledgeIncident(c *gin.Context) {
+	projectID, ok := h.authorize(c, PermissionManageTasks)
+	if !ok {
+		return
+	}
+
+	ctx := c.Request.Context()
+	incidentUUID := c.Param("incident_uuid")
+	incident, err := h.repo.GetIncidentByUUID(ctx, projectID, incidentUUID)
+	if err != nil {
+		h.respondIncidentError(c, err, "Failed to get incident")
+		return
+	}
+	if incident.Status != models.IncidentStatusOpen {
+		c.JSON(http.StatusConflict, gin.H{
+			"error":   "Only open incidents can be acknowledged",
+			"details": "The incident is " + string(incident.Status),
+		})
+		return
+	}
+
+	incident, err = h.repo.AcknowledgeIncident(ctx, projectID, incidentUUID, actorEmail(c), time.Now())
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			// Acknowledged or resolved since it was read
+			c.JSON(http.StatusConflict, gin.H{
+				"error": "Only open incidents can be acknowledged",
+			})
+			return
+		}
+		log.Printf("Failed to acknowledge incident %s of project %s: %v", incidentUUID, projectID.Hex(), err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to acknowledge incident",
+		})
+		return
+	}
+
+	events.Publish(h.eventBus, events.IncidentUpdatedTopic, events.IncidentPayload{Incident: incident})
+	log.Printf("Incident acknowledged: project=%s, incident=%s, by=%s", projectID.Hex(), incident.UUID, incident.AcknowledgedBy)
+	c.JSON(http.StatusOK, incident)
+}
+
+// ResolveIncident resolves an incident by hand
+// @Summary      Resolve an incident
+// @Description  Resolve an open or acknowledged incident without waiting for the task to succeed, e.g. after disabling it. The next failure of the task opens a new incident.
+// @Tags         incidents
+// @Accept       json
+// @Produce      json
+// @Param        project_id path string true "Project ID"
+// @Param        incident_uuid path string true "Incident UUID"
+// @Success      200  {object}  models.Incident
+// @Failure      400  {object}  models.ErrorResponse
+// @Failure      403  {object}  models.ErrorResponse
+// @Failure      404  {object}  models.ErrorResponse
+// @Failure      409  {object}  models.ErrorResponse "The incident is already resolved"
+// @Failure      500  {object}  models.ErrorResponse
+// @Router       /projects/{project_id}/incidents/{incident_uuid}/resolve [post]
+func (h *IncidentHandler) ResolveIncident(c *gin.Context) {
+	projectID, ok := h.authorize(c, PermissionManageTasks)
+	if !ok {
+		return
+	}
+
+	ctx := c.Request.Context()
+	incidentUUID := c.Param("incident_uuid")
+	if _, err := h.repo.GetIncidentByUUID(ctx, projectID, incidentUUID); err != nil {
+		h.respondIncidentError(c, err, "Failed to get incident")
+		return
+	}
+
+	incident, err := h.repo.ResolveIncident(ctx, projectID, incidentUUID, actorEmail(c), time.Now())
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			c.JSON(http.StatusConflict, gin.H{
+				"error": "Incident is already resolved",
+			})
+			return
+		}
+		log.Printf("Failed to resolve incident %s of project %s: %v", incidentUUID, projectID.Hex(), err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to resolve incident",
+		})
+		return
+	}
+
+	events.Publish(h.eventBus, events.IncidentUpdatedTopic, events.IncidentPayload{Incident: incident})
+	log.Printf("Incident resolved: project=%s, incident=%s, by=%s", projectID.Hex(), incident.UUID, incident.ResolvedBy)
+	c.JSON(http.StatusOK, incident)
+}
+
+// AddIncidentComment adds a comment to an incident
+// @Summary      Comment on an incident
+// @Description  Add a note to an incident, e.g. what was found while investigating it. Comments do not send alerts.
+// @Tags         incidents
+// @Accept       json
+// @Produce      json
+// @Param        project_id path string true "Project ID"
+// @Param        incident_uuid path string true "Incident UUID"
+// @Param        comment body models.AddIncidentCommentRequest true "Comment"
+// @Success      201  {object}  models.IncidentComment
+// @Failure      400  {object}  models.ErrorResponse
+// @Failure      403  {object}  models.ErrorResponse
+// @Failure      404  {object}  models.ErrorResponse
+// @Failure      500  {object}  models.ErrorResponse
+// @Router       /projects/{project_id}/incidents/{incident_uuid}/comments [post]
+func (h *IncidentHandler) AddIncidentComment(c *gin.Context) {
+	var req models.AddIncidentCommentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.HandleValidationError(c, err)
+		return
+	}
+
+	projectID, ok := h.authorize(c, PermissionManageTasks)
+	if !ok {
+		return
+	}
+
+	comment := models.IncidentComment{
+		Author:    actorEmail(c),
+		Message:   req.Message,
+		CreatedAt: time.Now(),
+	}
+	if err := h.repo.AddIncidentComment(c.Request.Context(), projectID, c.Param("incident_uuid"), comment); err != nil {
+		h.respondIncidentError(c, err, "Failed to add comment")
+		return
+	}
+
+	c.JSON(http.StatusCreated, comment)
+}
+
+// authorize parses the project_id path parameter and checks the permission, writing the error response and
+// returning false when the request may not proceed
+func (h *IncidentHandler) authorize(c *gin.Context, permission ProjectPermission) (primitive.ObjectID, bool) {
+	projectID, err := primitive.ObjectIDFromHex(c.Param("project_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid project_id format in path",
+		})
+		return primitive.NilObjectID, false
+	}
+	if !RequireProjectPermission(c, h.repo, projectID, h.superAdmins, permission) {
+		return primitive.NilObjectID, false
+	}
+	return projectID, true
+}
+
+// respondIncidentError maps a repository error to 404 for missing incidents and 500 otherwise
+func (h *IncidentHandler) respondIncidentError(c *gin.Context, err error, message string) {
+	if err == mongo.ErrNoDocuments {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Incident not found",
+		})
+		return
+	}
+	log.Printf("%s %s: %v", message, c.Param("incident_uuid"), err)
+	c.JSON(http.StatusInternalServerError, gin.H{
+		"error": message,
+	})
+}
+
+// actorEmail returns the email of the authenticated user, or "an API client" for API key requests
+func actorEmail(c *gin.Context) string {
+	if user, exists := middleware.GetUserFromContext(c); exists {
+		return user.Email
+	}
+	return "an API client"
+}
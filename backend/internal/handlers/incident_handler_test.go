@@ -0,0 +1,143 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/yourusername/cron-observer/backend/internal/events"
+	"github.com/yourusername/cron-observer/backend/internal/middleware"
+	"github.com/yourusername/cron-observer/backend/internal/models"
+	"github.com/yourusername/cron-observer/backend/mocks"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.uber.org/mock/gomock"
+)
+
+func TestIncidentHandler_AcknowledgeIncident_PublishesUpdate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	projectID := primitive.NewObjectID()
+	open := &models.Incident{UUID: "incident-1", ProjectID: projectID, Status: models.IncidentStatusOpen}
+	now := time.Now()
+	acknowledged := &models.Incident{UUID: "incident-1", ProjectID: projectID, Status: models.IncidentStatusAcknowledged, AcknowledgedBy: "root@example.com", AcknowledgedAt: &now}
+
+	repo := mocks.NewMockRepository(ctrl)
+	bus := events.NewEventBus(1)
+	updates := events.Subscribe(bus, events.IncidentUpdatedTopic)
+	handler := NewIncidentHandler(repo, bus, middleware.NewSuperAdmins([]string{"root@example.com"}))
+
+	repo.EXPECT().GetIncidentByUUID(gomock.Any(), projectID, "incident-1").Return(open, nil)
+	repo.EXPECT().AcknowledgeIncident(gomock.Any(), projectID, "incident-1", "root@example.com", gomock.Any()).Return(acknowledged, nil)
+
+	router := setupProjectRouter("root@example.com")
+	router.POST("/api/v1/projects/:project_id/incidents/:incident_uuid/acknowledge", handler.AcknowledgeIncident)
+
+	req, _ := http.NewRequest(http.MethodPost, "/api/v1/projects/"+projectID.Hex()+"/incidents/incident-1/acknowledge", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var body models.Incident
+	if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil || body.Status != models.IncidentStatusAcknowledged {
+		t.Errorf("unexpected response: %s", w.Body.String())
+	}
+	select {
+	case payload := <-updates:
+		if payload.Incident.Status != models.IncidentStatusAcknowledged {
+			t.Errorf("published incident status = %s", payload.Incident.Status)
+		}
+	default:
+		t.Error("IncidentUpdated was not published")
+	}
+}
+
+func TestIncidentHandler_AcknowledgeIncident_RejectsResolvedIncident(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	projectID := primitive.NewObjectID()
+	resolved := &models.Incident{UUID: "incident-1", ProjectID: projectID, Status: models.IncidentStatusResolved}
+
+	repo := mocks.NewMockRepository(ctrl)
+	handler := NewIncidentHandler(repo, events.NewEventBus(1), middleware.NewSuperAdmins([]string{"root@example.com"}))
+
+	repo.EXPECT().GetIncidentByUUID(gomock.Any(), projectID, "incident-1").Return(resolved, nil)
+	repo.EXPECT().AcknowledgeIncident(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+	router := setupProjectRouter("root@example.com")
+	router.POST("/api/v1/projects/:project_id/incidents/:incident_uuid/acknowledge", handler.AcknowledgeIncident)
+
+	req, _ := http.NewRequest(http.MethodPost, "/api/v1/projects/"+projectID.Hex()+"/incidents/incident-1/acknowledge", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusConflict {
+		t.Errorf("Expected status 409, got %d: %s", w.Code, w.Body.String())
+	}
+}
+
+func TestIncidentHandler_ListIncidents_FiltersByStatus(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	projectID := primitive.NewObjectID()
+	repo := mocks.NewMockRepository(ctrl)
+	handler := NewIncidentHandler(repo, events.NewEventBus(1), middleware.NewSuperAdmins([]string{"root@example.com"}))
+
+	filter := models.IncidentListFilter{Status: models.IncidentStatusOpen, TaskUUID: "task-1"}
+	repo.EXPECT().ListIncidents(gomock.Any(), projectID, filter, 1, 100).
+		Return([]*models.Incident{{UUID: "incident-1", Status: models.IncidentStatusOpen}}, int64(1), nil)
+
+	router := setupProjectRouter("root@example.com")
+	router.GET("/api/v1/projects/:project_id/incidents", handler.ListIncidents)
+
+	req, _ := http.NewRequest(http.MethodGet, "/api/v1/projects/"+projectID.Hex()+"/incidents?status=OPEN&task_uuid=task-1", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var body models.PaginatedIncidentsResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil || body.TotalCount != 1 || len(body.Data) != 1 {
+		t.Errorf("unexpected response: %s", w.Body.String())
+	}
+
+	req, _ = http.NewRequest(http.MethodGet, "/api/v1/projects/"+projectID.Hex()+"/incidents?status=CLOSED", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusBadRequest || !strings.Contains(w.Body.String(), "status") {
+		t.Errorf("Expected status 400, got %d: %s", w.Code, w.Body.String())
+	}
+}
+
+func TestIncidentHandler_AddIncidentComment_NotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	projectID := primitive.NewObjectID()
+	repo := mocks.NewMockRepository(ctrl)
+	handler := NewIncidentHandler(repo, events.NewEventBus(1), middleware.NewSuperAdmins([]string{"root@example.com"}))
+
+	repo.EXPECT().AddIncidentComment(gomock.Any(), projectID, "missing", gomock.Any()).Return(mongo.ErrNoDocuments)
+
+	router := setupProjectRouter("root@example.com")
+	router.POST("/api/v1/projects/:project_id/incidents/:incident_uuid/comments", handler.AddIncidentComment)
+
+	req, _ := http.NewRequest(http.MethodPost, "/api/v1/projects/"+projectID.Hex()+"/incidents/missing/comments", strings.NewReader(`{"message":"looking into it"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusNotFound {
+		t.Errorf("Expected status 404, got %d: %s", w.Code, w.Body.String())
+	}
+}
@@ -0,0 +1,285 @@
+package handlers
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yourusername/cron-observer/backend/internal/invite"
+	"github.com/yourusername/cron-observer/backend/internal/middleware"
+	"github.com/yourusername/cron-observer/backend/internal/models"
+	"github.com/yourusername/cron-observer/backend/internal/repositories"
+	"github.com/yourusername/cron-observer/backend/internal/utils"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type InvitationHandler struct {
+	repo          repositories.Repository
+	inviteService *invite.Service
+	superAdmins   *middleware.SuperAdmins
+}
+
+func NewInvitationHandler(repo repositories.Repository, inviteService *invite.Service, superAdmins *middleware.SuperAdmins) *InvitationHandler {
+	return &InvitationHandler{
+		repo:          repo,
+		inviteService: inviteService,
+		superAdmins:   superAdmins,
+	}
+}
+
+// CreateInvitation invites an email address to join a project
+// @Summary      Invite a user to a project
+// @Description  Create a pending invitation for an email address and email them a signed accept link. The invite becomes a project user when accepted.
+// @Tags         invitations
+// @Accept       json
+// @Produce      json
+// @Param        project_id path string true "Project ID"
+// @Param        invitation body models.CreateInvitationRequest true "Invitation request"
+// @Success      201  {object}  models.Invitation
+// @Failure      400  {object}  models.ErrorResponse
+// @Failure      403  {object}  models.ErrorResponse
+// @Failure      404  {object}  models.ErrorResponse
+// @Failure      409  {object}  models.ErrorResponse
+// @Failure      500  {object}  models.ErrorResponse
+// @Router       /projects/{project_id}/invitations [post]
+func (h *InvitationHandler) CreateInvitation(c *gin.Context) {
+	var req models.CreateInvitationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.HandleValidationError(c, err)
+		return
+	}
+
+	projectID, err := primitive.ObjectIDFromHex(c.Param("project_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid project_id format in path",
+		})
+		return
+	}
+
+	// Check authorization: only project admins may invite members
+	if !RequireProjectPermission(c, h.repo, projectID, h.superAdmins, PermissionManageProject) {
+		return
+	}
+
+	project, err := h.repo.GetProjectByID(c.Request.Context(), projectID)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": "Project not found",
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to get project",
+		})
+		return
+	}
+
+	invitedBy := ""
+	if user, exists := middleware.GetUserFromContext(c); exists {
+		invitedBy = user.Email
+	}
+
+	invitation, err := h.inviteService.Invite(c.Request.Context(), project, req.Email, req.Role, invitedBy)
+	if err != nil {
+		switch err {
+		case invite.ErrAlreadyMember:
+			c.JSON(http.StatusConflict, gin.H{
+				"error": "User is already a member of this project",
+			})
+		default:
+			log.Printf("[INVITE] Failed to invite %s to project %s: %v", req.Email, projectID.Hex(), err)
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "Failed to create invitation",
+			})
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, invitation)
+}
+
+// GetInvitationsByProject lists a project's invitations
+// @Summary      List project invitations
+// @Description  List invitations for a project, newest first. Filter with status=PENDING|ACCEPTED|REVOKED|EXPIRED.
+// @Tags         invitations
+// @Accept       json
+// @Produce      json
+// @Param        project_id path string true "Project ID"
+// @Param        status query string false "Invitation status filter"
+// @Success      200  {array}   models.Invitation
+// @Failure      400  {object}  models.ErrorResponse
+// @Failure      403  {object}  models.ErrorResponse
+// @Failure      500  {object}  models.ErrorResponse
+// @Router       /projects/{project_id}/invitations [get]
+func (h *InvitationHandler) GetInvitationsByProject(c *gin.Context) {
+	projectID, err := primitive.ObjectIDFromHex(c.Param("project_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid project_id format in path",
+		})
+		return
+	}
+
+	if !RequireProjectPermission(c, h.repo, projectID, h.superAdmins, PermissionManageProject) {
+		return
+	}
+
+	status := models.InvitationStatus(c.Query("status"))
+	switch status {
+	case "", models.InvitationStatusPending, models.InvitationStatusAccepted, models.InvitationStatusRevoked, models.InvitationStatusExpired:
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid status. Must be one of PENDING, ACCEPTED, REVOKED, EXPIRED",
+		})
+		return
+	}
+
+	invitations, err := h.repo.GetInvitationsByProjectID(c.Request.Context(), projectID, status)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to get invitations for project",
+		})
+		return
+	}
+
+	if invitations == nil {
+		invitations = []*models.Invitation{}
+	}
+
+	c.JSON(http.StatusOK, invitations)
+}
+
+// RevokeInvitation revokes a pending invitation
+// @Summary      Revoke an invitation
+// @Description  Revoke a pending invitation so its token can no longer be accepted
+// @Tags         invitations
+// @Accept       json
+// @Produce      json
+// @Param        project_id path string true "Project ID"
+// @Param        invitation_uuid path string true "Invitation UUID"
+// @Success      204  "No Content"
+// @Failure      400  {object}  models.ErrorResponse
+// @Failure      403  {object}  models.ErrorResponse
+// @Failure      404  {object}  models.ErrorResponse
+// @Failure      409  {object}  models.ErrorResponse
+// @Failure      500  {object}  models.ErrorResponse
+// @Router       /projects/{project_id}/invitations/{invitation_uuid} [delete]
+func (h *InvitationHandler) RevokeInvitation(c *gin.Context) {
+	projectID, err := primitive.ObjectIDFromHex(c.Param("project_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid project_id format in path",
+		})
+		return
+	}
+
+	invitationUUID := c.Param("invitation_uuid")
+	if invitationUUID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "invitation_uuid is required in path",
+		})
+		return
+	}
+
+	if !RequireProjectPermission(c, h.repo, projectID, h.superAdmins, PermissionManageProject) {
+		return
+	}
+
+	project, err := h.repo.GetProjectByID(c.Request.Context(), projectID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Project not found",
+		})
+		return
+	}
+
+	if err := h.inviteService.Revoke(c.Request.Context(), project, invitationUUID); err != nil {
+		switch err {
+		case invite.ErrNotFound, invite.ErrProjectMismatch:
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": "Invitation not found",
+			})
+		case invite.ErrNotPending:
+			c.JSON(http.StatusConflict, gin.H{
+				"error": "Invitation is no longer pending",
+			})
+		default:
+			log.Printf("[INVITE] Failed to revoke invitation %s: %v", invitationUUID, err)
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "Failed to revoke invitation",
+			})
+		}
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// AcceptInvitation accepts an invitation for the authenticated user
+// @Summary      Accept an invitation
+// @Description  Accept an invitation using the signed token from the invite email. The authenticated user's email must match the invited email.
+// @Tags         invitations
+// @Accept       json
+// @Produce      json
+// @Param        invitation body models.AcceptInvitationRequest true "Invitation token"
+// @Success      200  {object}  models.Invitation
+// @Failure      400  {object}  models.ErrorResponse
+// @Failure      401  {object}  models.ErrorResponse
+// @Failure      403  {object}  models.ErrorResponse
+// @Failure      404  {object}  models.ErrorResponse
+// @Failure      409  {object}  models.ErrorResponse
+// @Failure      410  {object}  models.ErrorResponse
+// @Failure      500  {object}  models.ErrorResponse
+// @Router       /invitations/accept [post]
+func (h *InvitationHandler) AcceptInvitation(c *gin.Context) {
+	user, exists := middleware.GetUserFromContext(c)
+	if !exists || user.Email == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "User not authenticated",
+		})
+		return
+	}
+
+	var req models.AcceptInvitationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.HandleValidationError(c, err)
+		return
+	}
+
+	invitation, err := h.inviteService.Accept(c.Request.Context(), req.Token, user.Email)
+	if err != nil {
+		switch err {
+		case invite.ErrInvalidToken:
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "Invalid invitation token",
+			})
+		case invite.ErrNotFound:
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": "Invitation not found",
+			})
+		case invite.ErrNotPending:
+			c.JSON(http.StatusConflict, gin.H{
+				"error": "Invitation is no longer pending",
+			})
+		case invite.ErrExpired:
+			c.JSON(http.StatusGone, gin.H{
+				"error": "Invitation has expired",
+			})
+		case invite.ErrEmailMismatch:
+			c.JSON(http.StatusForbidden, gin.H{
+				"error": "This invitation was sent to a different email address",
+			})
+		default:
+			log.Printf("[INVITE] Failed to accept invitation for %s: %v", user.Email, err)
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "Failed to accept invitation",
+			})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, invitation)
+}
@@ -0,0 +1,78 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yourusername/cron-observer/backend/internal/jsonschema"
+	"github.com/yourusername/cron-observer/backend/internal/repositories"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// loadMetadataSchema returns the project's compiled metadata schema, or nil when the project has none
+func loadMetadataSchema(ctx context.Context, repo repositories.Repository, projectID primitive.ObjectID) (*jsonschema.Schema, error) {
+	settings, err := repo.GetProjectSettings(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get project settings: %w", err)
+	}
+	if !settings.HasMetadataSchema() {
+		return nil, nil
+	}
+
+	schema, err := jsonschema.Compile(settings.MetadataSchema)
+	if err != nil {
+		// Schemas are compiled when saved, so this only happens if the stored document was edited by hand
+		return nil, fmt.Errorf("stored metadata schema is invalid: %w", err)
+	}
+	return schema, nil
+}
+
+// metadataViolations validates task metadata against a schema. A nil schema allows any metadata.
+func metadataViolations(schema *jsonschema.Schema, metadata map[string]interface{}) ([]string, error) {
+	if schema == nil {
+		return nil, nil
+	}
+
+	// Tasks without metadata are validated as an empty object so required properties are enforced
+	var value interface{} = metadata
+	if metadata == nil {
+		value = map[string]interface{}{}
+	}
+
+	errs, err := schema.Validate(value)
+	if err != nil {
+		return nil, err
+	}
+	violations := make([]string, 0, len(errs))
+	for _, validationErr := range errs {
+		violations = append(violations, "metadata"+validationErr.Path+": "+validationErr.Message)
+	}
+	return violations, nil
+}
+
+// requireMetadataMatchesSchema responds with 400 and returns false when the metadata does not match the project's schema
+func requireMetadataMatchesSchema(c *gin.Context, repo repositories.Repository, projectID primitive.ObjectID, metadata map[string]interface{}) bool {
+	schema, err := loadMetadataSchema(c.Request.Context(), repo, projectID)
+	var violations []string
+	if err == nil {
+		violations, err = metadataViolations(schema, metadata)
+	}
+	if err != nil {
+		log.Printf("Failed to validate metadata for project %s: %v", projectID.Hex(), err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to validate metadata",
+		})
+		return false
+	}
+	if len(violations) > 0 {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Metadata does not match the project's metadata schema",
+			"details": violations,
+		})
+		return false
+	}
+	return true
+}
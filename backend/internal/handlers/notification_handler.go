@@ -0,0 +1,460 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yourusername/cron-observer/backend/internal/alert"
+	"github.com/yourusername/cron-observer/backend/internal/middleware"
+	"github.com/yourusername/cron-observer/backend/internal/models"
+	"github.com/yourusername/cron-observer/backend/internal/repositories"
+	"github.com/yourusername/cron-observer/backend/internal/utils"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// TestAlertSender delivers test alerts through a project's notification channels; implemented by alert.Service
+type TestAlertSender interface {
+	SendTestAlert(ctx context.Context, project *models.Project, channel *models.NotificationChannel, requestedBy string) error
+}
+
+// NotificationHandler manages where a project's failure alerts are sent and sends test alerts
+type NotificationHandler struct {
+	repo        repositories.Repository
+	alerts      TestAlertSender
+	superAdmins *middleware.SuperAdmins
+}
+
+func NewNotificationHandler(repo repositories.Repository, alerts TestAlertSender, superAdmins *middleware.SuperAdmins) *NotificationHandler {
+	return &NotificationHandler{
+		repo:        repo,
+		alerts:      alerts,
+		superAdmins: superAdmins,
+	}
+}
+
+// CreateNotificationChannel adds a notification channel to a project
+// @Summary      Create a notification channel
+// @Description  Add a named channel failure alerts are sent to: email (to the listed addresses, or the project users; digest_minutes collects alerts into one email), slack (an incoming webhook URL), webhook (the alert is POSTed as JSON) or pagerduty (an Events API v2 routing_key). Projects without channels email their users.
+// @Tags         notifications
+// @Accept       json
+// @Produce      json
+// @Param        project_id path string true "Project ID"
+// @Param        channel body models.CreateNotificationChannelRequest true "Channel creation request"
+// @Success      201  {object}  models.NotificationChannel
+// @Failure      400  {object}  models.ErrorResponse
+// @Failure      403  {object}  models.ErrorResponse
+// @Failure      404  {object}  models.ErrorResponse
+// @Failure      409  {object}  models.ErrorResponse
+// @Failure      500  {object}  models.ErrorResponse
+// @Router       /projects/{project_id}/notifications/channels [post]
+func (h *NotificationHandler) CreateNotificationChannel(c *gin.Context) {
+	var req models.CreateNotificationChannelRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.HandleValidationError(c, err)
+		return
+	}
+	now := time.Now()
+	channel := models.NotificationChannel{
+		Name:          req.Name,
+		Type:          req.Type,
+		Emails:        req.Emails,
+		URL:           req.URL,
+		RoutingKey:    req.RoutingKey,
+		DigestMinutes: req.DigestMinutes,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+	if msg := validateChannelDestination(channel); msg != "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": msg,
+		})
+		return
+	}
+
+	projectID, err := primitive.ObjectIDFromHex(c.Param("project_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid project_id format in path",
+		})
+		return
+	}
+
+	// Check authorization: only project admins may change where alerts go
+	if !RequireProjectPermission(c, h.repo, projectID, h.superAdmins, PermissionManageProject) {
+		return
+	}
+
+	project, err := h.repo.GetProjectByID(c.Request.Context(), projectID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Project not found",
+		})
+		return
+	}
+	if _, exists := project.FindNotificationChannel(req.Name); exists {
+		c.JSON(http.StatusConflict, gin.H{
+			"error": "A notification channel with this name already exists",
+		})
+		return
+	}
+
+	if err := h.repo.AddNotificationChannel(c.Request.Context(), projectID, channel); err != nil {
+		if err == mongo.ErrNoDocuments {
+			// Lost a race with a concurrent request creating the same name
+			c.JSON(http.StatusConflict, gin.H{
+				"error": "A notification channel with this name already exists",
+			})
+			return
+		}
+		log.Printf("Failed to create notification channel %s for project %s: %v", req.Name, projectID.Hex(), err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to create notification channel",
+		})
+		return
+	}
+
+	log.Printf("Notification channel created: project=%s, channel=%s, type=%s", projectID.Hex(), channel.Name, channel.Type)
+	c.JSON(http.StatusCreated, channel)
+}
+
+// UpdateNotificationChannel replaces a notification channel's type and destination
+// @Summary      Update a notification channel
+// @Description  Replace the type and destination of a notification channel
+// @Tags         notifications
+// @Accept       json
+// @Produce      json
+// @Param        project_id path string true "Project ID"
+// @Param        channel_name path string true "Channel name"
+// @Param        channel body models.UpdateNotificationChannelRequest true "Channel update request"
+// @Success      204  "No Content"
+// @Failure      400  {object}  models.ErrorResponse
+// @Failure      403  {object}  models.ErrorResponse
+// @Failure      404  {object}  models.ErrorResponse
+// @Failure      500  {object}  models.ErrorResponse
+// @Router       /projects/{project_id}/notifications/channels/{channel_name} [put]
+func (h *NotificationHandler) UpdateNotificationChannel(c *gin.Context) {
+	var req models.UpdateNotificationChannelRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.HandleValidationError(c, err)
+		return
+	}
+	channel := models.NotificationChannel{
+		Name:          c.Param("channel_name"),
+		Type:          req.Type,
+		Emails:        req.Emails,
+		URL:           req.URL,
+		RoutingKey:    req.RoutingKey,
+		DigestMinutes: req.DigestMinutes,
+	}
+	if msg := validateChannelDestination(channel); msg != "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": msg,
+		})
+		return
+	}
+
+	projectID, err := primitive.ObjectIDFromHex(c.Param("project_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid project_id format in path",
+		})
+		return
+	}
+
+	if !RequireProjectPermission(c, h.repo, projectID, h.superAdmins, PermissionManageProject) {
+		return
+	}
+
+	if err := h.repo.UpdateNotificationChannel(c.Request.Context(), projectID, channel); err != nil {
+		if err == mongo.ErrNoDocuments {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": "Notification channel not found",
+			})
+			return
+		}
+		log.Printf("Failed to update notification channel %s for project %s: %v", channel.Name, projectID.Hex(), err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to update notification channel",
+		})
+		return
+	}
+
+	log.Printf("Notification channel updated: project=%s, channel=%s, type=%s", projectID.Hex(), channel.Name, channel.Type)
+	c.Status(http.StatusNoContent)
+}
+
+// DeleteNotificationChannel removes a notification channel from a project
+// @Summary      Delete a notification channel
+// @Description  Remove a notification channel. Channels used by alert routes cannot be removed. Once a project has no channels, alerts are emailed to its users.
+// @Tags         notifications
+// @Produce      json
+// @Param        project_id path string true "Project ID"
+// @Param        channel_name path string true "Channel name"
+// @Success      204  "No Content"
+// @Failure      400  {object}  models.ErrorResponse
+// @Failure      403  {object}  models.ErrorResponse
+// @Failure      404  {object}  models.ErrorResponse
+// @Failure      409  {object}  models.ErrorResponse
+// @Failure      500  {object}  models.ErrorResponse
+// @Router       /projects/{project_id}/notifications/channels/{channel_name} [delete]
+func (h *NotificationHandler) DeleteNotificationChannel(c *gin.Context) {
+	projectID, err := primitive.ObjectIDFromHex(c.Param("project_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid project_id format in path",
+		})
+		return
+	}
+
+	if !RequireProjectPermission(c, h.repo, projectID, h.superAdmins, PermissionManageProject) {
+		return
+	}
+
+	project, err := h.repo.GetProjectByID(c.Request.Context(), projectID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Project not found",
+		})
+		return
+	}
+
+	// Refuse to leave routes pointing at a channel that no longer exists
+	name := c.Param("channel_name")
+	inUse := 0
+	for _, route := range project.AlertRoutes {
+		for _, channel := range route.Channels {
+			if channel == name {
+				inUse++
+				break
+			}
+		}
+	}
+	if inUse > 0 {
+		c.JSON(http.StatusConflict, gin.H{
+			"error": fmt.Sprintf("Notification channel is used by %d alert route(s); change the routes first", inUse),
+		})
+		return
+	}
+
+	if err := h.repo.RemoveNotificationChannel(c.Request.Context(), projectID, name); err != nil {
+		if err == mongo.ErrNoDocuments {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": "Notification channel not found",
+			})
+			return
+		}
+		log.Printf("Failed to delete notification channel %s for project %s: %v", name, projectID.Hex(), err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to delete notification channel",
+		})
+		return
+	}
+
+	log.Printf("Notification channel deleted: project=%s, channel=%s", projectID.Hex(), name)
+	c.Status(http.StatusNoContent)
+}
+
+// SetAlertRoutes replaces the alert routes of a project
+// @Summary      Set alert routes
+// @Description  Replace the project's alert routes. Routes are evaluated in order and the first route matching the failed task by tags and severity decides which channels the alert is sent to; a route without tags or severities matches every task. Alerts of tasks no route matches go to every channel. An empty list removes the routes.
+// @Tags         notifications
+// @Accept       json
+// @Produce      json
+// @Param        project_id path string true "Project ID"
+// @Param        routes body models.SetAlertRoutesRequest true "Alert routes"
+// @Success      200  {array}   models.AlertRoute
+// @Failure      400  {object}  models.ErrorResponse
+// @Failure      403  {object}  models.ErrorResponse
+// @Failure      404  {object}  models.ErrorResponse
+// @Failure      500  {object}  models.ErrorResponse
+// @Router       /projects/{project_id}/notifications/routes [put]
+func (h *NotificationHandler) SetAlertRoutes(c *gin.Context) {
+	var req models.SetAlertRoutesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.HandleValidationError(c, err)
+		return
+	}
+
+	projectID, err := primitive.ObjectIDFromHex(c.Param("project_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid project_id format in path",
+		})
+		return
+	}
+
+	if !RequireProjectPermission(c, h.repo, projectID, h.superAdmins, PermissionManageProject) {
+		return
+	}
+
+	ctx := c.Request.Context()
+	project, err := h.repo.GetProjectByID(ctx, projectID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Project not found",
+		})
+		return
+	}
+
+	routes := make([]models.AlertRoute, 0, len(req.Routes))
+	for i, route := range req.Routes {
+		for _, name := range route.Channels {
+			if _, exists := project.FindNotificationChannel(name); !exists {
+				c.JSON(http.StatusBadRequest, gin.H{
+					"error": fmt.Sprintf("Route %d uses unknown notification channel %q", i+1, name),
+				})
+				return
+			}
+		}
+		routes = append(routes, models.AlertRoute{
+			Tags:     models.NormalizeTags(route.Tags),
+			Channels: route.Channels,
+		})
+	}
+
+	if err := h.repo.SetAlertRoutes(ctx, projectID, routes); err != nil {
+		if err == mongo.ErrNoDocuments {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": "Project not found",
+			})
+			return
+		}
+		log.Printf("Failed to set alert routes of project %s: %v", projectID.Hex(), err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to set alert routes",
+		})
+		return
+	}
+
+	log.Printf("Alert routes set: project=%s, routes=%d", projectID.Hex(), len(routes))
+	c.JSON(http.StatusOK, routes)
+}
+
+// SendTestNotification sends a sample alert through a notification channel
+// @Summary      Send a test alert
+// @Description  Send a sample alert through a notification channel to verify its configuration without waiting for a failure. Without a channel, the test is emailed where projects without channels send their alerts: the person on call, or the project users without an on-call schedule.
+// @Tags         notifications
+// @Accept       json
+// @Produce      json
+// @Param        project_id path string true "Project ID"
+// @Param        request body models.TestNotificationRequest false "Channel to test"
+// @Success      200  {object}  models.TestNotificationResponse
+// @Failure      400  {object}  models.ErrorResponse
+// @Failure      403  {object}  models.ErrorResponse
+// @Failure      404  {object}  models.ErrorResponse
+// @Failure      502  {object}  models.ErrorResponse "The channel rejected the alert or could not be reached"
+// @Failure      503  {object}  models.ErrorResponse "Email alerts are not configured on the server"
+// @Router       /projects/{project_id}/notifications/test [post]
+func (h *NotificationHandler) SendTestNotification(c *gin.Context) {
+	var req models.TestNotificationRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			utils.HandleValidationError(c, err)
+			return
+		}
+	}
+
+	projectID, err := primitive.ObjectIDFromHex(c.Param("project_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid project_id format in path",
+		})
+		return
+	}
+
+	if !RequireProjectPermission(c, h.repo, projectID, h.superAdmins, PermissionManageProject) {
+		return
+	}
+
+	ctx := c.Request.Context()
+	project, err := h.repo.GetProjectByID(ctx, projectID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Project not found",
+		})
+		return
+	}
+
+	response := models.TestNotificationResponse{
+		Message: "Test alert sent",
+		Type:    models.NotificationChannelEmail,
+	}
+	var channel *models.NotificationChannel
+	if req.Channel != "" {
+		found, exists := project.FindNotificationChannel(req.Channel)
+		if !exists {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": "Notification channel not found",
+			})
+			return
+		}
+		channel = found
+		response.Channel = found.Name
+		response.Type = found.Type
+	}
+
+	if err := h.alerts.SendTestAlert(ctx, project, channel, actorEmail(c)); err != nil {
+		switch {
+		case errors.Is(err, alert.ErrEmailNotConfigured):
+			c.JSON(http.StatusServiceUnavailable, gin.H{
+				"error": "Email alerts are not configured on this server",
+			})
+		case errors.Is(err, alert.ErrNoRecipients):
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "No email recipients: add emails to the channel or users to the project",
+			})
+		default:
+			log.Printf("Failed to send test alert for project %s via channel %q: %v", projectID.Hex(), req.Channel, err)
+			c.JSON(http.StatusBadGateway, gin.H{
+				"error":   "Failed to send test alert",
+				"details": err.Error(),
+			})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// validateChannelDestination checks that the channel has the destination its type needs and no other, returning an
+// error message
+func validateChannelDestination(channel models.NotificationChannel) string {
+	if channel.Type != models.NotificationChannelEmail {
+		if len(channel.Emails) > 0 {
+			return "emails are only used by email channels"
+		}
+		if channel.DigestMinutes > 0 {
+			return "digest_minutes is only used by email channels"
+		}
+	}
+	if channel.Type != models.NotificationChannelPagerDuty && channel.RoutingKey != "" {
+		return "routing_key is only used by pagerduty channels"
+	}
+
+	switch channel.Type {
+	case models.NotificationChannelEmail, models.NotificationChannelPagerDuty:
+		if channel.URL != "" {
+			return "url is not used by " + string(channel.Type) + " channels"
+		}
+		if channel.Type == models.NotificationChannelPagerDuty && channel.RoutingKey == "" {
+			return "routing_key is required for pagerduty channels"
+		}
+		return ""
+	}
+
+	if channel.URL == "" {
+		return "url is required for " + string(channel.Type) + " channels"
+	}
+	parsed, err := url.Parse(channel.URL)
+	if err != nil || (parsed.Scheme != "https" && parsed.Scheme != "http") || parsed.Host == "" {
+		return "url must be an http or https URL"
+	}
+	return ""
+}
@@ -0,0 +1,170 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/yourusername/cron-observer/backend/internal/alert"
+	"github.com/yourusername/cron-observer/backend/internal/middleware"
+	"github.com/yourusername/cron-observer/backend/internal/models"
+	"github.com/yourusername/cron-observer/backend/mocks"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.uber.org/mock/gomock"
+)
+
+// fakeTestAlertSender records the test alerts it is asked to send
+type fakeTestAlertSender struct {
+	err         error
+	channel     *models.NotificationChannel
+	requestedBy string
+	calls       int
+}
+
+func (f *fakeTestAlertSender) SendTestAlert(ctx context.Context, project *models.Project, channel *models.NotificationChannel, requestedBy string) error {
+	f.calls++
+	f.channel = channel
+	f.requestedBy = requestedBy
+	return f.err
+}
+
+func TestNotificationHandler_SendTestNotification_UsesChosenChannel(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	project := &models.Project{
+		ID:   primitive.NewObjectID(),
+		Name: "billing",
+		NotificationChannels: []models.NotificationChannel{
+			{Name: "ops-email", Type: models.NotificationChannelEmail, Emails: []string{"ops@example.com"}},
+			{Name: "ops-slack", Type: models.NotificationChannelSlack, URL: "https://hooks.slack.com/services/T/B/X"},
+		},
+	}
+	repo := mocks.NewMockRepository(ctrl)
+	repo.EXPECT().GetProjectByID(gomock.Any(), project.ID).Return(project, nil)
+
+	sender := &fakeTestAlertSender{}
+	handler := NewNotificationHandler(repo, sender, middleware.NewSuperAdmins([]string{"root@example.com"}))
+	router := setupProjectRouter("root@example.com")
+	router.POST("/api/v1/projects/:project_id/notifications/test", handler.SendTestNotification)
+
+	req, _ := http.NewRequest(http.MethodPost, "/api/v1/projects/"+project.ID.Hex()+"/notifications/test", strings.NewReader(`{"channel":"ops-slack"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if sender.channel == nil || sender.channel.Name != "ops-slack" || sender.requestedBy != "root@example.com" {
+		t.Errorf("sent via %+v by %q", sender.channel, sender.requestedBy)
+	}
+	var response models.TestNotificationResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if response.Channel != "ops-slack" || response.Type != models.NotificationChannelSlack {
+		t.Errorf("unexpected response: %+v", response)
+	}
+}
+
+func TestNotificationHandler_SendTestNotification_MapsDeliveryErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{"email not configured", alert.ErrEmailNotConfigured, http.StatusServiceUnavailable},
+		{"no recipients", alert.ErrNoRecipients, http.StatusBadRequest},
+		{"channel rejected", errors.New("channel responded with status 404"), http.StatusBadGateway},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			project := &models.Project{ID: primitive.NewObjectID(), Name: "billing"}
+			repo := mocks.NewMockRepository(ctrl)
+			repo.EXPECT().GetProjectByID(gomock.Any(), project.ID).Return(project, nil)
+
+			sender := &fakeTestAlertSender{err: tt.err}
+			handler := NewNotificationHandler(repo, sender, middleware.NewSuperAdmins([]string{"root@example.com"}))
+			router := setupProjectRouter("root@example.com")
+			router.POST("/api/v1/projects/:project_id/notifications/test", handler.SendTestNotification)
+
+			// Without a body the project users are emailed
+			req, _ := http.NewRequest(http.MethodPost, "/api/v1/projects/"+project.ID.Hex()+"/notifications/test", nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.want {
+				t.Errorf("Expected status %d, got %d: %s", tt.want, w.Code, w.Body.String())
+			}
+			if sender.calls != 1 || sender.channel != nil {
+				t.Errorf("sent %d test alerts via %+v, want one to the project users", sender.calls, sender.channel)
+			}
+		})
+	}
+}
+
+func TestNotificationHandler_CreateNotificationChannel_RequiresURLForSlack(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockRepository(ctrl)
+	repo.EXPECT().AddNotificationChannel(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+	handler := NewNotificationHandler(repo, &fakeTestAlertSender{}, middleware.NewSuperAdmins([]string{"root@example.com"}))
+	router := setupValidatedRouter(t, "root@example.com")
+	router.POST("/api/v1/projects/:project_id/notifications/channels", handler.CreateNotificationChannel)
+
+	url := "/api/v1/projects/" + primitive.NewObjectID().Hex() + "/notifications/channels"
+	req, _ := http.NewRequest(http.MethodPost, url, strings.NewReader(`{"name":"ops-slack","type":"slack"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusBadRequest || !strings.Contains(w.Body.String(), "url is required") {
+		t.Errorf("Expected status 400, got %d: %s", w.Code, w.Body.String())
+	}
+}
+
+func TestNotificationHandler_SetAlertRoutes_RejectsUnknownChannel(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	project := &models.Project{
+		ID:                   primitive.NewObjectID(),
+		NotificationChannels: []models.NotificationChannel{{Name: "pagerduty", Type: models.NotificationChannelPagerDuty, RoutingKey: "key"}},
+	}
+	repo := mocks.NewMockRepository(ctrl)
+	repo.EXPECT().GetProjectByID(gomock.Any(), project.ID).Return(project, nil).Times(2)
+	repo.EXPECT().SetAlertRoutes(gomock.Any(), project.ID, []models.AlertRoute{
+		{Tags: []string{"critical"}, Channels: []string{"pagerduty"}},
+	}).Return(nil)
+
+	handler := NewNotificationHandler(repo, &fakeTestAlertSender{}, middleware.NewSuperAdmins([]string{"root@example.com"}))
+	router := setupValidatedRouter(t, "root@example.com")
+	router.PUT("/api/v1/projects/:project_id/notifications/routes", handler.SetAlertRoutes)
+
+	put := func(body string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest(http.MethodPut, "/api/v1/projects/"+project.ID.Hex()+"/notifications/routes", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	if w := put(`{"routes":[{"tags":["critical"],"channels":["pagerduty"]},{"channels":["email-digest"]}]}`); w.Code != http.StatusBadRequest || !strings.Contains(w.Body.String(), "email-digest") {
+		t.Errorf("Expected status 400, got %d: %s", w.Code, w.Body.String())
+	}
+	// Tags are de-duplicated like task tags
+	if w := put(`{"routes":[{"tags":["critical","critical"],"channels":["pagerduty"]}]}`); w.Code != http.StatusOK {
+		t.Errorf("Expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+}
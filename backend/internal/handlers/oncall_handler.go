@@ -0,0 +1,210 @@
+package handlers
+
+import (
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yourusername/cron-observer/backend/internal/middleware"
+	"github.com/yourusername/cron-observer/backend/internal/models"
+	"github.com/yourusername/cron-observer/backend/internal/repositories"
+	"github.com/yourusername/cron-observer/backend/internal/utils"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// OnCallHandler manages a project's on-call rotation, which decides who is emailed about failures
+type OnCallHandler struct {
+	repo        repositories.Repository
+	superAdmins *middleware.SuperAdmins
+}
+
+func NewOnCallHandler(repo repositories.Repository, superAdmins *middleware.SuperAdmins) *OnCallHandler {
+	return &OnCallHandler{
+		repo:        repo,
+		superAdmins: superAdmins,
+	}
+}
+
+// GetOnCall reports who is on call
+// @Summary      Get the on-call schedule
+// @Description  Retrieve the project's on-call schedule with the participant on call now, or at the given time, and the next handoff.
+// @Tags         oncall
+// @Accept       json
+// @Produce      json
+// @Param        project_id path string true "Project ID"
+// @Param        at query string false "RFC3339 time to report on (default: now)"
+// @Success      200  {object}  models.OnCallStatus
+// @Failure      400  {object}  models.ErrorResponse
+// @Failure      403  {object}  models.ErrorResponse
+// @Failure      404  {object}  models.ErrorResponse "The project or its on-call schedule does not exist"
+// @Router       /projects/{project_id}/oncall [get]
+func (h *OnCallHandler) GetOnCall(c *gin.Context) {
+	at := time.Now()
+	if raw := c.Query("at"); raw != "" {
+		parsed, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "Invalid at. Use RFC3339, e.g. 2025-01-15T10:00:00Z",
+			})
+			return
+		}
+		at = parsed
+	}
+
+	projectID, ok := h.authorize(c, PermissionViewProject)
+	if !ok {
+		return
+	}
+
+	project, err := h.repo.GetProjectByID(c.Request.Context(), projectID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Project not found",
+		})
+		return
+	}
+	if project.OnCall == nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Project has no on-call schedule",
+		})
+		return
+	}
+
+	onCall, override := project.OnCall.OnCallAt(at)
+	c.JSON(http.StatusOK, models.OnCallStatus{
+		Schedule:      project.OnCall,
+		At:            at,
+		OnCall:        onCall,
+		Override:      override,
+		NextHandoffAt: project.OnCall.NextHandoff(at),
+	})
+}
+
+// SetOnCallSchedule replaces the on-call schedule of a project
+// @Summary      Set the on-call schedule
+// @Description  Replace the project's on-call rotation. Participants take turns in the given order for shift_days days each, starting with the first participant at handoff_time on start_date in the schedule's timezone. Overrides put a participant on call for a period regardless of the rotation. While a schedule is set, alerts that would email the project users email the person on call instead.
+// @Tags         oncall
+// @Accept       json
+// @Produce      json
+// @Param        project_id path string true "Project ID"
+// @Param        schedule body models.OnCallSchedule true "On-call schedule"
+// @Success      200  {object}  models.OnCallSchedule
+// @Failure      400  {object}  models.ErrorResponse
+// @Failure      403  {object}  models.ErrorResponse
+// @Failure      404  {object}  models.ErrorResponse
+// @Failure      500  {object}  models.ErrorResponse
+// @Router       /projects/{project_id}/oncall [put]
+func (h *OnCallHandler) SetOnCallSchedule(c *gin.Context) {
+	var schedule models.OnCallSchedule
+	if err := c.ShouldBindJSON(&schedule); err != nil {
+		utils.HandleValidationError(c, err)
+		return
+	}
+	for i := range schedule.Participants {
+		schedule.Participants[i].Email = normalizeEmail(schedule.Participants[i].Email)
+	}
+	for i := range schedule.Overrides {
+		schedule.Overrides[i].Email = normalizeEmail(schedule.Overrides[i].Email)
+	}
+	if msg := validateOnCallSchedule(&schedule); msg != "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": msg,
+		})
+		return
+	}
+
+	projectID, ok := h.authorize(c, PermissionManageProject)
+	if !ok {
+		return
+	}
+
+	schedule.UpdatedAt = time.Now()
+	if err := h.repo.SetOnCallSchedule(c.Request.Context(), projectID, &schedule); err != nil {
+		h.respondProjectError(c, err, "Failed to set on-call schedule")
+		return
+	}
+
+	log.Printf("On-call schedule set: project=%s, participants=%d, overrides=%d", projectID.Hex(), len(schedule.Participants), len(schedule.Overrides))
+	c.JSON(http.StatusOK, schedule)
+}
+
+// DeleteOnCallSchedule removes the on-call schedule of a project
+// @Summary      Delete the on-call schedule
+// @Description  Remove the project's on-call rotation. Alerts are emailed to the project users again.
+// @Tags         oncall
+// @Accept       json
+// @Produce      json
+// @Param        project_id path string true "Project ID"
+// @Success      204
+// @Failure      400  {object}  models.ErrorResponse
+// @Failure      403  {object}  models.ErrorResponse
+// @Failure      404  {object}  models.ErrorResponse
+// @Failure      500  {object}  models.ErrorResponse
+// @Router       /projects/{project_id}/oncall [delete]
+func (h *OnCallHandler) DeleteOnCallSchedule(c *gin.Context) {
+	projectID, ok := h.authorize(c, PermissionManageProject)
+	if !ok {
+		return
+	}
+
+	if err := h.repo.SetOnCallSchedule(c.Request.Context(), projectID, nil); err != nil {
+		h.respondProjectError(c, err, "Failed to delete on-call schedule")
+		return
+	}
+
+	log.Printf("On-call schedule deleted: project=%s", projectID.Hex())
+	c.Status(http.StatusNoContent)
+}
+
+// authorize parses the project_id path parameter and checks the permission, writing the error response and
+// returning false when the request may not proceed
+func (h *OnCallHandler) authorize(c *gin.Context, permission ProjectPermission) (primitive.ObjectID, bool) {
+	projectID, err := primitive.ObjectIDFromHex(c.Param("project_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid project_id format in path",
+		})
+		return primitive.NilObjectID, false
+	}
+	if !RequireProjectPermission(c, h.repo, projectID, h.superAdmins, permission) {
+		return primitive.NilObjectID, false
+	}
+	return projectID, true
+}
+
+// respondProjectError maps a repository error to 404 for missing projects and 500 otherwise
+func (h *OnCallHandler) respondProjectError(c *gin.Context, err error, message string) {
+	if err == mongo.ErrNoDocuments {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Project not found",
+		})
+		return
+	}
+	log.Printf("%s of project %s: %v", message, c.Param("project_id"), err)
+	c.JSON(http.StatusInternalServerError, gin.H{
+		"error": message,
+	})
+}
+
+// validateOnCallSchedule checks what binding tags cannot: that participants are unique and overrides are well
+// formed, returning an error message
+func validateOnCallSchedule(schedule *models.OnCallSchedule) string {
+	seen := make(map[string]bool, len(schedule.Participants))
+	for _, participant := range schedule.Participants {
+		if seen[participant.Email] {
+			return "participant " + participant.Email + " is listed more than once"
+		}
+		seen[participant.Email] = true
+	}
+	for _, override := range schedule.Overrides {
+		if !seen[override.Email] {
+			return "override for " + override.Email + " is not a participant"
+		}
+		if !override.End.After(override.Start) {
+			return "override for " + override.Email + " must end after it starts"
+		}
+	}
+	return ""
+}
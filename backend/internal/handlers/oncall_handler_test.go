@@ -0,0 +1,188 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/yourusername/cron-observer/backend/internal/middleware"
+	"github.com/yourusername/cron-observer/backend/internal/models"
+	"github.com/yourusername/cron-observer/backend/mocks"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.uber.org/mock/gomock"
+)
+
+func TestOnCallHandler_GetOnCall_RotatesAtHandoffInScheduleTimezone(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	projectID := primitive.NewObjectID()
+	project := &models.Project{
+		ID: projectID,
+		OnCall: &models.OnCallSchedule{
+			Participants: []models.OnCallParticipant{
+				{Name: "Alice", Email: "alice@example.com"},
+				{Name: "Bob", Email: "bob@example.com"},
+				{Name: "Carol", Email: "carol@example.com"},
+			},
+			Timezone:    "America/New_York",
+			HandoffTime: "09:00",
+			ShiftDays:   7,
+			StartDate:   "2025-01-06",
+			Overrides: []models.OnCallOverride{{
+				Email: "carol@example.com",
+				Start: time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC),
+				End:   time.Date(2025, 1, 16, 0, 0, 0, 0, time.UTC),
+			}},
+		},
+	}
+
+	repo := mocks.NewMockRepository(ctrl)
+	handler := NewOnCallHandler(repo, middleware.NewSuperAdmins([]string{"root@example.com"}))
+	repo.EXPECT().GetProjectByID(gomock.Any(), projectID).Return(project, nil).AnyTimes()
+
+	router := setupProjectRouter("root@example.com")
+	router.GET("/api/v1/projects/:project_id/oncall", handler.GetOnCall)
+
+	tests := []struct {
+		at           string
+		wantEmail    string
+		wantOverride bool
+		wantHandoff  string
+	}{
+		// 08:59 in New York: the first shift has not been handed over yet
+		{"2025-01-13T13:59:00Z", "alice@example.com", false, "2025-01-13T09:00:00-05:00"},
+		{"2025-01-13T14:00:00Z", "bob@example.com", false, "2025-01-20T09:00:00-05:00"},
+		{"2025-01-15T12:00:00Z", "carol@example.com", true, "2025-01-20T09:00:00-05:00"},
+		{"2025-01-27T14:00:00Z", "alice@example.com", false, "2025-02-03T09:00:00-05:00"},
+		// Before the start date the rotation runs backwards
+		{"2025-01-05T12:00:00Z", "carol@example.com", false, "2025-01-06T09:00:00-05:00"},
+	}
+	for _, tt := range tests {
+		req, _ := http.NewRequest(http.MethodGet, "/api/v1/projects/"+projectID.Hex()+"/oncall?at="+tt.at, nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		if w.Code != http.StatusOK {
+			t.Fatalf("at %s: expected status 200, got %d: %s", tt.at, w.Code, w.Body.String())
+		}
+		var body models.OnCallStatus
+		if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil || body.OnCall == nil {
+			t.Fatalf("at %s: unexpected response: %s", tt.at, w.Body.String())
+		}
+		wantHandoff, _ := time.Parse(time.RFC3339, tt.wantHandoff)
+		if body.OnCall.Email != tt.wantEmail || body.Override != tt.wantOverride || !body.NextHandoffAt.Equal(wantHandoff) {
+			t.Errorf("at %s: on call %s (override %v) until %s, want %s (override %v) until %s",
+				tt.at, body.OnCall.Email, body.Override, body.NextHandoffAt, tt.wantEmail, tt.wantOverride, tt.wantHandoff)
+		}
+	}
+}
+
+func TestOnCallHandler_GetOnCall_NoSchedule(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	projectID := primitive.NewObjectID()
+	repo := mocks.NewMockRepository(ctrl)
+	handler := NewOnCallHandler(repo, middleware.NewSuperAdmins([]string{"root@example.com"}))
+	repo.EXPECT().GetProjectByID(gomock.Any(), projectID).Return(&models.Project{ID: projectID}, nil)
+
+	router := setupProjectRouter("root@example.com")
+	router.GET("/api/v1/projects/:project_id/oncall", handler.GetOnCall)
+
+	req, _ := http.NewRequest(http.MethodGet, "/api/v1/projects/"+projectID.Hex()+"/oncall", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusNotFound || !strings.Contains(w.Body.String(), "on-call schedule") {
+		t.Errorf("Expected status 404, got %d: %s", w.Code, w.Body.String())
+	}
+}
+
+func TestOnCallHandler_SetOnCallSchedule(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	projectID := primitive.NewObjectID()
+	repo := mocks.NewMockRepository(ctrl)
+	handler := NewOnCallHandler(repo, middleware.NewSuperAdmins([]string{"root@example.com"}))
+
+	repo.EXPECT().SetOnCallSchedule(gomock.Any(), projectID, gomock.Any()).DoAndReturn(
+		func(_ interface{}, _ primitive.ObjectID, schedule *models.OnCallSchedule) error {
+			if schedule.Participants[0].Email != "alice@example.com" || schedule.UpdatedAt.IsZero() {
+				t.Errorf("unexpected schedule: %+v", schedule)
+			}
+			return nil
+		})
+
+	router := setupValidatedRouter(t, "root@example.com")
+	router.PUT("/api/v1/projects/:project_id/oncall", handler.SetOnCallSchedule)
+
+	tests := []struct {
+		name       string
+		body       string
+		wantStatus int
+		wantError  string
+	}{
+		{
+			name:       "valid",
+			body:       `{"participants":[{"name":"Alice","email":"Alice@Example.com","phone":"+4915112345678"},{"name":"Bob","email":"bob@example.com"}],"timezone":"Europe/Berlin","handoff_time":"09:00","shift_days":7,"start_date":"2025-01-06"}`,
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "override of a non-participant",
+			body:       `{"participants":[{"name":"Alice","email":"alice@example.com"}],"timezone":"UTC","handoff_time":"09:00","shift_days":1,"start_date":"2025-01-06","overrides":[{"email":"eve@example.com","start":"2025-01-10T00:00:00Z","end":"2025-01-11T00:00:00Z"}]}`,
+			wantStatus: http.StatusBadRequest,
+			wantError:  "not a participant",
+		},
+		{
+			name:       "duplicate participant",
+			body:       `{"participants":[{"name":"Alice","email":"alice@example.com"},{"name":"Alice","email":"ALICE@example.com"}],"timezone":"UTC","handoff_time":"09:00","shift_days":1,"start_date":"2025-01-06"}`,
+			wantStatus: http.StatusBadRequest,
+			wantError:  "more than once",
+		},
+		{
+			name:       "invalid start date",
+			body:       `{"participants":[{"name":"Alice","email":"alice@example.com"}],"timezone":"UTC","handoff_time":"09:00","shift_days":1,"start_date":"06/01/2025"}`,
+			wantStatus: http.StatusBadRequest,
+			wantError:  "YYYY-MM-DD",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, _ := http.NewRequest(http.MethodPut, "/api/v1/projects/"+projectID.Hex()+"/oncall", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus || !strings.Contains(w.Body.String(), tt.wantError) {
+				t.Errorf("Expected status %d with %q, got %d: %s", tt.wantStatus, tt.wantError, w.Code, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestOnCallHandler_DeleteOnCallSchedule_ProjectNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	projectID := primitive.NewObjectID()
+	repo := mocks.NewMockRepository(ctrl)
+	handler := NewOnCallHandler(repo, middleware.NewSuperAdmins([]string{"root@example.com"}))
+	repo.EXPECT().SetOnCallSchedule(gomock.Any(), projectID, (*models.OnCallSchedule)(nil)).Return(mongo.ErrNoDocuments)
+
+	router := setupProjectRouter("root@example.com")
+	router.DELETE("/api/v1/projects/:project_id/oncall", handler.DeleteOnCallSchedule)
+
+	req, _ := http.NewRequest(http.MethodDelete, "/api/v1/projects/"+projectID.Hex()+"/oncall", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusNotFound {
+		t.Errorf("Expected status 404, got %d: %s", w.Code, w.Body.String())
+	}
+}
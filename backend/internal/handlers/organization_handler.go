@@ -0,0 +1,556 @@
+package handlers
+
+import (
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/yourusername/cron-observer/backend/internal/middleware"
+	"github.com/yourusername/cron-observer/backend/internal/models"
+	"github.com/yourusername/cron-observer/backend/internal/repositories"
+	"github.com/yourusername/cron-observer/backend/internal/utils"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// OrganizationHandler manages organizations, which group projects under shared membership. Organization admins
+// manage the organization and are admins of all of its projects; members only see the organization.
+type OrganizationHandler struct {
+	repo        repositories.Repository
+	superAdmins *middleware.SuperAdmins
+}
+
+func NewOrganizationHandler(repo repositories.Repository, superAdmins *middleware.SuperAdmins) *OrganizationHandler {
+	return &OrganizationHandler{
+		repo:        repo,
+		superAdmins: superAdmins,
+	}
+}
+
+// isSuperAdmin reports whether the user is a super admin
+func (h *OrganizationHandler) isSuperAdmin(user *middleware.UserInfo) bool {
+	return user.IsSuperAdmin() || h.superAdmins.Contains(user.Email)
+}
+
+// GetAllOrganizations lists organizations
+// @Summary      List organizations
+// @Description  Super admins get all organizations, other users get the organizations they are members of
+// @Tags         organizations
+// @Produce      json
+// @Success      200  {array}   models.Organization
+// @Failure      401  {object}  models.ErrorResponse
+// @Failure      500  {object}  models.ErrorResponse
+// @Router       /organizations [get]
+func (h *OrganizationHandler) GetAllOrganizations(c *gin.Context) {
+	user, exists := middleware.GetUserFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "User not authenticated",
+		})
+		return
+	}
+
+	var organizations []*models.Organization
+	var err error
+	if h.isSuperAdmin(user) {
+		organizations, err = h.repo.GetAllOrganizations(c.Request.Context())
+	} else {
+		organizations, err = h.repo.GetUserOrganizations(c.Request.Context(), normalizeEmail(user.Email))
+	}
+	if err != nil {
+		log.Printf("[ORGANIZATIONS] Failed to list organizations of %s: %v", user.Email, err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to fetch organizations",
+		})
+		return
+	}
+
+	if organizations == nil {
+		organizations = []*models.Organization{}
+	}
+	c.JSON(http.StatusOK, organizations)
+}
+
+// CreateOrganization creates an organization with the current user as its admin
+// @Summary      Create an organization
+// @Description  Create an organization. The user creating it becomes its first admin.
+// @Tags         organizations
+// @Accept       json
+// @Produce      json
+// @Param        organization body models.CreateOrganizationRequest true "Organization creation request"
+// @Success      201  {object}  models.Organization
+// @Failure      400  {object}  models.ErrorResponse
+// @Failure      401  {object}  models.ErrorResponse
+// @Failure      409  {object}  models.ErrorResponse
+// @Failure      500  {object}  models.ErrorResponse
+// @Router       /organizations [post]
+func (h *OrganizationHandler) CreateOrganization(c *gin.Context) {
+	user, exists := middleware.GetUserFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "User not authenticated",
+		})
+		return
+	}
+
+	var req models.CreateOrganizationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.HandleValidationError(c, err)
+		return
+	}
+
+	now := time.Now()
+	organization := &models.Organization{
+		UUID:        uuid.New().String(),
+		Name:        strings.TrimSpace(req.Name),
+		Description: req.Description,
+		Members: []models.OrganizationMember{
+			{Email: normalizeEmail(user.Email), Role: models.OrganizationRoleAdmin},
+		},
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	if err := h.repo.CreateOrganization(c.Request.Context(), organization); err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			c.JSON(http.StatusConflict, gin.H{
+				"error": "An organization with this name already exists",
+			})
+			return
+		}
+		log.Printf("[ORGANIZATIONS] Failed to create organization %s: %v", organization.Name, err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to create organization",
+		})
+		return
+	}
+
+	log.Printf("[ORGANIZATIONS] %s created organization %s (%s)", user.Email, organization.Name, organization.ID.Hex())
+	c.JSON(http.StatusCreated, organization)
+}
+
+// GetOrganization returns an organization
+// @Summary      Get an organization
+// @Description  Get an organization with its members. Requires organization membership.
+// @Tags         organizations
+// @Produce      json
+// @Param        org_id path string true "Organization ID"
+// @Success      200  {object}  models.Organization
+// @Failure      400  {object}  models.ErrorResponse
+// @Failure      403  {object}  models.ErrorResponse
+// @Failure      404  {object}  models.ErrorResponse
+// @Failure      500  {object}  models.ErrorResponse
+// @Router       /organizations/{org_id} [get]
+func (h *OrganizationHandler) GetOrganization(c *gin.Context) {
+	organization, _, ok := h.requireOrganizationRole(c, models.OrganizationRoleMember)
+	if !ok {
+		return
+	}
+	c.JSON(http.StatusOK, organization)
+}
+
+// UpdateOrganization renames an organization or changes its description
+// @Summary      Update an organization
+// @Description  Change the name or description of an organization. Requires the organization admin role.
+// @Tags         organizations
+// @Accept       json
+// @Produce      json
+// @Param        org_id path string true "Organization ID"
+// @Param        organization body models.UpdateOrganizationRequest true "Organization update request"
+// @Success      200  {object}  models.Organization
+// @Failure      400  {object}  models.ErrorResponse
+// @Failure      403  {object}  models.ErrorResponse
+// @Failure      404  {object}  models.ErrorResponse
+// @Failure      409  {object}  models.ErrorResponse
+// @Failure      500  {object}  models.ErrorResponse
+// @Router       /organizations/{org_id} [put]
+func (h *OrganizationHandler) UpdateOrganization(c *gin.Context) {
+	var req models.UpdateOrganizationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.HandleValidationError(c, err)
+		return
+	}
+
+	organization, _, ok := h.requireOrganizationRole(c, models.OrganizationRoleAdmin)
+	if !ok {
+		return
+	}
+
+	if name := strings.TrimSpace(req.Name); name != "" {
+		organization.Name = name
+	}
+	organization.Description = req.Description
+	h.saveOrganization(c, organization)
+}
+
+// DeleteOrganization deletes an organization without projects
+// @Summary      Delete an organization
+// @Description  Delete an organization. Its projects must be moved out or deleted first. Requires the organization admin role.
+// @Tags         organizations
+// @Produce      json
+// @Param        org_id path string true "Organization ID"
+// @Success      204
+// @Failure      400  {object}  models.ErrorResponse
+// @Failure      403  {object}  models.ErrorResponse
+// @Failure      404  {object}  models.ErrorResponse
+// @Failure      409  {object}  models.ErrorResponse
+// @Failure      500  {object}  models.ErrorResponse
+// @Router       /organizations/{org_id} [delete]
+func (h *OrganizationHandler) DeleteOrganization(c *gin.Context) {
+	organization, _, ok := h.requireOrganizationRole(c, models.OrganizationRoleAdmin)
+	if !ok {
+		return
+	}
+
+	projects, err := h.repo.GetProjectsByOrganizationID(c.Request.Context(), organization.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to fetch organization projects",
+		})
+		return
+	}
+	if len(projects) > 0 {
+		c.JSON(http.StatusConflict, gin.H{
+			"error": "Organization still has projects. Move them out or delete them first.",
+		})
+		return
+	}
+
+	if err := h.repo.DeleteOrganization(c.Request.Context(), organization.ID); err != nil && err != mongo.ErrNoDocuments {
+		log.Printf("[ORGANIZATIONS] Failed to delete organization %s: %v", organization.ID.Hex(), err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to delete organization",
+		})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// SetOrganizationMember adds a member to an organization or changes a member's role
+// @Summary      Add or update an organization member
+// @Description  Add a user to an organization, or change the role of a member. Admins of an organization are admins of all of its projects. Requires the organization admin role.
+// @Tags         organizations
+// @Accept       json
+// @Produce      json
+// @Param        org_id path string true "Organization ID"
+// @Param        member body models.OrganizationMember true "Member"
+// @Success      200  {object}  models.Organization
+// @Failure      400  {object}  models.ErrorResponse
+// @Failure      403  {object}  models.ErrorResponse
+// @Failure      404  {object}  models.ErrorResponse
+// @Failure      409  {object}  models.ErrorResponse
+// @Failure      500  {object}  models.ErrorResponse
+// @Router       /organizations/{org_id}/members [put]
+func (h *OrganizationHandler) SetOrganizationMember(c *gin.Context) {
+	var member models.OrganizationMember
+	if err := c.ShouldBindJSON(&member); err != nil {
+		utils.HandleValidationError(c, err)
+		return
+	}
+	member.Email = normalizeEmail(member.Email)
+
+	organization, _, ok := h.requireOrganizationRole(c, models.OrganizationRoleAdmin)
+	if !ok {
+		return
+	}
+
+	members := make([]models.OrganizationMember, 0, len(organization.Members)+1)
+	found := false
+	for _, existing := range organization.Members {
+		if normalizeEmail(existing.Email) == member.Email {
+			existing.Role = member.Role
+			found = true
+		}
+		members = append(members, existing)
+	}
+	if !found {
+		members = append(members, member)
+	}
+	if !hasOrganizationAdmin(members) {
+		c.JSON(http.StatusConflict, gin.H{
+			"error": "An organization must keep at least one admin",
+		})
+		return
+	}
+
+	organization.Members = members
+	h.saveOrganization(c, organization)
+}
+
+// RemoveOrganizationMember removes a member from an organization
+// @Summary      Remove an organization member
+// @Description  Remove a user from an organization. The last admin cannot be removed. Requires the organization admin role.
+// @Tags         organizations
+// @Produce      json
+// @Param        org_id path string true "Organization ID"
+// @Param        email path string true "Member email"
+// @Success      200  {object}  models.Organization
+// @Failure      400  {object}  models.ErrorResponse
+// @Failure      403  {object}  models.ErrorResponse
+// @Failure      404  {object}  models.ErrorResponse
+// @Failure      409  {object}  models.ErrorResponse
+// @Failure      500  {object}  models.ErrorResponse
+// @Router       /organizations/{org_id}/members/{email} [delete]
+func (h *OrganizationHandler) RemoveOrganizationMember(c *gin.Context) {
+	organization, _, ok := h.requireOrganizationRole(c, models.OrganizationRoleAdmin)
+	if !ok {
+		return
+	}
+
+	email := normalizeEmail(c.Param("email"))
+	members := make([]models.OrganizationMember, 0, len(organization.Members))
+	for _, member := range organization.Members {
+		if normalizeEmail(member.Email) != email {
+			members = append(members, member)
+		}
+	}
+	if len(members) == len(organization.Members) {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "User is not a member of this organization",
+		})
+		return
+	}
+	if !hasOrganizationAdmin(members) {
+		c.JSON(http.StatusConflict, gin.H{
+			"error": "An organization must keep at least one admin",
+		})
+		return
+	}
+
+	organization.Members = members
+	h.saveOrganization(c, organization)
+}
+
+// GetOrganizationProjects lists the projects of an organization
+// @Summary      List organization projects
+// @Description  List the projects of an organization. Organization admins and super admins get all of them, members only the projects they are members of. Requires organization membership.
+// @Tags         organizations
+// @Produce      json
+// @Param        org_id path string true "Organization ID"
+// @Success      200  {array}   models.Project
+// @Failure      400  {object}  models.ErrorResponse
+// @Failure      403  {object}  models.ErrorResponse
+// @Failure      404  {object}  models.ErrorResponse
+// @Failure      500  {object}  models.ErrorResponse
+// @Router       /organizations/{org_id}/projects [get]
+func (h *OrganizationHandler) GetOrganizationProjects(c *gin.Context) {
+	organization, user, ok := h.requireOrganizationRole(c, models.OrganizationRoleMember)
+	if !ok {
+		return
+	}
+
+	projects, err := h.repo.GetProjectsByOrganizationID(c.Request.Context(), organization.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to fetch organization projects",
+		})
+		return
+	}
+
+	// Membership of the organization alone does not grant access to its projects
+	email := normalizeEmail(user.Email)
+	visible := make([]*models.Project, 0, len(projects))
+	for _, project := range projects {
+		if h.isSuperAdmin(user) || organization.IsAdmin(email) || isProjectUser(project, email) {
+			if project.ProjectUsers == nil {
+				project.ProjectUsers = []models.ProjectUser{}
+			}
+			visible = append(visible, project)
+		}
+	}
+	c.JSON(http.StatusOK, visible)
+}
+
+// SetProjectOrganization moves a project into an organization or out of its organization
+// @Summary      Move a project to an organization
+// @Description  Move a project into an organization, or out of its organization when organization_id is empty. Requires the admin role on the project and the admin role in the target organization.
+// @Tags         organizations
+// @Accept       json
+// @Produce      json
+// @Param        project_id path string true "Project ID"
+// @Param        request body models.SetProjectOrganizationRequest true "Target organization"
+// @Success      200  {object}  models.Project
+// @Failure      400  {object}  models.ErrorResponse
+// @Failure      403  {object}  models.ErrorResponse
+// @Failure      404  {object}  models.ErrorResponse
+// @Failure      500  {object}  models.ErrorResponse
+// @Router       /projects/{project_id}/organization [put]
+func (h *OrganizationHandler) SetProjectOrganization(c *gin.Context) {
+	projectID, err := primitive.ObjectIDFromHex(c.Param("project_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid project_id format in path",
+		})
+		return
+	}
+
+	var req models.SetProjectOrganizationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.HandleValidationError(c, err)
+		return
+	}
+
+	if !RequireProjectPermission(c, h.repo, projectID, h.superAdmins, PermissionManageProject) {
+		return
+	}
+
+	var organizationID *primitive.ObjectID
+	if req.OrganizationID != "" {
+		id, err := primitive.ObjectIDFromHex(req.OrganizationID)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "Invalid organization_id format",
+			})
+			return
+		}
+		organization, err := h.repo.GetOrganizationByID(c.Request.Context(), id)
+		if err != nil {
+			if err == mongo.ErrNoDocuments {
+				c.JSON(http.StatusNotFound, gin.H{
+					"error": "Organization not found",
+				})
+				return
+			}
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "Failed to get organization",
+			})
+			return
+		}
+
+		// Otherwise a project admin could hand the project to admins of any organization
+		user, _ := middleware.GetUserFromContext(c)
+		if !h.isSuperAdmin(user) && !organization.IsAdmin(user.Email) {
+			c.JSON(http.StatusForbidden, gin.H{
+				"error": "You do not have permission to perform this action. Admin role in the target organization or super admin access required.",
+			})
+			return
+		}
+		organizationID = &organization.ID
+	}
+
+	if err := h.repo.SetProjectOrganization(c.Request.Context(), projectID, organizationID); err != nil {
+		if err == mongo.ErrNoDocuments {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": "Project not found",
+			})
+			return
+		}
+		log.Printf("[ORGANIZATIONS] Failed to move project %s: %v", projectID.Hex(), err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to update project organization",
+		})
+		return
+	}
+
+	project, err := h.repo.GetProjectByID(c.Request.Context(), projectID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to get project",
+		})
+		return
+	}
+	c.JSON(http.StatusOK, project)
+}
+
+// requireOrganizationRole loads the organization of the org_id path parameter and checks that the user has at
+// least the given role in it. Super admins have every role. Writes the error response and returns false otherwise.
+func (h *OrganizationHandler) requireOrganizationRole(c *gin.Context, role models.OrganizationRole) (*models.Organization, *middleware.UserInfo, bool) {
+	user, exists := middleware.GetUserFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "User not authenticated",
+		})
+		return nil, user, false
+	}
+
+	organizationID, err := primitive.ObjectIDFromHex(c.Param("org_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid org_id format in path",
+		})
+		return nil, user, false
+	}
+
+	organization, err := h.repo.GetOrganizationByID(c.Request.Context(), organizationID)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": "Organization not found",
+			})
+			return nil, user, false
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to get organization",
+		})
+		return nil, user, false
+	}
+
+	if h.isSuperAdmin(user) {
+		return organization, user, true
+	}
+	memberRole, isMember := organization.MemberRole(user.Email)
+	if !isMember || (role == models.OrganizationRoleAdmin && memberRole != models.OrganizationRoleAdmin) {
+		requirement := "Organization membership or super admin access required."
+		if role == models.OrganizationRoleAdmin {
+			requirement = "Organization admin role or super admin access required."
+		}
+		c.JSON(http.StatusForbidden, gin.H{
+			"error": "You do not have permission to perform this action. " + requirement,
+		})
+		return nil, user, false
+	}
+	return organization, user, true
+}
+
+// saveOrganization stores the changed organization and responds with it
+func (h *OrganizationHandler) saveOrganization(c *gin.Context, organization *models.Organization) {
+	organization.UpdatedAt = time.Now()
+	if err := h.repo.UpdateOrganization(c.Request.Context(), organization.ID, organization); err != nil {
+		switch {
+		case err == mongo.ErrNoDocuments:
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": "Organization not found",
+			})
+		case mongo.IsDuplicateKeyError(err):
+			c.JSON(http.StatusConflict, gin.H{
+				"error": "An organization with this name already exists",
+			})
+		default:
+			log.Printf("[ORGANIZATIONS] Failed to update organization %s: %v", organization.ID.Hex(), err)
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "Failed to update organization",
+			})
+		}
+		return
+	}
+	c.JSON(http.StatusOK, organization)
+}
+
+// hasOrganizationAdmin reports whether at least one of the members is an admin
+func hasOrganizationAdmin(members []models.OrganizationMember) bool {
+	for _, member := range members {
+		if member.Role == models.OrganizationRoleAdmin {
+			return true
+		}
+	}
+	return false
+}
+
+// isProjectUser reports whether the email is in the project's project_users
+func isProjectUser(project *models.Project, email string) bool {
+	for _, projectUser := range project.ProjectUsers {
+		if normalizeEmail(projectUser.Email) == email {
+			return true
+		}
+	}
+	return false
+}
+
+// normalizeEmail lower-cases and trims an email for comparisons and storage
+func normalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
@@ -0,0 +1,209 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yourusername/cron-observer/backend/internal/middleware"
+	"github.com/yourusername/cron-observer/backend/internal/models"
+	"github.com/yourusername/cron-observer/backend/mocks"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.uber.org/mock/gomock"
+)
+
+func newTestOrganization() *models.Organization {
+	return &models.Organization{
+		ID:   primitive.NewObjectID(),
+		Name: "Payments",
+		Members: []models.OrganizationMember{
+			{Email: "lead@example.com", Role: models.OrganizationRoleAdmin},
+			{Email: "dev@example.com", Role: models.OrganizationRoleMember},
+		},
+	}
+}
+
+func TestOrganizationHandler_CreateOrganization_CreatorBecomesAdmin(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockRepository(ctrl)
+	handler := NewOrganizationHandler(repo, middleware.NewSuperAdmins([]string{}))
+
+	var created *models.Organization
+	repo.EXPECT().CreateOrganization(gomock.Any(), gomock.Any()).DoAndReturn(func(_ interface{}, organization *models.Organization) error {
+		created = organization
+		return nil
+	})
+
+	router := setupProjectRouter("Lead@Example.com")
+	router.POST("/api/v1/organizations", handler.CreateOrganization)
+
+	req, _ := http.NewRequest(http.MethodPost, "/api/v1/organizations", strings.NewReader(`{"name":" Payments "}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusCreated {
+		t.Fatalf("Expected status 201, got %d: %s", w.Code, w.Body.String())
+	}
+	if created.Name != "Payments" || created.UUID == "" {
+		t.Errorf("unexpected organization: %+v", created)
+	}
+	if !created.IsAdmin("lead@example.com") || len(created.Members) != 1 {
+		t.Errorf("creator is not the only admin: %+v", created.Members)
+	}
+}
+
+func TestOrganizationHandler_SetOrganizationMember_RequiresAdmin(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	organization := newTestOrganization()
+	repo := mocks.NewMockRepository(ctrl)
+	handler := NewOrganizationHandler(repo, middleware.NewSuperAdmins([]string{}))
+
+	repo.EXPECT().GetOrganizationByID(gomock.Any(), organization.ID).Return(organization, nil).AnyTimes()
+	repo.EXPECT().UpdateOrganization(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+	router := setupProjectRouter("dev@example.com")
+	router.PUT("/api/v1/organizations/:org_id/members", handler.SetOrganizationMember)
+
+	body := `{"email":"dev@example.com","role":"admin"}`
+	req, _ := http.NewRequest(http.MethodPut, "/api/v1/organizations/"+organization.ID.Hex()+"/members", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusForbidden {
+		t.Errorf("Expected status 403, got %d: %s", w.Code, w.Body.String())
+	}
+}
+
+func TestOrganizationHandler_RemoveOrganizationMember_KeepsLastAdmin(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	organization := newTestOrganization()
+	repo := mocks.NewMockRepository(ctrl)
+	handler := NewOrganizationHandler(repo, middleware.NewSuperAdmins([]string{}))
+
+	repo.EXPECT().GetOrganizationByID(gomock.Any(), organization.ID).Return(organization, nil).AnyTimes()
+	repo.EXPECT().UpdateOrganization(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+	router := setupProjectRouter("lead@example.com")
+	router.DELETE("/api/v1/organizations/:org_id/members/:email", handler.RemoveOrganizationMember)
+
+	req, _ := http.NewRequest(http.MethodDelete, "/api/v1/organizations/"+organization.ID.Hex()+"/members/lead@example.com", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusConflict {
+		t.Errorf("Expected status 409, got %d: %s", w.Code, w.Body.String())
+	}
+}
+
+func TestOrganizationHandler_GetOrganizationProjects_MembersSeeTheirProjects(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	organization := newTestOrganization()
+	projects := []*models.Project{
+		{ID: primitive.NewObjectID(), Name: "billing", OrganizationID: &organization.ID, ProjectUsers: []models.ProjectUser{{Email: "dev@example.com", Role: models.ProjectUserRoleEditor}}},
+		{ID: primitive.NewObjectID(), Name: "ledger", OrganizationID: &organization.ID},
+	}
+
+	repo := mocks.NewMockRepository(ctrl)
+	handler := NewOrganizationHandler(repo, middleware.NewSuperAdmins([]string{}))
+
+	repo.EXPECT().GetOrganizationByID(gomock.Any(), organization.ID).Return(organization, nil).AnyTimes()
+	repo.EXPECT().GetProjectsByOrganizationID(gomock.Any(), organization.ID).Return(projects, nil).AnyTimes()
+
+	list := func(email string) []models.Project {
+		router := setupProjectRouter(email)
+		router.GET("/api/v1/organizations/:org_id/projects", handler.GetOrganizationProjects)
+		req, _ := http.NewRequest(http.MethodGet, "/api/v1/organizations/"+organization.ID.Hex()+"/projects", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		if w.Code != http.StatusOK {
+			t.Fatalf("Expected status 200 for %s, got %d: %s", email, w.Code, w.Body.String())
+		}
+		var listed []models.Project
+		if err := json.Unmarshal(w.Body.Bytes(), &listed); err != nil {
+			t.Fatalf("Failed to decode response: %v", err)
+		}
+		return listed
+	}
+
+	if listed := list("lead@example.com"); len(listed) != 2 {
+		t.Errorf("admin got %d projects, want 2", len(listed))
+	}
+	if listed := list("dev@example.com"); len(listed) != 1 || listed[0].Name != "billing" {
+		t.Errorf("member got %+v, want only billing", listed)
+	}
+}
+
+func TestProjectAuthGuard_OrganizationAdminIsProjectAdmin(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	organization := newTestOrganization()
+	project := &models.Project{ID: primitive.NewObjectID(), Name: "billing", OrganizationID: &organization.ID}
+
+	repo := mocks.NewMockRepository(ctrl)
+	repo.EXPECT().GetProjectByID(gomock.Any(), project.ID).Return(project, nil).AnyTimes()
+	repo.EXPECT().GetOrganizationByID(gomock.Any(), organization.ID).Return(organization, nil).AnyTimes()
+
+	tests := map[string]bool{
+		"lead@example.com": true,  // organization admin
+		"dev@example.com":  false, // organization member without a project role
+	}
+	for email, want := range tests {
+		router := setupProjectRouter(email)
+		router.Use(ProjectPermissionMiddleware(repo, middleware.NewSuperAdmins([]string{}), PermissionManageProject))
+		router.GET("/api/v1/projects/:project_id", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+		req, _ := http.NewRequest(http.MethodGet, "/api/v1/projects/"+project.ID.Hex(), nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		if got := w.Code == http.StatusOK; got != want {
+			t.Errorf("%s: status %d, want access %v", email, w.Code, want)
+		}
+	}
+}
+
+func TestOrganizationHandler_SetProjectOrganization_RequiresTargetOrganizationAdmin(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	organization := newTestOrganization()
+	project := &models.Project{
+		ID:           primitive.NewObjectID(),
+		Name:         "billing",
+		ProjectUsers: []models.ProjectUser{{Email: "owner@example.com", Role: models.ProjectUserRoleAdmin}},
+	}
+
+	repo := mocks.NewMockRepository(ctrl)
+	handler := NewOrganizationHandler(repo, middleware.NewSuperAdmins([]string{}))
+
+	repo.EXPECT().GetProjectByID(gomock.Any(), project.ID).Return(project, nil).AnyTimes()
+	repo.EXPECT().GetOrganizationByID(gomock.Any(), organization.ID).Return(organization, nil).AnyTimes()
+	repo.EXPECT().SetProjectOrganization(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+	router := setupProjectRouter("owner@example.com")
+	router.PUT("/api/v1/projects/:project_id/organization", handler.SetProjectOrganization)
+
+	body := `{"organization_id":"` + organization.ID.Hex() + `"}`
+	req, _ := http.NewRequest(http.MethodPut, "/api/v1/projects/"+project.ID.Hex()+"/organization", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusForbidden {
+		t.Errorf("Expected status 403, got %d: %s", w.Code, w.Body.String())
+	}
+}
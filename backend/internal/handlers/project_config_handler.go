@@ -0,0 +1,553 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/yourusername/cron-observer/backend/internal/events"
+	"github.com/yourusername/cron-observer/backend/internal/middleware"
+	"github.com/yourusername/cron-observer/backend/internal/models"
+	"github.com/yourusername/cron-observer/backend/internal/quota"
+	"github.com/yourusername/cron-observer/backend/internal/repositories"
+	"github.com/yourusername/cron-observer/backend/internal/utils"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// ProjectConfigHandler exports and imports a project's task groups and tasks as declarative documents
+type ProjectConfigHandler struct {
+	repo        repositories.Repository
+	eventBus    *events.EventBus
+	superAdmins *middleware.SuperAdmins
+
+	analyticsRepo repositories.Repository // Serves exports; may read from secondaries
+	quotas        *quota.Service          // optional; nil enforces no quotas
+}
+
+func NewProjectConfigHandler(repo repositories.Repository, eventBus *events.EventBus, superAdmins *middleware.SuperAdmins) *ProjectConfigHandler {
+	return &ProjectConfigHandler{
+		repo:        repo,
+		eventBus:    eventBus,
+		superAdmins: superAdmins,
+	}
+}
+
+// SetAnalyticsRepository routes the reads of exports to a repository that may read from secondaries
+func (h *ProjectConfigHandler) SetAnalyticsRepository(repo repositories.Repository) {
+	h.analyticsRepo = repo
+}
+
+// SetQuotaService enforces the project task quota on imports
+func (h *ProjectConfigHandler) SetQuotaService(quotas *quota.Service) {
+	h.quotas = quotas
+}
+
+// analytics returns the repository for exports
+func (h *ProjectConfigHandler) analytics() repositories.Repository {
+	if h.analyticsRepo != nil {
+		return h.analyticsRepo
+	}
+	return h.repo
+}
+
+// ExportProjectConfig exports a project's task groups and tasks
+// @Summary      Export project configuration
+// @Description  Export the project's task groups and tasks as a declarative JSON or YAML document that can be re-imported into this or another project
+// @Tags         projects
+// @Accept       json
+// @Produce      json,x-yaml
+// @Param        project_id path string true "Project ID"
+// @Param        format query string false "Document format (default: json)" Enums(json, yaml)
+// @Success      200  {object}  models.ProjectConfig
+// @Failure      400  {object}  models.ErrorResponse
+// @Failure      403  {object}  models.ErrorResponse
+// @Failure      404  {object}  models.ErrorResponse
+// @Failure      500  {object}  models.ErrorResponse
+// @Router       /projects/{project_id}/export [get]
+func (h *ProjectConfigHandler) ExportProjectConfig(c *gin.Context) {
+	projectID, err := primitive.ObjectIDFromHex(c.Param("project_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid project_id format in path",
+		})
+		return
+	}
+
+	format := c.DefaultQuery("format", "json")
+	if format != "json" && format != "yaml" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid format. Use json or yaml",
+		})
+		return
+	}
+
+	if !RequireProjectPermission(c, h.repo, projectID, h.superAdmins, PermissionViewProject) {
+		return
+	}
+
+	ctx := c.Request.Context()
+	project, err := h.repo.GetProjectByID(ctx, projectID)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": "Project not found",
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to get project",
+		})
+		return
+	}
+
+	taskGroups, err := h.analytics().GetTaskGroupsByProjectID(ctx, projectID)
+	if err != nil {
+		log.Printf("Failed to get task groups for export of project %s: %v", projectID.Hex(), err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to get task groups",
+		})
+		return
+	}
+
+	tasks, err := h.analytics().GetTasksByProjectID(ctx, projectID)
+	if err != nil {
+		log.Printf("Failed to get tasks for export of project %s: %v", projectID.Hex(), err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to get tasks",
+		})
+		return
+	}
+
+	config := buildProjectConfig(project, taskGroups, tasks)
+
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="project-%s.%s"`, projectID.Hex(), format))
+	if format == "yaml" {
+		c.YAML(http.StatusOK, config)
+		return
+	}
+	c.JSON(http.StatusOK, config)
+}
+
+// ImportProjectConfig creates or updates a project's task groups and tasks from a declarative document
+// @Summary      Import project configuration
+// @Description  Create or update task groups and tasks from a JSON or YAML document (Content-Type application/x-yaml). Entries are matched by UUID, then by name. Nothing is deleted, and the whole document is validated before any change is made.
+// @Tags         projects
+// @Accept       json,x-yaml
+// @Produce      json
+// @Param        project_id path string true "Project ID"
+// @Param        config body models.ProjectConfig true "Project configuration"
+// @Success      200  {object}  models.ImportProjectConfigResponse
+// @Failure      400  {object}  models.ErrorResponse
+// @Failure      403  {object}  models.ErrorResponse
+// @Failure      404  {object}  models.ErrorResponse
+// @Failure      500  {object}  models.ErrorResponse
+// @Router       /projects/{project_id}/import [post]
+func (h *ProjectConfigHandler) ImportProjectConfig(c *gin.Context) {
+	var config models.ProjectConfig
+	var bindErr error
+	switch c.ContentType() {
+	case "application/x-yaml", "application/yaml", "text/yaml":
+		bindErr = c.ShouldBindYAML(&config)
+	default:
+		bindErr = c.ShouldBindJSON(&config)
+	}
+	if bindErr != nil {
+		utils.HandleValidationError(c, bindErr)
+		return
+	}
+
+	projectID, err := primitive.ObjectIDFromHex(c.Param("project_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid project_id format in path",
+		})
+		return
+	}
+
+	if !RequireProjectPermission(c, h.repo, projectID, h.superAdmins, PermissionManageTasks) {
+		return
+	}
+
+	ctx := c.Request.Context()
+	project, err := h.repo.GetProjectByID(ctx, projectID)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": "Project not found",
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to get project",
+		})
+		return
+	}
+	if project.Status == models.ProjectStatusArchived || project.Status == models.ProjectStatusPendingDelete {
+		c.JSON(http.StatusConflict, gin.H{
+			"error": "Configuration cannot be imported into an archived or deleted project",
+		})
+		return
+	}
+
+	taskGroups, err := h.repo.GetTaskGroupsByProjectID(ctx, projectID)
+	if err != nil {
+		log.Printf("Failed to get task groups for import into project %s: %v", projectID.Hex(), err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to get task groups",
+		})
+		return
+	}
+
+	tasks, err := h.repo.GetTasksByProjectID(ctx, projectID)
+	if err != nil {
+		log.Printf("Failed to get tasks for import into project %s: %v", projectID.Hex(), err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to get tasks",
+		})
+		return
+	}
+
+	if err := validateProjectConfig(&config, project, taskGroups); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	// Imported tasks must satisfy the project's metadata schema like tasks created through the API
+	metadataSchema, err := loadMetadataSchema(ctx, h.repo, projectID)
+	if err != nil {
+		log.Printf("Failed to load metadata schema for import into project %s: %v", projectID.Hex(), err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to validate metadata",
+		})
+		return
+	}
+	for _, taskConfig := range config.Tasks {
+		violations, err := metadataViolations(metadataSchema, taskConfig.Metadata)
+		if err != nil {
+			log.Printf("Failed to validate metadata for import into project %s: %v", projectID.Hex(), err)
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "Failed to validate metadata",
+			})
+			return
+		}
+		if len(violations) > 0 {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error":   "Metadata of task '" + taskConfig.Name + "' does not match the project's metadata schema",
+				"details": violations,
+			})
+			return
+		}
+	}
+
+	// Only tasks the import creates count against the task quota
+	if err := h.quotas.CheckTasks(ctx, project, countNewTasks(&config, tasks)); err != nil {
+		if !respondQuotaExceeded(c, err) {
+			log.Printf("Failed to check task quota for import into project %s: %v", projectID.Hex(), err)
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "Failed to check project quotas",
+			})
+		}
+		return
+	}
+
+	response, err := h.applyProjectConfig(ctx, projectID, &config, taskGroups, tasks)
+	if err != nil {
+		log.Printf("Failed to import configuration into project %s: %v", projectID.Hex(), err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to import configuration",
+		})
+		return
+	}
+
+	log.Printf("Project configuration imported: project=%s, groups_created=%d, groups_updated=%d, tasks_created=%d, tasks_updated=%d",
+		projectID.Hex(), response.TaskGroupsCreated, response.TaskGroupsUpdated, response.TasksCreated, response.TasksUpdated)
+	c.JSON(http.StatusOK, response)
+}
+
+// buildProjectConfig converts a project's groups and tasks to their declarative form, sorted by name for stable diffs
+func buildProjectConfig(project *models.Project, taskGroups []*models.TaskGroup, tasks []*models.Task) models.ProjectConfig {
+	config := models.ProjectConfig{
+		Version:    models.ProjectConfigVersion,
+		Project:    project.Name,
+		TaskGroups: []models.TaskGroupConfig{},
+		Tasks:      []models.TaskConfig{},
+	}
+
+	groupNames := make(map[primitive.ObjectID]string, len(taskGroups))
+	for _, group := range taskGroups {
+		groupNames[group.ID] = group.Name
+		config.TaskGroups = append(config.TaskGroups, models.TaskGroupConfig{
+			UUID:        group.UUID,
+			Name:        group.Name,
+			Description: group.Description,
+			Status:      group.Status,
+			StartTime:   group.StartTime,
+			EndTime:     group.EndTime,
+			Timezone:    group.Timezone,
+			Concurrency: group.Concurrency,
+			OnConflict:  group.OnConflict,
+		})
+	}
+
+	for _, task := range tasks {
+		taskConfig := models.TaskConfig{
+			UUID:           task.UUID,
+			Name:           task.Name,
+			Description:    task.Description,
+			ScheduleType:   task.ScheduleType,
+			Status:         task.Status,
+			ScheduleConfig: task.ScheduleConfig,
+			TimeoutSeconds: task.TimeoutSeconds,
+			Priority:       task.Priority,
+			AutoPauseAfter: task.AutoPauseAfter,
+			Concurrency:    task.Concurrency,
+			Severity:       task.Severity,
+			Metadata:       task.Metadata,
+			Environment:    task.Environment,
+			Env:            task.Env,
+			Tags:           task.Tags,
+		}
+		// Pausing is runtime state: imports keep existing tasks paused and create new ones ACTIVE
+		if task.Status == models.TaskStatusPaused {
+			taskConfig.Status = ""
+		}
+		if task.TaskGroupID != nil {
+			taskConfig.TaskGroup = groupNames[*task.TaskGroupID]
+		}
+		config.Tasks = append(config.Tasks, taskConfig)
+	}
+
+	sort.SliceStable(config.TaskGroups, func(i, j int) bool { return config.TaskGroups[i].Name < config.TaskGroups[j].Name })
+	sort.SliceStable(config.Tasks, func(i, j int) bool { return config.Tasks[i].Name < config.Tasks[j].Name })
+
+	return config
+}
+
+// validateProjectConfig checks references that binding cannot: unique names, task group references and environments
+func validateProjectConfig(config *models.ProjectConfig, project *models.Project, existingGroups []*models.TaskGroup) error {
+	groupNames := make(map[string]bool, len(existingGroups)+len(config.TaskGroups))
+	for _, group := range existingGroups {
+		groupNames[group.Name] = true
+	}
+
+	seenGroups := make(map[string]bool, len(config.TaskGroups))
+	for _, group := range config.TaskGroups {
+		if seenGroups[group.Name] {
+			return fmt.Errorf("task group '%s' is defined more than once", group.Name)
+		}
+		if (group.StartTime == "") != (group.EndTime == "") {
+			return fmt.Errorf("task group '%s' must set both start_time and end_time, or neither", group.Name)
+		}
+		seenGroups[group.Name] = true
+		groupNames[group.Name] = true
+	}
+
+	seenTasks := make(map[string]bool, len(config.Tasks))
+	for i := range config.Tasks {
+		task := &config.Tasks[i]
+		if seenTasks[task.Name] {
+			return fmt.Errorf("task '%s' is defined more than once", task.Name)
+		}
+		seenTasks[task.Name] = true
+
+		if task.TaskGroup != "" && !groupNames[task.TaskGroup] {
+			return fmt.Errorf("task '%s' references unknown task group '%s'", task.Name, task.TaskGroup)
+		}
+		if task.Environment != "" {
+			if _, ok := project.FindEnvironment(task.Environment); !ok {
+				return fmt.Errorf("task '%s' references environment '%s' which is not defined in this project", task.Name, task.Environment)
+			}
+		}
+		if err := task.ScheduleConfig.ResolveSchedule(); err != nil {
+			return fmt.Errorf("task '%s' has an invalid schedule: %w", task.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// countNewTasks returns how many tasks of the config match no existing task by UUID or name, as applyProjectConfig
+// matches them
+func countNewTasks(config *models.ProjectConfig, existingTasks []*models.Task) int {
+	uuids := make(map[string]bool, len(existingTasks))
+	names := make(map[string]bool, len(existingTasks))
+	for _, task := range existingTasks {
+		uuids[task.UUID] = true
+		names[task.Name] = true
+	}
+
+	count := 0
+	for _, taskConfig := range config.Tasks {
+		if !uuids[taskConfig.UUID] && !names[taskConfig.Name] {
+			count++
+		}
+	}
+	return count
+}
+
+// applyProjectConfig creates or updates groups first so tasks can reference them, then tasks.
+// Every change publishes the same event as the corresponding API call so the scheduler stays in sync.
+func (h *ProjectConfigHandler) applyProjectConfig(ctx context.Context, projectID primitive.ObjectID, config *models.ProjectConfig, existingGroups []*models.TaskGroup, existingTasks []*models.Task) (*models.ImportProjectConfigResponse, error) {
+	response := &models.ImportProjectConfigResponse{}
+	now := time.Now()
+
+	groupsByUUID := make(map[string]*models.TaskGroup, len(existingGroups))
+	groupsByName := make(map[string]*models.TaskGroup, len(existingGroups))
+	for _, group := range existingGroups {
+		groupsByUUID[group.UUID] = group
+		groupsByName[group.Name] = group
+	}
+
+	for _, groupConfig := range config.TaskGroups {
+		existing, ok := groupsByUUID[groupConfig.UUID]
+		if !ok {
+			existing, ok = groupsByName[groupConfig.Name]
+		}
+
+		if ok {
+			group := *existing
+			group.Name = groupConfig.Name
+			group.Description = groupConfig.Description
+			if groupConfig.Status != "" {
+				group.Status = groupConfig.Status
+			}
+			group.StartTime = groupConfig.StartTime
+			group.EndTime = groupConfig.EndTime
+			group.Timezone = groupConfig.Timezone
+			group.Concurrency = groupConfig.Concurrency
+			group.OnConflict = groupConfig.OnConflict
+			group.UpdatedAt = now
+
+			if err := h.repo.UpdateTaskGroup(ctx, group.UUID, &group); err != nil {
+				return nil, fmt.Errorf("failed to update task group %s: %w", group.Name, err)
+			}
+			groupsByName[group.Name] = &group
+			response.TaskGroupsUpdated++
+			publishIfEnabled(h.eventBus, events.TaskGroupUpdatedTopic, events.TaskGroupPayload{TaskGroup: &group})
+			continue
+		}
+
+		status := groupConfig.Status
+		if status == "" {
+			status = models.TaskGroupStatusActive
+		}
+		group := &models.TaskGroup{
+			ID:          primitive.NewObjectID(),
+			UUID:        uuid.New().String(),
+			ProjectID:   projectID,
+			Name:        groupConfig.Name,
+			Description: groupConfig.Description,
+			Status:      status,
+			State:       models.TaskGroupStateNotRunning,
+			StartTime:   groupConfig.StartTime,
+			EndTime:     groupConfig.EndTime,
+			Timezone:    groupConfig.Timezone,
+			Concurrency: groupConfig.Concurrency,
+			OnConflict:  groupConfig.OnConflict,
+			CreatedAt:   now,
+			UpdatedAt:   now,
+		}
+		if err := h.repo.CreateTaskGroup(ctx, projectID.Hex(), group); err != nil {
+			return nil, fmt.Errorf("failed to create task group %s: %w", group.Name, err)
+		}
+		groupsByName[group.Name] = group
+		response.TaskGroupsCreated++
+		publishIfEnabled(h.eventBus, events.TaskGroupCreatedTopic, events.TaskGroupPayload{TaskGroup: group})
+	}
+
+	tasksByUUID := make(map[string]*models.Task, len(existingTasks))
+	tasksByName := make(map[string]*models.Task, len(existingTasks))
+	for _, task := range existingTasks {
+		tasksByUUID[task.UUID] = task
+		tasksByName[task.Name] = task
+	}
+
+	for _, taskConfig := range config.Tasks {
+		var taskGroupID *primitive.ObjectID
+		if taskConfig.TaskGroup != "" {
+			groupID := groupsByName[taskConfig.TaskGroup].ID
+			taskGroupID = &groupID
+		}
+
+		existing, ok := tasksByUUID[taskConfig.UUID]
+		if !ok {
+			existing, ok = tasksByName[taskConfig.Name]
+		}
+
+		if ok {
+			task := *existing
+			applyTaskConfig(&task, taskConfig, taskGroupID)
+			if taskConfig.Status != "" {
+				task.Status = taskConfig.Status
+			}
+			if task.Status == models.TaskStatusDisabled {
+				task.State = models.TaskStateNotRunning
+			}
+			task.UpdatedAt = now
+
+			if err := h.repo.UpdateTask(ctx, task.UUID, &task); err != nil {
+				return nil, fmt.Errorf("failed to update task %s: %w", task.Name, err)
+			}
+			response.TasksUpdated++
+			publishIfEnabled(h.eventBus, events.TaskUpdatedTopic, events.TaskPayload{Task: &task})
+			continue
+		}
+
+		status := taskConfig.Status
+		if status == "" {
+			status = models.TaskStatusActive
+		}
+		task := &models.Task{
+			ID:        primitive.NewObjectID(),
+			UUID:      uuid.New().String(),
+			ProjectID: projectID,
+			Status:    status,
+			State:     models.TaskStateNotRunning,
+			CreatedAt: now,
+		}
+		applyTaskConfig(task, taskConfig, taskGroupID)
+		task.UpdatedAt = now
+
+		if err := h.repo.CreateTask(ctx, projectID.Hex(), task); err != nil {
+			return nil, fmt.Errorf("failed to create task %s: %w", task.Name, err)
+		}
+		response.TasksCreated++
+		publishIfEnabled(h.eventBus, events.TaskCreatedTopic, events.TaskPayload{Task: task})
+	}
+
+	return response, nil
+}
+
+// applyTaskConfig copies the declarative fields of a task onto the task model
+func applyTaskConfig(task *models.Task, taskConfig models.TaskConfig, taskGroupID *primitive.ObjectID) {
+	task.Name = taskConfig.Name
+	task.Description = taskConfig.Description
+	task.TaskGroupID = taskGroupID
+	task.ScheduleType = taskConfig.ScheduleType
+	task.ScheduleConfig = taskConfig.ScheduleConfig
+	task.TimeoutSeconds = taskConfig.TimeoutSeconds
+	task.Priority = taskConfig.Priority
+	task.AutoPauseAfter = taskConfig.AutoPauseAfter
+	task.Concurrency = taskConfig.Concurrency
+	task.Severity = taskConfig.Severity.OrDefault()
+	task.Metadata = taskConfig.Metadata
+	task.Environment = taskConfig.Environment
+	task.Env = taskConfig.Env
+	task.Tags = models.NormalizeTags(taskConfig.Tags)
+}
+
+// publishIfEnabled publishes the payload unless the handler was created without an event bus
+func publishIfEnabled[P any](bus *events.EventBus, topic events.Topic[P], payload P) {
+	if bus == nil {
+		return
+	}
+	events.Publish(bus, topic, payload)
+}
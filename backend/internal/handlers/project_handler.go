@@ -1,6 +1,8 @@
 package handlers
 
 import (
+	"context"
+	"fmt"
 	"log"
 	"net/http"
 	"strings"
@@ -8,46 +10,52 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"github.com/yourusername/cron-observer/backend/internal/deletequeue"
+	"github.com/yourusername/cron-observer/backend/internal/events"
+	"github.com/yourusername/cron-observer/backend/internal/jsonschema"
 	"github.com/yourusername/cron-observer/backend/internal/middleware"
 	"github.com/yourusername/cron-observer/backend/internal/models"
+	"github.com/yourusername/cron-observer/backend/internal/quota"
 	"github.com/yourusername/cron-observer/backend/internal/repositories"
 	"github.com/yourusername/cron-observer/backend/internal/utils"
 	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
 )
 
 type ProjectHandler struct {
-	repo          repositories.Repository
-	superAdminMap map[string]bool
+	repo            repositories.Repository
+	eventBus        *events.EventBus
+	superAdmins     *middleware.SuperAdmins
+	deletePublisher deletequeue.DeleteJobPublisher // optional until wired in main
+	quotas          *quota.Service                 // optional; nil enforces no quotas
 }
 
-func NewProjectHandler(repo repositories.Repository, superAdmins []string) *ProjectHandler {
-	// Create a map for O(1) lookup
-	superAdminMap := make(map[string]bool)
-	for _, admin := range superAdmins {
-		normalizedAdmin := strings.ToLower(strings.TrimSpace(admin))
-		if normalizedAdmin != "" {
-			superAdminMap[normalizedAdmin] = true
-		}
-	}
-
+func NewProjectHandler(repo repositories.Repository, eventBus *events.EventBus, superAdmins *middleware.SuperAdmins, deletePublisher deletequeue.DeleteJobPublisher) *ProjectHandler {
 	return &ProjectHandler{
-		repo:          repo,
-		superAdminMap: superAdminMap,
+		repo:            repo,
+		eventBus:        eventBus,
+		superAdmins:     superAdmins,
+		deletePublisher: deletePublisher,
 	}
 }
 
+// SetQuotaService enforces the task quota on cloned projects
+func (h *ProjectHandler) SetQuotaService(quotas *quota.Service) {
+	h.quotas = quotas
+}
+
 // isSuperAdmin checks if the given email is a super admin
 func (h *ProjectHandler) isSuperAdmin(email string) bool {
-	normalizedEmail := strings.ToLower(strings.TrimSpace(email))
-	return h.superAdminMap[normalizedEmail]
+	return h.superAdmins.Contains(email)
 }
 
 // GetAllProjects retrieves all projects
 // @Summary      Get all projects
-// @Description  Retrieve a list of all projects. Super admins get all projects, regular users get only projects they are members of.
+// @Description  Retrieve a list of all projects. Super admins get all projects; other users get the projects they are members of and every project of the organizations they administer. Archived projects are hidden unless include_archived=true. API keys and the status page token are left empty on projects the user cannot manage.
 // @Tags         projects
 // @Accept       json
 // @Produce      json
+// @Param        include_archived query bool false "Include archived projects"
 // @Success      200  {array}   models.Project
 // @Failure      500  {object}  models.ErrorResponse
 // @Router       /projects [get]
@@ -65,12 +73,14 @@ func (h *ProjectHandler) GetAllProjects(c *gin.Context) {
 	var err error
 
 	// Check if user is a super admin
-	if h.isSuperAdmin(user.Email) {
+	superAdmin := user.IsSuperAdmin() || h.isSuperAdmin(user.Email)
+	if superAdmin {
 		// Super admin - return all projects
 		log.Printf("Super admin %s requesting all projects", user.Email)
 		projects, err = h.repo.GetAllProjects(c.Request.Context())
 	} else {
-		// Regular user - return only projects they are members of
+		// Regular user - GetUserProjects returns the projects they are members of, and those of the organizations
+		// they administer, whose admins are admins of every project in it
 		log.Printf("User %s requesting their projects", user.Email)
 		projects, err = h.repo.GetUserProjects(c.Request.Context(), user.Email)
 	}
@@ -82,10 +92,31 @@ func (h *ProjectHandler) GetAllProjects(c *gin.Context) {
 		return
 	}
 
+	// Archived projects are hidden from default listings
+	if c.Query("include_archived") != "true" {
+		visible := make([]*models.Project, 0, len(projects))
+		for _, project := range projects {
+			if project.Status != models.ProjectStatusArchived {
+				visible = append(visible, project)
+			}
+		}
+		projects = visible
+	}
+
 	if projects == nil {
 		projects = []*models.Project{}
 	}
 
+	// API keys and tokens are only shown to users who may manage the project
+	if !superAdmin {
+		adminOrganizations := h.adminOrganizationIDs(c, user.Email)
+		for i, project := range projects {
+			if !canManageProject(project, user.Email, adminOrganizations) {
+				projects[i] = project.WithoutSecrets()
+			}
+		}
+	}
+
 	// Ensure project_users is always initialized (not nil) for JSON serialization
 	for _, project := range projects {
 		if project.ProjectUsers == nil {
@@ -96,6 +127,50 @@ func (h *ProjectHandler) GetAllProjects(c *gin.Context) {
 	c.JSON(http.StatusOK, projects)
 }
 
+// sameRateLimits reports whether two rate limit overrides are equal, counting nil as no overrides
+func sameRateLimits(a, b *models.ProjectRateLimits) bool {
+	var left, right models.ProjectRateLimits
+	if a != nil {
+		left = *a
+	}
+	if b != nil {
+		right = *b
+	}
+	return left == right
+}
+
+// adminOrganizationIDs returns the organizations the user is an admin of. A failed lookup returns none, so the
+// caller errs on the side of hiding secrets.
+func (h *ProjectHandler) adminOrganizationIDs(c *gin.Context, email string) map[primitive.ObjectID]bool {
+	organizations, err := h.repo.GetUserOrganizations(c.Request.Context(), email)
+	if err != nil {
+		log.Printf("Failed to get organizations of %s: %v", email, err)
+		return nil
+	}
+	ids := make(map[primitive.ObjectID]bool, len(organizations))
+	for _, organization := range organizations {
+		if organization.IsAdmin(email) {
+			ids[organization.ID] = true
+		}
+	}
+	return ids
+}
+
+// canManageProject reports whether the user's project role, or admin role in the project's organization, grants
+// PermissionManageProject
+func canManageProject(project *models.Project, email string, adminOrganizations map[primitive.ObjectID]bool) bool {
+	if project.OrganizationID != nil && adminOrganizations[*project.OrganizationID] {
+		return true
+	}
+	email = strings.ToLower(strings.TrimSpace(email))
+	for _, projectUser := range project.ProjectUsers {
+		if strings.ToLower(strings.TrimSpace(projectUser.Email)) == email && RoleHasPermission(projectUser.Role, PermissionManageProject) {
+			return true
+		}
+	}
+	return false
+}
+
 // CreateProject creates a new project
 // @Summary      Create a new project
 // @Description  Create a new project with auto-generated UUID and API key
@@ -151,6 +226,7 @@ func (h *ProjectHandler) CreateProject(c *gin.Context) {
 		Name:              name,
 		Description:       req.Description,
 		ExecutionEndpoint: req.ExecutionEndpoint,
+		FailoverEndpoint:  req.FailoverEndpoint,
 		UUID:              uuid.New().String(),
 		APIKey:            utils.GenerateAPIKey(),
 		CreatedAt:         now,
@@ -176,7 +252,7 @@ func (h *ProjectHandler) CreateProject(c *gin.Context) {
 
 // UpdateProject updates an existing project
 // @Summary      Update a project
-// @Description  Update an existing project
+// @Description  Update an existing project. PUT clears description, execution_endpoint and alert_emails when they are omitted; PATCH only changes the fields that are sent. Only super admins may change rate_limits.
 // @Tags         projects
 // @Accept       json
 // @Produce      json
@@ -184,9 +260,11 @@ func (h *ProjectHandler) CreateProject(c *gin.Context) {
 // @Param        project body models.UpdateProjectRequest true "Project update request"
 // @Success      200  {object}  models.Project
 // @Failure      400  {object}  models.ErrorResponse
+// @Failure      403  {object}  models.ErrorResponse
 // @Failure      404  {object}  models.ErrorResponse
 // @Failure      500  {object}  models.ErrorResponse
 // @Router       /projects/{project_id} [put]
+// @Router       /projects/{project_id} [patch]
 func (h *ProjectHandler) UpdateProject(c *gin.Context) {
 	var req models.UpdateProjectRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
@@ -216,6 +294,11 @@ func (h *ProjectHandler) UpdateProject(c *gin.Context) {
 		return
 	}
 
+	// Check authorization: only project admins may change settings and members
+	if !RequireProjectPermission(c, h.repo, projectID, h.superAdmins, PermissionManageProject) {
+		return
+	}
+
 	// Get existing project to preserve UUID, APIKey, and timestamps
 	existingProject, err := h.repo.GetProjectByID(c.Request.Context(), projectID)
 	if err != nil {
@@ -225,19 +308,52 @@ func (h *ProjectHandler) UpdateProject(c *gin.Context) {
 		return
 	}
 
+	if existingProject.Status == models.ProjectStatusPendingDelete {
+		c.JSON(http.StatusConflict, gin.H{
+			"error": "Project is being deleted",
+		})
+		return
+	}
+
+	// Rate limits protect the server from noisy projects, so only super admins may change them. Sending the
+	// current limits back unchanged is allowed.
+	if req.RateLimits != nil && !sameRateLimits(req.RateLimits, existingProject.RateLimits) {
+		user, _ := middleware.GetUserFromContext(c)
+		if user == nil || (!user.IsSuperAdmin() && !h.isSuperAdmin(user.Email)) {
+			c.JSON(http.StatusForbidden, gin.H{
+				"error": "Only super admins can change rate limits",
+			})
+			return
+		}
+	}
+
+	// PATCH leaves omitted fields untouched; PUT treats them as cleared, whatever the Content-Type parameters
+	replace := c.Request.Method == http.MethodPut
+
 	// Update only provided fields
 	now := time.Now()
 	updatedProject := &models.Project{
-		ID:                existingProject.ID,
-		UUID:              existingProject.UUID,   // UUID cannot be changed
-		APIKey:            existingProject.APIKey, // API key cannot be changed
-		Name:              existingProject.Name,
-		Description:       existingProject.Description,
-		ExecutionEndpoint: existingProject.ExecutionEndpoint,
-		AlertEmails:       existingProject.AlertEmails,
-		ProjectUsers:      existingProject.ProjectUsers, // Preserve existing users
-		CreatedAt:         existingProject.CreatedAt,    // Preserve original creation time
-		UpdatedAt:         now,
+		ID:                   existingProject.ID,
+		UUID:                 existingProject.UUID,   // UUID cannot be changed
+		APIKey:               existingProject.APIKey, // API key cannot be changed
+		Name:                 existingProject.Name,
+		Description:          existingProject.Description,
+		ExecutionEndpoint:    existingProject.ExecutionEndpoint,
+		FailoverEndpoint:     existingProject.FailoverEndpoint,
+		AlertEmails:          existingProject.AlertEmails,
+		ProjectUsers:         existingProject.ProjectUsers, // Preserve existing users
+		ScopedAPIKeys:        existingProject.ScopedAPIKeys,
+		APIKeyAllowedCIDRs:   existingProject.APIKeyAllowedCIDRs,
+		RateLimits:           existingProject.RateLimits,
+		ExecutionHeaders:     existingProject.ExecutionHeaders,
+		Environments:         existingProject.Environments,
+		NotificationChannels: existingProject.NotificationChannels,
+		AlertRoutes:          existingProject.AlertRoutes,
+		OnCall:               existingProject.OnCall,
+		Status:               existingProject.Status,
+		StatusPageToken:      existingProject.StatusPageToken,
+		CreatedAt:            existingProject.CreatedAt, // Preserve original creation time
+		UpdatedAt:            now,
 	}
 
 	// Update fields if provided in request
@@ -255,19 +371,24 @@ func (h *ProjectHandler) UpdateProject(c *gin.Context) {
 	}
 	if req.Description != "" {
 		updatedProject.Description = req.Description
-	} else if req.Description == "" && c.GetHeader("Content-Type") == "application/json" {
+	} else if replace {
 		// Allow clearing description by sending empty string
 		updatedProject.Description = ""
 	}
 	if req.ExecutionEndpoint != "" {
 		updatedProject.ExecutionEndpoint = req.ExecutionEndpoint
-	} else if req.ExecutionEndpoint == "" && c.GetHeader("Content-Type") == "application/json" {
+	} else if replace {
 		// Allow clearing execution endpoint by sending empty string
 		updatedProject.ExecutionEndpoint = ""
 	}
+	if req.FailoverEndpoint != "" {
+		updatedProject.FailoverEndpoint = req.FailoverEndpoint
+	} else if replace {
+		updatedProject.FailoverEndpoint = ""
+	}
 	if req.AlertEmails != "" {
 		updatedProject.AlertEmails = req.AlertEmails
-	} else if req.AlertEmails == "" && c.GetHeader("Content-Type") == "application/json" {
+	} else if replace {
 		// Allow clearing alert emails by sending empty string
 		updatedProject.AlertEmails = ""
 	}
@@ -281,6 +402,18 @@ func (h *ProjectHandler) UpdateProject(c *gin.Context) {
 		log.Printf("ProjectUsers not provided in request, preserving existing: %d users", len(updatedProject.ProjectUsers))
 	}
 
+	if req.APIKeyAllowedCIDRs != nil {
+		updatedProject.APIKeyAllowedCIDRs = req.APIKeyAllowedCIDRs
+		log.Printf("Updating primary API key allowlist: %v", req.APIKeyAllowedCIDRs)
+	}
+
+	if req.RateLimits != nil {
+		updatedProject.RateLimits = req.RateLimits
+	}
+	if req.ExecutionHeaders != nil {
+		updatedProject.ExecutionHeaders = req.ExecutionHeaders
+	}
+
 	// Update the project
 	log.Printf("Updating project with ProjectUsers: %v", updatedProject.ProjectUsers)
 	err = h.repo.UpdateProject(c.Request.Context(), projectID, updatedProject)
@@ -295,3 +428,1027 @@ func (h *ProjectHandler) UpdateProject(c *gin.Context) {
 	log.Printf("Project updated successfully: ID=%s, UUID=%s, Name=%s", updatedProject.ID.Hex(), updatedProject.UUID, updatedProject.Name)
 	c.JSON(http.StatusOK, updatedProject)
 }
+
+// CreateScopedAPIKey creates an additional API key for a project
+// @Summary      Create a scoped API key
+// @Description  Create an additional API key for a project. READ_ONLY keys can only GET tasks and executions, which is useful for dashboards and status pages.
+// @Tags         projects
+// @Accept       json
+// @Produce      json
+// @Param        project_id path string true "Project ID"
+// @Param        api_key body models.CreateScopedAPIKeyRequest true "Scoped API key creation request"
+// @Success      201  {object}  models.ScopedAPIKey
+// @Failure      400  {object}  models.ErrorResponse
+// @Failure      403  {object}  models.ErrorResponse
+// @Failure      404  {object}  models.ErrorResponse
+// @Failure      500  {object}  models.ErrorResponse
+// @Router       /projects/{project_id}/api-keys [post]
+func (h *ProjectHandler) CreateScopedAPIKey(c *gin.Context) {
+	var req models.CreateScopedAPIKeyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.HandleValidationError(c, err)
+		return
+	}
+
+	projectID, err := primitive.ObjectIDFromHex(c.Param("project_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid project_id format in path",
+		})
+		return
+	}
+
+	// Check authorization: user must be admin in project or super admin
+	if !RequireProjectPermission(c, h.repo, projectID, h.superAdmins, PermissionManageProject) {
+		return
+	}
+
+	// Default to the most restrictive scope
+	scope := req.Scope
+	if scope == "" {
+		scope = models.APIKeyScopeReadOnly
+	}
+
+	apiKey := models.ScopedAPIKey{
+		ID:           uuid.New().String(),
+		Name:         strings.TrimSpace(req.Name),
+		Key:          utils.GenerateAPIKey(),
+		Scope:        scope,
+		AllowedCIDRs: req.AllowedCIDRs,
+		CreatedAt:    time.Now(),
+	}
+
+	if err := h.repo.AddScopedAPIKey(c.Request.Context(), projectID, apiKey); err != nil {
+		if err == mongo.ErrNoDocuments {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": "Project not found",
+			})
+			return
+		}
+		log.Printf("Failed to create scoped API key for project %s: %v", projectID.Hex(), err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to create API key",
+		})
+		return
+	}
+
+	log.Printf("Scoped API key created: project=%s, key_id=%s, scope=%s", projectID.Hex(), apiKey.ID, apiKey.Scope)
+	c.JSON(http.StatusCreated, apiKey)
+}
+
+// RevokeScopedAPIKey revokes an additional API key of a project
+// @Summary      Revoke a scoped API key
+// @Description  Revoke a scoped API key. The project's primary API key cannot be revoked.
+// @Tags         projects
+// @Accept       json
+// @Produce      json
+// @Param        project_id path string true "Project ID"
+// @Param        key_id path string true "Scoped API key ID"
+// @Success      204  "No Content"
+// @Failure      400  {object}  models.ErrorResponse
+// @Failure      403  {object}  models.ErrorResponse
+// @Failure      404  {object}  models.ErrorResponse
+// @Failure      500  {object}  models.ErrorResponse
+// @Router       /projects/{project_id}/api-keys/{key_id} [delete]
+func (h *ProjectHandler) RevokeScopedAPIKey(c *gin.Context) {
+	projectID, err := primitive.ObjectIDFromHex(c.Param("project_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid project_id format in path",
+		})
+		return
+	}
+
+	keyID := c.Param("key_id")
+	if keyID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "key_id is required in path",
+		})
+		return
+	}
+
+	// Check authorization: user must be admin in project or super admin
+	if !RequireProjectPermission(c, h.repo, projectID, h.superAdmins, PermissionManageProject) {
+		return
+	}
+
+	if err := h.repo.RemoveScopedAPIKey(c.Request.Context(), projectID, keyID); err != nil {
+		if err == mongo.ErrNoDocuments {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": "API key not found",
+			})
+			return
+		}
+		log.Printf("Failed to revoke scoped API key %s for project %s: %v", keyID, projectID.Hex(), err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to revoke API key",
+		})
+		return
+	}
+
+	log.Printf("Scoped API key revoked: project=%s, key_id=%s", projectID.Hex(), keyID)
+	c.Status(http.StatusNoContent)
+}
+
+// UpdateScopedAPIKeyAllowedCIDRs replaces the CIDR allowlist of a scoped API key
+// @Summary      Update a scoped API key's IP allowlist
+// @Description  Replace the CIDR allowlist of a scoped API key. Requests using the key from other networks are rejected with 403. Send an empty list to allow any network.
+// @Tags         projects
+// @Accept       json
+// @Produce      json
+// @Param        project_id path string true "Project ID"
+// @Param        key_id path string true "Scoped API key ID"
+// @Param        allowlist body models.UpdateAPIKeyAllowedCIDRsRequest true "CIDR allowlist"
+// @Success      204  "No Content"
+// @Failure      400  {object}  models.ErrorResponse
+// @Failure      403  {object}  models.ErrorResponse
+// @Failure      404  {object}  models.ErrorResponse
+// @Failure      500  {object}  models.ErrorResponse
+// @Router       /projects/{project_id}/api-keys/{key_id}/allowed-cidrs [put]
+func (h *ProjectHandler) UpdateScopedAPIKeyAllowedCIDRs(c *gin.Context) {
+	var req models.UpdateAPIKeyAllowedCIDRsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.HandleValidationError(c, err)
+		return
+	}
+
+	projectID, err := primitive.ObjectIDFromHex(c.Param("project_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid project_id format in path",
+		})
+		return
+	}
+
+	keyID := c.Param("key_id")
+	if keyID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "key_id is required in path",
+		})
+		return
+	}
+
+	// Check authorization: user must be admin in project or super admin
+	if !RequireProjectPermission(c, h.repo, projectID, h.superAdmins, PermissionManageProject) {
+		return
+	}
+
+	allowedCIDRs := req.AllowedCIDRs
+	if allowedCIDRs == nil {
+		allowedCIDRs = []string{}
+	}
+
+	if err := h.repo.UpdateScopedAPIKeyAllowedCIDRs(c.Request.Context(), projectID, keyID, allowedCIDRs); err != nil {
+		if err == mongo.ErrNoDocuments {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": "API key not found",
+			})
+			return
+		}
+		log.Printf("Failed to update allowlist of scoped API key %s for project %s: %v", keyID, projectID.Hex(), err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to update API key allowlist",
+		})
+		return
+	}
+
+	log.Printf("Scoped API key allowlist updated: project=%s, key_id=%s, cidrs=%v", projectID.Hex(), keyID, allowedCIDRs)
+	c.Status(http.StatusNoContent)
+}
+
+// CloneProject deep-copies a project's settings, task groups and tasks into a new project
+// @Summary      Clone a project
+// @Description  Create a new project with a new UUID and API key, copying settings, members, environments (with new API keys), task groups and tasks. Cloned tasks and groups are DISABLED unless enable_tasks is true. Secrets and scoped API keys are not copied.
+// @Tags         projects
+// @Accept       json
+// @Produce      json
+// @Param        project_id path string true "Source project ID"
+// @Param        clone body models.CloneProjectRequest true "Clone request"
+// @Success      201  {object}  models.CloneProjectResponse
+// @Failure      400  {object}  models.ErrorResponse
+// @Failure      403  {object}  models.ErrorResponse
+// @Failure      404  {object}  models.ErrorResponse
+// @Failure      409  {object}  models.ErrorResponse
+// @Failure      500  {object}  models.ErrorResponse
+// @Router       /projects/{project_id}/clone [post]
+func (h *ProjectHandler) CloneProject(c *gin.Context) {
+	var req models.CloneProjectRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.HandleValidationError(c, err)
+		return
+	}
+
+	projectID, err := primitive.ObjectIDFromHex(c.Param("project_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid project_id format in path",
+		})
+		return
+	}
+
+	// Check authorization: cloning copies members and settings, so it requires project admin
+	if !RequireProjectPermission(c, h.repo, projectID, h.superAdmins, PermissionManageProject) {
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	source, err := h.repo.GetProjectByID(ctx, projectID)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": "Project not found",
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to get project",
+		})
+		return
+	}
+	if source.Status == models.ProjectStatusPendingDelete {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Project not found",
+		})
+		return
+	}
+
+	// Check project name is unique (case-insensitive)
+	name := strings.TrimSpace(req.Name)
+	if existing, getErr := h.repo.GetProjectByName(ctx, name); getErr == nil && existing != nil {
+		c.JSON(http.StatusConflict, gin.H{
+			"error": "A project with this name already exists",
+		})
+		return
+	}
+
+	taskGroups, err := h.repo.GetTaskGroupsByProjectID(ctx, projectID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to get task groups for project",
+		})
+		return
+	}
+	tasks, err := h.repo.GetTasksByProjectID(ctx, projectID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to get tasks for project",
+		})
+		return
+	}
+
+	now := time.Now()
+	description := req.Description
+	if description == "" {
+		description = source.Description
+	}
+
+	clone := &models.Project{
+		ID:                   primitive.NewObjectID(),
+		UUID:                 uuid.New().String(),
+		Name:                 name,
+		Description:          description,
+		APIKey:               utils.GenerateAPIKey(),
+		ExecutionEndpoint:    source.ExecutionEndpoint,
+		FailoverEndpoint:     source.FailoverEndpoint,
+		AlertEmails:          source.AlertEmails,
+		ExecutionHeaders:     source.ExecutionHeaders,
+		ProjectUsers:         source.ProjectUsers,
+		RateLimits:           source.RateLimits,
+		NotificationChannels: source.NotificationChannels,
+		AlertRoutes:          source.AlertRoutes,
+		OnCall:               source.OnCall,
+		CreatedAt:            now,
+		UpdatedAt:            now,
+	}
+	for _, environment := range source.Environments {
+		clone.Environments = append(clone.Environments, models.ProjectEnvironment{
+			Name:              environment.Name,
+			ExecutionEndpoint: environment.ExecutionEndpoint,
+			APIKey:            utils.GenerateAPIKey(), // Keys are never shared between projects
+			CreatedAt:         now,
+			UpdatedAt:         now,
+		})
+	}
+
+	// The clone starts with the server's quotas, which must fit the copied tasks
+	if err := h.quotas.CheckTasks(ctx, clone, len(tasks)); err != nil {
+		if !respondQuotaExceeded(c, err) {
+			log.Printf("Failed to check task quota of clone of project %s: %v", projectID.Hex(), err)
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "Failed to check project quotas",
+			})
+		}
+		return
+	}
+
+	if err := h.repo.CreateProject(ctx, clone); err != nil {
+		log.Printf("Failed to create clone of project %s: %v", projectID.Hex(), err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to create project",
+		})
+		return
+	}
+
+	clonedGroups, clonedTasks, err := h.cloneTaskGroupsAndTasks(ctx, clone, taskGroups, tasks, req.EnableTasks)
+	if err != nil {
+		log.Printf("Failed to clone tasks of project %s into %s: %v", projectID.Hex(), clone.ID.Hex(), err)
+		h.discardClone(ctx, clone, clonedGroups, clonedTasks)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to clone project",
+		})
+		return
+	}
+
+	// Publish creation events so the scheduler picks up enabled clones
+	if h.eventBus != nil && req.EnableTasks {
+		for _, taskGroup := range clonedGroups {
+			if err := events.Publish(h.eventBus, events.TaskGroupCreatedTopic, events.TaskGroupPayload{TaskGroup: taskGroup}); err != nil {
+				log.Printf("Failed to publish creation of cloned task group %s: %v", taskGroup.UUID, err)
+			}
+		}
+		for _, task := range clonedTasks {
+			if err := events.Publish(h.eventBus, events.TaskCreatedTopic, events.TaskPayload{Task: task}); err != nil {
+				log.Printf("Failed to publish creation of cloned task %s: %v", task.UUID, err)
+			}
+		}
+	}
+
+	log.Printf("Project cloned: source=%s, clone=%s, name=%s, task_groups=%d, tasks=%d",
+		projectID.Hex(), clone.ID.Hex(), clone.Name, len(clonedGroups), len(clonedTasks))
+	c.JSON(http.StatusCreated, models.CloneProjectResponse{
+		Project:          clone,
+		TaskGroupsCloned: len(clonedGroups),
+		TasksCloned:      len(clonedTasks),
+	})
+}
+
+// cloneTaskGroupsAndTasks copies task groups and tasks into the cloned project with new IDs and UUIDs.
+// It returns whatever was created so far, even on error, so the caller can clean up.
+func (h *ProjectHandler) cloneTaskGroupsAndTasks(ctx context.Context, clone *models.Project, taskGroups []*models.TaskGroup, tasks []*models.Task, enableTasks bool) ([]*models.TaskGroup, []*models.Task, error) {
+	now := time.Now()
+	projectIDParam := clone.ID.Hex()
+
+	clonedGroups := make([]*models.TaskGroup, 0, len(taskGroups))
+	groupIDs := make(map[primitive.ObjectID]primitive.ObjectID, len(taskGroups))
+	for _, taskGroup := range taskGroups {
+		clonedGroup := *taskGroup
+		clonedGroup.ID = primitive.NewObjectID()
+		clonedGroup.UUID = uuid.New().String()
+		clonedGroup.ProjectID = clone.ID
+		clonedGroup.State = models.TaskGroupStateNotRunning
+		clonedGroup.CreatedAt = now
+		clonedGroup.UpdatedAt = now
+		if !enableTasks {
+			clonedGroup.Status = models.TaskGroupStatusDisabled
+		}
+
+		if err := h.repo.CreateTaskGroup(ctx, projectIDParam, &clonedGroup); err != nil {
+			return clonedGroups, nil, fmt.Errorf("failed to clone task group %s: %w", taskGroup.UUID, err)
+		}
+		groupIDs[taskGroup.ID] = clonedGroup.ID
+		clonedGroups = append(clonedGroups, &clonedGroup)
+	}
+
+	clonedTasks := make([]*models.Task, 0, len(tasks))
+	for _, task := range tasks {
+		clonedTask := *task
+		clonedTask.ID = primitive.NewObjectID()
+		clonedTask.UUID = uuid.New().String()
+		clonedTask.ProjectID = clone.ID
+		clonedTask.State = models.TaskStateNotRunning
+		clonedTask.CreatedAt = now
+		clonedTask.UpdatedAt = now
+		if task.TaskGroupID != nil {
+			groupID, ok := groupIDs[*task.TaskGroupID]
+			if ok {
+				clonedTask.TaskGroupID = &groupID
+			} else {
+				// The source points at a group that no longer exists; don't carry the dangling reference over
+				clonedTask.TaskGroupID = nil
+			}
+		}
+		if !enableTasks {
+			clonedTask.Status = models.TaskStatusDisabled
+		}
+
+		if err := h.repo.CreateTask(ctx, projectIDParam, &clonedTask); err != nil {
+			return clonedGroups, clonedTasks, fmt.Errorf("failed to clone task %s: %w", task.UUID, err)
+		}
+		clonedTasks = append(clonedTasks, &clonedTask)
+	}
+
+	return clonedGroups, clonedTasks, nil
+}
+
+// discardClone removes a partially created clone. Failures are logged; nothing was scheduled yet.
+func (h *ProjectHandler) discardClone(ctx context.Context, clone *models.Project, taskGroups []*models.TaskGroup, tasks []*models.Task) {
+	for _, task := range tasks {
+		if err := h.repo.DeleteTask(ctx, task.UUID); err != nil {
+			log.Printf("Failed to remove cloned task %s: %v", task.UUID, err)
+		}
+	}
+	for _, taskGroup := range taskGroups {
+		if err := h.repo.DeleteTaskGroup(ctx, taskGroup.UUID); err != nil {
+			log.Printf("Failed to remove cloned task group %s: %v", taskGroup.UUID, err)
+		}
+	}
+	if err := h.repo.DeleteProject(ctx, clone.ID); err != nil {
+		log.Printf("Failed to remove cloned project %s: %v", clone.ID.Hex(), err)
+	}
+}
+
+// CreateProjectEnvironment adds a named environment to a project
+// @Summary      Create a project environment
+// @Description  Add a named environment (e.g. staging, prod) with its own execution endpoint and a generated API key. Tasks select it via their environment field.
+// @Tags         projects
+// @Accept       json
+// @Produce      json
+// @Param        project_id path string true "Project ID"
+// @Param        environment body models.CreateProjectEnvironmentRequest true "Environment creation request"
+// @Success      201  {object}  models.ProjectEnvironment
+// @Failure      400  {object}  models.ErrorResponse
+// @Failure      403  {object}  models.ErrorResponse
+// @Failure      404  {object}  models.ErrorResponse
+// @Failure      409  {object}  models.ErrorResponse
+// @Failure      500  {object}  models.ErrorResponse
+// @Router       /projects/{project_id}/environments [post]
+func (h *ProjectHandler) CreateProjectEnvironment(c *gin.Context) {
+	var req models.CreateProjectEnvironmentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.HandleValidationError(c, err)
+		return
+	}
+
+	projectID, err := primitive.ObjectIDFromHex(c.Param("project_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid project_id format in path",
+		})
+		return
+	}
+
+	// Check authorization: only project admins may manage environments and their keys
+	if !RequireProjectPermission(c, h.repo, projectID, h.superAdmins, PermissionManageProject) {
+		return
+	}
+
+	project, err := h.repo.GetProjectByID(c.Request.Context(), projectID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Project not found",
+		})
+		return
+	}
+	if _, exists := project.FindEnvironment(req.Name); exists {
+		c.JSON(http.StatusConflict, gin.H{
+			"error": "An environment with this name already exists",
+		})
+		return
+	}
+
+	now := time.Now()
+	environment := models.ProjectEnvironment{
+		Name:              req.Name,
+		ExecutionEndpoint: req.ExecutionEndpoint,
+		APIKey:            utils.GenerateAPIKey(),
+		CreatedAt:         now,
+		UpdatedAt:         now,
+	}
+
+	if err := h.repo.AddProjectEnvironment(c.Request.Context(), projectID, environment); err != nil {
+		if err == mongo.ErrNoDocuments {
+			// Lost a race with a concurrent request creating the same name
+			c.JSON(http.StatusConflict, gin.H{
+				"error": "An environment with this name already exists",
+			})
+			return
+		}
+		log.Printf("Failed to create environment %s for project %s: %v", req.Name, projectID.Hex(), err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to create environment",
+		})
+		return
+	}
+
+	log.Printf("Project environment created: project=%s, environment=%s", projectID.Hex(), environment.Name)
+	c.JSON(http.StatusCreated, environment)
+}
+
+// UpdateProjectEnvironment changes an environment's execution endpoint
+// @Summary      Update a project environment
+// @Description  Change the execution endpoint of a project environment. The environment's API key is unchanged.
+// @Tags         projects
+// @Accept       json
+// @Produce      json
+// @Param        project_id path string true "Project ID"
+// @Param        env_name path string true "Environment name"
+// @Param        environment body models.UpdateProjectEnvironmentRequest true "Environment update request"
+// @Success      204  "No Content"
+// @Failure      400  {object}  models.ErrorResponse
+// @Failure      403  {object}  models.ErrorResponse
+// @Failure      404  {object}  models.ErrorResponse
+// @Failure      500  {object}  models.ErrorResponse
+// @Router       /projects/{project_id}/environments/{env_name} [put]
+func (h *ProjectHandler) UpdateProjectEnvironment(c *gin.Context) {
+	var req models.UpdateProjectEnvironmentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.HandleValidationError(c, err)
+		return
+	}
+
+	projectID, err := primitive.ObjectIDFromHex(c.Param("project_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid project_id format in path",
+		})
+		return
+	}
+
+	name := c.Param("env_name")
+	if name == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "env_name is required in path",
+		})
+		return
+	}
+
+	if !RequireProjectPermission(c, h.repo, projectID, h.superAdmins, PermissionManageProject) {
+		return
+	}
+
+	if err := h.repo.UpdateProjectEnvironmentEndpoint(c.Request.Context(), projectID, name, req.ExecutionEndpoint); err != nil {
+		if err == mongo.ErrNoDocuments {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": "Environment not found",
+			})
+			return
+		}
+		log.Printf("Failed to update environment %s for project %s: %v", name, projectID.Hex(), err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to update environment",
+		})
+		return
+	}
+
+	log.Printf("Project environment updated: project=%s, environment=%s", projectID.Hex(), name)
+	c.Status(http.StatusNoContent)
+}
+
+// DeleteProjectEnvironment removes an environment from a project
+// @Summary      Delete a project environment
+// @Description  Remove a project environment and revoke its API key. Fails while tasks still dispatch to the environment.
+// @Tags         projects
+// @Accept       json
+// @Produce      json
+// @Param        project_id path string true "Project ID"
+// @Param        env_name path string true "Environment name"
+// @Success      204  "No Content"
+// @Failure      400  {object}  models.ErrorResponse
+// @Failure      403  {object}  models.ErrorResponse
+// @Failure      404  {object}  models.ErrorResponse
+// @Failure      409  {object}  models.ErrorResponse
+// @Failure      500  {object}  models.ErrorResponse
+// @Router       /projects/{project_id}/environments/{env_name} [delete]
+func (h *ProjectHandler) DeleteProjectEnvironment(c *gin.Context) {
+	projectID, err := primitive.ObjectIDFromHex(c.Param("project_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid project_id format in path",
+		})
+		return
+	}
+
+	name := c.Param("env_name")
+	if name == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "env_name is required in path",
+		})
+		return
+	}
+
+	if !RequireProjectPermission(c, h.repo, projectID, h.superAdmins, PermissionManageProject) {
+		return
+	}
+
+	// Refuse to strand tasks that still dispatch to this environment
+	tasks, err := h.repo.GetTasksByProjectID(c.Request.Context(), projectID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to check tasks using this environment",
+		})
+		return
+	}
+	inUse := 0
+	for _, task := range tasks {
+		if task.Environment == name {
+			inUse++
+		}
+	}
+	if inUse > 0 {
+		c.JSON(http.StatusConflict, gin.H{
+			"error": fmt.Sprintf("Environment is used by %d task(s); move them to another environment first", inUse),
+		})
+		return
+	}
+
+	if err := h.repo.RemoveProjectEnvironment(c.Request.Context(), projectID, name); err != nil {
+		if err == mongo.ErrNoDocuments {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": "Environment not found",
+			})
+			return
+		}
+		log.Printf("Failed to delete environment %s for project %s: %v", name, projectID.Hex(), err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to delete environment",
+		})
+		return
+	}
+
+	log.Printf("Project environment deleted: project=%s, environment=%s", projectID.Hex(), name)
+	c.Status(http.StatusNoContent)
+}
+
+// ArchiveProject archives a project
+// @Summary      Archive a project
+// @Description  Unregister all of the project's tasks from the scheduler and block new executions. Tasks, executions and stats are preserved; restore the project to resume scheduling.
+// @Tags         projects
+// @Accept       json
+// @Produce      json
+// @Param        project_id path string true "Project ID"
+// @Success      200  {object}  models.Project
+// @Failure      400  {object}  models.ErrorResponse
+// @Failure      403  {object}  models.ErrorResponse
+// @Failure      404  {object}  models.ErrorResponse
+// @Failure      409  {object}  models.ErrorResponse
+// @Failure      500  {object}  models.ErrorResponse
+// @Router       /projects/{project_id}/archive [post]
+func (h *ProjectHandler) ArchiveProject(c *gin.Context) {
+	h.setProjectArchived(c, true)
+}
+
+// RestoreProject restores an archived project
+// @Summary      Restore an archived project
+// @Description  Make an archived project active again and re-register its active tasks with the scheduler
+// @Tags         projects
+// @Accept       json
+// @Produce      json
+// @Param        project_id path string true "Project ID"
+// @Success      200  {object}  models.Project
+// @Failure      400  {object}  models.ErrorResponse
+// @Failure      403  {object}  models.ErrorResponse
+// @Failure      404  {object}  models.ErrorResponse
+// @Failure      409  {object}  models.ErrorResponse
+// @Failure      500  {object}  models.ErrorResponse
+// @Router       /projects/{project_id}/restore [post]
+func (h *ProjectHandler) RestoreProject(c *gin.Context) {
+	h.setProjectArchived(c, false)
+}
+
+// setProjectArchived moves a project between ACTIVE and ARCHIVED and notifies the scheduler
+func (h *ProjectHandler) setProjectArchived(c *gin.Context, archived bool) {
+	projectID, err := primitive.ObjectIDFromHex(c.Param("project_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid project_id format in path",
+		})
+		return
+	}
+
+	// Check authorization: only project admins may archive or restore a project
+	if !RequireProjectPermission(c, h.repo, projectID, h.superAdmins, PermissionManageProject) {
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	project, err := h.repo.GetProjectByID(ctx, projectID)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": "Project not found",
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to get project",
+		})
+		return
+	}
+
+	if project.Status == models.ProjectStatusPendingDelete {
+		c.JSON(http.StatusConflict, gin.H{
+			"error": "Project is being deleted",
+		})
+		return
+	}
+
+	isArchived := project.Status == models.ProjectStatusArchived
+	if !archived && !isArchived {
+		c.JSON(http.StatusConflict, gin.H{
+			"error": "Project is not archived",
+		})
+		return
+	}
+	if archived && isArchived {
+		// Idempotent: archiving an archived project is a no-op
+		c.JSON(http.StatusOK, project)
+		return
+	}
+
+	status := models.ProjectStatusActive
+	topic := events.ProjectRestoredTopic
+	if archived {
+		status = models.ProjectStatusArchived
+		topic = events.ProjectArchivedTopic
+	}
+
+	// The event makes the scheduler unregister or re-register the project's tasks
+	err = events.PublishAtomic(ctx, h.eventBus, topic, func(ctx context.Context) (events.ProjectPayload, error) {
+		if err := h.repo.UpdateProjectStatus(ctx, projectID, status); err != nil {
+			return events.ProjectPayload{}, err
+		}
+		project.Status = status
+		project.UpdatedAt = time.Now()
+		return events.ProjectPayload{Project: project}, nil
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to update project status",
+		})
+		return
+	}
+
+	log.Printf("Project %s status changed to %s", projectID.Hex(), status)
+	c.JSON(http.StatusOK, project)
+}
+
+// DeleteProject queues a project for deletion together with its task groups, tasks, executions and stats
+// @Summary      Delete a project
+// @Description  Mark the project PENDING_DELETE and enqueue a delete job. The delete worker unregisters and removes the project's tasks, executions, task groups and stats in batches and records its progress in the project's deletion_progress. Retrying the request re-enqueues the job.
+// @Tags         projects
+// @Accept       json
+// @Produce      json
+// @Param        project_id path string true "Project ID"
+// @Success      202  {object}  models.DeleteProjectResponse "Project deletion accepted"
+// @Failure      400  {object}  models.ErrorResponse
+// @Failure      403  {object}  models.ErrorResponse
+// @Failure      404  {object}  models.ErrorResponse
+// @Failure      500  {object}  models.ErrorResponse
+// @Router       /projects/{project_id} [delete]
+func (h *ProjectHandler) DeleteProject(c *gin.Context) {
+	projectID, err := primitive.ObjectIDFromHex(c.Param("project_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid project_id format in path",
+		})
+		return
+	}
+
+	// Check authorization: only project admins may delete a project
+	if !RequireProjectPermission(c, h.repo, projectID, h.superAdmins, PermissionManageProject) {
+		return
+	}
+
+	// Check if RabbitMQ publisher is available
+	if h.deletePublisher == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Delete queue not available",
+		})
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	project, err := h.repo.GetProjectByID(ctx, projectID)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": "Project not found",
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to get project",
+		})
+		return
+	}
+
+	// Hide the project from listings and stop new executions before the job is queued
+	if err := h.repo.UpdateProjectStatus(ctx, projectID, models.ProjectStatusPendingDelete); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to mark project for deletion",
+		})
+		return
+	}
+
+	msg := deletequeue.DeleteProjectMessage{
+		ProjectID:   projectID.Hex(),
+		RequestedAt: time.Now(),
+	}
+	if err := h.deletePublisher.PublishDeleteProject(ctx, msg); err != nil {
+		log.Printf("[Handler] Failed to enqueue project delete job: ProjectID=%s, error=%v", projectID.Hex(), err)
+
+		// Restore the previous status so the project does not stay hidden without a job to remove it
+		if project.Status != models.ProjectStatusPendingDelete {
+			if revertErr := h.repo.UpdateProjectStatus(ctx, projectID, project.Status); revertErr != nil {
+				log.Printf("[Handler] WARNING: Failed to restore project status: ProjectID=%s, error=%v", projectID.Hex(), revertErr)
+			}
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to enqueue delete job",
+		})
+		return
+	}
+
+	log.Printf("[Handler] Accepted project delete: ProjectID=%s, Name=%s", projectID.Hex(), project.Name)
+
+	// Return 202 Accepted - the project is removed asynchronously by the delete worker
+	c.JSON(http.StatusAccepted, models.DeleteProjectResponse{
+		Status:    string(models.ProjectStatusPendingDelete),
+		ProjectID: projectID.Hex(),
+		Message:   "Project deletion has been scheduled",
+	})
+}
+
+// GetProjectSettings returns a project's default settings
+// @Summary      Get project settings
+// @Description  Get the project's defaults for timezone, execution retention, alert throttling and execution timeout. Projects without stored settings return zero values.
+// @Tags         projects
+// @Accept       json
+// @Produce      json
+// @Param        project_id path string true "Project ID"
+// @Success      200  {object}  models.ProjectSettings
+// @Failure      400  {object}  models.ErrorResponse
+// @Failure      403  {object}  models.ErrorResponse
+// @Failure      404  {object}  models.ErrorResponse
+// @Failure      500  {object}  models.ErrorResponse
+// @Router       /projects/{project_id}/settings [get]
+func (h *ProjectHandler) GetProjectSettings(c *gin.Context) {
+	projectID, err := primitive.ObjectIDFromHex(c.Param("project_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid project_id format in path",
+		})
+		return
+	}
+
+	if !RequireProjectPermission(c, h.repo, projectID, h.superAdmins, PermissionViewProject) {
+		return
+	}
+
+	if _, err := h.repo.GetProjectByID(c.Request.Context(), projectID); err != nil {
+		if err == mongo.ErrNoDocuments {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": "Project not found",
+			})
+			return
+		}
+		log.Printf("Failed to get project %s: %v", projectID.Hex(), err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to get project",
+		})
+		return
+	}
+
+	settings, err := h.repo.GetProjectSettings(c.Request.Context(), projectID)
+	if err != nil {
+		log.Printf("Failed to get settings for project %s: %v", projectID.Hex(), err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to get project settings",
+		})
+		return
+	}
+	if settings == nil {
+		settings = &models.ProjectSettings{ProjectID: projectID}
+	}
+
+	c.JSON(http.StatusOK, settings)
+}
+
+// UpdateProjectSettings replaces a project's default settings
+// @Summary      Update project settings
+// @Description  Replace the project's defaults. Tasks that set their own timezone or timeout_seconds keep using them; zero values disable the corresponding default. metadata_schema is a JSON Schema that the metadata of tasks created or updated afterwards must match. check_in_mode LENIENT recreates executions the server has no record of when an SDK reports them with an X-Task-UUID header. log_retention replaces the server's retention of the logs of successful and failed executions; omit it to use the server defaults. flap_threshold is how many times a task may flip between success and failure within an hour before it is marked flapping and its alerts are held back.
+// @Tags         projects
+// @Accept       json
+// @Produce      json
+// @Param        project_id path string true "Project ID"
+// @Param        settings body models.UpdateProjectSettingsRequest true "Project settings"
+// @Success      200  {object}  models.ProjectSettings
+// @Failure      400  {object}  models.ErrorResponse
+// @Failure      403  {object}  models.ErrorResponse
+// @Failure      404  {object}  models.ErrorResponse
+// @Failure      500  {object}  models.ErrorResponse
+// @Router       /projects/{project_id}/settings [put]
+func (h *ProjectHandler) UpdateProjectSettings(c *gin.Context) {
+	var req models.UpdateProjectSettingsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.HandleValidationError(c, err)
+		return
+	}
+
+	projectID, err := primitive.ObjectIDFromHex(c.Param("project_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid project_id format in path",
+		})
+		return
+	}
+
+	if !RequireProjectPermission(c, h.repo, projectID, h.superAdmins, PermissionManageProject) {
+		return
+	}
+
+	ctx := c.Request.Context()
+	if _, err := h.repo.GetProjectByID(ctx, projectID); err != nil {
+		if err == mongo.ErrNoDocuments {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": "Project not found",
+			})
+			return
+		}
+		log.Printf("Failed to get project %s: %v", projectID.Hex(), err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to get project",
+		})
+		return
+	}
+
+	previous, err := h.repo.GetProjectSettings(ctx, projectID)
+	if err != nil {
+		log.Printf("Failed to get settings for project %s: %v", projectID.Hex(), err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to get project settings",
+		})
+		return
+	}
+
+	settings := &models.ProjectSettings{
+		ProjectID:              projectID,
+		DefaultTimezone:        req.DefaultTimezone,
+		ExecutionRetentionDays: req.ExecutionRetentionDays,
+		AlertThrottleMinutes:   req.AlertThrottleMinutes,
+		FlapThreshold:          req.FlapThreshold,
+		DispatchRateLimit:      req.DispatchRateLimit,
+		DefaultTimeoutSeconds:  req.DefaultTimeoutSeconds,
+		MetadataSchema:         req.MetadataSchema,
+		CheckInMode:            req.CheckInMode,
+		LogRetention:           req.LogRetention,
+	}
+
+	// Existing tasks are not re-validated; the schema applies to tasks created or updated from now on
+	if settings.HasMetadataSchema() {
+		if _, err := jsonschema.Compile(settings.MetadataSchema); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error":   "Invalid metadata_schema",
+				"details": []string{err.Error()},
+			})
+			return
+		}
+	} else {
+		settings.MetadataSchema = nil
+	}
+
+	if err := h.repo.UpsertProjectSettings(ctx, settings); err != nil {
+		log.Printf("Failed to update settings for project %s: %v", projectID.Hex(), err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to update project settings",
+		})
+		return
+	}
+
+	// Tasks without their own timezone are scheduled in the project default, so reschedule them when it changes
+	previousTimezone := ""
+	if previous != nil {
+		previousTimezone = previous.DefaultTimezone
+	}
+	if previousTimezone != settings.DefaultTimezone {
+		h.rescheduleProjectTasks(ctx, projectID)
+	}
+
+	log.Printf("Project settings updated: project=%s", projectID.Hex())
+	c.JSON(http.StatusOK, settings)
+}
+
+// rescheduleProjectTasks publishes TaskUpdated for every task so the scheduler re-registers it with the current settings
+func (h *ProjectHandler) rescheduleProjectTasks(ctx context.Context, projectID primitive.ObjectID) {
+	if h.eventBus == nil {
+		return
+	}
+
+	tasks, err := h.repo.GetTasksByProjectID(ctx, projectID)
+	if err != nil {
+		log.Printf("Failed to get tasks to reschedule for project %s: %v", projectID.Hex(), err)
+		return
+	}
+
+	for _, task := range tasks {
+		if task.ScheduleConfig.Timezone != "" {
+			continue
+		}
+		if err := events.Publish(h.eventBus, events.TaskUpdatedTopic, events.TaskPayload{Task: task}); err != nil {
+			log.Printf("Failed to reschedule task %s: %v", task.UUID, err)
+		}
+	}
+}
@@ -0,0 +1,636 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yourusername/cron-observer/backend/internal/deletequeue"
+	"github.com/yourusername/cron-observer/backend/internal/events"
+	"github.com/yourusername/cron-observer/backend/internal/middleware"
+	"github.com/yourusername/cron-observer/backend/internal/models"
+	"github.com/yourusername/cron-observer/backend/internal/repositories"
+	"github.com/yourusername/cron-observer/backend/mocks"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.uber.org/mock/gomock"
+)
+
+// setupProjectRouter returns a router that authenticates every request as the given email
+func setupProjectRouter(email string) *gin.Engine {
+	router := setupRouter()
+	router.Use(func(c *gin.Context) {
+		c.Set(middleware.UserContextKey, middleware.UserInfo{Email: email})
+		c.Next()
+	})
+	return router
+}
+
+func TestProjectHandler_GetAllProjects_HidesSecretsFromNonAdmins(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	organizationID := primitive.NewObjectID()
+	newProject := func(role models.ProjectUserRole, organization *primitive.ObjectID) *models.Project {
+		return &models.Project{
+			ID:              primitive.NewObjectID(),
+			APIKey:          "primary-key",
+			StatusPageToken: "status-token",
+			Environments:    []models.ProjectEnvironment{{Name: "staging", APIKey: "staging-key"}},
+			ScopedAPIKeys:   []models.ScopedAPIKey{{ID: "key-1", Key: "scoped-key"}},
+			OrganizationID:  organization,
+			ProjectUsers:    []models.ProjectUser{{Email: "user@example.com", Role: role}},
+		}
+	}
+	administered := newProject(models.ProjectUserRoleAdmin, nil)
+	edited := newProject(models.ProjectUserRoleEditor, nil)
+	viewed := newProject(models.ProjectUserRoleViewer, nil)
+	organizationProject := newProject(models.ProjectUserRoleViewer, &organizationID)
+
+	repo := mocks.NewMockRepository(ctrl)
+	handler := NewProjectHandler(repo, nil, middleware.NewSuperAdmins([]string{}), nil)
+
+	repo.EXPECT().GetUserProjects(gomock.Any(), "user@example.com").Return([]*models.Project{administered, edited, viewed, organizationProject}, nil)
+	repo.EXPECT().GetUserOrganizations(gomock.Any(), "user@example.com").Return([]*models.Organization{{
+		ID:      organizationID,
+		Members: []models.OrganizationMember{{Email: "user@example.com", Role: models.OrganizationRoleAdmin}},
+	}}, nil)
+
+	router := setupProjectRouter("user@example.com")
+	router.GET("/api/v1/projects", handler.GetAllProjects)
+
+	req, _ := http.NewRequest("GET", "/api/v1/projects", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status code %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	var projects []models.Project
+	if err := json.Unmarshal(w.Body.Bytes(), &projects); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+	if len(projects) != 4 {
+		t.Fatalf("Expected 4 projects, got %d", len(projects))
+	}
+
+	for i, wantSecrets := range []bool{true, false, false, true} {
+		project := projects[i]
+		hasSecrets := project.APIKey != "" || project.StatusPageToken != "" || project.Environments[0].APIKey != "" || project.ScopedAPIKeys[0].Key != ""
+		if hasSecrets != wantSecrets {
+			t.Errorf("Project %d: expected secrets shown = %v, got %+v", i, wantSecrets, project)
+		}
+		if project.Environments[0].Name != "staging" || project.ScopedAPIKeys[0].ID != "key-1" {
+			t.Errorf("Project %d: expected environments and scoped keys to be listed, got %+v", i, project)
+		}
+	}
+	if viewed.APIKey != "primary-key" || viewed.Environments[0].APIKey != "staging-key" || viewed.ScopedAPIKeys[0].Key != "scoped-key" {
+		t.Error("Expected the repository's project to be left unchanged")
+	}
+}
+
+func TestProjectHandler_GetAllProjects_ListsProjectsOfAdministeredOrganizations(t *testing.T) {
+	ctx := context.Background()
+	repo := repositories.NewMemoryRepository()
+
+	organization := &models.Organization{
+		UUID: "o-1",
+		Name: "Payments",
+		Members: []models.OrganizationMember{
+			{Email: "lead@example.com", Role: models.OrganizationRoleAdmin},
+			{Email: "dev@example.com", Role: models.OrganizationRoleMember},
+		},
+	}
+	if err := repo.CreateOrganization(ctx, organization); err != nil {
+		t.Fatalf("CreateOrganization: %v", err)
+	}
+	// Neither user is a member of the projects themselves
+	child := &models.Project{ID: primitive.NewObjectID(), UUID: "p-1", Name: "Billing", APIKey: "billing-key", OrganizationID: &organization.ID}
+	other := &models.Project{ID: primitive.NewObjectID(), UUID: "p-2", Name: "Search", APIKey: "search-key"}
+	for _, project := range []*models.Project{child, other} {
+		if err := repo.CreateProject(ctx, project); err != nil {
+			t.Fatalf("CreateProject: %v", err)
+		}
+	}
+	handler := NewProjectHandler(repo, nil, middleware.NewSuperAdmins([]string{}), nil)
+
+	list := func(email string) []models.Project {
+		router := setupProjectRouter(email)
+		router.GET("/api/v1/projects", handler.GetAllProjects)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/projects", nil))
+		if w.Code != http.StatusOK {
+			t.Fatalf("Expected status %d for %s, got %d: %s", http.StatusOK, email, w.Code, w.Body.String())
+		}
+		var projects []models.Project
+		if err := json.Unmarshal(w.Body.Bytes(), &projects); err != nil {
+			t.Fatalf("Failed to unmarshal response: %v", err)
+		}
+		return projects
+	}
+
+	// Organization admins manage the organization's projects, so they are listed with their API key
+	if projects := list("lead@example.com"); len(projects) != 1 || projects[0].UUID != "p-1" || projects[0].APIKey != "billing-key" {
+		t.Errorf("Expected the organization admin to list the organization's project with its API key, got %+v", projects)
+	}
+	// Organization members only see the projects they are members of
+	if projects := list("dev@example.com"); len(projects) != 0 {
+		t.Errorf("Expected no projects for an organization member, got %+v", projects)
+	}
+}
+
+func TestProjectHandler_DeleteProject_QueuesDeleteJob(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	projectID := primitive.NewObjectID()
+	project := &models.Project{
+		ID:   projectID,
+		Name: "doomed",
+		ProjectUsers: []models.ProjectUser{
+			{Email: "admin@example.com", Role: models.ProjectUserRoleAdmin},
+		},
+	}
+
+	repo := mocks.NewMockRepository(ctrl)
+	deletePublisher := mocks.NewMockDeleteJobPublisher(ctrl)
+
+	handler := NewProjectHandler(repo, nil, middleware.NewSuperAdmins([]string{}), deletePublisher)
+
+	repo.EXPECT().GetProjectByID(gomock.Any(), projectID).Return(project, nil).AnyTimes()
+	repo.EXPECT().UpdateProjectStatus(gomock.Any(), projectID, models.ProjectStatusPendingDelete).Return(nil)
+	deletePublisher.EXPECT().
+		PublishDeleteProject(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, msg deletequeue.DeleteProjectMessage) error {
+			if msg.ProjectID != projectID.Hex() {
+				t.Errorf("Expected ProjectID %s, got %s", projectID.Hex(), msg.ProjectID)
+			}
+			return nil
+		})
+
+	// Nothing is removed inside the request
+	repo.EXPECT().GetTasksByProjectID(gomock.Any(), gomock.Any()).Times(0)
+	repo.EXPECT().DeleteProject(gomock.Any(), gomock.Any()).Times(0)
+
+	router := setupProjectRouter("admin@example.com")
+	router.DELETE("/api/v1/projects/:project_id", handler.DeleteProject)
+
+	req, _ := http.NewRequest("DELETE", "/api/v1/projects/"+projectID.Hex(), nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusAccepted {
+		t.Errorf("Expected status code %d, got %d: %s", http.StatusAccepted, w.Code, w.Body.String())
+	}
+}
+
+func TestProjectHandler_DeleteProject_PublishFailureRestoresStatus(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	projectID := primitive.NewObjectID()
+	project := &models.Project{ID: projectID, Name: "doomed", Status: models.ProjectStatusArchived}
+
+	repo := mocks.NewMockRepository(ctrl)
+	deletePublisher := mocks.NewMockDeleteJobPublisher(ctrl)
+
+	handler := NewProjectHandler(repo, nil, middleware.NewSuperAdmins([]string{"root@example.com"}), deletePublisher)
+
+	repo.EXPECT().GetProjectByID(gomock.Any(), projectID).Return(project, nil)
+	gomock.InOrder(
+		repo.EXPECT().UpdateProjectStatus(gomock.Any(), projectID, models.ProjectStatusPendingDelete).Return(nil),
+		deletePublisher.EXPECT().PublishDeleteProject(gomock.Any(), gomock.Any()).Return(errors.New("broker unavailable")),
+		repo.EXPECT().UpdateProjectStatus(gomock.Any(), projectID, models.ProjectStatusArchived).Return(nil),
+	)
+	repo.EXPECT().DeleteProject(gomock.Any(), gomock.Any()).Times(0)
+
+	router := setupProjectRouter("root@example.com")
+	router.DELETE("/api/v1/projects/:project_id", handler.DeleteProject)
+
+	req, _ := http.NewRequest("DELETE", "/api/v1/projects/"+projectID.Hex(), nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusInternalServerError {
+		t.Errorf("Expected status code %d, got %d", http.StatusInternalServerError, w.Code)
+	}
+}
+
+func TestProjectHandler_ArchiveProject_NotifiesScheduler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	projectID := primitive.NewObjectID()
+	project := &models.Project{ID: projectID, Name: "quiet", Status: models.ProjectStatusActive}
+
+	repo := mocks.NewMockRepository(ctrl)
+	eventBus := events.NewEventBus(100)
+	defer eventBus.Close()
+	archivedCh := eventBus.Subscribe(events.ProjectArchived)
+
+	handler := NewProjectHandler(repo, eventBus, middleware.NewSuperAdmins([]string{"root@example.com"}), nil)
+
+	repo.EXPECT().GetProjectByID(gomock.Any(), projectID).Return(project, nil)
+	repo.EXPECT().UpdateProjectStatus(gomock.Any(), projectID, models.ProjectStatusArchived).Return(nil)
+
+	router := setupProjectRouter("root@example.com")
+	router.POST("/api/v1/projects/:project_id/archive", handler.ArchiveProject)
+
+	req, _ := http.NewRequest("POST", "/api/v1/projects/"+projectID.Hex()+"/archive", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status code %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+
+	select {
+	case event := <-archivedCh:
+		payload, ok := event.Payload.(events.ProjectPayload)
+		if !ok || payload.Project.ID != projectID {
+			t.Errorf("Unexpected ProjectArchived payload: %+v", event.Payload)
+		}
+	case <-time.After(time.Second):
+		t.Error("Expected ProjectArchived event to be published")
+	}
+}
+
+func TestProjectHandler_RestoreProject_RejectsActiveProject(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	projectID := primitive.NewObjectID()
+
+	repo := mocks.NewMockRepository(ctrl)
+	handler := NewProjectHandler(repo, nil, middleware.NewSuperAdmins([]string{"root@example.com"}), nil)
+
+	repo.EXPECT().GetProjectByID(gomock.Any(), projectID).Return(&models.Project{ID: projectID}, nil)
+	repo.EXPECT().UpdateProjectStatus(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+	router := setupProjectRouter("root@example.com")
+	router.POST("/api/v1/projects/:project_id/restore", handler.RestoreProject)
+
+	req, _ := http.NewRequest("POST", "/api/v1/projects/"+projectID.Hex()+"/restore", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusConflict {
+		t.Errorf("Expected status code %d, got %d", http.StatusConflict, w.Code)
+	}
+}
+
+func TestProjectHandler_CloneProject_CopiesTasksDisabled(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	projectID := primitive.NewObjectID()
+	groupID := primitive.NewObjectID()
+	source := &models.Project{
+		ID:                projectID,
+		Name:              "prod",
+		APIKey:            "source-key",
+		ExecutionEndpoint: "https://prod.example.com/execute",
+		Environments:      []models.ProjectEnvironment{{Name: "eu", APIKey: "eu-key"}},
+	}
+	taskGroups := []*models.TaskGroup{{ID: groupID, UUID: "group-1", ProjectID: projectID, Status: models.TaskGroupStatusActive}}
+	tasks := []*models.Task{
+		{ID: primitive.NewObjectID(), UUID: "task-1", ProjectID: projectID, TaskGroupID: &groupID, Status: models.TaskStatusActive},
+		{ID: primitive.NewObjectID(), UUID: "task-2", ProjectID: projectID, Status: models.TaskStatusActive},
+	}
+
+	repo := mocks.NewMockRepository(ctrl)
+	handler := NewProjectHandler(repo, nil, middleware.NewSuperAdmins([]string{"root@example.com"}), nil)
+
+	repo.EXPECT().GetProjectByID(gomock.Any(), projectID).Return(source, nil)
+	repo.EXPECT().GetProjectByName(gomock.Any(), "staging").Return(nil, mongo.ErrNoDocuments)
+	repo.EXPECT().GetTaskGroupsByProjectID(gomock.Any(), projectID).Return(taskGroups, nil)
+	repo.EXPECT().GetTasksByProjectID(gomock.Any(), projectID).Return(tasks, nil)
+
+	var clone *models.Project
+	repo.EXPECT().CreateProject(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, project *models.Project) error {
+		clone = project
+		return nil
+	})
+
+	var clonedGroup *models.TaskGroup
+	repo.EXPECT().CreateTaskGroup(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, projectID string, taskGroup *models.TaskGroup) error {
+		clonedGroup = taskGroup
+		return nil
+	})
+
+	var clonedTasks []*models.Task
+	repo.EXPECT().CreateTask(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, projectID string, task *models.Task) error {
+		clonedTasks = append(clonedTasks, task)
+		return nil
+	}).Times(2)
+
+	router := setupProjectRouter("root@example.com")
+	router.POST("/api/v1/projects/:project_id/clone", handler.CloneProject)
+
+	req, _ := http.NewRequest("POST", "/api/v1/projects/"+projectID.Hex()+"/clone", strings.NewReader(`{"name":"staging"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusCreated {
+		t.Fatalf("Expected status code %d, got %d: %s", http.StatusCreated, w.Code, w.Body.String())
+	}
+
+	if clone.ID == projectID || clone.APIKey == source.APIKey || clone.Environments[0].APIKey == "eu-key" {
+		t.Errorf("Clone must get a new ID and new API keys: %+v", clone)
+	}
+	if clonedGroup.ProjectID != clone.ID || clonedGroup.UUID == "group-1" || clonedGroup.Status != models.TaskGroupStatusDisabled {
+		t.Errorf("Unexpected cloned task group: %+v", clonedGroup)
+	}
+	for _, task := range clonedTasks {
+		if task.ProjectID != clone.ID || task.Status != models.TaskStatusDisabled || task.UUID == "task-1" || task.UUID == "task-2" {
+			t.Errorf("Unexpected cloned task: %+v", task)
+		}
+	}
+	if clonedTasks[0].TaskGroupID == nil || *clonedTasks[0].TaskGroupID != clonedGroup.ID {
+		t.Errorf("Expected cloned task to reference cloned group %s, got %v", clonedGroup.ID.Hex(), clonedTasks[0].TaskGroupID)
+	}
+}
+
+func TestProjectHandler_UpdateProjectSettings_ReschedulesTasksWithoutTimezone(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	projectID := primitive.NewObjectID()
+	project := &models.Project{ID: projectID, Name: "settings"}
+	tasks := []*models.Task{
+		{UUID: "inherits", ProjectID: projectID},
+		{UUID: "explicit", ProjectID: projectID, ScheduleConfig: models.ScheduleConfig{Timezone: "UTC"}},
+	}
+
+	repo := mocks.NewMockRepository(ctrl)
+	eventBus := events.NewEventBus(100)
+	defer eventBus.Close()
+	updatedCh := eventBus.Subscribe(events.TaskUpdated)
+
+	handler := NewProjectHandler(repo, eventBus, middleware.NewSuperAdmins([]string{"root@example.com"}), nil)
+
+	repo.EXPECT().GetProjectByID(gomock.Any(), projectID).Return(project, nil)
+	repo.EXPECT().GetProjectSettings(gomock.Any(), projectID).Return(nil, nil)
+	repo.EXPECT().
+		UpsertProjectSettings(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, settings *models.ProjectSettings) error {
+			if settings.ProjectID != projectID || settings.DefaultTimezone != "Europe/Berlin" || settings.DefaultTimeoutSeconds != 300 {
+				t.Errorf("Unexpected settings: %+v", settings)
+			}
+			return nil
+		})
+	repo.EXPECT().GetTasksByProjectID(gomock.Any(), projectID).Return(tasks, nil)
+
+	router := setupProjectRouter("root@example.com")
+	router.PUT("/api/v1/projects/:project_id/settings", handler.UpdateProjectSettings)
+
+	body := `{"default_timezone":"Europe/Berlin","execution_retention_days":30,"alert_throttle_minutes":15,"default_timeout_seconds":300}`
+	req, _ := http.NewRequest("PUT", "/api/v1/projects/"+projectID.Hex()+"/settings", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status code %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+
+	select {
+	case event := <-updatedCh:
+		payload, ok := event.Payload.(events.TaskPayload)
+		if !ok || payload.Task.UUID != "inherits" {
+			t.Errorf("Unexpected TaskUpdated payload: %+v", event.Payload)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Expected TaskUpdated event for the task without a timezone")
+	}
+
+	select {
+	case event := <-updatedCh:
+		t.Errorf("Expected no TaskUpdated event for the task with its own timezone, got %+v", event.Payload)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func TestProjectConfigHandler_ImportProjectConfig_CreatesGroupAndUpdatesTaskByName(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	projectID := primitive.NewObjectID()
+	project := &models.Project{ID: projectID, Name: "imports"}
+	existing := &models.Task{
+		ID:           primitive.NewObjectID(),
+		UUID:         "task-uuid",
+		ProjectID:    projectID,
+		Name:         "nightly",
+		ScheduleType: models.ScheduleTypeRecurring,
+		Status:       models.TaskStatusActive,
+		State:        models.TaskStateRunning,
+	}
+
+	repo := mocks.NewMockRepository(ctrl)
+	eventBus := events.NewEventBus(100)
+	defer eventBus.Close()
+	updatedCh := eventBus.Subscribe(events.TaskUpdated)
+
+	handler := NewProjectConfigHandler(repo, eventBus, middleware.NewSuperAdmins([]string{"root@example.com"}))
+
+	repo.EXPECT().GetProjectByID(gomock.Any(), projectID).Return(project, nil)
+	repo.EXPECT().GetTaskGroupsByProjectID(gomock.Any(), projectID).Return([]*models.TaskGroup{}, nil)
+	repo.EXPECT().GetTasksByProjectID(gomock.Any(), projectID).Return([]*models.Task{existing}, nil)
+	repo.EXPECT().GetProjectSettings(gomock.Any(), projectID).Return(nil, nil)
+
+	var createdGroup *models.TaskGroup
+	repo.EXPECT().CreateTaskGroup(gomock.Any(), projectID.Hex(), gomock.Any()).DoAndReturn(func(ctx context.Context, projectID string, taskGroup *models.TaskGroup) error {
+		createdGroup = taskGroup
+		return nil
+	})
+
+	var updated *models.Task
+	repo.EXPECT().UpdateTask(gomock.Any(), "task-uuid", gomock.Any()).DoAndReturn(func(ctx context.Context, taskUUID string, task *models.Task) error {
+		updated = task
+		return nil
+	})
+
+	router := setupValidatedRouter(t, "root@example.com")
+	router.POST("/api/v1/projects/:project_id/import", handler.ImportProjectConfig)
+
+	body := `version: 1
+task_groups:
+  - name: reports
+    start_time: "08:00"
+    end_time: "18:00"
+tasks:
+  - name: nightly
+    task_group: reports
+    schedule_type: RECURRING
+    schedule_config:
+      cron_expression: "0 0 3 * * *"
+    tags: [reports, reports]
+`
+	req, _ := http.NewRequest("POST", "/api/v1/projects/"+projectID.Hex()+"/import", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/x-yaml")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status code %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	if !strings.Contains(w.Body.String(), `"task_groups_created":1`) || !strings.Contains(w.Body.String(), `"tasks_updated":1`) {
+		t.Errorf("Unexpected import summary: %s", w.Body.String())
+	}
+
+	if createdGroup.Name != "reports" || createdGroup.Status != models.TaskGroupStatusActive {
+		t.Errorf("Unexpected created task group: %+v", createdGroup)
+	}
+	if updated.ID != existing.ID || updated.State != models.TaskStateRunning || updated.Status != models.TaskStatusActive {
+		t.Errorf("Expected identity, state and status to be preserved: %+v", updated)
+	}
+	if updated.TaskGroupID == nil || *updated.TaskGroupID != createdGroup.ID {
+		t.Errorf("Expected task to reference the created group %s, got %v", createdGroup.ID.Hex(), updated.TaskGroupID)
+	}
+	if updated.ScheduleConfig.CronExpression != "0 0 3 * * *" || len(updated.Tags) != 1 || updated.Tags[0] != "reports" {
+		t.Errorf("Unexpected imported task fields: %+v", updated)
+	}
+
+	select {
+	case event := <-updatedCh:
+		payload, ok := event.Payload.(events.TaskPayload)
+		if !ok || payload.Task.UUID != "task-uuid" {
+			t.Errorf("Unexpected TaskUpdated payload: %+v", event.Payload)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Expected TaskUpdated event for the imported task")
+	}
+}
+
+func TestProjectConfigHandler_ImportProjectConfig_RejectsUnknownTaskGroup(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	projectID := primitive.NewObjectID()
+	repo := mocks.NewMockRepository(ctrl)
+	handler := NewProjectConfigHandler(repo, nil, middleware.NewSuperAdmins([]string{"root@example.com"}))
+
+	repo.EXPECT().GetProjectByID(gomock.Any(), projectID).Return(&models.Project{ID: projectID}, nil)
+	repo.EXPECT().GetTaskGroupsByProjectID(gomock.Any(), projectID).Return([]*models.TaskGroup{}, nil)
+	repo.EXPECT().GetTasksByProjectID(gomock.Any(), projectID).Return([]*models.Task{}, nil)
+
+	router := setupValidatedRouter(t, "root@example.com")
+	router.POST("/api/v1/projects/:project_id/import", handler.ImportProjectConfig)
+
+	body := `{"tasks":[{"name":"orphan","task_group":"missing","schedule_type":"RECURRING","schedule_config":{"cron_expression":"0 * * * * *"}}]}`
+	req, _ := http.NewRequest("POST", "/api/v1/projects/"+projectID.Hex()+"/import", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("Expected status code %d, got %d: %s", http.StatusBadRequest, w.Code, w.Body.String())
+	}
+}
+
+func TestProjectHandler_UpdateProject_ReplacesOnPutAndMergesOnPatch(t *testing.T) {
+	tests := []struct {
+		method      string
+		contentType string
+		description string
+	}{
+		{http.MethodPut, "application/json", ""},
+		{http.MethodPut, "application/json; charset=utf-8", ""},
+		{http.MethodPatch, "application/json", "nightly jobs"},
+		{http.MethodPatch, "application/merge-patch+json", "nightly jobs"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.method+" "+tt.contentType, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			projectID := primitive.NewObjectID()
+			project := &models.Project{
+				ID:           projectID,
+				Name:         "billing",
+				Description:  "nightly jobs",
+				ProjectUsers: []models.ProjectUser{{Email: "admin@example.com", Role: models.ProjectUserRoleAdmin}},
+			}
+			repo := mocks.NewMockRepository(ctrl)
+			repo.EXPECT().GetProjectByID(gomock.Any(), projectID).Return(project, nil).AnyTimes()
+			var updated *models.Project
+			repo.EXPECT().UpdateProject(gomock.Any(), projectID, gomock.Any()).DoAndReturn(func(ctx context.Context, id primitive.ObjectID, p *models.Project) error {
+				updated = p
+				return nil
+			})
+
+			handler := NewProjectHandler(repo, nil, middleware.NewSuperAdmins(nil), nil)
+			router := setupProjectRouter("admin@example.com")
+			router.Handle(tt.method, "/projects/:project_id", handler.UpdateProject)
+
+			req := httptest.NewRequest(tt.method, "/projects/"+projectID.Hex(), strings.NewReader(`{"alert_emails":"ops@example.com"}`))
+			req.Header.Set("Content-Type", tt.contentType)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != http.StatusOK {
+				t.Fatalf("Expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+			}
+			if updated.Description != tt.description {
+				t.Errorf("Expected description %q, got %q", tt.description, updated.Description)
+			}
+			if updated.AlertEmails != "ops@example.com" {
+				t.Errorf("Expected the sent alert_emails to be applied, got %q", updated.AlertEmails)
+			}
+		})
+	}
+}
+
+func TestProjectHandler_UpdateProject_OnlySuperAdminsChangeRateLimits(t *testing.T) {
+	tests := []struct {
+		name       string
+		email      string
+		body       string
+		wantStatus int
+	}{
+		{"project admin raises limits", "admin@example.com", `{"rate_limits":{"log_appends_per_minute":100000,"status_updates_per_minute":120}}`, http.StatusForbidden},
+		{"project admin resends current limits", "admin@example.com", `{"name":"billing","rate_limits":{"log_appends_per_minute":600,"status_updates_per_minute":120}}`, http.StatusOK},
+		{"super admin raises limits", "root@example.com", `{"rate_limits":{"log_appends_per_minute":100000,"status_updates_per_minute":120}}`, http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			projectID := primitive.NewObjectID()
+			project := &models.Project{
+				ID:           projectID,
+				Name:         "billing",
+				RateLimits:   &models.ProjectRateLimits{LogAppendsPerMinute: 600, StatusUpdatesPerMinute: 120},
+				ProjectUsers: []models.ProjectUser{{Email: "admin@example.com", Role: models.ProjectUserRoleAdmin}},
+			}
+			repo := mocks.NewMockRepository(ctrl)
+			repo.EXPECT().GetProjectByID(gomock.Any(), projectID).Return(project, nil).AnyTimes()
+			repo.EXPECT().GetProjectByName(gomock.Any(), "billing").Return(project, nil).AnyTimes()
+			if tt.wantStatus == http.StatusOK {
+				repo.EXPECT().UpdateProject(gomock.Any(), projectID, gomock.Any()).Return(nil)
+			}
+
+			handler := NewProjectHandler(repo, nil, middleware.NewSuperAdmins([]string{"root@example.com"}), nil)
+			router := setupProjectRouter(tt.email)
+			router.PATCH("/projects/:project_id", handler.UpdateProject)
+
+			req := httptest.NewRequest(http.MethodPatch, "/projects/"+projectID.Hex(), strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("Expected status %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,283 @@
+package handlers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yourusername/cron-observer/backend/internal/middleware"
+	"github.com/yourusername/cron-observer/backend/internal/models"
+	"github.com/yourusername/cron-observer/backend/internal/quota"
+	"github.com/yourusername/cron-observer/backend/internal/repositories"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// QuotaHandler reports quota usage of projects and lets super admins override the server-wide quotas for a project
+// or an organization
+type QuotaHandler struct {
+	repo        repositories.Repository
+	quotas      *quota.Service
+	superAdmins *middleware.SuperAdmins
+}
+
+func NewQuotaHandler(repo repositories.Repository, quotas *quota.Service, superAdmins *middleware.SuperAdmins) *QuotaHandler {
+	return &QuotaHandler{
+		repo:        repo,
+		quotas:      quotas,
+		superAdmins: superAdmins,
+	}
+}
+
+// GetProjectQuotas reports the project's effective quotas and usage
+// @Summary      Get project quota usage
+// @Description  Get the quotas in effect for the project (project overrides, then organization overrides, then server defaults; 0 is unlimited), the project's own overrides, and its current task count and executions started today (UTC)
+// @Tags         quotas
+// @Produce      json
+// @Param        project_id path string true "Project ID"
+// @Success      200  {object}  models.QuotaUsageResponse
+// @Failure      400  {object}  models.ErrorResponse
+// @Failure      403  {object}  models.ErrorResponse
+// @Failure      404  {object}  models.ErrorResponse
+// @Failure      500  {object}  models.ErrorResponse
+// @Router       /projects/{project_id}/quotas [get]
+func (h *QuotaHandler) GetProjectQuotas(c *gin.Context) {
+	projectID, err := primitive.ObjectIDFromHex(c.Param("project_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid project_id format in path",
+		})
+		return
+	}
+
+	if !RequireProjectPermission(c, h.repo, projectID, h.superAdmins, PermissionViewProject) {
+		return
+	}
+
+	project, ok := getProject(c, h.repo, projectID)
+	if !ok {
+		return
+	}
+
+	usage, err := h.quotas.Usage(c.Request.Context(), project)
+	if err != nil {
+		log.Printf("Failed to get quota usage of project %s: %v", projectID.Hex(), err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to get quota usage",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, usage)
+}
+
+// SetProjectQuotas overrides the quotas of a project
+// @Summary      Set project quotas
+// @Description  Override quotas for the project. Non-zero values replace the organization's or the server's quotas; an empty body or all zeros removes the overrides. Super admins only.
+// @Tags         quotas
+// @Accept       json
+// @Produce      json
+// @Param        project_id path string true "Project ID"
+// @Param        quotas body models.ProjectQuotas false "Quota overrides"
+// @Success      200  {object}  models.QuotaUsageResponse
+// @Failure      400  {object}  models.ErrorResponse
+// @Failure      401  {object}  models.ErrorResponse
+// @Failure      403  {object}  models.ErrorResponse
+// @Failure      404  {object}  models.ErrorResponse
+// @Failure      500  {object}  models.ErrorResponse
+// @Router       /projects/{project_id}/quotas [put]
+func (h *QuotaHandler) SetProjectQuotas(c *gin.Context) {
+	projectID, err := primitive.ObjectIDFromHex(c.Param("project_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid project_id format in path",
+		})
+		return
+	}
+
+	if !requireSuperAdmin(c, h.superAdmins) {
+		return
+	}
+
+	quotas, ok := bindQuotas(c)
+	if !ok {
+		return
+	}
+
+	ctx := c.Request.Context()
+	if err := h.repo.SetProjectQuotas(ctx, projectID, quotas); err != nil {
+		if err == mongo.ErrNoDocuments {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": "Project not found",
+			})
+			return
+		}
+		log.Printf("Failed to set quotas of project %s: %v", projectID.Hex(), err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to set project quotas",
+		})
+		return
+	}
+	log.Printf("Quotas of project %s set to %+v", projectID.Hex(), quotas)
+
+	project, ok := getProject(c, h.repo, projectID)
+	if !ok {
+		return
+	}
+	usage, err := h.quotas.Usage(ctx, project)
+	if err != nil {
+		log.Printf("Failed to get quota usage of project %s: %v", projectID.Hex(), err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to get quota usage",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, usage)
+}
+
+// SetOrganizationQuotas overrides the quotas of all projects of an organization
+// @Summary      Set organization quotas
+// @Description  Override quotas for every project of the organization. Non-zero values replace the server's quotas and are themselves replaced by project overrides; an empty body or all zeros removes the overrides. Super admins only.
+// @Tags         quotas
+// @Accept       json
+// @Produce      json
+// @Param        org_id path string true "Organization ID"
+// @Param        quotas body models.ProjectQuotas false "Quota overrides"
+// @Success      200  {object}  models.Organization
+// @Failure      400  {object}  models.ErrorResponse
+// @Failure      401  {object}  models.ErrorResponse
+// @Failure      403  {object}  models.ErrorResponse
+// @Failure      404  {object}  models.ErrorResponse
+// @Failure      500  {object}  models.ErrorResponse
+// @Router       /organizations/{org_id}/quotas [put]
+func (h *QuotaHandler) SetOrganizationQuotas(c *gin.Context) {
+	orgID, err := primitive.ObjectIDFromHex(c.Param("org_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid org_id format in path",
+		})
+		return
+	}
+
+	if !requireSuperAdmin(c, h.superAdmins) {
+		return
+	}
+
+	quotas, ok := bindQuotas(c)
+	if !ok {
+		return
+	}
+
+	ctx := c.Request.Context()
+	if err := h.repo.SetOrganizationQuotas(ctx, orgID, quotas); err != nil {
+		if err == mongo.ErrNoDocuments {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": "Organization not found",
+			})
+			return
+		}
+		log.Printf("Failed to set quotas of organization %s: %v", orgID.Hex(), err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to set organization quotas",
+		})
+		return
+	}
+	log.Printf("Quotas of organization %s set to %+v", orgID.Hex(), quotas)
+
+	organization, err := h.repo.GetOrganizationByID(ctx, orgID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to get organization",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, organization)
+}
+
+// bindQuotas binds optional quota overrides from the body; nil means no overrides
+func bindQuotas(c *gin.Context) (*models.ProjectQuotas, bool) {
+	var quotas models.ProjectQuotas
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&quotas); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error":   "Invalid request body",
+				"details": []string{err.Error()},
+			})
+			return nil, false
+		}
+	}
+	if quotas.IsZero() {
+		return nil, true
+	}
+	return &quotas, true
+}
+
+// getProject loads a project, writing a 404 or 500 response when it cannot
+func getProject(c *gin.Context, repo repositories.Repository, projectID primitive.ObjectID) (*models.Project, bool) {
+	project, err := repo.GetProjectByID(c.Request.Context(), projectID)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": "Project not found",
+			})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "Failed to get project",
+			})
+		}
+		return nil, false
+	}
+	return project, true
+}
+
+// requireTaskQuota writes an error response when adding count tasks to the project would go over its task quota
+func requireTaskQuota(c *gin.Context, repo repositories.Repository, quotas *quota.Service, projectID primitive.ObjectID, count int) bool {
+	if quotas == nil {
+		return true
+	}
+	project, ok := getProject(c, repo, projectID)
+	if !ok {
+		return false
+	}
+	if err := quotas.CheckTasks(c.Request.Context(), project, count); err != nil {
+		if !respondQuotaExceeded(c, err) {
+			log.Printf("Failed to check task quota of project %s: %v", projectID.Hex(), err)
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "Failed to check project quotas",
+			})
+		}
+		return false
+	}
+	return true
+}
+
+// respondQuotaExceeded writes the response of a *quota.ExceededError and reports whether err was one. Exceeding the
+// task quota is 403, the daily execution quota 429 with Retry-After set to the reset at UTC midnight, and the log
+// quota 413.
+func respondQuotaExceeded(c *gin.Context, err error) bool {
+	var exceeded *quota.ExceededError
+	if !errors.As(err, &exceeded) {
+		return false
+	}
+
+	status := http.StatusForbidden
+	switch exceeded.Quota {
+	case quota.MaxExecutionsPerDay:
+		status = http.StatusTooManyRequests
+		now := time.Now()
+		c.Header("Retry-After", strconv.Itoa(int(quota.ResetTime(now).Sub(now).Seconds())+1))
+	case quota.MaxLogBytesPerExecution:
+		status = http.StatusRequestEntityTooLarge
+	}
+	c.JSON(status, gin.H{
+		"error": exceeded.Error(),
+		"quota": exceeded.Quota,
+		"limit": exceeded.Limit,
+	})
+	return true
+}
@@ -0,0 +1,116 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yourusername/cron-observer/backend/internal/config"
+	"github.com/yourusername/cron-observer/backend/internal/events"
+	"github.com/yourusername/cron-observer/backend/internal/middleware"
+	"github.com/yourusername/cron-observer/backend/internal/models"
+	"github.com/yourusername/cron-observer/backend/internal/quota"
+	"github.com/yourusername/cron-observer/backend/mocks"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.uber.org/mock/gomock"
+)
+
+func TestExecutionHandler_CreateClientExecution_RejectsOverDailyQuota(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockRepository(ctrl)
+	handler := NewExecutionHandler(repo, events.NewEventBus(1), middleware.NewSuperAdmins([]string{"root@example.com"}))
+	handler.SetQuotaService(quota.NewService(repo, config.QuotaConfig{MaxExecutionsPerDay: 5}))
+
+	project := &models.Project{ID: primitive.NewObjectID()}
+	task := &models.Task{ID: primitive.NewObjectID(), UUID: "task-1", ProjectID: project.ID, Status: models.TaskStatusActive}
+
+	today := time.Now().UTC().Format("2006-01-02")
+	repo.EXPECT().GetExecutionStatsByProject(gomock.Any(), project.ID, 0).Return([]*models.ExecutionStats{{Date: today, Total: 5}}, nil)
+	repo.EXPECT().CreateExecution(gomock.Any(), gomock.Any()).Times(0)
+
+	req := httptest.NewRequest(http.MethodPost, "/tasks/task-1/executions", nil)
+	w := httptest.NewRecorder()
+	setupClientExecutionRouter(handler, project, task).ServeHTTP(w, req)
+
+	if w.Code != http.StatusTooManyRequests {
+		t.Fatalf("Expected status 429, got %d: %s", w.Code, w.Body.String())
+	}
+	if w.Header().Get("Retry-After") == "" {
+		t.Error("Retry-After not set")
+	}
+	var body map[string]interface{}
+	if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if body["quota"] != quota.MaxExecutionsPerDay || body["limit"] != float64(5) {
+		t.Errorf("unexpected body: %v", body)
+	}
+}
+
+func TestExecutionHandler_AppendLogToExecution_RejectsOverLogQuota(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockRepository(ctrl)
+	handler := NewExecutionHandler(repo, events.NewEventBus(1), middleware.NewSuperAdmins([]string{"root@example.com"}))
+	handler.SetQuotaService(quota.NewService(repo, config.QuotaConfig{MaxLogBytesPerExecution: 16}))
+
+	project := &models.Project{ID: primitive.NewObjectID()}
+	execution := &models.Execution{UUID: "execution-1", Logs: []models.LogEntry{{Message: "processing batch"}}}
+	repo.EXPECT().AppendLogToExecution(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+	router := setupRouter()
+	router.POST("/executions/:execution_uuid/logs", func(c *gin.Context) {
+		c.Set(middleware.ProjectContextKey, project)
+		c.Set(middleware.ExecutionContextKey, execution)
+		c.Next()
+	}, handler.AppendLogToExecution)
+
+	req := httptest.NewRequest(http.MethodPost, "/executions/execution-1/logs", strings.NewReader(`{"message":"done","level":"info"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("Expected status 413, got %d: %s", w.Code, w.Body.String())
+	}
+}
+
+func TestTaskHandler_CreateTask_RejectsOverTaskQuota(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	project := &models.Project{
+		ID:           primitive.NewObjectID(),
+		ProjectUsers: []models.ProjectUser{{Email: "dev@example.com", Role: models.ProjectUserRoleEditor}},
+		Quotas:       &models.ProjectQuotas{MaxTasks: 2},
+	}
+
+	repo := mocks.NewMockRepository(ctrl)
+	handler := NewTaskHandler(repo, events.NewEventBus(1), &mockScheduler{}, middleware.NewSuperAdmins([]string{}), nil)
+	handler.SetQuotaService(quota.NewService(repo, config.QuotaConfig{MaxTasksPerProject: 100}))
+
+	repo.EXPECT().GetProjectByID(gomock.Any(), project.ID).Return(project, nil).AnyTimes()
+	repo.EXPECT().GetProjectSettings(gomock.Any(), project.ID).Return(&models.ProjectSettings{}, nil).AnyTimes()
+	repo.EXPECT().ListTasksByProjectID(gomock.Any(), project.ID, gomock.Any(), 1, 1).Return(nil, int64(2), nil)
+	repo.EXPECT().CreateTask(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+	router := setupValidatedRouter(t, "dev@example.com")
+	router.POST("/api/v1/projects/:project_id/tasks", handler.CreateTask)
+
+	body := `{"project_id":"` + project.ID.Hex() + `","name":"nightly","schedule_type":"RECURRING","schedule_config":{"cron_expression":"0 2 * * *"}}`
+	req, _ := http.NewRequest(http.MethodPost, "/api/v1/projects/"+project.ID.Hex()+"/tasks", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusForbidden || !strings.Contains(w.Body.String(), quota.MaxTasks) {
+		t.Errorf("Expected a 403 max_tasks error, got %d: %s", w.Code, w.Body.String())
+	}
+}
@@ -0,0 +1,63 @@
+package handlers
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yourusername/cron-observer/backend/internal/middleware"
+	"github.com/yourusername/cron-observer/backend/internal/models"
+	"github.com/yourusername/cron-observer/backend/internal/reconciler"
+)
+
+// ReconcilerHandler lets super admins run the background reconcilers on demand, e.g. during an incident
+type ReconcilerHandler struct {
+	deleteReconciler *reconciler.DeleteReconciler // nil when the delete reconciler is not running in this process
+	superAdmins      *middleware.SuperAdmins
+}
+
+func NewReconcilerHandler(deleteReconciler *reconciler.DeleteReconciler, superAdmins *middleware.SuperAdmins) *ReconcilerHandler {
+	return &ReconcilerHandler{
+		deleteReconciler: deleteReconciler,
+		superAdmins:      superAdmins,
+	}
+}
+
+// RunDeleteReconciler runs a delete reconciler cycle immediately
+// @Summary      Run the delete reconciler
+// @Description  Re-enqueue the delete jobs of tasks stuck in PENDING_DELETE or DELETE_FAILED for longer than the reconciler threshold now, instead of waiting for the next cycle. Super admin access required.
+// @Tags         admin
+// @Produce      json
+// @Success      200  {object}  models.ReconcilerRunResponse
+// @Failure      401  {object}  models.ErrorResponse
+// @Failure      403  {object}  models.ErrorResponse
+// @Failure      500  {object}  models.ErrorResponse
+// @Router       /admin/reconcilers/delete/run [post]
+func (h *ReconcilerHandler) RunDeleteReconciler(c *gin.Context) {
+	if !requireSuperAdmin(c, h.superAdmins) {
+		return
+	}
+
+	if h.deleteReconciler == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Delete reconciler not available",
+		})
+		return
+	}
+
+	result, err := h.deleteReconciler.RunOnce(c.Request.Context())
+	if err != nil {
+		log.Printf("[Handler] Delete reconciler run failed: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to run delete reconciler",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.ReconcilerRunResponse{
+		StuckFound: result.StuckFound,
+		Requeued:   result.Requeued,
+		Failed:     result.Failed,
+		Status:     h.deleteReconciler.Status(),
+	})
+}
@@ -0,0 +1,102 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/yourusername/cron-observer/backend/internal/deletequeue"
+	"github.com/yourusername/cron-observer/backend/internal/middleware"
+	"github.com/yourusername/cron-observer/backend/internal/models"
+	"github.com/yourusername/cron-observer/backend/internal/reconciler"
+	"github.com/yourusername/cron-observer/backend/mocks"
+	"go.uber.org/mock/gomock"
+)
+
+func TestReconcilerHandler_RunDeleteReconciler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockRepository(ctrl)
+	mockPublisher := mocks.NewMockDeleteJobPublisher(ctrl)
+	mockRepo.EXPECT().GetTasksByStatus(gomock.Any(), gomock.Any()).Return(stuckTasks(), nil)
+	mockPublisher.EXPECT().PublishDeleteTask(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ interface{}, msg deletequeue.DeleteTaskMessage) error {
+			if msg.TaskUUID == "task-failed" {
+				return errors.New("channel closed")
+			}
+			return nil
+		}).Times(2)
+
+	deleteReconciler := reconciler.NewDeleteReconciler(mockRepo, mockPublisher, time.Hour, 0)
+	handler := NewReconcilerHandler(deleteReconciler, middleware.NewSuperAdmins([]string{"admin@example.com"}))
+	router := setupProjectRouter("admin@example.com")
+	router.POST("/api/v1/admin/reconcilers/delete/run", handler.RunDeleteReconciler)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPost, "/api/v1/admin/reconcilers/delete/run", nil)
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var response models.ReconcilerRunResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if response.StuckFound != 2 || len(response.Requeued) != 1 || response.Requeued[0] != "task-pending" || len(response.Failed) != 1 || response.Failed[0] != "task-failed" {
+		t.Fatalf("unexpected response %+v", response)
+	}
+	status := response.Status
+	if status.Runs != 1 || status.StuckFound != 2 || status.Requeued != 1 || status.RequeueFailed != 1 {
+		t.Errorf("unexpected counters %+v", status)
+	}
+	if status.LastRunAt == nil || status.LastError != "failed to re-enqueue 1 of 2 stuck task(s): channel closed" {
+		t.Errorf("unexpected last run %+v", status)
+	}
+}
+
+func TestReconcilerHandler_RunDeleteReconciler_QueryFails(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockRepository(ctrl)
+	mockRepo.EXPECT().GetTasksByStatus(gomock.Any(), gomock.Any()).Return(nil, errors.New("server selection timeout"))
+
+	deleteReconciler := reconciler.NewDeleteReconciler(mockRepo, mocks.NewMockDeleteJobPublisher(ctrl), time.Hour, 0)
+	handler := NewReconcilerHandler(deleteReconciler, middleware.NewSuperAdmins([]string{"admin@example.com"}))
+	router := setupProjectRouter("admin@example.com")
+	router.POST("/api/v1/admin/reconcilers/delete/run", handler.RunDeleteReconciler)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPost, "/api/v1/admin/reconcilers/delete/run", nil)
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusInternalServerError {
+		t.Fatalf("Expected status 500, got %d: %s", w.Code, w.Body.String())
+	}
+	if status := deleteReconciler.Status(); status.Runs != 1 || status.LastError != "query stuck delete tasks: server selection timeout" {
+		t.Errorf("unexpected status %+v", status)
+	}
+}
+
+func TestReconcilerHandler_RunDeleteReconciler_RequiresSuperAdmin(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	deleteReconciler := reconciler.NewDeleteReconciler(mocks.NewMockRepository(ctrl), mocks.NewMockDeleteJobPublisher(ctrl), time.Hour, 0)
+	handler := NewReconcilerHandler(deleteReconciler, middleware.NewSuperAdmins([]string{"admin@example.com"}))
+	router := setupProjectRouter("user@example.com")
+	router.POST("/api/v1/admin/reconcilers/delete/run", handler.RunDeleteReconciler)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPost, "/api/v1/admin/reconcilers/delete/run", nil)
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusForbidden {
+		t.Fatalf("Expected status 403, got %d: %s", w.Code, w.Body.String())
+	}
+}
@@ -0,0 +1,155 @@
+package handlers
+
+import (
+	"context"
+	"log"
+	"sort"
+	"time"
+
+	"github.com/yourusername/cron-observer/backend/internal/models"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	// conflictHorizon is how far ahead schedule conflicts are simulated
+	conflictHorizon = 24 * time.Hour
+	// conflictDefaultRunLength is how long runs of tasks without a timeout are assumed to take
+	conflictDefaultRunLength = time.Minute
+)
+
+// simulatedRun is a planned run of a task, assumed to last as long as the task's timeout
+type simulatedRun struct {
+	taskUUID string
+	start    time.Time
+	end      time.Time
+}
+
+// checkScheduleConflict simulates the next 24 hours of the task's group and reports a conflict when runs of the task
+// overlap with more runs of the group than its concurrency budget allows. The second result reports whether the group
+// rejects such tasks. Failures to read the group are logged and skip the check.
+func (h *TaskHandler) checkScheduleConflict(ctx context.Context, task *models.Task) (*models.ScheduleConflict, bool) {
+	if task.TaskGroupID == nil || task.Status != models.TaskStatusActive || task.ScheduleConfig.CronExpression == "" {
+		return nil, false
+	}
+	group, err := h.repo.GetTaskGroupByID(ctx, *task.TaskGroupID)
+	if err != nil {
+		log.Printf("Failed to get task group %s for schedule conflict detection: %v", task.TaskGroupID.Hex(), err)
+		return nil, false
+	}
+	if group.Concurrency == 0 {
+		return nil, false
+	}
+	members, err := h.repo.GetTasksByGroupID(ctx, group.ID)
+	if err != nil {
+		log.Printf("Failed to get tasks of group %s for schedule conflict detection: %v", group.UUID, err)
+		return nil, false
+	}
+	settings, err := h.repo.GetProjectSettings(ctx, task.ProjectID)
+	if err != nil {
+		log.Printf("Failed to get settings of project %s for schedule conflict detection: %v", task.ProjectID.Hex(), err)
+	}
+
+	from := time.Now()
+	to := from.Add(conflictHorizon)
+	groups := map[primitive.ObjectID]*models.TaskGroup{group.ID: group}
+	taskRuns := h.simulateRuns(task, settings, groups, from, to)
+	var otherRuns []simulatedRun
+	for _, member := range members {
+		if member.UUID == task.UUID || member.Status != models.TaskStatusActive || member.ScheduleConfig.CronExpression == "" {
+			continue
+		}
+		otherRuns = append(otherRuns, h.simulateRuns(member, settings, groups, from, to)...)
+	}
+
+	conflict := findScheduleConflict(group.Concurrency, taskRuns, otherRuns)
+	return conflict, conflict != nil && group.OnConflict == models.ConflictPolicyReject
+}
+
+// simulateRuns expands the task's schedule between from and to in the timezone the scheduler uses for it
+func (h *TaskHandler) simulateRuns(task *models.Task, settings *models.ProjectSettings, groups map[primitive.ObjectID]*models.TaskGroup, from, to time.Time) []simulatedRun {
+	timezone := settings.EffectiveTimezone(task.ScheduleConfig.Timezone)
+	if timezone == "" {
+		timezone = h.schedulerLocation.String()
+	}
+	length := conflictDefaultRunLength
+	if timeout := settings.EffectiveTimeoutSeconds(task.TimeoutSeconds); timeout > 0 {
+		length = time.Duration(timeout) * time.Second
+	}
+
+	starts, _ := plannedRuns(task, timezone, groups, from, to)
+	runs := make([]simulatedRun, len(starts))
+	for i, start := range starts {
+		runs[i] = simulatedRun{taskUUID: task.UUID, start: start, end: start.Add(length)}
+	}
+	return runs
+}
+
+// findScheduleConflict sweeps over the runs of a task and the other runs of its group and reports a conflict when
+// runs of the task overlap with more runs than budget allows, counting the run itself
+func findScheduleConflict(budget int, taskRuns, otherRuns []simulatedRun) *models.ScheduleConflict {
+	type edge struct {
+		at    time.Time
+		start bool
+		run   int // Index into taskRuns, or -1 for other runs
+		uuid  string
+	}
+	edges := make([]edge, 0, 2*(len(taskRuns)+len(otherRuns)))
+	for i, run := range taskRuns {
+		edges = append(edges, edge{run.start, true, i, run.taskUUID}, edge{run.end, false, i, run.taskUUID})
+	}
+	for _, run := range otherRuns {
+		edges = append(edges, edge{run.start, true, -1, run.taskUUID}, edge{run.end, false, -1, run.taskUUID})
+	}
+	// Runs ending when another starts do not overlap with it
+	sort.SliceStable(edges, func(i, j int) bool {
+		if !edges[i].at.Equal(edges[j].at) {
+			return edges[i].at.Before(edges[j].at)
+		}
+		return !edges[i].start && edges[j].start
+	})
+
+	conflict := &models.ScheduleConflict{Budget: budget, Runs: len(taskRuns)}
+	running := 0
+	activeTaskRuns := make(map[int]bool)
+	activeOthers := make(map[string]int)
+	overBudget := make(map[int]bool)
+	for _, e := range edges {
+		if !e.start {
+			running--
+			if e.run >= 0 {
+				delete(activeTaskRuns, e.run)
+			} else if activeOthers[e.uuid]--; activeOthers[e.uuid] == 0 {
+				delete(activeOthers, e.uuid)
+			}
+			continue
+		}
+
+		running++
+		if e.run >= 0 {
+			activeTaskRuns[e.run] = true
+		} else {
+			activeOthers[e.uuid]++
+		}
+		if len(activeTaskRuns) == 0 || running <= budget {
+			continue
+		}
+		for run := range activeTaskRuns {
+			overBudget[run] = true
+		}
+		if running > conflict.Peak {
+			conflict.Peak = running
+			conflict.PeakAt = e.at
+			conflict.TaskUUIDs = conflict.TaskUUIDs[:0]
+			for uuid := range activeOthers {
+				conflict.TaskUUIDs = append(conflict.TaskUUIDs, uuid)
+			}
+			sort.Strings(conflict.TaskUUIDs)
+		}
+	}
+
+	if len(overBudget) == 0 {
+		return nil
+	}
+	conflict.OverBudgetRuns = len(overBudget)
+	return conflict
+}
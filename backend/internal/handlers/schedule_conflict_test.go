@@ -0,0 +1,97 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/yourusername/cron-observer/backend/internal/events"
+	"github.com/yourusername/cron-observer/backend/internal/middleware"
+	"github.com/yourusername/cron-observer/backend/internal/models"
+	"github.com/yourusername/cron-observer/backend/mocks"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.uber.org/mock/gomock"
+)
+
+func TestFindScheduleConflict(t *testing.T) {
+	at := func(hour, minute int) time.Time {
+		return time.Date(2025, 1, 15, hour, minute, 0, 0, time.UTC)
+	}
+	run := func(uuid string, start time.Time, length time.Duration) simulatedRun {
+		return simulatedRun{taskUUID: uuid, start: start, end: start.Add(length)}
+	}
+	taskRuns := []simulatedRun{run("task", at(2, 0), 30*time.Minute), run("task", at(3, 0), 30*time.Minute)}
+	otherRuns := []simulatedRun{
+		run("a", at(2, 10), time.Hour),
+		run("b", at(2, 20), time.Minute),
+		run("c", at(2, 30), time.Minute), // Starts when the task's run ends
+		run("b", at(4, 0), time.Minute),
+	}
+
+	conflict := findScheduleConflict(2, taskRuns, otherRuns)
+	if conflict == nil {
+		t.Fatal("Expected a conflict")
+	}
+	if conflict.Peak != 3 || !conflict.PeakAt.Equal(at(2, 20)) || conflict.Runs != 2 || conflict.OverBudgetRuns != 1 {
+		t.Errorf("Unexpected conflict %+v", conflict)
+	}
+	if strings.Join(conflict.TaskUUIDs, ",") != "a,b" {
+		t.Errorf("Expected tasks a and b at the peak, got %v", conflict.TaskUUIDs)
+	}
+
+	if conflict := findScheduleConflict(3, taskRuns, otherRuns); conflict != nil {
+		t.Errorf("Expected no conflict within the budget, got %+v", conflict)
+	}
+}
+
+func TestTaskHandler_PatchTask_RejectsScheduleConflict(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	projectID := primitive.NewObjectID()
+	group := &models.TaskGroup{ID: primitive.NewObjectID(), UUID: "group-uuid", ProjectID: projectID, Concurrency: 1, OnConflict: models.ConflictPolicyReject}
+	createdAt := time.Now().Add(-time.Hour)
+	existing := &models.Task{
+		ID: primitive.NewObjectID(), UUID: "task-uuid", ProjectID: projectID, TaskGroupID: &group.ID, Name: "export",
+		ScheduleType: models.ScheduleTypeRecurring, Status: models.TaskStatusActive, CreatedAt: createdAt,
+		ScheduleConfig: models.ScheduleConfig{CronExpression: "0 30 * * * *", Timezone: "UTC"},
+	}
+	other := &models.Task{
+		UUID: "other-uuid", ProjectID: projectID, TaskGroupID: &group.ID, Name: "import",
+		ScheduleType: models.ScheduleTypeRecurring, Status: models.TaskStatusActive, CreatedAt: createdAt,
+		ScheduleConfig: models.ScheduleConfig{CronExpression: "0 0 * * * *", Timezone: "UTC"},
+	}
+
+	repo := mocks.NewMockRepository(ctrl)
+	handler := NewTaskHandler(repo, events.NewEventBus(1), &mockScheduler{}, middleware.NewSuperAdmins([]string{"root@example.com"}), nil)
+	repo.EXPECT().GetTaskByUUID(gomock.Any(), "task-uuid").Return(existing, nil)
+	repo.EXPECT().GetProjectSettings(gomock.Any(), projectID).Return(nil, nil).Times(2)
+	repo.EXPECT().GetTaskGroupByID(gomock.Any(), group.ID).Return(group, nil)
+	repo.EXPECT().GetTasksByGroupID(gomock.Any(), group.ID).Return([]*models.Task{existing, other}, nil)
+
+	router := setupValidatedRouter(t, "root@example.com")
+	router.PATCH("/api/v1/projects/:project_id/tasks/:task_uuid", handler.PatchTask)
+
+	// Moving the task onto the hour makes it run at the same time as the other task of the group
+	body := `{"schedule_config":{"cron_expression":"0 0 * * * *","timezone":"UTC"}}`
+	req, _ := http.NewRequest(http.MethodPatch, "/api/v1/projects/"+projectID.Hex()+"/tasks/task-uuid", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusConflict {
+		t.Fatalf("Expected status 409, got %d: %s", w.Code, w.Body.String())
+	}
+	var response struct {
+		Conflict models.ScheduleConflict `json:"schedule_conflict"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Unexpected response: %s", w.Body.String())
+	}
+	if response.Conflict.Peak != 2 || response.Conflict.OverBudgetRuns != response.Conflict.Runs || strings.Join(response.Conflict.TaskUUIDs, ",") != "other-uuid" {
+		t.Errorf("Unexpected conflict %+v", response.Conflict)
+	}
+}
@@ -0,0 +1,200 @@
+package handlers
+
+import (
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yourusername/cron-observer/backend/internal/middleware"
+	"github.com/yourusername/cron-observer/backend/internal/models"
+	"github.com/yourusername/cron-observer/backend/internal/repositories"
+	"github.com/yourusername/cron-observer/backend/internal/secrets"
+	"github.com/yourusername/cron-observer/backend/internal/utils"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type SecretHandler struct {
+	repo        repositories.Repository
+	cipher      *secrets.Cipher
+	superAdmins *middleware.SuperAdmins
+}
+
+func NewSecretHandler(repo repositories.Repository, cipher *secrets.Cipher, superAdmins *middleware.SuperAdmins) *SecretHandler {
+	return &SecretHandler{
+		repo:        repo,
+		cipher:      cipher,
+		superAdmins: superAdmins,
+	}
+}
+
+// GetSecretsByProject lists a project's secrets without their values
+// @Summary      List project secrets
+// @Description  List the names of a project's secrets. Secret values are never returned.
+// @Tags         secrets
+// @Accept       json
+// @Produce      json
+// @Param        project_id path string true "Project ID"
+// @Success      200  {array}   models.Secret
+// @Failure      400  {object}  models.ErrorResponse
+// @Failure      403  {object}  models.ErrorResponse
+// @Failure      500  {object}  models.ErrorResponse
+// @Router       /projects/{project_id}/secrets [get]
+func (h *SecretHandler) GetSecretsByProject(c *gin.Context) {
+	projectID, err := primitive.ObjectIDFromHex(c.Param("project_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid project_id format in path",
+		})
+		return
+	}
+
+	if !RequireProjectPermission(c, h.repo, projectID, h.superAdmins, PermissionManageProject) {
+		return
+	}
+
+	projectSecrets, err := h.repo.GetSecretsByProjectID(c.Request.Context(), projectID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to get secrets for project",
+		})
+		return
+	}
+
+	if projectSecrets == nil {
+		projectSecrets = []*models.Secret{}
+	}
+
+	c.JSON(http.StatusOK, projectSecrets)
+}
+
+// PutSecret creates or rotates a project secret
+// @Summary      Create or rotate a secret
+// @Description  Store an encrypted secret that can be referenced as {{secret:NAME}} in execution headers and trigger bodies. Existing secrets with the same name are overwritten.
+// @Tags         secrets
+// @Accept       json
+// @Produce      json
+// @Param        project_id path string true "Project ID"
+// @Param        name path string true "Secret name (letters, digits and underscores)"
+// @Param        secret body models.PutSecretRequest true "Secret value"
+// @Success      200  {object}  models.Secret
+// @Failure      400  {object}  models.ErrorResponse
+// @Failure      403  {object}  models.ErrorResponse
+// @Failure      500  {object}  models.ErrorResponse
+// @Failure      503  {object}  models.ErrorResponse
+// @Router       /projects/{project_id}/secrets/{name} [put]
+func (h *SecretHandler) PutSecret(c *gin.Context) {
+	var req models.PutSecretRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.HandleValidationError(c, err)
+		return
+	}
+
+	projectID, err := primitive.ObjectIDFromHex(c.Param("project_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid project_id format in path",
+		})
+		return
+	}
+
+	name := c.Param("name")
+	if !secrets.IsValidName(name) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid secret name. Use letters, digits and underscores, starting with a letter or underscore",
+		})
+		return
+	}
+
+	if !RequireProjectPermission(c, h.repo, projectID, h.superAdmins, PermissionManageProject) {
+		return
+	}
+
+	encrypted, err := h.cipher.Encrypt(req.Value)
+	if err != nil {
+		if err == secrets.ErrMasterKeyNotConfigured {
+			c.JSON(http.StatusServiceUnavailable, gin.H{
+				"error": "Secrets are not enabled on this server. Set SECRETS_MASTER_KEY to enable them",
+			})
+			return
+		}
+		log.Printf("[SECRETS] Failed to encrypt secret %s for project %s: %v", name, projectID.Hex(), err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to encrypt secret",
+		})
+		return
+	}
+
+	now := time.Now()
+	secret := &models.Secret{
+		ProjectID:        projectID,
+		Name:             name,
+		Ciphertext:       encrypted.Ciphertext,
+		EncryptedDataKey: encrypted.EncryptedDataKey,
+		CreatedAt:        now,
+		UpdatedAt:        now,
+	}
+
+	if err := h.repo.UpsertSecret(c.Request.Context(), secret); err != nil {
+		log.Printf("[SECRETS] Failed to store secret %s for project %s: %v", name, projectID.Hex(), err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to store secret",
+		})
+		return
+	}
+
+	log.Printf("[SECRETS] Secret %s stored for project %s", name, projectID.Hex())
+
+	stored, err := h.repo.GetSecretByName(c.Request.Context(), projectID, name)
+	if err != nil {
+		stored = secret
+	}
+	c.JSON(http.StatusOK, stored)
+}
+
+// DeleteSecret deletes a project secret
+// @Summary      Delete a secret
+// @Description  Delete a project secret. Executions referencing it will fail to dispatch until it is recreated.
+// @Tags         secrets
+// @Accept       json
+// @Produce      json
+// @Param        project_id path string true "Project ID"
+// @Param        name path string true "Secret name"
+// @Success      204  "No Content"
+// @Failure      400  {object}  models.ErrorResponse
+// @Failure      403  {object}  models.ErrorResponse
+// @Failure      404  {object}  models.ErrorResponse
+// @Failure      500  {object}  models.ErrorResponse
+// @Router       /projects/{project_id}/secrets/{name} [delete]
+func (h *SecretHandler) DeleteSecret(c *gin.Context) {
+	projectID, err := primitive.ObjectIDFromHex(c.Param("project_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid project_id format in path",
+		})
+		return
+	}
+
+	name := c.Param("name")
+
+	if !RequireProjectPermission(c, h.repo, projectID, h.superAdmins, PermissionManageProject) {
+		return
+	}
+
+	if err := h.repo.DeleteSecret(c.Request.Context(), projectID, name); err != nil {
+		if err == mongo.ErrNoDocuments {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": "Secret not found",
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to delete secret",
+		})
+		return
+	}
+
+	log.Printf("[SECRETS] Secret %s deleted from project %s", name, projectID.Hex())
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,202 @@
+package handlers
+
+import (
+	"crypto/subtle"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yourusername/cron-observer/backend/internal/middleware"
+	"github.com/yourusername/cron-observer/backend/internal/models"
+	"github.com/yourusername/cron-observer/backend/internal/repositories"
+	"github.com/yourusername/cron-observer/backend/internal/utils"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// StatusPageHandler manages and serves the public, token-protected project status pages
+type StatusPageHandler struct {
+	repo        repositories.Repository
+	superAdmins *middleware.SuperAdmins
+}
+
+func NewStatusPageHandler(repo repositories.Repository, superAdmins *middleware.SuperAdmins) *StatusPageHandler {
+	return &StatusPageHandler{
+		repo:        repo,
+		superAdmins: superAdmins,
+	}
+}
+
+// EnableStatusPage enables the public status page of a project, or rotates its token
+// @Summary      Enable a project's public status page
+// @Description  Generate a new status page token. Anyone holding the token can see the names and health of the project's active tasks, without a dashboard account. Calling this again rotates the token and invalidates the previous one.
+// @Tags         projects
+// @Accept       json
+// @Produce      json
+// @Param        project_id path string true "Project ID"
+// @Success      201  {object}  models.StatusPageTokenResponse
+// @Failure      400  {object}  models.ErrorResponse
+// @Failure      403  {object}  models.ErrorResponse
+// @Failure      404  {object}  models.ErrorResponse
+// @Failure      500  {object}  models.ErrorResponse
+// @Router       /projects/{project_id}/status-page [post]
+func (h *StatusPageHandler) EnableStatusPage(c *gin.Context) {
+	h.setStatusPageToken(c, utils.GenerateAPIKey())
+}
+
+// DisableStatusPage disables the public status page of a project
+// @Summary      Disable a project's public status page
+// @Description  Remove the status page token. The public status page returns 404 afterwards.
+// @Tags         projects
+// @Accept       json
+// @Produce      json
+// @Param        project_id path string true "Project ID"
+// @Success      204  "No Content"
+// @Failure      400  {object}  models.ErrorResponse
+// @Failure      403  {object}  models.ErrorResponse
+// @Failure      404  {object}  models.ErrorResponse
+// @Failure      500  {object}  models.ErrorResponse
+// @Router       /projects/{project_id}/status-page [delete]
+func (h *StatusPageHandler) DisableStatusPage(c *gin.Context) {
+	h.setStatusPageToken(c, "")
+}
+
+func (h *StatusPageHandler) setStatusPageToken(c *gin.Context, token string) {
+	projectID, err := primitive.ObjectIDFromHex(c.Param("project_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid project_id format in path",
+		})
+		return
+	}
+
+	// Check authorization: user must be admin in project or super admin
+	if !RequireProjectPermission(c, h.repo, projectID, h.superAdmins, PermissionManageProject) {
+		return
+	}
+
+	if err := h.repo.SetProjectStatusPageToken(c.Request.Context(), projectID, token); err != nil {
+		if err == mongo.ErrNoDocuments {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": "Project not found",
+			})
+			return
+		}
+		log.Printf("Failed to update status page of project %s: %v", projectID.Hex(), err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to update status page",
+		})
+		return
+	}
+
+	if token == "" {
+		log.Printf("Status page disabled: project=%s", projectID.Hex())
+		c.Status(http.StatusNoContent)
+		return
+	}
+
+	log.Printf("Status page enabled: project=%s", projectID.Hex())
+	c.JSON(http.StatusCreated, models.StatusPageTokenResponse{Token: token})
+}
+
+// GetStatusPage returns the public status page of a project
+// @Summary      Get a project's public status page
+// @Description  Public endpoint, authenticated only by the status page token. Returns the up/down health and last success time of each active task. Returns 404 when the page is disabled or the token does not match.
+// @Tags         status
+// @Produce      json
+// @Param        project_id path string true "Project ID"
+// @Param        token query string true "Status page token"
+// @Success      200  {object}  models.StatusPageResponse
+// @Failure      404  {object}  models.ErrorResponse
+// @Failure      500  {object}  models.ErrorResponse
+// @Router       /status/{project_id} [get]
+func (h *StatusPageHandler) GetStatusPage(c *gin.Context) {
+	// Every rejection looks the same so the endpoint does not reveal which projects exist
+	notFound := func() {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Status page not found",
+		})
+	}
+
+	projectID, err := primitive.ObjectIDFromHex(c.Param("project_id"))
+	if err != nil {
+		notFound()
+		return
+	}
+
+	token := c.Query("token")
+	if token == "" {
+		notFound()
+		return
+	}
+
+	project, err := h.repo.GetProjectByID(c.Request.Context(), projectID)
+	if err != nil {
+		if err != mongo.ErrNoDocuments {
+			log.Printf("Failed to get project %s for status page: %v", projectID.Hex(), err)
+		}
+		notFound()
+		return
+	}
+	if project.StatusPageToken == "" || project.Status == models.ProjectStatusPendingDelete ||
+		subtle.ConstantTimeCompare([]byte(token), []byte(project.StatusPageToken)) != 1 {
+		notFound()
+		return
+	}
+
+	filter := models.TaskListFilter{Status: models.TaskStatusActive, SortBy: models.TaskSortByName}
+	tasks, _, err := h.repo.ListTasksByProjectID(c.Request.Context(), projectID, filter, 1, 0)
+	if err != nil {
+		log.Printf("Failed to list tasks of project %s for status page: %v", projectID.Hex(), err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to load status page",
+		})
+		return
+	}
+
+	taskUUIDs := make([]string, len(tasks))
+	for i, task := range tasks {
+		taskUUIDs[i] = task.UUID
+	}
+	lastSuccess, err := h.repo.GetLastSuccessByTaskUUIDs(c.Request.Context(), taskUUIDs)
+	if err != nil {
+		log.Printf("Failed to get last successes of project %s for status page: %v", projectID.Hex(), err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to load status page",
+		})
+		return
+	}
+
+	response := models.StatusPageResponse{
+		Project:     project.Name,
+		Status:      models.StatusPageOperational,
+		GeneratedAt: time.Now().UTC(),
+		Tasks:       make([]models.StatusPageTask, 0, len(tasks)),
+	}
+	for _, task := range tasks {
+		entry := models.StatusPageTask{Name: task.Name}
+		if at, ok := lastSuccess[task.UUID]; ok {
+			entry.LastSuccessAt = &at
+		}
+		entry.Health = taskHealth(entry.LastSuccessAt, task.LastFailureAt)
+		if entry.Health == models.TaskHealthDown {
+			response.Status = models.StatusPageDegraded
+		}
+		response.Tasks = append(response.Tasks, entry)
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// taskHealth reports a task as down when its most recent completed run failed
+func taskHealth(lastSuccessAt, lastFailureAt *time.Time) models.TaskHealth {
+	switch {
+	case lastFailureAt != nil && (lastSuccessAt == nil || lastFailureAt.After(*lastSuccessAt)):
+		return models.TaskHealthDown
+	case lastSuccessAt != nil:
+		return models.TaskHealthUp
+	default:
+		return models.TaskHealthUnknown
+	}
+}
@@ -0,0 +1,149 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/yourusername/cron-observer/backend/internal/middleware"
+	"github.com/yourusername/cron-observer/backend/internal/models"
+	"github.com/yourusername/cron-observer/backend/mocks"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.uber.org/mock/gomock"
+)
+
+func TestStatusPageHandler_GetStatusPage_ReportsTaskHealth(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockRepository(ctrl)
+	handler := NewStatusPageHandler(repo, middleware.NewSuperAdmins([]string{}))
+
+	project := &models.Project{ID: primitive.NewObjectID(), Name: "Billing", StatusPageToken: "page-token"}
+	lastNight := time.Date(2025, 1, 15, 2, 0, 5, 0, time.UTC)
+	tonight := lastNight.Add(24 * time.Hour)
+	tasks := []*models.Task{
+		{UUID: "backup", Name: "Nightly backup"},
+		{UUID: "invoices", Name: "Send invoices", LastFailureAt: &tonight},
+		{UUID: "report", Name: "Weekly report"},
+	}
+
+	repo.EXPECT().GetProjectByID(gomock.Any(), project.ID).Return(project, nil)
+	repo.EXPECT().ListTasksByProjectID(gomock.Any(), project.ID, models.TaskListFilter{Status: models.TaskStatusActive, SortBy: models.TaskSortByName}, 1, 0).Return(tasks, int64(3), nil)
+	repo.EXPECT().GetLastSuccessByTaskUUIDs(gomock.Any(), []string{"backup", "invoices", "report"}).Return(map[string]time.Time{
+		"backup":   lastNight,
+		"invoices": lastNight,
+	}, nil)
+
+	router := setupRouter()
+	router.GET("/status/:project_id", handler.GetStatusPage)
+
+	req := httptest.NewRequest(http.MethodGet, "/status/"+project.ID.Hex()+"?token=page-token", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+
+	var response models.StatusPageResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if response.Project != "Billing" || response.Status != models.StatusPageDegraded {
+		t.Errorf("Expected degraded Billing page, got %s %s", response.Project, response.Status)
+	}
+
+	expected := []models.TaskHealth{models.TaskHealthUp, models.TaskHealthDown, models.TaskHealthUnknown}
+	if len(response.Tasks) != len(expected) {
+		t.Fatalf("Expected %d tasks, got %d", len(expected), len(response.Tasks))
+	}
+	for i, health := range expected {
+		if response.Tasks[i].Health != health {
+			t.Errorf("Task %s: expected %s, got %s", response.Tasks[i].Name, health, response.Tasks[i].Health)
+		}
+	}
+	if response.Tasks[0].LastSuccessAt == nil || !response.Tasks[0].LastSuccessAt.Equal(lastNight) {
+		t.Errorf("Expected last success %v, got %v", lastNight, response.Tasks[0].LastSuccessAt)
+	}
+}
+
+func TestStatusPageHandler_GetStatusPage_RejectsWrongToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockRepository(ctrl)
+	handler := NewStatusPageHandler(repo, middleware.NewSuperAdmins([]string{}))
+
+	enabled := &models.Project{ID: primitive.NewObjectID(), StatusPageToken: "page-token"}
+	disabled := &models.Project{ID: primitive.NewObjectID()}
+	repo.EXPECT().GetProjectByID(gomock.Any(), enabled.ID).Return(enabled, nil)
+	repo.EXPECT().GetProjectByID(gomock.Any(), disabled.ID).Return(disabled, nil)
+
+	router := setupRouter()
+	router.GET("/status/:project_id", handler.GetStatusPage)
+
+	for _, path := range []string{
+		"/status/" + enabled.ID.Hex(),
+		"/status/" + enabled.ID.Hex() + "?token=guess",
+		"/status/" + disabled.ID.Hex() + "?token=page-token",
+	} {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		if w.Code != http.StatusNotFound {
+			t.Errorf("%s: expected status %d, got %d", path, http.StatusNotFound, w.Code)
+		}
+	}
+}
+
+func TestStatusPageHandler_EnableStatusPage_RequiresProjectAdmin(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockRepository(ctrl)
+	handler := NewStatusPageHandler(repo, middleware.NewSuperAdmins([]string{}))
+
+	project := &models.Project{
+		ID: primitive.NewObjectID(),
+		ProjectUsers: []models.ProjectUser{
+			{Email: "admin@example.com", Role: models.ProjectUserRoleAdmin},
+			{Email: "viewer@example.com", Role: models.ProjectUserRoleViewer},
+		},
+	}
+	repo.EXPECT().GetProjectByID(gomock.Any(), project.ID).Return(project, nil).Times(2)
+
+	var stored string
+	repo.EXPECT().SetProjectStatusPageToken(gomock.Any(), project.ID, gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ primitive.ObjectID, token string) error {
+			stored = token
+			return nil
+		})
+
+	for _, tc := range []struct {
+		email string
+		code  int
+	}{
+		{"viewer@example.com", http.StatusForbidden},
+		{"admin@example.com", http.StatusCreated},
+	} {
+		router := setupProjectRouter(tc.email)
+		router.POST("/projects/:project_id/status-page", handler.EnableStatusPage)
+
+		req := httptest.NewRequest(http.MethodPost, "/projects/"+project.ID.Hex()+"/status-page", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		if w.Code != tc.code {
+			t.Fatalf("%s: expected status %d, got %d: %s", tc.email, tc.code, w.Code, w.Body.String())
+		}
+	}
+
+	if stored == "" {
+		t.Error("Expected a generated token to be stored")
+	}
+}
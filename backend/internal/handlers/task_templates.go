@@ -0,0 +1,242 @@
+package handlers
+
+import (
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/google/uuid"
+	"github.com/yourusername/cron-observer/backend/internal/models"
+	"github.com/yourusername/cron-observer/backend/internal/utils"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// GetTaskTemplates lists the templates available to a project
+// @Summary      List task templates
+// @Description  List the built-in task templates followed by the project's own templates
+// @Tags         task-templates
+// @Accept       json
+// @Produce      json
+// @Param        project_id path string true "Project ID"
+// @Success      200  {array}   models.TaskTemplate
+// @Failure      400  {object}  models.ErrorResponse
+// @Failure      500  {object}  models.ErrorResponse
+// @Router       /projects/{project_id}/task-templates [get]
+func (h *TaskHandler) GetTaskTemplates(c *gin.Context) {
+	projectID, err := primitive.ObjectIDFromHex(c.Param("project_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid project_id format in path",
+		})
+		return
+	}
+
+	projectTemplates, err := h.repo.GetTaskTemplatesByProjectID(c.Request.Context(), projectID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to get task templates",
+		})
+		return
+	}
+
+	templates := append(models.BuiltinTaskTemplates(), projectTemplates...)
+	c.JSON(http.StatusOK, templates)
+}
+
+// CreateTaskTemplate adds a task template to a project
+// @Summary      Create a task template
+// @Description  Save a reusable task skeleton. Name, description and metadata string values may use {{parameter}} placeholders, which must be declared in parameters.
+// @Tags         task-templates
+// @Accept       json
+// @Produce      json
+// @Param        project_id path string true "Project ID"
+// @Param        template body models.CreateTaskTemplateRequest true "Task template"
+// @Success      201  {object}  models.TaskTemplate
+// @Failure      400  {object}  models.ErrorResponse
+// @Failure      403  {object}  models.ErrorResponse
+// @Failure      500  {object}  models.ErrorResponse
+// @Router       /projects/{project_id}/task-templates [post]
+func (h *TaskHandler) CreateTaskTemplate(c *gin.Context) {
+	var req models.CreateTaskTemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.HandleValidationError(c, err)
+		return
+	}
+
+	projectID, err := primitive.ObjectIDFromHex(c.Param("project_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid project_id format in path",
+		})
+		return
+	}
+
+	if !RequireProjectPermission(c, h.repo, projectID, h.superAdmins, PermissionManageTasks) {
+		return
+	}
+
+	seen := make(map[string]bool, len(req.Parameters))
+	for _, param := range req.Parameters {
+		if seen[param.Name] {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "Parameter '" + param.Name + "' is declared more than once",
+			})
+			return
+		}
+		seen[param.Name] = true
+	}
+
+	now := time.Now()
+	template := &models.TaskTemplate{
+		UUID:        uuid.New().String(),
+		ProjectID:   &projectID,
+		Name:        req.Name,
+		Description: req.Description,
+		Parameters:  req.Parameters,
+		Task:        req.Task,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	template.Task.Tags = models.NormalizeTags(template.Task.Tags)
+
+	if undeclared := template.UndeclaredPlaceholders(); len(undeclared) > 0 {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Template uses undeclared parameters: " + strings.Join(undeclared, ", "),
+		})
+		return
+	}
+
+	if err := h.repo.CreateTaskTemplate(c.Request.Context(), template); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to create task template",
+		})
+		return
+	}
+
+	log.Printf("Task template created: project=%s, template=%s, name=%s", projectID.Hex(), template.UUID, template.Name)
+	c.JSON(http.StatusCreated, template)
+}
+
+// DeleteTaskTemplate removes a project's task template
+// @Summary      Delete a task template
+// @Description  Delete a project's task template. Tasks created from it are not affected. Built-in templates cannot be deleted.
+// @Tags         task-templates
+// @Accept       json
+// @Produce      json
+// @Param        project_id path string true "Project ID"
+// @Param        template_uuid path string true "Template UUID"
+// @Success      204
+// @Failure      400  {object}  models.ErrorResponse
+// @Failure      403  {object}  models.ErrorResponse
+// @Failure      404  {object}  models.ErrorResponse
+// @Failure      500  {object}  models.ErrorResponse
+// @Router       /projects/{project_id}/task-templates/{template_uuid} [delete]
+func (h *TaskHandler) DeleteTaskTemplate(c *gin.Context) {
+	projectID, err := primitive.ObjectIDFromHex(c.Param("project_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid project_id format in path",
+		})
+		return
+	}
+
+	templateUUID := c.Param("template_uuid")
+	if _, ok := models.FindBuiltinTaskTemplate(templateUUID); ok {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Built-in templates cannot be deleted",
+		})
+		return
+	}
+
+	if !RequireProjectPermission(c, h.repo, projectID, h.superAdmins, PermissionManageTasks) {
+		return
+	}
+
+	if err := h.repo.DeleteTaskTemplate(c.Request.Context(), projectID, templateUUID); err != nil {
+		if err == mongo.ErrNoDocuments {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": "Task template not found",
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to delete task template",
+		})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// CreateTaskFromTemplate creates a task from a built-in or project template
+// @Summary      Create a task from a template
+// @Description  Render a template with the given parameters and create the resulting task. Required parameters without a value and unknown parameters are rejected. The rendered task is validated like a regular create request.
+// @Tags         task-templates
+// @Accept       json
+// @Produce      json
+// @Param        project_id path string true "Project ID"
+// @Param        template_uuid path string true "Template UUID"
+// @Param        request body models.CreateTaskFromTemplateRequest true "Template parameters and overrides"
+// @Success      201  {object}  models.Task
+// @Failure      400  {object}  models.ErrorResponse
+// @Failure      403  {object}  models.ErrorResponse
+// @Failure      404  {object}  models.ErrorResponse
+// @Failure      500  {object}  models.ErrorResponse
+// @Router       /projects/{project_id}/task-templates/{template_uuid}/tasks [post]
+func (h *TaskHandler) CreateTaskFromTemplate(c *gin.Context) {
+	var req models.CreateTaskFromTemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.HandleValidationError(c, err)
+		return
+	}
+
+	projectID, err := primitive.ObjectIDFromHex(c.Param("project_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid project_id format in path",
+		})
+		return
+	}
+
+	if !RequireProjectPermission(c, h.repo, projectID, h.superAdmins, PermissionManageTasks) {
+		return
+	}
+
+	templateUUID := c.Param("template_uuid")
+	template, ok := models.FindBuiltinTaskTemplate(templateUUID)
+	if !ok {
+		template, err = h.repo.GetTaskTemplateByUUID(c.Request.Context(), projectID, templateUUID)
+		if err != nil {
+			if err == mongo.ErrNoDocuments {
+				c.JSON(http.StatusNotFound, gin.H{
+					"error": "Task template not found",
+				})
+				return
+			}
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "Failed to get task template",
+			})
+			return
+		}
+	}
+
+	taskReq, err := template.Instantiate(projectID.Hex(), req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	// Substituted values end up in the task name and metadata, so validate the result like a regular create
+	if err := binding.Validator.ValidateStruct(taskReq); err != nil {
+		utils.HandleValidationError(c, err)
+		return
+	}
+
+	h.createTask(c, projectID, *taskReq)
+}
@@ -4,11 +4,12 @@ import (
 	"context"
 	"log"
 	"net/http"
-	"strings"
+	"sort"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"github.com/yourusername/cron-observer/backend/internal/deletequeue"
 	"github.com/yourusername/cron-observer/backend/internal/events"
 	"github.com/yourusername/cron-observer/backend/internal/middleware"
 	"github.com/yourusername/cron-observer/backend/internal/models"
@@ -19,27 +20,20 @@ import (
 )
 
 type TaskGroupHandler struct {
-	repo          repositories.Repository
-	eventBus      *events.EventBus
-	scheduler     *scheduler.Scheduler
-	superAdminMap map[string]bool
+	repo            repositories.Repository
+	eventBus        *events.EventBus
+	scheduler       *scheduler.Scheduler
+	superAdmins     *middleware.SuperAdmins
+	deletePublisher deletequeue.DeleteJobPublisher // optional until wired in main
 }
 
-func NewTaskGroupHandler(repo repositories.Repository, eventBus *events.EventBus, sched *scheduler.Scheduler, superAdmins []string) *TaskGroupHandler {
-	// Create a map for O(1) lookup
-	superAdminMap := make(map[string]bool)
-	for _, admin := range superAdmins {
-		normalizedAdmin := strings.ToLower(strings.TrimSpace(admin))
-		if normalizedAdmin != "" {
-			superAdminMap[normalizedAdmin] = true
-		}
-	}
-
+func NewTaskGroupHandler(repo repositories.Repository, eventBus *events.EventBus, sched *scheduler.Scheduler, superAdmins *middleware.SuperAdmins, deletePublisher deletequeue.DeleteJobPublisher) *TaskGroupHandler {
 	return &TaskGroupHandler{
-		repo:          repo,
-		eventBus:      eventBus,
-		scheduler:     sched,
-		superAdminMap: superAdminMap,
+		repo:            repo,
+		eventBus:        eventBus,
+		scheduler:       sched,
+		superAdmins:     superAdmins,
+		deletePublisher: deletePublisher,
 	}
 }
 
@@ -87,6 +81,27 @@ func (h *TaskGroupHandler) calculateTaskGroupState(ctx context.Context, existing
 	return existingState
 }
 
+// requireManageTasks parses project_id from the path and checks the user may manage its task groups
+func (h *TaskGroupHandler) requireManageTasks(c *gin.Context) bool {
+	_, ok := h.requireProjectPermission(c, PermissionManageTasks)
+	return ok
+}
+
+// requireProjectPermission parses project_id from the path and checks the user has the permission on the project.
+// It writes the error response and aborts the request when the check fails.
+func (h *TaskGroupHandler) requireProjectPermission(c *gin.Context, permission ProjectPermission) (primitive.ObjectID, bool) {
+	projectID, err := primitive.ObjectIDFromHex(c.Param("project_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid project_id format in path",
+		})
+		c.Abort()
+		return primitive.NilObjectID, false
+	}
+
+	return projectID, RequireProjectPermission(c, h.repo, projectID, h.superAdmins, permission)
+}
+
 // GetTaskGroupsByProject retrieves all task groups for a project
 // @Summary      Get task groups by project
 // @Description  Retrieve all task groups belonging to a project
@@ -96,23 +111,12 @@ func (h *TaskGroupHandler) calculateTaskGroupState(ctx context.Context, existing
 // @Param        project_id path string true "Project ID"
 // @Success      200  {array}   models.TaskGroup
 // @Failure      400  {object}  models.ErrorResponse
+// @Failure      403  {object}  models.ErrorResponse
 // @Failure      500  {object}  models.ErrorResponse
 // @Router       /projects/{project_id}/task-groups [get]
 func (h *TaskGroupHandler) GetTaskGroupsByProject(c *gin.Context) {
-	projectIDParam := c.Param("project_id")
-	if projectIDParam == "" {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": "project_id is required in path",
-		})
-		return
-	}
-
-	// Convert project_id to ObjectID
-	projectID, err := primitive.ObjectIDFromHex(projectIDParam)
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": "Invalid project_id format in path",
-		})
+	projectID, ok := h.requireProjectPermission(c, PermissionViewProject)
+	if !ok {
 		return
 	}
 
@@ -194,6 +198,11 @@ func (h *TaskGroupHandler) CreateTaskGroup(c *gin.Context) {
 		return
 	}
 
+	// Check authorization: user must be editor or admin in project, or super admin
+	if !RequireProjectPermission(c, h.repo, projectID, h.superAdmins, PermissionManageTasks) {
+		return
+	}
+
 	// Set default status if not provided
 	status := req.Status
 	if status == "" {
@@ -231,12 +240,16 @@ func (h *TaskGroupHandler) CreateTaskGroup(c *gin.Context) {
 		StartTime:   req.StartTime,
 		EndTime:     req.EndTime,
 		Timezone:    timezone,
+		Concurrency: req.Concurrency,
+		OnConflict:  req.OnConflict,
 		CreatedAt:   time.Now(),
 		UpdatedAt:   time.Now(),
 	}
 
-	// Create the task group
-	err = h.repo.CreateTaskGroup(c.Request.Context(), projectIDParam, taskGroup)
+	// Create the task group and publish TaskGroupCreated
+	err = events.PublishAtomic(c.Request.Context(), h.eventBus, events.TaskGroupCreatedTopic, func(ctx context.Context) (events.TaskGroupPayload, error) {
+		return events.TaskGroupPayload{TaskGroup: taskGroup}, h.repo.CreateTaskGroup(ctx, projectIDParam, taskGroup)
+	})
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error": "Failed to create task group",
@@ -244,12 +257,6 @@ func (h *TaskGroupHandler) CreateTaskGroup(c *gin.Context) {
 		return
 	}
 
-	// Publish TaskGroupCreated event
-	h.eventBus.Publish(events.Event{
-		Type:    events.TaskGroupCreated,
-		Payload: events.TaskGroupPayload{TaskGroup: taskGroup},
-	})
-
 	c.JSON(http.StatusCreated, taskGroup)
 }
 
@@ -263,9 +270,15 @@ func (h *TaskGroupHandler) CreateTaskGroup(c *gin.Context) {
 // @Param        group_uuid path string true "Task Group UUID"
 // @Success      200  {object}  models.TaskGroup
 // @Failure      400  {object}  models.ErrorResponse
+// @Failure      403  {object}  models.ErrorResponse
 // @Failure      404  {object}  models.ErrorResponse
 // @Router       /projects/{project_id}/task-groups/{group_uuid} [get]
 func (h *TaskGroupHandler) GetTaskGroup(c *gin.Context) {
+	projectID, ok := h.requireProjectPermission(c, PermissionViewProject)
+	if !ok {
+		return
+	}
+
 	taskGroupUUID := c.Param("group_uuid")
 	if taskGroupUUID == "" {
 		c.JSON(http.StatusBadRequest, gin.H{
@@ -275,7 +288,7 @@ func (h *TaskGroupHandler) GetTaskGroup(c *gin.Context) {
 	}
 
 	taskGroup, err := h.repo.GetTaskGroupByUUID(c.Request.Context(), taskGroupUUID)
-	if err != nil {
+	if err != nil || taskGroup.ProjectID != projectID {
 		c.JSON(http.StatusNotFound, gin.H{
 			"error": "Task group not found",
 		})
@@ -335,14 +348,15 @@ func (h *TaskGroupHandler) UpdateTaskGroup(c *gin.Context) {
 		return
 	}
 
-	// Check authorization: user must be admin in project or super admin
-	if !RequireProjectAdmin(c, h.repo, projectID, h.superAdminMap) {
+	// Check authorization: user must be editor or admin in project, or super admin
+	if !RequireProjectPermission(c, h.repo, projectID, h.superAdmins, PermissionManageTasks) {
 		return
 	}
 
 	// Get existing task group to preserve ID, UUID, ProjectID and timestamps
+	// A group of another project is reported as missing rather than moved into this one
 	existingTaskGroup, err := h.repo.GetTaskGroupByUUID(c.Request.Context(), taskGroupUUIDParam)
-	if err != nil {
+	if err != nil || existingTaskGroup.ProjectID != projectID {
 		c.JSON(http.StatusNotFound, gin.H{
 			"error": "Task group not found",
 		})
@@ -390,123 +404,94 @@ func (h *TaskGroupHandler) UpdateTaskGroup(c *gin.Context) {
 		StartTime:   req.StartTime,
 		EndTime:     req.EndTime,
 		Timezone:    timezone,
+		Concurrency: req.Concurrency,
+		OnConflict:  req.OnConflict,
 		CreatedAt:   existingTaskGroup.CreatedAt, // Preserve original creation time
 		UpdatedAt:   time.Now(),
 	}
 
-	// Update the task group
-	err = h.repo.UpdateTaskGroup(c.Request.Context(), taskGroupUUIDParam, taskGroup)
+	// Determine what the update changes on the group's tasks
+	statusChangedToActive := status == models.TaskGroupStatusActive && existingTaskGroup.Status != models.TaskGroupStatusActive
+	statusChangedToDisabled := status == models.TaskGroupStatusDisabled && existingTaskGroup.Status != models.TaskGroupStatusDisabled
+	stateChanged := state != existingTaskGroup.State
+
+	var cascade models.TaskGroupCascade
+	if statusChangedToActive {
+		cascade.TaskStatus = models.TaskStatusActive
+	}
+	if statusChangedToDisabled {
+		// When group becomes disabled, always set state to NOT_RUNNING
+		cascade.TaskStatus = models.TaskStatusDisabled
+		cascade.TaskState = models.TaskStateNotRunning
+	} else if stateChanged {
+		// Normal state change based on group state
+		cascade.TaskState = models.TaskStateNotRunning
+		if state == models.TaskGroupStateRunning {
+			cascade.TaskState = models.TaskStateRunning
+		}
+	}
+
+	// Update the task group and its tasks together, so a failure cannot leave them in mixed states, and publish
+	// TaskGroupUpdated for the scheduler to register or unregister cron jobs
+	var result *models.TaskGroupCascadeResult
+	err = events.PublishAtomic(c.Request.Context(), h.eventBus, events.TaskGroupUpdatedTopic, func(ctx context.Context) (events.TaskGroupPayload, error) {
+		var err error
+		result, err = h.repo.UpdateTaskGroupWithTasks(ctx, taskGroup, cascade)
+		return events.TaskGroupPayload{TaskGroup: taskGroup}, err
+	})
 	if err != nil {
+		log.Printf("Failed to update task group %s: %v", taskGroup.UUID, err)
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error": "Failed to update task group",
 		})
 		return
 	}
 
-	// Update state separately to ensure it's persisted
-	if err := h.repo.UpdateTaskGroupState(c.Request.Context(), taskGroupUUIDParam, state); err != nil {
-		log.Printf("Failed to update task group state: %v", err)
-	}
-
-	// Determine if we need to update tasks
-	statusChangedToActive := status == models.TaskGroupStatusActive && existingTaskGroup.Status != models.TaskGroupStatusActive
-	statusChangedToDisabled := status == models.TaskGroupStatusDisabled && existingTaskGroup.Status != models.TaskGroupStatusDisabled
-	stateChanged := state != existingTaskGroup.State
-
-	// Only fetch tasks if we need to update them
-	if statusChangedToActive || statusChangedToDisabled || stateChanged {
+	// Unregister cron jobs if group became disabled
+	if statusChangedToDisabled {
 		tasks, err := h.repo.GetTasksByGroupID(c.Request.Context(), taskGroup.ID)
 		if err != nil {
 			log.Printf("Failed to get tasks for group %s: %v", taskGroup.UUID, err)
-		} else if len(tasks) > 0 {
-			// Calculate task state based on group state
-			taskState := models.TaskStateNotRunning
-			if state == models.TaskGroupStateRunning {
-				taskState = models.TaskStateRunning
-			}
-
-			// Update all tasks in a single pass
-			statusUpdatedCount := 0
-			stateUpdatedCount := 0
-			for _, task := range tasks {
-				// Update status to ACTIVE if group became active
-				if statusChangedToActive && task.Status != models.TaskStatusActive {
-					if err := h.repo.UpdateTaskStatus(c.Request.Context(), task.UUID, models.TaskStatusActive); err != nil {
-						log.Printf("Failed to update task %s status to ACTIVE: %v", task.UUID, err)
-					} else {
-						statusUpdatedCount++
-					}
-				}
-
-				// Update status to DISABLED if group became disabled
-				if statusChangedToDisabled && task.Status != models.TaskStatusDisabled {
-					if err := h.repo.UpdateTaskStatus(c.Request.Context(), task.UUID, models.TaskStatusDisabled); err != nil {
-						log.Printf("Failed to update task %s status to DISABLED: %v", task.UUID, err)
-					} else {
-						statusUpdatedCount++
-					}
-				}
-
-				// Update state if group state changed or group became disabled
-				if statusChangedToDisabled {
-					// When group becomes disabled, always set state to NOT_RUNNING
-					if task.State != models.TaskStateNotRunning {
-						if err := h.repo.UpdateTaskState(c.Request.Context(), task.UUID, models.TaskStateNotRunning); err != nil {
-							log.Printf("Failed to update task %s state to NOT_RUNNING: %v", task.UUID, err)
-						} else {
-							stateUpdatedCount++
-						}
-					}
-				} else if stateChanged && task.State != taskState {
-					// Normal state change based on group state
-					if err := h.repo.UpdateTaskState(c.Request.Context(), task.UUID, taskState); err != nil {
-						log.Printf("Failed to update task %s state to %s: %v", task.UUID, taskState, err)
-					} else {
-						stateUpdatedCount++
-					}
-				}
-
-				// Unregister cron job if group became disabled
-				if statusChangedToDisabled {
-					h.scheduler.UnregisterTask(task.UUID)
-				}
-			}
-
-			// Log updates
-			if statusChangedToActive && statusUpdatedCount > 0 {
-				log.Printf("[GROUP] Updated %d tasks' status to ACTIVE for group %s", statusUpdatedCount, taskGroup.UUID)
-			}
-			if statusChangedToDisabled {
-				log.Printf("[GROUP] Updated %d tasks' status to DISABLED, %d tasks' state to NOT_RUNNING, and unregistered all cron jobs for group %s", statusUpdatedCount, stateUpdatedCount, taskGroup.UUID)
-			}
-			if stateChanged && stateUpdatedCount > 0 && !statusChangedToDisabled {
-				log.Printf("[GROUP] Updated %d tasks' state to %s for group %s", stateUpdatedCount, taskState, taskGroup.UUID)
-			}
+		}
+		for _, task := range tasks {
+			h.scheduler.UnregisterTask(task.UUID)
 		}
 	}
 
-	// Publish TaskGroupUpdated event (for scheduler to register/unregister cron jobs)
-	h.eventBus.Publish(events.Event{
-		Type:    events.TaskGroupUpdated,
-		Payload: events.TaskGroupPayload{TaskGroup: taskGroup},
-	})
+	// Log updates
+	if statusChangedToActive && result.StatusUpdated > 0 {
+		log.Printf("[GROUP] Updated %d tasks' status to ACTIVE for group %s", result.StatusUpdated, taskGroup.UUID)
+	}
+	if statusChangedToDisabled {
+		log.Printf("[GROUP] Updated %d tasks' status to DISABLED, %d tasks' state to NOT_RUNNING, and unregistered all cron jobs for group %s", result.StatusUpdated, result.StateUpdated, taskGroup.UUID)
+	}
+	if stateChanged && result.StateUpdated > 0 && !statusChangedToDisabled {
+		log.Printf("[GROUP] Updated %d tasks' state to %s for group %s", result.StateUpdated, cascade.TaskState, taskGroup.UUID)
+	}
 
 	c.JSON(http.StatusOK, taskGroup)
 }
 
-// DeleteTaskGroup deletes a task group
+// DeleteTaskGroup enqueues a task group for deletion
 // @Summary      Delete a task group
-// @Description  Delete an existing task group
+// @Description  Queue an existing task group for deletion. The delete worker detaches its tasks (default) or deletes them together with their executions when tasks=delete, then removes the group and its window jobs.
 // @Tags         task-groups
 // @Accept       json
 // @Produce      json
 // @Param        project_id path string true "Project ID"
 // @Param        group_uuid path string true "Task Group UUID"
-// @Success      204  "No Content"
+// @Param        tasks query string false "What happens to the group's tasks (default: detach)" Enums(detach, delete)
+// @Success      202  {object}  map[string]string
 // @Failure      400  {object}  models.ErrorResponse
+// @Failure      403  {object}  models.ErrorResponse
+// @Failure      404  {object}  models.ErrorResponse
 // @Failure      500  {object}  models.ErrorResponse
 // @Router       /projects/{project_id}/task-groups/{group_uuid} [delete]
 func (h *TaskGroupHandler) DeleteTaskGroup(c *gin.Context) {
+	if !h.requireManageTasks(c) {
+		return
+	}
+
 	taskGroupUUIDParam := c.Param("group_uuid")
 
 	if taskGroupUUIDParam == "" {
@@ -516,22 +501,69 @@ func (h *TaskGroupHandler) DeleteTaskGroup(c *gin.Context) {
 		return
 	}
 
-	// Delete the task group
-	err := h.repo.DeleteTaskGroup(c.Request.Context(), taskGroupUUIDParam)
-	if err != nil {
+	var taskPolicy deletequeue.TaskGroupTaskPolicy
+	switch c.DefaultQuery("tasks", "detach") {
+	case "detach":
+		taskPolicy = deletequeue.TaskGroupTaskPolicyDetach
+	case "delete":
+		taskPolicy = deletequeue.TaskGroupTaskPolicyDelete
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid tasks policy. Use detach or delete",
+		})
+		return
+	}
+
+	ctx := c.Request.Context()
+	taskGroup, err := h.repo.GetTaskGroupByUUID(ctx, taskGroupUUIDParam)
+	if err != nil || taskGroup.ProjectID.Hex() != c.Param("project_id") {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Task group not found",
+		})
+		return
+	}
+
+	if h.deletePublisher == nil {
 		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": "Failed to delete task group",
+			"error": "Delete queue not available",
 		})
 		return
 	}
 
-	// Publish TaskGroupDeleted event
-	h.eventBus.Publish(events.Event{
-		Type:    events.TaskGroupDeleted,
-		Payload: events.TaskGroupDeletedPayload{TaskGroupUUID: taskGroupUUIDParam},
-	})
+	// Hide the group from the scheduler until the worker removes it; member tasks only run while their group is ACTIVE
+	previousStatus := taskGroup.Status
+	if err := h.repo.UpdateTaskGroupStatus(ctx, taskGroup.UUID, models.TaskGroupStatusPendingDelete); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to update task group status",
+		})
+		return
+	}
 
-	c.Status(http.StatusNoContent)
+	msg := deletequeue.DeleteTaskGroupMessage{
+		TaskGroupUUID: taskGroup.UUID,
+		ProjectID:     taskGroup.ProjectID.Hex(),
+		TaskPolicy:    taskPolicy,
+		RequestedAt:   time.Now(),
+	}
+	if err := h.deletePublisher.PublishDeleteTaskGroup(ctx, msg); err != nil {
+		log.Printf("[Handler] Failed to enqueue task group delete job: TaskGroupUUID=%s, error=%v", taskGroup.UUID, err)
+		if revertErr := h.repo.UpdateTaskGroupStatus(ctx, taskGroup.UUID, previousStatus); revertErr != nil {
+			log.Printf("[Handler] Failed to restore status of task group %s after enqueue failure: %v", taskGroup.UUID, revertErr)
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to enqueue delete job",
+		})
+		return
+	}
+
+	log.Printf("[Handler] Accepted task group delete request: TaskGroupUUID=%s, Name=%s, TaskPolicy=%s",
+		taskGroup.UUID, taskGroup.Name, taskPolicy)
+
+	c.JSON(http.StatusAccepted, gin.H{
+		"status":          string(models.TaskGroupStatusPendingDelete),
+		"task_group_uuid": taskGroup.UUID,
+		"message":         "Delete request accepted and queued",
+	})
 }
 
 // StartGroup starts all tasks in a task group
@@ -544,9 +576,16 @@ func (h *TaskGroupHandler) DeleteTaskGroup(c *gin.Context) {
 // @Param        group_uuid path string true "Task Group UUID"
 // @Success      200  {object}  map[string]string
 // @Failure      400  {object}  models.ErrorResponse
+// @Failure      403  {object}  models.ErrorResponse
+// @Failure      404  {object}  models.ErrorResponse
 // @Failure      500  {object}  models.ErrorResponse
 // @Router       /projects/{project_id}/task-groups/{group_uuid}/start [post]
 func (h *TaskGroupHandler) StartGroup(c *gin.Context) {
+	projectID, ok := h.requireProjectPermission(c, PermissionManageTasks)
+	if !ok {
+		return
+	}
+
 	taskGroupUUIDParam := c.Param("group_uuid")
 
 	if taskGroupUUIDParam == "" {
@@ -556,7 +595,16 @@ func (h *TaskGroupHandler) StartGroup(c *gin.Context) {
 		return
 	}
 
-	err := h.scheduler.StartGroup(c.Request.Context(), taskGroupUUIDParam)
+	// Groups of other projects are reported as missing, so the permission on this project cannot reach them
+	taskGroup, err := h.repo.GetTaskGroupByUUID(c.Request.Context(), taskGroupUUIDParam)
+	if err != nil || taskGroup.ProjectID != projectID {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Task group not found",
+		})
+		return
+	}
+
+	err = h.scheduler.StartGroup(c.Request.Context(), taskGroupUUIDParam)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error":   "Failed to start group",
@@ -580,9 +628,16 @@ func (h *TaskGroupHandler) StartGroup(c *gin.Context) {
 // @Param        group_uuid path string true "Task Group UUID"
 // @Success      200  {object}  map[string]string
 // @Failure      400  {object}  models.ErrorResponse
+// @Failure      403  {object}  models.ErrorResponse
+// @Failure      404  {object}  models.ErrorResponse
 // @Failure      500  {object}  models.ErrorResponse
 // @Router       /projects/{project_id}/task-groups/{group_uuid}/stop [post]
 func (h *TaskGroupHandler) StopGroup(c *gin.Context) {
+	projectID, ok := h.requireProjectPermission(c, PermissionManageTasks)
+	if !ok {
+		return
+	}
+
 	taskGroupUUIDParam := c.Param("group_uuid")
 
 	if taskGroupUUIDParam == "" {
@@ -592,7 +647,16 @@ func (h *TaskGroupHandler) StopGroup(c *gin.Context) {
 		return
 	}
 
-	err := h.scheduler.StopGroup(c.Request.Context(), taskGroupUUIDParam)
+	// Groups of other projects are reported as missing, so the permission on this project cannot reach them
+	taskGroup, err := h.repo.GetTaskGroupByUUID(c.Request.Context(), taskGroupUUIDParam)
+	if err != nil || taskGroup.ProjectID != projectID {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Task group not found",
+		})
+		return
+	}
+
+	err = h.scheduler.StopGroup(c.Request.Context(), taskGroupUUIDParam)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error":   "Failed to stop group",
@@ -616,10 +680,16 @@ func (h *TaskGroupHandler) StopGroup(c *gin.Context) {
 // @Param        group_uuid path string true "Task Group UUID"
 // @Success      200  {array}   models.Task
 // @Failure      400  {object}  models.ErrorResponse
+// @Failure      403  {object}  models.ErrorResponse
 // @Failure      404  {object}  models.ErrorResponse
 // @Failure      500  {object}  models.ErrorResponse
 // @Router       /projects/{project_id}/task-groups/{group_uuid}/tasks [get]
 func (h *TaskGroupHandler) GetTasksByGroup(c *gin.Context) {
+	projectID, ok := h.requireProjectPermission(c, PermissionViewProject)
+	if !ok {
+		return
+	}
+
 	taskGroupUUIDParam := c.Param("group_uuid")
 
 	if taskGroupUUIDParam == "" {
@@ -631,7 +701,7 @@ func (h *TaskGroupHandler) GetTasksByGroup(c *gin.Context) {
 
 	// Get task group to get its ID
 	taskGroup, err := h.repo.GetTaskGroupByUUID(c.Request.Context(), taskGroupUUIDParam)
-	if err != nil {
+	if err != nil || taskGroup.ProjectID != projectID {
 		c.JSON(http.StatusNotFound, gin.H{
 			"error": "Task group not found",
 		})
@@ -649,3 +719,175 @@ func (h *TaskGroupHandler) GetTasksByGroup(c *gin.Context) {
 
 	c.JSON(http.StatusOK, tasks)
 }
+
+// timelineMaxExecutions bounds the executions listed in a group timeline
+const timelineMaxExecutions = 5000
+
+// GetGroupTimeline lays out the executions of a group's tasks within its window on one day
+// @Summary      Get the execution timeline of a task group
+// @Description  List the executions of each task in the group that started within the group's start_time to end_time window on the given day, for a Gantt-style view of overlap and sequencing. A window whose end_time is not after its start_time ends on the next day; groups without a window cover the whole day. max_concurrent is the most executions running at once, with running executions counted until now.
+// @Tags         task-groups
+// @Accept       json
+// @Produce      json
+// @Param        project_id path string true "Project ID"
+// @Param        group_uuid path string true "Task Group UUID"
+// @Param        date query string false "Day the window starts on (YYYY-MM-DD) in the group's timezone (default: today)"
+// @Success      200  {object}  models.GroupTimelineResponse
+// @Failure      400  {object}  models.ErrorResponse
+// @Failure      403  {object}  models.ErrorResponse
+// @Failure      404  {object}  models.ErrorResponse
+// @Failure      500  {object}  models.ErrorResponse
+// @Router       /projects/{project_id}/task-groups/{group_uuid}/timeline [get]
+func (h *TaskGroupHandler) GetGroupTimeline(c *gin.Context) {
+	projectID, err := primitive.ObjectIDFromHex(c.Param("project_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid project_id format in path",
+		})
+		return
+	}
+	if !RequireProjectPermission(c, h.repo, projectID, h.superAdmins, PermissionViewProject) {
+		return
+	}
+
+	ctx := c.Request.Context()
+	taskGroup, err := h.repo.GetTaskGroupByUUID(ctx, c.Param("group_uuid"))
+	if err != nil || taskGroup.ProjectID != projectID {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Task group not found",
+		})
+		return
+	}
+
+	loc, err := time.LoadLocation(taskGroup.Timezone)
+	if err != nil {
+		log.Printf("Invalid timezone %q of task group %s, using UTC: %v", taskGroup.Timezone, taskGroup.UUID, err)
+		loc = time.UTC
+	}
+	date := c.Query("date")
+	if date == "" {
+		date = time.Now().In(loc).Format("2006-01-02")
+	}
+	windowStart, windowEnd, err := groupWindow(taskGroup, date, loc)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid date format. Use YYYY-MM-DD",
+		})
+		return
+	}
+
+	tasks, err := h.repo.GetTasksByGroupID(ctx, taskGroup.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to get tasks for group",
+		})
+		return
+	}
+	taskUUIDs := make([]string, 0, len(tasks))
+	for _, task := range tasks {
+		taskUUIDs = append(taskUUIDs, task.UUID)
+	}
+	// The window end is exclusive
+	executions, err := h.repo.GetExecutionsByTaskUUIDsBetween(ctx, taskUUIDs, windowStart, windowEnd.Add(-time.Nanosecond), timelineMaxExecutions)
+	if err != nil {
+		log.Printf("Failed to get executions of task group %s: %v", taskGroup.UUID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to get group timeline",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.GroupTimelineResponse{
+		GroupUUID:     taskGroup.UUID,
+		GroupName:     taskGroup.Name,
+		Date:          date,
+		Timezone:      loc.String(),
+		WindowStart:   windowStart,
+		WindowEnd:     windowEnd,
+		Lanes:         timelineLanes(tasks, executions),
+		MaxConcurrent: maxConcurrent(executions, time.Now()),
+		Truncated:     len(executions) == timelineMaxExecutions,
+	})
+}
+
+// groupWindow returns the start and exclusive end of the group's window on date (YYYY-MM-DD) in loc
+func groupWindow(taskGroup *models.TaskGroup, date string, loc *time.Location) (time.Time, time.Time, error) {
+	day, err := time.ParseInLocation("2006-01-02", date, loc)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	if taskGroup.StartTime == "" || taskGroup.EndTime == "" {
+		return day, day.AddDate(0, 0, 1), nil
+	}
+	start, errStart := time.Parse("15:04", taskGroup.StartTime)
+	end, errEnd := time.Parse("15:04", taskGroup.EndTime)
+	if errStart != nil || errEnd != nil {
+		return day, day.AddDate(0, 0, 1), nil
+	}
+
+	windowStart := time.Date(day.Year(), day.Month(), day.Day(), start.Hour(), start.Minute(), 0, 0, loc)
+	windowEnd := time.Date(day.Year(), day.Month(), day.Day(), end.Hour(), end.Minute(), 0, 0, loc)
+	if !windowEnd.After(windowStart) {
+		windowEnd = time.Date(day.Year(), day.Month(), day.Day()+1, end.Hour(), end.Minute(), 0, 0, loc)
+	}
+	return windowStart, windowEnd, nil
+}
+
+// timelineLanes groups executions, oldest first, into a lane per task, ordered by when each task first ran
+func timelineLanes(tasks []*models.Task, executions []*models.Execution) []models.GroupTimelineLane {
+	lanes := make([]models.GroupTimelineLane, 0, len(tasks))
+	laneByTask := make(map[string]int, len(tasks))
+	for _, execution := range executions {
+		i, ok := laneByTask[execution.TaskUUID]
+		if !ok {
+			i = len(lanes)
+			laneByTask[execution.TaskUUID] = i
+			lanes = append(lanes, models.GroupTimelineLane{TaskUUID: execution.TaskUUID})
+		}
+		lanes[i].Executions = append(lanes[i].Executions, *execution.Summary())
+	}
+	for _, task := range tasks {
+		if i, ok := laneByTask[task.UUID]; ok {
+			lanes[i].TaskName = task.Name
+			continue
+		}
+		lanes = append(lanes, models.GroupTimelineLane{
+			TaskUUID:   task.UUID,
+			TaskName:   task.Name,
+			Executions: []models.ExecutionSummary{},
+		})
+	}
+	return lanes
+}
+
+// maxConcurrent returns the most executions running at the same time, counting running executions until now
+func maxConcurrent(executions []*models.Execution, now time.Time) int {
+	type edge struct {
+		at    time.Time
+		delta int
+	}
+	edges := make([]edge, 0, 2*len(executions))
+	for _, execution := range executions {
+		end := now
+		if execution.EndedAt != nil {
+			end = *execution.EndedAt
+		}
+		edges = append(edges, edge{execution.StartedAt, 1}, edge{end, -1})
+	}
+	// An execution ending when another starts does not overlap it
+	sort.Slice(edges, func(a, b int) bool {
+		if edges[a].at.Equal(edges[b].at) {
+			return edges[a].delta < edges[b].delta
+		}
+		return edges[a].at.Before(edges[b].at)
+	})
+
+	running, most := 0, 0
+	for _, e := range edges {
+		running += e.delta
+		if running > most {
+			most = running
+		}
+	}
+	return most
+}
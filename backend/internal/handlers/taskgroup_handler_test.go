@@ -0,0 +1,229 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yourusername/cron-observer/backend/internal/events"
+	"github.com/yourusername/cron-observer/backend/internal/middleware"
+	"github.com/yourusername/cron-observer/backend/internal/models"
+	"github.com/yourusername/cron-observer/backend/internal/scheduler"
+	"github.com/yourusername/cron-observer/backend/mocks"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.uber.org/mock/gomock"
+)
+
+func TestTaskGroupHandler_UpdateTaskGroup_DisablingCascadesToTasksInOneUpdate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	projectID := primitive.NewObjectID()
+	existing := &models.TaskGroup{
+		ID:        primitive.NewObjectID(),
+		UUID:      "group-uuid",
+		ProjectID: projectID,
+		Name:      "nightly",
+		Status:    models.TaskGroupStatusActive,
+		State:     models.TaskGroupStateRunning,
+	}
+
+	repo := mocks.NewMockRepository(ctrl)
+	eventBus := events.NewEventBus(100)
+	defer eventBus.Close()
+	handler := NewTaskGroupHandler(repo, eventBus, scheduler.New(eventBus, repo), middleware.NewSuperAdmins([]string{"root@example.com"}), nil)
+
+	repo.EXPECT().GetTaskGroupByUUID(gomock.Any(), "group-uuid").Return(existing, nil)
+	repo.EXPECT().UpdateTaskGroupWithTasks(gomock.Any(), gomock.Any(), models.TaskGroupCascade{
+		TaskStatus: models.TaskStatusDisabled,
+		TaskState:  models.TaskStateNotRunning,
+	}).DoAndReturn(func(_ interface{}, taskGroup *models.TaskGroup, _ models.TaskGroupCascade) (*models.TaskGroupCascadeResult, error) {
+		if taskGroup.Status != models.TaskGroupStatusDisabled || taskGroup.State != models.TaskGroupStateNotRunning {
+			t.Errorf("Unexpected task group written: status=%s, state=%s", taskGroup.Status, taskGroup.State)
+		}
+		return &models.TaskGroupCascadeResult{StatusUpdated: 1, StateUpdated: 1}, nil
+	})
+	repo.EXPECT().GetTasksByGroupID(gomock.Any(), existing.ID).Return([]*models.Task{{UUID: "task-uuid"}}, nil)
+
+	router := setupValidatedRouter(t, "root@example.com")
+	router.PUT("/api/v1/projects/:project_id/task-groups/:group_uuid", handler.UpdateTaskGroup)
+
+	body := `{"name":"nightly","status":"DISABLED"}`
+	req, _ := http.NewRequest("PUT", "/api/v1/projects/"+projectID.Hex()+"/task-groups/group-uuid", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status code %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+}
+
+func TestTaskGroupHandler_UpdateTaskGroup_FailedCascadeReturnsError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	projectID := primitive.NewObjectID()
+	existing := &models.TaskGroup{
+		ID:        primitive.NewObjectID(),
+		UUID:      "group-uuid",
+		ProjectID: projectID,
+		Name:      "nightly",
+		Status:    models.TaskGroupStatusDisabled,
+		State:     models.TaskGroupStateNotRunning,
+	}
+
+	repo := mocks.NewMockRepository(ctrl)
+	eventBus := events.NewEventBus(100)
+	defer eventBus.Close()
+	updatedCh := eventBus.Subscribe(events.TaskGroupUpdated)
+	handler := NewTaskGroupHandler(repo, eventBus, scheduler.New(eventBus, repo), middleware.NewSuperAdmins([]string{"root@example.com"}), nil)
+
+	repo.EXPECT().GetTaskGroupByUUID(gomock.Any(), "group-uuid").Return(existing, nil)
+	repo.EXPECT().UpdateTaskGroupWithTasks(gomock.Any(), gomock.Any(), models.TaskGroupCascade{
+		TaskStatus: models.TaskStatusActive,
+	}).Return(nil, errors.New("transaction aborted"))
+
+	router := setupValidatedRouter(t, "root@example.com")
+	router.PUT("/api/v1/projects/:project_id/task-groups/:group_uuid", handler.UpdateTaskGroup)
+
+	body := `{"name":"nightly","status":"ACTIVE"}`
+	req, _ := http.NewRequest("PUT", "/api/v1/projects/"+projectID.Hex()+"/task-groups/group-uuid", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusInternalServerError {
+		t.Fatalf("Expected status code %d, got %d: %s", http.StatusInternalServerError, w.Code, w.Body.String())
+	}
+	if len(updatedCh) != 0 {
+		t.Error("TaskGroupUpdated must not be published when the update failed")
+	}
+}
+
+func TestTaskGroupHandler_RejectsGroupsOfOtherProjects(t *testing.T) {
+	projectID := primitive.NewObjectID()
+	foreign := &models.TaskGroup{
+		ID:        primitive.NewObjectID(),
+		UUID:      "foreign-group",
+		ProjectID: primitive.NewObjectID(),
+		Name:      "nightly",
+		Status:    models.TaskGroupStatusActive,
+	}
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		body   string
+		route  func(h *TaskGroupHandler) func(c *gin.Context)
+	}{
+		{"update", http.MethodPut, "", `{"name":"taken over"}`, func(h *TaskGroupHandler) func(c *gin.Context) { return h.UpdateTaskGroup }},
+		{"start", http.MethodPost, "/start", "", func(h *TaskGroupHandler) func(c *gin.Context) { return h.StartGroup }},
+		{"stop", http.MethodPost, "/stop", "", func(h *TaskGroupHandler) func(c *gin.Context) { return h.StopGroup }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			// The group is only looked up: nothing is written and no task is started or stopped
+			repo := mocks.NewMockRepository(ctrl)
+			repo.EXPECT().GetTaskGroupByUUID(gomock.Any(), "foreign-group").Return(foreign, nil)
+			eventBus := events.NewEventBus(100)
+			defer eventBus.Close()
+			handler := NewTaskGroupHandler(repo, eventBus, scheduler.New(eventBus, repo), middleware.NewSuperAdmins([]string{"root@example.com"}), nil)
+
+			router := setupValidatedRouter(t, "root@example.com")
+			router.Handle(tt.method, "/api/v1/projects/:project_id/task-groups/:group_uuid"+tt.path, tt.route(handler))
+
+			req, _ := http.NewRequest(tt.method, "/api/v1/projects/"+projectID.Hex()+"/task-groups/foreign-group"+tt.path, strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != http.StatusNotFound {
+				t.Fatalf("Expected status code %d, got %d: %s", http.StatusNotFound, w.Code, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestTaskGroupHandler_GetGroupTimeline_OvernightWindow(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	projectID := primitive.NewObjectID()
+	group := &models.TaskGroup{
+		ID:        primitive.NewObjectID(),
+		UUID:      "group-uuid",
+		ProjectID: projectID,
+		Name:      "nightly",
+		StartTime: "22:00",
+		EndTime:   "04:00",
+		Timezone:  "America/New_York",
+	}
+	tasks := []*models.Task{{UUID: "extract", Name: "extract"}, {UUID: "load", Name: "load"}, {UUID: "report", Name: "report"}}
+	at := func(hour, minute int) time.Time {
+		return time.Date(2025, 1, 16, hour, minute, 0, 0, time.UTC)
+	}
+	ended := func(hour, minute int) *time.Time {
+		end := at(hour, minute)
+		return &end
+	}
+	executions := []*models.Execution{
+		{UUID: "load-1", TaskUUID: "load", Status: models.ExecutionStatusSuccess, StartedAt: at(3, 5), EndedAt: ended(3, 30)},
+		{UUID: "extract-1", TaskUUID: "extract", Status: models.ExecutionStatusSuccess, StartedAt: at(3, 10), EndedAt: ended(3, 20)},
+		{UUID: "load-2", TaskUUID: "load", Status: models.ExecutionStatusFailed, StartedAt: at(3, 30), EndedAt: ended(3, 40)},
+	}
+
+	repo := mocks.NewMockRepository(ctrl)
+	eventBus := events.NewEventBus(100)
+	defer eventBus.Close()
+	handler := NewTaskGroupHandler(repo, eventBus, scheduler.New(eventBus, repo), middleware.NewSuperAdmins([]string{"root@example.com"}), nil)
+
+	repo.EXPECT().GetTaskGroupByUUID(gomock.Any(), "group-uuid").Return(group, nil)
+	repo.EXPECT().GetTasksByGroupID(gomock.Any(), group.ID).Return(tasks, nil)
+	// 22:00 to 04:00 in New York is 03:00 to 09:00 UTC on the next day
+	repo.EXPECT().GetExecutionsByTaskUUIDsBetween(gomock.Any(), []string{"extract", "load", "report"}, gomock.Any(), gomock.Any(), timelineMaxExecutions).
+		DoAndReturn(func(_ interface{}, _ []string, from, to time.Time, _ int) ([]*models.Execution, error) {
+			if !from.Equal(at(3, 0)) || !to.Before(at(9, 0)) || to.Before(at(8, 59)) {
+				t.Errorf("Unexpected range %s to %s", from, to)
+			}
+			return executions, nil
+		})
+
+	router := setupProjectRouter("root@example.com")
+	router.GET("/api/v1/projects/:project_id/task-groups/:group_uuid/timeline", handler.GetGroupTimeline)
+
+	req, _ := http.NewRequest(http.MethodGet, "/api/v1/projects/"+projectID.Hex()+"/task-groups/group-uuid/timeline?date=2025-01-15", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var body models.GroupTimelineResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+		t.Fatalf("Unexpected response: %s", w.Body.String())
+	}
+	if !body.WindowStart.Equal(at(3, 0)) || !body.WindowEnd.Equal(at(9, 0)) {
+		t.Errorf("Expected the window 03:00 to 09:00 UTC, got %s to %s", body.WindowStart, body.WindowEnd)
+	}
+	// load-2 starts as extract-1 ended and load-1 ends, so at most two run at once
+	if body.MaxConcurrent != 2 {
+		t.Errorf("Expected max_concurrent 2, got %d", body.MaxConcurrent)
+	}
+	var lanes []string
+	for _, lane := range body.Lanes {
+		lanes = append(lanes, lane.TaskName)
+	}
+	if strings.Join(lanes, ",") != "load,extract,report" || len(body.Lanes[0].Executions) != 2 || len(body.Lanes[2].Executions) != 0 {
+		t.Errorf("Expected lanes load (2 executions), extract and report (none), got %+v", body.Lanes)
+	}
+}
@@ -2,17 +2,22 @@ package handlers
 
 import (
 	"context"
+	"errors"
+	"io"
 	"log"
 	"net/http"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
 	"github.com/google/uuid"
 	"github.com/yourusername/cron-observer/backend/internal/deletequeue"
 	"github.com/yourusername/cron-observer/backend/internal/events"
 	"github.com/yourusername/cron-observer/backend/internal/middleware"
 	"github.com/yourusername/cron-observer/backend/internal/models"
+	"github.com/yourusername/cron-observer/backend/internal/quota"
 	"github.com/yourusername/cron-observer/backend/internal/repositories"
 	"github.com/yourusername/cron-observer/backend/internal/scheduler"
 	"github.com/yourusername/cron-observer/backend/internal/utils"
@@ -27,44 +32,78 @@ type TaskHandler struct {
 		RegisterTask(ctx context.Context, task *models.Task) error
 		UnregisterTask(taskUUID string)
 		IsWithinGroupWindow(ctx context.Context, taskGroup *models.TaskGroup) bool
+		NextRun(taskUUID string) (time.Time, bool)
 	}
-	superAdminMap   map[string]bool
-	deletePublisher deletequeue.DeleteJobPublisher // optional until wired in main
+	superAdmins       *middleware.SuperAdmins
+	deletePublisher   deletequeue.DeleteJobPublisher // optional until wired in main
+	quotas            *quota.Service                 // optional; nil enforces no quotas
+	schedulerLocation *time.Location                 // Timezone of tasks without one, like the scheduler's
 }
 
 func NewTaskHandler(repo repositories.Repository, eventBus *events.EventBus, scheduler interface {
 	RegisterTask(ctx context.Context, task *models.Task) error
 	UnregisterTask(taskUUID string)
 	IsWithinGroupWindow(ctx context.Context, taskGroup *models.TaskGroup) bool
-}, superAdmins []string, deletePublisher deletequeue.DeleteJobPublisher) *TaskHandler {
-
-	// Create a map for O(1) lookup
-	superAdminMap := make(map[string]bool)
-	for _, admin := range superAdmins {
-		normalizedAdmin := strings.ToLower(strings.TrimSpace(admin))
-		if normalizedAdmin != "" {
-			superAdminMap[normalizedAdmin] = true
-		}
-	}
+	NextRun(taskUUID string) (time.Time, bool)
+}, superAdmins *middleware.SuperAdmins, deletePublisher deletequeue.DeleteJobPublisher) *TaskHandler {
 
 	return &TaskHandler{
-		repo:            repo,
-		eventBus:        eventBus,
-		scheduler:       scheduler, // Can be nil if scheduler is not needed
-		superAdminMap:   superAdminMap,
-		deletePublisher: deletePublisher, // optional until wired in main
+		repo:              repo,
+		eventBus:          eventBus,
+		scheduler:         scheduler, // Can be nil if scheduler is not needed
+		superAdmins:       superAdmins,
+		deletePublisher:   deletePublisher, // optional until wired in main
+		schedulerLocation: time.UTC,
 	}
 }
 
-// GetTasksByProject retrieves all tasks for a project
+// SetSchedulerLocation sets the timezone schedule conflicts of tasks without their own or a project default are
+// simulated in; it must match the scheduler's (SCHEDULER_TIMEZONE)
+func (h *TaskHandler) SetSchedulerLocation(loc *time.Location) {
+	h.schedulerLocation = loc
+}
+
+// SetQuotaService enforces the project task and execution quotas
+func (h *TaskHandler) SetQuotaService(quotas *quota.Service) {
+	h.quotas = quotas
+}
+
+// saveNewTask creates the task and publishes TaskCreated, which the event outbox stores in the same transaction
+func (h *TaskHandler) saveNewTask(ctx context.Context, projectID primitive.ObjectID, task *models.Task) error {
+	return events.PublishAtomic(ctx, h.eventBus, events.TaskCreatedTopic, func(ctx context.Context) (events.TaskPayload, error) {
+		return events.TaskPayload{Task: task}, h.repo.CreateTask(ctx, projectID.Hex(), task)
+	})
+}
+
+// saveTask replaces the task and publishes TaskUpdated, which the event outbox stores in the same transaction
+func (h *TaskHandler) saveTask(ctx context.Context, task *models.Task) error {
+	return events.PublishAtomic(ctx, h.eventBus, events.TaskUpdatedTopic, func(ctx context.Context) (events.TaskPayload, error) {
+		return events.TaskPayload{Task: task}, h.repo.UpdateTask(ctx, task.UUID, task)
+	})
+}
+
+// GetTasksByProject retrieves the tasks of a project
 // @Summary      Get tasks by project
-// @Description  Retrieve all tasks belonging to a project
+// @Description  Retrieve tasks belonging to a project, optionally filtered and sorted. Without page or page_size all matching tasks are returned as an array; with either, a paginated response is returned.
 // @Tags         tasks
 // @Accept       json
 // @Produce      json
 // @Param        project_id path string true "Project ID"
-// @Success      200  {array}   models.Task
+// @Param        page query int false "Page number (default: 1)"
+// @Param        page_size query int false "Page size (default: 100, max: 100)"
+// @Param        sort query string false "Sort field; severity sorts by urgency" Enums(name, created_at, last_failure_at, severity)
+// @Param        order query string false "Sort order (default: asc)" Enums(asc, desc)
+// @Param        status query string false "Filter by status" Enums(ACTIVE, DISABLED, PAUSED)
+// @Param        state query string false "Filter by state" Enums(RUNNING, NOT_RUNNING)
+// @Param        task_group_id query string false "Filter by task group ID"
+// @Param        schedule_type query string false "Filter by schedule type" Enums(RECURRING, ONEOFF)
+// @Param        tag query []string false "Filter by tag; repeat to require several tags" collectionFormat(multi)
+// @Param        severity query string false "Filter by severity" Enums(CRITICAL, HIGH, NORMAL, LOW)
+// @Param        search query string false "Case-insensitive text matched against name, description and metadata keys"
+// @Success      200  {array}   models.TaskDetailResponse
+// @Success      200  {object}  models.PaginatedTasksResponse
 // @Failure      400  {object}  models.ErrorResponse
+// @Failure      403  {object}  models.ErrorResponse
 // @Failure      500  {object}  models.ErrorResponse
 // @Router       /projects/{project_id}/tasks [get]
 func (h *TaskHandler) GetTasksByProject(c *gin.Context) {
@@ -85,8 +124,24 @@ func (h *TaskHandler) GetTasksByProject(c *gin.Context) {
 		return
 	}
 
-	// Get all tasks for this project
-	tasks, err := h.repo.GetTasksByProjectID(c.Request.Context(), projectID)
+	// Check authorization: user must be a member of the project, or super admin
+	if !RequireProjectPermission(c, h.repo, projectID, h.superAdmins, PermissionViewProject) {
+		return
+	}
+
+	filter, ok := parseTaskListFilter(c)
+	if !ok {
+		return
+	}
+
+	// Without pagination parameters keep returning a plain array for existing clients
+	paginated := c.Query("page") != "" || c.Query("page_size") != ""
+	page, pageSize := 1, 0
+	if paginated {
+		page, pageSize = parsePagination(c)
+	}
+
+	tasks, totalCount, err := h.repo.ListTasksByProjectID(c.Request.Context(), projectID, filter, page, pageSize)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error": "Failed to get tasks for project",
@@ -98,12 +153,234 @@ func (h *TaskHandler) GetTasksByProject(c *gin.Context) {
 		tasks = []*models.Task{}
 	}
 
-	c.JSON(http.StatusOK, tasks)
+	// One query for the last execution of every listed task instead of one per row
+	taskUUIDs := make([]string, len(tasks))
+	for i, task := range tasks {
+		taskUUIDs[i] = task.UUID
+	}
+	lastExecutions, err := h.repo.GetLatestExecutionsByTaskUUIDs(c.Request.Context(), taskUUIDs)
+	if err != nil {
+		log.Printf("Failed to get last executions for project %s: %v", projectID.Hex(), err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to get last executions",
+		})
+		return
+	}
+
+	details := make([]*models.TaskDetailResponse, len(tasks))
+	for i, task := range tasks {
+		details[i] = h.taskDetail(task, lastExecutions[task.UUID])
+	}
+
+	if !paginated {
+		c.JSON(http.StatusOK, details)
+		return
+	}
+
+	// Calculate total pages
+	totalPages := int((totalCount + int64(pageSize) - 1) / int64(pageSize))
+	if totalPages == 0 {
+		totalPages = 1
+	}
+
+	c.JSON(http.StatusOK, models.PaginatedTasksResponse{
+		Data:       details,
+		Page:       page,
+		PageSize:   pageSize,
+		TotalCount: totalCount,
+		TotalPages: totalPages,
+	})
+}
+
+// parseTaskListFilter reads the task list's filter and sort query parameters.
+// It writes a 400 response and returns false when a parameter is invalid.
+func parseTaskListFilter(c *gin.Context) (models.TaskListFilter, bool) {
+	var filter models.TaskListFilter
+
+	invalid := func(message string) (models.TaskListFilter, bool) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": message,
+		})
+		return models.TaskListFilter{}, false
+	}
+
+	switch sortBy := models.TaskSortField(c.Query("sort")); sortBy {
+	case "", models.TaskSortByName, models.TaskSortByCreatedAt, models.TaskSortByLastFailure, models.TaskSortBySeverity:
+		filter.SortBy = sortBy
+	default:
+		return invalid("Invalid sort. Use name, created_at, last_failure_at or severity")
+	}
+
+	switch order := c.Query("order"); order {
+	case "", "asc":
+	case "desc":
+		filter.SortDesc = true
+	default:
+		return invalid("Invalid order. Use asc or desc")
+	}
+
+	switch status := models.TaskStatus(c.Query("status")); status {
+	case "", models.TaskStatusActive, models.TaskStatusDisabled, models.TaskStatusPaused:
+		filter.Status = status
+	default:
+		return invalid("Invalid status. Use ACTIVE, DISABLED or PAUSED")
+	}
+
+	switch state := models.TaskState(c.Query("state")); state {
+	case "", models.TaskStateRunning, models.TaskStateNotRunning:
+		filter.State = state
+	default:
+		return invalid("Invalid state. Use RUNNING or NOT_RUNNING")
+	}
+
+	switch scheduleType := models.ScheduleType(c.Query("schedule_type")); scheduleType {
+	case "", models.ScheduleTypeRecurring, models.ScheduleTypeOneOff:
+		filter.ScheduleType = scheduleType
+	default:
+		return invalid("Invalid schedule_type. Use RECURRING or ONEOFF")
+	}
+
+	filter.Tags = models.NormalizeTags(c.QueryArray("tag"))
+
+	if severity := models.TaskSeverity(c.Query("severity")); severity != "" {
+		if !severity.IsValid() {
+			return invalid("Invalid severity. Use CRITICAL, HIGH, NORMAL or LOW")
+		}
+		filter.Severity = severity
+	}
+
+	filter.Search = strings.TrimSpace(c.Query("search"))
+	if len(filter.Search) > 100 {
+		return invalid("search must be at most 100 characters")
+	}
+
+	if groupIDParam := c.Query("task_group_id"); groupIDParam != "" {
+		groupID, err := primitive.ObjectIDFromHex(groupIDParam)
+		if err != nil {
+			return invalid("Invalid task_group_id format")
+		}
+		filter.TaskGroupID = &groupID
+	}
+
+	return filter, true
+}
+
+// parsePagination reads page and page_size query parameters with defaults (1 and 100, max 100)
+func parsePagination(c *gin.Context) (int, int) {
+	page := 1
+	if pageParam := c.Query("page"); pageParam != "" {
+		if parsedPage, err := strconv.Atoi(pageParam); err == nil && parsedPage > 0 {
+			page = parsedPage
+		}
+	}
+
+	pageSize := 100
+	if pageSizeParam := c.Query("page_size"); pageSizeParam != "" {
+		if parsedPageSize, err := strconv.Atoi(pageSizeParam); err == nil && parsedPageSize > 0 {
+			// Limit max page size to prevent abuse
+			if parsedPageSize > 100 {
+				pageSize = 100
+			} else {
+				pageSize = parsedPageSize
+			}
+		}
+	}
+
+	return page, pageSize
+}
+
+// GetTask retrieves a single task with its scheduling state and last execution
+// @Summary      Get a task
+// @Description  Retrieve a task with derived fields: whether it is registered in the scheduler, its next run and a summary of its last execution
+// @Tags         tasks
+// @Accept       json
+// @Produce      json
+// @Param        project_id path string true "Project ID"
+// @Param        task_uuid path string true "Task UUID"
+// @Success      200  {object}  models.TaskDetailResponse
+// @Failure      400  {object}  models.ErrorResponse
+// @Failure      403  {object}  models.ErrorResponse
+// @Failure      404  {object}  models.ErrorResponse
+// @Failure      500  {object}  models.ErrorResponse
+// @Router       /projects/{project_id}/tasks/{task_uuid} [get]
+func (h *TaskHandler) GetTask(c *gin.Context) {
+	projectID, err := primitive.ObjectIDFromHex(c.Param("project_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid project_id format in path",
+		})
+		return
+	}
+
+	// Check authorization: user must be a member of the project, or super admin
+	if !RequireProjectPermission(c, h.repo, projectID, h.superAdmins, PermissionViewProject) {
+		return
+	}
+
+	taskUUID := c.Param("task_uuid")
+	if taskUUID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "task_uuid is required in path",
+		})
+		return
+	}
+
+	task, err := h.repo.GetTaskByUUID(c.Request.Context(), taskUUID)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": "Task not found",
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to get task",
+		})
+		return
+	}
+	if task.ProjectID != projectID {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Task not found",
+		})
+		return
+	}
+
+	lastExecution, err := h.repo.GetLatestExecutionByTaskUUID(c.Request.Context(), task.UUID)
+	if err != nil {
+		log.Printf("Failed to get last execution for task %s: %v", task.UUID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to get last execution",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, h.taskDetail(task, lastExecution))
+}
+
+// taskDetail combines a task with its scheduler state and last execution (nil when it never ran)
+func (h *TaskHandler) taskDetail(task *models.Task, lastExecution *models.Execution) *models.TaskDetailResponse {
+	task.Muted = task.IsMuted(time.Now())
+	detail := &models.TaskDetailResponse{Task: *task}
+
+	// The live cron entry is authoritative over the persisted next_run_at
+	if h.scheduler != nil {
+		nextRun, registered := h.scheduler.NextRun(task.UUID)
+		detail.IsRegistered = registered
+		detail.NextRunAt = nil
+		if registered && !nextRun.IsZero() {
+			detail.NextRunAt = &nextRun
+		}
+	}
+
+	if lastExecution != nil {
+		detail.LastExecution = lastExecution.Summary()
+	}
+	return detail
 }
 
 // CreateTask creates a new task
 // @Summary      Create a new task
-// @Description  Create a new scheduled task in a project
+// @Description  Create a new scheduled task in a project. When the task's group has a concurrency_budget, its runs over the next 24 hours are checked against those of the group's other tasks: conflicts are reported in schedule_conflict, or rejected with 409 when the group's schedule_conflict_policy is REJECT.
 // @Tags         tasks
 // @Accept       json
 // @Produce      json
@@ -111,6 +388,8 @@ func (h *TaskHandler) GetTasksByProject(c *gin.Context) {
 // @Param        task body models.CreateTaskRequest true "Task creation request"
 // @Success      201  {object}  models.Task
 // @Failure      400  {object}  models.ErrorResponse
+// @Failure      403  {object}  models.ErrorResponse
+// @Failure      409  {object}  models.ErrorResponse
 // @Failure      500  {object}  models.ErrorResponse
 // @Router       /projects/{project_id}/tasks [post]
 func (h *TaskHandler) CreateTask(c *gin.Context) {
@@ -163,6 +442,16 @@ func (h *TaskHandler) CreateTask(c *gin.Context) {
 		return
 	}
 
+	// Check authorization: user must be editor or admin in project, or super admin
+	if !RequireProjectPermission(c, h.repo, projectID, h.superAdmins, PermissionManageTasks) {
+		return
+	}
+
+	h.createTask(c, projectID, req)
+}
+
+// createTask creates a task from a validated request in a project the user may manage
+func (h *TaskHandler) createTask(c *gin.Context, projectID primitive.ObjectID, req models.CreateTaskRequest) {
 	// Set default status if not provided. Binding restricts client input to ACTIVE/DISABLED only (PENDING_DELETE/DELETE_FAILED are backend-only).
 	status := req.Status
 	if status == "" {
@@ -195,6 +484,22 @@ func (h *TaskHandler) CreateTask(c *gin.Context) {
 		}
 	}
 
+	if req.Environment != "" && !h.requireEnvironment(c, projectID, req.Environment) {
+		return
+	}
+
+	if !requireMetadataMatchesSchema(c, h.repo, projectID, req.Metadata) {
+		return
+	}
+
+	if !requireResolvedSchedule(c, &req.ScheduleConfig) {
+		return
+	}
+
+	if !requireTaskQuota(c, h.repo, h.quotas, projectID, 1) {
+		return
+	}
+
 	// Convert request DTO to Task model
 	task := &models.Task{
 		ProjectID:    projectID,
@@ -210,9 +515,17 @@ func (h *TaskHandler) CreateTask(c *gin.Context) {
 			Timezone:       req.ScheduleConfig.Timezone,
 			DaysOfWeek:     req.ScheduleConfig.DaysOfWeek,
 			Exclusions:     req.ScheduleConfig.Exclusions,
+			Schedule:       req.ScheduleConfig.Schedule,
 		},
 		TimeoutSeconds: req.TimeoutSeconds,
+		Priority:       req.Priority,
+		AutoPauseAfter: req.AutoPauseAfter,
+		Concurrency:    req.Concurrency,
+		Severity:       req.Severity.OrDefault(),
 		Metadata:       req.Metadata,
+		Environment:    req.Environment,
+		Env:            req.Env,
+		Tags:           models.NormalizeTags(req.Tags),
 		CreatedAt:      time.Now(),
 		UpdatedAt:      time.Now(),
 	}
@@ -232,27 +545,334 @@ func (h *TaskHandler) CreateTask(c *gin.Context) {
 	// TriggerConfig is no longer required - tasks use project's execution_endpoint
 	// Leave TriggerConfig empty/zero value for new tasks
 
-	// Create the task
-	err = h.repo.CreateTask(c.Request.Context(), projectIDParam, task)
-	if err != nil {
+	conflict, reject := h.checkScheduleConflict(c.Request.Context(), task)
+	if reject {
+		c.JSON(http.StatusConflict, gin.H{
+			"error":             "Task schedule exceeds the concurrency budget of its task group",
+			"schedule_conflict": conflict,
+		})
+		return
+	}
+
+	// Create the task and publish TaskCreated
+	if err := h.saveNewTask(c.Request.Context(), projectID, task); err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error": "Failed to create task",
 		})
 		return
 	}
-
-	// Publish TaskCreated event
-	h.eventBus.Publish(events.Event{
-		Type:    events.TaskCreated,
-		Payload: events.TaskPayload{Task: task},
-	})
+	task.Conflict = conflict
 
 	c.JSON(http.StatusCreated, task)
 }
 
-// UpdateTask updates an existing task
+// CloneTask duplicates a task with a new UUID
+// @Summary      Clone a task
+// @Description  Create a copy of a task with a new UUID and a " (copy)" name suffix, carrying over its schedule, trigger config, timeout, metadata, environment and tags. The copy is DISABLED unless enable is set, and can be placed into a different task group.
+// @Tags         tasks
+// @Accept       json
+// @Produce      json
+// @Param        project_id path string true "Project ID"
+// @Param        task_uuid path string true "Task UUID"
+// @Param        clone body models.CloneTaskRequest false "Clone options"
+// @Success      201  {object}  models.Task
+// @Failure      400  {object}  models.ErrorResponse
+// @Failure      403  {object}  models.ErrorResponse
+// @Failure      404  {object}  models.ErrorResponse
+// @Failure      409  {object}  models.ErrorResponse
+// @Failure      500  {object}  models.ErrorResponse
+// @Router       /projects/{project_id}/tasks/{task_uuid}/clone [post]
+func (h *TaskHandler) CloneTask(c *gin.Context) {
+	var req models.CloneTaskRequest
+	// The body is optional; an empty body clones with defaults
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		utils.HandleValidationError(c, err)
+		return
+	}
+
+	projectID, source, ok := h.getTaskForUpdate(c)
+	if !ok {
+		return
+	}
+
+	taskGroupID := source.TaskGroupID
+	if req.TaskGroupID != "" {
+		groupID, err := primitive.ObjectIDFromHex(req.TaskGroupID)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "Invalid task_group_id format",
+			})
+			return
+		}
+
+		taskGroup, err := h.repo.GetTaskGroupByID(c.Request.Context(), groupID)
+		if err != nil || taskGroup.ProjectID != projectID {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": "Task group not found",
+			})
+			return
+		}
+		taskGroupID = &groupID
+	}
+
+	name := req.Name
+	if name == "" {
+		name = copyName(source.Name)
+	}
+
+	status := models.TaskStatusDisabled
+	if req.Enable && source.Status != models.TaskStatusPaused {
+		status = source.Status
+	} else if req.Enable {
+		status = models.TaskStatusActive // The copy has not failed yet
+	}
+
+	if !requireTaskQuota(c, h.repo, h.quotas, projectID, 1) {
+		return
+	}
+
+	now := time.Now()
+	clone := *source
+	clone.ID = primitive.NewObjectID()
+	clone.UUID = uuid.New().String()
+	clone.TaskGroupID = taskGroupID
+	clone.Name = name
+	clone.Status = status
+	clone.State = models.TaskStateNotRunning // updated by the scheduler when the group window starts
+	clone.LastFailureAt = nil
+	clone.MutedUntil = nil
+	clone.FlappingSince = nil
+	clone.AutoPausedAt = nil
+	clone.CreatedAt = now
+	clone.UpdatedAt = now
+
+	// TaskCreated makes the scheduler register enabled copies
+	if err := h.saveNewTask(c.Request.Context(), projectID, &clone); err != nil {
+		log.Printf("Failed to clone task %s: %v", source.UUID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to clone task",
+		})
+		return
+	}
+
+	log.Printf("Task cloned: source=%s, clone=%s, project=%s", source.UUID, clone.UUID, projectID.Hex())
+	c.JSON(http.StatusCreated, &clone)
+}
+
+// MoveTask moves a task to another task group of the same project, or out of its group
+// @Summary      Move a task between task groups
+// @Description  Assign the task to the task group with the given UUID, or remove it from its group when task_group_uuid is null. The task's state follows the new group's window and the scheduler re-registers it.
+// @Tags         tasks
+// @Accept       json
+// @Produce      json
+// @Param        project_id path string true "Project ID"
+// @Param        task_uuid path string true "Task UUID"
+// @Param        request body models.MoveTaskRequest true "Target task group"
+// @Success      200  {object}  models.Task
+// @Failure      400  {object}  models.ErrorResponse
+// @Failure      403  {object}  models.ErrorResponse
+// @Failure      404  {object}  models.ErrorResponse
+// @Failure      409  {object}  models.ErrorResponse
+// @Failure      500  {object}  models.ErrorResponse
+// @Router       /projects/{project_id}/tasks/{task_uuid}/move [post]
+func (h *TaskHandler) MoveTask(c *gin.Context) {
+	var req models.MoveTaskRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.HandleValidationError(c, err)
+		return
+	}
+
+	projectID, existingTask, ok := h.getTaskForUpdate(c)
+	if !ok {
+		return
+	}
+
+	ctx := c.Request.Context()
+	var taskGroup *models.TaskGroup
+	if req.TaskGroupUUID != nil {
+		group, err := h.repo.GetTaskGroupByUUID(ctx, *req.TaskGroupUUID)
+		if err != nil || group.ProjectID != projectID {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": "Task group not found",
+			})
+			return
+		}
+		if group.Status == models.TaskGroupStatusPendingDelete {
+			c.JSON(http.StatusConflict, gin.H{
+				"error": "Task group is being deleted",
+			})
+			return
+		}
+		taskGroup = group
+	}
+
+	updatedTask := *existingTask
+	updatedTask.TaskGroupID = nil
+	updatedTask.State = models.TaskStateNotRunning
+	if taskGroup != nil {
+		updatedTask.TaskGroupID = &taskGroup.ID
+
+		// Tasks of an active group run while the group's window is open
+		if updatedTask.Status == models.TaskStatusActive && taskGroup.Status == models.TaskGroupStatusActive &&
+			h.scheduler != nil && h.scheduler.IsWithinGroupWindow(ctx, taskGroup) {
+			updatedTask.State = models.TaskStateRunning
+		}
+	}
+	updatedTask.UpdatedAt = time.Now()
+
+	// TaskUpdated makes the scheduler re-register the task against the new group's window
+	if err := h.saveTask(ctx, &updatedTask); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to move task",
+		})
+		return
+	}
+
+	target := "no group"
+	if taskGroup != nil {
+		target = "group " + taskGroup.UUID
+	}
+	log.Printf("Task moved: task=%s, project=%s, to %s", updatedTask.UUID, projectID.Hex(), target)
+	c.JSON(http.StatusOK, &updatedTask)
+}
+
+// MuteTask suppresses failure alerts for a task until a given time
+// @Summary      Mute a task's alerts
+// @Description  Suppress failure alerts for the task until the given time, or for duration_minutes from now. Executions keep running and are recorded as usual.
+// @Tags         tasks
+// @Accept       json
+// @Produce      json
+// @Param        project_id path string true "Project ID"
+// @Param        task_uuid path string true "Task UUID"
+// @Param        request body models.MuteTaskRequest true "Mute end time or duration"
+// @Success      200  {object}  models.Task
+// @Failure      400  {object}  models.ErrorResponse
+// @Failure      403  {object}  models.ErrorResponse
+// @Failure      404  {object}  models.ErrorResponse
+// @Failure      409  {object}  models.ErrorResponse
+// @Failure      500  {object}  models.ErrorResponse
+// @Router       /projects/{project_id}/tasks/{task_uuid}/mute [post]
+func (h *TaskHandler) MuteTask(c *gin.Context) {
+	var req models.MuteTaskRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.HandleValidationError(c, err)
+		return
+	}
+
+	if (req.Until == nil) == (req.DurationMinutes == 0) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Provide exactly one of until or duration_minutes",
+		})
+		return
+	}
+
+	now := time.Now()
+	mutedUntil := now.Add(time.Duration(req.DurationMinutes) * time.Minute)
+	if req.Until != nil {
+		mutedUntil = req.Until.UTC()
+	}
+	if !mutedUntil.After(now) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "until must be in the future",
+		})
+		return
+	}
+
+	h.setTaskMute(c, &mutedUntil)
+}
+
+// UnmuteTask clears a task's alert mute
+// @Summary      Unmute a task's alerts
+// @Description  Resume failure alerts for the task immediately
+// @Tags         tasks
+// @Produce      json
+// @Param        project_id path string true "Project ID"
+// @Param        task_uuid path string true "Task UUID"
+// @Success      200  {object}  models.Task
+// @Failure      400  {object}  models.ErrorResponse
+// @Failure      403  {object}  models.ErrorResponse
+// @Failure      404  {object}  models.ErrorResponse
+// @Failure      409  {object}  models.ErrorResponse
+// @Failure      500  {object}  models.ErrorResponse
+// @Router       /projects/{project_id}/tasks/{task_uuid}/mute [delete]
+func (h *TaskHandler) UnmuteTask(c *gin.Context) {
+	h.setTaskMute(c, nil)
+}
+
+// setTaskMute stores the task's mute end time (nil clears it) and responds with the updated task
+func (h *TaskHandler) setTaskMute(c *gin.Context, mutedUntil *time.Time) {
+	projectID, task, ok := h.getTaskForUpdate(c)
+	if !ok {
+		return
+	}
+
+	if err := h.repo.SetTaskMutedUntil(c.Request.Context(), task.UUID, mutedUntil); err != nil {
+		log.Printf("Failed to update mute for task %s: %v", task.UUID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to update task mute",
+		})
+		return
+	}
+
+	task.MutedUntil = mutedUntil
+	task.Muted = task.IsMuted(time.Now())
+	task.UpdatedAt = time.Now()
+
+	if mutedUntil != nil {
+		log.Printf("Task muted: task=%s, project=%s, until=%s", task.UUID, projectID.Hex(), mutedUntil.Format(time.RFC3339))
+	} else {
+		log.Printf("Task unmuted: task=%s, project=%s", task.UUID, projectID.Hex())
+	}
+	c.JSON(http.StatusOK, task)
+}
+
+// copyName appends " (copy)" to a task name, trimming the original so the result stays within 255 characters
+func copyName(name string) string {
+	const suffix = " (copy)"
+	const maxLen = 255
+
+	runes := []rune(name)
+	if len(runes)+len(suffix) > maxLen {
+		runes = runes[:maxLen-len(suffix)]
+	}
+	return string(runes) + suffix
+}
+
+// requireResolvedSchedule translates a natural-language schedule into the cron expression.
+// It responds with 400 and returns false when the phrase is not understood or conflicts with cron_expression.
+func requireResolvedSchedule(c *gin.Context, config *models.ScheduleConfig) bool {
+	if err := config.ResolveSchedule(); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid schedule",
+			"details": []string{err.Error()},
+		})
+		return false
+	}
+	return true
+}
+
+// requireEnvironment responds with 400 and returns false when the project does not define the environment
+func (h *TaskHandler) requireEnvironment(c *gin.Context, projectID primitive.ObjectID, environment string) bool {
+	project, err := h.repo.GetProjectByID(c.Request.Context(), projectID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Project not found",
+		})
+		return false
+	}
+
+	if _, ok := project.FindEnvironment(environment); !ok {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Environment '" + environment + "' is not defined in this project",
+		})
+		return false
+	}
+	return true
+}
+
+// UpdateTask replaces an existing task
 // @Summary      Update a task
-// @Description  Update an existing scheduled task
+// @Description  Replace an existing scheduled task. UUID and created_at are preserved and the scheduler re-registers the task's cron entry. Schedule conflicts within the task's group are checked like on creation.
 // @Tags         tasks
 // @Accept       json
 // @Produce      json
@@ -261,7 +881,9 @@ func (h *TaskHandler) CreateTask(c *gin.Context) {
 // @Param        task body models.UpdateTaskRequest true "Task update request"
 // @Success      200  {object}  models.Task
 // @Failure      400  {object}  models.ErrorResponse
+// @Failure      403  {object}  models.ErrorResponse
 // @Failure      404  {object}  models.ErrorResponse
+// @Failure      409  {object}  models.ErrorResponse
 // @Failure      500  {object}  models.ErrorResponse
 // @Router       /projects/{project_id}/tasks/{task_uuid} [put]
 func (h *TaskHandler) UpdateTask(c *gin.Context) {
@@ -273,6 +895,55 @@ func (h *TaskHandler) UpdateTask(c *gin.Context) {
 		return
 	}
 
+	projectID, existingTask, ok := h.getTaskForUpdate(c)
+	if !ok {
+		return
+	}
+
+	h.applyTaskUpdate(c, projectID, existingTask, req)
+}
+
+// PatchTask partially updates an existing task
+// @Summary      Patch a task
+// @Description  Update only the provided fields of a scheduled task. schedule_config is replaced as a whole when present. The scheduler re-registers the task's cron entry. Schedule conflicts within the task's group are checked like on creation.
+// @Tags         tasks
+// @Accept       json
+// @Produce      json
+// @Param        project_id path string true "Project ID"
+// @Param        task_uuid path string true "Task UUID"
+// @Param        task body models.PatchTaskRequest true "Task patch request"
+// @Success      200  {object}  models.Task
+// @Failure      400  {object}  models.ErrorResponse
+// @Failure      403  {object}  models.ErrorResponse
+// @Failure      404  {object}  models.ErrorResponse
+// @Failure      409  {object}  models.ErrorResponse
+// @Failure      500  {object}  models.ErrorResponse
+// @Router       /projects/{project_id}/tasks/{task_uuid} [patch]
+func (h *TaskHandler) PatchTask(c *gin.Context) {
+	var patch models.PatchTaskRequest
+	if err := c.ShouldBindJSON(&patch); err != nil {
+		utils.HandleValidationError(c, err)
+		return
+	}
+
+	projectID, existingTask, ok := h.getTaskForUpdate(c)
+	if !ok {
+		return
+	}
+
+	// Validate the merged task the same way a full update is validated
+	req := patch.ToUpdateRequest(existingTask)
+	if err := binding.Validator.ValidateStruct(&req); err != nil {
+		utils.HandleValidationError(c, err)
+		return
+	}
+
+	h.applyTaskUpdate(c, projectID, existingTask, req)
+}
+
+// getTaskForUpdate resolves the path parameters, checks permissions and loads the task to update.
+// It writes the error response and returns false when the update cannot proceed.
+func (h *TaskHandler) getTaskForUpdate(c *gin.Context) (primitive.ObjectID, *models.Task, bool) {
 	// Get project_id and task_uuid from path parameters
 	projectIDParam := c.Param("project_id")
 	taskUUIDParam := c.Param("task_uuid")
@@ -281,14 +952,14 @@ func (h *TaskHandler) UpdateTask(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{
 			"error": "project_id is required in path",
 		})
-		return
+		return primitive.NilObjectID, nil, false
 	}
 
 	if taskUUIDParam == "" {
 		c.JSON(http.StatusBadRequest, gin.H{
 			"error": "task_uuid is required in path",
 		})
-		return
+		return primitive.NilObjectID, nil, false
 	}
 
 	// Convert project_id to ObjectID
@@ -297,23 +968,38 @@ func (h *TaskHandler) UpdateTask(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{
 			"error": "Invalid project_id format in path",
 		})
-		return
+		return primitive.NilObjectID, nil, false
 	}
 
-	// Check authorization: user must be admin in project or super admin
-	if !RequireProjectAdmin(c, h.repo, projectID, h.superAdminMap) {
-		return
+	// Check authorization: user must be editor or admin in project, or super admin
+	if !RequireProjectPermission(c, h.repo, projectID, h.superAdmins, PermissionManageTasks) {
+		return primitive.NilObjectID, nil, false
 	}
 
 	// Get existing task to preserve UUID and timestamps
 	existingTask, err := h.repo.GetTaskByUUID(c.Request.Context(), taskUUIDParam)
-	if err != nil {
+	if err != nil || existingTask.ProjectID != projectID {
 		c.JSON(http.StatusNotFound, gin.H{
 			"error": "Task not found",
 		})
-		return
+		return primitive.NilObjectID, nil, false
+	}
+
+	// Tasks queued for deletion are owned by the delete worker
+	if existingTask.Status == models.TaskStatusPendingDelete || existingTask.Status == models.TaskStatusDeleteFailed {
+		c.JSON(http.StatusConflict, gin.H{
+			"error": "Task is being deleted and cannot be updated",
+		})
+		return primitive.NilObjectID, nil, false
 	}
 
+	return projectID, existingTask, true
+}
+
+// applyTaskUpdate persists a validated full update and publishes TaskUpdated so the scheduler re-registers the task
+func (h *TaskHandler) applyTaskUpdate(c *gin.Context, projectID primitive.ObjectID, existingTask *models.Task, req models.UpdateTaskRequest) {
+	taskUUID := existingTask.UUID
+
 	// Set default status if not provided. Binding restricts client input to ACTIVE/DISABLED only (PENDING_DELETE/DELETE_FAILED are backend-only).
 	status := req.Status
 	if status == "" {
@@ -358,6 +1044,18 @@ func (h *TaskHandler) UpdateTask(c *gin.Context) {
 		}
 	}
 
+	if req.Environment != "" && !h.requireEnvironment(c, projectID, req.Environment) {
+		return
+	}
+
+	if !requireMetadataMatchesSchema(c, h.repo, projectID, req.Metadata) {
+		return
+	}
+
+	if !requireResolvedSchedule(c, &req.ScheduleConfig) {
+		return
+	}
+
 	// Update task fields
 	task := &models.Task{
 		ID:           existingTask.ID,
@@ -374,22 +1072,39 @@ func (h *TaskHandler) UpdateTask(c *gin.Context) {
 			Timezone:       req.ScheduleConfig.Timezone,
 			DaysOfWeek:     req.ScheduleConfig.DaysOfWeek,
 			Exclusions:     req.ScheduleConfig.Exclusions,
+			Schedule:       req.ScheduleConfig.Schedule,
 		},
 		TimeoutSeconds: req.TimeoutSeconds,
+		Priority:       req.Priority,
+		AutoPauseAfter: req.AutoPauseAfter,
+		Concurrency:    req.Concurrency,
+		Severity:       req.Severity.OrDefault(),
 		Metadata:       req.Metadata,
+		Environment:    req.Environment,
+		Env:            req.Env,
+		Tags:           models.NormalizeTags(req.Tags),
+		LastFailureAt:  existingTask.LastFailureAt,
+		MutedUntil:     existingTask.MutedUntil,
+		FlappingSince:  existingTask.FlappingSince,
 		CreatedAt:      existingTask.CreatedAt, // Preserve original creation time
 		UpdatedAt:      time.Now(),
 	}
+	// Paused tasks stay paused until they are set ACTIVE or DISABLED
+	if status == models.TaskStatusPaused {
+		task.AutoPausedAt = existingTask.AutoPausedAt
+	}
 
 	// Convert TimeRange if provided
 	if req.ScheduleConfig.TimeRange != nil {
 		task.ScheduleConfig.TimeRange = &models.TimeRange{
 			Start: req.ScheduleConfig.TimeRange.Start,
 			End:   req.ScheduleConfig.TimeRange.End,
-			Frequency: &models.Frequency{
+		}
+		if req.ScheduleConfig.TimeRange.Frequency != nil {
+			task.ScheduleConfig.TimeRange.Frequency = &models.Frequency{
 				Value: req.ScheduleConfig.TimeRange.Frequency.Value,
 				Unit:  req.ScheduleConfig.TimeRange.Frequency.Unit,
-			},
+			}
 		}
 	}
 
@@ -397,69 +1112,38 @@ func (h *TaskHandler) UpdateTask(c *gin.Context) {
 	// Preserve existing TriggerConfig if it exists, otherwise leave empty
 	task.TriggerConfig = existingTask.TriggerConfig
 
-	// Update the task
-	err = h.repo.UpdateTask(c.Request.Context(), taskUUIDParam, task)
-	if err != nil {
+	conflict, reject := h.checkScheduleConflict(c.Request.Context(), task)
+	if reject {
+		c.JSON(http.StatusConflict, gin.H{
+			"error":             "Task schedule exceeds the concurrency budget of its task group",
+			"schedule_conflict": conflict,
+		})
+		return
+	}
+
+	// Update the task; TaskUpdated makes the scheduler replace the task's cron entry with the new schedule
+	if err := h.saveTask(c.Request.Context(), task); err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error": "Failed to update task",
 		})
 		return
 	}
+	task.Conflict = conflict
 
 	// If status changed to DISABLED, update state and unregister cron job immediately
 	if status == models.TaskStatusDisabled && existingTask.Status != models.TaskStatusDisabled {
 		// Update state to NOT_RUNNING
-		if err := h.repo.UpdateTaskState(c.Request.Context(), taskUUIDParam, models.TaskStateNotRunning); err != nil {
-			log.Printf("Failed to update task %s state to NOT_RUNNING: %v", taskUUIDParam, err)
+		if err := h.repo.UpdateTaskState(c.Request.Context(), taskUUID, models.TaskStateNotRunning); err != nil {
+			log.Printf("Failed to update task %s state to NOT_RUNNING: %v", taskUUID, err)
 		}
 
 		// Unregister task from scheduler immediately
 		if h.scheduler != nil {
-			h.scheduler.UnregisterTask(taskUUIDParam)
-			log.Printf("Unregistered cron job for task %s (status set to DISABLED)", taskUUIDParam)
-		}
-	}
-
-	// If status changed to ACTIVE, check if we need to register cron job and update state
-	if status == models.TaskStatusActive && existingTask.Status != models.TaskStatusActive {
-		// Check if task belongs to an active group within window
-		if task.TaskGroupID != nil && h.scheduler != nil {
-			taskGroup, err := h.repo.GetTaskGroupByID(c.Request.Context(), *task.TaskGroupID)
-			if err == nil && taskGroup != nil {
-				if taskGroup.Status == models.TaskGroupStatusActive {
-					// Check if group is within time window
-					if h.scheduler.IsWithinGroupWindow(c.Request.Context(), taskGroup) {
-						// Update state to RUNNING
-						if err := h.repo.UpdateTaskState(c.Request.Context(), taskUUIDParam, models.TaskStateRunning); err != nil {
-							log.Printf("Failed to update task %s state to RUNNING: %v", taskUUIDParam, err)
-						}
-						// Register cron job
-						if err := h.scheduler.RegisterTask(c.Request.Context(), task); err != nil {
-							log.Printf("Failed to register task %s: %v", taskUUIDParam, err)
-						} else {
-							log.Printf("Registered cron job for task %s (status set to ACTIVE, group within window)", taskUUIDParam)
-						}
-					}
-				}
-			}
-		} else if task.TaskGroupID == nil && h.scheduler != nil {
-			// Task doesn't belong to a group - register directly if it has a cron expression
-			if task.ScheduleConfig.CronExpression != "" {
-				if err := h.scheduler.RegisterTask(c.Request.Context(), task); err != nil {
-					log.Printf("Failed to register task %s in scheduler: %v", taskUUIDParam, err)
-				} else {
-					log.Printf("Registered cron job for task %s (status set to ACTIVE, no group)", taskUUIDParam)
-				}
-			}
+			h.scheduler.UnregisterTask(taskUUID)
+			log.Printf("Unregistered cron job for task %s (status set to DISABLED)", taskUUID)
 		}
 	}
 
-	// Publish TaskUpdated event
-	h.eventBus.Publish(events.Event{
-		Type:    events.TaskUpdated,
-		Payload: events.TaskPayload{Task: task},
-	})
-
 	c.JSON(http.StatusOK, task)
 }
 
@@ -473,6 +1157,7 @@ func (h *TaskHandler) UpdateTask(c *gin.Context) {
 // @Param        task_uuid path string true "Task UUID"
 // @Success      200  {object}  models.DeleteTaskResponse "Task deleted successfully"
 // @Failure      400  {object}  models.ErrorResponse
+// @Failure      403  {object}  models.ErrorResponse
 // @Failure      404  {object}  models.ErrorResponse
 // @Failure      500  {object}  models.ErrorResponse
 // @Router       /projects/{project_id}/tasks/{task_uuid} [delete]
@@ -521,6 +1206,19 @@ func (h *TaskHandler) DeleteTask(c *gin.Context) {
 		return
 	}
 
+	projectID, err := primitive.ObjectIDFromHex(projectIDParam)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid project_id format in path",
+		})
+		return
+	}
+
+	// Check authorization: user must be editor or admin in project, or super admin
+	if !RequireProjectPermission(c, h.repo, projectID, h.superAdmins, PermissionManageTasks) {
+		return
+	}
+
 	ctx := c.Request.Context()
 
 	// Idempotent: if task already gone, treat as success
@@ -539,6 +1237,12 @@ func (h *TaskHandler) DeleteTask(c *gin.Context) {
 		})
 		return
 	}
+	if task.ProjectID != projectID {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Task not found",
+		})
+		return
+	}
 
 	// Check if RabbitMQ publisher is available
 	if h.deletePublisher == nil {
@@ -556,6 +1260,7 @@ func (h *TaskHandler) DeleteTask(c *gin.Context) {
 	}
 	
 	if err := h.deletePublisher.PublishDeleteTask(ctx, msg); err != nil {
+		log.Printf("[Handler] Failed to enqueue task delete job: TaskUUID=%s, error=%v", task.UUID, err)
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error": "Failed to enqueue delete job",
 		})
@@ -615,6 +1320,11 @@ func (h *TaskHandler) UpdateTaskStatus(c *gin.Context) {
 		return
 	}
 
+	// Check authorization: user must be editor or admin in project, or super admin
+	if !RequireProjectPermission(c, h.repo, projectID, h.superAdmins, PermissionManageTasks) {
+		return
+	}
+
 	// Parse request body. Only ACTIVE and DISABLED are accepted; PENDING_DELETE/DELETE_FAILED are backend-only.
 	var req struct {
 		Status models.TaskStatus `json:"status" binding:"required,oneof=ACTIVE DISABLED"`
@@ -670,14 +1380,15 @@ func (h *TaskHandler) UpdateTaskStatus(c *gin.Context) {
 		}
 	}
 
-	// Update task status
+	// Update task status; setting a paused task ACTIVE resumes it
 	updatedTask := *existingTask
 	updatedTask.Status = req.Status
 	updatedTask.State = state
+	updatedTask.AutoPausedAt = nil
 	updatedTask.UpdatedAt = time.Now()
 
-	// Update in database
-	err = h.repo.UpdateTask(c.Request.Context(), taskUUIDParam, &updatedTask)
+	// Update in database and publish TaskUpdated
+	err = h.saveTask(c.Request.Context(), &updatedTask)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error": "Failed to update task status",
@@ -728,12 +1439,6 @@ func (h *TaskHandler) UpdateTaskStatus(c *gin.Context) {
 		}
 	}
 
-	// Publish TaskUpdated event
-	h.eventBus.Publish(events.Event{
-		Type:    events.TaskUpdated,
-		Payload: events.TaskPayload{Task: &updatedTask},
-	})
-
 	c.JSON(http.StatusOK, &updatedTask)
 }
 
@@ -748,7 +1453,10 @@ func (h *TaskHandler) UpdateTaskStatus(c *gin.Context) {
 // @Success      201  {object}  map[string]interface{}
 // @Failure      400  {object}  models.ErrorResponse
 // @Failure      404  {object}  models.ErrorResponse
+// @Failure      409  {object}  models.ErrorResponse
+// @Failure      429  {object}  models.ErrorResponse
 // @Failure      500  {object}  models.ErrorResponse
+// @Failure      503  {object}  models.ErrorResponse
 // @Router       /projects/{project_id}/tasks/{task_uuid}/trigger [post]
 func (h *TaskHandler) TriggerTask(c *gin.Context) {
 	// Get project_id and task_uuid from path parameters
@@ -769,6 +1477,20 @@ func (h *TaskHandler) TriggerTask(c *gin.Context) {
 		return
 	}
 
+	// Convert project_id to ObjectID
+	projectID, err := primitive.ObjectIDFromHex(projectIDParam)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid project_id format in path",
+		})
+		return
+	}
+
+	// Check authorization: user must be editor or admin in project, or super admin
+	if !RequireProjectPermission(c, h.repo, projectID, h.superAdmins, PermissionManageTasks) {
+		return
+	}
+
 	// Get the task
 	task, err := h.repo.GetTaskByUUID(c.Request.Context(), taskUUIDParam)
 	if err != nil {
@@ -784,9 +1506,45 @@ func (h *TaskHandler) TriggerTask(c *gin.Context) {
 		return
 	}
 
+	// Verify project_id matches
+	if task.ProjectID != projectID {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Task does not belong to this project",
+		})
+		return
+	}
+
 	// Use the shared ExecuteTask function from scheduler package
 	executionUUID, err := scheduler.ExecuteTask(c.Request.Context(), task, h.repo, h.eventBus, "TRIGGER")
 	if err != nil {
+		if errors.Is(err, scheduler.ErrEnvironmentNotFound) {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "Task environment is not defined in this project",
+			})
+			return
+		}
+		if errors.Is(err, scheduler.ErrEnvVariableNotFound) {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "Trigger config references an env variable the task does not define: " + err.Error(),
+			})
+			return
+		}
+		if errors.Is(err, scheduler.ErrProjectArchived) {
+			c.JSON(http.StatusConflict, gin.H{
+				"error": "Project is archived; restore it before triggering tasks",
+			})
+			return
+		}
+		if respondQuotaExceeded(c, err) {
+			return
+		}
+		if errors.Is(err, scheduler.ErrCircuitOpen) {
+			c.JSON(http.StatusServiceUnavailable, gin.H{
+				"error":          "The execution endpoint keeps failing; the execution was failed without being sent",
+				"execution_uuid": executionUUID,
+			})
+			return
+		}
 		if err.Error() == "no execution_endpoint set for project" {
 			c.JSON(http.StatusBadRequest, gin.H{
 				"error": "No execution_endpoint set for this project",
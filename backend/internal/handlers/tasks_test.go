@@ -6,12 +6,18 @@ import (
 	"errors"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
 	"github.com/yourusername/cron-observer/backend/internal/deletequeue"
 	"github.com/yourusername/cron-observer/backend/internal/events"
+	"github.com/yourusername/cron-observer/backend/internal/middleware"
 	"github.com/yourusername/cron-observer/backend/internal/models"
+	"github.com/yourusername/cron-observer/backend/internal/validators"
 	"github.com/yourusername/cron-observer/backend/mocks"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
@@ -22,6 +28,8 @@ import (
 type mockScheduler struct {
 	unregisterTaskCalled bool
 	taskUUID             string
+	nextRun              time.Time
+	withinGroupWindow    bool
 }
 
 func (m *mockScheduler) RegisterTask(ctx context.Context, task *models.Task) error {
@@ -34,7 +42,11 @@ func (m *mockScheduler) UnregisterTask(taskUUID string) {
 }
 
 func (m *mockScheduler) IsWithinGroupWindow(ctx context.Context, taskGroup *models.TaskGroup) bool {
-	return false
+	return m.withinGroupWindow
+}
+
+func (m *mockScheduler) NextRun(taskUUID string) (time.Time, bool) {
+	return m.nextRun, !m.nextRun.IsZero()
 }
 
 func setupRouter() *gin.Engine {
@@ -64,7 +76,7 @@ func TestTaskHandler_DeleteTask_Success(t *testing.T) {
 	scheduler := &mockScheduler{}
 	deletePublisher := mocks.NewMockDeleteJobPublisher(ctrl)
 
-	handler := NewTaskHandler(repo, eventBus, scheduler, []string{}, deletePublisher)
+	handler := NewTaskHandler(repo, eventBus, scheduler, middleware.NewSuperAdmins([]string{"root@example.com"}), deletePublisher)
 
 	// Expectations
 	// Handler calls GetTaskByUUID once to fetch task
@@ -88,7 +100,7 @@ func TestTaskHandler_DeleteTask_Success(t *testing.T) {
 		Times(1)
 
 	// Setup router
-	router := setupRouter()
+	router := setupProjectRouter("root@example.com")
 	router.DELETE("/api/v1/projects/:project_id/tasks/:task_uuid", handler.DeleteTask)
 
 	// Create request
@@ -136,7 +148,7 @@ func TestTaskHandler_DeleteTask_TaskAlreadyDeleted(t *testing.T) {
 	scheduler := &mockScheduler{}
 	deletePublisher := mocks.NewMockDeleteJobPublisher(ctrl)
 
-	handler := NewTaskHandler(repo, eventBus, scheduler, []string{}, deletePublisher)
+	handler := NewTaskHandler(repo, eventBus, scheduler, middleware.NewSuperAdmins([]string{"root@example.com"}), deletePublisher)
 
 	// Expectations - task already deleted (idempotent)
 	repo.EXPECT().
@@ -145,7 +157,7 @@ func TestTaskHandler_DeleteTask_TaskAlreadyDeleted(t *testing.T) {
 		Times(1)
 
 	// Setup router
-	router := setupRouter()
+	router := setupProjectRouter("root@example.com")
 	router.DELETE("/api/v1/projects/:project_id/tasks/:task_uuid", handler.DeleteTask)
 
 	// Create request
@@ -172,6 +184,37 @@ func TestTaskHandler_DeleteTask_TaskAlreadyDeleted(t *testing.T) {
 	// Publisher should NOT be called for already deleted tasks (no expectation needed since it returns early)
 }
 
+func TestTaskHandler_DeleteTask_RejectsTaskOfAnotherProject(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	projectID := primitive.NewObjectID()
+	project := &models.Project{
+		ID:           projectID,
+		ProjectUsers: []models.ProjectUser{{Email: "editor@example.com", Role: models.ProjectUserRoleEditor}},
+	}
+	otherTask := &models.Task{UUID: "other-task-uuid", ProjectID: primitive.NewObjectID()}
+
+	repo := mocks.NewMockRepository(ctrl)
+	deletePublisher := mocks.NewMockDeleteJobPublisher(ctrl)
+	handler := NewTaskHandler(repo, nil, &mockScheduler{}, middleware.NewSuperAdmins([]string{}), deletePublisher)
+
+	repo.EXPECT().GetProjectByID(gomock.Any(), projectID).Return(project, nil)
+	repo.EXPECT().GetTaskByUUID(gomock.Any(), otherTask.UUID).Return(otherTask, nil)
+	deletePublisher.EXPECT().PublishDeleteTask(gomock.Any(), gomock.Any()).Times(0)
+
+	router := setupProjectRouter("editor@example.com")
+	router.DELETE("/api/v1/projects/:project_id/tasks/:task_uuid", handler.DeleteTask)
+
+	req, _ := http.NewRequest("DELETE", "/api/v1/projects/"+projectID.Hex()+"/tasks/"+otherTask.UUID, nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusNotFound {
+		t.Errorf("Expected status code %d, got %d: %s", http.StatusNotFound, w.Code, w.Body.String())
+	}
+}
+
 func TestTaskHandler_DeleteTask_MissingProjectID(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -182,10 +225,10 @@ func TestTaskHandler_DeleteTask_MissingProjectID(t *testing.T) {
 	scheduler := &mockScheduler{}
 	deletePublisher := mocks.NewMockDeleteJobPublisher(ctrl)
 
-	handler := NewTaskHandler(repo, eventBus, scheduler, []string{}, deletePublisher)
+	handler := NewTaskHandler(repo, eventBus, scheduler, middleware.NewSuperAdmins([]string{"root@example.com"}), deletePublisher)
 
 	// Setup router
-	router := setupRouter()
+	router := setupProjectRouter("root@example.com")
 	router.DELETE("/api/v1/projects/:project_id/tasks/:task_uuid", handler.DeleteTask)
 
 	// Create request with empty project_id
@@ -213,7 +256,7 @@ func TestTaskHandler_DeleteTask_MissingTaskUUID(t *testing.T) {
 	scheduler := &mockScheduler{}
 	deletePublisher := mocks.NewMockDeleteJobPublisher(ctrl)
 
-	handler := NewTaskHandler(repo, eventBus, scheduler, []string{}, deletePublisher)
+	handler := NewTaskHandler(repo, eventBus, scheduler, middleware.NewSuperAdmins([]string{}), deletePublisher)
 
 	// Test by calling the handler directly with empty task_uuid param
 	w := httptest.NewRecorder()
@@ -248,7 +291,7 @@ func TestTaskHandler_DeleteTask_GetTaskByUUIDError(t *testing.T) {
 	scheduler := &mockScheduler{}
 	deletePublisher := mocks.NewMockDeleteJobPublisher(ctrl)
 
-	handler := NewTaskHandler(repo, eventBus, scheduler, []string{}, deletePublisher)
+	handler := NewTaskHandler(repo, eventBus, scheduler, middleware.NewSuperAdmins([]string{"root@example.com"}), deletePublisher)
 
 	// Expectations
 	repo.EXPECT().
@@ -257,7 +300,7 @@ func TestTaskHandler_DeleteTask_GetTaskByUUIDError(t *testing.T) {
 		Times(1)
 
 	// Setup router
-	router := setupRouter()
+	router := setupProjectRouter("root@example.com")
 	router.DELETE("/api/v1/projects/:project_id/tasks/:task_uuid", handler.DeleteTask)
 
 	// Create request
@@ -305,7 +348,7 @@ func TestTaskHandler_DeleteTask_PublishFailure(t *testing.T) {
 	scheduler := &mockScheduler{}
 	deletePublisher := mocks.NewMockDeleteJobPublisher(ctrl)
 
-	handler := NewTaskHandler(repo, eventBus, scheduler, []string{}, deletePublisher)
+	handler := NewTaskHandler(repo, eventBus, scheduler, middleware.NewSuperAdmins([]string{"root@example.com"}), deletePublisher)
 
 	// Expectations
 	// Handler calls GetTaskByUUID once to fetch task
@@ -321,7 +364,7 @@ func TestTaskHandler_DeleteTask_PublishFailure(t *testing.T) {
 		Times(1)
 
 	// Setup router
-	router := setupRouter()
+	router := setupProjectRouter("root@example.com")
 	router.DELETE("/api/v1/projects/:project_id/tasks/:task_uuid", handler.DeleteTask)
 
 	// Create request
@@ -368,7 +411,7 @@ func TestTaskHandler_DeleteTask_NoPublisher(t *testing.T) {
 	scheduler := &mockScheduler{}
 
 	// Handler with nil publisher (RabbitMQ not configured)
-	handler := NewTaskHandler(repo, eventBus, scheduler, []string{}, nil)
+	handler := NewTaskHandler(repo, eventBus, scheduler, middleware.NewSuperAdmins([]string{"root@example.com"}), nil)
 
 	// Expectations
 	repo.EXPECT().
@@ -377,7 +420,7 @@ func TestTaskHandler_DeleteTask_NoPublisher(t *testing.T) {
 		Times(1)
 
 	// Setup router
-	router := setupRouter()
+	router := setupProjectRouter("root@example.com")
 	router.DELETE("/api/v1/projects/:project_id/tasks/:task_uuid", handler.DeleteTask)
 
 	// Create request
@@ -427,7 +470,7 @@ func TestTaskHandler_DeleteTask_NilScheduler(t *testing.T) {
 	deletePublisher := mocks.NewMockDeleteJobPublisher(ctrl)
 
 	// Create handler with nil scheduler (scheduler is optional)
-	handler := NewTaskHandler(repo, eventBus, nil, []string{}, deletePublisher)
+	handler := NewTaskHandler(repo, eventBus, nil, middleware.NewSuperAdmins([]string{"root@example.com"}), deletePublisher)
 
 	// Expectations
 	// Handler calls GetTaskByUUID once to fetch task
@@ -443,7 +486,7 @@ func TestTaskHandler_DeleteTask_NilScheduler(t *testing.T) {
 		Times(1)
 
 	// Setup router
-	router := setupRouter()
+	router := setupProjectRouter("root@example.com")
 	router.DELETE("/api/v1/projects/:project_id/tasks/:task_uuid", handler.DeleteTask)
 
 	// Create request
@@ -467,3 +510,796 @@ func TestTaskHandler_DeleteTask_NilScheduler(t *testing.T) {
 		t.Errorf("Expected status 'PENDING_DELETE', got '%v'", response["status"])
 	}
 }
+
+// setupValidatedRouter returns a project router with the custom binding validators registered
+func setupValidatedRouter(t *testing.T, email string) *gin.Engine {
+	if v, ok := binding.Validator.Engine().(*validator.Validate); ok {
+		if err := validators.RegisterCustomValidators(v); err != nil {
+			t.Fatalf("Failed to register validators: %v", err)
+		}
+	}
+	return setupProjectRouter(email)
+}
+
+func TestTaskHandler_PatchTask_PreservesOmittedFieldsAndPublishesUpdate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	projectID := primitive.NewObjectID()
+	timeout := 30
+	createdAt := time.Date(2025, 1, 15, 10, 0, 0, 0, time.UTC)
+	existing := &models.Task{
+		ID:             primitive.NewObjectID(),
+		UUID:           "task-uuid",
+		ProjectID:      projectID,
+		Name:           "nightly",
+		Description:    "nightly report",
+		ScheduleType:   models.ScheduleTypeRecurring,
+		Status:         models.TaskStatusActive,
+		ScheduleConfig: models.ScheduleConfig{CronExpression: "0 0 2 * * *", Timezone: "UTC"},
+		TimeoutSeconds: &timeout,
+		CreatedAt:      createdAt,
+	}
+
+	repo := mocks.NewMockRepository(ctrl)
+	eventBus := events.NewEventBus(100)
+	defer eventBus.Close()
+	updatedCh := eventBus.Subscribe(events.TaskUpdated)
+
+	handler := NewTaskHandler(repo, eventBus, &mockScheduler{}, middleware.NewSuperAdmins([]string{"root@example.com"}), nil)
+
+	repo.EXPECT().GetTaskByUUID(gomock.Any(), "task-uuid").Return(existing, nil)
+	repo.EXPECT().GetProjectSettings(gomock.Any(), projectID).Return(nil, nil)
+	repo.EXPECT().
+		UpdateTask(gomock.Any(), "task-uuid", gomock.Any()).
+		DoAndReturn(func(ctx context.Context, taskUUID string, task *models.Task) error {
+			if task.ScheduleConfig.CronExpression != "0 30 3 * * *" {
+				t.Errorf("Expected new cron expression, got %q", task.ScheduleConfig.CronExpression)
+			}
+			if task.Name != "nightly" || task.Description != "nightly report" || task.TimeoutSeconds == nil || *task.TimeoutSeconds != 30 {
+				t.Errorf("Expected omitted fields to be preserved, got %+v", task)
+			}
+			if task.UUID != "task-uuid" || !task.CreatedAt.Equal(createdAt) {
+				t.Errorf("Expected UUID and created_at to be preserved, got %s %v", task.UUID, task.CreatedAt)
+			}
+			return nil
+		})
+
+	router := setupValidatedRouter(t, "root@example.com")
+	router.PATCH("/api/v1/projects/:project_id/tasks/:task_uuid", handler.PatchTask)
+
+	body := `{"schedule_config":{"cron_expression":"0 30 3 * * *","timezone":"UTC"}}`
+	req, _ := http.NewRequest("PATCH", "/api/v1/projects/"+projectID.Hex()+"/tasks/task-uuid", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status code %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+
+	select {
+	case event := <-updatedCh:
+		payload, ok := event.Payload.(events.TaskPayload)
+		if !ok || payload.Task.ScheduleConfig.CronExpression != "0 30 3 * * *" {
+			t.Errorf("Unexpected TaskUpdated payload: %+v", event.Payload)
+		}
+	case <-time.After(time.Second):
+		t.Error("Expected TaskUpdated event to be published")
+	}
+}
+
+func TestTaskHandler_UpdateTask_RejectsTaskFromAnotherProject(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	projectID := primitive.NewObjectID()
+	existing := &models.Task{UUID: "task-uuid", ProjectID: primitive.NewObjectID(), Status: models.TaskStatusActive}
+
+	repo := mocks.NewMockRepository(ctrl)
+	eventBus := events.NewEventBus(100)
+	defer eventBus.Close()
+
+	handler := NewTaskHandler(repo, eventBus, &mockScheduler{}, middleware.NewSuperAdmins([]string{"root@example.com"}), nil)
+
+	repo.EXPECT().GetTaskByUUID(gomock.Any(), "task-uuid").Return(existing, nil)
+
+	router := setupValidatedRouter(t, "root@example.com")
+	router.PUT("/api/v1/projects/:project_id/tasks/:task_uuid", handler.UpdateTask)
+
+	body := `{"name":"moved","schedule_type":"RECURRING","schedule_config":{"cron_expression":"0 0 * * * *","timezone":"UTC"}}`
+	req, _ := http.NewRequest("PUT", "/api/v1/projects/"+projectID.Hex()+"/tasks/task-uuid", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusNotFound {
+		t.Errorf("Expected status code %d, got %d: %s", http.StatusNotFound, w.Code, w.Body.String())
+	}
+}
+
+func TestTaskHandler_GetTask_IncludesSchedulerStateAndLastExecution(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	projectID := primitive.NewObjectID()
+	task := &models.Task{UUID: "task-uuid", ProjectID: projectID, Name: "nightly", Status: models.TaskStatusActive}
+	nextRun := time.Date(2025, 1, 16, 2, 0, 0, 0, time.UTC)
+	lastExecution := &models.Execution{
+		UUID:      "exec-uuid",
+		TaskUUID:  "task-uuid",
+		Status:    models.ExecutionStatusFailed,
+		StartedAt: time.Date(2025, 1, 15, 2, 0, 0, 0, time.UTC),
+		Error:     "Connection timeout",
+	}
+
+	repo := mocks.NewMockRepository(ctrl)
+	handler := NewTaskHandler(repo, nil, &mockScheduler{nextRun: nextRun}, middleware.NewSuperAdmins([]string{"root@example.com"}), nil)
+
+	repo.EXPECT().GetTaskByUUID(gomock.Any(), "task-uuid").Return(task, nil)
+	repo.EXPECT().GetLatestExecutionByTaskUUID(gomock.Any(), "task-uuid").Return(lastExecution, nil)
+
+	router := setupProjectRouter("root@example.com")
+	router.GET("/api/v1/projects/:project_id/tasks/:task_uuid", handler.GetTask)
+
+	req, _ := http.NewRequest("GET", "/api/v1/projects/"+projectID.Hex()+"/tasks/task-uuid", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status code %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+
+	var response models.TaskDetailResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+
+	if response.UUID != "task-uuid" || response.Name != "nightly" {
+		t.Errorf("Expected task fields in response, got %+v", response.Task)
+	}
+	if !response.IsRegistered || response.NextRunAt == nil || !response.NextRunAt.Equal(nextRun) {
+		t.Errorf("Expected registered task with next run %v, got registered=%v next_run_at=%v", nextRun, response.IsRegistered, response.NextRunAt)
+	}
+	if response.LastExecution == nil || response.LastExecution.UUID != "exec-uuid" || response.LastExecution.Status != models.ExecutionStatusFailed {
+		t.Errorf("Unexpected last execution: %+v", response.LastExecution)
+	}
+}
+
+func TestTaskHandler_GetTasksByProject_PaginatesWithFilters(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	projectID := primitive.NewObjectID()
+	groupID := primitive.NewObjectID()
+	tasks := []*models.Task{{UUID: "task-1", ProjectID: projectID}, {UUID: "task-2", ProjectID: projectID}}
+
+	repo := mocks.NewMockRepository(ctrl)
+	handler := NewTaskHandler(repo, nil, nil, middleware.NewSuperAdmins([]string{"root@example.com"}), nil)
+
+	expectedFilter := models.TaskListFilter{
+		Status:      models.TaskStatusActive,
+		TaskGroupID: &groupID,
+		Tags:        []string{"team:payments", "critical"},
+		Search:      "backup",
+		SortBy:      models.TaskSortByLastFailure,
+		SortDesc:    true,
+	}
+	repo.EXPECT().ListTasksByProjectID(gomock.Any(), projectID, expectedFilter, 2, 2).Return(tasks, int64(5), nil)
+
+	startedAt := time.Date(2025, 1, 15, 2, 0, 0, 0, time.UTC)
+	endedAt := startedAt.Add(5 * time.Second)
+	lastExecutions := map[string]*models.Execution{
+		"task-1": {UUID: "exec-1", TaskUUID: "task-1", Status: models.ExecutionStatusSuccess, StartedAt: startedAt, EndedAt: &endedAt},
+	}
+	repo.EXPECT().GetLatestExecutionsByTaskUUIDs(gomock.Any(), []string{"task-1", "task-2"}).Return(lastExecutions, nil)
+
+	router := setupProjectRouter("root@example.com")
+	router.GET("/api/v1/projects/:project_id/tasks", handler.GetTasksByProject)
+
+	query := "?page=2&page_size=2&sort=last_failure_at&order=desc&status=ACTIVE&search=+backup+&tag=Team:Payments&tag=critical&task_group_id=" + groupID.Hex()
+	req, _ := http.NewRequest("GET", "/api/v1/projects/"+projectID.Hex()+"/tasks"+query, nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status code %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+
+	var response models.PaginatedTasksResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+	if len(response.Data) != 2 || response.Page != 2 || response.TotalCount != 5 || response.TotalPages != 3 {
+		t.Fatalf("Unexpected pagination: page=%d total=%d pages=%d data=%d", response.Page, response.TotalCount, response.TotalPages, len(response.Data))
+	}
+	last := response.Data[0].LastExecution
+	if last == nil || last.UUID != "exec-1" || last.DurationMs == nil || *last.DurationMs != 5000 {
+		t.Errorf("Expected last execution exec-1 lasting 5000ms on task-1, got %+v", last)
+	}
+	if response.Data[1].LastExecution != nil {
+		t.Errorf("Expected no last execution on task-2, got %+v", response.Data[1].LastExecution)
+	}
+}
+
+func TestTaskHandler_GetTasksByProject_RejectsInvalidSort(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockRepository(ctrl)
+	handler := NewTaskHandler(repo, nil, nil, middleware.NewSuperAdmins([]string{"root@example.com"}), nil)
+
+	router := setupProjectRouter("root@example.com")
+	router.GET("/api/v1/projects/:project_id/tasks", handler.GetTasksByProject)
+
+	req, _ := http.NewRequest("GET", "/api/v1/projects/"+primitive.NewObjectID().Hex()+"/tasks?sort=priority", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status code %d, got %d", http.StatusBadRequest, w.Code)
+	}
+}
+
+func TestTaskHandler_GetTasksByProject_FiltersAndSortsBySeverity(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	projectID := primitive.NewObjectID()
+	repo := mocks.NewMockRepository(ctrl)
+	handler := NewTaskHandler(repo, nil, nil, middleware.NewSuperAdmins([]string{"root@example.com"}), nil)
+
+	expectedFilter := models.TaskListFilter{Severity: models.TaskSeverityCritical, SortBy: models.TaskSortBySeverity, SortDesc: true}
+	repo.EXPECT().ListTasksByProjectID(gomock.Any(), projectID, expectedFilter, 1, 0).Return([]*models.Task{}, int64(0), nil)
+	repo.EXPECT().GetLatestExecutionsByTaskUUIDs(gomock.Any(), []string{}).Return(map[string]*models.Execution{}, nil)
+
+	router := setupProjectRouter("root@example.com")
+	router.GET("/api/v1/projects/:project_id/tasks", handler.GetTasksByProject)
+
+	for query, want := range map[string]int{
+		"?severity=CRITICAL&sort=severity&order=desc": http.StatusOK,
+		"?severity=URGENT": http.StatusBadRequest,
+	} {
+		req, _ := http.NewRequest("GET", "/api/v1/projects/"+projectID.Hex()+"/tasks"+query, nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		if w.Code != want {
+			t.Errorf("%s: expected status code %d, got %d: %s", query, want, w.Code, w.Body.String())
+		}
+	}
+}
+
+func TestTaskHandler_CloneTask_CopiesIntoOtherGroupDisabled(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	projectID := primitive.NewObjectID()
+	targetGroupID := primitive.NewObjectID()
+	source := &models.Task{
+		ID:             primitive.NewObjectID(),
+		UUID:           "source-uuid",
+		ProjectID:      projectID,
+		Name:           "nightly",
+		ScheduleType:   models.ScheduleTypeRecurring,
+		Status:         models.TaskStatusActive,
+		ScheduleConfig: models.ScheduleConfig{CronExpression: "0 0 2 * * *", Timezone: "UTC"},
+		TriggerConfig:  models.TriggerConfig{Type: models.TriggerTypeHTTP},
+		Tags:           []string{"critical"},
+	}
+
+	repo := mocks.NewMockRepository(ctrl)
+	eventBus := events.NewEventBus(100)
+	defer eventBus.Close()
+
+	handler := NewTaskHandler(repo, eventBus, &mockScheduler{}, middleware.NewSuperAdmins([]string{"root@example.com"}), nil)
+
+	repo.EXPECT().GetTaskByUUID(gomock.Any(), "source-uuid").Return(source, nil)
+	repo.EXPECT().GetTaskGroupByID(gomock.Any(), targetGroupID).Return(&models.TaskGroup{ID: targetGroupID, ProjectID: projectID}, nil)
+
+	var created *models.Task
+	repo.EXPECT().
+		CreateTask(gomock.Any(), projectID.Hex(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, projectID string, task *models.Task) error {
+			created = task
+			return nil
+		})
+
+	router := setupValidatedRouter(t, "root@example.com")
+	router.POST("/api/v1/projects/:project_id/tasks/:task_uuid/clone", handler.CloneTask)
+
+	body := `{"task_group_id":"` + targetGroupID.Hex() + `"}`
+	req, _ := http.NewRequest("POST", "/api/v1/projects/"+projectID.Hex()+"/tasks/source-uuid/clone", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusCreated {
+		t.Fatalf("Expected status code %d, got %d: %s", http.StatusCreated, w.Code, w.Body.String())
+	}
+	if created == nil {
+		t.Fatal("Expected the clone to be created")
+	}
+	if created.UUID == source.UUID || created.ID == source.ID {
+		t.Errorf("Expected a new UUID and ID, got %s %s", created.UUID, created.ID.Hex())
+	}
+	if created.Name != "nightly (copy)" || created.Status != models.TaskStatusDisabled {
+		t.Errorf("Expected disabled copy named 'nightly (copy)', got %q %s", created.Name, created.Status)
+	}
+	if created.TaskGroupID == nil || *created.TaskGroupID != targetGroupID {
+		t.Errorf("Expected clone in group %s, got %v", targetGroupID.Hex(), created.TaskGroupID)
+	}
+	if created.ScheduleConfig.CronExpression != "0 0 2 * * *" || created.TriggerConfig.Type != models.TriggerTypeHTTP || len(created.Tags) != 1 {
+		t.Errorf("Expected schedule, trigger config and tags to be carried over, got %+v", created)
+	}
+}
+
+func TestTaskHandler_MoveTask_IntoGroupWithinWindow(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	projectID := primitive.NewObjectID()
+	targetGroup := &models.TaskGroup{ID: primitive.NewObjectID(), UUID: "group-uuid", ProjectID: projectID, Status: models.TaskGroupStatusActive}
+	task := &models.Task{
+		UUID:      "task-uuid",
+		ProjectID: projectID,
+		Status:    models.TaskStatusActive,
+		State:     models.TaskStateNotRunning,
+	}
+
+	repo := mocks.NewMockRepository(ctrl)
+	eventBus := events.NewEventBus(100)
+	defer eventBus.Close()
+	updatedCh := eventBus.Subscribe(events.TaskUpdated)
+
+	handler := NewTaskHandler(repo, eventBus, &mockScheduler{withinGroupWindow: true}, middleware.NewSuperAdmins([]string{"root@example.com"}), nil)
+
+	repo.EXPECT().GetTaskByUUID(gomock.Any(), "task-uuid").Return(task, nil)
+	repo.EXPECT().GetTaskGroupByUUID(gomock.Any(), "group-uuid").Return(targetGroup, nil)
+
+	var saved *models.Task
+	repo.EXPECT().
+		UpdateTask(gomock.Any(), "task-uuid", gomock.Any()).
+		DoAndReturn(func(ctx context.Context, taskUUID string, updated *models.Task) error {
+			saved = updated
+			return nil
+		})
+
+	router := setupValidatedRouter(t, "root@example.com")
+	router.POST("/api/v1/projects/:project_id/tasks/:task_uuid/move", handler.MoveTask)
+
+	req, _ := http.NewRequest("POST", "/api/v1/projects/"+projectID.Hex()+"/tasks/task-uuid/move", strings.NewReader(`{"task_group_uuid":"group-uuid"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status code %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	if saved.TaskGroupID == nil || *saved.TaskGroupID != targetGroup.ID {
+		t.Errorf("Expected task in group %s, got %v", targetGroup.ID.Hex(), saved.TaskGroupID)
+	}
+	if saved.State != models.TaskStateRunning {
+		t.Errorf("Expected state RUNNING inside the group window, got %s", saved.State)
+	}
+
+	select {
+	case event := <-updatedCh:
+		if event.Payload.(events.TaskPayload).Task.UUID != "task-uuid" {
+			t.Errorf("Expected TaskUpdated for task-uuid, got %+v", event.Payload)
+		}
+	case <-time.After(time.Second):
+		t.Error("Expected TaskUpdated event to be published")
+	}
+}
+
+func TestTaskHandler_MoveTask_RejectsGroupFromAnotherProject(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	projectID := primitive.NewObjectID()
+	task := &models.Task{UUID: "task-uuid", ProjectID: projectID, Status: models.TaskStatusActive}
+	otherGroup := &models.TaskGroup{ID: primitive.NewObjectID(), UUID: "group-uuid", ProjectID: primitive.NewObjectID()}
+
+	repo := mocks.NewMockRepository(ctrl)
+	eventBus := events.NewEventBus(100)
+	defer eventBus.Close()
+
+	handler := NewTaskHandler(repo, eventBus, &mockScheduler{}, middleware.NewSuperAdmins([]string{"root@example.com"}), nil)
+
+	repo.EXPECT().GetTaskByUUID(gomock.Any(), "task-uuid").Return(task, nil)
+	repo.EXPECT().GetTaskGroupByUUID(gomock.Any(), "group-uuid").Return(otherGroup, nil)
+	repo.EXPECT().UpdateTask(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+	router := setupValidatedRouter(t, "root@example.com")
+	router.POST("/api/v1/projects/:project_id/tasks/:task_uuid/move", handler.MoveTask)
+
+	req, _ := http.NewRequest("POST", "/api/v1/projects/"+projectID.Hex()+"/tasks/task-uuid/move", strings.NewReader(`{"task_group_uuid":"group-uuid"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusNotFound {
+		t.Errorf("Expected status code %d, got %d: %s", http.StatusNotFound, w.Code, w.Body.String())
+	}
+}
+
+func TestTaskHandler_CreateTask_RejectsMetadataNotMatchingProjectSchema(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	projectID := primitive.NewObjectID()
+	settings := &models.ProjectSettings{
+		ProjectID:      projectID,
+		MetadataSchema: []byte(`{"type":"object","required":["owner"],"properties":{"owner":{"type":"string"}}}`),
+	}
+
+	repo := mocks.NewMockRepository(ctrl)
+	eventBus := events.NewEventBus(100)
+	defer eventBus.Close()
+
+	handler := NewTaskHandler(repo, eventBus, &mockScheduler{}, middleware.NewSuperAdmins([]string{"root@example.com"}), nil)
+
+	repo.EXPECT().GetProjectSettings(gomock.Any(), projectID).Return(settings, nil)
+	repo.EXPECT().CreateTask(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+	router := setupValidatedRouter(t, "root@example.com")
+	router.POST("/api/v1/projects/:project_id/tasks", handler.CreateTask)
+
+	body := `{"project_id":"` + projectID.Hex() + `","name":"nightly","schedule_type":"RECURRING","schedule_config":{"cron_expression":"0 0 2 * * *"},"metadata":{"owner":42}}`
+	req, _ := http.NewRequest("POST", "/api/v1/projects/"+projectID.Hex()+"/tasks", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("Expected status code %d, got %d: %s", http.StatusBadRequest, w.Code, w.Body.String())
+	}
+	if !strings.Contains(w.Body.String(), "metadata/owner: expected string, got integer") {
+		t.Errorf("Expected the violation in the response, got %s", w.Body.String())
+	}
+}
+
+func TestTaskHandler_CreateTaskFromTemplate_SubstitutesBuiltinParameters(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	projectID := primitive.NewObjectID()
+	repo := mocks.NewMockRepository(ctrl)
+	eventBus := events.NewEventBus(100)
+	defer eventBus.Close()
+	createdCh := eventBus.Subscribe(events.TaskCreated)
+
+	handler := NewTaskHandler(repo, eventBus, &mockScheduler{}, middleware.NewSuperAdmins([]string{"root@example.com"}), nil)
+
+	repo.EXPECT().GetProjectSettings(gomock.Any(), projectID).Return(nil, nil)
+	var created *models.Task
+	repo.EXPECT().CreateTask(gomock.Any(), projectID.Hex(), gomock.Any()).DoAndReturn(func(ctx context.Context, projectID string, task *models.Task) error {
+		created = task
+		return nil
+	})
+
+	router := setupValidatedRouter(t, "root@example.com")
+	router.POST("/api/v1/projects/:project_id/task-templates/:template_uuid/tasks", handler.CreateTaskFromTemplate)
+
+	body := `{"parameters":{"service":"billing","url":"https://billing.example.com/health"}}`
+	req, _ := http.NewRequest("POST", "/api/v1/projects/"+projectID.Hex()+"/task-templates/builtin-hourly-http-health-check/tasks", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusCreated {
+		t.Fatalf("Expected status code %d, got %d: %s", http.StatusCreated, w.Code, w.Body.String())
+	}
+	if created.Name != "Health check billing" || created.ScheduleConfig.CronExpression != "0 0 * * * *" {
+		t.Errorf("Unexpected task rendered from template: %+v", created)
+	}
+	if created.Metadata["url"] != "https://billing.example.com/health" || created.Metadata["expected_status"] != "200" {
+		t.Errorf("Expected parameters and defaults substituted into metadata, got %+v", created.Metadata)
+	}
+	if created.Status != models.TaskStatusActive || len(created.Tags) != 1 || created.Tags[0] != "health-check" {
+		t.Errorf("Unexpected status or tags: %+v", created)
+	}
+
+	select {
+	case <-createdCh:
+	case <-time.After(time.Second):
+		t.Fatal("Expected TaskCreated event")
+	}
+}
+
+func TestTaskHandler_CreateTaskFromTemplate_RejectsMissingRequiredParameter(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	projectID := primitive.NewObjectID()
+	repo := mocks.NewMockRepository(ctrl)
+	handler := NewTaskHandler(repo, nil, &mockScheduler{}, middleware.NewSuperAdmins([]string{"root@example.com"}), nil)
+
+	template := &models.TaskTemplate{
+		UUID:       "template-uuid",
+		ProjectID:  &projectID,
+		Parameters: []models.TemplateParameter{{Name: "queue", Required: true}},
+		Task: models.TaskTemplateSpec{
+			Name:           "Drain {{queue}}",
+			ScheduleType:   models.ScheduleTypeRecurring,
+			ScheduleConfig: models.ScheduleConfig{CronExpression: "0 */5 * * * *"},
+		},
+	}
+	repo.EXPECT().GetTaskTemplateByUUID(gomock.Any(), projectID, "template-uuid").Return(template, nil)
+
+	router := setupValidatedRouter(t, "root@example.com")
+	router.POST("/api/v1/projects/:project_id/task-templates/:template_uuid/tasks", handler.CreateTaskFromTemplate)
+
+	req, _ := http.NewRequest("POST", "/api/v1/projects/"+projectID.Hex()+"/task-templates/template-uuid/tasks", strings.NewReader(`{"parameters":{"queu":"emails"}}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("Expected status code %d, got %d: %s", http.StatusBadRequest, w.Code, w.Body.String())
+	}
+	if !strings.Contains(w.Body.String(), "queue") {
+		t.Errorf("Expected error to name the missing parameter, got %s", w.Body.String())
+	}
+}
+
+func TestTaskHandler_MuteTask_ForDuration(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	projectID := primitive.NewObjectID()
+	task := &models.Task{UUID: "task-uuid", ProjectID: projectID, Status: models.TaskStatusActive}
+
+	repo := mocks.NewMockRepository(ctrl)
+	eventBus := events.NewEventBus(100)
+	defer eventBus.Close()
+
+	handler := NewTaskHandler(repo, eventBus, &mockScheduler{}, middleware.NewSuperAdmins([]string{"root@example.com"}), nil)
+
+	repo.EXPECT().GetTaskByUUID(gomock.Any(), "task-uuid").Return(task, nil)
+
+	var mutedUntil *time.Time
+	repo.EXPECT().
+		SetTaskMutedUntil(gomock.Any(), "task-uuid", gomock.Any()).
+		DoAndReturn(func(ctx context.Context, taskUUID string, until *time.Time) error {
+			mutedUntil = until
+			return nil
+		})
+
+	router := setupValidatedRouter(t, "root@example.com")
+	router.POST("/api/v1/projects/:project_id/tasks/:task_uuid/mute", handler.MuteTask)
+
+	before := time.Now()
+	req, _ := http.NewRequest("POST", "/api/v1/projects/"+projectID.Hex()+"/tasks/task-uuid/mute", strings.NewReader(`{"duration_minutes":60}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status code %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	if mutedUntil == nil || mutedUntil.Before(before.Add(59*time.Minute)) || mutedUntil.After(time.Now().Add(61*time.Minute)) {
+		t.Fatalf("Expected task muted for about an hour, got %v", mutedUntil)
+	}
+
+	var response models.Task
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if !response.Muted || response.MutedUntil == nil {
+		t.Errorf("Expected muted task in response, got muted=%v muted_until=%v", response.Muted, response.MutedUntil)
+	}
+}
+
+func TestTaskHandler_MuteTask_RejectsPastTime(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	projectID := primitive.NewObjectID()
+
+	repo := mocks.NewMockRepository(ctrl)
+	eventBus := events.NewEventBus(100)
+	defer eventBus.Close()
+
+	handler := NewTaskHandler(repo, eventBus, &mockScheduler{}, middleware.NewSuperAdmins([]string{"root@example.com"}), nil)
+
+	repo.EXPECT().SetTaskMutedUntil(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+	router := setupValidatedRouter(t, "root@example.com")
+	router.POST("/api/v1/projects/:project_id/tasks/:task_uuid/mute", handler.MuteTask)
+
+	body := `{"until":"` + time.Now().Add(-time.Hour).Format(time.RFC3339) + `"}`
+	req, _ := http.NewRequest("POST", "/api/v1/projects/"+projectID.Hex()+"/tasks/task-uuid/mute", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status code %d, got %d: %s", http.StatusBadRequest, w.Code, w.Body.String())
+	}
+}
+
+func TestTaskHandler_GetTasksByProject_IndicatesMutedTasks(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	projectID := primitive.NewObjectID()
+	future := time.Now().Add(time.Hour)
+	past := time.Now().Add(-time.Hour)
+	tasks := []*models.Task{
+		{UUID: "muted", ProjectID: projectID, MutedUntil: &future},
+		{UUID: "expired", ProjectID: projectID, MutedUntil: &past},
+		{UUID: "unmuted", ProjectID: projectID},
+	}
+
+	repo := mocks.NewMockRepository(ctrl)
+	eventBus := events.NewEventBus(100)
+	defer eventBus.Close()
+
+	handler := NewTaskHandler(repo, eventBus, &mockScheduler{}, middleware.NewSuperAdmins([]string{"root@example.com"}), nil)
+
+	repo.EXPECT().ListTasksByProjectID(gomock.Any(), projectID, gomock.Any(), 1, 0).Return(tasks, int64(len(tasks)), nil)
+	repo.EXPECT().GetLatestExecutionsByTaskUUIDs(gomock.Any(), gomock.Any()).Return(map[string]*models.Execution{}, nil)
+
+	router := setupProjectRouter("root@example.com")
+	router.GET("/api/v1/projects/:project_id/tasks", handler.GetTasksByProject)
+
+	req, _ := http.NewRequest("GET", "/api/v1/projects/"+projectID.Hex()+"/tasks", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status code %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+
+	var response []models.Task
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	want := map[string]bool{"muted": true, "expired": false, "unmuted": false}
+	for _, task := range response {
+		if task.Muted != want[task.UUID] {
+			t.Errorf("Expected muted=%v for task %s, got %v", want[task.UUID], task.UUID, task.Muted)
+		}
+	}
+}
+
+func TestTaskHandler_CreateTask_TranslatesNaturalLanguageSchedule(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	projectID := primitive.NewObjectID()
+	repo := mocks.NewMockRepository(ctrl)
+	eventBus := events.NewEventBus(100)
+	defer eventBus.Close()
+
+	handler := NewTaskHandler(repo, eventBus, &mockScheduler{}, middleware.NewSuperAdmins([]string{"root@example.com"}), nil)
+
+	repo.EXPECT().GetProjectSettings(gomock.Any(), projectID).Return(nil, nil)
+	var created *models.Task
+	repo.EXPECT().CreateTask(gomock.Any(), projectID.Hex(), gomock.Any()).DoAndReturn(func(ctx context.Context, projectID string, task *models.Task) error {
+		created = task
+		return nil
+	})
+
+	router := setupValidatedRouter(t, "root@example.com")
+	router.POST("/api/v1/projects/:project_id/tasks", handler.CreateTask)
+
+	body := `{"project_id":"` + projectID.Hex() + `","name":"standup reminder","schedule_type":"RECURRING","schedule_config":{"schedule":"Weekdays at 9am"}}`
+	req, _ := http.NewRequest("POST", "/api/v1/projects/"+projectID.Hex()+"/tasks", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusCreated {
+		t.Fatalf("Expected status code %d, got %d: %s", http.StatusCreated, w.Code, w.Body.String())
+	}
+	if created.ScheduleConfig.CronExpression != "0 0 9 * * 1-5" || created.ScheduleConfig.Schedule != "Weekdays at 9am" {
+		t.Errorf("Expected translated cron stored with the phrase, got %+v", created.ScheduleConfig)
+	}
+}
+
+func TestTaskHandler_CreateTask_RejectsUnrecognizedSchedule(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	projectID := primitive.NewObjectID()
+	repo := mocks.NewMockRepository(ctrl)
+	eventBus := events.NewEventBus(100)
+	defer eventBus.Close()
+
+	handler := NewTaskHandler(repo, eventBus, &mockScheduler{}, middleware.NewSuperAdmins([]string{"root@example.com"}), nil)
+
+	repo.EXPECT().GetProjectSettings(gomock.Any(), projectID).Return(nil, nil)
+	repo.EXPECT().CreateTask(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+	router := setupValidatedRouter(t, "root@example.com")
+	router.POST("/api/v1/projects/:project_id/tasks", handler.CreateTask)
+
+	body := `{"project_id":"` + projectID.Hex() + `","name":"standup reminder","schedule_type":"RECURRING","schedule_config":{"schedule":"whenever it suits"}}`
+	req, _ := http.NewRequest("POST", "/api/v1/projects/"+projectID.Hex()+"/tasks", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("Expected status code %d, got %d: %s", http.StatusBadRequest, w.Code, w.Body.String())
+	}
+	if !strings.Contains(w.Body.String(), "unrecognized schedule") {
+		t.Errorf("Expected the parser error in the response, got %s", w.Body.String())
+	}
+}
+
+func TestTaskHandler_CreateTask_AcceptsCronDescriptor(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	projectID := primitive.NewObjectID()
+	repo := mocks.NewMockRepository(ctrl)
+	eventBus := events.NewEventBus(100)
+	defer eventBus.Close()
+
+	handler := NewTaskHandler(repo, eventBus, &mockScheduler{}, middleware.NewSuperAdmins([]string{"root@example.com"}), nil)
+
+	repo.EXPECT().GetProjectSettings(gomock.Any(), projectID).Return(nil, nil)
+	var created *models.Task
+	repo.EXPECT().CreateTask(gomock.Any(), projectID.Hex(), gomock.Any()).DoAndReturn(func(ctx context.Context, projectID string, task *models.Task) error {
+		created = task
+		return nil
+	})
+
+	router := setupValidatedRouter(t, "root@example.com")
+	router.POST("/api/v1/projects/:project_id/tasks", handler.CreateTask)
+
+	body := `{"project_id":"` + projectID.Hex() + `","name":"poll","schedule_type":"RECURRING","schedule_config":{"cron_expression":"@every 10m"}}`
+	req, _ := http.NewRequest("POST", "/api/v1/projects/"+projectID.Hex()+"/tasks", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusCreated {
+		t.Fatalf("Expected status code %d, got %d: %s", http.StatusCreated, w.Code, w.Body.String())
+	}
+	if created.ScheduleConfig.CronExpression != "@every 10m" {
+		t.Errorf("Expected descriptor stored unchanged, got %q", created.ScheduleConfig.CronExpression)
+	}
+
+	// Unknown descriptors are still rejected at create time
+	body = `{"project_id":"` + projectID.Hex() + `","name":"poll","schedule_type":"RECURRING","schedule_config":{"cron_expression":"@fortnightly"}}`
+	req, _ = http.NewRequest("POST", "/api/v1/projects/"+projectID.Hex()+"/tasks", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status code %d for unknown descriptor, got %d: %s", http.StatusBadRequest, w.Code, w.Body.String())
+	}
+}
+
+func TestTaskHandler_CreateTask_RejectsCronTheSchedulerCannotParse(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	projectID := primitive.NewObjectID()
+	repo := mocks.NewMockRepository(ctrl)
+	handler := NewTaskHandler(repo, nil, &mockScheduler{}, middleware.NewSuperAdmins([]string{"root@example.com"}), nil)
+
+	repo.EXPECT().CreateTask(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+	router := setupValidatedRouter(t, "root@example.com")
+	router.POST("/api/v1/projects/:project_id/tasks", handler.CreateTask)
+
+	// Well-formed fields with out-of-range values used to pass validation and fail at registration
+	for _, expression := range []string{"0 0 25 * * *", "*/0 * * * *", "0 0 1 * * 9"} {
+		body := `{"project_id":"` + projectID.Hex() + `","name":"nightly","schedule_type":"RECURRING","schedule_config":{"cron_expression":"` + expression + `"}}`
+		req, _ := http.NewRequest("POST", "/api/v1/projects/"+projectID.Hex()+"/tasks", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("Expected status code %d for %q, got %d: %s", http.StatusBadRequest, expression, w.Code, w.Body.String())
+		}
+	}
+}
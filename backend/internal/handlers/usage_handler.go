@@ -0,0 +1,217 @@
+package handlers
+
+import (
+	"encoding/csv"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yourusername/cron-observer/backend/internal/middleware"
+	"github.com/yourusername/cron-observer/backend/internal/models"
+	"github.com/yourusername/cron-observer/backend/internal/repositories"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// defaultUsageDays is the range of usage queries without from, ending at to
+const defaultUsageDays = 30
+
+// UsageHandler serves the metered usage of projects, per project and as an export of all projects for chargeback
+type UsageHandler struct {
+	repo        repositories.Repository
+	superAdmins *middleware.SuperAdmins
+}
+
+func NewUsageHandler(repo repositories.Repository, superAdmins *middleware.SuperAdmins) *UsageHandler {
+	return &UsageHandler{
+		repo:        repo,
+		superAdmins: superAdmins,
+	}
+}
+
+// GetProjectUsage returns the metered usage of a project
+// @Summary      Get project usage
+// @Description  Get the project's metered usage per UTC day: executions the scheduler started, alerts sent and log bytes stored. Usage is written periodically, so the last minutes may be missing.
+// @Tags         usage
+// @Produce      json
+// @Param        project_id path string true "Project ID"
+// @Param        from query string false "First day (YYYY-MM-DD, default: 29 days before to)"
+// @Param        to query string false "Last day (YYYY-MM-DD, default: today, UTC)"
+// @Success      200  {object}  models.UsageResponse
+// @Failure      400  {object}  models.ErrorResponse
+// @Failure      403  {object}  models.ErrorResponse
+// @Failure      500  {object}  models.ErrorResponse
+// @Router       /projects/{project_id}/usage [get]
+func (h *UsageHandler) GetProjectUsage(c *gin.Context) {
+	projectID, err := primitive.ObjectIDFromHex(c.Param("project_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid project_id format in path",
+		})
+		return
+	}
+
+	filter, ok := usageFilter(c)
+	if !ok {
+		return
+	}
+	filter.ProjectID = &projectID
+
+	if !RequireProjectPermission(c, h.repo, projectID, h.superAdmins, PermissionViewProject) {
+		return
+	}
+
+	records, err := h.repo.GetUsage(c.Request.Context(), filter)
+	if err != nil {
+		log.Printf("Failed to get usage of project %s: %v", projectID.Hex(), err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to get usage",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, usageResponse(filter, records))
+}
+
+// ExportUsage exports the metered usage of all projects
+// @Summary      Export usage
+// @Description  Export the metered usage per project and UTC day as JSON or CSV, for chargeback or billing. Super admins only.
+// @Tags         usage
+// @Produce      json,text/csv
+// @Param        from query string false "First day (YYYY-MM-DD, default: 29 days before to)"
+// @Param        to query string false "Last day (YYYY-MM-DD, default: today, UTC)"
+// @Param        project_id query string false "Only this project"
+// @Param        format query string false "Export format (default: json)" Enums(json, csv)
+// @Success      200  {object}  models.UsageResponse
+// @Failure      400  {object}  models.ErrorResponse
+// @Failure      401  {object}  models.ErrorResponse
+// @Failure      403  {object}  models.ErrorResponse
+// @Failure      500  {object}  models.ErrorResponse
+// @Router       /admin/usage/export [get]
+func (h *UsageHandler) ExportUsage(c *gin.Context) {
+	if !requireSuperAdmin(c, h.superAdmins) {
+		return
+	}
+
+	format := c.DefaultQuery("format", "json")
+	if format != "json" && format != "csv" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid format. Use json or csv",
+		})
+		return
+	}
+
+	filter, ok := usageFilter(c)
+	if !ok {
+		return
+	}
+	if value := c.Query("project_id"); value != "" {
+		projectID, err := primitive.ObjectIDFromHex(value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "Invalid project_id format",
+			})
+			return
+		}
+		filter.ProjectID = &projectID
+	}
+
+	ctx := c.Request.Context()
+	records, err := h.repo.GetUsage(ctx, filter)
+	if err != nil {
+		log.Printf("Failed to export usage: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to get usage",
+		})
+		return
+	}
+
+	filename := fmt.Sprintf("usage-%s-%s.%s", filter.From, filter.To, format)
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, filename))
+	if format == "json" {
+		c.JSON(http.StatusOK, usageResponse(filter, records))
+		return
+	}
+
+	// Names make the export readable; usage of deleted projects is exported without one
+	names := make(map[primitive.ObjectID]string)
+	if projects, err := h.repo.GetAllProjects(ctx); err != nil {
+		log.Printf("Failed to get project names for usage export: %v", err)
+	} else {
+		for _, project := range projects {
+			names[project.ID] = project.Name
+		}
+	}
+
+	c.Header("Content-Type", "text/csv; charset=utf-8")
+	c.Status(http.StatusOK)
+	writer := csv.NewWriter(c.Writer)
+	writer.Write([]string{"date", "project_id", "project_name", "executions", "alerts_sent", "log_bytes"})
+	for _, record := range records {
+		writer.Write([]string{
+			record.Date,
+			record.ProjectID.Hex(),
+			names[record.ProjectID],
+			strconv.FormatInt(record.Executions, 10),
+			strconv.FormatInt(record.AlertsSent, 10),
+			strconv.FormatInt(record.LogBytes, 10),
+		})
+	}
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		log.Printf("Failed to write usage export: %v", err)
+	}
+}
+
+// usageFilter reads the from and to query parameters, defaulting to the last 30 UTC days
+func usageFilter(c *gin.Context) (models.UsageFilter, bool) {
+	to := time.Now().UTC()
+	if value := c.Query("to"); value != "" {
+		parsed, err := time.Parse("2006-01-02", value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "Invalid to date. Use YYYY-MM-DD",
+			})
+			return models.UsageFilter{}, false
+		}
+		to = parsed
+	}
+
+	from := to.AddDate(0, 0, -(defaultUsageDays - 1))
+	if value := c.Query("from"); value != "" {
+		parsed, err := time.Parse("2006-01-02", value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "Invalid from date. Use YYYY-MM-DD",
+			})
+			return models.UsageFilter{}, false
+		}
+		from = parsed
+	}
+
+	if from.After(to) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "from must not be after to",
+		})
+		return models.UsageFilter{}, false
+	}
+	return models.UsageFilter{From: from.Format("2006-01-02"), To: to.Format("2006-01-02")}, true
+}
+
+// usageResponse sums the records of the filter's range
+func usageResponse(filter models.UsageFilter, records []*models.UsageRecord) models.UsageResponse {
+	response := models.UsageResponse{
+		From:    filter.From,
+		To:      filter.To,
+		Records: records,
+	}
+	if response.Records == nil {
+		response.Records = []*models.UsageRecord{}
+	}
+	for _, record := range records {
+		response.Total.Add(record.UsageCounters)
+	}
+	return response
+}
@@ -0,0 +1,86 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/yourusername/cron-observer/backend/internal/middleware"
+	"github.com/yourusername/cron-observer/backend/internal/models"
+	"github.com/yourusername/cron-observer/backend/mocks"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.uber.org/mock/gomock"
+)
+
+func TestUsageHandler_ExportUsage_CSV(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	project := &models.Project{ID: primitive.NewObjectID(), Name: "billing"}
+	records := []*models.UsageRecord{
+		{ProjectID: project.ID, Date: "2025-01-15", UsageCounters: models.UsageCounters{Executions: 1440, AlertsSent: 2, LogBytes: 4096}},
+	}
+
+	repo := mocks.NewMockRepository(ctrl)
+	handler := NewUsageHandler(repo, middleware.NewSuperAdmins([]string{"root@example.com"}))
+
+	repo.EXPECT().GetUsage(gomock.Any(), models.UsageFilter{From: "2025-01-01", To: "2025-01-31"}).Return(records, nil)
+	repo.EXPECT().GetAllProjects(gomock.Any()).Return([]*models.Project{project}, nil)
+
+	router := setupProjectRouter("root@example.com")
+	router.GET("/api/v1/admin/usage/export", handler.ExportUsage)
+
+	req, _ := http.NewRequest(http.MethodGet, "/api/v1/admin/usage/export?from=2025-01-01&to=2025-01-31&format=csv", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	want := "date,project_id,project_name,executions,alerts_sent,log_bytes\n" +
+		"2025-01-15," + project.ID.Hex() + ",billing,1440,2,4096\n"
+	if w.Body.String() != want {
+		t.Errorf("unexpected export:\n%s", w.Body.String())
+	}
+}
+
+func TestUsageHandler_ExportUsage_RequiresSuperAdmin(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockRepository(ctrl)
+	handler := NewUsageHandler(repo, middleware.NewSuperAdmins([]string{"root@example.com"}))
+	repo.EXPECT().GetUsage(gomock.Any(), gomock.Any()).Times(0)
+
+	router := setupProjectRouter("dev@example.com")
+	router.GET("/api/v1/admin/usage/export", handler.ExportUsage)
+
+	req, _ := http.NewRequest(http.MethodGet, "/api/v1/admin/usage/export", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusForbidden {
+		t.Errorf("Expected status 403, got %d", w.Code)
+	}
+}
+
+func TestUsageHandler_GetProjectUsage_RejectsInvertedRange(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockRepository(ctrl)
+	handler := NewUsageHandler(repo, middleware.NewSuperAdmins([]string{}))
+
+	router := setupProjectRouter("dev@example.com")
+	router.GET("/api/v1/projects/:project_id/usage", handler.GetProjectUsage)
+
+	url := "/api/v1/projects/" + primitive.NewObjectID().Hex() + "/usage?from=2025-02-01&to=2025-01-01"
+	req, _ := http.NewRequest(http.MethodGet, url, nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusBadRequest || !strings.Contains(w.Body.String(), "from") {
+		t.Errorf("Expected status 400, got %d: %s", w.Code, w.Body.String())
+	}
+}
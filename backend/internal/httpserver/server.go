@@ -0,0 +1,164 @@
+// Package httpserver runs the HTTP API over plain HTTP or, when TLS is configured, over HTTPS with a certificate from
+// files or from Let's Encrypt, so the API can be exposed without a load balancer in front of it.
+package httpserver
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/yourusername/cron-observer/backend/internal/config"
+	"golang.org/x/crypto/acme/autocert"
+)
+
+// Server is the HTTP API server, with the plain HTTP redirect server when one is configured
+type Server struct {
+	api      *http.Server
+	redirect *http.Server // nil unless TLS is enabled and a TLS HTTP port is configured
+	tls      bool
+}
+
+// New creates the server of handler as configured in cfg. Certificate files are loaded now, so a bad certificate
+// fails the startup instead of the first request.
+func New(handler http.Handler, cfg config.ServerConfig) (*Server, error) {
+	s := &Server{
+		api: &http.Server{
+			Addr:         ":" + cfg.Port,
+			Handler:      handler,
+			ReadTimeout:  cfg.ReadTimeout,
+			WriteTimeout: cfg.WriteTimeout,
+		},
+	}
+
+	domains := autocertDomains(cfg.TLSAutocertDomains)
+	if cfg.TLSCertFile != "" && len(domains) > 0 {
+		return nil, errors.New("configure either SERVER_TLS_CERT_FILE or SERVER_TLS_AUTOCERT_DOMAINS, not both")
+	}
+
+	// Challenges and redirects of the plain HTTP server; only autocert answers challenges
+	var challengeHandler func(fallback http.Handler) http.Handler
+	switch {
+	case cfg.TLSCertFile != "":
+		certificate, err := tls.LoadX509KeyPair(cfg.TLSCertFile, cfg.TLSKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("load TLS certificate: %w", err)
+		}
+		s.api.TLSConfig = &tls.Config{
+			MinVersion:   tls.VersionTLS12,
+			Certificates: []tls.Certificate{certificate},
+		}
+	case len(domains) > 0:
+		manager := &autocert.Manager{
+			Prompt:     autocert.AcceptTOS,
+			HostPolicy: autocert.HostWhitelist(domains...),
+			Cache:      autocert.DirCache(cfg.TLSAutocertCacheDir),
+			Email:      cfg.TLSAutocertEmail,
+		}
+		// Includes the ALPN protocol of TLS-ALPN-01 challenges, so certificates can be obtained without port 80
+		s.api.TLSConfig = manager.TLSConfig()
+		s.api.TLSConfig.MinVersion = tls.VersionTLS12
+		challengeHandler = manager.HTTPHandler
+	default:
+		return s, nil
+	}
+	s.tls = true
+
+	if cfg.TLSHTTPPort != "" {
+		var redirect http.Handler = http.HandlerFunc(redirectToHTTPS(cfg.Port))
+		if challengeHandler != nil {
+			redirect = challengeHandler(redirect)
+		}
+		s.redirect = &http.Server{
+			Addr:         ":" + cfg.TLSHTTPPort,
+			Handler:      redirect,
+			ReadTimeout:  cfg.ReadTimeout,
+			WriteTimeout: cfg.WriteTimeout,
+		}
+	}
+	return s, nil
+}
+
+// TLS reports whether the API is served over HTTPS
+func (s *Server) TLS() bool {
+	return s.tls
+}
+
+// ListenAndServe serves until Shutdown is called, and then returns nil
+func (s *Server) ListenAndServe() error {
+	listener, err := net.Listen("tcp", s.api.Addr)
+	if err != nil {
+		return err
+	}
+
+	if s.redirect != nil {
+		redirectListener, err := net.Listen("tcp", s.redirect.Addr)
+		if err != nil {
+			listener.Close()
+			return err
+		}
+		go func() {
+			log.Printf("[HTTP] Redirecting plain HTTP on %s to HTTPS", s.redirect.Addr)
+			if err := s.redirect.Serve(redirectListener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				log.Printf("[HTTP] Redirect server failed: %v", err)
+			}
+		}()
+	}
+	return s.Serve(listener)
+}
+
+// Serve serves the API on listener until Shutdown is called, and then returns nil
+func (s *Server) Serve(listener net.Listener) error {
+	var err error
+	if s.tls {
+		log.Printf("[HTTP] Serving HTTPS on %s", listener.Addr())
+		// The certificates are in TLSConfig, so no files are passed
+		err = s.api.ServeTLS(listener, "", "")
+	} else {
+		log.Printf("[HTTP] Serving HTTP on %s", listener.Addr())
+		err = s.api.Serve(listener)
+	}
+	if errors.Is(err, http.ErrServerClosed) {
+		return nil
+	}
+	return err
+}
+
+// Shutdown stops accepting connections and waits for the requests in progress, until ctx is done
+func (s *Server) Shutdown(ctx context.Context) error {
+	if s.redirect != nil {
+		if err := s.redirect.Shutdown(ctx); err != nil {
+			log.Printf("[HTTP] Failed to shut down the redirect server: %v", err)
+		}
+	}
+	return s.api.Shutdown(ctx)
+}
+
+// redirectToHTTPS redirects to the same URL over HTTPS on the API port
+func redirectToHTTPS(httpsPort string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		host := r.Host
+		if h, _, err := net.SplitHostPort(host); err == nil {
+			host = h
+		}
+		if httpsPort != "443" {
+			host = net.JoinHostPort(host, httpsPort)
+		}
+		http.Redirect(w, r, "https://"+host+r.URL.RequestURI(), http.StatusMovedPermanently)
+	}
+}
+
+// autocertDomains parses the comma-separated SERVER_TLS_AUTOCERT_DOMAINS
+func autocertDomains(value string) []string {
+	var domains []string
+	for _, domain := range strings.Split(value, ",") {
+		if domain = strings.TrimSpace(domain); domain != "" {
+			domains = append(domains, domain)
+		}
+	}
+	return domains
+}
@@ -0,0 +1,148 @@
+package httpserver
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/yourusername/cron-observer/backend/internal/config"
+)
+
+// writeCertificate writes a self-signed certificate for localhost and its key, returning their paths
+func writeCertificate(t *testing.T) (string, string) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "localhost"},
+		DNSNames:     []string{"localhost"},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dir := t.TempDir()
+	certFile := filepath.Join(dir, "cert.pem")
+	keyFile := filepath.Join(dir, "key.pem")
+	if err := os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return certFile, keyFile
+}
+
+func okHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+}
+
+func TestServer_ServesHTTPSWithCertificateFiles(t *testing.T) {
+	certFile, keyFile := writeCertificate(t)
+	server, err := New(okHandler(), config.ServerConfig{Port: "0", TLSCertFile: certFile, TLSKeyFile: keyFile})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	if !server.TLS() {
+		t.Fatal("TLS() = false, want true")
+	}
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	served := make(chan error, 1)
+	go func() { served <- server.Serve(listener) }()
+
+	client := &http.Client{Transport: &http.Transport{TLSClientConfig: &tls.Config{InsecureSkipVerify: true}}}
+	resp, err := client.Get("https://" + listener.Addr().String() + "/health")
+	if err != nil {
+		t.Fatalf("HTTPS request failed: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || resp.TLS == nil {
+		t.Errorf("status = %d, TLS = %v, want 200 over TLS", resp.StatusCode, resp.TLS != nil)
+	}
+
+	if err := server.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown() error = %v", err)
+	}
+	if err := <-served; err != nil {
+		t.Errorf("Serve() error = %v, want nil after Shutdown", err)
+	}
+}
+
+func TestNew_PlainHTTPWithoutTLSConfig(t *testing.T) {
+	server, err := New(okHandler(), config.ServerConfig{Port: "8080"})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	if server.TLS() || server.redirect != nil {
+		t.Error("TLS enabled without TLS configuration")
+	}
+}
+
+func TestNew_RejectsInvalidTLSConfig(t *testing.T) {
+	certFile, keyFile := writeCertificate(t)
+
+	tests := map[string]config.ServerConfig{
+		"files and autocert": {Port: "443", TLSCertFile: certFile, TLSKeyFile: keyFile, TLSAutocertDomains: "api.example.com"},
+		"missing file":       {Port: "443", TLSCertFile: filepath.Join(t.TempDir(), "missing.pem"), TLSKeyFile: keyFile},
+		"key is not the key": {Port: "443", TLSCertFile: certFile, TLSKeyFile: certFile},
+	}
+	for name, cfg := range tests {
+		if _, err := New(okHandler(), cfg); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestNew_AutocertRedirectsPlainHTTP(t *testing.T) {
+	server, err := New(okHandler(), config.ServerConfig{
+		Port:                "8443",
+		TLSAutocertDomains:  "api.example.com, www.example.com",
+		TLSAutocertCacheDir: t.TempDir(),
+		TLSHTTPPort:         "80",
+	})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	if !server.TLS() || server.api.TLSConfig.GetCertificate == nil {
+		t.Fatal("autocert not configured")
+	}
+
+	w := httptest.NewRecorder()
+	server.redirect.Handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "http://api.example.com/api/v1/health?x=1", nil))
+	if w.Code != http.StatusMovedPermanently && w.Code != http.StatusFound {
+		t.Fatalf("status = %d, want a redirect", w.Code)
+	}
+	if location := w.Header().Get("Location"); location != "https://api.example.com:8443/api/v1/health?x=1" {
+		t.Errorf("Location = %s", location)
+	}
+}
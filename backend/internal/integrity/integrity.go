@@ -0,0 +1,258 @@
+// Package integrity finds records that point at a deleted task group, project or task, left behind by deletes that
+// were interrupted or raced with other writes, and repairs them according to a Policy.
+package integrity
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"time"
+
+	"github.com/yourusername/cron-observer/backend/internal/events"
+	"github.com/yourusername/cron-observer/backend/internal/models"
+	"github.com/yourusername/cron-observer/backend/internal/repositories"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// Policy decides what Repair does with the orphans it is given
+type Policy string
+
+const (
+	// PolicyFlag only logs and reports the orphans
+	PolicyFlag Policy = "flag"
+	// PolicyReassign detaches tasks of deleted task groups, as deleting a group with the DETACH policy does, and
+	// reports the other orphans
+	PolicyReassign Policy = "reassign"
+	// PolicyClean detaches tasks of deleted task groups and deletes tasks of deleted projects, executions of
+	// deleted tasks and stats of deleted projects
+	PolicyClean Policy = "clean"
+)
+
+// ParsePolicy validates a policy name from configuration or a command line flag
+func ParsePolicy(value string) (Policy, error) {
+	switch policy := Policy(value); policy {
+	case PolicyFlag, PolicyReassign, PolicyClean:
+		return policy, nil
+	}
+	return "", fmt.Errorf("invalid integrity policy %q: use flag, reassign or clean", value)
+}
+
+// EventPublisher is the minimal event bus interface needed to tell the scheduler about repaired tasks
+type EventPublisher interface {
+	Publish(event events.Event) error
+}
+
+// Orphans are the records whose references dangle
+type Orphans struct {
+	TasksOfDeletedGroups   []*models.TaskReference
+	TasksOfDeletedProjects []*models.TaskReference
+	ExecutionTaskUUIDs     []string             // Deleted tasks that still have executions
+	StatsProjectIDs        []primitive.ObjectID // Deleted projects that still have failure stats
+}
+
+// Result counts what Repair changed
+type Result struct {
+	DetachedTasks     int
+	DeletedTasks      int
+	DeletedExecutions int64
+	DeletedStats      int // Projects whose stats were deleted
+}
+
+// Find looks for orphans. Referencing records are loaded before the records they point at, so a record created
+// while Find runs is never taken for an orphan.
+func Find(ctx context.Context, repo repositories.Repository) (*Orphans, error) {
+	statsProjectIDs, err := repo.GetStatsProjectIDs(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list projects with stats: %w", err)
+	}
+	executionTaskUUIDs, err := repo.GetExecutionTaskUUIDs(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list tasks with executions: %w", err)
+	}
+	tasks, err := repo.GetTaskReferences(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list tasks: %w", err)
+	}
+	projects, err := repo.GetAllProjects(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list projects: %w", err)
+	}
+
+	projectExists := make(map[primitive.ObjectID]bool, len(projects))
+	for _, project := range projects {
+		projectExists[project.ID] = true
+	}
+	taskExists := make(map[string]bool, len(tasks))
+	for _, task := range tasks {
+		taskExists[task.UUID] = true
+	}
+
+	orphans := &Orphans{}
+	groupExists := make(map[primitive.ObjectID]bool)
+	for _, task := range tasks {
+		if !projectExists[task.ProjectID] {
+			orphans.TasksOfDeletedProjects = append(orphans.TasksOfDeletedProjects, task)
+			continue
+		}
+		if task.TaskGroupID == nil {
+			continue
+		}
+		exists, checked := groupExists[*task.TaskGroupID]
+		if !checked {
+			_, err := repo.GetTaskGroupByID(ctx, *task.TaskGroupID)
+			if err != nil && !errors.Is(err, mongo.ErrNoDocuments) {
+				return nil, fmt.Errorf("get task group %s: %w", task.TaskGroupID.Hex(), err)
+			}
+			exists = err == nil
+			groupExists[*task.TaskGroupID] = exists
+		}
+		if !exists {
+			orphans.TasksOfDeletedGroups = append(orphans.TasksOfDeletedGroups, task)
+		}
+	}
+	for _, taskUUID := range executionTaskUUIDs {
+		if !taskExists[taskUUID] {
+			orphans.ExecutionTaskUUIDs = append(orphans.ExecutionTaskUUIDs, taskUUID)
+		}
+	}
+	for _, projectID := range statsProjectIDs {
+		if !projectExists[projectID] {
+			orphans.StatsProjectIDs = append(orphans.StatsProjectIDs, projectID)
+		}
+	}
+	return orphans, nil
+}
+
+// Count returns the number of orphans
+func (o *Orphans) Count() int {
+	return len(o.TasksOfDeletedGroups) + len(o.TasksOfDeletedProjects) + len(o.ExecutionTaskUUIDs) + len(o.StatsProjectIDs)
+}
+
+// Empty reports whether no orphans were found
+func (o *Orphans) Empty() bool {
+	return o.Count() == 0
+}
+
+// WriteSummary writes a human-readable list of the orphans
+func (o *Orphans) WriteSummary(w io.Writer) {
+	if o.Empty() {
+		fmt.Fprintln(w, "No dangling references found.")
+		return
+	}
+
+	if len(o.TasksOfDeletedGroups) > 0 {
+		fmt.Fprintf(w, "Tasks of deleted task groups (%d):\n", len(o.TasksOfDeletedGroups))
+		for _, task := range o.TasksOfDeletedGroups {
+			fmt.Fprintf(w, "  - %s (task group %s)\n", task.UUID, task.TaskGroupID.Hex())
+		}
+	}
+	if len(o.TasksOfDeletedProjects) > 0 {
+		fmt.Fprintf(w, "Tasks of deleted projects (%d):\n", len(o.TasksOfDeletedProjects))
+		for _, task := range o.TasksOfDeletedProjects {
+			fmt.Fprintf(w, "  - %s (project %s)\n", task.UUID, task.ProjectID.Hex())
+		}
+	}
+	if len(o.ExecutionTaskUUIDs) > 0 {
+		fmt.Fprintf(w, "Deleted tasks with executions left (%d):\n", len(o.ExecutionTaskUUIDs))
+		for _, taskUUID := range o.ExecutionTaskUUIDs {
+			fmt.Fprintf(w, "  - %s\n", taskUUID)
+		}
+	}
+	if len(o.StatsProjectIDs) > 0 {
+		fmt.Fprintf(w, "Deleted projects with failure stats left (%d):\n", len(o.StatsProjectIDs))
+		for _, projectID := range o.StatsProjectIDs {
+			fmt.Fprintf(w, "  - %s\n", projectID.Hex())
+		}
+	}
+}
+
+// Repair applies the policy to the orphans. It stops at the first failure; the rest is picked up by the next run.
+// publisher may be nil.
+func Repair(ctx context.Context, repo repositories.Repository, publisher EventPublisher, orphans *Orphans, policy Policy) (Result, error) {
+	var result Result
+	if policy == PolicyFlag {
+		return result, nil
+	}
+
+	for _, reference := range orphans.TasksOfDeletedGroups {
+		detached, err := detachTask(ctx, repo, publisher, reference)
+		if err != nil {
+			return result, fmt.Errorf("detach task %s: %w", reference.UUID, err)
+		}
+		if detached {
+			result.DetachedTasks++
+		}
+	}
+	if policy != PolicyClean {
+		return result, nil
+	}
+
+	for _, reference := range orphans.TasksOfDeletedProjects {
+		deleted, err := repo.DeleteExecutionsByTaskUUIDs(ctx, []string{reference.UUID})
+		result.DeletedExecutions += deleted
+		if err != nil {
+			return result, fmt.Errorf("delete executions of task %s: %w", reference.UUID, err)
+		}
+		if err := repo.DeleteTask(ctx, reference.UUID); err != nil {
+			return result, fmt.Errorf("delete task %s: %w", reference.UUID, err)
+		}
+		result.DeletedTasks++
+		if publisher != nil {
+			publish(publisher, events.TaskDeletedTopic.Event(events.TaskDeletedPayload{TaskUUID: reference.UUID}))
+		}
+	}
+
+	if len(orphans.ExecutionTaskUUIDs) > 0 {
+		deleted, err := repo.DeleteExecutionsByTaskUUIDs(ctx, orphans.ExecutionTaskUUIDs)
+		result.DeletedExecutions += deleted
+		if err != nil {
+			return result, fmt.Errorf("delete executions of deleted tasks: %w", err)
+		}
+	}
+
+	for _, projectID := range orphans.StatsProjectIDs {
+		if err := repo.DeleteStatsByProjectID(ctx, projectID); err != nil {
+			return result, fmt.Errorf("delete stats of project %s: %w", projectID.Hex(), err)
+		}
+		result.DeletedStats++
+	}
+	return result, nil
+}
+
+// detachTask removes the group reference of a task and publishes TaskUpdated so the scheduler re-registers it as a
+// standalone task. Returns false when the task was deleted or regrouped in the meantime.
+func detachTask(ctx context.Context, repo repositories.Repository, publisher EventPublisher, reference *models.TaskReference) (bool, error) {
+	task, err := repo.GetTaskByUUID(ctx, reference.UUID)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	if task.TaskGroupID == nil || *task.TaskGroupID != *reference.TaskGroupID {
+		return false, nil
+	}
+
+	task.TaskGroupID = nil
+	task.State = models.TaskStateNotRunning
+	task.UpdatedAt = time.Now()
+	if err := repo.UpdateTask(ctx, task.UUID, task); err != nil {
+		return false, err
+	}
+	log.Printf("[integrity] Detached task %s from deleted task group %s", task.UUID, reference.TaskGroupID.Hex())
+
+	if publisher != nil {
+		publish(publisher, events.TaskUpdatedTopic.Event(events.TaskPayload{Task: task}))
+	}
+	return true, nil
+}
+
+// publish publishes an event about a repair already applied; failures are logged since the repair stands
+func publish(publisher EventPublisher, event events.Event) {
+	if err := publisher.Publish(event); err != nil {
+		log.Printf("[integrity] Failed to publish %s event: %v", event.Type, err)
+	}
+}
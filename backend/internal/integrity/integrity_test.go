@@ -0,0 +1,173 @@
+package integrity
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/yourusername/cron-observer/backend/internal/events"
+	"github.com/yourusername/cron-observer/backend/internal/models"
+	"github.com/yourusername/cron-observer/backend/internal/repositories"
+	"github.com/yourusername/cron-observer/backend/internal/seed"
+)
+
+type recordingPublisher struct {
+	events []events.Event
+}
+
+func (p *recordingPublisher) Publish(event events.Event) error {
+	p.events = append(p.events, event)
+	return nil
+}
+
+// seedOrphans seeds two projects and removes records underneath others, the way interrupted deletes leave them:
+// a task group of the first project, a task of the first project and the whole second project
+func seedOrphans(t *testing.T, repo repositories.Repository) (groupTasks []*models.Task, deletedTask *models.Task) {
+	t.Helper()
+	ctx := context.Background()
+	now := time.Now().UTC().Truncate(time.Hour)
+
+	var projects []*models.Project
+	for _, name := range []string{"Kept", "Deleted"} {
+		result, err := seed.Demo(ctx, repo, seed.Options{ProjectName: name, Days: 3, Now: now})
+		if err != nil {
+			t.Fatalf("seed %s: %v", name, err)
+		}
+		projects = append(projects, result.Project)
+	}
+
+	groups, err := repo.GetTaskGroupsByProjectID(ctx, projects[0].ID)
+	if err != nil || len(groups) == 0 {
+		t.Fatalf("GetTaskGroupsByProjectID: %v (%d groups)", err, len(groups))
+	}
+	groupTasks, err = repo.GetTasksByGroupID(ctx, groups[0].ID)
+	if err != nil || len(groupTasks) == 0 {
+		t.Fatalf("GetTasksByGroupID: %v (%d tasks)", err, len(groupTasks))
+	}
+	if err := repo.DeleteTaskGroup(ctx, groups[0].UUID); err != nil {
+		t.Fatalf("DeleteTaskGroup: %v", err)
+	}
+
+	tasks, err := repo.GetTasksByProjectID(ctx, projects[0].ID)
+	if err != nil {
+		t.Fatalf("GetTasksByProjectID: %v", err)
+	}
+	for _, task := range tasks {
+		if task.TaskGroupID == nil || *task.TaskGroupID != groups[0].ID {
+			deletedTask = task
+			break
+		}
+	}
+	if err := repo.DeleteTask(ctx, deletedTask.UUID); err != nil {
+		t.Fatalf("DeleteTask: %v", err)
+	}
+
+	if err := repo.DeleteProject(ctx, projects[1].ID); err != nil {
+		t.Fatalf("DeleteProject: %v", err)
+	}
+	return groupTasks, deletedTask
+}
+
+func TestFind_ReportsEveryKindOfOrphan(t *testing.T) {
+	ctx := context.Background()
+	repo := repositories.NewMemoryRepository()
+	groupTasks, deletedTask := seedOrphans(t, repo)
+
+	orphans, err := Find(ctx, repo)
+	if err != nil {
+		t.Fatalf("Find: %v", err)
+	}
+	if len(orphans.TasksOfDeletedGroups) != len(groupTasks) {
+		t.Errorf("%d tasks of deleted groups, want %d", len(orphans.TasksOfDeletedGroups), len(groupTasks))
+	}
+	if len(orphans.TasksOfDeletedProjects) == 0 {
+		t.Error("tasks of the deleted project were not found")
+	}
+	found := false
+	for _, taskUUID := range orphans.ExecutionTaskUUIDs {
+		found = found || taskUUID == deletedTask.UUID
+	}
+	if !found {
+		t.Errorf("executions of deleted task %s were not found in %v", deletedTask.UUID, orphans.ExecutionTaskUUIDs)
+	}
+	if len(orphans.StatsProjectIDs) != 1 {
+		t.Errorf("%d deleted projects with stats, want 1", len(orphans.StatsProjectIDs))
+	}
+
+	var summary bytes.Buffer
+	orphans.WriteSummary(&summary)
+	if !strings.Contains(summary.String(), deletedTask.UUID) {
+		t.Errorf("summary does not list the deleted task:\n%s", summary.String())
+	}
+
+	// Flagging changes nothing
+	if result, err := Repair(ctx, repo, nil, orphans, PolicyFlag); err != nil || result != (Result{}) {
+		t.Fatalf("Repair(flag) = %+v, %v", result, err)
+	}
+	if again, _ := Find(ctx, repo); again.Count() != orphans.Count() {
+		t.Errorf("flag policy changed the orphans from %d to %d", orphans.Count(), again.Count())
+	}
+}
+
+func TestRepair_ReassignDetachesTasksOnly(t *testing.T) {
+	ctx := context.Background()
+	repo := repositories.NewMemoryRepository()
+	groupTasks, _ := seedOrphans(t, repo)
+	orphans, err := Find(ctx, repo)
+	if err != nil {
+		t.Fatalf("Find: %v", err)
+	}
+
+	publisher := &recordingPublisher{}
+	result, err := Repair(ctx, repo, publisher, orphans, PolicyReassign)
+	if err != nil {
+		t.Fatalf("Repair: %v", err)
+	}
+	if result.DetachedTasks != len(groupTasks) || result.DeletedTasks != 0 || result.DeletedExecutions != 0 || result.DeletedStats != 0 {
+		t.Errorf("unexpected result %+v", result)
+	}
+	if len(publisher.events) != len(groupTasks) {
+		t.Errorf("published %d events, want one TaskUpdated per detached task", len(publisher.events))
+	}
+	task, err := repo.GetTaskByUUID(ctx, groupTasks[0].UUID)
+	if err != nil || task.TaskGroupID != nil {
+		t.Errorf("task still in the deleted group: %+v, %v", task, err)
+	}
+
+	remaining, _ := Find(ctx, repo)
+	if len(remaining.TasksOfDeletedGroups) != 0 || len(remaining.TasksOfDeletedProjects) != len(orphans.TasksOfDeletedProjects) {
+		t.Errorf("unexpected orphans after reassign %+v", remaining)
+	}
+}
+
+func TestRepair_CleanRemovesEveryOrphan(t *testing.T) {
+	ctx := context.Background()
+	repo := repositories.NewMemoryRepository()
+	seedOrphans(t, repo)
+	orphans, err := Find(ctx, repo)
+	if err != nil {
+		t.Fatalf("Find: %v", err)
+	}
+
+	result, err := Repair(ctx, repo, nil, orphans, PolicyClean)
+	if err != nil {
+		t.Fatalf("Repair: %v", err)
+	}
+	if result.DeletedTasks != len(orphans.TasksOfDeletedProjects) || result.DeletedExecutions == 0 || result.DeletedStats != 1 {
+		t.Errorf("unexpected result %+v", result)
+	}
+	if remaining, _ := Find(ctx, repo); !remaining.Empty() {
+		t.Errorf("%d orphans left after clean", remaining.Count())
+	}
+}
+
+func TestParsePolicy(t *testing.T) {
+	if policy, err := ParsePolicy("clean"); err != nil || policy != PolicyClean {
+		t.Errorf("ParsePolicy(clean) = %q, %v", policy, err)
+	}
+	if _, err := ParsePolicy("delete"); err == nil {
+		t.Error("ParsePolicy accepted an unknown policy")
+	}
+}
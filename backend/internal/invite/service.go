@@ -0,0 +1,252 @@
+package invite
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"html"
+	"log"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	appconfig "github.com/yourusername/cron-observer/backend/internal/config"
+	"github.com/yourusername/cron-observer/backend/internal/gmail"
+	"github.com/yourusername/cron-observer/backend/internal/models"
+	"github.com/yourusername/cron-observer/backend/internal/repositories"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+const (
+	defaultInvitationTTL = 7 * 24 * time.Hour
+	defaultAcceptURL     = "http://localhost:3000/invites/accept"
+)
+
+var (
+	ErrInvalidToken      = errors.New("invalid invitation token")
+	ErrNotFound          = errors.New("invitation not found")
+	ErrNotPending        = errors.New("invitation is no longer pending")
+	ErrExpired           = errors.New("invitation has expired")
+	ErrEmailMismatch     = errors.New("invitation was sent to a different email address")
+	ErrAlreadyMember     = errors.New("user is already a member of this project")
+	ErrMissingSigningKey = errors.New("invite signing secret is not configured")
+	ErrProjectMismatch   = errors.New("invitation does not belong to this project")
+)
+
+// Service manages project invitations: creating signed invites, emailing them and accepting them
+type Service struct {
+	repo        repositories.Repository
+	gmailSender gmail.Sender
+	config      appconfig.InviteConfig
+}
+
+// NewService creates a new invite service. gmailSender may be nil, in which case invites are
+// created but no email is sent.
+func NewService(repo repositories.Repository, gmailSender gmail.Sender, config appconfig.InviteConfig) *Service {
+	if config.TTL <= 0 {
+		config.TTL = defaultInvitationTTL
+	}
+	if config.AcceptURL == "" {
+		config.AcceptURL = defaultAcceptURL
+	}
+
+	return &Service{
+		repo:        repo,
+		gmailSender: gmailSender,
+		config:      config,
+	}
+}
+
+// Invite creates a pending invitation for the email and sends it via the notification layer.
+// Email delivery failures are logged but do not fail the invite.
+func (s *Service) Invite(ctx context.Context, project *models.Project, email string, role models.ProjectUserRole, invitedBy string) (*models.Invitation, error) {
+	if s.config.SigningSecret == "" {
+		return nil, ErrMissingSigningKey
+	}
+
+	email = strings.ToLower(strings.TrimSpace(email))
+	for _, projectUser := range project.ProjectUsers {
+		if strings.ToLower(strings.TrimSpace(projectUser.Email)) == email {
+			return nil, ErrAlreadyMember
+		}
+	}
+
+	now := time.Now()
+	invitation := &models.Invitation{
+		UUID:      uuid.New().String(),
+		ProjectID: project.ID,
+		Email:     email,
+		Role:      role,
+		Status:    models.InvitationStatusPending,
+		InvitedBy: invitedBy,
+		ExpiresAt: now.Add(s.config.TTL),
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	if err := s.repo.CreateInvitation(ctx, invitation); err != nil {
+		return nil, fmt.Errorf("failed to create invitation: %w", err)
+	}
+
+	s.sendInviteEmail(project, invitation)
+
+	return invitation, nil
+}
+
+// Accept verifies the token and converts the invitation into a ProjectUser for the given user. The invitation is
+// accepted with a single conditional update, in the same transaction as the membership, so a token can only be used
+// once even when it is submitted twice at the same time.
+func (s *Service) Accept(ctx context.Context, token string, userEmail string) (*models.Invitation, error) {
+	if s.config.SigningSecret == "" {
+		return nil, ErrMissingSigningKey
+	}
+
+	invitationUUID, ok := VerifyToken(s.config.SigningSecret, token)
+	if !ok {
+		return nil, ErrInvalidToken
+	}
+
+	invitation, err := s.repo.GetInvitationByUUID(ctx, invitationUUID)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get invitation: %w", err)
+	}
+
+	if invitation.Status != models.InvitationStatusPending {
+		return nil, ErrNotPending
+	}
+
+	if time.Now().After(invitation.ExpiresAt) {
+		if err := s.repo.UpdateInvitationStatus(ctx, invitation.UUID, models.InvitationStatusExpired); err != nil {
+			log.Printf("[INVITE] Failed to mark invitation %s as expired: %v", invitation.UUID, err)
+		}
+		return nil, ErrExpired
+	}
+
+	email := strings.ToLower(strings.TrimSpace(userEmail))
+	if email != invitation.Email {
+		return nil, ErrEmailMismatch
+	}
+
+	now := time.Now()
+	err = s.repo.RunInTransaction(ctx, func(ctx context.Context) error {
+		accepted, err := s.repo.AcceptInvitation(ctx, invitation.UUID, email, now)
+		if err != nil {
+			return fmt.Errorf("failed to mark invitation as accepted: %w", err)
+		}
+		if !accepted {
+			// Accepted, revoked or expired since it was read
+			return ErrNotPending
+		}
+
+		projectUser := models.ProjectUser{
+			Email: invitation.Email,
+			Role:  invitation.Role,
+		}
+		if err := s.repo.AddProjectUser(ctx, invitation.ProjectID, projectUser); err != nil {
+			return fmt.Errorf("failed to add project user: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	log.Printf("[INVITE] %s accepted invitation %s to project %s as %s", invitation.Email, invitation.UUID, invitation.ProjectID.Hex(), invitation.Role)
+
+	invitation.Status = models.InvitationStatusAccepted
+	invitation.AcceptedAt = &now
+	invitation.UpdatedAt = now
+	return invitation, nil
+}
+
+// Revoke cancels a pending invitation belonging to the project
+func (s *Service) Revoke(ctx context.Context, project *models.Project, invitationUUID string) error {
+	invitation, err := s.repo.GetInvitationByUUID(ctx, invitationUUID)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return ErrNotFound
+		}
+		return fmt.Errorf("failed to get invitation: %w", err)
+	}
+
+	if invitation.ProjectID != project.ID {
+		return ErrProjectMismatch
+	}
+	if invitation.Status != models.InvitationStatusPending {
+		return ErrNotPending
+	}
+
+	return s.repo.UpdateInvitationStatus(ctx, invitation.UUID, models.InvitationStatusRevoked)
+}
+
+// AcceptLink builds the UI link for accepting an invitation
+func (s *Service) AcceptLink(invitationUUID string) string {
+	token := SignToken(s.config.SigningSecret, invitationUUID)
+	return s.config.AcceptURL + "?token=" + url.QueryEscape(token)
+}
+
+// sendInviteEmail emails the invite link to the invited address
+func (s *Service) sendInviteEmail(project *models.Project, invitation *models.Invitation) {
+	if s.gmailSender == nil {
+		log.Printf("[INVITE] Gmail sender not configured, skipping invite email for %s", invitation.Email)
+		return
+	}
+
+	msg := gmail.EmailMessage{
+		To:      []string{invitation.Email},
+		Subject: fmt.Sprintf("You've been invited to %s on Cron Observer", project.Name),
+		Body:    s.buildEmailBody(project, invitation),
+	}
+
+	if err := s.gmailSender.Send(msg); err != nil {
+		log.Printf("[INVITE] Failed to send invite email to %s for project %s: %v", invitation.Email, project.Name, err)
+		return
+	}
+
+	log.Printf("[INVITE] Sent invite email to %s for project %s", invitation.Email, project.Name)
+}
+
+// buildEmailBody creates the HTML email body for an invitation. The project name and inviter are escaped, as both
+// are chosen by users.
+func (s *Service) buildEmailBody(project *models.Project, invitation *models.Invitation) string {
+	return fmt.Sprintf(`
+<!DOCTYPE html>
+<html>
+<head>
+	<style>
+		body { font-family: Arial, sans-serif; line-height: 1.6; color: #333; }
+		.container { max-width: 600px; margin: 0 auto; padding: 20px; }
+		.header { background-color: #0d6efd; color: white; padding: 20px; border-radius: 5px 5px 0 0; }
+		.content { background-color: #f8f9fa; padding: 20px; border: 1px solid #dee2e6; border-top: none; }
+		.button { display: inline-block; background-color: #0d6efd; color: white; padding: 10px 20px; border-radius: 4px; text-decoration: none; }
+		.footer { margin-top: 20px; padding-top: 20px; border-top: 1px solid #dee2e6; font-size: 12px; color: #6c757d; }
+	</style>
+</head>
+<body>
+	<div class="container">
+		<div class="header">
+			<h2 style="margin: 0;">Project Invitation</h2>
+		</div>
+		<div class="content">
+			<p>%s has invited you to join <strong>%s</strong> as <strong>%s</strong>.</p>
+			<p><a class="button" href="%s">Accept invitation</a></p>
+			<p>This invitation expires on %s.</p>
+		</div>
+		<div class="footer">
+			<p>If you were not expecting this invitation, you can ignore this email.</p>
+		</div>
+	</div>
+</body>
+</html>
+`,
+		html.EscapeString(invitation.InvitedBy),
+		html.EscapeString(project.Name),
+		invitation.Role,
+		html.EscapeString(s.AcceptLink(invitation.UUID)),
+		invitation.ExpiresAt.Format(time.RFC1123),
+	)
+}
@@ -0,0 +1,164 @@
+package invite
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	appconfig "github.com/yourusername/cron-observer/backend/internal/config"
+	"github.com/yourusername/cron-observer/backend/internal/gmail"
+	"github.com/yourusername/cron-observer/backend/internal/models"
+	"github.com/yourusername/cron-observer/backend/internal/repositories"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// recordingSender keeps the emails it is asked to send
+type recordingSender struct {
+	sent []gmail.EmailMessage
+}
+
+func (s *recordingSender) Send(msg gmail.EmailMessage) error {
+	s.sent = append(s.sent, msg)
+	return nil
+}
+
+// newTestService creates a service on an in-memory repository holding one project. Invitations expire after ttl,
+// or the default when it is zero.
+func newTestService(t *testing.T, sender gmail.Sender, ttl time.Duration) (*Service, *repositories.MemoryRepository, *models.Project) {
+	t.Helper()
+	repo := repositories.NewMemoryRepository()
+	project := &models.Project{ID: primitive.NewObjectID(), UUID: "project-1", Name: "billing", APIKey: "sk_billing"}
+	if err := repo.CreateProject(context.Background(), project); err != nil {
+		t.Fatalf("CreateProject: %v", err)
+	}
+	service := NewService(repo, sender, appconfig.InviteConfig{SigningSecret: "secret", AcceptURL: "https://observer.example.com/invites/accept", TTL: ttl})
+	return service, repo, project
+}
+
+func tokenOf(invitation *models.Invitation) string {
+	return SignToken("secret", invitation.UUID)
+}
+
+func membersOf(t *testing.T, repo *repositories.MemoryRepository, projectID primitive.ObjectID) []models.ProjectUser {
+	t.Helper()
+	project, err := repo.GetProjectByID(context.Background(), projectID)
+	if err != nil {
+		t.Fatalf("GetProjectByID: %v", err)
+	}
+	return project.ProjectUsers
+}
+
+func TestService_Accept_AddsMemberOnce(t *testing.T) {
+	ctx := context.Background()
+	service, repo, project := newTestService(t, nil, 0)
+	invitation, err := service.Invite(ctx, project, "New.User@example.com", models.ProjectUserRoleEditor, "admin@example.com")
+	if err != nil {
+		t.Fatalf("Invite: %v", err)
+	}
+
+	accepted, err := service.Accept(ctx, tokenOf(invitation), "new.user@example.com")
+	if err != nil {
+		t.Fatalf("Accept: %v", err)
+	}
+	if accepted.Status != models.InvitationStatusAccepted || accepted.AcceptedAt == nil {
+		t.Errorf("Expected an accepted invitation, got %+v", accepted)
+	}
+
+	// The token cannot be used a second time
+	if _, err := service.Accept(ctx, tokenOf(invitation), "new.user@example.com"); !errors.Is(err, ErrNotPending) {
+		t.Errorf("Expected ErrNotPending when the token is reused, got %v", err)
+	}
+	members := membersOf(t, repo, project.ID)
+	if len(members) != 1 || members[0].Email != "new.user@example.com" || members[0].Role != models.ProjectUserRoleEditor {
+		t.Errorf("Expected one editor, got %+v", members)
+	}
+}
+
+func TestService_Accept_RejectsExpiredInvitation(t *testing.T) {
+	ctx := context.Background()
+	service, repo, project := newTestService(t, nil, time.Millisecond)
+	invitation, err := service.Invite(ctx, project, "late@example.com", models.ProjectUserRoleViewer, "admin@example.com")
+	if err != nil {
+		t.Fatalf("Invite: %v", err)
+	}
+	time.Sleep(5 * time.Millisecond)
+
+	if _, err := service.Accept(ctx, tokenOf(invitation), "late@example.com"); !errors.Is(err, ErrExpired) {
+		t.Fatalf("Expected ErrExpired, got %v", err)
+	}
+	stored, err := repo.GetInvitationByUUID(ctx, invitation.UUID)
+	if err != nil {
+		t.Fatalf("GetInvitationByUUID: %v", err)
+	}
+	if stored.Status != models.InvitationStatusExpired {
+		t.Errorf("Expected the invitation to be marked EXPIRED, got %s", stored.Status)
+	}
+	if members := membersOf(t, repo, project.ID); len(members) != 0 {
+		t.Errorf("Expected no members, got %+v", members)
+	}
+}
+
+func TestService_Accept_RejectsOtherEmail(t *testing.T) {
+	ctx := context.Background()
+	service, repo, project := newTestService(t, nil, 0)
+	invitation, err := service.Invite(ctx, project, "invited@example.com", models.ProjectUserRoleAdmin, "admin@example.com")
+	if err != nil {
+		t.Fatalf("Invite: %v", err)
+	}
+
+	if _, err := service.Accept(ctx, tokenOf(invitation), "someone.else@example.com"); !errors.Is(err, ErrEmailMismatch) {
+		t.Fatalf("Expected ErrEmailMismatch, got %v", err)
+	}
+	if members := membersOf(t, repo, project.ID); len(members) != 0 {
+		t.Errorf("Expected no members, got %+v", members)
+	}
+
+	// The invitation stays usable by the invited address
+	if _, err := service.Accept(ctx, tokenOf(invitation), "invited@example.com"); err != nil {
+		t.Errorf("Expected the invited address to accept, got %v", err)
+	}
+}
+
+func TestService_Accept_RejectsForgedToken(t *testing.T) {
+	ctx := context.Background()
+	service, _, project := newTestService(t, nil, 0)
+	invitation, err := service.Invite(ctx, project, "invited@example.com", models.ProjectUserRoleViewer, "admin@example.com")
+	if err != nil {
+		t.Fatalf("Invite: %v", err)
+	}
+
+	if _, err := service.Accept(ctx, SignToken("other-secret", invitation.UUID), "invited@example.com"); !errors.Is(err, ErrInvalidToken) {
+		t.Errorf("Expected ErrInvalidToken, got %v", err)
+	}
+}
+
+func TestService_Invite_EscapesUserInputInEmail(t *testing.T) {
+	ctx := context.Background()
+	sender := &recordingSender{}
+	service, _, project := newTestService(t, sender, 0)
+	project.Name = `<script>alert("x")</script>`
+
+	invitation, err := service.Invite(ctx, project, "invited@example.com", models.ProjectUserRoleViewer, `<a href="https://evil.example.com">Support</a>`)
+	if err != nil {
+		t.Fatalf("Invite: %v", err)
+	}
+	if len(sender.sent) != 1 {
+		t.Fatalf("Expected one email, got %d", len(sender.sent))
+	}
+
+	body := sender.sent[0].Body
+	for _, raw := range []string{"<script>", `<a href="https://evil.example.com">`} {
+		if strings.Contains(body, raw) {
+			t.Errorf("Expected %q to be escaped in the email body", raw)
+		}
+	}
+	if !strings.Contains(body, "&lt;script&gt;") {
+		t.Errorf("Expected the escaped project name in the email body")
+	}
+	if !strings.Contains(body, url.QueryEscape(tokenOf(invitation))) {
+		t.Errorf("Expected the accept link in the email body")
+	}
+}
@@ -0,0 +1,36 @@
+package invite
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"strings"
+)
+
+// SignToken creates an invite token of the form "<invitation_uuid>.<signature>".
+// The signature is an HMAC-SHA256 of the invitation UUID, so the token cannot be forged
+// or pointed at a different invitation without the signing secret.
+func SignToken(secret, invitationUUID string) string {
+	return invitationUUID + "." + sign(secret, invitationUUID)
+}
+
+// VerifyToken checks the token signature and returns the invitation UUID it refers to
+func VerifyToken(secret, token string) (string, bool) {
+	invitationUUID, signature, found := strings.Cut(token, ".")
+	if !found || invitationUUID == "" || signature == "" {
+		return "", false
+	}
+
+	expected := sign(secret, invitationUUID)
+	if !hmac.Equal([]byte(signature), []byte(expected)) {
+		return "", false
+	}
+	return invitationUUID, true
+}
+
+// sign returns the base64url-encoded HMAC-SHA256 of the payload
+func sign(secret, payload string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(payload))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
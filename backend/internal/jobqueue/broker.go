@@ -0,0 +1,125 @@
+package jobqueue
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/yourusername/cron-observer/backend/internal/config"
+)
+
+// PublishCloser is a Publisher holding broker resources that must be released
+type PublishCloser interface {
+	Publisher
+	Close() error
+}
+
+// NewPublisher connects a publisher to the broker selected by cfg.Driver. The in-process queue, used when no
+// broker is configured, keeps its jobs in store.
+func NewPublisher(cfg config.BrokerConfig, store JobStore) (PublishCloser, error) {
+	// Each case checks its own error so a failed connect never returns a non-nil interface holding a nil pointer
+	switch driver(cfg) {
+	case config.BrokerDriverInProcess:
+		return NewInProcessQueue(store, InProcessOptions{}), nil
+	case config.BrokerDriverRabbitMQ:
+		publisher, err := NewRabbitMQPublisher(cfg.AMQPURL, cfg.DeleteQueueName)
+		if err != nil {
+			return nil, err
+		}
+		return publisher, nil
+	case config.BrokerDriverSQS:
+		publisher, err := NewSQSPublisher(sqsOptions(cfg))
+		if err != nil {
+			return nil, err
+		}
+		return publisher, nil
+	case config.BrokerDriverRedis:
+		publisher, err := NewRedisStreamsPublisher(context.Background(), redisStreamsOptions(cfg))
+		if err != nil {
+			return nil, err
+		}
+		return publisher, nil
+	default:
+		return nil, fmt.Errorf("unknown job queue driver %q", cfg.Driver)
+	}
+}
+
+// NewConsumer connects a consumer to the broker selected by cfg.Driver. The in-process queue, used when no
+// broker is configured, takes its jobs from store.
+func NewConsumer(cfg config.BrokerConfig, store JobStore) (Consumer, error) {
+	switch driver(cfg) {
+	case config.BrokerDriverInProcess:
+		return NewInProcessQueue(store, InProcessOptions{}), nil
+	case config.BrokerDriverRabbitMQ:
+		consumer, err := NewRabbitMQConsumer(cfg.AMQPURL, cfg.DeleteQueueName, ConsumerOptions{
+			Retry:    retryPolicy(cfg),
+			Workers:  cfg.ConsumerWorkers,
+			Prefetch: cfg.ConsumerPrefetch,
+		})
+		if err != nil {
+			return nil, err
+		}
+		return consumer, nil
+	case config.BrokerDriverSQS:
+		consumer, err := NewSQSConsumer(sqsOptions(cfg))
+		if err != nil {
+			return nil, err
+		}
+		return consumer, nil
+	case config.BrokerDriverRedis:
+		consumer, err := NewRedisStreamsConsumer(context.Background(), redisStreamsOptions(cfg))
+		if err != nil {
+			return nil, err
+		}
+		return consumer, nil
+	default:
+		return nil, fmt.Errorf("unknown job queue driver %q", cfg.Driver)
+	}
+}
+
+// NewDeadLetterQueue connects to the dead-letter queue of the broker selected by cfg.Driver. Only RabbitMQ parks
+// jobs in a queue the backend manages; SQS and Redis keep them in their own dead-letter queue and stream.
+func NewDeadLetterQueue(cfg config.BrokerConfig) (*RabbitMQDeadLetterQueue, error) {
+	if d := driver(cfg); d != config.BrokerDriverRabbitMQ {
+		return nil, fmt.Errorf("parked jobs are not available with the %s job queue driver", d)
+	}
+	return NewRabbitMQDeadLetterQueue(cfg.AMQPURL, cfg.DeleteQueueName, retryPolicy(cfg))
+}
+
+// driver returns the configured broker, falling back to the in-process queue when RabbitMQ has no URL
+func driver(cfg config.BrokerConfig) string {
+	if cfg.Driver == "" || cfg.Driver == config.BrokerDriverRabbitMQ {
+		if cfg.AMQPURL == "" {
+			return config.BrokerDriverInProcess
+		}
+		return config.BrokerDriverRabbitMQ
+	}
+	return cfg.Driver
+}
+
+func retryPolicy(cfg config.BrokerConfig) RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts:     cfg.MaxAttempts,
+		RetryDelay:      cfg.RetryDelay,
+		DeadLetterQueue: cfg.DeadLetterQueueName,
+	}
+}
+
+func sqsOptions(cfg config.BrokerConfig) SQSOptions {
+	return SQSOptions{
+		QueueURL:           cfg.SQSQueueURL,
+		Region:             cfg.SQSRegion,
+		DeadLetterQueueURL: cfg.SQSDeadLetterQueueURL,
+		VisibilityTimeout:  cfg.SQSVisibilityTimeout,
+		RetryDelay:         cfg.SQSRetryDelay,
+	}
+}
+
+func redisStreamsOptions(cfg config.BrokerConfig) RedisStreamsOptions {
+	return RedisStreamsOptions{
+		URL:           cfg.RedisURL,
+		Stream:        cfg.DeleteQueueName,
+		Group:         cfg.RedisConsumerGroup,
+		ClaimMinIdle:  cfg.RedisClaimMinIdle,
+		MaxDeliveries: cfg.RedisMaxDeliveries,
+	}
+}
@@ -0,0 +1,231 @@
+package jobqueue
+
+import (
+	"context"
+	"errors"
+	"log"
+	"sync"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+const (
+	// reconnectInitialDelay is the wait before the first reconnection attempt; it doubles up to reconnectMaxDelay
+	reconnectInitialDelay = time.Second
+	reconnectMaxDelay     = 30 * time.Second
+)
+
+// ErrNotConnected is returned while the RabbitMQ connection is lost and being re-established
+var ErrNotConnected = errors.New("not connected to RabbitMQ, reconnecting")
+
+// ErrSessionClosed is returned once the session has been closed
+var ErrSessionClosed = errors.New("RabbitMQ session closed")
+
+// Dial connects to RabbitMQ at the given URL and opens a channel on the connection.
+// Other RabbitMQ clients of the backend, such as the event broker, connect through it too.
+func Dial(amqpURL string) (*amqp.Connection, *amqp.Channel, error) {
+	conn, err := amqp.Dial(amqpURL)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ch, err := conn.Channel()
+	if err != nil {
+		conn.Close()
+		return nil, nil, err
+	}
+
+	return conn, ch, nil
+}
+
+// RabbitMQSession is a supervised connection and channel. When the broker closes either, it reconnects with
+// exponential backoff and runs setup again on the new channel (declaring queues, QoS, ...). The event broker
+// keeps its connection up with it too.
+type RabbitMQSession struct {
+	url   string
+	setup func(ch *amqp.Channel) error
+
+	mu      sync.Mutex
+	conn    *amqp.Connection
+	channel *amqp.Channel // nil while reconnecting
+	ready   chan struct{} // closed once the next connection is established
+	done    chan struct{} // closed by Close
+}
+
+// NewRabbitMQSession connects once, failing if RabbitMQ is unreachable, and then keeps the connection up
+func NewRabbitMQSession(amqpURL string, setup func(ch *amqp.Channel) error) (*RabbitMQSession, error) {
+	s := &RabbitMQSession{
+		url:   amqpURL,
+		setup: setup,
+		ready: make(chan struct{}),
+		done:  make(chan struct{}),
+	}
+	conn, ch, err := s.connect()
+	if err != nil {
+		return nil, err
+	}
+	s.conn, s.channel = conn, ch
+	close(s.ready)
+
+	go s.supervise(conn, ch)
+	return s, nil
+}
+
+// connect dials RabbitMQ and prepares the channel
+func (s *RabbitMQSession) connect() (*amqp.Connection, *amqp.Channel, error) {
+	conn, ch, err := Dial(s.url)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := s.setup(ch); err != nil {
+		ch.Close()
+		conn.Close()
+		return nil, nil, err
+	}
+	return conn, ch, nil
+}
+
+// supervise waits for the connection or channel to close and reconnects, until the session is closed
+func (s *RabbitMQSession) supervise(conn *amqp.Connection, ch *amqp.Channel) {
+	for {
+		connClosed := conn.NotifyClose(make(chan *amqp.Error, 1))
+		channelClosed := ch.NotifyClose(make(chan *amqp.Error, 1))
+
+		var reason *amqp.Error
+		select {
+		case <-s.done:
+			return
+		case reason = <-connClosed:
+		case reason = <-channelClosed:
+		}
+
+		s.mu.Lock()
+		select {
+		case <-s.done:
+			s.mu.Unlock()
+			return
+		default:
+		}
+		s.channel = nil
+		s.ready = make(chan struct{})
+		s.mu.Unlock()
+		// A closed channel leaves the connection open; drop it and start over
+		conn.Close()
+		log.Printf("[jobqueue] RabbitMQ connection lost (%v), reconnecting", reason)
+
+		var ok bool
+		conn, ch, ok = s.reconnect()
+		if !ok {
+			return
+		}
+	}
+}
+
+// reconnect retries connect with exponential backoff. Returns false if the session is closed meanwhile.
+func (s *RabbitMQSession) reconnect() (*amqp.Connection, *amqp.Channel, bool) {
+	for attempt := 0; ; attempt++ {
+		select {
+		case <-s.done:
+			return nil, nil, false
+		case <-time.After(reconnectDelay(attempt)):
+		}
+
+		conn, ch, err := s.connect()
+		if err != nil {
+			log.Printf("[jobqueue] RabbitMQ reconnection attempt %d failed: %v", attempt+1, err)
+			continue
+		}
+
+		s.mu.Lock()
+		select {
+		case <-s.done:
+			s.mu.Unlock()
+			ch.Close()
+			conn.Close()
+			return nil, nil, false
+		default:
+		}
+		s.conn, s.channel = conn, ch
+		close(s.ready)
+		s.mu.Unlock()
+		log.Printf("[jobqueue] Reconnected to RabbitMQ after %d attempt(s)", attempt+1)
+		return conn, ch, true
+	}
+}
+
+// reconnectDelay is the wait before the given reconnection attempt, counting from 0
+func reconnectDelay(attempt int) time.Duration {
+	delay := reconnectInitialDelay
+	for i := 0; i < attempt && delay < reconnectMaxDelay; i++ {
+		delay *= 2
+	}
+	if delay > reconnectMaxDelay {
+		delay = reconnectMaxDelay
+	}
+	return delay
+}
+
+// Channel returns the channel, or ErrNotConnected while reconnecting
+func (s *RabbitMQSession) Channel() (*amqp.Channel, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	select {
+	case <-s.done:
+		return nil, ErrSessionClosed
+	default:
+	}
+	if s.channel == nil {
+		return nil, ErrNotConnected
+	}
+	return s.channel, nil
+}
+
+// WaitChannel returns the channel, waiting for a reconnection if needed, until ctx is cancelled or the session
+// is closed
+func (s *RabbitMQSession) WaitChannel(ctx context.Context) (*amqp.Channel, error) {
+	for {
+		s.mu.Lock()
+		ch, ready := s.channel, s.ready
+		s.mu.Unlock()
+		if ch != nil && !ch.IsClosed() {
+			return ch, nil
+		}
+		if ch != nil {
+			// Closed, but the supervisor has not noticed yet
+			ready = nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-s.done:
+			return nil, ErrSessionClosed
+		case <-ready:
+		case <-time.After(100 * time.Millisecond):
+		}
+	}
+}
+
+// Close stops reconnecting and closes the connection
+func (s *RabbitMQSession) Close() error {
+	s.mu.Lock()
+	select {
+	case <-s.done:
+		s.mu.Unlock()
+		return nil
+	default:
+	}
+	close(s.done)
+	conn := s.conn
+	s.channel = nil
+	s.mu.Unlock()
+
+	if conn == nil {
+		return nil
+	}
+	if err := conn.Close(); err != nil && !errors.Is(err, amqp.ErrClosed) {
+		return err
+	}
+	return nil
+}
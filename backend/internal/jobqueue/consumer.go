@@ -0,0 +1,195 @@
+package jobqueue
+
+import (
+	"context"
+	"errors"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+// ConsumerOptions tunes a RabbitMQ consumer. Zero values use the defaults.
+type ConsumerOptions struct {
+	Retry RetryPolicy
+
+	// Workers is how many jobs are processed in parallel (default 1). Jobs with the same ordering key
+	// (see OrderedJob) always run one after another, in the order they were received.
+	Workers int
+
+	// Prefetch is how many unacknowledged jobs RabbitMQ delivers ahead; raised to Workers when lower
+	Prefetch int
+}
+
+// RabbitMQConsumer consumes jobs from a RabbitMQ queue. It reconnects and re-subscribes when the broker restarts.
+type RabbitMQConsumer struct {
+	session   *RabbitMQSession
+	queueName string
+	policy    RetryPolicy
+	workers   int
+	prefetch  int
+}
+
+// NewRabbitMQConsumer creates a new RabbitMQ consumer.
+// Connects to RabbitMQ at the given URL and declares the queue with its retry and dead-letter queues.
+func NewRabbitMQConsumer(amqpURL, queueName string, opts ConsumerOptions) (*RabbitMQConsumer, error) {
+	policy := opts.Retry.withDefaults(queueName)
+	workers := max(opts.Workers, 1)
+	prefetch := max(opts.Prefetch, workers)
+	session, err := NewRabbitMQSession(amqpURL, func(ch *amqp.Channel) error {
+		// Declare queues (idempotent: creates if not exists)
+		if err := declareRetryQueues(ch, queueName, policy.DeadLetterQueue); err != nil {
+			return err
+		}
+
+		// Set QoS: bound the unacked messages per consumer for fair distribution across replicas
+		err := ch.Qos(
+			prefetch, // prefetch count
+			0,        // prefetch size
+			false,    // global
+		)
+		if err != nil {
+			return err
+		}
+
+		// Retried and parked jobs are republished; confirms make sure they are stored before the original is acked
+		return ch.Confirm(false)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &RabbitMQConsumer{
+		session:   session,
+		queueName: queueName,
+		policy:    policy,
+		workers:   workers,
+		prefetch:  prefetch,
+	}, nil
+}
+
+// Start subscribes to the queue and passes each message to the router. Once ctx is cancelled it takes no new
+// messages and returns after the jobs in progress have been settled.
+// Acks when the handler returns nil. A failed job is moved to the retry queue, and parked in the dead-letter
+// queue once it has failed policy.MaxAttempts times; malformed messages are parked at once.
+// When the connection is lost, unacked messages are redelivered by the broker and the consumer subscribes
+// again once reconnected. Runs until ctx is cancelled or the consumer is closed.
+func (c *RabbitMQConsumer) Start(ctx context.Context, router *Router) error {
+	for {
+		ch, err := c.session.WaitChannel(ctx)
+		if errors.Is(err, ErrSessionClosed) {
+			return nil
+		}
+		if err != nil {
+			log.Printf("[jobqueue] Consumer context cancelled, stopping")
+			return err
+		}
+
+		msgs, err := ch.Consume(
+			c.queueName, // queue
+			"",          // consumer tag (empty = auto-generated)
+			false,       // auto-ack (false = manual ack)
+			false,       // exclusive
+			false,       // no-local
+			false,       // no-wait
+			nil,         // args
+		)
+		if err != nil {
+			if errors.Is(err, amqp.ErrClosed) {
+				// The channel closed between WaitChannel and Consume; wait for the reconnection
+				continue
+			}
+			return err
+		}
+
+		log.Printf("[jobqueue] RabbitMQ consumer started for queue: %s (workers=%d, prefetch=%d)", c.queueName, c.workers, c.prefetch)
+		if err := c.consume(ctx, ch, router, msgs); err != nil {
+			return err
+		}
+		log.Printf("[jobqueue] Message channel closed, re-subscribing after reconnect")
+	}
+}
+
+// consume hands deliveries to the workers until the channel closes (nil) or ctx is cancelled, and then waits for
+// the jobs in progress
+func (c *RabbitMQConsumer) consume(ctx context.Context, ch *amqp.Channel, router *Router, msgs <-chan amqp.Delivery) error {
+	// Each worker buffers up to prefetch deliveries, more than RabbitMQ sends ahead, so submit never blocks
+	pool := newKeyedPool(c.workers, c.prefetch)
+	defer pool.stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.Printf("[jobqueue] Consumer context cancelled, stopping")
+			return ctx.Err()
+		case msg, ok := <-msgs:
+			if !ok {
+				return nil
+			}
+			pool.submit(orderingKey(msg.Body), func() { c.process(ctx, ch, router, msg) })
+		}
+	}
+}
+
+// process runs one delivery and settles it with the broker. A job that has started runs to completion even when
+// the consumer is stopped meanwhile, so shutdown never interrupts a handler mid-write.
+func (c *RabbitMQConsumer) process(ctx context.Context, ch *amqp.Channel, router *Router, msg amqp.Delivery) {
+	if ctx.Err() != nil {
+		// Stopping: leave the job to the next consumer
+		msg.Nack(false, true)
+		return
+	}
+	ctx = context.WithoutCancel(ctx)
+
+	// Process message
+	envelope, err := router.Dispatch(ctx, msg.Body)
+	if err != nil {
+		c.retryOrPark(ctx, ch, msg, envelope, err)
+		return
+	}
+
+	// Success: ack the message
+	msg.Ack(false)
+	log.Printf("[Consumer] Successfully processed %s", envelope)
+}
+
+// retryOrPark moves a failed message to the retry queue, or to the dead-letter queue when it is malformed or out
+// of attempts, and acks it. If that publish fails the message is nacked back onto the job queue.
+func (c *RabbitMQConsumer) retryOrPark(ctx context.Context, ch *amqp.Channel, msg amqp.Delivery, envelope *Envelope, handlerErr error) {
+	attempt := attempts(msg.Headers) + 1
+
+	var target string
+	var republished amqp.Publishing
+	if errors.Is(handlerErr, ErrMalformedJob) || attempt >= c.policy.MaxAttempts {
+		if msg.MessageId == "" {
+			// Unenveloped messages have no ID; parked jobs need one to be replayed
+			msg.MessageId = uuid.New().String()
+		}
+		target = c.policy.DeadLetterQueue
+		republished = withHeaders(msg, amqp.Table{
+			headerAttempts: int32(attempt),
+			headerError:    handlerErr.Error(),
+			headerParkedAt: time.Now().UTC(),
+		})
+		log.Printf("[Consumer] Parking %s after %d attempt(s) in %s: %v", envelope, attempt, target, handlerErr)
+	} else {
+		target = retryQueueName(c.queueName)
+		republished = withHeaders(msg, amqp.Table{headerAttempts: int32(attempt)})
+		republished.Expiration = strconv.FormatInt(c.policy.RetryDelay.Milliseconds(), 10)
+		log.Printf("[Consumer] Handler error for %s: %v (attempt %d of %d, retrying in %s)", envelope, handlerErr, attempt, c.policy.MaxAttempts, c.policy.RetryDelay)
+	}
+
+	if err := publishConfirmed(ctx, ch, target, republished); err != nil {
+		log.Printf("[Consumer] Failed to move job to %s, requeueing it: %v", target, err)
+		msg.Nack(false, true)
+		return
+	}
+	msg.Ack(false)
+}
+
+// Close closes the RabbitMQ connection and channel.
+func (c *RabbitMQConsumer) Close() error {
+	return c.session.Close()
+}
@@ -0,0 +1,249 @@
+package jobqueue
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+	"github.com/yourusername/cron-observer/backend/internal/models"
+)
+
+// Headers the RabbitMQ consumer keeps on retried and parked jobs
+const (
+	headerAttempts = "x-job-attempts"
+	headerError    = "x-job-error"
+	headerParkedAt = "x-job-parked-at"
+)
+
+const (
+	// DefaultMaxAttempts is how often a job is tried before it is parked, when RetryPolicy.MaxAttempts is not set
+	DefaultMaxAttempts = 5
+
+	// defaultRetryDelay is how long a failed job waits in the retry queue, when RetryPolicy.RetryDelay is not set
+	defaultRetryDelay = 30 * time.Second
+
+	// parkedScanLimit bounds how many parked jobs one replay looks at
+	parkedScanLimit = 10000
+)
+
+// RetryPolicy bounds the retries of failed jobs on RabbitMQ. A failed job waits RetryDelay in the retry queue
+// "<queue>.retry" and is tried again; after MaxAttempts, or at once for a malformed message, it is parked in
+// the dead-letter queue. Zero values use the defaults.
+type RetryPolicy struct {
+	MaxAttempts     int
+	RetryDelay      time.Duration
+	DeadLetterQueue string // Defaults to "<queue>.dead"
+}
+
+func (p RetryPolicy) withDefaults(queueName string) RetryPolicy {
+	if p.MaxAttempts <= 0 {
+		p.MaxAttempts = DefaultMaxAttempts
+	}
+	if p.RetryDelay <= 0 {
+		p.RetryDelay = defaultRetryDelay
+	}
+	if p.DeadLetterQueue == "" {
+		p.DeadLetterQueue = queueName + ".dead"
+	}
+	return p
+}
+
+// retryQueueName is the queue where failed jobs wait before they are tried again
+func retryQueueName(queueName string) string {
+	return queueName + ".retry"
+}
+
+// declareRetryQueues declares the job queue, its retry queue and its dead-letter queue. Messages expire from the
+// retry queue back into the job queue.
+func declareRetryQueues(ch *amqp.Channel, queueName, deadLetterQueue string) error {
+	queues := []struct {
+		name string
+		args amqp.Table
+	}{
+		{name: queueName},
+		{name: retryQueueName(queueName), args: amqp.Table{
+			"x-dead-letter-exchange":    "",
+			"x-dead-letter-routing-key": queueName,
+		}},
+		{name: deadLetterQueue},
+	}
+	for _, queue := range queues {
+		_, err := ch.QueueDeclare(
+			queue.name, // name
+			true,       // durable
+			false,      // delete when unused
+			false,      // exclusive
+			false,      // no-wait
+			queue.args, // arguments
+		)
+		if err != nil {
+			return fmt.Errorf("declare queue %s: %w", queue.name, err)
+		}
+	}
+	return nil
+}
+
+// attempts returns how often the message has been tried, from the header set when it was retried
+func attempts(headers amqp.Table) int {
+	switch n := headers[headerAttempts].(type) {
+	case int32:
+		return int(n)
+	case int64:
+		return int(n)
+	case int:
+		return n
+	}
+	return 0
+}
+
+// withHeaders republishes a delivery with its headers changed
+func withHeaders(msg amqp.Delivery, set amqp.Table, remove ...string) amqp.Publishing {
+	headers := amqp.Table{}
+	for key, value := range msg.Headers {
+		headers[key] = value
+	}
+	for _, key := range remove {
+		delete(headers, key)
+	}
+	for key, value := range set {
+		headers[key] = value
+	}
+	return amqp.Publishing{
+		Headers:      headers,
+		ContentType:  msg.ContentType,
+		Type:         msg.Type,
+		MessageId:    msg.MessageId,
+		Body:         msg.Body,
+		DeliveryMode: amqp.Persistent,
+	}
+}
+
+// ParkedJobs inspects and replays jobs in the dead-letter queue
+type ParkedJobs interface {
+	ListParked(ctx context.Context, limit int) ([]models.ParkedJob, error)
+	ReplayParked(ctx context.Context, ids []string) ([]string, error) // empty ids replays every parked job
+}
+
+// RabbitMQDeadLetterQueue implements ParkedJobs on the RabbitMQ dead-letter queue.
+type RabbitMQDeadLetterQueue struct {
+	session   *RabbitMQSession
+	queueName string
+	policy    RetryPolicy
+
+	// Parked messages are fetched without ack and returned afterwards, so one operation runs at a time
+	mu sync.Mutex
+}
+
+// NewRabbitMQDeadLetterQueue connects to the dead-letter queue of the job queue
+func NewRabbitMQDeadLetterQueue(amqpURL, queueName string, policy RetryPolicy) (*RabbitMQDeadLetterQueue, error) {
+	policy = policy.withDefaults(queueName)
+	session, err := NewRabbitMQSession(amqpURL, func(ch *amqp.Channel) error {
+		if err := declareRetryQueues(ch, queueName, policy.DeadLetterQueue); err != nil {
+			return err
+		}
+		return ch.Confirm(false)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &RabbitMQDeadLetterQueue{session: session, queueName: queueName, policy: policy}, nil
+}
+
+// ListParked returns up to limit parked jobs, oldest first, leaving them in the queue
+func (q *RabbitMQDeadLetterQueue) ListParked(ctx context.Context, limit int) ([]models.ParkedJob, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	ch, err := q.session.Channel()
+	if err != nil {
+		return nil, err
+	}
+
+	jobs := []models.ParkedJob{}
+	var fetched []amqp.Delivery
+	defer func() {
+		for _, msg := range fetched {
+			msg.Nack(false, true)
+		}
+	}()
+	for len(fetched) < limit && ctx.Err() == nil {
+		msg, ok, err := ch.Get(q.policy.DeadLetterQueue, false)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			break
+		}
+		fetched = append(fetched, msg)
+		jobs = append(jobs, parkedJob(msg))
+	}
+	return jobs, ctx.Err()
+}
+
+// ReplayParked moves the parked jobs with the given IDs, or all of them, back to the job queue with their
+// attempts reset. Returns the IDs of the replayed jobs.
+func (q *RabbitMQDeadLetterQueue) ReplayParked(ctx context.Context, ids []string) ([]string, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	ch, err := q.session.Channel()
+	if err != nil {
+		return nil, err
+	}
+
+	selected := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		selected[id] = true
+	}
+
+	replayed := []string{}
+	var kept []amqp.Delivery
+	defer func() {
+		for _, msg := range kept {
+			msg.Nack(false, true)
+		}
+	}()
+	for scanned := 0; scanned < parkedScanLimit && ctx.Err() == nil; scanned++ {
+		msg, ok, err := ch.Get(q.policy.DeadLetterQueue, false)
+		if err != nil {
+			return replayed, err
+		}
+		if !ok {
+			break
+		}
+		if len(selected) > 0 && !selected[msg.MessageId] {
+			kept = append(kept, msg)
+			continue
+		}
+
+		republished := withHeaders(msg, nil, headerAttempts, headerError, headerParkedAt)
+		if err := publishConfirmed(ctx, ch, q.queueName, republished); err != nil {
+			kept = append(kept, msg)
+			return replayed, err
+		}
+		msg.Ack(false)
+		replayed = append(replayed, msg.MessageId)
+	}
+	return replayed, ctx.Err()
+}
+
+// Close closes the RabbitMQ connection.
+func (q *RabbitMQDeadLetterQueue) Close() error {
+	return q.session.Close()
+}
+
+func parkedJob(msg amqp.Delivery) models.ParkedJob {
+	job := models.ParkedJob{
+		ID:       msg.MessageId,
+		Type:     msg.Type,
+		Attempts: attempts(msg.Headers),
+		Body:     string(msg.Body),
+	}
+	job.Error, _ = msg.Headers[headerError].(string)
+	if parkedAt, ok := msg.Headers[headerParkedAt].(time.Time); ok {
+		job.ParkedAt = parkedAt
+	}
+	return job
+}
@@ -0,0 +1,49 @@
+package jobqueue
+
+import (
+	"testing"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+func TestRetryPolicy_Defaults(t *testing.T) {
+	policy := RetryPolicy{}.withDefaults("task_delete_queue")
+
+	if policy.MaxAttempts != DefaultMaxAttempts || policy.RetryDelay != 30*time.Second {
+		t.Errorf("policy = %+v, want the default attempts and delay", policy)
+	}
+	if policy.DeadLetterQueue != "task_delete_queue.dead" {
+		t.Errorf("DeadLetterQueue = %q, want task_delete_queue.dead", policy.DeadLetterQueue)
+	}
+}
+
+func TestWithHeaders_CountsAttemptsAndKeepsMessage(t *testing.T) {
+	msg := amqp.Delivery{
+		Headers:   amqp.Table{"x-death": "kept", headerError: "old error"},
+		Type:      "delete_task",
+		MessageId: "job-1",
+		Body:      []byte(`{"type":"delete_task"}`),
+	}
+	if attempts(msg.Headers) != 0 {
+		t.Fatalf("a new message has %d attempts, want 0", attempts(msg.Headers))
+	}
+
+	republished := withHeaders(msg, amqp.Table{headerAttempts: int32(2)}, headerError)
+
+	if attempts(republished.Headers) != 2 {
+		t.Errorf("attempts = %d, want 2", attempts(republished.Headers))
+	}
+	if _, ok := republished.Headers[headerError]; ok {
+		t.Error("removed header is still set")
+	}
+	if republished.Headers["x-death"] != "kept" || republished.MessageId != "job-1" || republished.Type != "delete_task" {
+		t.Errorf("republished message lost fields: %+v", republished)
+	}
+	if republished.DeliveryMode != amqp.Persistent {
+		t.Error("republished message is not persistent")
+	}
+	if msg.Headers[headerError] != "old error" {
+		t.Error("the original delivery's headers were modified")
+	}
+}
@@ -0,0 +1,149 @@
+package jobqueue
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/yourusername/cron-observer/backend/internal/models"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// JobStore persists the jobs of the in-process queue until they are processed. Implemented by the repository.
+type JobStore interface {
+	CreateQueuedJob(ctx context.Context, job *models.QueuedJob) error
+	ClaimQueuedJobs(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*models.QueuedJob, error)
+	DeleteQueuedJob(ctx context.Context, id primitive.ObjectID) error
+}
+
+// InProcessOptions tunes the in-process queue. Zero values use the defaults.
+type InProcessOptions struct {
+	PollInterval time.Duration // How often stored jobs are checked; jobs published through this queue run immediately
+	Lease        time.Duration // How long a claimed job is owned by its consumer; failed jobs are retried after it
+	BatchSize    int
+}
+
+const (
+	defaultInProcessPollInterval = 5 * time.Second
+	defaultInProcessLease        = time.Minute
+	defaultInProcessBatchSize    = 10
+)
+
+// InProcessQueue implements Publisher and Consumer without a message broker, for single-node deployments.
+// Jobs are persisted in the store, so they survive a restart, and a channel wakes the consumer when one is
+// published. Consumers on several replicas sharing the store claim each job once.
+type InProcessQueue struct {
+	store   JobStore
+	options InProcessOptions
+	notify  chan struct{}
+}
+
+// NewInProcessQueue creates a queue of the jobs in store
+func NewInProcessQueue(store JobStore, options InProcessOptions) *InProcessQueue {
+	if options.PollInterval <= 0 {
+		options.PollInterval = defaultInProcessPollInterval
+	}
+	if options.Lease <= 0 {
+		options.Lease = defaultInProcessLease
+	}
+	if options.BatchSize <= 0 {
+		options.BatchSize = defaultInProcessBatchSize
+	}
+	return &InProcessQueue{
+		store:   store,
+		options: options,
+		notify:  make(chan struct{}, 1),
+	}
+}
+
+// Publish wraps the job in an envelope, stores it and wakes the consumer
+func (q *InProcessQueue) Publish(ctx context.Context, job Job) error {
+	envelope, err := NewEnvelope(job)
+	if err != nil {
+		return err
+	}
+	body, err := json.Marshal(envelope)
+	if err != nil {
+		return err
+	}
+	if err := q.store.CreateQueuedJob(ctx, &models.QueuedJob{
+		Type:      string(envelope.Type),
+		Body:      body,
+		CreatedAt: envelope.PublishedAt,
+	}); err != nil {
+		return err
+	}
+
+	select {
+	case q.notify <- struct{}{}:
+	default:
+		// A dispatch is already pending
+	}
+	return nil
+}
+
+// Start passes stored jobs to the router until ctx is cancelled.
+// Deletes a job when the handler returns nil. On error the job stays stored and is retried once the lease expires.
+func (q *InProcessQueue) Start(ctx context.Context, router *Router) error {
+	log.Printf("[jobqueue] In-process consumer started")
+
+	ticker := time.NewTicker(q.options.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		q.dispatch(ctx, router)
+
+		select {
+		case <-ctx.Done():
+			log.Printf("[jobqueue] Consumer context cancelled, stopping")
+			return ctx.Err()
+		case <-ticker.C:
+		case <-q.notify:
+		}
+	}
+}
+
+// dispatch runs claimed jobs in batches until none are left
+func (q *InProcessQueue) dispatch(ctx context.Context, router *Router) {
+	for ctx.Err() == nil {
+		claimed, err := q.store.ClaimQueuedJobs(ctx, time.Now(), q.options.Lease, q.options.BatchSize)
+		if err != nil {
+			log.Printf("[jobqueue] Failed to claim queued jobs: %v", err)
+		}
+
+		for _, job := range claimed {
+			if ctx.Err() != nil {
+				// Stopping: the remaining jobs run again once their lease expires
+				return
+			}
+			// A job that has started runs to completion, so shutdown never interrupts a handler mid-write
+			jobCtx := context.WithoutCancel(ctx)
+			envelope, err := router.Dispatch(jobCtx, job.Body)
+			if err != nil {
+				if !errors.Is(err, ErrMalformedJob) {
+					log.Printf("[Consumer] Handler error for %s: %v (retrying after %s)", envelope, err, q.options.Lease)
+					continue
+				}
+				log.Printf("[Consumer] Dropping job that cannot be processed: %v", err)
+			} else {
+				log.Printf("[Consumer] Successfully processed %s", envelope)
+			}
+
+			if err := q.store.DeleteQueuedJob(jobCtx, job.ID); err != nil {
+				// The job runs again after the lease; handlers are idempotent
+				log.Printf("[Consumer] Failed to acknowledge job %s: %v", job.ID.Hex(), err)
+			}
+		}
+
+		if len(claimed) < q.options.BatchSize {
+			return
+		}
+	}
+}
+
+// Close is a no-op; the queue holds no connection of its own.
+func (q *InProcessQueue) Close() error {
+	return nil
+}
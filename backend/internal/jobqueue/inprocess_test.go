@@ -0,0 +1,74 @@
+package jobqueue
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/yourusername/cron-observer/backend/internal/repositories"
+)
+
+func TestInProcessQueue_RunsStoredJobsAndRetriesFailures(t *testing.T) {
+	repo := repositories.NewMemoryRepository()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Published before the consumer starts, as if left over from before a restart
+	if err := NewInProcessQueue(repo, InProcessOptions{}).Publish(ctx, archiveJob{ProjectID: "stored"}); err != nil {
+		t.Fatalf("Publish: %v", err)
+	}
+
+	queue := NewInProcessQueue(repo, InProcessOptions{PollInterval: 10 * time.Millisecond, Lease: 20 * time.Millisecond})
+	var mu sync.Mutex
+	runs := make(map[string]int)
+	router := NewRouter()
+	Register(router, func(ctx context.Context, job archiveJob) error {
+		mu.Lock()
+		defer mu.Unlock()
+		runs[job.ProjectID]++
+		if job.ProjectID == "flaky" && runs[job.ProjectID] == 1 {
+			return errors.New("temporary failure")
+		}
+		return nil
+	})
+
+	done := make(chan error, 1)
+	go func() { done <- queue.Start(ctx, router) }()
+	if err := queue.Publish(ctx, archiveJob{ProjectID: "flaky"}); err != nil {
+		t.Fatalf("Publish: %v", err)
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		mu.Lock()
+		finished := runs["stored"] == 1 && runs["flaky"] == 2
+		mu.Unlock()
+		if finished || time.Now().After(deadline) {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	cancel()
+	if err := <-done; !errors.Is(err, context.Canceled) {
+		t.Fatalf("Start returned %v, want context.Canceled", err)
+	}
+
+	remaining, err := repo.ClaimQueuedJobs(context.Background(), time.Now().Add(time.Hour), time.Minute, 10)
+	if err != nil {
+		t.Fatalf("ClaimQueuedJobs: %v", err)
+	}
+	if len(remaining) != 0 {
+		t.Errorf("%d jobs left in the store, want 0", len(remaining))
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if runs["stored"] != 1 {
+		t.Errorf("stored job ran %d times, want 1", runs["stored"])
+	}
+	if runs["flaky"] != 2 {
+		t.Errorf("failing job ran %d times, want 2 (one retry)", runs["flaky"])
+	}
+}
@@ -0,0 +1,148 @@
+// Package jobqueue runs background jobs through a message broker. Jobs are published in a typed Envelope;
+// consumers pass each envelope to the handler registered for its type on a Router.
+package jobqueue
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// JobType identifies the kind of a job and selects its handler
+type JobType string
+
+// Job is a message that can be published to the job queue
+type Job interface {
+	JobType() JobType
+}
+
+// OrderedJob is a job that must not run concurrently with, or out of order relative to, other jobs with the same
+// ordering key, such as two deletes of the same task
+type OrderedJob interface {
+	Job
+	OrderingKey() string
+}
+
+// Envelope is the wire format of a job: its type and metadata around the JSON-encoded job
+type Envelope struct {
+	Type        JobType         `json:"type"`
+	ID          string          `json:"id"`
+	Key         string          `json:"key,omitempty"` // Ordering key of an OrderedJob
+	PublishedAt time.Time       `json:"published_at"`
+	Payload     json.RawMessage `json:"payload"`
+}
+
+// NewEnvelope wraps a job in an envelope with a new ID
+func NewEnvelope(job Job) (*Envelope, error) {
+	payload, err := json.Marshal(job)
+	if err != nil {
+		return nil, err
+	}
+	envelope := &Envelope{
+		Type:        job.JobType(),
+		ID:          uuid.New().String(),
+		PublishedAt: time.Now(),
+		Payload:     payload,
+	}
+	if ordered, ok := job.(OrderedJob); ok {
+		envelope.Key = ordered.OrderingKey()
+	}
+	return envelope, nil
+}
+
+// orderingKey reads the ordering key of an encoded envelope; empty if it has none or cannot be decoded
+func orderingKey(body []byte) string {
+	var envelope struct {
+		Key string `json:"key"`
+	}
+	_ = json.Unmarshal(body, &envelope)
+	return envelope.Key
+}
+
+// Publisher is a broker-agnostic interface for publishing jobs.
+// Implementations may use RabbitMQ, SQS, Redis, or any other message broker.
+type Publisher interface {
+	Publish(ctx context.Context, job Job) error
+}
+
+// Consumer takes jobs off a queue and passes them to the router until ctx is cancelled
+type Consumer interface {
+	Start(ctx context.Context, router *Router) error
+	Close() error
+}
+
+// Handler processes the payload of one job. Returning nil acks the message; a non-nil error triggers
+// broker retry/DLQ. Errors wrapping ErrMalformedJob are not retried.
+type Handler func(ctx context.Context, payload json.RawMessage) error
+
+// ErrMalformedJob is returned for messages that can never be processed: undecodable or of an unknown type
+var ErrMalformedJob = errors.New("malformed job message")
+
+// Router maps job types to their handlers
+type Router struct {
+	handlers    map[JobType]Handler
+	unenveloped Handler
+}
+
+// NewRouter creates a router without handlers
+func NewRouter() *Router {
+	return &Router{handlers: make(map[JobType]Handler)}
+}
+
+// Handle registers the handler of a job type, replacing any previous one
+func (r *Router) Handle(jobType JobType, handler Handler) {
+	r.handlers[jobType] = handler
+}
+
+// HandleUnenveloped registers the handler of messages without an envelope, i.e. published before a job type
+// moved to the job queue. It receives the whole message body.
+func (r *Router) HandleUnenveloped(handler Handler) {
+	r.unenveloped = handler
+}
+
+// Register registers a typed handler for jobs of type T
+func Register[T Job](r *Router, handle func(ctx context.Context, job T) error) {
+	var zero T
+	r.Handle(zero.JobType(), func(ctx context.Context, payload json.RawMessage) error {
+		var job T
+		if err := json.Unmarshal(payload, &job); err != nil {
+			return fmt.Errorf("%w: %v", ErrMalformedJob, err)
+		}
+		return handle(ctx, job)
+	})
+}
+
+// Dispatch decodes a message and passes it to the handler of its type.
+// It returns the envelope for logging; unenveloped messages return an envelope with only the payload set.
+func (r *Router) Dispatch(ctx context.Context, body []byte) (*Envelope, error) {
+	var envelope Envelope
+	if err := json.Unmarshal(body, &envelope); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrMalformedJob, err)
+	}
+
+	if envelope.Type == "" {
+		if r.unenveloped == nil {
+			return nil, fmt.Errorf("%w: message has no job type", ErrMalformedJob)
+		}
+		envelope.Payload = body
+		return &envelope, r.unenveloped(ctx, body)
+	}
+
+	handler, ok := r.handlers[envelope.Type]
+	if !ok {
+		return &envelope, fmt.Errorf("%w: unknown job type %q", ErrMalformedJob, envelope.Type)
+	}
+	return &envelope, handler(ctx, envelope.Payload)
+}
+
+// String describes the envelope for log lines
+func (e *Envelope) String() string {
+	if e.Type == "" {
+		return "unenveloped job"
+	}
+	return fmt.Sprintf("%s job %s", e.Type, e.ID)
+}
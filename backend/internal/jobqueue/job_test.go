@@ -0,0 +1,104 @@
+package jobqueue
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+type archiveJob struct {
+	ProjectID string `json:"project_id"`
+}
+
+func (archiveJob) JobType() JobType { return "archive_project" }
+
+func envelopeBody(t *testing.T, job Job) []byte {
+	t.Helper()
+	envelope, err := NewEnvelope(job)
+	if err != nil {
+		t.Fatalf("NewEnvelope: %v", err)
+	}
+	body, err := json.Marshal(envelope)
+	if err != nil {
+		t.Fatalf("marshal envelope: %v", err)
+	}
+	return body
+}
+
+func TestRouter_DispatchesByType(t *testing.T) {
+	router := NewRouter()
+	var got archiveJob
+	Register(router, func(ctx context.Context, job archiveJob) error {
+		got = job
+		return nil
+	})
+
+	envelope, err := router.Dispatch(context.Background(), envelopeBody(t, archiveJob{ProjectID: "p1"}))
+	if err != nil {
+		t.Fatalf("Dispatch: %v", err)
+	}
+	if got.ProjectID != "p1" || envelope.Type != "archive_project" || envelope.ID == "" {
+		t.Fatalf("handled %+v from %+v", got, envelope)
+	}
+}
+
+func TestRouter_HandlerErrorsAreReturned(t *testing.T) {
+	router := NewRouter()
+	failure := errors.New("database unavailable")
+	Register(router, func(ctx context.Context, job archiveJob) error { return failure })
+
+	if _, err := router.Dispatch(context.Background(), envelopeBody(t, archiveJob{})); !errors.Is(err, failure) || errors.Is(err, ErrMalformedJob) {
+		t.Fatalf("Dispatch error = %v, want the handler's error", err)
+	}
+}
+
+func TestRouter_RejectsUnprocessableMessages(t *testing.T) {
+	router := NewRouter()
+
+	for name, body := range map[string][]byte{
+		"not json":     []byte("{"),
+		"unknown type": envelopeBody(t, archiveJob{}),
+		"no envelope":  []byte(`{"project_id":"p1"}`),
+	} {
+		if _, err := router.Dispatch(context.Background(), body); !errors.Is(err, ErrMalformedJob) {
+			t.Errorf("%s: Dispatch error = %v, want ErrMalformedJob", name, err)
+		}
+	}
+}
+
+func TestRouter_UnenvelopedMessagesGoToTheirHandler(t *testing.T) {
+	router := NewRouter()
+	var got string
+	router.HandleUnenveloped(func(ctx context.Context, body json.RawMessage) error {
+		got = string(body)
+		return nil
+	})
+
+	if _, err := router.Dispatch(context.Background(), []byte(`{"project_id":"p1"}`)); err != nil {
+		t.Fatalf("Dispatch: %v", err)
+	}
+	if got != `{"project_id":"p1"}` {
+		t.Fatalf("unenveloped handler got %s", got)
+	}
+}
+
+type orderedArchiveJob struct {
+	ProjectID string `json:"project_id"`
+}
+
+func (orderedArchiveJob) JobType() JobType { return "archive_project" }
+
+func (j orderedArchiveJob) OrderingKey() string { return j.ProjectID }
+
+func TestNewEnvelope_SetsOrderingKey(t *testing.T) {
+	if key := orderingKey(envelopeBody(t, orderedArchiveJob{ProjectID: "p1"})); key != "p1" {
+		t.Errorf("ordering key = %q, want p1", key)
+	}
+	if key := orderingKey(envelopeBody(t, archiveJob{ProjectID: "p1"})); key != "" {
+		t.Errorf("ordering key of an unordered job = %q, want empty", key)
+	}
+	if key := orderingKey([]byte("not json")); key != "" {
+		t.Errorf("ordering key of a malformed message = %q, want empty", key)
+	}
+}
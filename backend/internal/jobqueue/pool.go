@@ -0,0 +1,56 @@
+package jobqueue
+
+import (
+	"hash/fnv"
+	"sync"
+)
+
+// keyedPool runs work on a fixed number of workers. Work with the same key always runs on the same worker, one
+// item after another and in submission order; work without a key is spread round-robin.
+type keyedPool struct {
+	queues []chan func()
+	next   int
+	wg     sync.WaitGroup
+}
+
+// newKeyedPool starts the workers; each buffers up to buffer items before submit blocks
+func newKeyedPool(workers, buffer int) *keyedPool {
+	if workers < 1 {
+		workers = 1
+	}
+	p := &keyedPool{queues: make([]chan func(), workers)}
+	for i := range p.queues {
+		queue := make(chan func(), buffer)
+		p.queues[i] = queue
+		p.wg.Add(1)
+		go func() {
+			defer p.wg.Done()
+			for work := range queue {
+				work()
+			}
+		}()
+	}
+	return p
+}
+
+// submit hands the work to its worker, blocking while that worker's buffer is full. Not safe for concurrent use.
+func (p *keyedPool) submit(key string, work func()) {
+	var worker int
+	if key == "" {
+		worker = p.next
+		p.next = (p.next + 1) % len(p.queues)
+	} else {
+		hash := fnv.New32a()
+		hash.Write([]byte(key))
+		worker = int(hash.Sum32() % uint32(len(p.queues)))
+	}
+	p.queues[worker] <- work
+}
+
+// stop waits for the submitted work to finish and stops the workers
+func (p *keyedPool) stop() {
+	for _, queue := range p.queues {
+		close(queue)
+	}
+	p.wg.Wait()
+}
@@ -0,0 +1,52 @@
+package jobqueue
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestKeyedPool_RunsSameKeyInOrder(t *testing.T) {
+	pool := newKeyedPool(4, 100)
+	var mu sync.Mutex
+	var order []int
+	for i := 0; i < 50; i++ {
+		pool.submit("task-1", func() {
+			mu.Lock()
+			defer mu.Unlock()
+			order = append(order, i)
+		})
+	}
+	pool.stop()
+
+	if len(order) != 50 {
+		t.Fatalf("ran %d items, want 50", len(order))
+	}
+	for i, got := range order {
+		if got != i {
+			t.Fatalf("item %d ran at position %d; same-key work must keep its order", got, i)
+		}
+	}
+}
+
+func TestKeyedPool_RunsUnkeyedWorkInParallel(t *testing.T) {
+	pool := newKeyedPool(3, 3)
+	started := make(chan struct{}, 3)
+	release := make(chan struct{})
+	for i := 0; i < 3; i++ {
+		pool.submit("", func() {
+			started <- struct{}{}
+			<-release
+		})
+	}
+
+	for i := 0; i < 3; i++ {
+		select {
+		case <-started:
+		case <-time.After(2 * time.Second):
+			t.Fatalf("only %d of 3 items started; workers are not running in parallel", i)
+		}
+	}
+	close(release)
+	pool.stop()
+}
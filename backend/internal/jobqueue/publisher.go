@@ -0,0 +1,123 @@
+package jobqueue
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+// publishConfirmTimeout bounds the wait for the broker to confirm a publish when ctx has no earlier deadline
+const publishConfirmTimeout = 5 * time.Second
+
+// ErrPublishNotConfirmed is returned when RabbitMQ rejects (nacks) a published job, so it was not stored
+var ErrPublishNotConfirmed = errors.New("job not confirmed by RabbitMQ")
+
+// RabbitMQPublisher implements Publisher using RabbitMQ. It reconnects when the broker restarts; publishes
+// fail with ErrNotConnected until the connection is back.
+type RabbitMQPublisher struct {
+	session   *RabbitMQSession
+	queueName string
+}
+
+// NewRabbitMQPublisher creates a new RabbitMQ publisher.
+// Connects to RabbitMQ at the given URL and declares the queue.
+func NewRabbitMQPublisher(amqpURL, queueName string) (*RabbitMQPublisher, error) {
+	session, err := NewRabbitMQSession(amqpURL, func(ch *amqp.Channel) error {
+		// Declare queue (idempotent: creates if not exists, same as consumer)
+		_, err := ch.QueueDeclare(
+			queueName, // name
+			true,      // durable
+			false,     // delete when unused
+			false,     // exclusive
+			false,     // no-wait
+			nil,       // arguments
+		)
+		if err != nil {
+			return err
+		}
+
+		// Publisher confirms: the broker acks each message once it has taken responsibility for it
+		// (written to disk for persistent messages on a durable queue)
+		return ch.Confirm(false)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &RabbitMQPublisher{
+		session:   session,
+		queueName: queueName,
+	}, nil
+}
+
+// QueueName returns the name of the queue jobs are published to
+func (p *RabbitMQPublisher) QueueName() string {
+	return p.queueName
+}
+
+// Publish wraps the job in an envelope, publishes it as a persistent message and waits for the broker to
+// confirm it. An error means the job may not have been stored and must not be reported as queued.
+func (p *RabbitMQPublisher) Publish(ctx context.Context, job Job) error {
+	envelope, err := NewEnvelope(job)
+	if err != nil {
+		return err
+	}
+	body, err := json.Marshal(envelope)
+	if err != nil {
+		return err
+	}
+
+	ch, err := p.session.Channel()
+	if err != nil {
+		return err
+	}
+
+	// Publish to queue
+	return publishConfirmed(ctx, ch, p.queueName, amqp.Publishing{
+		ContentType:  "application/json",
+		Type:         string(envelope.Type),
+		MessageId:    envelope.ID,
+		Body:         body,
+		DeliveryMode: amqp.Persistent, // Make message persistent
+		// Why persistent for jobs?
+		// Jobs such as deletes are critical: if lost, tasks may remain in PENDING_DELETE indefinitely
+		// Reliability: survives RabbitMQ restarts
+		// Consistency: matches the durable queue (durable: true)
+	})
+}
+
+// publishConfirmed publishes to a queue through the default exchange on a channel in confirm mode and waits
+// for the broker's confirmation
+func publishConfirmed(ctx context.Context, ch *amqp.Channel, queueName string, msg amqp.Publishing) error {
+	confirmation, err := ch.PublishWithDeferredConfirmWithContext(
+		ctx,
+		"",        // exchange (empty = default/direct exchange)
+		queueName, // routing key (queue name)
+		false,     // mandatory
+		false,     // immediate
+		msg,
+	)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, publishConfirmTimeout)
+	defer cancel()
+	acked, err := confirmation.WaitContext(ctx)
+	if err != nil {
+		return fmt.Errorf("waiting for RabbitMQ to confirm job %s: %w", msg.MessageId, err)
+	}
+	if !acked {
+		return fmt.Errorf("%w: job %s", ErrPublishNotConfirmed, msg.MessageId)
+	}
+	return nil
+}
+
+// Close closes the RabbitMQ connection and channel.
+func (p *RabbitMQPublisher) Close() error {
+	return p.session.Close()
+}
@@ -0,0 +1,166 @@
+package jobqueue
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"errors"
+	"io"
+	"net"
+	"testing"
+	"time"
+)
+
+// confirmMode is how the fake broker answers a publish
+type confirmMode int
+
+const (
+	confirmAck confirmMode = iota
+	confirmNack
+	confirmNever
+)
+
+// startFakeBroker serves just enough of AMQP 0-9-1 for a RabbitMQPublisher to connect, put its channel in
+// confirm mode and publish, answering every publish as mode says. It returns the URL to connect to.
+func startFakeBroker(t *testing.T, mode confirmMode) string {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	t.Cleanup(func() { ln.Close() })
+
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go serveFakeBroker(conn, mode)
+		}
+	}()
+	return "amqp://guest:guest@" + ln.Addr().String() + "/"
+}
+
+func serveFakeBroker(conn net.Conn, mode confirmMode) {
+	defer conn.Close()
+	r := bufio.NewReader(conn)
+
+	header := make([]byte, 8)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return
+	}
+	// connection.start: version 0-9, no server properties, PLAIN authentication
+	writeMethod(conn, 0, 10, 10, []byte{0, 9}, table(), longstr("PLAIN"), longstr("en_US"))
+
+	var deliveryTag uint64
+	for {
+		frameType, channel, payload, err := readFrame(r)
+		if err != nil {
+			return
+		}
+		if frameType != 1 { // content header, body and heartbeat frames carry nothing to answer
+			continue
+		}
+
+		class, method := binary.BigEndian.Uint16(payload[0:2]), binary.BigEndian.Uint16(payload[2:4])
+		switch {
+		case class == 10 && method == 11: // connection.start-ok
+			writeMethod(conn, 0, 10, 30, short(0), long(131072), short(0))
+		case class == 10 && method == 40: // connection.open
+			writeMethod(conn, 0, 10, 41, shortstr(""))
+		case class == 10 && method == 50: // connection.close
+			writeMethod(conn, 0, 10, 51)
+			return
+		case class == 20 && method == 10: // channel.open
+			writeMethod(conn, channel, 20, 11, longstr(""))
+		case class == 20 && method == 40: // channel.close
+			writeMethod(conn, channel, 20, 41)
+		case class == 50 && method == 10: // queue.declare: reserved short, then the queue name
+			name := string(payload[7 : 7+int(payload[6])])
+			writeMethod(conn, channel, 50, 11, shortstr(name), long(0), long(0))
+		case class == 85 && method == 10: // confirm.select
+			writeMethod(conn, channel, 85, 11)
+		case class == 60 && method == 40: // basic.publish
+			deliveryTag++
+			switch mode {
+			case confirmAck:
+				writeMethod(conn, channel, 60, 80, longlong(deliveryTag), []byte{0})
+			case confirmNack:
+				writeMethod(conn, channel, 60, 120, longlong(deliveryTag), []byte{0})
+			}
+		}
+	}
+}
+
+func readFrame(r *bufio.Reader) (frameType byte, channel uint16, payload []byte, err error) {
+	header := make([]byte, 7)
+	if _, err = io.ReadFull(r, header); err != nil {
+		return 0, 0, nil, err
+	}
+	payload = make([]byte, binary.BigEndian.Uint32(header[3:7])+1) // and the frame-end octet
+	if _, err = io.ReadFull(r, payload); err != nil {
+		return 0, 0, nil, err
+	}
+	return header[0], binary.BigEndian.Uint16(header[1:3]), payload[:len(payload)-1], nil
+}
+
+func writeMethod(w io.Writer, channel uint16, class, method uint16, args ...[]byte) {
+	payload := append(short(class), short(method)...)
+	for _, arg := range args {
+		payload = append(payload, arg...)
+	}
+	frame := append([]byte{1}, short(channel)...)
+	frame = append(frame, long(uint32(len(payload)))...)
+	frame = append(frame, payload...)
+	w.Write(append(frame, 0xCE))
+}
+
+func short(v uint16) []byte    { return binary.BigEndian.AppendUint16(nil, v) }
+func long(v uint32) []byte     { return binary.BigEndian.AppendUint32(nil, v) }
+func longlong(v uint64) []byte { return binary.BigEndian.AppendUint64(nil, v) }
+func shortstr(s string) []byte { return append([]byte{byte(len(s))}, s...) }
+func longstr(s string) []byte  { return append(long(uint32(len(s))), s...) }
+func table() []byte            { return long(0) }
+
+func newTestPublisher(t *testing.T, mode confirmMode) *RabbitMQPublisher {
+	t.Helper()
+	publisher, err := NewRabbitMQPublisher(startFakeBroker(t, mode), "jobs")
+	if err != nil {
+		t.Fatalf("NewRabbitMQPublisher: %v", err)
+	}
+	t.Cleanup(func() { publisher.Close() })
+	return publisher
+}
+
+func TestRabbitMQPublisher_Publish_SucceedsWhenConfirmed(t *testing.T) {
+	publisher := newTestPublisher(t, confirmAck)
+
+	if err := publisher.Publish(context.Background(), archiveJob{ProjectID: "project-1"}); err != nil {
+		t.Fatalf("Expected the confirmed publish to succeed, got %v", err)
+	}
+}
+
+func TestRabbitMQPublisher_Publish_FailsWhenNacked(t *testing.T) {
+	publisher := newTestPublisher(t, confirmNack)
+
+	err := publisher.Publish(context.Background(), archiveJob{ProjectID: "project-1"})
+	if !errors.Is(err, ErrPublishNotConfirmed) {
+		t.Fatalf("Expected ErrPublishNotConfirmed, got %v", err)
+	}
+}
+
+func TestRabbitMQPublisher_Publish_FailsWhenConfirmTimesOut(t *testing.T) {
+	publisher := newTestPublisher(t, confirmNever)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	err := publisher.Publish(ctx, archiveJob{ProjectID: "project-1"})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Expected the wait for a confirmation to time out, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > publishConfirmTimeout {
+		t.Errorf("Expected Publish to give up at the context deadline, took %s", elapsed)
+	}
+}
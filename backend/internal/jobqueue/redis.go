@@ -0,0 +1,286 @@
+package jobqueue
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	// redisBodyField is the stream entry field holding the JSON envelope
+	redisBodyField = "body"
+
+	// redisReadBlock bounds how long XREADGROUP waits for a job, and so how long a stopped consumer takes to return
+	redisReadBlock = 5 * time.Second
+
+	// redisReadErrorBackoff is how long the consumer waits after a failed XREADGROUP call
+	redisReadErrorBackoff = 5 * time.Second
+
+	// redisReclaimBatch is how many pending entries are inspected per reclaim
+	redisReclaimBatch = 100
+)
+
+// RedisStreamsOptions configures the Redis Streams publisher and consumer
+type RedisStreamsOptions struct {
+	URL    string // redis://[user:password@]host:port/db
+	Stream string
+
+	// Group is the consumer group shared by all replicas; each job is delivered to one of its consumers
+	Group string
+
+	// Consumer names this replica within the group. Defaults to hostname and process ID.
+	Consumer string
+
+	// ClaimMinIdle is how long a job stays pending (delivered but not acked) before it is reclaimed and retried,
+	// either after a handler error or because the consumer that received it died
+	ClaimMinIdle time.Duration
+
+	// MaxDeliveries moves a job to the dead-letter stream after it has been delivered this many times
+	MaxDeliveries int64
+
+	// DeadLetterStream receives jobs that can never be processed or that exhausted their deliveries
+	DeadLetterStream string
+}
+
+func newRedisClient(ctx context.Context, redisURL string) (*redis.Client, error) {
+	opts, err := redis.ParseURL(redisURL)
+	if err != nil {
+		return nil, err
+	}
+	client := redis.NewClient(opts)
+	if err := client.Ping(ctx).Err(); err != nil {
+		client.Close()
+		return nil, err
+	}
+	return client, nil
+}
+
+// RedisStreamsPublisher implements Publisher using a Redis stream.
+type RedisStreamsPublisher struct {
+	client *redis.Client
+	stream string
+}
+
+// NewRedisStreamsPublisher connects to Redis and publishes to the stream in opts
+func NewRedisStreamsPublisher(ctx context.Context, opts RedisStreamsOptions) (*RedisStreamsPublisher, error) {
+	client, err := newRedisClient(ctx, opts.URL)
+	if err != nil {
+		return nil, err
+	}
+	return &RedisStreamsPublisher{client: client, stream: opts.Stream}, nil
+}
+
+// Publish wraps the job in an envelope and appends it to the stream
+func (p *RedisStreamsPublisher) Publish(ctx context.Context, job Job) error {
+	envelope, err := NewEnvelope(job)
+	if err != nil {
+		return err
+	}
+	body, err := json.Marshal(envelope)
+	if err != nil {
+		return err
+	}
+	return p.client.XAdd(ctx, &redis.XAddArgs{
+		Stream: p.stream,
+		Values: map[string]interface{}{"type": string(envelope.Type), redisBodyField: body},
+	}).Err()
+}
+
+// Close closes the Redis connection.
+func (p *RedisStreamsPublisher) Close() error {
+	return p.client.Close()
+}
+
+// RedisStreamsConsumer consumes jobs from a Redis stream through a consumer group.
+type RedisStreamsConsumer struct {
+	client *redis.Client
+	opts   RedisStreamsOptions
+}
+
+// NewRedisStreamsConsumer connects to Redis and creates the consumer group (and stream) if they do not exist
+func NewRedisStreamsConsumer(ctx context.Context, opts RedisStreamsOptions) (*RedisStreamsConsumer, error) {
+	if opts.Consumer == "" {
+		hostname, _ := os.Hostname()
+		opts.Consumer = fmt.Sprintf("%s-%d", hostname, os.Getpid())
+	}
+	if opts.DeadLetterStream == "" {
+		opts.DeadLetterStream = opts.Stream + ":dead"
+	}
+
+	client, err := newRedisClient(ctx, opts.URL)
+	if err != nil {
+		return nil, err
+	}
+	// Start at "0" so jobs published before the group existed are processed too
+	err = client.XGroupCreateMkStream(ctx, opts.Stream, opts.Group, "0").Err()
+	if err != nil && !strings.HasPrefix(err.Error(), "BUSYGROUP") {
+		client.Close()
+		return nil, err
+	}
+	return &RedisStreamsConsumer{client: client, opts: opts}, nil
+}
+
+// Start reads new jobs for this consumer and passes them to the router.
+// Acks (and removes) an entry when the handler returns nil. On error the entry stays pending and is reclaimed
+// once it has been idle for ClaimMinIdle, by this or any other consumer of the group.
+// Runs until ctx is cancelled.
+func (c *RedisStreamsConsumer) Start(ctx context.Context, router *Router) error {
+	log.Printf("[jobqueue] Redis Streams consumer %s started for stream: %s (group %s)", c.opts.Consumer, c.opts.Stream, c.opts.Group)
+
+	var lastReclaim time.Time
+	for {
+		if ctx.Err() != nil {
+			log.Printf("[jobqueue] Consumer context cancelled, stopping")
+			return ctx.Err()
+		}
+
+		// Check for stale pending jobs a few times per idle period
+		if time.Since(lastReclaim) >= c.opts.ClaimMinIdle/4 {
+			c.reclaim(ctx, router)
+			lastReclaim = time.Now()
+		}
+
+		streams, err := c.client.XReadGroup(ctx, &redis.XReadGroupArgs{
+			Group:    c.opts.Group,
+			Consumer: c.opts.Consumer,
+			Streams:  []string{c.opts.Stream, ">"},
+			Count:    1, // One job at a time, like the RabbitMQ consumer's prefetch of 1
+			Block:    redisReadBlock,
+		}).Result()
+		if errors.Is(err, redis.Nil) {
+			continue
+		}
+		if err != nil {
+			if ctx.Err() != nil {
+				continue
+			}
+			log.Printf("[jobqueue] Failed to read from Redis stream: %v (retrying in %s)", err, redisReadErrorBackoff)
+			select {
+			case <-ctx.Done():
+			case <-time.After(redisReadErrorBackoff):
+			}
+			continue
+		}
+
+		for _, stream := range streams {
+			for _, msg := range stream.Messages {
+				// A job that has been read runs to completion, so shutdown never interrupts a handler mid-write
+				c.process(context.WithoutCancel(ctx), router, msg)
+			}
+		}
+	}
+}
+
+// reclaim takes over jobs that have been pending for at least ClaimMinIdle and retries them, or moves them to
+// the dead-letter stream once they have been delivered MaxDeliveries times
+func (c *RedisStreamsConsumer) reclaim(ctx context.Context, router *Router) {
+	pending, err := c.client.XPendingExt(ctx, &redis.XPendingExtArgs{
+		Stream: c.opts.Stream,
+		Group:  c.opts.Group,
+		Start:  "-",
+		End:    "+",
+		Count:  redisReclaimBatch,
+	}).Result()
+	if err != nil {
+		log.Printf("[jobqueue] Failed to list pending jobs: %v", err)
+		return
+	}
+
+	for _, entry := range pending {
+		if ctx.Err() != nil {
+			return
+		}
+		if entry.Idle < c.opts.ClaimMinIdle {
+			continue
+		}
+		// XCLAIM re-checks the idle time, so only one consumer wins each entry; it also counts a delivery
+		msgs, err := c.client.XClaim(ctx, &redis.XClaimArgs{
+			Stream:   c.opts.Stream,
+			Group:    c.opts.Group,
+			Consumer: c.opts.Consumer,
+			MinIdle:  c.opts.ClaimMinIdle,
+			Messages: []string{entry.ID},
+		}).Result()
+		if err != nil {
+			log.Printf("[jobqueue] Failed to claim pending job %s: %v", entry.ID, err)
+			continue
+		}
+		for _, msg := range msgs {
+			if c.opts.MaxDeliveries > 0 && entry.RetryCount >= c.opts.MaxDeliveries {
+				log.Printf("[Consumer] Job %s failed %d deliveries, moving it to %s", msg.ID, entry.RetryCount, c.opts.DeadLetterStream)
+				c.deadLetter(ctx, msg, "max deliveries exceeded")
+				continue
+			}
+			log.Printf("[Consumer] Retrying job %s (delivery %d)", msg.ID, entry.RetryCount+1)
+			c.process(context.WithoutCancel(ctx), router, msg)
+		}
+	}
+}
+
+// process dispatches one entry and acks it unless the handler asked for a retry
+func (c *RedisStreamsConsumer) process(ctx context.Context, router *Router, msg redis.XMessage) {
+	envelope, err := router.Dispatch(ctx, redisMessageBody(msg))
+	if err != nil {
+		if errors.Is(err, ErrMalformedJob) {
+			log.Printf("[Consumer] Dropping job that cannot be processed: %v", err)
+			c.deadLetter(ctx, msg, err.Error())
+			return
+		}
+		log.Printf("[Consumer] Handler error for %s: %v (will retry after %s)", envelope, err, c.opts.ClaimMinIdle)
+		return
+	}
+
+	c.ack(ctx, msg.ID)
+	log.Printf("[Consumer] Successfully processed %s", envelope)
+}
+
+// deadLetter copies the entry to the dead-letter stream and removes it from the job stream
+func (c *RedisStreamsConsumer) deadLetter(ctx context.Context, msg redis.XMessage, reason string) {
+	values := make(map[string]interface{}, len(msg.Values)+2)
+	for field, value := range msg.Values {
+		values[field] = value
+	}
+	values["original_id"] = msg.ID
+	values["error"] = reason
+	if err := c.client.XAdd(ctx, &redis.XAddArgs{Stream: c.opts.DeadLetterStream, Values: values}).Err(); err != nil {
+		// Keep the entry pending; the next reclaim tries again
+		log.Printf("[Consumer] Failed to move job %s to the dead-letter stream: %v", msg.ID, err)
+		return
+	}
+	c.ack(ctx, msg.ID)
+}
+
+// ack acknowledges the entry and deletes it, so the stream does not grow without bound
+func (c *RedisStreamsConsumer) ack(ctx context.Context, id string) {
+	_, err := c.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.XAck(ctx, c.opts.Stream, c.opts.Group, id)
+		pipe.XDel(ctx, c.opts.Stream, id)
+		return nil
+	})
+	if err != nil {
+		// The entry is reclaimed and processed again; handlers are idempotent
+		log.Printf("[Consumer] Failed to ack job %s: %v", id, err)
+	}
+}
+
+// Close closes the Redis connection.
+func (c *RedisStreamsConsumer) Close() error {
+	return c.client.Close()
+}
+
+// redisMessageBody returns the envelope stored in a stream entry, or nil (which the router rejects as
+// malformed) when the entry has no body field
+func redisMessageBody(msg redis.XMessage) []byte {
+	body, _ := msg.Values[redisBodyField].(string)
+	if body == "" {
+		return nil
+	}
+	return []byte(body)
+}
@@ -0,0 +1,44 @@
+package jobqueue
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/redis/go-redis/v9"
+)
+
+func TestRedisMessageBody_RoutesEnvelope(t *testing.T) {
+	router := NewRouter()
+	var got archiveJob
+	Register(router, func(ctx context.Context, job archiveJob) error {
+		got = job
+		return nil
+	})
+
+	msg := redis.XMessage{ID: "1-0", Values: map[string]interface{}{
+		"type":         "archive_project",
+		redisBodyField: string(envelopeBody(t, archiveJob{ProjectID: "p1"})),
+	}}
+	if _, err := router.Dispatch(context.Background(), redisMessageBody(msg)); err != nil {
+		t.Fatalf("Dispatch: %v", err)
+	}
+	if got.ProjectID != "p1" {
+		t.Errorf("ProjectID = %q, want p1", got.ProjectID)
+	}
+}
+
+func TestRedisMessageBody_EntryWithoutBodyIsMalformed(t *testing.T) {
+	msg := redis.XMessage{ID: "1-0", Values: map[string]interface{}{"type": "archive_project"}}
+
+	_, err := NewRouter().Dispatch(context.Background(), redisMessageBody(msg))
+	if !errors.Is(err, ErrMalformedJob) {
+		t.Fatalf("err = %v, want ErrMalformedJob", err)
+	}
+}
+
+func TestNewRedisStreamsPublisher_InvalidURL(t *testing.T) {
+	if _, err := NewRedisStreamsPublisher(context.Background(), RedisStreamsOptions{URL: "not a url", Stream: "jobs"}); err == nil {
+		t.Fatal("expected an error for an invalid Redis URL")
+	}
+}
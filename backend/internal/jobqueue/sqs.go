@@ -0,0 +1,250 @@
+package jobqueue
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/sqs"
+	"github.com/aws/aws-sdk-go-v2/service/sqs/types"
+)
+
+const (
+	// sqsWaitTimeSeconds is the long-poll duration of ReceiveMessage, the maximum SQS allows
+	sqsWaitTimeSeconds = 20
+
+	// sqsReceiveErrorBackoff is how long the consumer waits after a failed ReceiveMessage call
+	sqsReceiveErrorBackoff = 5 * time.Second
+
+	// sqsDefaultVisibilityTimeout is the visibility timeout of received jobs when none is configured, the SQS default
+	sqsDefaultVisibilityTimeout = 30 * time.Second
+)
+
+// SQSOptions configures the SQS publisher and consumer
+type SQSOptions struct {
+	QueueURL string
+	Region   string
+
+	// DeadLetterQueueURL receives messages that can never be processed. Optional; without it they are deleted.
+	// Jobs that keep failing are moved to a dead-letter queue by the queue's own redrive policy.
+	DeadLetterQueueURL string
+
+	// VisibilityTimeout hides a received job from other consumers. It is extended while the job runs, so it only
+	// bounds how long a job of a consumer that died stays hidden.
+	VisibilityTimeout time.Duration
+
+	// RetryDelay hides a failed job before it is received again
+	RetryDelay time.Duration
+}
+
+// newSQSClient creates an SQS client for the queue's endpoint, so queue URLs of SQS-compatible services work too.
+// Credentials come from the AWS SDK's default chain: environment variables, shared config and credentials files,
+// web identity tokens, and ECS or EC2 instance roles.
+func newSQSClient(queueURL, region string) (*sqs.Client, error) {
+	parsed, err := url.Parse(queueURL)
+	if err != nil || parsed.Scheme == "" || parsed.Host == "" {
+		return nil, fmt.Errorf("invalid SQS queue URL %q", queueURL)
+	}
+	cfg, err := config.LoadDefaultConfig(context.Background(), config.WithRegion(region))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load AWS configuration: %w", err)
+	}
+	return sqs.NewFromConfig(cfg, func(o *sqs.Options) {
+		o.BaseEndpoint = aws.String(parsed.Scheme + "://" + parsed.Host)
+	}), nil
+}
+
+// SQSPublisher implements Publisher using AWS SQS.
+type SQSPublisher struct {
+	client   *sqs.Client
+	queueURL string
+}
+
+// NewSQSPublisher creates a publisher for the queue. Credentials come from the AWS SDK's default chain.
+func NewSQSPublisher(opts SQSOptions) (*SQSPublisher, error) {
+	client, err := newSQSClient(opts.QueueURL, opts.Region)
+	if err != nil {
+		return nil, err
+	}
+	return &SQSPublisher{client: client, queueURL: opts.QueueURL}, nil
+}
+
+// Publish wraps the job in an envelope and sends it to the queue
+func (p *SQSPublisher) Publish(ctx context.Context, job Job) error {
+	envelope, err := NewEnvelope(job)
+	if err != nil {
+		return err
+	}
+	body, err := json.Marshal(envelope)
+	if err != nil {
+		return err
+	}
+	_, err = p.client.SendMessage(ctx, &sqs.SendMessageInput{
+		QueueUrl:    aws.String(p.queueURL),
+		MessageBody: aws.String(string(body)),
+	})
+	return err
+}
+
+// Close is a no-op; SQS requests do not hold a connection.
+func (p *SQSPublisher) Close() error {
+	return nil
+}
+
+// SQSConsumer consumes jobs from an AWS SQS queue.
+type SQSConsumer struct {
+	client      *sqs.Client
+	opts        SQSOptions
+	extendEvery time.Duration // how often the visibility timeout of a running job is extended
+}
+
+// NewSQSConsumer creates a consumer for the queue. Credentials come from the AWS SDK's default chain.
+func NewSQSConsumer(opts SQSOptions) (*SQSConsumer, error) {
+	if opts.VisibilityTimeout < time.Second {
+		opts.VisibilityTimeout = sqsDefaultVisibilityTimeout
+	}
+	client, err := newSQSClient(opts.QueueURL, opts.Region)
+	if err != nil {
+		return nil, err
+	}
+	// Extending halfway through leaves the other half for the request to get through
+	return &SQSConsumer{client: client, opts: opts, extendEvery: opts.VisibilityTimeout / 2}, nil
+}
+
+// Start long-polls the queue and passes each message to the router.
+// Deletes a message when the handler returns nil. On error the message is hidden for RetryDelay and then
+// received again, until the queue's redrive policy moves it to the dead-letter queue.
+// Runs until ctx is cancelled.
+func (c *SQSConsumer) Start(ctx context.Context, router *Router) error {
+	log.Printf("[jobqueue] SQS consumer started for queue: %s", c.opts.QueueURL)
+
+	for {
+		if ctx.Err() != nil {
+			log.Printf("[jobqueue] Consumer context cancelled, stopping")
+			return ctx.Err()
+		}
+
+		out, err := c.client.ReceiveMessage(ctx, &sqs.ReceiveMessageInput{
+			QueueUrl:            aws.String(c.opts.QueueURL),
+			MaxNumberOfMessages: 1, // One job at a time, like the RabbitMQ consumer's prefetch of 1
+			WaitTimeSeconds:     sqsWaitTimeSeconds,
+			VisibilityTimeout:   int32(c.opts.VisibilityTimeout / time.Second),
+		})
+		if err != nil {
+			if ctx.Err() != nil {
+				continue
+			}
+			log.Printf("[jobqueue] Failed to receive from SQS: %v (retrying in %s)", err, sqsReceiveErrorBackoff)
+			select {
+			case <-ctx.Done():
+			case <-time.After(sqsReceiveErrorBackoff):
+			}
+			continue
+		}
+
+		for _, msg := range out.Messages {
+			if ctx.Err() != nil {
+				// Stopping: the remaining messages are received again after the visibility timeout
+				break
+			}
+			// A job that has started runs to completion, so shutdown never interrupts a handler mid-write
+			c.process(context.WithoutCancel(ctx), router, msg)
+		}
+	}
+}
+
+// process dispatches one message and settles it with SQS
+func (c *SQSConsumer) process(ctx context.Context, router *Router, msg types.Message) {
+	stopExtending := c.extendVisibility(ctx, msg)
+	envelope, err := router.Dispatch(ctx, []byte(aws.ToString(msg.Body)))
+	stopExtending()
+
+	if err != nil {
+		if errors.Is(err, ErrMalformedJob) {
+			log.Printf("[Consumer] Dropping job that cannot be processed: %v", err)
+			if c.opts.DeadLetterQueueURL != "" {
+				_, err := c.client.SendMessage(ctx, &sqs.SendMessageInput{
+					QueueUrl:    aws.String(c.opts.DeadLetterQueueURL),
+					MessageBody: msg.Body,
+				})
+				if err != nil {
+					// Leave the message on the queue; the redrive policy moves it once it is received often enough
+					log.Printf("[Consumer] Failed to move message %s to the dead-letter queue: %v", aws.ToString(msg.MessageId), err)
+					return
+				}
+			}
+			c.delete(ctx, msg)
+			return
+		}
+		log.Printf("[Consumer] Handler error for %s: %v (will retry)", envelope, err)
+		if err := c.changeVisibility(ctx, msg, c.opts.RetryDelay); err != nil {
+			// The message is received again once the visibility timeout expires
+			log.Printf("[Consumer] Failed to schedule retry of message %s: %v", aws.ToString(msg.MessageId), err)
+		}
+		return
+	}
+
+	c.delete(ctx, msg)
+	log.Printf("[Consumer] Successfully processed %s", envelope)
+}
+
+// extendVisibility keeps the message hidden from other consumers while its job runs, so a job that outlasts the
+// visibility timeout is not received and run a second time. The returned function stops extending and waits until
+// a pending extension is done, so it cannot override the visibility set once the job is settled.
+func (c *SQSConsumer) extendVisibility(ctx context.Context, msg types.Message) func() {
+	done := make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		ticker := time.NewTicker(c.extendEvery)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				if err := c.changeVisibility(ctx, msg, c.opts.VisibilityTimeout); err != nil {
+					// Keep trying; the job may run twice if the timeout expires meanwhile, handlers are idempotent
+					log.Printf("[Consumer] Failed to extend visibility of message %s: %v", aws.ToString(msg.MessageId), err)
+				}
+			}
+		}
+	}()
+	return func() {
+		close(done)
+		wg.Wait()
+	}
+}
+
+func (c *SQSConsumer) changeVisibility(ctx context.Context, msg types.Message, timeout time.Duration) error {
+	_, err := c.client.ChangeMessageVisibility(ctx, &sqs.ChangeMessageVisibilityInput{
+		QueueUrl:          aws.String(c.opts.QueueURL),
+		ReceiptHandle:     msg.ReceiptHandle,
+		VisibilityTimeout: int32(timeout / time.Second),
+	})
+	return err
+}
+
+func (c *SQSConsumer) delete(ctx context.Context, msg types.Message) {
+	_, err := c.client.DeleteMessage(ctx, &sqs.DeleteMessageInput{
+		QueueUrl:      aws.String(c.opts.QueueURL),
+		ReceiptHandle: msg.ReceiptHandle,
+	})
+	if err != nil {
+		// The message is redelivered after the visibility timeout; handlers are idempotent
+		log.Printf("[Consumer] Failed to delete message %s: %v", aws.ToString(msg.MessageId), err)
+	}
+}
+
+// Close is a no-op; SQS requests do not hold a connection.
+func (c *SQSConsumer) Close() error {
+	return nil
+}
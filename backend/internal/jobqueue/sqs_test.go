@@ -0,0 +1,223 @@
+package jobqueue
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeSQSMessage is a message as the SQS JSON API returns it
+type fakeSQSMessage struct {
+	MessageID     string `json:"MessageId"`
+	ReceiptHandle string `json:"ReceiptHandle"`
+	Body          string `json:"Body"`
+}
+
+// fakeSQS serves the SQS JSON API for one queue and a dead-letter queue
+type fakeSQS struct {
+	mu         sync.Mutex
+	queue      []fakeSQSMessage
+	dlq        []string
+	deleted    []string
+	retried    map[string]int   // last visibility timeout set per receipt handle
+	visibility map[string][]int // every visibility timeout set per receipt handle
+	sent       int
+	received   int
+}
+
+func (f *fakeSQS) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 ") {
+		w.WriteHeader(http.StatusForbidden)
+		json.NewEncoder(w).Encode(map[string]string{"__type": "com.amazon.coral.service#MissingAuthenticationTokenException", "message": "unsigned"})
+		return
+	}
+	var in map[string]any
+	json.NewDecoder(r.Body).Decode(&in)
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	switch strings.TrimPrefix(r.Header.Get("X-Amz-Target"), "AmazonSQS.") {
+	case "SendMessage":
+		if strings.HasSuffix(in["QueueUrl"].(string), "/dlq") {
+			f.dlq = append(f.dlq, in["MessageBody"].(string))
+		} else {
+			id := string(rune('a' + f.sent))
+			f.sent++
+			f.queue = append(f.queue, fakeSQSMessage{MessageID: id, ReceiptHandle: "rh-" + id, Body: in["MessageBody"].(string)})
+		}
+		w.Write([]byte(`{}`))
+	case "ReceiveMessage":
+		var out struct {
+			Messages []fakeSQSMessage `json:"Messages"`
+		}
+		if len(f.queue) > 0 {
+			out.Messages = f.queue[:1]
+			f.queue = f.queue[1:]
+			f.received++
+		}
+		json.NewEncoder(w).Encode(out)
+	case "DeleteMessage":
+		f.deleted = append(f.deleted, in["ReceiptHandle"].(string))
+		w.Write([]byte(`{}`))
+	case "ChangeMessageVisibility":
+		handle, timeout := in["ReceiptHandle"].(string), int(in["VisibilityTimeout"].(float64))
+		f.retried[handle] = timeout
+		f.visibility[handle] = append(f.visibility[handle], timeout)
+		w.Write([]byte(`{}`))
+	default:
+		w.WriteHeader(http.StatusBadRequest)
+	}
+}
+
+func TestSQS_PublishAndConsume(t *testing.T) {
+	t.Setenv("AWS_ACCESS_KEY_ID", "AKIDEXAMPLE")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "secret")
+	fake := &fakeSQS{retried: make(map[string]int), visibility: make(map[string][]int)}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	opts := SQSOptions{
+		QueueURL:           server.URL + "/123456789012/jobs",
+		Region:             "us-east-1",
+		DeadLetterQueueURL: server.URL + "/123456789012/dlq",
+		VisibilityTimeout:  5 * time.Minute,
+		RetryDelay:         30 * time.Second,
+	}
+	publisher, err := NewSQSPublisher(opts)
+	if err != nil {
+		t.Fatalf("NewSQSPublisher: %v", err)
+	}
+	ctx := context.Background()
+	for _, projectID := range []string{"ok", "fail"} {
+		if err := publisher.Publish(ctx, archiveJob{ProjectID: projectID}); err != nil {
+			t.Fatalf("Publish: %v", err)
+		}
+	}
+	fake.mu.Lock()
+	fake.queue = append(fake.queue, fakeSQSMessage{MessageID: "bad", ReceiptHandle: "rh-bad", Body: `not json`})
+	fake.mu.Unlock()
+
+	consumer, err := NewSQSConsumer(opts)
+	if err != nil {
+		t.Fatalf("NewSQSConsumer: %v", err)
+	}
+	router := NewRouter()
+	runCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	var handled []string
+	Register(router, func(ctx context.Context, job archiveJob) error {
+		handled = append(handled, job.ProjectID)
+		if job.ProjectID == "fail" {
+			return errors.New("temporary failure")
+		}
+		return nil
+	})
+
+	done := make(chan error, 1)
+	go func() { done <- consumer.Start(runCtx, router) }()
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		fake.mu.Lock()
+		settled := len(fake.deleted) == 2 && len(fake.retried) == 1
+		fake.mu.Unlock()
+		if settled || time.Now().After(deadline) {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	cancel()
+	if err := <-done; !errors.Is(err, context.Canceled) {
+		t.Fatalf("Start returned %v, want context.Canceled", err)
+	}
+
+	if len(handled) != 2 || handled[0] != "ok" || handled[1] != "fail" {
+		t.Errorf("handled = %v, want [ok fail]", handled)
+	}
+	if len(fake.deleted) != 2 || fake.deleted[0] != "rh-a" || fake.deleted[1] != "rh-bad" {
+		t.Errorf("deleted = %v, want the successful and the malformed message", fake.deleted)
+	}
+	if fake.retried["rh-b"] != 30 {
+		t.Errorf("failed message visibility = %d, want the 30s retry delay", fake.retried["rh-b"])
+	}
+	if len(fake.dlq) != 1 || fake.dlq[0] != "not json" {
+		t.Errorf("dead-letter queue = %v, want the malformed message", fake.dlq)
+	}
+}
+
+func TestSQSConsumer_ExtendsVisibilityWhileJobRuns(t *testing.T) {
+	t.Setenv("AWS_ACCESS_KEY_ID", "AKIDEXAMPLE")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "secret")
+	fake := &fakeSQS{retried: make(map[string]int), visibility: make(map[string][]int)}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	opts := SQSOptions{
+		QueueURL:          server.URL + "/123456789012/jobs",
+		Region:            "us-east-1",
+		VisibilityTimeout: 5 * time.Minute,
+		RetryDelay:        30 * time.Second,
+	}
+	publisher, err := NewSQSPublisher(opts)
+	if err != nil {
+		t.Fatalf("NewSQSPublisher: %v", err)
+	}
+	if err := publisher.Publish(context.Background(), archiveJob{ProjectID: "slow"}); err != nil {
+		t.Fatalf("Publish: %v", err)
+	}
+
+	consumer, err := NewSQSConsumer(opts)
+	if err != nil {
+		t.Fatalf("NewSQSConsumer: %v", err)
+	}
+	consumer.extendEvery = 20 * time.Millisecond
+	router := NewRouter()
+	Register(router, func(ctx context.Context, job archiveJob) error {
+		// Outlasts several extension intervals
+		time.Sleep(150 * time.Millisecond)
+		return nil
+	})
+
+	runCtx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- consumer.Start(runCtx, router) }()
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		fake.mu.Lock()
+		settled := len(fake.deleted) == 1
+		fake.mu.Unlock()
+		if settled || time.Now().After(deadline) {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	cancel()
+	<-done
+
+	fake.mu.Lock()
+	defer fake.mu.Unlock()
+	if len(fake.deleted) != 1 {
+		t.Fatalf("deleted = %v, want the finished job", fake.deleted)
+	}
+	extensions := fake.visibility["rh-a"]
+	if len(extensions) < 2 {
+		t.Fatalf("visibility changes = %v, want the timeout extended while the job ran", extensions)
+	}
+	for _, timeout := range extensions {
+		if timeout != 300 {
+			t.Errorf("visibility changes = %v, want each to extend by the 300s visibility timeout", extensions)
+			break
+		}
+	}
+}
+
+func TestNewSQSPublisher_RejectsInvalidQueueURL(t *testing.T) {
+	if _, err := NewSQSPublisher(SQSOptions{QueueURL: "jobs", Region: "us-east-1"}); err == nil {
+		t.Fatal("expected an error for a queue URL without scheme and host")
+	}
+}
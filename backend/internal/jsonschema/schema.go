@@ -0,0 +1,498 @@
+// Package jsonschema validates JSON values against a practical subset of JSON Schema (draft 2020-12).
+//
+// Supported keywords: type, enum, const, properties, required, additionalProperties, minProperties,
+// maxProperties, items, minItems, maxItems, uniqueItems, minLength, maxLength, pattern, minimum, maximum,
+// exclusiveMinimum, exclusiveMaximum, multipleOf, allOf, anyOf, oneOf and not. Annotations such as title,
+// description, default, examples, format, $schema, $id and $comment are accepted and ignored. Schemas using
+// any other keyword (for example $ref) are rejected by Compile so they never silently pass everything.
+package jsonschema
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// annotationKeywords carry no validation semantics and are ignored
+var annotationKeywords = map[string]bool{
+	"$schema": true, "$id": true, "$comment": true, "title": true, "description": true,
+	"default": true, "examples": true, "format": true, "deprecated": true, "readOnly": true, "writeOnly": true,
+}
+
+var validTypes = map[string]bool{
+	"null": true, "boolean": true, "object": true, "array": true, "number": true, "integer": true, "string": true,
+}
+
+// Schema is a compiled JSON Schema
+type Schema struct {
+	alwaysValid bool // true schema; a false schema is represented by not: {}
+	types       []string
+	enum        []interface{}
+	constValue  interface{}
+	hasConst    bool
+
+	properties           map[string]*Schema
+	required             []string
+	additionalProperties *Schema
+	minProperties        *int
+	maxProperties        *int
+
+	items       *Schema
+	minItems    *int
+	maxItems    *int
+	uniqueItems bool
+
+	minLength *int
+	maxLength *int
+	pattern   *regexp.Regexp
+
+	minimum          *float64
+	maximum          *float64
+	exclusiveMinimum *float64
+	exclusiveMaximum *float64
+	multipleOf       *float64
+
+	allOf []*Schema
+	anyOf []*Schema
+	oneOf []*Schema
+	not   *Schema
+}
+
+// ValidationError describes one way a value does not match a schema
+type ValidationError struct {
+	Path    string // JSON pointer of the offending value; empty for the root
+	Message string
+}
+
+func (e ValidationError) String() string {
+	if e.Path == "" {
+		return e.Message
+	}
+	return e.Path + ": " + e.Message
+}
+
+// Compile parses a JSON Schema document
+func Compile(raw []byte) (*Schema, error) {
+	var doc interface{}
+	if err := json.Unmarshal(raw, &doc); err != nil {
+		return nil, fmt.Errorf("schema is not valid JSON: %w", err)
+	}
+	return compile(doc, "")
+}
+
+func compile(doc interface{}, path string) (*Schema, error) {
+	switch v := doc.(type) {
+	case bool:
+		if v {
+			return &Schema{alwaysValid: true}, nil
+		}
+		return &Schema{not: &Schema{alwaysValid: true}}, nil
+	case map[string]interface{}:
+		return compileObject(v, path)
+	default:
+		return nil, fmt.Errorf("%s: schema must be an object or a boolean", pathOrRoot(path))
+	}
+}
+
+func compileObject(doc map[string]interface{}, path string) (*Schema, error) {
+	s := &Schema{}
+
+	keys := make([]string, 0, len(doc))
+	for key := range doc {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		value := doc[key]
+		keyPath := path + "/" + key
+		var err error
+
+		switch key {
+		case "type":
+			s.types, err = compileTypes(value, keyPath)
+		case "enum":
+			values, ok := value.([]interface{})
+			if !ok {
+				return nil, fmt.Errorf("%s: must be an array", keyPath)
+			}
+			s.enum = values
+		case "const":
+			s.constValue = value
+			s.hasConst = true
+		case "properties":
+			props, ok := value.(map[string]interface{})
+			if !ok {
+				return nil, fmt.Errorf("%s: must be an object", keyPath)
+			}
+			s.properties = make(map[string]*Schema, len(props))
+			for name, propDoc := range props {
+				if s.properties[name], err = compile(propDoc, keyPath+"/"+name); err != nil {
+					return nil, err
+				}
+			}
+		case "required":
+			s.required, err = compileStrings(value, keyPath)
+		case "additionalProperties":
+			s.additionalProperties, err = compile(value, keyPath)
+		case "items":
+			s.items, err = compile(value, keyPath)
+		case "minProperties":
+			s.minProperties, err = compileCount(value, keyPath)
+		case "maxProperties":
+			s.maxProperties, err = compileCount(value, keyPath)
+		case "minItems":
+			s.minItems, err = compileCount(value, keyPath)
+		case "maxItems":
+			s.maxItems, err = compileCount(value, keyPath)
+		case "minLength":
+			s.minLength, err = compileCount(value, keyPath)
+		case "maxLength":
+			s.maxLength, err = compileCount(value, keyPath)
+		case "uniqueItems":
+			unique, ok := value.(bool)
+			if !ok {
+				return nil, fmt.Errorf("%s: must be a boolean", keyPath)
+			}
+			s.uniqueItems = unique
+		case "pattern":
+			pattern, ok := value.(string)
+			if !ok {
+				return nil, fmt.Errorf("%s: must be a string", keyPath)
+			}
+			if s.pattern, err = regexp.Compile(pattern); err != nil {
+				return nil, fmt.Errorf("%s: invalid regular expression: %v", keyPath, err)
+			}
+		case "minimum":
+			s.minimum, err = compileNumber(value, keyPath)
+		case "maximum":
+			s.maximum, err = compileNumber(value, keyPath)
+		case "exclusiveMinimum":
+			s.exclusiveMinimum, err = compileNumber(value, keyPath)
+		case "exclusiveMaximum":
+			s.exclusiveMaximum, err = compileNumber(value, keyPath)
+		case "multipleOf":
+			if s.multipleOf, err = compileNumber(value, keyPath); err == nil && *s.multipleOf <= 0 {
+				err = fmt.Errorf("%s: must be greater than 0", keyPath)
+			}
+		case "allOf":
+			s.allOf, err = compileList(value, keyPath)
+		case "anyOf":
+			s.anyOf, err = compileList(value, keyPath)
+		case "oneOf":
+			s.oneOf, err = compileList(value, keyPath)
+		case "not":
+			s.not, err = compile(value, keyPath)
+		default:
+			if !annotationKeywords[key] {
+				return nil, fmt.Errorf("%s: unsupported keyword", keyPath)
+			}
+		}
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return s, nil
+}
+
+func compileTypes(value interface{}, path string) ([]string, error) {
+	var types []string
+	switch v := value.(type) {
+	case string:
+		types = []string{v}
+	case []interface{}:
+		for _, item := range v {
+			name, ok := item.(string)
+			if !ok {
+				return nil, fmt.Errorf("%s: must contain strings", path)
+			}
+			types = append(types, name)
+		}
+	default:
+		return nil, fmt.Errorf("%s: must be a string or an array of strings", path)
+	}
+
+	for _, name := range types {
+		if !validTypes[name] {
+			return nil, fmt.Errorf("%s: unknown type %q", path, name)
+		}
+	}
+	return types, nil
+}
+
+func compileStrings(value interface{}, path string) ([]string, error) {
+	items, ok := value.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("%s: must be an array of strings", path)
+	}
+	result := make([]string, 0, len(items))
+	for _, item := range items {
+		str, ok := item.(string)
+		if !ok {
+			return nil, fmt.Errorf("%s: must be an array of strings", path)
+		}
+		result = append(result, str)
+	}
+	return result, nil
+}
+
+func compileCount(value interface{}, path string) (*int, error) {
+	number, ok := value.(float64)
+	if !ok || number < 0 || number != math.Trunc(number) {
+		return nil, fmt.Errorf("%s: must be a non-negative integer", path)
+	}
+	count := int(number)
+	return &count, nil
+}
+
+func compileNumber(value interface{}, path string) (*float64, error) {
+	number, ok := value.(float64)
+	if !ok {
+		return nil, fmt.Errorf("%s: must be a number", path)
+	}
+	return &number, nil
+}
+
+func compileList(value interface{}, path string) ([]*Schema, error) {
+	items, ok := value.([]interface{})
+	if !ok || len(items) == 0 {
+		return nil, fmt.Errorf("%s: must be a non-empty array of schemas", path)
+	}
+	schemas := make([]*Schema, 0, len(items))
+	for i, item := range items {
+		schema, err := compile(item, fmt.Sprintf("%s/%d", path, i))
+		if err != nil {
+			return nil, err
+		}
+		schemas = append(schemas, schema)
+	}
+	return schemas, nil
+}
+
+func pathOrRoot(path string) string {
+	if path == "" {
+		return "schema"
+	}
+	return path
+}
+
+// Validate checks a value against the schema. The value may be anything encoding/json can marshal,
+// including BSON documents read back from MongoDB; it is normalized to its JSON form first.
+func (s *Schema) Validate(value interface{}) ([]ValidationError, error) {
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return nil, fmt.Errorf("value cannot be represented as JSON: %w", err)
+	}
+	var normalized interface{}
+	if err := json.Unmarshal(raw, &normalized); err != nil {
+		return nil, err
+	}
+
+	var errs []ValidationError
+	s.validate(normalized, "", &errs)
+	return errs, nil
+}
+
+func (s *Schema) validate(value interface{}, path string, errs *[]ValidationError) {
+	if s.alwaysValid {
+		return
+	}
+	fail := func(format string, args ...interface{}) {
+		*errs = append(*errs, ValidationError{Path: path, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if len(s.types) > 0 && !matchesAnyType(value, s.types) {
+		fail("expected %s, got %s", strings.Join(s.types, " or "), typeOf(value))
+		return
+	}
+	if s.enum != nil && !containsValue(s.enum, value) {
+		fail("must be one of the allowed values")
+	}
+	if s.hasConst && !reflect.DeepEqual(s.constValue, value) {
+		fail("must equal the constant value")
+	}
+
+	switch v := value.(type) {
+	case map[string]interface{}:
+		s.validateObject(v, path, errs)
+	case []interface{}:
+		s.validateArray(v, path, errs)
+	case string:
+		length := utf8.RuneCountInString(v)
+		if s.minLength != nil && length < *s.minLength {
+			fail("must be at least %d characters", *s.minLength)
+		}
+		if s.maxLength != nil && length > *s.maxLength {
+			fail("must be at most %d characters", *s.maxLength)
+		}
+		if s.pattern != nil && !s.pattern.MatchString(v) {
+			fail("must match pattern %s", s.pattern.String())
+		}
+	case float64:
+		if s.minimum != nil && v < *s.minimum {
+			fail("must be >= %v", *s.minimum)
+		}
+		if s.maximum != nil && v > *s.maximum {
+			fail("must be <= %v", *s.maximum)
+		}
+		if s.exclusiveMinimum != nil && v <= *s.exclusiveMinimum {
+			fail("must be > %v", *s.exclusiveMinimum)
+		}
+		if s.exclusiveMaximum != nil && v >= *s.exclusiveMaximum {
+			fail("must be < %v", *s.exclusiveMaximum)
+		}
+		if s.multipleOf != nil {
+			quotient := v / *s.multipleOf
+			if math.Abs(quotient-math.Round(quotient)) > 1e-9 {
+				fail("must be a multiple of %v", *s.multipleOf)
+			}
+		}
+	}
+
+	for _, sub := range s.allOf {
+		sub.validate(value, path, errs)
+	}
+	if len(s.anyOf) > 0 && countMatches(s.anyOf, value) == 0 {
+		fail("must match at least one schema in anyOf")
+	}
+	if len(s.oneOf) > 0 && countMatches(s.oneOf, value) != 1 {
+		fail("must match exactly one schema in oneOf")
+	}
+	if s.not != nil && s.not.matches(value) {
+		fail("must not match the schema in not")
+	}
+}
+
+func (s *Schema) validateObject(object map[string]interface{}, path string, errs *[]ValidationError) {
+	for _, name := range s.required {
+		if _, ok := object[name]; !ok {
+			*errs = append(*errs, ValidationError{Path: path, Message: fmt.Sprintf("missing required property %q", name)})
+		}
+	}
+	if s.minProperties != nil && len(object) < *s.minProperties {
+		*errs = append(*errs, ValidationError{Path: path, Message: fmt.Sprintf("must have at least %d properties", *s.minProperties)})
+	}
+	if s.maxProperties != nil && len(object) > *s.maxProperties {
+		*errs = append(*errs, ValidationError{Path: path, Message: fmt.Sprintf("must have at most %d properties", *s.maxProperties)})
+	}
+
+	names := make([]string, 0, len(object))
+	for name := range object {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		propPath := path + "/" + escapePointer(name)
+		if prop, ok := s.properties[name]; ok {
+			prop.validate(object[name], propPath, errs)
+			continue
+		}
+		if s.additionalProperties != nil {
+			if s.additionalProperties.matches(object[name]) {
+				continue
+			}
+			if s.additionalProperties.not != nil && s.additionalProperties.not.alwaysValid {
+				*errs = append(*errs, ValidationError{Path: propPath, Message: "property is not allowed"})
+				continue
+			}
+			s.additionalProperties.validate(object[name], propPath, errs)
+		}
+	}
+}
+
+func (s *Schema) validateArray(items []interface{}, path string, errs *[]ValidationError) {
+	if s.minItems != nil && len(items) < *s.minItems {
+		*errs = append(*errs, ValidationError{Path: path, Message: fmt.Sprintf("must have at least %d items", *s.minItems)})
+	}
+	if s.maxItems != nil && len(items) > *s.maxItems {
+		*errs = append(*errs, ValidationError{Path: path, Message: fmt.Sprintf("must have at most %d items", *s.maxItems)})
+	}
+	if s.uniqueItems {
+		for i := range items {
+			for j := i + 1; j < len(items); j++ {
+				if reflect.DeepEqual(items[i], items[j]) {
+					*errs = append(*errs, ValidationError{Path: path, Message: "items must be unique"})
+					i = len(items)
+					break
+				}
+			}
+		}
+	}
+	if s.items != nil {
+		for i, item := range items {
+			s.items.validate(item, fmt.Sprintf("%s/%d", path, i), errs)
+		}
+	}
+}
+
+// matches reports whether the value is valid without collecting errors
+func (s *Schema) matches(value interface{}) bool {
+	var errs []ValidationError
+	s.validate(value, "", &errs)
+	return len(errs) == 0
+}
+
+func countMatches(schemas []*Schema, value interface{}) int {
+	count := 0
+	for _, schema := range schemas {
+		if schema.matches(value) {
+			count++
+		}
+	}
+	return count
+}
+
+func matchesAnyType(value interface{}, types []string) bool {
+	actual := typeOf(value)
+	for _, name := range types {
+		if name == actual || (name == "number" && actual == "integer") {
+			return true
+		}
+	}
+	return false
+}
+
+// typeOf returns the JSON Schema type of a decoded JSON value; whole numbers report "integer"
+func typeOf(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case map[string]interface{}:
+		return "object"
+	case []interface{}:
+		return "array"
+	case string:
+		return "string"
+	case float64:
+		if v == math.Trunc(v) && !math.IsInf(v, 0) {
+			return "integer"
+		}
+		return "number"
+	default:
+		return fmt.Sprintf("%T", value)
+	}
+}
+
+func containsValue(values []interface{}, value interface{}) bool {
+	for _, candidate := range values {
+		if reflect.DeepEqual(candidate, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// escapePointer escapes a property name for use in a JSON pointer
+func escapePointer(name string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(name, "~", "~0"), "/", "~1")
+}
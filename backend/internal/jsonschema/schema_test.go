@@ -0,0 +1,78 @@
+package jsonschema
+
+import (
+	"strings"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const ownerSchema = `{
+	"type": "object",
+	"required": ["owner"],
+	"properties": {
+		"owner": {"type": "string", "pattern": "^team:[a-z]+$"},
+		"retries": {"type": "integer", "minimum": 0, "maximum": 5},
+		"channels": {"type": "array", "items": {"enum": ["email", "slack"]}, "uniqueItems": true}
+	},
+	"additionalProperties": false
+}`
+
+func TestSchema_ValidateAcceptsMatchingValue(t *testing.T) {
+	schema, err := Compile([]byte(ownerSchema))
+	if err != nil {
+		t.Fatalf("Expected schema to compile, got: %v", err)
+	}
+
+	// BSON documents read back from MongoDB validate like decoded JSON
+	value := primitive.M{"owner": "team:payments", "retries": int32(3), "channels": primitive.A{"email", "slack"}}
+	errs, err := schema.Validate(value)
+	if err != nil {
+		t.Fatalf("Expected nil error, got: %v", err)
+	}
+	if len(errs) != 0 {
+		t.Errorf("Expected no violations, got %v", errs)
+	}
+}
+
+func TestSchema_ValidateReportsViolationsWithPaths(t *testing.T) {
+	schema, err := Compile([]byte(ownerSchema))
+	if err != nil {
+		t.Fatalf("Expected schema to compile, got: %v", err)
+	}
+
+	value := map[string]interface{}{
+		"retries":  2.5,
+		"channels": []interface{}{"email", "pager"},
+		"extra":    true,
+	}
+	errs, err := schema.Validate(value)
+	if err != nil {
+		t.Fatalf("Expected nil error, got: %v", err)
+	}
+
+	var got []string
+	for _, validationErr := range errs {
+		got = append(got, validationErr.String())
+	}
+	joined := strings.Join(got, "\n")
+	for _, expected := range []string{
+		`missing required property "owner"`,
+		"/retries: expected integer, got number",
+		"/channels/1: must be one of the allowed values",
+		"/extra: property is not allowed",
+	} {
+		if !strings.Contains(joined, expected) {
+			t.Errorf("Expected violation %q, got:\n%s", expected, joined)
+		}
+	}
+}
+
+func TestCompile_RejectsUnsupportedKeywords(t *testing.T) {
+	if _, err := Compile([]byte(`{"properties": {"owner": {"$ref": "#/$defs/owner"}}}`)); err == nil {
+		t.Error("Expected $ref to be rejected")
+	}
+	if _, err := Compile([]byte(`{"type": "text"}`)); err == nil {
+		t.Error("Expected unknown type to be rejected")
+	}
+}
@@ -0,0 +1,100 @@
+// Package logging filters the output of the standard logger by level. The backend logs with the log package and no
+// explicit levels, so the level of a line is derived from its text: lines mentioning an error, a failure or a panic
+// are errors, lines mentioning a warning are warnings and everything else is info.
+package logging
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"log"
+	"strings"
+	"sync/atomic"
+)
+
+// Level is the minimum level of the lines written
+type Level int32
+
+const (
+	LevelInfo Level = iota
+	LevelWarn
+	LevelError
+)
+
+var levelNames = map[Level]string{
+	LevelInfo:  "info",
+	LevelWarn:  "warn",
+	LevelError: "error",
+}
+
+func (l Level) String() string {
+	return levelNames[l]
+}
+
+// ParseLevel parses a level name from configuration (LOG_LEVEL)
+func ParseLevel(value string) (Level, error) {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "", "info":
+		return LevelInfo, nil
+	case "warn", "warning":
+		return LevelWarn, nil
+	case "error":
+		return LevelError, nil
+	}
+	return LevelInfo, fmt.Errorf("invalid log level %q: use info, warn or error", value)
+}
+
+var level atomic.Int32
+
+// SetLevel sets the minimum level of the lines written. Safe to call while logging.
+func SetLevel(l Level) {
+	level.Store(int32(l))
+}
+
+// CurrentLevel returns the minimum level of the lines written
+func CurrentLevel() Level {
+	return Level(level.Load())
+}
+
+// Install makes the standard logger write through a level filter to its current output
+func Install() {
+	log.SetOutput(NewWriter(log.Writer()))
+}
+
+// NewWriter returns a writer that drops the lines below the current level and writes the others to out
+func NewWriter(out io.Writer) io.Writer {
+	return &filterWriter{out: out}
+}
+
+type filterWriter struct {
+	out io.Writer
+}
+
+// Write receives one line per call from the standard logger
+func (w *filterWriter) Write(p []byte) (int, error) {
+	if classify(p) < CurrentLevel() {
+		return len(p), nil
+	}
+	return w.out.Write(p)
+}
+
+var (
+	errorMarkers = [][]byte{[]byte("error"), []byte("fail"), []byte("panic")}
+	warnMarkers  = [][]byte{[]byte("warn")}
+)
+
+// classify derives the level of a log line from its text
+func classify(line []byte) Level {
+	lower := bytes.ToLower(line)
+	for _, marker := range errorMarkers {
+		if bytes.Contains(lower, marker) {
+			return LevelError
+		}
+	}
+	for _, marker := range warnMarkers {
+		if bytes.Contains(lower, marker) {
+			return LevelWarn
+		}
+	}
+	return LevelInfo
+}
@@ -0,0 +1,51 @@
+package logging
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestParseLevel(t *testing.T) {
+	tests := map[string]Level{"": LevelInfo, "info": LevelInfo, "WARN": LevelWarn, "warning": LevelWarn, " error ": LevelError}
+	for value, want := range tests {
+		got, err := ParseLevel(value)
+		if err != nil || got != want {
+			t.Errorf("ParseLevel(%q) = %v, %v, want %v", value, got, err, want)
+		}
+	}
+	if _, err := ParseLevel("verbose"); err == nil {
+		t.Error("expected an error for an unknown level")
+	}
+}
+
+func TestWriter_FiltersByLevel(t *testing.T) {
+	defer SetLevel(LevelInfo)
+
+	lines := []string{
+		"[Consumer] Job processed\n",
+		"[jobqueue] WARNING: prefetch raised to 4\n",
+		"[Worker] ERROR: Failed to delete task\n",
+		"[AlertService] Failed to send alert email\n",
+	}
+	tests := []struct {
+		level Level
+		want  int
+	}{
+		{LevelInfo, 4},
+		{LevelWarn, 3},
+		{LevelError, 2},
+	}
+	for _, tt := range tests {
+		SetLevel(tt.level)
+		var out bytes.Buffer
+		w := NewWriter(&out)
+		for _, line := range lines {
+			if n, err := w.Write([]byte(line)); err != nil || n != len(line) {
+				t.Fatalf("Write() = %d, %v, want %d, nil", n, err, len(line))
+			}
+		}
+		if got := bytes.Count(out.Bytes(), []byte("\n")); got != tt.want {
+			t.Errorf("level %s wrote %d lines, want %d:\n%s", tt.level, got, tt.want, out.String())
+		}
+	}
+}
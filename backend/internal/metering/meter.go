@@ -0,0 +1,139 @@
+// Package metering records billable usage of projects per UTC day: executions the scheduler starts, alerts sent and
+// log bytes stored. Usage is counted in memory and added to the usage collection periodically, so busy paths such as
+// log appends do not write to the database on every call. Usage counted since the last flush is lost if the process
+// dies without shutting down.
+package metering
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/yourusername/cron-observer/backend/internal/config"
+	"github.com/yourusername/cron-observer/backend/internal/models"
+	"github.com/yourusername/cron-observer/backend/internal/repositories"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// defaultFlushInterval applies when the configured interval is not positive
+const defaultFlushInterval = time.Minute
+
+// finalFlushTimeout bounds the flush when the meter stops
+const finalFlushTimeout = 10 * time.Second
+
+type usageKey struct {
+	projectID primitive.ObjectID
+	date      string
+}
+
+// Meter counts usage and flushes it to the repository. A nil *Meter records nothing, so components work without one.
+type Meter struct {
+	repo     repositories.Repository
+	interval time.Duration
+
+	mu      sync.Mutex
+	pending map[usageKey]models.UsageCounters
+	now     func() time.Time
+}
+
+// NewMeter creates a meter flushing to repo every cfg.FlushInterval once Run is called
+func NewMeter(repo repositories.Repository, cfg config.MeteringConfig) *Meter {
+	interval := cfg.FlushInterval
+	if interval <= 0 {
+		interval = defaultFlushInterval
+	}
+	return &Meter{
+		repo:     repo,
+		interval: interval,
+		pending:  make(map[usageKey]models.UsageCounters),
+		now:      time.Now,
+	}
+}
+
+// RecordExecution counts an execution the scheduler started for the project
+func (m *Meter) RecordExecution(projectID primitive.ObjectID) {
+	m.record(projectID, models.UsageCounters{Executions: 1})
+}
+
+// RecordAlert counts an alert notification sent for the project
+func (m *Meter) RecordAlert(projectID primitive.ObjectID) {
+	m.record(projectID, models.UsageCounters{AlertsSent: 1})
+}
+
+// RecordLogBytes counts log message bytes stored for the project
+func (m *Meter) RecordLogBytes(projectID primitive.ObjectID, bytes int) {
+	if bytes <= 0 {
+		return
+	}
+	m.record(projectID, models.UsageCounters{LogBytes: int64(bytes)})
+}
+
+func (m *Meter) record(projectID primitive.ObjectID, counters models.UsageCounters) {
+	if m == nil {
+		return
+	}
+	key := usageKey{projectID: projectID, date: m.now().UTC().Format("2006-01-02")}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	total := m.pending[key]
+	total.Add(counters)
+	m.pending[key] = total
+}
+
+// Run flushes the usage every interval until ctx is done, then flushes once more
+func (m *Meter) Run(ctx context.Context) error {
+	ticker := time.NewTicker(m.interval)
+	defer ticker.Stop()
+
+	log.Printf("[Metering] Flushing usage every %s", m.interval)
+	for {
+		select {
+		case <-ctx.Done():
+			flushCtx, cancel := context.WithTimeout(context.Background(), finalFlushTimeout)
+			defer cancel()
+			if err := m.Flush(flushCtx); err != nil {
+				log.Printf("[Metering] Failed to flush usage on stop: %v", err)
+			}
+			return nil
+		case <-ticker.C:
+			if err := m.Flush(ctx); err != nil {
+				log.Printf("[Metering] Failed to flush usage: %v", err)
+			}
+		}
+	}
+}
+
+// Flush adds the usage counted since the last flush to the repository. Counters that fail to be written are kept for
+// the next flush, and the last error is returned.
+func (m *Meter) Flush(ctx context.Context) error {
+	if m == nil {
+		return nil
+	}
+	m.mu.Lock()
+	pending := m.pending
+	m.pending = make(map[usageKey]models.UsageCounters)
+	m.mu.Unlock()
+
+	var lastErr error
+	for key, counters := range pending {
+		if counters.IsZero() {
+			continue
+		}
+		if err := m.repo.IncrementUsage(ctx, key.projectID, key.date, counters); err != nil {
+			lastErr = err
+			m.restore(key, counters)
+		}
+	}
+	return lastErr
+}
+
+// restore puts counters that could not be written back, to be written by the next flush
+func (m *Meter) restore(key usageKey, counters models.UsageCounters) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	total := m.pending[key]
+	total.Add(counters)
+	m.pending[key] = total
+}
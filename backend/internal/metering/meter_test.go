@@ -0,0 +1,118 @@
+package metering
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/yourusername/cron-observer/backend/internal/config"
+	"github.com/yourusername/cron-observer/backend/internal/models"
+	"github.com/yourusername/cron-observer/backend/internal/repositories"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// failingRepository fails usage writes until fail is cleared
+type failingRepository struct {
+	repositories.Repository
+	fail bool
+}
+
+func (r *failingRepository) IncrementUsage(ctx context.Context, projectID primitive.ObjectID, date string, counters models.UsageCounters) error {
+	if r.fail {
+		return errors.New("connection reset")
+	}
+	return r.Repository.IncrementUsage(ctx, projectID, date, counters)
+}
+
+func TestMeter_FlushAddsUsagePerProjectAndDay(t *testing.T) {
+	ctx := context.Background()
+	repo := repositories.NewMemoryRepository()
+	meter := NewMeter(repo, config.MeteringConfig{})
+	projectID := primitive.NewObjectID()
+
+	now := time.Date(2025, 1, 15, 23, 59, 0, 0, time.UTC)
+	meter.now = func() time.Time { return now }
+	meter.RecordExecution(projectID)
+	meter.RecordExecution(projectID)
+	meter.RecordLogBytes(projectID, 120)
+	now = now.Add(2 * time.Minute) // the next UTC day
+	meter.RecordAlert(projectID)
+	if err := meter.Flush(ctx); err != nil {
+		t.Fatalf("Flush: %v", err)
+	}
+
+	// A second flush adds to the stored counters
+	meter.RecordLogBytes(projectID, 30)
+	if err := meter.Flush(ctx); err != nil {
+		t.Fatalf("Flush: %v", err)
+	}
+
+	records, err := repo.GetUsage(ctx, models.UsageFilter{ProjectID: &projectID})
+	if err != nil {
+		t.Fatalf("GetUsage: %v", err)
+	}
+	if len(records) != 2 {
+		t.Fatalf("got %d records, want 2", len(records))
+	}
+	if records[0].Date != "2025-01-15" || records[0].UsageCounters != (models.UsageCounters{Executions: 2, LogBytes: 120}) {
+		t.Errorf("first day = %s %+v", records[0].Date, records[0].UsageCounters)
+	}
+	if records[1].Date != "2025-01-16" || records[1].UsageCounters != (models.UsageCounters{AlertsSent: 1, LogBytes: 30}) {
+		t.Errorf("second day = %s %+v", records[1].Date, records[1].UsageCounters)
+	}
+}
+
+func TestMeter_KeepsUsageThatFailedToFlush(t *testing.T) {
+	ctx := context.Background()
+	repo := &failingRepository{Repository: repositories.NewMemoryRepository(), fail: true}
+	meter := NewMeter(repo, config.MeteringConfig{})
+	projectID := primitive.NewObjectID()
+
+	meter.RecordExecution(projectID)
+	if err := meter.Flush(ctx); err == nil {
+		t.Fatal("expected the flush to fail")
+	}
+
+	repo.fail = false
+	meter.RecordExecution(projectID)
+	if err := meter.Flush(ctx); err != nil {
+		t.Fatalf("Flush: %v", err)
+	}
+	records, err := repo.GetUsage(ctx, models.UsageFilter{ProjectID: &projectID})
+	if err != nil {
+		t.Fatalf("GetUsage: %v", err)
+	}
+	if len(records) != 1 || records[0].Executions != 2 {
+		t.Errorf("records = %+v, want 2 executions", records)
+	}
+}
+
+func TestMeter_RunFlushesOnStop(t *testing.T) {
+	repo := repositories.NewMemoryRepository()
+	meter := NewMeter(repo, config.MeteringConfig{FlushInterval: time.Hour})
+	projectID := primitive.NewObjectID()
+	meter.RecordAlert(projectID)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := meter.Run(ctx); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+
+	records, err := repo.GetUsage(context.Background(), models.UsageFilter{})
+	if err != nil {
+		t.Fatalf("GetUsage: %v", err)
+	}
+	if len(records) != 1 || records[0].AlertsSent != 1 {
+		t.Errorf("records = %+v, want the alert flushed", records)
+	}
+}
+
+func TestNilMeter_RecordsNothing(t *testing.T) {
+	var meter *Meter
+	meter.RecordExecution(primitive.NewObjectID())
+	if err := meter.Flush(context.Background()); err != nil {
+		t.Errorf("Flush: %v", err)
+	}
+}
@@ -1,17 +1,33 @@
 package middleware
 
 import (
+	"context"
 	"log"
+	"net"
 	"net/http"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/yourusername/cron-observer/backend/internal/cronexpr"
 	"github.com/yourusername/cron-observer/backend/internal/models"
 	"github.com/yourusername/cron-observer/backend/internal/repositories"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
 )
 
 // ProjectContextKey is the key for storing project info in gin context
 const ProjectContextKey = "project"
 
+// TaskContextKey is the key for storing the task authorized by TaskAPIKeyMiddleware in gin context
+const TaskContextKey = "task"
+
+// ExecutionContextKey is the key for storing the execution authorized by APIKeyMiddleware in gin context
+const ExecutionContextKey = "execution"
+
+// APIKeyScopeContextKey is the key for storing the scope of the authenticating API key in gin context
+const APIKeyScopeContextKey = "api_key_scope"
+
 // APIKeyMiddleware validates API key authentication for SDK endpoints
 // It validates that the API key matches the project that owns the execution
 func APIKeyMiddleware(repo repositories.Repository) gin.HandlerFunc {
@@ -38,21 +54,52 @@ func APIKeyMiddleware(repo repositories.Repository) gin.HandlerFunc {
 			return
 		}
 
-		// Get execution by UUID
-		execution, err := repo.GetExecutionByUUID(c.Request.Context(), executionUUID)
+		access, err := AuthorizeExecutionAPIKey(c.Request.Context(), repo, executionUUID, c.GetHeader(CheckInTaskHeader), apiKey, allowlistIP(c))
 		if err != nil {
-			log.Printf("[API_KEY] Execution not found: %s, error: %v", executionUUID, err)
-			c.JSON(http.StatusNotFound, gin.H{
-				"error": "Execution not found",
+			c.JSON(err.Status, gin.H{
+				"error": err.Message,
 			})
 			c.Abort()
 			return
 		}
 
-		// Get task by execution's TaskUUID
-		task, err := repo.GetTaskByUUID(c.Request.Context(), execution.TaskUUID)
+		// Store project info in context for handlers to access
+		c.Set(ProjectContextKey, access.Project)
+		c.Set(ExecutionContextKey, access.Execution)
+		c.Set(APIKeyScopeContextKey, access.Scope)
+
+		// Continue to next handler
+		c.Next()
+	}
+}
+
+// TaskAPIKeyMiddleware validates API key authentication for SDK endpoints addressing a task by UUID
+// (e.g. POST /tasks/:task_uuid/executions), with the same checks as APIKeyMiddleware
+func TaskAPIKeyMiddleware(repo repositories.Repository) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		// Extract API key from Authorization header (raw format, no prefix)
+		apiKey := c.GetHeader("Authorization")
+		if apiKey == "" {
+			log.Printf("[API_KEY] Missing Authorization header for %s %s", c.Request.Method, c.Request.URL.Path)
+			c.JSON(http.StatusUnauthorized, gin.H{
+				"error": "Authorization header required",
+			})
+			c.Abort()
+			return
+		}
+
+		taskUUID := c.Param("task_uuid")
+		if taskUUID == "" {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "task_uuid is required",
+			})
+			c.Abort()
+			return
+		}
+
+		task, err := repo.GetTaskByUUID(c.Request.Context(), taskUUID)
 		if err != nil {
-			log.Printf("[API_KEY] Task not found for execution %s: %v", executionUUID, err)
+			log.Printf("[API_KEY] Task not found: %s, error: %v", taskUUID, err)
 			c.JSON(http.StatusNotFound, gin.H{
 				"error": "Task not found",
 			})
@@ -60,10 +107,224 @@ func APIKeyMiddleware(repo repositories.Repository) gin.HandlerFunc {
 			return
 		}
 
-		// Get project by task's ProjectID
-		project, err := repo.GetProjectByID(c.Request.Context(), task.ProjectID)
+		access, apiKeyErr := AuthorizeTaskAPIKey(c.Request.Context(), repo, task, apiKey, allowlistIP(c))
+		if apiKeyErr != nil {
+			c.JSON(apiKeyErr.Status, gin.H{
+				"error": apiKeyErr.Message,
+			})
+			c.Abort()
+			return
+		}
+
+		// Store project and task info in context for handlers to access
+		c.Set(ProjectContextKey, access.Project)
+		c.Set(TaskContextKey, access.Task)
+		c.Set(APIKeyScopeContextKey, access.Scope)
+
+		c.Next()
+	}
+}
+
+// ExecutionAccess is the result of a successful execution API key check
+type ExecutionAccess struct {
+	Execution *models.Execution
+	Task      *models.Task
+	Project   *models.Project
+	Scope     models.APIKeyScope
+}
+
+// APIKeyError is a rejected API key check with the HTTP status it maps to
+type APIKeyError struct {
+	Status  int
+	Message string
+}
+
+func (e *APIKeyError) Error() string {
+	return e.Message
+}
+
+// CheckInTaskHeader names the task of a reported execution, so projects in LENIENT check-in mode can recreate
+// executions the server has no record of
+const CheckInTaskHeader = "X-Task-UUID"
+
+// AuthorizeExecutionAPIKey checks that apiKey may report on the execution: the key must belong to the project
+// that owns the execution, match the task's environment if it is an environment key, allow the client IP, and
+// not be read-only. When the execution does not exist and checkInTaskUUID is set, it is recreated if the task's
+// project is in LENIENT check-in mode. Shared by the REST SDK endpoints and the gRPC SDK API.
+func AuthorizeExecutionAPIKey(ctx context.Context, repo repositories.Repository, executionUUID, checkInTaskUUID, apiKey, clientIP string) (*ExecutionAccess, *APIKeyError) {
+	// Get execution by UUID
+	execution, err := repo.GetExecutionByUUID(ctx, executionUUID)
+	if err == mongo.ErrNoDocuments && checkInTaskUUID != "" {
+		return recoverCheckInExecution(ctx, repo, executionUUID, checkInTaskUUID, apiKey, clientIP)
+	}
+	if err != nil {
+		log.Printf("[API_KEY] Execution not found: %s, error: %v", executionUUID, err)
+		return nil, &APIKeyError{Status: http.StatusNotFound, Message: "Execution not found"}
+	}
+
+	// Get task by execution's TaskUUID
+	task, err := repo.GetTaskByUUID(ctx, execution.TaskUUID)
+	if err != nil {
+		log.Printf("[API_KEY] Task not found for execution %s: %v", executionUUID, err)
+		return nil, &APIKeyError{Status: http.StatusNotFound, Message: "Task not found"}
+	}
+
+	access, apiKeyErr := AuthorizeTaskAPIKey(ctx, repo, task, apiKey, clientIP)
+	if apiKeyErr != nil {
+		return nil, apiKeyErr
+	}
+
+	access.Execution = execution
+	return access, nil
+}
+
+// AuthorizeTaskAPIKey checks that apiKey may report executions of the task: the key must belong to the project
+// that owns the task, match the task's environment if it is an environment key, allow the client IP, and
+// not be read-only. The returned access has no Execution.
+func AuthorizeTaskAPIKey(ctx context.Context, repo repositories.Repository, task *models.Task, apiKey, clientIP string) (*ExecutionAccess, *APIKeyError) {
+	// Get project by task's ProjectID
+	project, err := repo.GetProjectByID(ctx, task.ProjectID)
+	if err != nil {
+		log.Printf("[API_KEY] Project not found for task %s: %v", task.UUID, err)
+		return nil, &APIKeyError{Status: http.StatusNotFound, Message: "Project not found"}
+	}
+
+	// Match API key from Authorization header with project's API keys
+	scope, ok := ResolveAPIKeyScope(project, apiKey)
+	if !ok {
+		log.Printf("[API_KEY] API key mismatch for task %s (project: %s)", task.UUID, project.ID.Hex())
+		return nil, &APIKeyError{Status: http.StatusUnauthorized, Message: "Invalid API key"}
+	}
+
+	// Environment keys may only report executions dispatched to their own environment
+	if environment, ok := EnvironmentForAPIKey(project, apiKey); ok && environment != task.Environment {
+		log.Printf("[API_KEY] API key for environment %s used to report task %s of environment %q (project: %s)", environment, task.UUID, task.Environment, project.ID.Hex())
+		return nil, &APIKeyError{Status: http.StatusForbidden, Message: "API key belongs to a different environment"}
+	}
+
+	// Enforce the key's network allowlist, if any
+	if !IsClientIPAllowed(clientIP, AllowedCIDRsForAPIKey(project, apiKey)) {
+		log.Printf("[API_KEY] Request from %s rejected by API key allowlist for task %s (project: %s)", clientIP, task.UUID, project.ID.Hex())
+		return nil, &APIKeyError{Status: http.StatusForbidden, Message: "Request IP is not allowed for this API key"}
+	}
+
+	// Execution reporting endpoints mutate state, so read-only keys are rejected
+	if scope == models.APIKeyScopeReadOnly {
+		log.Printf("[API_KEY] Read-only API key used to report task %s (project: %s)", task.UUID, project.ID.Hex())
+		return nil, &APIKeyError{Status: http.StatusForbidden, Message: "API key is read-only"}
+	}
+
+	return &ExecutionAccess{Task: task, Project: project, Scope: scope}, nil
+}
+
+// recoverCheckInExecution recreates an execution the server has no record of, e.g. because the dispatch record was
+// lost, from a client report naming its task. The execution is linked to the task's most recent expected fire time.
+func recoverCheckInExecution(ctx context.Context, repo repositories.Repository, executionUUID, taskUUID, apiKey, clientIP string) (*ExecutionAccess, *APIKeyError) {
+	notFound := &APIKeyError{Status: http.StatusNotFound, Message: "Execution not found"}
+
+	if _, err := uuid.Parse(executionUUID); err != nil {
+		return nil, notFound
+	}
+
+	task, err := repo.GetTaskByUUID(ctx, taskUUID)
+	if err != nil {
+		log.Printf("[API_KEY] Task %s not found for check-in of execution %s: %v", taskUUID, executionUUID, err)
+		return nil, notFound
+	}
+
+	// Authorize before revealing anything about the project's settings
+	access, apiKeyErr := AuthorizeTaskAPIKey(ctx, repo, task, apiKey, clientIP)
+	if apiKeyErr != nil {
+		return nil, apiKeyErr
+	}
+	if task.Status == models.TaskStatusPendingDelete || task.Status == models.TaskStatusDeleteFailed ||
+		access.Project.Status == models.ProjectStatusArchived || access.Project.Status == models.ProjectStatusPendingDelete {
+		return nil, notFound
+	}
+
+	settings, err := repo.GetProjectSettings(ctx, task.ProjectID)
+	if err != nil {
+		log.Printf("[API_KEY] Failed to get settings for project %s: %v", task.ProjectID.Hex(), err)
+		return nil, &APIKeyError{Status: http.StatusInternalServerError, Message: "Failed to get project settings"}
+	}
+	if !settings.IsLenientCheckIn() {
+		return nil, notFound
+	}
+
+	now := time.Now()
+	execution := &models.Execution{
+		ID:        primitive.NewObjectID(),
+		UUID:      executionUUID,
+		TaskID:    task.ID,
+		TaskUUID:  task.UUID,
+		Status:    models.ExecutionStatusRunning, // The client is reporting, so the run has started
+		StartedAt: now,
+		CreatedAt: now,
+		UpdatedAt: now,
+		Source:    models.ExecutionSourceCheckIn,
+	}
+	if scheduledFor, ok := expectedFireTime(task, settings, now); ok {
+		execution.ScheduledFor = &scheduledFor
+		execution.StartedAt = scheduledFor
+	}
+
+	if err := repo.CreateExecution(ctx, execution); err != nil {
+		// A concurrent report for the same execution recreated it first
+		if !mongo.IsDuplicateKeyError(err) {
+			log.Printf("[API_KEY] Failed to recreate execution %s for task %s: %v", executionUUID, task.UUID, err)
+			return nil, &APIKeyError{Status: http.StatusInternalServerError, Message: "Failed to create execution"}
+		}
+		if execution, err = repo.GetExecutionByUUID(ctx, executionUUID); err != nil || execution.TaskUUID != task.UUID {
+			return nil, notFound
+		}
+	} else {
+		log.Printf("[API_KEY] Recreated execution %s of task %s from check-in (scheduled for %v)", executionUUID, task.UUID, execution.ScheduledFor)
+	}
+
+	access.Execution = execution
+	return access, nil
+}
+
+// expectedFireTime returns the task's most recent cron fire time at or before now
+func expectedFireTime(task *models.Task, settings *models.ProjectSettings, now time.Time) (time.Time, bool) {
+	if task.ScheduleConfig.CronExpression == "" {
+		return time.Time{}, false
+	}
+	schedule, err := cronexpr.Parse(cronexpr.WithTimezone(task.ScheduleConfig.CronExpression, settings.EffectiveTimezone(task.ScheduleConfig.Timezone)))
+	if err != nil {
+		return time.Time{}, false
+	}
+	return cronexpr.PrevRun(schedule, now)
+}
+
+// ProjectAPIKeyMiddleware validates API key authentication for project-scoped read endpoints
+// (e.g. GET /projects/:project_id/tasks). It accepts the project's primary API key or any of
+// its scoped API keys. READ_ONLY keys are only allowed to perform GET requests.
+func ProjectAPIKeyMiddleware(repo repositories.Repository) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		// Extract API key from Authorization header (raw format, no prefix)
+		apiKey := c.GetHeader("Authorization")
+		if apiKey == "" {
+			log.Printf("[API_KEY] Missing Authorization header for %s %s", c.Request.Method, c.Request.URL.Path)
+			c.JSON(http.StatusUnauthorized, gin.H{
+				"error": "Authorization header required",
+			})
+			c.Abort()
+			return
+		}
+
+		projectID, err := primitive.ObjectIDFromHex(c.Param("project_id"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "Invalid project_id format in path",
+			})
+			c.Abort()
+			return
+		}
+
+		project, err := repo.GetProjectByID(c.Request.Context(), projectID)
 		if err != nil {
-			log.Printf("[API_KEY] Project not found for task %s: %v", execution.TaskUUID, err)
+			log.Printf("[API_KEY] Project not found: %s, error: %v", projectID.Hex(), err)
 			c.JSON(http.StatusNotFound, gin.H{
 				"error": "Project not found",
 			})
@@ -71,9 +332,9 @@ func APIKeyMiddleware(repo repositories.Repository) gin.HandlerFunc {
 			return
 		}
 
-		// Match API key from Authorization header with project's API key
-		if project.APIKey != apiKey {
-			log.Printf("[API_KEY] API key mismatch for execution %s (project: %s)", executionUUID, project.ID.Hex())
+		scope, ok := ResolveAPIKeyScope(project, apiKey)
+		if !ok {
+			log.Printf("[API_KEY] API key mismatch for project %s", project.ID.Hex())
 			c.JSON(http.StatusUnauthorized, gin.H{
 				"error": "Invalid API key",
 			})
@@ -81,14 +342,122 @@ func APIKeyMiddleware(repo repositories.Repository) gin.HandlerFunc {
 			return
 		}
 
-		// Store project info in context for handlers to access
+		// Enforce the key's network allowlist, if any
+		if !IsClientIPAllowed(allowlistIP(c), AllowedCIDRsForAPIKey(project, apiKey)) {
+			log.Printf("[API_KEY] Request from %s rejected by API key allowlist (project: %s)", allowlistIP(c), project.ID.Hex())
+			c.JSON(http.StatusForbidden, gin.H{
+				"error": "Request IP is not allowed for this API key",
+			})
+			c.Abort()
+			return
+		}
+
+		if scope == models.APIKeyScopeReadOnly && c.Request.Method != http.MethodGet {
+			log.Printf("[API_KEY] Read-only API key used for %s %s (project: %s)", c.Request.Method, c.Request.URL.Path, project.ID.Hex())
+			c.JSON(http.StatusForbidden, gin.H{
+				"error": "API key is read-only",
+			})
+			c.Abort()
+			return
+		}
+
+		// Store project info and key scope in context for handlers to access
 		c.Set(ProjectContextKey, project)
+		c.Set(APIKeyScopeContextKey, scope)
 
-		// Continue to next handler
 		c.Next()
 	}
 }
 
+// ResolveAPIKeyScope returns the scope granted by apiKey for the given project.
+// The project's primary API key and environment API keys always have FULL scope.
+func ResolveAPIKeyScope(project *models.Project, apiKey string) (models.APIKeyScope, bool) {
+	if apiKey == "" {
+		return "", false
+	}
+	if project.APIKey == apiKey {
+		return models.APIKeyScopeFull, true
+	}
+	for _, scopedKey := range project.ScopedAPIKeys {
+		if scopedKey.Key == apiKey {
+			return scopedKey.Scope, true
+		}
+	}
+	if _, ok := EnvironmentForAPIKey(project, apiKey); ok {
+		return models.APIKeyScopeFull, true
+	}
+	return "", false
+}
+
+// EnvironmentForAPIKey returns the name of the project environment that owns apiKey, if any
+func EnvironmentForAPIKey(project *models.Project, apiKey string) (string, bool) {
+	if apiKey == "" {
+		return "", false
+	}
+	for _, environment := range project.Environments {
+		if environment.APIKey == apiKey {
+			return environment.Name, true
+		}
+	}
+	return "", false
+}
+
+// AllowedCIDRsForAPIKey returns the CIDR allowlist attached to apiKey. An empty result allows any network.
+func AllowedCIDRsForAPIKey(project *models.Project, apiKey string) []string {
+	if project.APIKey == apiKey {
+		return project.APIKeyAllowedCIDRs
+	}
+	for _, scopedKey := range project.ScopedAPIKeys {
+		if scopedKey.Key == apiKey {
+			return scopedKey.AllowedCIDRs
+		}
+	}
+	return nil
+}
+
+// allowlistIP returns the address API key allowlists are checked against: the peer of the connection, as for gRPC.
+// X-Forwarded-For and X-Real-IP are ignored because any client can set them, and gin trusts them from every peer
+// unless the engine's trusted proxies are configured.
+func allowlistIP(c *gin.Context) string {
+	return c.RemoteIP()
+}
+
+// IsClientIPAllowed reports whether clientIP falls within any of the CIDRs.
+// An empty allowlist allows every address; invalid entries are ignored.
+func IsClientIPAllowed(clientIP string, allowedCIDRs []string) bool {
+	if len(allowedCIDRs) == 0 {
+		return true
+	}
+
+	ip := net.ParseIP(clientIP)
+	if ip == nil {
+		return false
+	}
+
+	for _, cidr := range allowedCIDRs {
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			log.Printf("[API_KEY] Ignoring invalid CIDR in allowlist: %s", cidr)
+			continue
+		}
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// GetAPIKeyScopeFromContext extracts the authenticating API key's scope from gin context
+func GetAPIKeyScopeFromContext(c *gin.Context) (models.APIKeyScope, bool) {
+	scope, exists := c.Get(APIKeyScopeContextKey)
+	if !exists {
+		return "", false
+	}
+
+	apiKeyScope, ok := scope.(models.APIKeyScope)
+	return apiKeyScope, ok
+}
+
 // GetProjectFromContext extracts project info from gin context
 func GetProjectFromContext(c *gin.Context) (*models.Project, bool) {
 	project, exists := c.Get(ProjectContextKey)
@@ -103,3 +472,26 @@ func GetProjectFromContext(c *gin.Context) (*models.Project, bool) {
 
 	return projectInfo, true
 }
+
+// GetTaskFromContext extracts the task authorized by TaskAPIKeyMiddleware from gin context
+func GetTaskFromContext(c *gin.Context) (*models.Task, bool) {
+	task, exists := c.Get(TaskContextKey)
+	if !exists {
+		return nil, false
+	}
+
+	taskInfo, ok := task.(*models.Task)
+	return taskInfo, ok
+}
+
+// GetExecutionFromContext extracts the execution authorized by APIKeyMiddleware from gin context, as it was loaded
+// before the handler ran
+func GetExecutionFromContext(c *gin.Context) (*models.Execution, bool) {
+	execution, exists := c.Get(ExecutionContextKey)
+	if !exists {
+		return nil, false
+	}
+
+	executionInfo, ok := execution.(*models.Execution)
+	return executionInfo, ok
+}
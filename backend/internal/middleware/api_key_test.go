@@ -0,0 +1,269 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yourusername/cron-observer/backend/internal/models"
+	"github.com/yourusername/cron-observer/backend/mocks"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.uber.org/mock/gomock"
+)
+
+func newScopedKeyProject() *models.Project {
+	return &models.Project{
+		ID:     primitive.NewObjectID(),
+		APIKey: "primary-key",
+		ScopedAPIKeys: []models.ScopedAPIKey{
+			{ID: "ro", Key: "read-only-key", Scope: models.APIKeyScopeReadOnly},
+		},
+	}
+}
+
+func performProjectAPIKeyRequest(t *testing.T, project *models.Project, method, apiKey string) int {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockRepository(ctrl)
+	repo.EXPECT().GetProjectByID(gomock.Any(), project.ID).Return(project, nil).AnyTimes()
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Handle(method, "/api/v1/projects/:project_id/tasks", ProjectAPIKeyMiddleware(repo), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	req, _ := http.NewRequest(method, "/api/v1/projects/"+project.ID.Hex()+"/tasks", nil)
+	req.Header.Set("Authorization", apiKey)
+	req.RemoteAddr = "192.0.2.1:1234"
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w.Code
+}
+
+func TestProjectAPIKeyMiddleware_ReadOnlyKeyAllowsGet(t *testing.T) {
+	project := newScopedKeyProject()
+	if code := performProjectAPIKeyRequest(t, project, http.MethodGet, "read-only-key"); code != http.StatusOK {
+		t.Errorf("Expected status code %d, got %d", http.StatusOK, code)
+	}
+}
+
+func TestProjectAPIKeyMiddleware_ReadOnlyKeyRejectsMutation(t *testing.T) {
+	project := newScopedKeyProject()
+	if code := performProjectAPIKeyRequest(t, project, http.MethodPost, "read-only-key"); code != http.StatusForbidden {
+		t.Errorf("Expected status code %d, got %d", http.StatusForbidden, code)
+	}
+}
+
+func TestProjectAPIKeyMiddleware_PrimaryKeyAllowsMutation(t *testing.T) {
+	project := newScopedKeyProject()
+	if code := performProjectAPIKeyRequest(t, project, http.MethodPost, "primary-key"); code != http.StatusOK {
+		t.Errorf("Expected status code %d, got %d", http.StatusOK, code)
+	}
+}
+
+func TestProjectAPIKeyMiddleware_UnknownKey(t *testing.T) {
+	project := newScopedKeyProject()
+	if code := performProjectAPIKeyRequest(t, project, http.MethodGet, "unknown-key"); code != http.StatusUnauthorized {
+		t.Errorf("Expected status code %d, got %d", http.StatusUnauthorized, code)
+	}
+}
+
+func TestProjectAPIKeyMiddleware_AllowlistRejectsUnknownNetwork(t *testing.T) {
+	project := newScopedKeyProject()
+	project.ScopedAPIKeys[0].AllowedCIDRs = []string{"10.0.0.0/8"}
+
+	// performProjectAPIKeyRequest sends requests from 192.0.2.1
+	if code := performProjectAPIKeyRequest(t, project, http.MethodGet, "read-only-key"); code != http.StatusForbidden {
+		t.Errorf("Expected status code %d, got %d", http.StatusForbidden, code)
+	}
+
+	project.ScopedAPIKeys[0].AllowedCIDRs = []string{"192.0.2.0/24"}
+	if code := performProjectAPIKeyRequest(t, project, http.MethodGet, "read-only-key"); code != http.StatusOK {
+		t.Errorf("Expected status code %d, got %d", http.StatusOK, code)
+	}
+}
+
+func TestProjectAPIKeyMiddleware_AllowlistIgnoresForwardedFor(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	project := newScopedKeyProject()
+	project.ScopedAPIKeys[0].AllowedCIDRs = []string{"10.0.0.0/8"}
+
+	repo := mocks.NewMockRepository(ctrl)
+	repo.EXPECT().GetProjectByID(gomock.Any(), project.ID).Return(project, nil).AnyTimes()
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/api/v1/projects/:project_id/tasks", ProjectAPIKeyMiddleware(repo), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	req, _ := http.NewRequest(http.MethodGet, "/api/v1/projects/"+project.ID.Hex()+"/tasks", nil)
+	req.Header.Set("Authorization", "read-only-key")
+	req.Header.Set("X-Forwarded-For", "10.1.2.3")
+	req.Header.Set("X-Real-IP", "10.1.2.3")
+	req.RemoteAddr = "192.0.2.1:1234"
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusForbidden {
+		t.Errorf("Expected status code %d for a spoofed X-Forwarded-For, got %d", http.StatusForbidden, w.Code)
+	}
+}
+
+func TestAPIKeyMiddleware_EnvironmentKeyLimitedToItsEnvironment(t *testing.T) {
+	project := newScopedKeyProject()
+	project.Environments = []models.ProjectEnvironment{
+		{Name: "staging", APIKey: "staging-key"},
+		{Name: "prod", APIKey: "prod-key"},
+	}
+	task := &models.Task{UUID: "task-1", ProjectID: project.ID, Environment: "staging"}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockRepository(ctrl)
+	repo.EXPECT().GetExecutionByUUID(gomock.Any(), "exec-1").Return(&models.Execution{UUID: "exec-1", TaskUUID: task.UUID}, nil).AnyTimes()
+	repo.EXPECT().GetTaskByUUID(gomock.Any(), task.UUID).Return(task, nil).AnyTimes()
+	repo.EXPECT().GetProjectByID(gomock.Any(), project.ID).Return(project, nil).AnyTimes()
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.PATCH("/api/v1/executions/:execution_uuid/status", APIKeyMiddleware(repo), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	cases := []struct {
+		apiKey   string
+		expected int
+	}{
+		{"staging-key", http.StatusOK},
+		{"prod-key", http.StatusForbidden},
+		{"primary-key", http.StatusOK},
+	}
+
+	for _, tc := range cases {
+		req, _ := http.NewRequest(http.MethodPatch, "/api/v1/executions/exec-1/status", nil)
+		req.Header.Set("Authorization", tc.apiKey)
+		req.RemoteAddr = "192.0.2.1:1234"
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		if w.Code != tc.expected {
+			t.Errorf("%s: expected status code %d, got %d", tc.apiKey, tc.expected, w.Code)
+		}
+	}
+}
+
+func TestTaskAPIKeyMiddleware_RejectsReadOnlyAndForeignKeys(t *testing.T) {
+	project := newScopedKeyProject()
+	task := &models.Task{UUID: "task-1", ProjectID: project.ID}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockRepository(ctrl)
+	repo.EXPECT().GetTaskByUUID(gomock.Any(), task.UUID).Return(task, nil).AnyTimes()
+	repo.EXPECT().GetProjectByID(gomock.Any(), project.ID).Return(project, nil).AnyTimes()
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.POST("/api/v1/tasks/:task_uuid/executions", TaskAPIKeyMiddleware(repo), func(c *gin.Context) {
+		if authorized, ok := GetTaskFromContext(c); !ok || authorized.UUID != task.UUID {
+			t.Errorf("Expected the task in context, got %v", authorized)
+		}
+		c.Status(http.StatusOK)
+	})
+
+	cases := []struct {
+		apiKey   string
+		expected int
+	}{
+		{"primary-key", http.StatusOK},
+		{"read-only-key", http.StatusForbidden},
+		{"other-project-key", http.StatusUnauthorized},
+	}
+
+	for _, tc := range cases {
+		req, _ := http.NewRequest(http.MethodPost, "/api/v1/tasks/task-1/executions", nil)
+		req.Header.Set("Authorization", tc.apiKey)
+		req.RemoteAddr = "192.0.2.1:1234"
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		if w.Code != tc.expected {
+			t.Errorf("%s: expected status code %d, got %d", tc.apiKey, tc.expected, w.Code)
+		}
+	}
+}
+
+func performCheckInRequest(t *testing.T, repo *mocks.MockRepository, taskUUID string) int {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.PATCH("/api/v1/executions/:execution_uuid/status", APIKeyMiddleware(repo), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	req, _ := http.NewRequest(http.MethodPatch, "/api/v1/executions/6f1c2b9e-3d4a-4c8e-9b71-2a5f0e8d3c14/status", nil)
+	req.Header.Set("Authorization", "primary-key")
+	req.Header.Set(CheckInTaskHeader, taskUUID)
+	req.RemoteAddr = "192.0.2.1:1234"
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w.Code
+}
+
+func TestAPIKeyMiddleware_LenientCheckInRecreatesUnknownExecution(t *testing.T) {
+	project := newScopedKeyProject()
+	task := &models.Task{
+		UUID:           "task-1",
+		ProjectID:      project.ID,
+		ScheduleConfig: models.ScheduleConfig{CronExpression: "*/5 * * * *", Timezone: "UTC"},
+	}
+	executionUUID := "6f1c2b9e-3d4a-4c8e-9b71-2a5f0e8d3c14"
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockRepository(ctrl)
+	repo.EXPECT().GetExecutionByUUID(gomock.Any(), executionUUID).Return(nil, mongo.ErrNoDocuments)
+	repo.EXPECT().GetTaskByUUID(gomock.Any(), task.UUID).Return(task, nil)
+	repo.EXPECT().GetProjectByID(gomock.Any(), project.ID).Return(project, nil)
+	repo.EXPECT().GetProjectSettings(gomock.Any(), project.ID).Return(&models.ProjectSettings{CheckInMode: models.CheckInModeLenient}, nil)
+	repo.EXPECT().CreateExecution(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, execution *models.Execution) error {
+		if execution.UUID != executionUUID || execution.TaskUUID != task.UUID || execution.Source != models.ExecutionSourceCheckIn {
+			t.Errorf("Unexpected execution %+v", execution)
+		}
+		if execution.ScheduledFor == nil || execution.ScheduledFor.After(time.Now()) || execution.ScheduledFor.Minute()%5 != 0 {
+			t.Errorf("Expected the execution to be linked to the last fire time, got %v", execution.ScheduledFor)
+		}
+		return nil
+	})
+
+	if code := performCheckInRequest(t, repo, task.UUID); code != http.StatusOK {
+		t.Errorf("Expected status code %d, got %d", http.StatusOK, code)
+	}
+}
+
+func TestAPIKeyMiddleware_StrictCheckInRejectsUnknownExecution(t *testing.T) {
+	project := newScopedKeyProject()
+	task := &models.Task{UUID: "task-1", ProjectID: project.ID}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockRepository(ctrl)
+	repo.EXPECT().GetExecutionByUUID(gomock.Any(), gomock.Any()).Return(nil, mongo.ErrNoDocuments)
+	repo.EXPECT().GetTaskByUUID(gomock.Any(), task.UUID).Return(task, nil)
+	repo.EXPECT().GetProjectByID(gomock.Any(), project.ID).Return(project, nil)
+	repo.EXPECT().GetProjectSettings(gomock.Any(), project.ID).Return(nil, nil)
+
+	if code := performCheckInRequest(t, repo, task.UUID); code != http.StatusNotFound {
+		t.Errorf("Expected status code %d, got %d", http.StatusNotFound, code)
+	}
+}
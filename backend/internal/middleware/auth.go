@@ -5,32 +5,57 @@ import (
 	"log"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/golang-jwt/jwt/v5"
 )
 
+// UserRole is the platform-wide role of an authenticated user
+type UserRole string
+
+const (
+	UserRoleUser       UserRole = "user"
+	UserRoleSuperAdmin UserRole = "super_admin" // Full access to every project
+)
+
 // UserInfo holds authenticated user information
 type UserInfo struct {
 	Email string
 	Name  string
-	Sub   string // User ID from JWT
+	Sub   string   // User ID from JWT
+	Role  UserRole // Assigned only after the token signature has been verified
+
+	TokenID  string    // jti claim, used for revocation
+	IssuedAt time.Time // iat claim; zero when the token has none
+}
+
+// IsSuperAdmin reports whether the user was granted the super admin role
+func (u *UserInfo) IsSuperAdmin() bool {
+	return u.Role == UserRoleSuperAdmin
 }
 
 // Context key for storing user info
 const UserContextKey = "user"
 
 // AuthMiddleware validates JWT tokens from NextAuth
-// If the user's email is in the superAdmins list, they bypass JWT validation
+// Users listed in superAdmins receive the super admin role once their token validates
 func AuthMiddleware(jwtSecret string, superAdmins []string) gin.HandlerFunc {
-	// Create a map for O(1) lookup
-	superAdminMap := make(map[string]bool)
-	for _, admin := range superAdmins {
-		superAdminMap[strings.ToLower(strings.TrimSpace(admin))] = true
-	}
+	return AuthMiddlewareWithOIDC(jwtSecret, superAdmins, nil)
+}
+
+// AuthMiddlewareWithOIDC validates HMAC-signed NextAuth tokens and, when oidc is non-nil,
+// RS256 tokens issued by the configured OIDC provider
+func AuthMiddlewareWithOIDC(jwtSecret string, superAdmins []string, oidc *OIDCVerifier) gin.HandlerFunc {
+	return AuthMiddlewareWithKeys(NewHMACKeySet(jwtSecret, nil), NewSuperAdmins(superAdmins), oidc)
+}
 
-	// Log super admin list on startup (once)
-	log.Printf("[AUTH] Initialized with %d super admins: %v", len(superAdmins), superAdmins)
+// AuthMiddlewareWithKeys validates HMAC-signed NextAuth tokens against any secret in keys
+// (supporting secret rotation) and, when oidc is non-nil, RS256 tokens from the OIDC provider.
+// superAdmins is consulted on every request, so a reloaded list applies to the next request.
+func AuthMiddlewareWithKeys(keys *HMACKeySet, superAdmins *SuperAdmins, oidc *OIDCVerifier) gin.HandlerFunc {
+	// Log super admin count on startup (once)
+	log.Printf("[AUTH] Initialized with %d super admins", superAdmins.Len())
 
 	return func(c *gin.Context) {
 		// Extract token from Authorization header
@@ -56,82 +81,17 @@ func AuthMiddleware(jwtSecret string, superAdmins []string) gin.HandlerFunc {
 
 		tokenString := parts[1]
 
-		// First, try to parse token without validation to extract email for super admin check
-		parser := jwt.NewParser()
-		unverifiedToken, _, err := parser.ParseUnverified(tokenString, jwt.MapClaims{})
-
-		var userEmail string
-		var unverifiedClaims jwt.MapClaims
-		if err == nil && unverifiedToken != nil {
-			if claims, ok := unverifiedToken.Claims.(jwt.MapClaims); ok {
-				unverifiedClaims = claims
-				// Try multiple ways to extract email
-				userEmail = getStringClaim(claims, "email")
-				if userEmail == "" {
-					// Try nested user object
-					if userObj, ok := claims["user"].(map[string]interface{}); ok {
-						userEmail = getStringFromMap(userObj, "email")
-					}
-				}
-				if userEmail == "" {
-					// Try alternative claim names that NextAuth might use
-					// Check for common variations
-					userEmail = getStringClaim(claims, "preferred_username")
-				}
-			}
-		}
-
-		// If email extraction failed, try one more time with a fresh parser
-		// This handles edge cases where token format is slightly different
-		if userEmail == "" && tokenString != "" {
-			parser := jwt.NewParser()
-			if token, _, parseErr := parser.ParseUnverified(tokenString, jwt.MapClaims{}); parseErr == nil && token != nil {
-				if claims, ok := token.Claims.(jwt.MapClaims); ok {
-					if unverifiedClaims == nil {
-						unverifiedClaims = claims
-					}
-					userEmail = getStringClaim(claims, "email")
-					if userEmail == "" {
-						if userObj, ok := claims["user"].(map[string]interface{}); ok {
-							userEmail = getStringFromMap(userObj, "email")
-						}
-					}
-				}
-			}
-		}
-
-		// Check if user is a super admin - if yes, bypass JWT validation
-		normalizedEmail := strings.ToLower(strings.TrimSpace(userEmail))
-		log.Printf("[AUTH] Extracted email: '%s', normalized: '%s', is super admin: %v", userEmail, normalizedEmail, superAdminMap[normalizedEmail])
-
-		if userEmail != "" && superAdminMap[normalizedEmail] {
-			// Super admin - allow request without strict JWT validation
-			log.Printf("[AUTH] Super admin access granted for: %s", userEmail)
-			userInfo := UserInfo{
-				Email: userEmail,
-			}
-			if unverifiedClaims != nil {
-				userInfo.Name = getStringClaim(unverifiedClaims, "name")
-				userInfo.Sub = getStringClaim(unverifiedClaims, "sub")
-				if userInfo.Name == "" {
-					if userObj, ok := unverifiedClaims["user"].(map[string]interface{}); ok {
-						userInfo.Name = getStringFromMap(userObj, "name")
-					}
-				}
-			}
-			c.Set(UserContextKey, userInfo)
-			c.Next()
-			return
-		}
-
-		log.Printf("[AUTH] Not a super admin, proceeding with JWT validation for email: '%s'", userEmail)
-
-		// Not a super admin - proceed with normal JWT validation
+		// Every token must pass signature validation, including super admins'
 		token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
-			if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-				return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+			switch token.Method.(type) {
+			case *jwt.SigningMethodHMAC:
+				return keys.KeyFunc(token)
+			case *jwt.SigningMethodRSA:
+				if oidc != nil {
+					return oidc.KeyFunc(token)
+				}
 			}
-			return []byte(jwtSecret), nil
+			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
 		})
 
 		if err != nil {
@@ -161,19 +121,40 @@ func AuthMiddleware(jwtSecret string, superAdmins []string) gin.HandlerFunc {
 			return
 		}
 
-		// Extract user info from claims (NextAuth JWT format)
-		userInfo := UserInfo{
-			Email: getStringClaim(claims, "email"),
-			Name:  getStringClaim(claims, "name"),
-			Sub:   getStringClaim(claims, "sub"),
+		var userInfo UserInfo
+		if _, isRSA := token.Method.(*jwt.SigningMethodRSA); isRSA {
+			// OIDC tokens: check issuer/audience and map standard OIDC claims
+			if err := oidc.ValidateClaims(claims); err != nil {
+				c.JSON(http.StatusUnauthorized, gin.H{
+					"error":   "Invalid token",
+					"details": err.Error(),
+				})
+				c.Abort()
+				return
+			}
+			var err error
+			if userInfo, err = oidc.UserInfoFromClaims(claims); err != nil {
+				c.JSON(http.StatusUnauthorized, gin.H{
+					"error":   "Invalid token",
+					"details": err.Error(),
+				})
+				c.Abort()
+				return
+			}
+		} else {
+			userInfo = userInfoFromNextAuthClaims(claims)
+		}
+
+		userInfo.TokenID = getStringClaim(claims, "jti")
+		if issuedAt, err := claims.GetIssuedAt(); err == nil && issuedAt != nil {
+			userInfo.IssuedAt = issuedAt.Time
 		}
 
-		// If email is missing, try to get it from user object in token
-		if userInfo.Email == "" {
-			if userObj, ok := claims["user"].(map[string]interface{}); ok {
-				userInfo.Email = getStringFromMap(userObj, "email")
-				userInfo.Name = getStringFromMap(userObj, "name")
-			}
+		// Privileges are derived from verified claims only
+		userInfo.Role = UserRoleUser
+		if userInfo.Email != "" && superAdmins.Contains(userInfo.Email) {
+			userInfo.Role = UserRoleSuperAdmin
+			log.Printf("[AUTH] Super admin role granted for: %s", userInfo.Email)
 		}
 
 		// Store user info in context for handlers to access
@@ -184,6 +165,25 @@ func AuthMiddleware(jwtSecret string, superAdmins []string) gin.HandlerFunc {
 	}
 }
 
+// userInfoFromNextAuthClaims extracts user info from NextAuth JWT claims
+func userInfoFromNextAuthClaims(claims jwt.MapClaims) UserInfo {
+	userInfo := UserInfo{
+		Email: getStringClaim(claims, "email"),
+		Name:  getStringClaim(claims, "name"),
+		Sub:   getStringClaim(claims, "sub"),
+	}
+
+	// If email is missing, try to get it from user object in token
+	if userInfo.Email == "" {
+		if userObj, ok := claims["user"].(map[string]interface{}); ok {
+			userInfo.Email = getStringFromMap(userObj, "email")
+			userInfo.Name = getStringFromMap(userObj, "name")
+		}
+	}
+
+	return userInfo
+}
+
 // GetUserFromContext extracts user info from gin context
 func GetUserFromContext(c *gin.Context) (*UserInfo, bool) {
 	user, exists := c.Get(UserContextKey)
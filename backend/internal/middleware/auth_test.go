@@ -0,0 +1,106 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+func performAuthRequest(t *testing.T, signingSecret string) (int, *UserInfo) {
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"email": "admin@example.com",
+		"exp":   time.Now().Add(time.Hour).Unix(),
+	})
+	signed, err := token.SignedString([]byte(signingSecret))
+	if err != nil {
+		t.Fatalf("Failed to sign token: %v", err)
+	}
+
+	var user *UserInfo
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/me", AuthMiddleware("server-secret", []string{"admin@example.com"}), func(c *gin.Context) {
+		user, _ = GetUserFromContext(c)
+		c.Status(http.StatusOK)
+	})
+
+	req, _ := http.NewRequest(http.MethodGet, "/me", nil)
+	req.Header.Set("Authorization", "Bearer "+signed)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w.Code, user
+}
+
+func TestAuthMiddleware_RejectsForgedSuperAdminToken(t *testing.T) {
+	code, user := performAuthRequest(t, "attacker-secret")
+
+	if code != http.StatusUnauthorized {
+		t.Errorf("Expected status code %d, got %d", http.StatusUnauthorized, code)
+	}
+	if user != nil {
+		t.Errorf("Expected no user in context, got %+v", user)
+	}
+}
+
+func TestAuthMiddleware_GrantsSuperAdminRoleAfterVerification(t *testing.T) {
+	code, user := performAuthRequest(t, "server-secret")
+
+	if code != http.StatusOK {
+		t.Fatalf("Expected status code %d, got %d", http.StatusOK, code)
+	}
+	if user == nil || !user.IsSuperAdmin() {
+		t.Errorf("Expected super admin role, got %+v", user)
+	}
+}
+
+func TestAuthMiddlewareWithKeys_AcceptsRotatedSecrets(t *testing.T) {
+	keys := NewHMACKeySet("new-secret", map[string]string{"2024-old": "old-secret"})
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/me", AuthMiddlewareWithKeys(keys, nil, nil), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	sign := func(secret, kid string) string {
+		token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+			"email": "user@example.com",
+			"exp":   time.Now().Add(time.Hour).Unix(),
+		})
+		if kid != "" {
+			token.Header["kid"] = kid
+		}
+		signed, err := token.SignedString([]byte(secret))
+		if err != nil {
+			t.Fatalf("Failed to sign token: %v", err)
+		}
+		return signed
+	}
+
+	cases := []struct {
+		name     string
+		token    string
+		expected int
+	}{
+		{"new secret without kid", sign("new-secret", ""), http.StatusOK},
+		{"old secret without kid", sign("old-secret", ""), http.StatusOK},
+		{"old secret with kid", sign("old-secret", "2024-old"), http.StatusOK},
+		{"new secret with old kid", sign("new-secret", "2024-old"), http.StatusUnauthorized},
+		{"unknown kid", sign("old-secret", "unknown"), http.StatusUnauthorized},
+		{"unknown secret", sign("other-secret", ""), http.StatusUnauthorized},
+	}
+
+	for _, tc := range cases {
+		req, _ := http.NewRequest(http.MethodGet, "/me", nil)
+		req.Header.Set("Authorization", "Bearer "+tc.token)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		if w.Code != tc.expected {
+			t.Errorf("%s: expected status code %d, got %d", tc.name, tc.expected, w.Code)
+		}
+	}
+}
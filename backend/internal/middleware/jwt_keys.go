@@ -0,0 +1,59 @@
+package middleware
+
+import (
+	"fmt"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// HMACKeySet holds the HMAC secrets accepted for NextAuth tokens. During a secret rotation
+// the old and new secrets are both valid, so users are not logged out and in-flight tokens
+// keep working until they expire.
+type HMACKeySet struct {
+	primary []byte
+	byKID   map[string][]byte
+	ordered []jwt.VerificationKey // primary first, then keyed secrets; tried for tokens without a kid
+}
+
+// NewHMACKeySet creates a key set from the primary secret and additional secrets keyed by kid.
+// Empty secrets are ignored.
+func NewHMACKeySet(primary string, secretsByKID map[string]string) *HMACKeySet {
+	keys := &HMACKeySet{
+		byKID: make(map[string][]byte),
+	}
+
+	if primary != "" {
+		keys.primary = []byte(primary)
+		keys.ordered = append(keys.ordered, keys.primary)
+	}
+
+	for kid, secret := range secretsByKID {
+		if kid == "" || secret == "" {
+			continue
+		}
+		keys.byKID[kid] = []byte(secret)
+		keys.ordered = append(keys.ordered, []byte(secret))
+	}
+
+	return keys
+}
+
+// KeyFunc returns the secret matching the token's kid header. Tokens without a kid
+// (NextAuth does not set one) are checked against every configured secret.
+func (k *HMACKeySet) KeyFunc(token *jwt.Token) (interface{}, error) {
+	if kid, ok := token.Header["kid"].(string); ok && kid != "" {
+		if secret, found := k.byKID[kid]; found {
+			return secret, nil
+		}
+		return nil, fmt.Errorf("unknown signing key id: %s", kid)
+	}
+
+	switch len(k.ordered) {
+	case 0:
+		return nil, fmt.Errorf("no JWT secret configured")
+	case 1:
+		return k.ordered[0], nil
+	default:
+		return jwt.VerificationKeySet{Keys: k.ordered}, nil
+	}
+}
@@ -0,0 +1,271 @@
+package middleware
+
+import (
+	"context"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"log"
+	"math/big"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// minJWKSRefreshInterval limits how often an unknown kid can trigger a JWKS refetch
+const minJWKSRefreshInterval = 30 * time.Second
+
+// OIDCConfig holds the settings needed to validate tokens issued by an OIDC provider
+// (Auth0, Keycloak, Azure AD, ...)
+type OIDCConfig struct {
+	IssuerURL       string        // e.g. https://tenant.auth0.com/ ; must match the token's iss claim
+	Audience        string        // optional; when set the token's aud claim must contain it
+	JWKSURL         string        // optional; discovered from IssuerURL/.well-known/openid-configuration when empty
+	RefreshInterval time.Duration // how long fetched keys are cached before refetching
+}
+
+// OIDCVerifier validates RS256 tokens against the signing keys published by an OIDC issuer
+type OIDCVerifier struct {
+	config     OIDCConfig
+	httpClient *http.Client
+
+	mu          sync.RWMutex
+	keys        map[string]*rsa.PublicKey
+	jwksURL     string
+	lastFetched time.Time
+}
+
+// NewOIDCVerifier creates a verifier for the configured issuer. Keys are fetched lazily on first use.
+func NewOIDCVerifier(config OIDCConfig) *OIDCVerifier {
+	if config.RefreshInterval <= 0 {
+		config.RefreshInterval = time.Hour
+	}
+
+	return &OIDCVerifier{
+		config:     config,
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+		keys:       make(map[string]*rsa.PublicKey),
+		jwksURL:    config.JWKSURL,
+	}
+}
+
+// KeyFunc returns the issuer's public key for the token's kid, refreshing the JWKS when needed
+func (v *OIDCVerifier) KeyFunc(token *jwt.Token) (interface{}, error) {
+	if _, ok := token.Method.(*jwt.SigningMethodRSA); !ok {
+		return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+	}
+
+	kid, _ := token.Header["kid"].(string)
+
+	if key, ok := v.cachedKey(kid); ok {
+		return key, nil
+	}
+
+	// Unknown kid or stale cache: the provider may have rotated its keys
+	if err := v.refresh(context.Background()); err != nil {
+		return nil, err
+	}
+
+	if key, ok := v.cachedKey(kid); ok {
+		return key, nil
+	}
+	return nil, fmt.Errorf("no signing key found for kid %q", kid)
+}
+
+// ValidateClaims checks the issuer and audience of a verified token
+func (v *OIDCVerifier) ValidateClaims(claims jwt.MapClaims) error {
+	issuer, _ := claims.GetIssuer()
+	if strings.TrimSuffix(issuer, "/") != strings.TrimSuffix(v.config.IssuerURL, "/") {
+		return fmt.Errorf("unexpected token issuer: %s", issuer)
+	}
+
+	if v.config.Audience != "" {
+		audience, _ := claims.GetAudience()
+		for _, aud := range audience {
+			if aud == v.config.Audience {
+				return nil
+			}
+		}
+		return fmt.Errorf("token audience does not include %s", v.config.Audience)
+	}
+
+	return nil
+}
+
+// UserInfoFromClaims maps standard OIDC claims to UserInfo. Project access is granted by email, so the token must
+// carry an email the provider verified; preferred_username and upn are not used because users can often choose them.
+func (v *OIDCVerifier) UserInfoFromClaims(claims jwt.MapClaims) (UserInfo, error) {
+	userInfo := UserInfo{
+		Email: getStringClaim(claims, "email"),
+		Name:  getStringClaim(claims, "name"),
+		Sub:   getStringClaim(claims, "sub"),
+	}
+
+	if userInfo.Email == "" {
+		return UserInfo{}, fmt.Errorf("token has no email claim")
+	}
+	if !emailVerified(claims) {
+		return UserInfo{}, fmt.Errorf("token email %s is not verified", userInfo.Email)
+	}
+	if userInfo.Name == "" {
+		userInfo.Name = strings.TrimSpace(getStringClaim(claims, "given_name") + " " + getStringClaim(claims, "family_name"))
+	}
+
+	return userInfo, nil
+}
+
+// emailVerified reads the email_verified claim, which some providers send as a string
+func emailVerified(claims jwt.MapClaims) bool {
+	switch verified := claims["email_verified"].(type) {
+	case bool:
+		return verified
+	case string:
+		return verified == "true"
+	default:
+		return false
+	}
+}
+
+// cachedKey returns a cached key if it exists and the cache has not expired
+func (v *OIDCVerifier) cachedKey(kid string) (*rsa.PublicKey, bool) {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+
+	if time.Since(v.lastFetched) > v.config.RefreshInterval {
+		return nil, false
+	}
+
+	if kid == "" && len(v.keys) == 1 {
+		// Some providers omit kid when they only publish a single key
+		for _, key := range v.keys {
+			return key, true
+		}
+	}
+
+	key, ok := v.keys[kid]
+	return key, ok
+}
+
+// refresh refetches the JWKS, at most once per minJWKSRefreshInterval
+func (v *OIDCVerifier) refresh(ctx context.Context) error {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	if !v.lastFetched.IsZero() && time.Since(v.lastFetched) < minJWKSRefreshInterval {
+		return nil
+	}
+
+	if v.jwksURL == "" {
+		jwksURL, err := v.discoverJWKSURL(ctx)
+		if err != nil {
+			return err
+		}
+		v.jwksURL = jwksURL
+	}
+
+	keys, err := v.fetchJWKS(ctx, v.jwksURL)
+	if err != nil {
+		return err
+	}
+
+	v.keys = keys
+	v.lastFetched = time.Now()
+	log.Printf("[AUTH] Loaded %d OIDC signing keys from %s", len(keys), v.jwksURL)
+	return nil
+}
+
+// discoverJWKSURL reads jwks_uri from the issuer's OpenID configuration document
+func (v *OIDCVerifier) discoverJWKSURL(ctx context.Context) (string, error) {
+	discoveryURL := strings.TrimSuffix(v.config.IssuerURL, "/") + "/.well-known/openid-configuration"
+
+	var discovery struct {
+		JWKSURI string `json:"jwks_uri"`
+	}
+	if err := v.getJSON(ctx, discoveryURL, &discovery); err != nil {
+		return "", fmt.Errorf("failed to fetch OIDC discovery document: %w", err)
+	}
+	if discovery.JWKSURI == "" {
+		return "", fmt.Errorf("OIDC discovery document at %s has no jwks_uri", discoveryURL)
+	}
+	return discovery.JWKSURI, nil
+}
+
+// fetchJWKS downloads the key set and decodes its RSA signing keys
+func (v *OIDCVerifier) fetchJWKS(ctx context.Context, jwksURL string) (map[string]*rsa.PublicKey, error) {
+	var jwks struct {
+		Keys []struct {
+			Kid string `json:"kid"`
+			Kty string `json:"kty"`
+			Use string `json:"use"`
+			N   string `json:"n"`
+			E   string `json:"e"`
+		} `json:"keys"`
+	}
+	if err := v.getJSON(ctx, jwksURL, &jwks); err != nil {
+		return nil, fmt.Errorf("failed to fetch JWKS: %w", err)
+	}
+
+	keys := make(map[string]*rsa.PublicKey)
+	for _, jwk := range jwks.Keys {
+		if jwk.Kty != "RSA" || (jwk.Use != "" && jwk.Use != "sig") {
+			continue
+		}
+		key, err := parseRSAPublicKey(jwk.N, jwk.E)
+		if err != nil {
+			log.Printf("[AUTH] Skipping invalid JWK %s: %v", jwk.Kid, err)
+			continue
+		}
+		keys[jwk.Kid] = key
+	}
+
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("JWKS at %s contains no RSA signing keys", jwksURL)
+	}
+	return keys, nil
+}
+
+// getJSON performs a GET request and decodes the JSON response
+func (v *OIDCVerifier) getJSON(ctx context.Context, url string, out interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := v.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status %d from %s", resp.StatusCode, url)
+	}
+
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+// parseRSAPublicKey builds an RSA public key from base64url-encoded modulus and exponent
+func parseRSAPublicKey(n, e string) (*rsa.PublicKey, error) {
+	nBytes, err := base64.RawURLEncoding.DecodeString(n)
+	if err != nil {
+		return nil, fmt.Errorf("invalid modulus: %w", err)
+	}
+	eBytes, err := base64.RawURLEncoding.DecodeString(e)
+	if err != nil {
+		return nil, fmt.Errorf("invalid exponent: %w", err)
+	}
+
+	exponent := new(big.Int).SetBytes(eBytes)
+	if !exponent.IsInt64() || exponent.Int64() > int64(^uint32(0)>>1) {
+		return nil, fmt.Errorf("exponent too large")
+	}
+
+	return &rsa.PublicKey{
+		N: new(big.Int).SetBytes(nBytes),
+		E: int(exponent.Int64()),
+	}, nil
+}
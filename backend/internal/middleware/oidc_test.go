@@ -0,0 +1,158 @@
+package middleware
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+func newTestJWKSServer(t *testing.T, key *rsa.PrivateKey, kid string) *httptest.Server {
+	jwks := map[string]interface{}{
+		"keys": []map[string]string{{
+			"kid": kid,
+			"kty": "RSA",
+			"use": "sig",
+			"n":   base64.RawURLEncoding.EncodeToString(key.PublicKey.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.PublicKey.E)).Bytes()),
+		}},
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(jwks)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func performOIDCRequest(t *testing.T, verifier *OIDCVerifier, key *rsa.PrivateKey, kid string, claims jwt.MapClaims) (int, *UserInfo) {
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = kid
+	signed, err := token.SignedString(key)
+	if err != nil {
+		t.Fatalf("Failed to sign token: %v", err)
+	}
+
+	var user *UserInfo
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/me", AuthMiddlewareWithOIDC("hmac-secret", nil, verifier), func(c *gin.Context) {
+		user, _ = GetUserFromContext(c)
+		c.Status(http.StatusOK)
+	})
+
+	req, _ := http.NewRequest(http.MethodGet, "/me", nil)
+	req.Header.Set("Authorization", "Bearer "+signed)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w.Code, user
+}
+
+func TestAuthMiddlewareWithOIDC_ValidRS256Token(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+	server := newTestJWKSServer(t, key, "kid-1")
+	verifier := NewOIDCVerifier(OIDCConfig{IssuerURL: "https://issuer.example.com/", Audience: "cron-observer", JWKSURL: server.URL})
+
+	code, user := performOIDCRequest(t, verifier, key, "kid-1", jwt.MapClaims{
+		"iss":            "https://issuer.example.com/",
+		"aud":            "cron-observer",
+		"sub":            "user-123",
+		"email":          "user@example.com",
+		"email_verified": true,
+		"name":           "Test User",
+		"exp":            time.Now().Add(time.Hour).Unix(),
+	})
+
+	if code != http.StatusOK {
+		t.Fatalf("Expected status code %d, got %d", http.StatusOK, code)
+	}
+	if user == nil || user.Email != "user@example.com" || user.Sub != "user-123" || user.Name != "Test User" {
+		t.Errorf("Unexpected user info: %+v", user)
+	}
+}
+
+func TestAuthMiddlewareWithOIDC_RequiresVerifiedEmail(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+	server := newTestJWKSServer(t, key, "kid-1")
+	verifier := NewOIDCVerifier(OIDCConfig{IssuerURL: "https://issuer.example.com/", JWKSURL: server.URL})
+
+	cases := []struct {
+		name   string
+		claims jwt.MapClaims
+	}{
+		{"preferred_username only", jwt.MapClaims{"preferred_username": "admin@example.com"}},
+		{"upn only", jwt.MapClaims{"upn": "admin@example.com"}},
+		{"unverified email", jwt.MapClaims{"email": "admin@example.com", "email_verified": false}},
+		{"email without email_verified", jwt.MapClaims{"email": "admin@example.com"}},
+	}
+
+	for _, tc := range cases {
+		tc.claims["iss"] = "https://issuer.example.com/"
+		tc.claims["sub"] = "user-123"
+		tc.claims["exp"] = time.Now().Add(time.Hour).Unix()
+
+		if code, _ := performOIDCRequest(t, verifier, key, "kid-1", tc.claims); code != http.StatusUnauthorized {
+			t.Errorf("%s: expected status code %d, got %d", tc.name, http.StatusUnauthorized, code)
+		}
+	}
+
+	code, user := performOIDCRequest(t, verifier, key, "kid-1", jwt.MapClaims{
+		"iss":            "https://issuer.example.com/",
+		"sub":            "user-123",
+		"email":          "user@example.com",
+		"email_verified": "true",
+		"exp":            time.Now().Add(time.Hour).Unix(),
+	})
+	if code != http.StatusOK || user == nil || user.Email != "user@example.com" {
+		t.Errorf("Expected a string email_verified to be accepted, got %d %+v", code, user)
+	}
+}
+
+func TestAuthMiddlewareWithOIDC_RejectsWrongAudience(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+	server := newTestJWKSServer(t, key, "kid-1")
+	verifier := NewOIDCVerifier(OIDCConfig{IssuerURL: "https://issuer.example.com/", Audience: "cron-observer", JWKSURL: server.URL})
+
+	code, _ := performOIDCRequest(t, verifier, key, "kid-1", jwt.MapClaims{
+		"iss":   "https://issuer.example.com/",
+		"aud":   "another-app",
+		"email": "user@example.com",
+		"exp":   time.Now().Add(time.Hour).Unix(),
+	})
+
+	if code != http.StatusUnauthorized {
+		t.Errorf("Expected status code %d, got %d", http.StatusUnauthorized, code)
+	}
+}
+
+func TestAuthMiddleware_RejectsRS256WithoutOIDC(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+
+	code, _ := performOIDCRequest(t, nil, key, "kid-1", jwt.MapClaims{
+		"email": "user@example.com",
+		"exp":   time.Now().Add(time.Hour).Unix(),
+	})
+
+	if code != http.StatusUnauthorized {
+		t.Errorf("Expected status code %d, got %d", http.StatusUnauthorized, code)
+	}
+}
@@ -0,0 +1,178 @@
+package middleware
+
+import (
+	"log"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yourusername/cron-observer/backend/internal/models"
+)
+
+// RateLimitKind identifies which per-project quota a request counts against
+type RateLimitKind string
+
+const (
+	RateLimitLogAppends    RateLimitKind = "log_appends"
+	RateLimitStatusUpdates RateLimitKind = "status_updates"
+)
+
+// rateLimitWindow is the length of a quota window
+const rateLimitWindow = time.Minute
+
+// RateLimitDefaults holds the per-minute quotas applied to projects without overrides
+type RateLimitDefaults struct {
+	LogAppendsPerMinute    int
+	StatusUpdatesPerMinute int
+}
+
+// RateLimiter counts SDK requests per project in fixed one-minute windows.
+// Counters are kept in memory, so limits apply per backend instance.
+type RateLimiter struct {
+	now func() time.Time
+
+	mu       sync.Mutex
+	defaults RateLimitDefaults
+	counters map[string]*rateLimitCounter
+}
+
+type rateLimitCounter struct {
+	windowStart time.Time
+	count       int
+}
+
+// NewRateLimiter creates a rate limiter with the given default quotas
+func NewRateLimiter(defaults RateLimitDefaults) *RateLimiter {
+	return &RateLimiter{
+		defaults: defaults,
+		now:      time.Now,
+		counters: make(map[string]*rateLimitCounter),
+	}
+}
+
+// SetDefaults replaces the quotas of projects without overrides, e.g. when the configuration is reloaded.
+// Requests already counted in the current window still count against the new quotas.
+func (l *RateLimiter) SetDefaults(defaults RateLimitDefaults) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.defaults = defaults
+}
+
+// ProjectRateLimitMiddleware enforces the project's quota for kind. It must run after
+// APIKeyMiddleware, which stores the project in the context.
+func ProjectRateLimitMiddleware(limiter *RateLimiter, kind RateLimitKind) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		project, ok := GetProjectFromContext(c)
+		if !ok {
+			c.Next()
+			return
+		}
+
+		decision := limiter.Allow(project, kind)
+		if decision.Limit <= 0 {
+			c.Next()
+			return
+		}
+
+		c.Header("X-RateLimit-Limit", strconv.Itoa(decision.Limit))
+		c.Header("X-RateLimit-Remaining", strconv.Itoa(decision.Remaining))
+		c.Header("X-RateLimit-Reset", strconv.FormatInt(decision.Reset.Unix(), 10))
+
+		if !decision.Allowed {
+			c.Header("Retry-After", strconv.Itoa(decision.RetryAfter))
+			c.JSON(http.StatusTooManyRequests, gin.H{
+				"error": "Rate limit exceeded for this project. Retry after " + strconv.Itoa(decision.RetryAfter) + " seconds",
+			})
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// RateLimitDecision is the outcome of counting a request against a project's quota
+type RateLimitDecision struct {
+	Allowed    bool
+	Limit      int       // per-minute quota; zero when the project has none
+	Remaining  int       // requests left in the current window
+	Reset      time.Time // when the current window ends
+	RetryAfter int       // seconds to wait before retrying a rejected request
+}
+
+// Allow counts a request of the project against its quota for kind. It is shared by the REST middleware and the
+// gRPC interceptors, so both APIs draw on the same per-project counters.
+func (l *RateLimiter) Allow(project *models.Project, kind RateLimitKind) RateLimitDecision {
+	limit := l.limitFor(project, kind)
+	if limit <= 0 {
+		// Zero or negative disables the limit
+		return RateLimitDecision{Allowed: true}
+	}
+
+	allowed, remaining, reset := l.allow(project.ID.Hex()+":"+string(kind), limit)
+	decision := RateLimitDecision{Allowed: allowed, Limit: limit, Remaining: remaining, Reset: reset}
+	if !allowed {
+		decision.RetryAfter = int(reset.Sub(l.now()).Seconds()) + 1
+		log.Printf("[RATE_LIMIT] Project %s exceeded %s quota of %d/min", project.ID.Hex(), kind, limit)
+	}
+	return decision
+}
+
+// limitFor returns the project's per-minute quota for kind, falling back to the defaults
+func (l *RateLimiter) limitFor(project *models.Project, kind RateLimitKind) int {
+	l.mu.Lock()
+	defaults := l.defaults
+	l.mu.Unlock()
+
+	switch kind {
+	case RateLimitLogAppends:
+		if project.RateLimits != nil && project.RateLimits.LogAppendsPerMinute > 0 {
+			return project.RateLimits.LogAppendsPerMinute
+		}
+		return defaults.LogAppendsPerMinute
+	case RateLimitStatusUpdates:
+		if project.RateLimits != nil && project.RateLimits.StatusUpdatesPerMinute > 0 {
+			return project.RateLimits.StatusUpdatesPerMinute
+		}
+		return defaults.StatusUpdatesPerMinute
+	}
+	return 0
+}
+
+// allow records a request for key and reports whether it fits within limit, along with
+// the remaining quota and when the current window resets
+func (l *RateLimiter) allow(key string, limit int) (bool, int, time.Time) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	windowStart := now.Truncate(rateLimitWindow)
+	reset := windowStart.Add(rateLimitWindow)
+
+	counter, ok := l.counters[key]
+	if !ok || !counter.windowStart.Equal(windowStart) {
+		if !ok && len(l.counters) > 0 {
+			l.evictStale(windowStart)
+		}
+		counter = &rateLimitCounter{windowStart: windowStart}
+		l.counters[key] = counter
+	}
+
+	if counter.count >= limit {
+		return false, 0, reset
+	}
+
+	counter.count++
+	return true, limit - counter.count, reset
+}
+
+// evictStale drops counters from previous windows so the map does not grow unbounded
+func (l *RateLimiter) evictStale(windowStart time.Time) {
+	for key, counter := range l.counters {
+		if counter.windowStart.Before(windowStart) {
+			delete(l.counters, key)
+		}
+	}
+}
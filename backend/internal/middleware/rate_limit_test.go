@@ -0,0 +1,46 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yourusername/cron-observer/backend/internal/models"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestProjectRateLimitMiddleware_Returns429WhenQuotaExceeded(t *testing.T) {
+	project := &models.Project{
+		ID:         primitive.NewObjectID(),
+		RateLimits: &models.ProjectRateLimits{LogAppendsPerMinute: 2},
+	}
+	limiter := NewRateLimiter(RateLimitDefaults{LogAppendsPerMinute: 100})
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.POST("/executions/:execution_uuid/logs", func(c *gin.Context) {
+		c.Set(ProjectContextKey, project)
+	}, ProjectRateLimitMiddleware(limiter, RateLimitLogAppends), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	var codes []int
+	var last *httptest.ResponseRecorder
+	for i := 0; i < 3; i++ {
+		req, _ := http.NewRequest(http.MethodPost, "/executions/abc/logs", nil)
+		last = httptest.NewRecorder()
+		router.ServeHTTP(last, req)
+		codes = append(codes, last.Code)
+	}
+
+	if codes[0] != http.StatusOK || codes[1] != http.StatusOK || codes[2] != http.StatusTooManyRequests {
+		t.Fatalf("Expected [200 200 429], got %v", codes)
+	}
+	if last.Header().Get("X-RateLimit-Limit") != "2" || last.Header().Get("X-RateLimit-Remaining") != "0" {
+		t.Errorf("Unexpected rate limit headers: %v", last.Header())
+	}
+	if last.Header().Get("Retry-After") == "" {
+		t.Errorf("Expected Retry-After header")
+	}
+}
@@ -0,0 +1,120 @@
+package middleware
+
+import (
+	"log"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yourusername/cron-observer/backend/internal/repositories"
+)
+
+// DefaultRevocationCacheTTL bounds how long another server may keep accepting a token after it was revoked
+const DefaultRevocationCacheTTL = 5 * time.Second
+
+// RevocationCache remembers the outcome of token revocation checks for a short time, so a dashboard making many
+// requests with the same token does not query the revocations collection for each of them. Revocations made on
+// this server clear it right away; those made on other servers apply once the cached outcome expires.
+type RevocationCache struct {
+	ttl time.Duration
+	now func() time.Time
+
+	mu      sync.Mutex
+	entries map[string]cachedRevocation // keyed by jti, email and iat
+}
+
+type cachedRevocation struct {
+	revoked   bool
+	expiresAt time.Time
+}
+
+// NewRevocationCache creates a cache keeping check outcomes for ttl, DefaultRevocationCacheTTL when not positive
+func NewRevocationCache(ttl time.Duration) *RevocationCache {
+	if ttl <= 0 {
+		ttl = DefaultRevocationCacheTTL
+	}
+	return &RevocationCache{
+		ttl:     ttl,
+		now:     time.Now,
+		entries: make(map[string]cachedRevocation),
+	}
+}
+
+// Clear forgets every cached outcome. Call it after storing a revocation.
+func (rc *RevocationCache) Clear() {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	rc.entries = make(map[string]cachedRevocation)
+}
+
+func (rc *RevocationCache) get(key string) (revoked bool, ok bool) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	entry, ok := rc.entries[key]
+	if !ok || !rc.now().Before(entry.expiresAt) {
+		return false, false
+	}
+	return entry.revoked, true
+}
+
+func (rc *RevocationCache) set(key string, revoked bool) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	now := rc.now()
+	// Drop expired outcomes so tokens that are no longer used do not accumulate
+	for k, entry := range rc.entries {
+		if !now.Before(entry.expiresAt) {
+			delete(rc.entries, k)
+		}
+	}
+	rc.entries[key] = cachedRevocation{revoked: revoked, expiresAt: now.Add(rc.ttl)}
+}
+
+// TokenRevocationMiddleware rejects tokens that were revoked by jti, or whose user had all tokens
+// issued before a cutoff revoked. It must run after AuthMiddleware. cache may be nil to check every request.
+func TokenRevocationMiddleware(repo repositories.Repository, cache *RevocationCache) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user, exists := GetUserFromContext(c)
+		if !exists {
+			c.Next()
+			return
+		}
+
+		key := user.TokenID + "|" + user.Email + "|" + strconv.FormatInt(user.IssuedAt.Unix(), 10)
+		revoked, cached := false, false
+		if cache != nil {
+			revoked, cached = cache.get(key)
+		}
+		if !cached {
+			var err error
+			revoked, err = repo.IsTokenRevoked(c.Request.Context(), user.TokenID, user.Email, user.IssuedAt)
+			if err != nil {
+				// Fail closed: a revoked token must never slip through because the check failed
+				log.Printf("[AUTH] Failed to check token revocation for %s: %v", user.Email, err)
+				c.JSON(http.StatusServiceUnavailable, gin.H{
+					"error": "Unable to verify token status",
+				})
+				c.Abort()
+				return
+			}
+			if cache != nil {
+				cache.set(key, revoked)
+			}
+		}
+
+		if revoked {
+			log.Printf("[AUTH] Rejected revoked token for %s (jti: %s)", user.Email, user.TokenID)
+			c.JSON(http.StatusUnauthorized, gin.H{
+				"error": "Token has been revoked",
+			})
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
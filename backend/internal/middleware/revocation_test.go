@@ -0,0 +1,91 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yourusername/cron-observer/backend/internal/models"
+	"github.com/yourusername/cron-observer/backend/internal/repositories"
+)
+
+// countingRevocations counts revocation checks and fails them on demand
+type countingRevocations struct {
+	*repositories.MemoryRepository
+	checks int
+	err    error
+}
+
+func (r *countingRevocations) IsTokenRevoked(ctx context.Context, jti string, email string, issuedAt time.Time) (bool, error) {
+	r.checks++
+	if r.err != nil {
+		return false, r.err
+	}
+	return r.MemoryRepository.IsTokenRevoked(ctx, jti, email, issuedAt)
+}
+
+func performRevocationRequest(router *gin.Engine) int {
+	req, _ := http.NewRequest(http.MethodGet, "/me", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w.Code
+}
+
+func setupRevocationRouter(repo repositories.Repository, cache *RevocationCache) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/me", func(c *gin.Context) {
+		c.Set(UserContextKey, UserInfo{Email: "user@example.com", TokenID: "token-1", IssuedAt: time.Now().Add(-time.Hour)})
+	}, TokenRevocationMiddleware(repo, cache), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+	return router
+}
+
+func TestTokenRevocationMiddleware_CachesChecksBriefly(t *testing.T) {
+	repo := &countingRevocations{MemoryRepository: repositories.NewMemoryRepository()}
+	cache := NewRevocationCache(time.Minute)
+	now := time.Now()
+	cache.now = func() time.Time { return now }
+	router := setupRevocationRouter(repo, cache)
+
+	for i := 0; i < 3; i++ {
+		if code := performRevocationRequest(router); code != http.StatusOK {
+			t.Fatalf("Expected status %d, got %d", http.StatusOK, code)
+		}
+	}
+	if repo.checks != 1 {
+		t.Errorf("Expected one revocation check for repeated requests, got %d", repo.checks)
+	}
+
+	// Revoked on another server: accepted until the cached outcome expires
+	if err := repo.CreateTokenRevocation(context.Background(), &models.TokenRevocation{JTI: "token-1"}); err != nil {
+		t.Fatalf("CreateTokenRevocation: %v", err)
+	}
+	if code := performRevocationRequest(router); code != http.StatusOK {
+		t.Errorf("Expected the cached outcome within the TTL, got %d", code)
+	}
+	now = now.Add(time.Minute)
+	if code := performRevocationRequest(router); code != http.StatusUnauthorized {
+		t.Errorf("Expected the revoked token to be rejected once the TTL passed, got %d", code)
+	}
+}
+
+func TestTokenRevocationMiddleware_FailsClosed(t *testing.T) {
+	repo := &countingRevocations{MemoryRepository: repositories.NewMemoryRepository(), err: errors.New("database unavailable")}
+	router := setupRevocationRouter(repo, NewRevocationCache(time.Minute))
+
+	for i := 0; i < 2; i++ {
+		if code := performRevocationRequest(router); code != http.StatusServiceUnavailable {
+			t.Errorf("Expected status %d when the check fails, got %d", http.StatusServiceUnavailable, code)
+		}
+	}
+	// Failed checks are not cached
+	if repo.checks != 2 {
+		t.Errorf("Expected every request to be checked again, got %d checks", repo.checks)
+	}
+}
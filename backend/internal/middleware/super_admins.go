@@ -0,0 +1,55 @@
+package middleware
+
+import (
+	"strings"
+	"sync/atomic"
+)
+
+// SuperAdmins is the set of super admin emails from configuration (SUPER_ADMINS). One set is shared by the auth
+// middleware and the handlers, so replacing it when the configuration is reloaded takes effect everywhere at once.
+// A nil set contains nobody.
+type SuperAdmins struct {
+	emails atomic.Pointer[map[string]bool]
+}
+
+// NewSuperAdmins creates a set of the given emails
+func NewSuperAdmins(emails []string) *SuperAdmins {
+	s := &SuperAdmins{}
+	s.Set(emails)
+	return s
+}
+
+// Set replaces the emails of the set. Safe to call while requests are being served.
+func (s *SuperAdmins) Set(emails []string) {
+	set := make(map[string]bool, len(emails))
+	for _, email := range emails {
+		if normalized := normalizeEmail(email); normalized != "" {
+			set[normalized] = true
+		}
+	}
+	s.emails.Store(&set)
+}
+
+// Contains reports whether email is a super admin, ignoring case and surrounding whitespace
+func (s *SuperAdmins) Contains(email string) bool {
+	if s == nil {
+		return false
+	}
+	set := s.emails.Load()
+	return set != nil && (*set)[normalizeEmail(email)]
+}
+
+// Len returns the number of super admins
+func (s *SuperAdmins) Len() int {
+	if s == nil {
+		return 0
+	}
+	if set := s.emails.Load(); set != nil {
+		return len(*set)
+	}
+	return 0
+}
+
+func normalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
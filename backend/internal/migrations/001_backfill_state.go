@@ -0,0 +1,34 @@
+package migrations
+
+import (
+	"context"
+
+	"github.com/yourusername/cron-observer/backend/internal/database"
+	"github.com/yourusername/cron-observer/backend/internal/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// backfillState sets the runtime state of tasks and task groups created before state was tracked. The scheduler
+// corrects the state of tasks inside a time window on its next pass.
+var backfillState = Migration{
+	Version:     1,
+	Description: "Backfill state of tasks and task groups",
+	Up: func(ctx context.Context, db *mongo.Database) error {
+		missing := bson.M{"$or": bson.A{
+			bson.M{"state": bson.M{"$exists": false}},
+			bson.M{"state": nil},
+			bson.M{"state": ""},
+		}}
+
+		_, err := db.Collection(database.CollectionTasks).UpdateMany(ctx, missing,
+			bson.M{"$set": bson.M{"state": models.TaskStateNotRunning}})
+		if err != nil {
+			return err
+		}
+
+		_, err = db.Collection(database.CollectionTaskGroups).UpdateMany(ctx, missing,
+			bson.M{"$set": bson.M{"state": models.TaskGroupStateNotRunning}})
+		return err
+	},
+}
@@ -0,0 +1,30 @@
+package migrations
+
+import (
+	"context"
+
+	"github.com/yourusername/cron-observer/backend/internal/database"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// backfillMetadataKeys stores the metadata keys of tasks created before task search matched them through
+// metadata_keys. Tasks keep them up to date on every create and update.
+var backfillMetadataKeys = Migration{
+	Version:     2,
+	Description: "Backfill metadata_keys of tasks",
+	Up: func(ctx context.Context, db *mongo.Database) error {
+		withMetadata := bson.M{"metadata": bson.M{"$type": "object", "$ne": bson.M{}}}
+
+		_, err := db.Collection(database.CollectionTasks).UpdateMany(ctx, withMetadata, mongo.Pipeline{
+			{{Key: "$set", Value: bson.M{"metadata_keys": bson.M{"$sortArray": bson.M{
+				"input": bson.M{"$map": bson.M{
+					"input": bson.M{"$objectToArray": "$metadata"},
+					"in":    "$$this.k",
+				}},
+				"sortBy": 1,
+			}}}}},
+		})
+		return err
+	},
+}
@@ -0,0 +1,248 @@
+// Package migrations applies versioned changes to stored data, such as renaming fields or backfilling new ones,
+// so that every environment goes through the same changes in the same order. Applied versions are recorded in
+// the migrations collection; migrations run at startup (Database.MigrateOnStartup) or through the migrate CLI.
+package migrations
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"sort"
+	"text/tabwriter"
+	"time"
+
+	"github.com/yourusername/cron-observer/backend/internal/database"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Migration is a versioned change to stored data. Up may be run again after an interrupted attempt, so it must
+// be idempotent.
+type Migration struct {
+	Version     int
+	Description string
+	Up          func(ctx context.Context, db *mongo.Database) error
+}
+
+// all lists every migration in version order. Versions are never reused or reordered once released.
+var all = []Migration{
+	backfillState,
+	backfillMetadataKeys,
+}
+
+// State of a migration record
+const (
+	StateRunning = "running"
+	StateApplied = "applied"
+	StateFailed  = "failed"
+)
+
+// runningLease is how long a running migration blocks other instances; after it a crashed attempt is retried
+const runningLease = time.Hour
+
+// ErrLocked is returned when another instance is applying a migration
+var ErrLocked = errors.New("migration is being applied by another instance")
+
+// record is the document stored for each attempted migration
+type record struct {
+	Version     int       `bson:"_id"`
+	Description string    `bson:"description"`
+	State       string    `bson:"state"`
+	StartedAt   time.Time `bson:"started_at"`
+	AppliedAt   time.Time `bson:"applied_at,omitempty"`
+	Error       string    `bson:"error,omitempty"`
+}
+
+// Status describes a migration and whether it has been applied
+type Status struct {
+	Migration
+	State     string // Empty when the migration was never attempted
+	AppliedAt time.Time
+	Error     string
+}
+
+// Runner applies migrations to a database
+type Runner struct {
+	db         *mongo.Database
+	migrations []Migration
+}
+
+// NewRunner creates a runner for the registered migrations
+func NewRunner(db *mongo.Database) *Runner {
+	return &Runner{db: db, migrations: all}
+}
+
+// validate checks that versions are positive, unique and in ascending order
+func validate(migrations []Migration) error {
+	for i, m := range migrations {
+		if m.Version <= 0 {
+			return fmt.Errorf("migration %q has invalid version %d", m.Description, m.Version)
+		}
+		if m.Up == nil {
+			return fmt.Errorf("migration %d has no Up function", m.Version)
+		}
+		if i > 0 && m.Version <= migrations[i-1].Version {
+			return fmt.Errorf("migration %d is listed after version %d", m.Version, migrations[i-1].Version)
+		}
+	}
+	return nil
+}
+
+func (r *Runner) collection() *mongo.Collection {
+	return r.db.Collection(database.CollectionMigrations)
+}
+
+// Up applies every pending migration in version order and returns the versions it applied. It stops at the
+// first failure, since later migrations may depend on earlier ones.
+func (r *Runner) Up(ctx context.Context) ([]int, error) {
+	if err := validate(r.migrations); err != nil {
+		return nil, err
+	}
+
+	var applied []int
+	for _, m := range r.migrations {
+		claimed, err := r.claim(ctx, m)
+		if err != nil {
+			return applied, err
+		}
+		if !claimed {
+			continue
+		}
+
+		log.Printf("[Migrations] Applying %d: %s", m.Version, m.Description)
+		started := time.Now()
+		if err := m.Up(ctx, r.db); err != nil {
+			r.finish(m, StateFailed, err)
+			return applied, fmt.Errorf("migration %d (%s) failed: %w", m.Version, m.Description, err)
+		}
+		if err := r.finish(m, StateApplied, nil); err != nil {
+			return applied, fmt.Errorf("failed to record migration %d: %w", m.Version, err)
+		}
+		log.Printf("[Migrations] Applied %d in %s", m.Version, time.Since(started).Round(time.Millisecond))
+		applied = append(applied, m.Version)
+	}
+	return applied, nil
+}
+
+// claim marks a migration as running, unless it was applied or another instance is applying it. A failed
+// attempt, or a running one whose lease expired, is claimed again. Reports whether the migration should run.
+func (r *Runner) claim(ctx context.Context, m Migration) (bool, error) {
+	now := time.Now()
+	filter := bson.M{
+		"_id": m.Version,
+		"$or": bson.A{
+			bson.M{"state": StateFailed},
+			bson.M{"state": StateRunning, "started_at": bson.M{"$lt": now.Add(-runningLease)}},
+		},
+	}
+	update := bson.M{
+		"$set":   bson.M{"description": m.Description, "state": StateRunning, "started_at": now},
+		"$unset": bson.M{"error": ""},
+	}
+
+	_, err := r.collection().UpdateOne(ctx, filter, update, options.Update().SetUpsert(true))
+	if err == nil {
+		return true, nil
+	}
+	if !mongo.IsDuplicateKeyError(err) {
+		return false, err
+	}
+
+	// The record exists and did not match: the migration was applied, or is running elsewhere
+	var existing record
+	if err := r.collection().FindOne(ctx, bson.M{"_id": m.Version}).Decode(&existing); err != nil {
+		return false, err
+	}
+	if existing.State == StateApplied {
+		return false, nil
+	}
+	return false, fmt.Errorf("%w: version %d started at %s", ErrLocked, m.Version, existing.StartedAt.Format(time.RFC3339))
+}
+
+// finish records the outcome of a claimed migration. It runs even when ctx was cancelled by the migration.
+func (r *Runner) finish(m Migration, state string, migrationErr error) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	set := bson.M{"state": state}
+	if state == StateApplied {
+		set["applied_at"] = time.Now()
+	}
+	if migrationErr != nil {
+		set["error"] = migrationErr.Error()
+	}
+
+	_, err := r.collection().UpdateOne(ctx, bson.M{"_id": m.Version}, bson.M{"$set": set})
+	if err != nil {
+		log.Printf("[Migrations] Failed to record state %s of %d: %v", state, m.Version, err)
+	}
+	return err
+}
+
+// Status returns every registered migration with its recorded state, in version order
+func (r *Runner) Status(ctx context.Context) ([]Status, error) {
+	cursor, err := r.collection().Find(ctx, bson.M{})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var records []record
+	if err := cursor.All(ctx, &records); err != nil {
+		return nil, err
+	}
+	byVersion := make(map[int]record, len(records))
+	for _, rec := range records {
+		byVersion[rec.Version] = rec
+	}
+
+	statuses := make([]Status, 0, len(r.migrations))
+	for _, m := range r.migrations {
+		rec := byVersion[m.Version]
+		statuses = append(statuses, Status{Migration: m, State: rec.State, AppliedAt: rec.AppliedAt, Error: rec.Error})
+	}
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i].Version < statuses[j].Version })
+	return statuses, nil
+}
+
+// Command runs a migrate CLI subcommand: "up" applies pending migrations, "status" lists every migration
+func Command(ctx context.Context, runner *Runner, args []string, out io.Writer) error {
+	if len(args) == 0 {
+		return errors.New("usage: migrate <up|status>")
+	}
+
+	switch args[0] {
+	case "up":
+		applied, err := runner.Up(ctx)
+		if len(applied) == 0 && err == nil {
+			fmt.Fprintln(out, "No pending migrations")
+		}
+		for _, version := range applied {
+			fmt.Fprintf(out, "Applied migration %d\n", version)
+		}
+		return err
+	case "status":
+		statuses, err := runner.Status(ctx)
+		if err != nil {
+			return err
+		}
+		w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "VERSION\tSTATE\tAPPLIED AT\tDESCRIPTION")
+		for _, s := range statuses {
+			state, appliedAt := s.State, ""
+			if state == "" {
+				state = "pending"
+			}
+			if !s.AppliedAt.IsZero() {
+				appliedAt = s.AppliedAt.Format(time.RFC3339)
+			}
+			fmt.Fprintf(w, "%d\t%s\t%s\t%s\n", s.Version, state, appliedAt, s.Description)
+		}
+		return w.Flush()
+	default:
+		return fmt.Errorf("unknown migrate command %q (expected up or status)", args[0])
+	}
+}
@@ -0,0 +1,50 @@
+package migrations
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func noop(ctx context.Context, db *mongo.Database) error { return nil }
+
+func TestRegisteredMigrationsAreValid(t *testing.T) {
+	if err := validate(all); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name       string
+		migrations []Migration
+	}{
+		{"zero version", []Migration{{Version: 0, Up: noop}}},
+		{"missing up", []Migration{{Version: 1}}},
+		{"duplicate version", []Migration{{Version: 1, Up: noop}, {Version: 1, Up: noop}}},
+		{"out of order", []Migration{{Version: 2, Up: noop}, {Version: 1, Up: noop}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := validate(tt.migrations); err == nil {
+				t.Fatal("expected an error")
+			}
+		})
+	}
+
+	if err := validate([]Migration{{Version: 1, Up: noop}, {Version: 3, Up: noop}}); err != nil {
+		t.Fatalf("versions with gaps should be valid: %v", err)
+	}
+}
+
+func TestCommand_RejectsUnknownSubcommands(t *testing.T) {
+	var out bytes.Buffer
+	if err := Command(context.Background(), &Runner{}, nil, &out); err == nil {
+		t.Error("expected a usage error without a subcommand")
+	}
+	if err := Command(context.Background(), &Runner{}, []string{"down"}, &out); err == nil {
+		t.Error("expected an error for an unknown subcommand")
+	}
+}
@@ -0,0 +1,42 @@
+package models
+
+import "time"
+
+// CalendarRunStatus is the state of a run in the run calendar: the status of its execution, or MISSED or UPCOMING
+// for planned runs without one
+type CalendarRunStatus string
+
+const (
+	CalendarRunMissed   CalendarRunStatus = "MISSED"   // The planned run did not start
+	CalendarRunUpcoming CalendarRunStatus = "UPCOMING" // The planned run is in the future or may still start
+)
+
+// CalendarRun is a planned fire time of a task, the execution that started for it, or both
+type CalendarRun struct {
+	ScheduledFor *time.Time        `json:"scheduled_for,omitempty" example:"2025-01-15T09:00:00Z"` // Omitted for executions no planned run accounts for, e.g. manual triggers
+	Status       CalendarRunStatus `json:"status" enums:"QUEUED,PENDING,RUNNING,SUCCESS,FAILED,MISSED,UPCOMING" example:"SUCCESS"`
+	Execution    *ExecutionSummary `json:"execution,omitempty"`
+	DelayMs      *int64            `json:"delay_ms,omitempty" example:"1200"` // How long after the planned time the execution started
+}
+
+// TaskRunCalendar overlays the planned runs of a task with its executions
+type TaskRunCalendar struct {
+	TaskUUID       string        `json:"task_uuid" example:"550e8400-e29b-41d4-a716-446655440000"`
+	TaskName       string        `json:"task_name" example:"nightly-invoices"`
+	CronExpression string        `json:"cron_expression,omitempty" example:"0 2 * * *"` // Omitted for tasks without a schedule, which have no planned runs
+	Timezone       string        `json:"timezone,omitempty" example:"America/New_York"` // Timezone the planned runs were expanded in
+	Planned        int           `json:"planned" example:"31"`
+	Missed         int           `json:"missed" example:"1"`
+	Unplanned      int           `json:"unplanned" example:"2"`               // Executions no planned run accounts for
+	Truncated      bool          `json:"truncated,omitempty" example:"false"` // The range has more planned runs than are listed
+	Runs           []CalendarRun `json:"runs"`                                // Chronological
+}
+
+// RunCalendarResponse is the planned-vs-actual run calendar of a project
+// @Description RunCalendarResponse is the planned-vs-actual run calendar of a project
+type RunCalendarResponse struct {
+	From      time.Time         `json:"from" example:"2025-01-01T00:00:00Z"`
+	To        time.Time         `json:"to" example:"2025-01-31T23:59:59Z"`
+	Tasks     []TaskRunCalendar `json:"tasks"`
+	Truncated bool              `json:"truncated,omitempty" example:"false"` // The range has more executions than were read; later runs may show as MISSED
+}
@@ -0,0 +1,6 @@
+package models
+
+// ConfigReloadResponse represents the response for reloading the configuration
+type ConfigReloadResponse struct {
+	Changed []string `json:"changed" example:"auth.super_admins,rate_limit"` // Reloadable settings that changed and were applied; empty when nothing changed
+}
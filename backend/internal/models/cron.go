@@ -0,0 +1,19 @@
+package models
+
+import "time"
+
+// ValidateCronRequest represents the request DTO for checking a cron expression
+type ValidateCronRequest struct {
+	Expression string `json:"expression" binding:"required" example:"0 */15 9-16 * * 1-5"`
+	Timezone   string `json:"timezone,omitempty" binding:"omitempty,timezone" example:"Europe/Berlin"` // Timezone of next_runs; defaults to UTC
+}
+
+// ValidateCronResponse is the result of parsing a cron expression with the scheduler's parser
+// @Description ValidateCronResponse is the result of parsing a cron expression with the scheduler's parser
+type ValidateCronResponse struct {
+	Valid       bool        `json:"valid" example:"true"`
+	Error       string      `json:"error,omitempty" example:"end of range (61) above maximum (59): 61"`               // Parser error; set when valid is false
+	Description string      `json:"description,omitempty" example:"every 15 minutes between 9am and 5pm on weekdays"` // English description of the schedule
+	Timezone    string      `json:"timezone" example:"Europe/Berlin"`
+	NextRuns    []time.Time `json:"next_runs,omitempty" example:"2025-01-15T09:00:00+01:00"` // Next 5 fire times in timezone
+}
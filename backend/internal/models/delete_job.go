@@ -0,0 +1,40 @@
+package models
+
+import "time"
+
+// StuckDeleteTask is a task whose delete job has not completed
+// @Description StuckDeleteTask is a task whose delete job has not completed
+type StuckDeleteTask struct {
+	TaskUUID  string     `json:"task_uuid" example:"550e8400-e29b-41d4-a716-446655440000"`
+	ProjectID string     `json:"project_id" example:"507f1f77bcf86cd799439011"`
+	Name      string     `json:"name" example:"Daily Backup"`
+	Status    TaskStatus `json:"status" enums:"PENDING_DELETE,DELETE_FAILED" example:"DELETE_FAILED"`
+	UpdatedAt time.Time  `json:"updated_at" example:"2025-01-15T10:00:00Z"` // When the delete was requested or last failed
+}
+
+// ListStuckDeletesResponse represents the response for listing stuck delete jobs
+type ListStuckDeletesResponse struct {
+	Tasks []StuckDeleteTask `json:"tasks"`
+}
+
+// RequeueDeletesRequest represents the request DTO for re-publishing stuck delete jobs
+type RequeueDeletesRequest struct {
+	TaskUUIDs []string `json:"task_uuids,omitempty" binding:"omitempty,max=1000"` // Limits the requeue to these tasks; empty requeues every stuck task
+}
+
+// RequeueDeletesResponse represents the response for re-publishing stuck delete jobs
+type RequeueDeletesResponse struct {
+	Requeued []string          `json:"requeued"`         // Task UUIDs whose delete job was published
+	Failed   map[string]string `json:"failed,omitempty"` // Publish errors by task UUID
+}
+
+// NewStuckDeleteTask describes a task waiting on its delete job
+func NewStuckDeleteTask(task *Task) StuckDeleteTask {
+	return StuckDeleteTask{
+		TaskUUID:  task.UUID,
+		ProjectID: task.ProjectID.Hex(),
+		Name:      task.Name,
+		Status:    task.Status,
+		UpdatedAt: task.UpdatedAt,
+	}
+}
@@ -0,0 +1,13 @@
+package models
+
+import "time"
+
+// DispatchBacklog shows how many scheduled firings of a project wait in the scheduler's dispatch queue, e.g. because
+// the project's dispatch_rate_limit holds them back
+// @Description DispatchBacklog shows how many scheduled firings of a project wait in the scheduler's dispatch queue
+type DispatchBacklog struct {
+	RateLimit      int        `json:"rate_limit" example:"20"`                                   // Current dispatch_rate_limit of the project; 0 is unlimited
+	Queued         int        `json:"queued" example:"35"`                                       // Firings waiting to be dispatched
+	OldestQueuedAt *time.Time `json:"oldest_queued_at,omitempty" example:"2025-01-15T10:00:00Z"` // When the longest waiting firing was queued
+	Throttled      int64      `json:"throttled" example:"120"`                                   // Firings the rate limit delayed since the scheduler started
+}
@@ -12,3 +12,10 @@ type DeleteTaskResponse struct {
 	TaskUUID string `json:"task_uuid" example:"550e8400-e29b-41d4-a716-446655440000"`
 	Message  string `json:"message" example:"Task deletion has been scheduled"`
 }
+
+// DeleteProjectResponse represents the response for async project deletion
+type DeleteProjectResponse struct {
+	Status    string `json:"status" example:"PENDING_DELETE" enums:"PENDING_DELETE"`
+	ProjectID string `json:"project_id" example:"507f1f77bcf86cd799439011"`
+	Message   string `json:"message" example:"Project deletion has been scheduled"`
+}
@@ -20,13 +20,55 @@ type Execution struct {
 	UUID      string             `json:"uuid" bson:"uuid" example:"550e8400-e29b-41d4-a716-446655440000"`
 	TaskID    primitive.ObjectID `json:"task_id" bson:"task_id" example:"507f1f77bcf86cd799439011"`
 	TaskUUID  string             `json:"task_uuid" bson:"task_uuid" example:"550e8400-e29b-41d4-a716-446655440000"`
-	Status    ExecutionStatus    `json:"status" bson:"status" enums:"PENDING,RUNNING,SUCCESS,FAILED" example:"PENDING"`
+	Status    ExecutionStatus    `json:"status" bson:"status" enums:"QUEUED,PENDING,RUNNING,SUCCESS,FAILED" example:"PENDING"`
 	StartedAt time.Time          `json:"started_at" bson:"started_at" example:"2025-01-15T10:00:00Z"`
 	EndedAt   *time.Time         `json:"ended_at,omitempty" bson:"ended_at,omitempty" example:"2025-01-15T10:00:05Z"`
 	Error     string             `json:"error,omitempty" bson:"error,omitempty" example:"Connection timeout"`
 	Logs      []LogEntry         `json:"logs,omitempty" bson:"logs,omitempty"`
 	CreatedAt time.Time          `json:"created_at" bson:"created_at" example:"2025-01-15T10:00:00Z"`
 	UpdatedAt time.Time          `json:"updated_at" bson:"updated_at" example:"2025-01-15T10:00:00Z"`
+
+	HeartbeatAt   *time.Time      `json:"heartbeat_at,omitempty" bson:"heartbeat_at,omitempty" example:"2025-01-15T10:00:03Z"`            // Last worker heartbeat, reported over the gRPC SDK API
+	Source        ExecutionSource `json:"source,omitempty" bson:"source,omitempty" enums:"SCHEDULER,CLIENT,CHECK_IN" example:"SCHEDULER"` // Empty means SCHEDULER
+	ScheduledFor  *time.Time      `json:"scheduled_for,omitempty" bson:"scheduled_for,omitempty" example:"2025-01-15T10:00:00Z"`          // Fire time a recovered check-in execution is linked to
+	LogsTrimmedAt *time.Time      `json:"logs_trimmed_at,omitempty" bson:"logs_trimmed_at,omitempty" example:"2025-02-15T03:30:00Z"`      // When the log retention policy removed the logs
+	QueuedAt      *time.Time      `json:"queued_at,omitempty" bson:"queued_at,omitempty" example:"2025-01-15T09:59:58Z"`                  // When the firing was queued for a limit; started_at is when it was dispatched
+	Endpoint      string          `json:"endpoint,omitempty" bson:"endpoint,omitempty" example:"https://dr.example.com/execute"`          // Execution endpoint the scheduler sent the execution to; the failover endpoint when the primary failed
+}
+
+// ExecutionSummary is an execution without its logs
+// @Description ExecutionSummary is an execution without its logs
+type ExecutionSummary struct {
+	UUID       string          `json:"uuid" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Status     ExecutionStatus `json:"status" enums:"QUEUED,PENDING,RUNNING,SUCCESS,FAILED" example:"SUCCESS"`
+	StartedAt  time.Time       `json:"started_at" example:"2025-01-15T10:00:00Z"`
+	EndedAt    *time.Time      `json:"ended_at,omitempty" example:"2025-01-15T10:00:05Z"`
+	Error      string          `json:"error,omitempty" example:"Connection timeout"`
+	DurationMs *int64          `json:"duration_ms,omitempty" example:"5000"` // Omitted while the execution is still running
+}
+
+// Summary returns the execution without its logs
+func (e *Execution) Summary() *ExecutionSummary {
+	summary := &ExecutionSummary{
+		UUID:      e.UUID,
+		Status:    e.Status,
+		StartedAt: e.StartedAt,
+		EndedAt:   e.EndedAt,
+		Error:     e.Error,
+	}
+	if e.EndedAt != nil {
+		durationMs := e.EndedAt.Sub(e.StartedAt).Milliseconds()
+		summary.DurationMs = &durationMs
+	}
+	return summary
+}
+
+// FailureStatDate returns the UTC day (YYYY-MM-DD) a failed execution is counted under in the daily failure stats
+func (e *Execution) FailureStatDate() string {
+	if e.EndedAt != nil {
+		return e.EndedAt.UTC().Format("2006-01-02")
+	}
+	return e.StartedAt.UTC().Format("2006-01-02")
 }
 
 // ExecutionStatus defines the status of an execution
@@ -37,8 +79,41 @@ const (
 	ExecutionStatusRunning ExecutionStatus = "RUNNING"
 	ExecutionStatusSuccess ExecutionStatus = "SUCCESS"
 	ExecutionStatusFailed  ExecutionStatus = "FAILED"
+
+	// Internal-only: set by the scheduler on firings a dispatch limit holds back, until they are dispatched as
+	// PENDING. Not accepted from clients.
+	ExecutionStatusQueued ExecutionStatus = "QUEUED"
 )
 
+// ExecutionSource records what opened an execution
+type ExecutionSource string
+
+const (
+	// ExecutionSourceScheduler marks executions dispatched by the scheduler
+	ExecutionSourceScheduler ExecutionSource = "SCHEDULER"
+	// ExecutionSourceClient marks executions opened by an SDK for a run the tool did not trigger
+	ExecutionSourceClient ExecutionSource = "CLIENT"
+	// ExecutionSourceCheckIn marks executions recreated from a client report for an execution the server had no record of
+	ExecutionSourceCheckIn ExecutionSource = "CHECK_IN"
+)
+
+// CreateClientExecutionRequest opens an execution for a run scheduled outside the tool
+type CreateClientExecutionRequest struct {
+	Status    ExecutionStatus `json:"status,omitempty" binding:"omitempty,oneof=RUNNING SUCCESS FAILED" enums:"RUNNING,SUCCESS,FAILED" example:"RUNNING"` // Defaults to RUNNING; SUCCESS or FAILED record a finished run in one call
+	StartedAt *time.Time      `json:"started_at,omitempty" example:"2025-01-15T10:00:00Z"`                                                                // Defaults to now
+	Error     string          `json:"error,omitempty" example:"Connection timeout"`                                                                       // Only recorded with FAILED
+}
+
+// ExecutionErrorTimeout is the error recorded on executions failed by the server-side timeout
+const ExecutionErrorTimeout = "timeout"
+
+// ExecutionErrorCircuitOpen is the error recorded on executions failed without being sent because their execution
+// endpoint kept failing
+const ExecutionErrorCircuitOpen = "circuit_open"
+
+// ExecutionErrorSchedulerStopped is the error recorded on QUEUED executions the scheduler stopped before dispatching
+const ExecutionErrorSchedulerStopped = "scheduler stopped"
+
 // PaginatedExecutionsResponse represents a paginated response for executions
 type PaginatedExecutionsResponse struct {
 	Data       []*Execution `json:"data"`
@@ -0,0 +1,8 @@
+package models
+
+// GraphQLRequest represents a GraphQL query posted to the GraphQL endpoint
+type GraphQLRequest struct {
+	Query         string                 `json:"query" binding:"required" example:"{ project(id: \"507f1f77bcf86cd799439011\") { name tasks { name lastExecution { status } } } }"`
+	OperationName string                 `json:"operationName,omitempty" example:"ProjectPage"`
+	Variables     map[string]interface{} `json:"variables,omitempty" swaggertype:"object"`
+}
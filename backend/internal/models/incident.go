@@ -0,0 +1,83 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// IncidentStatus is the state of an incident
+type IncidentStatus string
+
+const (
+	IncidentStatusOpen         IncidentStatus = "OPEN"         // The task is failing and nobody has acknowledged it
+	IncidentStatusAcknowledged IncidentStatus = "ACKNOWLEDGED" // Someone is looking into the failures
+	IncidentStatusResolved     IncidentStatus = "RESOLVED"     // The task succeeded again or the incident was resolved by hand
+)
+
+// Incident groups the consecutive failures of a task. It is opened by the first failure, updated by the following
+// ones and resolved by the next successful execution, and alerts are sent when its state changes rather than for
+// every failure. A task has at most one incident that is not resolved.
+// @Description Incident groups the consecutive failures of a task
+type Incident struct {
+	ID                    primitive.ObjectID `json:"id,omitempty" bson:"_id,omitempty" example:"507f1f77bcf86cd799439011"`
+	UUID                  string             `json:"uuid" bson:"uuid" example:"550e8400-e29b-41d4-a716-446655440000"`
+	ProjectID             primitive.ObjectID `json:"project_id" bson:"project_id" example:"507f1f77bcf86cd799439011"`
+	TaskUUID              string             `json:"task_uuid" bson:"task_uuid" example:"550e8400-e29b-41d4-a716-446655440000"`
+	TaskName              string             `json:"task_name" bson:"task_name" example:"nightly-invoices"`
+	Severity              TaskSeverity       `json:"severity" bson:"severity" enums:"CRITICAL,HIGH,NORMAL,LOW" example:"HIGH"` // Severity of the task when the incident was opened
+	Status                IncidentStatus     `json:"status" bson:"status" enums:"OPEN,ACKNOWLEDGED,RESOLVED" example:"OPEN"`
+	FailureCount          int                `json:"failure_count" bson:"failure_count" example:"3"`
+	FirstExecutionUUID    string             `json:"first_execution_uuid" bson:"first_execution_uuid" example:"550e8400-e29b-41d4-a716-446655440000"`
+	LastExecutionUUID     string             `json:"last_execution_uuid" bson:"last_execution_uuid" example:"550e8400-e29b-41d4-a716-446655440000"`
+	LastError             string             `json:"last_error,omitempty" bson:"last_error,omitempty" example:"exit status 1"`
+	OpenedAt              time.Time          `json:"opened_at" bson:"opened_at" example:"2025-01-15T10:00:00Z"`
+	LastFailureAt         time.Time          `json:"last_failure_at" bson:"last_failure_at" example:"2025-01-15T10:30:00Z"`
+	AcknowledgedAt        *time.Time         `json:"acknowledged_at,omitempty" bson:"acknowledged_at,omitempty" example:"2025-01-15T10:05:00Z"`
+	AcknowledgedBy        string             `json:"acknowledged_by,omitempty" bson:"acknowledged_by,omitempty" example:"oncall@example.com"`
+	ResolvedAt            *time.Time         `json:"resolved_at,omitempty" bson:"resolved_at,omitempty" example:"2025-01-15T11:00:00Z"`
+	ResolvedBy            string             `json:"resolved_by,omitempty" bson:"resolved_by,omitempty" example:"oncall@example.com"`                                           // Empty when a successful execution resolved the incident
+	ResolvedExecutionUUID string             `json:"resolved_execution_uuid,omitempty" bson:"resolved_execution_uuid,omitempty" example:"550e8400-e29b-41d4-a716-446655440000"` // The successful execution that resolved the incident
+	Comments              []IncidentComment  `json:"comments,omitempty" bson:"comments,omitempty"`
+	CreatedAt             time.Time          `json:"created_at" bson:"created_at" example:"2025-01-15T10:00:00Z"`
+	UpdatedAt             time.Time          `json:"updated_at" bson:"updated_at" example:"2025-01-15T10:30:00Z"`
+}
+
+// IncidentComment is a note left on an incident, e.g. by the person investigating it
+type IncidentComment struct {
+	Author    string    `json:"author" bson:"author" example:"oncall@example.com"`
+	Message   string    `json:"message" bson:"message" example:"Upstream API is down, waiting for the vendor"`
+	CreatedAt time.Time `json:"created_at" bson:"created_at" example:"2025-01-15T10:10:00Z"`
+}
+
+// IncidentFailure is a failed execution recorded on the active incident of its task
+type IncidentFailure struct {
+	IncidentUUID  string // UUID of the incident when the failure opens one
+	ProjectID     primitive.ObjectID
+	TaskUUID      string
+	TaskName      string
+	Severity      TaskSeverity
+	ExecutionUUID string
+	Error         string
+	FailedAt      time.Time
+}
+
+// IncidentListFilter narrows the incidents of a project. Zero-valued fields do not filter.
+type IncidentListFilter struct {
+	Status   IncidentStatus
+	TaskUUID string
+}
+
+// AddIncidentCommentRequest represents the request DTO for commenting on an incident
+type AddIncidentCommentRequest struct {
+	Message string `json:"message" binding:"required,min=1,max=2000" example:"Upstream API is down, waiting for the vendor"`
+}
+
+// PaginatedIncidentsResponse represents a paginated response for incidents
+type PaginatedIncidentsResponse struct {
+	Data       []*Incident `json:"data"`
+	Page       int         `json:"page"`
+	PageSize   int         `json:"page_size"`
+	TotalCount int64       `json:"total_count"`
+	TotalPages int         `json:"total_pages"`
+}
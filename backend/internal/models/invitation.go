@@ -0,0 +1,44 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// InvitationStatus represents the lifecycle state of a project invitation
+type InvitationStatus string
+
+const (
+	InvitationStatusPending  InvitationStatus = "PENDING"
+	InvitationStatusAccepted InvitationStatus = "ACCEPTED"
+	InvitationStatusRevoked  InvitationStatus = "REVOKED"
+	InvitationStatusExpired  InvitationStatus = "EXPIRED"
+)
+
+// Invitation represents a pending invite for an email address to join a project
+// @Description Invitation represents a pending invite for an email address to join a project
+type Invitation struct {
+	ID         primitive.ObjectID `json:"id" bson:"_id,omitempty" example:"507f1f77bcf86cd799439011"`
+	UUID       string             `json:"uuid" bson:"uuid" example:"550e8400-e29b-41d4-a716-446655440000"`
+	ProjectID  primitive.ObjectID `json:"project_id" bson:"project_id" example:"507f1f77bcf86cd799439011"`
+	Email      string             `json:"email" bson:"email" example:"user@example.com"`
+	Role       ProjectUserRole    `json:"role" bson:"role" enums:"admin,editor,viewer" example:"editor"`
+	Status     InvitationStatus   `json:"status" bson:"status" enums:"PENDING,ACCEPTED,REVOKED,EXPIRED" example:"PENDING"`
+	InvitedBy  string             `json:"invited_by" bson:"invited_by" example:"admin@example.com"`
+	ExpiresAt  time.Time          `json:"expires_at" bson:"expires_at" example:"2025-01-22T10:00:00Z"`
+	AcceptedAt *time.Time         `json:"accepted_at,omitempty" bson:"accepted_at,omitempty" example:"2025-01-16T10:00:00Z"`
+	CreatedAt  time.Time          `json:"created_at" bson:"created_at" example:"2025-01-15T10:00:00Z"`
+	UpdatedAt  time.Time          `json:"updated_at" bson:"updated_at" example:"2025-01-15T10:00:00Z"`
+}
+
+// CreateInvitationRequest represents the request DTO for inviting a user to a project
+type CreateInvitationRequest struct {
+	Email string          `json:"email" binding:"required,email" example:"user@example.com"`
+	Role  ProjectUserRole `json:"role" binding:"required,oneof=admin editor viewer" example:"editor"`
+}
+
+// AcceptInvitationRequest represents the request DTO for accepting an invitation
+type AcceptInvitationRequest struct {
+	Token string `json:"token" binding:"required" example:"550e8400-e29b-41d4-a716-446655440000.c2lnbmF0dXJl"`
+}
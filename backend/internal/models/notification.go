@@ -0,0 +1,103 @@
+package models
+
+import "time"
+
+// NotificationChannelType is how a notification channel delivers alerts
+type NotificationChannelType string
+
+const (
+	NotificationChannelEmail     NotificationChannelType = "email"     // Emails the listed addresses, or the project users
+	NotificationChannelSlack     NotificationChannelType = "slack"     // Posts a message to a Slack incoming webhook
+	NotificationChannelWebhook   NotificationChannelType = "webhook"   // POSTs the alert as JSON to a URL
+	NotificationChannelPagerDuty NotificationChannelType = "pagerduty" // Triggers a PagerDuty incident through the Events API v2
+)
+
+// NotificationChannel is a named destination for the project's alerts. Projects without channels email their users.
+// @Description NotificationChannel is a named destination for the project's alerts
+type NotificationChannel struct {
+	Name          string                  `json:"name" bson:"name" example:"ops-slack"`
+	Type          NotificationChannelType `json:"type" bson:"type" enums:"email,slack,webhook,pagerduty" example:"slack"`
+	Emails        []string                `json:"emails,omitempty" bson:"emails,omitempty" example:"ops@example.com"`                            // email: recipients; empty sends to the project users
+	URL           string                  `json:"url,omitempty" bson:"url,omitempty" example:"https://hooks.slack.com/services/T000/B000/XXXX"`  // slack and webhook: where alerts are posted
+	RoutingKey    string                  `json:"routing_key,omitempty" bson:"routing_key,omitempty" example:"R0123456789ABCDEF0123456789ABCDE"` // pagerduty: integration key of the service
+	DigestMinutes int                     `json:"digest_minutes,omitempty" bson:"digest_minutes,omitempty" example:"60"`                         // email: alerts are collected into one email every this many minutes; 0 sends each alert
+	CreatedAt     time.Time               `json:"created_at" bson:"created_at" example:"2025-01-15T10:00:00Z"`
+	UpdatedAt     time.Time               `json:"updated_at" bson:"updated_at" example:"2025-01-15T10:00:00Z"`
+}
+
+// FindNotificationChannel returns the project's notification channel with the given name
+func (p *Project) FindNotificationChannel(name string) (*NotificationChannel, bool) {
+	for i := range p.NotificationChannels {
+		if p.NotificationChannels[i].Name == name {
+			return &p.NotificationChannels[i], true
+		}
+	}
+	return nil, false
+}
+
+// CreateNotificationChannelRequest represents the request DTO for adding a notification channel to a project
+type CreateNotificationChannelRequest struct {
+	Name          string                  `json:"name" binding:"required,env_name" example:"ops-slack"`
+	Type          NotificationChannelType `json:"type" binding:"required,oneof=email slack webhook pagerduty" example:"slack"`
+	Emails        []string                `json:"emails,omitempty" binding:"omitempty,max=50,dive,email" example:"ops@example.com"`
+	URL           string                  `json:"url,omitempty" binding:"omitempty,url" example:"https://hooks.slack.com/services/T000/B000/XXXX"`
+	RoutingKey    string                  `json:"routing_key,omitempty" binding:"omitempty,max=64" example:"R0123456789ABCDEF0123456789ABCDE"`
+	DigestMinutes int                     `json:"digest_minutes,omitempty" binding:"omitempty,min=0,max=1440" example:"60"`
+}
+
+// UpdateNotificationChannelRequest represents the request DTO for replacing a notification channel's destination
+type UpdateNotificationChannelRequest struct {
+	Type          NotificationChannelType `json:"type" binding:"required,oneof=email slack webhook pagerduty" example:"webhook"`
+	Emails        []string                `json:"emails,omitempty" binding:"omitempty,max=50,dive,email" example:"ops@example.com"`
+	URL           string                  `json:"url,omitempty" binding:"omitempty,url" example:"https://alerts.example.com/cron-observer"`
+	RoutingKey    string                  `json:"routing_key,omitempty" binding:"omitempty,max=64" example:"R0123456789ABCDEF0123456789ABCDE"`
+	DigestMinutes int                     `json:"digest_minutes,omitempty" binding:"omitempty,min=0,max=1440" example:"60"`
+}
+
+// AlertRoute directs the alerts of matching tasks to some of the project's notification channels
+// @Description AlertRoute directs the alerts of matching tasks to some of the project's notification channels
+type AlertRoute struct {
+	Tags       []string       `json:"tags,omitempty" bson:"tags,omitempty" binding:"omitempty,max=20,dive,task_tag" example:"team:payments"`                             // Tasks must have every tag; empty matches every task
+	Severities []TaskSeverity `json:"severities,omitempty" bson:"severities,omitempty" binding:"omitempty,max=4,dive,oneof=CRITICAL HIGH NORMAL LOW" example:"CRITICAL"` // Tasks must have one of the severities; empty matches every severity
+	Channels   []string       `json:"channels" bson:"channels" binding:"required,min=1,max=20,dive,env_name" example:"pagerduty"`                                        // Names of the notification channels the alerts are sent to
+}
+
+// Matches reports whether the route applies to alerts of the task
+func (r AlertRoute) Matches(task *Task) bool {
+	if len(r.Severities) > 0 {
+		matched := false
+		for _, severity := range r.Severities {
+			if severity == task.EffectiveSeverity() {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	for _, tag := range r.Tags {
+		if !task.HasTag(tag) {
+			return false
+		}
+	}
+	return true
+}
+
+// SetAlertRoutesRequest represents the request DTO for replacing a project's alert routes
+type SetAlertRoutesRequest struct {
+	Routes []AlertRoute `json:"routes" binding:"max=50,dive"` // Evaluated in order; the first matching route decides the channels
+}
+
+// TestNotificationRequest represents the request DTO for sending a test alert
+type TestNotificationRequest struct {
+	Channel string `json:"channel,omitempty" example:"ops-slack"` // Channel name; empty emails the project users
+}
+
+// TestNotificationResponse reports a test alert that was delivered
+// @Description TestNotificationResponse reports a test alert that was delivered
+type TestNotificationResponse struct {
+	Message string                  `json:"message" example:"Test alert sent"`
+	Channel string                  `json:"channel,omitempty" example:"ops-slack"`
+	Type    NotificationChannelType `json:"type" example:"slack"`
+}
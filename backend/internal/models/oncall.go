@@ -0,0 +1,149 @@
+package models
+
+import (
+	"time"
+)
+
+// OnCallSchedule rotates alert duty through a list of participants in shifts of shift_days days, handed over at
+// handoff_time in the schedule's timezone. Alerts that would email the project users go to the person on call.
+// @Description OnCallSchedule rotates alert duty through a list of participants
+type OnCallSchedule struct {
+	Participants []OnCallParticipant `json:"participants" bson:"participants" binding:"required,min=1,max=50,dive"`                    // In rotation order
+	Timezone     string              `json:"timezone" bson:"timezone" binding:"required,timezone" example:"Europe/Berlin"`             // Handoffs happen at handoff_time in this timezone
+	HandoffTime  string              `json:"handoff_time" bson:"handoff_time" binding:"required,time_format" example:"09:00"`          // HH:MM
+	ShiftDays    int                 `json:"shift_days" bson:"shift_days" binding:"required,min=1,max=28" example:"7"`                 // Days each participant is on call
+	StartDate    string              `json:"start_date" bson:"start_date" binding:"required,datetime=2006-01-02" example:"2025-01-13"` // The first participant's first shift starts at handoff_time on this date
+	Overrides    []OnCallOverride    `json:"overrides,omitempty" bson:"overrides,omitempty" binding:"omitempty,max=100,dive"`          // Take precedence over the rotation, e.g. for vacations
+	UpdatedAt    time.Time           `json:"updated_at" bson:"updated_at" example:"2025-01-15T10:00:00Z"`
+}
+
+// OnCallParticipant is a person taking part in an on-call rotation
+type OnCallParticipant struct {
+	Name  string `json:"name" bson:"name" binding:"required,max=100" example:"Jane Doe"`
+	Email string `json:"email" bson:"email" binding:"required,email" example:"jane@example.com"`
+	Phone string `json:"phone,omitempty" bson:"phone,omitempty" binding:"omitempty,e164" example:"+4915112345678"` // Passed to Slack, webhook and PagerDuty alerts for paging
+}
+
+// OnCallOverride puts one of the participants on call for a period, regardless of the rotation
+type OnCallOverride struct {
+	Email string    `json:"email" bson:"email" binding:"required,email" example:"john@example.com"` // Must be a participant
+	Start time.Time `json:"start" bson:"start" binding:"required" example:"2025-01-20T09:00:00+01:00"`
+	End   time.Time `json:"end" bson:"end" binding:"required" example:"2025-01-22T09:00:00+01:00"`
+}
+
+// OnCallStatus reports who is on call at a time
+// @Description OnCallStatus reports who is on call at a time
+type OnCallStatus struct {
+	Schedule      *OnCallSchedule    `json:"schedule"`
+	At            time.Time          `json:"at" example:"2025-01-15T10:00:00Z"`
+	OnCall        *OnCallParticipant `json:"on_call"`
+	Override      bool               `json:"override" example:"false"`                            // Whether an override put the participant on call
+	NextHandoffAt time.Time          `json:"next_handoff_at" example:"2025-01-20T09:00:00+01:00"` // Next rotation handoff; overrides are not considered
+}
+
+// FindParticipant returns the participant with the given email
+func (s *OnCallSchedule) FindParticipant(email string) (*OnCallParticipant, bool) {
+	for i := range s.Participants {
+		if s.Participants[i].Email == email {
+			return &s.Participants[i], true
+		}
+	}
+	return nil, false
+}
+
+// OnCallAt returns the participant on call at t and whether an override put them on call
+func (s *OnCallSchedule) OnCallAt(t time.Time) (*OnCallParticipant, bool) {
+	for _, override := range s.Overrides {
+		if !t.Before(override.Start) && t.Before(override.End) {
+			if participant, ok := s.FindParticipant(override.Email); ok {
+				return participant, true
+			}
+		}
+	}
+	if len(s.Participants) == 0 {
+		return nil, false
+	}
+
+	shift := s.shiftAt(t)
+	index := shift % len(s.Participants)
+	if index < 0 {
+		index += len(s.Participants)
+	}
+	return &s.Participants[index], false
+}
+
+// NextHandoff returns the first rotation handoff after t
+func (s *OnCallSchedule) NextHandoff(t time.Time) time.Time {
+	return s.shiftStart(s.shiftAt(t) + 1)
+}
+
+// shiftAt returns the number of the shift t falls into; shift 0 starts at handoff_time on start_date and earlier
+// times have negative shifts
+func (s *OnCallSchedule) shiftAt(t time.Time) int {
+	local := t.In(s.location())
+	hour, minute := s.handoff()
+
+	// Before the handoff, the shift that started on the previous day is still running
+	day := civilDay(local.Year(), local.Month(), local.Day())
+	if local.Hour()*60+local.Minute() < hour*60+minute {
+		day--
+	}
+	days := day - s.startDay()
+	shift := days / s.shiftDays()
+	if days%s.shiftDays() != 0 && days < 0 {
+		shift--
+	}
+	return shift
+}
+
+// shiftStart returns when the numbered shift starts
+func (s *OnCallSchedule) shiftStart(shift int) time.Time {
+	start, _ := time.Parse("2006-01-02", s.StartDate)
+	hour, minute := s.handoff()
+	return time.Date(start.Year(), start.Month(), start.Day()+shift*s.shiftDays(), hour, minute, 0, 0, s.location())
+}
+
+func (s *OnCallSchedule) location() *time.Location {
+	location, err := time.LoadLocation(s.Timezone)
+	if err != nil {
+		return time.UTC
+	}
+	return location
+}
+
+func (s *OnCallSchedule) handoff() (int, int) {
+	handoff, err := time.Parse("15:04", s.HandoffTime)
+	if err != nil {
+		return 0, 0
+	}
+	return handoff.Hour(), handoff.Minute()
+}
+
+func (s *OnCallSchedule) startDay() int {
+	start, err := time.Parse("2006-01-02", s.StartDate)
+	if err != nil {
+		return 0
+	}
+	return civilDay(start.Year(), start.Month(), start.Day())
+}
+
+func (s *OnCallSchedule) shiftDays() int {
+	if s.ShiftDays < 1 {
+		return 1
+	}
+	return s.ShiftDays
+}
+
+// civilDay numbers calendar days, so that day differences are not affected by daylight saving time
+func civilDay(year int, month time.Month, day int) int {
+	return int(time.Date(year, month, day, 0, 0, 0, 0, time.UTC).Unix() / 86400)
+}
+
+// CurrentOnCall returns the participant of the project's on-call schedule on call at t, or nil without a schedule
+func (p *Project) CurrentOnCall(t time.Time) *OnCallParticipant {
+	if p.OnCall == nil {
+		return nil
+	}
+	participant, _ := p.OnCall.OnCallAt(t)
+	return participant
+}
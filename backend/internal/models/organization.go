@@ -0,0 +1,70 @@
+package models
+
+import (
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// OrganizationRole represents the role of a user in an organization
+type OrganizationRole string
+
+const (
+	OrganizationRoleAdmin  OrganizationRole = "admin"  // Manages the organization and has admin rights on all of its projects
+	OrganizationRoleMember OrganizationRole = "member" // Sees the organization; project access still comes from project membership
+)
+
+// Organization groups projects under shared membership
+// @Description Organization groups projects under shared membership
+type Organization struct {
+	ID          primitive.ObjectID   `json:"id" bson:"_id,omitempty" example:"507f1f77bcf86cd799439011"`
+	UUID        string               `json:"uuid" bson:"uuid" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Name        string               `json:"name" bson:"name" example:"Payments"`
+	Description string               `json:"description,omitempty" bson:"description,omitempty" example:"Payments team projects"`
+	Members     []OrganizationMember `json:"members" bson:"members"`
+	Quotas      *ProjectQuotas       `json:"quotas,omitempty" bson:"quotas,omitempty"` // Quotas of each project of the organization, unless the project overrides them
+	CreatedAt   time.Time            `json:"created_at" bson:"created_at" example:"2025-01-15T10:00:00Z"`
+	UpdatedAt   time.Time            `json:"updated_at" bson:"updated_at" example:"2025-01-15T10:00:00Z"`
+}
+
+// OrganizationMember represents a user of an organization
+// @Description OrganizationMember represents a user of an organization
+type OrganizationMember struct {
+	Email string           `json:"email" bson:"email" binding:"required,email" example:"user@example.com"`
+	Role  OrganizationRole `json:"role" bson:"role" binding:"required,oneof=admin member" example:"admin"`
+}
+
+// MemberRole returns the role of the user with the given email (case-insensitive)
+func (o *Organization) MemberRole(email string) (OrganizationRole, bool) {
+	email = strings.ToLower(strings.TrimSpace(email))
+	for _, member := range o.Members {
+		if strings.ToLower(strings.TrimSpace(member.Email)) == email {
+			return member.Role, true
+		}
+	}
+	return "", false
+}
+
+// IsAdmin reports whether the user with the given email is an admin of the organization
+func (o *Organization) IsAdmin(email string) bool {
+	role, ok := o.MemberRole(email)
+	return ok && role == OrganizationRoleAdmin
+}
+
+// CreateOrganizationRequest represents the request DTO for creating an organization
+type CreateOrganizationRequest struct {
+	Name        string `json:"name" binding:"required,min=1,max=255" example:"Payments"`
+	Description string `json:"description,omitempty" binding:"omitempty,max=1000"`
+}
+
+// UpdateOrganizationRequest represents the request DTO for updating an organization
+type UpdateOrganizationRequest struct {
+	Name        string `json:"name,omitempty" binding:"omitempty,min=1,max=255" example:"Payments"`
+	Description string `json:"description,omitempty" binding:"omitempty,max=1000"`
+}
+
+// SetProjectOrganizationRequest represents the request DTO for moving a project into an organization
+type SetProjectOrganizationRequest struct {
+	OrganizationID string `json:"organization_id" example:"507f1f77bcf86cd799439011"` // Empty removes the project from its organization
+}
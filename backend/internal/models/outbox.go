@@ -0,0 +1,18 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// OutboxEvent is an internal event persisted until every subscriber has received it
+type OutboxEvent struct {
+	ID          primitive.ObjectID `bson:"_id,omitempty"`
+	Type        string             `bson:"type"`
+	Payload     bson.Raw           `bson:"payload,omitempty"`
+	Attempts    int                `bson:"attempts"`     // Delivery attempts so far
+	LockedUntil time.Time          `bson:"locked_until"` // A dispatcher owns the event until then; redelivered afterwards unless acknowledged
+	CreatedAt   time.Time          `bson:"created_at"`
+}
@@ -0,0 +1,29 @@
+package models
+
+import "time"
+
+// ParkedJob is a background job moved to the dead-letter queue after it could not be processed
+// @Description ParkedJob is a background job moved to the dead-letter queue after it could not be processed
+type ParkedJob struct {
+	ID       string    `json:"id" example:"9b2e5c1a-7f7e-4d0c-9a51-3c2b8f0e6d11"`
+	Type     string    `json:"type" example:"delete_task"`
+	Attempts int       `json:"attempts" example:"5"`                                // Deliveries before the job was parked
+	Error    string    `json:"error" example:"delete executions: connection reset"` // Error of the last attempt
+	ParkedAt time.Time `json:"parked_at" example:"2025-01-15T10:00:00Z"`
+	Body     string    `json:"body"` // The message as published
+}
+
+// ListParkedJobsResponse represents the response for listing parked jobs
+type ListParkedJobsResponse struct {
+	Jobs []ParkedJob `json:"jobs"`
+}
+
+// ReplayParkedJobsRequest represents the request DTO for replaying parked jobs
+type ReplayParkedJobsRequest struct {
+	IDs []string `json:"ids,omitempty" binding:"omitempty,max=1000"` // Limits the replay to these jobs; empty replays every parked job
+}
+
+// ReplayParkedJobsResponse represents the response for replaying parked jobs
+type ReplayParkedJobsResponse struct {
+	Replayed []string `json:"replayed"` // IDs of the jobs moved back to the job queue
+}
@@ -9,16 +9,32 @@ import (
 // Project represents a project entity that contains tasks
 // @Description Project represents a project entity that contains tasks
 type Project struct {
-	ID                primitive.ObjectID `json:"id" bson:"_id,omitempty" example:"507f1f77bcf86cd799439011"`
-	UUID              string             `json:"uuid" bson:"uuid" example:"550e8400-e29b-41d4-a716-446655440000"`
-	Name              string             `json:"name" bson:"name" example:"My Project"`
-	Description       string             `json:"description,omitempty" bson:"description,omitempty" example:"Project description"`
-	APIKey            string             `json:"api_key" bson:"api_key" example:"sk_live_abc123..."`
-	ExecutionEndpoint string             `json:"execution_endpoint" bson:"execution_endpoint" binding:"omitempty,url" example:"https://api.example.com/execute"`
-	AlertEmails       string             `json:"alert_emails,omitempty" bson:"alert_emails,omitempty" example:"admin@example.com,ops@example.com"`
-	ProjectUsers      []ProjectUser      `json:"project_users" bson:"project_users,omitempty"`
-	CreatedAt         time.Time          `json:"created_at" bson:"created_at" example:"2025-01-15T10:00:00Z"`
-	UpdatedAt         time.Time          `json:"updated_at" bson:"updated_at" example:"2025-01-15T10:00:00Z"`
+	ID                   primitive.ObjectID    `json:"id" bson:"_id,omitempty" example:"507f1f77bcf86cd799439011"`
+	UUID                 string                `json:"uuid" bson:"uuid" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Name                 string                `json:"name" bson:"name" example:"My Project"`
+	Description          string                `json:"description,omitempty" bson:"description,omitempty" example:"Project description"`
+	APIKey               string                `json:"api_key" bson:"api_key" example:"sk_live_abc123..."`
+	APIKeyAllowedCIDRs   []string              `json:"api_key_allowed_cidrs,omitempty" bson:"api_key_allowed_cidrs,omitempty" example:"10.0.0.0/8"` // Networks allowed to use the primary API key; empty allows any
+	ExecutionEndpoint    string                `json:"execution_endpoint" bson:"execution_endpoint" binding:"omitempty,url" example:"https://api.example.com/execute"`
+	FailoverEndpoint     string                `json:"failover_endpoint,omitempty" bson:"failover_endpoint,omitempty" binding:"omitempty,url" example:"https://dr.example.com/execute"`
+	Environments         []ProjectEnvironment  `json:"environments,omitempty" bson:"environments,omitempty"` // Named dispatch targets (e.g. staging, prod); tasks without an environment use execution_endpoint
+	AlertEmails          string                `json:"alert_emails,omitempty" bson:"alert_emails,omitempty" example:"admin@example.com,ops@example.com"`
+	NotificationChannels []NotificationChannel `json:"notification_channels,omitempty" bson:"notification_channels,omitempty"`                                             // Where failure alerts are sent; empty emails the project users
+	AlertRoutes          []AlertRoute          `json:"alert_routes,omitempty" bson:"alert_routes,omitempty"`                                                               // Send the alerts of matching tasks to some channels; alerts of other tasks go to every channel
+	OnCall               *OnCallSchedule       `json:"on_call,omitempty" bson:"on_call,omitempty"`                                                                         // Alerts that would email the project users go to the person on call
+	ExecutionHeaders     map[string]string     `json:"execution_headers,omitempty" bson:"execution_headers,omitempty" example:"Authorization:Bearer {{secret:API_TOKEN}}"` // Sent with every execution request; may reference secrets
+	OrganizationID       *primitive.ObjectID   `json:"organization_id,omitempty" bson:"organization_id,omitempty" example:"507f1f77bcf86cd799439011"`                      // Admins of the organization are admins of the project
+	ProjectUsers         []ProjectUser         `json:"project_users" bson:"project_users,omitempty"`
+	Quotas               *ProjectQuotas        `json:"quotas,omitempty" bson:"quotas,omitempty"`                                                                  // Overrides the organization's and the server-wide quotas
+	RateLimits           *ProjectRateLimits    `json:"rate_limits,omitempty" bson:"rate_limits,omitempty"`                                                        // Overrides the server-wide SDK quotas
+	ScopedAPIKeys        []ScopedAPIKey        `json:"scoped_api_keys,omitempty" bson:"scoped_api_keys,omitempty"`                                                // Additional keys with restricted scopes (e.g. read-only dashboards)
+	Status               ProjectStatus         `json:"status,omitempty" bson:"status,omitempty" enums:"ACTIVE,INACTIVE,ARCHIVED,PENDING_DELETE" example:"ACTIVE"` // Empty means ACTIVE
+	CreatedAt            time.Time             `json:"created_at" bson:"created_at" example:"2025-01-15T10:00:00Z"`
+	UpdatedAt            time.Time             `json:"updated_at" bson:"updated_at" example:"2025-01-15T10:00:00Z"`
+
+	DeletionProgress *ProjectDeletionProgress `json:"deletion_progress,omitempty" bson:"deletion_progress,omitempty"` // Set by the delete worker while the project is PENDING_DELETE
+
+	StatusPageToken string `json:"status_page_token,omitempty" bson:"status_page_token,omitempty" example:"6f1c2b9e-3d4a-4c8e-9b71-2a5f0e8d3c14"` // Grants read access to the public status page; empty disables it
 }
 
 // CreateProjectRequest represents the request DTO for creating a project
@@ -26,15 +42,41 @@ type CreateProjectRequest struct {
 	Name              string `json:"name" binding:"required,min=1,max=255"`
 	Description       string `json:"description,omitempty" binding:"omitempty,max=1000"`
 	ExecutionEndpoint string `json:"execution_endpoint,omitempty" binding:"omitempty,url"`
+	FailoverEndpoint  string `json:"failover_endpoint,omitempty" binding:"omitempty,url"`
+}
+
+// CloneProjectRequest represents the request DTO for cloning a project
+type CloneProjectRequest struct {
+	Name        string `json:"name" binding:"required,min=1,max=255" example:"My Project (staging)"`
+	Description string `json:"description,omitempty" binding:"omitempty,max=1000"`
+	EnableTasks bool   `json:"enable_tasks,omitempty"` // Keep the source statuses; by default cloned tasks and task groups are DISABLED
+}
+
+// CloneProjectResponse represents the response for a project clone
+type CloneProjectResponse struct {
+	Project          *Project `json:"project"`
+	TaskGroupsCloned int      `json:"task_groups_cloned" example:"2"`
+	TasksCloned      int      `json:"tasks_cloned" example:"12"`
 }
 
 // UpdateProjectRequest represents the request DTO for updating a project
 type UpdateProjectRequest struct {
-	Name              string        `json:"name,omitempty" binding:"omitempty,min=1,max=255"`
-	Description       string        `json:"description,omitempty" binding:"omitempty,max=1000"`
-	ExecutionEndpoint string        `json:"execution_endpoint,omitempty" binding:"omitempty,url"`
-	AlertEmails       string        `json:"alert_emails,omitempty" binding:"omitempty"`
-	ProjectUsers      []ProjectUser `json:"project_users,omitempty" binding:"omitempty,dive"`
+	Name               string             `json:"name,omitempty" binding:"omitempty,min=1,max=255"`
+	Description        string             `json:"description,omitempty" binding:"omitempty,max=1000"`
+	ExecutionEndpoint  string             `json:"execution_endpoint,omitempty" binding:"omitempty,url"`
+	FailoverEndpoint   string             `json:"failover_endpoint,omitempty" binding:"omitempty,url"`
+	AlertEmails        string             `json:"alert_emails,omitempty" binding:"omitempty"`
+	ProjectUsers       []ProjectUser      `json:"project_users,omitempty" binding:"omitempty,dive"`
+	APIKeyAllowedCIDRs []string           `json:"api_key_allowed_cidrs,omitempty" binding:"omitempty,dive,cidr"` // Send [] to remove the allowlist
+	RateLimits         *ProjectRateLimits `json:"rate_limits,omitempty" binding:"omitempty"`                     // Super admins only
+	ExecutionHeaders   map[string]string  `json:"execution_headers,omitempty"`                                   // Send {} to remove all headers
+}
+
+// ProjectRateLimits holds per-project quotas for SDK endpoints. Zero values use the server defaults.
+// @Description ProjectRateLimits holds per-project quotas for SDK endpoints. Zero values use the server defaults.
+type ProjectRateLimits struct {
+	LogAppendsPerMinute    int `json:"log_appends_per_minute" bson:"log_appends_per_minute" binding:"min=0" example:"600"`
+	StatusUpdatesPerMinute int `json:"status_updates_per_minute" bson:"status_updates_per_minute" binding:"min=0" example:"120"`
 }
 
 // ProjectStatus represents the status of a project
@@ -43,13 +85,40 @@ type ProjectStatus string
 const (
 	ProjectStatusActive   ProjectStatus = "ACTIVE"
 	ProjectStatusInactive ProjectStatus = "INACTIVE"
+	// ProjectStatusArchived unschedules all tasks and blocks new executions while keeping history
+	ProjectStatusArchived ProjectStatus = "ARCHIVED"
+	// ProjectStatusPendingDelete hides the project while its tasks are removed by the delete worker
+	ProjectStatusPendingDelete ProjectStatus = "PENDING_DELETE"
+)
+
+// ProjectDeletionPhase is the step the delete worker is at while removing a project
+type ProjectDeletionPhase string
+
+const (
+	ProjectDeletionPhaseTasks      ProjectDeletionPhase = "TASKS"       // Deleting tasks and their executions
+	ProjectDeletionPhaseTaskGroups ProjectDeletionPhase = "TASK_GROUPS" // Deleting task groups
+	ProjectDeletionPhaseCleanup    ProjectDeletionPhase = "CLEANUP"     // Deleting stats, settings and templates
 )
 
+// ProjectDeletionProgress records how far the delete worker got with a project
+// @Description ProjectDeletionProgress records how far the delete worker got with a project
+type ProjectDeletionProgress struct {
+	Phase             ProjectDeletionPhase `json:"phase" bson:"phase" enums:"TASKS,TASK_GROUPS,CLEANUP" example:"TASKS"`
+	TasksDeleted      int                  `json:"tasks_deleted" bson:"tasks_deleted" example:"1200"`
+	TaskGroupsDeleted int                  `json:"task_groups_deleted" bson:"task_groups_deleted" example:"4"`
+	ExecutionsDeleted int64                `json:"executions_deleted" bson:"executions_deleted" example:"250000"`
+	UpdatedAt         time.Time            `json:"updated_at" bson:"updated_at" example:"2025-01-15T10:00:00Z"`
+}
+
 // ProjectUserRole represents the role of a user in a project
 type ProjectUserRole string
 
 const (
-	ProjectUserRoleAdmin    ProjectUserRole = "admin"
+	ProjectUserRoleAdmin  ProjectUserRole = "admin"  // Full access: tasks, members, API keys and project settings
+	ProjectUserRoleEditor ProjectUserRole = "editor" // Manage tasks and task groups, but not members or API keys
+	ProjectUserRoleViewer ProjectUserRole = "viewer" // Read-only access
+
+	// Deprecated: use ProjectUserRoleViewer. Kept so existing project_users documents keep working.
 	ProjectUserRoleReadonly ProjectUserRole = "readonly"
 )
 
@@ -57,5 +126,92 @@ const (
 // @Description ProjectUser represents a user associated with a project
 type ProjectUser struct {
 	Email string          `json:"email" bson:"email" binding:"required,email" example:"user@example.com"`
-	Role  ProjectUserRole `json:"role" bson:"role" binding:"required,oneof=admin readonly" example:"admin"`
+	Role  ProjectUserRole `json:"role" bson:"role" binding:"required,oneof=admin editor viewer readonly" example:"admin"`
+}
+
+// APIKeyScope defines what an API key is allowed to do
+type APIKeyScope string
+
+const (
+	// APIKeyScopeFull allows reading data and reporting executions (same as the project's primary API key)
+	APIKeyScopeFull APIKeyScope = "FULL"
+	// APIKeyScopeReadOnly only allows GET access to tasks and executions
+	APIKeyScopeReadOnly APIKeyScope = "READ_ONLY"
+)
+
+// ProjectEnvironment is a named execution target with its own endpoint and API key
+// @Description ProjectEnvironment is a named execution target with its own endpoint and API key
+type ProjectEnvironment struct {
+	Name              string    `json:"name" bson:"name" example:"staging"`
+	ExecutionEndpoint string    `json:"execution_endpoint" bson:"execution_endpoint" example:"https://staging.example.com/execute"`
+	APIKey            string    `json:"api_key" bson:"api_key" example:"sk_live_abc123..."` // Authenticates SDK reports for executions dispatched to this environment
+	CreatedAt         time.Time `json:"created_at" bson:"created_at" example:"2025-01-15T10:00:00Z"`
+	UpdatedAt         time.Time `json:"updated_at" bson:"updated_at" example:"2025-01-15T10:00:00Z"`
+}
+
+// FindEnvironment returns the project's environment with the given name
+func (p *Project) FindEnvironment(name string) (*ProjectEnvironment, bool) {
+	for i := range p.Environments {
+		if p.Environments[i].Name == name {
+			return &p.Environments[i], true
+		}
+	}
+	return nil, false
+}
+
+// WithoutSecrets returns a copy of the project without its API keys and status page token, for users who may see
+// the project but not manage it
+func (p *Project) WithoutSecrets() *Project {
+	redacted := *p
+	redacted.APIKey = ""
+	redacted.StatusPageToken = ""
+	if p.Environments != nil {
+		redacted.Environments = make([]ProjectEnvironment, len(p.Environments))
+		for i, environment := range p.Environments {
+			environment.APIKey = ""
+			redacted.Environments[i] = environment
+		}
+	}
+	if p.ScopedAPIKeys != nil {
+		redacted.ScopedAPIKeys = make([]ScopedAPIKey, len(p.ScopedAPIKeys))
+		for i, key := range p.ScopedAPIKeys {
+			key.Key = ""
+			redacted.ScopedAPIKeys[i] = key
+		}
+	}
+	return &redacted
+}
+
+// CreateProjectEnvironmentRequest represents the request DTO for adding an environment to a project
+type CreateProjectEnvironmentRequest struct {
+	Name              string `json:"name" binding:"required,env_name" example:"staging"`
+	ExecutionEndpoint string `json:"execution_endpoint" binding:"required,url" example:"https://staging.example.com/execute"`
+}
+
+// UpdateProjectEnvironmentRequest represents the request DTO for changing an environment's endpoint
+type UpdateProjectEnvironmentRequest struct {
+	ExecutionEndpoint string `json:"execution_endpoint" binding:"required,url" example:"https://staging.example.com/execute"`
+}
+
+// ScopedAPIKey represents an additional project API key with a restricted scope
+// @Description ScopedAPIKey represents an additional project API key with a restricted scope
+type ScopedAPIKey struct {
+	ID           string      `json:"id" bson:"id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Name         string      `json:"name" bson:"name" example:"Status page"`
+	Key          string      `json:"key" bson:"key" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Scope        APIKeyScope `json:"scope" bson:"scope" enums:"FULL,READ_ONLY" example:"READ_ONLY"`
+	AllowedCIDRs []string    `json:"allowed_cidrs,omitempty" bson:"allowed_cidrs,omitempty" example:"203.0.113.0/24"` // Networks allowed to use this key; empty allows any
+	CreatedAt    time.Time   `json:"created_at" bson:"created_at" example:"2025-01-15T10:00:00Z"`
+}
+
+// CreateScopedAPIKeyRequest represents the request DTO for creating a scoped API key
+type CreateScopedAPIKeyRequest struct {
+	Name         string      `json:"name" binding:"required,min=1,max=255"`
+	Scope        APIKeyScope `json:"scope,omitempty" binding:"omitempty,oneof=FULL READ_ONLY"`
+	AllowedCIDRs []string    `json:"allowed_cidrs,omitempty" binding:"omitempty,dive,cidr"`
+}
+
+// UpdateAPIKeyAllowedCIDRsRequest represents the request DTO for replacing an API key's CIDR allowlist
+type UpdateAPIKeyAllowedCIDRsRequest struct {
+	AllowedCIDRs []string `json:"allowed_cidrs" binding:"omitempty,dive,cidr" example:"203.0.113.0/24"` // Empty list removes the allowlist
 }
@@ -0,0 +1,55 @@
+package models
+
+// ProjectConfigVersion is the current version of the project configuration document format
+const ProjectConfigVersion = 1
+
+// ProjectConfig is a declarative description of a project's task groups and tasks, exported and imported as JSON or YAML.
+// Task groups and tasks are matched to existing ones by UUID, then by name; unmatched entries are created.
+// @Description ProjectConfig is a declarative description of a project's task groups and tasks
+type ProjectConfig struct {
+	Version    int               `json:"version" yaml:"version" binding:"omitempty,eq=1" example:"1"`
+	Project    string            `json:"project,omitempty" yaml:"project,omitempty" example:"My Project"` // Informational; ignored on import
+	TaskGroups []TaskGroupConfig `json:"task_groups,omitempty" yaml:"task_groups,omitempty" binding:"omitempty,dive"`
+	Tasks      []TaskConfig      `json:"tasks,omitempty" yaml:"tasks,omitempty" binding:"omitempty,dive"`
+}
+
+// TaskGroupConfig is the declarative form of a task group
+type TaskGroupConfig struct {
+	UUID        string          `json:"uuid,omitempty" yaml:"uuid,omitempty" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Name        string          `json:"name" yaml:"name" binding:"required,min=1,max=255" example:"Morning Tasks"`
+	Description string          `json:"description,omitempty" yaml:"description,omitempty" binding:"omitempty,max=1000"`
+	Status      TaskGroupStatus `json:"status,omitempty" yaml:"status,omitempty" binding:"omitempty,oneof=ACTIVE DISABLED" example:"ACTIVE"`
+	StartTime   string          `json:"start_time,omitempty" yaml:"start_time,omitempty" binding:"omitempty,time_format" example:"09:00"`
+	EndTime     string          `json:"end_time,omitempty" yaml:"end_time,omitempty" binding:"omitempty,time_format" example:"17:00"`
+	Timezone    string          `json:"timezone,omitempty" yaml:"timezone,omitempty" binding:"omitempty,timezone" example:"America/New_York"`
+	Concurrency int             `json:"concurrency_budget,omitempty" yaml:"concurrency_budget,omitempty" binding:"omitempty,min=1,max=1000" example:"3"`
+	OnConflict  ConflictPolicy  `json:"schedule_conflict_policy,omitempty" yaml:"schedule_conflict_policy,omitempty" binding:"omitempty,oneof=WARN REJECT" example:"WARN"`
+}
+
+// TaskConfig is the declarative form of a task. Tasks reference their group by name so documents can be promoted between projects.
+type TaskConfig struct {
+	UUID           string                 `json:"uuid,omitempty" yaml:"uuid,omitempty" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Name           string                 `json:"name" yaml:"name" binding:"required,min=1,max=255" example:"Daily Backup"`
+	Description    string                 `json:"description,omitempty" yaml:"description,omitempty" binding:"omitempty,max=1000"`
+	TaskGroup      string                 `json:"task_group,omitempty" yaml:"task_group,omitempty" example:"Morning Tasks"` // Name of a task group in the document or the project
+	ScheduleType   ScheduleType           `json:"schedule_type" yaml:"schedule_type" binding:"required,oneof=RECURRING ONEOFF" example:"RECURRING"`
+	Status         TaskStatus             `json:"status,omitempty" yaml:"status,omitempty" binding:"omitempty,oneof=ACTIVE DISABLED" example:"ACTIVE"`
+	ScheduleConfig ScheduleConfig         `json:"schedule_config" yaml:"schedule_config" binding:"required"`
+	TimeoutSeconds *int                   `json:"timeout_seconds,omitempty" yaml:"timeout_seconds,omitempty" binding:"omitempty,min=1"`
+	Priority       int                    `json:"priority,omitempty" yaml:"priority,omitempty" binding:"omitempty,min=0,max=100"`
+	AutoPauseAfter int                    `json:"auto_pause_after_failures,omitempty" yaml:"auto_pause_after_failures,omitempty" binding:"omitempty,min=1,max=1000"`
+	Concurrency    int                    `json:"concurrency_budget,omitempty" yaml:"concurrency_budget,omitempty" binding:"omitempty,min=1,max=1000"`
+	Severity       TaskSeverity           `json:"severity,omitempty" yaml:"severity,omitempty" binding:"omitempty,oneof=CRITICAL HIGH NORMAL LOW" example:"NORMAL"`
+	Metadata       map[string]interface{} `json:"metadata,omitempty" yaml:"metadata,omitempty"`
+	Environment    string                 `json:"environment,omitempty" yaml:"environment,omitempty" binding:"omitempty,env_name"`
+	Env            map[string]string      `json:"env,omitempty" yaml:"env,omitempty" binding:"omitempty,max=50,dive,keys,env_var,endkeys,max=4096"`
+	Tags           []string               `json:"tags,omitempty" yaml:"tags,omitempty" binding:"omitempty,max=20,dive,task_tag"`
+}
+
+// ImportProjectConfigResponse summarizes the changes made by a project configuration import
+type ImportProjectConfigResponse struct {
+	TaskGroupsCreated int `json:"task_groups_created" example:"1"`
+	TaskGroupsUpdated int `json:"task_groups_updated" example:"2"`
+	TasksCreated      int `json:"tasks_created" example:"3"`
+	TasksUpdated      int `json:"tasks_updated" example:"10"`
+}
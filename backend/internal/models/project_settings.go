@@ -0,0 +1,115 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// ProjectSettings holds per-project defaults used when a task does not set its own value.
+// Zero values mean "no project default".
+// @Description ProjectSettings holds per-project defaults used when a task does not set its own value
+type ProjectSettings struct {
+	ProjectID              primitive.ObjectID  `json:"project_id" bson:"project_id" example:"507f1f77bcf86cd799439011"`
+	DefaultTimezone        string              `json:"default_timezone,omitempty" bson:"default_timezone,omitempty" example:"America/New_York"` // Used for tasks without a timezone
+	ExecutionRetentionDays int                 `json:"execution_retention_days" bson:"execution_retention_days" example:"30"`                   // Executions older than this are deleted; 0 keeps them forever
+	AlertThrottleMinutes   int                 `json:"alert_throttle_minutes" bson:"alert_throttle_minutes" example:"15"`                       // Minimum time between failure alerts for the same task; 0 sends every alert
+	FlapThreshold          int                 `json:"flap_threshold,omitempty" bson:"flap_threshold,omitempty" example:"5"`                    // Success/failure flips within an hour above which a task is flapping; 0 uses DefaultFlapThreshold
+	DefaultTimeoutSeconds  int                 `json:"default_timeout_seconds" bson:"default_timeout_seconds" example:"300"`                    // Used for tasks without timeout_seconds; 0 means no timeout
+	DispatchRateLimit      int                 `json:"dispatch_rate_limit,omitempty" bson:"dispatch_rate_limit,omitempty" example:"20"`         // Most scheduled execution requests sent per second; firings beyond it wait in the dispatch queue. 0 is unlimited
+	MetadataSchema         json.RawMessage     `json:"metadata_schema,omitempty" bson:"metadata_schema,omitempty" swaggertype:"object"`         // JSON Schema that task metadata must match; empty allows any metadata
+	CheckInMode            CheckInMode         `json:"check_in_mode,omitempty" bson:"check_in_mode,omitempty" enums:"STRICT,LENIENT"`           // How reports for unknown executions are handled; empty means STRICT
+	LogRetention           *LogRetentionPolicy `json:"log_retention,omitempty" bson:"log_retention,omitempty"`                                  // Overrides the server's log retention; omitted uses it
+	UpdatedAt              time.Time           `json:"updated_at,omitempty" bson:"updated_at" example:"2025-01-15T10:00:00Z"`
+}
+
+// UpdateProjectSettingsRequest represents the request DTO for replacing a project's settings
+type UpdateProjectSettingsRequest struct {
+	DefaultTimezone        string              `json:"default_timezone,omitempty" binding:"omitempty,timezone" example:"America/New_York"`
+	ExecutionRetentionDays int                 `json:"execution_retention_days" binding:"min=0,max=3650" example:"30"`
+	AlertThrottleMinutes   int                 `json:"alert_throttle_minutes" binding:"min=0,max=10080" example:"15"`
+	FlapThreshold          int                 `json:"flap_threshold,omitempty" binding:"min=0,max=100" example:"5"` // Omit to use the default of 5
+	DefaultTimeoutSeconds  int                 `json:"default_timeout_seconds" binding:"min=0,max=86400" example:"300"`
+	DispatchRateLimit      int                 `json:"dispatch_rate_limit,omitempty" binding:"min=0,max=10000" example:"20"`
+	MetadataSchema         json.RawMessage     `json:"metadata_schema,omitempty" swaggertype:"object"` // Omit or send null to allow any metadata
+	CheckInMode            CheckInMode         `json:"check_in_mode,omitempty" binding:"omitempty,oneof=STRICT LENIENT" enums:"STRICT,LENIENT" example:"LENIENT"`
+	LogRetention           *LogRetentionPolicy `json:"log_retention,omitempty"` // Omit to use the server's log retention
+}
+
+// DefaultFlapThreshold is the flap threshold of projects that do not set one
+const DefaultFlapThreshold = 5
+
+// LogRetentionPolicy decides how long the logs of finished executions are kept. Older logs are removed while the
+// executions themselves are kept, until execution_retention_days deletes them. 0 keeps logs forever.
+type LogRetentionPolicy struct {
+	SuccessDays int `json:"success_days" bson:"success_days" binding:"min=0,max=3650" example:"7"`
+	FailureDays int `json:"failure_days" bson:"failure_days" binding:"min=0,max=3650" example:"90"`
+}
+
+// CheckInMode controls how SDK reports for executions the server has no record of are handled
+type CheckInMode string
+
+const (
+	// CheckInModeStrict rejects reports for unknown executions
+	CheckInModeStrict CheckInMode = "STRICT"
+	// CheckInModeLenient recreates unknown executions from reports that name their task, linked to the expected fire time
+	CheckInModeLenient CheckInMode = "LENIENT"
+)
+
+// IsLenientCheckIn reports whether unknown executions are recreated from client reports. Safe to call on nil settings.
+func (s *ProjectSettings) IsLenientCheckIn() bool {
+	return s != nil && s.CheckInMode == CheckInModeLenient
+}
+
+// HasMetadataSchema reports whether the project constrains task metadata. Safe to call on nil settings.
+func (s *ProjectSettings) HasMetadataSchema() bool {
+	return s != nil && len(s.MetadataSchema) > 0 && string(s.MetadataSchema) != "null"
+}
+
+// EffectiveTimezone returns the task's timezone, falling back to the project default.
+// Safe to call on nil settings.
+func (s *ProjectSettings) EffectiveTimezone(taskTimezone string) string {
+	if taskTimezone != "" || s == nil {
+		return taskTimezone
+	}
+	return s.DefaultTimezone
+}
+
+// EffectiveTimeoutSeconds returns the task's timeout, falling back to the project default.
+// Zero means no timeout. Safe to call on nil settings.
+func (s *ProjectSettings) EffectiveTimeoutSeconds(taskTimeout *int) int {
+	if taskTimeout != nil && *taskTimeout > 0 {
+		return *taskTimeout
+	}
+	if s == nil {
+		return 0
+	}
+	return s.DefaultTimeoutSeconds
+}
+
+// EffectiveLogRetention returns the project's log retention, falling back to the server defaults.
+// Safe to call on nil settings.
+func (s *ProjectSettings) EffectiveLogRetention(defaults LogRetentionPolicy) LogRetentionPolicy {
+	if s == nil || s.LogRetention == nil {
+		return defaults
+	}
+	return *s.LogRetention
+}
+
+// AlertThrottle returns the minimum time between failure alerts for a task. Safe to call on nil settings.
+func (s *ProjectSettings) AlertThrottle() time.Duration {
+	if s == nil {
+		return 0
+	}
+	return time.Duration(s.AlertThrottleMinutes) * time.Minute
+}
+
+// EffectiveFlapThreshold returns how many success/failure flips within an hour make a task flapping.
+// Safe to call on nil settings.
+func (s *ProjectSettings) EffectiveFlapThreshold() int {
+	if s == nil || s.FlapThreshold == 0 {
+		return DefaultFlapThreshold
+	}
+	return s.FlapThreshold
+}
@@ -0,0 +1,17 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// QueuedJob is a background job waiting in the in-process job queue, persisted so it survives a restart
+type QueuedJob struct {
+	ID          primitive.ObjectID `bson:"_id,omitempty"`
+	Type        string             `bson:"type"`
+	Body        []byte             `bson:"body"`         // JSON-encoded job envelope
+	Attempts    int                `bson:"attempts"`     // Deliveries so far
+	LockedUntil time.Time          `bson:"locked_until"` // A consumer owns the job until then; redelivered afterwards unless acknowledged
+	CreatedAt   time.Time          `bson:"created_at"`
+}
@@ -0,0 +1,25 @@
+package models
+
+// ProjectQuotas holds enforced limits of a project. Zero values inherit the limit of the project's organization,
+// then the server default; a limit that resolves to zero is unlimited.
+// @Description ProjectQuotas holds enforced limits of a project. Zero values inherit the organization's limit, then the server default.
+type ProjectQuotas struct {
+	MaxTasks                int `json:"max_tasks" bson:"max_tasks" binding:"min=0" example:"500"`
+	MaxExecutionsPerDay     int `json:"max_executions_per_day" bson:"max_executions_per_day" binding:"min=0" example:"10000"`             // Per UTC day
+	MaxLogBytesPerExecution int `json:"max_log_bytes_per_execution" bson:"max_log_bytes_per_execution" binding:"min=0" example:"1048576"` // Total size of the log messages of one execution
+}
+
+// IsZero reports whether no limit is set
+func (q *ProjectQuotas) IsZero() bool {
+	return q == nil || *q == ProjectQuotas{}
+}
+
+// QuotaUsageResponse represents a project's effective quotas and how much of them is used
+// @Description QuotaUsageResponse represents a project's effective quotas and how much of them is used
+type QuotaUsageResponse struct {
+	Limits          ProjectQuotas  `json:"limits"`                          // Effective limits; 0 means unlimited
+	ProjectQuotas   *ProjectQuotas `json:"project_quotas,omitempty"`        // Overrides set on the project
+	Tasks           int            `json:"tasks" example:"120"`             // Tasks in the project
+	ExecutionsToday int            `json:"executions_today" example:"4200"` // Executions started today (UTC)
+	Date            string         `json:"date" example:"2025-01-15"`       // UTC day executions_today counts
+}
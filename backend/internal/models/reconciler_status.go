@@ -0,0 +1,24 @@
+package models
+
+import "time"
+
+// ReconcilerStatus reports the runs of a background reconciler since the process started
+// @Description ReconcilerStatus reports the runs of a background reconciler since the process started
+type ReconcilerStatus struct {
+	Running       bool       `json:"running"`                                                 // Whether the periodic loop is active
+	Runs          uint64     `json:"runs" example:"12"`                                       // Completed cycles, periodic and manual
+	StuckFound    uint64     `json:"stuck_found" example:"3"`                                 // Stuck tasks found over all cycles
+	Requeued      uint64     `json:"requeued" example:"3"`                                    // Delete jobs re-enqueued over all cycles
+	RequeueFailed uint64     `json:"requeue_failed" example:"0"`                              // Delete jobs that could not be re-enqueued
+	LastRunAt     *time.Time `json:"last_run_at,omitempty" example:"2025-01-15T10:00:00Z"`    // End of the last cycle
+	LastError     string     `json:"last_error,omitempty" example:"server selection timeout"` // Most recent error of a cycle, kept after later successful cycles
+	LastErrorAt   *time.Time `json:"last_error_at,omitempty" example:"2025-01-15T09:55:00Z"`  // When the most recent failed cycle ended
+}
+
+// ReconcilerRunResponse represents the response for running a reconciler cycle on demand
+type ReconcilerRunResponse struct {
+	StuckFound int              `json:"stuck_found" example:"2"` // Stuck tasks found by this cycle
+	Requeued   []string         `json:"requeued"`                // UUIDs of the tasks whose delete job was re-enqueued
+	Failed     []string         `json:"failed,omitempty"`        // UUIDs of the tasks whose delete job could not be re-enqueued
+	Status     ReconcilerStatus `json:"status"`
+}
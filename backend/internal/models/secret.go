@@ -0,0 +1,25 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Secret is an envelope-encrypted project secret referenced as {{secret:NAME}} in trigger headers and bodies.
+// The encrypted fields are never serialized to JSON.
+// @Description Secret is an envelope-encrypted project secret. Values are never returned by the API.
+type Secret struct {
+	ID               primitive.ObjectID `json:"id" bson:"_id,omitempty" example:"507f1f77bcf86cd799439011"`
+	ProjectID        primitive.ObjectID `json:"project_id" bson:"project_id" example:"507f1f77bcf86cd799439011"`
+	Name             string             `json:"name" bson:"name" example:"API_TOKEN"`
+	Ciphertext       []byte             `json:"-" bson:"ciphertext"`
+	EncryptedDataKey []byte             `json:"-" bson:"encrypted_data_key"`
+	CreatedAt        time.Time          `json:"created_at" bson:"created_at" example:"2025-01-15T10:00:00Z"`
+	UpdatedAt        time.Time          `json:"updated_at" bson:"updated_at" example:"2025-01-15T10:00:00Z"`
+}
+
+// PutSecretRequest represents the request DTO for creating or rotating a secret
+type PutSecretRequest struct {
+	Value string `json:"value" binding:"required" example:"s3cr3t-token"`
+}
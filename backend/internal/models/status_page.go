@@ -0,0 +1,39 @@
+package models
+
+import "time"
+
+// TaskHealth is the sanitized health of a task shown on the public status page
+type TaskHealth string
+
+const (
+	TaskHealthUp      TaskHealth = "up"
+	TaskHealthDown    TaskHealth = "down"
+	TaskHealthUnknown TaskHealth = "unknown" // The task has not completed a run yet
+)
+
+// Overall statuses of a public status page
+const (
+	StatusPageOperational = "operational"
+	StatusPageDegraded    = "degraded"
+)
+
+// StatusPageResponse is the public status page of a project. It only exposes task names and health.
+// @Description StatusPageResponse is the public status page of a project. It only exposes task names and health.
+type StatusPageResponse struct {
+	Project     string           `json:"project" example:"My Project"`
+	Status      string           `json:"status" enums:"operational,degraded" example:"operational"` // degraded when any task is down
+	GeneratedAt time.Time        `json:"generated_at" example:"2025-01-15T10:00:00Z"`
+	Tasks       []StatusPageTask `json:"tasks"`
+}
+
+// StatusPageTask is the health of one active task on the public status page
+type StatusPageTask struct {
+	Name          string     `json:"name" example:"Nightly backup"`
+	Health        TaskHealth `json:"health" enums:"up,down,unknown" example:"up"`
+	LastSuccessAt *time.Time `json:"last_success_at,omitempty" example:"2025-01-15T02:00:05Z"`
+}
+
+// StatusPageTokenResponse returns the token of a newly enabled status page
+type StatusPageTokenResponse struct {
+	Token string `json:"token" example:"6f1c2b9e-3d4a-4c8e-9b71-2a5f0e8d3c14"` // Pass as ?token= to GET /status/{project_id}
+}
@@ -1,8 +1,12 @@
 package models
 
 import (
+	"fmt"
+	"strings"
 	"time"
 
+	"github.com/yourusername/cron-observer/backend/internal/cronexpr"
+
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
@@ -16,17 +20,97 @@ type Task struct {
 	Name           string                 `json:"name" bson:"name" example:"Daily Backup"`
 	Description    string                 `json:"description,omitempty" bson:"description,omitempty" example:"Backup database daily"`
 	ScheduleType   ScheduleType           `json:"schedule_type" bson:"schedule_type" enums:"RECURRING,ONEOFF" example:"RECURRING"`
-	Status         TaskStatus             `json:"status" bson:"status" enums:"ACTIVE,DISABLED,PENDING_DELETE,DELETE_FAILED" example:"ACTIVE"`
+	Status         TaskStatus             `json:"status" bson:"status" enums:"ACTIVE,DISABLED,PAUSED,PENDING_DELETE,DELETE_FAILED" example:"ACTIVE"`
 	State          TaskState              `json:"state" bson:"state" enums:"RUNNING,NOT_RUNNING" example:"NOT_RUNNING"` // System-controlled: based on time window
 	ScheduleConfig ScheduleConfig         `json:"schedule_config" bson:"schedule_config"`
-	TriggerConfig  TriggerConfig          `json:"trigger_config,omitempty" bson:"trigger_config,omitempty"`                             // Deprecated: Tasks now use project's execution_endpoint
-	TimeoutSeconds *int                   `json:"timeout_seconds,omitempty" bson:"timeout_seconds,omitempty" binding:"omitempty,min=1"` // Optional timeout in seconds
+	TriggerConfig  TriggerConfig          `json:"trigger_config,omitempty" bson:"trigger_config,omitempty"`                                       // Deprecated: Tasks now use project's execution_endpoint
+	TimeoutSeconds *int                   `json:"timeout_seconds,omitempty" bson:"timeout_seconds,omitempty" binding:"omitempty,min=1"`           // Optional timeout in seconds
+	Priority       int                    `json:"priority,omitempty" bson:"priority,omitempty" example:"10"`                                      // Higher priorities dispatch first when firings queue up; 0 by default
+	Severity       TaskSeverity           `json:"severity,omitempty" bson:"severity,omitempty" enums:"CRITICAL,HIGH,NORMAL,LOW" example:"NORMAL"` // How urgent failures are, for alert routing and ordering; empty means NORMAL
 	Metadata       map[string]interface{} `json:"metadata,omitempty" bson:"metadata,omitempty"`
+	MetadataKeys   []string               `json:"-" bson:"metadata_keys,omitempty"`                                                           // Derived: the keys of metadata, stored by the repository so task search matches them through an index
+	Environment    string                 `json:"environment,omitempty" bson:"environment,omitempty" example:"staging"`                       // Project environment to dispatch to; empty uses the project's execution_endpoint
+	Env            map[string]string      `json:"env,omitempty" bson:"env,omitempty" example:"CONFIG_SET:eu-batch"`                           // Sent with every dispatch and available to trigger headers and body as {{env:NAME}}
+	Tags           []string               `json:"tags,omitempty" bson:"tags,omitempty" example:"team:payments"`                               // Free-form labels for filtering (owner, service, criticality, ...)
+	LastFailureAt  *time.Time             `json:"last_failure_at,omitempty" bson:"last_failure_at,omitempty" example:"2025-01-15T10:00:05Z"`  // System-controlled: time of the most recent failed execution
+	AutoPauseAfter int                    `json:"auto_pause_after_failures,omitempty" bson:"auto_pause_after_failures,omitempty" example:"5"` // Pause the task after this many consecutive failures; 0 never pauses
+	Concurrency    int                    `json:"concurrency_budget,omitempty" bson:"concurrency_budget,omitempty" example:"1"`               // How many executions of the task may run at once; 0 is unlimited. Firings over it wait as QUEUED executions
+	AutoPausedAt   *time.Time             `json:"auto_paused_at,omitempty" bson:"auto_paused_at,omitempty" example:"2025-01-15T03:10:00Z"`    // System-controlled: when the task was paused after consecutive failures; cleared when it is resumed
+	MutedUntil     *time.Time             `json:"muted_until,omitempty" bson:"muted_until,omitempty" example:"2025-01-15T12:00:00Z"`          // Failure alerts are suppressed until this time; executions still run and are recorded
+	Muted          bool                   `json:"muted" bson:"-" example:"false"`                                                             // Derived: whether alerts are currently muted
+	FlappingSince  *time.Time             `json:"flapping_since,omitempty" bson:"flapping_since,omitempty" example:"2025-01-15T10:30:00Z"`    // System-controlled: set while the task flips between success and failure; its alerts are held back meanwhile
+	NextRunAt      *time.Time             `json:"next_run_at,omitempty" bson:"next_run_at,omitempty" example:"2025-01-15T11:00:00Z"`          // System-controlled: next cron fire time, refreshed by the scheduler after every fire
+	Conflict       *ScheduleConflict      `json:"schedule_conflict,omitempty" bson:"-"`                                                       // Derived: set in create and update responses when the schedule exceeds the task group's concurrency budget
 
 	CreatedAt time.Time `json:"created_at" bson:"created_at" example:"2025-01-15T10:00:00Z"`
 	UpdatedAt time.Time `json:"updated_at" bson:"updated_at" example:"2025-01-15T10:00:00Z"`
 }
 
+// IsMuted reports whether failure alerts for the task are suppressed at the given time
+func (t *Task) IsMuted(now time.Time) bool {
+	return t.MutedUntil != nil && now.Before(*t.MutedUntil)
+}
+
+// EffectiveSeverity returns the task's severity; tasks without one are NORMAL
+func (t *Task) EffectiveSeverity() TaskSeverity {
+	return t.Severity.OrDefault()
+}
+
+// HasTag reports whether the task has the tag; tags are stored normalized
+func (t *Task) HasTag(tag string) bool {
+	for _, taskTag := range t.Tags {
+		if taskTag == tag {
+			return true
+		}
+	}
+	return false
+}
+
+// TaskSeverity is how urgent the failures of a task are
+type TaskSeverity string
+
+const (
+	TaskSeverityCritical TaskSeverity = "CRITICAL"
+	TaskSeverityHigh     TaskSeverity = "HIGH"
+	TaskSeverityNormal   TaskSeverity = "NORMAL"
+	TaskSeverityLow      TaskSeverity = "LOW"
+)
+
+// TaskSeverities lists the severities from the most to the least urgent
+var TaskSeverities = []TaskSeverity{TaskSeverityCritical, TaskSeverityHigh, TaskSeverityNormal, TaskSeverityLow}
+
+// OrDefault returns the severity, or NORMAL when it is empty
+func (s TaskSeverity) OrDefault() TaskSeverity {
+	if s == "" {
+		return TaskSeverityNormal
+	}
+	return s
+}
+
+// Rank orders severities by urgency, from LOW (1) to CRITICAL (4); empty and unknown severities rank as NORMAL
+func (s TaskSeverity) Rank() int {
+	switch s {
+	case TaskSeverityCritical:
+		return 4
+	case TaskSeverityHigh:
+		return 3
+	case TaskSeverityLow:
+		return 1
+	default:
+		return 2
+	}
+}
+
+// IsValid reports whether the severity is one of the known levels
+func (s TaskSeverity) IsValid() bool {
+	for _, severity := range TaskSeverities {
+		if s == severity {
+			return true
+		}
+	}
+	return false
+}
+
 // ScheduleType defines the type of schedule
 type ScheduleType string
 
@@ -44,6 +128,10 @@ const (
 	TaskStatusActive   TaskStatus = "ACTIVE"
 	TaskStatusDisabled TaskStatus = "DISABLED"
 
+	// Internal-only: set by backend after auto_pause_after_failures consecutive failures. Unscheduled like DISABLED
+	// until a client sets the task ACTIVE again.
+	TaskStatusPaused TaskStatus = "PAUSED"
+
 	// Internal-only: set by backend during durable delete flow. Not accepted from external clients.
 	TaskStatusPendingDelete TaskStatus = "PENDING_DELETE" // Delete requested; job enqueued or will be.
 	TaskStatusDeleteFailed  TaskStatus = "DELETE_FAILED"  // Delete attempt failed; record exists, needs attention.
@@ -61,12 +149,14 @@ const (
 // Behavior:
 //   - If CronExpression is provided: TimeRange and DaysOfWeek are ignored, schedule follows cron expression only
 //   - If CronExpression is not provided: TimeRange and DaysOfWeek are used to determine execution schedule
+//   - If Schedule is provided: it is translated into CronExpression and kept alongside it
 type ScheduleConfig struct {
-	CronExpression string     `json:"cron_expression,omitempty" bson:"cron_expression,omitempty" binding:"omitempty,cron"` // If provided, TimeRange and DaysOfWeek are ignored
-	Timezone       string     `json:"timezone" bson:"timezone" binding:"required,timezone"`
-	TimeRange      *TimeRange `json:"time_range,omitempty" bson:"time_range,omitempty" binding:"omitempty"`                      // Used only if CronExpression is not provided
-	DaysOfWeek     []int      `json:"days_of_week,omitempty" bson:"days_of_week,omitempty" binding:"omitempty,dive,min=0,max=6"` // Used only if CronExpression is not provided
-	Exclusions     []int      `json:"exclusions,omitempty" bson:"exclusions,omitempty" binding:"omitempty,dive,min=0,max=6"`
+	CronExpression string     `json:"cron_expression,omitempty" bson:"cron_expression,omitempty" yaml:"cron_expression,omitempty" binding:"omitempty,cron"`    // If provided, TimeRange and DaysOfWeek are ignored
+	Timezone       string     `json:"timezone" bson:"timezone" yaml:"timezone,omitempty" binding:"omitempty,timezone"`                                         // Falls back to the project's default_timezone
+	TimeRange      *TimeRange `json:"time_range,omitempty" bson:"time_range,omitempty" yaml:"time_range,omitempty" binding:"omitempty"`                        // Used only if CronExpression is not provided
+	DaysOfWeek     []int      `json:"days_of_week,omitempty" bson:"days_of_week,omitempty" yaml:"days_of_week,omitempty" binding:"omitempty,dive,min=0,max=6"` // Used only if CronExpression is not provided
+	Exclusions     []int      `json:"exclusions,omitempty" bson:"exclusions,omitempty" yaml:"exclusions,omitempty" binding:"omitempty,dive,min=0,max=6"`
+	Schedule       string     `json:"schedule,omitempty" bson:"schedule,omitempty" yaml:"schedule,omitempty" binding:"omitempty,max=200" example:"weekdays at 9am"` // Natural-language schedule; translated into CronExpression when the task is saved
 }
 
 // FrequencyUnit defines the unit for frequency
@@ -80,15 +170,33 @@ const (
 
 // Frequency defines how often a task should run within a time range
 type Frequency struct {
-	Value int           `json:"value" bson:"value" binding:"required,min=1"`     // Numeric value (e.g., 15)
-	Unit  FrequencyUnit `json:"unit" bson:"unit" binding:"required,oneof=s m h"` // Unit: "s" (seconds), "m" (minutes), "h" (hours)
+	Value int           `json:"value" bson:"value" yaml:"value" binding:"required,min=1"`    // Numeric value (e.g., 15)
+	Unit  FrequencyUnit `json:"unit" bson:"unit" yaml:"unit" binding:"required,oneof=s m h"` // Unit: "s" (seconds), "m" (minutes), "h" (hours)
+}
+
+// ResolveSchedule translates the natural-language Schedule into CronExpression.
+// A CronExpression given alongside it must be the same translation, so exported configs can be imported again.
+func (sc *ScheduleConfig) ResolveSchedule() error {
+	if sc.Schedule == "" {
+		return nil
+	}
+
+	expression, err := cronexpr.FromPhrase(sc.Schedule)
+	if err != nil {
+		return err
+	}
+	if sc.CronExpression != "" && sc.CronExpression != expression {
+		return fmt.Errorf("schedule %q means %q, which conflicts with cron_expression %q", sc.Schedule, expression, sc.CronExpression)
+	}
+	sc.CronExpression = expression
+	return nil
 }
 
 // TimeRange defines a time range for task execution with frequency
 type TimeRange struct {
-	Start     string     `json:"start" bson:"start" binding:"required,time_format"` // Format: "HH:MM"
-	End       string     `json:"end" bson:"end" binding:"required,time_format"`     // Format: "HH:MM"
-	Frequency *Frequency `json:"frequency" bson:"frequency" binding:"required"`     // Frequency with value and unit (e.g., {value: 15, unit: "m"})
+	Start     string     `json:"start" bson:"start" yaml:"start" binding:"required,time_format"` // Format: "HH:MM"
+	End       string     `json:"end" bson:"end" yaml:"end" binding:"required,time_format"`       // Format: "HH:MM"
+	Frequency *Frequency `json:"frequency" bson:"frequency" yaml:"frequency" binding:"required"` // Frequency with value and unit (e.g., {value: 15, unit: "m"})
 }
 
 // CreateTaskRequest represents the request DTO for creating a task.
@@ -102,12 +210,19 @@ type CreateTaskRequest struct {
 	Status         TaskStatus             `json:"status,omitempty" binding:"omitempty,oneof=ACTIVE DISABLED"`
 	ScheduleConfig ScheduleConfig         `json:"schedule_config" binding:"required"`
 	TimeoutSeconds *int                   `json:"timeout_seconds,omitempty" binding:"omitempty,min=1"`
+	Priority       int                    `json:"priority,omitempty" binding:"omitempty,min=0,max=100"`
+	AutoPauseAfter int                    `json:"auto_pause_after_failures,omitempty" binding:"omitempty,min=1,max=1000"` // Consecutive failures that pause the task; omit to never pause
+	Concurrency    int                    `json:"concurrency_budget,omitempty" binding:"omitempty,min=1,max=1000"`        // Executions of the task that may run at once; omit for no limit
+	Severity       TaskSeverity           `json:"severity,omitempty" binding:"omitempty,oneof=CRITICAL HIGH NORMAL LOW"`  // Defaults to NORMAL
 	Metadata       map[string]interface{} `json:"metadata,omitempty"`
+	Environment    string                 `json:"environment,omitempty" binding:"omitempty,env_name"`
+	Env            map[string]string      `json:"env,omitempty" binding:"omitempty,max=50,dive,keys,env_var,endkeys,max=4096"`
+	Tags           []string               `json:"tags,omitempty" binding:"omitempty,max=20,dive,task_tag"`
 }
 
 // UpdateTaskRequest represents the request DTO for full task update (PUT).
 // Same structure as CreateTaskRequest but without ProjectID (comes from path parameter).
-// Status: only ACTIVE and DISABLED are accepted from clients. PENDING_DELETE and DELETE_FAILED are backend-only.
+// Status: only ACTIVE and DISABLED are accepted from clients. PAUSED, PENDING_DELETE and DELETE_FAILED are backend-only.
 type UpdateTaskRequest struct {
 	TaskGroupID    string                 `json:"task_group_id,omitempty" binding:"omitempty,objectid"` // Optional task group ID
 	Name           string                 `json:"name" binding:"required,min=1,max=255"`
@@ -116,7 +231,178 @@ type UpdateTaskRequest struct {
 	Status         TaskStatus             `json:"status,omitempty" binding:"omitempty,oneof=ACTIVE DISABLED"`
 	ScheduleConfig ScheduleConfig         `json:"schedule_config" binding:"required"`
 	TimeoutSeconds *int                   `json:"timeout_seconds,omitempty" binding:"omitempty,min=1"`
+	Priority       int                    `json:"priority,omitempty" binding:"omitempty,min=0,max=100"`
+	AutoPauseAfter int                    `json:"auto_pause_after_failures,omitempty" binding:"omitempty,min=1,max=1000"` // Consecutive failures that pause the task; omit to never pause
+	Concurrency    int                    `json:"concurrency_budget,omitempty" binding:"omitempty,min=1,max=1000"`        // Executions of the task that may run at once; omit for no limit
+	Severity       TaskSeverity           `json:"severity,omitempty" binding:"omitempty,oneof=CRITICAL HIGH NORMAL LOW"`  // Defaults to NORMAL
+	Metadata       map[string]interface{} `json:"metadata,omitempty"`
+	Environment    string                 `json:"environment,omitempty" binding:"omitempty,env_name"` // Empty dispatches to the project's execution_endpoint
+	Env            map[string]string      `json:"env,omitempty" binding:"omitempty,max=50,dive,keys,env_var,endkeys,max=4096"`
+	Tags           []string               `json:"tags,omitempty" binding:"omitempty,max=20,dive,task_tag"`
+}
+
+// NormalizeTags trims, lowercases and de-duplicates tags, preserving their order
+func NormalizeTags(tags []string) []string {
+	if len(tags) == 0 {
+		return nil
+	}
+
+	seen := make(map[string]bool, len(tags))
+	normalized := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag == "" || seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		normalized = append(normalized, tag)
+	}
+	return normalized
+}
+
+// TaskSortField is a field the task list can be sorted by
+type TaskSortField string
+
+const (
+	TaskSortByName        TaskSortField = "name"
+	TaskSortByCreatedAt   TaskSortField = "created_at"
+	TaskSortByLastFailure TaskSortField = "last_failure_at"
+	TaskSortBySeverity    TaskSortField = "severity" // By urgency, not alphabetically
+)
+
+// TaskListFilter narrows and orders a project's task list. Zero values apply no filter.
+type TaskListFilter struct {
+	Status       TaskStatus
+	State        TaskState
+	TaskGroupID  *primitive.ObjectID
+	ScheduleType ScheduleType
+	Tags         []string      // Tasks must have every listed tag
+	Severity     TaskSeverity  // NORMAL also matches tasks without a severity
+	Search       string        // Case-insensitive substring matched against name, description and metadata keys
+	SortBy       TaskSortField // Defaults to created_at
+	SortDesc     bool
+}
+
+// PaginatedTasksResponse represents a paginated response for tasks
+type PaginatedTasksResponse struct {
+	Data       []*TaskDetailResponse `json:"data"`
+	Page       int                   `json:"page"`
+	PageSize   int                   `json:"page_size"`
+	TotalCount int64                 `json:"total_count"`
+	TotalPages int                   `json:"total_pages"`
+}
+
+// TaskDetailResponse is a task with fields derived from the scheduler and its execution history
+// @Description TaskDetailResponse is a task with fields derived from the scheduler and its execution history
+type TaskDetailResponse struct {
+	Task
+	IsRegistered  bool              `json:"is_registered" example:"true"` // Whether the task currently has a cron entry in the scheduler
+	LastExecution *ExecutionSummary `json:"last_execution,omitempty"`     // Most recent execution; omitted when the task has never run
+}
+
+// CloneTaskRequest represents the request DTO for duplicating a task. All fields are optional.
+type CloneTaskRequest struct {
+	TaskGroupID string `json:"task_group_id,omitempty" binding:"omitempty,objectid"`                           // Group for the copy; defaults to the source task's group
+	Name        string `json:"name,omitempty" binding:"omitempty,min=1,max=255" example:"Daily Backup (copy)"` // Defaults to the source name with a " (copy)" suffix
+	Enable      bool   `json:"enable,omitempty"`                                                               // Keep the source status; by default the copy is DISABLED
+}
+
+// MoveTaskRequest represents the request DTO for moving a task to another task group
+type MoveTaskRequest struct {
+	TaskGroupUUID *string `json:"task_group_uuid" example:"550e8400-e29b-41d4-a716-446655440000"` // Target group; null or omitted removes the task from its group
+}
+
+// MuteTaskRequest represents the request DTO for muting a task's failure alerts.
+// Exactly one of until or duration_minutes must be provided, and the result must lie in the future.
+type MuteTaskRequest struct {
+	Until           *time.Time `json:"until,omitempty" example:"2025-01-15T12:00:00Z"`
+	DurationMinutes int        `json:"duration_minutes,omitempty" binding:"omitempty,min=1,max=43200" example:"60"` // Up to 30 days
+}
+
+// PatchTaskRequest represents the request DTO for partial task update (PATCH).
+// Omitted fields keep their current value; schedule_config is replaced as a whole when present.
+type PatchTaskRequest struct {
+	TaskGroupID    string                 `json:"task_group_id,omitempty" binding:"omitempty,objectid"`
+	Name           *string                `json:"name,omitempty" binding:"omitempty,min=1,max=255"`
+	Description    *string                `json:"description,omitempty" binding:"omitempty,max=1000"`
+	ScheduleType   *ScheduleType          `json:"schedule_type,omitempty" binding:"omitempty,oneof=RECURRING ONEOFF"`
+	Status         *TaskStatus            `json:"status,omitempty" binding:"omitempty,oneof=ACTIVE DISABLED"`
+	ScheduleConfig *ScheduleConfig        `json:"schedule_config,omitempty" binding:"omitempty"`
+	TimeoutSeconds *int                   `json:"timeout_seconds,omitempty" binding:"omitempty,min=1"`
+	Priority       *int                   `json:"priority,omitempty" binding:"omitempty,min=0,max=100"`
+	AutoPauseAfter *int                   `json:"auto_pause_after_failures,omitempty" binding:"omitempty,min=0,max=1000"` // Send 0 to never pause
+	Concurrency    *int                   `json:"concurrency_budget,omitempty" binding:"omitempty,min=0,max=1000"`        // Send 0 to remove the limit
+	Severity       *TaskSeverity          `json:"severity,omitempty" binding:"omitempty,oneof=CRITICAL HIGH NORMAL LOW"`
 	Metadata       map[string]interface{} `json:"metadata,omitempty"`
+	Environment    *string                `json:"environment,omitempty"` // Send "" to dispatch to the project's execution_endpoint
+	Env            map[string]string      `json:"env,omitempty"`         // Replaces all variables; send {} to remove them
+	Tags           []string               `json:"tags,omitempty"`        // Send [] to remove all tags
+}
+
+// ToUpdateRequest merges the patch into the task's current values, producing a full update request
+func (r *PatchTaskRequest) ToUpdateRequest(task *Task) UpdateTaskRequest {
+	req := UpdateTaskRequest{
+		TaskGroupID:    r.TaskGroupID,
+		Name:           task.Name,
+		Description:    task.Description,
+		ScheduleType:   task.ScheduleType,
+		Status:         task.Status,
+		ScheduleConfig: task.ScheduleConfig,
+		TimeoutSeconds: task.TimeoutSeconds,
+		Priority:       task.Priority,
+		AutoPauseAfter: task.AutoPauseAfter,
+		Concurrency:    task.Concurrency,
+		Severity:       task.Severity,
+		Metadata:       task.Metadata,
+		Environment:    task.Environment,
+		Env:            task.Env,
+		Tags:           task.Tags,
+	}
+
+	if r.Name != nil {
+		req.Name = *r.Name
+	}
+	if r.Description != nil {
+		req.Description = *r.Description
+	}
+	if r.ScheduleType != nil {
+		req.ScheduleType = *r.ScheduleType
+	}
+	if r.Status != nil {
+		req.Status = *r.Status
+	}
+	if r.ScheduleConfig != nil {
+		req.ScheduleConfig = *r.ScheduleConfig
+	}
+	if r.TimeoutSeconds != nil {
+		req.TimeoutSeconds = r.TimeoutSeconds
+	}
+	if r.Priority != nil {
+		req.Priority = *r.Priority
+	}
+	if r.AutoPauseAfter != nil {
+		req.AutoPauseAfter = *r.AutoPauseAfter
+	}
+	if r.Concurrency != nil {
+		req.Concurrency = *r.Concurrency
+	}
+	if r.Severity != nil {
+		req.Severity = *r.Severity
+	}
+	if r.Metadata != nil {
+		req.Metadata = r.Metadata
+	}
+	if r.Environment != nil {
+		req.Environment = *r.Environment
+	}
+	if r.Env != nil {
+		req.Env = r.Env
+	}
+	if r.Tags != nil {
+		req.Tags = r.Tags
+	}
+
+	return req
 }
 
 // TriggerType defines the type of trigger
@@ -0,0 +1,11 @@
+package models
+
+import "go.mongodb.org/mongo-driver/bson/primitive"
+
+// TaskReference is the project and task group a task points at, loaded without the rest of the task for
+// referential integrity checks
+type TaskReference struct {
+	UUID        string              `bson:"uuid"`
+	ProjectID   primitive.ObjectID  `bson:"project_id"`
+	TaskGroupID *primitive.ObjectID `bson:"task_group_id,omitempty"`
+}
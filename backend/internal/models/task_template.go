@@ -0,0 +1,279 @@
+package models
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// TaskTemplate is a reusable task skeleton. Its name, description and metadata string values
+// may contain {{parameter}} placeholders that are substituted when a task is created from it.
+// @Description TaskTemplate is a reusable task skeleton with {{parameter}} placeholders
+type TaskTemplate struct {
+	ID          primitive.ObjectID  `json:"id,omitempty" bson:"_id,omitempty" example:"507f1f77bcf86cd799439011"`
+	UUID        string              `json:"uuid" bson:"uuid" example:"550e8400-e29b-41d4-a716-446655440000"`
+	ProjectID   *primitive.ObjectID `json:"project_id,omitempty" bson:"project_id,omitempty" example:"507f1f77bcf86cd799439011"` // Empty for built-in templates
+	Name        string              `json:"name" bson:"name" example:"Hourly HTTP health check"`
+	Description string              `json:"description,omitempty" bson:"description,omitempty" example:"Calls a health endpoint every hour"`
+	Parameters  []TemplateParameter `json:"parameters,omitempty" bson:"parameters,omitempty"`
+	Task        TaskTemplateSpec    `json:"task" bson:"task"`
+	BuiltIn     bool                `json:"built_in" bson:"-" example:"false"` // Built-in templates are defined by the server and cannot be deleted
+	CreatedAt   time.Time           `json:"created_at,omitempty" bson:"created_at" example:"2025-01-15T10:00:00Z"`
+	UpdatedAt   time.Time           `json:"updated_at,omitempty" bson:"updated_at" example:"2025-01-15T10:00:00Z"`
+}
+
+// TemplateParameter declares a placeholder that can be used in a template as {{name}}
+type TemplateParameter struct {
+	Name        string `json:"name" bson:"name" binding:"required,template_param" example:"url"`
+	Description string `json:"description,omitempty" bson:"description,omitempty" binding:"omitempty,max=255" example:"Health endpoint to call"`
+	Default     string `json:"default,omitempty" bson:"default,omitempty" example:"https://example.com/health"`
+	Required    bool   `json:"required,omitempty" bson:"required,omitempty" example:"true"` // Parameters that are not required fall back to their default
+}
+
+// TaskTemplateSpec holds the task fields a template creates. Schedule and tags are used as-is;
+// placeholders are only substituted in name, description and metadata string values.
+type TaskTemplateSpec struct {
+	Name           string                 `json:"name" bson:"name" binding:"required,min=1,max=255" example:"Health check {{service}}"`
+	Description    string                 `json:"description,omitempty" bson:"description,omitempty" binding:"omitempty,max=1000"`
+	ScheduleType   ScheduleType           `json:"schedule_type" bson:"schedule_type" binding:"required,oneof=RECURRING ONEOFF" example:"RECURRING"`
+	ScheduleConfig ScheduleConfig         `json:"schedule_config" bson:"schedule_config" binding:"required"`
+	TimeoutSeconds *int                   `json:"timeout_seconds,omitempty" bson:"timeout_seconds,omitempty" binding:"omitempty,min=1"`
+	Metadata       map[string]interface{} `json:"metadata,omitempty" bson:"metadata,omitempty"`
+	Tags           []string               `json:"tags,omitempty" bson:"tags,omitempty" binding:"omitempty,max=20,dive,task_tag"`
+}
+
+// CreateTaskTemplateRequest represents the request DTO for adding a template to a project
+type CreateTaskTemplateRequest struct {
+	Name        string              `json:"name" binding:"required,min=1,max=255"`
+	Description string              `json:"description,omitempty" binding:"omitempty,max=1000"`
+	Parameters  []TemplateParameter `json:"parameters,omitempty" binding:"omitempty,max=20,dive"`
+	Task        TaskTemplateSpec    `json:"task" binding:"required"`
+}
+
+// CreateTaskFromTemplateRequest represents the request DTO for creating a task from a template
+type CreateTaskFromTemplateRequest struct {
+	TaskGroupID    string            `json:"task_group_id,omitempty" binding:"omitempty,objectid"`
+	Name           string            `json:"name,omitempty" binding:"omitempty,min=1,max=255"` // Overrides the rendered template name
+	Status         TaskStatus        `json:"status,omitempty" binding:"omitempty,oneof=ACTIVE DISABLED"`
+	Environment    string            `json:"environment,omitempty" binding:"omitempty,env_name"`
+	ScheduleConfig *ScheduleConfig   `json:"schedule_config,omitempty" binding:"omitempty"` // Overrides the template schedule
+	Parameters     map[string]string `json:"parameters,omitempty" example:"url:https://api.example.com/health"`
+}
+
+// templatePlaceholderPattern matches {{name}} placeholders. Secret references such as
+// {{secret:API_TOKEN}} do not match and are left for the dispatcher to resolve.
+var templatePlaceholderPattern = regexp.MustCompile(`\{\{\s*([a-z][a-z0-9_]*)\s*\}\}`)
+
+// UndeclaredPlaceholders returns the placeholders used by the template that are not declared as parameters
+func (t *TaskTemplate) UndeclaredPlaceholders() []string {
+	declared := make(map[string]bool, len(t.Parameters))
+	for _, param := range t.Parameters {
+		declared[param.Name] = true
+	}
+
+	found := make(map[string]bool)
+	collect := func(s string) string {
+		for _, match := range templatePlaceholderPattern.FindAllStringSubmatch(s, -1) {
+			if !declared[match[1]] {
+				found[match[1]] = true
+			}
+		}
+		return s
+	}
+	collect(t.Task.Name)
+	collect(t.Task.Description)
+	substituteMetadata(t.Task.Metadata, collect)
+
+	undeclared := make([]string, 0, len(found))
+	for name := range found {
+		undeclared = append(undeclared, name)
+	}
+	sort.Strings(undeclared)
+	return undeclared
+}
+
+// Instantiate renders the template with the given parameter values into a task creation request.
+// Unknown parameters and missing required parameters are rejected.
+func (t *TaskTemplate) Instantiate(projectID string, req CreateTaskFromTemplateRequest) (*CreateTaskRequest, error) {
+	values := make(map[string]string, len(t.Parameters))
+	declared := make(map[string]bool, len(t.Parameters))
+	for _, param := range t.Parameters {
+		declared[param.Name] = true
+		if value, ok := req.Parameters[param.Name]; ok {
+			values[param.Name] = value
+			continue
+		}
+		if param.Required {
+			return nil, fmt.Errorf("parameter '%s' is required", param.Name)
+		}
+		values[param.Name] = param.Default
+	}
+	for name := range req.Parameters {
+		if !declared[name] {
+			return nil, fmt.Errorf("template has no parameter '%s'", name)
+		}
+	}
+
+	render := func(s string) string {
+		return templatePlaceholderPattern.ReplaceAllStringFunc(s, func(placeholder string) string {
+			name := templatePlaceholderPattern.FindStringSubmatch(placeholder)[1]
+			if value, ok := values[name]; ok {
+				return value
+			}
+			return placeholder
+		})
+	}
+
+	taskReq := &CreateTaskRequest{
+		ProjectID:      projectID,
+		TaskGroupID:    req.TaskGroupID,
+		Name:           render(t.Task.Name),
+		Description:    render(t.Task.Description),
+		ScheduleType:   t.Task.ScheduleType,
+		Status:         req.Status,
+		ScheduleConfig: t.Task.ScheduleConfig,
+		Metadata:       substituteMetadata(t.Task.Metadata, render),
+		Environment:    req.Environment,
+		Tags:           append([]string(nil), t.Task.Tags...),
+	}
+	if t.Task.TimeoutSeconds != nil {
+		timeout := *t.Task.TimeoutSeconds
+		taskReq.TimeoutSeconds = &timeout
+	}
+	if req.Name != "" {
+		taskReq.Name = req.Name
+	}
+	if req.ScheduleConfig != nil {
+		taskReq.ScheduleConfig = *req.ScheduleConfig
+	}
+	return taskReq, nil
+}
+
+// substituteMetadata returns a deep copy of metadata with fn applied to every string value
+func substituteMetadata(metadata map[string]interface{}, fn func(string) string) map[string]interface{} {
+	if metadata == nil {
+		return nil
+	}
+	result := make(map[string]interface{}, len(metadata))
+	for key, value := range metadata {
+		result[key] = substituteValue(value, fn)
+	}
+	return result
+}
+
+func substituteValue(value interface{}, fn func(string) string) interface{} {
+	switch v := value.(type) {
+	case string:
+		return fn(v)
+	case map[string]interface{}:
+		return substituteMetadata(v, fn)
+	case primitive.M:
+		return substituteMetadata(v, fn)
+	case []interface{}:
+		items := make([]interface{}, len(v))
+		for i, item := range v {
+			items[i] = substituteValue(item, fn)
+		}
+		return items
+	case primitive.A:
+		items := make([]interface{}, len(v))
+		for i, item := range v {
+			items[i] = substituteValue(item, fn)
+		}
+		return items
+	default:
+		return v
+	}
+}
+
+// BuiltinTaskTemplates returns the templates available to every project
+func BuiltinTaskTemplates() []*TaskTemplate {
+	healthCheckTimeout := 30
+	backupTimeout := 3600
+
+	return []*TaskTemplate{
+		{
+			UUID:        "builtin-hourly-http-health-check",
+			Name:        "Hourly HTTP health check",
+			Description: "Calls a service health endpoint at the top of every hour and fails on an unexpected status",
+			Parameters: []TemplateParameter{
+				{Name: "service", Description: "Service name used in the task name", Required: true},
+				{Name: "url", Description: "Health endpoint to call", Required: true},
+				{Name: "expected_status", Description: "HTTP status treated as healthy", Default: "200"},
+			},
+			Task: TaskTemplateSpec{
+				Name:           "Health check {{service}}",
+				Description:    "Hourly health check of {{url}}",
+				ScheduleType:   ScheduleTypeRecurring,
+				ScheduleConfig: ScheduleConfig{CronExpression: "0 0 * * * *"},
+				TimeoutSeconds: &healthCheckTimeout,
+				Metadata: map[string]interface{}{
+					"check":           "http",
+					"method":          "GET",
+					"url":             "{{url}}",
+					"expected_status": "{{expected_status}}",
+				},
+				Tags: []string{"health-check"},
+			},
+			BuiltIn: true,
+		},
+		{
+			UUID:        "builtin-daily-backup",
+			Name:        "Daily backup",
+			Description: "Runs a backup job every night at 02:00",
+			Parameters: []TemplateParameter{
+				{Name: "target", Description: "Database or volume to back up", Required: true},
+			},
+			Task: TaskTemplateSpec{
+				Name:           "Backup {{target}}",
+				Description:    "Nightly backup of {{target}}",
+				ScheduleType:   ScheduleTypeRecurring,
+				ScheduleConfig: ScheduleConfig{CronExpression: "0 0 2 * * *"},
+				TimeoutSeconds: &backupTimeout,
+				Metadata:       map[string]interface{}{"target": "{{target}}"},
+				Tags:           []string{"backup"},
+			},
+			BuiltIn: true,
+		},
+		{
+			UUID:        "builtin-business-hours-poll",
+			Name:        "Business hours poll",
+			Description: "Polls a resource every 15 minutes between 09:00 and 17:00 on weekdays",
+			Parameters: []TemplateParameter{
+				{Name: "resource", Description: "Resource to poll", Required: true},
+			},
+			Task: TaskTemplateSpec{
+				Name:         "Poll {{resource}}",
+				ScheduleType: ScheduleTypeRecurring,
+				ScheduleConfig: ScheduleConfig{
+					TimeRange: &TimeRange{
+						Start:     "09:00",
+						End:       "17:00",
+						Frequency: &Frequency{Value: 15, Unit: FrequencyUnitMinute},
+					},
+					DaysOfWeek: []int{1, 2, 3, 4, 5},
+				},
+				Metadata: map[string]interface{}{"resource": "{{resource}}"},
+				Tags:     []string{"poll"},
+			},
+			BuiltIn: true,
+		},
+	}
+}
+
+// FindBuiltinTaskTemplate returns the built-in template with the given UUID
+func FindBuiltinTaskTemplate(templateUUID string) (*TaskTemplate, bool) {
+	if !strings.HasPrefix(templateUUID, "builtin-") {
+		return nil, false
+	}
+	for _, template := range BuiltinTaskTemplates() {
+		if template.UUID == templateUUID {
+			return template, true
+		}
+	}
+	return nil, false
+}
@@ -14,13 +14,18 @@ type TaskGroup struct {
 	ProjectID   primitive.ObjectID `json:"project_id" bson:"project_id" example:"507f1f77bcf86cd799439011"`
 	Name        string             `json:"name" bson:"name" example:"Morning Tasks"`
 	Description string             `json:"description,omitempty" bson:"description,omitempty" example:"Tasks that run in the morning"`
-	Status      TaskGroupStatus    `json:"status" bson:"status" enums:"ACTIVE,DISABLED" example:"ACTIVE"`
+	Status      TaskGroupStatus    `json:"status" bson:"status" enums:"ACTIVE,DISABLED,PENDING_DELETE" example:"ACTIVE"`
 	State       TaskGroupState     `json:"state" bson:"state" enums:"RUNNING,NOT_RUNNING" example:"NOT_RUNNING"`    // System-controlled: based on time window
 	StartTime   string             `json:"start_time,omitempty" bson:"start_time,omitempty" example:"09:00"`        // Format: "HH:MM"
 	EndTime     string             `json:"end_time,omitempty" bson:"end_time,omitempty" example:"17:00"`            // Format: "HH:MM"
 	Timezone    string             `json:"timezone,omitempty" bson:"timezone,omitempty" example:"America/New_York"` // IANA timezone (e.g., "America/New_York")
 	CreatedAt   time.Time          `json:"created_at" bson:"created_at" example:"2025-01-15T10:00:00Z"`
 	UpdatedAt   time.Time          `json:"updated_at" bson:"updated_at" example:"2025-01-15T10:00:00Z"`
+
+	// Schedule conflict detection: creating or updating a task checks its runs over the next 24 hours against the
+	// runs of the group's other tasks
+	Concurrency int            `json:"concurrency_budget,omitempty" bson:"concurrency_budget" example:"3"`                                    // How many of the group's tasks may be scheduled to run at once; firings over it wait as QUEUED executions. 0 disables the check
+	OnConflict  ConflictPolicy `json:"schedule_conflict_policy,omitempty" bson:"schedule_conflict_policy" enums:"WARN,REJECT" example:"WARN"` // What saving a task that exceeds the budget does; empty means WARN
 }
 
 // TaskGroupStatus defines the status of a task group
@@ -29,8 +34,30 @@ type TaskGroupStatus string
 const (
 	TaskGroupStatusActive   TaskGroupStatus = "ACTIVE"
 	TaskGroupStatusDisabled TaskGroupStatus = "DISABLED"
+
+	// Internal-only: set by backend while the delete worker removes the group. Not accepted from external clients.
+	TaskGroupStatusPendingDelete TaskGroupStatus = "PENDING_DELETE"
+)
+
+// ConflictPolicy is what creating or updating a task whose schedule exceeds its group's concurrency budget does
+type ConflictPolicy string
+
+const (
+	ConflictPolicyWarn   ConflictPolicy = "WARN"   // Save the task and report the conflict in schedule_conflict
+	ConflictPolicyReject ConflictPolicy = "REJECT" // Refuse to save the task
 )
 
+// ScheduleConflict reports that a task's schedule overlaps with the other tasks of its group beyond the group's
+// concurrency budget within the next 24 hours
+type ScheduleConflict struct {
+	Budget         int       `json:"budget" example:"3"`
+	Peak           int       `json:"peak" example:"5"`                           // Most of the group's runs overlapping with a run of the task
+	PeakAt         time.Time `json:"peak_at" example:"2025-01-15T02:00:00Z"`     // When the peak is first reached
+	Runs           int       `json:"runs" example:"24"`                          // Runs of the task in the next 24 hours
+	OverBudgetRuns int       `json:"over_budget_runs" example:"6"`               // Runs of the task that overlap with more runs than the budget allows
+	TaskUUIDs      []string  `json:"task_uuids" example:"nightly-invoices-uuid"` // Other tasks running at the peak
+}
+
 // TaskGroupState defines the runtime state of a task group (system-controlled)
 type TaskGroupState string
 
@@ -39,6 +66,18 @@ const (
 	TaskGroupStateNotRunning TaskGroupState = "NOT_RUNNING"
 )
 
+// TaskGroupCascade is what a task group update changes on the group's tasks. Empty fields are left unchanged.
+type TaskGroupCascade struct {
+	TaskStatus TaskStatus
+	TaskState  TaskState
+}
+
+// TaskGroupCascadeResult counts the tasks a cascade changed
+type TaskGroupCascadeResult struct {
+	StatusUpdated int64
+	StateUpdated  int64
+}
+
 // CreateTaskGroupRequest represents the request DTO for creating a task group
 type CreateTaskGroupRequest struct {
 	ProjectID   string          `json:"project_id" binding:"required,objectid"`
@@ -48,6 +87,8 @@ type CreateTaskGroupRequest struct {
 	StartTime   string          `json:"start_time,omitempty" binding:"omitempty,time_format"` // Format: "HH:MM"
 	EndTime     string          `json:"end_time,omitempty" binding:"omitempty,time_format"`   // Format: "HH:MM"
 	Timezone    string          `json:"timezone,omitempty" binding:"omitempty,timezone"`
+	Concurrency int             `json:"concurrency_budget,omitempty" binding:"omitempty,min=1,max=1000"`
+	OnConflict  ConflictPolicy  `json:"schedule_conflict_policy,omitempty" binding:"omitempty,oneof=WARN REJECT"`
 }
 
 // UpdateTaskGroupRequest represents the request DTO for updating a task group
@@ -58,4 +99,28 @@ type UpdateTaskGroupRequest struct {
 	StartTime   string          `json:"start_time,omitempty" binding:"omitempty,time_format"` // Format: "HH:MM"
 	EndTime     string          `json:"end_time,omitempty" binding:"omitempty,time_format"`   // Format: "HH:MM"
 	Timezone    string          `json:"timezone,omitempty" binding:"omitempty,timezone"`
+	Concurrency int             `json:"concurrency_budget,omitempty" binding:"omitempty,min=1,max=1000"`
+	OnConflict  ConflictPolicy  `json:"schedule_conflict_policy,omitempty" binding:"omitempty,oneof=WARN REJECT"`
+}
+
+// GroupTimelineLane is one member task of a group timeline with its executions, oldest first
+type GroupTimelineLane struct {
+	TaskUUID   string             `json:"task_uuid" example:"550e8400-e29b-41d4-a716-446655440000"`
+	TaskName   string             `json:"task_name" example:"nightly-invoices"`
+	Executions []ExecutionSummary `json:"executions"`
+}
+
+// GroupTimelineResponse lays out the executions of a task group's tasks within its window on one day, for a
+// Gantt-style view
+// @Description GroupTimelineResponse lays out the executions of a task group's tasks within its window on one day
+type GroupTimelineResponse struct {
+	GroupUUID     string              `json:"group_uuid" example:"550e8400-e29b-41d4-a716-446655440000"`
+	GroupName     string              `json:"group_name" example:"Nightly batch"`
+	Date          string              `json:"date" example:"2025-01-15"`
+	Timezone      string              `json:"timezone" example:"America/New_York"`
+	WindowStart   time.Time           `json:"window_start" example:"2025-01-15T22:00:00-05:00"`
+	WindowEnd     time.Time           `json:"window_end" example:"2025-01-16T04:00:00-05:00"`
+	Lanes         []GroupTimelineLane `json:"lanes"`                               // Ordered by first execution; tasks that did not run come last
+	MaxConcurrent int                 `json:"max_concurrent" example:"3"`          // Most executions running at the same time
+	Truncated     bool                `json:"truncated,omitempty" example:"false"` // The window has more executions than are listed
 }
@@ -0,0 +1,26 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// TokenRevocation invalidates either a single token (by jti) or every token a user obtained before RevokedBefore.
+// Entries are removed by a TTL index once ExpiresAt passes, i.e. once the revoked tokens could no longer be valid anyway.
+// @Description TokenRevocation invalidates a single token (by jti) or all tokens of a user issued before a cutoff
+type TokenRevocation struct {
+	ID            primitive.ObjectID `json:"id" bson:"_id,omitempty" example:"507f1f77bcf86cd799439011"`
+	JTI           string             `json:"jti,omitempty" bson:"jti,omitempty" example:"c0a8012e-7d3b-4b7e-9f5a-1b2c3d4e5f60"`
+	Email         string             `json:"email,omitempty" bson:"email,omitempty" example:"user@example.com"`
+	RevokedBefore *time.Time         `json:"revoked_before,omitempty" bson:"revoked_before,omitempty" example:"2025-01-15T10:00:00Z"`
+	RevokedBy     string             `json:"revoked_by" bson:"revoked_by" example:"admin@example.com"`
+	ExpiresAt     time.Time          `json:"expires_at" bson:"expires_at" example:"2025-02-14T10:00:00Z"`
+	CreatedAt     time.Time          `json:"created_at" bson:"created_at" example:"2025-01-15T10:00:00Z"`
+}
+
+// RevokeTokensRequest represents the request DTO for revoking tokens. Exactly one of jti or email must be set.
+type RevokeTokensRequest struct {
+	JTI   string `json:"jti,omitempty" binding:"required_without=Email,excluded_with=Email" example:"c0a8012e-7d3b-4b7e-9f5a-1b2c3d4e5f60"`
+	Email string `json:"email,omitempty" binding:"required_without=JTI,omitempty,email" example:"user@example.com"`
+}
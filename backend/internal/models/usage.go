@@ -0,0 +1,52 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// UsageCounters are billable amounts of usage
+type UsageCounters struct {
+	Executions int64 `json:"executions" bson:"executions" example:"1440"`  // Executions the scheduler started, scheduled or triggered
+	AlertsSent int64 `json:"alerts_sent" bson:"alerts_sent" example:"3"`   // Alert notifications sent
+	LogBytes   int64 `json:"log_bytes" bson:"log_bytes" example:"5242880"` // Bytes of log messages stored
+}
+
+// IsZero reports whether nothing was used
+func (u UsageCounters) IsZero() bool {
+	return u == UsageCounters{}
+}
+
+// Add adds other to the counters
+func (u *UsageCounters) Add(other UsageCounters) {
+	u.Executions += other.Executions
+	u.AlertsSent += other.AlertsSent
+	u.LogBytes += other.LogBytes
+}
+
+// UsageRecord is the metered usage of a project on one UTC day
+// @Description UsageRecord is the metered usage of a project on one UTC day
+type UsageRecord struct {
+	ID            primitive.ObjectID `json:"-" bson:"_id,omitempty"`
+	ProjectID     primitive.ObjectID `json:"project_id" bson:"project_id" swaggertype:"string" example:"507f1f77bcf86cd799439011"`
+	Date          string             `json:"date" bson:"date" example:"2025-01-15"` // YYYY-MM-DD, UTC
+	UsageCounters `bson:",inline"`
+	UpdatedAt     time.Time `json:"updated_at" bson:"updated_at"`
+}
+
+// UsageFilter selects usage records; empty fields match everything
+type UsageFilter struct {
+	ProjectID *primitive.ObjectID
+	From      string // first day, YYYY-MM-DD, inclusive
+	To        string // last day, YYYY-MM-DD, inclusive
+}
+
+// UsageResponse represents the metered usage of a date range
+// @Description UsageResponse represents the metered usage of a date range
+type UsageResponse struct {
+	From    string         `json:"from" example:"2025-01-01"`
+	To      string         `json:"to" example:"2025-01-31"`
+	Total   UsageCounters  `json:"total"`   // Sum of the records
+	Records []*UsageRecord `json:"records"` // One per project and day with usage, by date then project
+}
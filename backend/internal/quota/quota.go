@@ -0,0 +1,234 @@
+// Package quota enforces per-project limits on tasks, executions per day and log bytes per execution. Limits come
+// from the project, then from its organization, then from the server defaults; a limit of 0 is unlimited.
+//
+// Checks count what is stored, so concurrent requests may overshoot a limit by the few that were in flight.
+package quota
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/yourusername/cron-observer/backend/internal/config"
+	"github.com/yourusername/cron-observer/backend/internal/models"
+	"github.com/yourusername/cron-observer/backend/internal/repositories"
+)
+
+// Names of the quotas, as reported in errors and API responses
+const (
+	MaxTasks                = "max_tasks"
+	MaxExecutionsPerDay     = "max_executions_per_day"
+	MaxLogBytesPerExecution = "max_log_bytes_per_execution"
+)
+
+// ExceededError is returned when a request would go over a quota
+type ExceededError struct {
+	Quota string // one of the quota names
+	Limit int
+}
+
+func (e *ExceededError) Error() string {
+	switch e.Quota {
+	case MaxTasks:
+		return fmt.Sprintf("project quota exceeded: at most %d tasks", e.Limit)
+	case MaxExecutionsPerDay:
+		return fmt.Sprintf("project quota exceeded: at most %d executions per day (UTC)", e.Limit)
+	case MaxLogBytesPerExecution:
+		return fmt.Sprintf("project quota exceeded: at most %d bytes of logs per execution", e.Limit)
+	}
+	return fmt.Sprintf("project quota %s exceeded: limit %d", e.Quota, e.Limit)
+}
+
+// Service checks requests against the quotas. A nil *Service allows everything, so components work without one.
+type Service struct {
+	repo repositories.Repository
+
+	mu       sync.RWMutex
+	defaults models.ProjectQuotas
+}
+
+// NewService creates a quota service with the server-wide defaults
+func NewService(repo repositories.Repository, defaults config.QuotaConfig) *Service {
+	s := &Service{repo: repo}
+	s.SetDefaults(defaults)
+	return s
+}
+
+// SetDefaults replaces the server-wide defaults, e.g. after a configuration reload
+func (s *Service) SetDefaults(defaults config.QuotaConfig) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.defaults = models.ProjectQuotas{
+		MaxTasks:                defaults.MaxTasksPerProject,
+		MaxExecutionsPerDay:     defaults.MaxExecutionsPerDay,
+		MaxLogBytesPerExecution: defaults.MaxLogBytesPerExecution,
+	}
+}
+
+// Limits returns the effective quotas of the project
+func (s *Service) Limits(ctx context.Context, project *models.Project) (models.ProjectQuotas, error) {
+	s.mu.RLock()
+	limits := s.defaults
+	s.mu.RUnlock()
+
+	if project.OrganizationID != nil {
+		organization, err := s.repo.GetOrganizationByID(ctx, *project.OrganizationID)
+		if err != nil {
+			return limits, fmt.Errorf("get organization %s: %w", project.OrganizationID.Hex(), err)
+		}
+		limits = override(limits, organization.Quotas)
+	}
+	return override(limits, project.Quotas), nil
+}
+
+// override returns limits with the non-zero values of quotas
+func override(limits models.ProjectQuotas, quotas *models.ProjectQuotas) models.ProjectQuotas {
+	if quotas == nil {
+		return limits
+	}
+	if quotas.MaxTasks > 0 {
+		limits.MaxTasks = quotas.MaxTasks
+	}
+	if quotas.MaxExecutionsPerDay > 0 {
+		limits.MaxExecutionsPerDay = quotas.MaxExecutionsPerDay
+	}
+	if quotas.MaxLogBytesPerExecution > 0 {
+		limits.MaxLogBytesPerExecution = quotas.MaxLogBytesPerExecution
+	}
+	return limits
+}
+
+// CheckTasks returns an *ExceededError when adding count tasks would go over the project's task quota
+func (s *Service) CheckTasks(ctx context.Context, project *models.Project, count int) error {
+	if s == nil {
+		return nil
+	}
+	limits, err := s.Limits(ctx, project)
+	if err != nil {
+		return err
+	}
+	if limits.MaxTasks == 0 {
+		return nil
+	}
+
+	tasks, err := s.countTasks(ctx, project)
+	if err != nil {
+		return err
+	}
+	if tasks+count > limits.MaxTasks {
+		return &ExceededError{Quota: MaxTasks, Limit: limits.MaxTasks}
+	}
+	return nil
+}
+
+// CheckExecution returns an *ExceededError when the project already started its daily quota of executions
+func (s *Service) CheckExecution(ctx context.Context, project *models.Project) error {
+	if s == nil {
+		return nil
+	}
+	limits, err := s.Limits(ctx, project)
+	if err != nil {
+		return err
+	}
+	if limits.MaxExecutionsPerDay == 0 {
+		return nil
+	}
+
+	executions, err := s.countExecutionsToday(ctx, project)
+	if err != nil {
+		return err
+	}
+	if executions >= limits.MaxExecutionsPerDay {
+		log.Printf("[QUOTA] Project %s reached %d executions today", project.UUID, limits.MaxExecutionsPerDay)
+		return &ExceededError{Quota: MaxExecutionsPerDay, Limit: limits.MaxExecutionsPerDay}
+	}
+	return nil
+}
+
+// CheckLog returns an *ExceededError when appending message to the execution's logs would go over the project's
+// log quota
+func (s *Service) CheckLog(ctx context.Context, project *models.Project, execution *models.Execution, message string) error {
+	if s == nil {
+		return nil
+	}
+	limits, err := s.Limits(ctx, project)
+	if err != nil {
+		return err
+	}
+	if limits.MaxLogBytesPerExecution == 0 {
+		return nil
+	}
+
+	if LogBytes(execution)+len(message) > limits.MaxLogBytesPerExecution {
+		return &ExceededError{Quota: MaxLogBytesPerExecution, Limit: limits.MaxLogBytesPerExecution}
+	}
+	return nil
+}
+
+// Usage returns the project's effective quotas and its current task and execution counts
+func (s *Service) Usage(ctx context.Context, project *models.Project) (*models.QuotaUsageResponse, error) {
+	limits, err := s.Limits(ctx, project)
+	if err != nil {
+		return nil, err
+	}
+	tasks, err := s.countTasks(ctx, project)
+	if err != nil {
+		return nil, err
+	}
+	executions, err := s.countExecutionsToday(ctx, project)
+	if err != nil {
+		return nil, err
+	}
+	return &models.QuotaUsageResponse{
+		Limits:          limits,
+		ProjectQuotas:   project.Quotas,
+		Tasks:           tasks,
+		ExecutionsToday: executions,
+		Date:            today(),
+	}, nil
+}
+
+// LogBytes returns the total size of the execution's log messages
+func LogBytes(execution *models.Execution) int {
+	total := 0
+	for _, entry := range execution.Logs {
+		total += len(entry.Message)
+	}
+	return total
+}
+
+// ResetTime returns when the daily execution quota starts over, the next UTC midnight
+func ResetTime(now time.Time) time.Time {
+	now = now.UTC()
+	return time.Date(now.Year(), now.Month(), now.Day()+1, 0, 0, 0, 0, time.UTC)
+}
+
+func (s *Service) countTasks(ctx context.Context, project *models.Project) (int, error) {
+	// Tasks being deleted are not counted, as in task listings
+	_, total, err := s.repo.ListTasksByProjectID(ctx, project.ID, models.TaskListFilter{}, 1, 1)
+	if err != nil {
+		return 0, fmt.Errorf("count tasks of project %s: %w", project.UUID, err)
+	}
+	return int(total), nil
+}
+
+func (s *Service) countExecutionsToday(ctx context.Context, project *models.Project) (int, error) {
+	// Zero days is today, UTC
+	stats, err := s.repo.GetExecutionStatsByProject(ctx, project.ID, 0)
+	if err != nil {
+		return 0, fmt.Errorf("count executions of project %s: %w", project.UUID, err)
+	}
+	date := today()
+	for _, day := range stats {
+		if day.Date == date {
+			return day.Total, nil
+		}
+	}
+	return 0, nil
+}
+
+func today() string {
+	return time.Now().UTC().Format("2006-01-02")
+}
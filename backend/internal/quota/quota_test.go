@@ -0,0 +1,133 @@
+package quota
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/yourusername/cron-observer/backend/internal/config"
+	"github.com/yourusername/cron-observer/backend/internal/models"
+	"github.com/yourusername/cron-observer/backend/internal/repositories"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func newProject(t *testing.T, repo repositories.Repository, tasks int) *models.Project {
+	t.Helper()
+	ctx := context.Background()
+	project := &models.Project{ID: primitive.NewObjectID(), UUID: primitive.NewObjectID().Hex(), Name: "billing"}
+	if err := repo.CreateProject(ctx, project); err != nil {
+		t.Fatalf("CreateProject: %v", err)
+	}
+	for i := 0; i < tasks; i++ {
+		task := &models.Task{ID: primitive.NewObjectID(), UUID: primitive.NewObjectID().Hex(), ProjectID: project.ID, Status: models.TaskStatusActive}
+		if err := repo.CreateTask(ctx, project.ID.Hex(), task); err != nil {
+			t.Fatalf("CreateTask: %v", err)
+		}
+	}
+	return project
+}
+
+func TestService_LimitsPreferProjectThenOrganizationThenDefaults(t *testing.T) {
+	ctx := context.Background()
+	repo := repositories.NewMemoryRepository()
+	service := NewService(repo, config.QuotaConfig{MaxTasksPerProject: 10, MaxExecutionsPerDay: 100, MaxLogBytesPerExecution: 1000})
+
+	organization := &models.Organization{ID: primitive.NewObjectID(), Name: "Payments", Quotas: &models.ProjectQuotas{MaxTasks: 20, MaxExecutionsPerDay: 200}}
+	if err := repo.CreateOrganization(ctx, organization); err != nil {
+		t.Fatalf("CreateOrganization: %v", err)
+	}
+	project := &models.Project{ID: primitive.NewObjectID(), OrganizationID: &organization.ID, Quotas: &models.ProjectQuotas{MaxTasks: 5}}
+
+	limits, err := service.Limits(ctx, project)
+	if err != nil {
+		t.Fatalf("Limits: %v", err)
+	}
+	want := models.ProjectQuotas{MaxTasks: 5, MaxExecutionsPerDay: 200, MaxLogBytesPerExecution: 1000}
+	if limits != want {
+		t.Errorf("Limits = %+v, want %+v", limits, want)
+	}
+}
+
+func TestService_CheckTasks(t *testing.T) {
+	ctx := context.Background()
+	repo := repositories.NewMemoryRepository()
+	service := NewService(repo, config.QuotaConfig{MaxTasksPerProject: 3})
+	project := newProject(t, repo, 2)
+
+	if err := service.CheckTasks(ctx, project, 1); err != nil {
+		t.Errorf("third task rejected: %v", err)
+	}
+	var exceeded *ExceededError
+	if err := service.CheckTasks(ctx, project, 2); !errors.As(err, &exceeded) || exceeded.Quota != MaxTasks || exceeded.Limit != 3 {
+		t.Errorf("CheckTasks(2) = %v, want the max_tasks quota exceeded", err)
+	}
+
+	// Unlimited after the defaults are reloaded
+	service.SetDefaults(config.QuotaConfig{})
+	if err := service.CheckTasks(ctx, project, 100); err != nil {
+		t.Errorf("unlimited quota rejected tasks: %v", err)
+	}
+}
+
+func TestService_CheckExecutionCountsToday(t *testing.T) {
+	ctx := context.Background()
+	repo := repositories.NewMemoryRepository()
+	service := NewService(repo, config.QuotaConfig{MaxExecutionsPerDay: 2})
+	project := newProject(t, repo, 1)
+	tasks, err := repo.GetTasksByProjectID(ctx, project.ID)
+	if err != nil {
+		t.Fatalf("GetTasksByProjectID: %v", err)
+	}
+
+	now := time.Now()
+	for i, startedAt := range []time.Time{now.Add(-48 * time.Hour), now} {
+		execution := &models.Execution{ID: primitive.NewObjectID(), UUID: primitive.NewObjectID().Hex(), TaskID: tasks[0].ID, TaskUUID: tasks[0].UUID, Status: models.ExecutionStatusSuccess, StartedAt: startedAt}
+		if err := repo.CreateExecution(ctx, execution); err != nil {
+			t.Fatalf("CreateExecution %d: %v", i, err)
+		}
+	}
+	if err := service.CheckExecution(ctx, project); err != nil {
+		t.Errorf("second execution of the day rejected: %v", err)
+	}
+
+	execution := &models.Execution{ID: primitive.NewObjectID(), UUID: primitive.NewObjectID().Hex(), TaskID: tasks[0].ID, TaskUUID: tasks[0].UUID, Status: models.ExecutionStatusSuccess, StartedAt: now}
+	if err := repo.CreateExecution(ctx, execution); err != nil {
+		t.Fatalf("CreateExecution: %v", err)
+	}
+	var exceeded *ExceededError
+	if err := service.CheckExecution(ctx, project); !errors.As(err, &exceeded) || exceeded.Quota != MaxExecutionsPerDay {
+		t.Errorf("CheckExecution = %v, want the max_executions_per_day quota exceeded", err)
+	}
+}
+
+func TestService_CheckLog(t *testing.T) {
+	ctx := context.Background()
+	service := NewService(repositories.NewMemoryRepository(), config.QuotaConfig{MaxLogBytesPerExecution: 10})
+	project := &models.Project{ID: primitive.NewObjectID()}
+	execution := &models.Execution{Logs: []models.LogEntry{{Message: "started"}}}
+
+	if err := service.CheckLog(ctx, project, execution, "ok"); err != nil {
+		t.Errorf("log within the quota rejected: %v", err)
+	}
+	err := service.CheckLog(ctx, project, execution, strings.Repeat("x", 4))
+	if err == nil || !strings.Contains(err.Error(), "10 bytes") {
+		t.Errorf("CheckLog = %v, want the log quota exceeded", err)
+	}
+}
+
+func TestNilService_AllowsEverything(t *testing.T) {
+	var service *Service
+	project := &models.Project{ID: primitive.NewObjectID()}
+	if service.CheckTasks(context.Background(), project, 1000) != nil || service.CheckExecution(context.Background(), project) != nil {
+		t.Error("nil service enforced a quota")
+	}
+}
+
+func TestResetTime(t *testing.T) {
+	now := time.Date(2024, 3, 31, 22, 30, 0, 0, time.FixedZone("CET", 3600))
+	if got, want := ResetTime(now), time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC); !got.Equal(want) {
+		t.Errorf("ResetTime = %v, want %v", got, want)
+	}
+}
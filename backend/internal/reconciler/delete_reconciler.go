@@ -2,8 +2,10 @@ package reconciler
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"log"
-	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/yourusername/cron-observer/backend/internal/deletequeue"
@@ -13,134 +15,143 @@ import (
 
 // DeleteReconciler periodically re-enqueues stuck PENDING_DELETE and DELETE_FAILED tasks.
 type DeleteReconciler struct {
+	*Runner
+
 	repo      repositories.Repository
 	publisher deletequeue.DeleteJobPublisher
-	ticker    *time.Ticker
-	interval  time.Duration
 	threshold time.Duration
-	mu        sync.RWMutex
-	running   bool
-	stopCh    chan struct{}
+
+	stuckFound    atomic.Uint64
+	requeued      atomic.Uint64
+	requeueFailed atomic.Uint64
+}
+
+// RunResult is the outcome of one reconciler cycle
+type RunResult struct {
+	StuckFound int
+	Requeued   []string // UUIDs of the re-enqueued tasks
+	Failed     []string // UUIDs of the tasks that could not be re-enqueued
+}
+
+// requeueError reports the delete jobs a cycle could not re-enqueue
+type requeueError struct {
+	failed int
+	found  int
+	err    error // Error of the last failed publish
+}
+
+func (e *requeueError) Error() string {
+	return fmt.Sprintf("failed to re-enqueue %d of %d stuck task(s): %v", e.failed, e.found, e.err)
+}
+
+func (e *requeueError) Unwrap() error {
+	return e.err
 }
 
 // NewDeleteReconciler creates a new delete reconciler.
 // interval: how often to run (e.g., 5 minutes)
 // threshold: only re-enqueue tasks older than this (e.g., 10 minutes)
 func NewDeleteReconciler(repo repositories.Repository, publisher deletequeue.DeleteJobPublisher, interval, threshold time.Duration) *DeleteReconciler {
-	return &DeleteReconciler{
+	r := &DeleteReconciler{
 		repo:      repo,
 		publisher: publisher,
-		ticker:    time.NewTicker(interval),
-		interval:  interval,
 		threshold: threshold,
-		stopCh:    make(chan struct{}),
 	}
+	r.Runner = NewRunner("delete", r, interval)
+	return r
 }
 
-// Start begins the reconciler loop. Runs until ctx is cancelled or Stop() is called.
-func (r *DeleteReconciler) Start(ctx context.Context) error {
-	r.mu.Lock()
-	if r.running {
-		r.mu.Unlock()
-		return ErrReconcilerAlreadyRunning
-	}
-	r.running = true
-	r.mu.Unlock()
-
-	defer func() {
-		r.mu.Lock()
-		r.running = false
-		r.ticker.Stop()
-		r.mu.Unlock()
-	}()
-
-	log.Printf("[reconciler] Delete reconciler started (interval=%v, threshold=%v)", r.interval, r.threshold)
-
-	// Run immediately on start
-	r.reconcile(ctx)
-
-	for {
-		select {
-		case <-ctx.Done():
-			log.Printf("[reconciler] Delete reconciler context cancelled, stopping")
-			return ctx.Err()
-		case <-r.stopCh:
-			log.Printf("[reconciler] Delete reconciler stopped")
-			return nil
-		case <-r.ticker.C:
-			r.reconcile(ctx)
-		}
-	}
+// Reconcile re-enqueues the stuck delete tasks once
+func (r *DeleteReconciler) Reconcile(ctx context.Context) error {
+	_, err := r.reconcile(ctx)
+	return err
 }
 
-// Stop stops the reconciler gracefully.
-func (r *DeleteReconciler) Stop() {
-	r.mu.Lock()
-	defer r.mu.Unlock()
-	if r.running {
-		close(r.stopCh)
+// RunOnce runs a cycle immediately, waiting for a cycle in progress to finish first. The error is that of the
+// stuck task query; publish failures are reported per task in the result.
+func (r *DeleteReconciler) RunOnce(ctx context.Context) (RunResult, error) {
+	log.Printf("[reconciler] Running delete reconciler on demand")
+	var result RunResult
+	err := r.run(ctx, func(ctx context.Context) error {
+		var err error
+		result, err = r.reconcile(ctx)
+		return err
+	})
+	var requeueErr *requeueError
+	if errors.As(err, &requeueErr) {
+		return result, nil
 	}
+	return result, err
+}
+
+// ReportStatus adds the stuck tasks found and re-enqueued since startup to the runner status
+func (r *DeleteReconciler) ReportStatus(status *models.ReconcilerStatus) {
+	status.StuckFound = r.stuckFound.Load()
+	status.Requeued = r.requeued.Load()
+	status.RequeueFailed = r.requeueFailed.Load()
 }
 
 // reconcile queries stuck tasks and re-enqueues them.
-func (r *DeleteReconciler) reconcile(ctx context.Context) {
-	// Query tasks with PENDING_DELETE or DELETE_FAILED status
-	statuses := []models.TaskStatus{
-		models.TaskStatusPendingDelete,
-		models.TaskStatusDeleteFailed,
-	}
+func (r *DeleteReconciler) reconcile(ctx context.Context) (RunResult, error) {
+	result := RunResult{Requeued: []string{}}
 
-	tasks, err := r.repo.GetTasksByStatus(ctx, statuses)
+	// Only re-enqueue tasks whose updated_at is older than the threshold
+	tasks, err := FindStuckDeletes(ctx, r.repo, r.threshold)
 	if err != nil {
-		log.Printf("[reconciler] Failed to query stuck delete tasks: %v", err)
-		return
-	}
-
-	if len(tasks) == 0 {
-		return // No stuck tasks
+		return result, fmt.Errorf("query stuck delete tasks: %w", err)
 	}
+	result.StuckFound = len(tasks)
+	r.stuckFound.Add(uint64(len(tasks)))
 
 	now := time.Now()
-	reEnqueuedCount := 0
+	var lastErr error
 
 	for _, task := range tasks {
-		// Only re-enqueue if updated_at is older than threshold
-		age := now.Sub(task.UpdatedAt)
-		if age < r.threshold {
-			continue // Task is too recent, skip
-		}
-
-		// Re-publish delete job
-		msg := deletequeue.DeleteTaskMessage{
-			TaskUUID:    task.UUID,
-			ProjectID:   task.ProjectID.Hex(),
-			RequestedAt: time.Now(),
-		}
-
-		if err := r.publisher.PublishDeleteTask(ctx, msg); err != nil {
+		if err := RequeueDelete(ctx, r.publisher, task); err != nil {
 			log.Printf("[reconciler] Failed to re-enqueue delete job for task %s: %v", task.UUID, err)
+			result.Failed = append(result.Failed, task.UUID)
+			lastErr = err
 			continue
 		}
 
-		reEnqueuedCount++
-		log.Printf("[reconciler] Re-enqueued delete job for task %s (status=%s, age=%v)", task.UUID, task.Status, age)
+		result.Requeued = append(result.Requeued, task.UUID)
+		log.Printf("[reconciler] Re-enqueued delete job for task %s (status=%s, age=%v)", task.UUID, task.Status, now.Sub(task.UpdatedAt))
 	}
+	r.requeued.Add(uint64(len(result.Requeued)))
+	r.requeueFailed.Add(uint64(len(result.Failed)))
 
-	if reEnqueuedCount > 0 {
-		log.Printf("[reconciler] Re-enqueued %d stuck delete task(s)", reEnqueuedCount)
+	if len(result.Requeued) > 0 {
+		log.Printf("[reconciler] Re-enqueued %d stuck delete task(s)", len(result.Requeued))
 	}
+	if lastErr != nil {
+		return result, &requeueError{failed: len(result.Failed), found: len(tasks), err: lastErr}
+	}
+	return result, nil
 }
 
-// Errors
-var (
-	ErrReconcilerAlreadyRunning = &ReconcilerError{Message: "reconciler is already running"}
-)
+// FindStuckDeletes returns the tasks in PENDING_DELETE or DELETE_FAILED whose updated_at is at least minAge old.
+// A minAge of zero returns all of them.
+func FindStuckDeletes(ctx context.Context, repo repositories.Repository, minAge time.Duration) ([]*models.Task, error) {
+	statuses := []models.TaskStatus{
+		models.TaskStatusPendingDelete,
+		models.TaskStatusDeleteFailed,
+	}
 
-// ReconcilerError represents a reconciler error.
-type ReconcilerError struct {
-	Message string
+	tasks, err := repo.GetTasksByStatus(ctx, statuses)
+	if err != nil {
+		return nil, err
+	}
+
+	return OlderThan(tasks, func(task *models.Task) time.Time { return task.UpdatedAt }, minAge), nil
 }
 
-func (e *ReconcilerError) Error() string {
-	return e.Message
+// RequeueDelete re-publishes the delete job of a task
+func RequeueDelete(ctx context.Context, publisher deletequeue.DeleteJobPublisher, task *models.Task) error {
+	msg := deletequeue.DeleteTaskMessage{
+		Kind:        deletequeue.DeleteJobKindTask,
+		TaskUUID:    task.UUID,
+		ProjectID:   task.ProjectID.Hex(),
+		RequestedAt: time.Now(),
+	}
+	return publisher.PublishDeleteTask(ctx, msg)
 }
@@ -0,0 +1,13 @@
+package reconciler
+
+import (
+	"time"
+
+	"github.com/yourusername/cron-observer/backend/internal/scheduler"
+)
+
+// NewGroupWindowReconciler returns a runner that repairs the task group window jobs of the scheduler every interval:
+// it registers the missing start/end jobs of active groups and removes the jobs of groups that are gone.
+func NewGroupWindowReconciler(s *scheduler.Scheduler, interval time.Duration) *Runner {
+	return NewRunner("group window", ReconcilerFunc(s.ReconcileGroupWindowJobs), interval)
+}
@@ -0,0 +1,34 @@
+package reconciler
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/yourusername/cron-observer/backend/internal/integrity"
+	"github.com/yourusername/cron-observer/backend/internal/repositories"
+)
+
+// NewIntegrityReconciler returns a runner that looks for tasks of deleted task groups or projects, executions of
+// deleted tasks and stats of deleted projects every interval, and repairs them according to the policy.
+// publisher may be nil.
+func NewIntegrityReconciler(repo repositories.Repository, publisher integrity.EventPublisher, policy integrity.Policy, interval time.Duration) *Runner {
+	return NewRunner("integrity", ReconcilerFunc(func(ctx context.Context) error {
+		orphans, err := integrity.Find(ctx, repo)
+		if err != nil {
+			return err
+		}
+		if orphans.Empty() {
+			return nil
+		}
+
+		log.Printf("[reconciler] Found dangling references: %d task(s) of deleted groups, %d task(s) of deleted projects, %d deleted task(s) with executions, %d deleted project(s) with stats (policy=%s)",
+			len(orphans.TasksOfDeletedGroups), len(orphans.TasksOfDeletedProjects), len(orphans.ExecutionTaskUUIDs), len(orphans.StatsProjectIDs), policy)
+		result, err := integrity.Repair(ctx, repo, publisher, orphans, policy)
+		if result != (integrity.Result{}) {
+			log.Printf("[reconciler] Repaired dangling references: detached %d task(s), deleted %d task(s), %d execution(s) and the stats of %d project(s)",
+				result.DetachedTasks, result.DeletedTasks, result.DeletedExecutions, result.DeletedStats)
+		}
+		return err
+	}), interval)
+}
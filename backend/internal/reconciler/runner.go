@@ -0,0 +1,185 @@
+package reconciler
+
+import (
+	"context"
+	"log"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/yourusername/cron-observer/backend/internal/models"
+)
+
+// Reconciler repairs state that drifted from what it should be (stuck deletes, missed schedules, orphans, ...).
+// Reconcile is one pass; a Runner calls it periodically and on demand, never concurrently.
+type Reconciler interface {
+	Reconcile(ctx context.Context) error
+}
+
+// ReconcilerFunc adapts a function to the Reconciler interface
+type ReconcilerFunc func(ctx context.Context) error
+
+func (f ReconcilerFunc) Reconcile(ctx context.Context) error {
+	return f(ctx)
+}
+
+// StatusReporter is implemented by reconcilers that keep counters of their own; Runner.Status adds them
+type StatusReporter interface {
+	ReportStatus(status *models.ReconcilerStatus)
+}
+
+// Runner runs a Reconciler on a ticker and records the outcome of each pass.
+type Runner struct {
+	name       string
+	reconciler Reconciler
+	interval   time.Duration
+	mu         sync.RWMutex
+	running    bool
+	stopCh     chan struct{}
+
+	// runMu serializes passes, so a manual run does not overlap a periodic one
+	runMu sync.Mutex
+
+	runs        atomic.Uint64
+	lastRunAt   time.Time // guarded by mu
+	lastError   string    // guarded by mu
+	lastErrorAt time.Time // guarded by mu
+}
+
+// NewRunner creates a runner that calls reconciler every interval. The name identifies it in logs.
+func NewRunner(name string, reconciler Reconciler, interval time.Duration) *Runner {
+	return &Runner{
+		name:       name,
+		reconciler: reconciler,
+		interval:   interval,
+		stopCh:     make(chan struct{}),
+	}
+}
+
+// Name returns the name of the reconciler
+func (r *Runner) Name() string {
+	return r.name
+}
+
+// Start runs the reconciler immediately and then every interval, until ctx is cancelled or Stop() is called.
+func (r *Runner) Start(ctx context.Context) error {
+	r.mu.Lock()
+	if r.running {
+		r.mu.Unlock()
+		return ErrReconcilerAlreadyRunning
+	}
+	r.running = true
+	r.mu.Unlock()
+
+	ticker := time.NewTicker(r.interval)
+	defer func() {
+		ticker.Stop()
+		r.mu.Lock()
+		r.running = false
+		r.mu.Unlock()
+	}()
+
+	log.Printf("[reconciler] %s reconciler started (interval=%v)", r.name, r.interval)
+
+	// Run immediately on start
+	r.run(ctx, r.reconciler.Reconcile)
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.Printf("[reconciler] %s reconciler context cancelled, stopping", r.name)
+			return ctx.Err()
+		case <-r.stopCh:
+			log.Printf("[reconciler] %s reconciler stopped", r.name)
+			return nil
+		case <-ticker.C:
+			r.run(ctx, r.reconciler.Reconcile)
+		}
+	}
+}
+
+// Stop stops the reconciler gracefully.
+func (r *Runner) Stop() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.running {
+		close(r.stopCh)
+	}
+}
+
+// RunOnce runs a pass immediately, waiting for a pass in progress to finish first.
+func (r *Runner) RunOnce(ctx context.Context) error {
+	log.Printf("[reconciler] Running %s reconciler on demand", r.name)
+	return r.run(ctx, r.reconciler.Reconcile)
+}
+
+// Status returns the runs of the reconciler, with the counters of reconcilers implementing StatusReporter
+func (r *Runner) Status() models.ReconcilerStatus {
+	status := models.ReconcilerStatus{Runs: r.runs.Load()}
+
+	r.mu.RLock()
+	status.Running = r.running
+	if !r.lastRunAt.IsZero() {
+		lastRunAt := r.lastRunAt
+		status.LastRunAt = &lastRunAt
+	}
+	if !r.lastErrorAt.IsZero() {
+		lastErrorAt := r.lastErrorAt
+		status.LastError = r.lastError
+		status.LastErrorAt = &lastErrorAt
+	}
+	r.mu.RUnlock()
+
+	if reporter, ok := r.reconciler.(StatusReporter); ok {
+		reporter.ReportStatus(&status)
+	}
+	return status
+}
+
+// run calls pass, one at a time, and records its outcome
+func (r *Runner) run(ctx context.Context, pass func(ctx context.Context) error) error {
+	r.runMu.Lock()
+	defer r.runMu.Unlock()
+
+	err := pass(ctx)
+	if err != nil {
+		log.Printf("[reconciler] %s reconciler failed: %v", r.name, err)
+	}
+
+	r.runs.Add(1)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.lastRunAt = time.Now()
+	if err != nil {
+		r.lastError = err.Error()
+		r.lastErrorAt = r.lastRunAt
+	}
+	return err
+}
+
+// OlderThan returns the items whose timestamp is at least minAge old. A minAge of zero returns all of them.
+// Reconcilers use it to leave alone records that are still being worked on.
+func OlderThan[T any](items []T, timestamp func(T) time.Time, minAge time.Duration) []T {
+	now := time.Now()
+	old := make([]T, 0, len(items))
+	for _, item := range items {
+		if now.Sub(timestamp(item)) >= minAge {
+			old = append(old, item)
+		}
+	}
+	return old
+}
+
+// Errors
+var (
+	ErrReconcilerAlreadyRunning = &ReconcilerError{Message: "reconciler is already running"}
+)
+
+// ReconcilerError represents a reconciler error.
+type ReconcilerError struct {
+	Message string
+}
+
+func (e *ReconcilerError) Error() string {
+	return e.Message
+}
@@ -0,0 +1,59 @@
+package reconciler
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRunner_RunsPeriodicallyAndRecordsStatus(t *testing.T) {
+	var passes atomic.Int32
+	runner := NewRunner("test", ReconcilerFunc(func(ctx context.Context) error {
+		if passes.Add(1) == 1 {
+			return errors.New("first pass failed")
+		}
+		return nil
+	}), 10*time.Millisecond)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	done := make(chan error, 1)
+	go func() { done <- runner.Start(ctx) }()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for passes.Load() < 3 && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	if err := runner.Start(ctx); !errors.Is(err, ErrReconcilerAlreadyRunning) {
+		t.Errorf("second Start returned %v, want ErrReconcilerAlreadyRunning", err)
+	}
+	status := runner.Status()
+	if !status.Running || status.Runs < 3 || status.LastRunAt == nil {
+		t.Errorf("unexpected status while running %+v", status)
+	}
+	if status.LastError != "first pass failed" || status.LastErrorAt == nil {
+		t.Errorf("last error = %q, want the error of the first pass to be kept", status.LastError)
+	}
+
+	runner.Stop()
+	if err := <-done; err != nil {
+		t.Fatalf("Start returned %v after Stop", err)
+	}
+	if runner.Status().Running {
+		t.Error("runner still reported running after Stop")
+	}
+}
+
+func TestOlderThan(t *testing.T) {
+	now := time.Now()
+	ages := []time.Duration{time.Minute, 10 * time.Minute, time.Hour}
+	old := OlderThan(ages, func(age time.Duration) time.Time { return now.Add(-age) }, 10*time.Minute)
+	if len(old) != 2 || old[0] != 10*time.Minute || old[1] != time.Hour {
+		t.Errorf("OlderThan = %v, want [10m 1h]", old)
+	}
+	if all := OlderThan(ages, func(age time.Duration) time.Time { return now.Add(-age) }, 0); len(all) != 3 {
+		t.Errorf("OlderThan with zero age returned %d items, want 3", len(all))
+	}
+}
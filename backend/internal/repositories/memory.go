@@ -0,0 +1,2395 @@
+package repositories
+
+import (
+	"bytes"
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/yourusername/cron-observer/backend/internal/database"
+	"github.com/yourusername/cron-observer/backend/internal/models"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+var _ Repository = (*MemoryRepository)(nil)
+
+// MemoryRepository keeps all data in process memory, for tests and local demos without MongoDB. It follows the
+// semantics of MongoRepository, including mongo.ErrNoDocuments for missing documents and duplicate key errors for
+// the unique indexes created by database.CreateIndexes. Every operation is atomic.
+type MemoryRepository struct {
+	mu sync.Mutex
+
+	projects             *memoryCollection[models.Project]
+	organizations        *memoryCollection[models.Organization]
+	invitations          *memoryCollection[models.Invitation]
+	secrets              *memoryCollection[models.Secret]
+	projectSettings      *memoryCollection[models.ProjectSettings]
+	taskTemplates        *memoryCollection[models.TaskTemplate]
+	tokenRevocations     *memoryCollection[models.TokenRevocation]
+	tasks                *memoryCollection[models.Task]
+	taskGroups           *memoryCollection[models.TaskGroup]
+	executions           *memoryCollection[models.Execution]
+	executionFailureStat *memoryCollection[models.ExecutionFailureStat]
+	taskFailureStats     *memoryCollection[models.StoredTaskFailureStats]
+	usage                *memoryCollection[models.UsageRecord]
+	outbox               *memoryCollection[models.OutboxEvent]
+	jobQueue             *memoryCollection[models.QueuedJob]
+	incidents            *memoryCollection[models.Incident]
+}
+
+func NewMemoryRepository() *MemoryRepository {
+	return &MemoryRepository{
+		projects: newMemoryCollection(database.CollectionProjects, func(a, b *models.Project) bool {
+			return a.UUID == b.UUID || a.APIKey == b.APIKey || strings.EqualFold(a.Name, b.Name)
+		}),
+		organizations: newMemoryCollection(database.CollectionOrganizations, func(a, b *models.Organization) bool {
+			return a.UUID == b.UUID || strings.EqualFold(a.Name, b.Name)
+		}),
+		invitations: newMemoryCollection(database.CollectionInvitations, func(a, b *models.Invitation) bool {
+			return a.UUID == b.UUID
+		}),
+		secrets: newMemoryCollection(database.CollectionSecrets, func(a, b *models.Secret) bool {
+			return a.ProjectID == b.ProjectID && a.Name == b.Name
+		}),
+		projectSettings: newMemoryCollection(database.CollectionProjectSettings, func(a, b *models.ProjectSettings) bool {
+			return a.ProjectID == b.ProjectID
+		}),
+		taskTemplates: newMemoryCollection(database.CollectionTaskTemplates, func(a, b *models.TaskTemplate) bool {
+			return a.UUID == b.UUID
+		}),
+		tokenRevocations: newMemoryCollection[models.TokenRevocation](database.CollectionTokenRevocations, nil),
+		tasks: newMemoryCollection(database.CollectionTasks, func(a, b *models.Task) bool {
+			return a.UUID == b.UUID
+		}),
+		taskGroups: newMemoryCollection(database.CollectionTaskGroups, func(a, b *models.TaskGroup) bool {
+			return a.UUID == b.UUID
+		}),
+		executions: newMemoryCollection[models.Execution](database.CollectionExecutions, nil),
+		executionFailureStat: newMemoryCollection(database.CollectionExecutionFailureStats, func(a, b *models.ExecutionFailureStat) bool {
+			return a.ProjectID == b.ProjectID && a.Date == b.Date
+		}),
+		taskFailureStats: newMemoryCollection(database.CollectionTaskFailureStats, func(a, b *models.StoredTaskFailureStats) bool {
+			return a.ProjectID == b.ProjectID && a.Date == b.Date
+		}),
+		usage: newMemoryCollection(database.CollectionUsage, func(a, b *models.UsageRecord) bool {
+			return a.ProjectID == b.ProjectID && a.Date == b.Date
+		}),
+		outbox:   newMemoryCollection[models.OutboxEvent](database.CollectionEventOutbox, nil),
+		jobQueue: newMemoryCollection[models.QueuedJob](database.CollectionJobQueue, nil),
+		incidents: newMemoryCollection(database.CollectionIncidents, func(a, b *models.Incident) bool {
+			return a.UUID == b.UUID
+		}),
+	}
+}
+
+// Projects
+
+func (r *MemoryRepository) GetAllProjects(ctx context.Context) ([]*models.Project, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.projects.find(func(p *models.Project) bool {
+		return p.Status != models.ProjectStatusPendingDelete
+	})
+}
+
+func (r *MemoryRepository) GetProjectByID(ctx context.Context, projectID primitive.ObjectID) (*models.Project, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.projects.findOne(func(p *models.Project) bool { return p.ID == projectID })
+}
+
+// GetProjectByName returns a project by name (case-insensitive). Returns mongo.ErrNoDocuments if not found.
+func (r *MemoryRepository) GetProjectByName(ctx context.Context, name string) (*models.Project, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.projects.findOne(func(p *models.Project) bool { return strings.EqualFold(p.Name, name) })
+}
+
+func (r *MemoryRepository) GetUserProjects(ctx context.Context, email string) ([]*models.Project, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// Admins of an organization see all of its projects
+	organizationIDs, err := r.administeredOrganizationIDs(email)
+	if err != nil {
+		return nil, err
+	}
+
+	projects, err := r.projects.find(func(p *models.Project) bool {
+		inOrganization := p.OrganizationID != nil && organizationIDs[*p.OrganizationID]
+		return p.Status != models.ProjectStatusPendingDelete && (hasProjectUser(p, email) || inOrganization)
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, project := range projects {
+		if project.ProjectUsers == nil {
+			project.ProjectUsers = []models.ProjectUser{}
+		}
+	}
+	return projects, nil
+}
+
+func (r *MemoryRepository) CreateProject(ctx context.Context, project *models.Project) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	_, err := r.projects.insert(project)
+	return err
+}
+
+func (r *MemoryRepository) UpdateProject(ctx context.Context, projectID primitive.ObjectID, project *models.Project) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	_, _, err := r.projects.update(projectByID(projectID), func(p *models.Project) {
+		p.Name = project.Name
+		p.Description = project.Description
+		p.ExecutionEndpoint = project.ExecutionEndpoint
+		p.FailoverEndpoint = project.FailoverEndpoint
+		p.AlertEmails = project.AlertEmails
+		p.UpdatedAt = project.UpdatedAt
+		p.APIKeyAllowedCIDRs = project.APIKeyAllowedCIDRs
+		p.ExecutionHeaders = project.ExecutionHeaders
+		p.RateLimits = project.RateLimits
+		p.ProjectUsers = project.ProjectUsers
+	})
+	return err
+}
+
+// UpdateProjectStatus sets the project's lifecycle status
+func (r *MemoryRepository) UpdateProjectStatus(ctx context.Context, projectID primitive.ObjectID, status models.ProjectStatus) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.updateProject(projectByID(projectID), func(p *models.Project) {
+		p.Status = status
+		p.UpdatedAt = time.Now()
+	})
+}
+
+// UpdateProjectDeletionProgress records how far the delete worker got with the project
+func (r *MemoryRepository) UpdateProjectDeletionProgress(ctx context.Context, projectID primitive.ObjectID, progress *models.ProjectDeletionProgress) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	progress.UpdatedAt = time.Now()
+	_, _, err := r.projects.update(projectByID(projectID), func(p *models.Project) {
+		p.DeletionProgress = progress
+	})
+	return err
+}
+
+// DeleteProject removes the project document. Tasks, groups and executions must be removed separately.
+func (r *MemoryRepository) DeleteProject(ctx context.Context, projectID primitive.ObjectID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	deleted, err := r.projects.delete(projectByID(projectID))
+	if err != nil {
+		return err
+	}
+	if deleted == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+// SetProjectStatusPageToken sets the token of the project's public status page, or removes it when token is empty.
+// Returns mongo.ErrNoDocuments if the project does not exist.
+func (r *MemoryRepository) SetProjectStatusPageToken(ctx context.Context, projectID primitive.ObjectID, token string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.updateProject(projectByID(projectID), func(p *models.Project) {
+		p.StatusPageToken = token
+		p.UpdatedAt = time.Now()
+	})
+}
+
+// AddScopedAPIKey appends a scoped API key to the project's scoped_api_keys array
+func (r *MemoryRepository) AddScopedAPIKey(ctx context.Context, projectID primitive.ObjectID, apiKey models.ScopedAPIKey) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.updateProject(projectByID(projectID), func(p *models.Project) {
+		p.ScopedAPIKeys = append(p.ScopedAPIKeys, apiKey)
+		p.UpdatedAt = time.Now()
+	})
+}
+
+// RemoveScopedAPIKey revokes a scoped API key by its ID. Returns mongo.ErrNoDocuments if the key does not exist.
+func (r *MemoryRepository) RemoveScopedAPIKey(ctx context.Context, projectID primitive.ObjectID, keyID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	filter := func(p *models.Project) bool { return p.ID == projectID && scopedAPIKeyIndex(p, keyID) >= 0 }
+	return r.updateProject(filter, func(p *models.Project) {
+		kept := p.ScopedAPIKeys[:0]
+		for _, key := range p.ScopedAPIKeys {
+			if key.ID != keyID {
+				kept = append(kept, key)
+			}
+		}
+		p.ScopedAPIKeys = kept
+		p.UpdatedAt = time.Now()
+	})
+}
+
+// UpdateScopedAPIKeyAllowedCIDRs replaces the CIDR allowlist of a scoped API key. Returns mongo.ErrNoDocuments if the key does not exist.
+func (r *MemoryRepository) UpdateScopedAPIKeyAllowedCIDRs(ctx context.Context, projectID primitive.ObjectID, keyID string, allowedCIDRs []string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	filter := func(p *models.Project) bool { return p.ID == projectID && scopedAPIKeyIndex(p, keyID) >= 0 }
+	return r.updateProject(filter, func(p *models.Project) {
+		p.ScopedAPIKeys[scopedAPIKeyIndex(p, keyID)].AllowedCIDRs = allowedCIDRs
+		p.UpdatedAt = time.Now()
+	})
+}
+
+// AddProjectEnvironment appends an environment unless one with the same name exists.
+// Returns mongo.ErrNoDocuments if the project does not exist or the name is already taken.
+func (r *MemoryRepository) AddProjectEnvironment(ctx context.Context, projectID primitive.ObjectID, environment models.ProjectEnvironment) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	filter := func(p *models.Project) bool {
+		_, taken := p.FindEnvironment(environment.Name)
+		return p.ID == projectID && !taken
+	}
+	return r.updateProject(filter, func(p *models.Project) {
+		p.Environments = append(p.Environments, environment)
+		p.UpdatedAt = time.Now()
+	})
+}
+
+// UpdateProjectEnvironmentEndpoint changes the execution endpoint of an environment. Returns mongo.ErrNoDocuments if the environment does not exist.
+func (r *MemoryRepository) UpdateProjectEnvironmentEndpoint(ctx context.Context, projectID primitive.ObjectID, name, executionEndpoint string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	filter := func(p *models.Project) bool {
+		_, found := p.FindEnvironment(name)
+		return p.ID == projectID && found
+	}
+	return r.updateProject(filter, func(p *models.Project) {
+		now := time.Now()
+		environment, _ := p.FindEnvironment(name)
+		environment.ExecutionEndpoint = executionEndpoint
+		environment.UpdatedAt = now
+		p.UpdatedAt = now
+	})
+}
+
+// RemoveProjectEnvironment removes an environment by name. Returns mongo.ErrNoDocuments if the environment does not exist.
+func (r *MemoryRepository) RemoveProjectEnvironment(ctx context.Context, projectID primitive.ObjectID, name string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	filter := func(p *models.Project) bool {
+		_, found := p.FindEnvironment(name)
+		return p.ID == projectID && found
+	}
+	return r.updateProject(filter, func(p *models.Project) {
+		kept := p.Environments[:0]
+		for _, environment := range p.Environments {
+			if environment.Name != name {
+				kept = append(kept, environment)
+			}
+		}
+		p.Environments = kept
+		p.UpdatedAt = time.Now()
+	})
+}
+
+// AddNotificationChannel appends a notification channel to a project. Returns mongo.ErrNoDocuments if the project does
+// not exist or already has a channel with the same name.
+func (r *MemoryRepository) AddNotificationChannel(ctx context.Context, projectID primitive.ObjectID, channel models.NotificationChannel) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	filter := func(p *models.Project) bool {
+		_, taken := p.FindNotificationChannel(channel.Name)
+		return p.ID == projectID && !taken
+	}
+	return r.updateProject(filter, func(p *models.Project) {
+		p.NotificationChannels = append(p.NotificationChannels, channel)
+		p.UpdatedAt = time.Now()
+	})
+}
+
+// UpdateNotificationChannel replaces the type and destination of the channel with the same name. Returns
+// mongo.ErrNoDocuments if the channel does not exist.
+func (r *MemoryRepository) UpdateNotificationChannel(ctx context.Context, projectID primitive.ObjectID, channel models.NotificationChannel) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	filter := func(p *models.Project) bool {
+		_, found := p.FindNotificationChannel(channel.Name)
+		return p.ID == projectID && found
+	}
+	return r.updateProject(filter, func(p *models.Project) {
+		now := time.Now()
+		existing, _ := p.FindNotificationChannel(channel.Name)
+		existing.Type = channel.Type
+		existing.Emails = channel.Emails
+		existing.URL = channel.URL
+		existing.RoutingKey = channel.RoutingKey
+		existing.DigestMinutes = channel.DigestMinutes
+		existing.UpdatedAt = now
+		p.UpdatedAt = now
+	})
+}
+
+// RemoveNotificationChannel removes a notification channel by name. Returns mongo.ErrNoDocuments if the channel does
+// not exist.
+func (r *MemoryRepository) RemoveNotificationChannel(ctx context.Context, projectID primitive.ObjectID, name string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	filter := func(p *models.Project) bool {
+		_, found := p.FindNotificationChannel(name)
+		return p.ID == projectID && found
+	}
+	return r.updateProject(filter, func(p *models.Project) {
+		kept := p.NotificationChannels[:0]
+		for _, channel := range p.NotificationChannels {
+			if channel.Name != name {
+				kept = append(kept, channel)
+			}
+		}
+		p.NotificationChannels = kept
+		p.UpdatedAt = time.Now()
+	})
+}
+
+// SetAlertRoutes replaces the alert routes of the project, or removes them when routes is empty.
+// Returns mongo.ErrNoDocuments if the project does not exist.
+func (r *MemoryRepository) SetAlertRoutes(ctx context.Context, projectID primitive.ObjectID, routes []models.AlertRoute) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if len(routes) == 0 {
+		routes = nil
+	}
+	return r.updateProject(projectByID(projectID), func(p *models.Project) {
+		p.AlertRoutes = routes
+		p.UpdatedAt = time.Now()
+	})
+}
+
+// SetOnCallSchedule replaces the on-call schedule of the project, or removes it when schedule is nil.
+// Returns mongo.ErrNoDocuments if the project does not exist.
+func (r *MemoryRepository) SetOnCallSchedule(ctx context.Context, projectID primitive.ObjectID, schedule *models.OnCallSchedule) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.updateProject(projectByID(projectID), func(p *models.Project) {
+		p.OnCall = schedule
+		p.UpdatedAt = time.Now()
+	})
+}
+
+// AddProjectUser adds a user to the project's project_users array unless the email is already a member.
+// Returns mongo.ErrNoDocuments if the project does not exist.
+func (r *MemoryRepository) AddProjectUser(ctx context.Context, projectID primitive.ObjectID, user models.ProjectUser) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	matched, _, err := r.projects.update(projectByID(projectID), func(p *models.Project) {
+		if hasProjectUser(p, user.Email) {
+			return
+		}
+		p.ProjectUsers = append(p.ProjectUsers, user)
+		p.UpdatedAt = time.Now()
+	})
+	if err != nil {
+		return err
+	}
+	if matched == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+// updateProject applies apply to the matching project. Returns mongo.ErrNoDocuments if none matches.
+func (r *MemoryRepository) updateProject(filter func(*models.Project) bool, apply func(*models.Project)) error {
+	matched, _, err := r.projects.update(filter, apply)
+	if err != nil {
+		return err
+	}
+	if matched == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+func projectByID(projectID primitive.ObjectID) func(*models.Project) bool {
+	return func(p *models.Project) bool { return p.ID == projectID }
+}
+
+func hasProjectUser(project *models.Project, email string) bool {
+	for _, user := range project.ProjectUsers {
+		if user.Email == email {
+			return true
+		}
+	}
+	return false
+}
+
+func scopedAPIKeyIndex(project *models.Project, keyID string) int {
+	for i, key := range project.ScopedAPIKeys {
+		if key.ID == keyID {
+			return i
+		}
+	}
+	return -1
+}
+
+// SetProjectQuotas sets the quota overrides of the project, or removes them when quotas is nil.
+// Returns mongo.ErrNoDocuments if the project does not exist.
+func (r *MemoryRepository) SetProjectQuotas(ctx context.Context, projectID primitive.ObjectID, quotas *models.ProjectQuotas) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.updateProject(projectByID(projectID), func(p *models.Project) {
+		p.Quotas = quotas
+		p.UpdatedAt = time.Now()
+	})
+}
+
+// Organizations
+
+// CreateOrganization inserts a new organization
+func (r *MemoryRepository) CreateOrganization(ctx context.Context, organization *models.Organization) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	id, err := r.organizations.insert(organization)
+	if err != nil {
+		return err
+	}
+	organization.ID = id
+	return nil
+}
+
+// GetOrganizationByID returns an organization. Returns mongo.ErrNoDocuments if not found.
+func (r *MemoryRepository) GetOrganizationByID(ctx context.Context, organizationID primitive.ObjectID) (*models.Organization, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.organizations.findOne(func(o *models.Organization) bool { return o.ID == organizationID })
+}
+
+// GetAllOrganizations returns all organizations sorted by name
+func (r *MemoryRepository) GetAllOrganizations(ctx context.Context) ([]*models.Organization, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.findOrganizations(func(*models.Organization) bool { return true })
+}
+
+// GetUserOrganizations returns the organizations the email is a member of, sorted by name
+func (r *MemoryRepository) GetUserOrganizations(ctx context.Context, email string) ([]*models.Organization, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.findOrganizations(func(o *models.Organization) bool {
+		for _, member := range o.Members {
+			if member.Email == email {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+func (r *MemoryRepository) findOrganizations(filter func(*models.Organization) bool) ([]*models.Organization, error) {
+	organizations, err := r.organizations.find(filter)
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(organizations, func(a, b int) bool {
+		return strings.ToLower(organizations[a].Name) < strings.ToLower(organizations[b].Name)
+	})
+	return organizations, nil
+}
+
+// administeredOrganizationIDs returns the IDs of the organizations the email is an admin of
+func (r *MemoryRepository) administeredOrganizationIDs(email string) (map[primitive.ObjectID]bool, error) {
+	organizations, err := r.organizations.find(func(o *models.Organization) bool {
+		for _, member := range o.Members {
+			if member.Email == email && member.Role == models.OrganizationRoleAdmin {
+				return true
+			}
+		}
+		return false
+	})
+	if err != nil {
+		return nil, err
+	}
+	ids := make(map[primitive.ObjectID]bool, len(organizations))
+	for _, organization := range organizations {
+		ids[organization.ID] = true
+	}
+	return ids, nil
+}
+
+// UpdateOrganization replaces the organization's name, description and members.
+// Returns mongo.ErrNoDocuments if the organization does not exist.
+func (r *MemoryRepository) UpdateOrganization(ctx context.Context, organizationID primitive.ObjectID, organization *models.Organization) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	members := organization.Members
+	if members == nil {
+		members = []models.OrganizationMember{}
+	}
+	matched, _, err := r.organizations.update(func(o *models.Organization) bool { return o.ID == organizationID }, func(o *models.Organization) {
+		o.Name = organization.Name
+		o.Description = organization.Description
+		o.Members = members
+		o.UpdatedAt = organization.UpdatedAt
+	})
+	if err != nil {
+		return err
+	}
+	if matched == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+// DeleteOrganization removes the organization document. Returns mongo.ErrNoDocuments if not found.
+func (r *MemoryRepository) DeleteOrganization(ctx context.Context, organizationID primitive.ObjectID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	deleted, err := r.organizations.delete(func(o *models.Organization) bool { return o.ID == organizationID })
+	if err != nil {
+		return err
+	}
+	if deleted == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+// GetProjectsByOrganizationID returns the projects of an organization, except those being deleted
+func (r *MemoryRepository) GetProjectsByOrganizationID(ctx context.Context, organizationID primitive.ObjectID) ([]*models.Project, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.projects.find(func(p *models.Project) bool {
+		return p.Status != models.ProjectStatusPendingDelete && p.OrganizationID != nil && *p.OrganizationID == organizationID
+	})
+}
+
+// SetProjectOrganization moves the project into an organization, or out of its organization when organizationID is nil.
+// Returns mongo.ErrNoDocuments if the project does not exist.
+func (r *MemoryRepository) SetProjectOrganization(ctx context.Context, projectID primitive.ObjectID, organizationID *primitive.ObjectID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.updateProject(projectByID(projectID), func(p *models.Project) {
+		p.OrganizationID = organizationID
+		p.UpdatedAt = time.Now()
+	})
+}
+
+// SetOrganizationQuotas sets the quotas of the organization's projects, or removes them when quotas is nil.
+// Returns mongo.ErrNoDocuments if the organization does not exist.
+func (r *MemoryRepository) SetOrganizationQuotas(ctx context.Context, organizationID primitive.ObjectID, quotas *models.ProjectQuotas) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	matched, _, err := r.organizations.update(func(o *models.Organization) bool { return o.ID == organizationID }, func(o *models.Organization) {
+		o.Quotas = quotas
+		o.UpdatedAt = time.Now()
+	})
+	if err != nil {
+		return err
+	}
+	if matched == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+// Invitations
+
+// CreateInvitation inserts a new project invitation
+func (r *MemoryRepository) CreateInvitation(ctx context.Context, invitation *models.Invitation) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	id, err := r.invitations.insert(invitation)
+	if err != nil {
+		return err
+	}
+	invitation.ID = id
+	return nil
+}
+
+// GetInvitationByUUID returns an invitation by UUID. Returns mongo.ErrNoDocuments if not found.
+func (r *MemoryRepository) GetInvitationByUUID(ctx context.Context, invitationUUID string) (*models.Invitation, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.invitations.findOne(func(i *models.Invitation) bool { return i.UUID == invitationUUID })
+}
+
+// GetInvitationsByProjectID returns a project's invitations, newest first. An empty status returns all statuses.
+func (r *MemoryRepository) GetInvitationsByProjectID(ctx context.Context, projectID primitive.ObjectID, status models.InvitationStatus) ([]*models.Invitation, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	invitations, err := r.invitations.find(func(i *models.Invitation) bool {
+		return i.ProjectID == projectID && (status == "" || i.Status == status)
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(invitations, func(a, b int) bool {
+		return invitations[a].CreatedAt.After(invitations[b].CreatedAt)
+	})
+	return invitations, nil
+}
+
+// UpdateInvitationStatus sets an invitation's status, recording accepted_at when it is accepted.
+// Returns mongo.ErrNoDocuments if the invitation does not exist.
+func (r *MemoryRepository) UpdateInvitationStatus(ctx context.Context, invitationUUID string, status models.InvitationStatus) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	matched, _, err := r.invitations.update(func(i *models.Invitation) bool { return i.UUID == invitationUUID }, func(i *models.Invitation) {
+		i.Status = status
+		i.UpdatedAt = now
+		if status == models.InvitationStatusAccepted {
+			i.AcceptedAt = &now
+		}
+	})
+	if err != nil {
+		return err
+	}
+	if matched == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+// AcceptInvitation marks the invitation as accepted if it is still pending, unexpired and sent to email
+func (r *MemoryRepository) AcceptInvitation(ctx context.Context, invitationUUID, email string, at time.Time) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	matched, _, err := r.invitations.update(func(i *models.Invitation) bool {
+		return i.UUID == invitationUUID && i.Status == models.InvitationStatusPending && i.Email == email && i.ExpiresAt.After(at)
+	}, func(i *models.Invitation) {
+		i.Status = models.InvitationStatusAccepted
+		i.AcceptedAt = &at
+		i.UpdatedAt = at
+	})
+	return matched > 0, err
+}
+
+// Secrets
+
+// UpsertSecret creates a secret or replaces the encrypted value of an existing secret with the same name
+func (r *MemoryRepository) UpsertSecret(ctx context.Context, secret *models.Secret) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	filter := func(s *models.Secret) bool { return s.ProjectID == secret.ProjectID && s.Name == secret.Name }
+	matched, _, err := r.secrets.update(filter, func(s *models.Secret) {
+		s.Ciphertext = secret.Ciphertext
+		s.EncryptedDataKey = secret.EncryptedDataKey
+		s.UpdatedAt = secret.UpdatedAt
+	})
+	if err != nil || matched > 0 {
+		return err
+	}
+
+	_, err = r.secrets.insert(&models.Secret{
+		ProjectID:        secret.ProjectID,
+		Name:             secret.Name,
+		Ciphertext:       secret.Ciphertext,
+		EncryptedDataKey: secret.EncryptedDataKey,
+		CreatedAt:        secret.CreatedAt,
+		UpdatedAt:        secret.UpdatedAt,
+	})
+	return err
+}
+
+// GetSecretByName returns a project secret by name. Returns mongo.ErrNoDocuments if not found.
+func (r *MemoryRepository) GetSecretByName(ctx context.Context, projectID primitive.ObjectID, name string) (*models.Secret, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.secrets.findOne(func(s *models.Secret) bool { return s.ProjectID == projectID && s.Name == name })
+}
+
+// GetSecretsByProjectID returns all secrets of a project sorted by name
+func (r *MemoryRepository) GetSecretsByProjectID(ctx context.Context, projectID primitive.ObjectID) ([]*models.Secret, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	secrets, err := r.secrets.find(func(s *models.Secret) bool { return s.ProjectID == projectID })
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(secrets, func(a, b int) bool { return secrets[a].Name < secrets[b].Name })
+	return secrets, nil
+}
+
+// DeleteSecret removes a project secret. Returns mongo.ErrNoDocuments if not found.
+func (r *MemoryRepository) DeleteSecret(ctx context.Context, projectID primitive.ObjectID, name string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	deleted, err := r.secrets.delete(func(s *models.Secret) bool { return s.ProjectID == projectID && s.Name == name })
+	if err != nil {
+		return err
+	}
+	if deleted == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+// Project settings
+
+// GetProjectSettings retrieves a project's settings
+func (r *MemoryRepository) GetProjectSettings(ctx context.Context, projectID primitive.ObjectID) (*models.ProjectSettings, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	settings, err := r.projectSettings.findOne(func(s *models.ProjectSettings) bool { return s.ProjectID == projectID })
+	if err == mongo.ErrNoDocuments {
+		return nil, nil // Not found, callers fall back to built-in defaults
+	}
+	return settings, err
+}
+
+// GetProjectSettingsWithRetention retrieves the settings of all projects that have an execution retention period
+func (r *MemoryRepository) GetProjectSettingsWithRetention(ctx context.Context) ([]*models.ProjectSettings, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.projectSettings.find(func(s *models.ProjectSettings) bool { return s.ExecutionRetentionDays > 0 })
+}
+
+// UpsertProjectSettings replaces a project's settings (upsert)
+func (r *MemoryRepository) UpsertProjectSettings(ctx context.Context, settings *models.ProjectSettings) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	settings.UpdatedAt = time.Now()
+	matched, _, err := r.projectSettings.update(func(s *models.ProjectSettings) bool { return s.ProjectID == settings.ProjectID }, func(s *models.ProjectSettings) {
+		*s = *settings
+	})
+	if err != nil || matched > 0 {
+		return err
+	}
+	_, err = r.projectSettings.insert(settings)
+	return err
+}
+
+// DeleteProjectSettings removes a project's settings. It is a no-op when none are stored.
+func (r *MemoryRepository) DeleteProjectSettings(ctx context.Context, projectID primitive.ObjectID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	_, err := r.projectSettings.delete(func(s *models.ProjectSettings) bool { return s.ProjectID == projectID })
+	return err
+}
+
+// Task templates
+
+// CreateTaskTemplate stores a project task template
+func (r *MemoryRepository) CreateTaskTemplate(ctx context.Context, template *models.TaskTemplate) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	id, err := r.taskTemplates.insert(template)
+	if err != nil {
+		return err
+	}
+	template.ID = id
+	return nil
+}
+
+// GetTaskTemplatesByProjectID returns a project's task templates sorted by name
+func (r *MemoryRepository) GetTaskTemplatesByProjectID(ctx context.Context, projectID primitive.ObjectID) ([]*models.TaskTemplate, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	templates, err := r.taskTemplates.find(templateOfProject(projectID))
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(templates, func(a, b int) bool { return templates[a].Name < templates[b].Name })
+	return templates, nil
+}
+
+// GetTaskTemplateByUUID returns a project task template. Returns mongo.ErrNoDocuments if not found.
+func (r *MemoryRepository) GetTaskTemplateByUUID(ctx context.Context, projectID primitive.ObjectID, templateUUID string) (*models.TaskTemplate, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	inProject := templateOfProject(projectID)
+	return r.taskTemplates.findOne(func(t *models.TaskTemplate) bool { return inProject(t) && t.UUID == templateUUID })
+}
+
+// DeleteTaskTemplate removes a project task template. Returns mongo.ErrNoDocuments if not found.
+func (r *MemoryRepository) DeleteTaskTemplate(ctx context.Context, projectID primitive.ObjectID, templateUUID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	inProject := templateOfProject(projectID)
+	deleted, err := r.taskTemplates.delete(func(t *models.TaskTemplate) bool { return inProject(t) && t.UUID == templateUUID })
+	if err != nil {
+		return err
+	}
+	if deleted == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+// DeleteTaskTemplatesByProjectID removes all task templates of a project
+func (r *MemoryRepository) DeleteTaskTemplatesByProjectID(ctx context.Context, projectID primitive.ObjectID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	_, err := r.taskTemplates.delete(templateOfProject(projectID))
+	return err
+}
+
+func templateOfProject(projectID primitive.ObjectID) func(*models.TaskTemplate) bool {
+	return func(t *models.TaskTemplate) bool { return t.ProjectID != nil && *t.ProjectID == projectID }
+}
+
+// Token revocations
+
+// CreateTokenRevocation stores a token revocation entry
+func (r *MemoryRepository) CreateTokenRevocation(ctx context.Context, revocation *models.TokenRevocation) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	id, err := r.tokenRevocations.insert(revocation)
+	if err != nil {
+		return err
+	}
+	revocation.ID = id
+	return nil
+}
+
+// IsTokenRevoked reports whether the token's jti was revoked, or the user's tokens issued before issuedAt were revoked.
+// Tokens without an iat (zero issuedAt) are treated as issued before any per-user cutoff.
+func (r *MemoryRepository) IsTokenRevoked(ctx context.Context, jti string, email string, issuedAt time.Time) (bool, error) {
+	if jti == "" && email == "" {
+		return false, nil
+	}
+	email = strings.ToLower(strings.TrimSpace(email))
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	_, err := r.tokenRevocations.findOne(func(t *models.TokenRevocation) bool {
+		if jti != "" && t.JTI == jti {
+			return true
+		}
+		return email != "" && t.Email == email && t.RevokedBefore != nil && t.RevokedBefore.After(issuedAt)
+	})
+	if err == mongo.ErrNoDocuments {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// Tasks
+
+func (r *MemoryRepository) CreateTask(ctx context.Context, projectID string, task *models.Task) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	_, err := r.tasks.insert(task)
+	return err
+}
+
+func (r *MemoryRepository) GetAllActiveTasks(ctx context.Context) ([]*models.Task, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.tasks.find(func(t *models.Task) bool {
+		return t.Status == models.TaskStatusActive && t.ScheduleConfig.CronExpression != ""
+	})
+}
+
+func (r *MemoryRepository) GetTasksByStatus(ctx context.Context, statuses []models.TaskStatus) ([]*models.Task, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.tasks.find(func(t *models.Task) bool {
+		for _, status := range statuses {
+			if t.Status == status {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// GetTaskBatchByProjectID returns up to limit tasks of a project regardless of their status
+func (r *MemoryRepository) GetTaskBatchByProjectID(ctx context.Context, projectID primitive.ObjectID, limit int) ([]*models.Task, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	tasks, err := r.tasks.find(func(t *models.Task) bool { return t.ProjectID == projectID })
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(tasks, func(a, b int) bool { return compareObjectIDs(tasks[a].ID, tasks[b].ID) < 0 })
+	return limitSlice(tasks, limit), nil
+}
+
+func (r *MemoryRepository) GetTasksByProjectID(ctx context.Context, projectID primitive.ObjectID) ([]*models.Task, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// Exclude PENDING_DELETE and DELETE_FAILED tasks, as MongoRepository does
+	return r.tasks.find(func(t *models.Task) bool { return t.ProjectID == projectID && !isDeleting(t.Status) })
+}
+
+// ListTasksByProjectID retrieves a filtered, sorted page of a project's tasks and the total number of matches
+func (r *MemoryRepository) ListTasksByProjectID(ctx context.Context, projectID primitive.ObjectID, filter models.TaskListFilter, page, pageSize int) ([]*models.Task, int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	tasks, err := r.tasks.find(func(t *models.Task) bool {
+		return t.ProjectID == projectID && matchesTaskListFilter(t, filter)
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	totalCount := int64(len(tasks))
+
+	sort.SliceStable(tasks, func(a, b int) bool {
+		order := compareTasks(tasks[a], tasks[b], filter.SortBy)
+		if order == 0 {
+			order = compareObjectIDs(tasks[a].ID, tasks[b].ID)
+		}
+		if filter.SortDesc {
+			return order > 0
+		}
+		return order < 0
+	})
+	if pageSize > 0 {
+		tasks = pageSlice(tasks, page, pageSize)
+	}
+
+	// Ensure we always return an empty slice instead of nil
+	if tasks == nil {
+		tasks = []*models.Task{}
+	}
+	return tasks, totalCount, nil
+}
+
+// UpdateTaskLastFailureAt records a failed execution on the task. Older failures never overwrite newer ones.
+func (r *MemoryRepository) UpdateTaskLastFailureAt(ctx context.Context, taskUUID string, failedAt time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	matched, _, err := r.tasks.update(taskByUUID(taskUUID), func(t *models.Task) {
+		if t.LastFailureAt == nil || failedAt.After(*t.LastFailureAt) {
+			t.LastFailureAt = &failedAt
+		}
+	})
+	if err != nil {
+		return err
+	}
+	if matched == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+// SetTaskMutedUntil sets or, when mutedUntil is nil, clears the task's alert mute
+func (r *MemoryRepository) SetTaskMutedUntil(ctx context.Context, taskUUID string, mutedUntil *time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	matched, _, err := r.tasks.update(taskByUUID(taskUUID), func(t *models.Task) {
+		t.MutedUntil = mutedUntil
+		t.UpdatedAt = time.Now()
+	})
+	if err != nil {
+		return err
+	}
+	if matched == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+// SetTaskNextRunAt records the task's next cron fire time or, when nextRunAt is nil, clears it.
+// Scheduler bookkeeping, so updated_at is left alone.
+func (r *MemoryRepository) SetTaskNextRunAt(ctx context.Context, taskUUID string, nextRunAt *time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	_, _, err := r.tasks.update(taskByUUID(taskUUID), func(t *models.Task) {
+		t.NextRunAt = nextRunAt
+	})
+	return err
+}
+
+// SetTaskFlapping marks the task as flapping since the given time or, when since is nil, clears the mark.
+// Alert bookkeeping, so updated_at is left alone.
+func (r *MemoryRepository) SetTaskFlapping(ctx context.Context, taskUUID string, since *time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	_, _, err := r.tasks.update(taskByUUID(taskUUID), func(t *models.Task) {
+		t.FlappingSince = since
+	})
+	return err
+}
+
+// GetTaskByUUID returns a task by UUID. Returns mongo.ErrNoDocuments when not found.
+func (r *MemoryRepository) GetTaskByUUID(ctx context.Context, taskUUID string) (*models.Task, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.tasks.findOne(taskByUUID(taskUUID))
+}
+
+func (r *MemoryRepository) UpdateTask(ctx context.Context, taskUUID string, task *models.Task) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// Same fields as MongoRepository: omitted optional fields are cleared, other empty fields keep their value
+	var unset []string
+	if task.TaskGroupID == nil {
+		unset = append(unset, "task_group_id")
+	}
+	if task.Environment == "" {
+		unset = append(unset, "environment")
+	}
+	if len(task.Tags) == 0 {
+		unset = append(unset, "tags")
+	}
+	if len(task.Env) == 0 {
+		unset = append(unset, "env")
+	}
+	if task.Priority == 0 {
+		unset = append(unset, "priority")
+	}
+
+	_, _, err := r.tasks.set(taskByUUID(taskUUID), task, unset...)
+	return err
+}
+
+func (r *MemoryRepository) UpdateTaskStatus(ctx context.Context, taskUUID string, status models.TaskStatus) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	_, _, err := r.tasks.update(taskByUUID(taskUUID), func(t *models.Task) {
+		t.Status = status
+		t.UpdatedAt = time.Now()
+	})
+	return err
+}
+
+func (r *MemoryRepository) UpdateTaskState(ctx context.Context, taskUUID string, state models.TaskState) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	_, _, err := r.tasks.update(taskByUUID(taskUUID), func(t *models.Task) {
+		t.State = state
+		t.UpdatedAt = time.Now()
+	})
+	return err
+}
+
+// DeleteTask removes the task. Returns mongo.ErrNoDocuments when not found.
+func (r *MemoryRepository) DeleteTask(ctx context.Context, taskUUID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	deleted, err := r.tasks.delete(taskByUUID(taskUUID))
+	if err != nil {
+		return err
+	}
+	if deleted == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+func taskByUUID(taskUUID string) func(*models.Task) bool {
+	return func(t *models.Task) bool { return t.UUID == taskUUID }
+}
+
+// isDeleting reports whether a task is in one of the internal delete states hidden from clients
+func isDeleting(status models.TaskStatus) bool {
+	return status == models.TaskStatusPendingDelete || status == models.TaskStatusDeleteFailed
+}
+
+// matchesTaskListFilter applies the query ListTasksByProjectID builds in MongoRepository
+func matchesTaskListFilter(task *models.Task, filter models.TaskListFilter) bool {
+	if filter.Status != "" {
+		if task.Status != filter.Status {
+			return false
+		}
+	} else if isDeleting(task.Status) {
+		return false
+	}
+	if filter.State != "" && task.State != filter.State {
+		return false
+	}
+	if filter.TaskGroupID != nil && (task.TaskGroupID == nil || *task.TaskGroupID != *filter.TaskGroupID) {
+		return false
+	}
+	if filter.ScheduleType != "" && task.ScheduleType != filter.ScheduleType {
+		return false
+	}
+	for _, tag := range filter.Tags {
+		if !containsString(task.Tags, tag) {
+			return false
+		}
+	}
+	if filter.Severity != "" && task.EffectiveSeverity() != filter.Severity {
+		return false
+	}
+	if filter.Search != "" && !matchesTaskSearch(task, filter.Search) {
+		return false
+	}
+	return true
+}
+
+// matchesTaskSearch matches tasks whose name, description or any metadata key contains the search term
+func matchesTaskSearch(task *models.Task, search string) bool {
+	search = strings.ToLower(search)
+	if strings.Contains(strings.ToLower(task.Name), search) || strings.Contains(strings.ToLower(task.Description), search) {
+		return true
+	}
+	for key := range task.Metadata {
+		if strings.Contains(strings.ToLower(key), search) {
+			return true
+		}
+	}
+	return false
+}
+
+// compareTasks orders two tasks by a sort field. Missing last failures sort first, as in MongoDB.
+func compareTasks(a, b *models.Task, field models.TaskSortField) int {
+	switch field {
+	case models.TaskSortByName:
+		return strings.Compare(a.Name, b.Name)
+	case models.TaskSortBySeverity:
+		return a.EffectiveSeverity().Rank() - b.EffectiveSeverity().Rank()
+	case models.TaskSortByLastFailure:
+		switch {
+		case a.LastFailureAt == nil && b.LastFailureAt == nil:
+			return 0
+		case a.LastFailureAt == nil:
+			return -1
+		case b.LastFailureAt == nil:
+			return 1
+		}
+		return a.LastFailureAt.Compare(*b.LastFailureAt)
+	default:
+		return a.CreatedAt.Compare(b.CreatedAt)
+	}
+}
+
+// Task groups
+
+func (r *MemoryRepository) CreateTaskGroup(ctx context.Context, projectID string, taskGroup *models.TaskGroup) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	_, err := r.taskGroups.insert(taskGroup)
+	return err
+}
+
+func (r *MemoryRepository) GetTaskGroupsByProjectID(ctx context.Context, projectID primitive.ObjectID) ([]*models.TaskGroup, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.taskGroups.find(func(g *models.TaskGroup) bool { return g.ProjectID == projectID })
+}
+
+func (r *MemoryRepository) GetTaskGroupByUUID(ctx context.Context, taskGroupUUID string) (*models.TaskGroup, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.taskGroups.findOne(taskGroupByUUID(taskGroupUUID))
+}
+
+func (r *MemoryRepository) GetTaskGroupByID(ctx context.Context, taskGroupID primitive.ObjectID) (*models.TaskGroup, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.taskGroups.findOne(func(g *models.TaskGroup) bool { return g.ID == taskGroupID })
+}
+
+func (r *MemoryRepository) UpdateTaskGroup(ctx context.Context, taskGroupUUID string, taskGroup *models.TaskGroup) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	_, _, err := r.taskGroups.set(taskGroupByUUID(taskGroupUUID), taskGroup)
+	return err
+}
+
+// UpdateTaskGroupWithTasks replaces the task group and cascades status and state to its tasks (except those being
+// deleted). Nothing is changed when a step fails.
+func (r *MemoryRepository) UpdateTaskGroupWithTasks(ctx context.Context, taskGroup *models.TaskGroup, cascade models.TaskGroupCascade) (*models.TaskGroupCascadeResult, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// Collections never modify their document slices in place, so these snapshots stay intact
+	previousGroups, previousTasks := r.taskGroups.docs, r.tasks.docs
+	result, err := r.applyTaskGroupCascade(taskGroup, cascade)
+	if err != nil {
+		r.taskGroups.docs, r.tasks.docs = previousGroups, previousTasks
+		return nil, err
+	}
+	return result, nil
+}
+
+func (r *MemoryRepository) applyTaskGroupCascade(taskGroup *models.TaskGroup, cascade models.TaskGroupCascade) (*models.TaskGroupCascadeResult, error) {
+	if _, _, err := r.taskGroups.set(taskGroupByUUID(taskGroup.UUID), taskGroup); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	result := &models.TaskGroupCascadeResult{}
+	inGroup := taskInGroup(taskGroup.ID)
+
+	if cascade.TaskStatus != "" {
+		filter := func(t *models.Task) bool { return inGroup(t) && t.Status != cascade.TaskStatus }
+		_, modified, err := r.tasks.update(filter, func(t *models.Task) {
+			t.Status = cascade.TaskStatus
+			t.UpdatedAt = now
+		})
+		if err != nil {
+			return nil, err
+		}
+		result.StatusUpdated = modified
+	}
+
+	if cascade.TaskState != "" {
+		filter := func(t *models.Task) bool { return inGroup(t) && t.State != cascade.TaskState }
+		_, modified, err := r.tasks.update(filter, func(t *models.Task) {
+			t.State = cascade.TaskState
+			t.UpdatedAt = now
+		})
+		if err != nil {
+			return nil, err
+		}
+		result.StateUpdated = modified
+	}
+
+	return result, nil
+}
+
+func (r *MemoryRepository) UpdateTaskGroupStatus(ctx context.Context, taskGroupUUID string, status models.TaskGroupStatus) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	_, _, err := r.taskGroups.update(taskGroupByUUID(taskGroupUUID), func(g *models.TaskGroup) {
+		g.Status = status
+		g.UpdatedAt = time.Now()
+	})
+	return err
+}
+
+func (r *MemoryRepository) UpdateTaskGroupState(ctx context.Context, taskGroupUUID string, state models.TaskGroupState) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	_, _, err := r.taskGroups.update(taskGroupByUUID(taskGroupUUID), func(g *models.TaskGroup) {
+		g.State = state
+		g.UpdatedAt = time.Now()
+	})
+	return err
+}
+
+func (r *MemoryRepository) DeleteTaskGroup(ctx context.Context, taskGroupUUID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	_, err := r.taskGroups.delete(taskGroupByUUID(taskGroupUUID))
+	return err
+}
+
+func (r *MemoryRepository) GetTasksByGroupID(ctx context.Context, taskGroupID primitive.ObjectID) ([]*models.Task, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.tasks.find(taskInGroup(taskGroupID))
+}
+
+func (r *MemoryRepository) GetActiveTaskGroupsWithWindows(ctx context.Context) ([]*models.TaskGroup, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.taskGroups.find(func(g *models.TaskGroup) bool {
+		return g.Status == models.TaskGroupStatusActive && g.StartTime != "" && g.EndTime != ""
+	})
+}
+
+func taskGroupByUUID(taskGroupUUID string) func(*models.TaskGroup) bool {
+	return func(g *models.TaskGroup) bool { return g.UUID == taskGroupUUID }
+}
+
+// taskInGroup matches the group's tasks that are not being deleted
+func taskInGroup(taskGroupID primitive.ObjectID) func(*models.Task) bool {
+	return func(t *models.Task) bool {
+		return t.TaskGroupID != nil && *t.TaskGroupID == taskGroupID && !isDeleting(t.Status)
+	}
+}
+
+// Executions
+
+func (r *MemoryRepository) CreateExecution(ctx context.Context, execution *models.Execution) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	_, err := r.executions.insert(execution)
+	return err
+}
+
+func (r *MemoryRepository) GetExecutionsByTaskUUID(ctx context.Context, taskUUID string, startDate, endDate *time.Time) ([]*models.Execution, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	executions, err := r.findExecutionsByTaskUUID(taskUUID, startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+	if executions == nil {
+		executions = []*models.Execution{}
+	}
+	return executions, nil
+}
+
+func (r *MemoryRepository) GetExecutionsByTaskUUIDPaginated(ctx context.Context, taskUUID string, startDate, endDate *time.Time, page, pageSize int) ([]*models.Execution, int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	executions, err := r.findExecutionsByTaskUUID(taskUUID, startDate, endDate)
+	if err != nil {
+		return nil, 0, err
+	}
+	totalCount := int64(len(executions))
+
+	executions = pageSlice(executions, page, pageSize)
+	if executions == nil {
+		executions = []*models.Execution{}
+	}
+	return executions, totalCount, nil
+}
+
+// findExecutionsByTaskUUID returns the task's executions started within the optional range, most recent first
+func (r *MemoryRepository) findExecutionsByTaskUUID(taskUUID string, startDate, endDate *time.Time) ([]*models.Execution, error) {
+	executions, err := r.executions.find(func(e *models.Execution) bool {
+		if e.TaskUUID != taskUUID {
+			return false
+		}
+		if startDate != nil && e.StartedAt.Before(*startDate) {
+			return false
+		}
+		return endDate == nil || !e.StartedAt.After(*endDate)
+	})
+	if err != nil {
+		return nil, err
+	}
+	sortExecutionsByStart(executions, true)
+	return executions, nil
+}
+
+func (r *MemoryRepository) AppendLogToExecution(ctx context.Context, executionUUID string, logEntry models.LogEntry) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	_, _, err := r.executions.update(executionByUUID(executionUUID), func(e *models.Execution) {
+		e.Logs = append(e.Logs, logEntry)
+		e.UpdatedAt = time.Now()
+	})
+	return err
+}
+
+func (r *MemoryRepository) UpdateExecutionStatus(ctx context.Context, executionUUID string, status models.ExecutionStatus, errorMessage *string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	_, _, err := r.executions.update(executionByUUID(executionUUID), func(e *models.Execution) {
+		e.Status = status
+		e.UpdatedAt = now
+		if status == models.ExecutionStatusSuccess || status == models.ExecutionStatusFailed {
+			e.EndedAt = &now
+		}
+		if errorMessage != nil {
+			e.Error = *errorMessage
+		}
+	})
+	return err
+}
+
+// RecordExecutionEndpoint records which execution endpoint the execution was sent to
+func (r *MemoryRepository) RecordExecutionEndpoint(ctx context.Context, executionUUID string, endpoint string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	_, _, err := r.executions.update(executionByUUID(executionUUID), func(e *models.Execution) {
+		e.Endpoint = endpoint
+		e.UpdatedAt = time.Now()
+	})
+	return err
+}
+
+// FailExecutionIfUnfinished marks a QUEUED, PENDING or RUNNING execution as FAILED. Reports whether the execution was
+// failed.
+func (r *MemoryRepository) FailExecutionIfUnfinished(ctx context.Context, executionUUID string, errorMessage string) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	_, modified, err := r.executions.update(unfinishedExecution(executionUUID), func(e *models.Execution) {
+		e.Status = models.ExecutionStatusFailed
+		e.Error = errorMessage
+		e.EndedAt = &now
+		e.UpdatedAt = now
+	})
+	if err != nil {
+		return false, err
+	}
+	return modified > 0, nil
+}
+
+func (r *MemoryRepository) StartQueuedExecution(ctx context.Context, executionUUID string, startedAt time.Time) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	queued := func(e *models.Execution) bool {
+		return e.UUID == executionUUID && e.Status == models.ExecutionStatusQueued
+	}
+	_, modified, err := r.executions.update(queued, func(e *models.Execution) {
+		e.Status = models.ExecutionStatusPending
+		e.StartedAt = startedAt
+		e.UpdatedAt = startedAt
+	})
+	if err != nil {
+		return false, err
+	}
+	return modified > 0, nil
+}
+
+func (r *MemoryRepository) CountActiveExecutions(ctx context.Context, taskUUIDs []string, since time.Time) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	tasks := make(map[string]bool, len(taskUUIDs))
+	for _, taskUUID := range taskUUIDs {
+		tasks[taskUUID] = true
+	}
+	active, err := r.executions.find(func(e *models.Execution) bool {
+		return tasks[e.TaskUUID] && !e.StartedAt.Before(since) &&
+			(e.Status == models.ExecutionStatusPending || e.Status == models.ExecutionStatusRunning)
+	})
+	return int64(len(active)), err
+}
+
+func (r *MemoryRepository) RecordExecutionHeartbeat(ctx context.Context, executionUUID string, at time.Time) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	matched, _, err := r.executions.update(unfinishedExecution(executionUUID), func(e *models.Execution) {
+		e.HeartbeatAt = &at
+		e.UpdatedAt = at
+	})
+	if err != nil {
+		return false, err
+	}
+	return matched > 0, nil
+}
+
+func (r *MemoryRepository) GetExecutionByUUID(ctx context.Context, executionUUID string) (*models.Execution, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.executions.findOne(executionByUUID(executionUUID))
+}
+
+// GetLatestExecutionByTaskUUID retrieves the most recent execution of a task without its logs
+func (r *MemoryRepository) GetLatestExecutionByTaskUUID(ctx context.Context, taskUUID string) (*models.Execution, error) {
+	latest, err := r.GetLatestExecutionsByTaskUUIDs(ctx, []string{taskUUID})
+	if err != nil {
+		return nil, err
+	}
+	return latest[taskUUID], nil // nil when the task has never run
+}
+
+// GetLatestExecutionsByTaskUUIDs retrieves the most recent execution of each task, without logs
+func (r *MemoryRepository) GetLatestExecutionsByTaskUUIDs(ctx context.Context, taskUUIDs []string) (map[string]*models.Execution, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	latest := make(map[string]*models.Execution, len(taskUUIDs))
+	executions, err := r.executions.find(func(e *models.Execution) bool { return containsString(taskUUIDs, e.TaskUUID) })
+	if err != nil {
+		return nil, err
+	}
+	sortExecutionsByStart(executions, true)
+
+	for _, execution := range executions {
+		if _, ok := latest[execution.TaskUUID]; !ok {
+			execution.Logs = nil
+			latest[execution.TaskUUID] = execution
+		}
+	}
+	return latest, nil
+}
+
+// GetLastSuccessByTaskUUIDs retrieves when each task last completed successfully
+func (r *MemoryRepository) GetLastSuccessByTaskUUIDs(ctx context.Context, taskUUIDs []string) (map[string]time.Time, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	lastSuccess := make(map[string]time.Time, len(taskUUIDs))
+	executions, err := r.executions.find(func(e *models.Execution) bool {
+		return e.Status == models.ExecutionStatusSuccess && containsString(taskUUIDs, e.TaskUUID)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, execution := range executions {
+		succeededAt := execution.StartedAt
+		if execution.EndedAt != nil {
+			succeededAt = *execution.EndedAt
+		}
+		if succeededAt.After(lastSuccess[execution.TaskUUID]) {
+			lastSuccess[execution.TaskUUID] = succeededAt
+		}
+	}
+	return lastSuccess, nil
+}
+
+// DeleteExecutionsByTaskUUIDs removes all executions of the given tasks and returns how many were deleted
+func (r *MemoryRepository) DeleteExecutionsByTaskUUIDs(ctx context.Context, taskUUIDs []string) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.executions.delete(func(e *models.Execution) bool { return containsString(taskUUIDs, e.TaskUUID) })
+}
+
+// DeleteExecutionsByTaskUUIDsBefore removes executions of the given tasks that started before the cutoff
+func (r *MemoryRepository) DeleteExecutionsByTaskUUIDsBefore(ctx context.Context, taskUUIDs []string, before time.Time) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.executions.delete(func(e *models.Execution) bool {
+		return containsString(taskUUIDs, e.TaskUUID) && e.StartedAt.Before(before)
+	})
+}
+
+// GetExecutionsByTaskUUIDsBetween returns up to limit executions of the given tasks that started from through to,
+// oldest first and without logs
+func (r *MemoryRepository) GetExecutionsByTaskUUIDsBetween(ctx context.Context, taskUUIDs []string, from, to time.Time, limit int) ([]*models.Execution, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	executions, err := r.executions.find(func(e *models.Execution) bool {
+		return containsString(taskUUIDs, e.TaskUUID) && !e.StartedAt.Before(from) && !e.StartedAt.After(to)
+	})
+	if err != nil {
+		return nil, err
+	}
+	sortExecutionsByStart(executions, false)
+
+	executions = limitSlice(executions, limit)
+	for _, execution := range executions {
+		execution.Logs = nil
+	}
+	return executions, nil
+}
+
+// TrimExecutionLogsBefore removes the logs of executions of the given tasks with the given statuses that started
+// before the cutoff, keeping the executions. Returns how many executions had their logs removed.
+func (r *MemoryRepository) TrimExecutionLogsBefore(ctx context.Context, taskUUIDs []string, statuses []models.ExecutionStatus, before time.Time) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	trimmed, _, err := r.executions.update(func(e *models.Execution) bool {
+		return containsString(taskUUIDs, e.TaskUUID) && containsStatus(statuses, e.Status) && e.StartedAt.Before(before) && len(e.Logs) > 0
+	}, func(e *models.Execution) {
+		e.Logs = nil
+		e.LogsTrimmedAt = &now
+		e.UpdatedAt = now
+	})
+	return trimmed, err
+}
+
+// GetExecutionBatchByTaskUUID returns up to limit executions of a task, oldest first and without logs
+func (r *MemoryRepository) GetExecutionBatchByTaskUUID(ctx context.Context, taskUUID string, limit int) ([]*models.Execution, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	executions, err := r.executions.find(func(e *models.Execution) bool { return e.TaskUUID == taskUUID })
+	if err != nil {
+		return nil, err
+	}
+	sortExecutionsByStart(executions, false)
+
+	executions = limitSlice(executions, limit)
+	for _, execution := range executions {
+		execution.Logs = nil
+	}
+	return executions, nil
+}
+
+// DeleteExecutionsByIDs removes the given executions and returns how many were deleted
+func (r *MemoryRepository) DeleteExecutionsByIDs(ctx context.Context, executionIDs []primitive.ObjectID) (int64, error) {
+	ids := make(map[primitive.ObjectID]bool, len(executionIDs))
+	for _, id := range executionIDs {
+		ids[id] = true
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.executions.delete(func(e *models.Execution) bool { return ids[e.ID] })
+}
+
+func executionByUUID(executionUUID string) func(*models.Execution) bool {
+	return func(e *models.Execution) bool { return e.UUID == executionUUID }
+}
+
+func unfinishedExecution(executionUUID string) func(*models.Execution) bool {
+	return func(e *models.Execution) bool {
+		return e.UUID == executionUUID &&
+			(e.Status == models.ExecutionStatusQueued || e.Status == models.ExecutionStatusPending || e.Status == models.ExecutionStatusRunning)
+	}
+}
+
+func sortExecutionsByStart(executions []*models.Execution, newestFirst bool) {
+	sort.SliceStable(executions, func(a, b int) bool {
+		if newestFirst {
+			return executions[a].StartedAt.After(executions[b].StartedAt)
+		}
+		return executions[a].StartedAt.Before(executions[b].StartedAt)
+	})
+}
+
+// Failure statistics
+
+func (r *MemoryRepository) IncrementFailureStat(ctx context.Context, projectID primitive.ObjectID, date string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	matched, _, err := r.executionFailureStat.update(failureStatOn(projectID, date), func(s *models.ExecutionFailureStat) {
+		s.Count++
+		s.UpdatedAt = now
+	})
+	if err != nil || matched > 0 {
+		return err
+	}
+	_, err = r.executionFailureStat.insert(&models.ExecutionFailureStat{ProjectID: projectID, Date: date, Count: 1, UpdatedAt: now})
+	return err
+}
+
+// DecrementFailureStats subtracts failures from a project's daily counters, clamping at zero
+func (r *MemoryRepository) DecrementFailureStats(ctx context.Context, projectID primitive.ObjectID, countsByDate map[string]int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	for date, count := range countsByDate {
+		if count <= 0 {
+			continue
+		}
+		_, _, err := r.executionFailureStat.update(failureStatOn(projectID, date), func(s *models.ExecutionFailureStat) {
+			s.Count = max(s.Count-count, 0)
+			s.UpdatedAt = now
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *MemoryRepository) GetFailureStatsByProject(ctx context.Context, projectID primitive.ObjectID, days int) ([]*models.FailedExecutionStats, int, error) {
+	startDate := time.Now().UTC().AddDate(0, 0, -days).Format("2006-01-02")
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stats, err := r.executionFailureStat.find(func(s *models.ExecutionFailureStat) bool {
+		return s.ProjectID == projectID && s.Date >= startDate
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	sort.SliceStable(stats, func(a, b int) bool { return stats[a].Date > stats[b].Date })
+
+	result := make([]*models.FailedExecutionStats, 0, len(stats))
+	total := 0
+	for _, stat := range stats {
+		result = append(result, &models.FailedExecutionStats{Date: stat.Date, Count: stat.Count})
+		total += stat.Count
+	}
+	return result, total, nil
+}
+
+// DeleteStatsByProjectID removes all failure counters and stored task failure stats for a project
+func (r *MemoryRepository) DeleteStatsByProjectID(ctx context.Context, projectID primitive.ObjectID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, err := r.executionFailureStat.delete(func(s *models.ExecutionFailureStat) bool { return s.ProjectID == projectID }); err != nil {
+		return err
+	}
+	_, err := r.taskFailureStats.delete(func(s *models.StoredTaskFailureStats) bool { return s.ProjectID == projectID })
+	return err
+}
+
+func (r *MemoryRepository) GetExecutionStatsByProject(ctx context.Context, projectID primitive.ObjectID, days int) ([]*models.ExecutionStats, error) {
+	startDate := time.Now().UTC().AddDate(0, 0, -days)
+	startOfDay := time.Date(startDate.Year(), startDate.Month(), startDate.Day(), 0, 0, 0, 0, time.UTC)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	taskIDs, err := r.projectTaskIDs(projectID)
+	if err != nil {
+		return nil, err
+	}
+	executions, err := r.executions.find(func(e *models.Execution) bool {
+		_, ok := taskIDs[e.TaskID]
+		return ok && !e.StartedAt.Before(startOfDay)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	byDate := make(map[string]*models.ExecutionStats)
+	for _, execution := range executions {
+		date := execution.StartedAt.UTC().Format("2006-01-02")
+		stat, ok := byDate[date]
+		if !ok {
+			stat = &models.ExecutionStats{Date: date}
+			byDate[date] = stat
+		}
+		stat.Total++
+		switch execution.Status {
+		case models.ExecutionStatusFailed:
+			stat.Failures++
+		case models.ExecutionStatusSuccess:
+			stat.Success++
+		}
+	}
+
+	stats := make([]*models.ExecutionStats, 0, len(byDate))
+	for _, stat := range byDate {
+		stats = append(stats, stat)
+	}
+	sort.Slice(stats, func(a, b int) bool { return stats[a].Date > stats[b].Date })
+	return stats, nil
+}
+
+// GetTaskFailuresByDate retrieves task failure stats from stored pre-calculated stats
+func (r *MemoryRepository) GetTaskFailuresByDate(ctx context.Context, projectID primitive.ObjectID, date string) ([]*models.TaskFailureStats, int, error) {
+	storedStats, err := r.GetStoredTaskFailureStats(ctx, projectID, date)
+	if err != nil {
+		return nil, 0, err
+	}
+	if storedStats == nil {
+		// Stats are calculated by the cron job
+		return []*models.TaskFailureStats{}, 0, nil
+	}
+
+	stats := make([]*models.TaskFailureStats, len(storedStats.Tasks))
+	for i := range storedStats.Tasks {
+		stats[i] = &storedStats.Tasks[i]
+	}
+	return stats, storedStats.Total, nil
+}
+
+// CalculateTaskFailureStats calculates task failure stats for a given project and date
+func (r *MemoryRepository) CalculateTaskFailureStats(ctx context.Context, projectID primitive.ObjectID, date string) (*models.StoredTaskFailureStats, error) {
+	parsedDate, err := time.Parse("2006-01-02", date)
+	if err != nil {
+		return nil, err
+	}
+	startOfDay := time.Date(parsedDate.Year(), parsedDate.Month(), parsedDate.Day(), 0, 0, 0, 0, time.UTC)
+	endOfDay := startOfDay.AddDate(0, 0, 1)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	tasks, err := r.tasks.find(func(t *models.Task) bool { return t.ProjectID == projectID })
+	if err != nil {
+		return nil, err
+	}
+	failures := make(map[primitive.ObjectID]int, len(tasks))
+	for _, task := range tasks {
+		failures[task.ID] = 0
+	}
+
+	executions, err := r.executions.find(func(e *models.Execution) bool {
+		_, ok := failures[e.TaskID]
+		return ok && e.Status == models.ExecutionStatusFailed && !e.StartedAt.Before(startOfDay) && e.StartedAt.Before(endOfDay)
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, execution := range executions {
+		failures[execution.TaskID]++
+	}
+
+	taskStats := []models.TaskFailureStats{}
+	total := 0
+	for _, task := range tasks {
+		if count := failures[task.ID]; count > 0 {
+			taskStats = append(taskStats, models.TaskFailureStats{TaskID: task.UUID, Failures: count})
+			total += count
+		}
+	}
+
+	return &models.StoredTaskFailureStats{
+		ProjectID:    projectID,
+		Date:         date,
+		Tasks:        taskStats,
+		Total:        total,
+		CalculatedAt: time.Now().UTC(),
+	}, nil
+}
+
+// StoreTaskFailureStats stores pre-calculated task failure stats (upsert)
+func (r *MemoryRepository) StoreTaskFailureStats(ctx context.Context, stats *models.StoredTaskFailureStats) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	matched, _, err := r.taskFailureStats.update(storedStatsOn(stats.ProjectID, stats.Date), func(s *models.StoredTaskFailureStats) {
+		s.Tasks = stats.Tasks
+		s.Total = stats.Total
+		s.CalculatedAt = stats.CalculatedAt
+	})
+	if err != nil || matched > 0 {
+		return err
+	}
+	_, err = r.taskFailureStats.insert(&models.StoredTaskFailureStats{
+		ProjectID:    stats.ProjectID,
+		Date:         stats.Date,
+		Tasks:        stats.Tasks,
+		Total:        stats.Total,
+		CalculatedAt: stats.CalculatedAt,
+	})
+	return err
+}
+
+// GetStoredTaskFailureStats retrieves pre-calculated task failure stats
+func (r *MemoryRepository) GetStoredTaskFailureStats(ctx context.Context, projectID primitive.ObjectID, date string) (*models.StoredTaskFailureStats, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stats, err := r.taskFailureStats.findOne(storedStatsOn(projectID, date))
+	if err == mongo.ErrNoDocuments {
+		return nil, nil // Not found, return nil
+	}
+	return stats, err
+}
+
+// RemoveTaskFromStoredFailureStats removes a task from every stored daily breakdown of its project
+// and subtracts its failures from the daily totals. Safe to repeat.
+func (r *MemoryRepository) RemoveTaskFromStoredFailureStats(ctx context.Context, projectID primitive.ObjectID, taskUUID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	_, _, err := r.taskFailureStats.update(func(s *models.StoredTaskFailureStats) bool { return s.ProjectID == projectID }, func(s *models.StoredTaskFailureStats) {
+		kept := s.Tasks[:0]
+		for _, task := range s.Tasks {
+			if task.TaskID == taskUUID {
+				s.Total -= task.Failures
+			} else {
+				kept = append(kept, task)
+			}
+		}
+		s.Tasks = kept
+		s.Total = max(s.Total, 0)
+	})
+	return err
+}
+
+// projectTaskIDs returns the IDs of all tasks of a project, whatever their status
+func (r *MemoryRepository) projectTaskIDs(projectID primitive.ObjectID) (map[primitive.ObjectID]struct{}, error) {
+	tasks, err := r.tasks.find(func(t *models.Task) bool { return t.ProjectID == projectID })
+	if err != nil {
+		return nil, err
+	}
+	ids := make(map[primitive.ObjectID]struct{}, len(tasks))
+	for _, task := range tasks {
+		ids[task.ID] = struct{}{}
+	}
+	return ids, nil
+}
+
+func failureStatOn(projectID primitive.ObjectID, date string) func(*models.ExecutionFailureStat) bool {
+	return func(s *models.ExecutionFailureStat) bool { return s.ProjectID == projectID && s.Date == date }
+}
+
+func storedStatsOn(projectID primitive.ObjectID, date string) func(*models.StoredTaskFailureStats) bool {
+	return func(s *models.StoredTaskFailureStats) bool { return s.ProjectID == projectID && s.Date == date }
+}
+
+// Usage metering
+
+// IncrementUsage adds to a project's usage counters of the day
+func (r *MemoryRepository) IncrementUsage(ctx context.Context, projectID primitive.ObjectID, date string, counters models.UsageCounters) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	matched, _, err := r.usage.update(func(u *models.UsageRecord) bool { return u.ProjectID == projectID && u.Date == date }, func(u *models.UsageRecord) {
+		u.UsageCounters.Add(counters)
+		u.UpdatedAt = now
+	})
+	if err != nil || matched > 0 {
+		return err
+	}
+	_, err = r.usage.insert(&models.UsageRecord{ID: primitive.NewObjectID(), ProjectID: projectID, Date: date, UsageCounters: counters, UpdatedAt: now})
+	return err
+}
+
+// GetUsage returns the usage records matching the filter, by date, then project
+func (r *MemoryRepository) GetUsage(ctx context.Context, filter models.UsageFilter) ([]*models.UsageRecord, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	records, err := r.usage.find(func(u *models.UsageRecord) bool {
+		return (filter.ProjectID == nil || u.ProjectID == *filter.ProjectID) &&
+			(filter.From == "" || u.Date >= filter.From) &&
+			(filter.To == "" || u.Date <= filter.To)
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(records, func(i, j int) bool {
+		if records[i].Date != records[j].Date {
+			return records[i].Date < records[j].Date
+		}
+		return records[i].ProjectID.Hex() < records[j].ProjectID.Hex()
+	})
+	return records, nil
+}
+
+// Transactions
+
+// RunInTransaction runs fn. The memory repository has no transactions: the writes fn made before failing stay
+// applied, which is acceptable for development and tests.
+func (r *MemoryRepository) RunInTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+// Event outbox
+
+// CreateOutboxEvent persists an event for delivery by the outbox dispatcher
+func (r *MemoryRepository) CreateOutboxEvent(ctx context.Context, event *models.OutboxEvent) error {
+	if event.ID.IsZero() {
+		event.ID = primitive.NewObjectID()
+	}
+	if event.CreatedAt.IsZero() {
+		event.CreatedAt = time.Now()
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	_, err := r.outbox.insert(event)
+	return err
+}
+
+// ClaimOutboxEvents locks up to limit undelivered events, oldest first, until now+lease
+func (r *MemoryRepository) ClaimOutboxEvents(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*models.OutboxEvent, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	claimable, err := r.outbox.find(func(e *models.OutboxEvent) bool { return !e.LockedUntil.After(now) })
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(claimable, func(a, b int) bool { return claimable[a].CreatedAt.Before(claimable[b].CreatedAt) })
+	claimable = limitSlice(claimable, limit)
+
+	ids := make(map[primitive.ObjectID]bool, len(claimable))
+	for _, event := range claimable {
+		ids[event.ID] = true
+	}
+	if _, _, err := r.outbox.update(func(e *models.OutboxEvent) bool { return ids[e.ID] }, func(e *models.OutboxEvent) {
+		e.LockedUntil = now.Add(lease)
+		e.Attempts++
+	}); err != nil {
+		return nil, err
+	}
+
+	claimed, err := r.outbox.find(func(e *models.OutboxEvent) bool { return ids[e.ID] })
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(claimed, func(a, b int) bool { return claimed[a].CreatedAt.Before(claimed[b].CreatedAt) })
+	return claimed, nil
+}
+
+// DeleteOutboxEvent removes a delivered event
+func (r *MemoryRepository) DeleteOutboxEvent(ctx context.Context, id primitive.ObjectID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	_, err := r.outbox.delete(func(e *models.OutboxEvent) bool { return e.ID == id })
+	return err
+}
+
+// In-process job queue
+
+// CreateQueuedJob persists a job for the in-process job queue
+func (r *MemoryRepository) CreateQueuedJob(ctx context.Context, job *models.QueuedJob) error {
+	if job.ID.IsZero() {
+		job.ID = primitive.NewObjectID()
+	}
+	if job.CreatedAt.IsZero() {
+		job.CreatedAt = time.Now()
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	_, err := r.jobQueue.insert(job)
+	return err
+}
+
+// ClaimQueuedJobs locks up to limit unacknowledged jobs, oldest first, until now+lease
+func (r *MemoryRepository) ClaimQueuedJobs(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*models.QueuedJob, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	claimable, err := r.jobQueue.find(func(j *models.QueuedJob) bool { return !j.LockedUntil.After(now) })
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(claimable, func(a, b int) bool { return claimable[a].CreatedAt.Before(claimable[b].CreatedAt) })
+	claimable = limitSlice(claimable, limit)
+
+	ids := make(map[primitive.ObjectID]bool, len(claimable))
+	for _, job := range claimable {
+		ids[job.ID] = true
+	}
+	if _, _, err := r.jobQueue.update(func(j *models.QueuedJob) bool { return ids[j.ID] }, func(j *models.QueuedJob) {
+		j.LockedUntil = now.Add(lease)
+		j.Attempts++
+	}); err != nil {
+		return nil, err
+	}
+
+	claimed, err := r.jobQueue.find(func(j *models.QueuedJob) bool { return ids[j.ID] })
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(claimed, func(a, b int) bool { return claimed[a].CreatedAt.Before(claimed[b].CreatedAt) })
+	return claimed, nil
+}
+
+// GetTaskReferences returns the project and task group of every task, of any status
+func (r *MemoryRepository) GetTaskReferences(ctx context.Context) ([]*models.TaskReference, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	tasks, err := r.tasks.find(func(*models.Task) bool { return true })
+	if err != nil {
+		return nil, err
+	}
+	references := make([]*models.TaskReference, 0, len(tasks))
+	for _, task := range tasks {
+		references = append(references, &models.TaskReference{UUID: task.UUID, ProjectID: task.ProjectID, TaskGroupID: task.TaskGroupID})
+	}
+	return references, nil
+}
+
+// GetExecutionTaskUUIDs returns the distinct task UUIDs of all executions
+func (r *MemoryRepository) GetExecutionTaskUUIDs(ctx context.Context) ([]string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	executions, err := r.executions.find(func(*models.Execution) bool { return true })
+	if err != nil {
+		return nil, err
+	}
+	seen := make(map[string]bool)
+	taskUUIDs := []string{}
+	for _, execution := range executions {
+		if !seen[execution.TaskUUID] {
+			seen[execution.TaskUUID] = true
+			taskUUIDs = append(taskUUIDs, execution.TaskUUID)
+		}
+	}
+	return taskUUIDs, nil
+}
+
+// GetStatsProjectIDs returns the distinct projects of the daily failure counters and stored task failure stats
+func (r *MemoryRepository) GetStatsProjectIDs(ctx context.Context) ([]primitive.ObjectID, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	seen := make(map[primitive.ObjectID]bool)
+	projectIDs := []primitive.ObjectID{}
+	add := func(projectID primitive.ObjectID) {
+		if !seen[projectID] {
+			seen[projectID] = true
+			projectIDs = append(projectIDs, projectID)
+		}
+	}
+
+	counters, err := r.executionFailureStat.find(func(*models.ExecutionFailureStat) bool { return true })
+	if err != nil {
+		return nil, err
+	}
+	for _, counter := range counters {
+		add(counter.ProjectID)
+	}
+	stored, err := r.taskFailureStats.find(func(*models.StoredTaskFailureStats) bool { return true })
+	if err != nil {
+		return nil, err
+	}
+	for _, stats := range stored {
+		add(stats.ProjectID)
+	}
+	return projectIDs, nil
+}
+
+// DeleteQueuedJob removes a processed job
+func (r *MemoryRepository) DeleteQueuedJob(ctx context.Context, id primitive.ObjectID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	_, err := r.jobQueue.delete(func(j *models.QueuedJob) bool { return j.ID == id })
+	return err
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func containsStatus(statuses []models.ExecutionStatus, status models.ExecutionStatus) bool {
+	for _, s := range statuses {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
+
+func compareObjectIDs(a, b primitive.ObjectID) int {
+	return bytes.Compare(a[:], b[:])
+}
+
+// limitSlice returns the first limit items; a limit of 0 returns all, as in MongoDB
+func limitSlice[T any](items []T, limit int) []T {
+	if limit > 0 && len(items) > limit {
+		return items[:limit]
+	}
+	return items
+}
+
+// pageSlice returns the given 1-based page of items
+func pageSlice[T any](items []T, page, pageSize int) []T {
+	skip := max(page-1, 0) * pageSize
+	if skip >= len(items) {
+		return nil
+	}
+	return limitSlice(items[skip:], pageSize)
+}
+
+// Incidents
+
+// RecordIncidentFailure adds a failed execution to the task's active incident, or opens an incident when the task
+// has none. The returned incident has FailureCount 1 when it was opened by this failure.
+func (r *MemoryRepository) RecordIncidentFailure(ctx context.Context, failure models.IncidentFailure) (*models.Incident, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	active := activeIncidentOfTask(failure.TaskUUID)
+	matched, _, err := r.incidents.update(active, func(i *models.Incident) {
+		i.FailureCount++
+		i.LastExecutionUUID = failure.ExecutionUUID
+		i.LastError = failure.Error
+		i.LastFailureAt = failure.FailedAt
+		i.UpdatedAt = failure.FailedAt
+	})
+	if err != nil {
+		return nil, err
+	}
+	if matched == 0 {
+		incident := &models.Incident{
+			UUID:               failure.IncidentUUID,
+			ProjectID:          failure.ProjectID,
+			TaskUUID:           failure.TaskUUID,
+			TaskName:           failure.TaskName,
+			Severity:           failure.Severity,
+			Status:             models.IncidentStatusOpen,
+			FailureCount:       1,
+			FirstExecutionUUID: failure.ExecutionUUID,
+			LastExecutionUUID:  failure.ExecutionUUID,
+			LastError:          failure.Error,
+			OpenedAt:           failure.FailedAt,
+			LastFailureAt:      failure.FailedAt,
+			CreatedAt:          failure.FailedAt,
+			UpdatedAt:          failure.FailedAt,
+		}
+		if _, err := r.incidents.insert(incident); err != nil {
+			return nil, err
+		}
+	}
+	return r.incidents.findOne(active)
+}
+
+// ResolveActiveIncident resolves the task's active incident after a successful execution. Returns
+// mongo.ErrNoDocuments when the task has no active incident.
+func (r *MemoryRepository) ResolveActiveIncident(ctx context.Context, taskUUID string, executionUUID string, resolvedAt time.Time) (*models.Incident, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.updateIncident(activeIncidentOfTask(taskUUID), func(i *models.Incident) {
+		i.Status = models.IncidentStatusResolved
+		i.ResolvedAt = &resolvedAt
+		i.ResolvedExecutionUUID = executionUUID
+		i.UpdatedAt = resolvedAt
+	})
+}
+
+// GetIncidentByUUID returns an incident of a project. Returns mongo.ErrNoDocuments if not found.
+func (r *MemoryRepository) GetIncidentByUUID(ctx context.Context, projectID primitive.ObjectID, incidentUUID string) (*models.Incident, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.incidents.findOne(incidentOfProject(projectID, incidentUUID))
+}
+
+// ListIncidents returns a page of a project's incidents, most recently opened first, and the total count
+func (r *MemoryRepository) ListIncidents(ctx context.Context, projectID primitive.ObjectID, filter models.IncidentListFilter, page, pageSize int) ([]*models.Incident, int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	incidents, err := r.incidents.find(func(i *models.Incident) bool {
+		return i.ProjectID == projectID &&
+			(filter.Status == "" || i.Status == filter.Status) &&
+			(filter.TaskUUID == "" || i.TaskUUID == filter.TaskUUID)
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	sort.SliceStable(incidents, func(a, b int) bool { return incidents[a].OpenedAt.After(incidents[b].OpenedAt) })
+
+	totalCount := int64(len(incidents))
+	return pageSlice(incidents, page, pageSize), totalCount, nil
+}
+
+// AcknowledgeIncident marks an open incident as being looked into. Returns mongo.ErrNoDocuments when the incident
+// does not exist or is not OPEN.
+func (r *MemoryRepository) AcknowledgeIncident(ctx context.Context, projectID primitive.ObjectID, incidentUUID string, acknowledgedBy string, acknowledgedAt time.Time) (*models.Incident, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	inProject := incidentOfProject(projectID, incidentUUID)
+	return r.updateIncident(func(i *models.Incident) bool { return inProject(i) && i.Status == models.IncidentStatusOpen }, func(i *models.Incident) {
+		i.Status = models.IncidentStatusAcknowledged
+		i.AcknowledgedAt = &acknowledgedAt
+		i.AcknowledgedBy = acknowledgedBy
+		i.UpdatedAt = acknowledgedAt
+	})
+}
+
+// ResolveIncident resolves an incident by hand. Returns mongo.ErrNoDocuments when the incident does not exist or
+// is already resolved.
+func (r *MemoryRepository) ResolveIncident(ctx context.Context, projectID primitive.ObjectID, incidentUUID string, resolvedBy string, resolvedAt time.Time) (*models.Incident, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	inProject := incidentOfProject(projectID, incidentUUID)
+	return r.updateIncident(func(i *models.Incident) bool { return inProject(i) && i.Status != models.IncidentStatusResolved }, func(i *models.Incident) {
+		i.Status = models.IncidentStatusResolved
+		i.ResolvedAt = &resolvedAt
+		i.ResolvedBy = resolvedBy
+		i.UpdatedAt = resolvedAt
+	})
+}
+
+// updateIncident applies apply to the incident matching filter and returns the updated incident. Callers hold r.mu.
+func (r *MemoryRepository) updateIncident(filter func(*models.Incident) bool, apply func(*models.Incident)) (*models.Incident, error) {
+	incident, err := r.incidents.findOne(filter)
+	if err != nil {
+		return nil, err
+	}
+	byID := func(i *models.Incident) bool { return i.ID == incident.ID }
+	if _, _, err := r.incidents.update(byID, apply); err != nil {
+		return nil, err
+	}
+	return r.incidents.findOne(byID)
+}
+
+// AddIncidentComment appends a comment to an incident. Returns mongo.ErrNoDocuments if not found.
+func (r *MemoryRepository) AddIncidentComment(ctx context.Context, projectID primitive.ObjectID, incidentUUID string, comment models.IncidentComment) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	matched, _, err := r.incidents.update(incidentOfProject(projectID, incidentUUID), func(i *models.Incident) {
+		i.Comments = append(i.Comments, comment)
+		i.UpdatedAt = comment.CreatedAt
+	})
+	if err != nil {
+		return err
+	}
+	if matched == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+// DeleteIncidentsByProjectID removes all incidents of a project
+func (r *MemoryRepository) DeleteIncidentsByProjectID(ctx context.Context, projectID primitive.ObjectID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	_, err := r.incidents.delete(func(i *models.Incident) bool { return i.ProjectID == projectID })
+	return err
+}
+
+func activeIncidentOfTask(taskUUID string) func(*models.Incident) bool {
+	return func(i *models.Incident) bool {
+		return i.TaskUUID == taskUUID && i.Status != models.IncidentStatusResolved
+	}
+}
+
+func incidentOfProject(projectID primitive.ObjectID, incidentUUID string) func(*models.Incident) bool {
+	return func(i *models.Incident) bool { return i.ProjectID == projectID && i.UUID == incidentUUID }
+}
@@ -0,0 +1,256 @@
+package repositories
+
+import (
+	"bytes"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// memoryCollection holds the documents of one collection in insertion order. Documents are stored as BSON, so
+// callers never share memory with the store and omitempty fields and time precision behave as in MongoDB.
+type memoryCollection[T any] struct {
+	name string
+	docs []bson.Raw
+
+	// conflicts reports whether two documents violate a unique index of the collection; nil when it has none
+	conflicts func(a, b *T) bool
+}
+
+func newMemoryCollection[T any](name string, conflicts func(a, b *T) bool) *memoryCollection[T] {
+	return &memoryCollection[T]{name: name, conflicts: conflicts}
+}
+
+// insert stores a copy of doc, assigning an _id when it has none, and returns the _id
+func (c *memoryCollection[T]) insert(doc *T) (primitive.ObjectID, error) {
+	raw, err := bson.Marshal(doc)
+	if err != nil {
+		return primitive.NilObjectID, err
+	}
+	id, ok := bson.Raw(raw).Lookup("_id").ObjectIDOK()
+	if !ok {
+		id = primitive.NewObjectID()
+		if raw, err = withID(raw, id); err != nil {
+			return primitive.NilObjectID, err
+		}
+	}
+
+	if err := c.checkUnique(c.docs, -1, raw); err != nil {
+		return primitive.NilObjectID, err
+	}
+	c.docs = append(c.docs, raw)
+	return id, nil
+}
+
+// find returns copies of the documents matching filter; a nil filter matches all
+func (c *memoryCollection[T]) find(filter func(*T) bool) ([]*T, error) {
+	var found []*T
+	for _, raw := range c.docs {
+		doc, err := decodeDocument[T](raw)
+		if err != nil {
+			return nil, err
+		}
+		if filter == nil || filter(doc) {
+			found = append(found, doc)
+		}
+	}
+	return found, nil
+}
+
+// findOne returns a copy of the first document matching filter, or mongo.ErrNoDocuments
+func (c *memoryCollection[T]) findOne(filter func(*T) bool) (*T, error) {
+	for _, raw := range c.docs {
+		doc, err := decodeDocument[T](raw)
+		if err != nil {
+			return nil, err
+		}
+		if filter(doc) {
+			return doc, nil
+		}
+	}
+	return nil, mongo.ErrNoDocuments
+}
+
+// update applies apply to every document matching filter, like UpdateMany
+func (c *memoryCollection[T]) update(filter func(*T) bool, apply func(*T)) (matched, modified int64, err error) {
+	return c.modify(filter, func(raw bson.Raw) (bson.Raw, error) {
+		doc, err := decodeDocument[T](raw)
+		if err != nil {
+			return nil, err
+		}
+		apply(doc)
+
+		updated, err := bson.Marshal(doc)
+		if err != nil {
+			return nil, err
+		}
+		// Documents without an _id field in their model keep the one assigned on insert
+		if _, err := bson.Raw(updated).LookupErr("_id"); err != nil {
+			return withID(updated, raw.Lookup("_id"))
+		}
+		return updated, nil
+	})
+}
+
+// set merges the fields doc encodes into every document matching filter and removes the unset fields, like
+// UpdateMany with {$set: doc, $unset: unset}. Zero omitempty fields of doc keep their stored values.
+func (c *memoryCollection[T]) set(filter func(*T) bool, doc interface{}, unset ...string) (matched, modified int64, err error) {
+	fields, err := bson.Marshal(doc)
+	if err != nil {
+		return 0, 0, err
+	}
+	elements, err := bson.Raw(fields).Elements()
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return c.modify(filter, func(raw bson.Raw) (bson.Raw, error) {
+		var stored bson.D
+		if err := bson.Unmarshal(raw, &stored); err != nil {
+			return nil, err
+		}
+		for _, element := range elements {
+			stored = setElement(stored, element.Key(), element.Value())
+		}
+		for _, key := range unset {
+			stored = removeElement(stored, key)
+		}
+		return bson.Marshal(stored)
+	})
+}
+
+// modify replaces every document matching filter with the result of change. Either all changes are applied or,
+// when one fails or breaks a unique index, none.
+func (c *memoryCollection[T]) modify(filter func(*T) bool, change func(bson.Raw) (bson.Raw, error)) (matched, modified int64, err error) {
+	docs := make([]bson.Raw, len(c.docs))
+	copy(docs, c.docs)
+
+	var changed []int
+	for i, raw := range docs {
+		doc, err := decodeDocument[T](raw)
+		if err != nil {
+			return 0, 0, err
+		}
+		if !filter(doc) {
+			continue
+		}
+		matched++
+
+		updated, err := change(raw)
+		if err != nil {
+			return 0, 0, err
+		}
+		if bytes.Equal(updated, raw) {
+			continue
+		}
+		docs[i] = updated
+		changed = append(changed, i)
+	}
+
+	for _, i := range changed {
+		if err := c.checkUnique(docs, i, docs[i]); err != nil {
+			return 0, 0, err
+		}
+	}
+	c.docs = docs
+	return matched, int64(len(changed)), nil
+}
+
+// delete removes the documents matching filter and returns how many were removed
+func (c *memoryCollection[T]) delete(filter func(*T) bool) (int64, error) {
+	kept := make([]bson.Raw, 0, len(c.docs))
+	for _, raw := range c.docs {
+		doc, err := decodeDocument[T](raw)
+		if err != nil {
+			return 0, err
+		}
+		if !filter(doc) {
+			kept = append(kept, raw)
+		}
+	}
+
+	deleted := int64(len(c.docs) - len(kept))
+	c.docs = kept
+	return deleted, nil
+}
+
+// checkUnique reports a duplicate key error when raw shares its _id or a unique index key with a document of
+// docs other than the one at skip
+func (c *memoryCollection[T]) checkUnique(docs []bson.Raw, skip int, raw bson.Raw) error {
+	id := raw.Lookup("_id")
+	var doc *T
+	if c.conflicts != nil {
+		decoded, err := decodeDocument[T](raw)
+		if err != nil {
+			return err
+		}
+		doc = decoded
+	}
+
+	for i, other := range docs {
+		if i == skip {
+			continue
+		}
+		if other.Lookup("_id").Equal(id) {
+			return duplicateKeyError(c.name, "_id_")
+		}
+		if c.conflicts == nil {
+			continue
+		}
+		otherDoc, err := decodeDocument[T](other)
+		if err != nil {
+			return err
+		}
+		if c.conflicts(doc, otherDoc) {
+			return duplicateKeyError(c.name, "unique index")
+		}
+	}
+	return nil
+}
+
+// duplicateKeyError mirrors the error MongoDB returns for unique index violations, so mongo.IsDuplicateKeyError
+// recognizes it
+func duplicateKeyError(collection, index string) error {
+	return mongo.WriteException{WriteErrors: mongo.WriteErrors{{
+		Code:    11000,
+		Message: fmt.Sprintf("E11000 duplicate key error collection: %s index: %s", collection, index),
+	}}}
+}
+
+func decodeDocument[T any](raw bson.Raw) (*T, error) {
+	var doc T
+	if err := bson.Unmarshal(raw, &doc); err != nil {
+		return nil, err
+	}
+	return &doc, nil
+}
+
+// withID prepends an _id field to a document
+func withID(raw bson.Raw, id interface{}) (bson.Raw, error) {
+	var doc bson.D
+	if err := bson.Unmarshal(raw, &doc); err != nil {
+		return nil, err
+	}
+	return bson.Marshal(append(bson.D{{Key: "_id", Value: id}}, doc...))
+}
+
+func setElement(doc bson.D, key string, value interface{}) bson.D {
+	for i := range doc {
+		if doc[i].Key == key {
+			doc[i].Value = value
+			return doc
+		}
+	}
+	return append(doc, bson.E{Key: key, Value: value})
+}
+
+func removeElement(doc bson.D, key string) bson.D {
+	for i := range doc {
+		if doc[i].Key == key {
+			return append(doc[:i], doc[i+1:]...)
+		}
+	}
+	return doc
+}
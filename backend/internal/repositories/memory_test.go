@@ -0,0 +1,341 @@
+package repositories
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/yourusername/cron-observer/backend/internal/models"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func newTestTask(projectID primitive.ObjectID, uuid, name string) *models.Task {
+	now := time.Now()
+	return &models.Task{
+		ID:           primitive.NewObjectID(),
+		UUID:         uuid,
+		ProjectID:    projectID,
+		Name:         name,
+		ScheduleType: models.ScheduleTypeRecurring,
+		Status:       models.TaskStatusActive,
+		State:        models.TaskStateNotRunning,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}
+}
+
+func TestMemoryRepository_GetReturnsCopies(t *testing.T) {
+	ctx := context.Background()
+	repo := NewMemoryRepository()
+	task := newTestTask(primitive.NewObjectID(), "task-1", "Backup")
+	if err := repo.CreateTask(ctx, task.ProjectID.Hex(), task); err != nil {
+		t.Fatalf("CreateTask: %v", err)
+	}
+
+	task.Name = "changed after create"
+	found, err := repo.GetTaskByUUID(ctx, "task-1")
+	if err != nil {
+		t.Fatalf("GetTaskByUUID: %v", err)
+	}
+	found.Name = "changed after get"
+
+	again, _ := repo.GetTaskByUUID(ctx, "task-1")
+	if again.Name != "Backup" {
+		t.Fatalf("stored task was modified through a caller's pointer: name=%q", again.Name)
+	}
+}
+
+func TestMemoryRepository_MissingDocumentsReturnErrNoDocuments(t *testing.T) {
+	ctx := context.Background()
+	repo := NewMemoryRepository()
+
+	if _, err := repo.GetTaskByUUID(ctx, "missing"); err != mongo.ErrNoDocuments {
+		t.Errorf("GetTaskByUUID: got %v, want mongo.ErrNoDocuments", err)
+	}
+	if err := repo.DeleteTask(ctx, "missing"); err != mongo.ErrNoDocuments {
+		t.Errorf("DeleteTask: got %v, want mongo.ErrNoDocuments", err)
+	}
+	if err := repo.UpdateProjectStatus(ctx, primitive.NewObjectID(), models.ProjectStatusArchived); err != mongo.ErrNoDocuments {
+		t.Errorf("UpdateProjectStatus: got %v, want mongo.ErrNoDocuments", err)
+	}
+	if settings, err := repo.GetProjectSettings(ctx, primitive.NewObjectID()); settings != nil || err != nil {
+		t.Errorf("GetProjectSettings: got %v, %v, want nil, nil", settings, err)
+	}
+}
+
+func TestMemoryRepository_ProjectNamesAreUniqueIgnoringCase(t *testing.T) {
+	ctx := context.Background()
+	repo := NewMemoryRepository()
+
+	first := &models.Project{ID: primitive.NewObjectID(), UUID: "p-1", Name: "Billing", APIKey: "key-1"}
+	if err := repo.CreateProject(ctx, first); err != nil {
+		t.Fatalf("CreateProject: %v", err)
+	}
+	second := &models.Project{ID: primitive.NewObjectID(), UUID: "p-2", Name: "billing", APIKey: "key-2"}
+	if err := repo.CreateProject(ctx, second); !mongo.IsDuplicateKeyError(err) {
+		t.Fatalf("CreateProject with a taken name: got %v, want a duplicate key error", err)
+	}
+
+	found, err := repo.GetProjectByName(ctx, "BILLING")
+	if err != nil || found.UUID != "p-1" {
+		t.Fatalf("GetProjectByName: got %v, %v", found, err)
+	}
+}
+
+func TestMemoryRepository_GetUserProjectsIncludesAdministeredOrganizations(t *testing.T) {
+	ctx := context.Background()
+	repo := NewMemoryRepository()
+
+	organization := &models.Organization{
+		UUID: "o-1",
+		Name: "Payments",
+		Members: []models.OrganizationMember{
+			{Email: "lead@example.com", Role: models.OrganizationRoleAdmin},
+			{Email: "dev@example.com", Role: models.OrganizationRoleMember},
+		},
+	}
+	if err := repo.CreateOrganization(ctx, organization); err != nil {
+		t.Fatalf("CreateOrganization: %v", err)
+	}
+	child := &models.Project{ID: primitive.NewObjectID(), UUID: "p-1", Name: "Billing", APIKey: "key-1", OrganizationID: &organization.ID}
+	other := &models.Project{ID: primitive.NewObjectID(), UUID: "p-2", Name: "Search", APIKey: "key-2"}
+	for _, project := range []*models.Project{child, other} {
+		if err := repo.CreateProject(ctx, project); err != nil {
+			t.Fatalf("CreateProject: %v", err)
+		}
+	}
+
+	projects, err := repo.GetUserProjects(ctx, "lead@example.com")
+	if err != nil || len(projects) != 1 || projects[0].UUID != "p-1" {
+		t.Fatalf("GetUserProjects for the admin: got %v, %v, want the organization's project", projects, err)
+	}
+	if projects, _ := repo.GetUserProjects(ctx, "dev@example.com"); len(projects) != 0 {
+		t.Errorf("GetUserProjects for a member: got %d projects, want none", len(projects))
+	}
+
+	if err := repo.SetProjectOrganization(ctx, child.ID, nil); err != nil {
+		t.Fatalf("SetProjectOrganization: %v", err)
+	}
+	if projects, _ := repo.GetProjectsByOrganizationID(ctx, organization.ID); len(projects) != 0 {
+		t.Errorf("GetProjectsByOrganizationID after removal: got %d projects, want none", len(projects))
+	}
+}
+
+func TestMemoryRepository_UpdateTaskClearsOmittedOptionalFields(t *testing.T) {
+	ctx := context.Background()
+	repo := NewMemoryRepository()
+	task := newTestTask(primitive.NewObjectID(), "task-1", "Backup")
+	groupID := primitive.NewObjectID()
+	task.TaskGroupID = &groupID
+	task.Description = "nightly backup"
+	task.Tags = []string{"team:ops"}
+	if err := repo.CreateTask(ctx, task.ProjectID.Hex(), task); err != nil {
+		t.Fatalf("CreateTask: %v", err)
+	}
+
+	update := newTestTask(task.ProjectID, "task-1", "Backup v2")
+	update.ID = task.ID
+	if err := repo.UpdateTask(ctx, "task-1", update); err != nil {
+		t.Fatalf("UpdateTask: %v", err)
+	}
+
+	updated, _ := repo.GetTaskByUUID(ctx, "task-1")
+	if updated.Name != "Backup v2" {
+		t.Errorf("name = %q, want Backup v2", updated.Name)
+	}
+	if updated.TaskGroupID != nil || len(updated.Tags) != 0 {
+		t.Errorf("group and tags were not cleared: group=%v tags=%v", updated.TaskGroupID, updated.Tags)
+	}
+	// $set of a struct skips empty omitempty fields, so MongoDB keeps the description
+	if updated.Description != "nightly backup" {
+		t.Errorf("description = %q, want the stored value", updated.Description)
+	}
+}
+
+func TestMemoryRepository_ListTasksByProjectID(t *testing.T) {
+	ctx := context.Background()
+	repo := NewMemoryRepository()
+	projectID := primitive.NewObjectID()
+
+	for _, name := range []string{"charlie", "alpha", "bravo", "delta"} {
+		task := newTestTask(projectID, "uuid-"+name, name)
+		task.Tags = []string{"team:ops"}
+		if name == "delta" {
+			task.Status = models.TaskStatusPendingDelete
+		}
+		if err := repo.CreateTask(ctx, projectID.Hex(), task); err != nil {
+			t.Fatalf("CreateTask: %v", err)
+		}
+	}
+	other := newTestTask(primitive.NewObjectID(), "uuid-other", "alpha")
+	if err := repo.CreateTask(ctx, other.ProjectID.Hex(), other); err != nil {
+		t.Fatalf("CreateTask: %v", err)
+	}
+
+	filter := models.TaskListFilter{Tags: []string{"team:ops"}, SortBy: models.TaskSortByName}
+	tasks, total, err := repo.ListTasksByProjectID(ctx, projectID, filter, 1, 2)
+	if err != nil {
+		t.Fatalf("ListTasksByProjectID: %v", err)
+	}
+	if total != 3 {
+		t.Fatalf("total = %d, want 3 (tasks being deleted are hidden)", total)
+	}
+	if len(tasks) != 2 || tasks[0].Name != "alpha" || tasks[1].Name != "bravo" {
+		t.Fatalf("first page = %v, want alpha, bravo", taskNames(tasks))
+	}
+
+	tasks, _, _ = repo.ListTasksByProjectID(ctx, projectID, filter, 2, 2)
+	if len(tasks) != 1 || tasks[0].Name != "charlie" {
+		t.Fatalf("second page = %v, want charlie", taskNames(tasks))
+	}
+}
+
+func TestMemoryRepository_ListTasksByProjectIDSearch(t *testing.T) {
+	ctx := context.Background()
+	repo := NewMemoryRepository()
+	projectID := primitive.NewObjectID()
+
+	backup := newTestTask(projectID, "uuid-backup", "Nightly Backup")
+	report := newTestTask(projectID, "uuid-report", "report")
+	report.Description = "Sends the weekly SALES summary"
+	sync := newTestTask(projectID, "uuid-sync", "sync")
+	sync.Metadata = map[string]interface{}{"owner": "ops", "Runbook_URL": "https://example.com"}
+	for _, task := range []*models.Task{backup, report, sync} {
+		if err := repo.CreateTask(ctx, projectID.Hex(), task); err != nil {
+			t.Fatalf("CreateTask: %v", err)
+		}
+	}
+
+	tests := []struct {
+		name   string
+		search string
+		want   []string
+	}{
+		{name: "name", search: "backup", want: []string{"Nightly Backup"}},
+		{name: "description", search: "sales", want: []string{"report"}},
+		{name: "metadata key", search: "runbook", want: []string{"sync"}},
+		{name: "metadata value is not searched", search: "ops", want: nil},
+		{name: "no match", search: "invoice", want: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tasks, total, err := repo.ListTasksByProjectID(ctx, projectID, models.TaskListFilter{Search: tt.search}, 1, 10)
+			if err != nil {
+				t.Fatalf("ListTasksByProjectID: %v", err)
+			}
+			if names := taskNames(tasks); total != int64(len(tt.want)) || strings.Join(names, ",") != strings.Join(tt.want, ",") {
+				t.Fatalf("search %q = %v (total %d), want %v", tt.search, names, total, tt.want)
+			}
+		})
+	}
+}
+
+func TestMemoryRepository_ListTasksByProjectIDBySeverity(t *testing.T) {
+	ctx := context.Background()
+	repo := NewMemoryRepository()
+	projectID := primitive.NewObjectID()
+
+	severities := map[string]models.TaskSeverity{
+		"backup":   models.TaskSeverityCritical,
+		"cleanup":  models.TaskSeverityLow,
+		"legacy":   "", // created before severities existed
+		"invoices": models.TaskSeverityHigh,
+		"reports":  models.TaskSeverityNormal,
+	}
+	for name, severity := range severities {
+		task := newTestTask(projectID, "uuid-"+name, name)
+		task.Severity = severity
+		if err := repo.CreateTask(ctx, projectID.Hex(), task); err != nil {
+			t.Fatalf("CreateTask: %v", err)
+		}
+	}
+
+	tasks, _, err := repo.ListTasksByProjectID(ctx, projectID, models.TaskListFilter{Severity: models.TaskSeverityNormal, SortBy: models.TaskSortByName}, 1, 0)
+	if err != nil {
+		t.Fatalf("ListTasksByProjectID: %v", err)
+	}
+	if names := taskNames(tasks); len(names) != 2 || names[0] != "legacy" || names[1] != "reports" {
+		t.Errorf("NORMAL tasks = %v, want legacy, reports", names)
+	}
+
+	tasks, _, _ = repo.ListTasksByProjectID(ctx, projectID, models.TaskListFilter{SortBy: models.TaskSortBySeverity, SortDesc: true}, 1, 0)
+	if names := taskNames(tasks); len(names) != 5 || names[0] != "backup" || names[1] != "invoices" || names[4] != "cleanup" {
+		t.Errorf("tasks by severity = %v, want backup, invoices, ..., cleanup", names)
+	}
+}
+
+func TestMemoryRepository_UpdateTaskGroupWithTasks(t *testing.T) {
+	ctx := context.Background()
+	repo := NewMemoryRepository()
+	projectID := primitive.NewObjectID()
+	group := &models.TaskGroup{ID: primitive.NewObjectID(), UUID: "group-1", ProjectID: projectID, Name: "nightly", Status: models.TaskGroupStatusActive}
+	if err := repo.CreateTaskGroup(ctx, projectID.Hex(), group); err != nil {
+		t.Fatalf("CreateTaskGroup: %v", err)
+	}
+
+	for _, uuid := range []string{"active", "deleting"} {
+		task := newTestTask(projectID, uuid, uuid)
+		task.TaskGroupID = &group.ID
+		if uuid == "deleting" {
+			task.Status = models.TaskStatusPendingDelete
+		}
+		if err := repo.CreateTask(ctx, projectID.Hex(), task); err != nil {
+			t.Fatalf("CreateTask: %v", err)
+		}
+	}
+
+	group.Status = models.TaskGroupStatusDisabled
+	result, err := repo.UpdateTaskGroupWithTasks(ctx, group, models.TaskGroupCascade{TaskStatus: models.TaskStatusDisabled})
+	if err != nil {
+		t.Fatalf("UpdateTaskGroupWithTasks: %v", err)
+	}
+	if result.StatusUpdated != 1 {
+		t.Errorf("StatusUpdated = %d, want 1", result.StatusUpdated)
+	}
+
+	if stored, _ := repo.GetTaskGroupByUUID(ctx, "group-1"); stored.Status != models.TaskGroupStatusDisabled {
+		t.Errorf("group status = %s, want DISABLED", stored.Status)
+	}
+	if task, _ := repo.GetTaskByUUID(ctx, "active"); task.Status != models.TaskStatusDisabled {
+		t.Errorf("task status = %s, want DISABLED", task.Status)
+	}
+	if task, _ := repo.GetTaskByUUID(ctx, "deleting"); task.Status != models.TaskStatusPendingDelete {
+		t.Errorf("task being deleted got status %s", task.Status)
+	}
+}
+
+func TestMemoryRepository_ClaimOutboxEvents(t *testing.T) {
+	ctx := context.Background()
+	repo := NewMemoryRepository()
+	now := time.Now()
+
+	for i := 0; i < 3; i++ {
+		event := &models.OutboxEvent{Type: "TaskCreated", CreatedAt: now.Add(time.Duration(i) * time.Second)}
+		if err := repo.CreateOutboxEvent(ctx, event); err != nil {
+			t.Fatalf("CreateOutboxEvent: %v", err)
+		}
+	}
+
+	claimed, err := repo.ClaimOutboxEvents(ctx, now, time.Minute, 2)
+	if err != nil {
+		t.Fatalf("ClaimOutboxEvents: %v", err)
+	}
+	if len(claimed) != 2 || claimed[0].Attempts != 1 || !claimed[0].CreatedAt.Before(claimed[1].CreatedAt) {
+		t.Fatalf("claimed %+v, want the two oldest events with one attempt each", claimed)
+	}
+
+	claimed, _ = repo.ClaimOutboxEvents(ctx, now, time.Minute, 10)
+	if len(claimed) != 1 {
+		t.Fatalf("claimed %d events while the others are locked, want 1", len(claimed))
+	}
+}
+
+func taskNames(tasks []*models.Task) []string {
+	names := make([]string, len(tasks))
+	for i, task := range tasks {
+		names[i] = task.Name
+	}
+	return names
+}
@@ -2,6 +2,11 @@ package repositories
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/yourusername/cron-observer/backend/internal/database"
@@ -18,7 +23,9 @@ type MongoRepository struct {
 
 func (r *MongoRepository) GetAllProjects(ctx context.Context) ([]*models.Project, error) {
 	collection := r.db.Collection(database.CollectionProjects)
-	cursor, err := collection.Find(ctx, bson.M{})
+
+	// Projects being deleted are no longer visible to clients
+	cursor, err := collection.Find(ctx, bson.M{"status": bson.M{"$ne": models.ProjectStatusPendingDelete}})
 	if err != nil {
 		return nil, err
 	}
@@ -40,91 +47,894 @@ func (r *MongoRepository) GetProjectByID(ctx context.Context, projectID primitiv
 	if err != nil {
 		return nil, err
 	}
-	return &project, nil
+	return &project, nil
+}
+
+// GetProjectByName returns a project by name (case-insensitive). Returns mongo.ErrNoDocuments if not found.
+func (r *MongoRepository) GetProjectByName(ctx context.Context, name string) (*models.Project, error) {
+	collection := r.db.Collection(database.CollectionProjects)
+	opts := options.FindOne().SetCollation(&options.Collation{Locale: "en", Strength: 2})
+	var project models.Project
+	err := collection.FindOne(ctx, bson.M{"name": name}, opts).Decode(&project)
+	if err != nil {
+		return nil, err
+	}
+	return &project, nil
+}
+
+func (r *MongoRepository) GetUserProjects(ctx context.Context, email string) ([]*models.Project, error) {
+	collection := r.db.Collection(database.CollectionProjects)
+
+	// Admins of an organization see all of its projects
+	organizationIDs, err := r.administeredOrganizationIDs(ctx, email)
+	if err != nil {
+		return nil, err
+	}
+
+	// Find projects where the user's email exists in the project_users array
+	filter := bson.M{
+		"$or": bson.A{
+			bson.M{"project_users.email": email},
+			bson.M{"organization_id": bson.M{"$in": organizationIDs}},
+		},
+		"status": bson.M{"$ne": models.ProjectStatusPendingDelete},
+	}
+
+	cursor, err := collection.Find(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var projects []*models.Project
+	err = cursor.All(ctx, &projects)
+	if err != nil {
+		return nil, err
+	}
+
+	// Ensure project_users is always initialized (not nil) for JSON serialization
+	for _, project := range projects {
+		if project.ProjectUsers == nil {
+			project.ProjectUsers = []models.ProjectUser{}
+		}
+	}
+
+	return projects, nil
+}
+
+func (r *MongoRepository) CreateProject(ctx context.Context, project *models.Project) error {
+	collection := r.db.Collection(database.CollectionProjects)
+	_, err := collection.InsertOne(ctx, project)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *MongoRepository) UpdateProject(ctx context.Context, projectID primitive.ObjectID, project *models.Project) error {
+	collection := r.db.Collection(database.CollectionProjects)
+
+	update := bson.M{
+		"$set": bson.M{
+			"name":               project.Name,
+			"description":        project.Description,
+			"execution_endpoint": project.ExecutionEndpoint,
+			"alert_emails":       project.AlertEmails,
+			"updated_at":         project.UpdatedAt,
+		},
+	}
+
+	// Optional fields are removed rather than stored empty
+	unset := bson.M{}
+
+	// An empty allowlist means any network may use the primary API key
+	if len(project.APIKeyAllowedCIDRs) > 0 {
+		update["$set"].(bson.M)["api_key_allowed_cidrs"] = project.APIKeyAllowedCIDRs
+	} else {
+		unset["api_key_allowed_cidrs"] = ""
+	}
+
+	if project.FailoverEndpoint != "" {
+		update["$set"].(bson.M)["failover_endpoint"] = project.FailoverEndpoint
+	} else {
+		unset["failover_endpoint"] = ""
+	}
+
+	if len(project.ExecutionHeaders) > 0 {
+		update["$set"].(bson.M)["execution_headers"] = project.ExecutionHeaders
+	} else {
+		unset["execution_headers"] = ""
+	}
+
+	if project.RateLimits != nil {
+		update["$set"].(bson.M)["rate_limits"] = project.RateLimits
+	} else {
+		unset["rate_limits"] = ""
+	}
+
+	if len(unset) > 0 {
+		update["$unset"] = unset
+	}
+
+	// Always include project_users in the update (even if empty array)
+	// This ensures the field exists in MongoDB
+	// If nil, initialize as empty array
+	projectUsers := project.ProjectUsers
+	if projectUsers == nil {
+		projectUsers = []models.ProjectUser{}
+	}
+	update["$set"].(bson.M)["project_users"] = projectUsers
+
+	_, err := collection.UpdateOne(ctx, bson.M{"_id": projectID}, update)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// UpdateProjectStatus sets the project's lifecycle status
+func (r *MongoRepository) UpdateProjectStatus(ctx context.Context, projectID primitive.ObjectID, status models.ProjectStatus) error {
+	collection := r.db.Collection(database.CollectionProjects)
+
+	update := bson.M{
+		"$set": bson.M{
+			"status":     status,
+			"updated_at": time.Now(),
+		},
+	}
+
+	result, err := collection.UpdateOne(ctx, bson.M{"_id": projectID}, update)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+// UpdateProjectDeletionProgress records how far the delete worker got with the project
+func (r *MongoRepository) UpdateProjectDeletionProgress(ctx context.Context, projectID primitive.ObjectID, progress *models.ProjectDeletionProgress) error {
+	collection := r.db.Collection(database.CollectionProjects)
+
+	progress.UpdatedAt = time.Now()
+	_, err := collection.UpdateOne(ctx, bson.M{"_id": projectID}, bson.M{"$set": bson.M{"deletion_progress": progress}})
+	return err
+}
+
+// DeleteProject removes the project document. Tasks, groups and executions must be removed separately.
+func (r *MongoRepository) DeleteProject(ctx context.Context, projectID primitive.ObjectID) error {
+	collection := r.db.Collection(database.CollectionProjects)
+
+	result, err := collection.DeleteOne(ctx, bson.M{"_id": projectID})
+	if err != nil {
+		return err
+	}
+	if result.DeletedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+// SetProjectStatusPageToken sets the token of the project's public status page, or removes it when token is empty.
+// Returns mongo.ErrNoDocuments if the project does not exist.
+func (r *MongoRepository) SetProjectStatusPageToken(ctx context.Context, projectID primitive.ObjectID, token string) error {
+	collection := r.db.Collection(database.CollectionProjects)
+
+	update := bson.M{"$set": bson.M{"status_page_token": token, "updated_at": time.Now()}}
+	if token == "" {
+		update = bson.M{
+			"$unset": bson.M{"status_page_token": ""},
+			"$set":   bson.M{"updated_at": time.Now()},
+		}
+	}
+
+	result, err := collection.UpdateOne(ctx, bson.M{"_id": projectID}, update)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+// AddScopedAPIKey appends a scoped API key to the project's scoped_api_keys array
+func (r *MongoRepository) AddScopedAPIKey(ctx context.Context, projectID primitive.ObjectID, apiKey models.ScopedAPIKey) error {
+	collection := r.db.Collection(database.CollectionProjects)
+
+	update := bson.M{
+		"$push": bson.M{"scoped_api_keys": apiKey},
+		"$set":  bson.M{"updated_at": time.Now()},
+	}
+
+	result, err := collection.UpdateOne(ctx, bson.M{"_id": projectID}, update)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+// RemoveScopedAPIKey revokes a scoped API key by its ID. Returns mongo.ErrNoDocuments if the key does not exist.
+func (r *MongoRepository) RemoveScopedAPIKey(ctx context.Context, projectID primitive.ObjectID, keyID string) error {
+	collection := r.db.Collection(database.CollectionProjects)
+
+	filter := bson.M{
+		"_id":                projectID,
+		"scoped_api_keys.id": keyID,
+	}
+	update := bson.M{
+		"$pull": bson.M{"scoped_api_keys": bson.M{"id": keyID}},
+		"$set":  bson.M{"updated_at": time.Now()},
+	}
+
+	result, err := collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+// UpdateScopedAPIKeyAllowedCIDRs replaces the CIDR allowlist of a scoped API key. Returns mongo.ErrNoDocuments if the key does not exist.
+func (r *MongoRepository) UpdateScopedAPIKeyAllowedCIDRs(ctx context.Context, projectID primitive.ObjectID, keyID string, allowedCIDRs []string) error {
+	collection := r.db.Collection(database.CollectionProjects)
+
+	filter := bson.M{
+		"_id":                projectID,
+		"scoped_api_keys.id": keyID,
+	}
+	update := bson.M{
+		"$set": bson.M{
+			"scoped_api_keys.$.allowed_cidrs": allowedCIDRs,
+			"updated_at":                      time.Now(),
+		},
+	}
+
+	result, err := collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+// AddProjectEnvironment appends an environment unless one with the same name exists.
+// Returns mongo.ErrNoDocuments if the project does not exist or the name is already taken.
+func (r *MongoRepository) AddProjectEnvironment(ctx context.Context, projectID primitive.ObjectID, environment models.ProjectEnvironment) error {
+	collection := r.db.Collection(database.CollectionProjects)
+
+	filter := bson.M{
+		"_id":               projectID,
+		"environments.name": bson.M{"$ne": environment.Name},
+	}
+	update := bson.M{
+		"$push": bson.M{"environments": environment},
+		"$set":  bson.M{"updated_at": time.Now()},
+	}
+
+	result, err := collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+// UpdateProjectEnvironmentEndpoint changes the execution endpoint of an environment. Returns mongo.ErrNoDocuments if the environment does not exist.
+func (r *MongoRepository) UpdateProjectEnvironmentEndpoint(ctx context.Context, projectID primitive.ObjectID, name, executionEndpoint string) error {
+	collection := r.db.Collection(database.CollectionProjects)
+
+	now := time.Now()
+	filter := bson.M{
+		"_id":               projectID,
+		"environments.name": name,
+	}
+	update := bson.M{
+		"$set": bson.M{
+			"environments.$.execution_endpoint": executionEndpoint,
+			"environments.$.updated_at":         now,
+			"updated_at":                        now,
+		},
+	}
+
+	result, err := collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+// RemoveProjectEnvironment removes an environment by name. Returns mongo.ErrNoDocuments if the environment does not exist.
+func (r *MongoRepository) RemoveProjectEnvironment(ctx context.Context, projectID primitive.ObjectID, name string) error {
+	collection := r.db.Collection(database.CollectionProjects)
+
+	filter := bson.M{
+		"_id":               projectID,
+		"environments.name": name,
+	}
+	update := bson.M{
+		"$pull": bson.M{"environments": bson.M{"name": name}},
+		"$set":  bson.M{"updated_at": time.Now()},
+	}
+
+	result, err := collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+// AddNotificationChannel appends a notification channel to a project. Returns mongo.ErrNoDocuments if the project does
+// not exist or already has a channel with the same name.
+func (r *MongoRepository) AddNotificationChannel(ctx context.Context, projectID primitive.ObjectID, channel models.NotificationChannel) error {
+	collection := r.db.Collection(database.CollectionProjects)
+
+	filter := bson.M{
+		"_id":                        projectID,
+		"notification_channels.name": bson.M{"$ne": channel.Name},
+	}
+	update := bson.M{
+		"$push": bson.M{"notification_channels": channel},
+		"$set":  bson.M{"updated_at": time.Now()},
+	}
+
+	result, err := collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+// UpdateNotificationChannel replaces the type and destination of the channel with the same name. Returns
+// mongo.ErrNoDocuments if the channel does not exist.
+func (r *MongoRepository) UpdateNotificationChannel(ctx context.Context, projectID primitive.ObjectID, channel models.NotificationChannel) error {
+	collection := r.db.Collection(database.CollectionProjects)
+
+	now := time.Now()
+	filter := bson.M{
+		"_id":                        projectID,
+		"notification_channels.name": channel.Name,
+	}
+	update := bson.M{
+		"$set": bson.M{
+			"notification_channels.$.type":           channel.Type,
+			"notification_channels.$.emails":         channel.Emails,
+			"notification_channels.$.url":            channel.URL,
+			"notification_channels.$.routing_key":    channel.RoutingKey,
+			"notification_channels.$.digest_minutes": channel.DigestMinutes,
+			"notification_channels.$.updated_at":     now,
+			"updated_at":                             now,
+		},
+	}
+
+	result, err := collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+// RemoveNotificationChannel removes a notification channel by name. Returns mongo.ErrNoDocuments if the channel does
+// not exist.
+func (r *MongoRepository) RemoveNotificationChannel(ctx context.Context, projectID primitive.ObjectID, name string) error {
+	collection := r.db.Collection(database.CollectionProjects)
+
+	filter := bson.M{
+		"_id":                        projectID,
+		"notification_channels.name": name,
+	}
+	update := bson.M{
+		"$pull": bson.M{"notification_channels": bson.M{"name": name}},
+		"$set":  bson.M{"updated_at": time.Now()},
+	}
+
+	result, err := collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+// SetAlertRoutes replaces the alert routes of the project, or removes them when routes is empty.
+// Returns mongo.ErrNoDocuments if the project does not exist.
+func (r *MongoRepository) SetAlertRoutes(ctx context.Context, projectID primitive.ObjectID, routes []models.AlertRoute) error {
+	collection := r.db.Collection(database.CollectionProjects)
+
+	update := bson.M{"$set": bson.M{"alert_routes": routes, "updated_at": time.Now()}}
+	if len(routes) == 0 {
+		update = bson.M{
+			"$unset": bson.M{"alert_routes": ""},
+			"$set":   bson.M{"updated_at": time.Now()},
+		}
+	}
+
+	result, err := collection.UpdateOne(ctx, bson.M{"_id": projectID}, update)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+// SetOnCallSchedule replaces the on-call schedule of the project, or removes it when schedule is nil.
+// Returns mongo.ErrNoDocuments if the project does not exist.
+func (r *MongoRepository) SetOnCallSchedule(ctx context.Context, projectID primitive.ObjectID, schedule *models.OnCallSchedule) error {
+	collection := r.db.Collection(database.CollectionProjects)
+
+	update := bson.M{"$set": bson.M{"on_call": schedule, "updated_at": time.Now()}}
+	if schedule == nil {
+		update = bson.M{
+			"$unset": bson.M{"on_call": ""},
+			"$set":   bson.M{"updated_at": time.Now()},
+		}
+	}
+
+	result, err := collection.UpdateOne(ctx, bson.M{"_id": projectID}, update)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+// AddProjectUser adds a user to the project's project_users array unless the email is already a member.
+// Returns mongo.ErrNoDocuments if the project does not exist.
+func (r *MongoRepository) AddProjectUser(ctx context.Context, projectID primitive.ObjectID, user models.ProjectUser) error {
+	collection := r.db.Collection(database.CollectionProjects)
+
+	filter := bson.M{
+		"_id":                 projectID,
+		"project_users.email": bson.M{"$ne": user.Email},
+	}
+	update := bson.M{
+		"$push": bson.M{"project_users": user},
+		"$set":  bson.M{"updated_at": time.Now()},
+	}
+
+	result, err := collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		// Either the project does not exist or the user is already a member
+		count, err := collection.CountDocuments(ctx, bson.M{"_id": projectID})
+		if err != nil {
+			return err
+		}
+		if count == 0 {
+			return mongo.ErrNoDocuments
+		}
+	}
+	return nil
+}
+
+// SetProjectQuotas sets the quota overrides of the project, or removes them when quotas is nil.
+// Returns mongo.ErrNoDocuments if the project does not exist.
+func (r *MongoRepository) SetProjectQuotas(ctx context.Context, projectID primitive.ObjectID, quotas *models.ProjectQuotas) error {
+	return r.setQuotas(ctx, database.CollectionProjects, projectID, quotas)
+}
+
+// CreateOrganization inserts a new organization
+func (r *MongoRepository) CreateOrganization(ctx context.Context, organization *models.Organization) error {
+	collection := r.db.Collection(database.CollectionOrganizations)
+	result, err := collection.InsertOne(ctx, organization)
+	if err != nil {
+		return err
+	}
+	if oid, ok := result.InsertedID.(primitive.ObjectID); ok {
+		organization.ID = oid
+	}
+	return nil
+}
+
+// GetOrganizationByID returns an organization. Returns mongo.ErrNoDocuments if not found.
+func (r *MongoRepository) GetOrganizationByID(ctx context.Context, organizationID primitive.ObjectID) (*models.Organization, error) {
+	collection := r.db.Collection(database.CollectionOrganizations)
+
+	var organization models.Organization
+	if err := collection.FindOne(ctx, bson.M{"_id": organizationID}).Decode(&organization); err != nil {
+		return nil, err
+	}
+	return &organization, nil
+}
+
+// GetAllOrganizations returns all organizations sorted by name
+func (r *MongoRepository) GetAllOrganizations(ctx context.Context) ([]*models.Organization, error) {
+	return r.findOrganizations(ctx, bson.M{})
+}
+
+// GetUserOrganizations returns the organizations the email is a member of, sorted by name
+func (r *MongoRepository) GetUserOrganizations(ctx context.Context, email string) ([]*models.Organization, error) {
+	return r.findOrganizations(ctx, bson.M{"members.email": email})
+}
+
+func (r *MongoRepository) findOrganizations(ctx context.Context, filter bson.M) ([]*models.Organization, error) {
+	collection := r.db.Collection(database.CollectionOrganizations)
+	opts := options.Find().
+		SetSort(bson.D{{Key: "name", Value: 1}}).
+		SetCollation(&options.Collation{Locale: "en", Strength: 2})
+
+	cursor, err := collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var organizations []*models.Organization
+	if err := cursor.All(ctx, &organizations); err != nil {
+		return nil, err
+	}
+	return organizations, nil
+}
+
+// administeredOrganizationIDs returns the IDs of the organizations the email is an admin of
+func (r *MongoRepository) administeredOrganizationIDs(ctx context.Context, email string) ([]primitive.ObjectID, error) {
+	collection := r.db.Collection(database.CollectionOrganizations)
+	filter := bson.M{"members": bson.M{"$elemMatch": bson.M{"email": email, "role": models.OrganizationRoleAdmin}}}
+
+	cursor, err := collection.Find(ctx, filter, options.Find().SetProjection(bson.M{"_id": 1}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var docs []struct {
+		ID primitive.ObjectID `bson:"_id"`
+	}
+	if err := cursor.All(ctx, &docs); err != nil {
+		return nil, err
+	}
+	ids := make([]primitive.ObjectID, len(docs))
+	for i, doc := range docs {
+		ids[i] = doc.ID
+	}
+	return ids, nil
+}
+
+// UpdateOrganization replaces the organization's name, description and members.
+// Returns mongo.ErrNoDocuments if the organization does not exist.
+func (r *MongoRepository) UpdateOrganization(ctx context.Context, organizationID primitive.ObjectID, organization *models.Organization) error {
+	collection := r.db.Collection(database.CollectionOrganizations)
+
+	members := organization.Members
+	if members == nil {
+		members = []models.OrganizationMember{}
+	}
+	update := bson.M{
+		"$set": bson.M{
+			"name":        organization.Name,
+			"description": organization.Description,
+			"members":     members,
+			"updated_at":  organization.UpdatedAt,
+		},
+	}
+
+	result, err := collection.UpdateOne(ctx, bson.M{"_id": organizationID}, update)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+// DeleteOrganization removes the organization document. Returns mongo.ErrNoDocuments if not found.
+func (r *MongoRepository) DeleteOrganization(ctx context.Context, organizationID primitive.ObjectID) error {
+	collection := r.db.Collection(database.CollectionOrganizations)
+
+	result, err := collection.DeleteOne(ctx, bson.M{"_id": organizationID})
+	if err != nil {
+		return err
+	}
+	if result.DeletedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+// GetProjectsByOrganizationID returns the projects of an organization, except those being deleted
+func (r *MongoRepository) GetProjectsByOrganizationID(ctx context.Context, organizationID primitive.ObjectID) ([]*models.Project, error) {
+	collection := r.db.Collection(database.CollectionProjects)
+
+	filter := bson.M{
+		"organization_id": organizationID,
+		"status":          bson.M{"$ne": models.ProjectStatusPendingDelete},
+	}
+	cursor, err := collection.Find(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var projects []*models.Project
+	if err := cursor.All(ctx, &projects); err != nil {
+		return nil, err
+	}
+	return projects, nil
+}
+
+// SetProjectOrganization moves the project into an organization, or out of its organization when organizationID is nil.
+// Returns mongo.ErrNoDocuments if the project does not exist.
+func (r *MongoRepository) SetProjectOrganization(ctx context.Context, projectID primitive.ObjectID, organizationID *primitive.ObjectID) error {
+	collection := r.db.Collection(database.CollectionProjects)
+
+	update := bson.M{"$set": bson.M{"updated_at": time.Now()}}
+	if organizationID != nil {
+		update["$set"].(bson.M)["organization_id"] = *organizationID
+	} else {
+		update["$unset"] = bson.M{"organization_id": ""}
+	}
+
+	result, err := collection.UpdateOne(ctx, bson.M{"_id": projectID}, update)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+// SetOrganizationQuotas sets the quotas of the organization's projects, or removes them when quotas is nil.
+// Returns mongo.ErrNoDocuments if the organization does not exist.
+func (r *MongoRepository) SetOrganizationQuotas(ctx context.Context, organizationID primitive.ObjectID, quotas *models.ProjectQuotas) error {
+	return r.setQuotas(ctx, database.CollectionOrganizations, organizationID, quotas)
+}
+
+func (r *MongoRepository) setQuotas(ctx context.Context, collectionName string, id primitive.ObjectID, quotas *models.ProjectQuotas) error {
+	collection := r.db.Collection(collectionName)
+
+	update := bson.M{"$set": bson.M{"updated_at": time.Now()}}
+	if quotas != nil {
+		update["$set"].(bson.M)["quotas"] = quotas
+	} else {
+		update["$unset"] = bson.M{"quotas": ""}
+	}
+
+	result, err := collection.UpdateOne(ctx, bson.M{"_id": id}, update)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+// CreateInvitation inserts a new project invitation
+func (r *MongoRepository) CreateInvitation(ctx context.Context, invitation *models.Invitation) error {
+	collection := r.db.Collection(database.CollectionInvitations)
+	result, err := collection.InsertOne(ctx, invitation)
+	if err != nil {
+		return err
+	}
+	if oid, ok := result.InsertedID.(primitive.ObjectID); ok {
+		invitation.ID = oid
+	}
+	return nil
+}
+
+// GetInvitationByUUID returns an invitation by UUID. Returns mongo.ErrNoDocuments if not found.
+func (r *MongoRepository) GetInvitationByUUID(ctx context.Context, invitationUUID string) (*models.Invitation, error) {
+	collection := r.db.Collection(database.CollectionInvitations)
+
+	var invitation models.Invitation
+	err := collection.FindOne(ctx, bson.M{"uuid": invitationUUID}).Decode(&invitation)
+	if err != nil {
+		return nil, err
+	}
+	return &invitation, nil
+}
+
+// GetInvitationsByProjectID returns a project's invitations, newest first. An empty status returns all statuses.
+func (r *MongoRepository) GetInvitationsByProjectID(ctx context.Context, projectID primitive.ObjectID, status models.InvitationStatus) ([]*models.Invitation, error) {
+	collection := r.db.Collection(database.CollectionInvitations)
+
+	filter := bson.M{"project_id": projectID}
+	if status != "" {
+		filter["status"] = status
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}})
+
+	cursor, err := collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var invitations []*models.Invitation
+	if err := cursor.All(ctx, &invitations); err != nil {
+		return nil, err
+	}
+	return invitations, nil
+}
+
+// UpdateInvitationStatus sets an invitation's status, recording accepted_at when it is accepted.
+// Returns mongo.ErrNoDocuments if the invitation does not exist.
+func (r *MongoRepository) UpdateInvitationStatus(ctx context.Context, invitationUUID string, status models.InvitationStatus) error {
+	collection := r.db.Collection(database.CollectionInvitations)
+
+	now := time.Now()
+	set := bson.M{
+		"status":     status,
+		"updated_at": now,
+	}
+	if status == models.InvitationStatusAccepted {
+		set["accepted_at"] = now
+	}
+
+	result, err := collection.UpdateOne(ctx, bson.M{"uuid": invitationUUID}, bson.M{"$set": set})
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
 }
 
-// GetProjectByName returns a project by name (case-insensitive). Returns mongo.ErrNoDocuments if not found.
-func (r *MongoRepository) GetProjectByName(ctx context.Context, name string) (*models.Project, error) {
-	collection := r.db.Collection(database.CollectionProjects)
-	opts := options.FindOne().SetCollation(&options.Collation{Locale: "en", Strength: 2})
-	var project models.Project
-	err := collection.FindOne(ctx, bson.M{"name": name}, opts).Decode(&project)
+// AcceptInvitation marks the invitation as accepted in a single conditional update, so two concurrent acceptances
+// of the same token cannot both succeed
+func (r *MongoRepository) AcceptInvitation(ctx context.Context, invitationUUID, email string, at time.Time) (bool, error) {
+	collection := r.db.Collection(database.CollectionInvitations)
+
+	filter := bson.M{
+		"uuid":       invitationUUID,
+		"status":     models.InvitationStatusPending,
+		"email":      email,
+		"expires_at": bson.M{"$gt": at},
+	}
+	update := bson.M{"$set": bson.M{
+		"status":      models.InvitationStatusAccepted,
+		"accepted_at": at,
+		"updated_at":  at,
+	}}
+	result, err := collection.UpdateOne(ctx, filter, update)
 	if err != nil {
-		return nil, err
+		return false, err
 	}
-	return &project, nil
+	return result.ModifiedCount > 0, nil
 }
 
-func (r *MongoRepository) GetUserProjects(ctx context.Context, email string) ([]*models.Project, error) {
-	collection := r.db.Collection(database.CollectionProjects)
+// UpsertSecret creates a secret or replaces the encrypted value of an existing secret with the same name
+func (r *MongoRepository) UpsertSecret(ctx context.Context, secret *models.Secret) error {
+	collection := r.db.Collection(database.CollectionSecrets)
 
-	// Find projects where the user's email exists in the project_users array
 	filter := bson.M{
-		"project_users.email": email,
+		"project_id": secret.ProjectID,
+		"name":       secret.Name,
+	}
+	update := bson.M{
+		"$set": bson.M{
+			"ciphertext":         secret.Ciphertext,
+			"encrypted_data_key": secret.EncryptedDataKey,
+			"updated_at":         secret.UpdatedAt,
+		},
+		"$setOnInsert": bson.M{
+			"created_at": secret.CreatedAt,
+		},
 	}
 
-	cursor, err := collection.Find(ctx, filter)
+	_, err := collection.UpdateOne(ctx, filter, update, options.Update().SetUpsert(true))
+	return err
+}
+
+// GetSecretByName returns a project secret by name. Returns mongo.ErrNoDocuments if not found.
+func (r *MongoRepository) GetSecretByName(ctx context.Context, projectID primitive.ObjectID, name string) (*models.Secret, error) {
+	collection := r.db.Collection(database.CollectionSecrets)
+
+	var secret models.Secret
+	err := collection.FindOne(ctx, bson.M{"project_id": projectID, "name": name}).Decode(&secret)
 	if err != nil {
 		return nil, err
 	}
-	defer cursor.Close(ctx)
+	return &secret, nil
+}
 
-	var projects []*models.Project
-	err = cursor.All(ctx, &projects)
+// GetSecretsByProjectID returns all secrets of a project sorted by name
+func (r *MongoRepository) GetSecretsByProjectID(ctx context.Context, projectID primitive.ObjectID) ([]*models.Secret, error) {
+	collection := r.db.Collection(database.CollectionSecrets)
+
+	opts := options.Find().SetSort(bson.D{{Key: "name", Value: 1}})
+	cursor, err := collection.Find(ctx, bson.M{"project_id": projectID}, opts)
 	if err != nil {
 		return nil, err
 	}
+	defer cursor.Close(ctx)
 
-	// Ensure project_users is always initialized (not nil) for JSON serialization
-	for _, project := range projects {
-		if project.ProjectUsers == nil {
-			project.ProjectUsers = []models.ProjectUser{}
-		}
+	var secrets []*models.Secret
+	if err := cursor.All(ctx, &secrets); err != nil {
+		return nil, err
 	}
+	return secrets, nil
+}
 
-	return projects, nil
+// DeleteSecret removes a project secret. Returns mongo.ErrNoDocuments if not found.
+func (r *MongoRepository) DeleteSecret(ctx context.Context, projectID primitive.ObjectID, name string) error {
+	collection := r.db.Collection(database.CollectionSecrets)
+
+	result, err := collection.DeleteOne(ctx, bson.M{"project_id": projectID, "name": name})
+	if err != nil {
+		return err
+	}
+	if result.DeletedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
 }
 
-func (r *MongoRepository) CreateProject(ctx context.Context, project *models.Project) error {
-	collection := r.db.Collection(database.CollectionProjects)
-	_, err := collection.InsertOne(ctx, project)
+// CreateTokenRevocation stores a token revocation entry
+func (r *MongoRepository) CreateTokenRevocation(ctx context.Context, revocation *models.TokenRevocation) error {
+	collection := r.db.Collection(database.CollectionTokenRevocations)
+	result, err := collection.InsertOne(ctx, revocation)
 	if err != nil {
 		return err
 	}
+	if oid, ok := result.InsertedID.(primitive.ObjectID); ok {
+		revocation.ID = oid
+	}
 	return nil
 }
 
-func (r *MongoRepository) UpdateProject(ctx context.Context, projectID primitive.ObjectID, project *models.Project) error {
-	collection := r.db.Collection(database.CollectionProjects)
+// IsTokenRevoked reports whether the token's jti was revoked, or the user's tokens issued before issuedAt were revoked.
+// Tokens without an iat (zero issuedAt) are treated as issued before any per-user cutoff.
+func (r *MongoRepository) IsTokenRevoked(ctx context.Context, jti string, email string, issuedAt time.Time) (bool, error) {
+	collection := r.db.Collection(database.CollectionTokenRevocations)
 
-	update := bson.M{
-		"$set": bson.M{
-			"name":               project.Name,
-			"description":        project.Description,
-			"execution_endpoint": project.ExecutionEndpoint,
-			"alert_emails":       project.AlertEmails,
-			"updated_at":         project.UpdatedAt,
-		},
+	var conditions bson.A
+	if jti != "" {
+		conditions = append(conditions, bson.M{"jti": jti})
 	}
-
-	// Always include project_users in the update (even if empty array)
-	// This ensures the field exists in MongoDB
-	// If nil, initialize as empty array
-	projectUsers := project.ProjectUsers
-	if projectUsers == nil {
-		projectUsers = []models.ProjectUser{}
+	if email != "" {
+		conditions = append(conditions, bson.M{
+			"email":          strings.ToLower(strings.TrimSpace(email)),
+			"revoked_before": bson.M{"$gt": issuedAt},
+		})
+	}
+	if len(conditions) == 0 {
+		return false, nil
 	}
-	update["$set"].(bson.M)["project_users"] = projectUsers
 
-	_, err := collection.UpdateOne(ctx, bson.M{"_id": projectID}, update)
+	count, err := collection.CountDocuments(ctx, bson.M{"$or": conditions}, options.Count().SetLimit(1))
 	if err != nil {
-		return err
+		return false, err
 	}
-	return nil
+	return count > 0, nil
 }
 
 func (r *MongoRepository) CreateTask(ctx context.Context, projectID string, task *models.Task) error {
 	collection := r.db.Collection(database.CollectionTasks)
+	task.MetadataKeys = metadataKeys(task.Metadata)
 	_, err := collection.InsertOne(ctx, task)
 	if err != nil {
 		return err
@@ -174,6 +984,24 @@ func (r *MongoRepository) GetTasksByStatus(ctx context.Context, statuses []model
 	return tasks, nil
 }
 
+// GetTaskBatchByProjectID returns up to limit tasks of a project regardless of their status
+func (r *MongoRepository) GetTaskBatchByProjectID(ctx context.Context, projectID primitive.ObjectID, limit int) ([]*models.Task, error) {
+	collection := r.db.Collection(database.CollectionTasks)
+
+	opts := options.Find().SetSort(bson.D{{Key: "_id", Value: 1}}).SetLimit(int64(limit))
+	cursor, err := collection.Find(ctx, bson.M{"project_id": projectID}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var tasks []*models.Task
+	if err := cursor.All(ctx, &tasks); err != nil {
+		return nil, err
+	}
+	return tasks, nil
+}
+
 func (r *MongoRepository) GetTasksByProjectID(ctx context.Context, projectID primitive.ObjectID) ([]*models.Task, error) {
 	collection := r.db.Collection(database.CollectionTasks)
 
@@ -200,6 +1028,205 @@ func (r *MongoRepository) GetTasksByProjectID(ctx context.Context, projectID pri
 	return tasks, nil
 }
 
+// ListTasksByProjectID retrieves a filtered, sorted page of a project's tasks and the total number of matches
+func (r *MongoRepository) ListTasksByProjectID(ctx context.Context, projectID primitive.ObjectID, filter models.TaskListFilter, page, pageSize int) ([]*models.Task, int64, error) {
+	collection := r.db.Collection(database.CollectionTasks)
+
+	query := bson.M{"project_id": projectID}
+	if filter.Status != "" {
+		query["status"] = filter.Status
+	} else {
+		// Same visibility rule as GetTasksByProjectID
+		query["status"] = bson.M{
+			"$nin": []string{string(models.TaskStatusPendingDelete), string(models.TaskStatusDeleteFailed)},
+		}
+	}
+	if filter.State != "" {
+		query["state"] = filter.State
+	}
+	if filter.TaskGroupID != nil {
+		query["task_group_id"] = *filter.TaskGroupID
+	}
+	if filter.ScheduleType != "" {
+		query["schedule_type"] = filter.ScheduleType
+	}
+	if len(filter.Tags) > 0 {
+		query["tags"] = bson.M{"$all": filter.Tags}
+	}
+	if filter.Severity == models.TaskSeverityNormal {
+		// Tasks created before severities existed are NORMAL
+		query["severity"] = bson.M{"$in": []interface{}{models.TaskSeverityNormal, nil}}
+	} else if filter.Severity != "" {
+		query["severity"] = filter.Severity
+	}
+	if filter.Search != "" {
+		query["$or"] = taskSearchConditions(filter.Search)
+	}
+
+	totalCount, err := collection.CountDocuments(ctx, query)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	sortField := string(filter.SortBy)
+	if sortField == "" {
+		sortField = string(models.TaskSortByCreatedAt)
+	}
+	direction := 1
+	if filter.SortDesc {
+		direction = -1
+	}
+
+	var cursor *mongo.Cursor
+	if filter.SortBy == models.TaskSortBySeverity {
+		// Severities sort by urgency rather than alphabetically, so their rank is computed for the sort
+		pipeline := mongo.Pipeline{
+			{{Key: "$match", Value: query}},
+			{{Key: "$addFields", Value: bson.M{"severity_rank": severityRankExpression()}}},
+			{{Key: "$sort", Value: bson.D{{Key: "severity_rank", Value: direction}, {Key: "_id", Value: direction}}}},
+		}
+		if pageSize > 0 {
+			pipeline = append(pipeline,
+				bson.D{{Key: "$skip", Value: int64((page - 1) * pageSize)}},
+				bson.D{{Key: "$limit", Value: int64(pageSize)}},
+			)
+		}
+		cursor, err = collection.Aggregate(ctx, pipeline)
+	} else {
+		// _id breaks ties so pages are stable
+		opts := options.Find().SetSort(bson.D{{Key: sortField, Value: direction}, {Key: "_id", Value: direction}})
+		if pageSize > 0 {
+			opts.SetSkip(int64((page - 1) * pageSize)).SetLimit(int64(pageSize))
+		}
+		cursor, err = collection.Find(ctx, query, opts)
+	}
+	if err != nil {
+		return nil, 0, err
+	}
+	defer cursor.Close(ctx)
+
+	var tasks []*models.Task
+	if err := cursor.All(ctx, &tasks); err != nil {
+		return nil, 0, err
+	}
+
+	// Ensure we always return an empty slice instead of nil
+	if tasks == nil {
+		tasks = []*models.Task{}
+	}
+
+	return tasks, totalCount, nil
+}
+
+// severityRankExpression computes models.TaskSeverity.Rank in an aggregation
+func severityRankExpression() bson.M {
+	branches := make(bson.A, 0, len(models.TaskSeverities))
+	for _, severity := range models.TaskSeverities {
+		branches = append(branches, bson.M{
+			"case": bson.M{"$eq": bson.A{"$severity", severity}},
+			"then": severity.Rank(),
+		})
+	}
+	return bson.M{"$switch": bson.M{
+		"branches": branches,
+		"default":  models.TaskSeverityNormal.Rank(),
+	}}
+}
+
+// taskSearchConditions matches tasks whose name, description or any metadata key contains the search term.
+// A substring match cannot seek an index, but name and metadata keys are matched against the keys of
+// idx_project_name and idx_project_metadata_keys within the project; only description is read from the tasks.
+func taskSearchConditions(search string) bson.A {
+	regex := primitive.Regex{Pattern: regexp.QuoteMeta(search), Options: "i"}
+
+	return bson.A{
+		bson.M{"name": regex},
+		bson.M{"description": regex},
+		bson.M{"metadata_keys": regex},
+	}
+}
+
+// metadataKeys returns the sorted keys of a task's metadata, stored as metadata_keys for task search
+func metadataKeys(metadata map[string]interface{}) []string {
+	if len(metadata) == 0 {
+		return nil
+	}
+	keys := make([]string, 0, len(metadata))
+	for key := range metadata {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// UpdateTaskLastFailureAt records a failed execution on the task. Older failures never overwrite newer ones.
+func (r *MongoRepository) UpdateTaskLastFailureAt(ctx context.Context, taskUUID string, failedAt time.Time) error {
+	collection := r.db.Collection(database.CollectionTasks)
+
+	update := bson.M{
+		"$max": bson.M{"last_failure_at": failedAt},
+	}
+
+	result, err := collection.UpdateOne(ctx, bson.M{"uuid": taskUUID}, update)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+// SetTaskMutedUntil sets or, when mutedUntil is nil, clears the task's alert mute
+func (r *MongoRepository) SetTaskMutedUntil(ctx context.Context, taskUUID string, mutedUntil *time.Time) error {
+	collection := r.db.Collection(database.CollectionTasks)
+
+	set := bson.M{"updated_at": time.Now()}
+	update := bson.M{"$set": set}
+	if mutedUntil != nil {
+		set["muted_until"] = *mutedUntil
+	} else {
+		update["$unset"] = bson.M{"muted_until": ""}
+	}
+
+	result, err := collection.UpdateOne(ctx, bson.M{"uuid": taskUUID}, update)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+// SetTaskNextRunAt records the task's next cron fire time or, when nextRunAt is nil, clears it.
+// Scheduler bookkeeping, so updated_at is left alone.
+func (r *MongoRepository) SetTaskNextRunAt(ctx context.Context, taskUUID string, nextRunAt *time.Time) error {
+	collection := r.db.Collection(database.CollectionTasks)
+
+	update := bson.M{"$unset": bson.M{"next_run_at": ""}}
+	if nextRunAt != nil {
+		update = bson.M{"$set": bson.M{"next_run_at": *nextRunAt}}
+	}
+
+	_, err := collection.UpdateOne(ctx, bson.M{"uuid": taskUUID}, update)
+	return err
+}
+
+// SetTaskFlapping marks the task as flapping since the given time or, when since is nil, clears the mark.
+// Alert bookkeeping, so updated_at is left alone.
+func (r *MongoRepository) SetTaskFlapping(ctx context.Context, taskUUID string, since *time.Time) error {
+	collection := r.db.Collection(database.CollectionTasks)
+
+	update := bson.M{"$unset": bson.M{"flapping_since": ""}}
+	if since != nil {
+		update = bson.M{"$set": bson.M{"flapping_since": *since}}
+	}
+
+	_, err := collection.UpdateOne(ctx, bson.M{"uuid": taskUUID}, update)
+	return err
+}
+
 // GetTaskByUUID returns a task by UUID. Returns mongo.ErrNoDocuments when not found.
 func (r *MongoRepository) GetTaskByUUID(ctx context.Context, taskUUID string) (*models.Task, error) {
 	collection := r.db.Collection(database.CollectionTasks)
@@ -218,8 +1245,31 @@ func (r *MongoRepository) UpdateTask(ctx context.Context, taskUUID string, task
 	collection := r.db.Collection(database.CollectionTasks)
 
 	filter := bson.M{"uuid": taskUUID}
+	task.MetadataKeys = metadataKeys(task.Metadata)
 	update := bson.M{"$set": task}
 
+	// Omitted optional fields must be cleared rather than left at their previous values
+	unset := bson.M{}
+	if task.TaskGroupID == nil {
+		unset["task_group_id"] = ""
+	}
+	if task.Environment == "" {
+		// An empty environment means the project's default endpoint, so drop any previous selection
+		unset["environment"] = ""
+	}
+	if len(task.Tags) == 0 {
+		unset["tags"] = ""
+	}
+	if len(task.Env) == 0 {
+		unset["env"] = ""
+	}
+	if task.Priority == 0 {
+		unset["priority"] = ""
+	}
+	if len(unset) > 0 {
+		update["$unset"] = unset
+	}
+
 	_, err := collection.UpdateOne(ctx, filter, update)
 	return err
 }
@@ -280,60 +1330,204 @@ func (r *MongoRepository) CreateTaskGroup(ctx context.Context, projectID string,
 	if err != nil {
 		return err
 	}
-	return nil
-}
+	return nil
+}
+
+func (r *MongoRepository) GetTaskGroupsByProjectID(ctx context.Context, projectID primitive.ObjectID) ([]*models.TaskGroup, error) {
+	collection := r.db.Collection(database.CollectionTaskGroups)
+
+	filter := bson.M{"project_id": projectID}
+
+	cursor, err := collection.Find(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var taskGroups []*models.TaskGroup
+	err = cursor.All(ctx, &taskGroups)
+	if err != nil {
+		return nil, err
+	}
+	return taskGroups, nil
+}
+
+func (r *MongoRepository) GetTaskGroupByUUID(ctx context.Context, taskGroupUUID string) (*models.TaskGroup, error) {
+	collection := r.db.Collection(database.CollectionTaskGroups)
+
+	var taskGroup models.TaskGroup
+	err := collection.FindOne(ctx, bson.M{"uuid": taskGroupUUID}).Decode(&taskGroup)
+	if err != nil {
+		return nil, err
+	}
+	return &taskGroup, nil
+}
+
+func (r *MongoRepository) GetTaskGroupByID(ctx context.Context, taskGroupID primitive.ObjectID) (*models.TaskGroup, error) {
+	collection := r.db.Collection(database.CollectionTaskGroups)
+
+	var taskGroup models.TaskGroup
+	err := collection.FindOne(ctx, bson.M{"_id": taskGroupID}).Decode(&taskGroup)
+	if err != nil {
+		return nil, err
+	}
+	return &taskGroup, nil
+}
+
+func (r *MongoRepository) UpdateTaskGroup(ctx context.Context, taskGroupUUID string, taskGroup *models.TaskGroup) error {
+	collection := r.db.Collection(database.CollectionTaskGroups)
+
+	filter := bson.M{"uuid": taskGroupUUID}
+	update := bson.M{"$set": taskGroup}
+
+	_, err := collection.UpdateOne(ctx, filter, update)
+	return err
+}
+
+// UpdateTaskGroupWithTasks replaces the task group and cascades status and state to its tasks (except those being
+// deleted) as one unit. It runs in a transaction where the deployment supports them; on a standalone server the
+// changes already applied are undone when a later step fails.
+func (r *MongoRepository) UpdateTaskGroupWithTasks(ctx context.Context, taskGroup *models.TaskGroup, cascade models.TaskGroupCascade) (*models.TaskGroupCascadeResult, error) {
+	if mongo.SessionFromContext(ctx) != nil {
+		// Part of a transaction started by RunInTransaction
+		return r.applyTaskGroupCascade(ctx, taskGroup, cascade)
+	}
+
+	session, err := r.db.Client().StartSession()
+	if err != nil {
+		return nil, err
+	}
+	defer session.EndSession(ctx)
+
+	result, err := session.WithTransaction(ctx, func(sc mongo.SessionContext) (interface{}, error) {
+		return r.applyTaskGroupCascade(sc, taskGroup, cascade)
+	})
+	if err == nil {
+		return result.(*models.TaskGroupCascadeResult), nil
+	}
+	if !transactionsUnsupported(err) {
+		return nil, err
+	}
+
+	return r.applyTaskGroupCascadeWithUndo(ctx, taskGroup, cascade)
+}
+
+// applyTaskGroupCascade writes the task group and the cascade to its tasks
+func (r *MongoRepository) applyTaskGroupCascade(ctx context.Context, taskGroup *models.TaskGroup, cascade models.TaskGroupCascade) (*models.TaskGroupCascadeResult, error) {
+	taskGroups := r.db.Collection(database.CollectionTaskGroups)
+	if _, err := taskGroups.UpdateOne(ctx, bson.M{"uuid": taskGroup.UUID}, bson.M{"$set": taskGroup}); err != nil {
+		return nil, err
+	}
+
+	tasks := r.db.Collection(database.CollectionTasks)
+	// Same tasks as GetTasksByGroupID: those being deleted are left alone
+	deleting := []string{string(models.TaskStatusPendingDelete), string(models.TaskStatusDeleteFailed)}
+	now := time.Now()
+	result := &models.TaskGroupCascadeResult{}
+
+	if cascade.TaskStatus != "" {
+		filter := bson.M{
+			"task_group_id": taskGroup.ID,
+			"status":        bson.M{"$ne": cascade.TaskStatus, "$nin": deleting},
+		}
+		updated, err := tasks.UpdateMany(ctx, filter, bson.M{"$set": bson.M{"status": cascade.TaskStatus, "updated_at": now}})
+		if err != nil {
+			return nil, err
+		}
+		result.StatusUpdated = updated.ModifiedCount
+	}
 
-func (r *MongoRepository) GetTaskGroupsByProjectID(ctx context.Context, projectID primitive.ObjectID) ([]*models.TaskGroup, error) {
-	collection := r.db.Collection(database.CollectionTaskGroups)
+	if cascade.TaskState != "" {
+		filter := bson.M{
+			"task_group_id": taskGroup.ID,
+			"status":        bson.M{"$nin": deleting},
+			"state":         bson.M{"$ne": cascade.TaskState},
+		}
+		updated, err := tasks.UpdateMany(ctx, filter, bson.M{"$set": bson.M{"state": cascade.TaskState, "updated_at": now}})
+		if err != nil {
+			return nil, err
+		}
+		result.StateUpdated = updated.ModifiedCount
+	}
 
-	filter := bson.M{"project_id": projectID}
+	return result, nil
+}
 
-	cursor, err := collection.Find(ctx, filter)
+// applyTaskGroupCascadeWithUndo applies the cascade without a transaction and, when a step fails, restores the
+// task group and the status and state of its tasks from a snapshot taken beforehand
+func (r *MongoRepository) applyTaskGroupCascadeWithUndo(ctx context.Context, taskGroup *models.TaskGroup, cascade models.TaskGroupCascade) (*models.TaskGroupCascadeResult, error) {
+	previousGroup, err := r.GetTaskGroupByUUID(ctx, taskGroup.UUID)
 	if err != nil {
 		return nil, err
 	}
-	defer cursor.Close(ctx)
-
-	var taskGroups []*models.TaskGroup
-	err = cursor.All(ctx, &taskGroups)
+	previousTasks, err := r.GetTasksByGroupID(ctx, taskGroup.ID)
 	if err != nil {
 		return nil, err
 	}
-	return taskGroups, nil
-}
 
-func (r *MongoRepository) GetTaskGroupByUUID(ctx context.Context, taskGroupUUID string) (*models.TaskGroup, error) {
-	collection := r.db.Collection(database.CollectionTaskGroups)
+	result, err := r.applyTaskGroupCascade(ctx, taskGroup, cascade)
+	if err == nil {
+		return result, nil
+	}
 
-	var taskGroup models.TaskGroup
-	err := collection.FindOne(ctx, bson.M{"uuid": taskGroupUUID}).Decode(&taskGroup)
-	if err != nil {
-		return nil, err
+	// Undo even when the request was cancelled, or the group stays half updated
+	undoCtx := context.WithoutCancel(ctx)
+	if undoErr := r.undoTaskGroupCascade(undoCtx, previousGroup, previousTasks); undoErr != nil {
+		return nil, fmt.Errorf("%w (undoing partial task group update failed: %v)", err, undoErr)
 	}
-	return &taskGroup, nil
+	return nil, err
 }
 
-func (r *MongoRepository) GetTaskGroupByID(ctx context.Context, taskGroupID primitive.ObjectID) (*models.TaskGroup, error) {
-	collection := r.db.Collection(database.CollectionTaskGroups)
+// undoTaskGroupCascade restores the task group document and the status and state of the given tasks
+func (r *MongoRepository) undoTaskGroupCascade(ctx context.Context, previousGroup *models.TaskGroup, previousTasks []*models.Task) error {
+	taskGroups := r.db.Collection(database.CollectionTaskGroups)
+	if _, err := taskGroups.ReplaceOne(ctx, bson.M{"_id": previousGroup.ID}, previousGroup); err != nil {
+		return err
+	}
+	if len(previousTasks) == 0 {
+		return nil
+	}
 
-	var taskGroup models.TaskGroup
-	err := collection.FindOne(ctx, bson.M{"_id": taskGroupID}).Decode(&taskGroup)
-	if err != nil {
-		return nil, err
+	restores := make([]mongo.WriteModel, 0, len(previousTasks))
+	for _, task := range previousTasks {
+		restores = append(restores, mongo.NewUpdateOneModel().
+			SetFilter(bson.M{"_id": task.ID}).
+			SetUpdate(bson.M{"$set": bson.M{"status": task.Status, "state": task.State, "updated_at": task.UpdatedAt}}))
 	}
-	return &taskGroup, nil
+	_, err := r.db.Collection(database.CollectionTasks).BulkWrite(ctx, restores, options.BulkWrite().SetOrdered(false))
+	return err
 }
 
-func (r *MongoRepository) UpdateTaskGroup(ctx context.Context, taskGroupUUID string, taskGroup *models.TaskGroup) error {
-	collection := r.db.Collection(database.CollectionTaskGroups)
+// transactionsUnsupported reports whether err means the server cannot run transactions (standalone mongod)
+// RunInTransaction runs fn in a transaction. The repository calls fn makes with the context it is given join the
+// transaction, including nested RunInTransaction calls. fn is run again when the transaction is retried after a
+// transient error. On a standalone server, which has no transactions, fn runs without one.
+func (r *MongoRepository) RunInTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if mongo.SessionFromContext(ctx) != nil {
+		return fn(ctx)
+	}
 
-	filter := bson.M{"uuid": taskGroupUUID}
-	update := bson.M{"$set": taskGroup}
+	session, err := r.db.Client().StartSession()
+	if err != nil {
+		return err
+	}
+	defer session.EndSession(ctx)
 
-	_, err := collection.UpdateOne(ctx, filter, update)
+	_, err = session.WithTransaction(ctx, func(sc mongo.SessionContext) (interface{}, error) {
+		return nil, fn(sc)
+	})
+	if err != nil && transactionsUnsupported(err) {
+		return fn(ctx)
+	}
 	return err
 }
 
+func transactionsUnsupported(err error) bool {
+	var cmdErr mongo.CommandError
+	// IllegalOperation: "Transaction numbers are only allowed on a replica set member or mongos"
+	return errors.As(err, &cmdErr) && cmdErr.Code == 20
+}
+
 func (r *MongoRepository) UpdateTaskGroupStatus(ctx context.Context, taskGroupUUID string, status models.TaskGroupStatus) error {
 	collection := r.db.Collection(database.CollectionTaskGroups)
 
@@ -421,6 +1615,14 @@ func (r *MongoRepository) GetActiveTaskGroupsWithWindows(ctx context.Context) ([
 	return taskGroups, nil
 }
 
+// aggregateFunc runs an aggregation pipeline over executions, so the same pipelines serve the single executions
+// collection and time-partitioned storage (see PartitionedRepository)
+type aggregateFunc func(ctx context.Context, pipeline []bson.M) (*mongo.Cursor, error)
+
+func (r *MongoRepository) aggregateExecutions(ctx context.Context, pipeline []bson.M) (*mongo.Cursor, error) {
+	return r.db.Collection(database.CollectionExecutions).Aggregate(ctx, pipeline)
+}
+
 func (r *MongoRepository) CreateExecution(ctx context.Context, execution *models.Execution) error {
 	collection := r.db.Collection(database.CollectionExecutions)
 	_, err := collection.InsertOne(ctx, execution)
@@ -531,8 +1733,12 @@ func (r *MongoRepository) GetExecutionsByTaskUUIDPaginated(ctx context.Context,
 func (r *MongoRepository) AppendLogToExecution(ctx context.Context, executionUUID string, logEntry models.LogEntry) error {
 	collection := r.db.Collection(database.CollectionExecutions)
 
-	filter := bson.M{"uuid": executionUUID}
-	update := bson.M{
+	_, err := collection.UpdateOne(ctx, bson.M{"uuid": executionUUID}, appendLogUpdate(logEntry))
+	return err
+}
+
+func appendLogUpdate(logEntry models.LogEntry) bson.M {
+	return bson.M{
 		"$push": bson.M{
 			"logs": logEntry,
 		},
@@ -540,15 +1746,28 @@ func (r *MongoRepository) AppendLogToExecution(ctx context.Context, executionUUI
 			"updated_at": time.Now(),
 		},
 	}
+}
 
-	_, err := collection.UpdateOne(ctx, filter, update)
+func (r *MongoRepository) UpdateExecutionStatus(ctx context.Context, executionUUID string, status models.ExecutionStatus, errorMessage *string) error {
+	collection := r.db.Collection(database.CollectionExecutions)
+
+	_, err := collection.UpdateOne(ctx, bson.M{"uuid": executionUUID}, executionStatusUpdate(status, errorMessage))
 	return err
 }
 
-func (r *MongoRepository) UpdateExecutionStatus(ctx context.Context, executionUUID string, status models.ExecutionStatus, errorMessage *string) error {
+// RecordExecutionEndpoint records which execution endpoint the execution was sent to
+func (r *MongoRepository) RecordExecutionEndpoint(ctx context.Context, executionUUID string, endpoint string) error {
 	collection := r.db.Collection(database.CollectionExecutions)
 
-	filter := bson.M{"uuid": executionUUID}
+	_, err := collection.UpdateOne(ctx, bson.M{"uuid": executionUUID}, executionEndpointUpdate(endpoint))
+	return err
+}
+
+func executionEndpointUpdate(endpoint string) bson.M {
+	return bson.M{"$set": bson.M{"endpoint": endpoint, "updated_at": time.Now()}}
+}
+
+func executionStatusUpdate(status models.ExecutionStatus, errorMessage *string) bson.M {
 	now := time.Now()
 
 	update := bson.M{
@@ -568,8 +1787,101 @@ func (r *MongoRepository) UpdateExecutionStatus(ctx context.Context, executionUU
 		update["$set"].(bson.M)["error"] = *errorMessage
 	}
 
-	_, err := collection.UpdateOne(ctx, filter, update)
-	return err
+	return update
+}
+
+// FailExecutionIfUnfinished marks a QUEUED, PENDING or RUNNING execution as FAILED in a single conditional update,
+// so a status reported concurrently by the client is never overwritten. Reports whether the execution was failed.
+func (r *MongoRepository) FailExecutionIfUnfinished(ctx context.Context, executionUUID string, errorMessage string) (bool, error) {
+	collection := r.db.Collection(database.CollectionExecutions)
+
+	result, err := collection.UpdateOne(ctx, unfinishedExecutionFilter(executionUUID), failExecutionUpdate(errorMessage))
+	if err != nil {
+		return false, err
+	}
+	return result.ModifiedCount > 0, nil
+}
+
+// StartQueuedExecution moves a QUEUED execution to PENDING once it is dispatched, in a single conditional update so
+// an execution cancelled while it waited stays cancelled. Reports whether the execution was started.
+func (r *MongoRepository) StartQueuedExecution(ctx context.Context, executionUUID string, startedAt time.Time) (bool, error) {
+	collection := r.db.Collection(database.CollectionExecutions)
+
+	result, err := collection.UpdateOne(ctx, queuedExecutionFilter(executionUUID), startQueuedExecutionUpdate(startedAt))
+	if err != nil {
+		return false, err
+	}
+	return result.ModifiedCount > 0, nil
+}
+
+// CountActiveExecutions counts the PENDING or RUNNING executions of the tasks started at or after since
+func (r *MongoRepository) CountActiveExecutions(ctx context.Context, taskUUIDs []string, since time.Time) (int64, error) {
+	collection := r.db.Collection(database.CollectionExecutions)
+	return collection.CountDocuments(ctx, activeExecutionsFilter(taskUUIDs, since))
+}
+
+func activeExecutionsFilter(taskUUIDs []string, since time.Time) bson.M {
+	return bson.M{
+		"task_uuid":  bson.M{"$in": taskUUIDs},
+		"status":     bson.M{"$in": bson.A{models.ExecutionStatusPending, models.ExecutionStatusRunning}},
+		"started_at": bson.M{"$gte": since},
+	}
+}
+
+func (r *MongoRepository) RecordExecutionHeartbeat(ctx context.Context, executionUUID string, at time.Time) (bool, error) {
+	collection := r.db.Collection(database.CollectionExecutions)
+
+	result, err := collection.UpdateOne(ctx, unfinishedExecutionFilter(executionUUID), heartbeatUpdate(at))
+	if err != nil {
+		return false, err
+	}
+	return result.MatchedCount > 0, nil
+}
+
+func unfinishedExecutionFilter(executionUUID string) bson.M {
+	return bson.M{
+		"uuid": executionUUID,
+		"status": bson.M{"$in": bson.A{
+			models.ExecutionStatusQueued,
+			models.ExecutionStatusPending,
+			models.ExecutionStatusRunning,
+		}},
+	}
+}
+
+func failExecutionUpdate(errorMessage string) bson.M {
+	now := time.Now()
+	return bson.M{
+		"$set": bson.M{
+			"status":     models.ExecutionStatusFailed,
+			"error":      errorMessage,
+			"ended_at":   now,
+			"updated_at": now,
+		},
+	}
+}
+
+func queuedExecutionFilter(executionUUID string) bson.M {
+	return bson.M{"uuid": executionUUID, "status": models.ExecutionStatusQueued}
+}
+
+func startQueuedExecutionUpdate(startedAt time.Time) bson.M {
+	return bson.M{
+		"$set": bson.M{
+			"status":     models.ExecutionStatusPending,
+			"started_at": startedAt,
+			"updated_at": startedAt,
+		},
+	}
+}
+
+func heartbeatUpdate(at time.Time) bson.M {
+	return bson.M{
+		"$set": bson.M{
+			"heartbeat_at": at,
+			"updated_at":   at,
+		},
+	}
 }
 
 func (r *MongoRepository) GetExecutionByUUID(ctx context.Context, executionUUID string) (*models.Execution, error) {
@@ -584,6 +1896,244 @@ func (r *MongoRepository) GetExecutionByUUID(ctx context.Context, executionUUID
 	return &execution, nil
 }
 
+// GetLatestExecutionByTaskUUID retrieves the most recent execution of a task without its logs
+func (r *MongoRepository) GetLatestExecutionByTaskUUID(ctx context.Context, taskUUID string) (*models.Execution, error) {
+	collection := r.db.Collection(database.CollectionExecutions)
+
+	opts := options.FindOne().
+		SetSort(bson.M{"started_at": -1}).
+		SetProjection(bson.M{"logs": 0})
+
+	var execution models.Execution
+	err := collection.FindOne(ctx, bson.M{"task_uuid": taskUUID}, opts).Decode(&execution)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil // Task has never run
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &execution, nil
+}
+
+// GetLatestExecutionsByTaskUUIDs retrieves the most recent execution of each task in one query, without logs
+func (r *MongoRepository) GetLatestExecutionsByTaskUUIDs(ctx context.Context, taskUUIDs []string) (map[string]*models.Execution, error) {
+	return latestExecutionsByTaskUUIDs(ctx, r.aggregateExecutions, taskUUIDs)
+}
+
+func latestExecutionsByTaskUUIDs(ctx context.Context, aggregate aggregateFunc, taskUUIDs []string) (map[string]*models.Execution, error) {
+	latest := make(map[string]*models.Execution, len(taskUUIDs))
+	if len(taskUUIDs) == 0 {
+		return latest, nil
+	}
+
+	pipeline := []bson.M{
+		{"$match": bson.M{"task_uuid": bson.M{"$in": taskUUIDs}}},
+		{"$sort": bson.M{"started_at": -1}},
+		{"$project": bson.M{"logs": 0}},
+		{"$group": bson.M{
+			"_id":       "$task_uuid",
+			"execution": bson.M{"$first": "$$ROOT"},
+		}},
+	}
+
+	cursor, err := aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var results []struct {
+		TaskUUID  string           `bson:"_id"`
+		Execution models.Execution `bson:"execution"`
+	}
+	if err := cursor.All(ctx, &results); err != nil {
+		return nil, err
+	}
+
+	for i := range results {
+		latest[results[i].TaskUUID] = &results[i].Execution
+	}
+	return latest, nil
+}
+
+// GetLastSuccessByTaskUUIDs retrieves when each task last completed successfully, in one query
+func (r *MongoRepository) GetLastSuccessByTaskUUIDs(ctx context.Context, taskUUIDs []string) (map[string]time.Time, error) {
+	return lastSuccessByTaskUUIDs(ctx, r.aggregateExecutions, taskUUIDs)
+}
+
+func lastSuccessByTaskUUIDs(ctx context.Context, aggregate aggregateFunc, taskUUIDs []string) (map[string]time.Time, error) {
+	lastSuccess := make(map[string]time.Time, len(taskUUIDs))
+	if len(taskUUIDs) == 0 {
+		return lastSuccess, nil
+	}
+
+	pipeline := []bson.M{
+		{"$match": bson.M{
+			"task_uuid": bson.M{"$in": taskUUIDs},
+			"status":    models.ExecutionStatusSuccess,
+		}},
+		{"$group": bson.M{
+			"_id":          "$task_uuid",
+			"last_success": bson.M{"$max": bson.M{"$ifNull": bson.A{"$ended_at", "$started_at"}}},
+		}},
+	}
+
+	cursor, err := aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var results []struct {
+		TaskUUID    string    `bson:"_id"`
+		LastSuccess time.Time `bson:"last_success"`
+	}
+	if err := cursor.All(ctx, &results); err != nil {
+		return nil, err
+	}
+
+	for _, result := range results {
+		lastSuccess[result.TaskUUID] = result.LastSuccess
+	}
+	return lastSuccess, nil
+}
+
+// DeleteExecutionsByTaskUUIDs removes all executions of the given tasks and returns how many were deleted
+func (r *MongoRepository) DeleteExecutionsByTaskUUIDs(ctx context.Context, taskUUIDs []string) (int64, error) {
+	if len(taskUUIDs) == 0 {
+		return 0, nil
+	}
+
+	collection := r.db.Collection(database.CollectionExecutions)
+
+	result, err := collection.DeleteMany(ctx, bson.M{"task_uuid": bson.M{"$in": taskUUIDs}})
+	if err != nil {
+		return 0, err
+	}
+	return result.DeletedCount, nil
+}
+
+// DeleteExecutionsByTaskUUIDsBefore removes executions of the given tasks that started before the cutoff
+func (r *MongoRepository) DeleteExecutionsByTaskUUIDsBefore(ctx context.Context, taskUUIDs []string, before time.Time) (int64, error) {
+	if len(taskUUIDs) == 0 {
+		return 0, nil
+	}
+
+	collection := r.db.Collection(database.CollectionExecutions)
+
+	filter := bson.M{
+		"task_uuid":  bson.M{"$in": taskUUIDs},
+		"started_at": bson.M{"$lt": before},
+	}
+
+	result, err := collection.DeleteMany(ctx, filter)
+	if err != nil {
+		return 0, err
+	}
+	return result.DeletedCount, nil
+}
+
+// GetExecutionsByTaskUUIDsBetween returns up to limit executions of the given tasks that started from through to,
+// oldest first and without logs
+func (r *MongoRepository) GetExecutionsByTaskUUIDsBetween(ctx context.Context, taskUUIDs []string, from, to time.Time, limit int) ([]*models.Execution, error) {
+	if len(taskUUIDs) == 0 {
+		return []*models.Execution{}, nil
+	}
+
+	collection := r.db.Collection(database.CollectionExecutions)
+
+	filter := bson.M{
+		"task_uuid":  bson.M{"$in": taskUUIDs},
+		"started_at": bson.M{"$gte": from.UTC(), "$lte": to.UTC()},
+	}
+	opts := options.Find().
+		SetSort(bson.M{"started_at": 1}).
+		SetLimit(int64(limit)).
+		SetProjection(bson.M{"logs": 0})
+
+	cursor, err := collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	executions := []*models.Execution{}
+	if err := cursor.All(ctx, &executions); err != nil {
+		return nil, err
+	}
+	return executions, nil
+}
+
+// TrimExecutionLogsBefore removes the logs of executions of the given tasks with the given statuses that started
+// before the cutoff, keeping the executions. Returns how many executions had their logs removed.
+func (r *MongoRepository) TrimExecutionLogsBefore(ctx context.Context, taskUUIDs []string, statuses []models.ExecutionStatus, before time.Time) (int64, error) {
+	if len(taskUUIDs) == 0 || len(statuses) == 0 {
+		return 0, nil
+	}
+
+	collection := r.db.Collection(database.CollectionExecutions)
+
+	result, err := collection.UpdateMany(ctx, trimLogsFilter(taskUUIDs, statuses, before), trimLogsUpdate(time.Now()))
+	if err != nil {
+		return 0, err
+	}
+	return result.ModifiedCount, nil
+}
+
+func trimLogsFilter(taskUUIDs []string, statuses []models.ExecutionStatus, before time.Time) bson.M {
+	return bson.M{
+		"task_uuid":  bson.M{"$in": taskUUIDs},
+		"status":     bson.M{"$in": statuses},
+		"started_at": bson.M{"$lt": before},
+		"logs.0":     bson.M{"$exists": true},
+	}
+}
+
+func trimLogsUpdate(now time.Time) bson.M {
+	return bson.M{
+		"$unset": bson.M{"logs": ""},
+		"$set":   bson.M{"logs_trimmed_at": now, "updated_at": now},
+	}
+}
+
+// GetExecutionBatchByTaskUUID returns up to limit executions of a task, oldest first and without logs
+func (r *MongoRepository) GetExecutionBatchByTaskUUID(ctx context.Context, taskUUID string, limit int) ([]*models.Execution, error) {
+	collection := r.db.Collection(database.CollectionExecutions)
+
+	opts := options.Find().
+		SetSort(bson.D{{Key: "started_at", Value: 1}}).
+		SetLimit(int64(limit)).
+		SetProjection(bson.M{"logs": 0})
+
+	cursor, err := collection.Find(ctx, bson.M{"task_uuid": taskUUID}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var executions []*models.Execution
+	if err := cursor.All(ctx, &executions); err != nil {
+		return nil, err
+	}
+	return executions, nil
+}
+
+// DeleteExecutionsByIDs removes the given executions and returns how many were deleted
+func (r *MongoRepository) DeleteExecutionsByIDs(ctx context.Context, executionIDs []primitive.ObjectID) (int64, error) {
+	if len(executionIDs) == 0 {
+		return 0, nil
+	}
+
+	collection := r.db.Collection(database.CollectionExecutions)
+
+	result, err := collection.DeleteMany(ctx, bson.M{"_id": bson.M{"$in": executionIDs}})
+	if err != nil {
+		return 0, err
+	}
+	return result.DeletedCount, nil
+}
+
 func (r *MongoRepository) IncrementFailureStat(ctx context.Context, projectID primitive.ObjectID, date string) error {
 	collection := r.db.Collection(database.CollectionExecutionFailureStats)
 
@@ -598,12 +2148,40 @@ func (r *MongoRepository) IncrementFailureStat(ctx context.Context, projectID pr
 		"$setOnInsert": bson.M{
 			"project_id": projectID,
 			"date":       date,
-		},
-	}
+		},
+	}
+
+	opts := options.Update().SetUpsert(true)
+	_, err := collection.UpdateOne(ctx, filter, update, opts)
+	return err
+}
+
+// DecrementFailureStats subtracts failures from a project's daily counters, clamping at zero
+func (r *MongoRepository) DecrementFailureStats(ctx context.Context, projectID primitive.ObjectID, countsByDate map[string]int) error {
+	collection := r.db.Collection(database.CollectionExecutionFailureStats)
+
+	now := time.Now()
+	for date, count := range countsByDate {
+		if count <= 0 {
+			continue
+		}
+
+		filter := bson.M{
+			"project_id": projectID,
+			"date":       date,
+		}
+		update := mongo.Pipeline{
+			{{Key: "$set", Value: bson.M{
+				"count":      bson.M{"$max": bson.A{bson.M{"$subtract": bson.A{"$count", count}}, 0}},
+				"updated_at": now,
+			}}},
+		}
 
-	opts := options.Update().SetUpsert(true)
-	_, err := collection.UpdateOne(ctx, filter, update, opts)
-	return err
+		if _, err := collection.UpdateOne(ctx, filter, update); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 func (r *MongoRepository) GetFailureStatsByProject(ctx context.Context, projectID primitive.ObjectID, days int) ([]*models.FailedExecutionStats, int, error) {
@@ -649,8 +2227,10 @@ func (r *MongoRepository) GetFailureStatsByProject(ctx context.Context, projectI
 }
 
 func (r *MongoRepository) GetExecutionStatsByProject(ctx context.Context, projectID primitive.ObjectID, days int) ([]*models.ExecutionStats, error) {
-	collection := r.db.Collection(database.CollectionExecutions)
+	return r.executionStatsByProject(ctx, r.aggregateExecutions, projectID, days)
+}
 
+func (r *MongoRepository) executionStatsByProject(ctx context.Context, aggregate aggregateFunc, projectID primitive.ObjectID, days int) ([]*models.ExecutionStats, error) {
 	// Calculate date range (last N days)
 	now := time.Now().UTC()
 	startDate := now.AddDate(0, 0, -days)
@@ -719,7 +2299,7 @@ func (r *MongoRepository) GetExecutionStatsByProject(ctx context.Context, projec
 		},
 	}
 
-	cursor, err := collection.Aggregate(ctx, pipeline)
+	cursor, err := aggregate(ctx, pipeline)
 	if err != nil {
 		return nil, err
 	}
@@ -791,8 +2371,10 @@ func (r *MongoRepository) GetTaskFailuresByDate(ctx context.Context, projectID p
 // CalculateTaskFailureStats calculates task failure stats for a given project and date
 // This is the same logic as GetTaskFailuresByDate but returns a StoredTaskFailureStats
 func (r *MongoRepository) CalculateTaskFailureStats(ctx context.Context, projectID primitive.ObjectID, date string) (*models.StoredTaskFailureStats, error) {
-	collection := r.db.Collection(database.CollectionExecutions)
+	return r.calculateTaskFailureStats(ctx, r.aggregateExecutions, projectID, date)
+}
 
+func (r *MongoRepository) calculateTaskFailureStats(ctx context.Context, aggregate aggregateFunc, projectID primitive.ObjectID, date string) (*models.StoredTaskFailureStats, error) {
 	// Parse date string (YYYY-MM-DD) to time range
 	parsedDate, err := time.Parse("2006-01-02", date)
 	if err != nil {
@@ -854,7 +2436,7 @@ func (r *MongoRepository) CalculateTaskFailureStats(ctx context.Context, project
 		},
 	}
 
-	cursor, err := collection.Aggregate(ctx, pipeline)
+	cursor, err := aggregate(ctx, pipeline)
 	if err != nil {
 		return nil, err
 	}
@@ -895,6 +2477,18 @@ func (r *MongoRepository) CalculateTaskFailureStats(ctx context.Context, project
 	}, nil
 }
 
+// DeleteStatsByProjectID removes all failure counters and stored task failure stats for a project
+func (r *MongoRepository) DeleteStatsByProjectID(ctx context.Context, projectID primitive.ObjectID) error {
+	filter := bson.M{"project_id": projectID}
+
+	if _, err := r.db.Collection(database.CollectionExecutionFailureStats).DeleteMany(ctx, filter); err != nil {
+		return err
+	}
+
+	_, err := r.db.Collection(database.CollectionTaskFailureStats).DeleteMany(ctx, filter)
+	return err
+}
+
 // StoreTaskFailureStats stores pre-calculated task failure stats (upsert)
 func (r *MongoRepository) StoreTaskFailureStats(ctx context.Context, stats *models.StoredTaskFailureStats) error {
 	collection := r.db.Collection(database.CollectionTaskFailureStats)
@@ -922,6 +2516,37 @@ func (r *MongoRepository) StoreTaskFailureStats(ctx context.Context, stats *mode
 	return err
 }
 
+// RemoveTaskFromStoredFailureStats removes a task from every stored daily breakdown of its project
+// and subtracts its failures from the daily totals. Safe to repeat.
+func (r *MongoRepository) RemoveTaskFromStoredFailureStats(ctx context.Context, projectID primitive.ObjectID, taskUUID string) error {
+	collection := r.db.Collection(database.CollectionTaskFailureStats)
+
+	filter := bson.M{
+		"project_id":   projectID,
+		"tasks.taskid": taskUUID,
+	}
+
+	taskEntries := bson.M{"$filter": bson.M{
+		"input": "$tasks",
+		"cond":  bson.M{"$eq": bson.A{"$$this.taskid", taskUUID}},
+	}}
+	update := mongo.Pipeline{
+		{{Key: "$set", Value: bson.M{
+			"total": bson.M{"$max": bson.A{
+				bson.M{"$subtract": bson.A{"$total", bson.M{"$sum": bson.M{"$map": bson.M{"input": taskEntries, "in": "$$this.failures"}}}}},
+				0,
+			}},
+			"tasks": bson.M{"$filter": bson.M{
+				"input": "$tasks",
+				"cond":  bson.M{"$ne": bson.A{"$$this.taskid", taskUUID}},
+			}},
+		}}},
+	}
+
+	_, err := collection.UpdateMany(ctx, filter, update)
+	return err
+}
+
 // GetStoredTaskFailureStats retrieves pre-calculated task failure stats
 func (r *MongoRepository) GetStoredTaskFailureStats(ctx context.Context, projectID primitive.ObjectID, date string) (*models.StoredTaskFailureStats, error) {
 	collection := r.db.Collection(database.CollectionTaskFailureStats)
@@ -943,8 +2568,513 @@ func (r *MongoRepository) GetStoredTaskFailureStats(ctx context.Context, project
 	return &stats, nil
 }
 
+// GetProjectSettings retrieves a project's settings
+func (r *MongoRepository) GetProjectSettings(ctx context.Context, projectID primitive.ObjectID) (*models.ProjectSettings, error) {
+	collection := r.db.Collection(database.CollectionProjectSettings)
+
+	var settings models.ProjectSettings
+	err := collection.FindOne(ctx, bson.M{"project_id": projectID}).Decode(&settings)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil // Not found, callers fall back to built-in defaults
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &settings, nil
+}
+
+// GetProjectSettingsWithRetention retrieves the settings of all projects that have an execution retention period
+func (r *MongoRepository) GetProjectSettingsWithRetention(ctx context.Context) ([]*models.ProjectSettings, error) {
+	collection := r.db.Collection(database.CollectionProjectSettings)
+
+	cursor, err := collection.Find(ctx, bson.M{"execution_retention_days": bson.M{"$gt": 0}})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var settings []*models.ProjectSettings
+	if err := cursor.All(ctx, &settings); err != nil {
+		return nil, err
+	}
+
+	return settings, nil
+}
+
+// UpsertProjectSettings replaces a project's settings (upsert)
+func (r *MongoRepository) UpsertProjectSettings(ctx context.Context, settings *models.ProjectSettings) error {
+	collection := r.db.Collection(database.CollectionProjectSettings)
+
+	settings.UpdatedAt = time.Now()
+
+	opts := options.Replace().SetUpsert(true)
+	_, err := collection.ReplaceOne(ctx, bson.M{"project_id": settings.ProjectID}, settings, opts)
+	return err
+}
+
+// DeleteProjectSettings removes a project's settings. It is a no-op when none are stored.
+func (r *MongoRepository) DeleteProjectSettings(ctx context.Context, projectID primitive.ObjectID) error {
+	collection := r.db.Collection(database.CollectionProjectSettings)
+
+	_, err := collection.DeleteOne(ctx, bson.M{"project_id": projectID})
+	return err
+}
+
+// CreateTaskTemplate stores a project task template
+func (r *MongoRepository) CreateTaskTemplate(ctx context.Context, template *models.TaskTemplate) error {
+	collection := r.db.Collection(database.CollectionTaskTemplates)
+
+	result, err := collection.InsertOne(ctx, template)
+	if err != nil {
+		return err
+	}
+	if oid, ok := result.InsertedID.(primitive.ObjectID); ok {
+		template.ID = oid
+	}
+	return nil
+}
+
+// GetTaskTemplatesByProjectID returns a project's task templates sorted by name
+func (r *MongoRepository) GetTaskTemplatesByProjectID(ctx context.Context, projectID primitive.ObjectID) ([]*models.TaskTemplate, error) {
+	collection := r.db.Collection(database.CollectionTaskTemplates)
+
+	opts := options.Find().SetSort(bson.D{{Key: "name", Value: 1}})
+	cursor, err := collection.Find(ctx, bson.M{"project_id": projectID}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var templates []*models.TaskTemplate
+	if err := cursor.All(ctx, &templates); err != nil {
+		return nil, err
+	}
+	return templates, nil
+}
+
+// GetTaskTemplateByUUID returns a project task template. Returns mongo.ErrNoDocuments if not found.
+func (r *MongoRepository) GetTaskTemplateByUUID(ctx context.Context, projectID primitive.ObjectID, templateUUID string) (*models.TaskTemplate, error) {
+	collection := r.db.Collection(database.CollectionTaskTemplates)
+
+	var template models.TaskTemplate
+	err := collection.FindOne(ctx, bson.M{"project_id": projectID, "uuid": templateUUID}).Decode(&template)
+	if err != nil {
+		return nil, err
+	}
+	return &template, nil
+}
+
+// DeleteTaskTemplate removes a project task template. Returns mongo.ErrNoDocuments if not found.
+func (r *MongoRepository) DeleteTaskTemplate(ctx context.Context, projectID primitive.ObjectID, templateUUID string) error {
+	collection := r.db.Collection(database.CollectionTaskTemplates)
+
+	result, err := collection.DeleteOne(ctx, bson.M{"project_id": projectID, "uuid": templateUUID})
+	if err != nil {
+		return err
+	}
+	if result.DeletedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+// DeleteTaskTemplatesByProjectID removes all task templates of a project
+func (r *MongoRepository) DeleteTaskTemplatesByProjectID(ctx context.Context, projectID primitive.ObjectID) error {
+	collection := r.db.Collection(database.CollectionTaskTemplates)
+
+	_, err := collection.DeleteMany(ctx, bson.M{"project_id": projectID})
+	return err
+}
+
 func NewMongoRepository(db *mongo.Database) *MongoRepository {
 	return &MongoRepository{
 		db: db,
 	}
 }
+
+// IncrementUsage adds to a project's usage counters of the day
+func (r *MongoRepository) IncrementUsage(ctx context.Context, projectID primitive.ObjectID, date string, counters models.UsageCounters) error {
+	collection := r.db.Collection(database.CollectionUsage)
+
+	filter := bson.M{
+		"project_id": projectID,
+		"date":       date,
+	}
+	update := bson.M{
+		"$inc": bson.M{
+			"executions":  counters.Executions,
+			"alerts_sent": counters.AlertsSent,
+			"log_bytes":   counters.LogBytes,
+		},
+		"$set": bson.M{"updated_at": time.Now()},
+		"$setOnInsert": bson.M{
+			"project_id": projectID,
+			"date":       date,
+		},
+	}
+
+	_, err := collection.UpdateOne(ctx, filter, update, options.Update().SetUpsert(true))
+	return err
+}
+
+// GetUsage returns the usage records matching the filter, by date, then project
+func (r *MongoRepository) GetUsage(ctx context.Context, filter models.UsageFilter) ([]*models.UsageRecord, error) {
+	collection := r.db.Collection(database.CollectionUsage)
+
+	query := bson.M{}
+	if filter.ProjectID != nil {
+		query["project_id"] = *filter.ProjectID
+	}
+	date := bson.M{}
+	if filter.From != "" {
+		date["$gte"] = filter.From
+	}
+	if filter.To != "" {
+		date["$lte"] = filter.To
+	}
+	if len(date) > 0 {
+		query["date"] = date
+	}
+
+	opts := options.Find().SetSort(bson.D{{Key: "date", Value: 1}, {Key: "project_id", Value: 1}})
+	cursor, err := collection.Find(ctx, query, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var records []*models.UsageRecord
+	if err := cursor.All(ctx, &records); err != nil {
+		return nil, err
+	}
+	return records, nil
+}
+
+// CreateOutboxEvent persists an event for delivery by the outbox dispatcher
+func (r *MongoRepository) CreateOutboxEvent(ctx context.Context, event *models.OutboxEvent) error {
+	collection := r.db.Collection(database.CollectionEventOutbox)
+
+	if event.ID.IsZero() {
+		event.ID = primitive.NewObjectID()
+	}
+	if event.CreatedAt.IsZero() {
+		event.CreatedAt = time.Now()
+	}
+	_, err := collection.InsertOne(ctx, event)
+	return err
+}
+
+// ClaimOutboxEvents locks up to limit undelivered events, oldest first, so that only this dispatcher delivers them
+// until the lease expires. Each event is claimed atomically, so concurrent dispatchers never claim the same event.
+func (r *MongoRepository) ClaimOutboxEvents(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*models.OutboxEvent, error) {
+	collection := r.db.Collection(database.CollectionEventOutbox)
+
+	filter := bson.M{"locked_until": bson.M{"$lte": now}}
+	update := bson.M{
+		"$set": bson.M{"locked_until": now.Add(lease)},
+		"$inc": bson.M{"attempts": 1},
+	}
+	opts := options.FindOneAndUpdate().
+		SetSort(bson.D{{Key: "created_at", Value: 1}}).
+		SetReturnDocument(options.After)
+
+	var claimed []*models.OutboxEvent
+	for len(claimed) < limit {
+		var event models.OutboxEvent
+		err := collection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&event)
+		if err == mongo.ErrNoDocuments {
+			break
+		}
+		if err != nil {
+			return claimed, err
+		}
+		claimed = append(claimed, &event)
+	}
+	return claimed, nil
+}
+
+// DeleteOutboxEvent removes a delivered event
+func (r *MongoRepository) DeleteOutboxEvent(ctx context.Context, id primitive.ObjectID) error {
+	collection := r.db.Collection(database.CollectionEventOutbox)
+
+	_, err := collection.DeleteOne(ctx, bson.M{"_id": id})
+	return err
+}
+
+// CreateQueuedJob persists a job for the in-process job queue
+func (r *MongoRepository) CreateQueuedJob(ctx context.Context, job *models.QueuedJob) error {
+	collection := r.db.Collection(database.CollectionJobQueue)
+
+	if job.ID.IsZero() {
+		job.ID = primitive.NewObjectID()
+	}
+	if job.CreatedAt.IsZero() {
+		job.CreatedAt = time.Now()
+	}
+	_, err := collection.InsertOne(ctx, job)
+	return err
+}
+
+// ClaimQueuedJobs locks up to limit unacknowledged jobs, oldest first, so that only this consumer runs them until
+// the lease expires. Each job is claimed atomically, so consumers on other replicas never claim the same job.
+func (r *MongoRepository) ClaimQueuedJobs(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*models.QueuedJob, error) {
+	collection := r.db.Collection(database.CollectionJobQueue)
+
+	filter := bson.M{"locked_until": bson.M{"$lte": now}}
+	update := bson.M{
+		"$set": bson.M{"locked_until": now.Add(lease)},
+		"$inc": bson.M{"attempts": 1},
+	}
+	opts := options.FindOneAndUpdate().
+		SetSort(bson.D{{Key: "created_at", Value: 1}}).
+		SetReturnDocument(options.After)
+
+	var claimed []*models.QueuedJob
+	for len(claimed) < limit {
+		var job models.QueuedJob
+		err := collection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&job)
+		if err == mongo.ErrNoDocuments {
+			break
+		}
+		if err != nil {
+			return claimed, err
+		}
+		claimed = append(claimed, &job)
+	}
+	return claimed, nil
+}
+
+// DeleteQueuedJob removes a processed job
+func (r *MongoRepository) DeleteQueuedJob(ctx context.Context, id primitive.ObjectID) error {
+	collection := r.db.Collection(database.CollectionJobQueue)
+
+	_, err := collection.DeleteOne(ctx, bson.M{"_id": id})
+	return err
+}
+
+// GetTaskReferences returns the project and task group of every task, of any status
+func (r *MongoRepository) GetTaskReferences(ctx context.Context) ([]*models.TaskReference, error) {
+	collection := r.db.Collection(database.CollectionTasks)
+
+	opts := options.Find().SetProjection(bson.M{"uuid": 1, "project_id": 1, "task_group_id": 1})
+	cursor, err := collection.Find(ctx, bson.M{}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	references := []*models.TaskReference{}
+	if err := cursor.All(ctx, &references); err != nil {
+		return nil, err
+	}
+	return references, nil
+}
+
+// GetExecutionTaskUUIDs returns the distinct task UUIDs of all executions
+func (r *MongoRepository) GetExecutionTaskUUIDs(ctx context.Context) ([]string, error) {
+	return distinctTaskUUIDs(ctx, r.db.Collection(database.CollectionExecutions), nil)
+}
+
+// distinctTaskUUIDs adds the distinct task UUIDs of the executions collection to seen and returns the new ones
+func distinctTaskUUIDs(ctx context.Context, collection *mongo.Collection, seen map[string]bool) ([]string, error) {
+	values, err := collection.Distinct(ctx, "task_uuid", bson.M{})
+	if err != nil {
+		return nil, err
+	}
+	taskUUIDs := make([]string, 0, len(values))
+	for _, value := range values {
+		taskUUID, ok := value.(string)
+		if !ok || seen[taskUUID] {
+			continue
+		}
+		if seen != nil {
+			seen[taskUUID] = true
+		}
+		taskUUIDs = append(taskUUIDs, taskUUID)
+	}
+	return taskUUIDs, nil
+}
+
+// GetStatsProjectIDs returns the distinct projects of the daily failure counters and stored task failure stats
+func (r *MongoRepository) GetStatsProjectIDs(ctx context.Context) ([]primitive.ObjectID, error) {
+	seen := make(map[primitive.ObjectID]bool)
+	projectIDs := []primitive.ObjectID{}
+	for _, name := range []string{database.CollectionExecutionFailureStats, database.CollectionTaskFailureStats} {
+		values, err := r.db.Collection(name).Distinct(ctx, "project_id", bson.M{})
+		if err != nil {
+			return nil, err
+		}
+		for _, value := range values {
+			projectID, ok := value.(primitive.ObjectID)
+			if !ok || seen[projectID] {
+				continue
+			}
+			seen[projectID] = true
+			projectIDs = append(projectIDs, projectID)
+		}
+	}
+	return projectIDs, nil
+}
+
+// activeIncident matches the incidents that are not resolved
+var activeIncident = bson.M{"$ne": models.IncidentStatusResolved}
+
+// RecordIncidentFailure adds a failed execution to the task's active incident, or opens an incident when the task
+// has none. The returned incident has FailureCount 1 when it was opened by this failure. When a concurrent failure
+// opened the incident first, the unique idx_task_uuid_active index rejects the second upsert, which is retried to
+// add the failure to that incident.
+func (r *MongoRepository) RecordIncidentFailure(ctx context.Context, failure models.IncidentFailure) (*models.Incident, error) {
+	collection := r.db.Collection(database.CollectionIncidents)
+
+	filter := bson.M{"task_uuid": failure.TaskUUID, "status": activeIncident}
+	update := bson.M{
+		"$inc": bson.M{"failure_count": 1},
+		"$set": bson.M{
+			"last_execution_uuid": failure.ExecutionUUID,
+			"last_error":          failure.Error,
+			"last_failure_at":     failure.FailedAt,
+			"updated_at":          failure.FailedAt,
+		},
+		"$setOnInsert": bson.M{
+			"uuid":                 failure.IncidentUUID,
+			"project_id":           failure.ProjectID,
+			"task_name":            failure.TaskName,
+			"severity":             failure.Severity,
+			"status":               models.IncidentStatusOpen,
+			"first_execution_uuid": failure.ExecutionUUID,
+			"opened_at":            failure.FailedAt,
+			"created_at":           failure.FailedAt,
+		},
+	}
+	opts := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)
+
+	var incident models.Incident
+	err := collection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&incident)
+	if mongo.IsDuplicateKeyError(err) {
+		err = collection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&incident)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &incident, nil
+}
+
+// ResolveActiveIncident resolves the task's active incident after a successful execution. Returns
+// mongo.ErrNoDocuments when the task has no active incident.
+func (r *MongoRepository) ResolveActiveIncident(ctx context.Context, taskUUID string, executionUUID string, resolvedAt time.Time) (*models.Incident, error) {
+	return r.updateIncident(ctx, bson.M{"task_uuid": taskUUID, "status": activeIncident}, bson.M{
+		"status":                  models.IncidentStatusResolved,
+		"resolved_at":             resolvedAt,
+		"resolved_execution_uuid": executionUUID,
+		"updated_at":              resolvedAt,
+	})
+}
+
+// GetIncidentByUUID returns an incident of a project. Returns mongo.ErrNoDocuments if not found.
+func (r *MongoRepository) GetIncidentByUUID(ctx context.Context, projectID primitive.ObjectID, incidentUUID string) (*models.Incident, error) {
+	collection := r.db.Collection(database.CollectionIncidents)
+
+	var incident models.Incident
+	err := collection.FindOne(ctx, bson.M{"project_id": projectID, "uuid": incidentUUID}).Decode(&incident)
+	if err != nil {
+		return nil, err
+	}
+	return &incident, nil
+}
+
+// ListIncidents returns a page of a project's incidents, most recently opened first, and the total count
+func (r *MongoRepository) ListIncidents(ctx context.Context, projectID primitive.ObjectID, filter models.IncidentListFilter, page, pageSize int) ([]*models.Incident, int64, error) {
+	collection := r.db.Collection(database.CollectionIncidents)
+
+	query := bson.M{"project_id": projectID}
+	if filter.Status != "" {
+		query["status"] = filter.Status
+	}
+	if filter.TaskUUID != "" {
+		query["task_uuid"] = filter.TaskUUID
+	}
+
+	totalCount, err := collection.CountDocuments(ctx, query)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	opts := options.Find().
+		SetSort(bson.D{{Key: "opened_at", Value: -1}}).
+		SetSkip(int64((page - 1) * pageSize)).
+		SetLimit(int64(pageSize))
+	cursor, err := collection.Find(ctx, query, opts)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer cursor.Close(ctx)
+
+	var incidents []*models.Incident
+	if err := cursor.All(ctx, &incidents); err != nil {
+		return nil, 0, err
+	}
+	return incidents, totalCount, nil
+}
+
+// AcknowledgeIncident marks an open incident as being looked into. Returns mongo.ErrNoDocuments when the incident
+// does not exist or is not OPEN.
+func (r *MongoRepository) AcknowledgeIncident(ctx context.Context, projectID primitive.ObjectID, incidentUUID string, acknowledgedBy string, acknowledgedAt time.Time) (*models.Incident, error) {
+	return r.updateIncident(ctx, bson.M{"project_id": projectID, "uuid": incidentUUID, "status": models.IncidentStatusOpen}, bson.M{
+		"status":          models.IncidentStatusAcknowledged,
+		"acknowledged_at": acknowledgedAt,
+		"acknowledged_by": acknowledgedBy,
+		"updated_at":      acknowledgedAt,
+	})
+}
+
+// ResolveIncident resolves an incident by hand. Returns mongo.ErrNoDocuments when the incident does not exist or
+// is already resolved.
+func (r *MongoRepository) ResolveIncident(ctx context.Context, projectID primitive.ObjectID, incidentUUID string, resolvedBy string, resolvedAt time.Time) (*models.Incident, error) {
+	return r.updateIncident(ctx, bson.M{"project_id": projectID, "uuid": incidentUUID, "status": activeIncident}, bson.M{
+		"status":      models.IncidentStatusResolved,
+		"resolved_at": resolvedAt,
+		"resolved_by": resolvedBy,
+		"updated_at":  resolvedAt,
+	})
+}
+
+// updateIncident sets fields of the incident matching filter and returns the updated incident
+func (r *MongoRepository) updateIncident(ctx context.Context, filter bson.M, set bson.M) (*models.Incident, error) {
+	collection := r.db.Collection(database.CollectionIncidents)
+
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+	var incident models.Incident
+	if err := collection.FindOneAndUpdate(ctx, filter, bson.M{"$set": set}, opts).Decode(&incident); err != nil {
+		return nil, err
+	}
+	return &incident, nil
+}
+
+// AddIncidentComment appends a comment to an incident. Returns mongo.ErrNoDocuments if not found.
+func (r *MongoRepository) AddIncidentComment(ctx context.Context, projectID primitive.ObjectID, incidentUUID string, comment models.IncidentComment) error {
+	collection := r.db.Collection(database.CollectionIncidents)
+
+	result, err := collection.UpdateOne(ctx,
+		bson.M{"project_id": projectID, "uuid": incidentUUID},
+		bson.M{
+			"$push": bson.M{"comments": comment},
+			"$set":  bson.M{"updated_at": comment.CreatedAt},
+		},
+	)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+// DeleteIncidentsByProjectID removes all incidents of a project
+func (r *MongoRepository) DeleteIncidentsByProjectID(ctx context.Context, projectID primitive.ObjectID) error {
+	collection := r.db.Collection(database.CollectionIncidents)
+
+	_, err := collection.DeleteMany(ctx, bson.M{"project_id": projectID})
+	return err
+}
@@ -0,0 +1,559 @@
+package repositories
+
+import (
+	"context"
+	"log"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/yourusername/cron-observer/backend/internal/database"
+	"github.com/yourusername/cron-observer/backend/internal/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+var _ Repository = (*PartitionedRepository)(nil)
+
+// partitionListTTL bounds how long a partition created or dropped by another replica goes unnoticed. The partition
+// of the current month is always queried, so a stale listing only delays finding partitions of past months.
+const partitionListTTL = time.Minute
+
+// PartitionedRepository stores executions in monthly collections (executions_2025_01, ...) named after the UTC
+// month an execution started in, so old executions can be removed by dropping whole collections. Queries fan out
+// with $unionWith across the partitions their date range touches; lookups by execution UUID try the newest
+// partitions first. The unpartitioned executions collection is read as the oldest partition, so executions stored
+// before partitioning was switched on stay visible. Everything else is delegated to MongoRepository.
+type PartitionedRepository struct {
+	*MongoRepository
+
+	mu       sync.Mutex
+	listed   []string // executions collections found by the last listing
+	listedAt time.Time
+	indexed  map[string]bool // partitions whose indexes this process has created
+}
+
+func NewPartitionedRepository(db *mongo.Database) *PartitionedRepository {
+	return &PartitionedRepository{
+		MongoRepository: NewMongoRepository(db),
+		indexed:         make(map[string]bool),
+	}
+}
+
+// ExecutionPartitionName returns the name of the partition holding executions that started at t
+func ExecutionPartitionName(t time.Time) string {
+	return database.CollectionExecutionPartitionPrefix + t.UTC().Format("2006_01")
+}
+
+// parseExecutionPartition returns the first instant of the month a partition holds
+func parseExecutionPartition(name string) (time.Time, bool) {
+	suffix, ok := strings.CutPrefix(name, database.CollectionExecutionPartitionPrefix)
+	if !ok {
+		return time.Time{}, false
+	}
+	month, err := time.Parse("2006_01", suffix)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return month, true
+}
+
+// selectPartitions returns the collections that can hold executions started between from and to (either may be
+// nil), newest first, followed by the unpartitioned executions collection when it exists
+func selectPartitions(names []string, now time.Time, from, to *time.Time) []string {
+	months := map[time.Time]bool{}
+	if month, ok := parseExecutionPartition(ExecutionPartitionName(now)); ok {
+		months[month] = true
+	}
+	legacy := false
+	for _, name := range names {
+		if name == database.CollectionExecutions {
+			legacy = true
+		} else if month, ok := parseExecutionPartition(name); ok {
+			months[month] = true
+		}
+	}
+
+	selected := make([]time.Time, 0, len(months))
+	for month := range months {
+		if from != nil && !month.AddDate(0, 1, 0).After(*from) {
+			continue
+		}
+		if to != nil && month.After(*to) {
+			continue
+		}
+		selected = append(selected, month)
+	}
+	sort.Slice(selected, func(i, j int) bool { return selected[i].After(selected[j]) })
+
+	partitions := make([]string, 0, len(selected)+1)
+	for _, month := range selected {
+		partitions = append(partitions, ExecutionPartitionName(month))
+	}
+	if legacy {
+		partitions = append(partitions, database.CollectionExecutions)
+	}
+	return partitions
+}
+
+// listCollections returns the executions collections, listing them again when the last listing is older than
+// partitionListTTL or refresh is set
+func (r *PartitionedRepository) listCollections(ctx context.Context, refresh bool) ([]string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if !refresh && time.Since(r.listedAt) < partitionListTTL {
+		return r.listed, nil
+	}
+
+	filter := bson.M{"name": bson.M{"$regex": "^" + database.CollectionExecutions + "(_|$)"}}
+	names, err := r.db.ListCollectionNames(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	r.listed = names
+	r.listedAt = time.Now()
+	return names, nil
+}
+
+// partitions returns the collections to query for executions started between from and to, newest first
+func (r *PartitionedRepository) partitions(ctx context.Context, from, to *time.Time) ([]string, error) {
+	names, err := r.listCollections(ctx, false)
+	if err != nil {
+		return nil, err
+	}
+	return selectPartitions(names, time.Now(), from, to), nil
+}
+
+// aggregateAcross runs pipeline over the union of the partitions. A leading $match is applied inside every
+// partition so that their indexes are used.
+func (r *PartitionedRepository) aggregateAcross(ctx context.Context, partitions []string, pipeline []bson.M) (*mongo.Cursor, error) {
+	match := []bson.M{}
+	rest := pipeline
+	if len(pipeline) > 0 {
+		if _, ok := pipeline[0]["$match"]; ok {
+			match = pipeline[:1]
+			rest = pipeline[1:]
+		}
+	}
+
+	stages := append([]bson.M{}, match...)
+	for _, name := range partitions[1:] {
+		stages = append(stages, bson.M{"$unionWith": bson.M{"coll": name, "pipeline": match}})
+	}
+	stages = append(stages, rest...)
+
+	return r.db.Collection(partitions[0]).Aggregate(ctx, stages)
+}
+
+// aggregateBetween returns an aggregateFunc over the partitions of executions started between from and to
+func (r *PartitionedRepository) aggregateBetween(from, to *time.Time) aggregateFunc {
+	return func(ctx context.Context, pipeline []bson.M) (*mongo.Cursor, error) {
+		partitions, err := r.partitions(ctx, from, to)
+		if err != nil {
+			return nil, err
+		}
+		return r.aggregateAcross(ctx, partitions, pipeline)
+	}
+}
+
+// findExecutions runs pipeline over the partitions of executions started between from and to and decodes the results
+func (r *PartitionedRepository) findExecutions(ctx context.Context, from, to *time.Time, pipeline []bson.M) ([]*models.Execution, error) {
+	cursor, err := r.aggregateBetween(from, to)(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var executions []*models.Execution
+	if err := cursor.All(ctx, &executions); err != nil {
+		return nil, err
+	}
+	return executions, nil
+}
+
+// updateExecution applies update to the execution matching filter in the newest partition that has one
+func (r *PartitionedRepository) updateExecution(ctx context.Context, filter, update bson.M) (*mongo.UpdateResult, error) {
+	partitions, err := r.partitions(ctx, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &mongo.UpdateResult{}
+	for _, name := range partitions {
+		result, err = r.db.Collection(name).UpdateOne(ctx, filter, update)
+		if err != nil {
+			return nil, err
+		}
+		if result.MatchedCount > 0 {
+			break
+		}
+	}
+	return result, nil
+}
+
+// deleteExecutions removes the executions matching filter from every partition that can hold executions started
+// before the given time (nil for all partitions)
+func (r *PartitionedRepository) deleteExecutions(ctx context.Context, before *time.Time, filter bson.M) (int64, error) {
+	partitions, err := r.partitions(ctx, nil, before)
+	if err != nil {
+		return 0, err
+	}
+
+	var deleted int64
+	for _, name := range partitions {
+		result, err := r.db.Collection(name).DeleteMany(ctx, filter)
+		if err != nil {
+			return deleted, err
+		}
+		deleted += result.DeletedCount
+	}
+	return deleted, nil
+}
+
+func (r *PartitionedRepository) ensureIndexes(ctx context.Context, name string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.indexed[name] {
+		return
+	}
+	if err := database.CreateExecutionPartitionIndexes(ctx, r.db.Collection(name)); err != nil {
+		// The insert still works without indexes; they are retried with the next execution
+		log.Printf("[Partitions] Failed to create indexes for %s: %v", name, err)
+		return
+	}
+	r.indexed[name] = true
+}
+
+// DropExecutionPartitionsBefore drops the partitions holding only executions started before the cutoff and returns
+// their names. The unpartitioned executions collection is never dropped.
+func (r *PartitionedRepository) DropExecutionPartitionsBefore(ctx context.Context, before time.Time) ([]string, error) {
+	names, err := r.listCollections(ctx, true)
+	if err != nil {
+		return nil, err
+	}
+
+	var dropped []string
+	for _, name := range names {
+		month, ok := parseExecutionPartition(name)
+		if !ok || month.AddDate(0, 1, 0).After(before) {
+			continue
+		}
+		if err := r.db.Collection(name).Drop(ctx); err != nil {
+			return dropped, err
+		}
+		dropped = append(dropped, name)
+	}
+
+	if len(dropped) > 0 {
+		// Drop the listing so the dropped partitions are no longer queried
+		r.mu.Lock()
+		r.listedAt = time.Time{}
+		for _, name := range dropped {
+			delete(r.indexed, name)
+		}
+		r.mu.Unlock()
+	}
+	return dropped, nil
+}
+
+func (r *PartitionedRepository) CreateExecution(ctx context.Context, execution *models.Execution) error {
+	startedAt := execution.StartedAt
+	if startedAt.IsZero() {
+		startedAt = time.Now()
+	}
+	name := ExecutionPartitionName(startedAt)
+
+	r.ensureIndexes(ctx, name)
+	_, err := r.db.Collection(name).InsertOne(ctx, execution)
+	return err
+}
+
+func (r *PartitionedRepository) GetExecutionsByTaskUUID(ctx context.Context, taskUUID string, startDate, endDate *time.Time) ([]*models.Execution, error) {
+	pipeline := []bson.M{
+		{"$match": executionsOfTaskFilter(taskUUID, startDate, endDate)},
+		{"$sort": bson.M{"started_at": -1}}, // Most recent first
+	}
+
+	executions, err := r.findExecutions(ctx, startDate, endDate, pipeline)
+	if err != nil {
+		return nil, err
+	}
+
+	// Ensure we always return an empty slice instead of nil
+	if executions == nil {
+		executions = []*models.Execution{}
+	}
+	return executions, nil
+}
+
+func (r *PartitionedRepository) GetExecutionsByTaskUUIDPaginated(ctx context.Context, taskUUID string, startDate, endDate *time.Time, page, pageSize int) ([]*models.Execution, int64, error) {
+	filter := executionsOfTaskFilter(taskUUID, startDate, endDate)
+
+	// Get total count
+	cursor, err := r.aggregateBetween(startDate, endDate)(ctx, []bson.M{
+		{"$match": filter},
+		{"$count": "total"},
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	var counts []struct {
+		Total int64 `bson:"total"`
+	}
+	err = cursor.All(ctx, &counts)
+	cursor.Close(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+	var totalCount int64
+	if len(counts) > 0 {
+		totalCount = counts[0].Total
+	}
+
+	pipeline := []bson.M{
+		{"$match": filter},
+		{"$sort": bson.M{"started_at": -1}}, // Most recent first
+		{"$skip": (page - 1) * pageSize},
+		{"$limit": pageSize},
+	}
+	executions, err := r.findExecutions(ctx, startDate, endDate, pipeline)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// Ensure we always return an empty slice instead of nil
+	if executions == nil {
+		executions = []*models.Execution{}
+	}
+	return executions, totalCount, nil
+}
+
+// executionsOfTaskFilter matches the executions of a task, optionally limited to a start date range
+func executionsOfTaskFilter(taskUUID string, startDate, endDate *time.Time) bson.M {
+	filter := bson.M{"task_uuid": taskUUID}
+	if startDate != nil || endDate != nil {
+		dateFilter := bson.M{}
+		if startDate != nil {
+			dateFilter["$gte"] = startDate.UTC()
+		}
+		if endDate != nil {
+			dateFilter["$lte"] = endDate.UTC()
+		}
+		filter["started_at"] = dateFilter
+	}
+	return filter
+}
+
+func (r *PartitionedRepository) AppendLogToExecution(ctx context.Context, executionUUID string, logEntry models.LogEntry) error {
+	_, err := r.updateExecution(ctx, bson.M{"uuid": executionUUID}, appendLogUpdate(logEntry))
+	return err
+}
+
+func (r *PartitionedRepository) UpdateExecutionStatus(ctx context.Context, executionUUID string, status models.ExecutionStatus, errorMessage *string) error {
+	_, err := r.updateExecution(ctx, bson.M{"uuid": executionUUID}, executionStatusUpdate(status, errorMessage))
+	return err
+}
+
+func (r *PartitionedRepository) RecordExecutionEndpoint(ctx context.Context, executionUUID string, endpoint string) error {
+	_, err := r.updateExecution(ctx, bson.M{"uuid": executionUUID}, executionEndpointUpdate(endpoint))
+	return err
+}
+
+func (r *PartitionedRepository) FailExecutionIfUnfinished(ctx context.Context, executionUUID string, errorMessage string) (bool, error) {
+	result, err := r.updateExecution(ctx, unfinishedExecutionFilter(executionUUID), failExecutionUpdate(errorMessage))
+	if err != nil {
+		return false, err
+	}
+	return result.ModifiedCount > 0, nil
+}
+
+// StartQueuedExecution leaves the execution in the partition of the month it was queued in
+func (r *PartitionedRepository) StartQueuedExecution(ctx context.Context, executionUUID string, startedAt time.Time) (bool, error) {
+	result, err := r.updateExecution(ctx, queuedExecutionFilter(executionUUID), startQueuedExecutionUpdate(startedAt))
+	if err != nil {
+		return false, err
+	}
+	return result.ModifiedCount > 0, nil
+}
+
+func (r *PartitionedRepository) CountActiveExecutions(ctx context.Context, taskUUIDs []string, since time.Time) (int64, error) {
+	partitions, err := r.partitions(ctx, &since, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	var total int64
+	for _, name := range partitions {
+		count, err := r.db.Collection(name).CountDocuments(ctx, activeExecutionsFilter(taskUUIDs, since))
+		if err != nil {
+			return 0, err
+		}
+		total += count
+	}
+	return total, nil
+}
+
+func (r *PartitionedRepository) RecordExecutionHeartbeat(ctx context.Context, executionUUID string, at time.Time) (bool, error) {
+	result, err := r.updateExecution(ctx, unfinishedExecutionFilter(executionUUID), heartbeatUpdate(at))
+	if err != nil {
+		return false, err
+	}
+	return result.MatchedCount > 0, nil
+}
+
+func (r *PartitionedRepository) GetExecutionByUUID(ctx context.Context, executionUUID string) (*models.Execution, error) {
+	partitions, err := r.partitions(ctx, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, name := range partitions {
+		var execution models.Execution
+		err := r.db.Collection(name).FindOne(ctx, bson.M{"uuid": executionUUID}).Decode(&execution)
+		if err == mongo.ErrNoDocuments {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		return &execution, nil
+	}
+	return nil, mongo.ErrNoDocuments
+}
+
+func (r *PartitionedRepository) GetLatestExecutionByTaskUUID(ctx context.Context, taskUUID string) (*models.Execution, error) {
+	pipeline := []bson.M{
+		{"$match": bson.M{"task_uuid": taskUUID}},
+		{"$sort": bson.M{"started_at": -1}},
+		{"$limit": 1},
+		{"$project": bson.M{"logs": 0}},
+	}
+
+	executions, err := r.findExecutions(ctx, nil, nil, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	if len(executions) == 0 {
+		return nil, nil // Task has never run
+	}
+	return executions[0], nil
+}
+
+func (r *PartitionedRepository) GetLatestExecutionsByTaskUUIDs(ctx context.Context, taskUUIDs []string) (map[string]*models.Execution, error) {
+	return latestExecutionsByTaskUUIDs(ctx, r.aggregateBetween(nil, nil), taskUUIDs)
+}
+
+func (r *PartitionedRepository) GetLastSuccessByTaskUUIDs(ctx context.Context, taskUUIDs []string) (map[string]time.Time, error) {
+	return lastSuccessByTaskUUIDs(ctx, r.aggregateBetween(nil, nil), taskUUIDs)
+}
+
+func (r *PartitionedRepository) DeleteExecutionsByTaskUUIDs(ctx context.Context, taskUUIDs []string) (int64, error) {
+	if len(taskUUIDs) == 0 {
+		return 0, nil
+	}
+	return r.deleteExecutions(ctx, nil, bson.M{"task_uuid": bson.M{"$in": taskUUIDs}})
+}
+
+func (r *PartitionedRepository) DeleteExecutionsByTaskUUIDsBefore(ctx context.Context, taskUUIDs []string, before time.Time) (int64, error) {
+	if len(taskUUIDs) == 0 {
+		return 0, nil
+	}
+	filter := bson.M{
+		"task_uuid":  bson.M{"$in": taskUUIDs},
+		"started_at": bson.M{"$lt": before},
+	}
+	return r.deleteExecutions(ctx, &before, filter)
+}
+
+func (r *PartitionedRepository) GetExecutionsByTaskUUIDsBetween(ctx context.Context, taskUUIDs []string, from, to time.Time, limit int) ([]*models.Execution, error) {
+	if len(taskUUIDs) == 0 {
+		return []*models.Execution{}, nil
+	}
+	pipeline := []bson.M{
+		{"$match": bson.M{
+			"task_uuid":  bson.M{"$in": taskUUIDs},
+			"started_at": bson.M{"$gte": from.UTC(), "$lte": to.UTC()},
+		}},
+		{"$sort": bson.M{"started_at": 1}},
+		{"$limit": limit},
+		{"$project": bson.M{"logs": 0}},
+	}
+	return r.findExecutions(ctx, &from, &to, pipeline)
+}
+
+func (r *PartitionedRepository) TrimExecutionLogsBefore(ctx context.Context, taskUUIDs []string, statuses []models.ExecutionStatus, before time.Time) (int64, error) {
+	if len(taskUUIDs) == 0 || len(statuses) == 0 {
+		return 0, nil
+	}
+	partitions, err := r.partitions(ctx, nil, &before)
+	if err != nil {
+		return 0, err
+	}
+
+	var trimmed int64
+	filter := trimLogsFilter(taskUUIDs, statuses, before)
+	update := trimLogsUpdate(time.Now())
+	for _, name := range partitions {
+		result, err := r.db.Collection(name).UpdateMany(ctx, filter, update)
+		if err != nil {
+			return trimmed, err
+		}
+		trimmed += result.ModifiedCount
+	}
+	return trimmed, nil
+}
+
+func (r *PartitionedRepository) GetExecutionBatchByTaskUUID(ctx context.Context, taskUUID string, limit int) ([]*models.Execution, error) {
+	pipeline := []bson.M{
+		{"$match": bson.M{"task_uuid": taskUUID}},
+		{"$sort": bson.M{"started_at": 1}},
+		{"$limit": limit},
+		{"$project": bson.M{"logs": 0}},
+	}
+	return r.findExecutions(ctx, nil, nil, pipeline)
+}
+
+func (r *PartitionedRepository) DeleteExecutionsByIDs(ctx context.Context, executionIDs []primitive.ObjectID) (int64, error) {
+	if len(executionIDs) == 0 {
+		return 0, nil
+	}
+	return r.deleteExecutions(ctx, nil, bson.M{"_id": bson.M{"$in": executionIDs}})
+}
+
+func (r *PartitionedRepository) GetExecutionStatsByProject(ctx context.Context, projectID primitive.ObjectID, days int) ([]*models.ExecutionStats, error) {
+	// A day of slack covers the rounding to the start of the first day
+	since := time.Now().AddDate(0, 0, -days-1)
+	return r.executionStatsByProject(ctx, r.aggregateBetween(&since, nil), projectID, days)
+}
+
+func (r *PartitionedRepository) CalculateTaskFailureStats(ctx context.Context, projectID primitive.ObjectID, date string) (*models.StoredTaskFailureStats, error) {
+	aggregate := r.aggregateBetween(nil, nil)
+	if day, err := time.Parse("2006-01-02", date); err == nil {
+		end := day.AddDate(0, 0, 1)
+		aggregate = r.aggregateBetween(&day, &end)
+	}
+	return r.calculateTaskFailureStats(ctx, aggregate, projectID, date)
+}
+
+// GetExecutionTaskUUIDs returns the distinct task UUIDs of the executions in every partition
+func (r *PartitionedRepository) GetExecutionTaskUUIDs(ctx context.Context) ([]string, error) {
+	partitions, err := r.partitions(ctx, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool)
+	taskUUIDs := []string{}
+	for _, name := range partitions {
+		found, err := distinctTaskUUIDs(ctx, r.db.Collection(name), seen)
+		if err != nil {
+			return nil, err
+		}
+		taskUUIDs = append(taskUUIDs, found...)
+	}
+	return taskUUIDs, nil
+}
@@ -0,0 +1,50 @@
+package repositories
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestExecutionPartitionName(t *testing.T) {
+	startedAt := time.Date(2025, time.January, 31, 23, 30, 0, 0, time.FixedZone("UTC-2", -2*60*60))
+	if name := ExecutionPartitionName(startedAt); name != "executions_2025_02" {
+		t.Fatalf("name = %q, want the partition of the UTC month", name)
+	}
+
+	month, ok := parseExecutionPartition("executions_2025_02")
+	if !ok || !month.Equal(time.Date(2025, time.February, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Fatalf("parseExecutionPartition = %v, %v", month, ok)
+	}
+	for _, name := range []string{"executions", "executions_2025_2", "executions_2025_02_old", "execution_failure_stats"} {
+		if _, ok := parseExecutionPartition(name); ok {
+			t.Errorf("%q parsed as a partition", name)
+		}
+	}
+}
+
+func TestSelectPartitions(t *testing.T) {
+	now := time.Date(2025, time.April, 10, 0, 0, 0, 0, time.UTC)
+	names := []string{"executions_2025_01", "executions", "executions_2025_03", "executions_2024_12"}
+
+	all := selectPartitions(names, now, nil, nil)
+	want := []string{"executions_2025_04", "executions_2025_03", "executions_2025_01", "executions_2024_12", "executions"}
+	if !reflect.DeepEqual(all, want) {
+		t.Fatalf("all partitions = %v, want %v (newest first, current month included, legacy last)", all, want)
+	}
+
+	from := time.Date(2025, time.January, 31, 12, 0, 0, 0, time.UTC)
+	to := time.Date(2025, time.March, 1, 0, 0, 0, 0, time.UTC)
+	ranged := selectPartitions(names, now, &from, &to)
+	want = []string{"executions_2025_03", "executions_2025_01", "executions"}
+	if !reflect.DeepEqual(ranged, want) {
+		t.Fatalf("partitions in range = %v, want %v", ranged, want)
+	}
+
+	before := time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC)
+	older := selectPartitions(names[:1], now, nil, &before)
+	want = []string{"executions_2025_01"}
+	if !reflect.DeepEqual(older, want) {
+		t.Fatalf("partitions up to %v = %v, want %v", before, older, want)
+	}
+}
@@ -13,16 +13,80 @@ type Repository interface {
 	GetAllProjects(ctx context.Context) ([]*models.Project, error)
 	GetProjectByID(ctx context.Context, projectID primitive.ObjectID) (*models.Project, error)
 	GetProjectByName(ctx context.Context, name string) (*models.Project, error)
-	GetUserProjects(ctx context.Context, email string) ([]*models.Project, error)
+	GetUserProjects(ctx context.Context, email string) ([]*models.Project, error) // projects the email is a member of, and the projects of organizations it administers
 	CreateProject(ctx context.Context, project *models.Project) error
 	UpdateProject(ctx context.Context, projectID primitive.ObjectID, project *models.Project) error
+	UpdateProjectStatus(ctx context.Context, projectID primitive.ObjectID, status models.ProjectStatus) error
+	UpdateProjectDeletionProgress(ctx context.Context, projectID primitive.ObjectID, progress *models.ProjectDeletionProgress) error
+	DeleteProject(ctx context.Context, projectID primitive.ObjectID) error // hard delete; returns mongo.ErrNoDocuments when not found
+	AddScopedAPIKey(ctx context.Context, projectID primitive.ObjectID, apiKey models.ScopedAPIKey) error
+	RemoveScopedAPIKey(ctx context.Context, projectID primitive.ObjectID, keyID string) error                                    // returns mongo.ErrNoDocuments when the key does not exist
+	UpdateScopedAPIKeyAllowedCIDRs(ctx context.Context, projectID primitive.ObjectID, keyID string, allowedCIDRs []string) error // returns mongo.ErrNoDocuments when the key does not exist
+	AddProjectEnvironment(ctx context.Context, projectID primitive.ObjectID, environment models.ProjectEnvironment) error        // returns mongo.ErrNoDocuments when the project is missing or the name is taken
+	UpdateProjectEnvironmentEndpoint(ctx context.Context, projectID primitive.ObjectID, name, executionEndpoint string) error    // returns mongo.ErrNoDocuments when the environment does not exist
+	RemoveProjectEnvironment(ctx context.Context, projectID primitive.ObjectID, name string) error                               // returns mongo.ErrNoDocuments when the environment does not exist
+	AddNotificationChannel(ctx context.Context, projectID primitive.ObjectID, channel models.NotificationChannel) error          // returns mongo.ErrNoDocuments when the project is missing or the name is taken
+	UpdateNotificationChannel(ctx context.Context, projectID primitive.ObjectID, channel models.NotificationChannel) error       // replaces the destination by name; returns mongo.ErrNoDocuments when the channel does not exist
+	RemoveNotificationChannel(ctx context.Context, projectID primitive.ObjectID, name string) error                              // returns mongo.ErrNoDocuments when the channel does not exist
+	SetAlertRoutes(ctx context.Context, projectID primitive.ObjectID, routes []models.AlertRoute) error                          // empty removes the routes; returns mongo.ErrNoDocuments when not found
+	SetOnCallSchedule(ctx context.Context, projectID primitive.ObjectID, schedule *models.OnCallSchedule) error                  // nil removes the schedule; returns mongo.ErrNoDocuments when not found
+	AddProjectUser(ctx context.Context, projectID primitive.ObjectID, user models.ProjectUser) error                             // no-op when the email is already a member
+	SetProjectStatusPageToken(ctx context.Context, projectID primitive.ObjectID, token string) error                             // empty token disables the page; returns mongo.ErrNoDocuments when not found
+	SetProjectQuotas(ctx context.Context, projectID primitive.ObjectID, quotas *models.ProjectQuotas) error                      // nil removes the overrides; returns mongo.ErrNoDocuments when not found
+
+	// organizations
+	CreateOrganization(ctx context.Context, organization *models.Organization) error
+	GetOrganizationByID(ctx context.Context, organizationID primitive.ObjectID) (*models.Organization, error) // returns mongo.ErrNoDocuments when not found
+	GetAllOrganizations(ctx context.Context) ([]*models.Organization, error)
+	GetUserOrganizations(ctx context.Context, email string) ([]*models.Organization, error)                             // organizations the email is a member of, any role
+	UpdateOrganization(ctx context.Context, organizationID primitive.ObjectID, organization *models.Organization) error // name, description and members; returns mongo.ErrNoDocuments when not found
+	DeleteOrganization(ctx context.Context, organizationID primitive.ObjectID) error                                    // returns mongo.ErrNoDocuments when not found
+	GetProjectsByOrganizationID(ctx context.Context, organizationID primitive.ObjectID) ([]*models.Project, error)
+	SetProjectOrganization(ctx context.Context, projectID primitive.ObjectID, organizationID *primitive.ObjectID) error // nil removes the project from its organization; returns mongo.ErrNoDocuments when not found
+	SetOrganizationQuotas(ctx context.Context, organizationID primitive.ObjectID, quotas *models.ProjectQuotas) error   // nil removes them; returns mongo.ErrNoDocuments when not found
+
+	// invitations
+	CreateInvitation(ctx context.Context, invitation *models.Invitation) error
+	GetInvitationByUUID(ctx context.Context, invitationUUID string) (*models.Invitation, error) // returns mongo.ErrNoDocuments when not found
+	GetInvitationsByProjectID(ctx context.Context, projectID primitive.ObjectID, status models.InvitationStatus) ([]*models.Invitation, error)
+	UpdateInvitationStatus(ctx context.Context, invitationUUID string, status models.InvitationStatus) error
+	AcceptInvitation(ctx context.Context, invitationUUID, email string, at time.Time) (bool, error) // false when the invitation is no longer pending, expired before at or was sent to another email
+
+	// secrets
+	UpsertSecret(ctx context.Context, secret *models.Secret) error
+	GetSecretByName(ctx context.Context, projectID primitive.ObjectID, name string) (*models.Secret, error) // returns mongo.ErrNoDocuments when not found
+	GetSecretsByProjectID(ctx context.Context, projectID primitive.ObjectID) ([]*models.Secret, error)
+	DeleteSecret(ctx context.Context, projectID primitive.ObjectID, name string) error // returns mongo.ErrNoDocuments when not found
+
+	// project settings
+	GetProjectSettings(ctx context.Context, projectID primitive.ObjectID) (*models.ProjectSettings, error) // returns nil, nil when the project has no stored settings
+	GetProjectSettingsWithRetention(ctx context.Context) ([]*models.ProjectSettings, error)
+	UpsertProjectSettings(ctx context.Context, settings *models.ProjectSettings) error
+	DeleteProjectSettings(ctx context.Context, projectID primitive.ObjectID) error
+
+	// task templates
+	CreateTaskTemplate(ctx context.Context, template *models.TaskTemplate) error
+	GetTaskTemplatesByProjectID(ctx context.Context, projectID primitive.ObjectID) ([]*models.TaskTemplate, error)
+	GetTaskTemplateByUUID(ctx context.Context, projectID primitive.ObjectID, templateUUID string) (*models.TaskTemplate, error) // returns mongo.ErrNoDocuments when not found
+	DeleteTaskTemplate(ctx context.Context, projectID primitive.ObjectID, templateUUID string) error                            // returns mongo.ErrNoDocuments when not found
+	DeleteTaskTemplatesByProjectID(ctx context.Context, projectID primitive.ObjectID) error
+
+	// token revocations
+	CreateTokenRevocation(ctx context.Context, revocation *models.TokenRevocation) error
+	IsTokenRevoked(ctx context.Context, jti string, email string, issuedAt time.Time) (bool, error)
 
 	// tasks
 	CreateTask(ctx context.Context, projectID string, task *models.Task) error
 	GetAllActiveTasks(ctx context.Context) ([]*models.Task, error)
 	GetTasksByStatus(ctx context.Context, statuses []models.TaskStatus) ([]*models.Task, error) // Query tasks by status(es)
 	GetTasksByProjectID(ctx context.Context, projectID primitive.ObjectID) ([]*models.Task, error)
-	GetTaskByUUID(ctx context.Context, taskUUID string) (*models.Task, error) // returns mongo.ErrNoDocuments when not found
+	GetTaskBatchByProjectID(ctx context.Context, projectID primitive.ObjectID, limit int) ([]*models.Task, error)                                            // any status, including PENDING_DELETE and DELETE_FAILED
+	ListTasksByProjectID(ctx context.Context, projectID primitive.ObjectID, filter models.TaskListFilter, page, pageSize int) ([]*models.Task, int64, error) // pageSize 0 returns all matching tasks
+	UpdateTaskLastFailureAt(ctx context.Context, taskUUID string, failedAt time.Time) error
+	SetTaskMutedUntil(ctx context.Context, taskUUID string, mutedUntil *time.Time) error // nil clears the mute
+	SetTaskNextRunAt(ctx context.Context, taskUUID string, nextRunAt *time.Time) error   // nil clears it; does not touch updated_at
+	SetTaskFlapping(ctx context.Context, taskUUID string, since *time.Time) error        // nil clears it; does not touch updated_at
+	GetTaskByUUID(ctx context.Context, taskUUID string) (*models.Task, error)            // returns mongo.ErrNoDocuments when not found
 	UpdateTask(ctx context.Context, taskUUID string, task *models.Task) error
 	UpdateTaskStatus(ctx context.Context, taskUUID string, status models.TaskStatus) error
 	DeleteTask(ctx context.Context, taskUUID string) error // hard delete; removes document from MongoDB
@@ -33,6 +97,7 @@ type Repository interface {
 	GetTaskGroupByUUID(ctx context.Context, taskGroupUUID string) (*models.TaskGroup, error)
 	GetTaskGroupByID(ctx context.Context, taskGroupID primitive.ObjectID) (*models.TaskGroup, error)
 	UpdateTaskGroup(ctx context.Context, taskGroupUUID string, taskGroup *models.TaskGroup) error
+	UpdateTaskGroupWithTasks(ctx context.Context, taskGroup *models.TaskGroup, cascade models.TaskGroupCascade) (*models.TaskGroupCascadeResult, error) // all or nothing
 	UpdateTaskGroupStatus(ctx context.Context, taskGroupUUID string, status models.TaskGroupStatus) error
 	UpdateTaskGroupState(ctx context.Context, taskGroupUUID string, state models.TaskGroupState) error
 	DeleteTaskGroup(ctx context.Context, taskGroupUUID string) error
@@ -46,11 +111,27 @@ type Repository interface {
 	GetExecutionsByTaskUUIDPaginated(ctx context.Context, taskUUID string, startDate, endDate *time.Time, page, pageSize int) ([]*models.Execution, int64, error)
 	AppendLogToExecution(ctx context.Context, executionUUID string, logEntry models.LogEntry) error
 	UpdateExecutionStatus(ctx context.Context, executionUUID string, status models.ExecutionStatus, errorMessage *string) error
+	RecordExecutionEndpoint(ctx context.Context, executionUUID string, endpoint string) error
+	FailExecutionIfUnfinished(ctx context.Context, executionUUID string, errorMessage string) (bool, error) // false when the execution already completed
+	StartQueuedExecution(ctx context.Context, executionUUID string, startedAt time.Time) (bool, error)      // false when the execution is no longer QUEUED
+	RecordExecutionHeartbeat(ctx context.Context, executionUUID string, at time.Time) (bool, error)         // false when the execution already completed
+	CountActiveExecutions(ctx context.Context, taskUUIDs []string, since time.Time) (int64, error)          // PENDING or RUNNING executions of the tasks started at or after since
 	GetExecutionByUUID(ctx context.Context, executionUUID string) (*models.Execution, error)
+	GetLatestExecutionByTaskUUID(ctx context.Context, taskUUID string) (*models.Execution, error)                 // without logs; returns nil, nil when the task has never run
+	GetLatestExecutionsByTaskUUIDs(ctx context.Context, taskUUIDs []string) (map[string]*models.Execution, error) // keyed by task UUID, without logs; tasks that never ran are absent
+	GetLastSuccessByTaskUUIDs(ctx context.Context, taskUUIDs []string) (map[string]time.Time, error)              // keyed by task UUID; tasks that never succeeded are absent
+	DeleteExecutionsByTaskUUIDs(ctx context.Context, taskUUIDs []string) (int64, error)
+	DeleteExecutionsByTaskUUIDsBefore(ctx context.Context, taskUUIDs []string, before time.Time) (int64, error) // removes executions started before the cutoff
+	GetExecutionBatchByTaskUUID(ctx context.Context, taskUUID string, limit int) ([]*models.Execution, error)   // oldest first, without logs
+	DeleteExecutionsByIDs(ctx context.Context, executionIDs []primitive.ObjectID) (int64, error)
+	TrimExecutionLogsBefore(ctx context.Context, taskUUIDs []string, statuses []models.ExecutionStatus, before time.Time) (int64, error) // removes the logs of executions with the statuses started before the cutoff
+	GetExecutionsByTaskUUIDsBetween(ctx context.Context, taskUUIDs []string, from, to time.Time, limit int) ([]*models.Execution, error) // started from through to, oldest first, without logs
 
 	// failure statistics
 	IncrementFailureStat(ctx context.Context, projectID primitive.ObjectID, date string) error
 	GetFailureStatsByProject(ctx context.Context, projectID primitive.ObjectID, days int) ([]*models.FailedExecutionStats, int, error)
+	DeleteStatsByProjectID(ctx context.Context, projectID primitive.ObjectID) error                             // removes daily failure counters and stored task failure stats
+	DecrementFailureStats(ctx context.Context, projectID primitive.ObjectID, countsByDate map[string]int) error // never goes below zero
 
 	// execution statistics
 	GetExecutionStatsByProject(ctx context.Context, projectID primitive.ObjectID, days int) ([]*models.ExecutionStats, error)
@@ -62,4 +143,37 @@ type Repository interface {
 	StoreTaskFailureStats(ctx context.Context, stats *models.StoredTaskFailureStats) error
 	GetStoredTaskFailureStats(ctx context.Context, projectID primitive.ObjectID, date string) (*models.StoredTaskFailureStats, error)
 	CalculateTaskFailureStats(ctx context.Context, projectID primitive.ObjectID, date string) (*models.StoredTaskFailureStats, error)
+	RemoveTaskFromStoredFailureStats(ctx context.Context, projectID primitive.ObjectID, taskUUID string) error // drops the task's entries and subtracts them from the totals
+
+	// usage metering
+	IncrementUsage(ctx context.Context, projectID primitive.ObjectID, date string, counters models.UsageCounters) error // adds to the project's counters of the day
+	GetUsage(ctx context.Context, filter models.UsageFilter) ([]*models.UsageRecord, error)                             // by date, then project
+
+	// transactions
+	RunInTransaction(ctx context.Context, fn func(ctx context.Context) error) error // fn must make its calls with the ctx it is given; it may run more than once
+
+	// event outbox
+	CreateOutboxEvent(ctx context.Context, event *models.OutboxEvent) error
+	ClaimOutboxEvents(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*models.OutboxEvent, error) // oldest first; claimed events are locked until now+lease
+	DeleteOutboxEvent(ctx context.Context, id primitive.ObjectID) error                                                  // acknowledges delivery
+
+	// in-process job queue
+	CreateQueuedJob(ctx context.Context, job *models.QueuedJob) error
+	ClaimQueuedJobs(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*models.QueuedJob, error) // oldest first; claimed jobs are locked until now+lease
+	DeleteQueuedJob(ctx context.Context, id primitive.ObjectID) error                                                // acknowledges the job
+
+	// incidents
+	RecordIncidentFailure(ctx context.Context, failure models.IncidentFailure) (*models.Incident, error)                                                                   // adds to the task's active incident or opens one; FailureCount 1 means it was opened
+	ResolveActiveIncident(ctx context.Context, taskUUID string, executionUUID string, resolvedAt time.Time) (*models.Incident, error)                                      // returns mongo.ErrNoDocuments when the task has no active incident
+	GetIncidentByUUID(ctx context.Context, projectID primitive.ObjectID, incidentUUID string) (*models.Incident, error)                                                    // returns mongo.ErrNoDocuments when not found
+	ListIncidents(ctx context.Context, projectID primitive.ObjectID, filter models.IncidentListFilter, page, pageSize int) ([]*models.Incident, int64, error)              // most recently opened first
+	AcknowledgeIncident(ctx context.Context, projectID primitive.ObjectID, incidentUUID string, acknowledgedBy string, acknowledgedAt time.Time) (*models.Incident, error) // returns mongo.ErrNoDocuments unless the incident is OPEN
+	ResolveIncident(ctx context.Context, projectID primitive.ObjectID, incidentUUID string, resolvedBy string, resolvedAt time.Time) (*models.Incident, error)             // returns mongo.ErrNoDocuments when not found or already resolved
+	AddIncidentComment(ctx context.Context, projectID primitive.ObjectID, incidentUUID string, comment models.IncidentComment) error                                       // returns mongo.ErrNoDocuments when not found
+	DeleteIncidentsByProjectID(ctx context.Context, projectID primitive.ObjectID) error
+
+	// referential integrity
+	GetTaskReferences(ctx context.Context) ([]*models.TaskReference, error) // every task, any status
+	GetExecutionTaskUUIDs(ctx context.Context) ([]string, error)            // distinct task UUIDs of all executions
+	GetStatsProjectIDs(ctx context.Context) ([]primitive.ObjectID, error)   // distinct projects of the failure stats
 }
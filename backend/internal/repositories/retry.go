@@ -0,0 +1,943 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"log"
+	"math/rand/v2"
+	"time"
+
+	"github.com/yourusername/cron-observer/backend/internal/models"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/x/mongo/driver/topology"
+)
+
+var _ Repository = (*RetryRepository)(nil)
+
+// RetryPolicy bounds how a RetryRepository retries
+type RetryPolicy struct {
+	MaxAttempts int           // Attempts per operation, including the first; 1 disables retries
+	BaseDelay   time.Duration // Delay before the first retry; doubled for each further retry
+	MaxDelay    time.Duration // Upper bound on the delay between attempts
+}
+
+// RetryRepository retries operations of another repository that fail with transient MongoDB errors (network
+// blips, primary elections, server selection timeouts) with bounded exponential backoff, so a brief replica set
+// failover does not fail requests or delete jobs. Idempotent operations are retried for any transient error.
+// Operations that are not (inserts, increments, deletes reporting what they removed) are only retried when the
+// error guarantees nothing was written, since a network error can hide a write that was applied.
+type RetryRepository struct {
+	Repository
+	policy RetryPolicy
+}
+
+func NewRetryRepository(repo Repository, policy RetryPolicy) *RetryRepository {
+	if policy.MaxAttempts < 1 {
+		policy.MaxAttempts = 1
+	}
+	return &RetryRepository{Repository: repo, policy: policy}
+}
+
+const (
+	idempotent    = true
+	notIdempotent = false
+)
+
+// notAppliedCodes are server errors returned before an operation runs: the node is not (or no longer) the
+// primary, is shutting down, or could not be reached
+var notAppliedCodes = []int{
+	6,     // HostUnreachable
+	7,     // HostNotFound
+	91,    // ShutdownInProgress
+	189,   // PrimarySteppedDown
+	10107, // NotWritablePrimary
+	11600, // InterruptedAtShutdown
+	11602, // InterruptedDueToReplStateChange
+	13435, // NotPrimaryNoSecondaryOk
+	13436, // NotPrimaryOrSecondary
+}
+
+// classifyError reports whether err is transient, and whether the failed operation is known not to have been applied
+func classifyError(err error) (transient, notApplied bool) {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false, false
+	}
+
+	var selectionErr topology.ServerSelectionError
+	var waitQueueErr topology.WaitQueueTimeoutError
+	if errors.As(err, &selectionErr) || errors.As(err, &waitQueueErr) {
+		return true, true
+	}
+
+	var serverErr mongo.ServerError
+	if errors.As(err, &serverErr) {
+		for _, code := range notAppliedCodes {
+			if serverErr.HasErrorCode(code) {
+				return true, true
+			}
+		}
+	}
+
+	if mongo.IsNetworkError(err) || (serverErr != nil && serverErr.HasErrorLabel("RetryableWriteError")) {
+		return true, false
+	}
+	return false, false
+}
+
+// backoff returns the delay before the given retry (1 for the first), with jitter so replicas do not retry in step
+func (r *RetryRepository) backoff(retry int) time.Duration {
+	delay := r.policy.BaseDelay << (retry - 1)
+	if delay <= 0 || (r.policy.MaxDelay > 0 && delay > r.policy.MaxDelay) {
+		delay = r.policy.MaxDelay
+	}
+	if delay <= 0 {
+		return 0
+	}
+	return delay/2 + rand.N(delay/2+1)
+}
+
+// attempt runs op until it succeeds, fails with an error that must not be retried, or the attempts or ctx run out
+func (r *RetryRepository) attempt(ctx context.Context, name string, idempotent bool, op func() error) error {
+	for attempt := 1; ; attempt++ {
+		err := op()
+		if err == nil {
+			return nil
+		}
+
+		transient, notApplied := classifyError(err)
+		if !transient || (!idempotent && !notApplied) || attempt >= r.policy.MaxAttempts || ctx.Err() != nil {
+			return err
+		}
+
+		delay := r.backoff(attempt)
+		log.Printf("[Retry] %s failed with a transient error (attempt %d/%d), retrying in %s: %v", name, attempt, r.policy.MaxAttempts, delay, err)
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+	}
+}
+
+func retry1[T any](ctx context.Context, r *RetryRepository, name string, idempotent bool, op func() (T, error)) (T, error) {
+	var result T
+	err := r.attempt(ctx, name, idempotent, func() (err error) {
+		result, err = op()
+		return err
+	})
+	return result, err
+}
+
+func retry2[T, U any](ctx context.Context, r *RetryRepository, name string, idempotent bool, op func() (T, U, error)) (T, U, error) {
+	var first T
+	var second U
+	err := r.attempt(ctx, name, idempotent, func() (err error) {
+		first, second, err = op()
+		return err
+	})
+	return first, second, err
+}
+
+// Projects
+
+func (r *RetryRepository) GetAllProjects(ctx context.Context) ([]*models.Project, error) {
+	return retry1(ctx, r, "GetAllProjects", idempotent, func() ([]*models.Project, error) {
+		return r.Repository.GetAllProjects(ctx)
+	})
+}
+
+func (r *RetryRepository) GetProjectByID(ctx context.Context, projectID primitive.ObjectID) (*models.Project, error) {
+	return retry1(ctx, r, "GetProjectByID", idempotent, func() (*models.Project, error) {
+		return r.Repository.GetProjectByID(ctx, projectID)
+	})
+}
+
+func (r *RetryRepository) GetProjectByName(ctx context.Context, name string) (*models.Project, error) {
+	return retry1(ctx, r, "GetProjectByName", idempotent, func() (*models.Project, error) {
+		return r.Repository.GetProjectByName(ctx, name)
+	})
+}
+
+func (r *RetryRepository) GetUserProjects(ctx context.Context, email string) ([]*models.Project, error) {
+	return retry1(ctx, r, "GetUserProjects", idempotent, func() ([]*models.Project, error) {
+		return r.Repository.GetUserProjects(ctx, email)
+	})
+}
+
+func (r *RetryRepository) CreateProject(ctx context.Context, project *models.Project) error {
+	return r.attempt(ctx, "CreateProject", notIdempotent, func() error {
+		return r.Repository.CreateProject(ctx, project)
+	})
+}
+
+func (r *RetryRepository) UpdateProject(ctx context.Context, projectID primitive.ObjectID, project *models.Project) error {
+	return r.attempt(ctx, "UpdateProject", idempotent, func() error {
+		return r.Repository.UpdateProject(ctx, projectID, project)
+	})
+}
+
+func (r *RetryRepository) UpdateProjectStatus(ctx context.Context, projectID primitive.ObjectID, status models.ProjectStatus) error {
+	return r.attempt(ctx, "UpdateProjectStatus", idempotent, func() error {
+		return r.Repository.UpdateProjectStatus(ctx, projectID, status)
+	})
+}
+
+func (r *RetryRepository) UpdateProjectDeletionProgress(ctx context.Context, projectID primitive.ObjectID, progress *models.ProjectDeletionProgress) error {
+	return r.attempt(ctx, "UpdateProjectDeletionProgress", idempotent, func() error {
+		return r.Repository.UpdateProjectDeletionProgress(ctx, projectID, progress)
+	})
+}
+
+func (r *RetryRepository) DeleteProject(ctx context.Context, projectID primitive.ObjectID) error {
+	return r.attempt(ctx, "DeleteProject", notIdempotent, func() error {
+		return r.Repository.DeleteProject(ctx, projectID)
+	})
+}
+
+func (r *RetryRepository) AddScopedAPIKey(ctx context.Context, projectID primitive.ObjectID, apiKey models.ScopedAPIKey) error {
+	return r.attempt(ctx, "AddScopedAPIKey", notIdempotent, func() error {
+		return r.Repository.AddScopedAPIKey(ctx, projectID, apiKey)
+	})
+}
+
+func (r *RetryRepository) RemoveScopedAPIKey(ctx context.Context, projectID primitive.ObjectID, keyID string) error {
+	return r.attempt(ctx, "RemoveScopedAPIKey", notIdempotent, func() error {
+		return r.Repository.RemoveScopedAPIKey(ctx, projectID, keyID)
+	})
+}
+
+func (r *RetryRepository) UpdateScopedAPIKeyAllowedCIDRs(ctx context.Context, projectID primitive.ObjectID, keyID string, allowedCIDRs []string) error {
+	return r.attempt(ctx, "UpdateScopedAPIKeyAllowedCIDRs", idempotent, func() error {
+		return r.Repository.UpdateScopedAPIKeyAllowedCIDRs(ctx, projectID, keyID, allowedCIDRs)
+	})
+}
+
+func (r *RetryRepository) AddProjectEnvironment(ctx context.Context, projectID primitive.ObjectID, environment models.ProjectEnvironment) error {
+	return r.attempt(ctx, "AddProjectEnvironment", notIdempotent, func() error {
+		return r.Repository.AddProjectEnvironment(ctx, projectID, environment)
+	})
+}
+
+func (r *RetryRepository) UpdateProjectEnvironmentEndpoint(ctx context.Context, projectID primitive.ObjectID, name, executionEndpoint string) error {
+	return r.attempt(ctx, "UpdateProjectEnvironmentEndpoint", idempotent, func() error {
+		return r.Repository.UpdateProjectEnvironmentEndpoint(ctx, projectID, name, executionEndpoint)
+	})
+}
+
+func (r *RetryRepository) RemoveProjectEnvironment(ctx context.Context, projectID primitive.ObjectID, name string) error {
+	return r.attempt(ctx, "RemoveProjectEnvironment", notIdempotent, func() error {
+		return r.Repository.RemoveProjectEnvironment(ctx, projectID, name)
+	})
+}
+
+func (r *RetryRepository) AddNotificationChannel(ctx context.Context, projectID primitive.ObjectID, channel models.NotificationChannel) error {
+	return r.attempt(ctx, "AddNotificationChannel", notIdempotent, func() error {
+		return r.Repository.AddNotificationChannel(ctx, projectID, channel)
+	})
+}
+
+func (r *RetryRepository) UpdateNotificationChannel(ctx context.Context, projectID primitive.ObjectID, channel models.NotificationChannel) error {
+	return r.attempt(ctx, "UpdateNotificationChannel", idempotent, func() error {
+		return r.Repository.UpdateNotificationChannel(ctx, projectID, channel)
+	})
+}
+
+func (r *RetryRepository) RemoveNotificationChannel(ctx context.Context, projectID primitive.ObjectID, name string) error {
+	return r.attempt(ctx, "RemoveNotificationChannel", notIdempotent, func() error {
+		return r.Repository.RemoveNotificationChannel(ctx, projectID, name)
+	})
+}
+
+func (r *RetryRepository) SetAlertRoutes(ctx context.Context, projectID primitive.ObjectID, routes []models.AlertRoute) error {
+	return r.attempt(ctx, "SetAlertRoutes", idempotent, func() error {
+		return r.Repository.SetAlertRoutes(ctx, projectID, routes)
+	})
+}
+
+func (r *RetryRepository) SetOnCallSchedule(ctx context.Context, projectID primitive.ObjectID, schedule *models.OnCallSchedule) error {
+	return r.attempt(ctx, "SetOnCallSchedule", idempotent, func() error {
+		return r.Repository.SetOnCallSchedule(ctx, projectID, schedule)
+	})
+}
+
+func (r *RetryRepository) AddProjectUser(ctx context.Context, projectID primitive.ObjectID, user models.ProjectUser) error {
+	return r.attempt(ctx, "AddProjectUser", idempotent, func() error {
+		return r.Repository.AddProjectUser(ctx, projectID, user)
+	})
+}
+
+func (r *RetryRepository) SetProjectStatusPageToken(ctx context.Context, projectID primitive.ObjectID, token string) error {
+	return r.attempt(ctx, "SetProjectStatusPageToken", idempotent, func() error {
+		return r.Repository.SetProjectStatusPageToken(ctx, projectID, token)
+	})
+}
+
+func (r *RetryRepository) SetProjectQuotas(ctx context.Context, projectID primitive.ObjectID, quotas *models.ProjectQuotas) error {
+	return r.attempt(ctx, "SetProjectQuotas", idempotent, func() error {
+		return r.Repository.SetProjectQuotas(ctx, projectID, quotas)
+	})
+}
+
+// Organizations
+
+func (r *RetryRepository) CreateOrganization(ctx context.Context, organization *models.Organization) error {
+	return r.attempt(ctx, "CreateOrganization", notIdempotent, func() error {
+		return r.Repository.CreateOrganization(ctx, organization)
+	})
+}
+
+func (r *RetryRepository) GetOrganizationByID(ctx context.Context, organizationID primitive.ObjectID) (*models.Organization, error) {
+	return retry1(ctx, r, "GetOrganizationByID", idempotent, func() (*models.Organization, error) {
+		return r.Repository.GetOrganizationByID(ctx, organizationID)
+	})
+}
+
+func (r *RetryRepository) GetAllOrganizations(ctx context.Context) ([]*models.Organization, error) {
+	return retry1(ctx, r, "GetAllOrganizations", idempotent, func() ([]*models.Organization, error) {
+		return r.Repository.GetAllOrganizations(ctx)
+	})
+}
+
+func (r *RetryRepository) GetUserOrganizations(ctx context.Context, email string) ([]*models.Organization, error) {
+	return retry1(ctx, r, "GetUserOrganizations", idempotent, func() ([]*models.Organization, error) {
+		return r.Repository.GetUserOrganizations(ctx, email)
+	})
+}
+
+func (r *RetryRepository) UpdateOrganization(ctx context.Context, organizationID primitive.ObjectID, organization *models.Organization) error {
+	return r.attempt(ctx, "UpdateOrganization", idempotent, func() error {
+		return r.Repository.UpdateOrganization(ctx, organizationID, organization)
+	})
+}
+
+func (r *RetryRepository) DeleteOrganization(ctx context.Context, organizationID primitive.ObjectID) error {
+	return r.attempt(ctx, "DeleteOrganization", notIdempotent, func() error {
+		return r.Repository.DeleteOrganization(ctx, organizationID)
+	})
+}
+
+func (r *RetryRepository) GetProjectsByOrganizationID(ctx context.Context, organizationID primitive.ObjectID) ([]*models.Project, error) {
+	return retry1(ctx, r, "GetProjectsByOrganizationID", idempotent, func() ([]*models.Project, error) {
+		return r.Repository.GetProjectsByOrganizationID(ctx, organizationID)
+	})
+}
+
+func (r *RetryRepository) SetProjectOrganization(ctx context.Context, projectID primitive.ObjectID, organizationID *primitive.ObjectID) error {
+	return r.attempt(ctx, "SetProjectOrganization", idempotent, func() error {
+		return r.Repository.SetProjectOrganization(ctx, projectID, organizationID)
+	})
+}
+
+func (r *RetryRepository) SetOrganizationQuotas(ctx context.Context, organizationID primitive.ObjectID, quotas *models.ProjectQuotas) error {
+	return r.attempt(ctx, "SetOrganizationQuotas", idempotent, func() error {
+		return r.Repository.SetOrganizationQuotas(ctx, organizationID, quotas)
+	})
+}
+
+// Invitations
+
+func (r *RetryRepository) CreateInvitation(ctx context.Context, invitation *models.Invitation) error {
+	return r.attempt(ctx, "CreateInvitation", notIdempotent, func() error {
+		return r.Repository.CreateInvitation(ctx, invitation)
+	})
+}
+
+func (r *RetryRepository) GetInvitationByUUID(ctx context.Context, invitationUUID string) (*models.Invitation, error) {
+	return retry1(ctx, r, "GetInvitationByUUID", idempotent, func() (*models.Invitation, error) {
+		return r.Repository.GetInvitationByUUID(ctx, invitationUUID)
+	})
+}
+
+func (r *RetryRepository) GetInvitationsByProjectID(ctx context.Context, projectID primitive.ObjectID, status models.InvitationStatus) ([]*models.Invitation, error) {
+	return retry1(ctx, r, "GetInvitationsByProjectID", idempotent, func() ([]*models.Invitation, error) {
+		return r.Repository.GetInvitationsByProjectID(ctx, projectID, status)
+	})
+}
+
+func (r *RetryRepository) UpdateInvitationStatus(ctx context.Context, invitationUUID string, status models.InvitationStatus) error {
+	return r.attempt(ctx, "UpdateInvitationStatus", idempotent, func() error {
+		return r.Repository.UpdateInvitationStatus(ctx, invitationUUID, status)
+	})
+}
+
+func (r *RetryRepository) AcceptInvitation(ctx context.Context, invitationUUID, email string, at time.Time) (bool, error) {
+	// A retry after an acceptance whose reply was lost would report the invitation as no longer pending
+	return retry1(ctx, r, "AcceptInvitation", notIdempotent, func() (bool, error) {
+		return r.Repository.AcceptInvitation(ctx, invitationUUID, email, at)
+	})
+}
+
+// Secrets
+
+func (r *RetryRepository) UpsertSecret(ctx context.Context, secret *models.Secret) error {
+	return r.attempt(ctx, "UpsertSecret", idempotent, func() error {
+		return r.Repository.UpsertSecret(ctx, secret)
+	})
+}
+
+func (r *RetryRepository) GetSecretByName(ctx context.Context, projectID primitive.ObjectID, name string) (*models.Secret, error) {
+	return retry1(ctx, r, "GetSecretByName", idempotent, func() (*models.Secret, error) {
+		return r.Repository.GetSecretByName(ctx, projectID, name)
+	})
+}
+
+func (r *RetryRepository) GetSecretsByProjectID(ctx context.Context, projectID primitive.ObjectID) ([]*models.Secret, error) {
+	return retry1(ctx, r, "GetSecretsByProjectID", idempotent, func() ([]*models.Secret, error) {
+		return r.Repository.GetSecretsByProjectID(ctx, projectID)
+	})
+}
+
+func (r *RetryRepository) DeleteSecret(ctx context.Context, projectID primitive.ObjectID, name string) error {
+	return r.attempt(ctx, "DeleteSecret", notIdempotent, func() error {
+		return r.Repository.DeleteSecret(ctx, projectID, name)
+	})
+}
+
+// Project settings
+
+func (r *RetryRepository) GetProjectSettings(ctx context.Context, projectID primitive.ObjectID) (*models.ProjectSettings, error) {
+	return retry1(ctx, r, "GetProjectSettings", idempotent, func() (*models.ProjectSettings, error) {
+		return r.Repository.GetProjectSettings(ctx, projectID)
+	})
+}
+
+func (r *RetryRepository) GetProjectSettingsWithRetention(ctx context.Context) ([]*models.ProjectSettings, error) {
+	return retry1(ctx, r, "GetProjectSettingsWithRetention", idempotent, func() ([]*models.ProjectSettings, error) {
+		return r.Repository.GetProjectSettingsWithRetention(ctx)
+	})
+}
+
+func (r *RetryRepository) UpsertProjectSettings(ctx context.Context, settings *models.ProjectSettings) error {
+	return r.attempt(ctx, "UpsertProjectSettings", idempotent, func() error {
+		return r.Repository.UpsertProjectSettings(ctx, settings)
+	})
+}
+
+func (r *RetryRepository) DeleteProjectSettings(ctx context.Context, projectID primitive.ObjectID) error {
+	return r.attempt(ctx, "DeleteProjectSettings", idempotent, func() error {
+		return r.Repository.DeleteProjectSettings(ctx, projectID)
+	})
+}
+
+// Task templates
+
+func (r *RetryRepository) CreateTaskTemplate(ctx context.Context, template *models.TaskTemplate) error {
+	return r.attempt(ctx, "CreateTaskTemplate", notIdempotent, func() error {
+		return r.Repository.CreateTaskTemplate(ctx, template)
+	})
+}
+
+func (r *RetryRepository) GetTaskTemplatesByProjectID(ctx context.Context, projectID primitive.ObjectID) ([]*models.TaskTemplate, error) {
+	return retry1(ctx, r, "GetTaskTemplatesByProjectID", idempotent, func() ([]*models.TaskTemplate, error) {
+		return r.Repository.GetTaskTemplatesByProjectID(ctx, projectID)
+	})
+}
+
+func (r *RetryRepository) GetTaskTemplateByUUID(ctx context.Context, projectID primitive.ObjectID, templateUUID string) (*models.TaskTemplate, error) {
+	return retry1(ctx, r, "GetTaskTemplateByUUID", idempotent, func() (*models.TaskTemplate, error) {
+		return r.Repository.GetTaskTemplateByUUID(ctx, projectID, templateUUID)
+	})
+}
+
+func (r *RetryRepository) DeleteTaskTemplate(ctx context.Context, projectID primitive.ObjectID, templateUUID string) error {
+	return r.attempt(ctx, "DeleteTaskTemplate", notIdempotent, func() error {
+		return r.Repository.DeleteTaskTemplate(ctx, projectID, templateUUID)
+	})
+}
+
+func (r *RetryRepository) DeleteTaskTemplatesByProjectID(ctx context.Context, projectID primitive.ObjectID) error {
+	return r.attempt(ctx, "DeleteTaskTemplatesByProjectID", idempotent, func() error {
+		return r.Repository.DeleteTaskTemplatesByProjectID(ctx, projectID)
+	})
+}
+
+// Token revocations
+
+func (r *RetryRepository) CreateTokenRevocation(ctx context.Context, revocation *models.TokenRevocation) error {
+	return r.attempt(ctx, "CreateTokenRevocation", notIdempotent, func() error {
+		return r.Repository.CreateTokenRevocation(ctx, revocation)
+	})
+}
+
+func (r *RetryRepository) IsTokenRevoked(ctx context.Context, jti string, email string, issuedAt time.Time) (bool, error) {
+	return retry1(ctx, r, "IsTokenRevoked", idempotent, func() (bool, error) {
+		return r.Repository.IsTokenRevoked(ctx, jti, email, issuedAt)
+	})
+}
+
+// Tasks
+
+func (r *RetryRepository) CreateTask(ctx context.Context, projectID string, task *models.Task) error {
+	return r.attempt(ctx, "CreateTask", notIdempotent, func() error {
+		return r.Repository.CreateTask(ctx, projectID, task)
+	})
+}
+
+func (r *RetryRepository) GetAllActiveTasks(ctx context.Context) ([]*models.Task, error) {
+	return retry1(ctx, r, "GetAllActiveTasks", idempotent, func() ([]*models.Task, error) {
+		return r.Repository.GetAllActiveTasks(ctx)
+	})
+}
+
+func (r *RetryRepository) GetTasksByStatus(ctx context.Context, statuses []models.TaskStatus) ([]*models.Task, error) {
+	return retry1(ctx, r, "GetTasksByStatus", idempotent, func() ([]*models.Task, error) {
+		return r.Repository.GetTasksByStatus(ctx, statuses)
+	})
+}
+
+func (r *RetryRepository) GetTasksByProjectID(ctx context.Context, projectID primitive.ObjectID) ([]*models.Task, error) {
+	return retry1(ctx, r, "GetTasksByProjectID", idempotent, func() ([]*models.Task, error) {
+		return r.Repository.GetTasksByProjectID(ctx, projectID)
+	})
+}
+
+func (r *RetryRepository) GetTaskBatchByProjectID(ctx context.Context, projectID primitive.ObjectID, limit int) ([]*models.Task, error) {
+	return retry1(ctx, r, "GetTaskBatchByProjectID", idempotent, func() ([]*models.Task, error) {
+		return r.Repository.GetTaskBatchByProjectID(ctx, projectID, limit)
+	})
+}
+
+func (r *RetryRepository) ListTasksByProjectID(ctx context.Context, projectID primitive.ObjectID, filter models.TaskListFilter, page, pageSize int) ([]*models.Task, int64, error) {
+	return retry2(ctx, r, "ListTasksByProjectID", idempotent, func() ([]*models.Task, int64, error) {
+		return r.Repository.ListTasksByProjectID(ctx, projectID, filter, page, pageSize)
+	})
+}
+
+func (r *RetryRepository) UpdateTaskLastFailureAt(ctx context.Context, taskUUID string, failedAt time.Time) error {
+	return r.attempt(ctx, "UpdateTaskLastFailureAt", idempotent, func() error {
+		return r.Repository.UpdateTaskLastFailureAt(ctx, taskUUID, failedAt)
+	})
+}
+
+func (r *RetryRepository) SetTaskMutedUntil(ctx context.Context, taskUUID string, mutedUntil *time.Time) error {
+	return r.attempt(ctx, "SetTaskMutedUntil", idempotent, func() error {
+		return r.Repository.SetTaskMutedUntil(ctx, taskUUID, mutedUntil)
+	})
+}
+
+func (r *RetryRepository) SetTaskNextRunAt(ctx context.Context, taskUUID string, nextRunAt *time.Time) error {
+	return r.attempt(ctx, "SetTaskNextRunAt", idempotent, func() error {
+		return r.Repository.SetTaskNextRunAt(ctx, taskUUID, nextRunAt)
+	})
+}
+
+func (r *RetryRepository) SetTaskFlapping(ctx context.Context, taskUUID string, since *time.Time) error {
+	return r.attempt(ctx, "SetTaskFlapping", idempotent, func() error {
+		return r.Repository.SetTaskFlapping(ctx, taskUUID, since)
+	})
+}
+
+func (r *RetryRepository) GetTaskByUUID(ctx context.Context, taskUUID string) (*models.Task, error) {
+	return retry1(ctx, r, "GetTaskByUUID", idempotent, func() (*models.Task, error) {
+		return r.Repository.GetTaskByUUID(ctx, taskUUID)
+	})
+}
+
+func (r *RetryRepository) UpdateTask(ctx context.Context, taskUUID string, task *models.Task) error {
+	return r.attempt(ctx, "UpdateTask", idempotent, func() error {
+		return r.Repository.UpdateTask(ctx, taskUUID, task)
+	})
+}
+
+func (r *RetryRepository) UpdateTaskStatus(ctx context.Context, taskUUID string, status models.TaskStatus) error {
+	return r.attempt(ctx, "UpdateTaskStatus", idempotent, func() error {
+		return r.Repository.UpdateTaskStatus(ctx, taskUUID, status)
+	})
+}
+
+func (r *RetryRepository) DeleteTask(ctx context.Context, taskUUID string) error {
+	return r.attempt(ctx, "DeleteTask", notIdempotent, func() error {
+		return r.Repository.DeleteTask(ctx, taskUUID)
+	})
+}
+
+// Task groups
+
+func (r *RetryRepository) CreateTaskGroup(ctx context.Context, projectID string, taskGroup *models.TaskGroup) error {
+	return r.attempt(ctx, "CreateTaskGroup", notIdempotent, func() error {
+		return r.Repository.CreateTaskGroup(ctx, projectID, taskGroup)
+	})
+}
+
+func (r *RetryRepository) GetTaskGroupsByProjectID(ctx context.Context, projectID primitive.ObjectID) ([]*models.TaskGroup, error) {
+	return retry1(ctx, r, "GetTaskGroupsByProjectID", idempotent, func() ([]*models.TaskGroup, error) {
+		return r.Repository.GetTaskGroupsByProjectID(ctx, projectID)
+	})
+}
+
+func (r *RetryRepository) GetTaskGroupByUUID(ctx context.Context, taskGroupUUID string) (*models.TaskGroup, error) {
+	return retry1(ctx, r, "GetTaskGroupByUUID", idempotent, func() (*models.TaskGroup, error) {
+		return r.Repository.GetTaskGroupByUUID(ctx, taskGroupUUID)
+	})
+}
+
+func (r *RetryRepository) GetTaskGroupByID(ctx context.Context, taskGroupID primitive.ObjectID) (*models.TaskGroup, error) {
+	return retry1(ctx, r, "GetTaskGroupByID", idempotent, func() (*models.TaskGroup, error) {
+		return r.Repository.GetTaskGroupByID(ctx, taskGroupID)
+	})
+}
+
+func (r *RetryRepository) UpdateTaskGroup(ctx context.Context, taskGroupUUID string, taskGroup *models.TaskGroup) error {
+	return r.attempt(ctx, "UpdateTaskGroup", idempotent, func() error {
+		return r.Repository.UpdateTaskGroup(ctx, taskGroupUUID, taskGroup)
+	})
+}
+
+func (r *RetryRepository) UpdateTaskGroupWithTasks(ctx context.Context, taskGroup *models.TaskGroup, cascade models.TaskGroupCascade) (*models.TaskGroupCascadeResult, error) {
+	return retry1(ctx, r, "UpdateTaskGroupWithTasks", notIdempotent, func() (*models.TaskGroupCascadeResult, error) {
+		return r.Repository.UpdateTaskGroupWithTasks(ctx, taskGroup, cascade)
+	})
+}
+
+func (r *RetryRepository) UpdateTaskGroupStatus(ctx context.Context, taskGroupUUID string, status models.TaskGroupStatus) error {
+	return r.attempt(ctx, "UpdateTaskGroupStatus", idempotent, func() error {
+		return r.Repository.UpdateTaskGroupStatus(ctx, taskGroupUUID, status)
+	})
+}
+
+func (r *RetryRepository) UpdateTaskGroupState(ctx context.Context, taskGroupUUID string, state models.TaskGroupState) error {
+	return r.attempt(ctx, "UpdateTaskGroupState", idempotent, func() error {
+		return r.Repository.UpdateTaskGroupState(ctx, taskGroupUUID, state)
+	})
+}
+
+func (r *RetryRepository) DeleteTaskGroup(ctx context.Context, taskGroupUUID string) error {
+	return r.attempt(ctx, "DeleteTaskGroup", notIdempotent, func() error {
+		return r.Repository.DeleteTaskGroup(ctx, taskGroupUUID)
+	})
+}
+
+func (r *RetryRepository) GetTasksByGroupID(ctx context.Context, taskGroupID primitive.ObjectID) ([]*models.Task, error) {
+	return retry1(ctx, r, "GetTasksByGroupID", idempotent, func() ([]*models.Task, error) {
+		return r.Repository.GetTasksByGroupID(ctx, taskGroupID)
+	})
+}
+
+func (r *RetryRepository) GetActiveTaskGroupsWithWindows(ctx context.Context) ([]*models.TaskGroup, error) {
+	return retry1(ctx, r, "GetActiveTaskGroupsWithWindows", idempotent, func() ([]*models.TaskGroup, error) {
+		return r.Repository.GetActiveTaskGroupsWithWindows(ctx)
+	})
+}
+
+func (r *RetryRepository) UpdateTaskState(ctx context.Context, taskUUID string, state models.TaskState) error {
+	return r.attempt(ctx, "UpdateTaskState", idempotent, func() error {
+		return r.Repository.UpdateTaskState(ctx, taskUUID, state)
+	})
+}
+
+// Executions
+
+func (r *RetryRepository) CreateExecution(ctx context.Context, execution *models.Execution) error {
+	return r.attempt(ctx, "CreateExecution", notIdempotent, func() error {
+		return r.Repository.CreateExecution(ctx, execution)
+	})
+}
+
+func (r *RetryRepository) GetExecutionsByTaskUUID(ctx context.Context, taskUUID string, startDate, endDate *time.Time) ([]*models.Execution, error) {
+	return retry1(ctx, r, "GetExecutionsByTaskUUID", idempotent, func() ([]*models.Execution, error) {
+		return r.Repository.GetExecutionsByTaskUUID(ctx, taskUUID, startDate, endDate)
+	})
+}
+
+func (r *RetryRepository) GetExecutionsByTaskUUIDPaginated(ctx context.Context, taskUUID string, startDate, endDate *time.Time, page, pageSize int) ([]*models.Execution, int64, error) {
+	return retry2(ctx, r, "GetExecutionsByTaskUUIDPaginated", idempotent, func() ([]*models.Execution, int64, error) {
+		return r.Repository.GetExecutionsByTaskUUIDPaginated(ctx, taskUUID, startDate, endDate, page, pageSize)
+	})
+}
+
+func (r *RetryRepository) AppendLogToExecution(ctx context.Context, executionUUID string, logEntry models.LogEntry) error {
+	return r.attempt(ctx, "AppendLogToExecution", notIdempotent, func() error {
+		return r.Repository.AppendLogToExecution(ctx, executionUUID, logEntry)
+	})
+}
+
+func (r *RetryRepository) UpdateExecutionStatus(ctx context.Context, executionUUID string, status models.ExecutionStatus, errorMessage *string) error {
+	return r.attempt(ctx, "UpdateExecutionStatus", idempotent, func() error {
+		return r.Repository.UpdateExecutionStatus(ctx, executionUUID, status, errorMessage)
+	})
+}
+
+func (r *RetryRepository) FailExecutionIfUnfinished(ctx context.Context, executionUUID string, errorMessage string) (bool, error) {
+	return retry1(ctx, r, "FailExecutionIfUnfinished", notIdempotent, func() (bool, error) {
+		return r.Repository.FailExecutionIfUnfinished(ctx, executionUUID, errorMessage)
+	})
+}
+
+func (r *RetryRepository) RecordExecutionEndpoint(ctx context.Context, executionUUID string, endpoint string) error {
+	return r.attempt(ctx, "RecordExecutionEndpoint", idempotent, func() error {
+		return r.Repository.RecordExecutionEndpoint(ctx, executionUUID, endpoint)
+	})
+}
+
+func (r *RetryRepository) StartQueuedExecution(ctx context.Context, executionUUID string, startedAt time.Time) (bool, error) {
+	return retry1(ctx, r, "StartQueuedExecution", idempotent, func() (bool, error) {
+		return r.Repository.StartQueuedExecution(ctx, executionUUID, startedAt)
+	})
+}
+
+func (r *RetryRepository) CountActiveExecutions(ctx context.Context, taskUUIDs []string, since time.Time) (int64, error) {
+	return retry1(ctx, r, "CountActiveExecutions", idempotent, func() (int64, error) {
+		return r.Repository.CountActiveExecutions(ctx, taskUUIDs, since)
+	})
+}
+
+func (r *RetryRepository) RecordExecutionHeartbeat(ctx context.Context, executionUUID string, at time.Time) (bool, error) {
+	return retry1(ctx, r, "RecordExecutionHeartbeat", idempotent, func() (bool, error) {
+		return r.Repository.RecordExecutionHeartbeat(ctx, executionUUID, at)
+	})
+}
+
+func (r *RetryRepository) GetExecutionByUUID(ctx context.Context, executionUUID string) (*models.Execution, error) {
+	return retry1(ctx, r, "GetExecutionByUUID", idempotent, func() (*models.Execution, error) {
+		return r.Repository.GetExecutionByUUID(ctx, executionUUID)
+	})
+}
+
+func (r *RetryRepository) GetLatestExecutionByTaskUUID(ctx context.Context, taskUUID string) (*models.Execution, error) {
+	return retry1(ctx, r, "GetLatestExecutionByTaskUUID", idempotent, func() (*models.Execution, error) {
+		return r.Repository.GetLatestExecutionByTaskUUID(ctx, taskUUID)
+	})
+}
+
+func (r *RetryRepository) GetLatestExecutionsByTaskUUIDs(ctx context.Context, taskUUIDs []string) (map[string]*models.Execution, error) {
+	return retry1(ctx, r, "GetLatestExecutionsByTaskUUIDs", idempotent, func() (map[string]*models.Execution, error) {
+		return r.Repository.GetLatestExecutionsByTaskUUIDs(ctx, taskUUIDs)
+	})
+}
+
+func (r *RetryRepository) GetLastSuccessByTaskUUIDs(ctx context.Context, taskUUIDs []string) (map[string]time.Time, error) {
+	return retry1(ctx, r, "GetLastSuccessByTaskUUIDs", idempotent, func() (map[string]time.Time, error) {
+		return r.Repository.GetLastSuccessByTaskUUIDs(ctx, taskUUIDs)
+	})
+}
+
+func (r *RetryRepository) DeleteExecutionsByTaskUUIDs(ctx context.Context, taskUUIDs []string) (int64, error) {
+	return retry1(ctx, r, "DeleteExecutionsByTaskUUIDs", notIdempotent, func() (int64, error) {
+		return r.Repository.DeleteExecutionsByTaskUUIDs(ctx, taskUUIDs)
+	})
+}
+
+func (r *RetryRepository) DeleteExecutionsByTaskUUIDsBefore(ctx context.Context, taskUUIDs []string, before time.Time) (int64, error) {
+	return retry1(ctx, r, "DeleteExecutionsByTaskUUIDsBefore", notIdempotent, func() (int64, error) {
+		return r.Repository.DeleteExecutionsByTaskUUIDsBefore(ctx, taskUUIDs, before)
+	})
+}
+
+func (r *RetryRepository) GetExecutionsByTaskUUIDsBetween(ctx context.Context, taskUUIDs []string, from, to time.Time, limit int) ([]*models.Execution, error) {
+	return retry1(ctx, r, "GetExecutionsByTaskUUIDsBetween", idempotent, func() ([]*models.Execution, error) {
+		return r.Repository.GetExecutionsByTaskUUIDsBetween(ctx, taskUUIDs, from, to, limit)
+	})
+}
+
+func (r *RetryRepository) TrimExecutionLogsBefore(ctx context.Context, taskUUIDs []string, statuses []models.ExecutionStatus, before time.Time) (int64, error) {
+	return retry1(ctx, r, "TrimExecutionLogsBefore", idempotent, func() (int64, error) {
+		return r.Repository.TrimExecutionLogsBefore(ctx, taskUUIDs, statuses, before)
+	})
+}
+
+func (r *RetryRepository) GetExecutionBatchByTaskUUID(ctx context.Context, taskUUID string, limit int) ([]*models.Execution, error) {
+	return retry1(ctx, r, "GetExecutionBatchByTaskUUID", idempotent, func() ([]*models.Execution, error) {
+		return r.Repository.GetExecutionBatchByTaskUUID(ctx, taskUUID, limit)
+	})
+}
+
+func (r *RetryRepository) DeleteExecutionsByIDs(ctx context.Context, executionIDs []primitive.ObjectID) (int64, error) {
+	return retry1(ctx, r, "DeleteExecutionsByIDs", notIdempotent, func() (int64, error) {
+		return r.Repository.DeleteExecutionsByIDs(ctx, executionIDs)
+	})
+}
+
+// Failure statistics
+
+func (r *RetryRepository) IncrementFailureStat(ctx context.Context, projectID primitive.ObjectID, date string) error {
+	return r.attempt(ctx, "IncrementFailureStat", notIdempotent, func() error {
+		return r.Repository.IncrementFailureStat(ctx, projectID, date)
+	})
+}
+
+func (r *RetryRepository) GetFailureStatsByProject(ctx context.Context, projectID primitive.ObjectID, days int) ([]*models.FailedExecutionStats, int, error) {
+	return retry2(ctx, r, "GetFailureStatsByProject", idempotent, func() ([]*models.FailedExecutionStats, int, error) {
+		return r.Repository.GetFailureStatsByProject(ctx, projectID, days)
+	})
+}
+
+func (r *RetryRepository) DeleteStatsByProjectID(ctx context.Context, projectID primitive.ObjectID) error {
+	return r.attempt(ctx, "DeleteStatsByProjectID", idempotent, func() error {
+		return r.Repository.DeleteStatsByProjectID(ctx, projectID)
+	})
+}
+
+func (r *RetryRepository) DecrementFailureStats(ctx context.Context, projectID primitive.ObjectID, countsByDate map[string]int) error {
+	return r.attempt(ctx, "DecrementFailureStats", notIdempotent, func() error {
+		return r.Repository.DecrementFailureStats(ctx, projectID, countsByDate)
+	})
+}
+
+// Execution statistics
+
+func (r *RetryRepository) GetExecutionStatsByProject(ctx context.Context, projectID primitive.ObjectID, days int) ([]*models.ExecutionStats, error) {
+	return retry1(ctx, r, "GetExecutionStatsByProject", idempotent, func() ([]*models.ExecutionStats, error) {
+		return r.Repository.GetExecutionStatsByProject(ctx, projectID, days)
+	})
+}
+
+// Task failures by date
+
+func (r *RetryRepository) GetTaskFailuresByDate(ctx context.Context, projectID primitive.ObjectID, date string) ([]*models.TaskFailureStats, int, error) {
+	return retry2(ctx, r, "GetTaskFailuresByDate", idempotent, func() ([]*models.TaskFailureStats, int, error) {
+		return r.Repository.GetTaskFailuresByDate(ctx, projectID, date)
+	})
+}
+
+// Stored task failure stats (pre-calculated)
+
+func (r *RetryRepository) StoreTaskFailureStats(ctx context.Context, stats *models.StoredTaskFailureStats) error {
+	return r.attempt(ctx, "StoreTaskFailureStats", idempotent, func() error {
+		return r.Repository.StoreTaskFailureStats(ctx, stats)
+	})
+}
+
+func (r *RetryRepository) GetStoredTaskFailureStats(ctx context.Context, projectID primitive.ObjectID, date string) (*models.StoredTaskFailureStats, error) {
+	return retry1(ctx, r, "GetStoredTaskFailureStats", idempotent, func() (*models.StoredTaskFailureStats, error) {
+		return r.Repository.GetStoredTaskFailureStats(ctx, projectID, date)
+	})
+}
+
+func (r *RetryRepository) CalculateTaskFailureStats(ctx context.Context, projectID primitive.ObjectID, date string) (*models.StoredTaskFailureStats, error) {
+	return retry1(ctx, r, "CalculateTaskFailureStats", idempotent, func() (*models.StoredTaskFailureStats, error) {
+		return r.Repository.CalculateTaskFailureStats(ctx, projectID, date)
+	})
+}
+
+func (r *RetryRepository) RemoveTaskFromStoredFailureStats(ctx context.Context, projectID primitive.ObjectID, taskUUID string) error {
+	return r.attempt(ctx, "RemoveTaskFromStoredFailureStats", idempotent, func() error {
+		return r.Repository.RemoveTaskFromStoredFailureStats(ctx, projectID, taskUUID)
+	})
+}
+
+// Usage metering
+
+func (r *RetryRepository) IncrementUsage(ctx context.Context, projectID primitive.ObjectID, date string, counters models.UsageCounters) error {
+	// $inc is not idempotent; a retried increment could count the usage twice
+	return r.attempt(ctx, "IncrementUsage", notIdempotent, func() error {
+		return r.Repository.IncrementUsage(ctx, projectID, date, counters)
+	})
+}
+
+func (r *RetryRepository) GetUsage(ctx context.Context, filter models.UsageFilter) ([]*models.UsageRecord, error) {
+	return retry1(ctx, r, "GetUsage", idempotent, func() ([]*models.UsageRecord, error) {
+		return r.Repository.GetUsage(ctx, filter)
+	})
+}
+
+// Event outbox
+
+func (r *RetryRepository) CreateOutboxEvent(ctx context.Context, event *models.OutboxEvent) error {
+	return r.attempt(ctx, "CreateOutboxEvent", notIdempotent, func() error {
+		return r.Repository.CreateOutboxEvent(ctx, event)
+	})
+}
+
+func (r *RetryRepository) ClaimOutboxEvents(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*models.OutboxEvent, error) {
+	return retry1(ctx, r, "ClaimOutboxEvents", notIdempotent, func() ([]*models.OutboxEvent, error) {
+		return r.Repository.ClaimOutboxEvents(ctx, now, lease, limit)
+	})
+}
+
+func (r *RetryRepository) DeleteOutboxEvent(ctx context.Context, id primitive.ObjectID) error {
+	return r.attempt(ctx, "DeleteOutboxEvent", idempotent, func() error {
+		return r.Repository.DeleteOutboxEvent(ctx, id)
+	})
+}
+
+// In-process job queue
+
+func (r *RetryRepository) CreateQueuedJob(ctx context.Context, job *models.QueuedJob) error {
+	return r.attempt(ctx, "CreateQueuedJob", notIdempotent, func() error {
+		return r.Repository.CreateQueuedJob(ctx, job)
+	})
+}
+
+func (r *RetryRepository) ClaimQueuedJobs(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*models.QueuedJob, error) {
+	return retry1(ctx, r, "ClaimQueuedJobs", notIdempotent, func() ([]*models.QueuedJob, error) {
+		return r.Repository.ClaimQueuedJobs(ctx, now, lease, limit)
+	})
+}
+
+func (r *RetryRepository) DeleteQueuedJob(ctx context.Context, id primitive.ObjectID) error {
+	return r.attempt(ctx, "DeleteQueuedJob", idempotent, func() error {
+		return r.Repository.DeleteQueuedJob(ctx, id)
+	})
+}
+
+func (r *RetryRepository) GetTaskReferences(ctx context.Context) ([]*models.TaskReference, error) {
+	return retry1(ctx, r, "GetTaskReferences", idempotent, func() ([]*models.TaskReference, error) {
+		return r.Repository.GetTaskReferences(ctx)
+	})
+}
+
+func (r *RetryRepository) GetExecutionTaskUUIDs(ctx context.Context) ([]string, error) {
+	return retry1(ctx, r, "GetExecutionTaskUUIDs", idempotent, func() ([]string, error) {
+		return r.Repository.GetExecutionTaskUUIDs(ctx)
+	})
+}
+
+func (r *RetryRepository) GetStatsProjectIDs(ctx context.Context) ([]primitive.ObjectID, error) {
+	return retry1(ctx, r, "GetStatsProjectIDs", idempotent, func() ([]primitive.ObjectID, error) {
+		return r.Repository.GetStatsProjectIDs(ctx)
+	})
+}
+
+// Incidents
+
+func (r *RetryRepository) RecordIncidentFailure(ctx context.Context, failure models.IncidentFailure) (*models.Incident, error) {
+	return retry1(ctx, r, "RecordIncidentFailure", notIdempotent, func() (*models.Incident, error) {
+		return r.Repository.RecordIncidentFailure(ctx, failure)
+	})
+}
+
+func (r *RetryRepository) ResolveActiveIncident(ctx context.Context, taskUUID string, executionUUID string, resolvedAt time.Time) (*models.Incident, error) {
+	return retry1(ctx, r, "ResolveActiveIncident", notIdempotent, func() (*models.Incident, error) {
+		return r.Repository.ResolveActiveIncident(ctx, taskUUID, executionUUID, resolvedAt)
+	})
+}
+
+func (r *RetryRepository) GetIncidentByUUID(ctx context.Context, projectID primitive.ObjectID, incidentUUID string) (*models.Incident, error) {
+	return retry1(ctx, r, "GetIncidentByUUID", idempotent, func() (*models.Incident, error) {
+		return r.Repository.GetIncidentByUUID(ctx, projectID, incidentUUID)
+	})
+}
+
+func (r *RetryRepository) ListIncidents(ctx context.Context, projectID primitive.ObjectID, filter models.IncidentListFilter, page, pageSize int) ([]*models.Incident, int64, error) {
+	return retry2(ctx, r, "ListIncidents", idempotent, func() ([]*models.Incident, int64, error) {
+		return r.Repository.ListIncidents(ctx, projectID, filter, page, pageSize)
+	})
+}
+
+func (r *RetryRepository) AcknowledgeIncident(ctx context.Context, projectID primitive.ObjectID, incidentUUID string, acknowledgedBy string, acknowledgedAt time.Time) (*models.Incident, error) {
+	return retry1(ctx, r, "AcknowledgeIncident", notIdempotent, func() (*models.Incident, error) {
+		return r.Repository.AcknowledgeIncident(ctx, projectID, incidentUUID, acknowledgedBy, acknowledgedAt)
+	})
+}
+
+func (r *RetryRepository) ResolveIncident(ctx context.Context, projectID primitive.ObjectID, incidentUUID string, resolvedBy string, resolvedAt time.Time) (*models.Incident, error) {
+	return retry1(ctx, r, "ResolveIncident", notIdempotent, func() (*models.Incident, error) {
+		return r.Repository.ResolveIncident(ctx, projectID, incidentUUID, resolvedBy, resolvedAt)
+	})
+}
+
+func (r *RetryRepository) AddIncidentComment(ctx context.Context, projectID primitive.ObjectID, incidentUUID string, comment models.IncidentComment) error {
+	return r.attempt(ctx, "AddIncidentComment", notIdempotent, func() error {
+		return r.Repository.AddIncidentComment(ctx, projectID, incidentUUID, comment)
+	})
+}
+
+func (r *RetryRepository) DeleteIncidentsByProjectID(ctx context.Context, projectID primitive.ObjectID) error {
+	return r.attempt(ctx, "DeleteIncidentsByProjectID", idempotent, func() error {
+		return r.Repository.DeleteIncidentsByProjectID(ctx, projectID)
+	})
+}
@@ -0,0 +1,95 @@
+package repositories_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/yourusername/cron-observer/backend/internal/models"
+	"github.com/yourusername/cron-observer/backend/internal/repositories"
+	"github.com/yourusername/cron-observer/backend/mocks"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.uber.org/mock/gomock"
+)
+
+var (
+	networkErr     = mongo.CommandError{Message: "connection reset", Labels: []string{"NetworkError"}}
+	steppedDownErr = mongo.CommandError{Code: 10107, Name: "NotWritablePrimary", Message: "not primary"}
+)
+
+func newRetryRepository(t *testing.T) (*repositories.RetryRepository, *mocks.MockRepository) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+	repo := mocks.NewMockRepository(ctrl)
+	policy := repositories.RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: 5 * time.Millisecond}
+	return repositories.NewRetryRepository(repo, policy), repo
+}
+
+func TestRetryRepository_RetriesReadsAfterNetworkErrors(t *testing.T) {
+	retrying, repo := newRetryRepository(t)
+	task := &models.Task{UUID: "task-1"}
+	gomock.InOrder(
+		repo.EXPECT().GetTaskByUUID(gomock.Any(), "task-1").Return(nil, networkErr),
+		repo.EXPECT().GetTaskByUUID(gomock.Any(), "task-1").Return(task, nil),
+	)
+
+	found, err := retrying.GetTaskByUUID(context.Background(), "task-1")
+	if err != nil || found != task {
+		t.Fatalf("GetTaskByUUID = %v, %v, want the task after a retry", found, err)
+	}
+}
+
+func TestRetryRepository_DoesNotRetryInsertsAfterNetworkErrors(t *testing.T) {
+	retrying, repo := newRetryRepository(t)
+	repo.EXPECT().CreateTask(gomock.Any(), "project", gomock.Any()).Return(networkErr).Times(1)
+
+	err := retrying.CreateTask(context.Background(), "project", &models.Task{})
+	if !mongo.IsNetworkError(err) {
+		t.Fatalf("CreateTask error = %v, want the network error", err)
+	}
+}
+
+func TestRetryRepository_RetriesInsertsRejectedByAFormerPrimary(t *testing.T) {
+	retrying, repo := newRetryRepository(t)
+	gomock.InOrder(
+		repo.EXPECT().CreateTask(gomock.Any(), "project", gomock.Any()).Return(steppedDownErr),
+		repo.EXPECT().CreateTask(gomock.Any(), "project", gomock.Any()).Return(nil),
+	)
+
+	if err := retrying.CreateTask(context.Background(), "project", &models.Task{}); err != nil {
+		t.Fatalf("CreateTask: %v", err)
+	}
+}
+
+func TestRetryRepository_StopsAfterMaxAttempts(t *testing.T) {
+	retrying, repo := newRetryRepository(t)
+	repo.EXPECT().UpdateTaskStatus(gomock.Any(), "task-1", models.TaskStatusDisabled).Return(steppedDownErr).Times(3)
+
+	err := retrying.UpdateTaskStatus(context.Background(), "task-1", models.TaskStatusDisabled)
+	if !errors.As(err, new(mongo.CommandError)) {
+		t.Fatalf("UpdateTaskStatus error = %v, want the last server error", err)
+	}
+}
+
+func TestRetryRepository_DoesNotRetryPermanentErrors(t *testing.T) {
+	retrying, repo := newRetryRepository(t)
+	repo.EXPECT().GetTaskByUUID(gomock.Any(), "missing").Return(nil, mongo.ErrNoDocuments).Times(1)
+
+	if _, err := retrying.GetTaskByUUID(context.Background(), "missing"); err != mongo.ErrNoDocuments {
+		t.Fatalf("GetTaskByUUID error = %v, want mongo.ErrNoDocuments", err)
+	}
+}
+
+func TestRetryRepository_StopsWhenContextIsCancelled(t *testing.T) {
+	retrying, repo := newRetryRepository(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	repo.EXPECT().GetAllProjects(gomock.Any()).DoAndReturn(func(context.Context) ([]*models.Project, error) {
+		cancel()
+		return nil, networkErr
+	}).Times(1)
+
+	if _, err := retrying.GetAllProjects(ctx); !mongo.IsNetworkError(err) {
+		t.Fatalf("GetAllProjects error = %v, want the network error", err)
+	}
+}
@@ -0,0 +1,80 @@
+package scheduler
+
+import (
+	"sync"
+	"time"
+)
+
+const (
+	// circuitFailureThreshold is the number of consecutive failed dispatches that opens an endpoint's circuit
+	circuitFailureThreshold = 5
+	// circuitCooldown is how long an open circuit rejects dispatches before one is let through to test the endpoint
+	circuitCooldown = time.Minute
+)
+
+// endpointCircuits guards the execution endpoints of every project. Circuits are kept in memory per server.
+var endpointCircuits = newCircuitBreaker(circuitFailureThreshold, circuitCooldown)
+
+// endpointCircuit is the state of one execution endpoint
+type endpointCircuit struct {
+	failures  int       // consecutive failed dispatches
+	openUntil time.Time // zero while the circuit is closed
+}
+
+// circuitBreaker stops dispatching to execution endpoints that fail every request, so an unreachable endpoint does
+// not make every firing wait for its connection to fail or time out. After the cooldown one dispatch is let through;
+// if it fails the circuit stays open for another cooldown, if any dispatch succeeds it closes.
+type circuitBreaker struct {
+	mu        sync.Mutex
+	threshold int
+	cooldown  time.Duration
+	circuits  map[string]*endpointCircuit // by endpoint URL; endpoints without failures are absent
+}
+
+func newCircuitBreaker(threshold int, cooldown time.Duration) *circuitBreaker {
+	return &circuitBreaker{threshold: threshold, cooldown: cooldown, circuits: make(map[string]*endpointCircuit)}
+}
+
+// allow reports whether a dispatch may be sent to the endpoint. Once the cooldown of an open circuit ended, the
+// first caller is allowed and the cooldown restarts, so only one dispatch at a time tests the endpoint.
+func (b *circuitBreaker) allow(endpoint string, now time.Time) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	circuit, ok := b.circuits[endpoint]
+	if !ok || circuit.openUntil.IsZero() {
+		return true
+	}
+	if now.Before(circuit.openUntil) {
+		return false
+	}
+	circuit.openUntil = now.Add(b.cooldown)
+	return true
+}
+
+// record adds the outcome of a dispatch to the endpoint. It reports whether the failure opened the circuit; failures
+// of an endpoint whose circuit is already open only extend the cooldown.
+func (b *circuitBreaker) record(endpoint string, failed bool, now time.Time) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if !failed {
+		delete(b.circuits, endpoint)
+		return false
+	}
+	circuit, ok := b.circuits[endpoint]
+	if !ok {
+		circuit = &endpointCircuit{}
+		b.circuits[endpoint] = circuit
+	}
+	circuit.failures++
+	if !circuit.openUntil.IsZero() {
+		circuit.openUntil = now.Add(b.cooldown)
+		return false
+	}
+	if circuit.failures >= b.threshold {
+		circuit.openUntil = now.Add(b.cooldown)
+		return true
+	}
+	return false
+}
@@ -0,0 +1,102 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/yourusername/cron-observer/backend/internal/events"
+	"github.com/yourusername/cron-observer/backend/internal/models"
+	"github.com/yourusername/cron-observer/backend/internal/repositories"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestCircuitBreaker_OpensAfterConsecutiveFailuresAndProbesAfterCooldown(t *testing.T) {
+	breaker := newCircuitBreaker(3, time.Minute)
+	now := time.Date(2025, 1, 15, 10, 0, 0, 0, time.UTC)
+	const endpoint = "https://api.example.com/execute"
+
+	// A success in between resets the count
+	breaker.record(endpoint, true, now)
+	breaker.record(endpoint, false, now)
+	breaker.record(endpoint, true, now)
+	breaker.record(endpoint, true, now)
+	if opened := breaker.record(endpoint, true, now); !opened {
+		t.Fatal("Expected the third consecutive failure to open the circuit")
+	}
+	if breaker.allow(endpoint, now.Add(59*time.Second)) {
+		t.Error("Expected the open circuit to reject dispatches during the cooldown")
+	}
+
+	// After the cooldown a single dispatch tests the endpoint; its failure keeps the circuit open without reopening it
+	probeAt := now.Add(time.Minute)
+	if !breaker.allow(endpoint, probeAt) || breaker.allow(endpoint, probeAt) {
+		t.Error("Expected exactly one dispatch to be let through after the cooldown")
+	}
+	if opened := breaker.record(endpoint, true, probeAt); opened {
+		t.Error("Expected a failed probe not to report the circuit as opened again")
+	}
+	if breaker.allow(endpoint, probeAt.Add(30*time.Second)) {
+		t.Error("Expected a failed probe to restart the cooldown")
+	}
+
+	// A successful probe closes the circuit
+	recoveredAt := probeAt.Add(time.Minute)
+	if !breaker.allow(endpoint, recoveredAt) {
+		t.Fatal("Expected a probe after the second cooldown")
+	}
+	breaker.record(endpoint, false, recoveredAt)
+	if !breaker.allow(endpoint, recoveredAt) || !breaker.allow(endpoint, recoveredAt) {
+		t.Error("Expected the circuit to close after a successful dispatch")
+	}
+}
+
+func TestExecuteTask_FailsExecutionsWhileEndpointCircuitIsOpen(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	repo := repositories.NewMemoryRepository()
+	project := &models.Project{ID: primitive.NewObjectID(), Name: "billing", ExecutionEndpoint: server.URL}
+	if err := repo.CreateProject(ctx, project); err != nil {
+		t.Fatalf("CreateProject: %v", err)
+	}
+	task := &models.Task{UUID: "task-uuid", Name: "Backup", ProjectID: project.ID}
+	eventBus := events.NewEventBus(10)
+	defer eventBus.Close()
+	opened := events.Subscribe(eventBus, events.CircuitOpenedTopic)
+
+	for i := 0; i < circuitFailureThreshold; i++ {
+		if _, _, err := ExecuteTaskAndWait(ctx, task, repo, eventBus, "TEST"); err != nil {
+			t.Fatalf("ExecuteTaskAndWait %d: %v", i, err)
+		}
+	}
+	select {
+	case payload := <-opened:
+		if payload.Endpoint != server.URL || payload.Project.ID != project.ID || payload.LastError != "status 500" {
+			t.Errorf("Unexpected CircuitOpened payload: %+v", payload)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Expected CircuitOpened event to be published")
+	}
+
+	// The next firing is recorded and failed at once instead of being sent
+	executionUUID, _, err := ExecuteTaskAndWait(ctx, task, repo, eventBus, "TEST")
+	if !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("Expected ErrCircuitOpen, got %v", err)
+	}
+	execution, err := repo.GetExecutionByUUID(ctx, executionUUID)
+	if err != nil || execution.Status != models.ExecutionStatusFailed || execution.Error != models.ExecutionErrorCircuitOpen {
+		t.Fatalf("execution while the circuit is open = %+v, %v", execution, err)
+	}
+	if requests != circuitFailureThreshold {
+		t.Errorf("Expected %d requests to the endpoint, got %d", circuitFailureThreshold, requests)
+	}
+}
@@ -0,0 +1,495 @@
+package scheduler
+
+import (
+	"container/heap"
+	"context"
+	"log"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/yourusername/cron-observer/backend/internal/models"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	// defaultDispatchWorkers is the number of firings dispatched concurrently when not configured
+	defaultDispatchWorkers = 10
+	// concurrencyRecheckInterval is how long a firing over a concurrency limit waits before the limit is checked again
+	concurrencyRecheckInterval = 5 * time.Second
+	// activeExecutionWindow bounds how long ago an unfinished execution may have started to count against a concurrency limit
+	activeExecutionWindow = 24 * time.Hour
+)
+
+// dispatchItem is one queued firing of a task
+type dispatchItem struct {
+	task      *models.Task
+	seq       uint64    // enqueue order; breaks ties between firings of the same time
+	queuedAt  time.Time // when the task fired
+	rate      int       // dispatch rate limit of the task's project when the firing was queued; 0 is unlimited
+	throttled bool      // the rate limit held the firing back at least once
+	limited   bool      // a concurrency limit held the firing back at least once
+	notBefore time.Time // set while a concurrency limit holds the firing back
+	execution string    // UUID of the QUEUED execution recorded for the firing, if any
+}
+
+// rateBucket is the token bucket limiting how many firings of one project are dispatched per second. It holds up
+// to one second worth of tokens, so a project that was idle may send a burst of rate requests.
+type rateBucket struct {
+	rate      int
+	tokens    float64
+	reserved  int // tokens set aside for firings whose concurrency limits are being checked
+	updatedAt time.Time
+	throttled int64 // firings held back by the limit
+}
+
+// reserve sets a token aside if one is available; otherwise it returns how long until the next one is. A reserved
+// token is spent or given back by settle.
+func (b *rateBucket) reserve(rate int, now time.Time) (bool, time.Duration) {
+	if rate != b.rate {
+		b.rate = rate
+		b.tokens = math.Min(b.tokens, float64(rate))
+	}
+	b.tokens = math.Min(float64(rate), b.tokens+now.Sub(b.updatedAt).Seconds()*float64(rate))
+	b.updatedAt = now
+	if available := b.tokens - float64(b.reserved); available >= 1 {
+		b.reserved++
+		return true, 0
+	}
+	return false, time.Duration((1 - b.tokens + float64(b.reserved)) / float64(rate) * float64(time.Second))
+}
+
+// settle spends a reserved token when its firing is dispatched, or gives it back when a concurrency limit held
+// the firing back
+func (b *rateBucket) settle(dispatched bool) {
+	b.reserved--
+	if dispatched {
+		b.tokens--
+	}
+}
+
+// dispatchReservation counts the firings of a task and of its group that passed their concurrency check and are
+// being dispatched, so workers checking the limits at the same time do not all take the last slot
+type dispatchReservation struct {
+	task  int
+	group int
+}
+
+// dispatchHeap orders firings by descending task priority, then by fire time, so a flood of low priority firings
+// cannot starve critical ones and firings of equal priority go out first-in, first-out
+type dispatchHeap []*dispatchItem
+
+func (h dispatchHeap) Len() int { return len(h) }
+
+func (h dispatchHeap) Less(i, j int) bool {
+	if h[i].task.Priority != h[j].task.Priority {
+		return h[i].task.Priority > h[j].task.Priority
+	}
+	if !h[i].queuedAt.Equal(h[j].queuedAt) {
+		return h[i].queuedAt.Before(h[j].queuedAt)
+	}
+	return h[i].seq < h[j].seq
+}
+
+func (h dispatchHeap) Swap(i, j int) { h[i], h[j] = h[j], h[i] }
+
+func (h *dispatchHeap) Push(x interface{}) { *h = append(*h, x.(*dispatchItem)) }
+
+func (h *dispatchHeap) Pop() interface{} {
+	old := *h
+	n := len(old)
+	item := old[n-1]
+	old[n-1] = nil
+	*h = old[:n-1]
+	return item
+}
+
+// dispatchLimits are the optional limits of a dispatch queue; nil functions impose none
+type dispatchLimits struct {
+	// rateLimit looks up the requests per second a project's firings are dispatched at when a firing is queued
+	rateLimit func(ctx context.Context, projectID primitive.ObjectID) int
+	// capacity reports whether the concurrency limits of the task and its group allow another execution besides
+	// the reserved ones other workers are dispatching. It is checked right before a firing is dispatched.
+	capacity func(ctx context.Context, task *models.Task, reserved dispatchReservation) bool
+	// hold records a firing that waits, for a worker or a limit, and returns the UUID of the execution it recorded
+	// or an empty one
+	hold func(ctx context.Context, task *models.Task) string
+	// drop fails the execution hold recorded for a firing that will not be dispatched as the queue stopped
+	drop func(ctx context.Context, executionUUID string)
+}
+
+// dispatchQueue buffers task firings and hands them to a fixed pool of workers, highest priority first.
+// Cron callbacks only enqueue, so a burst of firings waits in priority order instead of spawning a goroutine each.
+// Firings of projects over their dispatch rate limit, and of tasks or groups at their concurrency limit, stay
+// queued while other firings go ahead.
+type dispatchQueue struct {
+	mu       sync.Mutex
+	cond     *sync.Cond
+	items    dispatchHeap
+	seq      uint64
+	started  bool
+	closed   bool
+	idle     int           // workers waiting for a firing
+	recheck  time.Duration // how long a firing over a concurrency limit waits before it is checked again
+	wg       sync.WaitGroup
+	dispatch func(ctx context.Context, task *models.Task, executionUUID string)
+	limits   dispatchLimits
+	buckets  map[primitive.ObjectID]*rateBucket
+	queued   map[primitive.ObjectID]int // queued firings per project
+
+	// Firings being admitted or dispatched, per task UUID and per task group
+	taskReservations  map[string]int
+	groupReservations map[primitive.ObjectID]int
+}
+
+// newDispatchQueue creates a queue that calls dispatch for every firing it hands out, with the UUID of the QUEUED
+// execution recorded for it or an empty one
+func newDispatchQueue(dispatch func(ctx context.Context, task *models.Task, executionUUID string)) *dispatchQueue {
+	q := &dispatchQueue{
+		dispatch: dispatch,
+		recheck:  concurrencyRecheckInterval,
+		buckets:  make(map[primitive.ObjectID]*rateBucket),
+		queued:   make(map[primitive.ObjectID]int),
+
+		taskReservations:  make(map[string]int),
+		groupReservations: make(map[primitive.ObjectID]int),
+	}
+	q.cond = sync.NewCond(&q.mu)
+	return q
+}
+
+// setLimits sets the rate and concurrency limits firings are dispatched within, and how waiting firings are recorded
+func (q *dispatchQueue) setLimits(limits dispatchLimits) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.limits = limits
+}
+
+// start launches the worker pool. Calling start more than once has no effect.
+func (q *dispatchQueue) start(workers int) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.started {
+		return
+	}
+	q.started = true
+
+	if workers <= 0 {
+		workers = defaultDispatchWorkers
+	}
+	for i := 0; i < workers; i++ {
+		q.wg.Add(1)
+		go q.worker()
+	}
+}
+
+// enqueue adds a firing of the task at firedAt to the queue. Firings are ordered by when they fired, not by when
+// they were queued, as looking up the project's rate limit may delay queueing. It returns false once the queue is
+// stopped.
+func (q *dispatchQueue) enqueue(task *models.Task, firedAt time.Time) bool {
+	q.mu.Lock()
+	limits, closed := q.limits, q.closed
+	q.mu.Unlock()
+	if closed {
+		return false
+	}
+
+	// Looked up and recorded outside the lock, they may read and write the database
+	rate := 0
+	if limits.rateLimit != nil {
+		rate = limits.rateLimit(context.Background(), task.ProjectID)
+	}
+	execution := ""
+	if limits.hold != nil && q.mustWait(task.ProjectID, rate, time.Now()) {
+		execution = limits.hold(context.Background(), task)
+	}
+
+	q.mu.Lock()
+	if q.closed {
+		q.mu.Unlock()
+		if execution != "" {
+			log.Printf("[CRON] Scheduler is stopping, queued execution %s of task %s will not be dispatched", execution, task.UUID)
+			limits.dropExecution(execution)
+		}
+		return false
+	}
+	q.seq++
+	heap.Push(&q.items, &dispatchItem{task: task, seq: q.seq, queuedAt: firedAt, rate: rate, execution: execution})
+	q.queued[task.ProjectID]++
+	q.cond.Signal()
+	q.mu.Unlock()
+	return true
+}
+
+// len returns the number of firings waiting for a worker
+func (q *dispatchQueue) len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return len(q.items)
+}
+
+// mustWait reports whether a new firing of the project would wait in the queue: every idle worker has a firing
+// ready to go before it, or the project's bucket holds no token beyond those the firings already queued for it will spend
+func (q *dispatchQueue) mustWait(projectID primitive.ObjectID, rate int, now time.Time) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if len(q.items) >= q.idle && q.ready(now) >= q.idle {
+		return true
+	}
+	if rate <= 0 {
+		return false
+	}
+	tokens := float64(rate)
+	if bucket, ok := q.buckets[projectID]; ok {
+		tokens = math.Min(float64(rate), bucket.tokens+now.Sub(bucket.updatedAt).Seconds()*float64(rate)) - float64(bucket.reserved)
+	}
+	return tokens-float64(q.queued[projectID]) < 1
+}
+
+// backlog returns the firings of the project waiting in the queue and how many the rate limit delayed so far
+func (q *dispatchQueue) backlog(projectID primitive.ObjectID) models.DispatchBacklog {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	var backlog models.DispatchBacklog
+	for _, item := range q.items {
+		if item.task.ProjectID != projectID {
+			continue
+		}
+		backlog.Queued++
+		if backlog.OldestQueuedAt == nil || item.queuedAt.Before(*backlog.OldestQueuedAt) {
+			queuedAt := item.queuedAt
+			backlog.OldestQueuedAt = &queuedAt
+		}
+	}
+	if bucket, ok := q.buckets[projectID]; ok {
+		backlog.Throttled = bucket.throttled
+	}
+	return backlog
+}
+
+// stop rejects new firings and waits for the workers to dispatch the ones already queued
+func (q *dispatchQueue) stop() {
+	q.mu.Lock()
+	q.closed = true
+	q.cond.Broadcast()
+	q.mu.Unlock()
+
+	q.wg.Wait()
+}
+
+// ready counts the queued firings no concurrency limit holds back. Must be called with q.mu held.
+func (q *dispatchQueue) ready(now time.Time) int {
+	ready := 0
+	for _, item := range q.items {
+		if !item.notBefore.After(now) {
+			ready++
+		}
+	}
+	return ready
+}
+
+// next blocks until a firing may be dispatched. It returns nil once the queue is stopped and drained.
+func (q *dispatchQueue) next() *dispatchItem {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	for {
+		if len(q.items) == 0 {
+			if q.closed {
+				return nil
+			}
+			q.wait()
+			continue
+		}
+
+		item, wait := q.popAllowed(time.Now())
+		if item != nil {
+			if q.queued[item.task.ProjectID]--; q.queued[item.task.ProjectID] == 0 {
+				delete(q.queued, item.task.ProjectID)
+			}
+			return item
+		}
+		// Every queued firing is held back by a limit; look again once the first may go
+		timer := time.AfterFunc(wait, func() {
+			q.mu.Lock()
+			q.cond.Broadcast()
+			q.mu.Unlock()
+		})
+		q.wait()
+		timer.Stop()
+	}
+}
+
+// wait waits for a change of the queue as an idle worker. Must be called with q.mu held.
+func (q *dispatchQueue) wait() {
+	q.idle++
+	q.cond.Wait()
+	q.idle--
+}
+
+// popAllowed removes the highest priority firing whose project is within its rate limit and that no concurrency
+// limit holds back, reserving a token of its project's bucket for it. When there is none it returns how long until
+// the first may go. Must be called with q.mu held.
+func (q *dispatchQueue) popAllowed(now time.Time) (*dispatchItem, time.Duration) {
+	var held []*dispatchItem
+	defer func() {
+		for _, item := range held {
+			heap.Push(&q.items, item)
+		}
+	}()
+
+	var wait time.Duration
+	waiting := make(map[primitive.ObjectID]bool)
+	for len(q.items) > 0 {
+		item := heap.Pop(&q.items).(*dispatchItem)
+		// Once stopped, held firings are checked again right away so they do not hold up the shutdown
+		if !q.closed && item.notBefore.After(now) {
+			if retryIn := item.notBefore.Sub(now); wait == 0 || retryIn < wait {
+				wait = retryIn
+			}
+			held = append(held, item)
+			continue
+		}
+		if item.rate <= 0 {
+			return item, 0
+		}
+		projectID := item.task.ProjectID
+		if !waiting[projectID] {
+			bucket, ok := q.buckets[projectID]
+			if !ok {
+				bucket = &rateBucket{rate: item.rate, tokens: float64(item.rate), updatedAt: now}
+				q.buckets[projectID] = bucket
+			}
+			allowed, retryIn := bucket.reserve(item.rate, now)
+			if allowed {
+				return item, 0
+			}
+			waiting[projectID] = true
+			if wait == 0 || retryIn < wait {
+				wait = retryIn
+			}
+		}
+		if !item.throttled {
+			item.throttled = true
+			q.buckets[projectID].throttled++
+		}
+		held = append(held, item)
+	}
+	return nil, wait
+}
+
+func (q *dispatchQueue) worker() {
+	defer q.wg.Done()
+
+	for {
+		item := q.next()
+		if item == nil {
+			return
+		}
+		if !q.admit(item) {
+			continue
+		}
+
+		if wait := time.Since(item.queuedAt); item.limited {
+			log.Printf("[CRON] Task %s waited %s in the dispatch queue for a concurrency limit", item.task.UUID, wait.Round(time.Millisecond))
+		} else if item.throttled {
+			log.Printf("[CRON] Task %s waited %s in the dispatch queue for the project's rate limit of %d/s", item.task.UUID, wait.Round(time.Millisecond), item.rate)
+		} else if wait > time.Second {
+			log.Printf("[CRON] Task %s waited %s in the dispatch queue (priority %d)", item.task.UUID, wait.Round(time.Millisecond), item.task.Priority)
+		}
+		q.dispatch(context.Background(), item.task, item.execution)
+
+		q.mu.Lock()
+		q.unreserve(item.task)
+		q.mu.Unlock()
+	}
+}
+
+// admit checks the concurrency limits of the task right before its firing is dispatched, reserving a slot of the
+// task and its group so concurrent workers count it. The firing's rate limit token is only spent once it is
+// admitted. A firing over a limit is recorded as QUEUED, if it was not already, and goes back to the queue to be
+// checked again after q.recheck. Once the queue is stopped such a firing is dropped and its execution failed: it
+// would hold up the shutdown.
+func (q *dispatchQueue) admit(item *dispatchItem) bool {
+	q.mu.Lock()
+	limits := q.limits
+	reserved := q.reserve(item.task)
+	q.mu.Unlock()
+	if limits.capacity == nil || limits.capacity(context.Background(), item.task, reserved) {
+		q.mu.Lock()
+		q.settleToken(item, true)
+		q.mu.Unlock()
+		return true
+	}
+
+	if item.execution == "" && limits.hold != nil {
+		item.execution = limits.hold(context.Background(), item.task)
+	}
+	if !item.limited {
+		item.limited = true
+		log.Printf("[CRON] Task %s is at its concurrency limit, holding its firing back", item.task.UUID)
+	}
+
+	q.mu.Lock()
+	q.unreserve(item.task)
+	q.settleToken(item, false)
+	if q.closed {
+		q.mu.Unlock()
+		log.Printf("[CRON] Scheduler is stopping, firing of task %s held back by a concurrency limit will not be dispatched", item.task.UUID)
+		if item.execution != "" {
+			limits.dropExecution(item.execution)
+		}
+		return false
+	}
+	item.notBefore = time.Now().Add(q.recheck)
+	heap.Push(&q.items, item)
+	q.queued[item.task.ProjectID]++
+	q.cond.Signal()
+	q.mu.Unlock()
+	return false
+}
+
+// reserve counts a firing of the task as being dispatched and returns the firings of the task and its group
+// reserved before it. Must be called with q.mu held.
+func (q *dispatchQueue) reserve(task *models.Task) dispatchReservation {
+	reserved := dispatchReservation{task: q.taskReservations[task.UUID]}
+	q.taskReservations[task.UUID]++
+	if task.TaskGroupID != nil {
+		reserved.group = q.groupReservations[*task.TaskGroupID]
+		q.groupReservations[*task.TaskGroupID]++
+	}
+	return reserved
+}
+
+// unreserve releases the slot reserve took for a firing of the task. Must be called with q.mu held.
+func (q *dispatchQueue) unreserve(task *models.Task) {
+	if q.taskReservations[task.UUID]--; q.taskReservations[task.UUID] == 0 {
+		delete(q.taskReservations, task.UUID)
+	}
+	if task.TaskGroupID != nil {
+		if q.groupReservations[*task.TaskGroupID]--; q.groupReservations[*task.TaskGroupID] == 0 {
+			delete(q.groupReservations, *task.TaskGroupID)
+		}
+	}
+}
+
+// settleToken spends or gives back the rate limit token popAllowed reserved for the firing. Must be called with
+// q.mu held.
+func (q *dispatchQueue) settleToken(item *dispatchItem, dispatched bool) {
+	if item.rate <= 0 {
+		return
+	}
+	if bucket, ok := q.buckets[item.task.ProjectID]; ok {
+		bucket.settle(dispatched)
+	}
+}
+
+// dropExecution fails the QUEUED execution of a firing that will not be dispatched
+func (l dispatchLimits) dropExecution(executionUUID string) {
+	if l.drop != nil {
+		l.drop(context.Background(), executionUUID)
+	}
+}
@@ -0,0 +1,403 @@
+package scheduler
+
+import (
+	"context"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/yourusername/cron-observer/backend/internal/models"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestDispatchQueue_DispatchesHigherPriorityFirst(t *testing.T) {
+	var mu sync.Mutex
+	var dispatched []string
+	q := newDispatchQueue(func(ctx context.Context, task *models.Task, executionUUID string) {
+		mu.Lock()
+		dispatched = append(dispatched, task.UUID)
+		mu.Unlock()
+	})
+
+	// Firings queued before the workers start are handed out in priority order
+	q.enqueue(&models.Task{UUID: "low", Priority: 0}, time.Now())
+	q.enqueue(&models.Task{UUID: "high", Priority: 50}, time.Now())
+	q.enqueue(&models.Task{UUID: "low-2", Priority: 0}, time.Now())
+	q.enqueue(&models.Task{UUID: "medium", Priority: 10}, time.Now())
+
+	q.start(1)
+	q.stop()
+
+	expected := []string{"high", "medium", "low", "low-2"}
+	if !reflect.DeepEqual(dispatched, expected) {
+		t.Errorf("Expected dispatch order %v, got %v", expected, dispatched)
+	}
+}
+
+func TestDispatchQueue_DispatchesEqualPrioritiesByFireTime(t *testing.T) {
+	var mu sync.Mutex
+	var dispatched []string
+	q := newDispatchQueue(func(ctx context.Context, task *models.Task, executionUUID string) {
+		mu.Lock()
+		dispatched = append(dispatched, task.UUID)
+		mu.Unlock()
+	})
+
+	// A firing whose queueing was delayed still goes before those that fired after it
+	firedAt := time.Date(2025, 1, 15, 9, 0, 0, 0, time.UTC)
+	q.enqueue(&models.Task{UUID: "second", Priority: 10}, firedAt.Add(time.Second))
+	q.enqueue(&models.Task{UUID: "first", Priority: 10}, firedAt)
+	q.enqueue(&models.Task{UUID: "critical", Priority: 90}, firedAt.Add(2*time.Second))
+
+	q.start(1)
+	q.stop()
+
+	expected := []string{"critical", "first", "second"}
+	if !reflect.DeepEqual(dispatched, expected) {
+		t.Errorf("Expected dispatch order %v, got %v", expected, dispatched)
+	}
+}
+
+func TestDispatchQueue_RejectsFiringsAfterStop(t *testing.T) {
+	q := newDispatchQueue(func(ctx context.Context, task *models.Task, executionUUID string) {})
+	q.start(2)
+	q.stop()
+
+	if q.enqueue(&models.Task{UUID: "late"}, time.Now()) {
+		t.Error("Expected enqueue to fail after stop")
+	}
+	if q.len() != 0 {
+		t.Errorf("Expected empty queue, got %d", q.len())
+	}
+}
+
+func TestDispatchQueue_HoldsBackFiringsOverProjectRateLimit(t *testing.T) {
+	limited := primitive.NewObjectID()
+	unlimited := primitive.NewObjectID()
+	var mu sync.Mutex
+	var dispatched []string
+	q := newDispatchQueue(func(ctx context.Context, task *models.Task, executionUUID string) {
+		mu.Lock()
+		dispatched = append(dispatched, task.UUID)
+		mu.Unlock()
+	})
+	q.setLimits(dispatchLimits{rateLimit: func(ctx context.Context, projectID primitive.ObjectID) int {
+		if projectID == limited {
+			return 2
+		}
+		return 0
+	}})
+
+	// The limited project may send two requests at once; its third firing waits for a token while the lower
+	// priority firing of the other project goes ahead
+	for _, uuid := range []string{"limited-1", "limited-2", "limited-3"} {
+		q.enqueue(&models.Task{UUID: uuid, ProjectID: limited, Priority: 10}, time.Now())
+	}
+	q.enqueue(&models.Task{UUID: "unlimited", ProjectID: unlimited}, time.Now())
+
+	start := time.Now()
+	q.start(1)
+	q.stop()
+
+	expected := []string{"limited-1", "limited-2", "unlimited", "limited-3"}
+	if !reflect.DeepEqual(dispatched, expected) {
+		t.Errorf("Expected dispatch order %v, got %v", expected, dispatched)
+	}
+	if elapsed := time.Since(start); elapsed < 400*time.Millisecond {
+		t.Errorf("Expected the third firing to wait for the rate limit, all were dispatched within %s", elapsed)
+	}
+	if backlog := q.backlog(limited); backlog.Queued != 0 || backlog.Throttled != 1 {
+		t.Errorf("Expected an empty backlog with one throttled firing, got %+v", backlog)
+	}
+}
+
+func TestDispatchQueue_RecordsFiringsThatWaitForTheRateLimit(t *testing.T) {
+	projectID := primitive.NewObjectID()
+	var mu sync.Mutex
+	dispatched := make(map[string]string)
+	q := newDispatchQueue(func(ctx context.Context, task *models.Task, executionUUID string) {
+		mu.Lock()
+		dispatched[task.UUID] = executionUUID
+		mu.Unlock()
+	})
+	var held []string
+	q.setLimits(dispatchLimits{
+		rateLimit: func(ctx context.Context, projectID primitive.ObjectID) int {
+			return 1
+		},
+		hold: func(ctx context.Context, task *models.Task) string {
+			held = append(held, task.UUID)
+			return "queued-" + task.UUID
+		},
+	})
+	q.start(1)
+	waitForIdleWorkers(t, q, 1)
+
+	// The first firing spends the only token; the second waits for the next one and is recorded meanwhile
+	q.enqueue(&models.Task{UUID: "first", ProjectID: projectID}, time.Now())
+	q.enqueue(&models.Task{UUID: "second", ProjectID: projectID}, time.Now())
+	q.stop()
+
+	if !reflect.DeepEqual(held, []string{"second"}) {
+		t.Errorf("Expected only the second firing to be held, got %v", held)
+	}
+	expected := map[string]string{"first": "", "second": "queued-second"}
+	if !reflect.DeepEqual(dispatched, expected) {
+		t.Errorf("Expected dispatches %v, got %v", expected, dispatched)
+	}
+}
+
+func TestDispatchQueue_RecordsFiringsThatWaitForAWorker(t *testing.T) {
+	var mu sync.Mutex
+	dispatched := make(map[string]string)
+	q := newDispatchQueue(func(ctx context.Context, task *models.Task, executionUUID string) {
+		mu.Lock()
+		dispatched[task.UUID] = executionUUID
+		mu.Unlock()
+	})
+	var held []string
+	q.setLimits(dispatchLimits{hold: func(ctx context.Context, task *models.Task) string {
+		held = append(held, task.UUID)
+		return "queued-" + task.UUID
+	}})
+
+	// Without a rate limit firings still wait while every worker is busy
+	q.enqueue(&models.Task{UUID: "first"}, time.Now())
+	q.enqueue(&models.Task{UUID: "second"}, time.Now())
+	q.start(1)
+	q.stop()
+
+	if !reflect.DeepEqual(held, []string{"first", "second"}) {
+		t.Errorf("Expected both firings to be held, got %v", held)
+	}
+	expected := map[string]string{"first": "queued-first", "second": "queued-second"}
+	if !reflect.DeepEqual(dispatched, expected) {
+		t.Errorf("Expected dispatches %v, got %v", expected, dispatched)
+	}
+}
+
+func TestDispatchQueue_HoldsBackFiringsOverConcurrencyLimit(t *testing.T) {
+	var mu sync.Mutex
+	var dispatched []string
+	q := newDispatchQueue(func(ctx context.Context, task *models.Task, executionUUID string) {
+		mu.Lock()
+		dispatched = append(dispatched, task.UUID+"/"+executionUUID)
+		mu.Unlock()
+	})
+	q.recheck = 50 * time.Millisecond
+	checks := 0
+	var held []string
+	q.setLimits(dispatchLimits{
+		capacity: func(ctx context.Context, task *models.Task, reserved dispatchReservation) bool {
+			if task.UUID != "limited" {
+				return true
+			}
+			// The limited task has capacity again on the third check
+			mu.Lock()
+			defer mu.Unlock()
+			checks++
+			return checks > 2
+		},
+		hold: func(ctx context.Context, task *models.Task) string {
+			mu.Lock()
+			defer mu.Unlock()
+			held = append(held, task.UUID)
+			return "queued"
+		},
+	})
+	q.start(1)
+	waitForIdleWorkers(t, q, 1)
+
+	// The limited firing goes back to the queue and the lower priority firing goes ahead
+	q.enqueue(&models.Task{UUID: "limited", Priority: 10}, time.Now())
+	waitFor(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(held) == 1
+	})
+	waitForIdleWorkers(t, q, 1)
+	q.enqueue(&models.Task{UUID: "other"}, time.Now())
+	waitFor(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(dispatched) == 2
+	})
+	q.stop()
+
+	expected := []string{"other/", "limited/queued"}
+	if !reflect.DeepEqual(dispatched, expected) {
+		t.Errorf("Expected dispatches %v, got %v", expected, dispatched)
+	}
+	if !reflect.DeepEqual(held, []string{"limited"}) {
+		t.Errorf("Expected the limited firing to be held once, got %v", held)
+	}
+	if checks != 3 {
+		t.Errorf("Expected the limit to be checked 3 times, got %d", checks)
+	}
+}
+
+func TestDispatchQueue_ReservesConcurrencyOfFiringsBeingDispatched(t *testing.T) {
+	release := make(chan struct{})
+	var mu sync.Mutex
+	running, maxRunning, dispatched := 0, 0, 0
+	q := newDispatchQueue(func(ctx context.Context, task *models.Task, executionUUID string) {
+		mu.Lock()
+		running++
+		if running > maxRunning {
+			maxRunning = running
+		}
+		mu.Unlock()
+		<-release
+		mu.Lock()
+		running--
+		dispatched++
+		mu.Unlock()
+	})
+	q.recheck = 20 * time.Millisecond
+	groupID := primitive.NewObjectID()
+	q.setLimits(dispatchLimits{capacity: func(ctx context.Context, task *models.Task, reserved dispatchReservation) bool {
+		// No execution is recorded yet, so only the reservations count against the group's limit of one
+		return reserved.group < 1
+	}})
+
+	// Both workers pick up a firing of the group at once; the second waits for the first to be dispatched
+	q.enqueue(&models.Task{UUID: "first", TaskGroupID: &groupID}, time.Now())
+	q.enqueue(&models.Task{UUID: "second", TaskGroupID: &groupID}, time.Now())
+	q.start(2)
+	waitFor(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return running == 1
+	})
+	time.Sleep(100 * time.Millisecond)
+	close(release)
+	waitFor(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return dispatched == 2
+	})
+	q.stop()
+
+	if maxRunning != 1 {
+		t.Errorf("Expected one firing of the group to be dispatched at a time, got %d", maxRunning)
+	}
+}
+
+func TestDispatchQueue_KeepsRateTokenOfFiringsOverConcurrencyLimit(t *testing.T) {
+	projectID := primitive.NewObjectID()
+	var mu sync.Mutex
+	var dispatched []string
+	q := newDispatchQueue(func(ctx context.Context, task *models.Task, executionUUID string) {
+		mu.Lock()
+		dispatched = append(dispatched, task.UUID)
+		mu.Unlock()
+	})
+	q.recheck = time.Minute
+	q.setLimits(dispatchLimits{
+		rateLimit: func(ctx context.Context, projectID primitive.ObjectID) int {
+			return 1
+		},
+		capacity: func(ctx context.Context, task *models.Task, reserved dispatchReservation) bool {
+			return task.UUID != "limited"
+		},
+	})
+
+	// The limited firing goes first but is held back; the project's only token goes to the other firing
+	q.enqueue(&models.Task{UUID: "limited", ProjectID: projectID, Priority: 10}, time.Now())
+	q.enqueue(&models.Task{UUID: "other", ProjectID: projectID}, time.Now())
+	start := time.Now()
+	q.start(1)
+	waitFor(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(dispatched) == 1
+	})
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("Expected the other firing to use the token the limited one did not spend, it waited %s", elapsed)
+	}
+	q.stop()
+
+	if !reflect.DeepEqual(dispatched, []string{"other"}) {
+		t.Errorf("Expected only the other firing to be dispatched, got %v", dispatched)
+	}
+}
+
+func TestDispatchQueue_FailsQueuedExecutionsWhenStopped(t *testing.T) {
+	t.Run("firing queued while stopping", func(t *testing.T) {
+		q := newDispatchQueue(func(ctx context.Context, task *models.Task, executionUUID string) {
+			t.Errorf("Expected no dispatch, got %s", task.UUID)
+		})
+		var dropped []string
+		q.setLimits(dispatchLimits{
+			hold: func(ctx context.Context, task *models.Task) string {
+				// The scheduler stops while the firing is being recorded
+				q.mu.Lock()
+				q.closed = true
+				q.mu.Unlock()
+				return "queued-" + task.UUID
+			},
+			drop: func(ctx context.Context, executionUUID string) {
+				dropped = append(dropped, executionUUID)
+			},
+		})
+
+		if q.enqueue(&models.Task{UUID: "late"}, time.Now()) {
+			t.Error("Expected enqueue to fail once stopped")
+		}
+		if !reflect.DeepEqual(dropped, []string{"queued-late"}) {
+			t.Errorf("Expected the queued execution to be failed, got %v", dropped)
+		}
+	})
+
+	t.Run("firing held back by a concurrency limit", func(t *testing.T) {
+		q := newDispatchQueue(func(ctx context.Context, task *models.Task, executionUUID string) {
+			t.Errorf("Expected no dispatch, got %s", task.UUID)
+		})
+		var mu sync.Mutex
+		var dropped []string
+		q.setLimits(dispatchLimits{
+			capacity: func(ctx context.Context, task *models.Task, reserved dispatchReservation) bool {
+				return false
+			},
+			hold: func(ctx context.Context, task *models.Task) string {
+				return "queued-" + task.UUID
+			},
+			drop: func(ctx context.Context, executionUUID string) {
+				mu.Lock()
+				defer mu.Unlock()
+				dropped = append(dropped, executionUUID)
+			},
+		})
+
+		q.enqueue(&models.Task{UUID: "limited"}, time.Now())
+		q.start(1)
+		q.stop()
+
+		if !reflect.DeepEqual(dropped, []string{"queued-limited"}) {
+			t.Errorf("Expected the queued execution to be failed, got %v", dropped)
+		}
+	})
+}
+
+// waitForIdleWorkers waits until the queue's workers wait for firings
+func waitForIdleWorkers(t *testing.T, q *dispatchQueue, workers int) {
+	t.Helper()
+	waitFor(t, func() bool {
+		q.mu.Lock()
+		defer q.mu.Unlock()
+		return q.idle >= workers
+	})
+}
+
+// waitFor polls condition until it holds, failing the test after two seconds
+func waitFor(t *testing.T, condition func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for !condition() {
+		if time.Now().After(deadline) {
+			t.Fatal("Timed out waiting for condition")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
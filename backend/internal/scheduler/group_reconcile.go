@@ -0,0 +1,72 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"log"
+)
+
+// ReconcileGroupWindowJobs repairs the start/end window jobs when they drifted from the database, e.g. because a
+// group was created or deleted while the backend was restarting and the event was missed. Active groups with a
+// window get their jobs registered when one or both are missing; jobs of groups that are gone, disabled or lost
+// their window are removed. Used as a periodic reconciler (see reconciler.Runner).
+func (s *Scheduler) ReconcileGroupWindowJobs(ctx context.Context) error {
+	// Snapshot before querying, so jobs registered by events in the meantime are not taken for orphans
+	s.mu.RLock()
+	registered := make(map[string]int, len(s.groupJobs))
+	for groupUUID, jobs := range s.groupJobs {
+		registered[groupUUID] = len(jobs)
+	}
+	s.mu.RUnlock()
+
+	taskGroups, err := s.repo.GetActiveTaskGroupsWithWindows(ctx)
+	if err != nil {
+		return fmt.Errorf("load active task groups: %w", err)
+	}
+
+	active := make(map[string]bool, len(taskGroups))
+	registeredCount := 0
+	var lastErr error
+	for _, group := range taskGroups {
+		active[group.UUID] = true
+		if registered[group.UUID] == 2 {
+			continue
+		}
+
+		s.mu.RLock()
+		jobs := len(s.groupJobs[group.UUID])
+		s.mu.RUnlock()
+		if jobs == 2 {
+			// Registered by an event since the snapshot
+			continue
+		}
+
+		// Drop a lone start or end job before registering both
+		s.unregisterGroupWindowJobs(group.UUID)
+		if err := s.registerGroupWindowJobs(group); err != nil {
+			log.Printf("[GROUP] Failed to register missing window jobs for group %s: %v", group.UUID, err)
+			lastErr = err
+			continue
+		}
+		registeredCount++
+		log.Printf("[GROUP] Registered missing window jobs for group %s", group.UUID)
+	}
+
+	removedCount := 0
+	for groupUUID := range registered {
+		if active[groupUUID] {
+			continue
+		}
+		s.unregisterGroupWindowJobs(groupUUID)
+		removedCount++
+		log.Printf("[GROUP] Removed orphaned window jobs for group %s", groupUUID)
+	}
+
+	if registeredCount > 0 || removedCount > 0 {
+		log.Printf("[GROUP] Window job reconciliation registered %d group(s) and removed %d orphaned group(s)", registeredCount, removedCount)
+	}
+	if lastErr != nil {
+		return fmt.Errorf("register window jobs: %w", lastErr)
+	}
+	return nil
+}
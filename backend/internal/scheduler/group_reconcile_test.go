@@ -0,0 +1,56 @@
+package scheduler
+
+import (
+	"context"
+	"testing"
+
+	"github.com/yourusername/cron-observer/backend/internal/models"
+	"github.com/yourusername/cron-observer/backend/mocks"
+	"go.uber.org/mock/gomock"
+)
+
+func windowGroup(uuid string) *models.TaskGroup {
+	return &models.TaskGroup{UUID: uuid, Status: models.TaskGroupStatusActive, StartTime: "09:00", EndTime: "17:00", Timezone: "UTC"}
+}
+
+func TestScheduler_ReconcileGroupWindowJobs_RepairsBothDirections(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockRepository(ctrl)
+	s := New(nil, repo)
+
+	// "kept" is registered and active, "orphan" was deleted while the backend was down, "partial" lost its end
+	// job and "missing" was created while the backend was down
+	for _, uuid := range []string{"kept", "orphan", "partial"} {
+		if err := s.registerGroupWindowJobs(windowGroup(uuid)); err != nil {
+			t.Fatalf("registerGroupWindowJobs(%s): %v", uuid, err)
+		}
+	}
+	keptStart := s.groupJobs["kept"]["start"]
+	s.cron.Remove(s.groupJobs["partial"]["end"])
+	delete(s.groupJobs["partial"], "end")
+
+	repo.EXPECT().GetActiveTaskGroupsWithWindows(gomock.Any()).Return([]*models.TaskGroup{
+		windowGroup("kept"), windowGroup("partial"), windowGroup("missing"),
+	}, nil)
+
+	if err := s.ReconcileGroupWindowJobs(context.Background()); err != nil {
+		t.Fatalf("ReconcileGroupWindowJobs: %v", err)
+	}
+
+	if _, ok := s.groupJobs["orphan"]; ok {
+		t.Error("window jobs of the deleted group were not removed")
+	}
+	for _, uuid := range []string{"kept", "partial", "missing"} {
+		if len(s.groupJobs[uuid]) != 2 {
+			t.Errorf("group %s has %d window jobs, want 2", uuid, len(s.groupJobs[uuid]))
+		}
+	}
+	if s.groupJobs["kept"]["start"] != keptStart {
+		t.Error("window jobs of an intact group were re-registered")
+	}
+	if entries := len(s.cron.Entries()); entries != 6 {
+		t.Errorf("cron has %d entries, want 6 (no stray jobs)", entries)
+	}
+}
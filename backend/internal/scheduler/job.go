@@ -4,15 +4,22 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"log"
 	"net/http"
+	"sync"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/robfig/cron/v3"
 	"github.com/yourusername/cron-observer/backend/internal/events"
+	"github.com/yourusername/cron-observer/backend/internal/metering"
 	"github.com/yourusername/cron-observer/backend/internal/models"
+	"github.com/yourusername/cron-observer/backend/internal/quota"
 	"github.com/yourusername/cron-observer/backend/internal/repositories"
+	"github.com/yourusername/cron-observer/backend/internal/secrets"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
@@ -21,147 +28,557 @@ type TaskJob struct {
 	Task     *models.Task
 	Repo     repositories.Repository
 	EventBus *events.EventBus
+
+	queue    *dispatchQueue // optional; without a queue the job dispatches immediately
+	schedule cron.Schedule  // optional; when set the task's next_run_at is kept up to date
+}
+
+// recordNextRun persists the task's next fire time after the given time
+func (j *TaskJob) recordNextRun(ctx context.Context, after time.Time) {
+	if j.schedule == nil {
+		return
+	}
+	nextRun := j.schedule.Next(after)
+	if err := j.Repo.SetTaskNextRunAt(ctx, j.Task.UUID, &nextRun); err != nil {
+		log.Printf("[CRON] Failed to record next run for task %s: %v", j.Task.UUID, err)
+	}
+}
+
+// ErrProjectArchived is returned by ExecuteTask when the task's project is archived or pending deletion
+var ErrProjectArchived = errors.New("project is archived")
+
+// ErrEnvironmentNotFound is returned by ExecuteTask when the task selects an environment the project does not define
+var ErrEnvironmentNotFound = errors.New("environment not found in project")
+
+// ErrCircuitOpen is returned when the execution was failed without being sent because its endpoint kept failing
+var ErrCircuitOpen = errors.New("execution endpoint circuit is open")
+
+// secretResolver resolves {{secret:NAME}} references in execution headers and bodies at dispatch time
+var secretResolver *secrets.Resolver
+
+// SetSecretResolver configures the resolver used when dispatching executions.
+// Without a resolver, executions whose headers or body reference secrets fail to dispatch.
+func SetSecretResolver(resolver *secrets.Resolver) {
+	secretResolver = resolver
+}
+
+// quotaService limits the executions projects start per day; nil allows any number
+var quotaService *quota.Service
+
+// SetQuotaService configures the quotas checked before an execution is created
+func SetQuotaService(service *quota.Service) {
+	quotaService = service
+}
+
+// meter counts the executions started for usage metering; nil records nothing
+var meter *metering.Meter
+
+// SetMeter configures the meter counting started executions
+func SetMeter(m *metering.Meter) {
+	meter = m
+}
+
+// inFlightSends tracks execution requests sent in the background by ExecuteTask, so shutdown can wait for them
+var inFlightSends sync.WaitGroup
+
+// WaitForDispatches waits for execution requests still being sent in the background, until ctx is done
+func WaitForDispatches(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		inFlightSends.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
 }
 
+const (
+	// defaultDispatchTimeout bounds an execution request of tasks without an HTTP trigger timeout
+	defaultDispatchTimeout = 30 * time.Second
+	minDispatchTimeout     = time.Second
+	maxDispatchTimeout     = 300 * time.Second
+)
+
+// dispatchTimeout returns how long the execution endpoint may take to answer an execution request of the task: the
+// timeout of its HTTP trigger config, or defaultDispatchTimeout. The request never outlasts the execution timeout
+// timeoutSeconds, as the execution is failed then anyway, and is kept within minDispatchTimeout and
+// maxDispatchTimeout.
+func dispatchTimeout(task *models.Task, timeoutSeconds int) time.Duration {
+	timeout := defaultDispatchTimeout
+	if task.TriggerConfig.HTTP != nil && task.TriggerConfig.HTTP.Timeout > 0 {
+		timeout = time.Duration(task.TriggerConfig.HTTP.Timeout) * time.Second
+	}
+	if executionTimeout := time.Duration(timeoutSeconds) * time.Second; timeoutSeconds > 0 && executionTimeout < timeout {
+		timeout = executionTimeout
+	}
+	if timeout < minDispatchTimeout {
+		return minDispatchTimeout
+	}
+	if timeout > maxDispatchTimeout {
+		return maxDispatchTimeout
+	}
+	return timeout
+}
+
+// buildDispatchPayload returns the headers and extra body fields for an execution request with {{env:NAME}}
+// references expanded from the task's variables and secrets resolved.
+// Project execution headers apply to every task; a task's trigger config headers override them.
+func buildDispatchPayload(ctx context.Context, project *models.Project, task *models.Task) (map[string]string, map[string]interface{}, error) {
+	headers := make(map[string]string)
+	for name, value := range project.ExecutionHeaders {
+		headers[name] = value
+	}
+
+	var body map[string]interface{}
+	if task.TriggerConfig.HTTP != nil {
+		for name, value := range task.TriggerConfig.HTTP.Headers {
+			headers[name] = value
+		}
+		switch b := task.TriggerConfig.HTTP.Body.(type) {
+		case map[string]interface{}:
+			body = b
+		case primitive.M:
+			body = b
+		}
+	}
+
+	for name, value := range headers {
+		expanded, err := expandTaskEnvString(value, task.Env)
+		if err != nil {
+			return nil, nil, err
+		}
+		headers[name] = expanded
+	}
+
+	resolvedHeaders, err := secretResolver.ResolveHeaders(ctx, project.ID, headers)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var resolvedBody map[string]interface{}
+	if body != nil {
+		expanded, err := expandTaskEnv(body, task.Env)
+		if err != nil {
+			return nil, nil, err
+		}
+		resolved, err := secretResolver.ResolveValue(ctx, project.ID, expanded)
+		if err != nil {
+			return nil, nil, err
+		}
+		resolvedBody, _ = resolved.(map[string]interface{})
+	}
+
+	// Include the task's variables, with secret references resolved, so the runner can pick its configuration set
+	if len(task.Env) > 0 {
+		resolvedEnv, err := secretResolver.ResolveHeaders(ctx, project.ID, task.Env)
+		if err != nil {
+			return nil, nil, err
+		}
+		if resolvedBody == nil {
+			resolvedBody = make(map[string]interface{})
+		}
+		resolvedBody["env"] = resolvedEnv
+	}
+
+	return resolvedHeaders, resolvedBody, nil
+}
+
+// DispatchResult is the execution endpoint's answer to a dispatch
+type DispatchResult struct {
+	Endpoint   string
+	StatusCode int
+	Body       string // The first dispatchResultBodyLimit bytes of the response
+	Duration   time.Duration
+}
+
+// dispatchResultBodyLimit bounds how much of the endpoint's response ExecuteTaskAndWait returns
+const dispatchResultBodyLimit = 4096
+
 // ExecuteTask creates an execution record and sends it to the execution endpoint.
 // Returns the execution UUID and any error encountered during execution creation.
 // The actual HTTP request to the execution endpoint is sent asynchronously.
 func ExecuteTask(ctx context.Context, task *models.Task, repo repositories.Repository, eventBus *events.EventBus, logPrefix string) (string, error) {
+	executionUUID, _, err := executeTask(ctx, task, repo, eventBus, logPrefix, false, "")
+	return executionUUID, err
+}
+
+// queueExecution records a firing a dispatch limit holds back as a QUEUED execution, so deferred runs are visible
+// before they are sent. Returns the UUID executeQueuedTask dispatches the execution with.
+func queueExecution(ctx context.Context, task *models.Task, repo repositories.Repository) (string, error) {
+	now := time.Now()
+	execution := &models.Execution{
+		ID:        primitive.NewObjectID(),
+		UUID:      uuid.New().String(),
+		TaskID:    task.ID,
+		TaskUUID:  task.UUID,
+		Status:    models.ExecutionStatusQueued,
+		StartedAt: now, // Replaced by the dispatch time
+		QueuedAt:  &now,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := repo.CreateExecution(ctx, execution); err != nil {
+		return "", err
+	}
+	return execution.UUID, nil
+}
+
+// executeQueuedTask is ExecuteTask for a firing queueExecution recorded: the QUEUED execution becomes PENDING and is
+// sent, or is failed with the reason when the firing cannot be dispatched
+func executeQueuedTask(ctx context.Context, task *models.Task, repo repositories.Repository, eventBus *events.EventBus, logPrefix, executionUUID string) (string, error) {
+	executionUUID, _, err := executeTask(ctx, task, repo, eventBus, logPrefix, false, executionUUID)
+	return executionUUID, err
+}
+
+// ExecuteTaskAndWait is ExecuteTask, but sends the HTTP request before returning and returns the endpoint's answer.
+// When the request fails after the execution was created, the execution UUID is returned with the error.
+func ExecuteTaskAndWait(ctx context.Context, task *models.Task, repo repositories.Repository, eventBus *events.EventBus, logPrefix string) (string, *DispatchResult, error) {
+	return executeTask(ctx, task, repo, eventBus, logPrefix, true, "")
+}
+
+func executeTask(ctx context.Context, task *models.Task, repo repositories.Repository, eventBus *events.EventBus, logPrefix string, wait bool, queuedExecutionUUID string) (string, *DispatchResult, error) {
+	// A queued execution that cannot be dispatched is failed with the reason instead of staying QUEUED
+	notDispatched := func(err error) error {
+		if queuedExecutionUUID == "" {
+			return err
+		}
+		if _, failErr := repo.FailExecutionIfUnfinished(ctx, queuedExecutionUUID, "Not dispatched: "+err.Error()); failErr != nil {
+			log.Printf("[%s] Failed to fail queued execution %s: %v", logPrefix, queuedExecutionUUID, failErr)
+		}
+		return err
+	}
+
 	// Get the project to retrieve execution_endpoint
 	project, err := repo.GetProjectByID(ctx, task.ProjectID)
 	if err != nil {
 		log.Printf("[%s] Failed to get project for task %s: %v", logPrefix, task.UUID, err)
-		return "", err
+		return "", nil, notDispatched(err)
+	}
+
+	// Archived projects keep their history but accept no new executions; projects being deleted neither
+	if project.Status == models.ProjectStatusArchived || project.Status == models.ProjectStatusPendingDelete {
+		log.Printf("[%s] Project %s is %s, skipping execution of task %s", logPrefix, project.UUID, project.Status, task.UUID)
+		return "", nil, notDispatched(ErrProjectArchived)
+	}
+
+	// Over-quota firings are skipped; the trigger endpoint reports the *quota.ExceededError
+	if err := quotaService.CheckExecution(ctx, project); err != nil {
+		log.Printf("[%s] Skipping execution of task %s: %v", logPrefix, task.UUID, err)
+		return "", nil, notDispatched(err)
+	}
+
+	// Tasks dispatch to their environment's endpoint, or to the project's default endpoint and its failover endpoint
+	executionEndpoint, failoverEndpoint := project.ExecutionEndpoint, project.FailoverEndpoint
+	if task.Environment != "" {
+		environment, ok := project.FindEnvironment(task.Environment)
+		if !ok {
+			log.Printf("[%s] Environment %s not found in project %s for task %s, skipping execution", logPrefix, task.Environment, project.UUID, task.UUID)
+			return "", nil, notDispatched(ErrEnvironmentNotFound)
+		}
+		executionEndpoint, failoverEndpoint = environment.ExecutionEndpoint, ""
+	}
+	if failoverEndpoint == executionEndpoint {
+		failoverEndpoint = ""
 	}
 
 	// Check if execution_endpoint is set
-	if project.ExecutionEndpoint == "" {
+	if executionEndpoint == "" {
 		log.Printf("[%s] No execution_endpoint set for project %s, skipping execution", logPrefix, project.UUID)
-		return "", fmt.Errorf("no execution_endpoint set for project")
+		return "", nil, notDispatched(fmt.Errorf("no execution_endpoint set for project"))
 	}
 
-	// Create execution record
-	executionUUID := uuid.New().String()
-	executionID := primitive.NewObjectID()
+	// Resolve secrets before creating the execution so a missing secret doesn't leave a dangling record.
+	// Resolved values only live in memory for the duration of the request.
+	dispatchHeaders, dispatchBody, err := buildDispatchPayload(ctx, project, task)
+	if err != nil {
+		log.Printf("[%s] Failed to build dispatch payload for task %s: %v", logPrefix, task.UUID, err)
+		return "", nil, notDispatched(fmt.Errorf("failed to build dispatch payload: %w", err))
+	}
+
+	// Endpoints that kept failing are not sent executions until their cooldown ended; the failover endpoint is
+	// used instead when its circuit is closed
 	now := time.Now()
+	circuitOpen := false
+	if !endpointCircuits.allow(executionEndpoint, now) {
+		if failoverEndpoint != "" && endpointCircuits.allow(failoverEndpoint, now) {
+			log.Printf("[%s] Circuit of execution endpoint of project %s is open, sending task %s to the failover endpoint", logPrefix, project.UUID, task.UUID)
+			executionEndpoint, failoverEndpoint = failoverEndpoint, ""
+		} else {
+			circuitOpen = true
+		}
+	}
 
-	execution := &models.Execution{
-		ID:        executionID,
-		UUID:      executionUUID,
-		TaskID:    task.ID,
-		TaskUUID:  task.UUID,
-		Status:    models.ExecutionStatusPending,
-		StartedAt: now,
-		CreatedAt: now,
-		UpdatedAt: now,
+	executionUUID := queuedExecutionUUID
+	if executionUUID != "" {
+		// The execution was recorded when the firing was queued
+		started, err := repo.StartQueuedExecution(ctx, executionUUID, now)
+		if err != nil {
+			log.Printf("[%s] Failed to start queued execution %s of task %s: %v", logPrefix, executionUUID, task.UUID, err)
+			return "", nil, err
+		}
+		if !started {
+			log.Printf("[%s] Queued execution %s of task %s was finished while it waited, not dispatching it", logPrefix, executionUUID, task.UUID)
+			return executionUUID, nil, nil
+		}
+		if err := repo.RecordExecutionEndpoint(ctx, executionUUID, executionEndpoint); err != nil {
+			log.Printf("[%s] Failed to record endpoint of execution %s: %v", logPrefix, executionUUID, err)
+		}
+	} else {
+		// Create execution record
+		executionUUID = uuid.New().String()
+		execution := &models.Execution{
+			ID:        primitive.NewObjectID(),
+			UUID:      executionUUID,
+			TaskID:    task.ID,
+			TaskUUID:  task.UUID,
+			Status:    models.ExecutionStatusPending,
+			StartedAt: now,
+			CreatedAt: now,
+			UpdatedAt: now,
+			Endpoint:  executionEndpoint,
+		}
+
+		// Save execution record
+		if err := repo.CreateExecution(ctx, execution); err != nil {
+			log.Printf("[%s] Failed to create execution record for task %s: %v", logPrefix, task.UUID, err)
+			return "", nil, err
+		}
 	}
 
-	// Save execution record
-	if err := repo.CreateExecution(ctx, execution); err != nil {
-		log.Printf("[%s] Failed to create execution record for task %s: %v", logPrefix, task.UUID, err)
-		return "", err
+	// The execution is recorded and failed at once, so the skipped firing shows in the history
+	if circuitOpen {
+		log.Printf("[%s] Circuit of execution endpoint of project %s is open, failing execution %s of task %s", logPrefix, project.UUID, executionUUID, task.UUID)
+		if _, err := repo.FailExecutionIfUnfinished(ctx, executionUUID, models.ExecutionErrorCircuitOpen); err != nil {
+			log.Printf("[%s] Failed to fail execution %s: %v", logPrefix, executionUUID, err)
+		}
+		return executionUUID, nil, ErrCircuitOpen
 	}
+	meter.RecordExecution(project.ID)
+
+	// Tasks without their own timeout use the project's default timeout
+	settings, err := repo.GetProjectSettings(ctx, project.ID)
+	if err != nil {
+		log.Printf("[%s] Failed to get settings for project %s, using task values only: %v", logPrefix, project.UUID, err)
+	}
+	timeoutSeconds := settings.EffectiveTimeoutSeconds(task.TimeoutSeconds)
+	requestTimeout := dispatchTimeout(task, timeoutSeconds)
 
 	// Create cancellable context for HTTP request (for timeout cancellation)
 	requestCtx, cancelRequest := context.WithCancel(context.Background())
 
 	// If timeout is configured, start timeout goroutine
-	if task.TimeoutSeconds != nil && *task.TimeoutSeconds > 0 {
+	if timeoutSeconds > 0 {
 		go func() {
-			time.Sleep(time.Duration(*task.TimeoutSeconds) * time.Second)
+			time.Sleep(time.Duration(timeoutSeconds) * time.Second)
 
-			// Check current execution status to avoid race condition
-			// If execution already completed (SUCCESS or FAILED), don't cancel or emit timeout
-			currentExecution, err := repo.GetExecutionByUUID(context.Background(), executionUUID)
-			if err != nil {
-				log.Printf("[%s] Failed to get execution for timeout check: %v", logPrefix, err)
-				return
-			}
-
-			// Only cancel and emit timeout if execution is still pending or running
-			if currentExecution.Status == models.ExecutionStatusPending ||
-				currentExecution.Status == models.ExecutionStatusRunning {
+			if failTimedOutExecution(context.Background(), repo, eventBus, task, executionUUID, timeoutSeconds, logPrefix) {
 				// Cancel the HTTP request
 				cancelRequest()
-
-				// Emit ExecutionTimedOut event
-				if eventBus != nil {
-					eventBus.Publish(events.Event{
-						Type: events.ExecutionTimedOut,
-						Payload: events.ExecutionTimedOutPayload{
-							ExecutionUUID:  executionUUID,
-							TaskUUID:       task.UUID,
-							TimeoutSeconds: *task.TimeoutSeconds,
-						},
-					})
-					log.Printf("[%s] Execution timed out after %d seconds for task %s (execution: %s)", logPrefix, *task.TimeoutSeconds, task.UUID, executionUUID)
-				}
-			} else {
-				// Execution already completed, no need to cancel or emit timeout
-				log.Printf("[%s] Execution %s already completed with status %s before timeout, skipping timeout handling", logPrefix, executionUUID, currentExecution.Status)
 			}
 		}()
 	}
 
-	// Send execution to the execution endpoint asynchronously (don't wait for response)
-	go func() {
-		defer cancelRequest() // Ensure cleanup when goroutine exits
-		// Prepare request body with task name and execution ID
-		requestBody := map[string]interface{}{
-			"task_name":    task.Name,
-			"execution_id": executionUUID,
-		}
+	// Prepare request body with task name and execution ID
+	requestBody := map[string]interface{}{}
+	for key, value := range dispatchBody {
+		requestBody[key] = value
+	}
+	requestBody["task_name"] = task.Name
+	requestBody["execution_id"] = executionUUID
+	requestBody["task_uuid"] = task.UUID // Lets SDKs report with X-Task-UUID for lenient check-ins
 
-		jsonBody, err := json.Marshal(requestBody)
-		if err != nil {
-			log.Printf("[%s] Failed to marshal request body for task %s: %v", logPrefix, task.UUID, err)
-			return
-		}
+	jsonBody, err := json.Marshal(requestBody)
+	if err != nil {
+		cancelRequest()
+		log.Printf("[%s] Failed to marshal request body for task %s: %v", logPrefix, task.UUID, err)
+		return executionUUID, nil, err
+	}
 
-		// Send POST request to execution_endpoint with cancellable context
-		req, err := http.NewRequestWithContext(requestCtx, "POST", project.ExecutionEndpoint, bytes.NewBuffer(jsonBody))
+	// Send execution to an execution endpoint
+	postExecution := func(endpoint string) (*DispatchResult, error) {
+		// Send POST request to the endpoint with cancellable context
+		req, err := http.NewRequestWithContext(requestCtx, "POST", endpoint, bytes.NewBuffer(jsonBody))
 		if err != nil {
 			log.Printf("[%s] Failed to create HTTP request for task %s: %v", logPrefix, task.UUID, err)
-			return
+			return nil, err
 		}
 
+		for name, value := range dispatchHeaders {
+			req.Header.Set(name, value)
+		}
 		req.Header.Set("Content-Type", "application/json")
 
 		client := &http.Client{
-			Timeout: 30 * time.Second,
+			Timeout: requestTimeout,
 		}
 
+		sentAt := time.Now()
 		resp, err := client.Do(req)
 		if err != nil {
 			// Check if error is due to context cancellation (timeout)
-			if err == context.Canceled {
+			if errors.Is(err, context.Canceled) {
 				log.Printf("[%s] HTTP request canceled due to timeout for task %s (execution: %s)", logPrefix, task.UUID, executionUUID)
-				return
+				return nil, err
 			}
 			log.Printf("[%s] Failed to send POST request for task %s: %v", logPrefix, task.UUID, err)
-			return
+			return nil, err
 		}
 		defer resp.Body.Close()
 
+		result := &DispatchResult{Endpoint: endpoint, StatusCode: resp.StatusCode}
+		if wait {
+			responseBody, _ := io.ReadAll(io.LimitReader(resp.Body, dispatchResultBodyLimit))
+			result.Body = string(responseBody)
+		}
+		result.Duration = time.Since(sentAt)
+
 		if resp.StatusCode >= 200 && resp.StatusCode < 300 {
 			log.Printf("[%s] Successfully executed task %s (execution: %s)", logPrefix, task.UUID, executionUUID)
 		} else {
 			log.Printf("[%s] Execution endpoint returned non-2xx status for task %s: %d", logPrefix, task.UUID, resp.StatusCode)
 		}
-	}()
+		return result, nil
+	}
+
+	// Feed the outcome of every request to the endpoint's circuit
+	post := func(endpoint string) (*DispatchResult, error) {
+		result, err := postExecution(endpoint)
+		failed := err != nil || result.StatusCode >= http.StatusInternalServerError
+		if endpointCircuits.record(endpoint, failed, time.Now()) {
+			var lastError string
+			if err != nil {
+				lastError = err.Error()
+			} else {
+				lastError = fmt.Sprintf("status %d", result.StatusCode)
+			}
+			log.Printf("[%s] Execution endpoint of project %s failed %d times in a row, opening its circuit for %s", logPrefix, project.UUID, circuitFailureThreshold, circuitCooldown)
+			if eventBus != nil {
+				events.Publish(eventBus, events.CircuitOpenedTopic, events.CircuitOpenedPayload{
+					Project:   project,
+					Endpoint:  endpoint,
+					Failures:  circuitFailureThreshold,
+					LastError: lastError,
+					OpenUntil: time.Now().Add(circuitCooldown),
+				})
+			}
+		}
+		return result, err
+	}
+
+	// Send to the primary endpoint, and to the failover endpoint when the primary is unreachable or fails
+	send := func() (*DispatchResult, error) {
+		defer cancelRequest() // Ensure cleanup when the request completes
 
-	return executionUUID, nil
+		result, err := post(executionEndpoint)
+		if failoverEndpoint == "" || !shouldFailOver(result, err) || !endpointCircuits.allow(failoverEndpoint, time.Now()) {
+			return result, err
+		}
+		log.Printf("[%s] Execution endpoint failed for task %s (execution: %s), sending to failover endpoint", logPrefix, task.UUID, executionUUID)
+		if err := repo.RecordExecutionEndpoint(context.Background(), executionUUID, failoverEndpoint); err != nil {
+			log.Printf("[%s] Failed to record failover endpoint of execution %s: %v", logPrefix, executionUUID, err)
+		}
+		return post(failoverEndpoint)
+	}
+
+	if !wait {
+		// Don't wait for the response
+		inFlightSends.Add(1)
+		go func() {
+			defer inFlightSends.Done()
+			send()
+		}()
+		return executionUUID, nil, nil
+	}
+	result, err := send()
+	return executionUUID, result, err
+}
+
+// shouldFailOver reports whether a dispatch failed in a way another endpoint may not: the endpoint could not be
+// reached or answered with a server error. Requests cancelled by the execution timeout are not sent again.
+func shouldFailOver(result *DispatchResult, err error) bool {
+	if err != nil {
+		return !errors.Is(err, context.Canceled)
+	}
+	return result.StatusCode >= http.StatusInternalServerError
+}
+
+// failTimedOutExecution fails an execution that is still pending or running once its timeout elapsed.
+// The status change is a single conditional update, so a result reported by the client in the meantime wins.
+// Reports whether the execution was failed; the failure is then fed to stats and alerts through ExecutionFailed.
+func failTimedOutExecution(ctx context.Context, repo repositories.Repository, eventBus *events.EventBus, task *models.Task, executionUUID string, timeoutSeconds int, logPrefix string) bool {
+	failed, err := repo.FailExecutionIfUnfinished(ctx, executionUUID, models.ExecutionErrorTimeout)
+	if err != nil {
+		log.Printf("[%s] Failed to mark execution %s as timed out: %v", logPrefix, executionUUID, err)
+		return false
+	}
+	if !failed {
+		// Execution already completed, no need to cancel or emit timeout
+		log.Printf("[%s] Execution %s already completed before timeout, skipping timeout handling", logPrefix, executionUUID)
+		return false
+	}
+	log.Printf("[%s] Execution timed out after %d seconds for task %s (execution: %s)", logPrefix, timeoutSeconds, task.UUID, executionUUID)
+
+	logEntry := models.LogEntry{
+		Message:   fmt.Sprintf("Task timed out after %d seconds", timeoutSeconds),
+		Level:     "error",
+		Timestamp: time.Now(),
+	}
+	if err := repo.AppendLogToExecution(ctx, executionUUID, logEntry); err != nil {
+		log.Printf("[%s] Failed to add timeout log to execution %s: %v", logPrefix, executionUUID, err)
+	}
+
+	if eventBus == nil {
+		return true
+	}
+
+	events.Publish(eventBus, events.ExecutionTimedOutTopic, events.ExecutionTimedOutPayload{
+		ExecutionUUID:  executionUUID,
+		TaskUUID:       task.UUID,
+		TimeoutSeconds: timeoutSeconds,
+	})
+
+	// Alerts read the task's mute state, so send the current task rather than the one captured at dispatch
+	currentTask, err := repo.GetTaskByUUID(ctx, task.UUID)
+	if err != nil {
+		log.Printf("[%s] Failed to reload task %s after timeout, using dispatched copy: %v", logPrefix, task.UUID, err)
+		currentTask = task
+	}
+	execution, err := repo.GetExecutionByUUID(ctx, executionUUID)
+	if err != nil {
+		log.Printf("[%s] Failed to get timed out execution %s: %v", logPrefix, executionUUID, err)
+		return true
+	}
+	events.Publish(eventBus, events.ExecutionFailedTopic, events.ExecutionFailedPayload{
+		Execution: execution,
+		Task:      currentTask,
+	})
+	return true
 }
 
 // Run executes the task job
 func (j *TaskJob) Run() {
 	ctx := context.Background()
+	firedAt := time.Now()
 	// ANSI color codes for task name decoration
 	// \033[46m = cyan background, \033[1;30m = bold black text, \033[0m = reset
 	const colorReset = "\033[0m"
 	const colorTaskName = "\033[46;1;30m" // Cyan background with bold black text
 	log.Printf("[CRON] Task triggered: %s%s%s (UUID: %s)", colorTaskName, j.Task.Name, colorReset, j.Task.UUID)
 
+	j.recordNextRun(ctx, firedAt)
+
+	// Queued firings are dispatched by the scheduler's workers in priority order
+	if j.queue != nil {
+		if !j.queue.enqueue(j.Task, firedAt) {
+			log.Printf("[CRON] Scheduler is stopping, dropping firing of task %s", j.Task.UUID)
+		}
+		return
+	}
+
 	_, err := ExecuteTask(ctx, j.Task, j.Repo, j.EventBus, "CRON")
 	if err != nil {
 		// Error already logged in ExecuteTask
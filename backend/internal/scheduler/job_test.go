@@ -0,0 +1,250 @@
+package scheduler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/yourusername/cron-observer/backend/internal/events"
+	"github.com/yourusername/cron-observer/backend/internal/models"
+	"github.com/yourusername/cron-observer/backend/internal/repositories"
+	"github.com/yourusername/cron-observer/backend/mocks"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.uber.org/mock/gomock"
+)
+
+func TestFailTimedOutExecution_FailsAndPublishes(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	task := &models.Task{UUID: "task-uuid", ProjectID: primitive.NewObjectID()}
+	endedAt := time.Now()
+	failedExecution := &models.Execution{UUID: "exec-uuid", TaskUUID: "task-uuid", Status: models.ExecutionStatusFailed, Error: models.ExecutionErrorTimeout, EndedAt: &endedAt}
+
+	repo := mocks.NewMockRepository(ctrl)
+	eventBus := events.NewEventBus(10)
+	defer eventBus.Close()
+	failedCh := eventBus.Subscribe(events.ExecutionFailed)
+
+	repo.EXPECT().FailExecutionIfUnfinished(gomock.Any(), "exec-uuid", models.ExecutionErrorTimeout).Return(true, nil)
+	repo.EXPECT().AppendLogToExecution(gomock.Any(), "exec-uuid", gomock.Any()).Return(nil)
+	repo.EXPECT().GetTaskByUUID(gomock.Any(), "task-uuid").Return(task, nil)
+	repo.EXPECT().GetExecutionByUUID(gomock.Any(), "exec-uuid").Return(failedExecution, nil)
+
+	if !failTimedOutExecution(context.Background(), repo, eventBus, task, "exec-uuid", 30, "TEST") {
+		t.Fatal("Expected execution to be failed")
+	}
+
+	select {
+	case event := <-failedCh:
+		payload := event.Payload.(events.ExecutionFailedPayload)
+		if payload.Execution.UUID != "exec-uuid" || payload.Task.UUID != "task-uuid" {
+			t.Errorf("Unexpected ExecutionFailed payload: %+v", payload)
+		}
+	case <-time.After(time.Second):
+		t.Error("Expected ExecutionFailed event to be published")
+	}
+}
+
+func TestFailTimedOutExecution_SkipsCompletedExecution(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	task := &models.Task{UUID: "task-uuid"}
+
+	repo := mocks.NewMockRepository(ctrl)
+	eventBus := events.NewEventBus(10)
+	defer eventBus.Close()
+	failedCh := eventBus.Subscribe(events.ExecutionFailed)
+
+	repo.EXPECT().FailExecutionIfUnfinished(gomock.Any(), "exec-uuid", models.ExecutionErrorTimeout).Return(false, nil)
+
+	if failTimedOutExecution(context.Background(), repo, eventBus, task, "exec-uuid", 30, "TEST") {
+		t.Fatal("Expected completed execution to be left alone")
+	}
+
+	select {
+	case event := <-failedCh:
+		t.Errorf("Expected no ExecutionFailed event, got %+v", event)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestExecuteTaskAndWait_ReturnsEndpointResponse(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	var received map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&received)
+		w.WriteHeader(http.StatusBadGateway)
+		w.Write([]byte(`{"error":"runner unavailable"}`))
+	}))
+	defer server.Close()
+
+	project := &models.Project{ID: primitive.NewObjectID(), ExecutionEndpoint: server.URL}
+	task := &models.Task{UUID: "task-uuid", Name: "Backup", ProjectID: project.ID}
+
+	repo := mocks.NewMockRepository(ctrl)
+	repo.EXPECT().GetProjectByID(gomock.Any(), project.ID).Return(project, nil)
+	repo.EXPECT().CreateExecution(gomock.Any(), gomock.Any()).Return(nil)
+	repo.EXPECT().GetProjectSettings(gomock.Any(), project.ID).Return(nil, nil)
+
+	executionUUID, result, err := ExecuteTaskAndWait(context.Background(), task, repo, nil, "TEST")
+	if err != nil {
+		t.Fatalf("ExecuteTaskAndWait: %v", err)
+	}
+	if result.StatusCode != http.StatusBadGateway || result.Body != `{"error":"runner unavailable"}` || result.Endpoint != server.URL {
+		t.Fatalf("unexpected dispatch result %+v", result)
+	}
+	if received["execution_id"] != executionUUID || received["task_name"] != "Backup" {
+		t.Fatalf("unexpected request body %v", received)
+	}
+}
+
+func TestExecuteQueuedTask_FailsQueuedExecutionThatCannotBeDispatched(t *testing.T) {
+	ctx := context.Background()
+	repo := repositories.NewMemoryRepository()
+	project := &models.Project{ID: primitive.NewObjectID(), Name: "billing"}
+	if err := repo.CreateProject(ctx, project); err != nil {
+		t.Fatalf("CreateProject: %v", err)
+	}
+	task := &models.Task{UUID: "task-uuid", Name: "Backup", ProjectID: project.ID}
+
+	executionUUID, err := queueExecution(ctx, task, repo)
+	if err != nil {
+		t.Fatalf("queueExecution: %v", err)
+	}
+	queued, err := repo.GetExecutionByUUID(ctx, executionUUID)
+	if err != nil || queued.Status != models.ExecutionStatusQueued || queued.QueuedAt == nil {
+		t.Fatalf("queued execution = %+v, %v", queued, err)
+	}
+
+	// The project has no execution endpoint, so the firing is never sent
+	if _, err := executeQueuedTask(ctx, task, repo, nil, "TEST", executionUUID); err == nil {
+		t.Fatal("Expected dispatch without an execution endpoint to fail")
+	}
+	failed, err := repo.GetExecutionByUUID(ctx, executionUUID)
+	if err != nil || failed.Status != models.ExecutionStatusFailed || failed.Error != "Not dispatched: no execution_endpoint set for project" {
+		t.Fatalf("execution after failed dispatch = %+v, %v", failed, err)
+	}
+}
+
+func TestExecuteTaskAndWait_FailsOverWhenPrimaryEndpointFails(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	primary := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer primary.Close()
+	var received map[string]interface{}
+	failover := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&received)
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer failover.Close()
+
+	project := &models.Project{ID: primitive.NewObjectID(), ExecutionEndpoint: primary.URL, FailoverEndpoint: failover.URL}
+	task := &models.Task{UUID: "task-uuid", Name: "Backup", ProjectID: project.ID}
+
+	repo := mocks.NewMockRepository(ctrl)
+	repo.EXPECT().GetProjectByID(gomock.Any(), project.ID).Return(project, nil)
+	repo.EXPECT().CreateExecution(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, execution *models.Execution) error {
+		if execution.Endpoint != primary.URL {
+			t.Errorf("Expected execution to be created for the primary endpoint, got %q", execution.Endpoint)
+		}
+		return nil
+	})
+	repo.EXPECT().GetProjectSettings(gomock.Any(), project.ID).Return(nil, nil)
+	repo.EXPECT().RecordExecutionEndpoint(gomock.Any(), gomock.Any(), failover.URL).Return(nil)
+
+	executionUUID, result, err := ExecuteTaskAndWait(context.Background(), task, repo, nil, "TEST")
+	if err != nil {
+		t.Fatalf("ExecuteTaskAndWait: %v", err)
+	}
+	if result.StatusCode != http.StatusAccepted || result.Endpoint != failover.URL {
+		t.Fatalf("Expected the failover endpoint to serve the execution, got %+v", result)
+	}
+	if received["execution_id"] != executionUUID {
+		t.Fatalf("unexpected request body %v", received)
+	}
+}
+
+func TestShouldFailOver(t *testing.T) {
+	tests := []struct {
+		result *DispatchResult
+		err    error
+		want   bool
+	}{
+		{nil, errors.New("connection refused"), true},
+		{nil, context.Canceled, false},
+		{&DispatchResult{StatusCode: http.StatusBadGateway}, nil, true},
+		{&DispatchResult{StatusCode: http.StatusBadRequest}, nil, false},
+		{&DispatchResult{StatusCode: http.StatusOK}, nil, false},
+	}
+	for _, tt := range tests {
+		if got := shouldFailOver(tt.result, tt.err); got != tt.want {
+			t.Errorf("shouldFailOver(%+v, %v) = %v, want %v", tt.result, tt.err, got, tt.want)
+		}
+	}
+}
+
+func TestDispatchTimeout(t *testing.T) {
+	withTriggerTimeout := func(seconds int) *models.Task {
+		return &models.Task{TriggerConfig: models.TriggerConfig{HTTP: &models.HTTPTriggerConfig{Timeout: seconds}}}
+	}
+	tests := []struct {
+		name           string
+		task           *models.Task
+		timeoutSeconds int
+		want           time.Duration
+	}{
+		{"default", &models.Task{}, 0, defaultDispatchTimeout},
+		{"trigger timeout", withTriggerTimeout(90), 0, 90 * time.Second},
+		{"capped by execution timeout", withTriggerTimeout(90), 20, 20 * time.Second},
+		{"execution timeout above default", &models.Task{}, 3600, defaultDispatchTimeout},
+		{"upper bound", withTriggerTimeout(900), 0, maxDispatchTimeout},
+	}
+	for _, tt := range tests {
+		if got := dispatchTimeout(tt.task, tt.timeoutSeconds); got != tt.want {
+			t.Errorf("%s: dispatchTimeout = %s, want %s", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestExecuteTaskAndWait_GivesUpAfterTriggerTimeout(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer server.Close()
+	defer close(release)
+
+	project := &models.Project{ID: primitive.NewObjectID(), ExecutionEndpoint: server.URL}
+	task := &models.Task{
+		UUID:          "task-uuid",
+		ProjectID:     project.ID,
+		TriggerConfig: models.TriggerConfig{HTTP: &models.HTTPTriggerConfig{Timeout: 1}},
+	}
+
+	repo := mocks.NewMockRepository(ctrl)
+	repo.EXPECT().GetProjectByID(gomock.Any(), project.ID).Return(project, nil)
+	repo.EXPECT().CreateExecution(gomock.Any(), gomock.Any()).Return(nil)
+	repo.EXPECT().GetProjectSettings(gomock.Any(), project.ID).Return(nil, nil)
+
+	start := time.Now()
+	if _, _, err := ExecuteTaskAndWait(context.Background(), task, repo, nil, "TEST"); err == nil {
+		t.Fatal("Expected the request to time out")
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("Expected the request to give up after the trigger timeout of 1s, took %s", elapsed)
+	}
+}
@@ -10,9 +10,11 @@ import (
 	_ "time/tzdata" // Embed IANA timezone database for timezone loading
 
 	"github.com/robfig/cron/v3"
+	"github.com/yourusername/cron-observer/backend/internal/cronexpr"
 	"github.com/yourusername/cron-observer/backend/internal/events"
 	"github.com/yourusername/cron-observer/backend/internal/models"
 	"github.com/yourusername/cron-observer/backend/internal/repositories"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 // Scheduler manages cron jobs for tasks
@@ -23,41 +25,178 @@ type Scheduler struct {
 	mu        sync.RWMutex
 	eventBus  *events.EventBus
 	repo      repositories.Repository
+
+	dispatchQueue   *dispatchQueue // cron firings wait here, highest task priority first
+	dispatchWorkers int
 }
 
 // New creates a new Scheduler instance
 func New(eventBus *events.EventBus, repo repositories.Repository) *Scheduler {
-	// Configure cron to use local timezone (container timezone, set to Asia/Dhaka)
-	// This allows cron expressions to be written in the container's local timezone
-	c := cron.New(
-		cron.WithSeconds(), // Using WithSeconds for more precise scheduling
-		// No WithLocation - uses system/local timezone (Asia/Dhaka in container)
+	s := &Scheduler{
+		cron:            newCron(time.UTC),
+		jobs:            make(map[string]cron.EntryID),
+		groupJobs:       make(map[string]map[string]cron.EntryID),
+		eventBus:        eventBus,
+		repo:            repo,
+		dispatchWorkers: defaultDispatchWorkers,
+	}
+	s.dispatchQueue = newDispatchQueue(func(ctx context.Context, task *models.Task, executionUUID string) {
+		// Errors are already logged in ExecuteTask
+		if executionUUID != "" {
+			_, _ = executeQueuedTask(ctx, task, s.repo, s.eventBus, "CRON", executionUUID)
+			return
+		}
+		_, _ = ExecuteTask(ctx, task, s.repo, s.eventBus, "CRON")
+	})
+	s.dispatchQueue.setLimits(dispatchLimits{
+		rateLimit: s.dispatchRateLimit,
+		capacity:  s.hasCapacity,
+		hold:      s.holdFiring,
+		drop:      s.dropFiring,
+	})
+	return s
+}
+
+// holdFiring records a firing that waits for a dispatch worker or a limit as a QUEUED execution. Firings that cannot
+// be recorded wait without one and get their execution when they are dispatched.
+func (s *Scheduler) holdFiring(ctx context.Context, task *models.Task) string {
+	executionUUID, err := queueExecution(ctx, task, s.repo)
+	if err != nil {
+		log.Printf("[CRON] Failed to record queued execution of task %s: %v", task.UUID, err)
+		return ""
+	}
+	return executionUUID
+}
+
+// dropFiring fails the QUEUED execution of a firing the stopping scheduler will not dispatch, so it does not stay
+// queued forever
+func (s *Scheduler) dropFiring(ctx context.Context, executionUUID string) {
+	if _, err := s.repo.FailExecutionIfUnfinished(ctx, executionUUID, models.ExecutionErrorSchedulerStopped); err != nil {
+		log.Printf("[CRON] Failed to fail queued execution %s: %v", executionUUID, err)
+	}
+}
+
+// dispatchRateLimit returns the project's dispatch_rate_limit. Firings of projects whose settings cannot be read
+// are dispatched without a limit.
+func (s *Scheduler) dispatchRateLimit(ctx context.Context, projectID primitive.ObjectID) int {
+	settings, err := s.repo.GetProjectSettings(ctx, projectID)
+	if err != nil {
+		log.Printf("[CRON] Failed to get settings for project %s, dispatching without a rate limit: %v", projectID.Hex(), err)
+		return 0
+	}
+	if settings == nil {
+		return 0
+	}
+	return settings.DispatchRateLimit
+}
+
+// hasCapacity reports whether another execution of the task stays within the concurrency_budget of the task and of
+// its group, counting the firings other workers reserved as they are being dispatched. Only executions started
+// within activeExecutionWindow count, so executions that never reported an end do not hold a limit forever. A
+// reserved firing may already have recorded its execution and count twice, which errs on the side of the limit.
+// Firings whose limits cannot be checked are dispatched.
+func (s *Scheduler) hasCapacity(ctx context.Context, task *models.Task, reserved dispatchReservation) bool {
+	since := time.Now().Add(-activeExecutionWindow)
+	if task.Concurrency > 0 {
+		active, err := s.repo.CountActiveExecutions(ctx, []string{task.UUID}, since)
+		if err != nil {
+			log.Printf("[CRON] Failed to count active executions of task %s, dispatching without its concurrency limit: %v", task.UUID, err)
+		} else if active+int64(reserved.task) >= int64(task.Concurrency) {
+			return false
+		}
+	}
+
+	if task.TaskGroupID == nil {
+		return true
+	}
+	group, err := s.repo.GetTaskGroupByID(ctx, *task.TaskGroupID)
+	if err != nil || group == nil || group.Concurrency == 0 {
+		if err != nil {
+			log.Printf("[CRON] Failed to get task group of task %s, dispatching without its concurrency limit: %v", task.UUID, err)
+		}
+		return true
+	}
+	tasks, err := s.repo.GetTasksByGroupID(ctx, group.ID)
+	if err != nil {
+		log.Printf("[CRON] Failed to get tasks of group %s, dispatching without its concurrency limit: %v", group.UUID, err)
+		return true
+	}
+	taskUUIDs := make([]string, 0, len(tasks))
+	for _, groupTask := range tasks {
+		taskUUIDs = append(taskUUIDs, groupTask.UUID)
+	}
+	active, err := s.repo.CountActiveExecutions(ctx, taskUUIDs, since)
+	if err != nil {
+		log.Printf("[CRON] Failed to count active executions of group %s, dispatching without its concurrency limit: %v", group.UUID, err)
+		return true
+	}
+	return active+int64(reserved.group) < int64(group.Concurrency)
+}
+
+// newCron creates the cron engine. Expressions without a CRON_TZ prefix fire in loc.
+func newCron(loc *time.Location) *cron.Cron {
+	return cron.New(
+		cron.WithParser(cronexpr.Parser), // Optional seconds field for more precise scheduling
+		cron.WithLocation(loc),
 	)
+}
 
-	return &Scheduler{
-		cron:      c,
-		jobs:      make(map[string]cron.EntryID),
-		groupJobs: make(map[string]map[string]cron.EntryID),
-		eventBus:  eventBus,
-		repo:      repo,
+// SetTimezone sets the IANA timezone of cron expressions without a timezone of their own (default UTC). Task
+// group windows are converted to it. Must be called before Start and before any job is registered.
+func (s *Scheduler) SetTimezone(timezone string) error {
+	loc, err := time.LoadLocation(timezone)
+	if err != nil {
+		return fmt.Errorf("invalid scheduler timezone %q: %w", timezone, err)
 	}
+	s.cron = newCron(loc)
+	return nil
+}
+
+// Location returns the timezone of cron expressions without a timezone of their own
+func (s *Scheduler) Location() *time.Location {
+	return s.cron.Location()
+}
+
+// SetDispatchWorkers sets how many queued firings are dispatched concurrently. Must be called before Start;
+// values below 1 keep the default.
+func (s *Scheduler) SetDispatchWorkers(workers int) {
+	if workers > 0 {
+		s.dispatchWorkers = workers
+	}
+}
+
+// DispatchQueueLength returns the number of firings waiting to be dispatched
+func (s *Scheduler) DispatchQueueLength() int {
+	return s.dispatchQueue.len()
+}
+
+// DispatchBacklog returns how many firings of the project wait to be dispatched and how many its rate limit delayed
+func (s *Scheduler) DispatchBacklog(projectID primitive.ObjectID) models.DispatchBacklog {
+	return s.dispatchQueue.backlog(projectID)
 }
 
 // Start starts the scheduler and begins listening for events
 func (s *Scheduler) Start(ctx context.Context) {
+	// Start the dispatch workers before the cron engine so no firing waits for them
+	s.dispatchQueue.start(s.dispatchWorkers)
+
 	// Start the cron engine
 	s.cron.Start()
 	log.Println("Scheduler started")
 
 	// Subscribe to task events
-	taskCreatedCh := s.eventBus.Subscribe(events.TaskCreated)
-	taskUpdatedCh := s.eventBus.Subscribe(events.TaskUpdated)
-	taskDeletedCh := s.eventBus.Subscribe(events.TaskDeleted)
+	taskCreatedCh := events.Subscribe(s.eventBus, events.TaskCreatedTopic)
+	taskUpdatedCh := events.Subscribe(s.eventBus, events.TaskUpdatedTopic)
+	taskDeletedCh := events.Subscribe(s.eventBus, events.TaskDeletedTopic)
 
 	// Subscribe to task group events
-	taskGroupCreatedCh := s.eventBus.Subscribe(events.TaskGroupCreated)
-	taskGroupUpdatedCh := s.eventBus.Subscribe(events.TaskGroupUpdated)
-	taskGroupDeletedCh := s.eventBus.Subscribe(events.TaskGroupDeleted)
+	taskGroupCreatedCh := events.Subscribe(s.eventBus, events.TaskGroupCreatedTopic)
+	taskGroupUpdatedCh := events.Subscribe(s.eventBus, events.TaskGroupUpdatedTopic)
+	taskGroupDeletedCh := events.Subscribe(s.eventBus, events.TaskGroupDeletedTopic)
+
+	// Subscribe to project events
+	projectArchivedCh := events.Subscribe(s.eventBus, events.ProjectArchivedTopic)
+	projectRestoredCh := events.Subscribe(s.eventBus, events.ProjectRestoredTopic)
 
 	// Start event listener goroutine
 	go func() {
@@ -66,42 +205,54 @@ func (s *Scheduler) Start(ctx context.Context) {
 			case <-ctx.Done():
 				log.Println("Scheduler context cancelled, stopping event listener")
 				return
-			case event, ok := <-taskCreatedCh:
+			case payload, ok := <-taskCreatedCh:
 				if !ok {
 					log.Println("TaskCreated channel closed")
 					return
 				}
-				s.handleTaskCreated(event)
-			case event, ok := <-taskUpdatedCh:
+				s.handleTaskCreated(payload)
+			case payload, ok := <-taskUpdatedCh:
 				if !ok {
 					log.Println("TaskUpdated channel closed")
 					return
 				}
-				s.handleTaskUpdated(event)
-			case event, ok := <-taskDeletedCh:
+				s.handleTaskUpdated(payload)
+			case payload, ok := <-taskDeletedCh:
 				if !ok {
 					log.Println("TaskDeleted channel closed")
 					return
 				}
-				s.handleTaskDeleted(event)
-			case event, ok := <-taskGroupCreatedCh:
+				s.handleTaskDeleted(payload)
+			case payload, ok := <-taskGroupCreatedCh:
 				if !ok {
 					log.Println("TaskGroupCreated channel closed")
 					return
 				}
-				s.handleTaskGroupCreated(event)
-			case event, ok := <-taskGroupUpdatedCh:
+				s.handleTaskGroupCreated(payload)
+			case payload, ok := <-taskGroupUpdatedCh:
 				if !ok {
 					log.Println("TaskGroupUpdated channel closed")
 					return
 				}
-				s.handleTaskGroupUpdated(event)
-			case event, ok := <-taskGroupDeletedCh:
+				s.handleTaskGroupUpdated(payload)
+			case payload, ok := <-taskGroupDeletedCh:
 				if !ok {
 					log.Println("TaskGroupDeleted channel closed")
 					return
 				}
-				s.handleTaskGroupDeleted(event)
+				s.handleTaskGroupDeleted(payload)
+			case payload, ok := <-projectArchivedCh:
+				if !ok {
+					log.Println("ProjectArchived channel closed")
+					return
+				}
+				s.handleProjectArchived(payload)
+			case payload, ok := <-projectRestoredCh:
+				if !ok {
+					log.Println("ProjectRestored channel closed")
+					return
+				}
+				s.handleProjectRestored(payload)
 			}
 		}
 	}()
@@ -110,11 +261,40 @@ func (s *Scheduler) Start(ctx context.Context) {
 // Stop gracefully stops the scheduler
 func (s *Scheduler) Stop() {
 	log.Println("Stopping scheduler...")
-	ctx := s.cron.Stop()
-	<-ctx.Done()
+	s.StopFiring()
+	// Firings already queued are still dispatched
+	s.dispatchQueue.stop()
 	log.Println("Scheduler stopped")
 }
 
+// StopFiring stops the cron engine, waiting for jobs that are firing, so no new executions are queued.
+// Firings already queued are dispatched by Drain.
+func (s *Scheduler) StopFiring() {
+	log.Println("Stopping cron firing...")
+	<-s.cron.Stop().Done()
+}
+
+// Drain dispatches the queued firings and waits for execution requests still being sent, until ctx is done.
+// Call after StopFiring.
+func (s *Scheduler) Drain(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		s.dispatchQueue.stop()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-ctx.Done():
+		return fmt.Errorf("%d firing(s) still queued: %w", s.dispatchQueue.len(), ctx.Err())
+	}
+
+	if err := WaitForDispatches(ctx); err != nil {
+		return fmt.Errorf("execution requests still in flight: %w", err)
+	}
+	log.Println("Scheduler drained")
+	return nil
+}
+
 // LoadAllActiveTasks loads all active tasks from the repository and registers them
 func (s *Scheduler) LoadAllActiveTasks(ctx context.Context) error {
 	// Load active task groups with windows
@@ -159,6 +339,21 @@ func (s *Scheduler) registerTask(ctx context.Context, task *models.Task) error {
 		return nil
 	}
 
+	// Paused tasks stay unscheduled until resumed, also within an active group window
+	if task.Status == models.TaskStatusPaused {
+		return nil
+	}
+
+	// Tasks of archived or deleted projects stay unscheduled
+	project, err := s.repo.GetProjectByID(ctx, task.ProjectID)
+	if err != nil {
+		log.Printf("Failed to get project for task %s: %v", task.UUID, err)
+		return nil // Don't register if project lookup fails
+	}
+	if project.Status == models.ProjectStatusArchived || project.Status == models.ProjectStatusPendingDelete {
+		return nil
+	}
+
 	// If task belongs to a group, check group status and window
 	if task.TaskGroupID != nil {
 		taskGroup, err := s.repo.GetTaskGroupByID(ctx, *task.TaskGroupID)
@@ -183,20 +378,49 @@ func (s *Scheduler) registerTask(ctx context.Context, task *models.Task) error {
 		}
 	}
 
-	job := &TaskJob{Task: task, Repo: s.repo, EventBus: s.eventBus}
-	entryID, err := s.cron.AddJob(task.ScheduleConfig.CronExpression, job)
+	// Tasks without their own timezone run in the project's default timezone, then in the scheduler's
+	settings, err := s.repo.GetProjectSettings(ctx, task.ProjectID)
+	if err != nil {
+		log.Printf("Failed to get project settings for task %s, using task values only: %v", task.UUID, err)
+	}
+	timezone := settings.EffectiveTimezone(task.ScheduleConfig.Timezone)
+	if timezone == "" {
+		// Pinned in the spec so next_run_at is computed in the same zone the cron engine fires in
+		timezone = s.Location().String()
+	}
+	spec := cronexpr.WithTimezone(task.ScheduleConfig.CronExpression, timezone)
+
+	schedule, err := cronexpr.Parse(spec)
 	if err != nil {
 		return err
 	}
 
+	job := &TaskJob{Task: task, Repo: s.repo, EventBus: s.eventBus, queue: s.dispatchQueue, schedule: schedule}
+	entryID := s.cron.Schedule(schedule, job)
+
 	s.mu.Lock()
 	s.jobs[task.UUID] = entryID
 	s.mu.Unlock()
 
-	log.Printf("Registered cron job for task %s (UUID: %s) with expression: %s", task.Name, task.UUID, task.ScheduleConfig.CronExpression)
+	job.recordNextRun(ctx, time.Now())
+
+	log.Printf("Registered cron job for task %s (UUID: %s) with expression: %s", task.Name, task.UUID, spec)
 	return nil
 }
 
+// NextRun returns the next scheduled run of a task and whether the task is registered.
+// The time is zero while the cron engine has not started.
+func (s *Scheduler) NextRun(taskUUID string) (time.Time, bool) {
+	s.mu.RLock()
+	entryID, exists := s.jobs[taskUUID]
+	s.mu.RUnlock()
+
+	if !exists {
+		return time.Time{}, false
+	}
+	return s.cron.Entry(entryID).Next, true
+}
+
 // UnregisterTask removes a task's cron job so it no longer runs.
 // It is idempotent: safe to call multiple times for the same task UUID;
 // if the task is not registered, it returns without error.
@@ -208,26 +432,25 @@ func (s *Scheduler) UnregisterTask(taskUUID string) {
 // unregisterTask removes a task's cron job (internal). Idempotent: no-op if task not in s.jobs.
 func (s *Scheduler) unregisterTask(taskUUID string) {
 	s.mu.Lock()
-	defer s.mu.Unlock()
-
 	entryID, exists := s.jobs[taskUUID]
 	if !exists {
+		s.mu.Unlock()
 		return
 	}
 
 	s.cron.Remove(entryID)
 	delete(s.jobs, taskUUID)
+	s.mu.Unlock()
 	log.Printf("Unregistered cron job for task UUID: %s", taskUUID)
-}
 
-// handleTaskCreated handles TaskCreated events
-func (s *Scheduler) handleTaskCreated(event events.Event) {
-	payload, ok := event.Payload.(events.TaskPayload)
-	if !ok {
-		log.Printf("Invalid payload for TaskCreated event")
-		return
+	// Unscheduled tasks have no next run
+	if err := s.repo.SetTaskNextRunAt(context.Background(), taskUUID, nil); err != nil {
+		log.Printf("Failed to clear next run for task %s: %v", taskUUID, err)
 	}
+}
 
+// handleTaskCreated handles TaskCreated events
+func (s *Scheduler) handleTaskCreated(payload events.TaskPayload) {
 	ctx := context.Background()
 	if err := s.registerTask(ctx, payload.Task); err != nil {
 		log.Printf("Failed to register task from event: %v", err)
@@ -235,13 +458,7 @@ func (s *Scheduler) handleTaskCreated(event events.Event) {
 }
 
 // handleTaskUpdated handles TaskUpdated events
-func (s *Scheduler) handleTaskUpdated(event events.Event) {
-	payload, ok := event.Payload.(events.TaskPayload)
-	if !ok {
-		log.Printf("Invalid payload for TaskUpdated event")
-		return
-	}
-
+func (s *Scheduler) handleTaskUpdated(payload events.TaskPayload) {
 	// Remove old job if exists
 	s.unregisterTask(payload.Task.UUID)
 
@@ -253,24 +470,45 @@ func (s *Scheduler) handleTaskUpdated(event events.Event) {
 }
 
 // handleTaskDeleted handles TaskDeleted events
-func (s *Scheduler) handleTaskDeleted(event events.Event) {
-	payload, ok := event.Payload.(events.TaskDeletedPayload)
-	if !ok {
-		log.Printf("Invalid payload for TaskDeleted event")
+func (s *Scheduler) handleTaskDeleted(payload events.TaskDeletedPayload) {
+	s.unregisterTask(payload.TaskUUID)
+}
+
+// handleProjectArchived handles ProjectArchived events by unregistering all of the project's tasks
+func (s *Scheduler) handleProjectArchived(payload events.ProjectPayload) {
+	ctx := context.Background()
+	tasks, err := s.repo.GetTasksByProjectID(ctx, payload.Project.ID)
+	if err != nil {
+		log.Printf("Failed to get tasks for archived project %s: %v", payload.Project.ID.Hex(), err)
 		return
 	}
 
-	s.unregisterTask(payload.TaskUUID)
+	for _, task := range tasks {
+		s.unregisterTask(task.UUID)
+	}
+	log.Printf("Unregistered %d tasks of archived project %s", len(tasks), payload.Project.ID.Hex())
 }
 
-// handleTaskGroupCreated handles TaskGroupCreated events
-func (s *Scheduler) handleTaskGroupCreated(event events.Event) {
-	payload, ok := event.Payload.(events.TaskGroupPayload)
-	if !ok {
-		log.Printf("Invalid payload for TaskGroupCreated event")
+// handleProjectRestored handles ProjectRestored events by registering the project's tasks again
+func (s *Scheduler) handleProjectRestored(payload events.ProjectPayload) {
+	ctx := context.Background()
+	tasks, err := s.repo.GetTasksByProjectID(ctx, payload.Project.ID)
+	if err != nil {
+		log.Printf("Failed to get tasks for restored project %s: %v", payload.Project.ID.Hex(), err)
 		return
 	}
 
+	for _, task := range tasks {
+		// Remove any stale job first so a restore never double-registers a task
+		s.unregisterTask(task.UUID)
+		if err := s.registerTask(ctx, task); err != nil {
+			log.Printf("Failed to register task %s of restored project: %v", task.UUID, err)
+		}
+	}
+}
+
+// handleTaskGroupCreated handles TaskGroupCreated events
+func (s *Scheduler) handleTaskGroupCreated(payload events.TaskGroupPayload) {
 	// Only register window jobs if group has start and end times
 	if payload.TaskGroup.StartTime != "" && payload.TaskGroup.EndTime != "" {
 		if err := s.registerGroupWindowJobs(payload.TaskGroup); err != nil {
@@ -280,13 +518,7 @@ func (s *Scheduler) handleTaskGroupCreated(event events.Event) {
 }
 
 // handleTaskGroupUpdated handles TaskGroupUpdated events
-func (s *Scheduler) handleTaskGroupUpdated(event events.Event) {
-	payload, ok := event.Payload.(events.TaskGroupPayload)
-	if !ok {
-		log.Printf("Invalid payload for TaskGroupUpdated event")
-		return
-	}
-
+func (s *Scheduler) handleTaskGroupUpdated(payload events.TaskGroupPayload) {
 	updatedTaskGroup := payload.TaskGroup
 	ctx := context.Background()
 
@@ -397,13 +629,7 @@ func (s *Scheduler) handleTaskGroupUpdated(event events.Event) {
 }
 
 // handleTaskGroupDeleted handles TaskGroupDeleted events
-func (s *Scheduler) handleTaskGroupDeleted(event events.Event) {
-	payload, ok := event.Payload.(events.TaskGroupDeletedPayload)
-	if !ok {
-		log.Printf("Invalid payload for TaskGroupDeleted event")
-		return
-	}
-
+func (s *Scheduler) handleTaskGroupDeleted(payload events.TaskGroupDeletedPayload) {
 	s.unregisterGroupWindowJobs(payload.TaskGroupUUID)
 }
 
@@ -415,13 +641,13 @@ func (s *Scheduler) registerGroupWindowJobs(taskGroup *models.TaskGroup) error {
 	}
 
 	// Convert start time to cron expression
-	startCron, err := timeToCronExpression(taskGroup.StartTime, taskGroup.Timezone)
+	startCron, err := timeToCronExpression(taskGroup.StartTime, taskGroup.Timezone, s.Location(), time.Now())
 	if err != nil {
 		return fmt.Errorf("failed to convert start time to cron: %w", err)
 	}
 
 	// Convert end time to cron expression
-	endCron, err := timeToCronExpression(taskGroup.EndTime, taskGroup.Timezone)
+	endCron, err := timeToCronExpression(taskGroup.EndTime, taskGroup.Timezone, s.Location(), time.Now())
 	if err != nil {
 		return fmt.Errorf("failed to convert end time to cron: %w", err)
 	}
@@ -541,8 +767,8 @@ func (s *Scheduler) calculateTaskGroupState(ctx context.Context, taskGroup *mode
 }
 
 // timeToCronExpression converts HH:MM time to daily cron expression
-// Assumes time is in the given timezone, converts to container's local timezone (Asia/Dhaka)
-func timeToCronExpression(timeStr, timezone string) (string, error) {
+// Assumes time is in the given timezone and converts it to the scheduler's timezone (target) as of the date of now
+func timeToCronExpression(timeStr, timezone string, target *time.Location, now time.Time) (string, error) {
 	// Parse time (HH:MM format)
 	loc, err := time.LoadLocation(timezone)
 	if err != nil {
@@ -556,19 +782,17 @@ func timeToCronExpression(timeStr, timezone string) (string, error) {
 	}
 
 	// Create a time for today in the group's timezone
-	now := time.Now()
 	nowInLoc := now.In(loc)
 	today := time.Date(nowInLoc.Year(), nowInLoc.Month(), nowInLoc.Day(), t.Hour(), t.Minute(), 0, 0, loc)
 
-	// Convert to container's local timezone (Asia/Dhaka)
-	// The container TZ is set to Asia/Dhaka, so time.Now() uses that timezone
-	localTime := today.In(time.Local)
+	// Convert to the timezone the cron engine fires in
+	targetTime := today.In(target)
 
 	// Create cron expression: second minute hour day month weekday
 	// Format: "second minute hour * * *"
-	cronExpr := fmt.Sprintf("%d %d %d * * *", localTime.Second(), localTime.Minute(), localTime.Hour())
+	cronExpr := fmt.Sprintf("%d %d %d * * *", targetTime.Second(), targetTime.Minute(), targetTime.Hour())
 
-	log.Printf("[CRON] Converting time: %s %s -> Local %s (cron: %s)", timeStr, timezone, localTime.Format("15:04:05 MST"), cronExpr)
+	log.Printf("[CRON] Converting time: %s %s -> %s (cron: %s)", timeStr, timezone, targetTime.Format("15:04:05 MST"), cronExpr)
 
 	return cronExpr, nil
 }
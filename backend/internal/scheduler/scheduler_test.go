@@ -0,0 +1,95 @@
+package scheduler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/yourusername/cron-observer/backend/internal/models"
+	"github.com/yourusername/cron-observer/backend/mocks"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.uber.org/mock/gomock"
+)
+
+func TestScheduler_RegisterTask_PersistsNextRunAndClearsOnUnregister(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	projectID := primitive.NewObjectID()
+	task := &models.Task{
+		UUID:           "task-uuid",
+		ProjectID:      projectID,
+		Status:         models.TaskStatusActive,
+		ScheduleConfig: models.ScheduleConfig{CronExpression: "*/15 * * * *"}, // standard five fields, as the UI sends them
+	}
+
+	repo := mocks.NewMockRepository(ctrl)
+	s := New(nil, repo)
+
+	repo.EXPECT().GetProjectByID(gomock.Any(), projectID).Return(&models.Project{ID: projectID, Status: models.ProjectStatusActive}, nil)
+	repo.EXPECT().GetProjectSettings(gomock.Any(), projectID).Return(nil, nil)
+
+	var recorded *time.Time
+	repo.EXPECT().
+		SetTaskNextRunAt(gomock.Any(), "task-uuid", gomock.Not(gomock.Nil())).
+		DoAndReturn(func(ctx context.Context, taskUUID string, nextRunAt *time.Time) error {
+			recorded = nextRunAt
+			return nil
+		})
+
+	before := time.Now()
+	if err := s.RegisterTask(context.Background(), task); err != nil {
+		t.Fatalf("RegisterTask failed: %v", err)
+	}
+	if recorded == nil || !recorded.After(before) || recorded.After(before.Add(15*time.Minute)) || recorded.Minute()%15 != 0 || recorded.Second() != 0 {
+		t.Fatalf("Expected next run on the next quarter hour, got %v", recorded)
+	}
+
+	repo.EXPECT().SetTaskNextRunAt(gomock.Any(), "task-uuid", gomock.Nil()).Return(nil)
+	s.UnregisterTask("task-uuid")
+
+	// Unregistering again is a no-op and does not touch the task
+	s.UnregisterTask("task-uuid")
+}
+
+func TestScheduler_RegisterTask_AcceptsDescriptorInProjectTimezone(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	projectID := primitive.NewObjectID()
+	task := &models.Task{
+		UUID:           "task-uuid",
+		ProjectID:      projectID,
+		Status:         models.TaskStatusActive,
+		ScheduleConfig: models.ScheduleConfig{CronExpression: "@daily", Timezone: "Asia/Tokyo"},
+	}
+
+	repo := mocks.NewMockRepository(ctrl)
+	s := New(nil, repo)
+
+	repo.EXPECT().GetProjectByID(gomock.Any(), projectID).Return(&models.Project{ID: projectID, Status: models.ProjectStatusActive}, nil)
+	repo.EXPECT().GetProjectSettings(gomock.Any(), projectID).Return(nil, nil)
+
+	var recorded *time.Time
+	repo.EXPECT().
+		SetTaskNextRunAt(gomock.Any(), "task-uuid", gomock.Any()).
+		DoAndReturn(func(ctx context.Context, taskUUID string, nextRunAt *time.Time) error {
+			recorded = nextRunAt
+			return nil
+		})
+
+	if err := s.RegisterTask(context.Background(), task); err != nil {
+		t.Fatalf("RegisterTask failed: %v", err)
+	}
+	if _, registered := s.NextRun("task-uuid"); !registered {
+		t.Fatal("Expected descriptor task to be registered")
+	}
+
+	tokyo, _ := time.LoadLocation("Asia/Tokyo")
+	if recorded == nil {
+		t.Fatal("Expected next run to be recorded")
+	}
+	if local := recorded.In(tokyo); local.Hour() != 0 || local.Minute() != 0 {
+		t.Errorf("Expected next run at midnight in Tokyo, got %v", local)
+	}
+}
@@ -0,0 +1,70 @@
+package scheduler
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// envReferencePattern matches {{env:NAME}} references to a task's environment variables
+var envReferencePattern = regexp.MustCompile(`\{\{\s*env:([A-Za-z_][A-Za-z0-9_]*)\s*\}\}`)
+
+// ErrEnvVariableNotFound is returned when a trigger header or body references a variable the task does not define
+var ErrEnvVariableNotFound = errors.New("env variable not found in task")
+
+// expandTaskEnv replaces {{env:NAME}} references in strings, maps and arrays with the task's variables
+func expandTaskEnv(value interface{}, env map[string]string) (interface{}, error) {
+	switch v := value.(type) {
+	case string:
+		return expandTaskEnvString(v, env)
+	case map[string]interface{}:
+		expanded := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			expandedItem, err := expandTaskEnv(item, env)
+			if err != nil {
+				return nil, err
+			}
+			expanded[key] = expandedItem
+		}
+		return expanded, nil
+	case primitive.M:
+		return expandTaskEnv(map[string]interface{}(v), env)
+	case []interface{}:
+		expanded := make([]interface{}, len(v))
+		for i, item := range v {
+			expandedItem, err := expandTaskEnv(item, env)
+			if err != nil {
+				return nil, err
+			}
+			expanded[i] = expandedItem
+		}
+		return expanded, nil
+	case primitive.A:
+		return expandTaskEnv([]interface{}(v), env)
+	default:
+		return value, nil
+	}
+}
+
+// expandTaskEnvString replaces {{env:NAME}} references in s
+func expandTaskEnvString(s string, env map[string]string) (string, error) {
+	var missing string
+	expanded := envReferencePattern.ReplaceAllStringFunc(s, func(match string) string {
+		name := envReferencePattern.FindStringSubmatch(match)[1]
+		value, ok := env[name]
+		if !ok {
+			if missing == "" {
+				missing = name
+			}
+			return match
+		}
+		return value
+	})
+
+	if missing != "" {
+		return "", fmt.Errorf("%w: %s", ErrEnvVariableNotFound, missing)
+	}
+	return expanded, nil
+}
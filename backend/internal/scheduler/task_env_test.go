@@ -0,0 +1,39 @@
+package scheduler
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestExpandTaskEnv_ReplacesReferencesInNestedValues(t *testing.T) {
+	env := map[string]string{"CONFIG_SET": "eu-batch", "REGION": "eu-west-1"}
+	body := primitive.M{
+		"config": "{{env:CONFIG_SET}}",
+		"target": primitive.A{"{{ env:REGION }}", 3},
+		"token":  "{{secret:API_TOKEN}}",
+	}
+
+	expanded, err := expandTaskEnv(body, env)
+	if err != nil {
+		t.Fatalf("Expected nil error, got: %v", err)
+	}
+
+	expected := map[string]interface{}{
+		"config": "eu-batch",
+		"target": []interface{}{"eu-west-1", 3},
+		"token":  "{{secret:API_TOKEN}}", // secret references are left for the secret resolver
+	}
+	if !reflect.DeepEqual(expanded, expected) {
+		t.Errorf("Expected %v, got %v", expected, expanded)
+	}
+}
+
+func TestExpandTaskEnvString_FailsOnUndefinedVariable(t *testing.T) {
+	_, err := expandTaskEnvString("Bearer {{env:MISSING}}", map[string]string{"CONFIG_SET": "eu-batch"})
+	if !errors.Is(err, ErrEnvVariableNotFound) {
+		t.Errorf("Expected ErrEnvVariableNotFound, got: %v", err)
+	}
+}
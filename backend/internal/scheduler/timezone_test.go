@@ -0,0 +1,139 @@
+package scheduler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/yourusername/cron-observer/backend/internal/models"
+	"github.com/yourusername/cron-observer/backend/mocks"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.uber.org/mock/gomock"
+)
+
+func TestTimeToCronExpression_ConvertsToSchedulerTimezone(t *testing.T) {
+	utc := time.UTC
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	winter := time.Date(2026, time.January, 15, 12, 0, 0, 0, time.UTC)
+	summer := time.Date(2026, time.July, 15, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		timeStr  string
+		timezone string
+		target   *time.Location
+		now      time.Time
+		want     string
+	}{
+		{"same timezone", "09:30", "UTC", utc, winter, "0 30 9 * * *"},
+		{"group ahead of scheduler", "09:00", "Asia/Dhaka", utc, winter, "0 0 3 * * *"},
+		{"wraps to the previous day", "02:00", "Asia/Dhaka", utc, winter, "0 0 20 * * *"},
+		{"group behind scheduler in winter", "09:00", "America/New_York", utc, winter, "0 0 14 * * *"},
+		{"group behind scheduler in summer", "09:00", "America/New_York", utc, summer, "0 0 13 * * *"},
+		{"scheduler not in UTC", "09:00", "Asia/Dhaka", newYork, winter, "0 0 22 * * *"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := timeToCronExpression(tt.timeStr, tt.timezone, tt.target, tt.now)
+			if err != nil {
+				t.Fatalf("timeToCronExpression() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("timeToCronExpression(%s %s) = %q, want %q", tt.timeStr, tt.timezone, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTimeToCronExpression_InvalidInput(t *testing.T) {
+	if _, err := timeToCronExpression("09:00", "Mars/Olympus", time.UTC, time.Now()); err == nil {
+		t.Error("expected an error for an unknown timezone")
+	}
+	if _, err := timeToCronExpression("9am", "UTC", time.UTC, time.Now()); err == nil {
+		t.Error("expected an error for a time not in HH:MM format")
+	}
+}
+
+func TestScheduler_SetTimezone(t *testing.T) {
+	s := New(nil, nil)
+	if s.Location() != time.UTC {
+		t.Errorf("default Location() = %v, want UTC", s.Location())
+	}
+
+	if err := s.SetTimezone("Asia/Dhaka"); err != nil {
+		t.Fatalf("SetTimezone() error = %v", err)
+	}
+	if got := s.Location().String(); got != "Asia/Dhaka" {
+		t.Errorf("Location() = %s, want Asia/Dhaka", got)
+	}
+
+	if err := s.SetTimezone("Mars/Olympus"); err == nil {
+		t.Error("expected an error for an unknown timezone")
+	}
+	if got := s.Location().String(); got != "Asia/Dhaka" {
+		t.Errorf("Location() after a failed SetTimezone = %s, want Asia/Dhaka", got)
+	}
+}
+
+func TestScheduler_RegisterGroupWindowJobs_FiresInSchedulerTimezone(t *testing.T) {
+	s := New(nil, nil)
+	if err := s.SetTimezone("America/New_York"); err != nil {
+		t.Fatal(err)
+	}
+
+	group := windowGroup("group-uuid")
+	group.Timezone = "Asia/Dhaka"
+	if err := s.registerGroupWindowJobs(group); err != nil {
+		t.Fatalf("registerGroupWindowJobs() error = %v", err)
+	}
+
+	entry := s.cron.Entry(s.groupJobs["group-uuid"]["start"])
+	dhaka, _ := time.LoadLocation("Asia/Dhaka")
+	// The cron engine passes its current time in the scheduler's timezone
+	next := entry.Schedule.Next(time.Now().In(s.Location())).In(dhaka)
+	if next.Hour() != 9 || next.Minute() != 0 {
+		t.Errorf("start job fires at %s, want 09:00 in the group's timezone", next)
+	}
+}
+
+func TestScheduler_RegisterTask_WithoutTimezoneRunsInSchedulerTimezone(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	projectID := primitive.NewObjectID()
+	task := &models.Task{
+		UUID:           "task-uuid",
+		ProjectID:      projectID,
+		Status:         models.TaskStatusActive,
+		ScheduleConfig: models.ScheduleConfig{CronExpression: "0 0 9 * * *"},
+	}
+
+	repo := mocks.NewMockRepository(ctrl)
+	s := New(nil, repo)
+	if err := s.SetTimezone("Asia/Dhaka"); err != nil {
+		t.Fatal(err)
+	}
+
+	repo.EXPECT().GetProjectByID(gomock.Any(), projectID).Return(&models.Project{ID: projectID, Status: models.ProjectStatusActive}, nil)
+	repo.EXPECT().GetProjectSettings(gomock.Any(), projectID).Return(nil, nil)
+
+	var recorded *time.Time
+	repo.EXPECT().
+		SetTaskNextRunAt(gomock.Any(), "task-uuid", gomock.Not(gomock.Nil())).
+		DoAndReturn(func(ctx context.Context, taskUUID string, nextRunAt *time.Time) error {
+			recorded = nextRunAt
+			return nil
+		})
+
+	if err := s.RegisterTask(context.Background(), task); err != nil {
+		t.Fatalf("RegisterTask() error = %v", err)
+	}
+	next := recorded.In(s.Location())
+	if next.Hour() != 9 || next.Minute() != 0 {
+		t.Errorf("next run = %s, want 09:00 in the scheduler's timezone", next)
+	}
+}
@@ -0,0 +1,131 @@
+package secrets
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// dataKeySize is the size of the per-secret AES-256 data key
+const dataKeySize = 32
+
+// ErrMasterKeyNotConfigured is returned when secrets are used without SECRETS_MASTER_KEY
+var ErrMasterKeyNotConfigured = errors.New("secrets master key is not configured")
+
+// EncryptedValue is an envelope-encrypted secret: the value is sealed with a random data key,
+// and the data key is sealed with the master key. Nonces are prepended to each ciphertext.
+type EncryptedValue struct {
+	Ciphertext       []byte
+	EncryptedDataKey []byte
+}
+
+// Cipher performs envelope encryption with a master key from config
+type Cipher struct {
+	masterKey []byte
+}
+
+// NewCipher creates a cipher from a base64-encoded 32-byte master key.
+// An empty key returns a cipher that rejects every operation with ErrMasterKeyNotConfigured.
+func NewCipher(encodedMasterKey string) (*Cipher, error) {
+	if encodedMasterKey == "" {
+		return &Cipher{}, nil
+	}
+
+	masterKey, err := base64.StdEncoding.DecodeString(encodedMasterKey)
+	if err != nil {
+		return nil, fmt.Errorf("secrets master key must be base64-encoded: %w", err)
+	}
+	if len(masterKey) != dataKeySize {
+		return nil, fmt.Errorf("secrets master key must be %d bytes, got %d", dataKeySize, len(masterKey))
+	}
+
+	return &Cipher{masterKey: masterKey}, nil
+}
+
+// Encrypt seals plaintext with a fresh data key and wraps the data key with the master key
+func (c *Cipher) Encrypt(plaintext string) (*EncryptedValue, error) {
+	if len(c.masterKey) == 0 {
+		return nil, ErrMasterKeyNotConfigured
+	}
+
+	dataKey := make([]byte, dataKeySize)
+	if _, err := io.ReadFull(rand.Reader, dataKey); err != nil {
+		return nil, fmt.Errorf("failed to generate data key: %w", err)
+	}
+
+	ciphertext, err := seal(dataKey, []byte(plaintext))
+	if err != nil {
+		return nil, err
+	}
+
+	encryptedDataKey, err := seal(c.masterKey, dataKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return &EncryptedValue{
+		Ciphertext:       ciphertext,
+		EncryptedDataKey: encryptedDataKey,
+	}, nil
+}
+
+// Decrypt unwraps the data key with the master key and opens the value
+func (c *Cipher) Decrypt(value *EncryptedValue) (string, error) {
+	if len(c.masterKey) == 0 {
+		return "", ErrMasterKeyNotConfigured
+	}
+
+	dataKey, err := open(c.masterKey, value.EncryptedDataKey)
+	if err != nil {
+		return "", fmt.Errorf("failed to decrypt data key: %w", err)
+	}
+
+	plaintext, err := open(dataKey, value.Ciphertext)
+	if err != nil {
+		return "", fmt.Errorf("failed to decrypt secret: %w", err)
+	}
+
+	return string(plaintext), nil
+}
+
+// seal encrypts plaintext with AES-GCM and prepends the nonce
+func seal(key, plaintext []byte) ([]byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+
+	return gcm.Seal(nonce, nonce, plaintext, nil), nil
+}
+
+// open decrypts a nonce-prefixed AES-GCM ciphertext
+func open(key, ciphertext []byte) ([]byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(ciphertext) < gcm.NonceSize() {
+		return nil, errors.New("ciphertext too short")
+	}
+
+	nonce, sealed := ciphertext[:gcm.NonceSize()], ciphertext[gcm.NonceSize():]
+	return gcm.Open(nil, nonce, sealed, nil)
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,56 @@
+package secrets
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestCipher_EncryptDecryptRoundTrip(t *testing.T) {
+	c, err := NewCipher(base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{7}, 32)))
+	if err != nil {
+		t.Fatalf("NewCipher failed: %v", err)
+	}
+
+	encrypted, err := c.Encrypt("Bearer token-123")
+	if err != nil {
+		t.Fatalf("Encrypt failed: %v", err)
+	}
+	if bytes.Contains(encrypted.Ciphertext, []byte("token-123")) {
+		t.Fatalf("Ciphertext contains plaintext")
+	}
+
+	plaintext, err := c.Decrypt(encrypted)
+	if err != nil {
+		t.Fatalf("Decrypt failed: %v", err)
+	}
+	if plaintext != "Bearer token-123" {
+		t.Errorf("Expected %q, got %q", "Bearer token-123", plaintext)
+	}
+}
+
+func TestCipher_WithoutMasterKey(t *testing.T) {
+	c, err := NewCipher("")
+	if err != nil {
+		t.Fatalf("NewCipher failed: %v", err)
+	}
+	if _, err := c.Encrypt("value"); err != ErrMasterKeyNotConfigured {
+		t.Errorf("Expected ErrMasterKeyNotConfigured, got %v", err)
+	}
+}
+
+func TestResolver_NilResolverRejectsReferences(t *testing.T) {
+	var r *Resolver
+	headers, err := r.ResolveHeaders(context.Background(), primitive.NilObjectID, map[string]string{"X-Static": "value"})
+	if err != nil || headers["X-Static"] != "value" {
+		t.Fatalf("Expected static headers to pass through, got %v, %v", headers, err)
+	}
+
+	if _, err := r.ResolveHeaders(context.Background(), primitive.NilObjectID, map[string]string{"Authorization": "Bearer {{secret:API_TOKEN}}"}); !errors.Is(err, ErrResolverNotConfigured) {
+		t.Errorf("Expected ErrResolverNotConfigured, got %v", err)
+	}
+}
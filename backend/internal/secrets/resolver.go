@@ -0,0 +1,155 @@
+package secrets
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+
+	"github.com/yourusername/cron-observer/backend/internal/models"
+	"github.com/yourusername/cron-observer/backend/internal/repositories"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// referencePattern matches secret references such as {{secret:API_TOKEN}}
+var referencePattern = regexp.MustCompile(`\{\{\s*secret:([A-Za-z_][A-Za-z0-9_]*)\s*\}\}`)
+
+// ErrResolverNotConfigured is returned when a value references a secret but no resolver is configured
+var ErrResolverNotConfigured = errors.New("secret references found but secrets are not configured")
+
+// Resolver replaces secret references with decrypted values at dispatch time.
+// A nil *Resolver passes through values without references and rejects values with references.
+type Resolver struct {
+	repo   repositories.Repository
+	cipher *Cipher
+}
+
+// NewResolver creates a resolver that loads secrets from the repository
+func NewResolver(repo repositories.Repository, cipher *Cipher) *Resolver {
+	return &Resolver{
+		repo:   repo,
+		cipher: cipher,
+	}
+}
+
+// HasReferences reports whether s contains at least one secret reference
+func HasReferences(s string) bool {
+	return referencePattern.MatchString(s)
+}
+
+// ResolveHeaders returns a copy of headers with every secret reference replaced
+func (r *Resolver) ResolveHeaders(ctx context.Context, projectID primitive.ObjectID, headers map[string]string) (map[string]string, error) {
+	cache := make(map[string]string)
+	resolved := make(map[string]string, len(headers))
+	for name, value := range headers {
+		v, err := r.resolveString(ctx, projectID, value, cache)
+		if err != nil {
+			return nil, fmt.Errorf("header %s: %w", name, err)
+		}
+		resolved[name] = v
+	}
+	return resolved, nil
+}
+
+// ResolveValue walks a JSON-like value (strings, maps, slices) and replaces secret references in strings
+func (r *Resolver) ResolveValue(ctx context.Context, projectID primitive.ObjectID, value interface{}) (interface{}, error) {
+	return r.resolveValue(ctx, projectID, value, make(map[string]string))
+}
+
+func (r *Resolver) resolveValue(ctx context.Context, projectID primitive.ObjectID, value interface{}, cache map[string]string) (interface{}, error) {
+	switch v := value.(type) {
+	case string:
+		return r.resolveString(ctx, projectID, v, cache)
+	case map[string]interface{}:
+		resolved := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			resolvedItem, err := r.resolveValue(ctx, projectID, item, cache)
+			if err != nil {
+				return nil, err
+			}
+			resolved[key] = resolvedItem
+		}
+		return resolved, nil
+	case primitive.M:
+		return r.resolveValue(ctx, projectID, map[string]interface{}(v), cache)
+	case []interface{}:
+		resolved := make([]interface{}, len(v))
+		for i, item := range v {
+			resolvedItem, err := r.resolveValue(ctx, projectID, item, cache)
+			if err != nil {
+				return nil, err
+			}
+			resolved[i] = resolvedItem
+		}
+		return resolved, nil
+	case primitive.A:
+		return r.resolveValue(ctx, projectID, []interface{}(v), cache)
+	default:
+		return value, nil
+	}
+}
+
+// resolveString replaces all references in s, decrypting each secret at most once per call
+func (r *Resolver) resolveString(ctx context.Context, projectID primitive.ObjectID, s string, cache map[string]string) (string, error) {
+	if !HasReferences(s) {
+		return s, nil
+	}
+	if r == nil {
+		return "", ErrResolverNotConfigured
+	}
+
+	var resolveErr error
+	resolved := referencePattern.ReplaceAllStringFunc(s, func(match string) string {
+		if resolveErr != nil {
+			return match
+		}
+
+		name := referencePattern.FindStringSubmatch(match)[1]
+		if value, ok := cache[name]; ok {
+			return value
+		}
+
+		value, err := r.lookup(ctx, projectID, name)
+		if err != nil {
+			resolveErr = err
+			return match
+		}
+		cache[name] = value
+		return value
+	})
+
+	if resolveErr != nil {
+		return "", resolveErr
+	}
+	return resolved, nil
+}
+
+// lookup loads and decrypts a single project secret
+func (r *Resolver) lookup(ctx context.Context, projectID primitive.ObjectID, name string) (string, error) {
+	secret, err := r.repo.GetSecretByName(ctx, projectID, name)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return "", fmt.Errorf("secret %s not found", name)
+		}
+		return "", fmt.Errorf("failed to load secret %s: %w", name, err)
+	}
+
+	return r.cipher.Decrypt(encryptedValueOf(secret))
+}
+
+// encryptedValueOf extracts the envelope from a stored secret
+func encryptedValueOf(secret *models.Secret) *EncryptedValue {
+	return &EncryptedValue{
+		Ciphertext:       secret.Ciphertext,
+		EncryptedDataKey: secret.EncryptedDataKey,
+	}
+}
+
+// namePattern restricts secret names to identifiers usable inside a reference
+var namePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// IsValidName reports whether name can be used as a secret name
+func IsValidName(name string) bool {
+	return len(name) <= 128 && namePattern.MatchString(name)
+}
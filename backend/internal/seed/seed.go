@@ -0,0 +1,432 @@
+// Package seed fills a database with a demo project: task groups, tasks on a mix of schedules and a
+// history of synthetic executions, including failures, so the UI has realistic data to show.
+package seed
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/yourusername/cron-observer/backend/internal/cronexpr"
+	"github.com/yourusername/cron-observer/backend/internal/models"
+	"github.com/yourusername/cron-observer/backend/internal/repositories"
+	"github.com/yourusername/cron-observer/backend/internal/utils"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// DefaultProjectName is the name of the demo project unless Options.ProjectName is set
+const DefaultProjectName = "Demo Shop"
+
+// maxExecutionsPerTask bounds the history of frequent tasks; the most recent firings are kept
+const maxExecutionsPerTask = 150
+
+// ErrProjectExists is returned when a project with the demo project's name already exists
+var ErrProjectExists = errors.New("project already exists")
+
+// Options configures the demo data
+type Options struct {
+	ProjectName       string    // Defaults to DefaultProjectName
+	OwnerEmail        string    // Added as project admin so the project shows up for that user; optional
+	ExecutionEndpoint string    // Optional; the scheduler cannot dispatch the demo tasks without one
+	Days              int       // Days of execution history, ending at Now
+	Now               time.Time // Defaults to time.Now()
+	RandomSeed        int64     // Same seed, same history
+}
+
+// Result describes what Demo created
+type Result struct {
+	Project    *models.Project
+	TaskGroups int
+	Tasks      int
+	Executions int
+	Failures   int
+}
+
+// demoGroup is a task group of the demo project
+type demoGroup struct {
+	name, description  string
+	startTime, endTime string
+	timezone           string
+	status             models.TaskGroupStatus
+	tasks              []demoTask
+}
+
+// demoTask is a task of the demo project and the shape of its execution history
+type demoTask struct {
+	name, description string
+	schedule          models.ScheduleConfig
+	scheduleType      models.ScheduleType
+	status            models.TaskStatus
+	priority          int
+	tags              []string
+	history           string        // Cron expression the history follows when the schedule has none
+	duration          time.Duration // Typical run time; actual runs vary by ±50%
+	failureRate       float64
+	failures          []string // Error messages of failed runs
+}
+
+var demoGroups = []demoGroup{
+	{
+		name:        "Nightly maintenance",
+		description: "Backups and housekeeping while traffic is low",
+		startTime:   "01:00",
+		endTime:     "05:00",
+		timezone:    "UTC",
+		tasks: []demoTask{
+			{
+				name:        "Database backup",
+				description: "Full dump of the orders database to object storage",
+				schedule:    models.ScheduleConfig{CronExpression: "0 0 2 * * *", Timezone: "UTC"},
+				priority:    50,
+				tags:        []string{"team:platform", "criticality:high"},
+				duration:    4 * time.Minute,
+				failureRate: 0.05,
+				failures:    []string{"upload failed: connection reset by peer", "pg_dump: lock timeout on table orders"},
+			},
+			{
+				name:        "Rotate logs",
+				schedule:    models.ScheduleConfig{CronExpression: "0 30 3 * * *", Timezone: "UTC"},
+				tags:        []string{"team:platform"},
+				duration:    20 * time.Second,
+				failureRate: 0.01,
+				failures:    []string{"disk full"},
+			},
+			{
+				name:        "Vacuum analytics tables",
+				description: "Weekly VACUUM ANALYZE of the reporting warehouse",
+				schedule:    models.ScheduleConfig{Schedule: "every sunday at 4am", Timezone: "UTC"},
+				tags:        []string{"team:data"},
+				duration:    25 * time.Minute,
+				failureRate: 0.2,
+				failures:    []string{"canceling statement due to statement timeout"},
+			},
+		},
+	},
+	{
+		name:        "Business hours",
+		description: "Integrations that follow the New York office",
+		startTime:   "09:00",
+		endTime:     "17:00",
+		timezone:    "America/New_York",
+		tasks: []demoTask{
+			{
+				name:        "Sync CRM contacts",
+				description: "Pulls changed contacts from the CRM",
+				schedule: models.ScheduleConfig{
+					Timezone:   "America/New_York",
+					TimeRange:  &models.TimeRange{Start: "09:00", End: "17:00", Frequency: &models.Frequency{Value: 30, Unit: models.FrequencyUnitMinute}},
+					DaysOfWeek: []int{1, 2, 3, 4, 5},
+				},
+				history:     "CRON_TZ=America/New_York 0 */30 9-16 * * 1-5",
+				tags:        []string{"team:sales"},
+				duration:    45 * time.Second,
+				failureRate: 0.3,
+				failures:    []string{"CRM API returned 429 Too Many Requests", "CRM API returned 503 Service Unavailable", "context deadline exceeded"},
+			},
+			{
+				name:        "Send reminder emails",
+				schedule:    models.ScheduleConfig{Schedule: "weekdays at 10am", Timezone: "America/New_York"},
+				priority:    10,
+				tags:        []string{"team:growth"},
+				duration:    90 * time.Second,
+				failureRate: 0.05,
+				failures:    []string{"SMTP 421: too many connections"},
+			},
+		},
+	},
+	{
+		name:        "Reporting",
+		description: "Paused while the new dashboards are built",
+		status:      models.TaskGroupStatusDisabled,
+		tasks: []demoTask{
+			{
+				name:        "Monthly revenue report",
+				schedule:    models.ScheduleConfig{Schedule: "every month on the 1st at 6am", Timezone: "UTC"},
+				status:      models.TaskStatusDisabled,
+				tags:        []string{"team:finance"},
+				duration:    3 * time.Minute,
+				failureRate: 0.1,
+				failures:    []string{"report template not found"},
+			},
+		},
+	},
+}
+
+var demoUngroupedTasks = []demoTask{
+	{
+		name:        "Refresh exchange rates",
+		schedule:    models.ScheduleConfig{Schedule: "every hour", Timezone: "UTC"},
+		priority:    20,
+		tags:        []string{"team:payments"},
+		duration:    5 * time.Second,
+		failureRate: 0.02,
+		failures:    []string{"rates provider returned stale data"},
+	},
+	{
+		name:         "Migrate legacy invoices",
+		description:  "One-off backfill of invoices from the old billing system",
+		scheduleType: models.ScheduleTypeOneOff,
+		schedule:     models.ScheduleConfig{CronExpression: "0 0 12 * * *", Timezone: "UTC"},
+		status:       models.TaskStatusDisabled,
+		tags:         []string{"team:payments"},
+		duration:     10 * time.Minute,
+	},
+}
+
+// Demo creates the demo project with its task groups, tasks and execution history
+func Demo(ctx context.Context, repo repositories.Repository, opts Options) (*Result, error) {
+	if opts.ProjectName == "" {
+		opts.ProjectName = DefaultProjectName
+	}
+	if opts.Now.IsZero() {
+		opts.Now = time.Now()
+	}
+	opts.Now = opts.Now.UTC()
+	if opts.Days < 0 {
+		return nil, fmt.Errorf("days must not be negative, got %d", opts.Days)
+	}
+
+	if existing, err := repo.GetProjectByName(ctx, opts.ProjectName); err == nil && existing != nil {
+		return nil, fmt.Errorf("%w: %q (%s)", ErrProjectExists, opts.ProjectName, existing.ID.Hex())
+	}
+
+	s := &seeder{repo: repo, opts: opts, rng: rand.New(rand.NewSource(opts.RandomSeed)), failureDates: make(map[string]bool)}
+	if err := s.createProject(ctx); err != nil {
+		return nil, err
+	}
+	for _, group := range demoGroups {
+		if err := s.createGroup(ctx, group); err != nil {
+			return nil, err
+		}
+	}
+	for _, task := range demoUngroupedTasks {
+		if err := s.createTask(ctx, task, nil); err != nil {
+			return nil, err
+		}
+	}
+	if err := s.storeFailureStats(ctx); err != nil {
+		return nil, err
+	}
+	return &s.result, nil
+}
+
+// seeder carries the state of one Demo run
+type seeder struct {
+	repo         repositories.Repository
+	opts         Options
+	rng          *rand.Rand
+	result       Result
+	failureDates map[string]bool
+}
+
+func (s *seeder) createProject(ctx context.Context) error {
+	now := s.opts.Now
+	project := &models.Project{
+		ID:                primitive.NewObjectID(),
+		UUID:              uuid.New().String(),
+		Name:              s.opts.ProjectName,
+		Description:       "Demo data created by `admin seed`",
+		APIKey:            utils.GenerateAPIKey(),
+		ExecutionEndpoint: s.opts.ExecutionEndpoint,
+		CreatedAt:         now.AddDate(0, 0, -s.opts.Days),
+		UpdatedAt:         now,
+	}
+	if s.opts.OwnerEmail != "" {
+		project.ProjectUsers = []models.ProjectUser{{Email: s.opts.OwnerEmail, Role: models.ProjectUserRoleAdmin}}
+	}
+	if err := s.repo.CreateProject(ctx, project); err != nil {
+		return fmt.Errorf("create project: %w", err)
+	}
+	s.result.Project = project
+	return nil
+}
+
+func (s *seeder) createGroup(ctx context.Context, spec demoGroup) error {
+	status := spec.status
+	if status == "" {
+		status = models.TaskGroupStatusActive
+	}
+	group := &models.TaskGroup{
+		ID:          primitive.NewObjectID(),
+		UUID:        uuid.New().String(),
+		ProjectID:   s.result.Project.ID,
+		Name:        spec.name,
+		Description: spec.description,
+		Status:      status,
+		State:       models.TaskGroupStateNotRunning,
+		StartTime:   spec.startTime,
+		EndTime:     spec.endTime,
+		Timezone:    spec.timezone,
+		CreatedAt:   s.result.Project.CreatedAt,
+		UpdatedAt:   s.result.Project.CreatedAt,
+	}
+	if err := s.repo.CreateTaskGroup(ctx, s.result.Project.ID.Hex(), group); err != nil {
+		return fmt.Errorf("create task group %q: %w", spec.name, err)
+	}
+	s.result.TaskGroups++
+
+	for _, task := range spec.tasks {
+		if err := s.createTask(ctx, task, &group.ID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *seeder) createTask(ctx context.Context, spec demoTask, groupID *primitive.ObjectID) error {
+	scheduleType, status := spec.scheduleType, spec.status
+	if scheduleType == "" {
+		scheduleType = models.ScheduleTypeRecurring
+	}
+	if status == "" {
+		status = models.TaskStatusActive
+	}
+	scheduleConfig := spec.schedule
+	if err := scheduleConfig.ResolveSchedule(); err != nil {
+		return fmt.Errorf("task %q: %w", spec.name, err)
+	}
+
+	task := &models.Task{
+		ID:             primitive.NewObjectID(),
+		UUID:           uuid.New().String(),
+		ProjectID:      s.result.Project.ID,
+		TaskGroupID:    groupID,
+		Name:           spec.name,
+		Description:    spec.description,
+		ScheduleType:   scheduleType,
+		Status:         status,
+		State:          models.TaskStateNotRunning,
+		ScheduleConfig: scheduleConfig,
+		Priority:       spec.priority,
+		Tags:           spec.tags,
+		CreatedAt:      s.result.Project.CreatedAt,
+		UpdatedAt:      s.result.Project.CreatedAt,
+	}
+	if err := s.repo.CreateTask(ctx, s.result.Project.ID.Hex(), task); err != nil {
+		return fmt.Errorf("create task %q: %w", spec.name, err)
+	}
+	s.result.Tasks++
+
+	expression := scheduleConfig.CronExpression
+	if expression == "" {
+		expression = spec.history
+	}
+	schedule, err := cronexpr.Parse(cronexpr.WithTimezone(expression, scheduleConfig.Timezone))
+	if err != nil {
+		return fmt.Errorf("task %q: %w", spec.name, err)
+	}
+
+	if scheduleType == models.ScheduleTypeRecurring && status == models.TaskStatusActive {
+		if next := schedule.Next(s.opts.Now); !next.IsZero() {
+			if err := s.repo.SetTaskNextRunAt(ctx, task.UUID, &next); err != nil {
+				return fmt.Errorf("task %q: %w", spec.name, err)
+			}
+		}
+	}
+	return s.createHistory(ctx, task, spec, s.fireTimes(schedule, scheduleType))
+}
+
+// fireTimes returns the firings of the schedule within the history window, oldest first.
+// A one-off task fired once, on its first firing.
+func (s *seeder) fireTimes(schedule interface{ Next(time.Time) time.Time }, scheduleType models.ScheduleType) []time.Time {
+	var times []time.Time
+	for next := schedule.Next(s.result.Project.CreatedAt); !next.IsZero() && next.Before(s.opts.Now); next = schedule.Next(next) {
+		times = append(times, next)
+		if scheduleType == models.ScheduleTypeOneOff {
+			break
+		}
+	}
+	if len(times) > maxExecutionsPerTask {
+		times = times[len(times)-maxExecutionsPerTask:]
+	}
+	return times
+}
+
+func (s *seeder) createHistory(ctx context.Context, task *models.Task, spec demoTask, fireTimes []time.Time) error {
+	var lastFailure *time.Time
+	for _, firedAt := range fireTimes {
+		execution := s.execution(task, spec, firedAt)
+		if err := s.repo.CreateExecution(ctx, execution); err != nil {
+			return fmt.Errorf("create execution of %q: %w", spec.name, err)
+		}
+		s.result.Executions++
+
+		if execution.Status != models.ExecutionStatusFailed {
+			continue
+		}
+		s.result.Failures++
+		date := execution.FailureStatDate()
+		s.failureDates[date] = true
+		if err := s.repo.IncrementFailureStat(ctx, s.result.Project.ID, date); err != nil {
+			return fmt.Errorf("count failure of %q: %w", spec.name, err)
+		}
+		lastFailure = execution.EndedAt
+	}
+
+	if lastFailure != nil {
+		if err := s.repo.UpdateTaskLastFailureAt(ctx, task.UUID, *lastFailure); err != nil {
+			return fmt.Errorf("task %q: %w", spec.name, err)
+		}
+	}
+	return nil
+}
+
+// execution returns a finished execution of the task fired at firedAt, failed with the task's failure rate
+func (s *seeder) execution(task *models.Task, spec demoTask, firedAt time.Time) *models.Execution {
+	startedAt := firedAt.Add(time.Duration(s.rng.Intn(3000)) * time.Millisecond)
+	duration := time.Duration(float64(spec.duration) * (0.5 + s.rng.Float64()))
+	endedAt := startedAt.Add(duration)
+
+	execution := &models.Execution{
+		UUID:      uuid.New().String(),
+		TaskID:    task.ID,
+		TaskUUID:  task.UUID,
+		Status:    models.ExecutionStatusSuccess,
+		StartedAt: startedAt,
+		EndedAt:   &endedAt,
+		Source:    models.ExecutionSourceScheduler,
+		CreatedAt: firedAt,
+		UpdatedAt: endedAt,
+		Logs: []models.LogEntry{
+			{Message: fmt.Sprintf("Starting %s", strings.ToLower(task.Name)), Level: "info", Timestamp: startedAt},
+		},
+	}
+
+	if len(spec.failures) > 0 && s.rng.Float64() < spec.failureRate {
+		execution.Status = models.ExecutionStatusFailed
+		execution.Error = spec.failures[s.rng.Intn(len(spec.failures))]
+		if s.rng.Intn(2) == 0 {
+			execution.Logs = append(execution.Logs, models.LogEntry{Message: "Retrying after a transient error", Level: "warn", Timestamp: startedAt.Add(duration / 2)})
+		}
+		execution.Logs = append(execution.Logs, models.LogEntry{Message: execution.Error, Level: "error", Timestamp: endedAt})
+		return execution
+	}
+
+	execution.Logs = append(execution.Logs, models.LogEntry{Message: fmt.Sprintf("Finished in %s", duration.Round(time.Second)), Level: "info", Timestamp: endedAt})
+	return execution
+}
+
+// storeFailureStats stores the per-task failure breakdown of every day with failures, as the failure stats cron would
+func (s *seeder) storeFailureStats(ctx context.Context) error {
+	dates := make([]string, 0, len(s.failureDates))
+	for date := range s.failureDates {
+		dates = append(dates, date)
+	}
+	sort.Strings(dates)
+
+	for _, date := range dates {
+		stats, err := s.repo.CalculateTaskFailureStats(ctx, s.result.Project.ID, date)
+		if err != nil {
+			return fmt.Errorf("calculate failure stats for %s: %w", date, err)
+		}
+		if err := s.repo.StoreTaskFailureStats(ctx, stats); err != nil {
+			return fmt.Errorf("store failure stats for %s: %w", date, err)
+		}
+	}
+	return nil
+}
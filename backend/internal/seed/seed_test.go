@@ -0,0 +1,89 @@
+package seed
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/yourusername/cron-observer/backend/internal/models"
+	"github.com/yourusername/cron-observer/backend/internal/repositories"
+)
+
+// now is recent so the history falls within the failure stats window
+var now = time.Now().UTC().Truncate(time.Hour)
+
+func TestDemo_CreatesProjectWithHistory(t *testing.T) {
+	ctx := context.Background()
+	repo := repositories.NewMemoryRepository()
+
+	result, err := Demo(ctx, repo, Options{OwnerEmail: "dev@example.com", Days: 7, Now: now})
+	if err != nil {
+		t.Fatalf("Demo: %v", err)
+	}
+	if result.TaskGroups != 3 || result.Tasks != 8 {
+		t.Fatalf("created %d groups and %d tasks, want 3 and 8", result.TaskGroups, result.Tasks)
+	}
+	if result.Executions == 0 || result.Failures == 0 || result.Failures >= result.Executions {
+		t.Fatalf("created %d executions with %d failures, want some of both", result.Executions, result.Failures)
+	}
+
+	projects, _ := repo.GetUserProjects(ctx, "dev@example.com")
+	if len(projects) != 1 || projects[0].Name != DefaultProjectName {
+		t.Fatalf("projects of the owner = %+v", projects)
+	}
+
+	tasks, err := repo.GetTasksByProjectID(ctx, result.Project.ID)
+	if err != nil {
+		t.Fatalf("GetTasksByProjectID: %v", err)
+	}
+	executions := 0
+	for _, task := range tasks {
+		history, _ := repo.GetExecutionsByTaskUUID(ctx, task.UUID, nil, nil)
+		executions += len(history)
+		if len(history) > maxExecutionsPerTask {
+			t.Errorf("task %q has %d executions, want at most %d", task.Name, len(history), maxExecutionsPerTask)
+		}
+		for _, execution := range history {
+			if execution.StartedAt.After(now) || execution.StartedAt.Before(now.AddDate(0, 0, -7)) {
+				t.Errorf("execution of %q started at %s, outside the history window", task.Name, execution.StartedAt)
+			}
+		}
+		if task.ScheduleType == models.ScheduleTypeOneOff && len(history) != 1 {
+			t.Errorf("one-off task %q has %d executions, want 1", task.Name, len(history))
+		}
+	}
+	if executions != result.Executions {
+		t.Fatalf("found %d executions, result reports %d", executions, result.Executions)
+	}
+
+	_, total, err := repo.GetFailureStatsByProject(ctx, result.Project.ID, 30)
+	if err != nil || total != result.Failures {
+		t.Fatalf("failure stats total = %d, %v, want %d", total, err, result.Failures)
+	}
+}
+
+func TestDemo_SameSeedSameHistory(t *testing.T) {
+	first, err := Demo(context.Background(), repositories.NewMemoryRepository(), Options{Days: 7, Now: now, RandomSeed: 42})
+	if err != nil {
+		t.Fatalf("Demo: %v", err)
+	}
+	second, err := Demo(context.Background(), repositories.NewMemoryRepository(), Options{Days: 7, Now: now, RandomSeed: 42})
+	if err != nil {
+		t.Fatalf("Demo: %v", err)
+	}
+	if first.Executions != second.Executions || first.Failures != second.Failures {
+		t.Fatalf("seed 42 gave %d/%d and then %d/%d executions/failures", first.Executions, first.Failures, second.Executions, second.Failures)
+	}
+}
+
+func TestDemo_RefusesExistingProject(t *testing.T) {
+	ctx := context.Background()
+	repo := repositories.NewMemoryRepository()
+	if _, err := Demo(ctx, repo, Options{Days: 1, Now: now}); err != nil {
+		t.Fatalf("Demo: %v", err)
+	}
+	if _, err := Demo(ctx, repo, Options{Days: 1, Now: now}); !errors.Is(err, ErrProjectExists) {
+		t.Fatalf("second Demo error = %v, want ErrProjectExists", err)
+	}
+}
@@ -0,0 +1,189 @@
+// Package shutdown stops the components of the server in a fixed order on SIGTERM, so work that has started is
+// finished before the connections it writes through are closed.
+package shutdown
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// Phase is a step of the shutdown. Phases run in order; the steps of one phase run concurrently.
+type Phase int
+
+const (
+	// PhaseStopIntake stops accepting requests: HTTP and gRPC servers
+	PhaseStopIntake Phase = iota
+	// PhaseStopFiring stops producing new work: cron firing, reconcilers. Background workers started with Go are
+	// told to stop after this phase.
+	PhaseStopFiring
+	// PhaseDrain waits for the work in progress: dispatches, queue messages, background workers started with Go
+	PhaseDrain
+	// PhaseClose closes connections: MongoDB, RabbitMQ, Redis. Runs even when draining timed out.
+	PhaseClose
+)
+
+var phaseNames = map[Phase]string{
+	PhaseStopIntake: "stop intake",
+	PhaseStopFiring: "stop firing",
+	PhaseDrain:      "drain",
+	PhaseClose:      "close",
+}
+
+func (p Phase) String() string {
+	return phaseNames[p]
+}
+
+// closeTimeout bounds the close phase, which gets its own deadline so connections are closed even after a drain
+// used up the shutdown timeout
+const closeTimeout = 10 * time.Second
+
+type step struct {
+	name string
+	fn   func(ctx context.Context) error
+}
+
+// Coordinator runs the shutdown phases. Register the steps while the server starts, then call Run or Shutdown.
+type Coordinator struct {
+	timeout time.Duration
+
+	mu      sync.Mutex
+	steps   map[Phase][]step
+	workers sync.WaitGroup
+	stop    context.CancelFunc // cancels the context of the workers started with Go
+	ctx     context.Context
+	once    sync.Once
+	err     error
+}
+
+// New creates a coordinator. timeout bounds the stop and drain phases together; work still running after it is
+// abandoned and the connections are closed anyway.
+func New(timeout time.Duration) *Coordinator {
+	ctx, stop := context.WithCancel(context.Background())
+	return &Coordinator{
+		timeout: timeout,
+		steps:   make(map[Phase][]step),
+		ctx:     ctx,
+		stop:    stop,
+	}
+}
+
+// Register adds a step to a phase. fn should return once its work is done or ctx is done.
+func (c *Coordinator) Register(phase Phase, name string, fn func(ctx context.Context) error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.steps[phase] = append(c.steps[phase], step{name: name, fn: fn})
+}
+
+// Go runs a background worker, such as a queue consumer, until shutdown. Its context is cancelled after the stop
+// firing phase and the drain phase waits for it to return, so the worker must finish the job in progress and then
+// return. A worker that returns early with an error other than context.Canceled is logged.
+func (c *Coordinator) Go(name string, run func(ctx context.Context) error) {
+	c.workers.Add(1)
+	go func() {
+		defer c.workers.Done()
+		if err := run(c.ctx); err != nil && !errors.Is(err, context.Canceled) {
+			log.Printf("[shutdown] %s stopped: %v", name, err)
+		}
+	}()
+}
+
+// Run blocks until SIGINT or SIGTERM, or until ctx is done, and then shuts down.
+func (c *Coordinator) Run(ctx context.Context) error {
+	signalCtx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	<-signalCtx.Done()
+	log.Printf("[shutdown] Shutting down (timeout %s)", c.timeout)
+	return c.Shutdown()
+}
+
+// Shutdown runs the phases in order and returns the errors of the steps that failed or timed out. Only the first
+// call shuts down; later calls return its result.
+func (c *Coordinator) Shutdown() error {
+	c.once.Do(func() {
+		c.err = c.shutdown()
+	})
+	return c.err
+}
+
+func (c *Coordinator) shutdown() error {
+	started := time.Now()
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	var errs []error
+	errs = append(errs, c.runPhase(ctx, PhaseStopIntake)...)
+	errs = append(errs, c.runPhase(ctx, PhaseStopFiring)...)
+
+	c.stop()
+	c.Register(PhaseDrain, "background workers", func(ctx context.Context) error {
+		done := make(chan struct{})
+		go func() {
+			c.workers.Wait()
+			close(done)
+		}()
+		select {
+		case <-done:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	})
+	errs = append(errs, c.runPhase(ctx, PhaseDrain)...)
+
+	closeCtx, cancelClose := context.WithTimeout(context.Background(), closeTimeout)
+	defer cancelClose()
+	errs = append(errs, c.runPhase(closeCtx, PhaseClose)...)
+
+	if len(errs) > 0 {
+		log.Printf("[shutdown] Shut down with %d error(s) after %s", len(errs), time.Since(started).Round(time.Millisecond))
+		return errors.Join(errs...)
+	}
+	log.Printf("[shutdown] Shut down cleanly after %s", time.Since(started).Round(time.Millisecond))
+	return nil
+}
+
+// runPhase runs the steps of a phase concurrently and waits for all of them, or for ctx
+func (c *Coordinator) runPhase(ctx context.Context, phase Phase) []error {
+	c.mu.Lock()
+	steps := c.steps[phase]
+	c.mu.Unlock()
+	if len(steps) == 0 {
+		return nil
+	}
+
+	results := make(chan error, len(steps))
+	for _, s := range steps {
+		go func() {
+			if err := s.fn(ctx); err != nil {
+				results <- fmt.Errorf("%s: %s: %w", phase, s.name, err)
+				return
+			}
+			results <- nil
+		}()
+	}
+
+	var errs []error
+	for range steps {
+		select {
+		case err := <-results:
+			if err != nil {
+				log.Printf("[shutdown] %v", err)
+				errs = append(errs, err)
+			}
+		case <-ctx.Done():
+			// A step ignores ctx; leave it running rather than hang the shutdown
+			err := fmt.Errorf("%s: %w", phase, ctx.Err())
+			log.Printf("[shutdown] %v", err)
+			return append(errs, err)
+		}
+	}
+	log.Printf("[shutdown] Phase %q done", phase)
+	return errs
+}
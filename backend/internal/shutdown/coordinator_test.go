@@ -0,0 +1,115 @@
+package shutdown
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestCoordinator_RunsPhasesInOrder(t *testing.T) {
+	c := New(time.Second)
+
+	var mu sync.Mutex
+	var order []string
+	record := func(name string) func(ctx context.Context) error {
+		return func(ctx context.Context) error {
+			mu.Lock()
+			defer mu.Unlock()
+			order = append(order, name)
+			return nil
+		}
+	}
+	// Registered out of order on purpose
+	c.Register(PhaseClose, "mongo", record("close"))
+	c.Register(PhaseDrain, "scheduler", record("drain"))
+	c.Register(PhaseStopIntake, "http", record("stop intake"))
+	c.Register(PhaseStopFiring, "cron", record("stop firing"))
+
+	workerStopped := make(chan struct{})
+	c.Go("consumer", func(ctx context.Context) error {
+		<-ctx.Done()
+		mu.Lock()
+		order = append(order, "worker")
+		mu.Unlock()
+		close(workerStopped)
+		return ctx.Err()
+	})
+
+	if err := c.Shutdown(); err != nil {
+		t.Fatalf("Shutdown() error = %v", err)
+	}
+	<-workerStopped
+
+	want := []string{"stop intake", "stop firing", "drain", "close"}
+	var got []string
+	workerIndex := -1
+	for i, name := range order {
+		if name == "worker" {
+			workerIndex = i
+			continue
+		}
+		got = append(got, name)
+	}
+	if len(got) != len(want) {
+		t.Fatalf("phases = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("phases = %v, want %v", got, want)
+		}
+	}
+	// The worker is stopped after stop firing and before close
+	if workerIndex < 2 || order[len(order)-1] != "close" {
+		t.Errorf("worker stopped at %d in %v, want between stop firing and close", workerIndex, order)
+	}
+}
+
+func TestCoordinator_ClosesAfterDrainTimeout(t *testing.T) {
+	c := New(50 * time.Millisecond)
+
+	c.Register(PhaseDrain, "stuck dispatch", func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	c.Go("stuck consumer", func(ctx context.Context) error {
+		select {} // ignores ctx
+	})
+	closed := false
+	c.Register(PhaseClose, "mongo", func(ctx context.Context) error {
+		closed = true
+		return nil
+	})
+
+	started := time.Now()
+	err := c.Shutdown()
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Shutdown() error = %v, want deadline exceeded", err)
+	}
+	if !closed {
+		t.Error("close phase did not run after the drain timed out")
+	}
+	if elapsed := time.Since(started); elapsed > time.Second {
+		t.Errorf("Shutdown() took %v, want it bounded by the timeout", elapsed)
+	}
+}
+
+func TestCoordinator_ShutdownOnce(t *testing.T) {
+	c := New(time.Second)
+
+	calls := 0
+	c.Register(PhaseClose, "mongo", func(ctx context.Context) error {
+		calls++
+		return errors.New("already closed")
+	})
+
+	first := c.Shutdown()
+	second := c.Shutdown()
+	if first == nil || second != first {
+		t.Errorf("Shutdown() errors = %v, %v, want the same error twice", first, second)
+	}
+	if calls != 1 {
+		t.Errorf("close step ran %d times, want 1", calls)
+	}
+}
@@ -42,11 +42,23 @@ func formatValidationError(fieldError validator.FieldError) string {
 	case "objectid":
 		return field + " must be a valid MongoDB ObjectID"
 	case "cron":
-		return field + " must be a valid cron expression"
+		return field + " must be a valid cron expression or descriptor such as @daily or @every 10m"
 	case "timezone":
 		return field + " must be a valid timezone (e.g., America/New_York, UTC)"
 	case "time_format":
 		return field + " must be in HH:MM format (24-hour)"
+	case "env_name":
+		return field + " must be lowercase letters, digits, '-' or '_' (e.g., staging, prod-eu)"
+	case "task_tag":
+		return field + " must be lowercase letters, digits or . _ : / = - (e.g., team:payments, critical)"
+	case "template_param":
+		return field + " must start with a lowercase letter and contain only lowercase letters, digits or '_' (e.g., url, interval_minutes)"
+	case "env_var":
+		return field + " must contain variable names of letters, digits or '_' that do not start with a digit (e.g., CONFIG_SET)"
+	case "datetime":
+		return field + " must be a date in YYYY-MM-DD format"
+	case "e164":
+		return field + " must be a phone number in E.164 format (e.g., +4915112345678)"
 	case "dive":
 		return field + " contains invalid values"
 	default:
@@ -8,6 +8,7 @@ import (
 
 	"github.com/go-playground/validator/v10"
 	"github.com/google/uuid"
+	"github.com/yourusername/cron-observer/backend/internal/cronexpr"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
@@ -31,29 +32,16 @@ var validateObjectID validator.Func = func(fl validator.FieldLevel) bool {
 	return err == nil
 }
 
-// validateCron checks if the string is a valid cron expression
+// validateCron checks if the string parses with the scheduler's cron parser: 5 fields (minute hour day month weekday),
+// 6 fields (second minute hour day month weekday) or a descriptor such as @daily or @every 10m
 var validateCron validator.Func = func(fl validator.FieldLevel) bool {
 	cronStr := fl.Field().String()
 	if cronStr == "" {
 		return true // Let required tag handle empty values
 	}
 
-	// Basic cron expression validation: 5 fields (minute hour day month weekday)
-	// or 6 fields (second minute hour day month weekday)
-	parts := strings.Fields(cronStr)
-	if len(parts) != 5 && len(parts) != 6 {
-		return false
-	}
-
-	// Validate each part contains valid cron characters
-	cronPattern := regexp.MustCompile(`^[\d\*\-\,\/]+$`)
-	for _, part := range parts {
-		if !cronPattern.MatchString(part) {
-			return false
-		}
-	}
-
-	return true
+	_, err := cronexpr.Parse(cronStr)
+	return err == nil
 }
 
 // validateTimezone checks if the string is a valid timezone
@@ -112,6 +100,53 @@ var validateHTTPMethod validator.Func = func(fl validator.FieldLevel) bool {
 	return validMethods[method]
 }
 
+// envNamePattern matches environment names such as "staging", "prod" or "prod-eu"
+var envNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]{0,63}$`)
+
+// validateEnvName checks if the string is a valid project environment name
+var validateEnvName validator.Func = func(fl validator.FieldLevel) bool {
+	name := fl.Field().String()
+	if name == "" {
+		return true // Let required tag handle empty values
+	}
+	return envNamePattern.MatchString(name)
+}
+
+var taskTagPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9._:/=-]{0,62}$`)
+
+// validateTaskTag checks if the string is a valid task tag (e.g. team:payments, critical)
+var validateTaskTag validator.Func = func(fl validator.FieldLevel) bool {
+	tag := fl.Field().String()
+	if tag == "" {
+		return true // Let required tag handle empty values
+	}
+	return taskTagPattern.MatchString(tag)
+}
+
+// templateParamPattern matches template parameter names such as "url" or "interval_minutes"
+var templateParamPattern = regexp.MustCompile(`^[a-z][a-z0-9_]{0,63}$`)
+
+// validateTemplateParam checks if the string is a valid task template parameter name
+var validateTemplateParam validator.Func = func(fl validator.FieldLevel) bool {
+	name := fl.Field().String()
+	if name == "" {
+		return true // Let required tag handle empty values
+	}
+	return templateParamPattern.MatchString(name)
+}
+
+// envVarPattern matches task environment variable names such as "CONFIG_SET" or "region"
+var envVarPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]{0,127}$`)
+
+// validateEnvVar checks if the string is a valid task environment variable name
+var validateEnvVar validator.Func = func(fl validator.FieldLevel) bool {
+	name := fl.Field().String()
+	if name == "" {
+		return true // Let required tag handle empty values
+	}
+	return envVarPattern.MatchString(name)
+}
+
 // RegisterCustomValidators registers all custom validators with the validator instance
 func RegisterCustomValidators(v *validator.Validate) error {
 	if err := v.RegisterValidation("uuid", validateUUID); err != nil {
@@ -135,5 +170,17 @@ func RegisterCustomValidators(v *validator.Validate) error {
 	if err := v.RegisterValidation("http_method", validateHTTPMethod); err != nil {
 		return err
 	}
+	if err := v.RegisterValidation("env_name", validateEnvName); err != nil {
+		return err
+	}
+	if err := v.RegisterValidation("task_tag", validateTaskTag); err != nil {
+		return err
+	}
+	if err := v.RegisterValidation("template_param", validateTemplateParam); err != nil {
+		return err
+	}
+	if err := v.RegisterValidation("env_var", validateEnvVar); err != nil {
+		return err
+	}
 	return nil
 }
@@ -1,20 +1,27 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: github.com/yourusername/cron-observer/backend/internal/deletequeue
+// Source: github.com/yourusername/cron-observer/backend/internal/deletequeue (interfaces: DeleteJobPublisher)
+//
+// Generated by this command:
+//
+//	mockgen -destination=mocks/mock_deletequeue.go -package=mocks github.com/yourusername/cron-observer/backend/internal/deletequeue DeleteJobPublisher
+//
 
+// Package mocks is a generated GoMock package.
 package mocks
 
 import (
-	"context"
-	"reflect"
+	context "context"
+	reflect "reflect"
 
-	"github.com/yourusername/cron-observer/backend/internal/deletequeue"
-	"go.uber.org/mock/gomock"
+	deletequeue "github.com/yourusername/cron-observer/backend/internal/deletequeue"
+	gomock "go.uber.org/mock/gomock"
 )
 
 // MockDeleteJobPublisher is a mock of DeleteJobPublisher interface.
 type MockDeleteJobPublisher struct {
 	ctrl     *gomock.Controller
 	recorder *MockDeleteJobPublisherMockRecorder
+	isgomock struct{}
 }
 
 // MockDeleteJobPublisherMockRecorder is the mock recorder for MockDeleteJobPublisher.
@@ -34,6 +41,20 @@ func (m *MockDeleteJobPublisher) EXPECT() *MockDeleteJobPublisherMockRecorder {
 	return m.recorder
 }
 
+// PublishDeleteProject mocks base method.
+func (m *MockDeleteJobPublisher) PublishDeleteProject(ctx context.Context, msg deletequeue.DeleteProjectMessage) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PublishDeleteProject", ctx, msg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PublishDeleteProject indicates an expected call of PublishDeleteProject.
+func (mr *MockDeleteJobPublisherMockRecorder) PublishDeleteProject(ctx, msg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PublishDeleteProject", reflect.TypeOf((*MockDeleteJobPublisher)(nil).PublishDeleteProject), ctx, msg)
+}
+
 // PublishDeleteTask mocks base method.
 func (m *MockDeleteJobPublisher) PublishDeleteTask(ctx context.Context, msg deletequeue.DeleteTaskMessage) error {
 	m.ctrl.T.Helper()
@@ -43,7 +64,21 @@ func (m *MockDeleteJobPublisher) PublishDeleteTask(ctx context.Context, msg dele
 }
 
 // PublishDeleteTask indicates an expected call of PublishDeleteTask.
-func (mr *MockDeleteJobPublisherMockRecorder) PublishDeleteTask(ctx, msg interface{}) *gomock.Call {
+func (mr *MockDeleteJobPublisherMockRecorder) PublishDeleteTask(ctx, msg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PublishDeleteTask", reflect.TypeOf((*MockDeleteJobPublisher)(nil).PublishDeleteTask), ctx, msg)
 }
+
+// PublishDeleteTaskGroup mocks base method.
+func (m *MockDeleteJobPublisher) PublishDeleteTaskGroup(ctx context.Context, msg deletequeue.DeleteTaskGroupMessage) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PublishDeleteTaskGroup", ctx, msg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PublishDeleteTaskGroup indicates an expected call of PublishDeleteTaskGroup.
+func (mr *MockDeleteJobPublisherMockRecorder) PublishDeleteTaskGroup(ctx, msg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PublishDeleteTaskGroup", reflect.TypeOf((*MockDeleteJobPublisher)(nil).PublishDeleteTaskGroup), ctx, msg)
+}
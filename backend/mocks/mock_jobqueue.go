@@ -0,0 +1,72 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/jobqueue/deadletter.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/jobqueue/deadletter.go -destination=mocks/mock_jobqueue.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	models "github.com/yourusername/cron-observer/backend/internal/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockParkedJobs is a mock of ParkedJobs interface.
+type MockParkedJobs struct {
+	ctrl     *gomock.Controller
+	recorder *MockParkedJobsMockRecorder
+	isgomock struct{}
+}
+
+// MockParkedJobsMockRecorder is the mock recorder for MockParkedJobs.
+type MockParkedJobsMockRecorder struct {
+	mock *MockParkedJobs
+}
+
+// NewMockParkedJobs creates a new mock instance.
+func NewMockParkedJobs(ctrl *gomock.Controller) *MockParkedJobs {
+	mock := &MockParkedJobs{ctrl: ctrl}
+	mock.recorder = &MockParkedJobsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockParkedJobs) EXPECT() *MockParkedJobsMockRecorder {
+	return m.recorder
+}
+
+// ListParked mocks base method.
+func (m *MockParkedJobs) ListParked(ctx context.Context, limit int) ([]models.ParkedJob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListParked", ctx, limit)
+	ret0, _ := ret[0].([]models.ParkedJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListParked indicates an expected call of ListParked.
+func (mr *MockParkedJobsMockRecorder) ListParked(ctx, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListParked", reflect.TypeOf((*MockParkedJobs)(nil).ListParked), ctx, limit)
+}
+
+// ReplayParked mocks base method.
+func (m *MockParkedJobs) ReplayParked(ctx context.Context, ids []string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplayParked", ctx, ids)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReplayParked indicates an expected call of ReplayParked.
+func (mr *MockParkedJobsMockRecorder) ReplayParked(ctx, ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplayParked", reflect.TypeOf((*MockParkedJobs)(nil).ReplayParked), ctx, ids)
+}
@@ -43,6 +43,106 @@ func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
 	return m.recorder
 }
 
+// AcceptInvitation mocks base method.
+func (m *MockRepository) AcceptInvitation(ctx context.Context, invitationUUID, email string, at time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AcceptInvitation", ctx, invitationUUID, email, at)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AcceptInvitation indicates an expected call of AcceptInvitation.
+func (mr *MockRepositoryMockRecorder) AcceptInvitation(ctx, invitationUUID, email, at any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AcceptInvitation", reflect.TypeOf((*MockRepository)(nil).AcceptInvitation), ctx, invitationUUID, email, at)
+}
+
+// AcknowledgeIncident mocks base method.
+func (m *MockRepository) AcknowledgeIncident(ctx context.Context, projectID primitive.ObjectID, incidentUUID, acknowledgedBy string, acknowledgedAt time.Time) (*models.Incident, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AcknowledgeIncident", ctx, projectID, incidentUUID, acknowledgedBy, acknowledgedAt)
+	ret0, _ := ret[0].(*models.Incident)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AcknowledgeIncident indicates an expected call of AcknowledgeIncident.
+func (mr *MockRepositoryMockRecorder) AcknowledgeIncident(ctx, projectID, incidentUUID, acknowledgedBy, acknowledgedAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AcknowledgeIncident", reflect.TypeOf((*MockRepository)(nil).AcknowledgeIncident), ctx, projectID, incidentUUID, acknowledgedBy, acknowledgedAt)
+}
+
+// AddIncidentComment mocks base method.
+func (m *MockRepository) AddIncidentComment(ctx context.Context, projectID primitive.ObjectID, incidentUUID string, comment models.IncidentComment) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddIncidentComment", ctx, projectID, incidentUUID, comment)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddIncidentComment indicates an expected call of AddIncidentComment.
+func (mr *MockRepositoryMockRecorder) AddIncidentComment(ctx, projectID, incidentUUID, comment any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddIncidentComment", reflect.TypeOf((*MockRepository)(nil).AddIncidentComment), ctx, projectID, incidentUUID, comment)
+}
+
+// AddNotificationChannel mocks base method.
+func (m *MockRepository) AddNotificationChannel(ctx context.Context, projectID primitive.ObjectID, channel models.NotificationChannel) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddNotificationChannel", ctx, projectID, channel)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddNotificationChannel indicates an expected call of AddNotificationChannel.
+func (mr *MockRepositoryMockRecorder) AddNotificationChannel(ctx, projectID, channel any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddNotificationChannel", reflect.TypeOf((*MockRepository)(nil).AddNotificationChannel), ctx, projectID, channel)
+}
+
+// AddProjectEnvironment mocks base method.
+func (m *MockRepository) AddProjectEnvironment(ctx context.Context, projectID primitive.ObjectID, environment models.ProjectEnvironment) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddProjectEnvironment", ctx, projectID, environment)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddProjectEnvironment indicates an expected call of AddProjectEnvironment.
+func (mr *MockRepositoryMockRecorder) AddProjectEnvironment(ctx, projectID, environment any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddProjectEnvironment", reflect.TypeOf((*MockRepository)(nil).AddProjectEnvironment), ctx, projectID, environment)
+}
+
+// AddProjectUser mocks base method.
+func (m *MockRepository) AddProjectUser(ctx context.Context, projectID primitive.ObjectID, user models.ProjectUser) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddProjectUser", ctx, projectID, user)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddProjectUser indicates an expected call of AddProjectUser.
+func (mr *MockRepositoryMockRecorder) AddProjectUser(ctx, projectID, user any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddProjectUser", reflect.TypeOf((*MockRepository)(nil).AddProjectUser), ctx, projectID, user)
+}
+
+// AddScopedAPIKey mocks base method.
+func (m *MockRepository) AddScopedAPIKey(ctx context.Context, projectID primitive.ObjectID, apiKey models.ScopedAPIKey) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddScopedAPIKey", ctx, projectID, apiKey)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddScopedAPIKey indicates an expected call of AddScopedAPIKey.
+func (mr *MockRepositoryMockRecorder) AddScopedAPIKey(ctx, projectID, apiKey any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddScopedAPIKey", reflect.TypeOf((*MockRepository)(nil).AddScopedAPIKey), ctx, projectID, apiKey)
+}
+
 // AppendLogToExecution mocks base method.
 func (m *MockRepository) AppendLogToExecution(ctx context.Context, executionUUID string, logEntry models.LogEntry) error {
 	m.ctrl.T.Helper()
@@ -72,6 +172,51 @@ func (mr *MockRepositoryMockRecorder) CalculateTaskFailureStats(ctx, projectID,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CalculateTaskFailureStats", reflect.TypeOf((*MockRepository)(nil).CalculateTaskFailureStats), ctx, projectID, date)
 }
 
+// ClaimOutboxEvents mocks base method.
+func (m *MockRepository) ClaimOutboxEvents(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*models.OutboxEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimOutboxEvents", ctx, now, lease, limit)
+	ret0, _ := ret[0].([]*models.OutboxEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimOutboxEvents indicates an expected call of ClaimOutboxEvents.
+func (mr *MockRepositoryMockRecorder) ClaimOutboxEvents(ctx, now, lease, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimOutboxEvents", reflect.TypeOf((*MockRepository)(nil).ClaimOutboxEvents), ctx, now, lease, limit)
+}
+
+// ClaimQueuedJobs mocks base method.
+func (m *MockRepository) ClaimQueuedJobs(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*models.QueuedJob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimQueuedJobs", ctx, now, lease, limit)
+	ret0, _ := ret[0].([]*models.QueuedJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimQueuedJobs indicates an expected call of ClaimQueuedJobs.
+func (mr *MockRepositoryMockRecorder) ClaimQueuedJobs(ctx, now, lease, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimQueuedJobs", reflect.TypeOf((*MockRepository)(nil).ClaimQueuedJobs), ctx, now, lease, limit)
+}
+
+// CountActiveExecutions mocks base method.
+func (m *MockRepository) CountActiveExecutions(ctx context.Context, taskUUIDs []string, since time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountActiveExecutions", ctx, taskUUIDs, since)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountActiveExecutions indicates an expected call of CountActiveExecutions.
+func (mr *MockRepositoryMockRecorder) CountActiveExecutions(ctx, taskUUIDs, since any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountActiveExecutions", reflect.TypeOf((*MockRepository)(nil).CountActiveExecutions), ctx, taskUUIDs, since)
+}
+
 // CreateExecution mocks base method.
 func (m *MockRepository) CreateExecution(ctx context.Context, execution *models.Execution) error {
 	m.ctrl.T.Helper()
@@ -86,6 +231,48 @@ func (mr *MockRepositoryMockRecorder) CreateExecution(ctx, execution any) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateExecution", reflect.TypeOf((*MockRepository)(nil).CreateExecution), ctx, execution)
 }
 
+// CreateInvitation mocks base method.
+func (m *MockRepository) CreateInvitation(ctx context.Context, invitation *models.Invitation) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateInvitation", ctx, invitation)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateInvitation indicates an expected call of CreateInvitation.
+func (mr *MockRepositoryMockRecorder) CreateInvitation(ctx, invitation any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateInvitation", reflect.TypeOf((*MockRepository)(nil).CreateInvitation), ctx, invitation)
+}
+
+// CreateOrganization mocks base method.
+func (m *MockRepository) CreateOrganization(ctx context.Context, organization *models.Organization) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateOrganization", ctx, organization)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateOrganization indicates an expected call of CreateOrganization.
+func (mr *MockRepositoryMockRecorder) CreateOrganization(ctx, organization any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOrganization", reflect.TypeOf((*MockRepository)(nil).CreateOrganization), ctx, organization)
+}
+
+// CreateOutboxEvent mocks base method.
+func (m *MockRepository) CreateOutboxEvent(ctx context.Context, event *models.OutboxEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateOutboxEvent", ctx, event)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateOutboxEvent indicates an expected call of CreateOutboxEvent.
+func (mr *MockRepositoryMockRecorder) CreateOutboxEvent(ctx, event any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOutboxEvent", reflect.TypeOf((*MockRepository)(nil).CreateOutboxEvent), ctx, event)
+}
+
 // CreateProject mocks base method.
 func (m *MockRepository) CreateProject(ctx context.Context, project *models.Project) error {
 	m.ctrl.T.Helper()
@@ -100,6 +287,20 @@ func (mr *MockRepositoryMockRecorder) CreateProject(ctx, project any) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateProject", reflect.TypeOf((*MockRepository)(nil).CreateProject), ctx, project)
 }
 
+// CreateQueuedJob mocks base method.
+func (m *MockRepository) CreateQueuedJob(ctx context.Context, job *models.QueuedJob) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateQueuedJob", ctx, job)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateQueuedJob indicates an expected call of CreateQueuedJob.
+func (mr *MockRepositoryMockRecorder) CreateQueuedJob(ctx, job any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateQueuedJob", reflect.TypeOf((*MockRepository)(nil).CreateQueuedJob), ctx, job)
+}
+
 // CreateTask mocks base method.
 func (m *MockRepository) CreateTask(ctx context.Context, projectID string, task *models.Task) error {
 	m.ctrl.T.Helper()
@@ -128,6 +329,205 @@ func (mr *MockRepositoryMockRecorder) CreateTaskGroup(ctx, projectID, taskGroup
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTaskGroup", reflect.TypeOf((*MockRepository)(nil).CreateTaskGroup), ctx, projectID, taskGroup)
 }
 
+// CreateTaskTemplate mocks base method.
+func (m *MockRepository) CreateTaskTemplate(ctx context.Context, template *models.TaskTemplate) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTaskTemplate", ctx, template)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateTaskTemplate indicates an expected call of CreateTaskTemplate.
+func (mr *MockRepositoryMockRecorder) CreateTaskTemplate(ctx, template any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTaskTemplate", reflect.TypeOf((*MockRepository)(nil).CreateTaskTemplate), ctx, template)
+}
+
+// CreateTokenRevocation mocks base method.
+func (m *MockRepository) CreateTokenRevocation(ctx context.Context, revocation *models.TokenRevocation) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTokenRevocation", ctx, revocation)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateTokenRevocation indicates an expected call of CreateTokenRevocation.
+func (mr *MockRepositoryMockRecorder) CreateTokenRevocation(ctx, revocation any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTokenRevocation", reflect.TypeOf((*MockRepository)(nil).CreateTokenRevocation), ctx, revocation)
+}
+
+// DecrementFailureStats mocks base method.
+func (m *MockRepository) DecrementFailureStats(ctx context.Context, projectID primitive.ObjectID, countsByDate map[string]int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DecrementFailureStats", ctx, projectID, countsByDate)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DecrementFailureStats indicates an expected call of DecrementFailureStats.
+func (mr *MockRepositoryMockRecorder) DecrementFailureStats(ctx, projectID, countsByDate any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DecrementFailureStats", reflect.TypeOf((*MockRepository)(nil).DecrementFailureStats), ctx, projectID, countsByDate)
+}
+
+// DeleteExecutionsByIDs mocks base method.
+func (m *MockRepository) DeleteExecutionsByIDs(ctx context.Context, executionIDs []primitive.ObjectID) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteExecutionsByIDs", ctx, executionIDs)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteExecutionsByIDs indicates an expected call of DeleteExecutionsByIDs.
+func (mr *MockRepositoryMockRecorder) DeleteExecutionsByIDs(ctx, executionIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteExecutionsByIDs", reflect.TypeOf((*MockRepository)(nil).DeleteExecutionsByIDs), ctx, executionIDs)
+}
+
+// DeleteExecutionsByTaskUUIDs mocks base method.
+func (m *MockRepository) DeleteExecutionsByTaskUUIDs(ctx context.Context, taskUUIDs []string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteExecutionsByTaskUUIDs", ctx, taskUUIDs)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteExecutionsByTaskUUIDs indicates an expected call of DeleteExecutionsByTaskUUIDs.
+func (mr *MockRepositoryMockRecorder) DeleteExecutionsByTaskUUIDs(ctx, taskUUIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteExecutionsByTaskUUIDs", reflect.TypeOf((*MockRepository)(nil).DeleteExecutionsByTaskUUIDs), ctx, taskUUIDs)
+}
+
+// DeleteExecutionsByTaskUUIDsBefore mocks base method.
+func (m *MockRepository) DeleteExecutionsByTaskUUIDsBefore(ctx context.Context, taskUUIDs []string, before time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteExecutionsByTaskUUIDsBefore", ctx, taskUUIDs, before)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteExecutionsByTaskUUIDsBefore indicates an expected call of DeleteExecutionsByTaskUUIDsBefore.
+func (mr *MockRepositoryMockRecorder) DeleteExecutionsByTaskUUIDsBefore(ctx, taskUUIDs, before any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteExecutionsByTaskUUIDsBefore", reflect.TypeOf((*MockRepository)(nil).DeleteExecutionsByTaskUUIDsBefore), ctx, taskUUIDs, before)
+}
+
+// DeleteIncidentsByProjectID mocks base method.
+func (m *MockRepository) DeleteIncidentsByProjectID(ctx context.Context, projectID primitive.ObjectID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteIncidentsByProjectID", ctx, projectID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteIncidentsByProjectID indicates an expected call of DeleteIncidentsByProjectID.
+func (mr *MockRepositoryMockRecorder) DeleteIncidentsByProjectID(ctx, projectID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteIncidentsByProjectID", reflect.TypeOf((*MockRepository)(nil).DeleteIncidentsByProjectID), ctx, projectID)
+}
+
+// DeleteOrganization mocks base method.
+func (m *MockRepository) DeleteOrganization(ctx context.Context, organizationID primitive.ObjectID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteOrganization", ctx, organizationID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteOrganization indicates an expected call of DeleteOrganization.
+func (mr *MockRepositoryMockRecorder) DeleteOrganization(ctx, organizationID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteOrganization", reflect.TypeOf((*MockRepository)(nil).DeleteOrganization), ctx, organizationID)
+}
+
+// DeleteOutboxEvent mocks base method.
+func (m *MockRepository) DeleteOutboxEvent(ctx context.Context, id primitive.ObjectID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteOutboxEvent", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteOutboxEvent indicates an expected call of DeleteOutboxEvent.
+func (mr *MockRepositoryMockRecorder) DeleteOutboxEvent(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteOutboxEvent", reflect.TypeOf((*MockRepository)(nil).DeleteOutboxEvent), ctx, id)
+}
+
+// DeleteProject mocks base method.
+func (m *MockRepository) DeleteProject(ctx context.Context, projectID primitive.ObjectID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteProject", ctx, projectID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteProject indicates an expected call of DeleteProject.
+func (mr *MockRepositoryMockRecorder) DeleteProject(ctx, projectID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteProject", reflect.TypeOf((*MockRepository)(nil).DeleteProject), ctx, projectID)
+}
+
+// DeleteProjectSettings mocks base method.
+func (m *MockRepository) DeleteProjectSettings(ctx context.Context, projectID primitive.ObjectID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteProjectSettings", ctx, projectID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteProjectSettings indicates an expected call of DeleteProjectSettings.
+func (mr *MockRepositoryMockRecorder) DeleteProjectSettings(ctx, projectID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteProjectSettings", reflect.TypeOf((*MockRepository)(nil).DeleteProjectSettings), ctx, projectID)
+}
+
+// DeleteQueuedJob mocks base method.
+func (m *MockRepository) DeleteQueuedJob(ctx context.Context, id primitive.ObjectID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteQueuedJob", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteQueuedJob indicates an expected call of DeleteQueuedJob.
+func (mr *MockRepositoryMockRecorder) DeleteQueuedJob(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteQueuedJob", reflect.TypeOf((*MockRepository)(nil).DeleteQueuedJob), ctx, id)
+}
+
+// DeleteSecret mocks base method.
+func (m *MockRepository) DeleteSecret(ctx context.Context, projectID primitive.ObjectID, name string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSecret", ctx, projectID, name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSecret indicates an expected call of DeleteSecret.
+func (mr *MockRepositoryMockRecorder) DeleteSecret(ctx, projectID, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSecret", reflect.TypeOf((*MockRepository)(nil).DeleteSecret), ctx, projectID, name)
+}
+
+// DeleteStatsByProjectID mocks base method.
+func (m *MockRepository) DeleteStatsByProjectID(ctx context.Context, projectID primitive.ObjectID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteStatsByProjectID", ctx, projectID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteStatsByProjectID indicates an expected call of DeleteStatsByProjectID.
+func (mr *MockRepositoryMockRecorder) DeleteStatsByProjectID(ctx, projectID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteStatsByProjectID", reflect.TypeOf((*MockRepository)(nil).DeleteStatsByProjectID), ctx, projectID)
+}
+
 // DeleteTask mocks base method.
 func (m *MockRepository) DeleteTask(ctx context.Context, taskUUID string) error {
 	m.ctrl.T.Helper()
@@ -156,6 +556,49 @@ func (mr *MockRepositoryMockRecorder) DeleteTaskGroup(ctx, taskGroupUUID any) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTaskGroup", reflect.TypeOf((*MockRepository)(nil).DeleteTaskGroup), ctx, taskGroupUUID)
 }
 
+// DeleteTaskTemplate mocks base method.
+func (m *MockRepository) DeleteTaskTemplate(ctx context.Context, projectID primitive.ObjectID, templateUUID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTaskTemplate", ctx, projectID, templateUUID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteTaskTemplate indicates an expected call of DeleteTaskTemplate.
+func (mr *MockRepositoryMockRecorder) DeleteTaskTemplate(ctx, projectID, templateUUID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTaskTemplate", reflect.TypeOf((*MockRepository)(nil).DeleteTaskTemplate), ctx, projectID, templateUUID)
+}
+
+// DeleteTaskTemplatesByProjectID mocks base method.
+func (m *MockRepository) DeleteTaskTemplatesByProjectID(ctx context.Context, projectID primitive.ObjectID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTaskTemplatesByProjectID", ctx, projectID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteTaskTemplatesByProjectID indicates an expected call of DeleteTaskTemplatesByProjectID.
+func (mr *MockRepositoryMockRecorder) DeleteTaskTemplatesByProjectID(ctx, projectID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTaskTemplatesByProjectID", reflect.TypeOf((*MockRepository)(nil).DeleteTaskTemplatesByProjectID), ctx, projectID)
+}
+
+// FailExecutionIfUnfinished mocks base method.
+func (m *MockRepository) FailExecutionIfUnfinished(ctx context.Context, executionUUID, errorMessage string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FailExecutionIfUnfinished", ctx, executionUUID, errorMessage)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FailExecutionIfUnfinished indicates an expected call of FailExecutionIfUnfinished.
+func (mr *MockRepositoryMockRecorder) FailExecutionIfUnfinished(ctx, executionUUID, errorMessage any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FailExecutionIfUnfinished", reflect.TypeOf((*MockRepository)(nil).FailExecutionIfUnfinished), ctx, executionUUID, errorMessage)
+}
+
 // GetActiveTaskGroupsWithWindows mocks base method.
 func (m *MockRepository) GetActiveTaskGroupsWithWindows(ctx context.Context) ([]*models.TaskGroup, error) {
 	m.ctrl.T.Helper()
@@ -186,6 +629,21 @@ func (mr *MockRepositoryMockRecorder) GetAllActiveTasks(ctx any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllActiveTasks", reflect.TypeOf((*MockRepository)(nil).GetAllActiveTasks), ctx)
 }
 
+// GetAllOrganizations mocks base method.
+func (m *MockRepository) GetAllOrganizations(ctx context.Context) ([]*models.Organization, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllOrganizations", ctx)
+	ret0, _ := ret[0].([]*models.Organization)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllOrganizations indicates an expected call of GetAllOrganizations.
+func (mr *MockRepositoryMockRecorder) GetAllOrganizations(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllOrganizations", reflect.TypeOf((*MockRepository)(nil).GetAllOrganizations), ctx)
+}
+
 // GetAllProjects mocks base method.
 func (m *MockRepository) GetAllProjects(ctx context.Context) ([]*models.Project, error) {
 	m.ctrl.T.Helper()
@@ -201,6 +659,21 @@ func (mr *MockRepositoryMockRecorder) GetAllProjects(ctx any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllProjects", reflect.TypeOf((*MockRepository)(nil).GetAllProjects), ctx)
 }
 
+// GetExecutionBatchByTaskUUID mocks base method.
+func (m *MockRepository) GetExecutionBatchByTaskUUID(ctx context.Context, taskUUID string, limit int) ([]*models.Execution, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetExecutionBatchByTaskUUID", ctx, taskUUID, limit)
+	ret0, _ := ret[0].([]*models.Execution)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetExecutionBatchByTaskUUID indicates an expected call of GetExecutionBatchByTaskUUID.
+func (mr *MockRepositoryMockRecorder) GetExecutionBatchByTaskUUID(ctx, taskUUID, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExecutionBatchByTaskUUID", reflect.TypeOf((*MockRepository)(nil).GetExecutionBatchByTaskUUID), ctx, taskUUID, limit)
+}
+
 // GetExecutionByUUID mocks base method.
 func (m *MockRepository) GetExecutionByUUID(ctx context.Context, executionUUID string) (*models.Execution, error) {
 	m.ctrl.T.Helper()
@@ -219,286 +692,1004 @@ func (mr *MockRepositoryMockRecorder) GetExecutionByUUID(ctx, executionUUID any)
 // GetExecutionStatsByProject mocks base method.
 func (m *MockRepository) GetExecutionStatsByProject(ctx context.Context, projectID primitive.ObjectID, days int) ([]*models.ExecutionStats, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetExecutionStatsByProject", ctx, projectID, days)
-	ret0, _ := ret[0].([]*models.ExecutionStats)
+	ret := m.ctrl.Call(m, "GetExecutionStatsByProject", ctx, projectID, days)
+	ret0, _ := ret[0].([]*models.ExecutionStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetExecutionStatsByProject indicates an expected call of GetExecutionStatsByProject.
+func (mr *MockRepositoryMockRecorder) GetExecutionStatsByProject(ctx, projectID, days any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExecutionStatsByProject", reflect.TypeOf((*MockRepository)(nil).GetExecutionStatsByProject), ctx, projectID, days)
+}
+
+// GetExecutionTaskUUIDs mocks base method.
+func (m *MockRepository) GetExecutionTaskUUIDs(ctx context.Context) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetExecutionTaskUUIDs", ctx)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetExecutionTaskUUIDs indicates an expected call of GetExecutionTaskUUIDs.
+func (mr *MockRepositoryMockRecorder) GetExecutionTaskUUIDs(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExecutionTaskUUIDs", reflect.TypeOf((*MockRepository)(nil).GetExecutionTaskUUIDs), ctx)
+}
+
+// GetExecutionsByTaskUUID mocks base method.
+func (m *MockRepository) GetExecutionsByTaskUUID(ctx context.Context, taskUUID string, startDate, endDate *time.Time) ([]*models.Execution, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetExecutionsByTaskUUID", ctx, taskUUID, startDate, endDate)
+	ret0, _ := ret[0].([]*models.Execution)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetExecutionsByTaskUUID indicates an expected call of GetExecutionsByTaskUUID.
+func (mr *MockRepositoryMockRecorder) GetExecutionsByTaskUUID(ctx, taskUUID, startDate, endDate any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExecutionsByTaskUUID", reflect.TypeOf((*MockRepository)(nil).GetExecutionsByTaskUUID), ctx, taskUUID, startDate, endDate)
+}
+
+// GetExecutionsByTaskUUIDPaginated mocks base method.
+func (m *MockRepository) GetExecutionsByTaskUUIDPaginated(ctx context.Context, taskUUID string, startDate, endDate *time.Time, page, pageSize int) ([]*models.Execution, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetExecutionsByTaskUUIDPaginated", ctx, taskUUID, startDate, endDate, page, pageSize)
+	ret0, _ := ret[0].([]*models.Execution)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetExecutionsByTaskUUIDPaginated indicates an expected call of GetExecutionsByTaskUUIDPaginated.
+func (mr *MockRepositoryMockRecorder) GetExecutionsByTaskUUIDPaginated(ctx, taskUUID, startDate, endDate, page, pageSize any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExecutionsByTaskUUIDPaginated", reflect.TypeOf((*MockRepository)(nil).GetExecutionsByTaskUUIDPaginated), ctx, taskUUID, startDate, endDate, page, pageSize)
+}
+
+// GetExecutionsByTaskUUIDsBetween mocks base method.
+func (m *MockRepository) GetExecutionsByTaskUUIDsBetween(ctx context.Context, taskUUIDs []string, from, to time.Time, limit int) ([]*models.Execution, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetExecutionsByTaskUUIDsBetween", ctx, taskUUIDs, from, to, limit)
+	ret0, _ := ret[0].([]*models.Execution)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetExecutionsByTaskUUIDsBetween indicates an expected call of GetExecutionsByTaskUUIDsBetween.
+func (mr *MockRepositoryMockRecorder) GetExecutionsByTaskUUIDsBetween(ctx, taskUUIDs, from, to, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExecutionsByTaskUUIDsBetween", reflect.TypeOf((*MockRepository)(nil).GetExecutionsByTaskUUIDsBetween), ctx, taskUUIDs, from, to, limit)
+}
+
+// GetFailureStatsByProject mocks base method.
+func (m *MockRepository) GetFailureStatsByProject(ctx context.Context, projectID primitive.ObjectID, days int) ([]*models.FailedExecutionStats, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFailureStatsByProject", ctx, projectID, days)
+	ret0, _ := ret[0].([]*models.FailedExecutionStats)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetFailureStatsByProject indicates an expected call of GetFailureStatsByProject.
+func (mr *MockRepositoryMockRecorder) GetFailureStatsByProject(ctx, projectID, days any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFailureStatsByProject", reflect.TypeOf((*MockRepository)(nil).GetFailureStatsByProject), ctx, projectID, days)
+}
+
+// GetIncidentByUUID mocks base method.
+func (m *MockRepository) GetIncidentByUUID(ctx context.Context, projectID primitive.ObjectID, incidentUUID string) (*models.Incident, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetIncidentByUUID", ctx, projectID, incidentUUID)
+	ret0, _ := ret[0].(*models.Incident)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetIncidentByUUID indicates an expected call of GetIncidentByUUID.
+func (mr *MockRepositoryMockRecorder) GetIncidentByUUID(ctx, projectID, incidentUUID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIncidentByUUID", reflect.TypeOf((*MockRepository)(nil).GetIncidentByUUID), ctx, projectID, incidentUUID)
+}
+
+// GetInvitationByUUID mocks base method.
+func (m *MockRepository) GetInvitationByUUID(ctx context.Context, invitationUUID string) (*models.Invitation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInvitationByUUID", ctx, invitationUUID)
+	ret0, _ := ret[0].(*models.Invitation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInvitationByUUID indicates an expected call of GetInvitationByUUID.
+func (mr *MockRepositoryMockRecorder) GetInvitationByUUID(ctx, invitationUUID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInvitationByUUID", reflect.TypeOf((*MockRepository)(nil).GetInvitationByUUID), ctx, invitationUUID)
+}
+
+// GetInvitationsByProjectID mocks base method.
+func (m *MockRepository) GetInvitationsByProjectID(ctx context.Context, projectID primitive.ObjectID, status models.InvitationStatus) ([]*models.Invitation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInvitationsByProjectID", ctx, projectID, status)
+	ret0, _ := ret[0].([]*models.Invitation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInvitationsByProjectID indicates an expected call of GetInvitationsByProjectID.
+func (mr *MockRepositoryMockRecorder) GetInvitationsByProjectID(ctx, projectID, status any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInvitationsByProjectID", reflect.TypeOf((*MockRepository)(nil).GetInvitationsByProjectID), ctx, projectID, status)
+}
+
+// GetLastSuccessByTaskUUIDs mocks base method.
+func (m *MockRepository) GetLastSuccessByTaskUUIDs(ctx context.Context, taskUUIDs []string) (map[string]time.Time, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLastSuccessByTaskUUIDs", ctx, taskUUIDs)
+	ret0, _ := ret[0].(map[string]time.Time)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLastSuccessByTaskUUIDs indicates an expected call of GetLastSuccessByTaskUUIDs.
+func (mr *MockRepositoryMockRecorder) GetLastSuccessByTaskUUIDs(ctx, taskUUIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLastSuccessByTaskUUIDs", reflect.TypeOf((*MockRepository)(nil).GetLastSuccessByTaskUUIDs), ctx, taskUUIDs)
+}
+
+// GetLatestExecutionByTaskUUID mocks base method.
+func (m *MockRepository) GetLatestExecutionByTaskUUID(ctx context.Context, taskUUID string) (*models.Execution, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLatestExecutionByTaskUUID", ctx, taskUUID)
+	ret0, _ := ret[0].(*models.Execution)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLatestExecutionByTaskUUID indicates an expected call of GetLatestExecutionByTaskUUID.
+func (mr *MockRepositoryMockRecorder) GetLatestExecutionByTaskUUID(ctx, taskUUID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLatestExecutionByTaskUUID", reflect.TypeOf((*MockRepository)(nil).GetLatestExecutionByTaskUUID), ctx, taskUUID)
+}
+
+// GetLatestExecutionsByTaskUUIDs mocks base method.
+func (m *MockRepository) GetLatestExecutionsByTaskUUIDs(ctx context.Context, taskUUIDs []string) (map[string]*models.Execution, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLatestExecutionsByTaskUUIDs", ctx, taskUUIDs)
+	ret0, _ := ret[0].(map[string]*models.Execution)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLatestExecutionsByTaskUUIDs indicates an expected call of GetLatestExecutionsByTaskUUIDs.
+func (mr *MockRepositoryMockRecorder) GetLatestExecutionsByTaskUUIDs(ctx, taskUUIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLatestExecutionsByTaskUUIDs", reflect.TypeOf((*MockRepository)(nil).GetLatestExecutionsByTaskUUIDs), ctx, taskUUIDs)
+}
+
+// GetOrganizationByID mocks base method.
+func (m *MockRepository) GetOrganizationByID(ctx context.Context, organizationID primitive.ObjectID) (*models.Organization, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrganizationByID", ctx, organizationID)
+	ret0, _ := ret[0].(*models.Organization)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrganizationByID indicates an expected call of GetOrganizationByID.
+func (mr *MockRepositoryMockRecorder) GetOrganizationByID(ctx, organizationID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrganizationByID", reflect.TypeOf((*MockRepository)(nil).GetOrganizationByID), ctx, organizationID)
+}
+
+// GetProjectByID mocks base method.
+func (m *MockRepository) GetProjectByID(ctx context.Context, projectID primitive.ObjectID) (*models.Project, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProjectByID", ctx, projectID)
+	ret0, _ := ret[0].(*models.Project)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProjectByID indicates an expected call of GetProjectByID.
+func (mr *MockRepositoryMockRecorder) GetProjectByID(ctx, projectID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProjectByID", reflect.TypeOf((*MockRepository)(nil).GetProjectByID), ctx, projectID)
+}
+
+// GetProjectByName mocks base method.
+func (m *MockRepository) GetProjectByName(ctx context.Context, name string) (*models.Project, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProjectByName", ctx, name)
+	ret0, _ := ret[0].(*models.Project)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProjectByName indicates an expected call of GetProjectByName.
+func (mr *MockRepositoryMockRecorder) GetProjectByName(ctx, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProjectByName", reflect.TypeOf((*MockRepository)(nil).GetProjectByName), ctx, name)
+}
+
+// GetProjectSettings mocks base method.
+func (m *MockRepository) GetProjectSettings(ctx context.Context, projectID primitive.ObjectID) (*models.ProjectSettings, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProjectSettings", ctx, projectID)
+	ret0, _ := ret[0].(*models.ProjectSettings)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProjectSettings indicates an expected call of GetProjectSettings.
+func (mr *MockRepositoryMockRecorder) GetProjectSettings(ctx, projectID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProjectSettings", reflect.TypeOf((*MockRepository)(nil).GetProjectSettings), ctx, projectID)
+}
+
+// GetProjectSettingsWithRetention mocks base method.
+func (m *MockRepository) GetProjectSettingsWithRetention(ctx context.Context) ([]*models.ProjectSettings, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProjectSettingsWithRetention", ctx)
+	ret0, _ := ret[0].([]*models.ProjectSettings)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProjectSettingsWithRetention indicates an expected call of GetProjectSettingsWithRetention.
+func (mr *MockRepositoryMockRecorder) GetProjectSettingsWithRetention(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProjectSettingsWithRetention", reflect.TypeOf((*MockRepository)(nil).GetProjectSettingsWithRetention), ctx)
+}
+
+// GetProjectsByOrganizationID mocks base method.
+func (m *MockRepository) GetProjectsByOrganizationID(ctx context.Context, organizationID primitive.ObjectID) ([]*models.Project, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProjectsByOrganizationID", ctx, organizationID)
+	ret0, _ := ret[0].([]*models.Project)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProjectsByOrganizationID indicates an expected call of GetProjectsByOrganizationID.
+func (mr *MockRepositoryMockRecorder) GetProjectsByOrganizationID(ctx, organizationID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProjectsByOrganizationID", reflect.TypeOf((*MockRepository)(nil).GetProjectsByOrganizationID), ctx, organizationID)
+}
+
+// GetSecretByName mocks base method.
+func (m *MockRepository) GetSecretByName(ctx context.Context, projectID primitive.ObjectID, name string) (*models.Secret, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSecretByName", ctx, projectID, name)
+	ret0, _ := ret[0].(*models.Secret)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSecretByName indicates an expected call of GetSecretByName.
+func (mr *MockRepositoryMockRecorder) GetSecretByName(ctx, projectID, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSecretByName", reflect.TypeOf((*MockRepository)(nil).GetSecretByName), ctx, projectID, name)
+}
+
+// GetSecretsByProjectID mocks base method.
+func (m *MockRepository) GetSecretsByProjectID(ctx context.Context, projectID primitive.ObjectID) ([]*models.Secret, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSecretsByProjectID", ctx, projectID)
+	ret0, _ := ret[0].([]*models.Secret)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSecretsByProjectID indicates an expected call of GetSecretsByProjectID.
+func (mr *MockRepositoryMockRecorder) GetSecretsByProjectID(ctx, projectID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSecretsByProjectID", reflect.TypeOf((*MockRepository)(nil).GetSecretsByProjectID), ctx, projectID)
+}
+
+// GetStatsProjectIDs mocks base method.
+func (m *MockRepository) GetStatsProjectIDs(ctx context.Context) ([]primitive.ObjectID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStatsProjectIDs", ctx)
+	ret0, _ := ret[0].([]primitive.ObjectID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStatsProjectIDs indicates an expected call of GetStatsProjectIDs.
+func (mr *MockRepositoryMockRecorder) GetStatsProjectIDs(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStatsProjectIDs", reflect.TypeOf((*MockRepository)(nil).GetStatsProjectIDs), ctx)
+}
+
+// GetStoredTaskFailureStats mocks base method.
+func (m *MockRepository) GetStoredTaskFailureStats(ctx context.Context, projectID primitive.ObjectID, date string) (*models.StoredTaskFailureStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStoredTaskFailureStats", ctx, projectID, date)
+	ret0, _ := ret[0].(*models.StoredTaskFailureStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStoredTaskFailureStats indicates an expected call of GetStoredTaskFailureStats.
+func (mr *MockRepositoryMockRecorder) GetStoredTaskFailureStats(ctx, projectID, date any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStoredTaskFailureStats", reflect.TypeOf((*MockRepository)(nil).GetStoredTaskFailureStats), ctx, projectID, date)
+}
+
+// GetTaskBatchByProjectID mocks base method.
+func (m *MockRepository) GetTaskBatchByProjectID(ctx context.Context, projectID primitive.ObjectID, limit int) ([]*models.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTaskBatchByProjectID", ctx, projectID, limit)
+	ret0, _ := ret[0].([]*models.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTaskBatchByProjectID indicates an expected call of GetTaskBatchByProjectID.
+func (mr *MockRepositoryMockRecorder) GetTaskBatchByProjectID(ctx, projectID, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTaskBatchByProjectID", reflect.TypeOf((*MockRepository)(nil).GetTaskBatchByProjectID), ctx, projectID, limit)
+}
+
+// GetTaskByUUID mocks base method.
+func (m *MockRepository) GetTaskByUUID(ctx context.Context, taskUUID string) (*models.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTaskByUUID", ctx, taskUUID)
+	ret0, _ := ret[0].(*models.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTaskByUUID indicates an expected call of GetTaskByUUID.
+func (mr *MockRepositoryMockRecorder) GetTaskByUUID(ctx, taskUUID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTaskByUUID", reflect.TypeOf((*MockRepository)(nil).GetTaskByUUID), ctx, taskUUID)
+}
+
+// GetTaskFailuresByDate mocks base method.
+func (m *MockRepository) GetTaskFailuresByDate(ctx context.Context, projectID primitive.ObjectID, date string) ([]*models.TaskFailureStats, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTaskFailuresByDate", ctx, projectID, date)
+	ret0, _ := ret[0].([]*models.TaskFailureStats)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetTaskFailuresByDate indicates an expected call of GetTaskFailuresByDate.
+func (mr *MockRepositoryMockRecorder) GetTaskFailuresByDate(ctx, projectID, date any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTaskFailuresByDate", reflect.TypeOf((*MockRepository)(nil).GetTaskFailuresByDate), ctx, projectID, date)
+}
+
+// GetTaskGroupByID mocks base method.
+func (m *MockRepository) GetTaskGroupByID(ctx context.Context, taskGroupID primitive.ObjectID) (*models.TaskGroup, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTaskGroupByID", ctx, taskGroupID)
+	ret0, _ := ret[0].(*models.TaskGroup)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTaskGroupByID indicates an expected call of GetTaskGroupByID.
+func (mr *MockRepositoryMockRecorder) GetTaskGroupByID(ctx, taskGroupID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTaskGroupByID", reflect.TypeOf((*MockRepository)(nil).GetTaskGroupByID), ctx, taskGroupID)
+}
+
+// GetTaskGroupByUUID mocks base method.
+func (m *MockRepository) GetTaskGroupByUUID(ctx context.Context, taskGroupUUID string) (*models.TaskGroup, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTaskGroupByUUID", ctx, taskGroupUUID)
+	ret0, _ := ret[0].(*models.TaskGroup)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTaskGroupByUUID indicates an expected call of GetTaskGroupByUUID.
+func (mr *MockRepositoryMockRecorder) GetTaskGroupByUUID(ctx, taskGroupUUID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTaskGroupByUUID", reflect.TypeOf((*MockRepository)(nil).GetTaskGroupByUUID), ctx, taskGroupUUID)
+}
+
+// GetTaskGroupsByProjectID mocks base method.
+func (m *MockRepository) GetTaskGroupsByProjectID(ctx context.Context, projectID primitive.ObjectID) ([]*models.TaskGroup, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTaskGroupsByProjectID", ctx, projectID)
+	ret0, _ := ret[0].([]*models.TaskGroup)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTaskGroupsByProjectID indicates an expected call of GetTaskGroupsByProjectID.
+func (mr *MockRepositoryMockRecorder) GetTaskGroupsByProjectID(ctx, projectID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTaskGroupsByProjectID", reflect.TypeOf((*MockRepository)(nil).GetTaskGroupsByProjectID), ctx, projectID)
+}
+
+// GetTaskReferences mocks base method.
+func (m *MockRepository) GetTaskReferences(ctx context.Context) ([]*models.TaskReference, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTaskReferences", ctx)
+	ret0, _ := ret[0].([]*models.TaskReference)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTaskReferences indicates an expected call of GetTaskReferences.
+func (mr *MockRepositoryMockRecorder) GetTaskReferences(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTaskReferences", reflect.TypeOf((*MockRepository)(nil).GetTaskReferences), ctx)
+}
+
+// GetTaskTemplateByUUID mocks base method.
+func (m *MockRepository) GetTaskTemplateByUUID(ctx context.Context, projectID primitive.ObjectID, templateUUID string) (*models.TaskTemplate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTaskTemplateByUUID", ctx, projectID, templateUUID)
+	ret0, _ := ret[0].(*models.TaskTemplate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTaskTemplateByUUID indicates an expected call of GetTaskTemplateByUUID.
+func (mr *MockRepositoryMockRecorder) GetTaskTemplateByUUID(ctx, projectID, templateUUID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTaskTemplateByUUID", reflect.TypeOf((*MockRepository)(nil).GetTaskTemplateByUUID), ctx, projectID, templateUUID)
+}
+
+// GetTaskTemplatesByProjectID mocks base method.
+func (m *MockRepository) GetTaskTemplatesByProjectID(ctx context.Context, projectID primitive.ObjectID) ([]*models.TaskTemplate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTaskTemplatesByProjectID", ctx, projectID)
+	ret0, _ := ret[0].([]*models.TaskTemplate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTaskTemplatesByProjectID indicates an expected call of GetTaskTemplatesByProjectID.
+func (mr *MockRepositoryMockRecorder) GetTaskTemplatesByProjectID(ctx, projectID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTaskTemplatesByProjectID", reflect.TypeOf((*MockRepository)(nil).GetTaskTemplatesByProjectID), ctx, projectID)
+}
+
+// GetTasksByGroupID mocks base method.
+func (m *MockRepository) GetTasksByGroupID(ctx context.Context, taskGroupID primitive.ObjectID) ([]*models.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTasksByGroupID", ctx, taskGroupID)
+	ret0, _ := ret[0].([]*models.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTasksByGroupID indicates an expected call of GetTasksByGroupID.
+func (mr *MockRepositoryMockRecorder) GetTasksByGroupID(ctx, taskGroupID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTasksByGroupID", reflect.TypeOf((*MockRepository)(nil).GetTasksByGroupID), ctx, taskGroupID)
+}
+
+// GetTasksByProjectID mocks base method.
+func (m *MockRepository) GetTasksByProjectID(ctx context.Context, projectID primitive.ObjectID) ([]*models.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTasksByProjectID", ctx, projectID)
+	ret0, _ := ret[0].([]*models.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTasksByProjectID indicates an expected call of GetTasksByProjectID.
+func (mr *MockRepositoryMockRecorder) GetTasksByProjectID(ctx, projectID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTasksByProjectID", reflect.TypeOf((*MockRepository)(nil).GetTasksByProjectID), ctx, projectID)
+}
+
+// GetTasksByStatus mocks base method.
+func (m *MockRepository) GetTasksByStatus(ctx context.Context, statuses []models.TaskStatus) ([]*models.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTasksByStatus", ctx, statuses)
+	ret0, _ := ret[0].([]*models.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTasksByStatus indicates an expected call of GetTasksByStatus.
+func (mr *MockRepositoryMockRecorder) GetTasksByStatus(ctx, statuses any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTasksByStatus", reflect.TypeOf((*MockRepository)(nil).GetTasksByStatus), ctx, statuses)
+}
+
+// GetUsage mocks base method.
+func (m *MockRepository) GetUsage(ctx context.Context, filter models.UsageFilter) ([]*models.UsageRecord, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUsage", ctx, filter)
+	ret0, _ := ret[0].([]*models.UsageRecord)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUsage indicates an expected call of GetUsage.
+func (mr *MockRepositoryMockRecorder) GetUsage(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUsage", reflect.TypeOf((*MockRepository)(nil).GetUsage), ctx, filter)
+}
+
+// GetUserOrganizations mocks base method.
+func (m *MockRepository) GetUserOrganizations(ctx context.Context, email string) ([]*models.Organization, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserOrganizations", ctx, email)
+	ret0, _ := ret[0].([]*models.Organization)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserOrganizations indicates an expected call of GetUserOrganizations.
+func (mr *MockRepositoryMockRecorder) GetUserOrganizations(ctx, email any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserOrganizations", reflect.TypeOf((*MockRepository)(nil).GetUserOrganizations), ctx, email)
+}
+
+// GetUserProjects mocks base method.
+func (m *MockRepository) GetUserProjects(ctx context.Context, email string) ([]*models.Project, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserProjects", ctx, email)
+	ret0, _ := ret[0].([]*models.Project)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserProjects indicates an expected call of GetUserProjects.
+func (mr *MockRepositoryMockRecorder) GetUserProjects(ctx, email any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserProjects", reflect.TypeOf((*MockRepository)(nil).GetUserProjects), ctx, email)
+}
+
+// IncrementFailureStat mocks base method.
+func (m *MockRepository) IncrementFailureStat(ctx context.Context, projectID primitive.ObjectID, date string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IncrementFailureStat", ctx, projectID, date)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// IncrementFailureStat indicates an expected call of IncrementFailureStat.
+func (mr *MockRepositoryMockRecorder) IncrementFailureStat(ctx, projectID, date any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncrementFailureStat", reflect.TypeOf((*MockRepository)(nil).IncrementFailureStat), ctx, projectID, date)
+}
+
+// IncrementUsage mocks base method.
+func (m *MockRepository) IncrementUsage(ctx context.Context, projectID primitive.ObjectID, date string, counters models.UsageCounters) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IncrementUsage", ctx, projectID, date, counters)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// IncrementUsage indicates an expected call of IncrementUsage.
+func (mr *MockRepositoryMockRecorder) IncrementUsage(ctx, projectID, date, counters any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncrementUsage", reflect.TypeOf((*MockRepository)(nil).IncrementUsage), ctx, projectID, date, counters)
+}
+
+// IsTokenRevoked mocks base method.
+func (m *MockRepository) IsTokenRevoked(ctx context.Context, jti, email string, issuedAt time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsTokenRevoked", ctx, jti, email, issuedAt)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsTokenRevoked indicates an expected call of IsTokenRevoked.
+func (mr *MockRepositoryMockRecorder) IsTokenRevoked(ctx, jti, email, issuedAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsTokenRevoked", reflect.TypeOf((*MockRepository)(nil).IsTokenRevoked), ctx, jti, email, issuedAt)
+}
+
+// ListIncidents mocks base method.
+func (m *MockRepository) ListIncidents(ctx context.Context, projectID primitive.ObjectID, filter models.IncidentListFilter, page, pageSize int) ([]*models.Incident, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListIncidents", ctx, projectID, filter, page, pageSize)
+	ret0, _ := ret[0].([]*models.Incident)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListIncidents indicates an expected call of ListIncidents.
+func (mr *MockRepositoryMockRecorder) ListIncidents(ctx, projectID, filter, page, pageSize any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListIncidents", reflect.TypeOf((*MockRepository)(nil).ListIncidents), ctx, projectID, filter, page, pageSize)
+}
+
+// ListTasksByProjectID mocks base method.
+func (m *MockRepository) ListTasksByProjectID(ctx context.Context, projectID primitive.ObjectID, filter models.TaskListFilter, page, pageSize int) ([]*models.Task, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTasksByProjectID", ctx, projectID, filter, page, pageSize)
+	ret0, _ := ret[0].([]*models.Task)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListTasksByProjectID indicates an expected call of ListTasksByProjectID.
+func (mr *MockRepositoryMockRecorder) ListTasksByProjectID(ctx, projectID, filter, page, pageSize any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTasksByProjectID", reflect.TypeOf((*MockRepository)(nil).ListTasksByProjectID), ctx, projectID, filter, page, pageSize)
+}
+
+// RecordExecutionEndpoint mocks base method.
+func (m *MockRepository) RecordExecutionEndpoint(ctx context.Context, executionUUID, endpoint string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordExecutionEndpoint", ctx, executionUUID, endpoint)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecordExecutionEndpoint indicates an expected call of RecordExecutionEndpoint.
+func (mr *MockRepositoryMockRecorder) RecordExecutionEndpoint(ctx, executionUUID, endpoint any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordExecutionEndpoint", reflect.TypeOf((*MockRepository)(nil).RecordExecutionEndpoint), ctx, executionUUID, endpoint)
+}
+
+// RecordExecutionHeartbeat mocks base method.
+func (m *MockRepository) RecordExecutionHeartbeat(ctx context.Context, executionUUID string, at time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordExecutionHeartbeat", ctx, executionUUID, at)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RecordExecutionHeartbeat indicates an expected call of RecordExecutionHeartbeat.
+func (mr *MockRepositoryMockRecorder) RecordExecutionHeartbeat(ctx, executionUUID, at any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordExecutionHeartbeat", reflect.TypeOf((*MockRepository)(nil).RecordExecutionHeartbeat), ctx, executionUUID, at)
+}
+
+// RecordIncidentFailure mocks base method.
+func (m *MockRepository) RecordIncidentFailure(ctx context.Context, failure models.IncidentFailure) (*models.Incident, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordIncidentFailure", ctx, failure)
+	ret0, _ := ret[0].(*models.Incident)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RecordIncidentFailure indicates an expected call of RecordIncidentFailure.
+func (mr *MockRepositoryMockRecorder) RecordIncidentFailure(ctx, failure any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordIncidentFailure", reflect.TypeOf((*MockRepository)(nil).RecordIncidentFailure), ctx, failure)
+}
+
+// RemoveNotificationChannel mocks base method.
+func (m *MockRepository) RemoveNotificationChannel(ctx context.Context, projectID primitive.ObjectID, name string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveNotificationChannel", ctx, projectID, name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveNotificationChannel indicates an expected call of RemoveNotificationChannel.
+func (mr *MockRepositoryMockRecorder) RemoveNotificationChannel(ctx, projectID, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveNotificationChannel", reflect.TypeOf((*MockRepository)(nil).RemoveNotificationChannel), ctx, projectID, name)
+}
+
+// RemoveProjectEnvironment mocks base method.
+func (m *MockRepository) RemoveProjectEnvironment(ctx context.Context, projectID primitive.ObjectID, name string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveProjectEnvironment", ctx, projectID, name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveProjectEnvironment indicates an expected call of RemoveProjectEnvironment.
+func (mr *MockRepositoryMockRecorder) RemoveProjectEnvironment(ctx, projectID, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveProjectEnvironment", reflect.TypeOf((*MockRepository)(nil).RemoveProjectEnvironment), ctx, projectID, name)
+}
+
+// RemoveScopedAPIKey mocks base method.
+func (m *MockRepository) RemoveScopedAPIKey(ctx context.Context, projectID primitive.ObjectID, keyID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveScopedAPIKey", ctx, projectID, keyID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveScopedAPIKey indicates an expected call of RemoveScopedAPIKey.
+func (mr *MockRepositoryMockRecorder) RemoveScopedAPIKey(ctx, projectID, keyID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveScopedAPIKey", reflect.TypeOf((*MockRepository)(nil).RemoveScopedAPIKey), ctx, projectID, keyID)
+}
+
+// RemoveTaskFromStoredFailureStats mocks base method.
+func (m *MockRepository) RemoveTaskFromStoredFailureStats(ctx context.Context, projectID primitive.ObjectID, taskUUID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveTaskFromStoredFailureStats", ctx, projectID, taskUUID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveTaskFromStoredFailureStats indicates an expected call of RemoveTaskFromStoredFailureStats.
+func (mr *MockRepositoryMockRecorder) RemoveTaskFromStoredFailureStats(ctx, projectID, taskUUID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveTaskFromStoredFailureStats", reflect.TypeOf((*MockRepository)(nil).RemoveTaskFromStoredFailureStats), ctx, projectID, taskUUID)
+}
+
+// ResolveActiveIncident mocks base method.
+func (m *MockRepository) ResolveActiveIncident(ctx context.Context, taskUUID, executionUUID string, resolvedAt time.Time) (*models.Incident, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResolveActiveIncident", ctx, taskUUID, executionUUID, resolvedAt)
+	ret0, _ := ret[0].(*models.Incident)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetExecutionStatsByProject indicates an expected call of GetExecutionStatsByProject.
-func (mr *MockRepositoryMockRecorder) GetExecutionStatsByProject(ctx, projectID, days any) *gomock.Call {
+// ResolveActiveIncident indicates an expected call of ResolveActiveIncident.
+func (mr *MockRepositoryMockRecorder) ResolveActiveIncident(ctx, taskUUID, executionUUID, resolvedAt any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExecutionStatsByProject", reflect.TypeOf((*MockRepository)(nil).GetExecutionStatsByProject), ctx, projectID, days)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveActiveIncident", reflect.TypeOf((*MockRepository)(nil).ResolveActiveIncident), ctx, taskUUID, executionUUID, resolvedAt)
 }
 
-// GetExecutionsByTaskUUID mocks base method.
-func (m *MockRepository) GetExecutionsByTaskUUID(ctx context.Context, taskUUID string, startDate, endDate *time.Time) ([]*models.Execution, error) {
+// ResolveIncident mocks base method.
+func (m *MockRepository) ResolveIncident(ctx context.Context, projectID primitive.ObjectID, incidentUUID, resolvedBy string, resolvedAt time.Time) (*models.Incident, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetExecutionsByTaskUUID", ctx, taskUUID, startDate, endDate)
-	ret0, _ := ret[0].([]*models.Execution)
+	ret := m.ctrl.Call(m, "ResolveIncident", ctx, projectID, incidentUUID, resolvedBy, resolvedAt)
+	ret0, _ := ret[0].(*models.Incident)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetExecutionsByTaskUUID indicates an expected call of GetExecutionsByTaskUUID.
-func (mr *MockRepositoryMockRecorder) GetExecutionsByTaskUUID(ctx, taskUUID, startDate, endDate any) *gomock.Call {
+// ResolveIncident indicates an expected call of ResolveIncident.
+func (mr *MockRepositoryMockRecorder) ResolveIncident(ctx, projectID, incidentUUID, resolvedBy, resolvedAt any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExecutionsByTaskUUID", reflect.TypeOf((*MockRepository)(nil).GetExecutionsByTaskUUID), ctx, taskUUID, startDate, endDate)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveIncident", reflect.TypeOf((*MockRepository)(nil).ResolveIncident), ctx, projectID, incidentUUID, resolvedBy, resolvedAt)
 }
 
-// GetExecutionsByTaskUUIDPaginated mocks base method.
-func (m *MockRepository) GetExecutionsByTaskUUIDPaginated(ctx context.Context, taskUUID string, startDate, endDate *time.Time, page, pageSize int) ([]*models.Execution, int64, error) {
+// RunInTransaction mocks base method.
+func (m *MockRepository) RunInTransaction(ctx context.Context, fn func(context.Context) error) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetExecutionsByTaskUUIDPaginated", ctx, taskUUID, startDate, endDate, page, pageSize)
-	ret0, _ := ret[0].([]*models.Execution)
-	ret1, _ := ret[1].(int64)
-	ret2, _ := ret[2].(error)
-	return ret0, ret1, ret2
+	ret := m.ctrl.Call(m, "RunInTransaction", ctx, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// GetExecutionsByTaskUUIDPaginated indicates an expected call of GetExecutionsByTaskUUIDPaginated.
-func (mr *MockRepositoryMockRecorder) GetExecutionsByTaskUUIDPaginated(ctx, taskUUID, startDate, endDate, page, pageSize any) *gomock.Call {
+// RunInTransaction indicates an expected call of RunInTransaction.
+func (mr *MockRepositoryMockRecorder) RunInTransaction(ctx, fn any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExecutionsByTaskUUIDPaginated", reflect.TypeOf((*MockRepository)(nil).GetExecutionsByTaskUUIDPaginated), ctx, taskUUID, startDate, endDate, page, pageSize)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RunInTransaction", reflect.TypeOf((*MockRepository)(nil).RunInTransaction), ctx, fn)
 }
 
-// GetFailureStatsByProject mocks base method.
-func (m *MockRepository) GetFailureStatsByProject(ctx context.Context, projectID primitive.ObjectID, days int) ([]*models.FailedExecutionStats, int, error) {
+// SetAlertRoutes mocks base method.
+func (m *MockRepository) SetAlertRoutes(ctx context.Context, projectID primitive.ObjectID, routes []models.AlertRoute) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetFailureStatsByProject", ctx, projectID, days)
-	ret0, _ := ret[0].([]*models.FailedExecutionStats)
-	ret1, _ := ret[1].(int)
-	ret2, _ := ret[2].(error)
-	return ret0, ret1, ret2
+	ret := m.ctrl.Call(m, "SetAlertRoutes", ctx, projectID, routes)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// GetFailureStatsByProject indicates an expected call of GetFailureStatsByProject.
-func (mr *MockRepositoryMockRecorder) GetFailureStatsByProject(ctx, projectID, days any) *gomock.Call {
+// SetAlertRoutes indicates an expected call of SetAlertRoutes.
+func (mr *MockRepositoryMockRecorder) SetAlertRoutes(ctx, projectID, routes any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFailureStatsByProject", reflect.TypeOf((*MockRepository)(nil).GetFailureStatsByProject), ctx, projectID, days)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetAlertRoutes", reflect.TypeOf((*MockRepository)(nil).SetAlertRoutes), ctx, projectID, routes)
 }
 
-// GetProjectByID mocks base method.
-func (m *MockRepository) GetProjectByID(ctx context.Context, projectID primitive.ObjectID) (*models.Project, error) {
+// SetOnCallSchedule mocks base method.
+func (m *MockRepository) SetOnCallSchedule(ctx context.Context, projectID primitive.ObjectID, schedule *models.OnCallSchedule) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetProjectByID", ctx, projectID)
-	ret0, _ := ret[0].(*models.Project)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "SetOnCallSchedule", ctx, projectID, schedule)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// GetProjectByID indicates an expected call of GetProjectByID.
-func (mr *MockRepositoryMockRecorder) GetProjectByID(ctx, projectID any) *gomock.Call {
+// SetOnCallSchedule indicates an expected call of SetOnCallSchedule.
+func (mr *MockRepositoryMockRecorder) SetOnCallSchedule(ctx, projectID, schedule any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProjectByID", reflect.TypeOf((*MockRepository)(nil).GetProjectByID), ctx, projectID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetOnCallSchedule", reflect.TypeOf((*MockRepository)(nil).SetOnCallSchedule), ctx, projectID, schedule)
 }
 
-// GetProjectByName mocks base method.
-func (m *MockRepository) GetProjectByName(ctx context.Context, name string) (*models.Project, error) {
+// SetOrganizationQuotas mocks base method.
+func (m *MockRepository) SetOrganizationQuotas(ctx context.Context, organizationID primitive.ObjectID, quotas *models.ProjectQuotas) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetProjectByName", ctx, name)
-	ret0, _ := ret[0].(*models.Project)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "SetOrganizationQuotas", ctx, organizationID, quotas)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// GetProjectByName indicates an expected call of GetProjectByName.
-func (mr *MockRepositoryMockRecorder) GetProjectByName(ctx, name any) *gomock.Call {
+// SetOrganizationQuotas indicates an expected call of SetOrganizationQuotas.
+func (mr *MockRepositoryMockRecorder) SetOrganizationQuotas(ctx, organizationID, quotas any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProjectByName", reflect.TypeOf((*MockRepository)(nil).GetProjectByName), ctx, name)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetOrganizationQuotas", reflect.TypeOf((*MockRepository)(nil).SetOrganizationQuotas), ctx, organizationID, quotas)
 }
 
-// GetStoredTaskFailureStats mocks base method.
-func (m *MockRepository) GetStoredTaskFailureStats(ctx context.Context, projectID primitive.ObjectID, date string) (*models.StoredTaskFailureStats, error) {
+// SetProjectOrganization mocks base method.
+func (m *MockRepository) SetProjectOrganization(ctx context.Context, projectID primitive.ObjectID, organizationID *primitive.ObjectID) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetStoredTaskFailureStats", ctx, projectID, date)
-	ret0, _ := ret[0].(*models.StoredTaskFailureStats)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "SetProjectOrganization", ctx, projectID, organizationID)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// GetStoredTaskFailureStats indicates an expected call of GetStoredTaskFailureStats.
-func (mr *MockRepositoryMockRecorder) GetStoredTaskFailureStats(ctx, projectID, date any) *gomock.Call {
+// SetProjectOrganization indicates an expected call of SetProjectOrganization.
+func (mr *MockRepositoryMockRecorder) SetProjectOrganization(ctx, projectID, organizationID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStoredTaskFailureStats", reflect.TypeOf((*MockRepository)(nil).GetStoredTaskFailureStats), ctx, projectID, date)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetProjectOrganization", reflect.TypeOf((*MockRepository)(nil).SetProjectOrganization), ctx, projectID, organizationID)
 }
 
-// GetTaskByUUID mocks base method.
-func (m *MockRepository) GetTaskByUUID(ctx context.Context, taskUUID string) (*models.Task, error) {
+// SetProjectQuotas mocks base method.
+func (m *MockRepository) SetProjectQuotas(ctx context.Context, projectID primitive.ObjectID, quotas *models.ProjectQuotas) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetTaskByUUID", ctx, taskUUID)
-	ret0, _ := ret[0].(*models.Task)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "SetProjectQuotas", ctx, projectID, quotas)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// GetTaskByUUID indicates an expected call of GetTaskByUUID.
-func (mr *MockRepositoryMockRecorder) GetTaskByUUID(ctx, taskUUID any) *gomock.Call {
+// SetProjectQuotas indicates an expected call of SetProjectQuotas.
+func (mr *MockRepositoryMockRecorder) SetProjectQuotas(ctx, projectID, quotas any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTaskByUUID", reflect.TypeOf((*MockRepository)(nil).GetTaskByUUID), ctx, taskUUID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetProjectQuotas", reflect.TypeOf((*MockRepository)(nil).SetProjectQuotas), ctx, projectID, quotas)
 }
 
-// GetTaskFailuresByDate mocks base method.
-func (m *MockRepository) GetTaskFailuresByDate(ctx context.Context, projectID primitive.ObjectID, date string) ([]*models.TaskFailureStats, int, error) {
+// SetProjectStatusPageToken mocks base method.
+func (m *MockRepository) SetProjectStatusPageToken(ctx context.Context, projectID primitive.ObjectID, token string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetTaskFailuresByDate", ctx, projectID, date)
-	ret0, _ := ret[0].([]*models.TaskFailureStats)
-	ret1, _ := ret[1].(int)
-	ret2, _ := ret[2].(error)
-	return ret0, ret1, ret2
+	ret := m.ctrl.Call(m, "SetProjectStatusPageToken", ctx, projectID, token)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// GetTaskFailuresByDate indicates an expected call of GetTaskFailuresByDate.
-func (mr *MockRepositoryMockRecorder) GetTaskFailuresByDate(ctx, projectID, date any) *gomock.Call {
+// SetProjectStatusPageToken indicates an expected call of SetProjectStatusPageToken.
+func (mr *MockRepositoryMockRecorder) SetProjectStatusPageToken(ctx, projectID, token any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTaskFailuresByDate", reflect.TypeOf((*MockRepository)(nil).GetTaskFailuresByDate), ctx, projectID, date)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetProjectStatusPageToken", reflect.TypeOf((*MockRepository)(nil).SetProjectStatusPageToken), ctx, projectID, token)
 }
 
-// GetTaskGroupByID mocks base method.
-func (m *MockRepository) GetTaskGroupByID(ctx context.Context, taskGroupID primitive.ObjectID) (*models.TaskGroup, error) {
+// SetTaskFlapping mocks base method.
+func (m *MockRepository) SetTaskFlapping(ctx context.Context, taskUUID string, since *time.Time) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetTaskGroupByID", ctx, taskGroupID)
-	ret0, _ := ret[0].(*models.TaskGroup)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "SetTaskFlapping", ctx, taskUUID, since)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// GetTaskGroupByID indicates an expected call of GetTaskGroupByID.
-func (mr *MockRepositoryMockRecorder) GetTaskGroupByID(ctx, taskGroupID any) *gomock.Call {
+// SetTaskFlapping indicates an expected call of SetTaskFlapping.
+func (mr *MockRepositoryMockRecorder) SetTaskFlapping(ctx, taskUUID, since any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTaskGroupByID", reflect.TypeOf((*MockRepository)(nil).GetTaskGroupByID), ctx, taskGroupID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTaskFlapping", reflect.TypeOf((*MockRepository)(nil).SetTaskFlapping), ctx, taskUUID, since)
 }
 
-// GetTaskGroupByUUID mocks base method.
-func (m *MockRepository) GetTaskGroupByUUID(ctx context.Context, taskGroupUUID string) (*models.TaskGroup, error) {
+// SetTaskMutedUntil mocks base method.
+func (m *MockRepository) SetTaskMutedUntil(ctx context.Context, taskUUID string, mutedUntil *time.Time) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetTaskGroupByUUID", ctx, taskGroupUUID)
-	ret0, _ := ret[0].(*models.TaskGroup)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "SetTaskMutedUntil", ctx, taskUUID, mutedUntil)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// GetTaskGroupByUUID indicates an expected call of GetTaskGroupByUUID.
-func (mr *MockRepositoryMockRecorder) GetTaskGroupByUUID(ctx, taskGroupUUID any) *gomock.Call {
+// SetTaskMutedUntil indicates an expected call of SetTaskMutedUntil.
+func (mr *MockRepositoryMockRecorder) SetTaskMutedUntil(ctx, taskUUID, mutedUntil any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTaskGroupByUUID", reflect.TypeOf((*MockRepository)(nil).GetTaskGroupByUUID), ctx, taskGroupUUID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTaskMutedUntil", reflect.TypeOf((*MockRepository)(nil).SetTaskMutedUntil), ctx, taskUUID, mutedUntil)
 }
 
-// GetTaskGroupsByProjectID mocks base method.
-func (m *MockRepository) GetTaskGroupsByProjectID(ctx context.Context, projectID primitive.ObjectID) ([]*models.TaskGroup, error) {
+// SetTaskNextRunAt mocks base method.
+func (m *MockRepository) SetTaskNextRunAt(ctx context.Context, taskUUID string, nextRunAt *time.Time) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetTaskGroupsByProjectID", ctx, projectID)
-	ret0, _ := ret[0].([]*models.TaskGroup)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "SetTaskNextRunAt", ctx, taskUUID, nextRunAt)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// GetTaskGroupsByProjectID indicates an expected call of GetTaskGroupsByProjectID.
-func (mr *MockRepositoryMockRecorder) GetTaskGroupsByProjectID(ctx, projectID any) *gomock.Call {
+// SetTaskNextRunAt indicates an expected call of SetTaskNextRunAt.
+func (mr *MockRepositoryMockRecorder) SetTaskNextRunAt(ctx, taskUUID, nextRunAt any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTaskGroupsByProjectID", reflect.TypeOf((*MockRepository)(nil).GetTaskGroupsByProjectID), ctx, projectID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTaskNextRunAt", reflect.TypeOf((*MockRepository)(nil).SetTaskNextRunAt), ctx, taskUUID, nextRunAt)
 }
 
-// GetTasksByGroupID mocks base method.
-func (m *MockRepository) GetTasksByGroupID(ctx context.Context, taskGroupID primitive.ObjectID) ([]*models.Task, error) {
+// StartQueuedExecution mocks base method.
+func (m *MockRepository) StartQueuedExecution(ctx context.Context, executionUUID string, startedAt time.Time) (bool, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetTasksByGroupID", ctx, taskGroupID)
-	ret0, _ := ret[0].([]*models.Task)
+	ret := m.ctrl.Call(m, "StartQueuedExecution", ctx, executionUUID, startedAt)
+	ret0, _ := ret[0].(bool)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetTasksByGroupID indicates an expected call of GetTasksByGroupID.
-func (mr *MockRepositoryMockRecorder) GetTasksByGroupID(ctx, taskGroupID any) *gomock.Call {
+// StartQueuedExecution indicates an expected call of StartQueuedExecution.
+func (mr *MockRepositoryMockRecorder) StartQueuedExecution(ctx, executionUUID, startedAt any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTasksByGroupID", reflect.TypeOf((*MockRepository)(nil).GetTasksByGroupID), ctx, taskGroupID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartQueuedExecution", reflect.TypeOf((*MockRepository)(nil).StartQueuedExecution), ctx, executionUUID, startedAt)
 }
 
-// GetTasksByProjectID mocks base method.
-func (m *MockRepository) GetTasksByProjectID(ctx context.Context, projectID primitive.ObjectID) ([]*models.Task, error) {
+// StoreTaskFailureStats mocks base method.
+func (m *MockRepository) StoreTaskFailureStats(ctx context.Context, stats *models.StoredTaskFailureStats) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetTasksByProjectID", ctx, projectID)
-	ret0, _ := ret[0].([]*models.Task)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "StoreTaskFailureStats", ctx, stats)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// GetTasksByProjectID indicates an expected call of GetTasksByProjectID.
-func (mr *MockRepositoryMockRecorder) GetTasksByProjectID(ctx, projectID any) *gomock.Call {
+// StoreTaskFailureStats indicates an expected call of StoreTaskFailureStats.
+func (mr *MockRepositoryMockRecorder) StoreTaskFailureStats(ctx, stats any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTasksByProjectID", reflect.TypeOf((*MockRepository)(nil).GetTasksByProjectID), ctx, projectID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StoreTaskFailureStats", reflect.TypeOf((*MockRepository)(nil).StoreTaskFailureStats), ctx, stats)
 }
 
-// GetTasksByStatus mocks base method.
-func (m *MockRepository) GetTasksByStatus(ctx context.Context, statuses []models.TaskStatus) ([]*models.Task, error) {
+// TrimExecutionLogsBefore mocks base method.
+func (m *MockRepository) TrimExecutionLogsBefore(ctx context.Context, taskUUIDs []string, statuses []models.ExecutionStatus, before time.Time) (int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetTasksByStatus", ctx, statuses)
-	ret0, _ := ret[0].([]*models.Task)
+	ret := m.ctrl.Call(m, "TrimExecutionLogsBefore", ctx, taskUUIDs, statuses, before)
+	ret0, _ := ret[0].(int64)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetTasksByStatus indicates an expected call of GetTasksByStatus.
-func (mr *MockRepositoryMockRecorder) GetTasksByStatus(ctx, statuses any) *gomock.Call {
+// TrimExecutionLogsBefore indicates an expected call of TrimExecutionLogsBefore.
+func (mr *MockRepositoryMockRecorder) TrimExecutionLogsBefore(ctx, taskUUIDs, statuses, before any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTasksByStatus", reflect.TypeOf((*MockRepository)(nil).GetTasksByStatus), ctx, statuses)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TrimExecutionLogsBefore", reflect.TypeOf((*MockRepository)(nil).TrimExecutionLogsBefore), ctx, taskUUIDs, statuses, before)
 }
 
-// GetUserProjects mocks base method.
-func (m *MockRepository) GetUserProjects(ctx context.Context, email string) ([]*models.Project, error) {
+// UpdateExecutionStatus mocks base method.
+func (m *MockRepository) UpdateExecutionStatus(ctx context.Context, executionUUID string, status models.ExecutionStatus, errorMessage *string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetUserProjects", ctx, email)
-	ret0, _ := ret[0].([]*models.Project)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "UpdateExecutionStatus", ctx, executionUUID, status, errorMessage)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// GetUserProjects indicates an expected call of GetUserProjects.
-func (mr *MockRepositoryMockRecorder) GetUserProjects(ctx, email any) *gomock.Call {
+// UpdateExecutionStatus indicates an expected call of UpdateExecutionStatus.
+func (mr *MockRepositoryMockRecorder) UpdateExecutionStatus(ctx, executionUUID, status, errorMessage any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserProjects", reflect.TypeOf((*MockRepository)(nil).GetUserProjects), ctx, email)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateExecutionStatus", reflect.TypeOf((*MockRepository)(nil).UpdateExecutionStatus), ctx, executionUUID, status, errorMessage)
 }
 
-// IncrementFailureStat mocks base method.
-func (m *MockRepository) IncrementFailureStat(ctx context.Context, projectID primitive.ObjectID, date string) error {
+// UpdateInvitationStatus mocks base method.
+func (m *MockRepository) UpdateInvitationStatus(ctx context.Context, invitationUUID string, status models.InvitationStatus) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "IncrementFailureStat", ctx, projectID, date)
+	ret := m.ctrl.Call(m, "UpdateInvitationStatus", ctx, invitationUUID, status)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// IncrementFailureStat indicates an expected call of IncrementFailureStat.
-func (mr *MockRepositoryMockRecorder) IncrementFailureStat(ctx, projectID, date any) *gomock.Call {
+// UpdateInvitationStatus indicates an expected call of UpdateInvitationStatus.
+func (mr *MockRepositoryMockRecorder) UpdateInvitationStatus(ctx, invitationUUID, status any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncrementFailureStat", reflect.TypeOf((*MockRepository)(nil).IncrementFailureStat), ctx, projectID, date)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateInvitationStatus", reflect.TypeOf((*MockRepository)(nil).UpdateInvitationStatus), ctx, invitationUUID, status)
 }
 
-// StoreTaskFailureStats mocks base method.
-func (m *MockRepository) StoreTaskFailureStats(ctx context.Context, stats *models.StoredTaskFailureStats) error {
+// UpdateNotificationChannel mocks base method.
+func (m *MockRepository) UpdateNotificationChannel(ctx context.Context, projectID primitive.ObjectID, channel models.NotificationChannel) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "StoreTaskFailureStats", ctx, stats)
+	ret := m.ctrl.Call(m, "UpdateNotificationChannel", ctx, projectID, channel)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// StoreTaskFailureStats indicates an expected call of StoreTaskFailureStats.
-func (mr *MockRepositoryMockRecorder) StoreTaskFailureStats(ctx, stats any) *gomock.Call {
+// UpdateNotificationChannel indicates an expected call of UpdateNotificationChannel.
+func (mr *MockRepositoryMockRecorder) UpdateNotificationChannel(ctx, projectID, channel any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StoreTaskFailureStats", reflect.TypeOf((*MockRepository)(nil).StoreTaskFailureStats), ctx, stats)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateNotificationChannel", reflect.TypeOf((*MockRepository)(nil).UpdateNotificationChannel), ctx, projectID, channel)
 }
 
-// UpdateExecutionStatus mocks base method.
-func (m *MockRepository) UpdateExecutionStatus(ctx context.Context, executionUUID string, status models.ExecutionStatus, errorMessage *string) error {
+// UpdateOrganization mocks base method.
+func (m *MockRepository) UpdateOrganization(ctx context.Context, organizationID primitive.ObjectID, organization *models.Organization) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateExecutionStatus", ctx, executionUUID, status, errorMessage)
+	ret := m.ctrl.Call(m, "UpdateOrganization", ctx, organizationID, organization)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// UpdateExecutionStatus indicates an expected call of UpdateExecutionStatus.
-func (mr *MockRepositoryMockRecorder) UpdateExecutionStatus(ctx, executionUUID, status, errorMessage any) *gomock.Call {
+// UpdateOrganization indicates an expected call of UpdateOrganization.
+func (mr *MockRepositoryMockRecorder) UpdateOrganization(ctx, organizationID, organization any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateExecutionStatus", reflect.TypeOf((*MockRepository)(nil).UpdateExecutionStatus), ctx, executionUUID, status, errorMessage)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateOrganization", reflect.TypeOf((*MockRepository)(nil).UpdateOrganization), ctx, organizationID, organization)
 }
 
 // UpdateProject mocks base method.
@@ -515,6 +1706,62 @@ func (mr *MockRepositoryMockRecorder) UpdateProject(ctx, projectID, project any)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateProject", reflect.TypeOf((*MockRepository)(nil).UpdateProject), ctx, projectID, project)
 }
 
+// UpdateProjectDeletionProgress mocks base method.
+func (m *MockRepository) UpdateProjectDeletionProgress(ctx context.Context, projectID primitive.ObjectID, progress *models.ProjectDeletionProgress) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateProjectDeletionProgress", ctx, projectID, progress)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateProjectDeletionProgress indicates an expected call of UpdateProjectDeletionProgress.
+func (mr *MockRepositoryMockRecorder) UpdateProjectDeletionProgress(ctx, projectID, progress any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateProjectDeletionProgress", reflect.TypeOf((*MockRepository)(nil).UpdateProjectDeletionProgress), ctx, projectID, progress)
+}
+
+// UpdateProjectEnvironmentEndpoint mocks base method.
+func (m *MockRepository) UpdateProjectEnvironmentEndpoint(ctx context.Context, projectID primitive.ObjectID, name, executionEndpoint string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateProjectEnvironmentEndpoint", ctx, projectID, name, executionEndpoint)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateProjectEnvironmentEndpoint indicates an expected call of UpdateProjectEnvironmentEndpoint.
+func (mr *MockRepositoryMockRecorder) UpdateProjectEnvironmentEndpoint(ctx, projectID, name, executionEndpoint any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateProjectEnvironmentEndpoint", reflect.TypeOf((*MockRepository)(nil).UpdateProjectEnvironmentEndpoint), ctx, projectID, name, executionEndpoint)
+}
+
+// UpdateProjectStatus mocks base method.
+func (m *MockRepository) UpdateProjectStatus(ctx context.Context, projectID primitive.ObjectID, status models.ProjectStatus) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateProjectStatus", ctx, projectID, status)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateProjectStatus indicates an expected call of UpdateProjectStatus.
+func (mr *MockRepositoryMockRecorder) UpdateProjectStatus(ctx, projectID, status any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateProjectStatus", reflect.TypeOf((*MockRepository)(nil).UpdateProjectStatus), ctx, projectID, status)
+}
+
+// UpdateScopedAPIKeyAllowedCIDRs mocks base method.
+func (m *MockRepository) UpdateScopedAPIKeyAllowedCIDRs(ctx context.Context, projectID primitive.ObjectID, keyID string, allowedCIDRs []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateScopedAPIKeyAllowedCIDRs", ctx, projectID, keyID, allowedCIDRs)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateScopedAPIKeyAllowedCIDRs indicates an expected call of UpdateScopedAPIKeyAllowedCIDRs.
+func (mr *MockRepositoryMockRecorder) UpdateScopedAPIKeyAllowedCIDRs(ctx, projectID, keyID, allowedCIDRs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateScopedAPIKeyAllowedCIDRs", reflect.TypeOf((*MockRepository)(nil).UpdateScopedAPIKeyAllowedCIDRs), ctx, projectID, keyID, allowedCIDRs)
+}
+
 // UpdateTask mocks base method.
 func (m *MockRepository) UpdateTask(ctx context.Context, taskUUID string, task *models.Task) error {
 	m.ctrl.T.Helper()
@@ -571,6 +1818,35 @@ func (mr *MockRepositoryMockRecorder) UpdateTaskGroupStatus(ctx, taskGroupUUID,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTaskGroupStatus", reflect.TypeOf((*MockRepository)(nil).UpdateTaskGroupStatus), ctx, taskGroupUUID, status)
 }
 
+// UpdateTaskGroupWithTasks mocks base method.
+func (m *MockRepository) UpdateTaskGroupWithTasks(ctx context.Context, taskGroup *models.TaskGroup, cascade models.TaskGroupCascade) (*models.TaskGroupCascadeResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTaskGroupWithTasks", ctx, taskGroup, cascade)
+	ret0, _ := ret[0].(*models.TaskGroupCascadeResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateTaskGroupWithTasks indicates an expected call of UpdateTaskGroupWithTasks.
+func (mr *MockRepositoryMockRecorder) UpdateTaskGroupWithTasks(ctx, taskGroup, cascade any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTaskGroupWithTasks", reflect.TypeOf((*MockRepository)(nil).UpdateTaskGroupWithTasks), ctx, taskGroup, cascade)
+}
+
+// UpdateTaskLastFailureAt mocks base method.
+func (m *MockRepository) UpdateTaskLastFailureAt(ctx context.Context, taskUUID string, failedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTaskLastFailureAt", ctx, taskUUID, failedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateTaskLastFailureAt indicates an expected call of UpdateTaskLastFailureAt.
+func (mr *MockRepositoryMockRecorder) UpdateTaskLastFailureAt(ctx, taskUUID, failedAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTaskLastFailureAt", reflect.TypeOf((*MockRepository)(nil).UpdateTaskLastFailureAt), ctx, taskUUID, failedAt)
+}
+
 // UpdateTaskState mocks base method.
 func (m *MockRepository) UpdateTaskState(ctx context.Context, taskUUID string, state models.TaskState) error {
 	m.ctrl.T.Helper()
@@ -598,3 +1874,31 @@ func (mr *MockRepositoryMockRecorder) UpdateTaskStatus(ctx, taskUUID, status any
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTaskStatus", reflect.TypeOf((*MockRepository)(nil).UpdateTaskStatus), ctx, taskUUID, status)
 }
+
+// UpsertProjectSettings mocks base method.
+func (m *MockRepository) UpsertProjectSettings(ctx context.Context, settings *models.ProjectSettings) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertProjectSettings", ctx, settings)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpsertProjectSettings indicates an expected call of UpsertProjectSettings.
+func (mr *MockRepositoryMockRecorder) UpsertProjectSettings(ctx, settings any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertProjectSettings", reflect.TypeOf((*MockRepository)(nil).UpsertProjectSettings), ctx, settings)
+}
+
+// UpsertSecret mocks base method.
+func (m *MockRepository) UpsertSecret(ctx context.Context, secret *models.Secret) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertSecret", ctx, secret)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpsertSecret indicates an expected call of UpsertSecret.
+func (mr *MockRepositoryMockRecorder) UpsertSecret(ctx, secret any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertSecret", reflect.TypeOf((*MockRepository)(nil).UpsertSecret), ctx, secret)
+}
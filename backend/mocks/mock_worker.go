@@ -77,9 +77,11 @@ func (m *MockEventPublisher) EXPECT() *MockEventPublisherMockRecorder {
 }
 
 // Publish mocks base method.
-func (m *MockEventPublisher) Publish(event events.Event) {
+func (m *MockEventPublisher) Publish(event events.Event) error {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "Publish", event)
+	ret := m.ctrl.Call(m, "Publish", event)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
 // Publish indicates an expected call of Publish.